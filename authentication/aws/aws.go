@@ -3,26 +3,58 @@ package aws
 import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 )
 
+// ClientOptions are the settings of an AWS session. Credentials not set in the options
+// are resolved through the default AWS credential chain.
+type ClientOptions struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	// RoleARN is the IAM role that is assumed with the resolved credentials
+	RoleARN  string
+	Region   string
+	Endpoint string
+}
+
 func GetClient(accessKey string, secretKey string, region string, endpoint string) (*session.Session, error) {
+	return GetClientWithOptions(ClientOptions{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Region:    region,
+		Endpoint:  endpoint,
+	})
+}
+
+// GetClientWithOptions returns an AWS session for static credentials, temporary credentials
+// with a session token, or an assumed IAM role.
+func GetClientWithOptions(opts ClientOptions) (*session.Session, error) {
 	awsConfig := aws.NewConfig()
 
-	if region != "" {
-		awsConfig = awsConfig.WithRegion(region)
+	if opts.Region != "" {
+		awsConfig = awsConfig.WithRegion(opts.Region)
 	}
-	if accessKey != "" && secretKey != "" {
-		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, ""))
+	if opts.AccessKey != "" && opts.SecretKey != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(opts.AccessKey, opts.SecretKey, opts.SessionToken))
 	}
 
-	if endpoint != "" {
-		awsConfig = awsConfig.WithEndpoint(endpoint)
+	if opts.Endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(opts.Endpoint)
 	}
 
 	awsSession, err := session.NewSession(awsConfig)
 	if err != nil {
 		return nil, err
 	}
+
+	if opts.RoleARN != "" {
+		// Assumed role credentials are refreshed before they expire
+		awsSession = awsSession.Copy(&aws.Config{
+			Credentials: stscreds.NewCredentials(awsSession, opts.RoleARN),
+		})
+	}
+
 	return awsSession, nil
 }
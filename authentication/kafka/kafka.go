@@ -0,0 +1,250 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/dapr/components-contrib/metadata"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// Metadata of the OAUTHBEARER SASL mechanism
+	OIDCTokenEndpointKey = "oidcTokenEndpoint"
+	OIDCClientIDKey      = "oidcClientID"
+	OIDCClientSecretKey  = "oidcClientSecret"
+	OIDCScopesKey        = "oidcScopes"
+
+	// Metadata of the client certificate files, which are loaded again when they change
+	ClientCertFileKey = "clientCertFile"
+	ClientKeyFileKey  = "clientKeyFile"
+
+	// tokenTimeout is how long a token request waits for the token endpoint
+	tokenTimeout = 10 * time.Second
+)
+
+// OAuthOptions are the client credentials with which OAUTHBEARER tokens are requested from an OIDC token endpoint
+type OAuthOptions struct {
+	TokenEndpoint string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+}
+
+// TLSOptions are the settings of the TLS connections to the brokers. The client certificate is either given as PEM,
+// or as files that are loaded again when they change so that rotated certificates are used for new connections.
+type TLSOptions struct {
+	CACert         string
+	ClientCert     string
+	ClientKey      string
+	ClientCertFile string
+	ClientKeyFile  string
+	SkipVerify     bool
+}
+
+// ParseOAuthOptions returns the OAuth options of the metadata, which are all required except the scopes
+func ParseOAuthOptions(properties map[string]string) (OAuthOptions, error) {
+	opts := OAuthOptions{
+		TokenEndpoint: properties[OIDCTokenEndpointKey],
+		ClientID:      properties[OIDCClientIDKey],
+		ClientSecret:  properties[OIDCClientSecretKey],
+	}
+	if opts.TokenEndpoint == "" || opts.ClientID == "" || opts.ClientSecret == "" {
+		return opts, fmt.Errorf("kafka error: '%s', '%s' and '%s' attributes are required by the %s SASL mechanism", OIDCTokenEndpointKey, OIDCClientIDKey, OIDCClientSecretKey, sarama.SASLTypeOAuth)
+	}
+	if val := properties[OIDCScopesKey]; val != "" {
+		opts.Scopes = strings.Split(val, ",")
+	}
+
+	return opts, nil
+}
+
+// ParseClientCertFiles returns the client certificate and key files of the metadata, which must be set together
+func ParseClientCertFiles(properties map[string]string) (string, string, error) {
+	certFile := properties[ClientCertFileKey]
+	keyFile := properties[ClientKeyFileKey]
+	if (certFile == "") != (keyFile == "") {
+		return "", "", fmt.Errorf("kafka error: '%s' and '%s' attributes must be set together", ClientCertFileKey, ClientKeyFileKey)
+	}
+
+	return certFile, keyFile, nil
+}
+
+// MetadataFields returns the metadata fields read by ParseOAuthOptions and ParseClientCertFiles
+func MetadataFields() []metadata.Field {
+	return []metadata.Field{
+		{Name: OIDCTokenEndpointKey, Type: metadata.TypeString},
+		{Name: OIDCClientIDKey, Type: metadata.TypeString},
+		{Name: OIDCClientSecretKey, Type: metadata.TypeString},
+		{Name: OIDCScopesKey, Type: metadata.TypeList},
+		{Name: ClientCertFileKey, Type: metadata.TypeString},
+		{Name: ClientKeyFileKey, Type: metadata.TypeString},
+	}
+}
+
+// tokenProvider implements sarama.AccessTokenProvider with the client credentials grant
+type tokenProvider struct {
+	tokenSource oauth2.TokenSource
+}
+
+// NewOAuthBearerTokenProvider returns the provider of the OAUTHBEARER tokens of the client credentials. Tokens are
+// reused until they expire.
+func NewOAuthBearerTokenProvider(opts OAuthOptions) sarama.AccessTokenProvider {
+	config := clientcredentials.Config{
+		ClientID:     opts.ClientID,
+		ClientSecret: opts.ClientSecret,
+		TokenURL:     opts.TokenEndpoint,
+		Scopes:       opts.Scopes,
+	}
+
+	return &tokenProvider{tokenSource: config.TokenSource(context.Background())}
+}
+
+// Token implements sarama.AccessTokenProvider
+func (p *tokenProvider) Token() (*sarama.AccessToken, error) {
+	// The token source does not take a context, so the request is abandoned after the timeout instead
+	type result struct {
+		token *oauth2.Token
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		token, err := p.tokenSource.Token()
+		done <- result{token, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return nil, fmt.Errorf("kafka error: failed to get OAUTHBEARER token: %s", res.err)
+		}
+		return &sarama.AccessToken{Token: res.token.AccessToken}, nil
+	case <-time.After(tokenTimeout):
+		return nil, errors.New("kafka error: timeout getting OAUTHBEARER token")
+	}
+}
+
+// NewTLSConfig returns the TLS configuration of the options
+func NewTLSConfig(opts TLSOptions) (*tls.Config, error) {
+	/* #nosec */
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: opts.SkipVerify,
+	}
+
+	if opts.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(opts.CACert)) {
+			return nil, errors.New("kafka error: 'caCert' attribute is not a valid PEM certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	switch {
+	case opts.ClientCert != "":
+		cert, err := tls.X509KeyPair([]byte(opts.ClientCert), []byte(opts.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("kafka error: invalid 'clientCert' or 'clientKey' attribute: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case opts.ClientCertFile != "":
+		reloader, err := newCertReloader(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = reloader.getClientCertificate
+	}
+
+	return tlsConfig, nil
+}
+
+// certReloader loads the client certificate from its files again when either of them was modified
+type certReloader struct {
+	certFile string
+	keyFile  string
+	cert     *tls.Certificate
+	modTime  time.Time
+	lock     sync.Mutex
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.certificate(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *certReloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.certificate()
+}
+
+// certificate returns the certificate, which is loaded again if the files were modified since it was loaded. The
+// certificate that was loaded before is kept while the files cannot be loaded, such as during their rotation.
+func (r *certReloader) certificate() (*tls.Certificate, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err == nil && r.cert != nil && !modTime.After(r.modTime) {
+		return r.cert, nil
+	}
+
+	if err == nil {
+		var cert tls.Certificate
+		cert, err = loadKeyPair(r.certFile, r.keyFile)
+		if err == nil {
+			r.cert = &cert
+			r.modTime = modTime
+			return r.cert, nil
+		}
+	}
+
+	if r.cert != nil {
+		return r.cert, nil
+	}
+	return nil, fmt.Errorf("kafka error: invalid '%s' or '%s' attribute: %s", ClientCertFileKey, ClientKeyFileKey, err)
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
+
+func loadKeyPair(certFile, keyFile string) (tls.Certificate, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
@@ -0,0 +1,160 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package kafka
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOAuthOptions(t *testing.T) {
+	opts, err := ParseOAuthOptions(map[string]string{
+		OIDCTokenEndpointKey: "https://login.example.com/token",
+		OIDCClientIDKey:      "client",
+		OIDCClientSecretKey:  "secret",
+		OIDCScopesKey:        "kafka,profile",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, OAuthOptions{TokenEndpoint: "https://login.example.com/token", ClientID: "client", ClientSecret: "secret", Scopes: []string{"kafka", "profile"}}, opts)
+
+	_, err = ParseOAuthOptions(map[string]string{OIDCTokenEndpointKey: "https://login.example.com/token", OIDCClientIDKey: "client"})
+	assert.Error(t, err)
+}
+
+func TestParseClientCertFiles(t *testing.T) {
+	certFile, keyFile, err := ParseClientCertFiles(map[string]string{ClientCertFileKey: "cert.pem", ClientKeyFileKey: "key.pem"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cert.pem", certFile)
+	assert.Equal(t, "key.pem", keyFile)
+
+	_, _, err = ParseClientCertFiles(map[string]string{ClientCertFileKey: "cert.pem"})
+	assert.Error(t, err)
+}
+
+func TestOAuthBearerTokenProvider(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+		assert.Equal(t, "kafka", r.Form.Get("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "token", "token_type": "bearer", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	provider := NewOAuthBearerTokenProvider(OAuthOptions{TokenEndpoint: server.URL, ClientID: "client", ClientSecret: "secret", Scopes: []string{"kafka"}})
+
+	for i := 0; i < 2; i++ {
+		token, err := provider.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token", token.Token)
+	}
+	// The token is reused until it expires
+	assert.Equal(t, 1, requests)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	t.Run("client certificate", func(t *testing.T) {
+		certPEM, keyPEM := generateCertificate(t, "first")
+
+		tlsConfig, err := NewTLSConfig(TLSOptions{CACert: certPEM, ClientCert: certPEM, ClientKey: keyPEM})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, tlsConfig.RootCAs)
+		assert.Len(t, tlsConfig.Certificates, 1)
+	})
+
+	t.Run("invalid certificates", func(t *testing.T) {
+		_, err := NewTLSConfig(TLSOptions{CACert: "invalid"})
+		assert.Error(t, err)
+
+		_, err = NewTLSConfig(TLSOptions{ClientCert: "invalid", ClientKey: "invalid"})
+		assert.Error(t, err)
+
+		_, err = NewTLSConfig(TLSOptions{ClientCertFile: "missing.pem", ClientKeyFile: "missing.pem"})
+		assert.Error(t, err)
+	})
+
+	t.Run("client certificate files are loaded again when they change", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "kafka")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		certFile := filepath.Join(dir, "cert.pem")
+		keyFile := filepath.Join(dir, "key.pem")
+		writeCertificate(t, certFile, keyFile, "first", time.Now().Add(-time.Minute))
+
+		tlsConfig, err := NewTLSConfig(TLSOptions{ClientCertFile: certFile, ClientKeyFile: keyFile})
+		require.NoError(t, err)
+		assert.Equal(t, "first", commonName(t, tlsConfig.GetClientCertificate))
+
+		writeCertificate(t, certFile, keyFile, "second", time.Now())
+		assert.Equal(t, "second", commonName(t, tlsConfig.GetClientCertificate))
+
+		// The last certificate is kept while the files are being replaced
+		require.NoError(t, ioutil.WriteFile(keyFile, []byte("partial"), 0600))
+		assert.Equal(t, "second", commonName(t, tlsConfig.GetClientCertificate))
+	})
+}
+
+func commonName(t *testing.T, getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)) string {
+	cert, err := getClientCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string, modTime time.Time) {
+	certPEM, keyPEM := generateCertificate(t, commonName)
+	require.NoError(t, ioutil.WriteFile(certFile, []byte(certPEM), 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte(keyPEM), 0600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+// generateCertificate returns a PEM encoded self-signed certificate and its key
+func generateCertificate(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
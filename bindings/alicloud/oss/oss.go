@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/google/uuid"
 
@@ -50,6 +51,11 @@ func (s *AliCloudOSS) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Alibaba Cloud OSS binding
+func (s *AliCloudOSS) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(ossMetadata{})}
+}
+
 func (s *AliCloudOSS) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
 
 	"github.com/dapr/dapr/pkg/logger"
 
@@ -55,6 +56,11 @@ func (d *DynamoDB) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the AWS DynamoDB binding
+func (d *DynamoDB) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(dynamoDBMetadata{})}
+}
+
 func (d *DynamoDB) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
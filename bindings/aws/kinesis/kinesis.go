@@ -16,6 +16,7 @@ import (
 	"time"
 
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -122,6 +123,11 @@ func (a *AWSKinesis) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the AWS Kinesis binding
+func (a *AWSKinesis) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(kinesisMetadata{KinesisConsumerMode: SharedThroughput})}
+}
+
 func (a *AWSKinesis) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
@@ -8,9 +8,15 @@ package s3
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
 
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
 
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/google/uuid"
 
@@ -19,11 +25,31 @@ import (
 	"github.com/dapr/dapr/pkg/logger"
 )
 
+const (
+	// Request metadata
+	metadataKey                  = "key"
+	metadataPresignTTL           = "presignTTL"
+	metadataServerSideEncryption = "serverSideEncryption"
+	metadataSSEKMSKeyID          = "sseKmsKeyId"
+	metadataPrefix               = "prefix"
+	metadataMaxResults           = "maxResults"
+	metadataContinuationToken    = "continuationToken"
+
+	// Component metadata that are not strings
+	metadataForcePathStyle = "forcePathStyle"
+	metadataPartSize       = "partSize"
+	metadataConcurrency    = "concurrency"
+
+	defaultMaxResults = 1000
+)
+
 // AWSS3 is a binding for an AWS S3 storage bucket
 type AWSS3 struct {
-	metadata *s3Metadata
-	uploader *s3manager.Uploader
-	logger   logger.Logger
+	metadata   *s3Metadata
+	client     s3iface.S3API
+	uploader   *s3manager.Uploader
+	downloader *s3manager.Downloader
+	logger     logger.Logger
 }
 
 type s3Metadata struct {
@@ -32,6 +58,41 @@ type s3Metadata struct {
 	AccessKey string `json:"accessKey"`
 	SecretKey string `json:"secretKey"`
 	Bucket    string `json:"bucket"`
+	// ServerSideEncryption is the default server-side encryption of the created objects, AES256 or aws:kms
+	ServerSideEncryption string `json:"serverSideEncryption"`
+	SSEKMSKeyID          string `json:"sseKmsKeyId"`
+
+	forcePathStyle bool
+	// partSize and concurrency are the size of the parts and the number of parts transferred at once when large
+	// objects are streamed in parts, or the defaults of s3manager when they are 0
+	partSize    int64
+	concurrency int
+}
+
+// createResponse is the response of the create operation
+type createResponse struct {
+	Location   string `json:"location"`
+	VersionID  string `json:"versionID,omitempty"`
+	PresignURL string `json:"presignURL,omitempty"`
+}
+
+// presignResponse is the response of the get operation with a presignTTL
+type presignResponse struct {
+	PresignURL string `json:"presignURL"`
+}
+
+// listResponse is the response of the list operation, whose next page is listed with the continuation token
+type listResponse struct {
+	Objects           []listObject `json:"objects"`
+	IsTruncated       bool         `json:"isTruncated"`
+	ContinuationToken string       `json:"continuationToken,omitempty"`
+}
+
+type listObject struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"lastModified"`
 }
 
 // NewAWSS3 returns a new AWSS3 instance
@@ -45,35 +106,226 @@ func (s *AWSS3) Init(metadata bindings.Metadata) error {
 	if err != nil {
 		return err
 	}
-	uploader, err := s.getClient(m)
+	client, err := s.getClient(m)
 	if err != nil {
 		return err
 	}
 	s.metadata = m
-	s.uploader = uploader
+	s.setClient(client)
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the AWS S3 binding
+func (s *AWSS3) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := metadata.FieldsOf(s3Metadata{})
+	fields = append(fields,
+		metadata.Field{Name: metadataForcePathStyle, Type: metadata.TypeBool},
+		metadata.Field{Name: metadataPartSize, Type: metadata.TypeInt},
+		metadata.Field{Name: metadataConcurrency, Type: metadata.TypeInt},
+	)
+	return metadata.ComponentMetadata{Fields: fields}
+}
+
+// setClient sets the client, and the uploader and downloader that stream large objects in parts with it
+func (s *AWSS3) setClient(client s3iface.S3API) {
+	s.client = client
+	s.uploader = s3manager.NewUploaderWithClient(client, func(u *s3manager.Uploader) {
+		if s.metadata.partSize > 0 {
+			u.PartSize = s.metadata.partSize
+		}
+		if s.metadata.concurrency > 0 {
+			u.Concurrency = s.metadata.concurrency
+		}
+	})
+	s.downloader = s3manager.NewDownloaderWithClient(client, func(d *s3manager.Downloader) {
+		if s.metadata.partSize > 0 {
+			d.PartSize = s.metadata.partSize
+		}
+		if s.metadata.concurrency > 0 {
+			d.Concurrency = s.metadata.concurrency
+		}
+	})
+}
+
 func (s *AWSS3) Operations() []bindings.OperationKind {
-	return []bindings.OperationKind{bindings.CreateOperation}
+	return []bindings.OperationKind{bindings.CreateOperation, bindings.GetOperation, bindings.DeleteOperation, bindings.ListOperation}
 }
 
 func (s *AWSS3) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	switch req.Operation {
+	case bindings.CreateOperation:
+		return s.create(req)
+	case bindings.GetOperation:
+		return s.get(req)
+	case bindings.DeleteOperation:
+		return s.delete(req)
+	case bindings.ListOperation:
+		return s.list(req)
+	default:
+		return nil, bindings.NewUnsupportedOperationError(req.Operation, s.Operations())
+	}
+}
+
+// create uploads the data, in parts when it is large. The response has a presigned URL to get the object when the
+// presignTTL metadata is set.
+func (s *AWSS3) create(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	key := ""
-	if val, ok := req.Metadata["key"]; ok && val != "" {
+	if val, ok := req.Metadata[metadataKey]; ok && val != "" {
 		key = val
 	} else {
 		key = uuid.New().String()
 		s.logger.Debugf("key not found. generating key %s", key)
 	}
 
-	r := bytes.NewReader(req.Data)
-	_, err := s.uploader.Upload(&s3manager.UploadInput{
+	presignTTL, err := parsePresignTTL(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &s3manager.UploadInput{
+		Bucket: aws.String(s.metadata.Bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(req.Data),
+	}
+	sse, kmsKeyID := s.serverSideEncryption(req.Metadata)
+	if sse != "" {
+		input.ServerSideEncryption = aws.String(sse)
+	}
+	if kmsKeyID != "" {
+		input.SSEKMSKeyId = aws.String(kmsKeyID)
+	}
+
+	output, err := s.uploader.Upload(input)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: uploading %s failed: %s", key, err)
+	}
+
+	resp := createResponse{Location: output.Location, VersionID: aws.StringValue(output.VersionID)}
+	if presignTTL > 0 {
+		resp.PresignURL, err = s.presign(key, presignTTL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jsonResponse(resp, map[string]string{metadataKey: key})
+}
+
+// get downloads the object, in parts when it is large, or returns a presigned URL to get it when the presignTTL
+// metadata is set
+func (s *AWSS3) get(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, err := requiredKey(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	presignTTL, err := parsePresignTTL(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if presignTTL > 0 {
+		url, err := s.presign(key, presignTTL)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResponse(presignResponse{PresignURL: url}, nil)
+	}
+
+	buf := aws.NewWriteAtBuffer([]byte{})
+	_, err = s.downloader.Download(buf, &s3.GetObjectInput{
+		Bucket: aws.String(s.metadata.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: downloading %s failed: %s", key, err)
+	}
+
+	return &bindings.InvokeResponse{Data: buf.Bytes()}, nil
+}
+
+func (s *AWSS3) delete(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, err := requiredKey(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.client.DeleteObject(&s3.DeleteObjectInput{
 		Bucket: aws.String(s.metadata.Bucket),
 		Key:    aws.String(key),
-		Body:   r,
 	})
-	return nil, err
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: deleting %s failed: %s", key, err)
+	}
+
+	return nil, nil
+}
+
+// list returns a page of the objects with the prefix metadata
+func (s *AWSS3) list(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	maxResults := int64(defaultMaxResults)
+	if val := req.Metadata[metadataMaxResults]; val != "" {
+		var err error
+		maxResults, err = strconv.ParseInt(val, 10, 64)
+		if err != nil || maxResults <= 0 {
+			return nil, fmt.Errorf("s3 binding error: invalid '%s' metadata '%s', must be a positive integer", metadataMaxResults, val)
+		}
+	}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(s.metadata.Bucket),
+		MaxKeys: aws.Int64(maxResults),
+	}
+	if val := req.Metadata[metadataPrefix]; val != "" {
+		input.Prefix = aws.String(val)
+	}
+	if val := req.Metadata[metadataContinuationToken]; val != "" {
+		input.ContinuationToken = aws.String(val)
+	}
+
+	output, err := s.client.ListObjectsV2(input)
+	if err != nil {
+		return nil, fmt.Errorf("s3 binding error: listing objects failed: %s", err)
+	}
+
+	resp := listResponse{
+		Objects:           make([]listObject, 0, len(output.Contents)),
+		IsTruncated:       aws.BoolValue(output.IsTruncated),
+		ContinuationToken: aws.StringValue(output.NextContinuationToken),
+	}
+	for _, object := range output.Contents {
+		resp.Objects = append(resp.Objects, listObject{
+			Key:          aws.StringValue(object.Key),
+			Size:         aws.Int64Value(object.Size),
+			ETag:         aws.StringValue(object.ETag),
+			LastModified: aws.TimeValue(object.LastModified),
+		})
+	}
+
+	return jsonResponse(resp, nil)
+}
+
+// presign returns a URL to get the object without credentials until the TTL is over
+func (s *AWSS3) presign(key string, ttl time.Duration) (string, error) {
+	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(s.metadata.Bucket),
+		Key:    aws.String(key),
+	})
+	url, err := req.Presign(ttl)
+	if err != nil {
+		return "", fmt.Errorf("s3 binding error: presigning %s failed: %s", key, err)
+	}
+
+	return url, nil
+}
+
+// serverSideEncryption returns the server-side encryption of the request metadata, or of the component metadata
+func (s *AWSS3) serverSideEncryption(metadata map[string]string) (string, string) {
+	sse, kmsKeyID := s.metadata.ServerSideEncryption, s.metadata.SSEKMSKeyID
+	if val := metadata[metadataServerSideEncryption]; val != "" {
+		sse, kmsKeyID = val, metadata[metadataSSEKMSKeyID]
+	}
+
+	return sse, kmsKeyID
 }
 
 func (s *AWSS3) parseMetadata(metadata bindings.Metadata) (*s3Metadata, error) {
@@ -87,15 +339,74 @@ func (s *AWSS3) parseMetadata(metadata bindings.Metadata) (*s3Metadata, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	switch m.ServerSideEncryption {
+	case "", s3.ServerSideEncryptionAes256, s3.ServerSideEncryptionAwsKms:
+	default:
+		return nil, fmt.Errorf("s3 binding error: invalid '%s' attribute '%s', supported values are: %s, %s", metadataServerSideEncryption, m.ServerSideEncryption, s3.ServerSideEncryptionAes256, s3.ServerSideEncryptionAwsKms)
+	}
+
+	if val := metadata.Properties[metadataForcePathStyle]; val != "" {
+		m.forcePathStyle, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("s3 binding error: invalid '%s' attribute '%s'", metadataForcePathStyle, val)
+		}
+	}
+
+	if val := metadata.Properties[metadataPartSize]; val != "" {
+		m.partSize, err = strconv.ParseInt(val, 10, 64)
+		if err != nil || m.partSize < s3manager.MinUploadPartSize {
+			return nil, fmt.Errorf("s3 binding error: invalid '%s' attribute '%s', must be at least %d bytes", metadataPartSize, val, s3manager.MinUploadPartSize)
+		}
+	}
+
+	if val := metadata.Properties[metadataConcurrency]; val != "" {
+		m.concurrency, err = strconv.Atoi(val)
+		if err != nil || m.concurrency <= 0 {
+			return nil, fmt.Errorf("s3 binding error: invalid '%s' attribute '%s', must be a positive integer", metadataConcurrency, val)
+		}
+	}
+
 	return &m, nil
 }
 
-func (s *AWSS3) getClient(metadata *s3Metadata) (*s3manager.Uploader, error) {
+func (s *AWSS3) getClient(metadata *s3Metadata) (s3iface.S3API, error) {
 	sess, err := aws_auth.GetClient(metadata.AccessKey, metadata.SecretKey, metadata.Region, metadata.Endpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	uploader := s3manager.NewUploader(sess)
-	return uploader, nil
+	return s3.New(sess, aws.NewConfig().WithS3ForcePathStyle(metadata.forcePathStyle)), nil
+}
+
+func requiredKey(metadata map[string]string) (string, error) {
+	key := metadata[metadataKey]
+	if key == "" {
+		return "", fmt.Errorf("s3 binding error: missing '%s' metadata", metadataKey)
+	}
+
+	return key, nil
+}
+
+func parsePresignTTL(metadata map[string]string) (time.Duration, error) {
+	val := metadata[metadataPresignTTL]
+	if val == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(val)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("s3 binding error: invalid '%s' metadata '%s', must be a positive duration", metadataPresignTTL, val)
+	}
+
+	return ttl, nil
+}
+
+func jsonResponse(v interface{}, metadata map[string]string) (*bindings.InvokeResponse, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bindings.InvokeResponse{Data: data, Metadata: metadata}, nil
 }
@@ -6,10 +6,20 @@
 package s3
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseMetadata(t *testing.T) {
@@ -24,3 +34,126 @@ func TestParseMetadata(t *testing.T) {
 	assert.Equal(t, "test", meta.Bucket)
 	assert.Equal(t, "endpoint", meta.Endpoint)
 }
+
+// fakeS3 is an S3 endpoint that keeps the objects of a bucket in memory
+type fakeS3 struct {
+	objects map[string][]byte
+	headers map[string]http.Header
+	lock    sync.Mutex
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/test/")
+	switch {
+	case r.Method == http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		f.objects[key] = data
+		f.headers[key] = r.Header
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated>`)
+		for k, data := range f.objects {
+			fmt.Fprintf(w, `<Contents><Key>%s</Key><Size>%d</Size><ETag>"etag"</ETag></Contents>`, k, len(data))
+		}
+		fmt.Fprint(w, `</ListBucketResult>`)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(data)-1, len(data)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3(t *testing.T, properties map[string]string) (*AWSS3, *fakeS3) {
+	fake := &fakeS3{objects: map[string][]byte{}, headers: map[string]http.Header{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	properties["bucket"] = "test"
+	properties["region"] = "us-east-1"
+	properties["accessKey"] = "key"
+	properties["secretKey"] = "secret"
+	properties["endpoint"] = server.URL
+	properties["forcePathStyle"] = "true"
+
+	s := NewAWSS3(logger.NewLogger("test"))
+	require.NoError(t, s.Init(bindings.Metadata{Properties: properties}))
+
+	return s, fake
+}
+
+func TestParseMetadataOptions(t *testing.T) {
+	s3 := AWSS3{}
+	meta, err := s3.parseMetadata(bindings.Metadata{Properties: map[string]string{"serverSideEncryption": "aws:kms", "sseKmsKeyId": "key", "partSize": "10485760", "concurrency": "2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "aws:kms", meta.ServerSideEncryption)
+	assert.Equal(t, "key", meta.SSEKMSKeyID)
+	assert.Equal(t, int64(10485760), meta.partSize)
+	assert.Equal(t, 2, meta.concurrency)
+
+	for _, properties := range []map[string]string{
+		{"serverSideEncryption": "none"},
+		{"partSize": "1024"},
+		{"concurrency": "0"},
+		{"forcePathStyle": "maybe"},
+	} {
+		_, err := s3.parseMetadata(bindings.Metadata{Properties: properties})
+		assert.Error(t, err)
+	}
+}
+
+func TestOperations(t *testing.T) {
+	s, fake := newTestS3(t, map[string]string{"serverSideEncryption": "AES256"})
+
+	resp, err := s.Invoke(&bindings.InvokeRequest{Operation: bindings.CreateOperation, Data: []byte("data"), Metadata: map[string]string{"key": "a", "presignTTL": "1h"}})
+	require.NoError(t, err)
+	assert.Equal(t, "a", resp.Metadata["key"])
+	var created createResponse
+	require.NoError(t, json.Unmarshal(resp.Data, &created))
+	assert.Contains(t, created.PresignURL, "X-Amz-Expires=3600")
+	assert.Equal(t, "AES256", fake.headers["a"].Get("X-Amz-Server-Side-Encryption"))
+
+	_, err = s.Invoke(&bindings.InvokeRequest{Operation: bindings.CreateOperation, Data: []byte("data"), Metadata: map[string]string{"key": "b", "serverSideEncryption": "aws:kms", "sseKmsKeyId": "kms"}})
+	require.NoError(t, err)
+	assert.Equal(t, "aws:kms", fake.headers["b"].Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, "kms", fake.headers["b"].Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+
+	resp, err = s.Invoke(&bindings.InvokeRequest{Operation: bindings.GetOperation, Metadata: map[string]string{"key": "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), resp.Data)
+
+	resp, err = s.Invoke(&bindings.InvokeRequest{Operation: bindings.GetOperation, Metadata: map[string]string{"key": "a", "presignTTL": "10m"}})
+	require.NoError(t, err)
+	var presigned presignResponse
+	require.NoError(t, json.Unmarshal(resp.Data, &presigned))
+	assert.Contains(t, presigned.PresignURL, "X-Amz-Expires=600")
+
+	resp, err = s.Invoke(&bindings.InvokeRequest{Operation: bindings.ListOperation})
+	require.NoError(t, err)
+	var listed listResponse
+	require.NoError(t, json.Unmarshal(resp.Data, &listed))
+	assert.Len(t, listed.Objects, 2)
+	assert.False(t, listed.IsTruncated)
+
+	_, err = s.Invoke(&bindings.InvokeRequest{Operation: bindings.DeleteOperation, Metadata: map[string]string{"key": "a"}})
+	require.NoError(t, err)
+	assert.NotContains(t, fake.objects, "a")
+
+	_, err = s.Invoke(&bindings.InvokeRequest{Operation: bindings.GetOperation, Metadata: map[string]string{"key": "a"}})
+	assert.Error(t, err)
+
+	_, err = s.Invoke(&bindings.InvokeRequest{Operation: bindings.DeleteOperation})
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
 
 	"github.com/dapr/dapr/pkg/logger"
 
@@ -58,6 +59,11 @@ func (a *AWSSNS) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the AWS SNS binding
+func (a *AWSSNS) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(snsMetadata{})}
+}
+
 func (a *AWSSNS) parseMetadata(metadata bindings.Metadata) (*snsMetadata, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sqs"
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
@@ -62,6 +63,11 @@ func (a *AWSSQS) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the AWS SQS binding
+func (a *AWSSQS) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(sqsMetadata{})}
+}
+
 func (a *AWSSQS) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
@@ -0,0 +1,161 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package blobstorage
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// storageResource is the resource that Azure AD tokens for Azure Storage are issued for
+	storageResource = "https://storage.azure.com/"
+
+	// tokenRefreshMargin is how long before their expiry the tokens are refreshed
+	tokenRefreshMargin = 5 * time.Minute
+	tokenRetryInterval = 30 * time.Second
+
+	defaultEndpointSuffix = "core.windows.net"
+)
+
+// newContainerURL returns the URL of the container authenticated with the connection string when one is configured,
+// with the account key when one is configured, and with an Azure AD token of the managed identity otherwise
+func newContainerURL(m *blobStorageMetadata, logger logger.Logger) (azblob.ContainerURL, error) {
+	var credential azblob.Credential
+	var err error
+	endpoint := fmt.Sprintf("https://%s.blob.%s", m.StorageAccount, defaultEndpointSuffix)
+	sas := ""
+
+	switch {
+	case m.ConnectionString != "":
+		var conn connectionString
+		conn, err = parseConnectionString(m.ConnectionString)
+		if err != nil {
+			return azblob.ContainerURL{}, err
+		}
+		endpoint = conn.blobEndpoint
+		sas = conn.sharedAccessSignature
+		if conn.accountKey != "" {
+			credential, err = azblob.NewSharedKeyCredential(conn.accountName, conn.accountKey)
+		} else {
+			credential = azblob.NewAnonymousCredential()
+		}
+	case m.StorageAccessKey != "":
+		credential, err = azblob.NewSharedKeyCredential(m.StorageAccount, m.StorageAccessKey)
+	default:
+		credential, err = newTokenCredential(m.AzureClientID, logger)
+	}
+	if err != nil {
+		return azblob.ContainerURL{}, fmt.Errorf("invalid credentials with error: %s", err.Error())
+	}
+
+	u, err := url.Parse(fmt.Sprintf("%s/%s", strings.TrimSuffix(endpoint, "/"), m.Container))
+	if err != nil {
+		return azblob.ContainerURL{}, fmt.Errorf("invalid blob endpoint '%s': %s", endpoint, err)
+	}
+	u.RawQuery = strings.TrimPrefix(sas, "?")
+
+	return azblob.NewContainerURL(*u, azblob.NewPipeline(credential, azblob.PipelineOptions{})), nil
+}
+
+// connectionString holds the settings of an Azure Storage connection string used by the binding
+type connectionString struct {
+	accountName           string
+	accountKey            string
+	sharedAccessSignature string
+	blobEndpoint          string
+}
+
+// parseConnectionString parses a connection string with an account key or a shared access signature. The blob
+// endpoint is either set, or built from the account name, the protocol and the endpoint suffix.
+func parseConnectionString(s string) (connectionString, error) {
+	values := map[string]string{}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		i := strings.Index(part, "=")
+		if i < 0 {
+			return connectionString{}, fmt.Errorf("invalid connection string segment '%s'", part)
+		}
+		values[strings.ToLower(part[:i])] = part[i+1:]
+	}
+
+	conn := connectionString{
+		accountName:           values["accountname"],
+		accountKey:            values["accountkey"],
+		sharedAccessSignature: values["sharedaccesssignature"],
+		blobEndpoint:          values["blobendpoint"],
+	}
+	if conn.accountKey != "" && conn.accountName == "" {
+		return connectionString{}, fmt.Errorf("the connection string must have an AccountName with an AccountKey")
+	}
+	if conn.accountKey == "" && conn.sharedAccessSignature == "" {
+		return connectionString{}, fmt.Errorf("the connection string must have an AccountKey or a SharedAccessSignature")
+	}
+
+	if conn.blobEndpoint == "" {
+		if conn.accountName == "" {
+			return connectionString{}, fmt.Errorf("the connection string must have an AccountName or a BlobEndpoint")
+		}
+		protocol := values["defaultendpointsprotocol"]
+		if protocol == "" {
+			protocol = "https"
+		}
+		suffix := values["endpointsuffix"]
+		if suffix == "" {
+			suffix = defaultEndpointSuffix
+		}
+		conn.blobEndpoint = fmt.Sprintf("%s://%s.blob.%s", protocol, conn.accountName, suffix)
+	}
+
+	return conn, nil
+}
+
+// newTokenCredential returns a credential with tokens of the system assigned identity, or of the user assigned identity
+// with clientID, which are refreshed before they expire
+func newTokenCredential(clientID string, logger logger.Logger) (azblob.TokenCredential, error) {
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	var spt *adal.ServicePrincipalToken
+	if clientID == "" {
+		spt, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, storageResource)
+	} else {
+		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, storageResource, clientID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create managed identity token: %s", err)
+	}
+
+	err = spt.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed identity token: %s", err)
+	}
+
+	return azblob.NewTokenCredential(spt.OAuthToken(), func(credential azblob.TokenCredential) time.Duration {
+		err := spt.EnsureFresh()
+		if err != nil {
+			logger.Errorf("error refreshing the storage token, retrying in %s: %s", tokenRetryInterval, err)
+			return tokenRetryInterval
+		}
+
+		credential.SetToken(spt.OAuthToken())
+		refresh := time.Until(spt.Token().Expires()) - tokenRefreshMargin
+		if refresh < tokenRetryInterval {
+			return tokenRetryInterval
+		}
+		return refresh
+	}), nil
+}
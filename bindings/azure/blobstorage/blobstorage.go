@@ -6,13 +6,16 @@
 package blobstorage
 
 import (
+	"bytes"
 	"context"
 	b64 "encoding/base64"
 	"encoding/json"
 	"fmt"
-	"net/url"
+	"io/ioutil"
 	"strconv"
+	"time"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/google/uuid"
 
@@ -28,6 +31,19 @@ const (
 	contentLanguage    = "ContentLanguage"
 	contentDisposition = "ContentDisposition"
 	cacheControl       = "CacheControl"
+
+	// Request metadata of the get, delete and list operations
+	deleteSnapshots = "deleteSnapshots"
+	prefix          = "prefix"
+	maxResults      = "maxResults"
+	marker          = "marker"
+
+	// blockSize is the size of the blocks that large blobs are uploaded in, of which up to maxBuffers are uploaded at
+	// the same time
+	blockSize  = 4 * 1024 * 1024
+	maxBuffers = 16
+	// maxRetryRequests is how many times the download of a blob resumes after its connection failed
+	maxRetryRequests = 3
 )
 
 // AzureBlobStorage allows saving blobs to an Azure Blob Storage account
@@ -41,7 +57,30 @@ type AzureBlobStorage struct {
 type blobStorageMetadata struct {
 	StorageAccount   string `json:"storageAccount"`
 	StorageAccessKey string `json:"storageAccessKey"`
-	Container        string `json:"container"`
+	Container        string `json:"container" metadata:",required"`
+	// ConnectionString is used instead of the account and its key when it is set. Without either, the binding
+	// authenticates with the managed identity, whose client ID is only required for user assigned identities.
+	ConnectionString string `json:"connectionString"`
+	AzureClientID    string `json:"azureClientId"`
+}
+
+// createResponse is the response of the create operation
+type createResponse struct {
+	BlobURL string `json:"blobURL"`
+}
+
+// listResponse is the response of the list operation, whose next page is listed with the marker
+type listResponse struct {
+	Blobs      []listBlob `json:"blobs"`
+	NextMarker string     `json:"nextMarker,omitempty"`
+}
+
+type listBlob struct {
+	Name         string            `json:"name"`
+	Size         int64             `json:"size"`
+	ContentType  string            `json:"contentType,omitempty"`
+	LastModified time.Time         `json:"lastModified"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
 }
 
 // NewAzureBlobStorage returns a new Azure Blob Storage instance
@@ -56,16 +95,11 @@ func (a *AzureBlobStorage) Init(metadata bindings.Metadata) error {
 		return err
 	}
 	a.metadata = m
-	credential, err := azblob.NewSharedKeyCredential(m.StorageAccount, m.StorageAccessKey)
+
+	containerURL, err := newContainerURL(m, a.logger)
 	if err != nil {
-		return fmt.Errorf("invalid credentials with error: %s", err.Error())
+		return err
 	}
-	p := azblob.NewPipeline(credential, azblob.PipelineOptions{})
-
-	containerName := a.metadata.Container
-	URL, _ := url.Parse(
-		fmt.Sprintf("https://%s.blob.core.windows.net/%s", m.StorageAccount, containerName))
-	containerURL := azblob.NewContainerURL(*URL, p)
 
 	ctx := context.Background()
 	_, err = containerURL.Create(ctx, azblob.Metadata{}, azblob.PublicAccessNone)
@@ -75,6 +109,11 @@ func (a *AzureBlobStorage) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Azure Blob Storage binding
+func (a *AzureBlobStorage) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(blobStorageMetadata{})}
+}
+
 func (a *AzureBlobStorage) parseMetadata(metadata bindings.Metadata) (*blobStorageMetadata, error) {
 	connInfo := metadata.Properties
 	b, err := json.Marshal(connInfo)
@@ -87,14 +126,37 @@ func (a *AzureBlobStorage) parseMetadata(metadata bindings.Metadata) (*blobStora
 	if err != nil {
 		return nil, err
 	}
+	if m.Container == "" {
+		return nil, fmt.Errorf("missing container")
+	}
+	if m.ConnectionString == "" && m.StorageAccount == "" {
+		return nil, fmt.Errorf("missing storageAccount or connectionString")
+	}
 	return &m, nil
 }
 
 func (a *AzureBlobStorage) Operations() []bindings.OperationKind {
-	return []bindings.OperationKind{bindings.CreateOperation}
+	return []bindings.OperationKind{bindings.CreateOperation, bindings.GetOperation, bindings.DeleteOperation, bindings.ListOperation}
 }
 
 func (a *AzureBlobStorage) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	switch req.Operation {
+	case bindings.CreateOperation:
+		return a.create(req)
+	case bindings.GetOperation:
+		return a.get(req)
+	case bindings.DeleteOperation:
+		return a.delete(req)
+	case bindings.ListOperation:
+		return a.list(req)
+	default:
+		return nil, bindings.NewUnsupportedOperationError(req.Operation, a.Operations())
+	}
+}
+
+// create uploads the data to a block blob, in blocks when it is large. The metadata that are not blob properties are
+// the metadata of the blob.
+func (a *AzureBlobStorage) create(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	name := ""
 	if val, ok := req.Metadata[blobName]; ok && val != "" {
 		name = val
@@ -134,13 +196,135 @@ func (a *AzureBlobStorage) Invoke(req *bindings.InvokeRequest) (*bindings.Invoke
 		delete(req.Metadata, cacheControl)
 	}
 
-	// Unescape data which will still be a JSON string
-	unescapedData, _ := strconv.Unquote(string(req.Data))
+	// Unescape data which is still a JSON string, and upload other data as it is
+	data := req.Data
+	if unescapedData, err := strconv.Unquote(string(req.Data)); err == nil {
+		data = []byte(unescapedData)
+	}
 
-	_, err := azblob.UploadBufferToBlockBlob(context.Background(), []byte(unescapedData), blobURL, azblob.UploadToBlockBlobOptions{
-		Parallelism:     16,
+	_, err := azblob.UploadStreamToBlockBlob(context.Background(), bytes.NewReader(data), blobURL, azblob.UploadStreamToBlockBlobOptions{
+		BufferSize:      blockSize,
+		MaxBuffers:      maxBuffers,
 		Metadata:        req.Metadata,
 		BlobHTTPHeaders: blobHTTPHeaders,
 	})
-	return nil, err
+	if err != nil {
+		return nil, fmt.Errorf("error uploading blob %s: %s", name, err)
+	}
+
+	return jsonResponse(createResponse{BlobURL: blobURL.String()}, map[string]string{blobName: name})
+}
+
+// get downloads the blob, and returns its metadata as the metadata of the response
+func (a *AzureBlobStorage) get(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	name, err := requiredBlobName(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	resp, err := a.containerURL.NewBlobURL(name).Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading blob %s: %s", name, err)
+	}
+
+	// The body resumes the download when its connection fails
+	body := resp.Body(azblob.RetryReaderOptions{MaxRetryRequests: maxRetryRequests})
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading blob %s: %s", name, err)
+	}
+
+	return &bindings.InvokeResponse{Data: data, Metadata: resp.NewMetadata()}, nil
+}
+
+// delete deletes the blob, and its snapshots with the deleteSnapshots metadata include, or only its snapshots with only
+func (a *AzureBlobStorage) delete(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	name, err := requiredBlobName(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	option := azblob.DeleteSnapshotsOptionNone
+	if val := req.Metadata[deleteSnapshots]; val != "" {
+		option = azblob.DeleteSnapshotsOptionType(val)
+		if option != azblob.DeleteSnapshotsOptionInclude && option != azblob.DeleteSnapshotsOptionOnly {
+			return nil, fmt.Errorf("invalid %s '%s', supported values are: %s, %s", deleteSnapshots, val, azblob.DeleteSnapshotsOptionInclude, azblob.DeleteSnapshotsOptionOnly)
+		}
+	}
+
+	_, err = a.containerURL.NewBlobURL(name).Delete(context.Background(), option, azblob.BlobAccessConditions{})
+	if err != nil {
+		return nil, fmt.Errorf("error deleting blob %s: %s", name, err)
+	}
+
+	return nil, nil
+}
+
+// list returns a page of the blobs with the prefix metadata, with their metadata
+func (a *AzureBlobStorage) list(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	options := azblob.ListBlobsSegmentOptions{
+		Prefix:  req.Metadata[prefix],
+		Details: azblob.BlobListingDetails{Metadata: true},
+	}
+	if val := req.Metadata[maxResults]; val != "" {
+		max, err := strconv.ParseInt(val, 10, 32)
+		if err != nil || max <= 0 {
+			return nil, fmt.Errorf("invalid %s '%s', must be a positive integer", maxResults, val)
+		}
+		options.MaxResults = int32(max)
+	}
+
+	resp, err := a.containerURL.ListBlobsFlatSegment(context.Background(), azblob.Marker{Val: stringOrNil(req.Metadata[marker])}, options)
+	if err != nil {
+		return nil, fmt.Errorf("error listing blobs: %s", err)
+	}
+
+	res := listResponse{Blobs: make([]listBlob, 0, len(resp.Segment.BlobItems))}
+	if resp.NextMarker.NotDone() {
+		res.NextMarker = *resp.NextMarker.Val
+	}
+	for _, item := range resp.Segment.BlobItems {
+		blob := listBlob{
+			Name:         item.Name,
+			LastModified: item.Properties.LastModified,
+			Metadata:     item.Metadata,
+		}
+		if item.Properties.ContentLength != nil {
+			blob.Size = *item.Properties.ContentLength
+		}
+		if item.Properties.ContentType != nil {
+			blob.ContentType = *item.Properties.ContentType
+		}
+		res.Blobs = append(res.Blobs, blob)
+	}
+
+	return jsonResponse(res, nil)
+}
+
+func requiredBlobName(metadata map[string]string) (string, error) {
+	name := metadata[blobName]
+	if name == "" {
+		return "", fmt.Errorf("missing %s metadata", blobName)
+	}
+
+	return name, nil
+}
+
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func jsonResponse(v interface{}, metadata map[string]string) (*bindings.InvokeResponse, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bindings.InvokeResponse{Data: data, Metadata: metadata}, nil
 }
@@ -6,11 +6,19 @@
 package blobstorage
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseMetadata(t *testing.T) {
@@ -22,4 +30,117 @@ func TestParseMetadata(t *testing.T) {
 	assert.Equal(t, "test", meta.Container)
 	assert.Equal(t, "account", meta.StorageAccount)
 	assert.Equal(t, "key", meta.StorageAccessKey)
+
+	_, err = blonStorage.parseMetadata(bindings.Metadata{Properties: map[string]string{"storageAccount": "account"}})
+	assert.Error(t, err)
+
+	_, err = blonStorage.parseMetadata(bindings.Metadata{Properties: map[string]string{"container": "test"}})
+	assert.Error(t, err)
+}
+
+func TestParseConnectionString(t *testing.T) {
+	conn, err := parseConnectionString("DefaultEndpointsProtocol=https;AccountName=account;AccountKey=a2V5;EndpointSuffix=core.chinacloudapi.cn")
+	assert.NoError(t, err)
+	assert.Equal(t, connectionString{accountName: "account", accountKey: "a2V5", blobEndpoint: "https://account.blob.core.chinacloudapi.cn"}, conn)
+
+	conn, err = parseConnectionString("BlobEndpoint=https://blobs.example.com;SharedAccessSignature=sv=2019&sig=abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://blobs.example.com", conn.blobEndpoint)
+	assert.Equal(t, "sv=2019&sig=abc", conn.sharedAccessSignature)
+
+	for _, s := range []string{"AccountName=account", "AccountKey=a2V5", "SharedAccessSignature=sig", "invalid"} {
+		_, err = parseConnectionString(s)
+		assert.Error(t, err)
+	}
+}
+
+// fakeBlobService is a Blob service endpoint that keeps the blobs of the test container in memory
+type fakeBlobService struct {
+	blobs    map[string][]byte
+	metadata map[string]string
+	lock     sync.Mutex
+}
+
+func (f *fakeBlobService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/account/test/")
+	query := r.URL.Query()
+	switch {
+	case query.Get("restype") == "container" && query.Get("comp") == "list":
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`)
+		for n, data := range f.blobs {
+			fmt.Fprintf(w, `<Blob><Name>%s</Name><Properties><Content-Length>%d</Content-Length><Content-Type>text/plain</Content-Type></Properties><Metadata><owner>%s</owner></Metadata></Blob>`, n, len(data), f.metadata[n])
+		}
+		fmt.Fprint(w, `</Blobs><NextMarker>next</NextMarker></EnumerationResults>`)
+	case query.Get("restype") == "container":
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut:
+		f.blobs[name], _ = ioutil.ReadAll(r.Body)
+		f.metadata[name] = r.Header.Get("x-ms-meta-owner")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodGet:
+		data, ok := f.blobs[name]
+		if !ok {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("x-ms-meta-owner", f.metadata[name])
+		w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestOperations(t *testing.T) {
+	fake := &fakeBlobService{blobs: map[string][]byte{}, metadata: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	a := NewAzureBlobStorage(logger.NewLogger("test"))
+	err := a.Init(bindings.Metadata{Properties: map[string]string{
+		"container":        "test",
+		"connectionString": fmt.Sprintf("AccountName=account;AccountKey=a2V5;BlobEndpoint=%s/account", server.URL),
+	}})
+	require.NoError(t, err)
+
+	resp, err := a.Invoke(&bindings.InvokeRequest{Operation: bindings.CreateOperation, Data: []byte(`"data"`), Metadata: map[string]string{"blobName": "a", "owner": "me"}})
+	require.NoError(t, err)
+	assert.Equal(t, "a", resp.Metadata["blobName"])
+	var created createResponse
+	require.NoError(t, json.Unmarshal(resp.Data, &created))
+	assert.Equal(t, server.URL+"/account/test/a", created.BlobURL)
+	assert.Equal(t, []byte("data"), fake.blobs["a"])
+
+	_, err = a.Invoke(&bindings.InvokeRequest{Operation: bindings.CreateOperation, Data: []byte{0xff, 0x00}, Metadata: map[string]string{"blobName": "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xff, 0x00}, fake.blobs["b"])
+
+	resp, err = a.Invoke(&bindings.InvokeRequest{Operation: bindings.GetOperation, Metadata: map[string]string{"blobName": "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), resp.Data)
+	assert.Equal(t, "me", resp.Metadata["owner"])
+
+	resp, err = a.Invoke(&bindings.InvokeRequest{Operation: bindings.ListOperation, Metadata: map[string]string{"maxResults": "10"}})
+	require.NoError(t, err)
+	var listed listResponse
+	require.NoError(t, json.Unmarshal(resp.Data, &listed))
+	assert.Len(t, listed.Blobs, 2)
+	assert.Equal(t, "next", listed.NextMarker)
+
+	_, err = a.Invoke(&bindings.InvokeRequest{Operation: bindings.DeleteOperation, Metadata: map[string]string{"blobName": "a", "deleteSnapshots": "include"}})
+	require.NoError(t, err)
+	assert.NotContains(t, fake.blobs, "a")
+
+	_, err = a.Invoke(&bindings.InvokeRequest{Operation: bindings.GetOperation, Metadata: map[string]string{"blobName": "a"}})
+	assert.Error(t, err)
+
+	_, err = a.Invoke(&bindings.InvokeRequest{Operation: bindings.DeleteOperation, Metadata: map[string]string{"blobName": "b", "deleteSnapshots": "all"}})
+	assert.Error(t, err)
+
+	_, err = a.Invoke(&bindings.InvokeRequest{Operation: bindings.DeleteOperation})
+	assert.Error(t, err)
 }
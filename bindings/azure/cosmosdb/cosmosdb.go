@@ -12,6 +12,7 @@ import (
 
 	"github.com/a8m/documentdb"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
@@ -82,6 +83,11 @@ func (c *CosmosDB) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Azure CosmosDB binding
+func (c *CosmosDB) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(cosmosDBCredentials{})}
+}
+
 func (c *CosmosDB) parseMetadata(metadata bindings.Metadata) (*cosmosDBCredentials, error) {
 	connInfo := metadata.Properties
 	b, err := json.Marshal(connInfo)
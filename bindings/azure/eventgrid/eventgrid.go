@@ -17,6 +17,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/services/preview/eventgrid/mgmt/2020-04-01-preview/eventgrid"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/valyala/fasthttp"
 )
@@ -29,7 +30,7 @@ type AzureEventGrid struct {
 
 type azureEventGridMetadata struct {
 	// Component Name
-	Name string
+	Name string `metadata:"-"`
 
 	// Required Input Binding Metadata
 	TenantID           string `json:"tenantId"`
@@ -64,6 +65,11 @@ func (a *AzureEventGrid) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Azure Event Grid binding
+func (a *AzureEventGrid) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(azureEventGridMetadata{HandshakePort: "8080"})}
+}
+
 func (a *AzureEventGrid) Read(handler func(*bindings.ReadResponse) error) error {
 	err := a.ensureInputBindingMetadata()
 	if err != nil {
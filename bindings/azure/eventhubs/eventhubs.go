@@ -19,6 +19,7 @@ import (
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
@@ -94,6 +95,21 @@ func (a *AzureEventHubs) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Azure Event Hubs binding
+func (a *AzureEventHubs) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionString, Type: metadata.TypeString, Required: true},
+			{Name: consumerGroup, Type: metadata.TypeString, Required: true},
+			{Name: storageAccountName, Type: metadata.TypeString, Required: true},
+			{Name: storageAccountKey, Type: metadata.TypeString, Required: true},
+			{Name: storageContainerName, Type: metadata.TypeString, Required: true},
+			{Name: partitionKeyName, Type: metadata.TypeString},
+			{Name: partitionIDName, Type: metadata.TypeString},
+		},
+	}
+}
+
 func parseMetadata(meta bindings.Metadata) (*azureEventHubsMetadata, error) {
 	m := &azureEventHubsMetadata{}
 
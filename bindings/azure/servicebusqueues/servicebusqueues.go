@@ -8,10 +8,12 @@ package servicebusqueues
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"time"
 
 	servicebus "github.com/Azure/azure-service-bus-go"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
@@ -100,6 +102,19 @@ func (a *AzureServiceBusQueues) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Azure Service Bus Queues binding
+func (a *AzureServiceBusQueues) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := append(metadata.FieldsOf(serviceBusQueuesMetadata{}), metadata.Field{
+		Name:    bindings.TTLMetadataKey,
+		Type:    metadata.TypeInt,
+		Default: strconv.Itoa(int(AzureServiceBusDefaultMessageTimeToLive / time.Second)),
+	})
+	return metadata.ComponentMetadata{
+		Fields:       fields,
+		Capabilities: []metadata.Capability{metadata.CapabilityTTL},
+	}
+}
+
 func (a *AzureServiceBusQueues) parseMetadata(metadata bindings.Metadata) (*serviceBusQueuesMetadata, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
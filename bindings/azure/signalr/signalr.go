@@ -11,9 +11,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/pkg/errors"
@@ -26,8 +30,24 @@ const (
 	hubKey              = "hub"
 	groupKey            = "group"
 	userKey             = "user"
+
+	// The endpoint and the Azure AD settings can be set in metadata instead of a connection string
+	endpointKey          = "endpoint"
+	azureClientIDKey     = "azureClientId"
+	azureClientSecretKey = "azureClientSecret"
+	azureTenantIDKey     = "azureTenantId"
+
+	// signalrResource is the resource that Azure AD tokens for Azure SignalR are issued for
+	signalrResource = "https://signalr.azure.com"
+	authTypeAAD     = "aad"
 )
 
+// aadTokenProvider provides the Azure AD tokens of the requests, refreshing them before they expire
+type aadTokenProvider interface {
+	EnsureFresh() error
+	OAuthToken() string
+}
+
 // NewSignalR creates a new pub/sub based on Azure SignalR
 func NewSignalR(logger logger.Logger) *SignalR {
 	return &SignalR{
@@ -49,24 +69,40 @@ type SignalR struct {
 	version    string
 	hub        string
 	tokens     map[string]signalrCachedToken
+	tokensLock sync.Mutex
 	httpClient *http.Client
+	aadToken   aadTokenProvider
 
 	logger logger.Logger
 }
 
-// Init is responsible for initializing the SignalR output based on the metadata
+// Init is responsible for initializing the SignalR output based on the metadata. The requests are signed with the
+// access key of the connection string when there is one, and authenticated with an Azure AD token otherwise.
 func (s *SignalR) Init(metadata bindings.Metadata) error {
-	connectionString, ok := metadata.Properties[connectionStringKey]
-	if !ok || connectionString == "" {
-		return fmt.Errorf("missing connection string")
-	}
-
 	if hub, ok := metadata.Properties[hubKey]; ok && hub != "" {
 		s.hub = hub
 	}
 
+	aad := aadSettings{
+		clientID:     metadata.Properties[azureClientIDKey],
+		clientSecret: metadata.Properties[azureClientSecretKey],
+		tenantID:     metadata.Properties[azureTenantIDKey],
+	}
+	authType := ""
+
+	connectionString := strings.TrimSpace(metadata.Properties[connectionStringKey])
+	if connectionString == "" {
+		endpoint := metadata.Properties[endpointKey]
+		if endpoint == "" {
+			return fmt.Errorf("missing connection string")
+		}
+		s.endpoint = strings.TrimSuffix(endpoint, "/")
+		authType = authTypeAAD
+	}
+
 	// Expected: Endpoint=https://<servicename>.service.signalr.net;AccessKey=<access key>;Version=1.0;
-	connectionValues := strings.Split(strings.TrimSpace(connectionString), ";")
+	// or, with Azure AD: Endpoint=https://<servicename>.service.signalr.net;AuthType=aad;ClientId=<client id>;ClientSecret=<client secret>;TenantId=<tenant id>;Version=1.0;
+	connectionValues := strings.Split(connectionString, ";")
 	for _, connectionValue := range connectionValues {
 		if i := strings.Index(connectionValue, "="); i != -1 && len(connectionValue) > (i+1) {
 			k := connectionValue[0:i]
@@ -80,6 +116,14 @@ func (s *SignalR) Init(metadata bindings.Metadata) error {
 				s.accessKey = connectionValue[i+1:]
 			case "Version":
 				s.version = connectionValue[i+1:]
+			case "AuthType":
+				authType = strings.ToLower(connectionValue[i+1:])
+			case "ClientId":
+				aad.clientID = connectionValue[i+1:]
+			case "ClientSecret":
+				aad.clientSecret = connectionValue[i+1:]
+			case "TenantId":
+				aad.tenantID = connectionValue[i+1:]
 			}
 		}
 	}
@@ -88,6 +132,15 @@ func (s *SignalR) Init(metadata bindings.Metadata) error {
 		return fmt.Errorf("missing endpoint in connection string")
 	}
 
+	if authType == authTypeAAD {
+		token, err := newAADToken(aad)
+		if err != nil {
+			return fmt.Errorf("%s %s", errorPrefix, err)
+		}
+		s.aadToken = token
+		return nil
+	}
+
 	if s.accessKey == "" {
 		return fmt.Errorf("missing access key in connection string")
 	}
@@ -95,6 +148,51 @@ func (s *SignalR) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Azure SignalR binding
+func (s *SignalR) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionStringKey, Type: metadata.TypeString},
+			{Name: endpointKey, Type: metadata.TypeString},
+			{Name: hubKey, Type: metadata.TypeString},
+			{Name: azureClientIDKey, Type: metadata.TypeString},
+			{Name: azureClientSecretKey, Type: metadata.TypeString},
+			{Name: azureTenantIDKey, Type: metadata.TypeString},
+		},
+	}
+}
+
+// aadSettings are the settings of the Azure AD application, or of the managed identity, that the tokens are issued to
+type aadSettings struct {
+	clientID     string
+	clientSecret string
+	tenantID     string
+}
+
+// newAADToken returns a token of the application with the client secret when there is one, and of the user assigned
+// or system assigned managed identity otherwise
+func newAADToken(settings aadSettings) (*adal.ServicePrincipalToken, error) {
+	if settings.clientSecret != "" {
+		if settings.clientID == "" || settings.tenantID == "" {
+			return nil, errors.New("the client id and the tenant id are required with a client secret")
+		}
+		oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, settings.tenantID)
+		if err != nil {
+			return nil, err
+		}
+		return adal.NewServicePrincipalToken(*oauthConfig, settings.clientID, settings.clientSecret, signalrResource)
+	}
+
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	if settings.clientID == "" {
+		return adal.NewServicePrincipalTokenFromMSI(msiEndpoint, signalrResource)
+	}
+	return adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, signalrResource, settings.clientID)
+}
+
 func (s *SignalR) resolveAPIURL(req *bindings.InvokeRequest) (string, error) {
 	hub := s.hub
 	if hub == "" {
@@ -172,6 +270,16 @@ func (s *SignalR) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse,
 }
 
 func (s *SignalR) ensureValidToken(url string) (string, error) {
+	if s.aadToken != nil {
+		if err := s.aadToken.EnsureFresh(); err != nil {
+			return "", errors.Wrap(err, "failed to refresh the azure ad token")
+		}
+		return s.aadToken.OAuthToken(), nil
+	}
+
+	s.tokensLock.Lock()
+	defer s.tokensLock.Unlock()
+
 	now := time.Now()
 
 	if existing, ok := s.tokens[url]; ok {
@@ -279,3 +279,103 @@ func TestWriteShouldSucceed(t *testing.T) {
 		})
 	}
 }
+
+func TestAADConfigurations(t *testing.T) {
+	tests := []struct {
+		name             string
+		properties       map[string]string
+		expectedEndpoint string
+		valid            bool
+	}{
+		{
+			"Connection string with client secret",
+			map[string]string{
+				"connectionString": "Endpoint=https://fake.service.signalr.net;AuthType=aad;ClientId=id;ClientSecret=secret;TenantId=tenant;Version=1.0;",
+			},
+			"https://fake.service.signalr.net",
+			true,
+		},
+		{
+			"Connection string with managed identity",
+			map[string]string{
+				"connectionString": "Endpoint=https://fake.service.signalr.net;AuthType=aad;Version=1.0;",
+			},
+			"https://fake.service.signalr.net",
+			true,
+		},
+		{
+			"Endpoint with client secret in metadata",
+			map[string]string{
+				"endpoint":          "https://fake.service.signalr.net/",
+				"azureClientId":     "id",
+				"azureClientSecret": "secret",
+				"azureTenantId":     "tenant",
+			},
+			"https://fake.service.signalr.net",
+			true,
+		},
+		{
+			"Client secret without tenant",
+			map[string]string{
+				"connectionString": "Endpoint=https://fake.service.signalr.net;AuthType=aad;ClientId=id;ClientSecret=secret;",
+			},
+			"",
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSignalR(logger.NewLogger("test"))
+			err := s.Init(bindings.Metadata{Properties: tt.properties})
+			if !tt.valid {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedEndpoint, s.endpoint)
+			assert.Empty(t, s.accessKey)
+			assert.NotNil(t, s.aadToken)
+		})
+	}
+}
+
+type fakeAADToken struct {
+	err error
+}
+
+func (f *fakeAADToken) EnsureFresh() error {
+	return f.err
+}
+
+func (f *fakeAADToken) OAuthToken() string {
+	return "aadtoken"
+}
+
+func TestWriteWithAADToken(t *testing.T) {
+	httpTransport := &mockTransport{
+		response: &http.Response{StatusCode: 202, Body: ioutil.NopCloser(strings.NewReader(""))},
+	}
+
+	token := &fakeAADToken{}
+	s := NewSignalR(logger.NewLogger("test"))
+	s.endpoint = "https://fake.service.signalr.net"
+	s.aadToken = token
+	s.httpClient = &http.Client{
+		Transport: httpTransport,
+	}
+
+	_, err := s.Invoke(&bindings.InvokeRequest{
+		Data:     []byte("hello world"),
+		Metadata: map[string]string{hubKey: "testHub"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer aadtoken", httpTransport.request.Header.Get("Authorization"))
+
+	token.err = errors.New("fake error")
+	_, err = s.Invoke(&bindings.InvokeRequest{
+		Data:     []byte("hello world"),
+		Metadata: map[string]string{hubKey: "testHub"},
+	})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,63 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package storagequeues
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-storage-queue-go/azqueue"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// storageResource is the resource that Azure AD tokens for Azure Storage are issued for
+	storageResource = "https://storage.azure.com/"
+
+	// tokenRefreshMargin is how long before their expiry the tokens are refreshed
+	tokenRefreshMargin = 5 * time.Minute
+	tokenRetryInterval = 30 * time.Second
+)
+
+// newTokenCredential returns a credential with tokens of the system assigned identity, or of the user assigned identity
+// with clientID, which are refreshed before they expire
+func newTokenCredential(clientID string, logger logger.Logger) (azqueue.TokenCredential, error) {
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	var spt *adal.ServicePrincipalToken
+	if clientID == "" {
+		spt, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, storageResource)
+	} else {
+		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, storageResource, clientID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create managed identity token: %s", err)
+	}
+
+	err = spt.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed identity token: %s", err)
+	}
+
+	return azqueue.NewTokenCredential(spt.OAuthToken(), func(credential azqueue.TokenCredential) time.Duration {
+		err := spt.EnsureFresh()
+		if err != nil {
+			logger.Errorf("error refreshing the storage token, retrying in %s: %s", tokenRetryInterval, err)
+			return tokenRetryInterval
+		}
+
+		credential.SetToken(spt.OAuthToken())
+		refresh := time.Until(spt.Token().Expires()) - tokenRefreshMargin
+		if refresh < tokenRetryInterval {
+			return tokenRetryInterval
+		}
+		return refresh
+	}), nil
+}
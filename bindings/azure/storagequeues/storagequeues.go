@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -20,11 +21,22 @@ import (
 
 	"github.com/Azure/azure-storage-queue-go/azqueue"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
 const (
-	defaultTTL = time.Minute * 10
+	defaultTTL               = time.Minute * 10
+	defaultVisibilityTimeout = time.Second * 30
+	defaultPollingInterval   = time.Second * 10
+	defaultMaxDequeueCount   = 5
+
+	visibilityTimeoutKey = "visibilityTimeout"
+	pollingIntervalKey   = "pollingInterval"
+	maxDequeueCountKey   = "maxDequeueCount"
+
+	// poisonQueueSuffix is appended to the name of the queue for the default name of its poison queue
+	poisonQueueSuffix = "-poison"
 )
 
 type consumer struct {
@@ -33,30 +45,50 @@ type consumer struct {
 
 // QueueHelper enables injection for testnig
 type QueueHelper interface {
-	Init(accountName string, accountKey string, queueName string, decodeBase64 bool) error
+	Init(metadata *storageQueuesMetadata) error
 	Write(data []byte, ttl *time.Duration) error
 	Read(ctx context.Context, consumer *consumer) error
 }
 
 // AzureQueueHelper concrete impl of queue helper
 type AzureQueueHelper struct {
-	credential   *azqueue.SharedKeyCredential
+	credential   azqueue.Credential
 	queueURL     azqueue.QueueURL
 	reqURI       string
 	logger       logger.Logger
 	decodeBase64 bool
+
+	visibilityTimeout time.Duration
+	pollingInterval   time.Duration
+	maxDequeueCount   int64
+	poisonQueueURL    azqueue.QueueURL
 }
 
-// Init sets up this helper
-func (d *AzureQueueHelper) Init(accountName string, accountKey string, queueName string, decodeBase64 bool) error {
-	credential, err := azqueue.NewSharedKeyCredential(accountName, accountKey)
+// Init sets up this helper. The requests are signed with the account key when there is one, and authenticated with
+// an Azure AD token of the managed identity otherwise.
+func (d *AzureQueueHelper) Init(metadata *storageQueuesMetadata) error {
+	var credential azqueue.Credential
+	var err error
+	if metadata.AccountKey != "" {
+		credential, err = azqueue.NewSharedKeyCredential(metadata.AccountName, metadata.AccountKey)
+	} else {
+		credential, err = newTokenCredential(metadata.AzureClientID, d.logger)
+	}
 	if err != nil {
 		return err
 	}
 	d.credential = credential
-	d.decodeBase64 = decodeBase64
-	u, _ := url.Parse(fmt.Sprintf(d.reqURI, accountName, queueName))
-	d.queueURL = azqueue.NewQueueURL(*u, azqueue.NewPipeline(credential, azqueue.PipelineOptions{}))
+	d.decodeBase64 = metadata.DecodeBase64 == "true"
+	d.visibilityTimeout = metadata.visibilityTimeout
+	d.pollingInterval = metadata.pollingInterval
+	d.maxDequeueCount = metadata.maxDequeueCount
+
+	p := azqueue.NewPipeline(credential, azqueue.PipelineOptions{})
+	u, _ := url.Parse(fmt.Sprintf(d.reqURI, metadata.AccountName, metadata.QueueName))
+	d.queueURL = azqueue.NewQueueURL(*u, p)
+	u, _ = url.Parse(fmt.Sprintf(d.reqURI, metadata.AccountName, metadata.PoisonQueueName))
+	d.poisonQueueURL = azqueue.NewQueueURL(*u, p)
+
 	ctx := context.TODO()
 	_, err = d.queueURL.Create(ctx, azqueue.Metadata{})
 	if err != nil {
@@ -80,16 +112,27 @@ func (d *AzureQueueHelper) Write(data []byte, ttl *time.Duration) error {
 
 func (d *AzureQueueHelper) Read(ctx context.Context, consumer *consumer) error {
 	messagesURL := d.queueURL.NewMessagesURL()
-	res, err := messagesURL.Dequeue(ctx, 1, time.Second*30)
+	res, err := messagesURL.Dequeue(ctx, 1, d.visibilityTimeout)
 	if err != nil {
 		return err
 	}
 	if res.NumMessages() == 0 {
-		// Queue was empty so back off by 10 seconds before trying again
-		time.Sleep(10 * time.Second)
+		// Queue was empty so back off before trying again
+		select {
+		case <-ctx.Done():
+		case <-time.After(d.pollingInterval):
+		}
 		return nil
 	}
-	mt := res.Message(0).Text
+	msg := res.Message(0)
+	messageIDURL := messagesURL.NewMessageIDURL(msg.ID)
+
+	// The messages that keep failing are moved to the poison queue instead of being retried forever
+	if d.maxDequeueCount > 0 && msg.DequeueCount > d.maxDequeueCount {
+		return d.moveToPoisonQueue(ctx, messageIDURL, msg)
+	}
+
+	mt := msg.Text
 
 	var data []byte
 
@@ -110,15 +153,29 @@ func (d *AzureQueueHelper) Read(ctx context.Context, consumer *consumer) error {
 	if err != nil {
 		return err
 	}
-	messageIDURL := messagesURL.NewMessageIDURL(res.Message(0).ID)
-	pr := res.Message(0).PopReceipt
-	_, err = messageIDURL.Delete(ctx, pr)
+	_, err = messageIDURL.Delete(ctx, msg.PopReceipt)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// moveToPoisonQueue enqueues the message in the poison queue, without expiration, and deletes it from the queue
+func (d *AzureQueueHelper) moveToPoisonQueue(ctx context.Context, messageIDURL azqueue.MessageIDURL, msg *azqueue.DequeuedMessage) error {
+	d.logger.Warnf("moving message %s to the poison queue after %d dequeues", msg.ID, msg.DequeueCount)
+
+	_, err := d.poisonQueueURL.Create(ctx, azqueue.Metadata{})
+	if err != nil {
+		return fmt.Errorf("error creating the poison queue: %s", err)
+	}
+	_, err = d.poisonQueueURL.NewMessagesURL().Enqueue(ctx, msg.Text, 0, -time.Second)
+	if err != nil {
+		return fmt.Errorf("error moving message %s to the poison queue: %s", msg.ID, err)
+	}
+	_, err = messageIDURL.Delete(ctx, msg.PopReceipt)
+	return err
+}
+
 // NewAzureQueueHelper creates new helper
 func NewAzureQueueHelper(logger logger.Logger) QueueHelper {
 	return &AzureQueueHelper{
@@ -137,10 +194,16 @@ type AzureStorageQueues struct {
 
 type storageQueuesMetadata struct {
 	AccountKey   string `json:"storageAccessKey"`
-	QueueName    string `json:"queue"`
-	AccountName  string `json:"storageAccount"`
+	QueueName    string `json:"queue" metadata:",required"`
+	AccountName  string `json:"storageAccount" metadata:",required"`
 	DecodeBase64 string `json:"decodeBase64"`
 	ttl          *time.Duration
+
+	AzureClientID     string `json:"azureClientId"`
+	PoisonQueueName   string `json:"poisonQueue"`
+	visibilityTimeout time.Duration
+	pollingInterval   time.Duration
+	maxDequeueCount   int64
 }
 
 // NewAzureStorageQueues returns a new AzureStorageQueues instance
@@ -156,18 +219,28 @@ func (a *AzureStorageQueues) Init(metadata bindings.Metadata) error {
 	}
 	a.metadata = meta
 
-	decodeBase64 := false
-	if a.metadata.DecodeBase64 == "true" {
-		decodeBase64 = true
-	}
-
-	err = a.helper.Init(a.metadata.AccountName, a.metadata.AccountKey, a.metadata.QueueName, decodeBase64)
+	err = a.helper.Init(a.metadata)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Azure Storage Queues binding
+func (a *AzureStorageQueues) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := metadata.FieldsOf(storageQueuesMetadata{})
+	fields = append(fields,
+		metadata.Field{Name: bindings.TTLMetadataKey, Type: metadata.TypeInt},
+		metadata.Field{Name: visibilityTimeoutKey, Type: metadata.TypeDuration, Default: defaultVisibilityTimeout.String()},
+		metadata.Field{Name: pollingIntervalKey, Type: metadata.TypeDuration, Default: defaultPollingInterval.String()},
+		metadata.Field{Name: maxDequeueCountKey, Type: metadata.TypeInt, Default: strconv.Itoa(defaultMaxDequeueCount)},
+	)
+	return metadata.ComponentMetadata{
+		Fields:       fields,
+		Capabilities: []metadata.Capability{metadata.CapabilityTTL},
+	}
+}
+
 func (a *AzureStorageQueues) parseMetadata(metadata bindings.Metadata) (*storageQueuesMetadata, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
@@ -188,9 +261,51 @@ func (a *AzureStorageQueues) parseMetadata(metadata bindings.Metadata) (*storage
 		m.ttl = &ttl
 	}
 
+	if m.AccountName == "" {
+		return nil, fmt.Errorf("missing storageAccount")
+	}
+	if m.QueueName == "" {
+		return nil, fmt.Errorf("missing queue")
+	}
+	if m.PoisonQueueName == "" {
+		m.PoisonQueueName = m.QueueName + poisonQueueSuffix
+	}
+
+	m.visibilityTimeout, err = parseDuration(metadata.Properties, visibilityTimeoutKey, defaultVisibilityTimeout)
+	if err != nil {
+		return nil, err
+	}
+	m.pollingInterval, err = parseDuration(metadata.Properties, pollingIntervalKey, defaultPollingInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	m.maxDequeueCount = defaultMaxDequeueCount
+	if val := metadata.Properties[maxDequeueCountKey]; val != "" {
+		// 0 disables the poison queue
+		m.maxDequeueCount, err = strconv.ParseInt(val, 10, 64)
+		if err != nil || m.maxDequeueCount < 0 {
+			return nil, fmt.Errorf("invalid %s %s", maxDequeueCountKey, val)
+		}
+	}
+
 	return &m, nil
 }
 
+// parseDuration returns the positive duration of the metadata key, or the default value when it is not set
+func parseDuration(properties map[string]string, key string, defaultValue time.Duration) (time.Duration, error) {
+	val := properties[key]
+	if val == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s %s", key, val)
+	}
+	return d, nil
+}
+
 func (a *AzureStorageQueues) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
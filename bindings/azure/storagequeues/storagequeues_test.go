@@ -7,6 +7,10 @@ package storagequeues
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"syscall"
 	"testing"
@@ -16,14 +20,15 @@ import (
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type MockHelper struct {
 	mock.Mock
 }
 
-func (m *MockHelper) Init(accountName string, accountKey string, queueName string, decodeBase64 bool) error {
-	retvals := m.Called(accountName, accountKey, queueName, decodeBase64)
+func (m *MockHelper) Init(metadata *storageQueuesMetadata) error {
+	retvals := m.Called(metadata)
 	return retvals.Error(0)
 }
 
@@ -38,7 +43,7 @@ func (m *MockHelper) Read(ctx context.Context, consumer *consumer) error {
 
 func TestWriteQueue(t *testing.T) {
 	mm := new(MockHelper)
-	mm.On("Init", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(nil)
+	mm.On("Init", mock.AnythingOfType("*storagequeues.storageQueuesMetadata")).Return(nil)
 	mm.On("Write", mock.AnythingOfType("[]uint8"), mock.MatchedBy(func(in *time.Duration) bool {
 		return in == nil
 	})).Return(nil)
@@ -60,7 +65,7 @@ func TestWriteQueue(t *testing.T) {
 
 func TestWriteWithTTLInQueue(t *testing.T) {
 	mm := new(MockHelper)
-	mm.On("Init", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(nil)
+	mm.On("Init", mock.AnythingOfType("*storagequeues.storageQueuesMetadata")).Return(nil)
 	mm.On("Write", mock.AnythingOfTypeArgument("[]uint8"), mock.MatchedBy(func(in *time.Duration) bool {
 		return in != nil && *in == time.Second
 	})).Return(nil)
@@ -82,7 +87,7 @@ func TestWriteWithTTLInQueue(t *testing.T) {
 
 func TestWriteWithTTLInWrite(t *testing.T) {
 	mm := new(MockHelper)
-	mm.On("Init", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(nil)
+	mm.On("Init", mock.AnythingOfType("*storagequeues.storageQueuesMetadata")).Return(nil)
 	mm.On("Write", mock.AnythingOfTypeArgument("[]uint8"), mock.MatchedBy(func(in *time.Duration) bool {
 		return in != nil && *in == time.Second
 	})).Return(nil)
@@ -125,7 +130,7 @@ func TestWriteWithTTLInWrite(t *testing.T) {
 
 func TestReadQueue(t *testing.T) {
 	mm := new(MockHelper)
-	mm.On("Init", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(nil)
+	mm.On("Init", mock.AnythingOfType("*storagequeues.storageQueuesMetadata")).Return(nil)
 	mm.On("Write", mock.AnythingOfType("[]uint8"), mock.AnythingOfType("*time.Duration")).Return(nil)
 	a := AzureStorageQueues{helper: mm, logger: logger.NewLogger("test")}
 
@@ -158,7 +163,7 @@ func TestReadQueue(t *testing.T) {
 
 func TestReadQueueDecode(t *testing.T) {
 	mm := new(MockHelper)
-	mm.On("Init", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("bool")).Return(nil)
+	mm.On("Init", mock.AnythingOfType("*storagequeues.storageQueuesMetadata")).Return(nil)
 	mm.On("Write", mock.AnythingOfType("[]uint8"), mock.AnythingOfType("*time.Duration")).Return(nil)
 
 	a := AzureStorageQueues{helper: mm, logger: logger.NewLogger("test")}
@@ -220,7 +225,9 @@ func TestReadQueueDecode(t *testing.T) {
 */
 func TestReadQueueNoMessage(t *testing.T) {
 	mm := new(MockHelper)
-	mm.On("Init", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), false).Return(nil)
+	mm.On("Init", mock.MatchedBy(func(in *storageQueuesMetadata) bool {
+		return in.DecodeBase64 != "true"
+	})).Return(nil)
 	mm.On("Write", mock.AnythingOfType("[]uint8"), mock.AnythingOfType("*time.Duration")).Return(nil)
 
 	a := AzureStorageQueues{helper: mm, logger: logger.NewLogger("test")}
@@ -323,3 +330,92 @@ func TestParseMetadataWithInvalidTTL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMetadataPollingOptions(t *testing.T) {
+	a := NewAzureStorageQueues(logger.NewLogger("test"))
+
+	meta, err := a.parseMetadata(bindings.Metadata{Properties: map[string]string{"queue": "queue1", "storageAccount": "devstoreaccount1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, defaultVisibilityTimeout, meta.visibilityTimeout)
+	assert.Equal(t, defaultPollingInterval, meta.pollingInterval)
+	assert.Equal(t, int64(defaultMaxDequeueCount), meta.maxDequeueCount)
+	assert.Equal(t, "queue1-poison", meta.PoisonQueueName)
+	assert.Empty(t, meta.AccountKey)
+
+	meta, err = a.parseMetadata(bindings.Metadata{Properties: map[string]string{"queue": "queue1", "storageAccount": "devstoreaccount1", "azureClientId": "id", "visibilityTimeout": "1m", "pollingInterval": "1s", "maxDequeueCount": "0", "poisonQueue": "failed"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "id", meta.AzureClientID)
+	assert.Equal(t, time.Minute, meta.visibilityTimeout)
+	assert.Equal(t, time.Second, meta.pollingInterval)
+	assert.Equal(t, int64(0), meta.maxDequeueCount)
+	assert.Equal(t, "failed", meta.PoisonQueueName)
+
+	for _, properties := range []map[string]string{
+		{"queue": "queue1"},
+		{"storageAccount": "devstoreaccount1"},
+		{"queue": "queue1", "storageAccount": "devstoreaccount1", "visibilityTimeout": "0s"},
+		{"queue": "queue1", "storageAccount": "devstoreaccount1", "pollingInterval": "often"},
+		{"queue": "queue1", "storageAccount": "devstoreaccount1", "maxDequeueCount": "-1"},
+	} {
+		_, err := a.parseMetadata(bindings.Metadata{Properties: properties})
+		assert.NotNil(t, err)
+	}
+}
+
+// fakeQueueService serves a single message from queue1, and records the messages enqueued and deleted
+type fakeQueueService struct {
+	dequeueCount      int
+	visibilityTimeout string
+	enqueued          map[string]string
+	deleted           []string
+}
+
+func (f *fakeQueueService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPut:
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPost:
+		body, _ := ioutil.ReadAll(r.Body)
+		f.enqueued[r.URL.Path] = string(body)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><QueueMessagesList><QueueMessage><MessageId>new</MessageId><PopReceipt>pr</PopReceipt></QueueMessage></QueueMessagesList>`)
+	case r.Method == http.MethodGet:
+		f.visibilityTimeout = r.URL.Query().Get("visibilitytimeout")
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><QueueMessagesList><QueueMessage><MessageId>id1</MessageId><PopReceipt>pr</PopReceipt><DequeueCount>%d</DequeueCount><MessageText>hello</MessageText></QueueMessage></QueueMessagesList>`, f.dequeueCount)
+	case r.Method == http.MethodDelete:
+		f.deleted = append(f.deleted, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestReadPoisonMessage(t *testing.T) {
+	service := &fakeQueueService{enqueued: map[string]string{}}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	a := NewAzureStorageQueues(logger.NewLogger("test"))
+	a.helper.(*AzureQueueHelper).reqURI = server.URL + "/%s/%s"
+	err := a.Init(bindings.Metadata{Properties: map[string]string{"storageAccessKey": "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==", "queue": "queue1", "storageAccount": "devstoreaccount1", "visibilityTimeout": "1m", "maxDequeueCount": "2"}})
+	require.Nil(t, err)
+
+	handled := 0
+	c := &consumer{callback: func(r *bindings.ReadResponse) error {
+		handled++
+		assert.Equal(t, "hello", string(r.Data))
+		return nil
+	}}
+
+	service.dequeueCount = 2
+	require.Nil(t, a.helper.Read(context.Background(), c))
+	assert.Equal(t, 1, handled)
+	assert.Equal(t, "60", service.visibilityTimeout)
+	assert.Equal(t, []string{"/devstoreaccount1/queue1/messages/id1"}, service.deleted)
+	assert.Empty(t, service.enqueued)
+
+	service.dequeueCount = 3
+	service.deleted = nil
+	require.Nil(t, a.helper.Read(context.Background(), c))
+	assert.Equal(t, 1, handled)
+	assert.Equal(t, []string{"/devstoreaccount1/queue1/messages/id1"}, service.deleted)
+	assert.Contains(t, service.enqueued["/devstoreaccount1/queue1-poison/messages"], "<MessageText>hello</MessageText>")
+}
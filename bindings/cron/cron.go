@@ -7,9 +7,14 @@ package twitter
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/pkg/errors"
 	"github.com/robfig/cron/v3"
@@ -20,6 +25,7 @@ type Binding struct {
 	logger   logger.Logger
 	schedule string
 	stopCh   chan bool
+	stopOnce sync.Once
 	parser   cron.Parser
 }
 
@@ -53,26 +59,45 @@ func (b *Binding) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Cron binding
+func (b *Binding) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{{Name: "schedule", Type: metadata.TypeString, Required: true}},
+	}
+}
+
 // Read triggers the Cron scheduler
 func (b *Binding) Read(handler func(*bindings.ReadResponse) error) error {
 	c := cron.New(cron.WithParser(b.parser))
 	id, err := c.AddFunc(b.schedule, func() {
 		b.logger.Debugf("schedule fired: %v", time.Now())
-		handler(&bindings.ReadResponse{
+		err := handler(&bindings.ReadResponse{
 			Metadata: map[string]string{
 				"timeZone":    c.Location().String(),
 				"readTimeUTC": time.Now().UTC().String(),
 			},
 		})
+		if err != nil {
+			b.logger.Errorf("error handling schedule %s: %s", b.schedule, err)
+		}
 	})
 	if err != nil {
 		return errors.Wrapf(err, "error scheduling %s", b.schedule)
 	}
 	c.Start()
 	b.logger.Debugf("next run: %v", time.Until(c.Entry(id).Next))
-	<-b.stopCh
+
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigterm)
+	select {
+	case <-b.stopCh:
+	case <-sigterm:
+	}
+
 	b.logger.Debugf("stopping schedule: %s", b.schedule)
-	c.Stop()
+	// wait for the handler of a schedule that already fired, so that it is not interrupted
+	<-c.Stop().Done()
 	return nil
 }
 
@@ -80,10 +105,12 @@ func (b *Binding) Read(handler func(*bindings.ReadResponse) error) error {
 func (b *Binding) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
 	b.logger.Debugf("operation: %v", req.Operation)
 	if req.Operation != bindings.DeleteOperation {
-		return nil, fmt.Errorf("invalid operation: '%v', only '%v' supported",
-			req.Operation, bindings.DeleteOperation)
+		return nil, bindings.NewUnsupportedOperationError(req.Operation, b.Operations())
 	}
-	b.stopCh <- true
+	// the schedule stops once, whether or not it is read yet
+	b.stopOnce.Do(func() {
+		close(b.stopCh)
+	})
 	return &bindings.InvokeResponse{
 		Metadata: map[string]string{
 			"schedule":    b.schedule,
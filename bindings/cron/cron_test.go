@@ -84,3 +84,19 @@ func TestCronInvokeInvalidOperation(t *testing.T) {
 	})
 	assert.Error(t, err)
 }
+
+func TestCronInvokeDeleteBeforeRead(t *testing.T) {
+	c := getNewCron()
+	assert.NoErrorf(t, c.Init(getTestMetadata("@every 1h")), "error initializing valid schedule")
+
+	// deleting twice does not block, and the read returns without firing the schedule
+	for i := 0; i < 2; i++ {
+		_, err := c.Invoke(&bindings.InvokeRequest{Operation: bindings.DeleteOperation})
+		assert.NoError(t, err)
+	}
+	err := c.Read(func(res *bindings.ReadResponse) error {
+		assert.Fail(t, "schedule fired after delete")
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -11,6 +11,7 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	uuid "github.com/satori/go.uuid"
 	"google.golang.org/api/option"
@@ -66,6 +67,11 @@ func (g *GCPStorage) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the GCP Storage Bucket binding
+func (g *GCPStorage) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(gcpMetadata{})}
+}
+
 func (g *GCPStorage) parseMetadata(metadata bindings.Metadata) ([]byte, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
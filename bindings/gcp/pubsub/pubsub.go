@@ -12,6 +12,7 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"google.golang.org/api/option"
 )
@@ -73,6 +74,11 @@ func (g *GCPPubSub) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the GCP Pub/Sub binding
+func (g *GCPPubSub) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(pubSubMetadata{})}
+}
+
 func (g *GCPPubSub) parseMetadata(metadata bindings.Metadata) ([]byte, error) {
 	b, err := json.Marshal(metadata.Properties)
 	return b, err
@@ -0,0 +1,205 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// QueryOperation runs the query of the "query" request metadata
+	QueryOperation = bindings.QueryOperation
+	// MutationOperation runs the mutation of the "mutation" request metadata
+	MutationOperation bindings.OperationKind = "mutation"
+
+	// Component metadata
+	endpointKey = "endpoint"
+	timeoutKey  = "timeout"
+
+	// Request metadata
+	queryKey    = "query"
+	mutationKey = "mutation"
+
+	// headerPrefix prefixes the component and request metadata sent as headers, e.g. "header:Authorization"
+	headerPrefix = "header:"
+	// variablePrefix prefixes the request metadata passed as string variables, e.g. "variable:id"
+	variablePrefix = "variable:"
+
+	defaultTimeout = 30 * time.Second
+)
+
+// GraphQL allows running queries and mutations against a GraphQL endpoint
+type GraphQL struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	logger   logger.Logger
+}
+
+// graphQLRequest is the body of the requests sent to the endpoint
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the body of the responses of the endpoint
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// NewGraphQL returns a new GraphQL binding instance
+func NewGraphQL(logger logger.Logger) *GraphQL {
+	return &GraphQL{logger: logger}
+}
+
+// Init does metadata parsing
+func (g *GraphQL) Init(metadata bindings.Metadata) error {
+	g.endpoint = metadata.Properties[endpointKey]
+	if g.endpoint == "" {
+		return errors.New("graphql binding error: missing endpoint")
+	}
+
+	timeout := defaultTimeout
+	if val := metadata.Properties[timeoutKey]; val != "" {
+		var err error
+		timeout, err = time.ParseDuration(val)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("graphql binding error: invalid timeout %s", val)
+		}
+	}
+
+	g.headers = prefixed(metadata.Properties, headerPrefix)
+	g.client = &http.Client{Timeout: timeout}
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the GraphQL binding, without the headers of the requests,
+// which are the metadata starting with header:
+func (g *GraphQL) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: endpointKey, Type: metadata.TypeString, Required: true},
+			{Name: timeoutKey, Type: metadata.TypeDuration, Default: defaultTimeout.String()},
+		},
+	}
+}
+
+func (g *GraphQL) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{QueryOperation, MutationOperation}
+}
+
+// Invoke runs the query or the mutation of the request metadata and returns the data of the response. The variables
+// are the JSON object of the request data, if any, and the "variable:" request metadata. The "header:" request
+// metadata override the headers of the component.
+func (g *GraphQL) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var key string
+	switch req.Operation {
+	case QueryOperation:
+		key = queryKey
+	case MutationOperation:
+		key = mutationKey
+	default:
+		return nil, bindings.NewUnsupportedOperationError(req.Operation, g.Operations())
+	}
+
+	document := req.Metadata[key]
+	if document == "" {
+		return nil, fmt.Errorf("graphql binding error: missing %s metadata", key)
+	}
+
+	variables, err := parseVariables(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(graphQLRequest{Query: document, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, g.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range g.headers {
+		httpReq.Header.Set(k, v)
+	}
+	for k, v := range prefixed(req.Metadata, headerPrefix) {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("graphql binding error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var graphQLResp graphQLResponse
+	if err = json.Unmarshal(b, &graphQLResp); err != nil {
+		return nil, fmt.Errorf("graphql binding error: invalid response with status %d: %s", resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	if len(graphQLResp.Errors) > 0 {
+		messages := make([]string, 0, len(graphQLResp.Errors))
+		for _, e := range graphQLResp.Errors {
+			messages = append(messages, e.Message)
+		}
+		return nil, fmt.Errorf("graphql binding error: %s", strings.Join(messages, "; "))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("graphql binding error: status %d", resp.StatusCode)
+	}
+
+	return &bindings.InvokeResponse{Data: graphQLResp.Data}, nil
+}
+
+// parseVariables returns the variables of the request data and metadata
+func parseVariables(req *bindings.InvokeRequest) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+	if len(bytes.TrimSpace(req.Data)) > 0 {
+		if err := json.Unmarshal(req.Data, &variables); err != nil {
+			return nil, fmt.Errorf("graphql binding error: the data must be a JSON object of variables: %s", err)
+		}
+	}
+	for k, v := range prefixed(req.Metadata, variablePrefix) {
+		variables[k] = v
+	}
+
+	if len(variables) == 0 {
+		return nil, nil
+	}
+	return variables, nil
+}
+
+// prefixed returns the values of the keys with the prefix, without the prefix
+func prefixed(m map[string]string, prefix string) map[string]string {
+	values := map[string]string{}
+	for k, v := range m {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			values[k[len(prefix):]] = v
+		}
+	}
+	return values
+}
@@ -0,0 +1,83 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	g := NewGraphQL(logger.NewLogger("test"))
+	assert.Error(t, g.Init(bindings.Metadata{Properties: map[string]string{}}))
+	assert.Error(t, g.Init(bindings.Metadata{Properties: map[string]string{"endpoint": "http://localhost", "timeout": "-1s"}}))
+
+	require.NoError(t, g.Init(bindings.Metadata{Properties: map[string]string{"endpoint": "http://localhost", "header:Authorization": "Bearer token"}}))
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, g.headers)
+}
+
+func TestInvoke(t *testing.T) {
+	var received graphQLRequest
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		received = graphQLRequest{}
+		json.NewDecoder(r.Body).Decode(&received)
+		if received.Query == "{ fail }" {
+			w.Write([]byte(`{"errors": [{"message": "first"}, {"message": "second"}]}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"user": {"name": "dapr"}}}`))
+	}))
+	defer server.Close()
+
+	g := NewGraphQL(logger.NewLogger("test"))
+	require.NoError(t, g.Init(bindings.Metadata{Properties: map[string]string{"endpoint": server.URL, "header:Authorization": "Bearer token", "header:X-Tenant": "a"}}))
+
+	resp, err := g.Invoke(&bindings.InvokeRequest{
+		Operation: QueryOperation,
+		Data:      []byte(`{"limit": 1}`),
+		Metadata: map[string]string{
+			"query":           "query ($id: ID!, $limit: Int) { user(id: $id) { name } }",
+			"variable:id":     "42",
+			"header:X-Tenant": "b",
+		},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user": {"name": "dapr"}}`, string(resp.Data))
+	assert.Equal(t, map[string]interface{}{"id": "42", "limit": float64(1)}, received.Variables)
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	assert.Equal(t, "b", header.Get("X-Tenant"))
+
+	_, err = g.Invoke(&bindings.InvokeRequest{Operation: MutationOperation, Metadata: map[string]string{"mutation": "mutation { deleteUser(id: 1) }"}})
+	require.NoError(t, err)
+	assert.Equal(t, "mutation { deleteUser(id: 1) }", received.Query)
+	assert.Nil(t, received.Variables)
+
+	_, err = g.Invoke(&bindings.InvokeRequest{Operation: QueryOperation, Metadata: map[string]string{"query": "{ fail }"}})
+	assert.EqualError(t, err, "graphql binding error: first; second")
+}
+
+func TestInvokeInvalidRequests(t *testing.T) {
+	g := NewGraphQL(logger.NewLogger("test"))
+	require.NoError(t, g.Init(bindings.Metadata{Properties: map[string]string{"endpoint": "http://localhost"}}))
+
+	_, err := g.Invoke(&bindings.InvokeRequest{Operation: bindings.CreateOperation, Metadata: map[string]string{"query": "{ a }"}})
+	assert.Error(t, err)
+
+	_, err = g.Invoke(&bindings.InvokeRequest{Operation: MutationOperation, Metadata: map[string]string{"query": "{ a }"}})
+	assert.Error(t, err)
+
+	_, err = g.Invoke(&bindings.InvokeRequest{Operation: QueryOperation, Data: []byte(`[1]`), Metadata: map[string]string{"query": "{ a }"}})
+	assert.Error(t, err)
+}
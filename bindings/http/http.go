@@ -7,12 +7,19 @@ package http
 
 import (
 	"bytes"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
@@ -20,6 +27,7 @@ import (
 // nolint:golint
 type HTTPSource struct {
 	metadata httpMetadata
+	client   *http.Client
 
 	logger logger.Logger
 }
@@ -29,6 +37,18 @@ type httpMetadata struct {
 	Method string `json:"method"`
 }
 
+const (
+	// methodKey is the operation metadata that overrides the method of the component. The other operation metadata
+	// are sent as the headers of the request.
+	methodKey = "method"
+	// statusCodeKey is the response metadata with the status code of the response
+	statusCodeKey = "statusCode"
+
+	defaultMethod      = http.MethodPost
+	defaultTimeout     = 5 * time.Second
+	defaultContentType = "application/json; charset=utf-8"
+)
+
 // NewHTTP returns a new HTTPSource
 func NewHTTP(logger logger.Logger) *HTTPSource {
 	return &HTTPSource{logger: logger}
@@ -47,10 +67,80 @@ func (h *HTTPSource) Init(metadata bindings.Metadata) error {
 		return err
 	}
 
+	if m.Method == "" {
+		m.Method = defaultMethod
+	}
+
+	client, err := newClient(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
 	h.metadata = m
+	h.client = client
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the HTTP binding
+func (h *HTTPSource) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := metadata.FieldsOf(httpMetadata{Method: defaultMethod})
+	fields = append(fields,
+		metadata.Field{Name: "timeout", Type: metadata.TypeDuration, Default: defaultTimeout.String()},
+		metadata.Field{Name: "skipVerify", Type: metadata.TypeBool},
+		metadata.Field{Name: "caCert", Type: metadata.TypeString},
+		metadata.Field{Name: "clientCert", Type: metadata.TypeString},
+		metadata.Field{Name: "clientKey", Type: metadata.TypeString},
+	)
+	return metadata.ComponentMetadata{Fields: fields}
+}
+
+// newClient returns the client of the invocations, with the timeout and TLS settings of the metadata
+func newClient(properties map[string]string) (*http.Client, error) {
+	timeout := defaultTimeout
+	if val := properties["timeout"]; val != "" {
+		var err error
+		timeout, err = time.ParseDuration(val)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("http binding error: invalid value '%s' for 'timeout' attribute", val)
+		}
+	}
+
+	/* #nosec */
+	tlsConfig := &tls.Config{}
+	if val := properties["skipVerify"]; val != "" {
+		skipVerify, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, errors.New("http binding error: invalid value for 'skipVerify' attribute")
+		}
+		tlsConfig.InsecureSkipVerify = skipVerify
+	}
+
+	if val := properties["caCert"]; val != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(val)) {
+			return nil, errors.New("http binding error: 'caCert' attribute is not a valid PEM certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	clientCert, clientKey := properties["clientCert"], properties["clientKey"]
+	if (clientCert == "") != (clientKey == "") {
+		return nil, errors.New("http binding error: 'clientCert' and 'clientKey' attributes must be set together")
+	}
+	if clientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("http binding error: invalid 'clientCert' or 'clientKey' attribute: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
 func (h *HTTPSource) get(url string) ([]byte, error) {
 	client := http.Client{Timeout: time.Second * 60}
 	resp, err := client.Get(url)
@@ -85,14 +175,40 @@ func (h *HTTPSource) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
 
+// Invoke sends the data to the URL, with the method of the component or of the operation metadata, and returns the
+// body and the status code of the response
 func (h *HTTPSource) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	client := http.Client{Timeout: time.Second * 5}
-	resp, err := client.Post(h.metadata.URL, "application/json; charset=utf-8", bytes.NewBuffer(req.Data))
+	method := h.metadata.Method
+	if val := req.Metadata[methodKey]; val != "" {
+		method = strings.ToUpper(val)
+	}
+
+	httpReq, err := http.NewRequest(method, h.metadata.URL, bytes.NewBuffer(req.Data))
 	if err != nil {
 		return nil, err
 	}
-	if resp != nil && resp.Body != nil {
-		resp.Body.Close()
+	httpReq.Header.Set("Content-Type", defaultContentType)
+	for k, v := range req.Metadata {
+		if k != methodKey {
+			httpReq.Header.Set(k, v)
+		}
 	}
-	return nil, nil
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bindings.InvokeResponse{
+		Data: b,
+		Metadata: map[string]string{
+			statusCodeKey: strconv.Itoa(resp.StatusCode),
+		},
+	}, nil
 }
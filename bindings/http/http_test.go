@@ -6,7 +6,11 @@
 package http
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/dapr/pkg/logger"
@@ -22,3 +26,46 @@ func TestInit(t *testing.T) {
 	assert.Equal(t, "a", hs.metadata.URL)
 	assert.Equal(t, "a", hs.metadata.Method)
 }
+
+func TestInitClient(t *testing.T) {
+	m := bindings.Metadata{Properties: map[string]string{"url": "a", "timeout": "10s", "skipVerify": "true"}}
+	hs := HTTPSource{logger: logger.NewLogger("test")}
+	assert.NoError(t, hs.Init(m))
+	assert.Equal(t, http.MethodPost, hs.metadata.Method)
+	assert.Equal(t, 10*time.Second, hs.client.Timeout)
+	assert.True(t, hs.client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+
+	for _, properties := range []map[string]string{
+		{"url": "a", "timeout": "ten"},
+		{"url": "a", "skipVerify": "maybe"},
+		{"url": "a", "caCert": "not a certificate"},
+		{"url": "a", "clientCert": "cert"},
+	} {
+		assert.Error(t, hs.Init(bindings.Metadata{Properties: properties}))
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		if r.Header.Get("X-Fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		w.Write([]byte(r.Method + " " + string(body)))
+	}))
+	defer server.Close()
+
+	hs := HTTPSource{logger: logger.NewLogger("test")}
+	assert.NoError(t, hs.Init(bindings.Metadata{Properties: map[string]string{"url": server.URL}}))
+
+	resp, err := hs.Invoke(&bindings.InvokeRequest{Data: []byte("data")})
+	assert.NoError(t, err)
+	assert.Equal(t, "POST data", string(resp.Data))
+	assert.Equal(t, "200", resp.Metadata["statusCode"])
+
+	resp, err = hs.Invoke(&bindings.InvokeRequest{Data: []byte("data"), Metadata: map[string]string{"method": "put", "X-Fail": "true"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT data", string(resp.Data))
+	assert.Equal(t, "500", resp.Metadata["statusCode"])
+}
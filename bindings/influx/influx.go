@@ -0,0 +1,310 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package influx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// Component metadata, org, bucket and measurement can also be set in the request metadata
+	urlKey         = "url"
+	tokenKey       = "token"
+	orgKey         = "org"
+	bucketKey      = "bucket"
+	measurementKey = "measurement"
+	precisionKey   = "precision"
+	timeoutKey     = "timeout"
+
+	defaultPrecision = "ns"
+	defaultTimeout   = 10 * time.Second
+	writePath        = "/api/v2/write"
+)
+
+// precisions are the timestamp precisions accepted by InfluxDB
+var precisions = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+}
+
+// Influx allows writing points to an InfluxDB bucket
+type Influx struct {
+	metadata influxMetadata
+	client   *http.Client
+	logger   logger.Logger
+}
+
+type influxMetadata struct {
+	url         string
+	token       string
+	org         string
+	bucket      string
+	measurement string
+	precision   string
+	timeout     time.Duration
+}
+
+// point is a point written with the JSON format. The measurement defaults to the one in metadata, and the time to
+// the time the point is written at.
+type point struct {
+	Measurement string                 `json:"measurement"`
+	Tags        map[string]string      `json:"tags"`
+	Fields      map[string]interface{} `json:"fields"`
+	Time        *time.Time             `json:"time"`
+}
+
+// NewInflux returns a new InfluxDB binding instance
+func NewInflux(logger logger.Logger) *Influx {
+	return &Influx{logger: logger}
+}
+
+// Init does metadata parsing
+func (i *Influx) Init(metadata bindings.Metadata) error {
+	m, err := parseMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	i.metadata = m
+	i.client = &http.Client{Timeout: m.timeout}
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the InfluxDB binding
+func (i *Influx) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: urlKey, Type: metadata.TypeString, Required: true},
+			{Name: tokenKey, Type: metadata.TypeString, Required: true},
+			{Name: orgKey, Type: metadata.TypeString, Required: true},
+			{Name: bucketKey, Type: metadata.TypeString, Required: true},
+			{Name: measurementKey, Type: metadata.TypeString},
+			{Name: precisionKey, Type: metadata.TypeString, Default: defaultPrecision},
+			{Name: timeoutKey, Type: metadata.TypeDuration, Default: defaultTimeout.String()},
+		},
+	}
+}
+
+func parseMetadata(metadata bindings.Metadata) (influxMetadata, error) {
+	m := influxMetadata{
+		url:         strings.TrimSuffix(metadata.Properties[urlKey], "/"),
+		token:       metadata.Properties[tokenKey],
+		org:         metadata.Properties[orgKey],
+		bucket:      metadata.Properties[bucketKey],
+		measurement: metadata.Properties[measurementKey],
+		precision:   defaultPrecision,
+		timeout:     defaultTimeout,
+	}
+
+	if m.url == "" {
+		return m, errors.New("influx error: missing url")
+	}
+	if m.token == "" {
+		return m, errors.New("influx error: missing token")
+	}
+	if m.org == "" {
+		return m, errors.New("influx error: missing org")
+	}
+	if m.bucket == "" {
+		return m, errors.New("influx error: missing bucket")
+	}
+
+	if val := metadata.Properties[precisionKey]; val != "" {
+		if _, ok := precisions[val]; !ok {
+			return m, fmt.Errorf("influx error: invalid precision %s, accepted values are ns, us, ms and s", val)
+		}
+		m.precision = val
+	}
+
+	if val := metadata.Properties[timeoutKey]; val != "" {
+		timeout, err := time.ParseDuration(val)
+		if err != nil || timeout <= 0 {
+			return m, fmt.Errorf("influx error: invalid timeout %s", val)
+		}
+		m.timeout = timeout
+	}
+
+	return m, nil
+}
+
+func (i *Influx) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{bindings.CreateOperation}
+}
+
+// Invoke writes the points of the request to the bucket. The data is either line protocol, a JSON string of line
+// protocol, or one or an array of points in the JSON format.
+func (i *Influx) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	org := i.metadata.org
+	if val := req.Metadata[orgKey]; val != "" {
+		org = val
+	}
+	bucket := i.metadata.bucket
+	if val := req.Metadata[bucketKey]; val != "" {
+		bucket = val
+	}
+	measurement := i.metadata.measurement
+	if val := req.Metadata[measurementKey]; val != "" {
+		measurement = val
+	}
+
+	body, err := toLineProtocol(req.Data, measurement, precisions[i.metadata.precision])
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("org", org)
+	query.Set("bucket", bucket)
+	query.Set("precision", i.metadata.precision)
+
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s%s?%s", i.metadata.url, writePath, query.Encode()), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Token "+i.metadata.token)
+	httpReq.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := i.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("influx error: failed to write points: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("influx error: failed to write points, status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil, nil
+}
+
+// toLineProtocol returns the line protocol of the data, with one line per point
+func toLineProtocol(data []byte, measurement string, precision time.Duration) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("influx error: no points to write")
+	}
+
+	var points []point
+	switch trimmed[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return nil, fmt.Errorf("influx error: invalid data: %s", err)
+		}
+		return []byte(s), nil
+	case '{':
+		var p point
+		if err := json.Unmarshal(trimmed, &p); err != nil {
+			return nil, fmt.Errorf("influx error: invalid point: %s", err)
+		}
+		points = []point{p}
+	case '[':
+		if err := json.Unmarshal(trimmed, &points); err != nil {
+			return nil, fmt.Errorf("influx error: invalid points: %s", err)
+		}
+	default:
+		// The data is already line protocol
+		return data, nil
+	}
+
+	var b strings.Builder
+	for n, p := range points {
+		if n > 0 {
+			b.WriteByte('\n')
+		}
+		if err := writePoint(&b, p, measurement, precision); err != nil {
+			return nil, err
+		}
+	}
+
+	return []byte(b.String()), nil
+}
+
+func writePoint(b *strings.Builder, p point, measurement string, precision time.Duration) error {
+	if p.Measurement == "" {
+		p.Measurement = measurement
+	}
+	if p.Measurement == "" {
+		return errors.New("influx error: missing measurement")
+	}
+	if len(p.Fields) == 0 {
+		return fmt.Errorf("influx error: point of measurement %s has no fields", p.Measurement)
+	}
+
+	b.WriteString(measurementEscaper.Replace(p.Measurement))
+	for _, k := range sortedKeys(p.Tags) {
+		b.WriteByte(',')
+		b.WriteString(keyEscaper.Replace(k))
+		b.WriteByte('=')
+		b.WriteString(keyEscaper.Replace(p.Tags[k]))
+	}
+
+	fieldKeys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		fieldKeys = append(fieldKeys, k)
+	}
+	sort.Strings(fieldKeys)
+	for n, k := range fieldKeys {
+		if n == 0 {
+			b.WriteByte(' ')
+		} else {
+			b.WriteByte(',')
+		}
+		b.WriteString(keyEscaper.Replace(k))
+		b.WriteByte('=')
+		switch v := p.Fields[k].(type) {
+		case float64:
+			b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			b.WriteString(strconv.FormatBool(v))
+		case string:
+			b.WriteByte('"')
+			b.WriteString(stringEscaper.Replace(v))
+			b.WriteByte('"')
+		default:
+			return fmt.Errorf("influx error: invalid value of field %s, fields are numbers, booleans or strings", k)
+		}
+	}
+
+	if p.Time != nil {
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatInt(p.Time.UnixNano()/int64(precision), 10))
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	keyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	stringEscaper      = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
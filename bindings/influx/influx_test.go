@@ -0,0 +1,113 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package influx
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetadata(t *testing.T) {
+	m, err := parseMetadata(bindings.Metadata{Properties: map[string]string{"url": "http://localhost:8086/", "token": "t", "org": "o", "bucket": "b", "precision": "ms", "timeout": "1s"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8086", m.url)
+	assert.Equal(t, "ms", m.precision)
+	assert.Equal(t, time.Second, m.timeout)
+
+	for _, properties := range []map[string]string{
+		{"token": "t", "org": "o", "bucket": "b"},
+		{"url": "u", "org": "o", "bucket": "b"},
+		{"url": "u", "token": "t", "bucket": "b"},
+		{"url": "u", "token": "t", "org": "o"},
+		{"url": "u", "token": "t", "org": "o", "bucket": "b", "precision": "m"},
+		{"url": "u", "token": "t", "org": "o", "bucket": "b", "timeout": "soon"},
+	} {
+		_, err := parseMetadata(bindings.Metadata{Properties: properties})
+		assert.Error(t, err)
+	}
+}
+
+func TestToLineProtocol(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "Line protocol",
+			data:     "cpu,host=a usage=1",
+			expected: "cpu,host=a usage=1",
+		},
+		{
+			name:     "JSON string",
+			data:     `"cpu,host=a usage=1"`,
+			expected: "cpu,host=a usage=1",
+		},
+		{
+			name:     "Point with default measurement",
+			data:     `{"tags": {"room": "living room", "floor": "1"}, "fields": {"temp": 21.5, "on": true, "name": "say \"hi\""}, "time": "2020-01-01T00:00:01Z"}`,
+			expected: `home,floor=1,room=living\ room name="say \"hi\"",on=true,temp=21.5 1577836801000`,
+		},
+		{
+			name:     "Points",
+			data:     `[{"measurement": "a,b", "fields": {"v": 1}}, {"fields": {"v": 2}}]`,
+			expected: "a\\,b v=1\nhome v=2",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := toLineProtocol([]byte(tt.data), "home", time.Millisecond)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(b))
+		})
+	}
+
+	for _, data := range []string{``, `{"fields": {"v": 1}}`, `{"measurement": "m"}`, `{"measurement": "m", "fields": {"v": [1]}}`, `{"fields": `} {
+		_, err := toLineProtocol([]byte(data), "", time.Nanosecond)
+		assert.Error(t, err)
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	var req *http.Request
+	var body []byte
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+		if status != http.StatusNoContent {
+			w.Write([]byte(`{"code": "unauthorized", "message": "unauthorized access"}`))
+		}
+	}))
+	defer server.Close()
+
+	i := NewInflux(logger.NewLogger("test"))
+	require.NoError(t, i.Init(bindings.Metadata{Properties: map[string]string{"url": server.URL, "token": "secret", "org": "o", "bucket": "b", "measurement": "cpu"}}))
+
+	_, err := i.Invoke(&bindings.InvokeRequest{Data: []byte(`{"fields": {"usage": 1}}`), Metadata: map[string]string{"bucket": "other"}})
+	require.NoError(t, err)
+	assert.Equal(t, writePath, req.URL.Path)
+	assert.Equal(t, "o", req.URL.Query().Get("org"))
+	assert.Equal(t, "other", req.URL.Query().Get("bucket"))
+	assert.Equal(t, "ns", req.URL.Query().Get("precision"))
+	assert.Equal(t, "Token secret", req.Header.Get("Authorization"))
+	assert.Equal(t, "cpu usage=1", string(body))
+
+	status = http.StatusUnauthorized
+	_, err = i.Invoke(&bindings.InvokeRequest{Data: []byte("cpu usage=1")})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unauthorized access")
+}
@@ -7,22 +7,29 @@ package kafka
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
-	"sync"
 	"syscall"
 
 	"github.com/Shopify/sarama"
+	kafkaauth "github.com/dapr/components-contrib/authentication/kafka"
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
 const (
 	key = "partitionKey"
+	// topicKey is the request metadata with the topic to publish to instead of publishTopic, and the metadata of the
+	// read messages with their topic
+	topicKey = "topic"
+
+	initialOffsetNewest = "newest"
+	initialOffsetOldest = "oldest"
 )
 
 // Kafka allows reading/writing to a Kafka consumer group
@@ -33,8 +40,7 @@ type Kafka struct {
 	brokers       []string
 	publishTopic  string
 	authRequired  bool
-	saslUsername  string
-	saslPassword  string
+	metadata      *kafkaMetadata
 	logger        logger.Logger
 }
 
@@ -46,18 +52,38 @@ type kafkaMetadata struct {
 	AuthRequired  bool     `json:"authRequired"`
 	SaslUsername  string   `json:"saslUsername"`
 	SaslPassword  string   `json:"saslPassword"`
+	SaslMechanism string   `json:"saslMechanism"`
+	CACert        string   `json:"caCert"`
+	ClientCert    string   `json:"clientCert"`
+	ClientKey     string   `json:"clientKey"`
+	SkipVerify    bool     `json:"skipVerify"`
+	InitialOffset int64    `json:"initialOffset"`
+
+	// ClientCertFile and ClientKeyFile are loaded again when they change, so that rotated certificates are used
+	ClientCertFile string `json:"clientCertFile"`
+	ClientKeyFile  string `json:"clientKeyFile"`
+	// OAuth are the client credentials of the OAUTHBEARER SASL mechanism
+	OAuth kafkaauth.OAuthOptions `json:"-"`
+	// RetryPolicy is how reading is retried when the brokers cannot be reached
+	RetryPolicy bindings.RetryPolicy `json:"-"`
 }
 
 type consumer struct {
-	ready    chan bool
+	// ready is called once a session of the consumer group is set up
+	ready    func()
 	callback func(*bindings.ReadResponse) error
 }
 
 func (consumer *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for message := range claim.Messages() {
 		if consumer.callback != nil {
+			metadata := map[string]string{topicKey: message.Topic}
+			if len(message.Key) > 0 {
+				metadata[key] = string(message.Key)
+			}
 			err := consumer.callback(&bindings.ReadResponse{
-				Data: message.Value,
+				Data:     message.Value,
+				Metadata: metadata,
 			})
 			if err == nil {
 				session.MarkMessage(message, "")
@@ -68,7 +94,9 @@ func (consumer *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 }
 
 func (consumer *consumer) Setup(sarama.ConsumerGroupSession) error {
-	close(consumer.ready)
+	if consumer.ready != nil {
+		consumer.ready()
+	}
 	return nil
 }
 
@@ -95,22 +123,49 @@ func (k *Kafka) Init(metadata bindings.Metadata) error {
 	k.publishTopic = meta.PublishTopic
 	k.consumerGroup = meta.ConsumerGroup
 	k.authRequired = meta.AuthRequired
+	k.metadata = meta
 
-	//ignore SASL properties if authRequired is false
-	if meta.AuthRequired {
-		k.saslUsername = meta.SaslUsername
-		k.saslPassword = meta.SaslPassword
-	}
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Kafka binding
+func (k *Kafka) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := []metadata.Field{
+		{Name: "brokers", Type: metadata.TypeList, Required: true},
+		{Name: "topics", Type: metadata.TypeList},
+		{Name: "publishTopic", Type: metadata.TypeString},
+		{Name: "consumerGroup", Type: metadata.TypeString},
+		{Name: "authRequired", Type: metadata.TypeBool, Required: true},
+		{Name: "saslMechanism", Type: metadata.TypeString, Default: sarama.SASLTypePlaintext},
+		{Name: "saslUsername", Type: metadata.TypeString},
+		{Name: "saslPassword", Type: metadata.TypeString},
+		{Name: "initialOffset", Type: metadata.TypeString, Default: initialOffsetNewest},
+		{Name: "caCert", Type: metadata.TypeString},
+		{Name: "clientCert", Type: metadata.TypeString},
+		{Name: "clientKey", Type: metadata.TypeString},
+		{Name: "skipVerify", Type: metadata.TypeBool},
+	}
+	fields = append(fields, kafkaauth.MetadataFields()...)
+	fields = append(fields, bindings.RetryPolicyMetadataFields()...)
+	return metadata.ComponentMetadata{Fields: fields}
+}
+
 func (k *Kafka) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
 
+// Invoke publishes the data to the topic of the request metadata, or to publishTopic
 func (k *Kafka) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	topic := k.publishTopic
+	if val := req.Metadata[topicKey]; val != "" {
+		topic = val
+	}
+	if topic == "" {
+		return nil, errors.New("kafka error: missing 'publishTopic' attribute or 'topic' metadata")
+	}
+
 	msg := &sarama.ProducerMessage{
-		Topic: k.publishTopic,
+		Topic: topic,
 		Value: sarama.ByteEncoder(req.Data),
 	}
 	if val, ok := req.Metadata[key]; ok && val != "" {
@@ -133,6 +188,8 @@ func (k *Kafka) getKafkaMetadata(metadata bindings.Metadata) (*kafkaMetadata, er
 
 	if val, ok := metadata.Properties["brokers"]; ok && val != "" {
 		meta.Brokers = strings.Split(val, ",")
+	} else {
+		return nil, errors.New("kafka error: missing 'brokers' attribute")
 	}
 	if val, ok := metadata.Properties["topics"]; ok && val != "" {
 		meta.Topics = strings.Split(val, ",")
@@ -154,18 +211,74 @@ func (k *Kafka) getKafkaMetadata(metadata bindings.Metadata) (*kafkaMetadata, er
 
 	//ignore SASL properties if authRequired is false
 	if meta.AuthRequired {
-		if val, ok := metadata.Properties["saslUsername"]; ok && val != "" {
-			meta.SaslUsername = val
-		} else {
-			return nil, errors.New("kafka error: missing SASL Username")
+		meta.SaslMechanism = sarama.SASLTypePlaintext
+		if val, ok := metadata.Properties["saslMechanism"]; ok && val != "" {
+			switch strings.ToUpper(val) {
+			case sarama.SASLTypePlaintext, sarama.SASLTypeOAuth:
+				meta.SaslMechanism = strings.ToUpper(val)
+			default:
+				return nil, fmt.Errorf("kafka error: invalid value '%s' for 'saslMechanism' attribute, supported values are: %s, %s", val, sarama.SASLTypePlaintext, sarama.SASLTypeOAuth)
+			}
 		}
 
-		if val, ok := metadata.Properties["saslPassword"]; ok && val != "" {
-			meta.SaslPassword = val
+		// OAUTHBEARER tokens are requested with client credentials instead of a username and password
+		if meta.SaslMechanism == sarama.SASLTypeOAuth {
+			meta.OAuth, err = kafkaauth.ParseOAuthOptions(metadata.Properties)
+			if err != nil {
+				return nil, err
+			}
 		} else {
-			return nil, errors.New("kafka error: missing SASL Password")
+			if val, ok := metadata.Properties["saslUsername"]; ok && val != "" {
+				meta.SaslUsername = val
+			} else {
+				return nil, errors.New("kafka error: missing SASL Username")
+			}
+
+			if val, ok := metadata.Properties["saslPassword"]; ok && val != "" {
+				meta.SaslPassword = val
+			} else {
+				return nil, errors.New("kafka error: missing SASL Password")
+			}
 		}
 	}
+
+	meta.InitialOffset = sarama.OffsetNewest
+	if val, ok := metadata.Properties["initialOffset"]; ok && val != "" {
+		switch strings.ToLower(val) {
+		case initialOffsetNewest:
+		case initialOffsetOldest:
+			meta.InitialOffset = sarama.OffsetOldest
+		default:
+			return nil, fmt.Errorf("kafka error: invalid value '%s' for 'initialOffset' attribute, supported values are: %s, %s", val, initialOffsetNewest, initialOffsetOldest)
+		}
+	}
+
+	meta.CACert = metadata.Properties["caCert"]
+	meta.ClientCert = metadata.Properties["clientCert"]
+	meta.ClientKey = metadata.Properties["clientKey"]
+	if (meta.ClientCert == "") != (meta.ClientKey == "") {
+		return nil, errors.New("kafka error: 'clientCert' and 'clientKey' attributes must be set together")
+	}
+	meta.ClientCertFile, meta.ClientKeyFile, err = kafkaauth.ParseClientCertFiles(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+	if meta.ClientCert != "" && meta.ClientCertFile != "" {
+		return nil, errors.New("kafka error: only one of the 'clientCert' and 'clientCertFile' attributes can be set")
+	}
+
+	if val, ok := metadata.Properties["skipVerify"]; ok && val != "" {
+		meta.SkipVerify, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, errors.New("kafka error: invalid value for 'skipVerify' attribute")
+		}
+	}
+
+	meta.RetryPolicy, err = bindings.ParseRetryPolicy(metadata.Properties)
+	if err != nil {
+		return nil, fmt.Errorf("kafka error: %s", err)
+	}
+
 	return &meta, nil
 }
 
@@ -176,9 +289,8 @@ func (k *Kafka) getSyncProducer(meta *kafkaMetadata) (sarama.SyncProducer, error
 	config.Producer.Return.Successes = true
 	config.Version = sarama.V1_0_0_0
 
-	//ignore SASL properties if authRequired is false
-	if meta.AuthRequired {
-		updateAuthInfo(config, meta.SaslUsername, meta.SaslPassword)
+	if err := updateAuthInfo(config, meta); err != nil {
+		return nil, err
 	}
 
 	producer, err := sarama.NewSyncProducer(meta.Brokers, config)
@@ -188,66 +300,97 @@ func (k *Kafka) getSyncProducer(meta *kafkaMetadata) (sarama.SyncProducer, error
 	return producer, nil
 }
 
+// Read consumes the topics with the consumer group, and commits the offsets of the messages that were handled
 func (k *Kafka) Read(handler func(*bindings.ReadResponse) error) error {
-	config := sarama.NewConfig()
-	config.Version = sarama.V1_0_0_0
-	//ignore SASL properties if authRequired is false
-	if k.authRequired {
-		updateAuthInfo(config, k.saslUsername, k.saslPassword)
-	}
-	c := consumer{
-		callback: handler,
-		ready:    make(chan bool),
+	if len(k.topics) == 0 || k.consumerGroup == "" {
+		return errors.New("kafka error: 'topics' and 'consumerGroup' attributes are required to read")
 	}
 
-	client, err := sarama.NewConsumerGroup(k.brokers, k.consumerGroup, config)
-	if err != nil {
+	config := sarama.NewConfig()
+	config.Version = sarama.V1_0_0_0
+	config.Consumer.Offsets.Initial = k.metadata.InitialOffset
+	if err := updateAuthInfo(config, k.metadata); err != nil {
 		return err
 	}
-
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	wg := &sync.WaitGroup{}
-	wg.Add(1)
 	go func() {
-		defer wg.Done()
+		sigterm := make(chan os.Signal, 1)
+		signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+		<-sigterm
+		cancel()
+	}()
+
+	// The consumer group is created, and consumed, with the retry policy so that brokers that cannot be reached for
+	// a while do not stop reading
+	var client sarama.ConsumerGroup
+	err := k.metadata.RetryPolicy.Retry(ctx, k.logger, func(reset func()) error {
+		if client == nil {
+			var err error
+			client, err = sarama.NewConsumerGroup(k.brokers, k.consumerGroup, config)
+			if err != nil {
+				client = nil
+				return fmt.Errorf("kafka error: failed to create the consumer group: %s", err)
+			}
+		}
+
+		c := consumer{
+			callback: handler,
+			ready:    reset,
+		}
 		for {
-			if err = client.Consume(ctx, k.topics, &c); err != nil {
-				k.logger.Errorf("error from c: %s", err)
+			// Consume returns at the end of every session, e.g. when the group rebalances
+			if err := client.Consume(ctx, k.topics, &c); err != nil {
+				return fmt.Errorf("kafka error: failed to consume: %s", err)
 			}
-			// check if context was cancelled, signaling that the c should stop
 			if ctx.Err() != nil {
-				return
+				return nil
 			}
-			c.ready = make(chan bool)
 		}
-	}()
-
-	<-c.ready
+	})
 
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	<-sigterm
-	cancel()
-	wg.Wait()
-	if err = client.Close(); err != nil {
-		return err
+	if client != nil {
+		if closeErr := client.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
 	}
-	return nil
+	return err
 }
 
 func (consumer *consumer) Cleanup(sarama.ConsumerGroupSession) error {
 	return nil
 }
-func updateAuthInfo(config *sarama.Config, saslUsername, saslPassword string) {
-	config.Net.SASL.Enable = true
-	config.Net.SASL.User = saslUsername
-	config.Net.SASL.Password = saslPassword
-	config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
 
-	config.Net.TLS.Enable = true
-	config.Net.TLS.Config = &tls.Config{
-		//InsecureSkipVerify: true,
-		ClientAuth: 0,
+// updateAuthInfo enables SASL and TLS when authRequired is true, and TLS alone when a client certificate is set
+func updateAuthInfo(config *sarama.Config, meta *kafkaMetadata) error {
+	if meta.AuthRequired {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(meta.SaslMechanism)
+		if meta.SaslMechanism == sarama.SASLTypeOAuth {
+			config.Net.SASL.TokenProvider = kafkaauth.NewOAuthBearerTokenProvider(meta.OAuth)
+		} else {
+			config.Net.SASL.User = meta.SaslUsername
+			config.Net.SASL.Password = meta.SaslPassword
+		}
+	} else if meta.ClientCert == "" && meta.ClientCertFile == "" {
+		return nil
 	}
+
+	tlsConfig, err := kafkaauth.NewTLSConfig(kafkaauth.TLSOptions{
+		CACert:         meta.CACert,
+		ClientCert:     meta.ClientCert,
+		ClientKey:      meta.ClientKey,
+		ClientCertFile: meta.ClientCertFile,
+		ClientKeyFile:  meta.ClientKeyFile,
+		SkipVerify:     meta.SkipVerify,
+	})
+	if err != nil {
+		return err
+	}
+
+	config.Net.TLS.Enable = true
+	config.Net.TLS.Config = tlsConfig
+
+	return nil
 }
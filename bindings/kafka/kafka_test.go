@@ -9,6 +9,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/Shopify/sarama"
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
@@ -242,3 +243,141 @@ func TestParseMetadata(t *testing.T) {
 		assert.Nil(t, meta)
 	})
 }
+
+func TestUpdateAuthInfo(t *testing.T) {
+	k := Kafka{logger: logger.NewLogger("test")}
+
+	t.Run("SASL PLAIN with TLS by default", func(t *testing.T) {
+		m := bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "true", "saslUsername": "foo", "saslPassword": "bar"}}
+		meta, err := k.getKafkaMetadata(m)
+		assert.NoError(t, err)
+
+		config := sarama.NewConfig()
+		assert.NoError(t, updateAuthInfo(config, meta))
+		assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypePlaintext), config.Net.SASL.Mechanism)
+		assert.Equal(t, "foo", config.Net.SASL.User)
+		assert.True(t, config.Net.TLS.Enable)
+	})
+
+	t.Run("SASL OAUTHBEARER", func(t *testing.T) {
+		m := bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "true", "saslMechanism": "OAUTHBEARER"}}
+		_, err := k.getKafkaMetadata(m)
+		assert.Error(t, err)
+
+		m.Properties["oidcTokenEndpoint"] = "https://login.example.com/token"
+		m.Properties["oidcClientID"] = "client"
+		m.Properties["oidcClientSecret"] = "secret"
+		meta, err := k.getKafkaMetadata(m)
+		assert.NoError(t, err)
+
+		config := sarama.NewConfig()
+		assert.NoError(t, updateAuthInfo(config, meta))
+		assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+		assert.NotNil(t, config.Net.SASL.TokenProvider)
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("invalid SASL mechanism", func(t *testing.T) {
+		m := bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "true", "saslMechanism": "GSSAPI"}}
+		_, err := k.getKafkaMetadata(m)
+		assert.Error(t, err)
+	})
+
+	t.Run("no authentication", func(t *testing.T) {
+		m := bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false"}}
+		meta, err := k.getKafkaMetadata(m)
+		assert.NoError(t, err)
+
+		config := sarama.NewConfig()
+		assert.NoError(t, updateAuthInfo(config, meta))
+		assert.False(t, config.Net.SASL.Enable)
+		assert.False(t, config.Net.TLS.Enable)
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		m := bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false", "clientCert": "cert"}}
+		_, err := k.getKafkaMetadata(m)
+		assert.Error(t, err)
+
+		m.Properties["clientKey"] = "key"
+		meta, err := k.getKafkaMetadata(m)
+		assert.NoError(t, err)
+		assert.Error(t, updateAuthInfo(sarama.NewConfig(), meta))
+
+		delete(m.Properties, "clientCert")
+		delete(m.Properties, "clientKey")
+		m.Properties["clientCertFile"] = "cert.pem"
+		_, err = k.getKafkaMetadata(m)
+		assert.Error(t, err)
+	})
+}
+
+// fakeSyncProducer keeps the messages it sends
+type fakeSyncProducer struct {
+	sent []*sarama.ProducerMessage
+}
+
+func (p *fakeSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.sent = append(p.sent, msg)
+	return 0, 0, nil
+}
+
+func (p *fakeSyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	p.sent = append(p.sent, msgs...)
+	return nil
+}
+
+func (p *fakeSyncProducer) Close() error {
+	return nil
+}
+
+func TestInvoke(t *testing.T) {
+	producer := &fakeSyncProducer{}
+	k := Kafka{logger: logger.NewLogger("test"), producer: producer, publishTopic: "orders"}
+
+	_, err := k.Invoke(&bindings.InvokeRequest{Data: []byte("a"), Metadata: map[string]string{"partitionKey": "key"}})
+	assert.NoError(t, err)
+	_, err = k.Invoke(&bindings.InvokeRequest{Data: []byte("b"), Metadata: map[string]string{"topic": "payments"}})
+	assert.NoError(t, err)
+
+	assert.Len(t, producer.sent, 2)
+	assert.Equal(t, "orders", producer.sent[0].Topic)
+	assert.Equal(t, sarama.StringEncoder("key"), producer.sent[0].Key)
+	assert.Equal(t, "payments", producer.sent[1].Topic)
+
+	k.publishTopic = ""
+	_, err = k.Invoke(&bindings.InvokeRequest{Data: []byte("c")})
+	assert.Error(t, err)
+}
+
+func TestMetadataOptions(t *testing.T) {
+	k := Kafka{logger: logger.NewLogger("test")}
+
+	meta, err := k.getKafkaMetadata(bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false"}})
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.OffsetNewest, meta.InitialOffset)
+
+	meta, err = k.getKafkaMetadata(bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false", "initialOffset": "oldest"}})
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.OffsetOldest, meta.InitialOffset)
+
+	_, err = k.getKafkaMetadata(bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false", "initialOffset": "latest"}})
+	assert.Error(t, err)
+
+	_, err = k.getKafkaMetadata(bindings.Metadata{Properties: map[string]string{"authRequired": "false"}})
+	assert.Error(t, err)
+
+	meta, err = k.getKafkaMetadata(bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false", "backOffPolicy": "exponential", "backOffMaxRetries": "3"}})
+	assert.NoError(t, err)
+	assert.Equal(t, bindings.ExponentialBackOff, meta.RetryPolicy.BackOff)
+	assert.Equal(t, 3, meta.RetryPolicy.MaxRetries)
+
+	_, err = k.getKafkaMetadata(bindings.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false", "backOffPolicy": "linear"}})
+	assert.Error(t, err)
+}
+
+func TestReadRequiresTopicsAndConsumerGroup(t *testing.T) {
+	k := Kafka{logger: logger.NewLogger("test"), topics: []string{"orders"}}
+
+	assert.Error(t, k.Read(func(*bindings.ReadResponse) error { return nil }))
+}
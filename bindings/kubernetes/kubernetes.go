@@ -6,10 +6,16 @@
 package kubernetes
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/kubernetes-client/go/kubernetes/client"
 	"github.com/kubernetes-client/go/kubernetes/config"
@@ -20,6 +26,10 @@ type kubernetesInput struct {
 	client    *client.APIClient
 	namespace string
 	logger    logger.Logger
+
+	// The selectors filter the events that are watched, e.g. "involvedObject.kind=Pod" and "app=myapp"
+	fieldSelector string
+	labelSelector string
 }
 
 var _ = bindings.InputBinding(&kubernetesInput{})
@@ -39,6 +49,17 @@ func (k *kubernetesInput) Init(metadata bindings.Metadata) error {
 	return k.parseMetadata(metadata)
 }
 
+// GetComponentMetadata returns the metadata fields of the Kubernetes events binding
+func (k *kubernetesInput) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: "namespace", Type: metadata.TypeString, Default: "default"},
+			{Name: "fieldSelector", Type: metadata.TypeString},
+			{Name: "labelSelector", Type: metadata.TypeString},
+		},
+	}
+}
+
 func (k *kubernetesInput) parseMetadata(metadata bindings.Metadata) error {
 	ns, found := metadata.Properties["namespace"]
 	if found {
@@ -47,39 +68,78 @@ func (k *kubernetesInput) parseMetadata(metadata bindings.Metadata) error {
 		k.namespace = "default"
 	}
 
+	k.fieldSelector = metadata.Properties["fieldSelector"]
+	k.labelSelector = metadata.Properties["labelSelector"]
+
 	return nil
 }
 
 func (k *kubernetesInput) Read(handler func(*bindings.ReadResponse) error) error {
-	watch := client.WatchClient{
-		Cfg:     k.config,
-		Client:  k.client,
-		Path:    "/api/v1/namespaces/" + k.namespace + "/events",
-		MakerFn: func() interface{} { return &client.V1Event{} },
-	}
-
-	resultChan, errChan, err := watch.Connect(context.Background(), "")
+	res, err := k.watch(context.Background())
 	if err != nil {
 		return err
 	}
-	done := false
-	for !done {
-		select {
-		case obj, ok := <-resultChan:
-			if !ok {
-				done = true
-				break
-			}
-			data, err := json.Marshal(obj)
-			if err != nil {
-				return err
-			}
-			handler(&bindings.ReadResponse{
-				Data: data,
-			})
-		case err := <-errChan:
+	defer res.Body.Close()
+
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		// Only the first JSON value of a line is decoded, like the watch client of the Kubernetes library does
+		result := client.Result{Object: &client.V1Event{}}
+		if err := json.NewDecoder(bytes.NewReader(scanner.Bytes())).Decode(&result); err != nil {
+			return err
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
 			return err
 		}
+		err = handler(&bindings.ReadResponse{
+			Data: data,
+		})
+		if err != nil {
+			k.logger.Errorf("error handling kubernetes event: %s", err)
+		}
 	}
-	return nil
+	return scanner.Err()
+}
+
+// watch starts watching the events of the namespace that match the selectors
+func (k *kubernetesInput) watch(ctx context.Context) (*http.Response, error) {
+	query := url.Values{}
+	query.Set("watch", "true")
+	if k.fieldSelector != "" {
+		query.Set("fieldSelector", k.fieldSelector)
+	}
+	if k.labelSelector != "" {
+		query.Set("labelSelector", k.labelSelector)
+	}
+
+	u := fmt.Sprintf("%s://%s/api/v1/namespaces/%s/events?%s", k.config.Scheme, k.config.Host, url.PathEscape(k.namespace), query.Encode())
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for name, value := range k.config.DefaultHeader {
+		req.Header.Set(name, value)
+	}
+	if k.config.UserAgent != "" {
+		req.Header.Set("User-Agent", k.config.UserAgent)
+	}
+
+	httpClient := k.config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("error connecting watch (%d: %s)", res.StatusCode, res.Status)
+	}
+	return res, nil
 }
@@ -75,3 +75,43 @@ func TestReadItem(t *testing.T) {
 
 	assert.Equal(t, 1, count, "Expected 1 item, saw %v\n", count)
 }
+
+func TestReadWithSelectors(t *testing.T) {
+	handler := &staticHandler{
+		Code: 200,
+		Body: `{"type":"ADDED","object":{"kind":"Event","apiVersion":"v1","metadata":{"name":"pod.1","namespace":"fooNamespace"},"involvedObject":{"kind":"Pod","name":"pod"},"reason":"Started"}}` + "\n" +
+			`{"type":"MODIFIED","object":{"kind":"Event","apiVersion":"v1","metadata":{"name":"pod.1","namespace":"fooNamespace"},"involvedObject":{"kind":"Pod","name":"pod"},"reason":"Killing"}}` + "\n",
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if !assert.NoError(t, err, "URL Parsing failed!") {
+		t.FailNow()
+	}
+
+	cfg := &client.Configuration{}
+	cfg.Host = u.Host
+	cfg.Scheme = u.Scheme
+
+	i := &kubernetesInput{
+		config: cfg,
+		client: client.NewAPIClient(cfg),
+		logger: logger.NewLogger("test"),
+	}
+	i.parseMetadata(bindings.Metadata{Properties: map[string]string{"namespace": "fooNamespace", "fieldSelector": "involvedObject.kind=Pod", "labelSelector": "app=foo"}})
+
+	var reasons []string
+	err = i.Read(func(res *bindings.ReadResponse) error {
+		result := client.Result{Object: &client.V1Event{}}
+		assert.NoError(t, json.Unmarshal(res.Data, &result))
+		reasons = append(reasons, result.Object.(*client.V1Event).Reason)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Started", "Killing"}, reasons)
+	assert.Equal(t, "true", handler.QueryParams.Get("watch"))
+	assert.Equal(t, "involvedObject.kind=Pod", handler.QueryParams.Get("fieldSelector"))
+	assert.Equal(t, "app=foo", handler.QueryParams.Get("labelSelector"))
+}
@@ -0,0 +1,96 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/metrics"
+)
+
+// MetricsInputBinding records the count, latency and errors of the initialization of an input binding, and of the
+// handling of the events it reads
+type MetricsInputBinding struct {
+	binding   InputBinding
+	component string
+	recorder  metrics.Recorder
+}
+
+// NewMetricsInputBinding wraps binding so that its operations are recorded with the name of the component
+func NewMetricsInputBinding(binding InputBinding, component string, recorder metrics.Recorder) InputBinding {
+	return &MetricsInputBinding{binding: binding, component: component, recorder: recorder}
+}
+
+// Init initializes the wrapped binding
+func (b *MetricsInputBinding) Init(metadata Metadata) error {
+	return metrics.Measure(b.recorder, b.component, "init", func() error {
+		return b.binding.Init(metadata)
+	})
+}
+
+// GetComponentMetadata returns the metadata of the wrapped binding
+func (b *MetricsInputBinding) GetComponentMetadata() metadata.ComponentMetadata {
+	return b.binding.GetComponentMetadata()
+}
+
+// Ping pings the wrapped binding
+func (b *MetricsInputBinding) Ping() error {
+	return health.Ping(b.binding)
+}
+
+// Read reads the events of the wrapped binding, the handling of each event being recorded as a read operation
+func (b *MetricsInputBinding) Read(handler func(*ReadResponse) error) error {
+	return b.binding.Read(func(resp *ReadResponse) error {
+		return metrics.Measure(b.recorder, b.component, "read", func() error {
+			return handler(resp)
+		})
+	})
+}
+
+// MetricsOutputBinding records the count, latency and errors of the operations of an output binding
+type MetricsOutputBinding struct {
+	binding   OutputBinding
+	component string
+	recorder  metrics.Recorder
+}
+
+// NewMetricsOutputBinding wraps binding so that its operations are recorded with the name of the component.
+// The invocations are recorded with the name of their operation, e.g. create.
+func NewMetricsOutputBinding(binding OutputBinding, component string, recorder metrics.Recorder) OutputBinding {
+	return &MetricsOutputBinding{binding: binding, component: component, recorder: recorder}
+}
+
+// Init initializes the wrapped binding
+func (b *MetricsOutputBinding) Init(metadata Metadata) error {
+	return metrics.Measure(b.recorder, b.component, "init", func() error {
+		return b.binding.Init(metadata)
+	})
+}
+
+// Invoke invokes the operation of the request
+func (b *MetricsOutputBinding) Invoke(req *InvokeRequest) (*InvokeResponse, error) {
+	var resp *InvokeResponse
+	err := metrics.Measure(b.recorder, b.component, string(req.Operation), func() (err error) {
+		resp, err = b.binding.Invoke(req)
+		return err
+	})
+	return resp, err
+}
+
+// Operations returns the operations of the wrapped binding
+func (b *MetricsOutputBinding) Operations() []OperationKind {
+	return b.binding.Operations()
+}
+
+// GetComponentMetadata returns the metadata of the wrapped binding
+func (b *MetricsOutputBinding) GetComponentMetadata() metadata.ComponentMetadata {
+	return b.binding.GetComponentMetadata()
+}
+
+// Ping pings the wrapped binding
+func (b *MetricsOutputBinding) Ping() error {
+	return health.Ping(b.binding)
+}
@@ -0,0 +1,67 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeInputBinding struct {
+	events []string
+}
+
+func (f *fakeInputBinding) Init(metadata Metadata) error {
+	return errors.New("init error")
+}
+
+func (f *fakeInputBinding) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+func (f *fakeInputBinding) Read(handler func(*ReadResponse) error) error {
+	for _, e := range f.events {
+		handler(&ReadResponse{Data: []byte(e)})
+	}
+	return nil
+}
+
+func TestMetricsInputBinding(t *testing.T) {
+	c := metrics.NewCollector()
+	b := NewMetricsInputBinding(&fakeInputBinding{events: []string{"a", "fail", "b"}}, "queue", c)
+
+	assert.Error(t, b.Init(Metadata{}))
+	assert.NoError(t, b.Read(func(resp *ReadResponse) error {
+		if string(resp.Data) == "fail" {
+			return errors.New("handler error")
+		}
+		return nil
+	}))
+
+	stats := c.Stats()
+	assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "queue", Operation: "init"}].Errors)
+	read := stats[metrics.OperationKey{Component: "queue", Operation: "read"}]
+	assert.Equal(t, int64(3), read.Count)
+	assert.Equal(t, int64(1), read.Errors)
+}
+
+func TestMetricsOutputBinding(t *testing.T) {
+	c := metrics.NewCollector()
+	b := NewMetricsOutputBinding(&fakeOutputBinding{}, "http", c)
+
+	assert.NoError(t, b.Init(Metadata{}))
+	_, err := b.Invoke(&InvokeRequest{Operation: CreateOperation})
+	assert.NoError(t, err)
+	assert.Equal(t, []OperationKind{CreateOperation, QueryOperation}, b.Operations())
+
+	stats := c.Stats()
+	assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "http", Operation: "init"}].Count)
+	assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "http", Operation: string(CreateOperation)}].Count)
+}
@@ -6,6 +6,7 @@
 package mqtt
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
@@ -28,17 +30,34 @@ type MQTT struct {
 	client   mqtt.Client
 
 	logger logger.Logger
+
+	retryPolicy bindings.RetryPolicy
+	// connectionLost is signaled when the connection to the broker is lost, which drops the subscription
+	connectionLost chan struct{}
 }
 
 // Metadata is the MQTT config
 type mqttMetadata struct {
-	URL   string `json:"url"`
-	Topic string `json:"topic"`
+	URL   string `json:"url" metadata:",required"`
+	Topic string `json:"topic" metadata:",required"`
+
+	// QoS and Retain apply to the messages published and, for QoS, to the subscription of the topic
+	QoS    byte `json:"qos,string"`
+	Retain bool `json:"retain,string"`
+
+	// The last will is published by the broker when the binding disconnects unexpectedly
+	LastWillTopic   string `json:"lastWillTopic"`
+	LastWillMessage string `json:"lastWillMessage"`
+	LastWillQoS     byte   `json:"lastWillQos,string"`
+	LastWillRetain  bool   `json:"lastWillRetain,string"`
 }
 
+// maxQoS is the highest quality of service, exactly once delivery
+const maxQoS = 2
+
 // NewMQTT returns a new MQTT instance
 func NewMQTT(logger logger.Logger) *MQTT {
-	return &MQTT{logger: logger}
+	return &MQTT{logger: logger, connectionLost: make(chan struct{}, 1)}
 }
 
 // Init does MQTT connection parsing
@@ -57,6 +76,11 @@ func (m *MQTT) Init(metadata bindings.Metadata) error {
 		return errors.New("MQTT error: topic required")
 	}
 
+	m.retryPolicy, err = bindings.ParseRetryPolicy(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
 	uri, err := url.Parse(m.metadata.URL)
 	if err != nil {
 		return err
@@ -69,6 +93,20 @@ func (m *MQTT) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the MQTT binding
+func (m *MQTT) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := metadata.FieldsOf(mqttMetadata{})
+	return metadata.ComponentMetadata{Fields: append(fields, bindings.RetryPolicyMetadataFields()...)}
+}
+
+// Ping returns an error when the connection to the broker is not open, for health checks
+func (m *MQTT) Ping() error {
+	if m.client == nil || !m.client.IsConnectionOpen() {
+		return errors.New("MQTT error: not connected to the broker")
+	}
+	return nil
+}
+
 func (m *MQTT) getMQTTMetadata(metadata bindings.Metadata) (*mqttMetadata, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
@@ -80,6 +118,16 @@ func (m *MQTT) getMQTTMetadata(metadata bindings.Metadata) (*mqttMetadata, error
 	if err != nil {
 		return nil, err
 	}
+
+	if mMetadata.QoS > maxQoS {
+		return nil, fmt.Errorf("MQTT error: invalid qos %d, accepted values are 0, 1 and 2", mMetadata.QoS)
+	}
+	if mMetadata.LastWillQoS > maxQoS {
+		return nil, fmt.Errorf("MQTT error: invalid lastWillQos %d, accepted values are 0, 1 and 2", mMetadata.LastWillQoS)
+	}
+	if mMetadata.LastWillTopic == "" && mMetadata.LastWillMessage != "" {
+		return nil, errors.New("MQTT error: lastWillTopic required with lastWillMessage")
+	}
 	return &mMetadata, nil
 }
 
@@ -88,21 +136,60 @@ func (m *MQTT) Operations() []bindings.OperationKind {
 }
 
 func (m *MQTT) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	m.client.Publish(m.metadata.Topic, 0, false, string(req.Data))
-	m.client.Disconnect(0)
+	token := m.client.Publish(m.metadata.Topic, m.metadata.QoS, m.metadata.Retain, req.Data)
+	if token.Wait() && token.Error() != nil {
+		return nil, fmt.Errorf("MQTT error: failed to publish to topic %s: %s", m.metadata.Topic, token.Error())
+	}
 	return nil, nil
 }
 
+// Read subscribes to the topic until the process is terminated. The client reconnects by itself when the connection
+// is lost, and the topic is subscribed to again with the retry policy of the metadata.
 func (m *MQTT) Read(handler func(*bindings.ReadResponse) error) error {
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		<-c
+		cancel()
+	}()
+
+	return m.read(ctx, handler)
+}
 
-	m.client.Subscribe(m.metadata.Topic, 0, func(client mqtt.Client, msg mqtt.Message) {
-		handler(&bindings.ReadResponse{
+func (m *MQTT) read(ctx context.Context, handler func(*bindings.ReadResponse) error) error {
+	err := m.retryPolicy.Retry(ctx, m.logger, func(reset func()) error {
+		err := m.subscribe(handler)
+		if err != nil {
+			return err
+		}
+		reset()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-m.connectionLost:
+			return fmt.Errorf("MQTT error: connection lost, subscribing to topic %s again", m.metadata.Topic)
+		}
+	})
+	m.client.Disconnect(0)
+	return err
+}
+
+func (m *MQTT) subscribe(handler func(*bindings.ReadResponse) error) error {
+	token := m.client.Subscribe(m.metadata.Topic, m.metadata.QoS, func(client mqtt.Client, msg mqtt.Message) {
+		err := handler(&bindings.ReadResponse{
 			Data: msg.Payload(),
 		})
+		if err != nil {
+			m.logger.Errorf("MQTT error handling message from topic %s: %s", msg.Topic(), err)
+		}
 	})
-	<-c
+	if token.Wait() && token.Error() != nil {
+		return fmt.Errorf("MQTT error: failed to subscribe to topic %s: %s", m.metadata.Topic, token.Error())
+	}
 	return nil
 }
 
@@ -125,5 +212,15 @@ func (m *MQTT) createClientOptions(clientID string, uri *url.URL) *mqtt.ClientOp
 	password, _ := uri.User.Password()
 	opts.SetPassword(password)
 	opts.SetClientID(clientID)
+	opts.SetConnectionLostHandler(func(client mqtt.Client, err error) {
+		m.logger.Warnf("MQTT connection lost: %s", err)
+		select {
+		case m.connectionLost <- struct{}{}:
+		default:
+		}
+	})
+	if m.metadata.LastWillTopic != "" {
+		opts.SetWill(m.metadata.LastWillTopic, m.metadata.LastWillMessage, m.metadata.LastWillQoS, m.metadata.LastWillRetain)
+	}
 	return opts
 }
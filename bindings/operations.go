@@ -0,0 +1,52 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnsupportedOperationError is the error of an invocation with an operation that the binding does not support
+type UnsupportedOperationError struct {
+	Operation OperationKind
+	Supported []OperationKind
+}
+
+// NewUnsupportedOperationError returns the error of an invocation with an operation that is not one of supported
+func NewUnsupportedOperationError(operation OperationKind, supported []OperationKind) *UnsupportedOperationError {
+	return &UnsupportedOperationError{Operation: operation, Supported: supported}
+}
+
+func (e *UnsupportedOperationError) Error() string {
+	supported := make([]string, 0, len(e.Supported))
+	for _, o := range e.Supported {
+		supported = append(supported, string(o))
+	}
+
+	return fmt.Sprintf("unsupported operation '%s', supported operations are: %s", e.Operation, strings.Join(supported, ", "))
+}
+
+// SupportsOperation returns whether the operation is one of the operations of the binding
+func SupportsOperation(binding OutputBinding, operation OperationKind) bool {
+	for _, o := range binding.Operations() {
+		if o == operation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidateOperation returns an UnsupportedOperationError when the operation of the request is not one of the
+// operations of the binding
+func ValidateOperation(binding OutputBinding, req *InvokeRequest) error {
+	if !SupportsOperation(binding, req.Operation) {
+		return NewUnsupportedOperationError(req.Operation, binding.Operations())
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOutputBinding struct{}
+
+func (f *fakeOutputBinding) Init(metadata Metadata) error {
+	return nil
+}
+
+func (f *fakeOutputBinding) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+func (f *fakeOutputBinding) Invoke(req *InvokeRequest) (*InvokeResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeOutputBinding) Operations() []OperationKind {
+	return []OperationKind{CreateOperation, QueryOperation}
+}
+
+func TestValidateOperation(t *testing.T) {
+	b := &fakeOutputBinding{}
+
+	assert.True(t, SupportsOperation(b, QueryOperation))
+	assert.False(t, SupportsOperation(b, DeleteOperation))
+	assert.NoError(t, ValidateOperation(b, &InvokeRequest{Operation: CreateOperation}))
+
+	err := ValidateOperation(b, &InvokeRequest{Operation: DeleteOperation})
+	var unsupported *UnsupportedOperationError
+	assert.True(t, errors.As(err, &unsupported))
+	assert.Equal(t, DeleteOperation, unsupported.Operation)
+	assert.Equal(t, []OperationKind{CreateOperation, QueryOperation}, unsupported.Supported)
+	assert.Equal(t, "unsupported operation 'delete', supported operations are: create, query", err.Error())
+}
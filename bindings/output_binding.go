@@ -5,9 +5,12 @@
 
 package bindings
 
+import "github.com/dapr/components-contrib/metadata"
+
 // OutputBinding is the interface for an output binding, allowing users to invoke remote systems with optional payloads
 type OutputBinding interface {
 	Init(metadata Metadata) error
 	Invoke(req *InvokeRequest) (*InvokeResponse, error)
 	Operations() []OperationKind
+	GetComponentMetadata() metadata.ComponentMetadata
 }
@@ -0,0 +1,200 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state/postgresql"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// List of operations
+	execOperation  bindings.OperationKind = bindings.ExecOperation
+	queryOperation bindings.OperationKind = bindings.QueryOperation
+	closeOperation bindings.OperationKind = "close"
+
+	// sqlKey is the request metadata with the statement, whose parameters are the JSON array of paramsKey
+	sqlKey    = "sql"
+	paramsKey = "params"
+
+	// Response metadata
+	operationKey    = "operation"
+	startTimeKey    = "start-time"
+	endTimeKey      = "end-time"
+	durationKey     = "duration"
+	rowsAffectedKey = "rows-affected"
+)
+
+var errClosed = errors.New("postgres binding error: the connection was closed")
+
+// Postgres is an output binding that runs SQL statements in PostgreSQL. It connects with the metadata of the
+// PostgreSQL state store.
+type Postgres struct {
+	conn   *postgresql.Conn
+	closed bool
+	lock   sync.RWMutex
+	logger logger.Logger
+}
+
+// NewPostgres returns a new PostgreSQL output binding
+func NewPostgres(logger logger.Logger) *Postgres {
+	return &Postgres{logger: logger}
+}
+
+// Init connects to PostgreSQL
+func (p *Postgres) Init(metadata bindings.Metadata) error {
+	conn, err := postgresql.Connect(p.logger, metadata.Properties)
+	if err != nil {
+		return fmt.Errorf("postgres binding error: %s", err)
+	}
+
+	p.conn = conn
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the PostgreSQL binding, which are those of the connection of
+// the PostgreSQL state store
+func (p *Postgres) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: postgresql.ConnectionMetadataFields()}
+}
+
+// Ping checks that the database can be reached, for health checks
+func (p *Postgres) Ping() error {
+	if p.conn == nil {
+		return fmt.Errorf("the PostgreSQL binding is not initialized")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.conn.Timeout())
+	defer cancel()
+	return p.conn.Ping(ctx)
+}
+
+// Operations returns the supported operations of the binding
+func (p *Postgres) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{execOperation, queryOperation, closeOperation}
+}
+
+// Invoke runs the statement of the sql metadata with an exec or query operation, or closes the connection with a close
+// operation
+func (p *Postgres) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	if req.Operation == closeOperation {
+		return nil, p.Close()
+	}
+
+	if req.Operation != execOperation && req.Operation != queryOperation {
+		return nil, bindings.NewUnsupportedOperationError(req.Operation, p.Operations())
+	}
+
+	sql := req.Metadata[sqlKey]
+	if sql == "" {
+		return nil, fmt.Errorf("postgres binding error: missing '%s' metadata", sqlKey)
+	}
+
+	params, err := parseParams(req.Metadata[paramsKey])
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.closed {
+		return nil, errClosed
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.conn.Timeout())
+	defer cancel()
+
+	start := time.Now()
+	resp := &bindings.InvokeResponse{Metadata: map[string]string{operationKey: string(req.Operation)}}
+	if req.Operation == execOperation {
+		var rowsAffected int64
+		rowsAffected, err = p.conn.Exec(ctx, sql, params...)
+		resp.Metadata[rowsAffectedKey] = strconv.FormatInt(rowsAffected, 10)
+	} else {
+		resp.Data, err = p.query(ctx, sql, params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("postgres binding error: %s failed: %s", req.Operation, err)
+	}
+
+	end := time.Now()
+	resp.Metadata[startTimeKey] = start.Format(time.RFC3339Nano)
+	resp.Metadata[endTimeKey] = end.Format(time.RFC3339Nano)
+	resp.Metadata[durationKey] = end.Sub(start).String()
+
+	return resp, nil
+}
+
+// query returns the rows of the query as a JSON array of objects with the columns of the rows
+func (p *Postgres) query(ctx context.Context, sql string, params []interface{}) ([]byte, error) {
+	rows, err := p.conn.Query(ctx, sql, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result, err := rowsToMaps(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(result)
+}
+
+func rowsToMaps(rows pgx.Rows) ([]map[string]interface{}, error) {
+	fields := rows.FieldDescriptions()
+	result := []map[string]interface{}{}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(fields))
+		for i, field := range fields {
+			row[string(field.Name)] = values[i]
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// parseParams returns the parameters of the JSON array, or none without
+func parseParams(val string) ([]interface{}, error) {
+	if val == "" {
+		return nil, nil
+	}
+
+	var params []interface{}
+	if err := json.Unmarshal([]byte(val), &params); err != nil {
+		return nil, fmt.Errorf("postgres binding error: '%s' metadata must be a JSON array: %s", paramsKey, err)
+	}
+
+	return params, nil
+}
+
+// Close closes the connection, once the statements in progress are done
+func (p *Postgres) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed || p.conn == nil {
+		return nil
+	}
+	p.closed = true
+
+	return p.conn.Close()
+}
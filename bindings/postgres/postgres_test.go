@@ -0,0 +1,82 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgres
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	connectionStringEnvKey = "DAPR_TEST_POSTGRES_CONNSTRING" // Environment variable containing the connection string
+)
+
+func TestInitMissingConnectionString(t *testing.T) {
+	p := NewPostgres(logger.NewLogger("test"))
+	assert.Error(t, p.Init(bindings.Metadata{Properties: map[string]string{}}))
+}
+
+func TestInvokeInvalidRequests(t *testing.T) {
+	p := NewPostgres(logger.NewLogger("test"))
+
+	_, err := p.Invoke(&bindings.InvokeRequest{Operation: bindings.CreateOperation, Metadata: map[string]string{sqlKey: "SELECT 1"}})
+	assert.Error(t, err)
+
+	_, err = p.Invoke(&bindings.InvokeRequest{Operation: execOperation})
+	assert.Error(t, err)
+
+	_, err = p.Invoke(&bindings.InvokeRequest{Operation: queryOperation, Metadata: map[string]string{sqlKey: "SELECT $1", paramsKey: "1"}})
+	assert.Error(t, err)
+
+	p.closed = true
+	_, err = p.Invoke(&bindings.InvokeRequest{Operation: queryOperation, Metadata: map[string]string{sqlKey: "SELECT 1"}})
+	assert.Equal(t, errClosed, err)
+}
+
+func TestParseParams(t *testing.T) {
+	params, err := parseParams("")
+	assert.NoError(t, err)
+	assert.Nil(t, params)
+
+	params, err = parseParams(`[1, "a", true]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{float64(1), "a", true}, params)
+}
+
+func TestPostgresIntegration(t *testing.T) {
+	connectionString := os.Getenv(connectionStringEnvKey)
+	if connectionString == "" {
+		t.Skipf("PostgreSQL binding integration tests skipped. To enable define the connection string using environment variable '%s'", connectionStringEnvKey)
+	}
+
+	p := NewPostgres(logger.NewLogger("test"))
+	require.NoError(t, p.Init(bindings.Metadata{Properties: map[string]string{"connectionString": connectionString}}))
+	defer p.Close()
+
+	exec := func(sql string, params string) *bindings.InvokeResponse {
+		resp, err := p.Invoke(&bindings.InvokeRequest{Operation: execOperation, Metadata: map[string]string{sqlKey: sql, paramsKey: params}})
+		require.NoError(t, err)
+		return resp
+	}
+
+	exec("CREATE TABLE IF NOT EXISTS dapr_binding_test (id INT PRIMARY KEY, name TEXT)", "")
+	defer exec("DROP TABLE dapr_binding_test", "")
+
+	resp := exec("INSERT INTO dapr_binding_test (id, name) VALUES ($1, $2)", `[1, "first"]`)
+	assert.Equal(t, "1", resp.Metadata[rowsAffectedKey])
+
+	resp, err := p.Invoke(&bindings.InvokeRequest{Operation: queryOperation, Metadata: map[string]string{sqlKey: "SELECT id, name FROM dapr_binding_test WHERE id = $1", paramsKey: "[1]"}})
+	require.NoError(t, err)
+	var rows []map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Data, &rows))
+	assert.Equal(t, []map[string]interface{}{{"id": float64(1), "name": "first"}}, rows)
+}
@@ -6,11 +6,19 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
 	"strconv"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/streadway/amqp"
 )
@@ -26,6 +34,10 @@ type RabbitMQ struct {
 	metadata   rabbitMQMetadata
 	logger     logger.Logger
 	queue      amqp.Queue
+
+	// lock guards the connection and the channel, which are replaced when the connection is lost
+	lock        sync.Mutex
+	retryPolicy bindings.RetryPolicy
 }
 
 // Metadata is the rabbitmq config
@@ -34,6 +46,9 @@ type rabbitMQMetadata struct {
 	Host             string `json:"host"`
 	Durable          bool   `json:"durable,string"`
 	DeleteWhenUnused bool   `json:"deleteWhenUnused,string"`
+	Exclusive        bool   `json:"exclusive,string"`
+	PrefetchCount    int    `json:"prefetchCount,string"`
+	RequeueInFailure bool   `json:"requeueInFailure,string"`
 	defaultQueueTTL  *time.Duration
 }
 
@@ -49,27 +64,88 @@ func (r *RabbitMQ) Init(metadata bindings.Metadata) error {
 		return err
 	}
 
-	conn, err := amqp.Dial(r.metadata.Host)
+	r.retryPolicy, err = bindings.ParseRetryPolicy(metadata.Properties)
 	if err != nil {
 		return err
 	}
 
+	_, _, err = r.ensureChannel()
+	return err
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the RabbitMQ binding
+func (r *RabbitMQ) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := metadata.FieldsOf(rabbitMQMetadata{})
+	fields = append(fields, metadata.Field{Name: bindings.TTLMetadataKey, Type: metadata.TypeInt})
+	fields = append(fields, bindings.RetryPolicyMetadataFields()...)
+	return metadata.ComponentMetadata{
+		Fields:       fields,
+		Capabilities: []metadata.Capability{metadata.CapabilityTTL},
+	}
+}
+
+// Ping returns an error when the connection to the broker is closed, e.g. before it is reconnected, for health checks
+func (r *RabbitMQ) Ping() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.connection == nil || r.connection.IsClosed() {
+		return errors.New("rabbitmq error: not connected to the broker")
+	}
+	return nil
+}
+
+// ensureChannel returns the channel of the connection and the queue, connecting and declaring the queue again first
+// when the connection is closed
+func (r *RabbitMQ) ensureChannel() (*amqp.Channel, amqp.Queue, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.connection != nil && !r.connection.IsClosed() {
+		return r.channel, r.queue, nil
+	}
+
+	conn, err := amqp.Dial(r.metadata.Host)
+	if err != nil {
+		return nil, amqp.Queue{}, err
+	}
+
 	ch, err := conn.Channel()
 	if err != nil {
-		return err
+		conn.Close()
+		return nil, amqp.Queue{}, err
 	}
 
-	r.connection = conn
-	r.channel = ch
+	// The prefetch count limits the unacknowledged messages delivered to the input binding, 0 for no limit
+	if r.metadata.PrefetchCount > 0 {
+		err = ch.Qos(r.metadata.PrefetchCount, 0, false)
+		if err != nil {
+			conn.Close()
+			return nil, amqp.Queue{}, err
+		}
+	}
 
-	q, err := r.declareQueue()
+	q, err := r.declareQueue(ch)
 	if err != nil {
-		return err
+		conn.Close()
+		return nil, amqp.Queue{}, err
 	}
 
+	r.connection = conn
+	r.channel = ch
 	r.queue = q
 
-	return nil
+	return ch, q, nil
+}
+
+// closeConnection closes the connection so that the next channel is opened on a new connection
+func (r *RabbitMQ) closeConnection() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.connection != nil && !r.connection.IsClosed() {
+		r.connection.Close()
+	}
 }
 
 func (r *RabbitMQ) Operations() []bindings.OperationKind {
@@ -95,7 +171,12 @@ func (r *RabbitMQ) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse
 		pub.Expiration = strconv.FormatInt(ttl.Milliseconds(), 10)
 	}
 
-	err = r.channel.Publish("", r.metadata.QueueName, false, false, pub)
+	ch, _, err := r.ensureChannel()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ch.Publish("", r.metadata.QueueName, false, false, pub)
 
 	if err != nil {
 		return nil, err
@@ -125,11 +206,15 @@ func (r *RabbitMQ) parseMetadata(metadata bindings.Metadata) error {
 		m.defaultQueueTTL = &ttl
 	}
 
+	if m.PrefetchCount < 0 {
+		return fmt.Errorf("invalid RabbitMQ prefetch count %d", m.PrefetchCount)
+	}
+
 	r.metadata = m
 	return nil
 }
 
-func (r *RabbitMQ) declareQueue() (amqp.Queue, error) {
+func (r *RabbitMQ) declareQueue(ch *amqp.Channel) (amqp.Queue, error) {
 	args := amqp.Table{}
 	if r.metadata.defaultQueueTTL != nil {
 		// Value in ms
@@ -137,12 +222,39 @@ func (r *RabbitMQ) declareQueue() (amqp.Queue, error) {
 		args[rabbitMQQueueMessageTTLKey] = int(ttl)
 	}
 
-	return r.channel.QueueDeclare(r.metadata.QueueName, r.metadata.Durable, r.metadata.DeleteWhenUnused, false, false, args)
+	return ch.QueueDeclare(r.metadata.QueueName, r.metadata.Durable, r.metadata.DeleteWhenUnused, r.metadata.Exclusive, false, args)
 }
 
+// Read consumes the queue until the process is terminated. Reading starts over on a new connection, with the
+// retry policy of the metadata, when the connection is lost.
 func (r *RabbitMQ) Read(handler func(*bindings.ReadResponse) error) error {
-	msgs, err := r.channel.Consume(
-		r.queue.Name,
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		sigterm := make(chan os.Signal, 1)
+		signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+		<-sigterm
+		cancel()
+	}()
+
+	return r.retryPolicy.Retry(ctx, r.logger, func(reset func()) error {
+		err := r.consume(ctx, handler, reset)
+		if err != nil {
+			r.closeConnection()
+		}
+		return err
+	})
+}
+
+func (r *RabbitMQ) consume(ctx context.Context, handler func(*bindings.ReadResponse) error, reset func()) error {
+	ch, q, err := r.ensureChannel()
+	if err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(
+		q.Name,
 		"",
 		false,
 		false,
@@ -153,20 +265,37 @@ func (r *RabbitMQ) Read(handler func(*bindings.ReadResponse) error) error {
 	if err != nil {
 		return err
 	}
-
-	forever := make(chan bool)
-
-	go func() {
-		for d := range msgs {
-			err := handler(&bindings.ReadResponse{
-				Data: d.Body,
-			})
-			if err == nil {
-				r.channel.Ack(d.DeliveryTag, false)
+	reset()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d, ok := <-msgs:
+			if !ok {
+				return errors.New("rabbitmq error: the consumer of the queue was closed")
 			}
+			r.handleMessage(d, handler)
 		}
-	}()
+	}
+}
 
-	<-forever
-	return nil
+// handleMessage acknowledges the delivery when the handler succeeds. Failed deliveries are requeued once when
+// requeueInFailure is set, and discarded otherwise.
+func (r *RabbitMQ) handleMessage(d amqp.Delivery, handler func(*bindings.ReadResponse) error) {
+	err := handler(&bindings.ReadResponse{
+		Data: d.Body,
+	})
+	if err == nil {
+		if err = d.Ack(false); err != nil {
+			r.logger.Errorf("error acking message from queue '%s': %s", r.metadata.QueueName, err)
+		}
+		return
+	}
+
+	requeue := r.metadata.RequeueInFailure && !d.Redelivered
+	r.logger.Errorf("error handling message from queue '%s', requeue=%t: %s", r.metadata.QueueName, requeue, err)
+	if err = d.Nack(false, requeue); err != nil {
+		r.logger.Errorf("error nacking message from queue '%s': %s", r.metadata.QueueName, err)
+	}
 }
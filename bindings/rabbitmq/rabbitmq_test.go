@@ -6,11 +6,13 @@
 package rabbitmq
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/dapr/pkg/logger"
+	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -101,3 +103,83 @@ func TestParseMetadataWithInvalidTTL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMetadataQueueOptions(t *testing.T) {
+	r := RabbitMQ{logger: logger.NewLogger("test")}
+	err := r.parseMetadata(bindings.Metadata{Properties: map[string]string{"queueName": "test-queue", "host": "test-host", "exclusive": "true", "prefetchCount": "10", "requeueInFailure": "true"}})
+	assert.Nil(t, err)
+	assert.True(t, r.metadata.Exclusive)
+	assert.Equal(t, 10, r.metadata.PrefetchCount)
+	assert.True(t, r.metadata.RequeueInFailure)
+
+	err = r.parseMetadata(bindings.Metadata{Properties: map[string]string{"queueName": "test-queue", "host": "test-host", "prefetchCount": "-1"}})
+	assert.NotNil(t, err)
+}
+
+// fakeAcknowledger records the acknowledgements of deliveries
+type fakeAcknowledger struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked = true
+	a.requeue = requeue
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func TestHandleMessage(t *testing.T) {
+	failingHandler := func(*bindings.ReadResponse) error { return errors.New("failed") }
+
+	testCases := []struct {
+		name             string
+		requeueInFailure bool
+		redelivered      bool
+		handler          func(*bindings.ReadResponse) error
+		expectedAcked    bool
+		expectedRequeue  bool
+	}{
+		{
+			name:          "Success",
+			handler:       func(*bindings.ReadResponse) error { return nil },
+			expectedAcked: true,
+		},
+		{
+			name:    "Failure without requeue",
+			handler: failingHandler,
+		},
+		{
+			name:             "Failure with requeue",
+			requeueInFailure: true,
+			handler:          failingHandler,
+			expectedRequeue:  true,
+		},
+		{
+			name:             "Failure of a redelivered message",
+			requeueInFailure: true,
+			redelivered:      true,
+			handler:          failingHandler,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RabbitMQ{logger: logger.NewLogger("test"), metadata: rabbitMQMetadata{RequeueInFailure: tt.requeueInFailure}}
+			a := &fakeAcknowledger{}
+			r.handleMessage(amqp.Delivery{Acknowledger: a, Redelivered: tt.redelivered, Body: []byte("test")}, tt.handler)
+			assert.Equal(t, tt.expectedAcked, a.acked)
+			assert.Equal(t, !tt.expectedAcked, a.nacked)
+			assert.Equal(t, tt.expectedRequeue, a.requeue)
+		})
+	}
+}
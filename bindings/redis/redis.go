@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 
 	redis "github.com/go-redis/redis/v7"
@@ -75,6 +76,28 @@ func (r *Redis) Init(meta bindings.Metadata) error {
 	return err
 }
 
+// GetComponentMetadata returns the metadata fields of the Redis binding
+func (r *Redis) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: host, Type: componentmetadata.TypeString, Required: true},
+			{Name: password, Type: componentmetadata.TypeString},
+			{Name: enableTLS, Type: componentmetadata.TypeBool},
+			{Name: maxRetries, Type: componentmetadata.TypeInt, Default: strconv.Itoa(defaultMaxRetries)},
+			// maxRetryBackoff is in nanoseconds
+			{Name: maxRetryBackoff, Type: componentmetadata.TypeInt, Default: strconv.FormatInt(int64(defaultMaxRetryBackoff), 10)},
+		},
+	}
+}
+
+// Ping pings the Redis server, for health checks
+func (r *Redis) Ping() error {
+	if r.client == nil {
+		return fmt.Errorf("the Redis binding is not initialized")
+	}
+	return r.client.Ping().Err()
+}
+
 func (r *Redis) parseMetadata(meta bindings.Metadata) (metadata, error) {
 	m := metadata{}
 
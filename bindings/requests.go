@@ -21,4 +21,6 @@ const (
 	CreateOperation OperationKind = "create"
 	DeleteOperation OperationKind = "delete"
 	ListOperation   OperationKind = "list"
+	ExecOperation   OperationKind = "exec"
+	QueryOperation  OperationKind = "query"
 )
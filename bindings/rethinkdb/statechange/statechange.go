@@ -0,0 +1,110 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package statechange
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/rethinkdb"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// Response metadata
+	keyKey       = "key"
+	etagKey      = "etag"
+	operationKey = "operation"
+
+	insertOperation = "insert"
+	updateOperation = "update"
+	deleteOperation = "delete"
+)
+
+// changeSource is the changefeed of the state store table
+type changeSource interface {
+	Changes(ctx context.Context, handler func(*rethinkdb.StateChange) error) error
+	Close() error
+}
+
+// Binding is an input binding delivering the changes of a RethinkDB state store table
+type Binding struct {
+	store  changeSource
+	logger logger.Logger
+}
+
+var _ = bindings.InputBinding(&Binding{})
+
+// NewRethinkDBStateChangeBinding returns a new RethinkDB state change input binding
+func NewRethinkDBStateChangeBinding(logger logger.Logger) *Binding {
+	return &Binding{logger: logger}
+}
+
+// Init connects to the table with the same metadata as the RethinkDB state store
+func (b *Binding) Init(metadata bindings.Metadata) error {
+	store := rethinkdb.NewRethinkDBStateStore(b.logger)
+	err := store.Init(state.Metadata{Properties: metadata.Properties})
+	if err != nil {
+		return err
+	}
+
+	b.store = store
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the RethinkDB state change binding, which are those of the
+// RethinkDB state store
+func (b *Binding) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: rethinkdb.NewRethinkDBStateStore(b.logger).GetComponentMetadata().Fields}
+}
+
+// Read delivers the changes of the table until the process is terminated. The data is the state of the key after
+// an insert or an update, and the metadata has the key, its ETag and the operation.
+func (b *Binding) Read(handler func(*bindings.ReadResponse) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		<-c
+		cancel()
+	}()
+
+	defer b.store.Close()
+	return b.read(ctx, handler)
+}
+
+func (b *Binding) read(ctx context.Context, handler func(*bindings.ReadResponse) error) error {
+	return b.store.Changes(ctx, func(change *rethinkdb.StateChange) error {
+		operation := updateOperation
+		switch {
+		case change.Deleted:
+			operation = deleteOperation
+		case change.Created:
+			operation = insertOperation
+		}
+
+		err := handler(&bindings.ReadResponse{
+			Data: change.Data,
+			Metadata: map[string]string{
+				keyKey:       change.Key,
+				etagKey:      change.ETag,
+				operationKey: operation,
+			},
+		})
+		if err != nil {
+			// A failed change does not stop the changefeed
+			b.logger.Errorf("error handling change of key %s: %s", change.Key, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package statechange
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/state/rethinkdb"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChangeSource struct {
+	changes []*rethinkdb.StateChange
+}
+
+func (f *fakeChangeSource) Changes(ctx context.Context, handler func(*rethinkdb.StateChange) error) error {
+	for _, change := range f.changes {
+		if err := handler(change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeChangeSource) Close() error {
+	return nil
+}
+
+func TestInitMissingMetadata(t *testing.T) {
+	b := NewRethinkDBStateChangeBinding(logger.NewLogger("test"))
+	assert.Error(t, b.Init(bindings.Metadata{Properties: map[string]string{"database": "dapr"}}))
+}
+
+func TestRead(t *testing.T) {
+	b := NewRethinkDBStateChangeBinding(logger.NewLogger("test"))
+	b.store = &fakeChangeSource{changes: []*rethinkdb.StateChange{
+		{Key: "a", Data: []byte(`"red"`), ETag: "1", Created: true},
+		{Key: "a", Data: []byte(`"blue"`), ETag: "2"},
+		{Key: "a", ETag: "2", Deleted: true},
+	}}
+
+	var responses []*bindings.ReadResponse
+	err := b.read(context.Background(), func(r *bindings.ReadResponse) error {
+		responses = append(responses, r)
+		// Handler errors do not stop the changefeed
+		return errors.New("failed")
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, responses, 3)
+	assert.Equal(t, `"red"`, string(responses[0].Data))
+	assert.Equal(t, map[string]string{"key": "a", "etag": "1", "operation": "insert"}, responses[0].Metadata)
+	assert.Equal(t, `"blue"`, string(responses[1].Data))
+	assert.Equal(t, "update", responses[1].Metadata["operation"])
+	assert.Empty(t, responses[2].Data)
+	assert.Equal(t, "delete", responses[2].Metadata["operation"])
+}
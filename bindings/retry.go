@@ -0,0 +1,171 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// BackOffPolicyMetadataKey is the metadata of how the waits between retries grow, constant or exponential
+	BackOffPolicyMetadataKey = "backOffPolicy"
+	// BackOffDurationMetadataKey is the metadata of the wait before the first retry, and before every retry of the
+	// constant policy
+	BackOffDurationMetadataKey = "backOffDuration"
+	// BackOffMaxDurationMetadataKey is the metadata of the longest wait of the exponential policy
+	BackOffMaxDurationMetadataKey = "backOffMaxDuration"
+	// BackOffMaxRetriesMetadataKey is the metadata of how many times in a row a failure is retried, -1 for no limit
+	BackOffMaxRetriesMetadataKey = "backOffMaxRetries"
+	// CircuitOpenDurationMetadataKey is the metadata of how long retrying pauses once the retries are exhausted,
+	// before they start over. Retrying stops with the last error when it is not set.
+	CircuitOpenDurationMetadataKey = "circuitOpenDuration"
+
+	// ConstantBackOff waits the back off duration before every retry
+	ConstantBackOff = "constant"
+	// ExponentialBackOff doubles the wait after every retry, up to the max duration
+	ExponentialBackOff = "exponential"
+
+	defaultBackOffDuration    = 5 * time.Second
+	defaultBackOffMaxDuration = time.Minute
+)
+
+// RetryPolicy is how input bindings retry reading, e.g. reconnecting to a broker after a transient outage
+type RetryPolicy struct {
+	BackOff             string
+	Duration            time.Duration
+	MaxDuration         time.Duration
+	MaxRetries          int
+	CircuitOpenDuration time.Duration
+}
+
+// NewRetryPolicy returns the default policy, which retries forever every 5 seconds
+func NewRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		BackOff:     ConstantBackOff,
+		Duration:    defaultBackOffDuration,
+		MaxDuration: defaultBackOffMaxDuration,
+		MaxRetries:  -1,
+	}
+}
+
+// ParseRetryPolicy returns the retry policy of the binding metadata, with the defaults of NewRetryPolicy
+func ParseRetryPolicy(properties map[string]string) (RetryPolicy, error) {
+	p := NewRetryPolicy()
+
+	if val := properties[BackOffPolicyMetadataKey]; val != "" {
+		if val != ConstantBackOff && val != ExponentialBackOff {
+			return p, fmt.Errorf("invalid %s '%s', accepted values are %s and %s", BackOffPolicyMetadataKey, val, ConstantBackOff, ExponentialBackOff)
+		}
+		p.BackOff = val
+	}
+
+	var err error
+	if p.Duration, err = parseRetryDuration(properties, BackOffDurationMetadataKey, p.Duration); err != nil {
+		return p, err
+	}
+	if p.MaxDuration, err = parseRetryDuration(properties, BackOffMaxDurationMetadataKey, p.MaxDuration); err != nil {
+		return p, err
+	}
+	if p.CircuitOpenDuration, err = parseRetryDuration(properties, CircuitOpenDurationMetadataKey, p.CircuitOpenDuration); err != nil {
+		return p, err
+	}
+	if p.MaxDuration < p.Duration {
+		p.MaxDuration = p.Duration
+	}
+
+	if val := properties[BackOffMaxRetriesMetadataKey]; val != "" {
+		p.MaxRetries, err = strconv.Atoi(val)
+		if err != nil || p.MaxRetries < -1 {
+			return p, fmt.Errorf("invalid %s '%s', must be -1 or more", BackOffMaxRetriesMetadataKey, val)
+		}
+	}
+
+	return p, nil
+}
+
+func parseRetryDuration(properties map[string]string, key string, defaultValue time.Duration) (time.Duration, error) {
+	val := properties[key]
+	if val == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s '%s', must be a positive duration", key, val)
+	}
+	return d, nil
+}
+
+// RetryPolicyMetadataFields returns the metadata fields read by ParseRetryPolicy
+func RetryPolicyMetadataFields() []metadata.Field {
+	return []metadata.Field{
+		{Name: BackOffPolicyMetadataKey, Type: metadata.TypeString, Default: ConstantBackOff},
+		{Name: BackOffDurationMetadataKey, Type: metadata.TypeDuration, Default: defaultBackOffDuration.String()},
+		{Name: BackOffMaxDurationMetadataKey, Type: metadata.TypeDuration, Default: defaultBackOffMaxDuration.String()},
+		{Name: BackOffMaxRetriesMetadataKey, Type: metadata.TypeInt, Default: "-1"},
+		{Name: CircuitOpenDurationMetadataKey, Type: metadata.TypeDuration},
+	}
+}
+
+// backOff returns the wait before the retry, starting at 1
+func (p RetryPolicy) backOff(retry int64) time.Duration {
+	if p.BackOff != ExponentialBackOff {
+		return p.Duration
+	}
+
+	d := p.Duration
+	for i := int64(1); i < retry && d < p.MaxDuration; i++ {
+		d *= 2
+	}
+	if d > p.MaxDuration {
+		d = p.MaxDuration
+	}
+	return d
+}
+
+// Retry calls operation until it returns nil or the context is done, waiting the back off of the policy after every
+// failure. The operation calls reset once it is ready, e.g. when it is connected, so that its next failure is retried
+// as a first failure. Retry returns the last error of the operation once the retries are exhausted, unless the
+// circuit open duration is set, and nil when the context is done.
+func (p RetryPolicy) Retry(ctx context.Context, logger logger.Logger, operation func(reset func()) error) error {
+	var retries int64
+	reset := func() {
+		atomic.StoreInt64(&retries, 0)
+	}
+
+	for {
+		err := operation(reset)
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+
+		retry := atomic.AddInt64(&retries, 1)
+		wait := p.backOff(retry)
+		if p.MaxRetries >= 0 && retry > int64(p.MaxRetries) {
+			if p.CircuitOpenDuration <= 0 {
+				return err
+			}
+			logger.Warnf("retries exhausted, pausing for %s: %s", p.CircuitOpenDuration, err)
+			reset()
+			wait = p.CircuitOpenDuration
+		} else {
+			logger.Warnf("retrying in %s: %s", wait, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
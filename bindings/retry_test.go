@@ -0,0 +1,125 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryPolicy(t *testing.T) {
+	p, err := ParseRetryPolicy(map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, NewRetryPolicy(), p)
+
+	p, err = ParseRetryPolicy(map[string]string{"backOffPolicy": "exponential", "backOffDuration": "1s", "backOffMaxDuration": "10s", "backOffMaxRetries": "3", "circuitOpenDuration": "1m"})
+	assert.NoError(t, err)
+	assert.Equal(t, RetryPolicy{BackOff: ExponentialBackOff, Duration: time.Second, MaxDuration: 10 * time.Second, MaxRetries: 3, CircuitOpenDuration: time.Minute}, p)
+
+	for _, properties := range []map[string]string{
+		{"backOffPolicy": "linear"},
+		{"backOffDuration": "0s"},
+		{"backOffMaxDuration": "soon"},
+		{"backOffMaxRetries": "-2"},
+		{"circuitOpenDuration": "-1m"},
+	} {
+		_, err := ParseRetryPolicy(properties)
+		assert.Error(t, err)
+	}
+}
+
+func TestBackOff(t *testing.T) {
+	p := RetryPolicy{BackOff: ConstantBackOff, Duration: time.Second, MaxDuration: 5 * time.Second}
+	assert.Equal(t, time.Second, p.backOff(1))
+	assert.Equal(t, time.Second, p.backOff(10))
+
+	p.BackOff = ExponentialBackOff
+	assert.Equal(t, time.Second, p.backOff(1))
+	assert.Equal(t, 2*time.Second, p.backOff(2))
+	assert.Equal(t, 4*time.Second, p.backOff(3))
+	assert.Equal(t, 5*time.Second, p.backOff(4))
+	assert.Equal(t, 5*time.Second, p.backOff(1000))
+}
+
+func TestRetry(t *testing.T) {
+	log := logger.NewLogger("test")
+	p := RetryPolicy{BackOff: ConstantBackOff, Duration: time.Millisecond, MaxDuration: time.Millisecond, MaxRetries: 2}
+	failure := errors.New("failed")
+
+	t.Run("Succeeds after failures", func(t *testing.T) {
+		calls := 0
+		err := p.Retry(context.Background(), log, func(reset func()) error {
+			calls++
+			if calls < 3 {
+				return failure
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Returns the last error once the retries are exhausted", func(t *testing.T) {
+		calls := 0
+		err := p.Retry(context.Background(), log, func(reset func()) error {
+			calls++
+			return failure
+		})
+		assert.Equal(t, failure, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Reset starts the retries over", func(t *testing.T) {
+		calls := 0
+		err := p.Retry(context.Background(), log, func(reset func()) error {
+			calls++
+			if calls%2 == 0 {
+				reset()
+			}
+			if calls < 10 {
+				return failure
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, calls)
+	})
+
+	t.Run("Pauses once the retries are exhausted", func(t *testing.T) {
+		p := p
+		p.CircuitOpenDuration = 10 * time.Millisecond
+		calls := 0
+		start := time.Now()
+		err := p.Retry(context.Background(), log, func(reset func()) error {
+			calls++
+			if calls < 5 {
+				return failure
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 5, calls)
+		assert.True(t, time.Since(start) >= p.CircuitOpenDuration)
+	})
+
+	t.Run("Stops when the context is done", func(t *testing.T) {
+		p := NewRetryPolicy()
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := p.Retry(ctx, log, func(reset func()) error {
+			calls++
+			cancel()
+			return failure
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
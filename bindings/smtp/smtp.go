@@ -0,0 +1,492 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package smtp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	// TLS modes of the connection to the SMTP server. auto upgrades the connection with STARTTLS when the server
+	// supports it, starttls requires the upgrade, and tls connects with TLS from the start.
+	tlsModeAuto     = "auto"
+	tlsModeStartTLS = "starttls"
+	tlsModeTLS      = "tls"
+	tlsModeNone     = "none"
+
+	defaultPort        = 25
+	defaultContentType = "text/html"
+	dialTimeout        = 30 * time.Second
+	// lineLength is the maximum length of the lines of the base64 encoded attachments
+	lineLength = 76
+)
+
+// Mail allows sending emails through an SMTP server
+type Mail struct {
+	metadata mailMetadata
+	logger   logger.Logger
+}
+
+// Our metadata holds the server settings and the standard email properties. The email properties can be set on a
+// per request basis.
+type mailMetadata struct {
+	Host          string
+	Port          int
+	User          string
+	Password      string
+	TLSMode       string
+	SkipTLSVerify bool
+	EmailFrom     string
+	EmailTo       string
+	EmailCc       string
+	EmailBcc      string
+	Subject       string
+	ContentType   string
+}
+
+// mailData is the data of a request with attachments. The data of the other requests is the body of the email.
+type mailData struct {
+	Body        string       `json:"body"`
+	Attachments []attachment `json:"attachments"`
+}
+
+// attachment is a file attached to an email, whose content is base64 encoded
+type attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Content     string `json:"content"`
+
+	data []byte
+}
+
+// NewMail returns a new SMTP binding instance
+func NewMail(logger logger.Logger) *Mail {
+	return &Mail{logger: logger}
+}
+
+// Helper to parse metadata
+func (m *Mail) parseMetadata(meta bindings.Metadata) (mailMetadata, error) {
+	mailMeta := mailMetadata{
+		Port:        defaultPort,
+		TLSMode:     tlsModeAuto,
+		ContentType: defaultContentType,
+	}
+
+	// Required properties
+	if val, ok := meta.Properties["host"]; ok && val != "" {
+		mailMeta.Host = val
+	} else {
+		return mailMeta, errors.New("smtp binding error: host field is required in metadata")
+	}
+
+	if val := meta.Properties["port"]; val != "" {
+		port, err := strconv.Atoi(val)
+		if err != nil || port <= 0 {
+			return mailMeta, fmt.Errorf("smtp binding error: invalid port '%s'", val)
+		}
+		mailMeta.Port = port
+	}
+
+	mailMeta.User = meta.Properties["user"]
+	mailMeta.Password = meta.Properties["password"]
+
+	if val := meta.Properties["tlsMode"]; val != "" {
+		switch strings.ToLower(val) {
+		case tlsModeAuto, tlsModeStartTLS, tlsModeTLS, tlsModeNone:
+			mailMeta.TLSMode = strings.ToLower(val)
+		default:
+			return mailMeta, fmt.Errorf("smtp binding error: invalid tlsMode '%s', supported values are: %s, %s, %s, %s", val, tlsModeAuto, tlsModeStartTLS, tlsModeTLS, tlsModeNone)
+		}
+	}
+
+	if val := meta.Properties["skipTLSVerify"]; val != "" {
+		skipTLSVerify, err := strconv.ParseBool(val)
+		if err != nil {
+			return mailMeta, fmt.Errorf("smtp binding error: invalid skipTLSVerify '%s'", val)
+		}
+		mailMeta.SkipTLSVerify = skipTLSVerify
+	}
+
+	// Optional properties, these can be set on a per request basis
+	mailMeta.EmailFrom = meta.Properties["emailFrom"]
+	mailMeta.EmailTo = meta.Properties["emailTo"]
+	mailMeta.EmailCc = meta.Properties["emailCc"]
+	mailMeta.EmailBcc = meta.Properties["emailBcc"]
+	mailMeta.Subject = meta.Properties["subject"]
+	if val := meta.Properties["contentType"]; val != "" {
+		mailMeta.ContentType = val
+	}
+
+	return mailMeta, nil
+}
+
+// Init does metadata parsing, the connection is opened for each email
+func (m *Mail) Init(metadata bindings.Metadata) error {
+	meta, err := m.parseMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	m.metadata = meta
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the SMTP binding
+func (m *Mail) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: "host", Type: metadata.TypeString, Required: true},
+			{Name: "port", Type: metadata.TypeInt, Default: strconv.Itoa(defaultPort)},
+			{Name: "user", Type: metadata.TypeString},
+			{Name: "password", Type: metadata.TypeString},
+			{Name: "tlsMode", Type: metadata.TypeString, Default: tlsModeAuto},
+			{Name: "skipTLSVerify", Type: metadata.TypeBool},
+			{Name: "emailFrom", Type: metadata.TypeString},
+			{Name: "emailTo", Type: metadata.TypeString},
+			{Name: "emailCc", Type: metadata.TypeString},
+			{Name: "emailBcc", Type: metadata.TypeString},
+			{Name: "subject", Type: metadata.TypeString},
+			{Name: "contentType", Type: metadata.TypeString, Default: defaultContentType},
+		},
+	}
+}
+
+func (m *Mail) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{bindings.CreateOperation}
+}
+
+// Invoke sends an email. The request metadata override the email properties of the component metadata, and are the
+// values of the templates of the subject and the body, such as {{.name}}.
+func (m *Mail) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	meta := m.metadata.merge(req.Metadata)
+	if meta.EmailFrom == "" {
+		return nil, fmt.Errorf("smtp binding error: emailFrom not supplied")
+	}
+	if meta.EmailTo == "" {
+		return nil, fmt.Errorf("smtp binding error: emailTo not supplied")
+	}
+	if meta.Subject == "" {
+		return nil, fmt.Errorf("smtp binding error: subject not supplied")
+	}
+
+	data, err := parseData(req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := buildMessage(meta, data, req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := envelopeAddress(meta.EmailFrom)
+	if err != nil {
+		return nil, err
+	}
+	var recipients []string
+	for _, a := range append(append(splitAddresses(meta.EmailTo), splitAddresses(meta.EmailCc)...), splitAddresses(meta.EmailBcc)...) {
+		rcpt, err := envelopeAddress(a)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, rcpt)
+	}
+
+	err = m.send(from, recipients, msg)
+	if err != nil {
+		return nil, fmt.Errorf("smtp binding error: sending email failed: %s", err)
+	}
+
+	m.logger.Debug("sent email with SMTP")
+	return nil, nil
+}
+
+// merge returns the metadata with the email properties of the request metadata that are set
+func (meta mailMetadata) merge(properties map[string]string) mailMetadata {
+	for key, field := range map[string]*string{
+		"emailFrom":   &meta.EmailFrom,
+		"emailTo":     &meta.EmailTo,
+		"emailCc":     &meta.EmailCc,
+		"emailBcc":    &meta.EmailBcc,
+		"subject":     &meta.Subject,
+		"contentType": &meta.ContentType,
+	} {
+		if val := properties[key]; val != "" {
+			*field = val
+		}
+	}
+
+	return meta
+}
+
+// parseData returns the body and the attachments of the data, which is either a JSON object with both, or the body
+func parseData(b []byte) (mailData, error) {
+	var data mailData
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) && json.Unmarshal(b, &data) == nil && (data.Body != "" || len(data.Attachments) > 0) {
+		for i, a := range data.Attachments {
+			if a.Filename == "" {
+				return data, fmt.Errorf("smtp binding error: attachment %d has no filename", i)
+			}
+			content, err := base64.StdEncoding.DecodeString(a.Content)
+			if err != nil {
+				return data, fmt.Errorf("smtp binding error: the content of attachment %s is not base64 encoded: %s", a.Filename, err)
+			}
+			data.Attachments[i].data = content
+		}
+		return data, nil
+	}
+
+	// Email body is held in the data, which is still a JSON string or the body as it is
+	body := string(b)
+	if unquoted, err := strconv.Unquote(body); err == nil {
+		body = unquoted
+	}
+
+	return mailData{Body: body}, nil
+}
+
+// buildMessage returns the RFC 5322 message of the email, with the templates of the subject and the body executed with
+// the values of the request metadata
+func buildMessage(meta mailMetadata, data mailData, values map[string]string) ([]byte, error) {
+	subject, err := executeTemplate(meta.Subject, values, false)
+	if err != nil {
+		return nil, fmt.Errorf("smtp binding error: invalid subject template: %s", err)
+	}
+	body, err := executeTemplate(data.Body, values, strings.HasPrefix(meta.ContentType, "text/html"))
+	if err != nil {
+		return nil, fmt.Errorf("smtp binding error: invalid body template: %s", err)
+	}
+
+	var msg bytes.Buffer
+	writeHeader(&msg, "From", meta.EmailFrom)
+	writeHeader(&msg, "To", strings.Join(splitAddresses(meta.EmailTo), ", "))
+	if cc := splitAddresses(meta.EmailCc); len(cc) > 0 {
+		writeHeader(&msg, "Cc", strings.Join(cc, ", "))
+	}
+	writeHeader(&msg, "Subject", mime.QEncoding.Encode("utf-8", subject))
+	writeHeader(&msg, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&msg, "Message-ID", fmt.Sprintf("<%s@%s>", uuid.New().String(), domain(meta.EmailFrom)))
+	writeHeader(&msg, "MIME-Version", "1.0")
+
+	contentType := mime.FormatMediaType(meta.ContentType, map[string]string{"charset": "utf-8"})
+	if len(data.Attachments) == 0 {
+		writeHeader(&msg, "Content-Type", contentType)
+		writeHeader(&msg, "Content-Transfer-Encoding", "quoted-printable")
+		msg.WriteString("\r\n")
+		err = writeQuotedPrintable(&msg, body)
+		return msg.Bytes(), err
+	}
+
+	mw := multipart.NewWriter(&msg)
+	writeHeader(&msg, "Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mw.Boundary()}))
+	msg.WriteString("\r\n")
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = writeQuotedPrintable(part, body); err != nil {
+		return nil, err
+	}
+
+	for _, a := range data.Attachments {
+		attachmentType := a.ContentType
+		if attachmentType == "" {
+			attachmentType = "application/octet-stream"
+		}
+		part, err = mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachmentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err = writeBase64(part, a.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return msg.Bytes(), nil
+}
+
+// executeTemplate executes the text as a template, escaping the values in HTML
+func executeTemplate(text string, values map[string]string, html bool) (string, error) {
+	var b strings.Builder
+	var err error
+	if html {
+		var t *htmltemplate.Template
+		t, err = htmltemplate.New("").Option("missingkey=zero").Parse(text)
+		if err == nil {
+			err = t.Execute(&b, values)
+		}
+	} else {
+		var t *template.Template
+		t, err = template.New("").Option("missingkey=zero").Parse(text)
+		if err == nil {
+			err = t.Execute(&b, values)
+		}
+	}
+
+	return b.String(), err
+}
+
+// send sends the message through the SMTP server, with the TLS mode and the authentication of the metadata
+func (m *Mail) send(from string, recipients []string, msg []byte) error {
+	meta := m.metadata
+	addr := net.JoinHostPort(meta.Host, strconv.Itoa(meta.Port))
+	/* #nosec */
+	tlsConfig := &tls.Config{
+		ServerName:         meta.Host,
+		InsecureSkipVerify: meta.SkipTLSVerify,
+	}
+
+	var conn net.Conn
+	var err error
+	if meta.TLSMode == tlsModeTLS {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: dialTimeout}, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, dialTimeout)
+	}
+	if err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, meta.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if meta.TLSMode == tlsModeAuto || meta.TLSMode == tlsModeStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err = client.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		} else if meta.TLSMode == tlsModeStartTLS {
+			return errors.New("the server does not support STARTTLS")
+		}
+	}
+
+	if meta.User != "" {
+		// PlainAuth only sends the credentials over TLS, or to localhost
+		if err = client.Auth(smtp.PlainAuth("", meta.User, meta.Password, meta.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err = client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(msg); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func writeHeader(w io.Writer, key, value string) {
+	fmt.Fprintf(w, "%s: %s\r\n", key, value)
+}
+
+func writeQuotedPrintable(w io.Writer, s string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(s)); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
+
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := lineLength
+		if len(encoded) < n {
+			n = len(encoded)
+		}
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+
+	return nil
+}
+
+// splitAddresses returns the addresses of a list separated by commas or semicolons
+func splitAddresses(s string) []string {
+	var addresses []string
+	for _, a := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if a = strings.TrimSpace(a); a != "" {
+			addresses = append(addresses, a)
+		}
+	}
+
+	return addresses
+}
+
+// envelopeAddress returns the address of an address that may have a display name, such as Dapr <dapr@example.com>
+func envelopeAddress(address string) (string, error) {
+	a, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", fmt.Errorf("smtp binding error: invalid email address '%s': %s", address, err)
+	}
+
+	return a.Address, nil
+}
+
+func domain(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return strings.Trim(address[i+1:], "> ")
+	}
+
+	return "localhost"
+}
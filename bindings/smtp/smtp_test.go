@@ -0,0 +1,195 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package smtp
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetadata(t *testing.T) {
+	m := NewMail(logger.NewLogger("test"))
+
+	meta, err := m.parseMetadata(bindings.Metadata{Properties: map[string]string{"host": "mail.example.com", "port": "587", "tlsMode": "STARTTLS", "emailFrom": "dapr@example.com"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "mail.example.com", meta.Host)
+	assert.Equal(t, 587, meta.Port)
+	assert.Equal(t, tlsModeStartTLS, meta.TLSMode)
+	assert.Equal(t, "dapr@example.com", meta.EmailFrom)
+	assert.Equal(t, defaultContentType, meta.ContentType)
+
+	for _, properties := range []map[string]string{
+		{},
+		{"host": "mail.example.com", "port": "smtp"},
+		{"host": "mail.example.com", "tlsMode": "ssl"},
+		{"host": "mail.example.com", "skipTLSVerify": "maybe"},
+	} {
+		_, err := m.parseMetadata(bindings.Metadata{Properties: properties})
+		assert.Error(t, err)
+	}
+}
+
+func TestParseData(t *testing.T) {
+	data, err := parseData([]byte(`"Hello"`))
+	assert.NoError(t, err)
+	assert.Equal(t, mailData{Body: "Hello"}, data)
+
+	data, err = parseData([]byte(`{"body": "Hello", "attachments": [{"filename": "a.txt", "contentType": "text/plain", "content": "YQ=="}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", data.Body)
+	require.Len(t, data.Attachments, 1)
+	assert.Equal(t, []byte("a"), data.Attachments[0].data)
+
+	_, err = parseData([]byte(`{"attachments": [{"filename": "a.txt", "content": "not base64"}]}`))
+	assert.Error(t, err)
+}
+
+func TestBuildMessage(t *testing.T) {
+	meta := mailMetadata{EmailFrom: "dapr@example.com", EmailTo: "a@example.com; b@example.com", EmailCc: "c@example.com", EmailBcc: "d@example.com", Subject: "Hello {{.name}}", ContentType: defaultContentType}
+	data := mailData{Body: "<p>Hi {{.name}}</p>", Attachments: []attachment{{Filename: "a.txt", ContentType: "text/plain", data: []byte("attached")}}}
+
+	b, err := buildMessage(meta, data, map[string]string{"name": "<Dapr>"})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(b)))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello <Dapr>", subject)
+	assert.Equal(t, "a@example.com, b@example.com", msg.Header.Get("To"))
+	assert.Equal(t, "c@example.com", msg.Header.Get("Cc"))
+	assert.Empty(t, msg.Header.Get("Bcc"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	part, err := mr.NextPart()
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(part)
+	require.NoError(t, err)
+	// The values are escaped in HTML bodies
+	assert.Equal(t, "<p>Hi &lt;Dapr&gt;</p>", string(body))
+
+	part, err = mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "a.txt", part.FileName())
+	assert.Equal(t, "base64", part.Header.Get("Content-Transfer-Encoding"))
+}
+
+// fakeServer is an SMTP server without TLS that accepts a single email
+type fakeServer struct {
+	listener   net.Listener
+	recipients []string
+	data       string
+	done       chan struct{}
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeServer{listener: listener, done: make(chan struct{})}
+	go s.serve()
+
+	return s
+}
+
+func (s *fakeServer) serve() {
+	defer close(s.done)
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "220 localhost\r\n")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "EHLO"):
+			fmt.Fprint(conn, "250-localhost\r\n250 8BITMIME\r\n")
+		case strings.HasPrefix(line, "RCPT TO:"):
+			s.recipients = append(s.recipients, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			fmt.Fprint(conn, "250 OK\r\n")
+		case line == "DATA":
+			fmt.Fprint(conn, "354 Go ahead\r\n")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.data = data.String()
+			fmt.Fprint(conn, "250 OK\r\n")
+		case line == "QUIT":
+			fmt.Fprint(conn, "221 Bye\r\n")
+			return
+		default:
+			fmt.Fprint(conn, "250 OK\r\n")
+		}
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	server := newFakeServer(t)
+	defer server.listener.Close()
+
+	m := NewMail(logger.NewLogger("test"))
+	port := server.listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, m.Init(bindings.Metadata{Properties: map[string]string{
+		"host":      "127.0.0.1",
+		"port":      strconv.Itoa(port),
+		"tlsMode":   "none",
+		"emailFrom": "Dapr <dapr@example.com>",
+		"emailTo":   "a@example.com",
+		"subject":   "Hello",
+	}}))
+
+	_, err := m.Invoke(&bindings.InvokeRequest{Data: []byte(`"Hi"`), Metadata: map[string]string{"emailTo": "b@example.com", "emailBcc": "c@example.com"}})
+	require.NoError(t, err)
+	<-server.done
+
+	assert.Equal(t, []string{"b@example.com", "c@example.com"}, server.recipients)
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	require.NoError(t, err)
+	assert.Equal(t, "b@example.com", msg.Header.Get("To"))
+	body, err := ioutil.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Hi", strings.TrimSpace(string(body)))
+}
+
+func TestInvokeMissingProperties(t *testing.T) {
+	m := NewMail(logger.NewLogger("test"))
+	require.NoError(t, m.Init(bindings.Metadata{Properties: map[string]string{"host": "127.0.0.1"}}))
+
+	_, err := m.Invoke(&bindings.InvokeRequest{Data: []byte(`"Hi"`), Metadata: map[string]string{"emailTo": "a@example.com", "subject": "Hello"}})
+	assert.Error(t, err)
+
+	_, err = m.Invoke(&bindings.InvokeRequest{Data: []byte(`"Hi"`), Metadata: map[string]string{"emailFrom": "not an address", "emailTo": "a@example.com", "subject": "Hello"}})
+	assert.Error(t, err)
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/sendgrid/sendgrid-go"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
@@ -26,7 +27,7 @@ type SendGrid struct {
 
 // Our metadata holds standard email properties
 type sendGridMetadata struct {
-	APIKey    string `json:"apiKey"`
+	APIKey    string `json:"apiKey" metadata:",required"`
 	EmailFrom string `json:"emailFrom"`
 	EmailTo   string `json:"emailTo"`
 	Subject   string `json:"subject"`
@@ -82,6 +83,11 @@ func (sg *SendGrid) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the SendGrid binding
+func (sg *SendGrid) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(sendGridMetadata{})}
+}
+
 func (sg *SendGrid) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
@@ -1,26 +1,42 @@
 package sms
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 )
 
 const (
-	toNumber      = "toNumber"
-	fromNumber    = "fromNumber"
-	accountSid    = "accountSid"
-	authToken     = "authToken"
-	timeout       = "timeout"
-	twilioURLBase = "https://api.twilio.com/2010-04-01/Accounts/"
+	toNumber            = "toNumber"
+	fromNumber          = "fromNumber"
+	messagingServiceSid = "messagingServiceSid"
+	accountSid          = "accountSid"
+	authToken           = "authToken"
+	timeout             = "timeout"
+	twilioURLBase       = "https://api.twilio.com/2010-04-01/Accounts/"
+
+	// Response metadata
+	messageSid    = "messageSid"
+	messageStatus = "status"
 )
 
+// twilioMessage is the part of the message resource, or of the error, that Twilio responds with
+type twilioMessage struct {
+	Sid     string `json:"sid"`
+	Status  string `json:"status"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
 type SMS struct {
 	metadata   twilioMetadata
 	logger     logger.Logger
@@ -28,11 +44,12 @@ type SMS struct {
 }
 
 type twilioMetadata struct {
-	toNumber   string
-	fromNumber string
-	accountSid string
-	authToken  string
-	timeout    time.Duration
+	toNumber            string
+	fromNumber          string
+	messagingServiceSid string
+	accountSid          string
+	authToken           string
+	timeout             time.Duration
 }
 
 func NewSMS(logger logger.Logger) *SMS {
@@ -47,8 +64,9 @@ func (t *SMS) Init(metadata bindings.Metadata) error {
 		timeout: time.Minute * 5,
 	}
 
-	if metadata.Properties[fromNumber] == "" {
-		return errors.New("\"fromNumber\" is a required field")
+	// A messaging service picks the sender from its pool of numbers
+	if metadata.Properties[fromNumber] == "" && metadata.Properties[messagingServiceSid] == "" {
+		return errors.New("\"fromNumber\" or \"messagingServiceSid\" is a required field")
 	}
 	if metadata.Properties[accountSid] == "" {
 		return errors.New("\"accountSid\" is a required field")
@@ -59,6 +77,7 @@ func (t *SMS) Init(metadata bindings.Metadata) error {
 
 	twilioM.toNumber = metadata.Properties[toNumber]
 	twilioM.fromNumber = metadata.Properties[fromNumber]
+	twilioM.messagingServiceSid = metadata.Properties[messagingServiceSid]
 	twilioM.accountSid = metadata.Properties[accountSid]
 	twilioM.authToken = metadata.Properties[authToken]
 	if metadata.Properties[timeout] != "" {
@@ -75,23 +94,43 @@ func (t *SMS) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Twilio SMS binding
+func (t *SMS) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: toNumber, Type: metadata.TypeString},
+			{Name: fromNumber, Type: metadata.TypeString},
+			{Name: messagingServiceSid, Type: metadata.TypeString},
+			{Name: accountSid, Type: metadata.TypeString, Required: true},
+			{Name: authToken, Type: metadata.TypeString, Required: true},
+			{Name: timeout, Type: metadata.TypeDuration},
+		},
+	}
+}
+
 func (t *SMS) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{bindings.CreateOperation}
 }
 
+// Invoke sends the data as an SMS to the toNumber of the request metadata, or of the component metadata. The response
+// metadata has the SID and the status of the message.
 func (t *SMS) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	toNumberValue := t.metadata.toNumber
+	toNumberValue := req.Metadata[toNumber]
 	if toNumberValue == "" {
-		toNumberFromRequest, ok := req.Metadata[toNumber]
-		if !ok || toNumberFromRequest == "" {
-			return nil, errors.New("twilio missing \"toNumber\" field")
-		}
-		toNumberValue = toNumberFromRequest
+		toNumberValue = t.metadata.toNumber
+	}
+	if toNumberValue == "" {
+		return nil, errors.New("twilio missing \"toNumber\" field")
 	}
 
 	v := url.Values{}
 	v.Set("To", toNumberValue)
-	v.Set("From", t.metadata.fromNumber)
+	if t.metadata.messagingServiceSid != "" {
+		v.Set("MessagingServiceSid", t.metadata.messagingServiceSid)
+	}
+	if t.metadata.fromNumber != "" {
+		v.Set("From", t.metadata.fromNumber)
+	}
 	v.Set("Body", string(req.Data))
 	vDr := *strings.NewReader(v.Encode())
 
@@ -109,8 +148,24 @@ func (t *SMS) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, err
 		return nil, err
 	}
 	defer resp.Body.Close()
+
+	// The body is the message, or the error
+	var msg twilioMessage
+	decodeErr := json.NewDecoder(resp.Body).Decode(&msg)
 	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		if decodeErr == nil && msg.Message != "" {
+			return nil, fmt.Errorf("error from Twilio: %s: %d %s", resp.Status, msg.Code, msg.Message)
+		}
 		return nil, fmt.Errorf("error from Twilio: %s", resp.Status)
 	}
-	return nil, nil
+	if decodeErr != nil && decodeErr != io.EOF {
+		return nil, fmt.Errorf("error decoding Twilio response: %s", decodeErr)
+	}
+
+	return &bindings.InvokeResponse{
+		Metadata: map[string]string{
+			messageSid:    msg.Sid,
+			messageStatus: msg.Status,
+		},
+	}, nil
 }
@@ -140,3 +140,57 @@ func TestWriteShouldFail(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestMessagingService(t *testing.T) {
+	httpTransport := &mockTransport{
+		response: &http.Response{StatusCode: 201, Body: ioutil.NopCloser(strings.NewReader(`{"sid": "SM123", "status": "accepted"}`))},
+	}
+	m := bindings.Metadata{}
+	m.Properties = map[string]string{"toNumber": "toNumber", "messagingServiceSid": "MG123",
+		"accountSid": "accountSid", "authToken": "authToken"}
+	tw := NewSMS(logger.NewLogger("test"))
+	tw.httpClient = &http.Client{
+		Transport: httpTransport,
+	}
+	err := tw.Init(m)
+	assert.Nil(t, err)
+
+	resp, err := tw.Invoke(&bindings.InvokeRequest{
+		Data: []byte("hello world"),
+		Metadata: map[string]string{
+			toNumber: "requestNumber",
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"messageSid": "SM123", "status": "accepted"}, resp.Metadata)
+	assert.NoError(t, httpTransport.request.ParseForm())
+	assert.Equal(t, "requestNumber", httpTransport.request.PostForm.Get("To"))
+	assert.Equal(t, "MG123", httpTransport.request.PostForm.Get("MessagingServiceSid"))
+	assert.Empty(t, httpTransport.request.PostForm.Get("From"))
+}
+
+func TestTwilioError(t *testing.T) {
+	httpTransport := &mockTransport{
+		response: &http.Response{StatusCode: 400, Status: "400 Bad Request", Body: ioutil.NopCloser(strings.NewReader(`{"code": 21211, "message": "Invalid 'To' Phone Number"}`))},
+	}
+	m := bindings.Metadata{}
+	m.Properties = map[string]string{"fromNumber": "fromNumber",
+		"accountSid": "accountSid", "authToken": "authToken"}
+	tw := NewSMS(logger.NewLogger("test"))
+	tw.httpClient = &http.Client{
+		Transport: httpTransport,
+	}
+	err := tw.Init(m)
+	assert.Nil(t, err)
+
+	_, err = tw.Invoke(&bindings.InvokeRequest{
+		Data: []byte("hello world"),
+		Metadata: map[string]string{
+			toNumber: "invalid",
+		},
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "21211")
+}
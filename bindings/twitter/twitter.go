@@ -13,6 +13,7 @@ import (
 	"syscall"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/pkg/errors"
 
@@ -41,6 +42,15 @@ func (t *twitterInput) Init(metadata bindings.Metadata) error {
 	return t.parseMetadata(metadata)
 }
 
+// GetComponentMetadata returns the metadata fields of the Twitter binding
+func (t *twitterInput) GetComponentMetadata() metadata.ComponentMetadata {
+	var fields []metadata.Field
+	for _, name := range []string{"consumerKey", "consumerSecret", "accessToken", "accessSecret", "query"} {
+		fields = append(fields, metadata.Field{Name: name, Type: metadata.TypeString, Required: true})
+	}
+	return metadata.ComponentMetadata{Fields: fields}
+}
+
 func (t *twitterInput) parseMetadata(metadata bindings.Metadata) error {
 	ck, f := metadata.Properties["consumerKey"]
 	if !f || ck == "" {
@@ -0,0 +1,335 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state/postgresql"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	tableNameKey     = "tableName"
+	defaultTableName = "configuration"
+
+	// listenRetryInterval is how long to wait before listening again after the listening connection failed
+	listenRetryInterval = time.Second * 5
+)
+
+// The trigger notifies the changes of the rows of the table with their key and operation, rather than their value,
+// as the payloads of the notifications are limited to 8000 bytes.
+const (
+	createTableSQL = `CREATE TABLE IF NOT EXISTS %s (
+	key text NOT NULL PRIMARY KEY,
+	value text NOT NULL,
+	version text,
+	metadata jsonb
+)`
+	createFunctionSQL = `CREATE OR REPLACE FUNCTION %[1]s() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		PERFORM pg_notify('%[2]s', json_build_object('operation', TG_OP, 'key', OLD.key)::text);
+		RETURN OLD;
+	END IF;
+	PERFORM pg_notify('%[2]s', json_build_object('operation', TG_OP, 'key', NEW.key)::text);
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql`
+	// The trigger is created once, as CREATE OR REPLACE TRIGGER requires PostgreSQL 14
+	createTriggerSQL = `DO $$
+BEGIN
+	CREATE TRIGGER %[1]s AFTER INSERT OR UPDATE OR DELETE ON %[2]s FOR EACH ROW EXECUTE PROCEDURE %[1]s();
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$`
+)
+
+// notification is the payload of the notifications of the trigger
+type notification struct {
+	Operation string `json:"operation"`
+	Key       string `json:"key"`
+}
+
+type subscription struct {
+	// keys are the keys of the subscription, which has all the keys when it is empty
+	keys    map[string]bool
+	handler func(e *configuration.UpdateEvent) error
+}
+
+// configurationStore reads the configuration items from the rows of a table, whose changes are notified to the
+// subscriptions with LISTEN/NOTIFY by a trigger of the table. The table, its trigger and the function of the trigger
+// are created by Init when they don't exist.
+type configurationStore struct {
+	conn    *postgresql.Conn
+	table   string
+	channel string
+
+	lock          sync.Mutex
+	subscriptions map[string]*subscription
+	// stopListening stops the listening connection, which is open while there are subscriptions
+	stopListening context.CancelFunc
+
+	logger logger.Logger
+}
+
+// NewPostgresConfigurationStore returns a new PostgreSQL configuration store
+func NewPostgresConfigurationStore(logger logger.Logger) configuration.Store {
+	return &configurationStore{logger: logger, subscriptions: map[string]*subscription{}}
+}
+
+// Init connects to PostgreSQL with the metadata of the PostgreSQL state store, and creates the table of the items
+func (p *configurationStore) Init(metadata configuration.Metadata) error {
+	tableName := defaultTableName
+	if val, ok := metadata.Properties[tableNameKey]; ok && val != "" {
+		if !sqlutil.IsValidName(val) {
+			return fmt.Errorf("postgres configuration error: invalid table name '%s', accepted characters are (A-Z, a-z, 0-9, _)", val)
+		}
+		tableName = val
+	}
+	p.table = fmt.Sprintf(`"%s"`, tableName)
+	p.channel = tableName + "_changed"
+
+	conn, err := postgresql.Connect(p.logger, metadata.Properties)
+	if err != nil {
+		return fmt.Errorf("postgres configuration error: %s", err)
+	}
+	p.conn = conn
+
+	ctx, cancel := context.WithTimeout(context.Background(), conn.Timeout())
+	defer cancel()
+	for _, sql := range []string{
+		fmt.Sprintf(createTableSQL, p.table),
+		fmt.Sprintf(createFunctionSQL, p.channel, p.channel),
+		fmt.Sprintf(createTriggerSQL, p.channel, p.table),
+	} {
+		if _, err = conn.Exec(ctx, sql); err != nil {
+			conn.Close()
+			return fmt.Errorf("postgres configuration error: failed to create table %s: %s", p.table, err)
+		}
+	}
+
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the PostgreSQL configuration store, which are the connection
+// fields of the PostgreSQL state store with the table of the items
+func (p *configurationStore) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := append(postgresql.ConnectionMetadataFields(),
+		metadata.Field{Name: tableNameKey, Type: metadata.TypeString, Default: defaultTableName},
+	)
+	return metadata.ComponentMetadata{Fields: fields}
+}
+
+// Get returns the items of the keys, or of all the rows when no keys are given
+func (p *configurationStore) Get(req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.conn.Timeout())
+	defer cancel()
+
+	items, err := p.getItems(ctx, req.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("postgres configuration error: %s", err)
+	}
+	return &configuration.GetResponse{Items: items}, nil
+}
+
+func (p *configurationStore) getItems(ctx context.Context, keys []string) (map[string]*configuration.Item, error) {
+	sql := fmt.Sprintf(`SELECT key, value, COALESCE(version, ''), COALESCE(metadata::text, '') FROM %s`, p.table)
+	var args []interface{}
+	if len(keys) > 0 {
+		sql += ` WHERE key = ANY($1)`
+		args = append(args, keys)
+	}
+
+	rows, err := p.conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := map[string]*configuration.Item{}
+	for rows.Next() {
+		var key, metadata string
+		item := &configuration.Item{}
+		if err = rows.Scan(&key, &item.Value, &item.Version, &metadata); err != nil {
+			return nil, err
+		}
+		if metadata != "" {
+			if err = json.Unmarshal([]byte(metadata), &item.Metadata); err != nil {
+				return nil, fmt.Errorf("invalid metadata of %s: %s", key, err)
+			}
+		}
+		items[key] = item
+	}
+	return items, rows.Err()
+}
+
+// Subscribe adds a subscription to the changes of the keys, or of all the rows when no keys are given
+func (p *configurationStore) Subscribe(req *configuration.SubscribeRequest, handler func(e *configuration.UpdateEvent) error) (string, error) {
+	s := &subscription{keys: map[string]bool{}, handler: handler}
+	for _, k := range req.Keys {
+		s.keys[k] = true
+	}
+
+	id := uuid.New().String()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.stopListening == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		// Listen before adding the subscription, so that no change is missed once Subscribe returns
+		listening := make(chan error, 1)
+		go p.listen(ctx, listening)
+		if err := <-listening; err != nil {
+			cancel()
+			return "", fmt.Errorf("postgres configuration error: failed to listen to %s: %s", p.channel, err)
+		}
+		p.stopListening = cancel
+	}
+	p.subscriptions[id] = s
+	return id, nil
+}
+
+// listen receives the notifications of the channel until the context is cancelled. The result of the first LISTEN is
+// sent to listening, and the notifications are received again with a new connection when the connection fails.
+func (p *configurationStore) listen(ctx context.Context, listening chan<- error) {
+	first := true
+	for {
+		err := p.receiveNotifications(ctx, func(err error) {
+			if first {
+				listening <- err
+			}
+		})
+		if first {
+			first = false
+			if err != nil {
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		p.logger.Warnf("postgres configuration: listening to %s failed, retrying in %s: %s", p.channel, listenRetryInterval, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+// receiveNotifications listens to the channel with a connection of the pool, calls onListen with the result of
+// LISTEN, and handles the notifications until the connection fails
+func (p *configurationStore) receiveNotifications(ctx context.Context, onListen func(err error)) error {
+	conn, err := p.conn.Acquire(ctx)
+	if err == nil {
+		// The connection is closed rather than returned to the pool, as it keeps listening otherwise
+		defer func() {
+			conn.Conn().Close(context.Background())
+			conn.Release()
+		}()
+		_, err = conn.Exec(ctx, fmt.Sprintf(`LISTEN "%s"`, p.channel))
+	}
+	onListen(err)
+	if err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		p.handleNotification(ctx, n.Payload)
+	}
+}
+
+// handleNotification sends the item of the changed row to the subscriptions of its key
+func (p *configurationStore) handleNotification(ctx context.Context, payload string) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil || n.Key == "" {
+		p.logger.Warnf("postgres configuration: invalid notification %s", payload)
+		return
+	}
+
+	item := &configuration.Item{Metadata: map[string]string{configuration.DeletedMetadataKey: "true"}}
+	if n.Operation != "DELETE" {
+		ctx, cancel := context.WithTimeout(ctx, p.conn.Timeout())
+		defer cancel()
+		items, err := p.getItems(ctx, []string{n.Key})
+		if err != nil {
+			p.logger.Warnf("postgres configuration: error reading %s after %s: %s", n.Key, n.Operation, err)
+			return
+		}
+		// The row can be deleted before it is read
+		if i, ok := items[n.Key]; ok {
+			item = i
+		}
+	}
+
+	p.dispatch(n.Key, item)
+}
+
+func (p *configurationStore) dispatch(key string, item *configuration.Item) {
+	p.lock.Lock()
+	subscriptions := make(map[string]*subscription, len(p.subscriptions))
+	for id, s := range p.subscriptions {
+		if len(s.keys) == 0 || s.keys[key] {
+			subscriptions[id] = s
+		}
+	}
+	p.lock.Unlock()
+
+	for id, s := range subscriptions {
+		err := s.handler(&configuration.UpdateEvent{
+			ID:    id,
+			Items: map[string]*configuration.Item{key: item},
+		})
+		if err != nil {
+			p.logger.Warnf("postgres configuration: error from the handler of subscription %s: %s", id, err)
+		}
+	}
+}
+
+// Unsubscribe removes the subscription of the ID, and stops listening once there are no subscriptions
+func (p *configurationStore) Unsubscribe(req *configuration.UnsubscribeRequest) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.subscriptions[req.ID]; !ok {
+		return fmt.Errorf("postgres configuration error: subscription %s not found", req.ID)
+	}
+	delete(p.subscriptions, req.ID)
+
+	if len(p.subscriptions) == 0 && p.stopListening != nil {
+		p.stopListening()
+		p.stopListening = nil
+	}
+	return nil
+}
+
+// Close stops listening and closes the connection pool
+func (p *configurationStore) Close() error {
+	p.lock.Lock()
+	if p.stopListening != nil {
+		p.stopListening()
+		p.stopListening = nil
+	}
+	p.lock.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
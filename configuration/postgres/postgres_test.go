@@ -0,0 +1,136 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connectionStringEnvKey is the environment variable with the connection string of the integration tests
+const connectionStringEnvKey = "DAPR_TEST_POSTGRES_CONNSTRING"
+
+func TestInitInvalidTableName(t *testing.T) {
+	p := NewPostgresConfigurationStore(logger.NewLogger("test"))
+	err := p.Init(configuration.Metadata{Properties: map[string]string{
+		"connectionString": "host=localhost",
+		tableNameKey:       "configuration;drop table configuration",
+	}})
+	assert.Error(t, err)
+}
+
+func TestInitMissingConnectionString(t *testing.T) {
+	p := NewPostgresConfigurationStore(logger.NewLogger("test"))
+	assert.Error(t, p.Init(configuration.Metadata{Properties: map[string]string{}}))
+}
+
+func TestHandleNotification(t *testing.T) {
+	p := NewPostgresConfigurationStore(logger.NewLogger("test")).(*configurationStore)
+
+	var events []*configuration.UpdateEvent
+	handler := func(e *configuration.UpdateEvent) error {
+		events = append(events, e)
+		return errors.New("handler errors are logged")
+	}
+	p.subscriptions["color"] = &subscription{keys: map[string]bool{"color": true}, handler: handler}
+	p.subscriptions["all"] = &subscription{keys: map[string]bool{}, handler: handler}
+
+	// the deleted rows are not read, and the invalid notifications are ignored
+	p.handleNotification(context.Background(), `{"operation":"DELETE","key":"size"}`)
+	p.handleNotification(context.Background(), `not json`)
+	p.handleNotification(context.Background(), `{"operation":"DELETE"}`)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "all", events[0].ID)
+	assert.Equal(t, map[string]*configuration.Item{
+		"size": {Metadata: map[string]string{configuration.DeletedMetadataKey: "true"}},
+	}, events[0].Items)
+
+	events = nil
+	p.dispatch("color", &configuration.Item{Value: "blue"})
+	require.Len(t, events, 2)
+	for _, e := range events {
+		assert.Equal(t, map[string]*configuration.Item{"color": {Value: "blue"}}, e.Items)
+	}
+}
+
+func TestUnsubscribeNotFound(t *testing.T) {
+	p := NewPostgresConfigurationStore(logger.NewLogger("test"))
+	assert.Error(t, p.Unsubscribe(&configuration.UnsubscribeRequest{ID: "missing"}))
+}
+
+func TestPostgresIntegration(t *testing.T) {
+	connectionString := os.Getenv(connectionStringEnvKey)
+	if connectionString == "" {
+		t.Skipf("PostgreSQL configuration integration tests skipped. To enable define the connection string using environment variable '%s'", connectionStringEnvKey)
+	}
+
+	tableName := "configuration_" + uuid.New().String()[:8]
+	p := NewPostgresConfigurationStore(logger.NewLogger("test")).(*configurationStore)
+	require.NoError(t, p.Init(configuration.Metadata{Properties: map[string]string{
+		"connectionString": connectionString,
+		tableNameKey:       tableName,
+	}}))
+	defer func() {
+		p.conn.Exec(context.Background(), fmt.Sprintf(`DROP TABLE %s`, p.table))
+		p.conn.Exec(context.Background(), fmt.Sprintf(`DROP FUNCTION %s`, p.channel))
+		p.Close()
+	}()
+
+	exec := func(sql string, args ...interface{}) {
+		_, err := p.conn.Exec(context.Background(), fmt.Sprintf(sql, p.table), args...)
+		require.NoError(t, err)
+	}
+	exec(`INSERT INTO %s (key, value, version, metadata) VALUES ('color', 'blue', '1', '{"owner":"team"}')`)
+
+	res, err := p.Get(&configuration.GetRequest{Keys: []string{"color", "missing"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*configuration.Item{
+		"color": {Value: "blue", Version: "1", Metadata: map[string]string{"owner": "team"}},
+	}, res.Items)
+
+	events := make(chan *configuration.UpdateEvent, 10)
+	id, err := p.Subscribe(&configuration.SubscribeRequest{Keys: []string{"color"}}, func(e *configuration.UpdateEvent) error {
+		events <- e
+		return nil
+	})
+	require.NoError(t, err)
+
+	receive := func() *configuration.UpdateEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(10 * time.Second):
+			t.Fatal("no event received")
+			return nil
+		}
+	}
+
+	exec(`UPDATE %s SET value = 'red', version = '2' WHERE key = 'color'`)
+	assert.Equal(t, map[string]*configuration.Item{
+		"color": {Value: "red", Version: "2", Metadata: map[string]string{"owner": "team"}},
+	}, receive().Items)
+
+	exec(`INSERT INTO %s (key, value) VALUES ('size', '10')`)
+	exec(`DELETE FROM %s WHERE key = 'color'`)
+	e := receive()
+	assert.Equal(t, id, e.ID)
+	assert.Equal(t, map[string]*configuration.Item{
+		"color": {Metadata: map[string]string{configuration.DeletedMetadataKey: "true"}},
+	}, e.Items)
+
+	require.NoError(t, p.Unsubscribe(&configuration.UnsubscribeRequest{ID: id}))
+}
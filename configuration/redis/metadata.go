@@ -0,0 +1,18 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import "time"
+
+type metadata struct {
+	host      string
+	password  string
+	enableTLS bool
+	// db is the database of the configuration items, whose keyspace notifications are watched by the subscriptions
+	db              int
+	maxRetries      int
+	maxRetryBackoff time.Duration
+}
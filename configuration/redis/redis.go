@@ -0,0 +1,302 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/configuration"
+	componentmetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/go-redis/redis/v7"
+	"github.com/google/uuid"
+)
+
+const (
+	host            = "redisHost"
+	password        = "redisPassword"
+	enableTLS       = "enableTLS"
+	redisDB         = "redisDB"
+	maxRetries      = "maxRetries"
+	maxRetryBackoff = "maxRetryBackoff"
+
+	defaultMaxRetries      = 3
+	defaultMaxRetryBackoff = time.Second * 2
+
+	// versionSeparator separates the value of an item from its version, e.g. "blue||2"
+	versionSeparator = "||"
+	// scanCount is the number of keys scanned at a time when all the items are read
+	scanCount = 100
+	// notifyKeyspaceEvents are the flags of the keyspace notifications of all the commands
+	notifyKeyspaceEvents = "KA"
+)
+
+// deletedEvents are the keyspace events of the items that no longer exist
+var deletedEvents = map[string]bool{"del": true, "expired": true, "evicted": true}
+
+// configurationStore reads the configuration items from the string keys of a Redis database. The subscriptions are
+// notified of the changes with the keyspace notifications of the keys, which are enabled by Init when the server
+// allows it.
+type configurationStore struct {
+	metadata metadata
+	client   *redis.Client
+	// subscriptions are the Redis subscriptions of the keyspace notifications by subscription ID
+	subscriptions map[string]*redis.PubSub
+	lock          sync.Mutex
+
+	logger logger.Logger
+}
+
+// NewRedisConfigurationStore returns a new redis configuration store
+func NewRedisConfigurationStore(logger logger.Logger) configuration.Store {
+	return &configurationStore{logger: logger}
+}
+
+func parseRedisMetadata(meta configuration.Metadata) (metadata, error) {
+	m := metadata{}
+	if val, ok := meta.Properties[host]; ok && val != "" {
+		m.host = val
+	} else {
+		return m, errors.New("redis configuration error: missing host address")
+	}
+
+	if val, ok := meta.Properties[password]; ok && val != "" {
+		m.password = val
+	}
+
+	if val, ok := meta.Properties[enableTLS]; ok && val != "" {
+		tls, err := strconv.ParseBool(val)
+		if err != nil {
+			return m, fmt.Errorf("redis configuration error: can't parse enableTLS field: %s", err)
+		}
+		m.enableTLS = tls
+	}
+
+	if val, ok := meta.Properties[redisDB]; ok && val != "" {
+		db, err := strconv.Atoi(val)
+		if err != nil || db < 0 {
+			return m, fmt.Errorf("redis configuration error: invalid redisDB %s", val)
+		}
+		m.db = db
+	}
+
+	m.maxRetries = defaultMaxRetries
+	if val, ok := meta.Properties[maxRetries]; ok && val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return m, fmt.Errorf("redis configuration error: can't parse maxRetries field: %s", err)
+		}
+		m.maxRetries = n
+	}
+
+	m.maxRetryBackoff = defaultMaxRetryBackoff
+	if val, ok := meta.Properties[maxRetryBackoff]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return m, fmt.Errorf("redis configuration error: can't parse maxRetryBackoff field: %s", err)
+		}
+		m.maxRetryBackoff = d
+	}
+
+	return m, nil
+}
+
+func (r *configurationStore) Init(metadata configuration.Metadata) error {
+	m, err := parseRedisMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	r.metadata = m
+
+	options := &redis.Options{
+		Addr:            m.host,
+		Password:        m.password,
+		DB:              m.db,
+		MaxRetries:      m.maxRetries,
+		MaxRetryBackoff: m.maxRetryBackoff,
+	}
+
+	/* #nosec */
+	if m.enableTLS {
+		options.TLSConfig = &tls.Config{
+			InsecureSkipVerify: m.enableTLS,
+		}
+	}
+
+	client := redis.NewClient(options)
+	if _, err = client.Ping().Result(); err != nil {
+		return fmt.Errorf("redis configuration: error connecting to redis at %s: %s", m.host, err)
+	}
+	r.client = client
+	r.subscriptions = map[string]*redis.PubSub{}
+
+	r.enableKeyspaceNotifications()
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the Redis configuration store
+func (r *configurationStore) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: host, Type: componentmetadata.TypeString, Required: true},
+			{Name: password, Type: componentmetadata.TypeString},
+			{Name: enableTLS, Type: componentmetadata.TypeBool},
+			{Name: redisDB, Type: componentmetadata.TypeInt, Default: "0"},
+			{Name: maxRetries, Type: componentmetadata.TypeInt, Default: strconv.Itoa(defaultMaxRetries)},
+			{Name: maxRetryBackoff, Type: componentmetadata.TypeDuration, Default: defaultMaxRetryBackoff.String()},
+		},
+	}
+}
+
+// enableKeyspaceNotifications adds the flags of the keyspace notifications to those of the server. Managed servers
+// often disallow the CONFIG command, in which case the notifications have to be enabled in their configuration.
+func (r *configurationStore) enableKeyspaceNotifications() {
+	res, err := r.client.ConfigGet("notify-keyspace-events").Result()
+	if err != nil || len(res) != 2 {
+		r.logger.Warnf("redis configuration: can't read the keyspace notifications of the server, subscriptions require notify-keyspace-events to be %s: %s", notifyKeyspaceEvents, err)
+		return
+	}
+
+	flags, _ := res[1].(string)
+	if strings.Contains(flags, "K") && strings.Contains(flags, "A") {
+		return
+	}
+	if err = r.client.ConfigSet("notify-keyspace-events", flags+notifyKeyspaceEvents).Err(); err != nil {
+		r.logger.Warnf("redis configuration: can't enable the keyspace notifications of the server, subscriptions require notify-keyspace-events to be %s: %s", notifyKeyspaceEvents, err)
+	}
+}
+
+// Get returns the items of the keys, or of all the string keys of the database when no keys are given
+func (r *configurationStore) Get(req *configuration.GetRequest) (*configuration.GetResponse, error) {
+	keys := req.Keys
+	if len(keys) == 0 {
+		var err error
+		if keys, err = r.scanKeys(); err != nil {
+			return nil, fmt.Errorf("redis configuration: error from get: %s", err)
+		}
+	}
+
+	items := map[string]*configuration.Item{}
+	if len(keys) == 0 {
+		return &configuration.GetResponse{Items: items}, nil
+	}
+
+	values, err := r.client.MGet(keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis configuration: error from get: %s", err)
+	}
+	for i, v := range values {
+		// MGET returns nil for the keys that don't exist or aren't strings
+		if s, ok := v.(string); ok {
+			items[keys[i]] = parseItem(s)
+		}
+	}
+	return &configuration.GetResponse{Items: items}, nil
+}
+
+func (r *configurationStore) scanKeys() ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		batch, next, err := r.client.Scan(cursor, "*", scanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+// parseItem returns the item of the value of a key, which has a version when it ends with ||version
+func parseItem(value string) *configuration.Item {
+	item := &configuration.Item{Value: value}
+	if i := strings.LastIndex(value, versionSeparator); i >= 0 {
+		item.Value = value[:i]
+		item.Version = value[i+len(versionSeparator):]
+	}
+	return item
+}
+
+// Subscribe subscribes to the keyspace notifications of the keys, or of all the keys of the database when no keys are
+// given, and calls the handler with the item of the key of each notification
+func (r *configurationStore) Subscribe(req *configuration.SubscribeRequest, handler func(e *configuration.UpdateEvent) error) (string, error) {
+	prefix := fmt.Sprintf("__keyspace@%d__:", r.metadata.db)
+
+	var ps *redis.PubSub
+	if len(req.Keys) == 0 {
+		ps = r.client.PSubscribe(prefix + "*")
+	} else {
+		channels := make([]string, 0, len(req.Keys))
+		for _, k := range req.Keys {
+			channels = append(channels, prefix+k)
+		}
+		ps = r.client.Subscribe(channels...)
+	}
+	// Wait for the confirmation of the subscription, so that no change is missed once Subscribe returns
+	if _, err := ps.Receive(); err != nil {
+		ps.Close()
+		return "", fmt.Errorf("redis configuration: error from subscribe: %s", err)
+	}
+
+	id := uuid.New().String()
+	r.lock.Lock()
+	r.subscriptions[id] = ps
+	r.lock.Unlock()
+
+	go r.handleNotifications(id, prefix, ps.Channel(), handler)
+	return id, nil
+}
+
+// handleNotifications calls the handler with the item of the key of each notification until the subscription is
+// closed. A notification is the name of the command that changed the key.
+func (r *configurationStore) handleNotifications(id, prefix string, notifications <-chan *redis.Message, handler func(e *configuration.UpdateEvent) error) {
+	for msg := range notifications {
+		key := strings.TrimPrefix(msg.Channel, prefix)
+
+		item := &configuration.Item{Metadata: map[string]string{configuration.DeletedMetadataKey: "true"}}
+		if !deletedEvents[msg.Payload] {
+			value, err := r.client.Get(key).Result()
+			if err != nil && err != redis.Nil {
+				r.logger.Warnf("redis configuration: error reading %s after %s: %s", key, msg.Payload, err)
+				continue
+			}
+			// The key can be deleted before it is read
+			if err == nil {
+				item = parseItem(value)
+			}
+		}
+
+		err := handler(&configuration.UpdateEvent{
+			ID:    id,
+			Items: map[string]*configuration.Item{key: item},
+		})
+		if err != nil {
+			r.logger.Warnf("redis configuration: error from the handler of subscription %s: %s", id, err)
+		}
+	}
+}
+
+// Unsubscribe closes the Redis subscription of the ID
+func (r *configurationStore) Unsubscribe(req *configuration.UnsubscribeRequest) error {
+	r.lock.Lock()
+	ps, ok := r.subscriptions[req.ID]
+	delete(r.subscriptions, req.ID)
+	r.lock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("redis configuration: subscription %s not found", req.ID)
+	}
+	return ps.Close()
+}
@@ -0,0 +1,134 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dapr/components-contrib/configuration"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedisMetadata(t *testing.T) {
+	t.Run("metadata is correct", func(t *testing.T) {
+		m, err := parseRedisMetadata(configuration.Metadata{Properties: map[string]string{
+			host:            "fake.redis.com",
+			password:        "fakePassword",
+			enableTLS:       "true",
+			redisDB:         "2",
+			maxRetryBackoff: "5s",
+		}})
+
+		require.NoError(t, err)
+		assert.Equal(t, "fake.redis.com", m.host)
+		assert.Equal(t, "fakePassword", m.password)
+		assert.True(t, m.enableTLS)
+		assert.Equal(t, 2, m.db)
+		assert.Equal(t, defaultMaxRetries, m.maxRetries)
+		assert.Equal(t, 5*time.Second, m.maxRetryBackoff)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for _, properties := range []map[string]string{
+			{},
+			{host: "fake.redis.com", enableTLS: "yes please"},
+			{host: "fake.redis.com", redisDB: "-1"},
+			{host: "fake.redis.com", maxRetries: "many"},
+			{host: "fake.redis.com", maxRetryBackoff: "2"},
+		} {
+			_, err := parseRedisMetadata(configuration.Metadata{Properties: properties})
+			assert.Error(t, err, properties)
+		}
+	})
+}
+
+func TestParseItem(t *testing.T) {
+	assert.Equal(t, &configuration.Item{Value: "blue"}, parseItem("blue"))
+	assert.Equal(t, &configuration.Item{Value: "blue", Version: "2"}, parseItem("blue||2"))
+	assert.Equal(t, &configuration.Item{Value: "a||b", Version: "3"}, parseItem("a||b||3"))
+}
+
+func newTestStore(t *testing.T) (*miniredis.Miniredis, configuration.Store) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+
+	store := NewRedisConfigurationStore(logger.NewLogger("test"))
+	require.NoError(t, store.Init(configuration.Metadata{Properties: map[string]string{host: s.Addr()}}))
+	return s, store
+}
+
+func TestGet(t *testing.T) {
+	s, store := newTestStore(t)
+	s.Set("color", "blue||1")
+	s.Set("size", "10")
+	s.Lpush("list", "value")
+
+	t.Run("keys", func(t *testing.T) {
+		res, err := store.Get(&configuration.GetRequest{Keys: []string{"color", "missing", "list"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{"color": {Value: "blue", Version: "1"}}, res.Items)
+	})
+
+	t.Run("all keys", func(t *testing.T) {
+		res, err := store.Get(&configuration.GetRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*configuration.Item{
+			"color": {Value: "blue", Version: "1"},
+			"size":  {Value: "10"},
+		}, res.Items)
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	s, store := newTestStore(t)
+	events := make(chan *configuration.UpdateEvent, 10)
+	handler := func(e *configuration.UpdateEvent) error {
+		events <- e
+		return nil
+	}
+
+	receive := func() *configuration.UpdateEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event received")
+			return nil
+		}
+	}
+
+	id, err := store.Subscribe(&configuration.SubscribeRequest{Keys: []string{"color"}}, handler)
+	require.NoError(t, err)
+	allID, err := store.Subscribe(&configuration.SubscribeRequest{}, handler)
+	require.NoError(t, err)
+
+	// miniredis doesn't send keyspace notifications, they are published as Redis does
+	s.Set("color", "red||2")
+	s.Publish("__keyspace@0__:color", "set")
+	for _, e := range []*configuration.UpdateEvent{receive(), receive()} {
+		assert.Contains(t, []string{id, allID}, e.ID)
+		assert.Equal(t, map[string]*configuration.Item{"color": {Value: "red", Version: "2"}}, e.Items)
+	}
+
+	s.Del("size")
+	s.Publish("__keyspace@0__:size", "del")
+	e := receive()
+	assert.Equal(t, allID, e.ID)
+	assert.Equal(t, map[string]*configuration.Item{
+		"size": {Metadata: map[string]string{configuration.DeletedMetadataKey: "true"}},
+	}, e.Items)
+
+	require.NoError(t, store.Unsubscribe(&configuration.UnsubscribeRequest{ID: allID}))
+	s.Publish("__keyspace@0__:color", "set")
+	assert.Equal(t, id, receive().ID)
+
+	assert.Error(t, store.Unsubscribe(&configuration.UnsubscribeRequest{ID: allID}))
+}
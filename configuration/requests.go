@@ -0,0 +1,23 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package configuration
+
+// GetRequest is the request to get the configuration items of the keys
+type GetRequest struct {
+	Keys     []string          `json:"keys"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// SubscribeRequest is the request to subscribe to the changes of the configuration items of the keys
+type SubscribeRequest struct {
+	Keys     []string          `json:"keys"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// UnsubscribeRequest is the request to stop a subscription
+type UnsubscribeRequest struct {
+	ID string `json:"id"`
+}
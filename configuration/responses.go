@@ -0,0 +1,28 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package configuration
+
+// Item is a configuration item, whose version changes with its value when the store has versions
+type Item struct {
+	Value    string            `json:"value"`
+	Version  string            `json:"version,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// GetResponse is the response with the configuration items of the keys that exist
+type GetResponse struct {
+	Items map[string]*Item `json:"items"`
+}
+
+// UpdateEvent is the event of the items that changed in a subscription. The items that were deleted have an empty
+// value, with the DeletedMetadataKey metadata.
+type UpdateEvent struct {
+	ID    string           `json:"id"`
+	Items map[string]*Item `json:"items"`
+}
+
+// DeletedMetadataKey is the metadata of the items of an UpdateEvent that were deleted
+const DeletedMetadataKey = "deleted"
@@ -0,0 +1,23 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package configuration
+
+import "github.com/dapr/components-contrib/metadata"
+
+// Store is the interface for a component that handles the configuration of the applications
+type Store interface {
+	// Init initializes the store with its metadata
+	Init(metadata Metadata) error
+	// Get returns the configuration items of the keys, or all the items when no keys are given
+	Get(req *GetRequest) (*GetResponse, error)
+	// Subscribe calls the handler with the items of the keys that changed, or of all the keys when no keys are
+	// given, and returns the ID of the subscription
+	Subscribe(req *SubscribeRequest, handler func(e *UpdateEvent) error) (string, error)
+	// Unsubscribe stops the subscription of the ID
+	Unsubscribe(req *UnsubscribeRequest) error
+	// GetComponentMetadata returns the metadata fields and the capabilities of the store
+	GetComponentMetadata() metadata.ComponentMetadata
+}
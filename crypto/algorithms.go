@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+// The algorithms are named as in JSON Web Algorithms (RFC 7518)
+const (
+	// Key encryption and wrapping with RSA
+	AlgorithmRSA15      = "RSA1_5"
+	AlgorithmRSAOAEP    = "RSA-OAEP"
+	AlgorithmRSAOAEP256 = "RSA-OAEP-256"
+
+	// Encryption with AES in Galois/Counter Mode
+	AlgorithmA128GCM = "A128GCM"
+	AlgorithmA192GCM = "A192GCM"
+	AlgorithmA256GCM = "A256GCM"
+
+	// Key wrapping with AES Key Wrap (RFC 3394)
+	AlgorithmA128KW = "A128KW"
+	AlgorithmA192KW = "A192KW"
+	AlgorithmA256KW = "A256KW"
+
+	// Signatures with RSASSA-PKCS1-v1_5, RSASSA-PSS and ECDSA
+	AlgorithmRS256 = "RS256"
+	AlgorithmRS384 = "RS384"
+	AlgorithmRS512 = "RS512"
+	AlgorithmPS256 = "PS256"
+	AlgorithmPS384 = "PS384"
+	AlgorithmPS512 = "PS512"
+	AlgorithmES256 = "ES256"
+	AlgorithmES384 = "ES384"
+	AlgorithmES512 = "ES512"
+)
@@ -0,0 +1,195 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/secretstores/azure/keyvault"
+	"github.com/dapr/dapr/pkg/logger"
+
+	kv "github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/keyvault"
+)
+
+// componentVaultName is the metadata of the name of the vault, which is authenticated with the metadata of the Azure
+// Key Vault secret store
+const componentVaultName = "vaultName"
+
+// keyvaultCrypto is a crypto component performing the operations with the keys of an Azure Key Vault, whose private
+// parts never leave the vault. The key names are the names of the keys followed by /<version> to use another version
+// than the latest.
+type keyvaultCrypto struct {
+	vaultName   string
+	vaultClient kv.BaseClient
+
+	logger logger.Logger
+}
+
+// NewAzureKeyvaultCrypto returns a new Azure Key Vault crypto component
+func NewAzureKeyvaultCrypto(logger logger.Logger) crypto.SubtleCrypto {
+	return &keyvaultCrypto{
+		vaultClient: kv.New(),
+		logger:      logger,
+	}
+}
+
+// Init creates the authorizer of the Key Vault client
+func (k *keyvaultCrypto) Init(metadata crypto.Metadata) error {
+	k.vaultName = metadata.Properties[componentVaultName]
+	if k.vaultName == "" {
+		return fmt.Errorf("azure key vault crypto error: missing %s", componentVaultName)
+	}
+
+	settings := keyvault.EnvironmentSettings{
+		Values: metadata.Properties,
+	}
+	authorizer, err := settings.GetAuthorizer()
+	if err != nil {
+		return fmt.Errorf("azure key vault crypto error: %s", err)
+	}
+	k.vaultClient.Authorizer = authorizer
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the Azure Key Vault crypto component, which are those of the
+// Azure Key Vault secret store
+func (k *keyvaultCrypto) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: keyvault.NewAzureKeyvaultSecretStore(k.logger).GetComponentMetadata().Fields}
+}
+
+// getVaultURI returns Azure Key Vault URI
+func (k *keyvaultCrypto) getVaultURI() string {
+	return fmt.Sprintf("https://%s.vault.azure.net", k.vaultName)
+}
+
+// splitKeyName returns the name and the version of the key, which is empty for the latest version
+func splitKeyName(keyName string) (string, string) {
+	parts := strings.SplitN(keyName, "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// encryptionAlgorithm returns the Key Vault algorithm of the RSA algorithms, which are the only ones of the encryptions
+// and key wrappings of the keys of the vaults
+func encryptionAlgorithm(algorithm string) (kv.JSONWebKeyEncryptionAlgorithm, error) {
+	for _, alg := range kv.PossibleJSONWebKeyEncryptionAlgorithmValues() {
+		if string(alg) == algorithm {
+			return alg, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported algorithm %s", algorithm)
+}
+
+func encode(b []byte) *string {
+	s := base64.RawURLEncoding.EncodeToString(b)
+	return &s
+}
+
+// decode returns the bytes of a result, which is base64url-encoded without padding
+func decode(s *string) ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("missing result")
+	}
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(*s, "="))
+}
+
+// encrypt runs the Encrypt, Decrypt, WrapKey or UnwrapKey operation of the client
+func (k *keyvaultCrypto) encrypt(operation func(context.Context, string, string, string, kv.KeyOperationsParameters) (kv.KeyOperationResult, error), keyName, algorithm string, value []byte) ([]byte, error) {
+	alg, err := encryptionAlgorithm(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	name, version := splitKeyName(keyName)
+	res, err := operation(context.Background(), k.getVaultURI(), name, version, kv.KeyOperationsParameters{Algorithm: alg, Value: encode(value)})
+	if err != nil {
+		return nil, err
+	}
+	return decode(res.Result)
+}
+
+// Encrypt encrypts with an RSA key of the vault, without nonce and associated data
+func (k *keyvaultCrypto) Encrypt(req *crypto.EncryptRequest) (*crypto.EncryptResponse, error) {
+	if len(req.Nonce) > 0 || len(req.AssociatedData) > 0 {
+		return nil, errors.New("azure key vault crypto error: nonce and associated data are not supported")
+	}
+
+	ciphertext, err := k.encrypt(k.vaultClient.Encrypt, req.KeyName, req.Algorithm, req.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault crypto error: failed to encrypt with %s: %s", req.KeyName, err)
+	}
+	return &crypto.EncryptResponse{Ciphertext: ciphertext}, nil
+}
+
+// Decrypt decrypts with an RSA key of the vault, without nonce, tag and associated data
+func (k *keyvaultCrypto) Decrypt(req *crypto.DecryptRequest) (*crypto.DecryptResponse, error) {
+	if len(req.Nonce) > 0 || len(req.Tag) > 0 || len(req.AssociatedData) > 0 {
+		return nil, errors.New("azure key vault crypto error: nonce, tag and associated data are not supported")
+	}
+
+	plaintext, err := k.encrypt(k.vaultClient.Decrypt, req.KeyName, req.Algorithm, req.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault crypto error: failed to decrypt with %s: %s", req.KeyName, err)
+	}
+	return &crypto.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// WrapKey wraps with an RSA key of the vault
+func (k *keyvaultCrypto) WrapKey(req *crypto.WrapKeyRequest) (*crypto.WrapKeyResponse, error) {
+	wrapped, err := k.encrypt(k.vaultClient.WrapKey, req.KeyName, req.Algorithm, req.Key)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault crypto error: failed to wrap a key with %s: %s", req.KeyName, err)
+	}
+	return &crypto.WrapKeyResponse{WrappedKey: wrapped}, nil
+}
+
+// UnwrapKey unwraps with an RSA key of the vault
+func (k *keyvaultCrypto) UnwrapKey(req *crypto.UnwrapKeyRequest) (*crypto.UnwrapKeyResponse, error) {
+	unwrapped, err := k.encrypt(k.vaultClient.UnwrapKey, req.KeyName, req.Algorithm, req.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault crypto error: failed to unwrap a key with %s: %s", req.KeyName, err)
+	}
+	return &crypto.UnwrapKeyResponse{Key: unwrapped}, nil
+}
+
+// Sign signs with an RSA or EC key of the vault
+func (k *keyvaultCrypto) Sign(req *crypto.SignRequest) (*crypto.SignResponse, error) {
+	name, version := splitKeyName(req.KeyName)
+	parameters := kv.KeySignParameters{Algorithm: kv.JSONWebKeySignatureAlgorithm(req.Algorithm), Value: encode(req.Digest)}
+	res, err := k.vaultClient.Sign(context.Background(), k.getVaultURI(), name, version, parameters)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault crypto error: failed to sign with %s: %s", req.KeyName, err)
+	}
+
+	signature, err := decode(res.Result)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault crypto error: failed to sign with %s: %s", req.KeyName, err)
+	}
+	return &crypto.SignResponse{Signature: signature}, nil
+}
+
+// Verify verifies with an RSA or EC key of the vault
+func (k *keyvaultCrypto) Verify(req *crypto.VerifyRequest) (*crypto.VerifyResponse, error) {
+	name, version := splitKeyName(req.KeyName)
+	parameters := kv.KeyVerifyParameters{
+		Algorithm: kv.JSONWebKeySignatureAlgorithm(req.Algorithm),
+		Digest:    encode(req.Digest),
+		Signature: encode(req.Signature),
+	}
+	res, err := k.vaultClient.Verify(context.Background(), k.getVaultURI(), name, version, parameters)
+	if err != nil {
+		return nil, fmt.Errorf("azure key vault crypto error: failed to verify with %s: %s", req.KeyName, err)
+	}
+	return &crypto.VerifyResponse{Valid: res.Value != nil && *res.Value}, nil
+}
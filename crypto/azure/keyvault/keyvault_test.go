@@ -0,0 +1,133 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package keyvault
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault records the Key Vault requests and answers them with the bodies of their paths
+type fakeVault struct {
+	responses map[string]string
+	paths     []string
+	requests  []map[string]string
+}
+
+func (f *fakeVault) Do(req *http.Request) (*http.Response, error) {
+	f.paths = append(f.paths, req.URL.Path)
+	var request map[string]string
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, request)
+
+	status := http.StatusOK
+	body, ok := f.responses[req.URL.Path]
+	if !ok {
+		status = http.StatusNotFound
+		body = `{"error": {"code": "KeyNotFound", "message": "not found"}}`
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}, nil
+}
+
+func (f *fakeVault) last() (string, map[string]string) {
+	return f.paths[len(f.paths)-1], f.requests[len(f.requests)-1]
+}
+
+func newFakeCrypto(vault *fakeVault) *keyvaultCrypto {
+	k := NewAzureKeyvaultCrypto(logger.NewLogger("test")).(*keyvaultCrypto)
+	k.vaultName = "test"
+	k.vaultClient.Sender = vault
+	return k
+}
+
+func TestInit(t *testing.T) {
+	k := NewAzureKeyvaultCrypto(logger.NewLogger("test"))
+	assert.Error(t, k.Init(crypto.Metadata{Properties: map[string]string{}}))
+}
+
+func TestEncrypt(t *testing.T) {
+	vault := &fakeVault{responses: map[string]string{
+		"/keys/rsa//encrypt":   `{"kid": "https://test.vault.azure.net/keys/rsa/v2", "value": "Y2lwaGVy"}`,
+		"/keys/rsa/v1/decrypt": `{"kid": "https://test.vault.azure.net/keys/rsa/v1", "value": "c2VjcmV0"}`,
+		"/keys/rsa//wrapkey":   `{"value": "d3JhcHBlZA"}`,
+		"/keys/rsa//unwrapkey": `{"value": "a2V5"}`,
+	}}
+	k := newFakeCrypto(vault)
+
+	res, err := k.Encrypt(&crypto.EncryptRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRSAOAEP256, Plaintext: []byte("secret")})
+	require.NoError(t, err)
+	assert.Equal(t, "cipher", string(res.Ciphertext))
+	path, request := vault.last()
+	assert.Equal(t, "/keys/rsa//encrypt", path)
+	assert.Equal(t, map[string]string{"alg": "RSA-OAEP-256", "value": "c2VjcmV0"}, request)
+
+	decrypted, err := k.Decrypt(&crypto.DecryptRequest{KeyName: "rsa/v1", Algorithm: crypto.AlgorithmRSAOAEP256, Ciphertext: []byte("cipher")})
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(decrypted.Plaintext))
+	_, request = vault.last()
+	assert.Equal(t, "Y2lwaGVy", request["value"])
+
+	wrapped, err := k.WrapKey(&crypto.WrapKeyRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRSA15, Key: []byte("key")})
+	require.NoError(t, err)
+	assert.Equal(t, "wrapped", string(wrapped.WrappedKey))
+	_, request = vault.last()
+	assert.Equal(t, "RSA1_5", request["alg"])
+
+	unwrapped, err := k.UnwrapKey(&crypto.UnwrapKeyRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRSAOAEP, WrappedKey: []byte("wrapped")})
+	require.NoError(t, err)
+	assert.Equal(t, "key", string(unwrapped.Key))
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := k.Encrypt(&crypto.EncryptRequest{KeyName: "missing", Algorithm: crypto.AlgorithmRSAOAEP, Plaintext: []byte("secret")})
+		assert.Error(t, err)
+		_, err = k.Encrypt(&crypto.EncryptRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmA256GCM, Plaintext: []byte("secret")})
+		assert.Error(t, err)
+		_, err = k.Encrypt(&crypto.EncryptRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRSAOAEP, Plaintext: []byte("secret"), AssociatedData: []byte("data")})
+		assert.Error(t, err)
+		_, err = k.Decrypt(&crypto.DecryptRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRSAOAEP, Ciphertext: []byte("cipher"), Tag: []byte("tag")})
+		assert.Error(t, err)
+	})
+}
+
+func TestSign(t *testing.T) {
+	vault := &fakeVault{responses: map[string]string{
+		"/keys/ec//sign":   `{"value": "c2lnbmF0dXJl"}`,
+		"/keys/ec//verify": `{"value": true}`,
+	}}
+	k := newFakeCrypto(vault)
+
+	res, err := k.Sign(&crypto.SignRequest{KeyName: "ec", Algorithm: crypto.AlgorithmES256, Digest: []byte("digest")})
+	require.NoError(t, err)
+	assert.Equal(t, "signature", string(res.Signature))
+	path, request := vault.last()
+	assert.Equal(t, "/keys/ec//sign", path)
+	assert.Equal(t, map[string]string{"alg": "ES256", "value": "ZGlnZXN0"}, request)
+
+	verified, err := k.Verify(&crypto.VerifyRequest{KeyName: "ec", Algorithm: crypto.AlgorithmES256, Digest: []byte("digest"), Signature: []byte("signature")})
+	require.NoError(t, err)
+	assert.True(t, verified.Valid)
+	_, request = vault.last()
+	assert.Equal(t, map[string]string{"alg": "ES256", "digest": "ZGlnZXN0", "value": "c2lnbmF0dXJl"}, request)
+
+	_, err = k.Sign(&crypto.SignRequest{KeyName: "missing", Algorithm: crypto.AlgorithmES256, Digest: []byte("digest")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,324 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package local
+
+import (
+	gocrypto "crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	_ "crypto/sha512" // SHA-384 and SHA-512 of the signatures
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/dapr/components-contrib/crypto"
+	josecipher "gopkg.in/square/go-jose.v2/cipher"
+)
+
+var errUnsupportedAlgorithm = errors.New("unsupported algorithm")
+
+// aesKeySizes are the sizes of the symmetric keys of the AES algorithms
+var aesKeySizes = map[string]int{
+	crypto.AlgorithmA128GCM: 16,
+	crypto.AlgorithmA192GCM: 24,
+	crypto.AlgorithmA256GCM: 32,
+	crypto.AlgorithmA128KW:  16,
+	crypto.AlgorithmA192KW:  24,
+	crypto.AlgorithmA256KW:  32,
+}
+
+// signatureHashes are the hash functions of the digests of the signature algorithms
+var signatureHashes = map[string]gocrypto.Hash{
+	crypto.AlgorithmRS256: gocrypto.SHA256,
+	crypto.AlgorithmRS384: gocrypto.SHA384,
+	crypto.AlgorithmRS512: gocrypto.SHA512,
+	crypto.AlgorithmPS256: gocrypto.SHA256,
+	crypto.AlgorithmPS384: gocrypto.SHA384,
+	crypto.AlgorithmPS512: gocrypto.SHA512,
+	crypto.AlgorithmES256: gocrypto.SHA256,
+	crypto.AlgorithmES384: gocrypto.SHA384,
+	crypto.AlgorithmES512: gocrypto.SHA512,
+}
+
+// signatureCurves are the curves of the keys of the ECDSA algorithms
+var signatureCurves = map[string]elliptic.Curve{
+	crypto.AlgorithmES256: elliptic.P256(),
+	crypto.AlgorithmES384: elliptic.P384(),
+	crypto.AlgorithmES512: elliptic.P521(),
+}
+
+// pssOptions are the options of the RSASSA-PSS signatures, whose salt has the size of the digest
+var pssOptions = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+
+func isRSAEncryption(algorithm string) bool {
+	switch algorithm {
+	case crypto.AlgorithmRSA15, crypto.AlgorithmRSAOAEP, crypto.AlgorithmRSAOAEP256:
+		return true
+	}
+	return false
+}
+
+func oaepHash(algorithm string) hash.Hash {
+	if algorithm == crypto.AlgorithmRSAOAEP256 {
+		return sha256.New()
+	}
+	return sha1.New()
+}
+
+func rsaPublicKey(key interface{}) (*rsa.PublicKey, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey, nil
+	case *rsa.PublicKey:
+		return k, nil
+	}
+	return nil, errors.New("not an RSA key")
+}
+
+func rsaPrivateKey(key interface{}) (*rsa.PrivateKey, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *rsa.PublicKey:
+		return nil, errors.New("not a private key")
+	}
+	return nil, errors.New("not an RSA key")
+}
+
+func ecdsaPublicKey(key interface{}) (*ecdsa.PublicKey, error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey, nil
+	case *ecdsa.PublicKey:
+		return k, nil
+	}
+	return nil, errors.New("not an EC key")
+}
+
+func ecdsaPrivateKey(key interface{}) (*ecdsa.PrivateKey, error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PublicKey:
+		return nil, errors.New("not a private key")
+	}
+	return nil, errors.New("not an EC key")
+}
+
+// aesBlock returns the AES cipher of the symmetric key, which must have the size of the algorithm
+func aesBlock(algorithm string, key interface{}) (cipher.Block, error) {
+	size, ok := aesKeySizes[algorithm]
+	if !ok {
+		return nil, errUnsupportedAlgorithm
+	}
+	k, ok := key.([]byte)
+	if !ok {
+		return nil, errors.New("not a symmetric key")
+	}
+	if len(k) != size {
+		return nil, fmt.Errorf("the key of %s must have %d bytes", algorithm, size)
+	}
+	return aes.NewCipher(k)
+}
+
+func rsaEncrypt(algorithm string, key interface{}, plaintext []byte) ([]byte, error) {
+	pub, err := rsaPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if algorithm == crypto.AlgorithmRSA15 {
+		return rsa.EncryptPKCS1v15(rand.Reader, pub, plaintext)
+	}
+	return rsa.EncryptOAEP(oaepHash(algorithm), rand.Reader, pub, plaintext, nil)
+}
+
+func rsaDecrypt(algorithm string, key interface{}, ciphertext []byte) ([]byte, error) {
+	priv, err := rsaPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if algorithm == crypto.AlgorithmRSA15 {
+		return rsa.DecryptPKCS1v15(rand.Reader, priv, ciphertext)
+	}
+	return rsa.DecryptOAEP(oaepHash(algorithm), rand.Reader, priv, ciphertext, nil)
+}
+
+func newGCM(algorithm string, key interface{}) (cipher.AEAD, error) {
+	switch algorithm {
+	case crypto.AlgorithmA128GCM, crypto.AlgorithmA192GCM, crypto.AlgorithmA256GCM:
+	default:
+		return nil, errUnsupportedAlgorithm
+	}
+	block, err := aesBlock(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// gcmSeal encrypts with AES-GCM and a random nonce when the nonce is empty, and returns the ciphertext, the nonce and
+// the authentication tag
+func gcmSeal(algorithm string, key interface{}, plaintext, nonce, additionalData []byte) ([]byte, []byte, []byte, error) {
+	aead, err := newGCM(algorithm, key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(nonce) == 0 {
+		nonce = make([]byte, aead.NonceSize())
+		if _, err = rand.Read(nonce); err != nil {
+			return nil, nil, nil, err
+		}
+	} else if len(nonce) != aead.NonceSize() {
+		return nil, nil, nil, fmt.Errorf("the nonce must have %d bytes", aead.NonceSize())
+	}
+
+	sealed := aead.Seal(nil, nonce, plaintext, additionalData)
+	n := len(sealed) - aead.Overhead()
+	return sealed[:n], nonce, sealed[n:], nil
+}
+
+func gcmOpen(algorithm string, key interface{}, ciphertext, nonce, tag, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() || len(tag) != aead.Overhead() {
+		return nil, fmt.Errorf("the nonce and the tag must have %d and %d bytes", aead.NonceSize(), aead.Overhead())
+	}
+
+	sealed := make([]byte, 0, len(ciphertext)+len(tag))
+	sealed = append(append(sealed, ciphertext...), tag...)
+	return aead.Open(nil, nonce, sealed, additionalData)
+}
+
+func isAESKeyWrap(algorithm string) bool {
+	switch algorithm {
+	case crypto.AlgorithmA128KW, crypto.AlgorithmA192KW, crypto.AlgorithmA256KW:
+		return true
+	}
+	return false
+}
+
+func aesKeyWrap(algorithm string, key interface{}, plaintext []byte) ([]byte, error) {
+	if !isAESKeyWrap(algorithm) {
+		return nil, errUnsupportedAlgorithm
+	}
+	block, err := aesBlock(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	return josecipher.KeyWrap(block, plaintext)
+}
+
+func aesKeyUnwrap(algorithm string, key interface{}, ciphertext []byte) ([]byte, error) {
+	if !isAESKeyWrap(algorithm) {
+		return nil, errUnsupportedAlgorithm
+	}
+	block, err := aesBlock(algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+	return josecipher.KeyUnwrap(block, ciphertext)
+}
+
+// signatureHash returns the hash function of the algorithm, whose size must be the one of the digest
+func signatureHash(algorithm string, digest []byte) (gocrypto.Hash, error) {
+	h, ok := signatureHashes[algorithm]
+	if !ok {
+		return 0, errUnsupportedAlgorithm
+	}
+	if len(digest) != h.Size() {
+		return 0, fmt.Errorf("the digest of %s must have %d bytes", algorithm, h.Size())
+	}
+	return h, nil
+}
+
+// ecdsaKeySize returns the size of the R and S values of the signatures of the key, which must have the curve of the
+// algorithm
+func ecdsaKeySize(algorithm string, pub *ecdsa.PublicKey) (int, error) {
+	if pub.Curve != signatureCurves[algorithm] {
+		return 0, fmt.Errorf("the key of %s must be on the curve %s", algorithm, signatureCurves[algorithm].Params().Name)
+	}
+	return (pub.Curve.Params().BitSize + 7) / 8, nil
+}
+
+func sign(algorithm string, key interface{}, digest []byte) ([]byte, error) {
+	h, err := signatureHash(algorithm, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := signatureCurves[algorithm]; ok {
+		priv, err := ecdsaPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		size, err := ecdsaKeySize(algorithm, &priv.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest)
+		if err != nil {
+			return nil, err
+		}
+		// R and S are padded to the size of the key
+		signature := make([]byte, 2*size)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(signature[size-len(rb):size], rb)
+		copy(signature[2*size-len(sb):], sb)
+		return signature, nil
+	}
+
+	priv, err := rsaPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if algorithm[0] == 'P' {
+		return rsa.SignPSS(rand.Reader, priv, h, digest, pssOptions)
+	}
+	return rsa.SignPKCS1v15(rand.Reader, priv, h, digest)
+}
+
+func verify(algorithm string, key interface{}, digest, signature []byte) (bool, error) {
+	h, err := signatureHash(algorithm, digest)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := signatureCurves[algorithm]; ok {
+		pub, err := ecdsaPublicKey(key)
+		if err != nil {
+			return false, err
+		}
+		size, err := ecdsaKeySize(algorithm, pub)
+		if err != nil {
+			return false, err
+		}
+		if len(signature) != 2*size {
+			return false, nil
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(pub, digest, r, s), nil
+	}
+
+	pub, err := rsaPublicKey(key)
+	if err != nil {
+		return false, err
+	}
+	if algorithm[0] == 'P' {
+		return rsa.VerifyPSS(pub, h, digest, signature, pssOptions) == nil, nil
+	}
+	return rsa.VerifyPKCS1v15(pub, h, digest, signature) == nil, nil
+}
@@ -0,0 +1,313 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package local
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+type localMetadata struct {
+	// JWKS is a JSON Web Key Set whose keys are named by their key ID
+	JWKS string `json:"jwks"`
+	// Path is a JWKS, JWK or PEM file, or a directory of such files. The keys of the JWK and PEM files without key ID
+	// are named by their file name without its extension.
+	Path string `json:"path"`
+}
+
+// localCrypto is a crypto component performing the operations in the process of Dapr, with the keys loaded from JSON
+// Web Keys and PEM files
+type localCrypto struct {
+	// keys are the *rsa.PrivateKey, *rsa.PublicKey, *ecdsa.PrivateKey, *ecdsa.PublicKey and []byte symmetric keys by
+	// name
+	keys map[string]interface{}
+
+	logger logger.Logger
+}
+
+// NewLocalCrypto returns a new local crypto component
+func NewLocalCrypto(logger logger.Logger) crypto.SubtleCrypto {
+	return &localCrypto{logger: logger}
+}
+
+// Init loads the keys of the jwks metadata and of the files of the path metadata
+func (c *localCrypto) Init(metadata crypto.Metadata) error {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	var meta localMetadata
+	if err = json.Unmarshal(b, &meta); err != nil {
+		return err
+	}
+	if meta.JWKS == "" && meta.Path == "" {
+		return errors.New("local crypto error: missing jwks or path")
+	}
+
+	c.keys = map[string]interface{}{}
+	if meta.JWKS != "" {
+		if err = c.addJSON("", []byte(meta.JWKS)); err != nil {
+			return fmt.Errorf("local crypto error: invalid jwks: %s", err)
+		}
+	}
+	if meta.Path != "" {
+		if err = c.loadPath(meta.Path); err != nil {
+			return fmt.Errorf("local crypto error: %s", err)
+		}
+	}
+	if len(c.keys) == 0 {
+		return errors.New("local crypto error: no keys")
+	}
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the local crypto component
+func (c *localCrypto) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(localMetadata{})}
+}
+
+// loadPath loads the file, or the files of the directory except the hidden ones, such as the ..data links of the
+// Kubernetes volumes
+func (c *localCrypto) loadPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return c.loadFile(path)
+	}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		if err = c.loadFile(filepath.Join(path, f.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *localCrypto) loadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := parsePEM(block)
+		if err != nil {
+			return fmt.Errorf("invalid key %s: %s", path, err)
+		}
+		return c.add(name, key)
+	}
+	if err = c.addJSON(name, data); err != nil {
+		return fmt.Errorf("invalid key %s: %s", path, err)
+	}
+	return nil
+}
+
+// addJSON adds the keys of a JWKS, or the JWK named by its key ID or by the name
+func (c *localCrypto) addJSON(name string, data []byte) error {
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return err
+	}
+	if set.Keys == nil {
+		return c.addJWK(name, data)
+	}
+
+	for _, k := range set.Keys {
+		if err := c.addJWK("", k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *localCrypto) addJWK(name string, data []byte) error {
+	var jwk jose.JSONWebKey
+	if err := jwk.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if jwk.KeyID != "" {
+		name = jwk.KeyID
+	}
+	if name == "" {
+		return errors.New("missing key ID")
+	}
+	return c.add(name, jwk.Key)
+}
+
+func (c *localCrypto) add(name string, key interface{}) error {
+	switch key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey, *ecdsa.PrivateKey, *ecdsa.PublicKey, []byte:
+	default:
+		return fmt.Errorf("unsupported key type %T of %s", key, name)
+	}
+	if _, ok := c.keys[name]; ok {
+		return fmt.Errorf("duplicate key %s", name)
+	}
+	c.keys[name] = key
+	return nil
+}
+
+// parsePEM returns the private or public key of the PEM block, or the public key of a certificate
+func parsePEM(block *pem.Block) (interface{}, error) {
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %s", block.Type)
+	}
+}
+
+func (c *localCrypto) key(name string) (interface{}, error) {
+	key, ok := c.keys[name]
+	if !ok {
+		return nil, fmt.Errorf("local crypto error: key %s not found", name)
+	}
+	return key, nil
+}
+
+// Encrypt encrypts with the public part of an RSA key, or with AES-GCM and a symmetric key
+func (c *localCrypto) Encrypt(req *crypto.EncryptRequest) (*crypto.EncryptResponse, error) {
+	key, err := c.key(req.KeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &crypto.EncryptResponse{}
+	if isRSAEncryption(req.Algorithm) {
+		res.Ciphertext, err = rsaEncrypt(req.Algorithm, key, req.Plaintext)
+	} else {
+		res.Ciphertext, res.Nonce, res.Tag, err = gcmSeal(req.Algorithm, key, req.Plaintext, req.Nonce, req.AssociatedData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("local crypto error: failed to encrypt with %s: %s", req.KeyName, err)
+	}
+	return res, nil
+}
+
+// Decrypt decrypts with the private part of an RSA key, or with AES-GCM and a symmetric key
+func (c *localCrypto) Decrypt(req *crypto.DecryptRequest) (*crypto.DecryptResponse, error) {
+	key, err := c.key(req.KeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	var plaintext []byte
+	if isRSAEncryption(req.Algorithm) {
+		plaintext, err = rsaDecrypt(req.Algorithm, key, req.Ciphertext)
+	} else {
+		plaintext, err = gcmOpen(req.Algorithm, key, req.Ciphertext, req.Nonce, req.Tag, req.AssociatedData)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("local crypto error: failed to decrypt with %s: %s", req.KeyName, err)
+	}
+	return &crypto.DecryptResponse{Plaintext: plaintext}, nil
+}
+
+// WrapKey wraps with the public part of an RSA key, or with AES Key Wrap and a symmetric key
+func (c *localCrypto) WrapKey(req *crypto.WrapKeyRequest) (*crypto.WrapKeyResponse, error) {
+	key, err := c.key(req.KeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapped []byte
+	if isRSAEncryption(req.Algorithm) {
+		wrapped, err = rsaEncrypt(req.Algorithm, key, req.Key)
+	} else {
+		wrapped, err = aesKeyWrap(req.Algorithm, key, req.Key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("local crypto error: failed to wrap a key with %s: %s", req.KeyName, err)
+	}
+	return &crypto.WrapKeyResponse{WrappedKey: wrapped}, nil
+}
+
+// UnwrapKey unwraps with the private part of an RSA key, or with AES Key Wrap and a symmetric key
+func (c *localCrypto) UnwrapKey(req *crypto.UnwrapKeyRequest) (*crypto.UnwrapKeyResponse, error) {
+	key, err := c.key(req.KeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	var unwrapped []byte
+	if isRSAEncryption(req.Algorithm) {
+		unwrapped, err = rsaDecrypt(req.Algorithm, key, req.WrappedKey)
+	} else {
+		unwrapped, err = aesKeyUnwrap(req.Algorithm, key, req.WrappedKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("local crypto error: failed to unwrap a key with %s: %s", req.KeyName, err)
+	}
+	return &crypto.UnwrapKeyResponse{Key: unwrapped}, nil
+}
+
+// Sign signs with the private part of an RSA or EC key
+func (c *localCrypto) Sign(req *crypto.SignRequest) (*crypto.SignResponse, error) {
+	key, err := c.key(req.KeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := sign(req.Algorithm, key, req.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("local crypto error: failed to sign with %s: %s", req.KeyName, err)
+	}
+	return &crypto.SignResponse{Signature: signature}, nil
+}
+
+// Verify verifies with the public part of an RSA or EC key
+func (c *localCrypto) Verify(req *crypto.VerifyRequest) (*crypto.VerifyResponse, error) {
+	key, err := c.key(req.KeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := verify(req.Algorithm, key, req.Digest, req.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("local crypto error: failed to verify with %s: %s", req.KeyName, err)
+	}
+	return &crypto.VerifyResponse{Valid: valid}, nil
+}
@@ -0,0 +1,240 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package local
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dapr/components-contrib/crypto"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+	aes []byte
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	aesKey := make([]byte, 32)
+	_, err = rand.Read(aesKey)
+	require.NoError(t, err)
+	return &testKeys{rsa: rsaKey, ec: ecKey, aes: aesKey}
+}
+
+func (k *testKeys) jwks(t *testing.T) string {
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: k.rsa, KeyID: "rsa"},
+		{Key: &k.rsa.PublicKey, KeyID: "rsa-public"},
+		{Key: k.ec, KeyID: "ec"},
+		{Key: k.aes, KeyID: "aes"},
+	}}
+	b, err := json.Marshal(set)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func newTestCrypto(t *testing.T, properties map[string]string) crypto.SubtleCrypto {
+	c := NewLocalCrypto(logger.NewLogger("test"))
+	require.NoError(t, c.Init(crypto.Metadata{Properties: properties}))
+	return c
+}
+
+func TestInit(t *testing.T) {
+	keys := newTestKeys(t)
+
+	dir, err := ioutil.TempDir("", "keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	der, err := x509.MarshalPKCS8PrivateKey(keys.rsa)
+	require.NoError(t, err)
+	write := func(name string, data []byte) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), data, 0600))
+	}
+	write("private.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	der, err = x509.MarshalPKIXPublicKey(&keys.ec.PublicKey)
+	require.NoError(t, err)
+	write("public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	jwk, err := json.Marshal(jose.JSONWebKey{Key: keys.aes})
+	require.NoError(t, err)
+	write("symmetric.json", jwk)
+	write("set.json", []byte(keys.jwks(t)))
+	write(".hidden", []byte("not a key"))
+
+	c := newTestCrypto(t, map[string]string{"path": dir}).(*localCrypto)
+	assert.Len(t, c.keys, 7)
+	assert.IsType(t, &rsa.PrivateKey{}, c.keys["private"])
+	assert.IsType(t, &ecdsa.PublicKey{}, c.keys["public"])
+	assert.Equal(t, keys.aes, c.keys["symmetric"])
+	assert.IsType(t, &rsa.PublicKey{}, c.keys["rsa-public"])
+
+	c = newTestCrypto(t, map[string]string{"path": filepath.Join(dir, "private.pem"), "jwks": keys.jwks(t)}).(*localCrypto)
+	assert.Len(t, c.keys, 5)
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		c := NewLocalCrypto(logger.NewLogger("test"))
+		assert.Error(t, c.Init(crypto.Metadata{Properties: map[string]string{}}))
+		assert.Error(t, c.Init(crypto.Metadata{Properties: map[string]string{"jwks": `{"keys":[{"kty":"oct","k":"AAAA"}]}`}}))
+		assert.Error(t, c.Init(crypto.Metadata{Properties: map[string]string{"path": filepath.Join(dir, "missing.pem")}}))
+
+		// the keys of the set are also in the directory
+		assert.Error(t, c.Init(crypto.Metadata{Properties: map[string]string{"path": dir, "jwks": keys.jwks(t)}}))
+	})
+}
+
+func TestEncrypt(t *testing.T) {
+	c := newTestCrypto(t, map[string]string{"jwks": newTestKeys(t).jwks(t)})
+
+	t.Run("RSA", func(t *testing.T) {
+		for _, alg := range []string{crypto.AlgorithmRSA15, crypto.AlgorithmRSAOAEP, crypto.AlgorithmRSAOAEP256} {
+			res, err := c.Encrypt(&crypto.EncryptRequest{KeyName: "rsa-public", Algorithm: alg, Plaintext: []byte("secret")})
+			require.NoError(t, err, alg)
+
+			decrypted, err := c.Decrypt(&crypto.DecryptRequest{KeyName: "rsa", Algorithm: alg, Ciphertext: res.Ciphertext})
+			require.NoError(t, err, alg)
+			assert.Equal(t, "secret", string(decrypted.Plaintext))
+		}
+
+		res, err := c.Encrypt(&crypto.EncryptRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRSAOAEP, Plaintext: []byte("secret")})
+		require.NoError(t, err)
+		_, err = c.Decrypt(&crypto.DecryptRequest{KeyName: "rsa-public", Algorithm: crypto.AlgorithmRSAOAEP, Ciphertext: res.Ciphertext})
+		assert.Error(t, err)
+	})
+
+	t.Run("AES-GCM", func(t *testing.T) {
+		res, err := c.Encrypt(&crypto.EncryptRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256GCM, Plaintext: []byte("secret"), AssociatedData: []byte("data")})
+		require.NoError(t, err)
+		assert.Len(t, res.Nonce, 12)
+		assert.Len(t, res.Tag, 16)
+		assert.Len(t, res.Ciphertext, len("secret"))
+
+		req := &crypto.DecryptRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256GCM, Ciphertext: res.Ciphertext, Nonce: res.Nonce, Tag: res.Tag, AssociatedData: []byte("data")}
+		decrypted, err := c.Decrypt(req)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", string(decrypted.Plaintext))
+
+		req.AssociatedData = []byte("other")
+		_, err = c.Decrypt(req)
+		assert.Error(t, err)
+
+		nonce := []byte("0123456789ab")
+		res, err = c.Encrypt(&crypto.EncryptRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256GCM, Plaintext: []byte("secret"), Nonce: nonce})
+		require.NoError(t, err)
+		assert.Equal(t, nonce, res.Nonce)
+
+		_, err = c.Encrypt(&crypto.EncryptRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA128GCM, Plaintext: []byte("secret")})
+		assert.Error(t, err)
+		_, err = c.Encrypt(&crypto.EncryptRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256GCM, Plaintext: []byte("secret"), Nonce: []byte("short")})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := c.Encrypt(&crypto.EncryptRequest{KeyName: "missing", Algorithm: crypto.AlgorithmRSAOAEP, Plaintext: []byte("secret")})
+		assert.Error(t, err)
+		_, err = c.Encrypt(&crypto.EncryptRequest{KeyName: "ec", Algorithm: crypto.AlgorithmRSAOAEP, Plaintext: []byte("secret")})
+		assert.Error(t, err)
+		_, err = c.Encrypt(&crypto.EncryptRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmA256GCM, Plaintext: []byte("secret")})
+		assert.Error(t, err)
+		_, err = c.Encrypt(&crypto.EncryptRequest{KeyName: "aes", Algorithm: crypto.AlgorithmES256, Plaintext: []byte("secret")})
+		assert.Error(t, err)
+	})
+}
+
+func TestWrapKey(t *testing.T) {
+	c := newTestCrypto(t, map[string]string{"jwks": newTestKeys(t).jwks(t)})
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	res, err := c.WrapKey(&crypto.WrapKeyRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256KW, Key: key})
+	require.NoError(t, err)
+	assert.Len(t, res.WrappedKey, 40)
+	unwrapped, err := c.UnwrapKey(&crypto.UnwrapKeyRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256KW, WrappedKey: res.WrappedKey})
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped.Key)
+
+	res, err = c.WrapKey(&crypto.WrapKeyRequest{KeyName: "rsa-public", Algorithm: crypto.AlgorithmRSAOAEP256, Key: key})
+	require.NoError(t, err)
+	unwrapped, err = c.UnwrapKey(&crypto.UnwrapKeyRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRSAOAEP256, WrappedKey: res.WrappedKey})
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped.Key)
+
+	_, err = c.UnwrapKey(&crypto.UnwrapKeyRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256KW, WrappedKey: key})
+	assert.Error(t, err)
+	_, err = c.WrapKey(&crypto.WrapKeyRequest{KeyName: "aes", Algorithm: crypto.AlgorithmA256GCM, Key: key})
+	assert.Error(t, err)
+}
+
+func TestSign(t *testing.T) {
+	c := newTestCrypto(t, map[string]string{"jwks": newTestKeys(t).jwks(t)})
+	digest256 := sha256.Sum256([]byte("message"))
+	digest512 := sha512.Sum512([]byte("message"))
+
+	tests := []struct {
+		keyName, verifyKeyName, algorithm string
+		digest                            []byte
+	}{
+		{"rsa", "rsa-public", crypto.AlgorithmRS256, digest256[:]},
+		{"rsa", "rsa-public", crypto.AlgorithmRS512, digest512[:]},
+		{"rsa", "rsa-public", crypto.AlgorithmPS256, digest256[:]},
+		{"rsa", "rsa", crypto.AlgorithmPS512, digest512[:]},
+		{"ec", "ec", crypto.AlgorithmES256, digest256[:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			res, err := c.Sign(&crypto.SignRequest{KeyName: tt.keyName, Algorithm: tt.algorithm, Digest: tt.digest})
+			require.NoError(t, err)
+
+			verified, err := c.Verify(&crypto.VerifyRequest{KeyName: tt.verifyKeyName, Algorithm: tt.algorithm, Digest: tt.digest, Signature: res.Signature})
+			require.NoError(t, err)
+			assert.True(t, verified.Valid)
+
+			res.Signature[len(res.Signature)-1] ^= 1
+			verified, err = c.Verify(&crypto.VerifyRequest{KeyName: tt.verifyKeyName, Algorithm: tt.algorithm, Digest: tt.digest, Signature: res.Signature})
+			require.NoError(t, err)
+			assert.False(t, verified.Valid)
+		})
+	}
+
+	t.Run("ES256 signature format", func(t *testing.T) {
+		res, err := c.Sign(&crypto.SignRequest{KeyName: "ec", Algorithm: crypto.AlgorithmES256, Digest: digest256[:]})
+		require.NoError(t, err)
+		assert.Len(t, res.Signature, 64)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := c.Sign(&crypto.SignRequest{KeyName: "rsa", Algorithm: crypto.AlgorithmRS256, Digest: digest512[:]})
+		assert.Error(t, err)
+		_, err = c.Sign(&crypto.SignRequest{KeyName: "rsa-public", Algorithm: crypto.AlgorithmRS256, Digest: digest256[:]})
+		assert.Error(t, err)
+		_, err = c.Sign(&crypto.SignRequest{KeyName: "ec", Algorithm: crypto.AlgorithmES512, Digest: digest512[:]})
+		assert.Error(t, err)
+		_, err = c.Sign(&crypto.SignRequest{KeyName: "aes", Algorithm: crypto.AlgorithmRS256, Digest: digest256[:]})
+		assert.Error(t, err)
+		_, err = c.Verify(&crypto.VerifyRequest{KeyName: "ec", Algorithm: crypto.AlgorithmA256KW, Digest: digest256[:]})
+		assert.Error(t, err)
+	})
+}
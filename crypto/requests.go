@@ -0,0 +1,55 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+// EncryptRequest is the object describing an encryption request. The nonce of the AES-GCM algorithms is generated when
+// it is empty.
+type EncryptRequest struct {
+	KeyName        string `json:"keyName"`
+	Algorithm      string `json:"algorithm"`
+	Plaintext      []byte `json:"plaintext"`
+	Nonce          []byte `json:"nonce,omitempty"`
+	AssociatedData []byte `json:"associatedData,omitempty"`
+}
+
+// DecryptRequest is the object describing a decryption request
+type DecryptRequest struct {
+	KeyName        string `json:"keyName"`
+	Algorithm      string `json:"algorithm"`
+	Ciphertext     []byte `json:"ciphertext"`
+	Nonce          []byte `json:"nonce,omitempty"`
+	Tag            []byte `json:"tag,omitempty"`
+	AssociatedData []byte `json:"associatedData,omitempty"`
+}
+
+// WrapKeyRequest is the object describing a request to wrap the material of a key
+type WrapKeyRequest struct {
+	KeyName   string `json:"keyName"`
+	Algorithm string `json:"algorithm"`
+	Key       []byte `json:"key"`
+}
+
+// UnwrapKeyRequest is the object describing a request to unwrap the material of a key
+type UnwrapKeyRequest struct {
+	KeyName    string `json:"keyName"`
+	Algorithm  string `json:"algorithm"`
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+// SignRequest is the object describing a request to sign a digest, whose hash function is the one of the algorithm
+type SignRequest struct {
+	KeyName   string `json:"keyName"`
+	Algorithm string `json:"algorithm"`
+	Digest    []byte `json:"digest"`
+}
+
+// VerifyRequest is the object describing a request to verify the signature of a digest
+type VerifyRequest struct {
+	KeyName   string `json:"keyName"`
+	Algorithm string `json:"algorithm"`
+	Digest    []byte `json:"digest"`
+	Signature []byte `json:"signature"`
+}
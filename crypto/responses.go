@@ -0,0 +1,40 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+// EncryptResponse is the response of an encryption, with the nonce and the authentication tag of the AES-GCM
+// algorithms
+type EncryptResponse struct {
+	Ciphertext []byte `json:"ciphertext"`
+	Nonce      []byte `json:"nonce,omitempty"`
+	Tag        []byte `json:"tag,omitempty"`
+}
+
+// DecryptResponse is the response of a decryption
+type DecryptResponse struct {
+	Plaintext []byte `json:"plaintext"`
+}
+
+// WrapKeyResponse is the response of a key wrapping
+type WrapKeyResponse struct {
+	WrappedKey []byte `json:"wrappedKey"`
+}
+
+// UnwrapKeyResponse is the response of a key unwrapping
+type UnwrapKeyResponse struct {
+	Key []byte `json:"key"`
+}
+
+// SignResponse is the response of a signature. The ECDSA signatures are the concatenation of their R and S values,
+// as in JSON Web Signatures.
+type SignResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+// VerifyResponse is the response of a signature verification. An invalid signature is not an error.
+type VerifyResponse struct {
+	Valid bool `json:"valid"`
+}
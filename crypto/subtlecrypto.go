@@ -0,0 +1,29 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package crypto
+
+import "github.com/dapr/components-contrib/metadata"
+
+// SubtleCrypto is the interface for a component that performs low-level cryptographic operations with the keys it
+// holds by name, without exposing their private material
+type SubtleCrypto interface {
+	// Init initializes the component with its metadata
+	Init(metadata Metadata) error
+	// Encrypt encrypts the plaintext with the key
+	Encrypt(req *EncryptRequest) (*EncryptResponse, error)
+	// Decrypt decrypts the ciphertext with the key
+	Decrypt(req *DecryptRequest) (*DecryptResponse, error)
+	// WrapKey encrypts the key material of another key with the key
+	WrapKey(req *WrapKeyRequest) (*WrapKeyResponse, error)
+	// UnwrapKey decrypts a key wrapped with the key
+	UnwrapKey(req *UnwrapKeyRequest) (*UnwrapKeyResponse, error)
+	// Sign signs the digest with the key
+	Sign(req *SignRequest) (*SignResponse, error)
+	// Verify verifies the signature of the digest with the key
+	Verify(req *VerifyRequest) (*VerifyResponse, error)
+	// GetComponentMetadata returns the metadata fields and the capabilities of the component
+	GetComponentMetadata() metadata.ComponentMetadata
+}
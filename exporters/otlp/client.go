@@ -0,0 +1,107 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	collectortrace "github.com/dapr/components-contrib/exporters/otlp/proto/collector/trace/v1"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// client sends the ExportTraceServiceRequest messages to the collector
+type client interface {
+	export(ctx context.Context, request *collectortrace.ExportTraceServiceRequest) error
+}
+
+// grpcClient sends the requests with the OTLP/gRPC transport
+type grpcClient struct {
+	conn    *grpc.ClientConn
+	client  collectortrace.TraceServiceClient
+	headers metadata.MD
+}
+
+func newGRPCClient(endpoint string, headers map[string]string, tlsConfig *tls.Config) (*grpcClient, error) {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if tlsConfig != nil {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	}
+
+	conn, err := grpc.Dial(endpoint, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcClient{conn: conn, client: collectortrace.NewTraceServiceClient(conn), headers: metadata.New(headers)}, nil
+}
+
+func (c *grpcClient) export(ctx context.Context, request *collectortrace.ExportTraceServiceRequest) error {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	_, err := c.client.Export(ctx, request)
+	return err
+}
+
+// httpClient sends the requests with the OTLP/HTTP transport, encoded with protobuf
+type httpClient struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// defaultHTTPPath is the path of the traces of the collectors
+const defaultHTTPPath = "/v1/traces"
+
+// newHTTPClient returns a client of the endpoint URL, whose path is /v1/traces when it is not set
+func newHTTPClient(endpoint string, headers map[string]string, tlsConfig *tls.Config) (*httpClient, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %s, must be an http or https URL", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = defaultHTTPPath
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &httpClient{url: u.String(), headers: headers, client: &http.Client{Transport: transport}}, nil
+}
+
+func (c *httpClient) export(ctx context.Context, request *collectortrace.ExportTraceServiceRequest) error {
+	body, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package otlp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	collectortrace "github.com/dapr/components-contrib/exporters/otlp/proto/collector/trace/v1"
+	common "github.com/dapr/components-contrib/exporters/otlp/proto/common/v1"
+	resource "github.com/dapr/components-contrib/exporters/otlp/proto/resource/v1"
+	otlptrace "github.com/dapr/components-contrib/exporters/otlp/proto/trace/v1"
+	"go.opencensus.io/trace"
+)
+
+// instrumentationName is the name of the instrumentation scope of the spans
+const instrumentationName = "dapr"
+
+// encodeRequest returns the ExportTraceServiceRequest of the spans of the service
+func encodeRequest(serviceName string, spans []*trace.SpanData) *collectortrace.ExportTraceServiceRequest {
+	encoded := make([]*otlptrace.Span, 0, len(spans))
+	for _, s := range spans {
+		encoded = append(encoded, encodeSpan(s))
+	}
+
+	return &collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: []*otlptrace.ResourceSpans{{
+			Resource: &resource.Resource{Attributes: []*common.KeyValue{encodeKeyValue("service.name", serviceName)}},
+			ScopeSpans: []*otlptrace.ScopeSpans{{
+				Scope: &common.InstrumentationScope{Name: instrumentationName},
+				Spans: encoded,
+			}},
+		}},
+	}
+}
+
+func encodeSpan(s *trace.SpanData) *otlptrace.Span {
+	span := &otlptrace.Span{
+		TraceId:                s.TraceID[:],
+		SpanId:                 s.SpanID[:],
+		TraceState:             encodeTracestate(s.SpanContext),
+		Name:                   s.Name,
+		Kind:                   otlptrace.Span_SPAN_KIND_INTERNAL,
+		StartTimeUnixNano:      uint64(s.StartTime.UnixNano()),
+		EndTimeUnixNano:        uint64(s.EndTime.UnixNano()),
+		Attributes:             encodeAttributes(s.Attributes),
+		DroppedAttributesCount: uint32(s.DroppedAttributeCount),
+		DroppedEventsCount:     uint32(s.DroppedAnnotationCount + s.DroppedMessageEventCount),
+		DroppedLinksCount:      uint32(s.DroppedLinkCount),
+	}
+	if s.ParentSpanID != (trace.SpanID{}) {
+		span.ParentSpanId = s.ParentSpanID[:]
+	}
+
+	switch s.SpanKind {
+	case trace.SpanKindServer:
+		span.Kind = otlptrace.Span_SPAN_KIND_SERVER
+	case trace.SpanKindClient:
+		span.Kind = otlptrace.Span_SPAN_KIND_CLIENT
+	}
+
+	for _, a := range s.Annotations {
+		span.Events = append(span.Events, &otlptrace.Span_Event{
+			TimeUnixNano: uint64(a.Time.UnixNano()),
+			Name:         a.Message,
+			Attributes:   encodeAttributes(a.Attributes),
+		})
+	}
+	for _, e := range s.MessageEvents {
+		span.Events = append(span.Events, &otlptrace.Span_Event{
+			TimeUnixNano: uint64(e.Time.UnixNano()),
+			Name:         "message",
+			Attributes: encodeAttributes(map[string]interface{}{
+				"message.type":              messageEventType(e.EventType),
+				"message.id":                e.MessageID,
+				"message.uncompressed_size": e.UncompressedByteSize,
+				"message.compressed_size":   e.CompressedByteSize,
+			}),
+		})
+	}
+
+	for _, l := range s.Links {
+		span.Links = append(span.Links, &otlptrace.Span_Link{
+			TraceId:    l.TraceID[:],
+			SpanId:     l.SpanID[:],
+			Attributes: encodeAttributes(l.Attributes),
+		})
+	}
+
+	// OpenCensus status codes are gRPC codes, zero being OK, and those other than OK are errors
+	if s.Code != trace.StatusCodeOK {
+		span.Status = &otlptrace.Status{Message: s.Message, Code: otlptrace.Status_STATUS_CODE_ERROR}
+	}
+	return span
+}
+
+func encodeTracestate(sc trace.SpanContext) string {
+	if sc.Tracestate == nil {
+		return ""
+	}
+	entries := sc.Tracestate.Entries()
+	pairs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		pairs = append(pairs, e.Key+"="+e.Value)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func messageEventType(t trace.MessageEventType) string {
+	switch t {
+	case trace.MessageEventTypeSent:
+		return "SENT"
+	case trace.MessageEventTypeRecv:
+		return "RECEIVED"
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// encodeAttributes returns the attributes as KeyValue messages, sorted by key
+func encodeAttributes(attributes map[string]interface{}) []*common.KeyValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]*common.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, encodeKeyValue(k, attributes[k]))
+	}
+	return kvs
+}
+
+func encodeKeyValue(key string, value interface{}) *common.KeyValue {
+	v := &common.AnyValue{}
+	switch value := value.(type) {
+	case string:
+		v.Value = &common.AnyValue_StringValue{StringValue: value}
+	case bool:
+		v.Value = &common.AnyValue_BoolValue{BoolValue: value}
+	case int64:
+		v.Value = &common.AnyValue_IntValue{IntValue: value}
+	case float64:
+		v.Value = &common.AnyValue_DoubleValue{DoubleValue: value}
+	default:
+		v.Value = &common.AnyValue_StringValue{StringValue: fmt.Sprint(value)}
+	}
+	return &common.KeyValue{Key: key, Value: v}
+}
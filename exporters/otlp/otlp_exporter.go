@@ -0,0 +1,251 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package otlp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/exporters"
+	"github.com/dapr/dapr/pkg/logger"
+	"go.opencensus.io/trace"
+)
+
+// Metadata is the OTLP exporter config
+type otlpExporterMetadata struct {
+	Enabled string `json:"enabled"`
+	// Endpoint is the host:port of the collector with the grpc protocol, and its URL with the http protocol
+	Endpoint string `json:"endpoint"`
+	// Protocol is the OTLP transport, grpc or http
+	Protocol string `json:"protocol"`
+	// Headers are the comma separated key=value headers of the requests, e.g. the API key of a vendor
+	Headers string `json:"headers"`
+	// Insecure disables TLS with the grpc protocol
+	Insecure           string `json:"insecure"`
+	CAFile             string `json:"caFile"`
+	CertFile           string `json:"certFile"`
+	KeyFile            string `json:"keyFile"`
+	InsecureSkipVerify string `json:"insecureSkipVerify"`
+	Timeout            string `json:"timeout"`
+	SamplingRate       string `json:"samplingRate"`
+}
+
+const (
+	grpcProtocol = "grpc"
+	httpProtocol = "http"
+
+	defaultTimeout = 10 * time.Second
+
+	// The spans are sent in batches, once a batch is full or after the batch interval
+	maxBatchSize  = 512
+	batchInterval = 5 * time.Second
+	// maxQueueSize is the number of spans waiting to be sent, after which the spans are dropped
+	maxQueueSize = 2048
+)
+
+// NewOTLPExporter returns a new OTLP exporter instance
+func NewOTLPExporter(logger logger.Logger) *Exporter {
+	return &Exporter{logger: logger}
+}
+
+// Exporter is an OpenCensus exporter sending the spans to OpenTelemetry collectors with the OTLP protocol
+type Exporter struct {
+	logger logger.Logger
+}
+
+// Init creates a new OTLP client and registers the exporter
+func (o *Exporter) Init(daprID string, hostAddress string, metadata exporters.Metadata) error {
+	meta, err := o.getOTLPMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	enabled, _ := strconv.ParseBool(meta.Enabled)
+	if !enabled {
+		return nil
+	}
+
+	c, timeout, err := o.newClient(meta)
+	if err != nil {
+		return fmt.Errorf("otlp exporter error: %s", err)
+	}
+	sampler, err := exporters.ParseSamplingRate(meta.SamplingRate)
+	if err != nil {
+		return fmt.Errorf("otlp exporter error: %s", err)
+	}
+
+	e := newSpanExporter(daprID, c, timeout, o.logger)
+	go e.run(batchInterval)
+	trace.RegisterExporter(e)
+	trace.ApplyConfig(trace.Config{DefaultSampler: sampler})
+	return nil
+}
+
+func (o *Exporter) newClient(meta *otlpExporterMetadata) (client, time.Duration, error) {
+	if meta.Endpoint == "" {
+		return nil, 0, errors.New("missing endpoint")
+	}
+
+	timeout := defaultTimeout
+	if meta.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(meta.Timeout); err != nil || timeout <= 0 {
+			return nil, 0, fmt.Errorf("invalid timeout %s", meta.Timeout)
+		}
+	}
+
+	headers, err := parseHeaders(meta.Headers)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tlsConfig, err := parseTLSConfig(meta)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch meta.Protocol {
+	case "", grpcProtocol:
+		if insecure, _ := strconv.ParseBool(meta.Insecure); insecure {
+			tlsConfig = nil
+		}
+		c, err := newGRPCClient(meta.Endpoint, headers, tlsConfig)
+		return c, timeout, err
+	case httpProtocol:
+		c, err := newHTTPClient(meta.Endpoint, headers, tlsConfig)
+		return c, timeout, err
+	default:
+		return nil, 0, fmt.Errorf("invalid protocol %s, accepted values are %s and %s", meta.Protocol, grpcProtocol, httpProtocol)
+	}
+}
+
+// parseHeaders returns the headers of the comma separated key=value pairs
+func parseHeaders(value string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid header %s, must be key=value", pair)
+		}
+		headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return headers, nil
+}
+
+func parseTLSConfig(meta *otlpExporterMetadata) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	tlsConfig.InsecureSkipVerify, _ = strconv.ParseBool(meta.InsecureSkipVerify)
+
+	if meta.CAFile != "" {
+		ca, err := ioutil.ReadFile(meta.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("invalid caFile %s", meta.CAFile)
+		}
+	}
+
+	if meta.CertFile != "" || meta.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(meta.CertFile, meta.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func (o *Exporter) getOTLPMetadata(metadata exporters.Metadata) (*otlpExporterMetadata, error) {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var otlpMeta otlpExporterMetadata
+	err = json.Unmarshal(b, &otlpMeta)
+	if err != nil {
+		return nil, err
+	}
+	return &otlpMeta, nil
+}
+
+// spanExporter queues the spans ended by the application, and sends them in batches in the background
+type spanExporter struct {
+	serviceName string
+	client      client
+	timeout     time.Duration
+	logger      logger.Logger
+	spans       chan *trace.SpanData
+}
+
+func newSpanExporter(serviceName string, c client, timeout time.Duration, logger logger.Logger) *spanExporter {
+	return &spanExporter{
+		serviceName: serviceName,
+		client:      c,
+		timeout:     timeout,
+		logger:      logger,
+		spans:       make(chan *trace.SpanData, maxQueueSize),
+	}
+}
+
+// ExportSpan queues the span, which is dropped when the queue is full, so that the application is never blocked
+func (e *spanExporter) ExportSpan(s *trace.SpanData) {
+	select {
+	case e.spans <- s:
+	default:
+		e.logger.Debugf("otlp exporter: queue full, dropping span %s", s.Name)
+	}
+}
+
+// run sends the queued spans until the queue is closed
+func (e *spanExporter) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]*trace.SpanData, 0, maxBatchSize)
+	for {
+		select {
+		case s, ok := <-e.spans:
+			if !ok {
+				e.send(batch)
+				return
+			}
+			batch = append(batch, s)
+			if len(batch) < maxBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		e.send(batch)
+		batch = batch[:0]
+	}
+}
+
+func (e *spanExporter) send(batch []*trace.SpanData) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+	if err := e.client.export(ctx, encodeRequest(e.serviceName, batch)); err != nil {
+		e.logger.Warnf("otlp exporter: failed to export %d spans: %s", len(batch), err)
+	}
+}
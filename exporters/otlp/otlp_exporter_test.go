@@ -0,0 +1,225 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package otlp
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/exporters"
+	collectortrace "github.com/dapr/components-contrib/exporters/otlp/proto/collector/trace/v1"
+	common "github.com/dapr/components-contrib/exporters/otlp/proto/common/v1"
+	otlptrace "github.com/dapr/components-contrib/exporters/otlp/proto/trace/v1"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func testSpan() *trace.SpanData {
+	start := time.Unix(100, 0)
+	return &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		ParentSpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		SpanKind:     trace.SpanKindServer,
+		Name:         "/v1.0/state/store",
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   map[string]interface{}{"http.status_code": int64(500), "db.system": "redis"},
+		Annotations:  []trace.Annotation{{Time: start, Message: "retrying"}},
+		Status:       trace.Status{Code: 13, Message: "internal"},
+	}
+}
+
+func TestEncodeRequest(t *testing.T) {
+	request := encodeRequest("app", []*trace.SpanData{testSpan(), {Name: "second"}})
+	require.Len(t, request.GetResourceSpans(), 1)
+
+	resourceSpans := request.GetResourceSpans()[0]
+	assert.Equal(t, []*common.KeyValue{stringKeyValue("service.name", "app")}, resourceSpans.GetResource().GetAttributes())
+
+	require.Len(t, resourceSpans.GetScopeSpans(), 1)
+	scopeSpans := resourceSpans.GetScopeSpans()[0]
+	assert.Equal(t, instrumentationName, scopeSpans.GetScope().GetName())
+	require.Len(t, scopeSpans.GetSpans(), 2)
+
+	span := scopeSpans.GetSpans()[0]
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, span.GetTraceId())
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, span.GetSpanId())
+	assert.Equal(t, []byte{8, 7, 6, 5, 4, 3, 2, 1}, span.GetParentSpanId())
+	assert.Equal(t, "/v1.0/state/store", span.GetName())
+	assert.Equal(t, otlptrace.Span_SPAN_KIND_SERVER, span.GetKind())
+	assert.Equal(t, uint64(100*time.Second), span.GetStartTimeUnixNano())
+	assert.Equal(t, uint64(101*time.Second), span.GetEndTimeUnixNano())
+
+	// The attributes are sorted by key
+	assert.Equal(t, []*common.KeyValue{
+		stringKeyValue("db.system", "redis"),
+		{Key: "http.status_code", Value: &common.AnyValue{Value: &common.AnyValue_IntValue{IntValue: 500}}},
+	}, span.GetAttributes())
+
+	require.Len(t, span.GetEvents(), 1)
+	assert.Equal(t, "retrying", span.GetEvents()[0].GetName())
+
+	assert.Equal(t, "internal", span.GetStatus().GetMessage())
+	assert.Equal(t, otlptrace.Status_STATUS_CODE_ERROR, span.GetStatus().GetCode())
+
+	second := scopeSpans.GetSpans()[1]
+	assert.Equal(t, otlptrace.Span_SPAN_KIND_INTERNAL, second.GetKind())
+	assert.Nil(t, second.GetParentSpanId())
+	assert.Nil(t, second.GetStatus())
+}
+
+func stringKeyValue(key, value string) *common.KeyValue {
+	return &common.KeyValue{Key: key, Value: &common.AnyValue{Value: &common.AnyValue_StringValue{StringValue: value}}}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := parseHeaders("")
+	require.NoError(t, err)
+	assert.Empty(t, headers)
+
+	headers, err = parseHeaders("api-key = secret, x-tenant=a=b")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api-key": "secret", "x-tenant": "a=b"}, headers)
+
+	_, err = parseHeaders("api-key")
+	assert.Error(t, err)
+}
+
+func TestInitInvalidMetadata(t *testing.T) {
+	exporter := NewOTLPExporter(logger.NewLogger("test"))
+	assert.NoError(t, exporter.Init("app", "localhost", exporters.Metadata{Properties: map[string]string{"enabled": "false"}}))
+
+	for _, properties := range []map[string]string{
+		{"enabled": "true"},
+		{"enabled": "true", "endpoint": "localhost:4317", "protocol": "thrift"},
+		{"enabled": "true", "endpoint": "localhost:4317", "protocol": "http"},
+		{"enabled": "true", "endpoint": "localhost:4317", "timeout": "0s"},
+		{"enabled": "true", "endpoint": "localhost:4317", "headers": "api-key"},
+		{"enabled": "true", "endpoint": "localhost:4317", "caFile": "missing.pem"},
+		{"enabled": "true", "endpoint": "localhost:4317", "certFile": "missing.pem"},
+		{"enabled": "true", "endpoint": "localhost:4317", "samplingRate": "2"},
+	} {
+		assert.Error(t, exporter.Init("app", "localhost", exporters.Metadata{Properties: properties}), properties)
+	}
+}
+
+func TestHTTPClient(t *testing.T) {
+	var path, contentType, apiKey string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		apiKey = r.Header.Get("api-key")
+		body, _ = ioutil.ReadAll(r.Body)
+		if apiKey == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	request := encodeRequest("app", []*trace.SpanData{testSpan()})
+	c, err := newHTTPClient(server.URL, map[string]string{"api-key": "secret"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.export(context.Background(), request))
+	assert.Equal(t, defaultHTTPPath, path)
+	assert.Equal(t, "application/x-protobuf", contentType)
+	assert.Equal(t, "secret", apiKey)
+	received := &collectortrace.ExportTraceServiceRequest{}
+	require.NoError(t, proto.Unmarshal(body, received))
+	assert.True(t, proto.Equal(request, received))
+
+	c, err = newHTTPClient(server.URL+"/custom/traces", nil, nil)
+	require.NoError(t, err)
+	assert.Error(t, c.export(context.Background(), request))
+	assert.Equal(t, "/custom/traces", path)
+}
+
+// fakeCollector records the requests of the TraceService and their api-key header
+type fakeCollector struct {
+	lock    sync.Mutex
+	request *collectortrace.ExportTraceServiceRequest
+	apiKey  string
+}
+
+func (f *fakeCollector) Export(ctx context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.request = req
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("api-key"); len(values) > 0 {
+		f.apiKey = values[0]
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func TestGRPCClient(t *testing.T) {
+	collector := &fakeCollector{}
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, collector)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	c, err := newGRPCClient(lis.Addr().String(), map[string]string{"api-key": "secret"}, nil)
+	require.NoError(t, err)
+	defer c.conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	request := encodeRequest("app", []*trace.SpanData{testSpan()})
+	require.NoError(t, c.export(ctx, request))
+
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	assert.Equal(t, "secret", collector.apiKey)
+	assert.True(t, proto.Equal(request, collector.request))
+}
+
+type fakeClient struct {
+	lock     sync.Mutex
+	requests []*collectortrace.ExportTraceServiceRequest
+}
+
+func (c *fakeClient) export(ctx context.Context, request *collectortrace.ExportTraceServiceRequest) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.requests = append(c.requests, request)
+	return nil
+}
+
+func TestSpanExporter(t *testing.T) {
+	c := &fakeClient{}
+	e := newSpanExporter("app", c, time.Second, logger.NewLogger("test"))
+	done := make(chan struct{})
+	go func() {
+		e.run(time.Hour)
+		close(done)
+	}()
+
+	for i := 0; i < maxBatchSize+1; i++ {
+		e.ExportSpan(testSpan())
+	}
+	close(e.spans)
+	<-done
+
+	require.Len(t, c.requests, 2)
+	assert.Len(t, c.requests[1].GetResourceSpans()[0].GetScopeSpans()[0].GetSpans(), 1)
+}
@@ -0,0 +1,29 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package exporters
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.opencensus.io/trace"
+)
+
+// SamplingRateMetadataKey is the metadata of the probability of a trace to be sampled, from 0 to 1
+const SamplingRateMetadataKey = "samplingRate"
+
+// ParseSamplingRate returns the sampler of the sampling rate, which samples every trace when it is not set
+func ParseSamplingRate(samplingRate string) (trace.Sampler, error) {
+	if samplingRate == "" {
+		return trace.AlwaysSample(), nil
+	}
+
+	rate, err := strconv.ParseFloat(samplingRate, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return nil, fmt.Errorf("invalid %s %s, must be between 0 and 1", SamplingRateMetadataKey, samplingRate)
+	}
+	return trace.ProbabilitySampler(rate), nil
+}
@@ -0,0 +1,36 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package exporters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/trace"
+)
+
+func TestParseSamplingRate(t *testing.T) {
+	sampled := func(sampler trace.Sampler, traceID byte) bool {
+		return sampler(trace.SamplingParameters{TraceID: trace.TraceID{0, 0, 0, 0, 0, 0, 0, traceID}}).Sample
+	}
+
+	sampler, err := ParseSamplingRate("")
+	assert.NoError(t, err)
+	assert.True(t, sampled(sampler, 0xff))
+
+	sampler, err = ParseSamplingRate("0")
+	assert.NoError(t, err)
+	assert.False(t, sampled(sampler, 0))
+
+	sampler, err = ParseSamplingRate("0.5")
+	assert.NoError(t, err)
+	assert.True(t, sampled(sampler, 0))
+
+	for _, rate := range []string{"-0.1", "1.5", "half"} {
+		_, err = ParseSamplingRate(rate)
+		assert.Error(t, err)
+	}
+}
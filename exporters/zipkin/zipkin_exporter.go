@@ -7,6 +7,9 @@ package zipkin
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"strconv"
 
 	"contrib.go.opencensus.io/exporter/zipkin"
@@ -21,6 +24,8 @@ import (
 type zipkinMetadata struct {
 	ExporterAddress string `json:"exporterAddress"`
 	Enabled         string `json:"enabled"`
+	// SamplingRate is the probability of a trace to be sampled, from 0 to 1, 1 by default
+	SamplingRate string `json:"samplingRate"`
 }
 
 // NewZipkinExporter returns a new zipkin exporter instance
@@ -45,6 +50,17 @@ func (z *Exporter) Init(daprID string, hostAddress string, metadata exporters.Me
 		return nil
 	}
 
+	if meta.ExporterAddress == "" {
+		return errors.New("zipkin exporter error: missing exporterAddress")
+	}
+	if _, err = url.ParseRequestURI(meta.ExporterAddress); err != nil {
+		return fmt.Errorf("zipkin exporter error: invalid exporterAddress: %s", err)
+	}
+	sampler, err := exporters.ParseSamplingRate(meta.SamplingRate)
+	if err != nil {
+		return fmt.Errorf("zipkin exporter error: %s", err)
+	}
+
 	localEndpoint, err := openzipkin.NewEndpoint(daprID, hostAddress)
 	if err != nil {
 		return err
@@ -52,7 +68,7 @@ func (z *Exporter) Init(daprID string, hostAddress string, metadata exporters.Me
 	reporter := zipkinHTTP.NewReporter(meta.ExporterAddress)
 	ze := zipkin.NewExporter(reporter, localEndpoint)
 	trace.RegisterExporter(ze)
-	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	trace.ApplyConfig(trace.Config{DefaultSampler: sampler})
 	return nil
 }
 
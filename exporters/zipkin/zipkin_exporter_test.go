@@ -21,3 +21,12 @@ func TestParseMetadata(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "c", metadata.ExporterAddress)
 }
+
+func TestInitInvalidMetadata(t *testing.T) {
+	exporter := NewZipkinExporter(logger.NewLogger("test"))
+
+	assert.NoError(t, exporter.Init("app", "localhost", exporters.Metadata{Properties: map[string]string{"enabled": "false"}}))
+	assert.Error(t, exporter.Init("app", "localhost", exporters.Metadata{Properties: map[string]string{"enabled": "true"}}))
+	assert.Error(t, exporter.Init("app", "localhost", exporters.Metadata{Properties: map[string]string{"enabled": "true", "exporterAddress": "zipkin"}}))
+	assert.Error(t, exporter.Init("app", "localhost", exporters.Metadata{Properties: map[string]string{"enabled": "true", "exporterAddress": "http://localhost:9411/api/v2/spans", "samplingRate": "2"}}))
+}
@@ -0,0 +1,59 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package health defines how the runtime actively checks the health of the components, rather than inferring it
+// from the failures of their requests.
+package health
+
+import "errors"
+
+// Pinger is implemented by the components that can check their connection to their backend, such as the state
+// stores, the pub/sub brokers, the bindings and the secret stores
+type Pinger interface {
+	// Ping returns an error when the component can't serve requests, e.g. when its backend can't be reached
+	Ping() error
+}
+
+// ErrPingNotImplemented is returned by Ping for the components that can't be pinged
+var ErrPingNotImplemented = errors.New("ping is not implemented by this component")
+
+// Ping pings the component when it is a Pinger, and returns ErrPingNotImplemented otherwise
+func Ping(component interface{}) error {
+	pinger, ok := component.(Pinger)
+	if !ok {
+		return ErrPingNotImplemented
+	}
+	return pinger.Ping()
+}
+
+// The statuses of the components
+const (
+	StatusOK = "ok"
+	// StatusFailed is the status of the components whose ping fails
+	StatusFailed = "failed"
+	// StatusUndefined is the status of the components that can't be pinged
+	StatusUndefined = "undefined"
+)
+
+// ComponentStatus is the health of a component
+type ComponentStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Message is the error of the ping of the failed components
+	Message string `json:"message,omitempty"`
+}
+
+// Check pings the component and returns its status
+func Check(name string, component interface{}) ComponentStatus {
+	err := Ping(component)
+	switch {
+	case err == nil:
+		return ComponentStatus{Name: name, Status: StatusOK}
+	case errors.Is(err, ErrPingNotImplemented):
+		return ComponentStatus{Name: name, Status: StatusUndefined}
+	default:
+		return ComponentStatus{Name: name, Status: StatusFailed, Message: err.Error()}
+	}
+}
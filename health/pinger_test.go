@@ -0,0 +1,36 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (f *fakePinger) Ping() error {
+	return f.err
+}
+
+func TestPing(t *testing.T) {
+	assert.NoError(t, Ping(&fakePinger{}))
+	assert.EqualError(t, Ping(&fakePinger{err: errors.New("unreachable")}), "unreachable")
+	assert.Equal(t, ErrPingNotImplemented, Ping(struct{}{}))
+}
+
+func TestCheck(t *testing.T) {
+	assert.Equal(t, ComponentStatus{Name: "statestore", Status: StatusOK}, Check("statestore", &fakePinger{}))
+	assert.Equal(t, ComponentStatus{Name: "statestore", Status: StatusFailed, Message: "unreachable"}, Check("statestore", &fakePinger{err: errors.New("unreachable")}))
+	assert.Equal(t, ComponentStatus{Name: "secretstore", Status: StatusUndefined}, Check("secretstore", struct{}{}))
+
+	// the wrappers of the components that can't be pinged return ErrPingNotImplemented
+	assert.Equal(t, StatusUndefined, Check("pubsub", &fakePinger{err: ErrPingNotImplemented}).Status)
+}
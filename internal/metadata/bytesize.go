@@ -0,0 +1,59 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metadata
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a number of bytes, decoded from a number with an optional unit: the decimal units k, M, G and T, and
+// the binary units Ki, Mi, Gi and Ti, followed or not by B, e.g. 512, 10Mi or 1GB.
+type ByteSize int64
+
+// byteUnits are the multipliers of the units, the longest suffixes first
+var byteUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+	{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"k", 1e3}, {"K", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12},
+	{"B", 1},
+}
+
+var errInvalidByteSize = errors.New("must be a size such as 512, 10Mi or 1GB")
+
+// ParseByteSize returns the number of bytes of the size
+func ParseByteSize(s string) (ByteSize, error) {
+	s = strings.TrimSpace(s)
+	multiplier := int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			multiplier = u.multiplier
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 || n > (1<<63-1)/multiplier {
+		return 0, errInvalidByteSize
+	}
+	return ByteSize(n * multiplier), nil
+}
+
+// UnmarshalText decodes the size as ParseByteSize does
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
@@ -0,0 +1,41 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	for s, expected := range map[string]ByteSize{
+		"0":         0,
+		"512":       512,
+		"512B":      512,
+		"1k":        1000,
+		"1KB":       1000,
+		"2Ki":       2048,
+		"2KiB":      2048,
+		"10M":       10 * 1000 * 1000,
+		"10Mi":      10 << 20,
+		"1GB":       1000 * 1000 * 1000,
+		"1Gi":       1 << 30,
+		"3 TiB":     3 << 40,
+		" 1T ":      1000 * 1000 * 1000 * 1000,
+		"8388607Ti": 8388607 << 40,
+	} {
+		size, err := ParseByteSize(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, size, s)
+	}
+
+	for _, s := range []string{"", "B", "-1", "1.5Mi", "1Xi", "10 apples", "8388608Ti"} {
+		_, err := ParseByteSize(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,223 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package metadata decodes the metadata properties of the components into typed structs.
+//
+// The fields of a struct are decoded from the properties named by their metadata tag, or their json tag, or else
+// their field name, so that a tag such as `json:"table" metadata:",required"` only adds options. The names are
+// matched exactly first, and then regardless of case. The options of the metadata tag give other names of a
+// property: alias=<name> for a name accepted as well, and deprecated=<name> for a name accepted with a warning. The
+// required option marks the properties that must be set, which is documented and validated by the metadata of the
+// components, but not checked by Decode. A tag of "-" skips the field.
+//
+//	type postgresMetadata struct {
+//	    ConnectionString string        `metadata:"connectionString,alias=url"`
+//	    Timeout          time.Duration `metadata:"timeout,deprecated=timeoutInterval"`
+//	}
+//
+// Empty properties are skipped, so that the fields keep the defaults they were set to before decoding. The pointer
+// fields tell the properties that are set from the others: they stay nil unless their property is set, even to zero,
+// and the *string fields are set when their property is present, even empty.
+package metadata
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	tagName          = "metadata"
+	aliasOption      = "alias="
+	deprecatedOption = "deprecated="
+	requiredOption   = "required"
+)
+
+var (
+	log = logger.NewLogger("dapr.contrib.metadata")
+
+	durationType        = reflect.TypeOf(time.Duration(0))
+	stringPointerType   = reflect.TypeOf((*string)(nil))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Field is a field of a struct with the names of its property
+type Field struct {
+	// Index is the index sequence of the field for reflect.Value.FieldByIndex
+	Index      []int
+	Type       reflect.Type
+	Name       string
+	Aliases    []string
+	Deprecated []string
+	Required   bool
+}
+
+// Decode sets the fields of the struct pointed to by result to the values of their properties. The fields are
+// strings, booleans, integers, floats, time.Duration values such as 5m, ByteSize values such as 10Mi, slices of
+// strings separated by commas, pointers to these, or encoding.TextUnmarshaler implementations.
+func Decode(properties map[string]string, result interface{}) error {
+	v := reflect.ValueOf(result)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("metadata: the result must be a pointer to a struct, not %T", result)
+	}
+
+	// lowerNames are the names of the properties by their lowercase names, to match them regardless of case
+	lowerNames := make(map[string]string, len(properties))
+	for name := range properties {
+		lowerNames[strings.ToLower(name)] = name
+	}
+	lookup := func(name string) (string, string, bool) {
+		if val, ok := properties[name]; ok {
+			return name, val, true
+		}
+		if actual, ok := lowerNames[strings.ToLower(name)]; ok {
+			return actual, properties[actual], true
+		}
+		return "", "", false
+	}
+
+	for _, f := range Fields(v.Elem().Type()) {
+		name, val, ok := lookup(f.Name)
+		for i := 0; !ok && i < len(f.Aliases); i++ {
+			name, val, ok = lookup(f.Aliases[i])
+		}
+		for i := 0; !ok && i < len(f.Deprecated); i++ {
+			if name, val, ok = lookup(f.Deprecated[i]); ok {
+				log.Warnf("the metadata property %s is deprecated, use %s instead", name, f.Name)
+			}
+		}
+		if !ok || (val == "" && f.Type != stringPointerType) {
+			continue
+		}
+
+		if err := DecodeValue(v.Elem().FieldByIndex(f.Index), val); err != nil {
+			return fmt.Errorf("invalid %s value '%s', %s", name, val, err)
+		}
+	}
+	return nil
+}
+
+// Fields returns the fields of the struct type decoded by Decode, with the fields of its embedded structs
+func Fields(t reflect.Type) []Field {
+	var result []Field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+
+		tag, hasTag := sf.Tag.Lookup(tagName)
+		if tag == "-" {
+			continue
+		}
+		if sf.Anonymous && !hasTag && sf.Type.Kind() == reflect.Struct {
+			for _, f := range Fields(sf.Type) {
+				f.Index = append([]int{i}, f.Index...)
+				result = append(result, f)
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		f := Field{Index: []int{i}, Type: sf.Type, Name: sf.Name}
+		if json := strings.Split(sf.Tag.Get("json"), ",")[0]; json != "" && json != "-" {
+			f.Name = json
+		}
+		for i, option := range strings.Split(tag, ",") {
+			switch {
+			case i == 0 && option != "":
+				f.Name = option
+			case option == requiredOption:
+				f.Required = true
+			case strings.HasPrefix(option, aliasOption):
+				f.Aliases = append(f.Aliases, strings.TrimPrefix(option, aliasOption))
+			case strings.HasPrefix(option, deprecatedOption):
+				f.Deprecated = append(f.Deprecated, strings.TrimPrefix(option, deprecatedOption))
+			}
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// DecodeValue sets the value decoded as Decode does. Its error completes "invalid <name> value '<val>', ".
+func DecodeValue(v reflect.Value, val string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return DecodeValue(v.Elem(), val)
+	}
+
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(val)); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("must be a duration such as 5m")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(val, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(val, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a non-negative integer")
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(val, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		var values []string
+		for _, s := range strings.Split(val, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, s := range values {
+			slice.Index(i).SetString(s)
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type embedded struct {
+	Region string `metadata:"region"`
+}
+
+type testMetadata struct {
+	embedded
+	ConnectionString string        `metadata:"connectionString,alias=url,deprecated=connString"`
+	TableName        string        `json:"tableName"`
+	Enabled          bool          `metadata:"enabled"`
+	MaxConnections   int           `metadata:"maxConnections"`
+	Port             uint16        `metadata:"port"`
+	Ratio            float64       `metadata:"ratio"`
+	Timeout          time.Duration `metadata:"timeout"`
+	MaxSize          ByteSize      `metadata:"maxSize"`
+	Hosts            []string      `metadata:"hosts"`
+	KeyPrefix        *string       `metadata:"keyPrefix"`
+	Interval         *time.Duration
+	Ignored          string `metadata:"-"`
+	unexported       string
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("all types", func(t *testing.T) {
+		var m testMetadata
+		err := Decode(map[string]string{
+			"region":           "westus",
+			"connectionString": "host=localhost",
+			"tableName":        "orders",
+			"enabled":          "true",
+			"maxConnections":   "-3",
+			"port":             "5432",
+			"ratio":            "0.5",
+			"timeout":          "5m",
+			"maxSize":          "10Mi",
+			"hosts":            " a, b ,,c ",
+			"keyPrefix":        "",
+			"interval":         "0",
+			"Ignored":          "value",
+			"unexported":       "value",
+		}, &m)
+		require.NoError(t, err)
+
+		prefix, interval := "", time.Duration(0)
+		assert.Equal(t, testMetadata{
+			embedded:         embedded{Region: "westus"},
+			ConnectionString: "host=localhost",
+			TableName:        "orders",
+			Enabled:          true,
+			MaxConnections:   -3,
+			Port:             5432,
+			Ratio:            0.5,
+			Timeout:          5 * time.Minute,
+			MaxSize:          10 << 20,
+			Hosts:            []string{"a", "b", "c"},
+			KeyPrefix:        &prefix,
+			Interval:         &interval,
+		}, m)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		m := testMetadata{TableName: "state", Timeout: time.Second}
+		require.NoError(t, Decode(map[string]string{"tableName": "", "timeout": "", "interval": ""}, &m))
+		assert.Equal(t, "state", m.TableName)
+		assert.Equal(t, time.Second, m.Timeout)
+		assert.Nil(t, m.KeyPrefix)
+		assert.Nil(t, m.Interval)
+	})
+
+	t.Run("names", func(t *testing.T) {
+		var m testMetadata
+		require.NoError(t, Decode(map[string]string{"CONNECTIONSTRING": "upper", "TableName": "orders"}, &m))
+		assert.Equal(t, "upper", m.ConnectionString)
+		assert.Equal(t, "orders", m.TableName)
+
+		m = testMetadata{}
+		require.NoError(t, Decode(map[string]string{"url": "alias", "connString": "deprecated"}, &m))
+		assert.Equal(t, "alias", m.ConnectionString)
+
+		m = testMetadata{}
+		require.NoError(t, Decode(map[string]string{"connString": "deprecated"}, &m))
+		assert.Equal(t, "deprecated", m.ConnectionString)
+
+		// the name wins over its aliases, and the exact name over the others
+		m = testMetadata{}
+		require.NoError(t, Decode(map[string]string{"url": "alias", "connectionString": "name", "connectionstring": "other"}, &m))
+		assert.Equal(t, "name", m.ConnectionString)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for name, val := range map[string]string{
+			"enabled":        "yes please",
+			"maxConnections": "many",
+			"port":           "-1",
+			"ratio":          "half",
+			"timeout":        "30",
+			"maxSize":        "10 apples",
+			"interval":       "soon",
+		} {
+			var m testMetadata
+			err := Decode(map[string]string{name: val}, &m)
+			require.Error(t, err, name)
+			assert.Contains(t, err.Error(), "invalid "+name+" value '"+val+"'")
+		}
+
+		var m testMetadata
+		err := Decode(map[string]string{"connString": "x", "maxConnections": "1.5"}, &m)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid maxConnections value '1.5', must be an integer")
+	})
+
+	t.Run("invalid results", func(t *testing.T) {
+		var m testMetadata
+		assert.Error(t, Decode(map[string]string{}, m))
+		assert.Error(t, Decode(map[string]string{}, (*testMetadata)(nil)))
+
+		var unsupported struct {
+			Values map[string]string `metadata:"values"`
+		}
+		assert.Error(t, Decode(map[string]string{"values": "a=b"}, &unsupported))
+	})
+}
@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package sqlutil contains the helpers shared by the components storing their data in SQL databases.
+package sqlutil
+
+import (
+	"database/sql"
+	"encoding/json"
+	"unicode"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// IsValidName returns true if s is safe to interpolate into a query as an identifier. The names of the tables are
+// interpolated with Sprintf, as database/sql does not substitute parameters for them, so they must be validated
+// first; the other values of the queries are parameters.
+func IsValidName(s string) bool {
+	for _, c := range s {
+		if !(unicode.IsLetter(c) || unicode.IsNumber(c) || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// ETagCondition returns the etag that a write must match. With last-write concurrency the etag is ignored.
+func ETagCondition(etag string, concurrency string) string {
+	if concurrency == state.LastWrite {
+		return ""
+	}
+
+	return etag
+}
+
+// InTransaction runs fn in a transaction of db, which is committed when fn succeeds and rolled back otherwise.
+func InTransaction(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SerializeValue returns the JSON of a value for a JSON column. Byte values are stored as they are when they are
+// already JSON.
+func SerializeValue(value interface{}) (string, error) {
+	if b, ok := value.([]byte); ok && json.Valid(b) {
+		return string(b), nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(valueBytes), nil
+}
+
+// ParseTTL returns the number of seconds until a value expires from the metadata of a set request,
+// following the semantics of state.ParseTTL. The TTL is NULL when the value never expires.
+func ParseTTL(requestMetadata map[string]string, defaultTTL int) (sql.NullInt64, error) {
+	ttl, err := state.ParseTTL(requestMetadata, defaultTTL)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+
+	return sql.NullInt64{Int64: int64(ttl), Valid: ttl > 0}, nil
+}
@@ -0,0 +1,80 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sqlutil
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidName(t *testing.T) {
+	assert.True(t, IsValidName("state"))
+	assert.True(t, IsValidName("Dapr_State_01"))
+	assert.False(t, IsValidName("state`"))
+	assert.False(t, IsValidName(`state"`))
+	assert.False(t, IsValidName("my-state"))
+	assert.False(t, IsValidName("public.state"))
+	assert.False(t, IsValidName("state; DROP TABLE users"))
+}
+
+func TestETagCondition(t *testing.T) {
+	assert.Equal(t, "1", ETagCondition("1", ""))
+	assert.Equal(t, "1", ETagCondition("1", state.FirstWrite))
+	assert.Equal(t, "", ETagCondition("1", state.LastWrite))
+	assert.Equal(t, "", ETagCondition("", state.FirstWrite))
+}
+
+func TestSerializeValue(t *testing.T) {
+	value, err := SerializeValue(map[string]string{"color": "red"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"color":"red"}`, value)
+
+	value, err = SerializeValue([]byte(`{"color":"red"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"color":"red"}`, value)
+
+	value, err = SerializeValue("red")
+	assert.Nil(t, err)
+	assert.Equal(t, `"red"`, value)
+}
+
+func TestParseTTL(t *testing.T) {
+	t.Run("No TTL", func(t *testing.T) {
+		ttl, err := ParseTTL(map[string]string{}, 0)
+		assert.Nil(t, err)
+		assert.False(t, ttl.Valid)
+	})
+
+	t.Run("Positive TTL", func(t *testing.T) {
+		ttl, err := ParseTTL(map[string]string{state.TTLInSecondsKey: "60"}, 0)
+		assert.Nil(t, err)
+		assert.True(t, ttl.Valid)
+		assert.Equal(t, int64(60), ttl.Int64)
+	})
+
+	t.Run("Negative TTL never expires", func(t *testing.T) {
+		ttl, err := ParseTTL(map[string]string{state.TTLInSecondsKey: "-1"}, 0)
+		assert.Nil(t, err)
+		assert.False(t, ttl.Valid)
+	})
+
+	t.Run("Default TTL", func(t *testing.T) {
+		ttl, err := ParseTTL(map[string]string{}, 30)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(30), ttl.Int64)
+
+		ttl, err = ParseTTL(map[string]string{state.TTLInSecondsKey: "-1"}, 30)
+		assert.Nil(t, err)
+		assert.False(t, ttl.Valid)
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		_, err := ParseTTL(map[string]string{state.TTLInSecondsKey: "soon"}, 0)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,11 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package lock
+
+// Metadata contains a lock store specific set of metadata properties
+type Metadata struct {
+	Properties map[string]string `json:"properties"`
+}
@@ -0,0 +1,430 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	contribmetadata "github.com/dapr/components-contrib/internal/metadata"
+	"github.com/dapr/components-contrib/lock"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state/postgresql"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	leaseRenewalIntervalKey = "leaseRenewalInterval"
+
+	defaultKeyPrefix            = "lock||"
+	defaultLeaseRenewalInterval = time.Second * 10
+)
+
+// isLockedSQL returns whether another session holds the advisory lock of the bigint key, whose high and low 32 bits
+// are the classid and objid of the lock
+const isLockedSQL = `SELECT EXISTS (
+	SELECT 1 FROM pg_locks
+	WHERE locktype = 'advisory' AND granted AND objsubid = 1
+	AND database = (SELECT oid FROM pg_database WHERE datname = current_database())
+	AND classid::bigint = $1 AND objid::bigint = $2
+)`
+
+// session is a database session, which holds the advisory locks it acquires until they are released or it ends
+type session interface {
+	tryLock(ctx context.Context, key int64) (bool, error)
+	unlock(ctx context.Context, key int64) error
+	ping(ctx context.Context) error
+	// close ends the session, which releases its locks
+	close()
+}
+
+// heldLock is a lock acquired by this instance, with the session holding it
+type heldLock struct {
+	key     int64
+	owner   string
+	session session
+	expiry  *time.Timer
+
+	lock     sync.Mutex
+	released bool
+}
+
+// lockStore acquires the locks as session-level advisory locks with pg_try_advisory_lock, all held by one session
+// dedicated to them, so that the size of the connection pool doesn't limit the number of locks, and the locks are
+// released when the session ends. The lease of the locks is renewed by pinging the session, and the locks are lost
+// when it ends: the next lock opens a new session. The keys of the advisory locks are the 64-bit FNV-1a hashes of
+// the resource IDs.
+//
+// The locks are released by the instance that acquired them: another instance sees a lock it doesn't hold as
+// belonging to others, whatever its owner.
+type lockStore struct {
+	conn                 *postgresql.Conn
+	keyPrefix            string
+	leaseRenewalInterval time.Duration
+	timeout              time.Duration
+	newSession           func(ctx context.Context) (session, error)
+	isLocked             func(ctx context.Context, key int64) (bool, error)
+
+	// lock guards held, the locks acquired by this instance by resource ID, and reserved, the resource IDs being
+	// locked. It is never held while calling the database.
+	lock     sync.Mutex
+	held     map[string]*heldLock
+	reserved map[string]bool
+
+	// sessionLock guards session, the session holding the locks, and serializes its statements, which a
+	// connection doesn't run concurrently. stopRenewal stops the renewal of the lease of the session.
+	sessionLock sync.Mutex
+	session     session
+	stopRenewal chan struct{}
+
+	logger logger.Logger
+}
+
+// NewPostgresLockStore returns a new PostgreSQL lock store
+func NewPostgresLockStore(logger logger.Logger) lock.Store {
+	return &lockStore{logger: logger, held: map[string]*heldLock{}, reserved: map[string]bool{}}
+}
+
+// Init connects to PostgreSQL with the metadata of the PostgreSQL state store
+func (p *lockStore) Init(metadata lock.Metadata) error {
+	// the key prefix is a pointer as it may be set to empty
+	m := struct {
+		KeyPrefix            *string       `metadata:"keyPrefix"`
+		LeaseRenewalInterval time.Duration `metadata:"leaseRenewalInterval"`
+	}{LeaseRenewalInterval: defaultLeaseRenewalInterval}
+	if err := contribmetadata.Decode(metadata.Properties, &m); err != nil {
+		return fmt.Errorf("postgres lock error: %s", err)
+	}
+
+	p.keyPrefix = defaultKeyPrefix
+	if m.KeyPrefix != nil {
+		p.keyPrefix = *m.KeyPrefix
+	}
+
+	if m.LeaseRenewalInterval <= 0 {
+		return fmt.Errorf("postgres lock error: invalid %s %s", leaseRenewalIntervalKey, m.LeaseRenewalInterval)
+	}
+	p.leaseRenewalInterval = m.LeaseRenewalInterval
+
+	conn, err := postgresql.Connect(p.logger, metadata.Properties)
+	if err != nil {
+		return fmt.Errorf("postgres lock error: %s", err)
+	}
+	p.conn = conn
+	p.timeout = conn.Timeout()
+	p.newSession = func(ctx context.Context) (session, error) {
+		c, err := conn.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &pgSession{conn: c}, nil
+	}
+	p.isLocked = func(ctx context.Context, key int64) (bool, error) {
+		rows, err := conn.Query(ctx, isLockedSQL, int64(uint32(key>>32)), int64(uint32(key)))
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+
+		var locked bool
+		for rows.Next() {
+			if err = rows.Scan(&locked); err != nil {
+				return false, err
+			}
+		}
+		return locked, rows.Err()
+	}
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the PostgreSQL lock store, which are the connection fields of
+// the PostgreSQL state store with the settings of the locks
+func (p *lockStore) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := append(postgresql.ConnectionMetadataFields(),
+		metadata.Field{Name: "keyPrefix", Type: metadata.TypeString, Default: defaultKeyPrefix},
+		metadata.Field{Name: leaseRenewalIntervalKey, Type: metadata.TypeDuration, Default: defaultLeaseRenewalInterval.String()},
+	)
+	return metadata.ComponentMetadata{Fields: fields}
+}
+
+// advisoryKey returns the key of the advisory lock of the resource
+func (p *lockStore) advisoryKey(resourceID string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(p.keyPrefix + resourceID))
+	return int64(h.Sum64())
+}
+
+// TryLock acquires the advisory lock of the resource with the session of the locks, which holds it until it expires.
+// The resource ID is reserved while it is being locked, so that the store isn't locked while calling the database.
+func (p *lockStore) TryLock(req *lock.TryLockRequest) (*lock.TryLockResponse, error) {
+	if err := lock.CheckTryLockRequest(req); err != nil {
+		return nil, fmt.Errorf("postgres lock error: %s", err)
+	}
+
+	p.lock.Lock()
+	if _, ok := p.held[req.ResourceID]; ok || p.reserved[req.ResourceID] {
+		p.lock.Unlock()
+		return &lock.TryLockResponse{Success: false}, nil
+	}
+	p.reserved[req.ResourceID] = true
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		delete(p.reserved, req.ResourceID)
+		p.lock.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	key := p.advisoryKey(req.ResourceID)
+	var s session
+	var ok bool
+	err := p.withSession(ctx, func(current session) error {
+		var err error
+		s = current
+		ok, err = s.tryLock(ctx, key)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("postgres lock error: failed to lock %s: %s", req.ResourceID, err)
+	}
+	if !ok {
+		return &lock.TryLockResponse{Success: false}, nil
+	}
+
+	l := &heldLock{key: key, owner: req.LockOwner, session: s}
+	p.lock.Lock()
+	l.expiry = time.AfterFunc(time.Duration(req.ExpiryInSeconds)*time.Second, func() {
+		p.remove(req.ResourceID, l)
+		p.release(l)
+	})
+	p.held[req.ResourceID] = l
+	p.lock.Unlock()
+
+	return &lock.TryLockResponse{Success: true}, nil
+}
+
+// withSession runs fn with the session of the locks, which is opened when there is none. The session is ended when
+// fn fails, as the connection may be broken, which loses its locks.
+func (p *lockStore) withSession(ctx context.Context, fn func(s session) error) error {
+	p.sessionLock.Lock()
+	s := p.session
+	if s == nil {
+		var err error
+		s, err = p.newSession(ctx)
+		if err != nil {
+			p.sessionLock.Unlock()
+			return err
+		}
+		p.session = s
+		p.stopRenewal = make(chan struct{})
+		go p.renewLease(s, p.stopRenewal)
+	}
+	err := fn(s)
+	p.sessionLock.Unlock()
+
+	if err != nil {
+		p.endSession(s, err)
+	}
+	return err
+}
+
+// renewLease pings the session of the locks until it ends. The locks are lost when the session ends, as PostgreSQL
+// releases its advisory locks.
+func (p *lockStore) renewLease(s session, stop chan struct{}) {
+	ticker := time.NewTicker(p.leaseRenewalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.sessionLock.Lock()
+		var err error
+		if p.session == s {
+			ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+			err = s.ping(ctx)
+			cancel()
+		}
+		p.sessionLock.Unlock()
+
+		if err != nil {
+			p.endSession(s, err)
+			return
+		}
+	}
+}
+
+// endSession ends the session of the locks after it failed with err, unless it was ended already, and forgets the
+// locks it held
+func (p *lockStore) endSession(s session, err error) {
+	p.sessionLock.Lock()
+	if p.session != s {
+		p.sessionLock.Unlock()
+		return
+	}
+	p.session = nil
+	close(p.stopRenewal)
+	s.close()
+	p.sessionLock.Unlock()
+
+	p.lock.Lock()
+	var lost []*heldLock
+	for resourceID, l := range p.held {
+		if l.session == s {
+			lost = append(lost, l)
+			delete(p.held, resourceID)
+		}
+	}
+	p.lock.Unlock()
+
+	if len(lost) > 0 {
+		p.logger.Warnf("postgres lock: lost %d locks: %s", len(lost), err)
+	}
+	for _, l := range lost {
+		l.markReleased()
+	}
+}
+
+// remove removes the lock from the held locks, unless it was replaced already
+func (p *lockStore) remove(resourceID string, l *heldLock) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.held[resourceID] == l {
+		delete(p.held, resourceID)
+	}
+}
+
+// markReleased marks the lock as released and stops its expiry, and returns whether it was held until then
+func (l *heldLock) markReleased() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.released {
+		return false
+	}
+	l.released = true
+	l.expiry.Stop()
+	return true
+}
+
+// release unlocks the advisory lock. The session is ended when the unlock fails, which releases the lock anyway.
+func (p *lockStore) release(l *heldLock) {
+	if !l.markReleased() {
+		return
+	}
+
+	p.sessionLock.Lock()
+	var err error
+	if p.session == l.session {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		err = l.session.unlock(ctx, l.key)
+		cancel()
+	}
+	p.sessionLock.Unlock()
+
+	if err != nil {
+		p.logger.Debugf("postgres lock: unlock failed, ending the session: %s", err)
+		p.endSession(l.session, err)
+	}
+}
+
+// Unlock releases the lock of the resource when this instance holds it for the owner
+func (p *lockStore) Unlock(req *lock.UnlockRequest) (*lock.UnlockResponse, error) {
+	if err := lock.CheckUnlockRequest(req); err != nil {
+		return nil, fmt.Errorf("postgres lock error: %s", err)
+	}
+
+	p.lock.Lock()
+	l, ok := p.held[req.ResourceID]
+	if ok && l.owner == req.LockOwner {
+		delete(p.held, req.ResourceID)
+	}
+	p.lock.Unlock()
+
+	if ok {
+		if l.owner != req.LockOwner {
+			return &lock.UnlockResponse{Status: lock.LockBelongsToOthers}, nil
+		}
+		p.release(l)
+		return &lock.UnlockResponse{Status: lock.Success}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	locked, err := p.isLocked(ctx, p.advisoryKey(req.ResourceID))
+	if err != nil {
+		return &lock.UnlockResponse{Status: lock.InternalError}, fmt.Errorf("postgres lock error: failed to unlock %s: %s", req.ResourceID, err)
+	}
+	if locked {
+		return &lock.UnlockResponse{Status: lock.LockBelongsToOthers}, nil
+	}
+	return &lock.UnlockResponse{Status: lock.LockDoesNotExist}, nil
+}
+
+// Close releases the locks held by this instance, and ends the session of the locks and closes the connection pool
+func (p *lockStore) Close() error {
+	p.lock.Lock()
+	held := p.held
+	p.held = map[string]*heldLock{}
+	p.lock.Unlock()
+
+	for _, l := range held {
+		p.release(l)
+	}
+
+	p.sessionLock.Lock()
+	if p.session != nil {
+		close(p.stopRenewal)
+		p.session.close()
+		p.session = nil
+	}
+	p.sessionLock.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+// pgSession is a session of a connection taken from the pool for the locks, which is closed rather than returned to
+// the pool when the session ends, so that no lock outlives it
+type pgSession struct {
+	conn *pgxpool.Conn
+}
+
+func (s *pgSession) tryLock(ctx context.Context, key int64) (bool, error) {
+	var ok bool
+	err := s.conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&ok)
+	return ok, err
+}
+
+func (s *pgSession) unlock(ctx context.Context, key int64) error {
+	var ok bool
+	if err := s.conn.QueryRow(ctx, `SELECT pg_advisory_unlock($1)`, key).Scan(&ok); err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("the session doesn't hold the lock %d", key)
+	}
+	return nil
+}
+
+func (s *pgSession) ping(ctx context.Context) error {
+	return s.conn.Conn().Ping(ctx)
+}
+
+func (s *pgSession) close() {
+	s.conn.Conn().Close(context.Background())
+	s.conn.Release()
+}
@@ -0,0 +1,320 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/lock"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connectionStringEnvKey is the environment variable with the connection string of the integration tests
+const connectionStringEnvKey = "DAPR_TEST_POSTGRES_CONNSTRING"
+
+// fakeDatabase holds the advisory locks of the fake sessions
+type fakeDatabase struct {
+	lock   sync.Mutex
+	locks  map[int64]*fakeSession
+	pingOK bool
+}
+
+type fakeSession struct {
+	db     *fakeDatabase
+	closed bool
+}
+
+func (s *fakeSession) tryLock(ctx context.Context, key int64) (bool, error) {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+	if _, ok := s.db.locks[key]; ok {
+		return false, nil
+	}
+	s.db.locks[key] = s
+	return true, nil
+}
+
+func (s *fakeSession) unlock(ctx context.Context, key int64) error {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+	if s.db.locks[key] != s {
+		return errors.New("not locked")
+	}
+	delete(s.db.locks, key)
+	return nil
+}
+
+func (s *fakeSession) ping(ctx context.Context) error {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+	if !s.db.pingOK {
+		return errors.New("connection lost")
+	}
+	return nil
+}
+
+func (s *fakeSession) close() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+	s.closed = true
+	for k, holder := range s.db.locks {
+		if holder == s {
+			delete(s.db.locks, k)
+		}
+	}
+}
+
+func (db *fakeDatabase) isLocked(ctx context.Context, key int64) (bool, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	_, ok := db.locks[key]
+	return ok, nil
+}
+
+func (db *fakeDatabase) count() int {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	return len(db.locks)
+}
+
+func newFakeStore(db *fakeDatabase) *lockStore {
+	p := NewPostgresLockStore(logger.NewLogger("test")).(*lockStore)
+	p.keyPrefix = defaultKeyPrefix
+	p.leaseRenewalInterval = defaultLeaseRenewalInterval
+	p.timeout = time.Second
+	p.newSession = func(ctx context.Context) (session, error) {
+		return &fakeSession{db: db}, nil
+	}
+	p.isLocked = db.isLocked
+	return p
+}
+
+func TestInitInvalidMetadata(t *testing.T) {
+	p := NewPostgresLockStore(logger.NewLogger("test"))
+	assert.Error(t, p.Init(lock.Metadata{Properties: map[string]string{leaseRenewalIntervalKey: "10"}}))
+	assert.Error(t, p.Init(lock.Metadata{Properties: map[string]string{}}))
+}
+
+func TestAdvisoryKey(t *testing.T) {
+	p := &lockStore{keyPrefix: defaultKeyPrefix}
+	assert.Equal(t, p.advisoryKey("orders"), p.advisoryKey("orders"))
+	assert.NotEqual(t, p.advisoryKey("orders"), p.advisoryKey("payments"))
+
+	other := &lockStore{keyPrefix: "other||"}
+	assert.NotEqual(t, p.advisoryKey("orders"), other.advisoryKey("orders"))
+}
+
+func TestLock(t *testing.T) {
+	db := &fakeDatabase{locks: map[int64]*fakeSession{}, pingOK: true}
+	p := newFakeStore(db)
+	other := newFakeStore(db)
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := p.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app1"})
+		assert.Error(t, err)
+		_, err = p.Unlock(&lock.UnlockRequest{LockOwner: "app1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("lock and unlock", func(t *testing.T) {
+		res, err := p.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app1", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+
+		res, err = p.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app2", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+
+		// the advisory lock is held by the session of the first instance
+		res, err = other.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app2", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+
+		unlock, err := p.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app2"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockBelongsToOthers, unlock.Status)
+
+		unlock, err = other.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockBelongsToOthers, unlock.Status)
+
+		unlock, err = p.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.Success, unlock.Status)
+		assert.Equal(t, 0, db.count())
+
+		unlock, err = p.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockDoesNotExist, unlock.Status)
+
+		res, err = other.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app2", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+		require.NoError(t, other.Close())
+		assert.Equal(t, 0, db.count())
+	})
+
+	t.Run("expired lock", func(t *testing.T) {
+		res, err := p.TryLock(&lock.TryLockRequest{ResourceID: "payments", LockOwner: "app1", ExpiryInSeconds: 1})
+		require.NoError(t, err)
+		require.True(t, res.Success)
+
+		assert.Eventually(t, func() bool { return db.count() == 0 }, 5*time.Second, 50*time.Millisecond)
+		unlock, err := p.Unlock(&lock.UnlockRequest{ResourceID: "payments", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockDoesNotExist, unlock.Status)
+	})
+
+	t.Run("lost session", func(t *testing.T) {
+		p := newFakeStore(db)
+		p.leaseRenewalInterval = 50 * time.Millisecond
+
+		res, err := p.TryLock(&lock.TryLockRequest{ResourceID: "invoices", LockOwner: "app1", ExpiryInSeconds: 60})
+		require.NoError(t, err)
+		require.True(t, res.Success)
+
+		db.lock.Lock()
+		db.pingOK = false
+		db.lock.Unlock()
+		assert.Eventually(t, func() bool {
+			p.lock.Lock()
+			defer p.lock.Unlock()
+			return len(p.held) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+		assert.Equal(t, 0, db.count())
+	})
+}
+
+func TestLockSession(t *testing.T) {
+	t.Run("locks share one session", func(t *testing.T) {
+		db := &fakeDatabase{locks: map[int64]*fakeSession{}, pingOK: true}
+		p := newFakeStore(db)
+		sessions := 0
+		newSession := p.newSession
+		p.newSession = func(ctx context.Context) (session, error) {
+			sessions++
+			return newSession(ctx)
+		}
+
+		for i := 0; i < 10; i++ {
+			res, err := p.TryLock(&lock.TryLockRequest{ResourceID: fmt.Sprintf("order-%d", i), LockOwner: "app1", ExpiryInSeconds: 10})
+			require.NoError(t, err)
+			assert.True(t, res.Success)
+		}
+		assert.Equal(t, 1, sessions)
+		assert.Equal(t, 10, db.count())
+
+		require.NoError(t, p.Close())
+		assert.Equal(t, 0, db.count())
+	})
+
+	t.Run("opening the session doesn't block the store", func(t *testing.T) {
+		db := &fakeDatabase{locks: map[int64]*fakeSession{}, pingOK: true}
+		p := newFakeStore(db)
+		opening := make(chan struct{})
+		open := make(chan struct{})
+		newSession := p.newSession
+		p.newSession = func(ctx context.Context) (session, error) {
+			close(opening)
+			<-open
+			return newSession(ctx)
+		}
+
+		done := make(chan bool)
+		go func() {
+			res, err := p.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app1", ExpiryInSeconds: 10})
+			assert.NoError(t, err)
+			done <- err == nil && res.Success
+		}()
+		<-opening
+
+		// the resource is reserved while it is being locked
+		res, err := p.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app2", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+
+		unlock, err := p.Unlock(&lock.UnlockRequest{ResourceID: "payments", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockDoesNotExist, unlock.Status)
+
+		close(open)
+		assert.True(t, <-done)
+		require.NoError(t, p.Close())
+	})
+
+	t.Run("failed session is replaced", func(t *testing.T) {
+		db := &fakeDatabase{locks: map[int64]*fakeSession{}, pingOK: true}
+		p := newFakeStore(db)
+		var sessions []*fakeSession
+		p.newSession = func(ctx context.Context) (session, error) {
+			s := &fakeSession{db: db}
+			sessions = append(sessions, s)
+			return s, nil
+		}
+		res, err := p.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app1", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		require.True(t, res.Success)
+
+		// the unlock fails as another session took the advisory lock, which ends the session
+		db.lock.Lock()
+		db.locks[p.advisoryKey("orders")] = &fakeSession{db: db}
+		db.lock.Unlock()
+		unlock, err := p.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.Success, unlock.Status)
+		require.Len(t, sessions, 1)
+		assert.True(t, sessions[0].closed)
+
+		res, err = p.TryLock(&lock.TryLockRequest{ResourceID: "payments", LockOwner: "app1", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+		assert.Len(t, sessions, 2)
+		require.NoError(t, p.Close())
+	})
+}
+
+func TestPostgresIntegration(t *testing.T) {
+	connectionString := os.Getenv(connectionStringEnvKey)
+	if connectionString == "" {
+		t.Skipf("PostgreSQL lock integration tests skipped. To enable define the connection string using environment variable '%s'", connectionStringEnvKey)
+	}
+
+	metadata := lock.Metadata{Properties: map[string]string{"connectionString": connectionString}}
+	p := NewPostgresLockStore(logger.NewLogger("test")).(*lockStore)
+	require.NoError(t, p.Init(metadata))
+	defer p.Close()
+	other := NewPostgresLockStore(logger.NewLogger("test")).(*lockStore)
+	require.NoError(t, other.Init(metadata))
+	defer other.Close()
+
+	res, err := p.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app1", ExpiryInSeconds: 10})
+	require.NoError(t, err)
+	require.True(t, res.Success)
+
+	res, err = other.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app2", ExpiryInSeconds: 10})
+	require.NoError(t, err)
+	assert.False(t, res.Success)
+
+	unlock, err := other.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app2"})
+	require.NoError(t, err)
+	assert.Equal(t, lock.LockBelongsToOthers, unlock.Status)
+
+	unlock, err = p.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app1"})
+	require.NoError(t, err)
+	assert.Equal(t, lock.Success, unlock.Status)
+
+	unlock, err = other.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app2"})
+	require.NoError(t, err)
+	assert.Equal(t, lock.LockDoesNotExist, unlock.Status)
+}
@@ -0,0 +1,19 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import "time"
+
+type metadata struct {
+	host            string
+	password        string
+	enableTLS       bool
+	db              int
+	maxRetries      int
+	maxRetryBackoff time.Duration
+	// keyPrefix is the prefix of the keys of the locks, so that they don't collide with other keys of the database
+	keyPrefix string
+}
@@ -0,0 +1,195 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dapr/components-contrib/lock"
+	componentmetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/go-redis/redis/v7"
+)
+
+const (
+	host            = "redisHost"
+	password        = "redisPassword"
+	enableTLS       = "enableTLS"
+	redisDB         = "redisDB"
+	maxRetries      = "maxRetries"
+	maxRetryBackoff = "maxRetryBackoff"
+	keyPrefix       = "keyPrefix"
+
+	defaultMaxRetries      = 3
+	defaultMaxRetryBackoff = time.Second * 2
+	defaultKeyPrefix       = "lock||"
+)
+
+// unlockScript deletes the key of the lock when its value is the owner, atomically. It returns 1 when the lock is
+// released, -1 when it doesn't exist and -2 when it belongs to another owner.
+var unlockScript = redis.NewScript(`
+local owner = redis.call("GET", KEYS[1])
+if owner == false then
+	return -1
+end
+if owner ~= ARGV[1] then
+	return -2
+end
+return redis.call("DEL", KEYS[1])
+`)
+
+// lockStore holds the locks as keys with the owner as value, which are set with SET NX PX so that only one owner
+// holds a lock until it is released or expires. It is a single instance lock, which doesn't provide the guarantees of
+// Redlock over several instances.
+type lockStore struct {
+	metadata metadata
+	client   *redis.Client
+
+	logger logger.Logger
+}
+
+// NewRedisLockStore returns a new redis lock store
+func NewRedisLockStore(logger logger.Logger) lock.Store {
+	return &lockStore{logger: logger}
+}
+
+func parseRedisMetadata(meta lock.Metadata) (metadata, error) {
+	m := metadata{}
+	if val, ok := meta.Properties[host]; ok && val != "" {
+		m.host = val
+	} else {
+		return m, errors.New("redis lock error: missing host address")
+	}
+
+	if val, ok := meta.Properties[password]; ok && val != "" {
+		m.password = val
+	}
+
+	if val, ok := meta.Properties[enableTLS]; ok && val != "" {
+		tls, err := strconv.ParseBool(val)
+		if err != nil {
+			return m, fmt.Errorf("redis lock error: can't parse enableTLS field: %s", err)
+		}
+		m.enableTLS = tls
+	}
+
+	if val, ok := meta.Properties[redisDB]; ok && val != "" {
+		db, err := strconv.Atoi(val)
+		if err != nil || db < 0 {
+			return m, fmt.Errorf("redis lock error: invalid redisDB %s", val)
+		}
+		m.db = db
+	}
+
+	m.maxRetries = defaultMaxRetries
+	if val, ok := meta.Properties[maxRetries]; ok && val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return m, fmt.Errorf("redis lock error: can't parse maxRetries field: %s", err)
+		}
+		m.maxRetries = n
+	}
+
+	m.maxRetryBackoff = defaultMaxRetryBackoff
+	if val, ok := meta.Properties[maxRetryBackoff]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return m, fmt.Errorf("redis lock error: can't parse maxRetryBackoff field: %s", err)
+		}
+		m.maxRetryBackoff = d
+	}
+
+	m.keyPrefix = defaultKeyPrefix
+	if val, ok := meta.Properties[keyPrefix]; ok {
+		m.keyPrefix = val
+	}
+
+	return m, nil
+}
+
+func (r *lockStore) Init(metadata lock.Metadata) error {
+	m, err := parseRedisMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	r.metadata = m
+
+	options := &redis.Options{
+		Addr:            m.host,
+		Password:        m.password,
+		DB:              m.db,
+		MaxRetries:      m.maxRetries,
+		MaxRetryBackoff: m.maxRetryBackoff,
+	}
+
+	/* #nosec */
+	if m.enableTLS {
+		options.TLSConfig = &tls.Config{
+			InsecureSkipVerify: m.enableTLS,
+		}
+	}
+
+	client := redis.NewClient(options)
+	if _, err = client.Ping().Result(); err != nil {
+		return fmt.Errorf("redis lock: error connecting to redis at %s: %s", m.host, err)
+	}
+	r.client = client
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the Redis lock store
+func (r *lockStore) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: host, Type: componentmetadata.TypeString, Required: true},
+			{Name: password, Type: componentmetadata.TypeString},
+			{Name: enableTLS, Type: componentmetadata.TypeBool},
+			{Name: redisDB, Type: componentmetadata.TypeInt, Default: "0"},
+			{Name: maxRetries, Type: componentmetadata.TypeInt, Default: strconv.Itoa(defaultMaxRetries)},
+			{Name: maxRetryBackoff, Type: componentmetadata.TypeDuration, Default: defaultMaxRetryBackoff.String()},
+			{Name: keyPrefix, Type: componentmetadata.TypeString, Default: defaultKeyPrefix},
+		},
+	}
+}
+
+// TryLock sets the key of the resource to the owner when the key doesn't exist
+func (r *lockStore) TryLock(req *lock.TryLockRequest) (*lock.TryLockResponse, error) {
+	if err := lock.CheckTryLockRequest(req); err != nil {
+		return nil, fmt.Errorf("redis lock error: %s", err)
+	}
+
+	expiry := time.Duration(req.ExpiryInSeconds) * time.Second
+	ok, err := r.client.SetNX(r.metadata.keyPrefix+req.ResourceID, req.LockOwner, expiry).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis lock error: failed to lock %s: %s", req.ResourceID, err)
+	}
+	return &lock.TryLockResponse{Success: ok}, nil
+}
+
+// Unlock deletes the key of the resource when it belongs to the owner
+func (r *lockStore) Unlock(req *lock.UnlockRequest) (*lock.UnlockResponse, error) {
+	if err := lock.CheckUnlockRequest(req); err != nil {
+		return nil, fmt.Errorf("redis lock error: %s", err)
+	}
+
+	res, err := unlockScript.Run(r.client, []string{r.metadata.keyPrefix + req.ResourceID}, req.LockOwner).Int()
+	if err != nil {
+		return &lock.UnlockResponse{Status: lock.InternalError}, fmt.Errorf("redis lock error: failed to unlock %s: %s", req.ResourceID, err)
+	}
+
+	switch res {
+	case -1:
+		return &lock.UnlockResponse{Status: lock.LockDoesNotExist}, nil
+	case -2:
+		return &lock.UnlockResponse{Status: lock.LockBelongsToOthers}, nil
+	default:
+		return &lock.UnlockResponse{Status: lock.Success}, nil
+	}
+}
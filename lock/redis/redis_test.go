@@ -0,0 +1,111 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dapr/components-contrib/lock"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedisMetadata(t *testing.T) {
+	t.Run("metadata is correct", func(t *testing.T) {
+		m, err := parseRedisMetadata(lock.Metadata{Properties: map[string]string{
+			host:      "fake.redis.com",
+			password:  "fakePassword",
+			enableTLS: "true",
+			redisDB:   "1",
+		}})
+
+		require.NoError(t, err)
+		assert.Equal(t, "fake.redis.com", m.host)
+		assert.Equal(t, "fakePassword", m.password)
+		assert.True(t, m.enableTLS)
+		assert.Equal(t, 1, m.db)
+		assert.Equal(t, defaultMaxRetries, m.maxRetries)
+		assert.Equal(t, defaultMaxRetryBackoff, m.maxRetryBackoff)
+		assert.Equal(t, defaultKeyPrefix, m.keyPrefix)
+	})
+
+	t.Run("empty key prefix", func(t *testing.T) {
+		m, err := parseRedisMetadata(lock.Metadata{Properties: map[string]string{host: "fake.redis.com", keyPrefix: ""}})
+		require.NoError(t, err)
+		assert.Equal(t, "", m.keyPrefix)
+	})
+
+	t.Run("invalid metadata", func(t *testing.T) {
+		for _, properties := range []map[string]string{
+			{},
+			{host: "fake.redis.com", enableTLS: "yes please"},
+			{host: "fake.redis.com", redisDB: "db"},
+			{host: "fake.redis.com", maxRetries: "many"},
+			{host: "fake.redis.com", maxRetryBackoff: "2"},
+		} {
+			_, err := parseRedisMetadata(lock.Metadata{Properties: properties})
+			assert.Error(t, err, properties)
+		}
+	})
+}
+
+func TestLock(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	store := NewRedisLockStore(logger.NewLogger("test"))
+	require.NoError(t, store.Init(lock.Metadata{Properties: map[string]string{host: s.Addr()}}))
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := store.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app1"})
+		assert.Error(t, err)
+		_, err = store.Unlock(&lock.UnlockRequest{ResourceID: "orders"})
+		assert.Error(t, err)
+	})
+
+	t.Run("lock and unlock", func(t *testing.T) {
+		res, err := store.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app1", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+		assert.Equal(t, 10*time.Second, s.TTL(defaultKeyPrefix+"orders"))
+
+		// the lock belongs to app1 until it is released
+		res, err = store.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app2", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.False(t, res.Success)
+
+		unlock, err := store.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app2"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockBelongsToOthers, unlock.Status)
+
+		unlock, err = store.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.Success, unlock.Status)
+
+		unlock, err = store.Unlock(&lock.UnlockRequest{ResourceID: "orders", LockOwner: "app1"})
+		require.NoError(t, err)
+		assert.Equal(t, lock.LockDoesNotExist, unlock.Status)
+
+		res, err = store.TryLock(&lock.TryLockRequest{ResourceID: "orders", LockOwner: "app2", ExpiryInSeconds: 10})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+	})
+
+	t.Run("expired lock", func(t *testing.T) {
+		res, err := store.TryLock(&lock.TryLockRequest{ResourceID: "payments", LockOwner: "app1", ExpiryInSeconds: 1})
+		require.NoError(t, err)
+		require.True(t, res.Success)
+
+		s.FastForward(2 * time.Second)
+		res, err = store.TryLock(&lock.TryLockRequest{ResourceID: "payments", LockOwner: "app2", ExpiryInSeconds: 1})
+		require.NoError(t, err)
+		assert.True(t, res.Success)
+	})
+}
@@ -0,0 +1,48 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package lock
+
+import "errors"
+
+// TryLockRequest is the request to acquire the lock of a resource
+type TryLockRequest struct {
+	ResourceID string `json:"resourceId"`
+	// LockOwner identifies the owner of the lock, which is the only one that can release it
+	LockOwner string `json:"lockOwner"`
+	// ExpiryInSeconds is how long the lock is held when it is not released
+	ExpiryInSeconds int32 `json:"expiryInSeconds"`
+}
+
+// UnlockRequest is the request to release the lock of a resource
+type UnlockRequest struct {
+	ResourceID string `json:"resourceId"`
+	LockOwner  string `json:"lockOwner"`
+}
+
+// CheckTryLockRequest checks that the request has a resource, an owner and a positive expiry
+func CheckTryLockRequest(req *TryLockRequest) error {
+	if req.ResourceID == "" {
+		return errors.New("missing resource ID")
+	}
+	if req.LockOwner == "" {
+		return errors.New("missing lock owner")
+	}
+	if req.ExpiryInSeconds <= 0 {
+		return errors.New("expiry must be positive")
+	}
+	return nil
+}
+
+// CheckUnlockRequest checks that the request has a resource and an owner
+func CheckUnlockRequest(req *UnlockRequest) error {
+	if req.ResourceID == "" {
+		return errors.New("missing resource ID")
+	}
+	if req.LockOwner == "" {
+		return errors.New("missing lock owner")
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package lock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTryLockRequest(t *testing.T) {
+	assert.NoError(t, CheckTryLockRequest(&TryLockRequest{ResourceID: "orders", LockOwner: "app", ExpiryInSeconds: 10}))
+	assert.Error(t, CheckTryLockRequest(&TryLockRequest{LockOwner: "app", ExpiryInSeconds: 10}))
+	assert.Error(t, CheckTryLockRequest(&TryLockRequest{ResourceID: "orders", ExpiryInSeconds: 10}))
+	assert.Error(t, CheckTryLockRequest(&TryLockRequest{ResourceID: "orders", LockOwner: "app"}))
+}
+
+func TestCheckUnlockRequest(t *testing.T) {
+	assert.NoError(t, CheckUnlockRequest(&UnlockRequest{ResourceID: "orders", LockOwner: "app"}))
+	assert.Error(t, CheckUnlockRequest(&UnlockRequest{LockOwner: "app"}))
+	assert.Error(t, CheckUnlockRequest(&UnlockRequest{ResourceID: "orders"}))
+}
@@ -0,0 +1,30 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package lock
+
+// TryLockResponse is the response of a TryLockRequest, which succeeds when the owner holds the lock
+type TryLockResponse struct {
+	Success bool `json:"success"`
+}
+
+// Status is the status of an UnlockRequest
+type Status int32
+
+const (
+	// Success is the status of the locks that were released
+	Success Status = 0
+	// LockDoesNotExist is the status of the locks that expired or were released already
+	LockDoesNotExist Status = 1
+	// LockBelongsToOthers is the status of the locks held by another owner, which are not released
+	LockBelongsToOthers Status = 2
+	// InternalError is the status of the locks the store failed to release
+	InternalError Status = 3
+)
+
+// UnlockResponse is the response of an UnlockRequest
+type UnlockResponse struct {
+	Status Status `json:"status"`
+}
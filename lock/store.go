@@ -0,0 +1,21 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package lock
+
+import "github.com/dapr/components-contrib/metadata"
+
+// Store is the interface for a component that handles distributed locks
+type Store interface {
+	// Init initializes the store with its metadata
+	Init(metadata Metadata) error
+	// TryLock acquires the lock of the resource for the owner until it expires, and doesn't wait when the lock belongs
+	// to another owner
+	TryLock(req *TryLockRequest) (*TryLockResponse, error)
+	// Unlock releases the lock of the resource when it belongs to the owner
+	Unlock(req *UnlockRequest) (*UnlockResponse, error)
+	// GetComponentMetadata returns the metadata fields and the capabilities of the store
+	GetComponentMetadata() metadata.ComponentMetadata
+}
@@ -0,0 +1,223 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package metadata describes the metadata and the capabilities of the components, which are returned by their
+// GetComponentMetadata method to validate their metadata and to document them.
+package metadata
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	contribmetadata "github.com/dapr/components-contrib/internal/metadata"
+)
+
+// FieldType is the type of the value of a metadata field
+type FieldType string
+
+// The types of the metadata fields
+const (
+	TypeString FieldType = "string"
+	TypeBool   FieldType = "bool"
+	TypeInt    FieldType = "int"
+	TypeFloat  FieldType = "float"
+	// TypeDuration is a duration such as 5m
+	TypeDuration FieldType = "duration"
+	// TypeByteSize is a number of bytes with an optional unit, such as 10Mi
+	TypeByteSize FieldType = "bytesize"
+	// TypeList is a list of strings separated by commas
+	TypeList FieldType = "list"
+)
+
+// Capability is a feature that a component supports
+type Capability string
+
+// The capabilities of the components
+const (
+	// CapabilityETag is the optimistic concurrency of the state stores with ETags
+	CapabilityETag Capability = "ETAG"
+	// CapabilityTTL is the expiry of the values, or of the messages, with the ttlInSeconds metadata
+	CapabilityTTL Capability = "TTL"
+	// CapabilityQuery is the query of the state stores implementing state.Querier
+	CapabilityQuery Capability = "QUERY"
+	// CapabilityTransactional is the transactions of the state stores implementing state.TransactionalStore
+	CapabilityTransactional Capability = "TRANSACTIONAL"
+)
+
+// Field is a metadata field of a component
+type Field struct {
+	Name string    `json:"name"`
+	Type FieldType `json:"type"`
+	// Default is the value of the field when it is not set, if any
+	Default  string `json:"default,omitempty"`
+	Required bool   `json:"required,omitempty"`
+	// Aliases are the other names of the field
+	Aliases []string `json:"aliases,omitempty"`
+	// Deprecated are the former names of the field, which are still accepted
+	Deprecated []string `json:"deprecated,omitempty"`
+}
+
+// ComponentMetadata is the metadata fields and capabilities of a component
+type ComponentMetadata struct {
+	Fields       []Field      `json:"fields"`
+	Capabilities []Capability `json:"capabilities,omitempty"`
+}
+
+// HasCapability returns whether the component has the capability
+func (m ComponentMetadata) HasCapability(capability Capability) bool {
+	for _, c := range m.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutCapability returns the metadata without the capability, for the wrappers of the components that can't
+// provide it
+func (m ComponentMetadata) WithoutCapability(capability Capability) ComponentMetadata {
+	capabilities := make([]Capability, 0, len(m.Capabilities))
+	for _, c := range m.Capabilities {
+		if c != capability {
+			capabilities = append(capabilities, c)
+		}
+	}
+	m.Capabilities = capabilities
+	return m
+}
+
+// Validate returns an error when a required field isn't set, or when the value of a field isn't of its type. The
+// names of the fields are matched regardless of case, and the properties which aren't fields are ignored.
+func (m ComponentMetadata) Validate(properties map[string]string) error {
+	values := make(map[string]string, len(properties))
+	for name, val := range properties {
+		values[strings.ToLower(name)] = val
+	}
+
+	for _, f := range m.Fields {
+		var val string
+		for _, name := range append(append([]string{f.Name}, f.Aliases...), f.Deprecated...) {
+			if val = values[strings.ToLower(name)]; val != "" {
+				break
+			}
+		}
+		if val == "" {
+			if f.Required {
+				return fmt.Errorf("missing %s metadata", f.Name)
+			}
+			continue
+		}
+
+		if err := validateValue(f.Type, val); err != nil {
+			return fmt.Errorf("invalid %s value '%s', %s", f.Name, val, err)
+		}
+	}
+	return nil
+}
+
+func validateValue(typ FieldType, val string) error {
+	var v interface{}
+	switch typ {
+	case TypeBool:
+		v = new(bool)
+	case TypeInt:
+		v = new(int64)
+	case TypeFloat:
+		v = new(float64)
+	case TypeDuration:
+		v = new(time.Duration)
+	case TypeByteSize:
+		v = new(contribmetadata.ByteSize)
+	default:
+		return nil
+	}
+	return contribmetadata.DecodeValue(reflect.ValueOf(v).Elem(), val)
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	byteSizeType        = reflect.TypeOf(contribmetadata.ByteSize(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// FieldsOf returns the fields of a metadata struct decoded by the internal/metadata package, with their metadata
+// tags. The defaults are the values of the fields of the struct, which is usually set to the defaults of the
+// component.
+func FieldsOf(metadataStruct interface{}) []Field {
+	v := reflect.Indirect(reflect.ValueOf(metadataStruct))
+	var fields []Field
+	for _, f := range contribmetadata.Fields(v.Type()) {
+		fields = append(fields, Field{
+			Name:       f.Name,
+			Type:       fieldType(f.Type),
+			Default:    defaultValue(v.FieldByIndex(f.Index)),
+			Required:   f.Required,
+			Aliases:    f.Aliases,
+			Deprecated: f.Deprecated,
+		})
+	}
+	return fields
+}
+
+func fieldType(t reflect.Type) FieldType {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		return TypeDuration
+	case t == byteSizeType:
+		return TypeByteSize
+	case reflect.PtrTo(t).Implements(textUnmarshalerType):
+		return TypeString
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return TypeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return TypeInt
+	case reflect.Float32, reflect.Float64:
+		return TypeFloat
+	case reflect.Slice:
+		return TypeList
+	default:
+		return TypeString
+	}
+}
+
+// defaultValue returns the value of the field as a metadata property, or empty when it is zero
+func defaultValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.IsZero() {
+		return ""
+	}
+
+	switch {
+	case v.Type() == durationType:
+		return time.Duration(v.Int()).String()
+	case v.Type() == byteSizeType:
+		return strconv.FormatInt(v.Int(), 10)
+	case v.Kind() == reflect.Slice:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = fmt.Sprint(v.Index(i).Interface())
+		}
+		return strings.Join(values, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
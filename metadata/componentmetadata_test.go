@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	contribmetadata "github.com/dapr/components-contrib/internal/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMetadata struct {
+	ConnectionString string                   `metadata:"connectionString,required,alias=url,deprecated=connString"`
+	TableName        string                   `json:"tableName"`
+	Bucket           string                   `json:"bucket" metadata:",required"`
+	Enabled          bool                     `metadata:"enabled"`
+	MaxConnections   *int                     `metadata:"maxConnections"`
+	Timeout          time.Duration            `metadata:"timeout"`
+	MaxSize          contribmetadata.ByteSize `metadata:"maxSize"`
+	Ratio            float64                  `metadata:"ratio"`
+	Hosts            []string                 `metadata:"hosts"`
+}
+
+func TestFieldsOf(t *testing.T) {
+	fields := FieldsOf(testMetadata{TableName: "state", Timeout: 20 * time.Second, MaxSize: 1024, Hosts: []string{"a", "b"}})
+	assert.Equal(t, []Field{
+		{Name: "connectionString", Type: TypeString, Required: true, Aliases: []string{"url"}, Deprecated: []string{"connString"}},
+		{Name: "tableName", Type: TypeString, Default: "state"},
+		{Name: "bucket", Type: TypeString, Required: true},
+		{Name: "enabled", Type: TypeBool},
+		{Name: "maxConnections", Type: TypeInt},
+		{Name: "timeout", Type: TypeDuration, Default: "20s"},
+		{Name: "maxSize", Type: TypeByteSize, Default: "1024"},
+		{Name: "ratio", Type: TypeFloat},
+		{Name: "hosts", Type: TypeList, Default: "a,b"},
+	}, fields)
+
+	assert.Equal(t, fields, FieldsOf(&testMetadata{TableName: "state", Timeout: 20 * time.Second, MaxSize: 1024, Hosts: []string{"a", "b"}}))
+}
+
+func TestComponentMetadata(t *testing.T) {
+	m := ComponentMetadata{Fields: FieldsOf(testMetadata{}), Capabilities: []Capability{CapabilityETag, CapabilityTTL}}
+
+	t.Run("capabilities", func(t *testing.T) {
+		assert.True(t, m.HasCapability(CapabilityETag))
+		assert.True(t, m.HasCapability(CapabilityTTL))
+		assert.False(t, m.HasCapability(CapabilityQuery))
+
+		without := m.WithoutCapability(CapabilityETag)
+		assert.False(t, without.HasCapability(CapabilityETag))
+		assert.True(t, without.HasCapability(CapabilityTTL))
+		assert.True(t, m.HasCapability(CapabilityETag))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, m.Validate(map[string]string{"connectionString": "host=localhost", "bucket": "b", "other": "ignored"}))
+		assert.NoError(t, m.Validate(map[string]string{"URL": "host=localhost", "Bucket": "b", "timeout": "", "maxSize": "10Mi"}))
+		assert.NoError(t, m.Validate(map[string]string{"connString": "host=localhost", "bucket": "b", "enabled": "true", "ratio": "0.5"}))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := m.Validate(map[string]string{"bucket": "b"})
+		if assert.Error(t, err) {
+			assert.Equal(t, "missing connectionString metadata", err.Error())
+		}
+
+		for name, val := range map[string]string{"enabled": "maybe", "maxConnections": "many", "timeout": "30", "maxSize": "big", "ratio": "half"} {
+			err := m.Validate(map[string]string{"connectionString": "host=localhost", "bucket": "b", name: val})
+			if assert.Error(t, err, name) {
+				assert.Contains(t, err.Error(), "invalid "+name+" value '"+val+"'")
+			}
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// OperationKey identifies the operations of a component
+type OperationKey struct {
+	Component string
+	Operation string
+}
+
+// OperationStats are the aggregated metrics of the operations of a component
+type OperationStats struct {
+	Count        int64
+	Errors       int64
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// ErrorRate returns the ratio of the operations which failed, from 0 to 1
+func (s OperationStats) ErrorRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Count)
+}
+
+// AverageLatency returns the average latency of the operations
+func (s OperationStats) AverageLatency() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.Count)
+}
+
+// Collector is a Recorder aggregating the metrics in memory, so that the health of the components can be read from
+// their stats
+type Collector struct {
+	stats map[OperationKey]OperationStats
+	lock  sync.Mutex
+}
+
+// NewCollector returns an empty collector
+func NewCollector() *Collector {
+	return &Collector{stats: map[OperationKey]OperationStats{}}
+}
+
+// RecordOperation aggregates the metrics of the operation
+func (c *Collector) RecordOperation(component, operation string, latency time.Duration, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := OperationKey{Component: component, Operation: operation}
+	s := c.stats[key]
+	s.Count++
+	if err != nil {
+		s.Errors++
+	}
+	s.TotalLatency += latency
+	if latency > s.MaxLatency {
+		s.MaxLatency = latency
+	}
+	c.stats[key] = s
+}
+
+// Stats returns a copy of the stats of the operations recorded so far
+func (c *Collector) Stats() map[OperationKey]OperationStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stats := make(map[OperationKey]OperationStats, len(c.stats))
+	for k, v := range c.stats {
+		stats[k] = v
+	}
+	return stats
+}
@@ -0,0 +1,48 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	c.RecordOperation("statestore", "get", time.Millisecond, nil)
+	c.RecordOperation("statestore", "get", 3*time.Millisecond, errors.New("timeout"))
+	c.RecordOperation("statestore", "set", time.Millisecond, nil)
+
+	stats := c.Stats()
+	assert.Len(t, stats, 2)
+
+	get := stats[OperationKey{Component: "statestore", Operation: "get"}]
+	assert.Equal(t, OperationStats{Count: 2, Errors: 1, TotalLatency: 4 * time.Millisecond, MaxLatency: 3 * time.Millisecond}, get)
+	assert.Equal(t, 0.5, get.ErrorRate())
+	assert.Equal(t, 2*time.Millisecond, get.AverageLatency())
+
+	assert.Equal(t, 0.0, OperationStats{}.ErrorRate())
+	assert.Equal(t, time.Duration(0), OperationStats{}.AverageLatency())
+}
+
+func TestMeasure(t *testing.T) {
+	c := NewCollector()
+	failure := errors.New("failed")
+
+	assert.NoError(t, Measure(c, "pubsub", "publish", func() error {
+		return nil
+	}))
+	assert.Equal(t, failure, Measure(c, "pubsub", "publish", func() error {
+		return failure
+	}))
+
+	stats := c.Stats()[OperationKey{Component: "pubsub", Operation: "publish"}]
+	assert.Equal(t, int64(2), stats.Count)
+	assert.Equal(t, int64(1), stats.Errors)
+}
@@ -0,0 +1,23 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package metrics
+
+import "time"
+
+// Recorder receives the metrics of the operations of the components wrapped for instrumentation, e.g. to export
+// them to Prometheus
+type Recorder interface {
+	// RecordOperation records an operation of the component, with its latency and its error, nil when it succeeded
+	RecordOperation(component, operation string, latency time.Duration, err error)
+}
+
+// Measure calls operation and records its latency and its error, which it returns
+func Measure(recorder Recorder, component, operation string, op func() error) error {
+	start := time.Now()
+	err := op()
+	recorder.RecordOperation(component, operation, time.Since(start), err)
+	return err
+}
@@ -7,6 +7,7 @@ package bearer
 
 import (
 	"encoding/json"
+	"errors"
 
 	"context"
 	"strings"
@@ -20,6 +21,13 @@ import (
 type bearerMiddlewareMetadata struct {
 	IssuerURL string `json:"issuerURL"`
 	ClientID  string `json:"clientID"`
+
+	// Audience is the expected audience of the tokens, the client ID by default
+	Audience string `json:"audience"`
+	// JWKSURL is the URL of the keys of the issuer, to skip the discovery of the issuer
+	JWKSURL string `json:"jwksURL"`
+	// SigningAlgorithms is the comma separated list of accepted signing algorithms, RS256 by default
+	SigningAlgorithms string `json:"signingAlgorithms"`
 }
 
 // NewBearerMiddleware returns a new oAuth2 middleware
@@ -45,14 +53,22 @@ func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.R
 		return nil, err
 	}
 
-	provider, err := oidc.NewProvider(context.Background(), meta.IssuerURL)
-	if err != nil {
-		return nil, err
+	config := &oidc.Config{
+		ClientID: meta.Audience,
+	}
+	config.SupportedSigningAlgs = signingAlgorithms(meta.SigningAlgorithms)
+
+	// The issuer, audience and expiry of the tokens are verified, and their signatures with the keys of the issuer
+	var verifier *oidc.IDTokenVerifier
+	if meta.JWKSURL != "" {
+		verifier = oidc.NewVerifier(meta.IssuerURL, oidc.NewRemoteKeySet(context.Background(), meta.JWKSURL), config)
+	} else {
+		provider, err := oidc.NewProvider(context.Background(), meta.IssuerURL)
+		if err != nil {
+			return nil, err
+		}
+		verifier = provider.Verifier(config)
 	}
-
-	verifier := provider.Verifier(&oidc.Config{
-		ClientID: meta.ClientID,
-	})
 
 	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
 		return func(ctx *fasthttp.RequestCtx) {
@@ -64,6 +80,7 @@ func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.R
 			rawToken := authHeader[bearerPrefixLength:]
 			_, err := verifier.Verify(ctx, rawToken)
 			if err != nil {
+				m.logger.Debugf("bearer middleware: invalid token: %s", err)
 				ctx.Error(fasthttp.StatusMessage(fasthttp.StatusUnauthorized), fasthttp.StatusUnauthorized)
 				return
 			}
@@ -73,6 +90,20 @@ func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.R
 	}, nil
 }
 
+// signingAlgorithms returns the algorithms of a list separated by commas, or nil for the default algorithms when the
+// list is empty
+func signingAlgorithms(list string) []string {
+	var algorithms []string
+	for _, algorithm := range strings.Split(list, ",") {
+		algorithm = strings.TrimSpace(algorithm)
+		if algorithm != "" {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+
+	return algorithms
+}
+
 func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*bearerMiddlewareMetadata, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
@@ -84,5 +115,15 @@ func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*bearerMid
 	if err != nil {
 		return nil, err
 	}
+
+	if middlewareMetadata.IssuerURL == "" {
+		return nil, errors.New("bearer middleware error: missing issuerURL")
+	}
+	if middlewareMetadata.Audience == "" {
+		middlewareMetadata.Audience = middlewareMetadata.ClientID
+	}
+	if middlewareMetadata.Audience == "" {
+		return nil, errors.New("bearer middleware error: missing clientID or audience")
+	}
 	return &middlewareMetadata, nil
 }
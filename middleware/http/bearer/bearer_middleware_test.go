@@ -0,0 +1,131 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bearer
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := NewBearerMiddleware(logger.NewLogger("test"))
+
+	meta, err := m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"issuerURL": "https://issuer", "clientID": "client"}})
+	require.NoError(t, err)
+	assert.Equal(t, "client", meta.Audience)
+
+	meta, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"issuerURL": "https://issuer", "clientID": "client", "audience": "api"}})
+	require.NoError(t, err)
+	assert.Equal(t, "api", meta.Audience)
+
+	_, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"clientID": "client"}})
+	assert.Error(t, err)
+	_, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"issuerURL": "https://issuer"}})
+	assert.Error(t, err)
+}
+
+func TestSigningAlgorithms(t *testing.T) {
+	assert.Nil(t, signingAlgorithms(""))
+	assert.Nil(t, signingAlgorithms(" , "))
+	assert.Equal(t, []string{"RS256"}, signingAlgorithms("RS256"))
+	assert.Equal(t, []string{"RS256", "ES256"}, signingAlgorithms(" RS256, ES256 ,"))
+}
+
+func TestHandler(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	var issuer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                 issuer,
+				"jwks_uri":               issuer + "/keys",
+				"authorization_endpoint": issuer + "/authorize",
+				"token_endpoint":         issuer + "/token",
+			})
+		case "/keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []map[string]string{{
+					"kty": "RSA",
+					"kid": "key",
+					"alg": "RS256",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	issuer = server.URL
+
+	sign := func(claims jwt.MapClaims, signingKey *rsa.PrivateKey) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "key"
+		s, err := token.SignedString(signingKey)
+		require.NoError(t, err)
+		return s
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	expires := time.Now().Add(time.Hour).Unix()
+
+	testCases := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{name: "Valid token", header: "Bearer " + sign(jwt.MapClaims{"iss": issuer, "aud": "api", "exp": expires}, key), status: fasthttp.StatusOK},
+		{name: "Missing token", header: "", status: fasthttp.StatusUnauthorized},
+		{name: "Wrong audience", header: "Bearer " + sign(jwt.MapClaims{"iss": issuer, "aud": "other", "exp": expires}, key), status: fasthttp.StatusUnauthorized},
+		{name: "Wrong issuer", header: "Bearer " + sign(jwt.MapClaims{"iss": "https://other", "aud": "api", "exp": expires}, key), status: fasthttp.StatusUnauthorized},
+		{name: "Expired", header: "Bearer " + sign(jwt.MapClaims{"iss": issuer, "aud": "api", "exp": time.Now().Add(-time.Hour).Unix()}, key), status: fasthttp.StatusUnauthorized},
+		{name: "Wrong key", header: "Bearer " + sign(jwt.MapClaims{"iss": issuer, "aud": "api", "exp": expires}, otherKey), status: fasthttp.StatusUnauthorized},
+	}
+
+	for _, discovery := range []bool{true, false} {
+		properties := map[string]string{"issuerURL": issuer, "clientID": "client", "audience": "api", "signingAlgorithms": "ES256, RS256 ,"}
+		if !discovery {
+			properties["jwksURL"] = issuer + "/keys"
+		}
+
+		m := NewBearerMiddleware(logger.NewLogger("test"))
+		handler, err := m.GetHandler(middleware.Metadata{Properties: properties})
+		require.NoError(t, err)
+		h := handler(func(ctx *fasthttp.RequestCtx) {})
+
+		for _, tt := range testCases {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := &fasthttp.RequestCtx{}
+				ctx.Init(&fasthttp.Request{}, nil, nil)
+				if tt.header != "" {
+					ctx.Request.Header.Set(fasthttp.HeaderAuthorization, tt.header)
+				}
+				h(ctx)
+				assert.Equal(t, tt.status, ctx.Response.StatusCode())
+			})
+		}
+	}
+}
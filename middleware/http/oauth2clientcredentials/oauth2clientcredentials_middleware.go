@@ -0,0 +1,116 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package oauth2clientcredentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/valyala/fasthttp"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Metadata is the oAuth client credentials middleware config
+type oAuth2ClientCredentialsMiddlewareMetadata struct {
+	ClientID            string `json:"clientID"`
+	ClientSecret        string `json:"clientSecret"`
+	Scopes              string `json:"scopes"`
+	TokenURL            string `json:"tokenURL"`
+	HeaderName          string `json:"headerName"`
+	EndpointParamsQuery string `json:"endpointParamsQuery,omitempty"`
+	AuthStyle           int    `json:"authStyle,string"`
+}
+
+const (
+	defaultHeaderName = fasthttp.HeaderAuthorization
+)
+
+// NewOAuth2ClientCredentialsMiddleware returns a new oAuth2 client credentials middleware
+func NewOAuth2ClientCredentialsMiddleware(logger logger.Logger) *Middleware {
+	return &Middleware{logger: logger}
+}
+
+// Middleware is an oAuth2 client credentials middleware, which adds an access token to the requests
+type Middleware struct {
+	logger logger.Logger
+}
+
+// GetHandler retruns the HTTP handler provided by the middleware
+func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.RequestHandler) fasthttp.RequestHandler, error) {
+	meta, err := m.getNativeMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	endpointParams, err := url.ParseQuery(meta.EndpointParamsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2clientcredentials middleware error: invalid endpointParamsQuery: %s", err)
+	}
+
+	conf := &clientcredentials.Config{
+		ClientID:       meta.ClientID,
+		ClientSecret:   meta.ClientSecret,
+		TokenURL:       meta.TokenURL,
+		EndpointParams: endpointParams,
+		AuthStyle:      oauth2.AuthStyle(meta.AuthStyle),
+	}
+	if meta.Scopes != "" {
+		conf.Scopes = strings.Split(meta.Scopes, ",")
+	}
+
+	// The token is cached and fetched again when it is about to expire
+	tokenSource := conf.TokenSource(context.Background())
+
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			token, err := tokenSource.Token()
+			if err != nil {
+				m.logger.Errorf("oauth2clientcredentials middleware error: couldn't get access token: %s", err)
+				ctx.Error(fasthttp.StatusMessage(fasthttp.StatusInternalServerError), fasthttp.StatusInternalServerError)
+				return
+			}
+
+			ctx.Request.Header.Set(meta.HeaderName, token.Type()+" "+token.AccessToken)
+			h(ctx)
+		}
+	}, nil
+}
+
+func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*oAuth2ClientCredentialsMiddlewareMetadata, error) {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var middlewareMetadata oAuth2ClientCredentialsMiddlewareMetadata
+	err = json.Unmarshal(b, &middlewareMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if middlewareMetadata.ClientID == "" {
+		return nil, fmt.Errorf("oauth2clientcredentials middleware error: missing clientID")
+	}
+	if middlewareMetadata.ClientSecret == "" {
+		return nil, fmt.Errorf("oauth2clientcredentials middleware error: missing clientSecret")
+	}
+	if middlewareMetadata.TokenURL == "" {
+		return nil, fmt.Errorf("oauth2clientcredentials middleware error: missing tokenURL")
+	}
+	if middlewareMetadata.AuthStyle < 0 || middlewareMetadata.AuthStyle > 2 {
+		return nil, fmt.Errorf("oauth2clientcredentials middleware error: invalid authStyle %d, accepted values are 0 (auto detect), 1 (in params) and 2 (in header)", middlewareMetadata.AuthStyle)
+	}
+	if middlewareMetadata.HeaderName == "" {
+		middlewareMetadata.HeaderName = defaultHeaderName
+	}
+	return &middlewareMetadata, nil
+}
@@ -0,0 +1,82 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package oauth2clientcredentials
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := NewOAuth2ClientCredentialsMiddleware(logger.NewLogger("test"))
+
+	meta, err := m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"clientID": "a", "clientSecret": "b", "tokenURL": "http://localhost", "authStyle": "1"}})
+	require.NoError(t, err)
+	assert.Equal(t, defaultHeaderName, meta.HeaderName)
+	assert.Equal(t, 1, meta.AuthStyle)
+
+	for _, properties := range []map[string]string{
+		{"clientSecret": "b", "tokenURL": "http://localhost"},
+		{"clientID": "a", "tokenURL": "http://localhost"},
+		{"clientID": "a", "clientSecret": "b"},
+		{"clientID": "a", "clientSecret": "b", "tokenURL": "http://localhost", "authStyle": "3"},
+	} {
+		_, err := m.getNativeMetadata(middleware.Metadata{Properties: properties})
+		assert.Error(t, err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tokens := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "a b" || r.Form.Get("audience") != "api" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tokens++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	m := NewOAuth2ClientCredentialsMiddleware(logger.NewLogger("test"))
+	handler, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{
+		"clientID":            "client",
+		"clientSecret":        "secret",
+		"scopes":              "a,b",
+		"tokenURL":            server.URL,
+		"headerName":          "X-Token",
+		"endpointParamsQuery": "audience=api",
+	}})
+	require.NoError(t, err)
+
+	var header string
+	h := handler(func(ctx *fasthttp.RequestCtx) {
+		header = string(ctx.Request.Header.Peek("X-Token"))
+	})
+
+	for i := 0; i < 2; i++ {
+		h(&fasthttp.RequestCtx{})
+		assert.Equal(t, "Bearer token", header)
+	}
+	assert.Equal(t, 1, tokens)
+
+	handler, err = m.GetHandler(middleware.Metadata{Properties: map[string]string{"clientID": "client", "clientSecret": "secret", "tokenURL": server.URL}})
+	require.NoError(t, err)
+	ctx := &fasthttp.RequestCtx{}
+	handler(func(ctx *fasthttp.RequestCtx) {
+		t.Error("unexpected call of the next handler")
+	})(ctx)
+	assert.Equal(t, fasthttp.StatusInternalServerError, ctx.Response.StatusCode())
+}
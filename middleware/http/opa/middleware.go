@@ -0,0 +1,184 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package opa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/valyala/fasthttp"
+)
+
+// Metadata is the OPA middleware config
+type opaMiddlewareMetadata struct {
+	Rego            string `json:"rego"`
+	DefaultStatus   int    `json:"defaultStatus,string"`
+	IncludedHeaders string `json:"includedHeaders"`
+	ReadBody        bool   `json:"readBody,string"`
+}
+
+// policyResult is the result of the allow rule when it is an object rather than a boolean
+type policyResult struct {
+	Allow             bool              `json:"allow"`
+	StatusCode        int               `json:"status_code"`
+	AdditionalHeaders map[string]string `json:"additional_headers"`
+}
+
+const (
+	// policyQuery is the rule the policy must define, in the http package
+	policyQuery = "result = data.http.allow"
+
+	defaultStatus = fasthttp.StatusForbidden
+)
+
+// NewMiddleware returns a new Open Policy Agent middleware
+func NewMiddleware(logger logger.Logger) *Middleware {
+	return &Middleware{logger: logger}
+}
+
+// Middleware is an Open Policy Agent middleware, which evaluates a Rego policy to allow or deny the requests.
+//
+// The policy defines the allow rule of the http package, which is either a boolean or an object with:
+//   - allow: whether the request is allowed
+//   - status_code: the status of the denied request, the defaultStatus metadata by default
+//   - additional_headers: headers added to the allowed request, or to the response of the denied request
+//
+// The input of the policy is the request, with its method, path, path_parts, raw_query, query, scheme, the
+// includedHeaders metadata headers and, when the readBody metadata is true, its body.
+type Middleware struct {
+	logger logger.Logger
+}
+
+// GetHandler returns the HTTP handler provided by the middleware
+func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.RequestHandler) fasthttp.RequestHandler, error) {
+	meta, err := m.getNativeMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	query, err := rego.New(
+		rego.Query(policyQuery),
+		rego.Module("inline.rego", meta.Rego),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("opa middleware error: invalid rego policy: %s", err)
+	}
+
+	var includedHeaders []string
+	for _, header := range strings.Split(meta.IncludedHeaders, ",") {
+		if header = strings.TrimSpace(header); header != "" {
+			includedHeaders = append(includedHeaders, header)
+		}
+	}
+
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			result, err := m.evaluate(ctx, query, meta, includedHeaders)
+			if err != nil {
+				m.logger.Errorf("opa middleware error: %s", err)
+				ctx.Error(fasthttp.StatusMessage(meta.DefaultStatus), meta.DefaultStatus)
+				return
+			}
+
+			if !result.Allow {
+				status := result.StatusCode
+				if status == 0 {
+					status = meta.DefaultStatus
+				}
+				ctx.Error(fasthttp.StatusMessage(status), status)
+				for k, v := range result.AdditionalHeaders {
+					ctx.Response.Header.Set(k, v)
+				}
+				return
+			}
+
+			for k, v := range result.AdditionalHeaders {
+				ctx.Request.Header.Set(k, v)
+			}
+			h(ctx)
+		}
+	}, nil
+}
+
+// evaluate returns the result of the policy for the request
+func (m *Middleware) evaluate(ctx *fasthttp.RequestCtx, query rego.PreparedEvalQuery, meta *opaMiddlewareMetadata, includedHeaders []string) (*policyResult, error) {
+	path := string(ctx.Path())
+	headers := map[string]string{}
+	for _, header := range includedHeaders {
+		if value := ctx.Request.Header.Peek(header); value != nil {
+			headers[header] = string(value)
+		}
+	}
+	queryArgs := map[string][]string{}
+	ctx.QueryArgs().VisitAll(func(key, value []byte) {
+		queryArgs[string(key)] = append(queryArgs[string(key)], string(value))
+	})
+
+	request := map[string]interface{}{
+		"method":     string(ctx.Method()),
+		"path":       path,
+		"path_parts": strings.Split(strings.Trim(path, "/"), "/"),
+		"raw_query":  string(ctx.QueryArgs().QueryString()),
+		"query":      queryArgs,
+		"headers":    headers,
+		"scheme":     string(ctx.URI().Scheme()),
+	}
+	if meta.ReadBody {
+		request["body"] = string(ctx.Request.Body())
+	}
+
+	results, err := query.Eval(context.Background(), rego.EvalInput(map[string]interface{}{"request": request}))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't evaluate the policy: %s", err)
+	}
+	// An undefined allow rule denies the request
+	if len(results) == 0 {
+		return &policyResult{}, nil
+	}
+
+	switch result := results[0].Bindings["result"].(type) {
+	case bool:
+		return &policyResult{Allow: result}, nil
+	case map[string]interface{}:
+		b, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		var r policyResult
+		if err := json.Unmarshal(b, &r); err != nil {
+			return nil, fmt.Errorf("invalid result of the allow rule: %s", err)
+		}
+		return &r, nil
+	default:
+		return nil, fmt.Errorf("the allow rule must be a boolean or an object, not %T", result)
+	}
+}
+
+func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*opaMiddlewareMetadata, error) {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	middlewareMetadata := opaMiddlewareMetadata{DefaultStatus: defaultStatus}
+	if err = json.Unmarshal(b, &middlewareMetadata); err != nil {
+		return nil, fmt.Errorf("opa middleware error: %s", err)
+	}
+	if middlewareMetadata.Rego == "" {
+		return nil, errors.New("opa middleware error: missing rego policy")
+	}
+	if middlewareMetadata.DefaultStatus < 100 || middlewareMetadata.DefaultStatus > 599 {
+		return nil, fmt.Errorf("opa middleware error: invalid defaultStatus %d", middlewareMetadata.DefaultStatus)
+	}
+	return &middlewareMetadata, nil
+}
@@ -0,0 +1,116 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package opa
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+const policy = `
+package http
+
+default allow = false
+
+allow = true {
+	input.request.method == "GET"
+	input.request.path_parts[0] == "public"
+}
+
+allow = {"allow": true, "additional_headers": {"X-User": input.request.headers["X-Api-Key"]}} {
+	input.request.path == "/private"
+	input.request.headers["X-Api-Key"] == "key"
+}
+
+allow = {"allow": false, "status_code": 418, "additional_headers": {"X-Reason": "teapot"}} {
+	input.request.query["brew"][0] == "coffee"
+}
+
+allow = true {
+	input.request.method == "POST"
+	input.request.body == "ok"
+}
+`
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := NewMiddleware(logger.NewLogger("test"))
+
+	meta, err := m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"rego": policy}})
+	require.NoError(t, err)
+	assert.Equal(t, defaultStatus, meta.DefaultStatus)
+
+	for _, properties := range []map[string]string{
+		{},
+		{"rego": policy, "defaultStatus": "0"},
+		{"rego": policy, "defaultStatus": "forbidden"},
+	} {
+		_, err := m.getNativeMetadata(middleware.Metadata{Properties: properties})
+		assert.Error(t, err)
+	}
+
+	_, err = m.GetHandler(middleware.Metadata{Properties: map[string]string{"rego": "package http\nallow = {"}})
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	m := NewMiddleware(logger.NewLogger("test"))
+	handler, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{
+		"rego":            policy,
+		"defaultStatus":   "401",
+		"includedHeaders": "X-Api-Key",
+		"readBody":        "true",
+	}})
+	require.NoError(t, err)
+
+	var user string
+	h := handler(func(ctx *fasthttp.RequestCtx) {
+		if value := ctx.Request.Header.Peek("X-User"); len(value) > 0 {
+			user = string(value)
+		}
+	})
+
+	testCases := []struct {
+		name    string
+		method  string
+		uri     string
+		headers map[string]string
+		body    string
+		status  int
+	}{
+		{name: "Allowed by a boolean", method: "GET", uri: "/public/a", status: fasthttp.StatusOK},
+		{name: "Denied with the default status", method: "DELETE", uri: "/public/a", status: fasthttp.StatusUnauthorized},
+		{name: "Allowed with headers", method: "GET", uri: "/private", headers: map[string]string{"X-Api-Key": "key"}, status: fasthttp.StatusOK},
+		{name: "Denied with a status", method: "GET", uri: "/kettle?brew=coffee", status: fasthttp.StatusTeapot},
+		{name: "Allowed by the body", method: "POST", uri: "/orders", body: "ok", status: fasthttp.StatusOK},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.Header.SetMethod(tt.method)
+			ctx.Request.SetRequestURI(tt.uri)
+			for k, v := range tt.headers {
+				ctx.Request.Header.Set(k, v)
+			}
+			ctx.Request.SetBodyString(tt.body)
+
+			h(ctx)
+			assert.Equal(t, tt.status, ctx.Response.StatusCode())
+		})
+	}
+
+	assert.Equal(t, "key", user)
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/kettle?brew=coffee")
+	h(ctx)
+	assert.Equal(t, "teapot", string(ctx.Response.Header.Peek("X-Reason")))
+}
@@ -7,34 +7,44 @@ package ratelimit
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	"github.com/dapr/components-contrib/middleware"
-	"github.com/dapr/components-contrib/middleware/http/nethttpadaptor"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/didip/tollbooth"
+	"github.com/didip/tollbooth/limiter"
 	"github.com/valyala/fasthttp"
-	"github.com/valyala/fasthttp/fasthttpadaptor"
 )
 
 // Metadata is the ratelimit middleware config
 type rateLimitMiddlewareMetadata struct {
 	MaxRequestsPerSecond float64 `json:"maxRequestsPerSecond"`
+	KeyHeader            string  `json:"keyHeader"`
 }
 
 const (
 	maxRequestsPerSecondKey = "maxRequestsPerSecond"
+	// keyHeaderKey is the metadata of the header that identifies the clients, e.g. an API key header. The clients
+	// are limited by the value of the header in addition to their IP, so that a client can't escape the limit of its
+	// IP by sending new values.
+	keyHeaderKey = "keyHeader"
 
 	// Defaults
 	defaultMaxRequestsPerSecond = 100
+
+	// clientExpirationTTL is how long the limit of a client is kept after its last request
+	clientExpirationTTL = time.Hour
 )
 
-// NewRateLimitMiddleware returns a new oAuth2 middleware
+// NewRateLimitMiddleware returns a new ratelimit middleware
 func NewRateLimitMiddleware(logger logger.Logger) *Middleware {
 	return &Middleware{logger: logger}
 }
 
-// Middleware is an oAuth2 authentication middleware
+// Middleware is a rate limiting middleware, which limits the requests per second of each client IP, and of each value
+// of the key header when it is set
 type Middleware struct {
 	logger logger.Logger
 }
@@ -46,17 +56,40 @@ func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.R
 		return nil, err
 	}
 
-	limiter := tollbooth.NewLimiter(meta.MaxRequestsPerSecond, nil)
+	lmt := tollbooth.NewLimiter(meta.MaxRequestsPerSecond, &limiter.ExpirableOptions{DefaultExpirationTTL: clientExpirationTTL})
+	retryAfter := strconv.Itoa(int(math.Ceil(1 / meta.MaxRequestsPerSecond)))
+	maxRequests := fmt.Sprintf("%.2f", meta.MaxRequestsPerSecond)
 
 	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
-		limitHandler := tollbooth.LimitFuncHandler(limiter, nethttpadaptor.NewNetHTTPHandlerFunc(m.logger, h))
-		wrappedHandler := fasthttpadaptor.NewFastHTTPHandlerFunc(limitHandler.ServeHTTP)
 		return func(ctx *fasthttp.RequestCtx) {
-			wrappedHandler(ctx)
+			for _, key := range clientKeys(ctx, meta.KeyHeader) {
+				if httpErr := tollbooth.LimitByKeys(lmt, []string{key}); httpErr != nil {
+					// Error resets the response, so the headers are set after it
+					ctx.Error(httpErr.Message, httpErr.StatusCode)
+					ctx.Response.Header.Set("X-Rate-Limit-Limit", maxRequests)
+					ctx.Response.Header.Set("X-Rate-Limit-Duration", "1")
+					ctx.Response.Header.Set(fasthttp.HeaderRetryAfter, retryAfter)
+					return
+				}
+			}
+
+			h(ctx)
 		}
 	}, nil
 }
 
+// clientKeys returns the limiter keys of the request: the IP of the client, and the value of the key header when it
+// is set
+func clientKeys(ctx *fasthttp.RequestCtx, keyHeader string) []string {
+	keys := []string{"ip:" + ctx.RemoteIP().String()}
+	if keyHeader != "" {
+		if key := ctx.Request.Header.Peek(keyHeader); len(key) > 0 {
+			keys = append(keys, "header:"+string(key))
+		}
+	}
+	return keys
+}
+
 func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*rateLimitMiddlewareMetadata, error) {
 	var middlewareMetadata rateLimitMiddlewareMetadata
 
@@ -72,5 +105,7 @@ func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*rateLimit
 		middlewareMetadata.MaxRequestsPerSecond = f
 	}
 
+	middlewareMetadata.KeyHeader = metadata.Properties[keyHeaderKey]
+
 	return &middlewareMetadata, nil
 }
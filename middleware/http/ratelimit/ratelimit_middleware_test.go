@@ -0,0 +1,99 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := NewRateLimitMiddleware(logger.NewLogger("test"))
+
+	meta, err := m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{}})
+	require.NoError(t, err)
+	assert.Equal(t, float64(defaultMaxRequestsPerSecond), meta.MaxRequestsPerSecond)
+
+	meta, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"maxRequestsPerSecond": "0.5", "keyHeader": "X-API-Key"}})
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, meta.MaxRequestsPerSecond)
+	assert.Equal(t, "X-API-Key", meta.KeyHeader)
+
+	_, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"maxRequestsPerSecond": "0"}})
+	assert.Error(t, err)
+}
+
+func newRequest(ip string, headers map[string]string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&fasthttp.Request{}, &net.TCPAddr{IP: net.ParseIP(ip)}, nil)
+	for k, v := range headers {
+		ctx.Request.Header.Set(k, v)
+	}
+	return ctx
+}
+
+func TestHandler(t *testing.T) {
+	m := NewRateLimitMiddleware(logger.NewLogger("test"))
+	handler, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{"maxRequestsPerSecond": "0.5", "keyHeader": "X-API-Key"}})
+	require.NoError(t, err)
+
+	calls := 0
+	h := handler(func(ctx *fasthttp.RequestCtx) {
+		calls++
+	})
+
+	ctx := newRequest("10.0.0.1", nil)
+	h(ctx)
+	assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+
+	ctx = newRequest("10.0.0.1", nil)
+	h(ctx)
+	assert.Equal(t, fasthttp.StatusTooManyRequests, ctx.Response.StatusCode())
+	assert.Equal(t, "2", string(ctx.Response.Header.Peek("Retry-After")))
+
+	// Other clients have their own limits
+	ctx = newRequest("10.0.0.2", nil)
+	h(ctx)
+	assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+
+	// The clients are limited by the key header in addition to their IP
+	ctx = newRequest("10.0.0.3", map[string]string{"X-API-Key": "a"})
+	h(ctx)
+	assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+
+	ctx = newRequest("10.0.0.4", map[string]string{"X-API-Key": "a"})
+	h(ctx)
+	assert.Equal(t, fasthttp.StatusTooManyRequests, ctx.Response.StatusCode())
+
+	assert.Equal(t, 3, calls)
+}
+
+func TestHandlerKeyHeaderRotation(t *testing.T) {
+	m := NewRateLimitMiddleware(logger.NewLogger("test"))
+	handler, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{"maxRequestsPerSecond": "0.5", "keyHeader": "X-API-Key"}})
+	require.NoError(t, err)
+
+	calls := 0
+	h := handler(func(ctx *fasthttp.RequestCtx) {
+		calls++
+	})
+
+	// A client sending a new key on every request is still limited by its IP
+	for i, status := range []int{fasthttp.StatusOK, fasthttp.StatusTooManyRequests, fasthttp.StatusTooManyRequests} {
+		ctx := newRequest("10.0.0.1", map[string]string{"X-API-Key": fmt.Sprintf("key-%d", i)})
+		h(ctx)
+		assert.Equal(t, status, ctx.Response.StatusCode())
+	}
+
+	assert.Equal(t, 1, calls)
+}
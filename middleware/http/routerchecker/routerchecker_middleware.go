@@ -0,0 +1,124 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package routerchecker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/valyala/fasthttp"
+)
+
+type routerCheckerMiddlewareMetadata struct {
+	// Rule is the regular expression the original and the normalized paths of the requests must match, e.g. ^[A-Za-z0-9/._-]+$
+	Rule string `json:"rule"`
+	// Normalize forwards the normalized path, decoded and without duplicate slashes and dot segments
+	Normalize bool `json:"normalize,string"`
+	// RewriteRule is the regular expression of the paths to rewrite, with RewriteReplacement, e.g. ^/v1/(.*)$
+	RewriteRule string `json:"rewriteRule"`
+	// RewriteReplacement is the replacement of the rewritten paths, e.g. /api/$1
+	RewriteReplacement string `json:"rewriteReplacement"`
+}
+
+// NewMiddleware returns a new router checker middleware
+func NewMiddleware(logger logger.Logger) *Middleware {
+	return &Middleware{logger: logger}
+}
+
+// Middleware is a router checker middleware, which rejects the requests whose path doesn't match the rule with 400,
+// and normalizes and rewrites the path of the others before they are forwarded
+type Middleware struct {
+	logger logger.Logger
+}
+
+// GetHandler returns the HTTP handler provided by the middleware
+func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.RequestHandler) fasthttp.RequestHandler, error) {
+	meta, err := m.getNativeMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	var rule, rewriteRule *regexp.Regexp
+	if meta.Rule != "" {
+		if rule, err = regexp.Compile(meta.Rule); err != nil {
+			return nil, fmt.Errorf("routerchecker middleware error: invalid rule: %s", err)
+		}
+	}
+	if meta.RewriteRule != "" {
+		if rewriteRule, err = regexp.Compile(meta.RewriteRule); err != nil {
+			return nil, fmt.Errorf("routerchecker middleware error: invalid rewriteRule: %s", err)
+		}
+	}
+
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			// The original path is checked, so that encoded characters can't bypass the rule, and the normalized path
+			// too, so that dot segments and encoded slashes can't reach a path the rule rejects once the app resolves them
+			original := string(ctx.URI().PathOriginal())
+			if rule != nil && (!rule.MatchString(original) || !rule.MatchString(string(ctx.Path()))) {
+				m.logger.Debugf("routerchecker middleware: rejected path %s", original)
+				ctx.Error(fasthttp.StatusMessage(fasthttp.StatusBadRequest), fasthttp.StatusBadRequest)
+				return
+			}
+
+			path := original
+			if meta.Normalize {
+				path = string(ctx.Path())
+			}
+			// The rewrite rule matches the normalized path either way
+			if rewriteRule != nil {
+				if normalized := string(ctx.Path()); rewriteRule.MatchString(normalized) {
+					path = rewriteRule.ReplaceAllString(normalized, meta.RewriteReplacement)
+				}
+			}
+			if path != original {
+				// The normalized and rewritten paths are decoded, so they are escaped again, otherwise their decoded
+				// ? and # would start the query and the fragment of the forwarded URI
+				uri := escapePath(path)
+				if query := ctx.URI().QueryString(); len(query) > 0 {
+					uri += "?" + string(query)
+				}
+				ctx.Request.SetRequestURI(uri)
+			}
+
+			h(ctx)
+		}
+	}, nil
+}
+
+// escapePath escapes the segments of a decoded path
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*routerCheckerMiddlewareMetadata, error) {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var middlewareMetadata routerCheckerMiddlewareMetadata
+	err = json.Unmarshal(b, &middlewareMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if middlewareMetadata.Rule == "" && middlewareMetadata.RewriteRule == "" && !middlewareMetadata.Normalize {
+		return nil, errors.New("routerchecker middleware error: missing rule, rewriteRule or normalize")
+	}
+	return &middlewareMetadata, nil
+}
@@ -0,0 +1,155 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package routerchecker
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := NewMiddleware(logger.NewLogger("test"))
+
+	meta, err := m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"rule": "^/v1/.*$", "normalize": "true"}})
+	require.NoError(t, err)
+	assert.Equal(t, "^/v1/.*$", meta.Rule)
+	assert.True(t, meta.Normalize)
+
+	_, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{}})
+	assert.Error(t, err)
+	_, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"normalize": "yes"}})
+	assert.Error(t, err)
+
+	_, err = m.GetHandler(middleware.Metadata{Properties: map[string]string{"rule": "("}})
+	assert.Error(t, err)
+	_, err = m.GetHandler(middleware.Metadata{Properties: map[string]string{"rewriteRule": "("}})
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	testCases := []struct {
+		name       string
+		properties map[string]string
+		uri        string
+		status     int
+		forwarded  string
+		// forwardedPath is the decoded path of the forwarded request, when it is checked
+		forwardedPath string
+	}{
+		{
+			name:       "Matching path",
+			properties: map[string]string{"rule": "^[A-Za-z0-9/._-]+$"},
+			uri:        "/v1.0/invoke/app/method/orders?id=1",
+			status:     fasthttp.StatusOK,
+			forwarded:  "/v1.0/invoke/app/method/orders?id=1",
+		},
+		{
+			name:       "Disallowed characters",
+			properties: map[string]string{"rule": "^[A-Za-z0-9/._-]+$"},
+			uri:        "/v1.0/invoke/app/method/orders%3Bdrop",
+			status:     fasthttp.StatusBadRequest,
+		},
+		{
+			name:       "Disallowed route",
+			properties: map[string]string{"rule": "^/v1.0/invoke/app/"},
+			uri:        "/v1.0/invoke/other/method/orders",
+			status:     fasthttp.StatusBadRequest,
+		},
+		{
+			name:       "Dot segments out of the allowed route",
+			properties: map[string]string{"rule": "^/allowed"},
+			uri:        "/allowed/../admin",
+			status:     fasthttp.StatusBadRequest,
+		},
+		{
+			name:       "Encoded slash out of the allowed route",
+			properties: map[string]string{"rule": "^/allowed"},
+			uri:        "/allowed/..%2Fadmin",
+			status:     fasthttp.StatusBadRequest,
+		},
+		{
+			name:       "Dot segments in the allowed route",
+			properties: map[string]string{"rule": "^/allowed"},
+			uri:        "/allowed/a/../b",
+			status:     fasthttp.StatusOK,
+			forwarded:  "/allowed/a/../b",
+		},
+		{
+			name:       "Not normalized",
+			properties: map[string]string{"rule": "^/"},
+			uri:        "/a//b/../c",
+			status:     fasthttp.StatusOK,
+			forwarded:  "/a//b/../c",
+		},
+		{
+			name:       "Normalized",
+			properties: map[string]string{"normalize": "true"},
+			uri:        "/a//b/../c?d=e",
+			status:     fasthttp.StatusOK,
+			forwarded:  "/a/c?d=e",
+		},
+		{
+			name:       "Rewritten",
+			properties: map[string]string{"rewriteRule": "^/v1/(.*)$", "rewriteReplacement": "/api/$1"},
+			uri:        "/v1/orders/1?d=e",
+			status:     fasthttp.StatusOK,
+			forwarded:  "/api/orders/1?d=e",
+		},
+		{
+			name:       "Not rewritten",
+			properties: map[string]string{"rewriteRule": "^/v1/(.*)$", "rewriteReplacement": "/api/$1"},
+			uri:        "/v2/orders/1",
+			status:     fasthttp.StatusOK,
+			forwarded:  "/v2/orders/1",
+		},
+		{
+			name:          "Normalized with encoded delimiters",
+			properties:    map[string]string{"normalize": "true"},
+			uri:           "/a%3Fb%23c%2Fd?e=f",
+			status:        fasthttp.StatusOK,
+			forwarded:     "/a%3Fb%23c/d?e=f",
+			forwardedPath: "/a?b#c/d",
+		},
+		{
+			name:          "Rewritten with encoded delimiters",
+			properties:    map[string]string{"rewriteRule": "^/v1/(.*)$", "rewriteReplacement": "/api/$1"},
+			uri:           "/v1/a%3Fb%23c%2Fd?e=f",
+			status:        fasthttp.StatusOK,
+			forwarded:     "/api/a%3Fb%23c/d?e=f",
+			forwardedPath: "/api/a?b#c/d",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMiddleware(logger.NewLogger("test"))
+			handler, err := m.GetHandler(middleware.Metadata{Properties: tt.properties})
+			require.NoError(t, err)
+
+			var forwarded, forwardedPath, forwardedQuery string
+			h := handler(func(ctx *fasthttp.RequestCtx) {
+				forwarded = string(ctx.RequestURI())
+				forwardedPath = string(ctx.Path())
+				forwardedQuery = string(ctx.URI().QueryString())
+			})
+
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.SetRequestURI(tt.uri)
+			h(ctx)
+			assert.Equal(t, tt.status, ctx.Response.StatusCode())
+			assert.Equal(t, tt.forwarded, forwarded)
+			if tt.forwardedPath != "" {
+				assert.Equal(t, tt.forwardedPath, forwardedPath)
+				assert.Equal(t, "e=f", forwardedQuery)
+			}
+		})
+	}
+}
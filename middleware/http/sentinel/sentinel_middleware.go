@@ -0,0 +1,243 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sentinel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	sentinel "github.com/alibaba/sentinel-golang/api"
+	"github.com/alibaba/sentinel-golang/core/base"
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+	"github.com/alibaba/sentinel-golang/core/config"
+	"github.com/alibaba/sentinel-golang/core/flow"
+	"github.com/alibaba/sentinel-golang/core/isolation"
+	"github.com/alibaba/sentinel-golang/core/system"
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/valyala/fasthttp"
+)
+
+// The rules are the JSON arrays of the rules of Sentinel, see https://sentinelguard.io/en-us/docs/golang/basic-api-usage.html.
+// Their resource is the method and the path of the requests, e.g. "GET:/v1.0/invoke/app/method/orders".
+type sentinelMiddlewareMetadata struct {
+	AppName string `json:"appName"`
+	// LogDir is the directory of the metric logs, which are not written when it is not set
+	LogDir              string `json:"logDir"`
+	FlowRules           string `json:"flowRules"`
+	CircuitBreakerRules string `json:"circuitBreakerRules"`
+	IsolationRules      string `json:"isolationRules"`
+	SystemRules         string `json:"systemRules"`
+}
+
+const defaultAppName = "dapr"
+
+// Sentinel is initialized once, as its configuration and rules are global to the process
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+// NewMiddleware returns a new Sentinel middleware
+func NewMiddleware(logger logger.Logger) *Middleware {
+	return &Middleware{logger: logger}
+}
+
+// Middleware is a flow control middleware of Alibaba Sentinel, which rejects with 429 the requests blocked by its
+// flow control, circuit breaking, concurrency isolation and system adaptive protection rules. The responses with a
+// 5xx status are the errors of the circuit breakers. The rules of a kind replace those loaded by other instances of
+// the middleware, as they are global to the process.
+type Middleware struct {
+	logger logger.Logger
+}
+
+// GetHandler returns the HTTP handler provided by the middleware
+func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.RequestHandler) fasthttp.RequestHandler, error) {
+	meta, err := m.getNativeMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	initOnce.Do(func() {
+		initErr = sentinel.InitWithConfig(m.config(meta))
+	})
+	if initErr != nil {
+		return nil, fmt.Errorf("sentinel middleware error: %s", initErr)
+	}
+	if err = m.loadRules(meta); err != nil {
+		return nil, err
+	}
+
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			resource := string(ctx.Method()) + ":" + string(ctx.Path())
+			entry, blockErr := sentinel.Entry(resource, sentinel.WithTrafficType(base.Inbound))
+			if blockErr != nil {
+				m.logger.Debugf("sentinel middleware: blocked %s: %s", resource, blockErr)
+				ctx.Error(fasthttp.StatusMessage(fasthttp.StatusTooManyRequests), fasthttp.StatusTooManyRequests)
+				return
+			}
+			defer entry.Exit()
+
+			h(ctx)
+
+			if status := ctx.Response.StatusCode(); status >= fasthttp.StatusInternalServerError {
+				sentinel.TraceError(entry, fmt.Errorf("status %d", status))
+			}
+		}
+	}, nil
+}
+
+func (m *Middleware) config(meta *sentinelMiddlewareMetadata) *config.Entity {
+	conf := config.NewDefaultConfig()
+	conf.Sentinel.App.Name = meta.AppName
+	conf.Sentinel.Log.Logger = &sentinelLogger{logger: m.logger}
+	if meta.LogDir != "" {
+		conf.Sentinel.Log.Dir = meta.LogDir
+	} else {
+		conf.Sentinel.Log.Metric.FlushIntervalSec = 0
+	}
+	return conf
+}
+
+// loadRules loads the rules of the metadata, which are all validated first, as Sentinel ignores the invalid rules
+func (m *Middleware) loadRules(meta *sentinelMiddlewareMetadata) error {
+	if meta.FlowRules != "" {
+		var rules []*flow.Rule
+		if err := unmarshalRules("flowRules", meta.FlowRules, &rules); err != nil {
+			return err
+		}
+		for _, r := range rules {
+			if err := flow.IsValidRule(r); err != nil {
+				return invalidRulesError("flowRules", err)
+			}
+		}
+		if _, err := flow.LoadRules(rules); err != nil {
+			return invalidRulesError("flowRules", err)
+		}
+	}
+	if meta.CircuitBreakerRules != "" {
+		var rules []*circuitbreaker.Rule
+		if err := unmarshalRules("circuitBreakerRules", meta.CircuitBreakerRules, &rules); err != nil {
+			return err
+		}
+		for _, r := range rules {
+			if err := circuitbreaker.IsValid(r); err != nil {
+				return invalidRulesError("circuitBreakerRules", err)
+			}
+		}
+		if _, err := circuitbreaker.LoadRules(rules); err != nil {
+			return invalidRulesError("circuitBreakerRules", err)
+		}
+	}
+	if meta.IsolationRules != "" {
+		var rules []*isolation.Rule
+		if err := unmarshalRules("isolationRules", meta.IsolationRules, &rules); err != nil {
+			return err
+		}
+		for _, r := range rules {
+			if err := isolation.IsValid(r); err != nil {
+				return invalidRulesError("isolationRules", err)
+			}
+		}
+		if _, err := isolation.LoadRules(rules); err != nil {
+			return invalidRulesError("isolationRules", err)
+		}
+	}
+	if meta.SystemRules != "" {
+		var rules []*system.Rule
+		if err := unmarshalRules("systemRules", meta.SystemRules, &rules); err != nil {
+			return err
+		}
+		for _, r := range rules {
+			if err := system.IsValidSystemRule(r); err != nil {
+				return invalidRulesError("systemRules", err)
+			}
+		}
+		if _, err := system.LoadRules(rules); err != nil {
+			return invalidRulesError("systemRules", err)
+		}
+	}
+	return nil
+}
+
+func unmarshalRules(key, value string, rules interface{}) error {
+	if err := json.Unmarshal([]byte(value), rules); err != nil {
+		return invalidRulesError(key, err)
+	}
+	return nil
+}
+
+func invalidRulesError(key string, err error) error {
+	return fmt.Errorf("sentinel middleware error: invalid %s: %s", key, err)
+}
+
+func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*sentinelMiddlewareMetadata, error) {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var middlewareMetadata sentinelMiddlewareMetadata
+	err = json.Unmarshal(b, &middlewareMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if middlewareMetadata.AppName == "" {
+		middlewareMetadata.AppName = defaultAppName
+	}
+	return &middlewareMetadata, nil
+}
+
+// sentinelLogger writes the logs of Sentinel to the logger of the middleware
+type sentinelLogger struct {
+	logger logger.Logger
+}
+
+func format(msg string, keysAndValues []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+	return b.String()
+}
+
+func (l *sentinelLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(format(msg, keysAndValues))
+}
+
+func (l *sentinelLogger) DebugEnabled() bool {
+	return true
+}
+
+func (l *sentinelLogger) Info(msg string, keysAndValues ...interface{}) {
+	// The info logs of Sentinel are too verbose for the sidecar
+	l.logger.Debug(format(msg, keysAndValues))
+}
+
+func (l *sentinelLogger) InfoEnabled() bool {
+	return true
+}
+
+func (l *sentinelLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.logger.Warn(format(msg, keysAndValues))
+}
+
+func (l *sentinelLogger) WarnEnabled() bool {
+	return true
+}
+
+func (l *sentinelLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.logger.Errorf("%s: %s", format(msg, keysAndValues), err)
+}
+
+func (l *sentinelLogger) ErrorEnabled() bool {
+	return true
+}
@@ -0,0 +1,81 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sentinel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := NewMiddleware(logger.NewLogger("test"))
+
+	meta, err := m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{}})
+	require.NoError(t, err)
+	assert.Equal(t, defaultAppName, meta.AppName)
+
+	meta, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"appName": "orders", "flowRules": "[]"}})
+	require.NoError(t, err)
+	assert.Equal(t, "orders", meta.AppName)
+	assert.Equal(t, "[]", meta.FlowRules)
+}
+
+func TestInvalidRules(t *testing.T) {
+	m := NewMiddleware(logger.NewLogger("test"))
+
+	for _, key := range []string{"flowRules", "circuitBreakerRules", "isolationRules", "systemRules"} {
+		_, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{key: "{"}})
+		assert.Error(t, err, key)
+	}
+
+	_, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{"flowRules": `[{"resource": "GET:/orders", "threshold": -1}]`}})
+	assert.Error(t, err)
+}
+
+func serve(h fasthttp.RequestHandler, path string) int {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI(path)
+	h(ctx)
+	return ctx.Response.StatusCode()
+}
+
+func TestHandler(t *testing.T) {
+	// The statistics of Sentinel are global, so the paths are unique to the run
+	limited := fmt.Sprintf("/limited/%d", time.Now().UnixNano())
+	failing := fmt.Sprintf("/failing/%d", time.Now().UnixNano())
+
+	m := NewMiddleware(logger.NewLogger("test"))
+	handler, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{
+		"flowRules":           fmt.Sprintf(`[{"resource": "GET:%s", "threshold": 1, "statIntervalInMs": 60000}]`, limited),
+		"circuitBreakerRules": fmt.Sprintf(`[{"resource": "GET:%s", "strategy": 2, "threshold": 1, "minRequestAmount": 1, "statIntervalMs": 60000, "retryTimeoutMs": 60000}]`, failing),
+	}})
+	require.NoError(t, err)
+
+	h := handler(func(ctx *fasthttp.RequestCtx) {
+		if string(ctx.Path()) == failing {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		}
+	})
+
+	t.Run("Flow control", func(t *testing.T) {
+		assert.Equal(t, fasthttp.StatusOK, serve(h, limited))
+		assert.Equal(t, fasthttp.StatusTooManyRequests, serve(h, limited))
+		assert.Equal(t, fasthttp.StatusOK, serve(h, "/other"))
+		assert.Equal(t, fasthttp.StatusOK, serve(h, "/other"))
+	})
+
+	t.Run("Circuit breaking", func(t *testing.T) {
+		assert.Equal(t, fasthttp.StatusInternalServerError, serve(h, failing))
+		assert.Equal(t, fasthttp.StatusTooManyRequests, serve(h, failing))
+	})
+}
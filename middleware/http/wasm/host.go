@@ -0,0 +1,353 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package wasm
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/go-interpreter/wagon/exec"
+	wagon "github.com/go-interpreter/wagon/wasm"
+	"github.com/valyala/fasthttp"
+)
+
+// Features of the http-wasm handler ABI. The requests and responses are always buffered by fasthttp, and trailers
+// are not supported.
+const (
+	featureBufferRequest  uint32 = 1
+	featureBufferResponse uint32 = 2
+
+	supportedFeatures = featureBufferRequest | featureBufferResponse
+)
+
+// Header kinds of the http-wasm handler ABI
+const (
+	headerKindRequest  uint32 = 0
+	headerKindResponse uint32 = 1
+)
+
+// Log levels of the http-wasm handler ABI
+const (
+	logLevelDebug int32 = -1
+	logLevelInfo  int32 = 0
+	logLevelWarn  int32 = 1
+	logLevelError int32 = 2
+	logLevelNone  int32 = 3
+)
+
+// header is implemented by both the request and response headers of fasthttp
+type header interface {
+	VisitAll(f func(key, value []byte))
+	Add(key, value string)
+	Set(key, value string)
+	Del(key string)
+}
+
+// instance is a guest module instance, handling a single request at a time
+type instance struct {
+	vm             *exec.VM
+	handleRequest  int64
+	handleResponse int64
+	config         []byte
+	logger         logger.Logger
+
+	// The state of the current request
+	ctx         *fasthttp.RequestCtx
+	bodyRead    [2]int
+	bodyWritten [2]bool
+}
+
+// handle calls handle_request of the guest, then the next handler and handle_response of the guest, unless
+// handle_request returned that the guest wrote the response
+func (i *instance) handle(ctx *fasthttp.RequestCtx, next fasthttp.RequestHandler) error {
+	i.ctx = ctx
+	i.bodyRead = [2]int{}
+	i.bodyWritten = [2]bool{}
+	defer func() {
+		i.ctx = nil
+	}()
+
+	out, err := i.vm.ExecCode(i.handleRequest)
+	if err != nil {
+		return fmt.Errorf("%s failed: %s", handleRequestExport, err)
+	}
+	result, ok := out.(uint64)
+	if !ok {
+		return fmt.Errorf("%s must return an i64", handleRequestExport)
+	}
+	if result&1 == 0 {
+		return nil
+	}
+
+	next(ctx)
+
+	i.bodyRead[headerKindResponse] = 0
+	i.bodyWritten[headerKindResponse] = false
+	if _, err = i.vm.ExecCode(i.handleResponse, result>>32, 0); err != nil {
+		return fmt.Errorf("%s failed: %s", handleResponseExport, err)
+	}
+	return nil
+}
+
+// hostModule returns the http_handler module imported by the guest, with its functions bound to the instance.
+// The signatures of the functions are those of the Go functions, uint32 being i32 and uint64 being i64.
+func (i *instance) hostModule() *wagon.Module {
+	functions := map[string]interface{}{
+		"enable_features":      i.enableFeatures,
+		"get_config":           i.getConfig,
+		"log_enabled":          i.logEnabled,
+		"log":                  i.log,
+		"get_method":           i.getMethod,
+		"set_method":           i.setMethod,
+		"get_uri":              i.getURI,
+		"set_uri":              i.setURI,
+		"get_protocol_version": i.getProtocolVersion,
+		"get_header_names":     i.getHeaderNames,
+		"get_header_values":    i.getHeaderValues,
+		"add_header_value":     i.addHeaderValue,
+		"set_header_value":     i.setHeaderValue,
+		"remove_header":        i.removeHeader,
+		"read_body":            i.readBody,
+		"write_body":           i.writeBody,
+		"get_status_code":      i.getStatusCode,
+		"set_status_code":      i.setStatusCode,
+		"get_source_addr":      i.getSourceAddr,
+	}
+
+	m := wagon.NewModule()
+	m.Types = &wagon.SectionTypes{Entries: make([]wagon.FunctionSig, 0, len(functions))}
+	m.Export = &wagon.SectionExports{Entries: map[string]wagon.ExportEntry{}}
+	for name, fn := range functions {
+		v := reflect.ValueOf(fn)
+		m.Types.Entries = append(m.Types.Entries, signature(v.Type()))
+		m.Export.Entries[name] = wagon.ExportEntry{FieldStr: name, Kind: wagon.ExternalFunction, Index: uint32(len(m.FunctionIndexSpace))}
+		m.FunctionIndexSpace = append(m.FunctionIndexSpace, wagon.Function{Host: v, Body: &wagon.FunctionBody{}})
+	}
+	for j := range m.FunctionIndexSpace {
+		m.FunctionIndexSpace[j].Sig = &m.Types.Entries[j]
+	}
+	return m
+}
+
+// signature returns the WebAssembly signature of a host function, whose first parameter is the process
+func signature(t reflect.Type) wagon.FunctionSig {
+	valueType := func(t reflect.Type) wagon.ValueType {
+		if t.Kind() == reflect.Uint64 {
+			return wagon.ValueTypeI64
+		}
+		return wagon.ValueTypeI32
+	}
+
+	sig := wagon.FunctionSig{Form: 0x60}
+	for j := 1; j < t.NumIn(); j++ {
+		sig.ParamTypes = append(sig.ParamTypes, valueType(t.In(j)))
+	}
+	for j := 0; j < t.NumOut(); j++ {
+		sig.ReturnTypes = append(sig.ReturnTypes, valueType(t.Out(j)))
+	}
+	return sig
+}
+
+// read returns the guest memory of the range. An invalid range traps the guest.
+func read(proc *exec.Process, ptr, length uint32) []byte {
+	if uint64(ptr)+uint64(length) > uint64(proc.MemSize()) {
+		panic(fmt.Errorf("out of bounds memory read at %d", ptr))
+	}
+	b := make([]byte, length)
+	proc.ReadAt(b, int64(ptr))
+	return b
+}
+
+// writeIfUnderLimit writes the value to the guest memory when it fits in the limit, and returns its length
+// either way, so that the guest can retry with a larger buffer
+func writeIfUnderLimit(proc *exec.Process, buf, limit uint32, value []byte) uint32 {
+	length := uint32(len(value))
+	if length > limit {
+		return length
+	}
+	if uint64(buf)+uint64(length) > uint64(proc.MemSize()) {
+		panic(fmt.Errorf("out of bounds memory write at %d", buf))
+	}
+	proc.WriteAt(value, int64(buf))
+	return length
+}
+
+func (i *instance) header(kind uint32) header {
+	switch kind {
+	case headerKindRequest:
+		return &i.ctx.Request.Header
+	case headerKindResponse:
+		return &i.ctx.Response.Header
+	default:
+		panic(fmt.Errorf("unsupported header kind %d", kind))
+	}
+}
+
+func (i *instance) enableFeatures(proc *exec.Process, features uint32) uint32 {
+	return supportedFeatures
+}
+
+func (i *instance) getConfig(proc *exec.Process, buf, limit uint32) uint32 {
+	return writeIfUnderLimit(proc, buf, limit, i.config)
+}
+
+func (i *instance) logEnabled(proc *exec.Process, level uint32) uint32 {
+	if int32(level) == logLevelNone {
+		return 0
+	}
+	return 1
+}
+
+func (i *instance) log(proc *exec.Process, level, buf, length uint32) {
+	message := string(read(proc, buf, length))
+	switch int32(level) {
+	case logLevelDebug:
+		i.logger.Debug(message)
+	case logLevelInfo:
+		i.logger.Info(message)
+	case logLevelWarn:
+		i.logger.Warn(message)
+	case logLevelError:
+		i.logger.Error(message)
+	}
+}
+
+func (i *instance) getMethod(proc *exec.Process, buf, limit uint32) uint32 {
+	return writeIfUnderLimit(proc, buf, limit, i.ctx.Method())
+}
+
+func (i *instance) setMethod(proc *exec.Process, ptr, length uint32) {
+	i.ctx.Request.Header.SetMethodBytes(read(proc, ptr, length))
+}
+
+func (i *instance) getURI(proc *exec.Process, buf, limit uint32) uint32 {
+	return writeIfUnderLimit(proc, buf, limit, i.ctx.RequestURI())
+}
+
+func (i *instance) setURI(proc *exec.Process, ptr, length uint32) {
+	i.ctx.Request.SetRequestURIBytes(read(proc, ptr, length))
+}
+
+func (i *instance) getProtocolVersion(proc *exec.Process, buf, limit uint32) uint32 {
+	version := "HTTP/1.0"
+	if i.ctx.Request.Header.IsHTTP11() {
+		version = "HTTP/1.1"
+	}
+	return writeIfUnderLimit(proc, buf, limit, []byte(version))
+}
+
+// getHeaderNames writes the NUL terminated names of the headers, and returns their count in the high 32 bits and
+// their length in the low 32 bits
+func (i *instance) getHeaderNames(proc *exec.Process, kind, buf, limit uint32) uint64 {
+	var names [][]byte
+	i.header(kind).VisitAll(func(key, value []byte) {
+		for _, n := range names {
+			if bytes.EqualFold(n, key) {
+				return
+			}
+		}
+		names = append(names, append([]byte(nil), key...))
+	})
+	return writeList(proc, buf, limit, names)
+}
+
+// getHeaderValues writes the NUL terminated values of the header, and returns their count in the high 32 bits and
+// their length in the low 32 bits
+func (i *instance) getHeaderValues(proc *exec.Process, kind, name, nameLength, buf, limit uint32) uint64 {
+	n := read(proc, name, nameLength)
+	var values [][]byte
+	i.header(kind).VisitAll(func(key, value []byte) {
+		if bytes.EqualFold(n, key) {
+			values = append(values, append([]byte(nil), value...))
+		}
+	})
+	return writeList(proc, buf, limit, values)
+}
+
+func writeList(proc *exec.Process, buf, limit uint32, items [][]byte) uint64 {
+	var b []byte
+	for _, item := range items {
+		b = append(b, item...)
+		b = append(b, 0)
+	}
+	return uint64(len(items))<<32 | uint64(writeIfUnderLimit(proc, buf, limit, b))
+}
+
+func (i *instance) addHeaderValue(proc *exec.Process, kind, name, nameLength, value, valueLength uint32) {
+	i.header(kind).Add(string(read(proc, name, nameLength)), string(read(proc, value, valueLength)))
+}
+
+func (i *instance) setHeaderValue(proc *exec.Process, kind, name, nameLength, value, valueLength uint32) {
+	i.header(kind).Set(string(read(proc, name, nameLength)), string(read(proc, value, valueLength)))
+}
+
+func (i *instance) removeHeader(proc *exec.Process, kind, name, nameLength uint32) {
+	i.header(kind).Del(string(read(proc, name, nameLength)))
+}
+
+func (i *instance) body(kind uint32) []byte {
+	switch kind {
+	case headerKindRequest:
+		return i.ctx.Request.Body()
+	case headerKindResponse:
+		return i.ctx.Response.Body()
+	default:
+		panic(fmt.Errorf("unsupported body kind %d", kind))
+	}
+}
+
+// readBody writes the next chunk of the body, and returns 1 in the high 32 bits once the body is read and the
+// length of the chunk in the low 32 bits
+func (i *instance) readBody(proc *exec.Process, kind, buf, limit uint32) uint64 {
+	body := i.body(kind)[i.bodyRead[kind]:]
+	if uint32(len(body)) > limit {
+		body = body[:limit]
+	}
+	n := writeIfUnderLimit(proc, buf, limit, body)
+	i.bodyRead[kind] += int(n)
+
+	var eof uint64
+	if i.bodyRead[kind] >= len(i.body(kind)) {
+		eof = 1
+	}
+	return eof<<32 | uint64(n)
+}
+
+// writeBody replaces the body on the first write of the handler, and appends to it on the next writes
+func (i *instance) writeBody(proc *exec.Process, kind, ptr, length uint32) {
+	b := read(proc, ptr, length)
+	switch kind {
+	case headerKindRequest:
+		if i.bodyWritten[kind] {
+			i.ctx.Request.AppendBody(b)
+		} else {
+			i.ctx.Request.SetBody(b)
+		}
+	case headerKindResponse:
+		if i.bodyWritten[kind] {
+			i.ctx.Response.AppendBody(b)
+		} else {
+			i.ctx.Response.SetBody(b)
+		}
+	default:
+		panic(fmt.Errorf("unsupported body kind %d", kind))
+	}
+	i.bodyWritten[kind] = true
+}
+
+func (i *instance) getStatusCode(proc *exec.Process) uint32 {
+	return uint32(i.ctx.Response.StatusCode())
+}
+
+func (i *instance) setStatusCode(proc *exec.Process, statusCode uint32) {
+	i.ctx.Response.SetStatusCode(int(statusCode))
+}
+
+func (i *instance) getSourceAddr(proc *exec.Process, buf, limit uint32) uint32 {
+	return writeIfUnderLimit(proc, buf, limit, []byte(i.ctx.RemoteAddr().String()))
+}
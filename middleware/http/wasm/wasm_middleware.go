@@ -0,0 +1,187 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package wasm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/go-interpreter/wagon/exec"
+	wagon "github.com/go-interpreter/wagon/wasm"
+	"github.com/valyala/fasthttp"
+)
+
+type wasmMiddlewareMetadata struct {
+	// Path is the path of the WebAssembly module implementing the http-wasm handler ABI
+	Path string `json:"path"`
+	// GuestConfig is returned to the guest by get_config
+	GuestConfig string `json:"guestConfig"`
+	// PoolSize is the number of idle guest instances kept between requests
+	PoolSize string `json:"poolSize"`
+}
+
+const (
+	// hostModule is the module of the functions the guest imports
+	hostModule = "http_handler"
+
+	handleRequestExport  = "handle_request"
+	handleResponseExport = "handle_response"
+
+	defaultPoolSize = 10
+)
+
+// NewMiddleware returns a new WebAssembly middleware
+func NewMiddleware(logger logger.Logger) *Middleware {
+	return &Middleware{logger: logger}
+}
+
+// Middleware is an HTTP middleware running a WebAssembly module implementing the http-wasm handler ABI
+// (https://http-wasm.io/http-handler-abi/), so that requests and responses are handled by user code, e.g. to rewrite
+// headers or to authorize requests.
+type Middleware struct {
+	logger logger.Logger
+}
+
+// GetHandler returns the HTTP handler provided by the middleware
+func (m *Middleware) GetHandler(metadata middleware.Metadata) (func(h fasthttp.RequestHandler) fasthttp.RequestHandler, error) {
+	meta, poolSize, err := m.getNativeMetadata(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := ioutil.ReadFile(meta.Path)
+	if err != nil {
+		return nil, fmt.Errorf("wasm middleware error: %s", err)
+	}
+
+	p := &pool{
+		code:      code,
+		config:    []byte(meta.GuestConfig),
+		logger:    m.logger,
+		instances: make(chan *instance, poolSize),
+	}
+	// The module is instantiated once up front, so that an invalid module fails the initialization
+	i, err := p.newInstance()
+	if err != nil {
+		return nil, err
+	}
+	p.put(i)
+
+	return func(h fasthttp.RequestHandler) fasthttp.RequestHandler {
+		return func(ctx *fasthttp.RequestCtx) {
+			i, err := p.get()
+			if err != nil {
+				m.logger.Errorf("wasm middleware: %s", err)
+				ctx.Error(fasthttp.StatusMessage(fasthttp.StatusInternalServerError), fasthttp.StatusInternalServerError)
+				return
+			}
+
+			if err = i.handle(ctx, h); err != nil {
+				// The state of a trapped guest is unknown, so the instance is not reused
+				m.logger.Errorf("wasm middleware: %s", err)
+				ctx.Error(fasthttp.StatusMessage(fasthttp.StatusInternalServerError), fasthttp.StatusInternalServerError)
+				return
+			}
+			p.put(i)
+		}
+	}, nil
+}
+
+func (m *Middleware) getNativeMetadata(metadata middleware.Metadata) (*wasmMiddlewareMetadata, int, error) {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var middlewareMetadata wasmMiddlewareMetadata
+	err = json.Unmarshal(b, &middlewareMetadata)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if middlewareMetadata.Path == "" {
+		return nil, 0, errors.New("wasm middleware error: missing path")
+	}
+
+	poolSize := defaultPoolSize
+	if middlewareMetadata.PoolSize != "" {
+		poolSize, err = strconv.Atoi(middlewareMetadata.PoolSize)
+		if err != nil || poolSize <= 0 {
+			return nil, 0, fmt.Errorf("wasm middleware error: invalid poolSize %s", middlewareMetadata.PoolSize)
+		}
+	}
+	return &middlewareMetadata, poolSize, nil
+}
+
+// pool keeps idle guest instances, as an instance handles a single request at a time
+type pool struct {
+	code      []byte
+	config    []byte
+	logger    logger.Logger
+	instances chan *instance
+}
+
+// get returns an idle instance, or a new one when they are all busy
+func (p *pool) get() (*instance, error) {
+	select {
+	case i := <-p.instances:
+		return i, nil
+	default:
+		return p.newInstance()
+	}
+}
+
+// put keeps the instance for the next requests, unless the pool is full
+func (p *pool) put(i *instance) {
+	select {
+	case p.instances <- i:
+	default:
+	}
+}
+
+// newInstance instantiates the module, with the host functions bound to the new instance
+func (p *pool) newInstance() (*instance, error) {
+	i := &instance{config: p.config, logger: p.logger}
+
+	module, err := wagon.ReadModule(bytes.NewReader(p.code), func(name string) (*wagon.Module, error) {
+		if name != hostModule {
+			return nil, fmt.Errorf("unsupported import module %s", name)
+		}
+		return i.hostModule(), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wasm middleware error: invalid module: %s", err)
+	}
+
+	if i.handleRequest, err = exportedFunction(module, handleRequestExport); err != nil {
+		return nil, err
+	}
+	if i.handleResponse, err = exportedFunction(module, handleResponseExport); err != nil {
+		return nil, err
+	}
+
+	if i.vm, err = exec.NewVM(module); err != nil {
+		return nil, fmt.Errorf("wasm middleware error: invalid module: %s", err)
+	}
+	i.vm.RecoverPanic = true
+	return i, nil
+}
+
+// exportedFunction returns the index of the function exported by the guest
+func exportedFunction(module *wagon.Module, name string) (int64, error) {
+	if module.Export != nil {
+		if e, ok := module.Export.Entries[name]; ok && e.Kind == wagon.ExternalFunction {
+			return int64(e.Index), nil
+		}
+	}
+	return 0, fmt.Errorf("wasm middleware error: the module does not export the %s function", name)
+}
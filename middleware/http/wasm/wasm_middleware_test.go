@@ -0,0 +1,247 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package wasm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dapr/components-contrib/middleware"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+// Encoding of the WebAssembly binary format, enough for the guest of the tests
+func uleb(v uint32) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			c |= 0x80
+		}
+		b = append(b, c)
+		if v == 0 {
+			return b
+		}
+	}
+}
+
+func sleb(v int64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+func vec(items ...[]byte) []byte {
+	return concat(uleb(uint32(len(items))), concat(items...))
+}
+
+func name(s string) []byte {
+	return concat(uleb(uint32(len(s))), []byte(s))
+}
+
+func section(id byte, payload []byte) []byte {
+	return concat([]byte{id}, uleb(uint32(len(payload))), payload)
+}
+
+func i32(v int64) []byte {
+	return concat([]byte{0x41}, sleb(v))
+}
+
+func call(index uint32) []byte {
+	return concat([]byte{0x10}, uleb(index))
+}
+
+const (
+	i32Type = 0x7f
+	i64Type = 0x7e
+)
+
+// testGuest returns a guest which denies the requests with 403 and a body when its config is "deny". Otherwise it
+// sets the X-Wasm header of the request, rewrites its URI to /rewritten and copies the method to the X-Wasm header
+// of the response.
+func testGuest() []byte {
+	// The data of the memory, at offset 0
+	data := "X-Wasmdapr/rewrittendenied"
+	const (
+		headerName, headerNameLength = 0, 6
+		headerValue, headerValueLen  = 6, 4
+		uri, uriLength               = 10, 10
+		body, bodyLength             = 20, 6
+		configBuf, methodBuf         = 100, 200
+	)
+
+	types := vec(
+		[]byte{0x60, 5, i32Type, i32Type, i32Type, i32Type, i32Type, 0}, // set_header_value
+		[]byte{0x60, 2, i32Type, i32Type, 0},                            // set_uri, handle_response
+		[]byte{0x60, 2, i32Type, i32Type, 1, i32Type},                   // get_config, get_method
+		[]byte{0x60, 1, i32Type, 0},                                     // set_status_code
+		[]byte{0x60, 3, i32Type, i32Type, i32Type, 0},                   // write_body
+		[]byte{0x60, 0, 1, i64Type},                                     // handle_request
+	)
+	imports := vec(
+		concat(name(hostModule), name("set_header_value"), []byte{0, 0}),
+		concat(name(hostModule), name("set_uri"), []byte{0, 1}),
+		concat(name(hostModule), name("get_config"), []byte{0, 2}),
+		concat(name(hostModule), name("set_status_code"), []byte{0, 3}),
+		concat(name(hostModule), name("write_body"), []byte{0, 4}),
+		concat(name(hostModule), name("get_method"), []byte{0, 2}),
+	)
+	const (
+		setHeaderValue = iota
+		setURI
+		getConfig
+		setStatusCode
+		writeBody
+		getMethod
+		handleRequest
+		handleResponse
+	)
+
+	handleRequestCode := concat(
+		// if get_config(configBuf, 16) == 4 { set_status_code(403); write_body(1, body); return 0 }
+		i32(configBuf), i32(16), call(getConfig), i32(4), []byte{0x46, 0x04, 0x40},
+		i32(403), call(setStatusCode),
+		i32(1), i32(body), i32(bodyLength), call(writeBody),
+		[]byte{0x42, 0, 0x0f, 0x0b},
+		// set_header_value(0, headerName, headerValue); set_uri(uri); return 1
+		i32(0), i32(headerName), i32(headerNameLength), i32(headerValue), i32(headerValueLen), call(setHeaderValue),
+		i32(uri), i32(uriLength), call(setURI),
+		[]byte{0x42, 1, 0x0b},
+	)
+	handleResponseCode := concat(
+		// set_header_value(1, headerName, methodBuf, get_method(methodBuf, 16))
+		i32(1), i32(headerName), i32(headerNameLength), i32(methodBuf),
+		i32(methodBuf), i32(16), call(getMethod),
+		call(setHeaderValue),
+		[]byte{0x0b},
+	)
+	code := vec(
+		concat(uleb(uint32(len(handleRequestCode)+1)), []byte{0}, handleRequestCode),
+		concat(uleb(uint32(len(handleResponseCode)+1)), []byte{0}, handleResponseCode),
+	)
+
+	return concat(
+		[]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00},
+		section(1, types),
+		section(2, imports),
+		section(3, vec([]byte{5}, []byte{1})),
+		section(5, vec([]byte{0, 1})),
+		section(7, vec(
+			concat(name("memory"), []byte{2, 0}),
+			concat(name(handleRequestExport), []byte{0}, uleb(handleRequest)),
+			concat(name(handleResponseExport), []byte{0}, uleb(handleResponse)),
+		)),
+		section(10, code),
+		section(11, vec(concat([]byte{0}, i32(0), []byte{0x0b}, name(data)))),
+	)
+}
+
+func writeGuest(t *testing.T, code []byte) string {
+	dir, err := ioutil.TempDir("", "wasm")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	path := filepath.Join(dir, "guest.wasm")
+	require.NoError(t, ioutil.WriteFile(path, code, 0600))
+	return path
+}
+
+func TestGetNativeMetadata(t *testing.T) {
+	m := NewMiddleware(logger.NewLogger("test"))
+
+	meta, poolSize, err := m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"path": "guest.wasm"}})
+	require.NoError(t, err)
+	assert.Equal(t, "guest.wasm", meta.Path)
+	assert.Equal(t, defaultPoolSize, poolSize)
+
+	_, poolSize, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"path": "guest.wasm", "poolSize": "2"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, poolSize)
+
+	_, _, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{}})
+	assert.Error(t, err)
+	_, _, err = m.getNativeMetadata(middleware.Metadata{Properties: map[string]string{"path": "guest.wasm", "poolSize": "0"}})
+	assert.Error(t, err)
+}
+
+func TestInvalidModule(t *testing.T) {
+	m := NewMiddleware(logger.NewLogger("test"))
+
+	_, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{"path": "missing.wasm"}})
+	assert.Error(t, err)
+
+	_, err = m.GetHandler(middleware.Metadata{Properties: map[string]string{"path": writeGuest(t, []byte("not wasm"))}})
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	path := writeGuest(t, testGuest())
+
+	t.Run("Rewrites the request and the response", func(t *testing.T) {
+		m := NewMiddleware(logger.NewLogger("test"))
+		handler, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{"path": path}})
+		require.NoError(t, err)
+
+		var uri, header string
+		h := handler(func(ctx *fasthttp.RequestCtx) {
+			uri = string(ctx.RequestURI())
+			header = string(ctx.Request.Header.Peek("X-Wasm"))
+			ctx.SetBodyString("ok")
+		})
+
+		for j := 0; j < 3; j++ {
+			ctx := &fasthttp.RequestCtx{}
+			ctx.Request.Header.SetMethod(fasthttp.MethodPut)
+			ctx.Request.SetRequestURI("/original?a=b")
+			h(ctx)
+
+			assert.Equal(t, "/rewritten", uri)
+			assert.Equal(t, "dapr", header)
+			assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+			assert.Equal(t, "ok", string(ctx.Response.Body()))
+			assert.Equal(t, fasthttp.MethodPut, string(ctx.Response.Header.Peek("X-Wasm")))
+		}
+	})
+
+	t.Run("Writes the response", func(t *testing.T) {
+		m := NewMiddleware(logger.NewLogger("test"))
+		handler, err := m.GetHandler(middleware.Metadata{Properties: map[string]string{"path": path, "guestConfig": "deny"}})
+		require.NoError(t, err)
+
+		called := false
+		h := handler(func(ctx *fasthttp.RequestCtx) {
+			called = true
+		})
+
+		ctx := &fasthttp.RequestCtx{}
+		h(ctx)
+		assert.False(t, called)
+		assert.Equal(t, fasthttp.StatusForbidden, ctx.Response.StatusCode())
+		assert.Equal(t, "denied", string(ctx.Response.Body()))
+	})
+}
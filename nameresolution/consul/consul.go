@@ -0,0 +1,189 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package consul
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/hashicorp/consul/api"
+)
+
+const (
+	defaultCheckInterval                  = "10s"
+	defaultCheckTimeout                   = "5s"
+	defaultDeregisterCriticalServiceAfter = "1m"
+)
+
+type consulConfig struct {
+	Datacenter string `json:"datacenter"`
+	HTTPAddr   string `json:"httpAddr"`
+	ACLToken   string `json:"aclToken"`
+	Scheme     string `json:"scheme"`
+	// Tags are the comma separated tags of the registered service
+	Tags string `json:"tags"`
+	// The registered service has a TCP health check of the address and port of the sidecar
+	CheckInterval                  string `json:"checkInterval"`
+	CheckTimeout                   string `json:"checkTimeout"`
+	DeregisterCriticalServiceAfter string `json:"deregisterCriticalServiceAfter"`
+}
+
+// NewResolver creates Consul name resolver.
+func NewResolver(logger logger.Logger) nameresolution.Resolver {
+	return &resolver{logger: logger}
+}
+
+type resolver struct {
+	client *api.Client
+	logger logger.Logger
+}
+
+// Init registers the sidecar as a service of the Consul agent.
+func (r *resolver) Init(metadata nameresolution.Metadata) error {
+	var props = metadata.Properties
+
+	id, ok := props[nameresolution.MDNSInstanceName]
+	if !ok {
+		return errors.New("name is missing")
+	}
+	hostAddress, ok := props[nameresolution.MDNSInstanceAddress]
+	if !ok {
+		return errors.New("address is missing")
+	}
+	p, ok := props[nameresolution.MDNSInstancePort]
+	if !ok {
+		return errors.New("port is missing")
+	}
+	port, err := strconv.ParseInt(p, 10, 32)
+	if err != nil {
+		return errors.New("port is invalid")
+	}
+
+	config, err := metadataToConfig(props)
+	if err != nil {
+		return fmt.Errorf("couldn't convert metadata properties: %s", err)
+	}
+
+	client, err := api.NewClient(&api.Config{
+		Datacenter: config.Datacenter,
+		Address:    config.HTTPAddr,
+		Token:      config.ACLToken,
+		Scheme:     config.Scheme,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize consul client: %s", err)
+	}
+	r.client = client
+
+	registration := newRegistration(id, hostAddress, int(port), config)
+	if err = client.Agent().ServiceRegister(registration); err != nil {
+		return fmt.Errorf("failed to register service %s: %s", id, err)
+	}
+	r.logger.Infof("service registered in consul: %s -> %s:%d", id, hostAddress, port)
+
+	go r.deregisterOnShutdown(registration.ID)
+
+	return nil
+}
+
+func newRegistration(id, hostAddress string, port int, config *consulConfig) *api.AgentServiceRegistration {
+	var tags []string
+	for _, tag := range strings.Split(config.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	address := net.JoinHostPort(hostAddress, strconv.Itoa(port))
+	return &api.AgentServiceRegistration{
+		// The ID is unique to the instance, as all the instances of the app are registered with its name
+		ID:      fmt.Sprintf("%s-%s", id, address),
+		Name:    id,
+		Tags:    tags,
+		Address: hostAddress,
+		Port:    port,
+		Check: &api.AgentServiceCheck{
+			Name:                           fmt.Sprintf("Dapr sidecar %s", address),
+			TCP:                            address,
+			Interval:                       config.CheckInterval,
+			Timeout:                        config.CheckTimeout,
+			DeregisterCriticalServiceAfter: config.DeregisterCriticalServiceAfter,
+		},
+	}
+}
+
+// deregisterOnShutdown removes the service of the sidecar when it gets a SIGTERM event, the others being removed
+// by the agent once their health check is critical for long enough.
+func (r *resolver) deregisterOnShutdown(serviceID string) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	<-sig
+
+	if err := r.client.Agent().ServiceDeregister(serviceID); err != nil {
+		r.logger.Errorf("failed to deregister service %s: %s", serviceID, err)
+	}
+}
+
+// ResolveID resolves name to the address of one of the healthy instances of the service.
+func (r *resolver) ResolveID(req nameresolution.ResolveRequest) (string, error) {
+	entries, _, err := r.client.Health().Service(req.ID, "", true, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to query healthy instances of %s: %s", req.ID, err)
+	}
+	if len(entries) == 0 {
+		return "", fmt.Errorf("couldn't find healthy instances of service: %s", req.ID)
+	}
+
+	entry := entries[rand.Intn(len(entries))]
+	addr := entry.Service.Address
+	if addr == "" {
+		// The services registered without address have the address of their node
+		addr = entry.Node.Address
+	}
+
+	return net.JoinHostPort(addr, strconv.Itoa(entry.Service.Port)), nil
+}
+
+func metadataToConfig(props map[string]string) (*consulConfig, error) {
+	b, err := json.Marshal(props)
+	if err != nil {
+		return nil, err
+	}
+
+	var config consulConfig
+	err = json.Unmarshal(b, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.CheckInterval == "" {
+		config.CheckInterval = defaultCheckInterval
+	}
+	if config.CheckTimeout == "" {
+		config.CheckTimeout = defaultCheckTimeout
+	}
+	if config.DeregisterCriticalServiceAfter == "" {
+		config.DeregisterCriticalServiceAfter = defaultDeregisterCriticalServiceAfter
+	}
+	for _, d := range []string{config.CheckInterval, config.CheckTimeout, config.DeregisterCriticalServiceAfter} {
+		if _, err := time.ParseDuration(d); err != nil {
+			return nil, fmt.Errorf("invalid duration %s", d)
+		}
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,162 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package consul
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	nr "github.com/dapr/components-contrib/nameresolution"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAgent is a Consul agent registering the services, which are all healthy
+type fakeAgent struct {
+	server     *httptest.Server
+	services   []*api.AgentServiceRegistration
+	tokens     []string
+	datacenter string
+}
+
+func newFakeAgent() *fakeAgent {
+	a := &fakeAgent{}
+	a.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.tokens = append(a.tokens, r.Header.Get("X-Consul-Token"))
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/agent/service/register":
+			var s api.AgentServiceRegistration
+			if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			a.services = append(a.services, &s)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/health/service/"):
+			a.datacenter = r.URL.Query().Get("dc")
+			entries := []*api.ServiceEntry{}
+			for _, s := range a.services {
+				if s.Name == strings.TrimPrefix(r.URL.Path, "/v1/health/service/") {
+					entries = append(entries, &api.ServiceEntry{
+						Node:    &api.Node{Address: "10.0.0.1"},
+						Service: &api.AgentService{ID: s.ID, Service: s.Name, Address: s.Address, Port: s.Port},
+					})
+				}
+			}
+			json.NewEncoder(w).Encode(entries)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return a
+}
+
+func TestInit(t *testing.T) {
+	a := newFakeAgent()
+	defer a.server.Close()
+
+	r := NewResolver(logger.NewLogger("test"))
+	err := r.Init(nr.Metadata{Properties: map[string]string{
+		nr.MDNSInstanceName:    "testAppID",
+		nr.MDNSInstanceAddress: "127.0.0.1",
+		nr.MDNSInstancePort:    "50002",
+		"httpAddr":             a.server.Listener.Addr().String(),
+		"aclToken":             "token",
+		"tags":                 "dapr, v1",
+		"checkInterval":        "5s",
+	}})
+	require.NoError(t, err)
+
+	require.Len(t, a.services, 1)
+	s := a.services[0]
+	assert.Equal(t, "testAppID-127.0.0.1:50002", s.ID)
+	assert.Equal(t, "testAppID", s.Name)
+	assert.Equal(t, "127.0.0.1", s.Address)
+	assert.Equal(t, 50002, s.Port)
+	assert.Equal(t, []string{"dapr", "v1"}, s.Tags)
+	assert.Equal(t, "127.0.0.1:50002", s.Check.TCP)
+	assert.Equal(t, "5s", s.Check.Interval)
+	assert.Equal(t, defaultCheckTimeout, s.Check.Timeout)
+	assert.Equal(t, defaultDeregisterCriticalServiceAfter, s.Check.DeregisterCriticalServiceAfter)
+	assert.Equal(t, []string{"token"}, a.tokens)
+}
+
+func TestInitInvalidMetadata(t *testing.T) {
+	var tests = []struct {
+		name  string
+		props map[string]string
+	}{
+		{"missing name", map[string]string{nr.MDNSInstanceAddress: "127.0.0.1", nr.MDNSInstancePort: "50002"}},
+		{"missing address", map[string]string{nr.MDNSInstanceName: "testAppID", nr.MDNSInstancePort: "50002"}},
+		{"missing port", map[string]string{nr.MDNSInstanceName: "testAppID", nr.MDNSInstanceAddress: "127.0.0.1"}},
+		{"invalid port", map[string]string{nr.MDNSInstanceName: "testAppID", nr.MDNSInstanceAddress: "127.0.0.1", nr.MDNSInstancePort: "abcd"}},
+		{"invalid check interval", map[string]string{nr.MDNSInstanceName: "testAppID", nr.MDNSInstanceAddress: "127.0.0.1", nr.MDNSInstancePort: "50002", "checkInterval": "10"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewResolver(logger.NewLogger("test"))
+			assert.Error(t, r.Init(nr.Metadata{Properties: tt.props}))
+		})
+	}
+}
+
+func TestInitRegistrationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	r := NewResolver(logger.NewLogger("test"))
+	err := r.Init(nr.Metadata{Properties: map[string]string{
+		nr.MDNSInstanceName:    "testAppID",
+		nr.MDNSInstanceAddress: "127.0.0.1",
+		nr.MDNSInstancePort:    "50002",
+		"httpAddr":             server.Listener.Addr().String(),
+	}})
+	assert.Error(t, err)
+}
+
+func TestResolveID(t *testing.T) {
+	a := newFakeAgent()
+	defer a.server.Close()
+	a.services = []*api.AgentServiceRegistration{
+		{Name: "otherAppID", Address: "10.0.0.3", Port: 50002},
+		{Name: "noAddressAppID", Port: 50003},
+	}
+
+	r := NewResolver(logger.NewLogger("test"))
+	err := r.Init(nr.Metadata{Properties: map[string]string{
+		nr.MDNSInstanceName:    "testAppID",
+		nr.MDNSInstanceAddress: "10.0.0.2",
+		nr.MDNSInstancePort:    "50002",
+		"httpAddr":             a.server.Listener.Addr().String(),
+		"datacenter":           "dc2",
+	}})
+	require.NoError(t, err)
+
+	t.Run("healthy instance", func(t *testing.T) {
+		addr, err := r.ResolveID(nr.ResolveRequest{ID: "otherAppID"})
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.3:50002", addr)
+		assert.Equal(t, "dc2", a.datacenter)
+	})
+
+	t.Run("node address", func(t *testing.T) {
+		addr, err := r.ResolveID(nr.ResolveRequest{ID: "noAddressAppID"})
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.1:50003", addr)
+	})
+
+	t.Run("no healthy instance", func(t *testing.T) {
+		_, err := r.ResolveID(nr.ResolveRequest{ID: "missingAppID"})
+		assert.Error(t, err)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
@@ -20,15 +21,39 @@ import (
 	"github.com/grandcat/zeroconf"
 )
 
-const browseTimeout = time.Second * 1
+const (
+	browseTimeout = time.Second * 1
+	// maxCacheTTL is the longest time an address is cached, as the records of the services have long TTLs
+	maxCacheTTL = time.Second * 60
+)
 
 // NewResolver creates the instance of mDNS name resolver.
 func NewResolver(logger logger.Logger) nameresolution.Resolver {
-	return &resolver{logger: logger}
+	return &resolver{
+		logger:      logger,
+		browse:      browseMDNS,
+		cache:       map[string]cachedAddress{},
+		subscribers: map[string][]chan browseResult{},
+	}
 }
 
 type resolver struct {
 	logger logger.Logger
+	browse func(id string) (string, time.Duration, error)
+
+	lock        sync.Mutex
+	cache       map[string]cachedAddress
+	subscribers map[string][]chan browseResult
+}
+
+type cachedAddress struct {
+	addr      string
+	expiresAt time.Time
+}
+
+type browseResult struct {
+	addr string
+	err  error
 }
 
 // Init registers service for mDNS.
@@ -99,52 +124,103 @@ func (m *resolver) registerMDNS(id string, ips []string, port int) error {
 	return err
 }
 
-// ResolveID resolves name to address via mDNS.
+// ResolveID resolves name to address via mDNS. The addresses are cached, and the concurrent requests of an app ID
+// share the result of a single browse.
 func (m *resolver) ResolveID(req nameresolution.ResolveRequest) (string, error) {
+	m.lock.Lock()
+	if cached, ok := m.cache[req.ID]; ok && time.Now().Before(cached.expiresAt) {
+		m.lock.Unlock()
+		return cached.addr, nil
+	}
+
+	// The first request of the app ID browses it, the others subscribe to its result.
+	result := make(chan browseResult, 1)
+	subscribers, browsing := m.subscribers[req.ID]
+	m.subscribers[req.ID] = append(subscribers, result)
+	m.lock.Unlock()
+
+	if !browsing {
+		m.browseAndNotify(req.ID)
+	}
+
+	res := <-result
+	return res.addr, res.err
+}
+
+func (m *resolver) browseAndNotify(id string) {
+	addr, ttl, err := m.browse(id)
+
+	m.lock.Lock()
+	if err == nil {
+		if ttl <= 0 || ttl > maxCacheTTL {
+			ttl = maxCacheTTL
+		}
+		m.cache[id] = cachedAddress{addr: addr, expiresAt: time.Now().Add(ttl)}
+	}
+	subscribers := m.subscribers[id]
+	delete(m.subscribers, id)
+	m.lock.Unlock()
+
+	for _, s := range subscribers {
+		s <- browseResult{addr: addr, err: err}
+	}
+}
+
+// browseMDNS returns the address of the first instance of the app ID found and the TTL of its record.
+func browseMDNS(id string) (string, time.Duration, error) {
 	resolver, err := zeroconf.NewResolver(nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to initialize resolver: %e", err)
+		return "", 0, fmt.Errorf("failed to initialize resolver: %s", err)
 	}
 
-	port := -1
-	var addr string
 	entries := make(chan *zeroconf.ServiceEntry)
+	found := make(chan browseResult, 1)
+	var ttl time.Duration
 
 	ctx, cancel := context.WithTimeout(context.Background(), browseTimeout)
+	defer cancel()
 
 	go func(results <-chan *zeroconf.ServiceEntry) {
 		for entry := range results {
 			for _, text := range entry.Text {
-				if text == req.ID {
-					port = entry.Port
-					if len(entry.AddrIPv4) > 0 {
-						addr = entry.AddrIPv4[0].String() // entry has IPv4
-					} else if len(entry.AddrIPv6) > 0 {
-						addr = entry.AddrIPv6[0].String() // entry has IPv6
-					} else {
-						addr = "localhost" // default
-					}
-
-					// cancel timeout because it found the service
-					cancel()
-					return
+				if text != id {
+					continue
+				}
+
+				var addr string
+				if len(entry.AddrIPv4) > 0 {
+					addr = entry.AddrIPv4[0].String() // entry has IPv4
+				} else if len(entry.AddrIPv6) > 0 {
+					addr = entry.AddrIPv6[0].String() // entry has IPv6
+				} else {
+					addr = "localhost" // default
 				}
+
+				ttl = time.Duration(entry.TTL) * time.Second
+				found <- browseResult{addr: fmt.Sprintf("%s:%d", addr, entry.Port)}
+
+				// cancel timeout because it found the service
+				cancel()
+				return
 			}
 		}
 	}(entries)
 
-	if err = resolver.Browse(ctx, req.ID, "local.", entries); err != nil {
-		// cancel context
-		cancel()
-		return "", fmt.Errorf("failed to browse: %s", err.Error())
+	if err = resolver.Browse(ctx, id, "local.", entries); err != nil {
+		return "", 0, fmt.Errorf("failed to browse: %s", err.Error())
 	}
 
-	// wait until context is cancelled or timeed out.
-	<-ctx.Done()
-
-	if port == -1 || addr == "" {
-		return "", fmt.Errorf("couldn't find service: %s", req.ID)
+	// wait until the service is found or the browse timed out.
+	select {
+	case res := <-found:
+		return res.addr, ttl, nil
+	case <-ctx.Done():
 	}
 
-	return fmt.Sprintf("%s:%d", addr, port), nil
+	select {
+	case res := <-found:
+		return res.addr, ttl, nil
+	default:
+		return "", 0, fmt.Errorf("couldn't find service: %s", id)
+	}
 }
@@ -6,7 +6,11 @@
 package mdns
 
 import (
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	nr "github.com/dapr/components-contrib/nameresolution"
 	"github.com/dapr/dapr/pkg/logger"
@@ -84,3 +88,72 @@ func TestResolver(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "127.0.0.1:1234", pt)
 }
+
+func TestResolverCache(t *testing.T) {
+	// arrange
+	var browsed int32
+	r := NewResolver(logger.NewLogger("test")).(*resolver)
+	r.browse = func(id string) (string, time.Duration, error) {
+		if atomic.AddInt32(&browsed, 1) > 1 {
+			return "", 0, errors.New("not found")
+		}
+		return "10.0.0.1:1234", time.Hour, nil
+	}
+
+	// act
+	addr, err := r.ResolveID(nr.ResolveRequest{ID: "testAppID"})
+	require.NoError(t, err)
+	cached, err := r.ResolveID(nr.ResolveRequest{ID: "testAppID"})
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:1234", addr)
+	assert.Equal(t, addr, cached)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&browsed))
+	assert.True(t, r.cache["testAppID"].expiresAt.Before(time.Now().Add(maxCacheTTL+time.Second)))
+
+	// the expired addresses are browsed again
+	r.cache["testAppID"] = cachedAddress{addr: addr, expiresAt: time.Now().Add(-time.Second)}
+	_, err = r.ResolveID(nr.ResolveRequest{ID: "testAppID"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&browsed))
+}
+
+func TestResolverSubscribers(t *testing.T) {
+	// arrange
+	var browsed int32
+	release := make(chan struct{})
+	r := NewResolver(logger.NewLogger("test")).(*resolver)
+	r.browse = func(id string) (string, time.Duration, error) {
+		atomic.AddInt32(&browsed, 1)
+		<-release
+		return "10.0.0.1:1234", time.Minute, nil
+	}
+
+	// act
+	const requests = 10
+	var wg sync.WaitGroup
+	addrs := make([]string, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			addrs[i], _ = r.ResolveID(nr.ResolveRequest{ID: "testAppID"})
+		}(i)
+	}
+	// wait until all the requests are subscribed to the browse in progress
+	require.Eventually(t, func() bool {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		return len(r.subscribers["testAppID"]) == requests
+	}, 5*time.Second, 10*time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&browsed))
+	for _, addr := range addrs {
+		assert.Equal(t, "10.0.0.1:1234", addr)
+	}
+	assert.Empty(t, r.subscribers)
+}
@@ -0,0 +1,203 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dapr/components-contrib/bindings"
+	components "github.com/dapr/components-contrib/pluggable/proto/components/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// inputBindingServer serves an input binding over the InputBinding service
+type inputBindingServer struct {
+	binding bindings.InputBinding
+	// lock guards reading, whether the binding is being read, and stream, the current stream of the reads, which is
+	// nil between two streams. The binding is read once, as the bindings can't stop reading, and its events fail
+	// until Dapr reads them again.
+	lock    sync.Mutex
+	reading bool
+	stream  *readStream
+}
+
+// readStream is a stream reading the events of an input binding
+type readStream struct {
+	*ackStream
+	stream components.InputBinding_ReadServer
+}
+
+// RegisterInputBinding registers the InputBinding service of the binding with the server
+func RegisterInputBinding(s *grpc.Server, binding bindings.InputBinding) {
+	components.RegisterInputBindingServer(s, &inputBindingServer{binding: binding})
+}
+
+func (i *inputBindingServer) Init(ctx context.Context, req *components.InputBindingInitRequest) (*components.InputBindingInitResponse, error) {
+	err := i.binding.Init(bindings.Metadata{Properties: req.GetMetadata().GetProperties()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.InputBindingInitResponse{}, nil
+}
+
+// Read sends the events of the binding on the stream until it is closed, or until reading the binding fails.
+// The events fail when the application fails to handle them, or when the stream is closed before they are
+// acknowledged.
+func (i *inputBindingServer) Read(stream components.InputBinding_ReadServer) error {
+	s := &readStream{ackStream: newAckStream(), stream: stream}
+	i.start(s)
+	defer i.stop(s)
+	defer s.close(nil)
+
+	received := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				received <- err
+				return
+			}
+			s.ack(req.GetMessageId(), ackError(req.GetResponseError() != nil, req.GetResponseError().GetMessage()))
+		}
+	}()
+
+	select {
+	case err := <-received:
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	case <-s.done:
+		return s.closeErr()
+	}
+}
+
+func (i *inputBindingServer) Ping(ctx context.Context, req *components.PingRequest) (*components.PingResponse, error) {
+	err := ping(i.binding)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &components.PingResponse{}, nil
+}
+
+// start makes s the stream of the reads, and starts reading the binding unless it is being read. When reading
+// fails, the current stream is closed with the error, and the binding is read again by the next stream.
+func (i *inputBindingServer) start(s *readStream) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.stream = s
+	if i.reading {
+		return
+	}
+	i.reading = true
+
+	go func() {
+		err := i.binding.Read(i.handle)
+		if err == nil {
+			return
+		}
+
+		i.lock.Lock()
+		i.reading = false
+		current := i.stream
+		i.lock.Unlock()
+		if current != nil {
+			current.close(status.Errorf(codes.Unavailable, "failed to read the binding: %s", err))
+		}
+	}()
+}
+
+// stop removes s from the reads, unless another stream replaced it
+func (i *inputBindingServer) stop(s *readStream) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.stream == s {
+		i.stream = nil
+	}
+}
+
+// handle delivers an event of the binding to the current stream
+func (i *inputBindingServer) handle(resp *bindings.ReadResponse) error {
+	i.lock.Lock()
+	s := i.stream
+	i.lock.Unlock()
+	if s == nil {
+		return fmt.Errorf("no stream reads the events of the binding")
+	}
+
+	return s.deliver(func(id string) error {
+		return s.stream.Send(&components.ReadResponse{Data: resp.Data, Metadata: resp.Metadata, MessageId: id})
+	})
+}
+
+// outputBindingServer serves an output binding over the OutputBinding service
+type outputBindingServer struct {
+	binding bindings.OutputBinding
+}
+
+// RegisterOutputBinding registers the OutputBinding service of the binding with the server
+func RegisterOutputBinding(s *grpc.Server, binding bindings.OutputBinding) {
+	components.RegisterOutputBindingServer(s, &outputBindingServer{binding: binding})
+}
+
+func (o *outputBindingServer) Init(ctx context.Context, req *components.OutputBindingInitRequest) (*components.OutputBindingInitResponse, error) {
+	err := o.binding.Init(bindings.Metadata{Properties: req.GetMetadata().GetProperties()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.OutputBindingInitResponse{}, nil
+}
+
+// Invoke invokes the binding. The invocations with an operation that the binding doesn't support are invalid.
+func (o *outputBindingServer) Invoke(ctx context.Context, req *components.InvokeRequest) (*components.InvokeResponse, error) {
+	resp, err := o.binding.Invoke(&bindings.InvokeRequest{
+		Data:      req.GetData(),
+		Metadata:  req.GetMetadata(),
+		Operation: bindings.OperationKind(req.GetOperation()),
+	})
+	if err != nil {
+		var unsupported *bindings.UnsupportedOperationError
+		if errors.As(err, &unsupported) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return &components.InvokeResponse{}, nil
+	}
+
+	return &components.InvokeResponse{Data: resp.Data, Metadata: resp.Metadata}, nil
+}
+
+func (o *outputBindingServer) ListOperations(ctx context.Context, req *components.ListOperationsRequest) (*components.ListOperationsResponse, error) {
+	operations := o.binding.Operations()
+	result := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		result = append(result, string(operation))
+	}
+
+	return &components.ListOperationsResponse{Operations: result}, nil
+}
+
+func (o *outputBindingServer) Ping(ctx context.Context, req *components.PingRequest) (*components.PingResponse, error) {
+	err := ping(o.binding)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &components.PingResponse{}, nil
+}
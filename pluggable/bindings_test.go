@@ -0,0 +1,174 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/metadata"
+	components "github.com/dapr/components-contrib/pluggable/proto/components/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeInputBinding sends its events to the handler of Read, and returns the results of the handler
+type fakeInputBinding struct {
+	events  chan string
+	results chan error
+	readErr error
+}
+
+func newFakeInputBinding() *fakeInputBinding {
+	return &fakeInputBinding{events: make(chan string), results: make(chan error)}
+}
+
+func (f *fakeInputBinding) Init(metadata bindings.Metadata) error {
+	return nil
+}
+
+func (f *fakeInputBinding) Read(handler func(*bindings.ReadResponse) error) error {
+	if f.readErr != nil {
+		return f.readErr
+	}
+	for event := range f.events {
+		f.results <- handler(&bindings.ReadResponse{Data: []byte(event), Metadata: map[string]string{"event": event}})
+	}
+	return nil
+}
+
+func (f *fakeInputBinding) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+// fakeOutputBinding returns the data of the invocations, and fails those with the fail metadata
+type fakeOutputBinding struct{}
+
+func (f *fakeOutputBinding) Init(metadata bindings.Metadata) error {
+	return nil
+}
+
+func (f *fakeOutputBinding) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	if !bindings.SupportsOperation(f, req.Operation) {
+		return nil, bindings.NewUnsupportedOperationError(req.Operation, f.Operations())
+	}
+	if req.Metadata["fail"] != "" {
+		return nil, errors.New(req.Metadata["fail"])
+	}
+	return &bindings.InvokeResponse{Data: req.Data, Metadata: map[string]string{"operation": string(req.Operation)}}, nil
+}
+
+func (f *fakeOutputBinding) Operations() []bindings.OperationKind {
+	return []bindings.OperationKind{bindings.CreateOperation, bindings.GetOperation}
+}
+
+func (f *fakeOutputBinding) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+// readEvent sends the event to the binding until the stream receives it, as the events fail until the server
+// received the stream. It returns the event received by the stream.
+func readEvent(t *testing.T, b *fakeInputBinding, stream components.InputBinding_ReadClient, event string) *components.ReadResponse {
+	received := make(chan *components.ReadResponse, 1)
+	go func() {
+		resp, err := stream.Recv()
+		if err == nil {
+			received <- resp
+		}
+	}()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case b.events <- event:
+		case <-timeout:
+			require.Fail(t, "the binding isn't read")
+		}
+		select {
+		case resp := <-received:
+			return resp
+		case <-b.results:
+			// the stream is not reading yet
+			time.Sleep(10 * time.Millisecond)
+		case <-timeout:
+			require.Fail(t, "the stream didn't receive the event")
+		}
+	}
+}
+
+func TestInputBinding(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Read with acknowledgements", func(t *testing.T) {
+		b := newFakeInputBinding()
+		defer close(b.events)
+		client := components.NewInputBindingClient(serve(t, WithInputBinding(b)))
+		_, err := client.Init(ctx, &components.InputBindingInitRequest{Metadata: &components.MetadataRequest{}})
+		require.NoError(t, err)
+		stream, err := client.Read(ctx)
+		require.NoError(t, err)
+
+		resp := readEvent(t, b, stream, "1")
+		assert.Equal(t, "1", string(resp.Data))
+		assert.Equal(t, map[string]string{"event": "1"}, resp.Metadata)
+		require.NoError(t, stream.Send(&components.ReadRequest{MessageId: resp.MessageId}))
+		assert.NoError(t, <-b.results)
+
+		resp = readEvent(t, b, stream, "2")
+		require.NoError(t, stream.Send(&components.ReadRequest{MessageId: resp.MessageId, ResponseError: &components.AckResponseError{Message: "failed"}}))
+		assert.EqualError(t, <-b.results, "failed")
+
+		_, err = client.Ping(ctx, &components.PingRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Read fails", func(t *testing.T) {
+		b := newFakeInputBinding()
+		b.readErr = errors.New("unreachable")
+		client := components.NewInputBindingClient(serve(t, WithInputBinding(b)))
+		stream, err := client.Read(ctx)
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestOutputBinding(t *testing.T) {
+	ctx := context.Background()
+	client := components.NewOutputBindingClient(serve(t, WithOutputBinding(&fakeOutputBinding{})))
+	_, err := client.Init(ctx, &components.OutputBindingInitRequest{Metadata: &components.MetadataRequest{}})
+	require.NoError(t, err)
+
+	t.Run("List operations", func(t *testing.T) {
+		resp, err := client.ListOperations(ctx, &components.ListOperationsRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"create", "get"}, resp.Operations)
+	})
+
+	t.Run("Invoke", func(t *testing.T) {
+		resp, err := client.Invoke(ctx, &components.InvokeRequest{Operation: "create", Data: []byte("data")})
+		require.NoError(t, err)
+		assert.Equal(t, "data", string(resp.Data))
+		assert.Equal(t, map[string]string{"operation": "create"}, resp.Metadata)
+	})
+
+	t.Run("Invoke fails", func(t *testing.T) {
+		_, err := client.Invoke(ctx, &components.InvokeRequest{Operation: "create", Metadata: map[string]string{"fail": "unreachable"}})
+		assert.Equal(t, codes.Unknown, status.Code(err))
+		assert.Equal(t, "unreachable", status.Convert(err).Message())
+	})
+
+	t.Run("Unsupported operation", func(t *testing.T) {
+		_, err := client.Invoke(ctx, &components.InvokeRequest{Operation: "delete"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,131 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package pluggable serves the components of this repository over the gRPC services of the Dapr pluggable
+// components, so that a component can also run in its own process, next to the Dapr sidecar which connects to it
+// over a unix domain socket.
+//
+// The services are those of dapr/proto/components/v1, whose definitions and generated code are in the proto
+// directory.
+package pluggable
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/state"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	// SocketFolderEnvVar is the environment variable of the folder where Dapr looks for the sockets of the pluggable
+	// components
+	SocketFolderEnvVar = "DAPR_COMPONENT_SOCKETS_FOLDER"
+	// DefaultSocketFolder is the folder of the sockets when SocketFolderEnvVar is not set
+	DefaultSocketFolder = "/tmp/dapr-components-sockets"
+)
+
+// Option registers the services of a component with a gRPC server
+type Option func(s *grpc.Server)
+
+// WithStateStore registers the services of the state store
+func WithStateStore(store state.Store) Option {
+	return func(s *grpc.Server) {
+		RegisterStateStore(s, store)
+	}
+}
+
+// WithPubSub registers the service of the pub/sub
+func WithPubSub(ps pubsub.PubSub) Option {
+	return func(s *grpc.Server) {
+		RegisterPubSub(s, ps)
+	}
+}
+
+// WithInputBinding registers the service of the input binding
+func WithInputBinding(binding bindings.InputBinding) Option {
+	return func(s *grpc.Server) {
+		RegisterInputBinding(s, binding)
+	}
+}
+
+// WithOutputBinding registers the service of the output binding
+func WithOutputBinding(binding bindings.OutputBinding) Option {
+	return func(s *grpc.Server) {
+		RegisterOutputBinding(s, binding)
+	}
+}
+
+// Register registers the services of the components with the server. A server serves at most one component of
+// each type, as Dapr names the components after the socket they are served on.
+func Register(s *grpc.Server, opts ...Option) {
+	for _, opt := range opts {
+		opt(s)
+	}
+}
+
+// SocketPath returns the path of the socket that Dapr connects to for the component name
+func SocketPath(name string) string {
+	folder := os.Getenv(SocketFolderEnvVar)
+	if folder == "" {
+		folder = DefaultSocketFolder
+	}
+
+	return filepath.Join(folder, name+".sock")
+}
+
+// Serve serves the components on the socket of name until the server fails. The socket left behind by a previous
+// process is removed first. The server also serves gRPC reflection, with which Dapr discovers the services of the
+// components.
+func Serve(name string, opts ...Option) error {
+	path := SocketPath(name)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create the folder of socket %s: %s", path, err)
+	}
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove socket %s: %s", path, err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on socket %s: %s", path, err)
+	}
+
+	s := grpc.NewServer()
+	Register(s, opts...)
+	reflection.Register(s)
+
+	return s.Serve(listener)
+}
+
+// features returns the capabilities of the component, which are the features implemented by the component in Dapr
+func features(m metadata.ComponentMetadata) []string {
+	result := make([]string, 0, len(m.Capabilities))
+	for _, c := range m.Capabilities {
+		result = append(result, string(c))
+	}
+
+	return result
+}
+
+// ping pings the component. The components that can't be pinged are considered alive, as their process is.
+func ping(component interface{}) error {
+	err := health.Ping(component)
+	if errors.Is(err, health.ErrPingNotImplemented) {
+		return nil
+	}
+
+	return err
+}
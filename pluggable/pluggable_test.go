@@ -0,0 +1,51 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serve serves the components in memory, and returns a client connection to them
+func serve(t *testing.T, opts ...Option) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	Register(s, opts...)
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufconn", grpc.WithInsecure(), grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return listener.Dial()
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return conn
+}
+
+func TestSocketPath(t *testing.T) {
+	t.Run("Default folder", func(t *testing.T) {
+		os.Unsetenv(SocketFolderEnvVar)
+		assert.Equal(t, filepath.Join(DefaultSocketFolder, "redis.sock"), SocketPath("redis"))
+	})
+
+	t.Run("Folder of the environment variable", func(t *testing.T) {
+		os.Setenv(SocketFolderEnvVar, "/var/run/components")
+		defer os.Unsetenv(SocketFolderEnvVar)
+		assert.Equal(t, "/var/run/components/redis.sock", SocketPath("redis"))
+	})
+}
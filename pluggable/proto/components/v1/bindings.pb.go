@@ -0,0 +1,979 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/bindings.proto
+
+package components
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// reserved for future-proof extensibility
+type ListOperationsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOperationsRequest) Reset()         { *m = ListOperationsRequest{} }
+func (m *ListOperationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsRequest) ProtoMessage()    {}
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{0}
+}
+
+func (m *ListOperationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsRequest.Unmarshal(m, b)
+}
+func (m *ListOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsRequest.Merge(m, src)
+}
+func (m *ListOperationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsRequest.Size(m)
+}
+func (m *ListOperationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsRequest proto.InternalMessageInfo
+
+type ListOperationsResponse struct {
+	// the list of all supported component operations.
+	Operations           []string `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOperationsResponse) Reset()         { *m = ListOperationsResponse{} }
+func (m *ListOperationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsResponse) ProtoMessage()    {}
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{1}
+}
+
+func (m *ListOperationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsResponse.Unmarshal(m, b)
+}
+func (m *ListOperationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsResponse.Merge(m, src)
+}
+func (m *ListOperationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsResponse.Size(m)
+}
+func (m *ListOperationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsResponse proto.InternalMessageInfo
+
+func (m *ListOperationsResponse) GetOperations() []string {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+// InputBindingInitRequest is the request for initializing the input binding
+// component.
+type InputBindingInitRequest struct {
+	// The metadata request.
+	Metadata             *MetadataRequest `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *InputBindingInitRequest) Reset()         { *m = InputBindingInitRequest{} }
+func (m *InputBindingInitRequest) String() string { return proto.CompactTextString(m) }
+func (*InputBindingInitRequest) ProtoMessage()    {}
+func (*InputBindingInitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{2}
+}
+
+func (m *InputBindingInitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InputBindingInitRequest.Unmarshal(m, b)
+}
+func (m *InputBindingInitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InputBindingInitRequest.Marshal(b, m, deterministic)
+}
+func (m *InputBindingInitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InputBindingInitRequest.Merge(m, src)
+}
+func (m *InputBindingInitRequest) XXX_Size() int {
+	return xxx_messageInfo_InputBindingInitRequest.Size(m)
+}
+func (m *InputBindingInitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InputBindingInitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InputBindingInitRequest proto.InternalMessageInfo
+
+func (m *InputBindingInitRequest) GetMetadata() *MetadataRequest {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type InputBindingInitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InputBindingInitResponse) Reset()         { *m = InputBindingInitResponse{} }
+func (m *InputBindingInitResponse) String() string { return proto.CompactTextString(m) }
+func (*InputBindingInitResponse) ProtoMessage()    {}
+func (*InputBindingInitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{3}
+}
+
+func (m *InputBindingInitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InputBindingInitResponse.Unmarshal(m, b)
+}
+func (m *InputBindingInitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InputBindingInitResponse.Marshal(b, m, deterministic)
+}
+func (m *InputBindingInitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InputBindingInitResponse.Merge(m, src)
+}
+func (m *InputBindingInitResponse) XXX_Size() int {
+	return xxx_messageInfo_InputBindingInitResponse.Size(m)
+}
+func (m *InputBindingInitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InputBindingInitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InputBindingInitResponse proto.InternalMessageInfo
+
+// OutputBindingInitRequest is the request for initializing the output binding
+// component.
+type OutputBindingInitRequest struct {
+	// The metadata request.
+	Metadata             *MetadataRequest `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *OutputBindingInitRequest) Reset()         { *m = OutputBindingInitRequest{} }
+func (m *OutputBindingInitRequest) String() string { return proto.CompactTextString(m) }
+func (*OutputBindingInitRequest) ProtoMessage()    {}
+func (*OutputBindingInitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{4}
+}
+
+func (m *OutputBindingInitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputBindingInitRequest.Unmarshal(m, b)
+}
+func (m *OutputBindingInitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputBindingInitRequest.Marshal(b, m, deterministic)
+}
+func (m *OutputBindingInitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputBindingInitRequest.Merge(m, src)
+}
+func (m *OutputBindingInitRequest) XXX_Size() int {
+	return xxx_messageInfo_OutputBindingInitRequest.Size(m)
+}
+func (m *OutputBindingInitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputBindingInitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputBindingInitRequest proto.InternalMessageInfo
+
+func (m *OutputBindingInitRequest) GetMetadata() *MetadataRequest {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type OutputBindingInitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OutputBindingInitResponse) Reset()         { *m = OutputBindingInitResponse{} }
+func (m *OutputBindingInitResponse) String() string { return proto.CompactTextString(m) }
+func (*OutputBindingInitResponse) ProtoMessage()    {}
+func (*OutputBindingInitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{5}
+}
+
+func (m *OutputBindingInitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OutputBindingInitResponse.Unmarshal(m, b)
+}
+func (m *OutputBindingInitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OutputBindingInitResponse.Marshal(b, m, deterministic)
+}
+func (m *OutputBindingInitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OutputBindingInitResponse.Merge(m, src)
+}
+func (m *OutputBindingInitResponse) XXX_Size() int {
+	return xxx_messageInfo_OutputBindingInitResponse.Size(m)
+}
+func (m *OutputBindingInitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OutputBindingInitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OutputBindingInitResponse proto.InternalMessageInfo
+
+// Used for describing errors when ack'ing messages.
+type AckResponseError struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckResponseError) Reset()         { *m = AckResponseError{} }
+func (m *AckResponseError) String() string { return proto.CompactTextString(m) }
+func (*AckResponseError) ProtoMessage()    {}
+func (*AckResponseError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{6}
+}
+
+func (m *AckResponseError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckResponseError.Unmarshal(m, b)
+}
+func (m *AckResponseError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckResponseError.Marshal(b, m, deterministic)
+}
+func (m *AckResponseError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckResponseError.Merge(m, src)
+}
+func (m *AckResponseError) XXX_Size() int {
+	return xxx_messageInfo_AckResponseError.Size(m)
+}
+func (m *AckResponseError) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckResponseError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckResponseError proto.InternalMessageInfo
+
+func (m *AckResponseError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ReadRequest struct {
+	// The handle response.
+	ResponseData []byte `protobuf:"bytes,1,opt,name=response_data,json=responseData,proto3" json:"response_data,omitempty"`
+	// The unique message ID.
+	MessageId string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Optional, should not be fulfilled when the message was successfully
+	// handled.
+	ResponseError        *AckResponseError `protobuf:"bytes,3,opt,name=response_error,json=responseError,proto3" json:"response_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
+func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadRequest) ProtoMessage()    {}
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{7}
+}
+
+func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
+}
+func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRequest.Merge(m, src)
+}
+func (m *ReadRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadRequest.Size(m)
+}
+func (m *ReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
+
+func (m *ReadRequest) GetResponseData() []byte {
+	if m != nil {
+		return m.ResponseData
+	}
+	return nil
+}
+
+func (m *ReadRequest) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+func (m *ReadRequest) GetResponseError() *AckResponseError {
+	if m != nil {
+		return m.ResponseError
+	}
+	return nil
+}
+
+type ReadResponse struct {
+	// The Read binding Data.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The message metadata
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The message content type.
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// The {transient} message ID used for ACK-ing it later.
+	MessageId            string   `protobuf:"bytes,4,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
+func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadResponse) ProtoMessage()    {}
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{8}
+}
+
+func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
+}
+func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadResponse.Merge(m, src)
+}
+func (m *ReadResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadResponse.Size(m)
+}
+func (m *ReadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
+
+func (m *ReadResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReadResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ReadResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *ReadResponse) GetMessageId() string {
+	if m != nil {
+		return m.MessageId
+	}
+	return ""
+}
+
+// Used for invoking systems with optional payload.
+type InvokeRequest struct {
+	// The invoke payload.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The invoke metadata.
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The system supported operation.
+	Operation            string   `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvokeRequest) Reset()         { *m = InvokeRequest{} }
+func (m *InvokeRequest) String() string { return proto.CompactTextString(m) }
+func (*InvokeRequest) ProtoMessage()    {}
+func (*InvokeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{9}
+}
+
+func (m *InvokeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeRequest.Unmarshal(m, b)
+}
+func (m *InvokeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeRequest.Marshal(b, m, deterministic)
+}
+func (m *InvokeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeRequest.Merge(m, src)
+}
+func (m *InvokeRequest) XXX_Size() int {
+	return xxx_messageInfo_InvokeRequest.Size(m)
+}
+func (m *InvokeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeRequest proto.InternalMessageInfo
+
+func (m *InvokeRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *InvokeRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *InvokeRequest) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+// Response from the invoked system.
+type InvokeResponse struct {
+	// The response payload.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The response metadata.
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The response content-type.
+	ContentType          string   `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
+func (m *InvokeResponse) String() string { return proto.CompactTextString(m) }
+func (*InvokeResponse) ProtoMessage()    {}
+func (*InvokeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41e4fa278c4a6074, []int{10}
+}
+
+func (m *InvokeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InvokeResponse.Unmarshal(m, b)
+}
+func (m *InvokeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InvokeResponse.Marshal(b, m, deterministic)
+}
+func (m *InvokeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InvokeResponse.Merge(m, src)
+}
+func (m *InvokeResponse) XXX_Size() int {
+	return xxx_messageInfo_InvokeResponse.Size(m)
+}
+func (m *InvokeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InvokeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InvokeResponse proto.InternalMessageInfo
+
+func (m *InvokeResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *InvokeResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *InvokeResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ListOperationsRequest)(nil), "dapr.proto.components.v1.ListOperationsRequest")
+	proto.RegisterType((*ListOperationsResponse)(nil), "dapr.proto.components.v1.ListOperationsResponse")
+	proto.RegisterType((*InputBindingInitRequest)(nil), "dapr.proto.components.v1.InputBindingInitRequest")
+	proto.RegisterType((*InputBindingInitResponse)(nil), "dapr.proto.components.v1.InputBindingInitResponse")
+	proto.RegisterType((*OutputBindingInitRequest)(nil), "dapr.proto.components.v1.OutputBindingInitRequest")
+	proto.RegisterType((*OutputBindingInitResponse)(nil), "dapr.proto.components.v1.OutputBindingInitResponse")
+	proto.RegisterType((*AckResponseError)(nil), "dapr.proto.components.v1.AckResponseError")
+	proto.RegisterType((*ReadRequest)(nil), "dapr.proto.components.v1.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "dapr.proto.components.v1.ReadResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.ReadResponse.MetadataEntry")
+	proto.RegisterType((*InvokeRequest)(nil), "dapr.proto.components.v1.InvokeRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.InvokeRequest.MetadataEntry")
+	proto.RegisterType((*InvokeResponse)(nil), "dapr.proto.components.v1.InvokeResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.InvokeResponse.MetadataEntry")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/bindings.proto", fileDescriptor_41e4fa278c4a6074)
+}
+
+var fileDescriptor_41e4fa278c4a6074 = []byte{
+	// 634 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x8d, 0x93, 0x50, 0xc8, 0xe4, 0xa2, 0x6a, 0x05, 0xd4, 0x98, 0x8b, 0x82, 0x51, 0x69, 0x40,
+	0x10, 0x37, 0x29, 0xa0, 0x8a, 0x3e, 0x51, 0x91, 0x87, 0x08, 0xaa, 0xb6, 0x16, 0x12, 0x12, 0x08,
+	0x85, 0x4d, 0xbc, 0x0a, 0x56, 0x92, 0x5d, 0x77, 0xbd, 0x8e, 0x94, 0xff, 0xe1, 0x07, 0x78, 0xe0,
+	0x43, 0x78, 0xe3, 0x3f, 0xf8, 0x01, 0x64, 0x7b, 0xed, 0x38, 0x17, 0xa7, 0xa9, 0xa2, 0xbe, 0x79,
+	0x66, 0x67, 0xce, 0xcc, 0x99, 0xcb, 0xae, 0x61, 0xcf, 0xc2, 0x0e, 0x37, 0x1c, 0xce, 0x04, 0x33,
+	0x7a, 0x6c, 0xe4, 0x30, 0x4a, 0xa8, 0x70, 0x8d, 0x71, 0xc3, 0xe8, 0xda, 0xd4, 0xb2, 0x69, 0xdf,
+	0xad, 0x07, 0x87, 0x48, 0xf5, 0x0d, 0xc3, 0xef, 0xfa, 0xd4, 0xb0, 0x3e, 0x6e, 0x68, 0xbb, 0xa9,
+	0x10, 0x3d, 0x36, 0x1a, 0x31, 0x1a, 0x3a, 0xe9, 0x3b, 0x70, 0xe7, 0xa3, 0xed, 0x8a, 0x53, 0x87,
+	0x70, 0x2c, 0x6c, 0x46, 0x5d, 0x93, 0x5c, 0x78, 0xc4, 0x15, 0xfa, 0x21, 0xdc, 0x9d, 0x3f, 0x70,
+	0x1d, 0x46, 0x5d, 0x82, 0x1e, 0x01, 0xb0, 0x58, 0xab, 0x2a, 0xd5, 0x5c, 0xad, 0x60, 0x26, 0x34,
+	0xfa, 0x77, 0xd8, 0x69, 0x53, 0xc7, 0x13, 0xc7, 0x61, 0xaa, 0x6d, 0x6a, 0x0b, 0x09, 0x8a, 0x5a,
+	0x70, 0x6b, 0x44, 0x04, 0xb6, 0xb0, 0xc0, 0xaa, 0x52, 0x55, 0x6a, 0xc5, 0xe6, 0xb3, 0x7a, 0x1a,
+	0x83, 0xfa, 0x89, 0xb4, 0x94, 0xce, 0x66, 0xec, 0xaa, 0x6b, 0xa0, 0x2e, 0x46, 0x08, 0xb3, 0xd3,
+	0x31, 0xa8, 0xa7, 0x9e, 0xb8, 0xd6, 0xf0, 0xf7, 0xe1, 0xde, 0x92, 0x10, 0x32, 0xfe, 0x0b, 0xd8,
+	0x7e, 0xd7, 0x1b, 0x44, 0x62, 0x8b, 0x73, 0xc6, 0x91, 0x0a, 0x37, 0x47, 0xc4, 0x75, 0x71, 0x9f,
+	0x04, 0x61, 0x0b, 0x66, 0x24, 0xea, 0x3f, 0x15, 0x28, 0x9a, 0x04, 0x5b, 0x51, 0x86, 0x4f, 0xa0,
+	0xcc, 0xa5, 0x6b, 0x27, 0x4e, 0xb3, 0x64, 0x96, 0x22, 0xe5, 0x7b, 0x2c, 0x30, 0x7a, 0x08, 0x20,
+	0xfd, 0x3b, 0xb6, 0xa5, 0x66, 0x03, 0xc4, 0x82, 0xd4, 0xb4, 0x2d, 0x74, 0x0e, 0x95, 0x18, 0x83,
+	0xf8, 0xf1, 0xd5, 0x5c, 0xc0, 0xf5, 0x79, 0x3a, 0xd7, 0xf9, 0x8c, 0xcd, 0x32, 0x4f, 0x8a, 0xfa,
+	0x3f, 0x05, 0x4a, 0x61, 0x9a, 0x72, 0x06, 0x10, 0xe4, 0x13, 0xe9, 0x05, 0xdf, 0xe8, 0x2c, 0x51,
+	0xdd, 0x6c, 0x35, 0x57, 0x2b, 0x36, 0x5f, 0xa5, 0x47, 0x4c, 0xa2, 0xc5, 0xa5, 0x6e, 0x51, 0xc1,
+	0x27, 0xd3, 0x42, 0xa3, 0xc7, 0x50, 0xea, 0x31, 0x2a, 0x08, 0x15, 0x1d, 0x31, 0x71, 0x48, 0xc0,
+	0xa3, 0x60, 0x16, 0xa5, 0xee, 0xd3, 0xc4, 0x21, 0x73, 0xb5, 0xc8, 0xcf, 0xd5, 0x42, 0x3b, 0x82,
+	0xf2, 0x0c, 0x38, 0xda, 0x86, 0xdc, 0x80, 0x4c, 0x64, 0x1b, 0xfc, 0x4f, 0x74, 0x1b, 0x6e, 0x8c,
+	0xf1, 0xd0, 0x23, 0xb2, 0x90, 0xa1, 0xf0, 0x36, 0x7b, 0xa8, 0xe8, 0x7f, 0x14, 0x28, 0xb7, 0xe9,
+	0x98, 0x0d, 0x48, 0xd4, 0x9e, 0x65, 0xb4, 0xcf, 0x17, 0x68, 0xbf, 0x4e, 0xa7, 0x3d, 0x03, 0x97,
+	0xca, 0xfb, 0x01, 0x14, 0xe2, 0x7d, 0x92, 0xa4, 0xa7, 0x8a, 0xcd, 0x38, 0xfd, 0x55, 0xa0, 0x12,
+	0x25, 0xb1, 0xa2, 0x97, 0xe6, 0x02, 0xa9, 0x37, 0x97, 0x93, 0xda, 0xb8, 0x9b, 0x1b, 0x51, 0x6b,
+	0xfe, 0xce, 0x42, 0x29, 0x79, 0x2d, 0x20, 0x06, 0x79, 0x7f, 0x35, 0x51, 0x63, 0x55, 0xea, 0x4b,
+	0x6f, 0x0a, 0xad, 0x79, 0x15, 0x17, 0xb9, 0xf9, 0x19, 0xf4, 0x15, 0xf2, 0xfe, 0x5c, 0xa3, 0xdd,
+	0xcb, 0xe6, 0x3e, 0x0c, 0xf2, 0x74, 0xbd, 0xf5, 0xd0, 0x33, 0x35, 0x65, 0x5f, 0x41, 0x9f, 0x21,
+	0x7f, 0xe6, 0xb3, 0x5a, 0x01, 0xee, 0x9f, 0xaf, 0x01, 0x1e, 0x9a, 0x45, 0xe0, 0xcd, 0x5f, 0x39,
+	0x28, 0xcf, 0xdc, 0x67, 0xe8, 0x42, 0x16, 0x6e, 0x45, 0x15, 0xd2, 0xee, 0x58, 0xed, 0xe0, 0x4a,
+	0x3e, 0x71, 0xe9, 0xbe, 0xc1, 0x56, 0x38, 0x46, 0x68, 0x6f, 0xcd, 0xed, 0xd1, 0x6a, 0xeb, 0x4e,
+	0xa4, 0x9e, 0x41, 0x1e, 0x54, 0x66, 0x5f, 0x33, 0x64, 0xa4, 0x7b, 0x2f, 0x7d, 0x10, 0xb5, 0xfd,
+	0xf5, 0x1d, 0xe2, 0xb0, 0xd7, 0xd5, 0xb3, 0xe3, 0x93, 0x2f, 0x1f, 0xfa, 0xb6, 0xf8, 0xe1, 0x75,
+	0x7d, 0x33, 0x23, 0x78, 0xea, 0xa7, 0xf6, 0x2f, 0xfd, 0x95, 0xe2, 0x76, 0xd7, 0x70, 0x86, 0x5e,
+	0xbf, 0x8f, 0xbb, 0x43, 0xb2, 0xec, 0x3f, 0xe0, 0x68, 0x2a, 0x75, 0xb7, 0x82, 0xf3, 0x83, 0xff,
+	0x03, 0x00, 0x80, 0x98, 0x26, 0xcf, 0x78, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// InputBindingClient is the client API for InputBinding service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type InputBindingClient interface {
+	// Initializes the inputbinding component component with the given metadata.
+	Init(ctx context.Context, in *InputBindingInitRequest, opts ...grpc.CallOption) (*InputBindingInitResponse, error)
+	// Establishes a stream with the server, which sends messages down to the
+	// client. The client streams acknowledgements back to the server. The server
+	// will close the stream and return the status on any error. In case of closed
+	// connection, the client should re-establish the stream.
+	Read(ctx context.Context, opts ...grpc.CallOption) (InputBinding_ReadClient, error)
+	// Ping the InputBinding. Used for liveness porpuses.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+}
+
+type inputBindingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewInputBindingClient(cc *grpc.ClientConn) InputBindingClient {
+	return &inputBindingClient{cc}
+}
+
+func (c *inputBindingClient) Init(ctx context.Context, in *InputBindingInitRequest, opts ...grpc.CallOption) (*InputBindingInitResponse, error) {
+	out := new(InputBindingInitResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.InputBinding/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputBindingClient) Read(ctx context.Context, opts ...grpc.CallOption) (InputBinding_ReadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_InputBinding_serviceDesc.Streams[0], "/dapr.proto.components.v1.InputBinding/Read", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &inputBindingReadClient{stream}
+	return x, nil
+}
+
+type InputBinding_ReadClient interface {
+	Send(*ReadRequest) error
+	Recv() (*ReadResponse, error)
+	grpc.ClientStream
+}
+
+type inputBindingReadClient struct {
+	grpc.ClientStream
+}
+
+func (x *inputBindingReadClient) Send(m *ReadRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *inputBindingReadClient) Recv() (*ReadResponse, error) {
+	m := new(ReadResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *inputBindingClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.InputBinding/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InputBindingServer is the server API for InputBinding service.
+type InputBindingServer interface {
+	// Initializes the inputbinding component component with the given metadata.
+	Init(context.Context, *InputBindingInitRequest) (*InputBindingInitResponse, error)
+	// Establishes a stream with the server, which sends messages down to the
+	// client. The client streams acknowledgements back to the server. The server
+	// will close the stream and return the status on any error. In case of closed
+	// connection, the client should re-establish the stream.
+	Read(InputBinding_ReadServer) error
+	// Ping the InputBinding. Used for liveness porpuses.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+}
+
+// UnimplementedInputBindingServer can be embedded to have forward compatible implementations.
+type UnimplementedInputBindingServer struct {
+}
+
+func (*UnimplementedInputBindingServer) Init(ctx context.Context, req *InputBindingInitRequest) (*InputBindingInitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedInputBindingServer) Read(srv InputBinding_ReadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Read not implemented")
+}
+func (*UnimplementedInputBindingServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+
+func RegisterInputBindingServer(s *grpc.Server, srv InputBindingServer) {
+	s.RegisterService(&_InputBinding_serviceDesc, srv)
+}
+
+func _InputBinding_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InputBindingInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputBindingServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.InputBinding/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputBindingServer).Init(ctx, req.(*InputBindingInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputBinding_Read_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(InputBindingServer).Read(&inputBindingReadServer{stream})
+}
+
+type InputBinding_ReadServer interface {
+	Send(*ReadResponse) error
+	Recv() (*ReadRequest, error)
+	grpc.ServerStream
+}
+
+type inputBindingReadServer struct {
+	grpc.ServerStream
+}
+
+func (x *inputBindingReadServer) Send(m *ReadResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *inputBindingReadServer) Recv() (*ReadRequest, error) {
+	m := new(ReadRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _InputBinding_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputBindingServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.InputBinding/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputBindingServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _InputBinding_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.InputBinding",
+	HandlerType: (*InputBindingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _InputBinding_Init_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _InputBinding_Ping_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Read",
+			Handler:       _InputBinding_Read_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/components/v1/bindings.proto",
+}
+
+// OutputBindingClient is the client API for OutputBinding service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OutputBindingClient interface {
+	// Initializes the outputbinding component component with the given metadata.
+	Init(ctx context.Context, in *OutputBindingInitRequest, opts ...grpc.CallOption) (*OutputBindingInitResponse, error)
+	// Invoke remote systems with optional payloads.
+	Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error)
+	// ListOperations list system supported operations.
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	// Ping the OutputBinding. Used for liveness porpuses.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+}
+
+type outputBindingClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOutputBindingClient(cc *grpc.ClientConn) OutputBindingClient {
+	return &outputBindingClient{cc}
+}
+
+func (c *outputBindingClient) Init(ctx context.Context, in *OutputBindingInitRequest, opts ...grpc.CallOption) (*OutputBindingInitResponse, error) {
+	out := new(OutputBindingInitResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputBindingClient) Invoke(ctx context.Context, in *InvokeRequest, opts ...grpc.CallOption) (*InvokeResponse, error) {
+	out := new(InvokeResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Invoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputBindingClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/ListOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputBindingClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutputBindingServer is the server API for OutputBinding service.
+type OutputBindingServer interface {
+	// Initializes the outputbinding component component with the given metadata.
+	Init(context.Context, *OutputBindingInitRequest) (*OutputBindingInitResponse, error)
+	// Invoke remote systems with optional payloads.
+	Invoke(context.Context, *InvokeRequest) (*InvokeResponse, error)
+	// ListOperations list system supported operations.
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	// Ping the OutputBinding. Used for liveness porpuses.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+}
+
+// UnimplementedOutputBindingServer can be embedded to have forward compatible implementations.
+type UnimplementedOutputBindingServer struct {
+}
+
+func (*UnimplementedOutputBindingServer) Init(ctx context.Context, req *OutputBindingInitRequest) (*OutputBindingInitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedOutputBindingServer) Invoke(ctx context.Context, req *InvokeRequest) (*InvokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Invoke not implemented")
+}
+func (*UnimplementedOutputBindingServer) ListOperations(ctx context.Context, req *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (*UnimplementedOutputBindingServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+
+func RegisterOutputBindingServer(s *grpc.Server, srv OutputBindingServer) {
+	s.RegisterService(&_OutputBinding_serviceDesc, srv)
+}
+
+func _OutputBinding_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutputBindingInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Init(ctx, req.(*OutputBindingInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputBinding_Invoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Invoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Invoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Invoke(ctx, req.(*InvokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputBinding_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/ListOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputBinding_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OutputBinding_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.OutputBinding",
+	HandlerType: (*OutputBindingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _OutputBinding_Init_Handler,
+		},
+		{
+			MethodName: "Invoke",
+			Handler:    _OutputBinding_Invoke_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _OutputBinding_ListOperations_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _OutputBinding_Ping_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/bindings.proto",
+}
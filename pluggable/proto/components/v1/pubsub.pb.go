@@ -0,0 +1,770 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/pubsub.proto
+
+package components
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Used for describing errors when ack'ing messages.
+type AckMessageError struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AckMessageError) Reset()         { *m = AckMessageError{} }
+func (m *AckMessageError) String() string { return proto.CompactTextString(m) }
+func (*AckMessageError) ProtoMessage()    {}
+func (*AckMessageError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{0}
+}
+
+func (m *AckMessageError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AckMessageError.Unmarshal(m, b)
+}
+func (m *AckMessageError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AckMessageError.Marshal(b, m, deterministic)
+}
+func (m *AckMessageError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AckMessageError.Merge(m, src)
+}
+func (m *AckMessageError) XXX_Size() int {
+	return xxx_messageInfo_AckMessageError.Size(m)
+}
+func (m *AckMessageError) XXX_DiscardUnknown() {
+	xxx_messageInfo_AckMessageError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AckMessageError proto.InternalMessageInfo
+
+func (m *AckMessageError) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Used for acknowledge a message.
+type PullMessagesRequest struct {
+	// Required. The subscribed topic for which to initialize the new stream. This
+	// must be provided in the first request on the stream, and must not be set in
+	// subsequent requests from client to server.
+	Topic *Topic `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The unique message ID.
+	AckMessageId string `protobuf:"bytes,2,opt,name=ack_message_id,json=ackMessageId,proto3" json:"ack_message_id,omitempty"`
+	// Optional, should not be fulfilled when the message was successfully
+	// handled.
+	AckError             *AckMessageError `protobuf:"bytes,3,opt,name=ack_error,json=ackError,proto3" json:"ack_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PullMessagesRequest) Reset()         { *m = PullMessagesRequest{} }
+func (m *PullMessagesRequest) String() string { return proto.CompactTextString(m) }
+func (*PullMessagesRequest) ProtoMessage()    {}
+func (*PullMessagesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{1}
+}
+
+func (m *PullMessagesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PullMessagesRequest.Unmarshal(m, b)
+}
+func (m *PullMessagesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PullMessagesRequest.Marshal(b, m, deterministic)
+}
+func (m *PullMessagesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullMessagesRequest.Merge(m, src)
+}
+func (m *PullMessagesRequest) XXX_Size() int {
+	return xxx_messageInfo_PullMessagesRequest.Size(m)
+}
+func (m *PullMessagesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullMessagesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PullMessagesRequest proto.InternalMessageInfo
+
+func (m *PullMessagesRequest) GetTopic() *Topic {
+	if m != nil {
+		return m.Topic
+	}
+	return nil
+}
+
+func (m *PullMessagesRequest) GetAckMessageId() string {
+	if m != nil {
+		return m.AckMessageId
+	}
+	return ""
+}
+
+func (m *PullMessagesRequest) GetAckError() *AckMessageError {
+	if m != nil {
+		return m.AckError
+	}
+	return nil
+}
+
+// PubSubInitRequest is the request for initializing the pubsub component.
+type PubSubInitRequest struct {
+	// The metadata request.
+	Metadata             *MetadataRequest `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *PubSubInitRequest) Reset()         { *m = PubSubInitRequest{} }
+func (m *PubSubInitRequest) String() string { return proto.CompactTextString(m) }
+func (*PubSubInitRequest) ProtoMessage()    {}
+func (*PubSubInitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{2}
+}
+
+func (m *PubSubInitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PubSubInitRequest.Unmarshal(m, b)
+}
+func (m *PubSubInitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PubSubInitRequest.Marshal(b, m, deterministic)
+}
+func (m *PubSubInitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubSubInitRequest.Merge(m, src)
+}
+func (m *PubSubInitRequest) XXX_Size() int {
+	return xxx_messageInfo_PubSubInitRequest.Size(m)
+}
+func (m *PubSubInitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubSubInitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubSubInitRequest proto.InternalMessageInfo
+
+func (m *PubSubInitRequest) GetMetadata() *MetadataRequest {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type PubSubInitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PubSubInitResponse) Reset()         { *m = PubSubInitResponse{} }
+func (m *PubSubInitResponse) String() string { return proto.CompactTextString(m) }
+func (*PubSubInitResponse) ProtoMessage()    {}
+func (*PubSubInitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{3}
+}
+
+func (m *PubSubInitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PubSubInitResponse.Unmarshal(m, b)
+}
+func (m *PubSubInitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PubSubInitResponse.Marshal(b, m, deterministic)
+}
+func (m *PubSubInitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubSubInitResponse.Merge(m, src)
+}
+func (m *PubSubInitResponse) XXX_Size() int {
+	return xxx_messageInfo_PubSubInitResponse.Size(m)
+}
+func (m *PubSubInitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubSubInitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubSubInitResponse proto.InternalMessageInfo
+
+type PublishRequest struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The pubsub name.
+	PubsubName string `protobuf:"bytes,2,opt,name=pubsub_name,json=pubsubName,proto3" json:"pubsub_name,omitempty"`
+	// The publishing topic.
+	Topic string `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Message metadata.
+	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The data content type.
+	ContentType          string   `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
+func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()    {}
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{4}
+}
+
+func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishRequest.Unmarshal(m, b)
+}
+func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+}
+func (m *PublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishRequest.Merge(m, src)
+}
+func (m *PublishRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishRequest.Size(m)
+}
+func (m *PublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+
+func (m *PublishRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *PublishRequest) GetPubsubName() string {
+	if m != nil {
+		return m.PubsubName
+	}
+	return ""
+}
+
+func (m *PublishRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *PublishRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *PublishRequest) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+// reserved for future-proof extensibility
+type PublishResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
+func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishResponse) ProtoMessage()    {}
+func (*PublishResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{5}
+}
+
+func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishResponse.Unmarshal(m, b)
+}
+func (m *PublishResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishResponse.Marshal(b, m, deterministic)
+}
+func (m *PublishResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishResponse.Merge(m, src)
+}
+func (m *PublishResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishResponse.Size(m)
+}
+func (m *PublishResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishResponse proto.InternalMessageInfo
+
+type Topic struct {
+	// The topic name desired to be subscribed
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Metadata related subscribe request.
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Topic) Reset()         { *m = Topic{} }
+func (m *Topic) String() string { return proto.CompactTextString(m) }
+func (*Topic) ProtoMessage()    {}
+func (*Topic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{6}
+}
+
+func (m *Topic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Topic.Unmarshal(m, b)
+}
+func (m *Topic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Topic.Marshal(b, m, deterministic)
+}
+func (m *Topic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Topic.Merge(m, src)
+}
+func (m *Topic) XXX_Size() int {
+	return xxx_messageInfo_Topic.Size(m)
+}
+func (m *Topic) XXX_DiscardUnknown() {
+	xxx_messageInfo_Topic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Topic proto.InternalMessageInfo
+
+func (m *Topic) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Topic) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+type PullMessagesResponse struct {
+	// The message content.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The topic where the message come from.
+	TopicName string `protobuf:"bytes,2,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	// The message related metadata.
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The message content type.
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// The message {transient} ID. Its used for ack'ing it later.
+	Id                   string   `protobuf:"bytes,5,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PullMessagesResponse) Reset()         { *m = PullMessagesResponse{} }
+func (m *PullMessagesResponse) String() string { return proto.CompactTextString(m) }
+func (*PullMessagesResponse) ProtoMessage()    {}
+func (*PullMessagesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_79bd61ce30be5363, []int{7}
+}
+
+func (m *PullMessagesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PullMessagesResponse.Unmarshal(m, b)
+}
+func (m *PullMessagesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PullMessagesResponse.Marshal(b, m, deterministic)
+}
+func (m *PullMessagesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullMessagesResponse.Merge(m, src)
+}
+func (m *PullMessagesResponse) XXX_Size() int {
+	return xxx_messageInfo_PullMessagesResponse.Size(m)
+}
+func (m *PullMessagesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullMessagesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PullMessagesResponse proto.InternalMessageInfo
+
+func (m *PullMessagesResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *PullMessagesResponse) GetTopicName() string {
+	if m != nil {
+		return m.TopicName
+	}
+	return ""
+}
+
+func (m *PullMessagesResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *PullMessagesResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *PullMessagesResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*AckMessageError)(nil), "dapr.proto.components.v1.AckMessageError")
+	proto.RegisterType((*PullMessagesRequest)(nil), "dapr.proto.components.v1.PullMessagesRequest")
+	proto.RegisterType((*PubSubInitRequest)(nil), "dapr.proto.components.v1.PubSubInitRequest")
+	proto.RegisterType((*PubSubInitResponse)(nil), "dapr.proto.components.v1.PubSubInitResponse")
+	proto.RegisterType((*PublishRequest)(nil), "dapr.proto.components.v1.PublishRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.PublishRequest.MetadataEntry")
+	proto.RegisterType((*PublishResponse)(nil), "dapr.proto.components.v1.PublishResponse")
+	proto.RegisterType((*Topic)(nil), "dapr.proto.components.v1.Topic")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.Topic.MetadataEntry")
+	proto.RegisterType((*PullMessagesResponse)(nil), "dapr.proto.components.v1.PullMessagesResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.PullMessagesResponse.MetadataEntry")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/pubsub.proto", fileDescriptor_79bd61ce30be5363)
+}
+
+var fileDescriptor_79bd61ce30be5363 = []byte{
+	// 616 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xad, 0x1d, 0xb7, 0x4d, 0x6e, 0xf2, 0xa5, 0x5f, 0x87, 0x2c, 0xac, 0x48, 0xa8, 0xc5, 0xa2,
+	0x28, 0xa5, 0xc4, 0xa6, 0x41, 0x20, 0x44, 0xd9, 0x80, 0x94, 0x4a, 0x11, 0x0a, 0x8a, 0x4c, 0x25,
+	0x50, 0x37, 0x61, 0x6c, 0x8f, 0x5c, 0x2b, 0xfe, 0xc3, 0x1e, 0x47, 0xca, 0x96, 0x17, 0x60, 0xc1,
+	0x23, 0xf0, 0x16, 0x3c, 0x1d, 0xf2, 0x78, 0xec, 0x38, 0xb4, 0x49, 0x8c, 0xba, 0x9b, 0xf1, 0x9c,
+	0xb9, 0xe7, 0xdc, 0x7b, 0xce, 0xc8, 0x70, 0x62, 0xe1, 0x30, 0xd2, 0xc2, 0x28, 0xa0, 0x81, 0x66,
+	0x06, 0x5e, 0x18, 0xf8, 0xc4, 0xa7, 0xb1, 0x36, 0x3f, 0xd7, 0xc2, 0xc4, 0x88, 0x13, 0x43, 0x65,
+	0x47, 0x48, 0x4e, 0x61, 0xd9, 0x5a, 0x5d, 0xc2, 0xd4, 0xf9, 0x79, 0x77, 0x7d, 0x01, 0x33, 0xf0,
+	0xbc, 0xc0, 0xcf, 0x2e, 0x29, 0x67, 0x70, 0xf0, 0xce, 0x9c, 0x8d, 0x49, 0x1c, 0x63, 0x9b, 0x0c,
+	0xa3, 0x28, 0x88, 0x90, 0x0c, 0xfb, 0x5e, 0xb6, 0x97, 0x85, 0x63, 0xa1, 0xd7, 0xd0, 0xf3, 0xad,
+	0xf2, 0x5b, 0x80, 0x07, 0x93, 0xc4, 0x75, 0x39, 0x3c, 0xd6, 0xc9, 0xb7, 0x84, 0xc4, 0x14, 0xbd,
+	0x84, 0x5d, 0x1a, 0x84, 0x8e, 0xc9, 0xf0, 0xcd, 0xc1, 0x91, 0xba, 0x4e, 0x95, 0x7a, 0x95, 0xc2,
+	0xf4, 0x0c, 0x8d, 0x1e, 0x43, 0x1b, 0x9b, 0xb3, 0x29, 0xaf, 0x3e, 0x75, 0x2c, 0x59, 0x64, 0x7c,
+	0x2d, 0x5c, 0x28, 0x1a, 0x59, 0xe8, 0x12, 0x1a, 0x29, 0x8a, 0xa4, 0xda, 0xe4, 0x1a, 0x23, 0x38,
+	0x5d, 0x4f, 0xf0, 0x57, 0x33, 0x7a, 0x1d, 0x9b, 0x33, 0xb6, 0x52, 0xae, 0xe1, 0x70, 0x92, 0x18,
+	0x9f, 0x12, 0x63, 0xe4, 0x3b, 0x34, 0x57, 0x3e, 0x84, 0xba, 0x47, 0x28, 0xb6, 0x30, 0xc5, 0xb2,
+	0xb0, 0xad, 0xf6, 0x98, 0x23, 0xf9, 0x65, 0xbd, 0xb8, 0xaa, 0x74, 0x00, 0x95, 0x6b, 0xc7, 0x61,
+	0xe0, 0xc7, 0x44, 0xf9, 0x21, 0x42, 0x7b, 0x92, 0x18, 0xae, 0x13, 0xdf, 0xe4, 0x7c, 0x08, 0xa4,
+	0x82, 0xab, 0xa5, 0xb3, 0x35, 0x3a, 0x82, 0x66, 0xe6, 0xe9, 0xd4, 0xc7, 0x1e, 0xe1, 0x33, 0x80,
+	0xec, 0xd3, 0x47, 0xec, 0x11, 0xd4, 0xc9, 0xc7, 0x5b, 0x63, 0x47, 0x7c, 0x7a, 0x7a, 0x49, 0xba,
+	0x74, 0x5c, 0xeb, 0x35, 0x07, 0xaf, 0xd6, 0x4b, 0x5f, 0x95, 0x51, 0x74, 0x32, 0xf4, 0x69, 0xb4,
+	0x58, 0xf6, 0x81, 0x1e, 0x41, 0xcb, 0x0c, 0x7c, 0x4a, 0x7c, 0x3a, 0xa5, 0x8b, 0x90, 0xc8, 0xbb,
+	0x8c, 0xb0, 0xc9, 0xbf, 0x5d, 0x2d, 0x42, 0xd2, 0xbd, 0x80, 0xff, 0x56, 0x6e, 0xa3, 0xff, 0xa1,
+	0x36, 0x23, 0x0b, 0x1e, 0x95, 0x74, 0x99, 0xea, 0x9d, 0x63, 0x37, 0xc9, 0x5b, 0xc9, 0x36, 0x6f,
+	0xc4, 0xd7, 0x82, 0x72, 0x08, 0x07, 0x85, 0x12, 0x3e, 0xa4, 0x5f, 0x02, 0xec, 0xb2, 0x54, 0xa4,
+	0xb3, 0x61, 0x03, 0xc8, 0x2a, 0xb1, 0x35, 0x1a, 0x95, 0x9a, 0x14, 0x59, 0x93, 0xfd, 0x2d, 0xe1,
+	0x5a, 0xd7, 0xdb, 0xfd, 0x84, 0xff, 0x14, 0xa1, 0xb3, 0x9a, 0xfc, 0x4c, 0xfe, 0x9d, 0x86, 0x3e,
+	0x04, 0x60, 0x16, 0x95, 0xfd, 0x6c, 0xb0, 0x2f, 0xcc, 0xce, 0x2f, 0xa5, 0x9e, 0x6a, 0xac, 0xa7,
+	0xb7, 0x9b, 0x8c, 0xbb, 0x4d, 0x5a, 0xd9, 0x3e, 0xe9, 0x96, 0x7d, 0xa8, 0x0d, 0xa2, 0x63, 0x71,
+	0x5f, 0x45, 0xc7, 0xba, 0xd7, 0x54, 0x06, 0xdf, 0x25, 0xd8, 0xcb, 0x72, 0x8f, 0x4c, 0x90, 0xd2,
+	0xec, 0xa3, 0xb3, 0x8d, 0x19, 0x5c, 0x7d, 0x7d, 0xdd, 0x67, 0xd5, 0xc0, 0x3c, 0x29, 0x3b, 0xc8,
+	0x84, 0xfa, 0x25, 0xc1, 0x34, 0x89, 0x48, 0x8c, 0x36, 0xbc, 0xd3, 0x1c, 0x93, 0xd3, 0x3c, 0xad,
+	0x02, 0x2d, 0x48, 0xbe, 0xc2, 0x3e, 0xcf, 0x28, 0xea, 0x55, 0x7d, 0x50, 0xdd, 0xd3, 0x0a, 0xc8,
+	0x82, 0x21, 0x86, 0x56, 0xd9, 0x56, 0xd4, 0xaf, 0x6a, 0x7f, 0xc6, 0xa5, 0xfe, 0x5b, 0x5a, 0x94,
+	0x9d, 0x9e, 0xf0, 0x5c, 0x40, 0x9f, 0x41, 0x9a, 0x38, 0xbe, 0x8d, 0x4e, 0x36, 0xdc, 0x76, 0x7c,
+	0x3b, 0x27, 0x79, 0xb2, 0x0d, 0x96, 0x17, 0x7f, 0x3f, 0xbe, 0xfe, 0x60, 0x3b, 0xf4, 0x26, 0x31,
+	0x52, 0x98, 0xc6, 0xfe, 0x3a, 0x4b, 0x7c, 0x3f, 0xcd, 0x5e, 0xe4, 0x18, 0x5a, 0xe8, 0x26, 0xb6,
+	0x8d, 0x0d, 0x97, 0xdc, 0xf5, 0x4b, 0xba, 0x58, 0xee, 0x8c, 0x3d, 0x76, 0xfe, 0xe2, 0xcf, 0x00,
+	0x32, 0x33, 0x59, 0x02, 0x01, 0x07, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PubSubClient is the client API for PubSub service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PubSubClient interface {
+	// Initializes the pubsub component with the given metadata.
+	Init(ctx context.Context, in *PubSubInitRequest, opts ...grpc.CallOption) (*PubSubInitResponse, error)
+	// Returns a list of implemented pubsub features.
+	Features(ctx context.Context, in *FeaturesRequest, opts ...grpc.CallOption) (*FeaturesResponse, error)
+	// Publish publishes a new message for the given topic.
+	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error)
+	// Establishes a stream with the server (PubSub component), which sends
+	// messages down to the client (daprd). The client streams acknowledgements
+	// back to the server. The server will close the stream and return the status
+	// on any error. In case of closed connection, the client should re-establish
+	// the stream. The first message MUST contain a `topic` attribute on it that
+	// should be used for the entire streaming pull.
+	PullMessages(ctx context.Context, opts ...grpc.CallOption) (PubSub_PullMessagesClient, error)
+	// Ping the pubsub. Used for liveness porpuses.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+}
+
+type pubSubClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPubSubClient(cc *grpc.ClientConn) PubSubClient {
+	return &pubSubClient{cc}
+}
+
+func (c *pubSubClient) Init(ctx context.Context, in *PubSubInitRequest, opts ...grpc.CallOption) (*PubSubInitResponse, error) {
+	out := new(PubSubInitResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) Features(ctx context.Context, in *FeaturesRequest, opts ...grpc.CallOption) (*FeaturesResponse, error) {
+	out := new(FeaturesResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Features", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*PublishResponse, error) {
+	out := new(PublishResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Publish", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) PullMessages(ctx context.Context, opts ...grpc.CallOption) (PubSub_PullMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_PubSub_serviceDesc.Streams[0], "/dapr.proto.components.v1.PubSub/PullMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pubSubPullMessagesClient{stream}
+	return x, nil
+}
+
+type PubSub_PullMessagesClient interface {
+	Send(*PullMessagesRequest) error
+	Recv() (*PullMessagesResponse, error)
+	grpc.ClientStream
+}
+
+type pubSubPullMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *pubSubPullMessagesClient) Send(m *PullMessagesRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pubSubPullMessagesClient) Recv() (*PullMessagesResponse, error) {
+	m := new(PullMessagesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pubSubClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PubSubServer is the server API for PubSub service.
+type PubSubServer interface {
+	// Initializes the pubsub component with the given metadata.
+	Init(context.Context, *PubSubInitRequest) (*PubSubInitResponse, error)
+	// Returns a list of implemented pubsub features.
+	Features(context.Context, *FeaturesRequest) (*FeaturesResponse, error)
+	// Publish publishes a new message for the given topic.
+	Publish(context.Context, *PublishRequest) (*PublishResponse, error)
+	// Establishes a stream with the server (PubSub component), which sends
+	// messages down to the client (daprd). The client streams acknowledgements
+	// back to the server. The server will close the stream and return the status
+	// on any error. In case of closed connection, the client should re-establish
+	// the stream. The first message MUST contain a `topic` attribute on it that
+	// should be used for the entire streaming pull.
+	PullMessages(PubSub_PullMessagesServer) error
+	// Ping the pubsub. Used for liveness porpuses.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+}
+
+// UnimplementedPubSubServer can be embedded to have forward compatible implementations.
+type UnimplementedPubSubServer struct {
+}
+
+func (*UnimplementedPubSubServer) Init(ctx context.Context, req *PubSubInitRequest) (*PubSubInitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedPubSubServer) Features(ctx context.Context, req *FeaturesRequest) (*FeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Features not implemented")
+}
+func (*UnimplementedPubSubServer) Publish(ctx context.Context, req *PublishRequest) (*PublishResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (*UnimplementedPubSubServer) PullMessages(srv PubSub_PullMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method PullMessages not implemented")
+}
+func (*UnimplementedPubSubServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+
+func RegisterPubSubServer(s *grpc.Server, srv PubSubServer) {
+	s.RegisterService(&_PubSub_serviceDesc, srv)
+}
+
+func _PubSub_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PubSubInitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Init(ctx, req.(*PubSubInitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_Features_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Features(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Features",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Features(ctx, req.(*FeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Publish",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Publish(ctx, req.(*PublishRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_PullMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PubSubServer).PullMessages(&pubSubPullMessagesServer{stream})
+}
+
+type PubSub_PullMessagesServer interface {
+	Send(*PullMessagesResponse) error
+	Recv() (*PullMessagesRequest, error)
+	grpc.ServerStream
+}
+
+type pubSubPullMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *pubSubPullMessagesServer) Send(m *PullMessagesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pubSubPullMessagesServer) Recv() (*PullMessagesRequest, error) {
+	m := new(PullMessagesRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _PubSub_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PubSub_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.PubSub",
+	HandlerType: (*PubSubServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _PubSub_Init_Handler,
+		},
+		{
+			MethodName: "Features",
+			Handler:    _PubSub_Features_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _PubSub_Publish_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _PubSub_Ping_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PullMessages",
+			Handler:       _PubSub_PullMessages_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/components/v1/pubsub.proto",
+}
@@ -0,0 +1,2101 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: dapr/proto/components/v1/state.proto
+
+package components
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	any "github.com/golang/protobuf/ptypes/any"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type Sorting_Order int32
+
+const (
+	Sorting_ASC  Sorting_Order = 0
+	Sorting_DESC Sorting_Order = 1
+)
+
+var Sorting_Order_name = map[int32]string{
+	0: "ASC",
+	1: "DESC",
+}
+
+var Sorting_Order_value = map[string]int32{
+	"ASC":  0,
+	"DESC": 1,
+}
+
+func (x Sorting_Order) String() string {
+	return proto.EnumName(Sorting_Order_name, int32(x))
+}
+
+func (Sorting_Order) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{0, 0}
+}
+
+// Enum describing the supported concurrency for state.
+type StateOptions_StateConcurrency int32
+
+const (
+	StateOptions_CONCURRENCY_UNSPECIFIED StateOptions_StateConcurrency = 0
+	StateOptions_CONCURRENCY_FIRST_WRITE StateOptions_StateConcurrency = 1
+	StateOptions_CONCURRENCY_LAST_WRITE  StateOptions_StateConcurrency = 2
+)
+
+var StateOptions_StateConcurrency_name = map[int32]string{
+	0: "CONCURRENCY_UNSPECIFIED",
+	1: "CONCURRENCY_FIRST_WRITE",
+	2: "CONCURRENCY_LAST_WRITE",
+}
+
+var StateOptions_StateConcurrency_value = map[string]int32{
+	"CONCURRENCY_UNSPECIFIED": 0,
+	"CONCURRENCY_FIRST_WRITE": 1,
+	"CONCURRENCY_LAST_WRITE":  2,
+}
+
+func (x StateOptions_StateConcurrency) String() string {
+	return proto.EnumName(StateOptions_StateConcurrency_name, int32(x))
+}
+
+func (StateOptions_StateConcurrency) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{10, 0}
+}
+
+// Enum describing the supported consistency for state.
+type StateOptions_StateConsistency int32
+
+const (
+	StateOptions_CONSISTENCY_UNSPECIFIED StateOptions_StateConsistency = 0
+	StateOptions_CONSISTENCY_EVENTUAL    StateOptions_StateConsistency = 1
+	StateOptions_CONSISTENCY_STRONG      StateOptions_StateConsistency = 2
+)
+
+var StateOptions_StateConsistency_name = map[int32]string{
+	0: "CONSISTENCY_UNSPECIFIED",
+	1: "CONSISTENCY_EVENTUAL",
+	2: "CONSISTENCY_STRONG",
+}
+
+var StateOptions_StateConsistency_value = map[string]int32{
+	"CONSISTENCY_UNSPECIFIED": 0,
+	"CONSISTENCY_EVENTUAL":    1,
+	"CONSISTENCY_STRONG":      2,
+}
+
+func (x StateOptions_StateConsistency) String() string {
+	return proto.EnumName(StateOptions_StateConsistency_name, int32(x))
+}
+
+func (StateOptions_StateConsistency) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{10, 1}
+}
+
+type Sorting struct {
+	// The key that should be used for sorting.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The order that should be used.
+	Order                Sorting_Order `protobuf:"varint,2,opt,name=order,proto3,enum=dapr.proto.components.v1.Sorting_Order" json:"order,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Sorting) Reset()         { *m = Sorting{} }
+func (m *Sorting) String() string { return proto.CompactTextString(m) }
+func (*Sorting) ProtoMessage()    {}
+func (*Sorting) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{0}
+}
+
+func (m *Sorting) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Sorting.Unmarshal(m, b)
+}
+func (m *Sorting) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Sorting.Marshal(b, m, deterministic)
+}
+func (m *Sorting) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Sorting.Merge(m, src)
+}
+func (m *Sorting) XXX_Size() int {
+	return xxx_messageInfo_Sorting.Size(m)
+}
+func (m *Sorting) XXX_DiscardUnknown() {
+	xxx_messageInfo_Sorting.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Sorting proto.InternalMessageInfo
+
+func (m *Sorting) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Sorting) GetOrder() Sorting_Order {
+	if m != nil {
+		return m.Order
+	}
+	return Sorting_ASC
+}
+
+type Pagination struct {
+	// Maximum of results that should be returned.
+	Limit int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// The pagination token.
+	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Pagination) Reset()         { *m = Pagination{} }
+func (m *Pagination) String() string { return proto.CompactTextString(m) }
+func (*Pagination) ProtoMessage()    {}
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{1}
+}
+
+func (m *Pagination) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pagination.Unmarshal(m, b)
+}
+func (m *Pagination) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pagination.Marshal(b, m, deterministic)
+}
+func (m *Pagination) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pagination.Merge(m, src)
+}
+func (m *Pagination) XXX_Size() int {
+	return xxx_messageInfo_Pagination.Size(m)
+}
+func (m *Pagination) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pagination.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pagination proto.InternalMessageInfo
+
+func (m *Pagination) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *Pagination) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type Query struct {
+	// Filters that should be applied.
+	Filter map[string]*any.Any `protobuf:"bytes,1,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The sort order.
+	Sort []*Sorting `protobuf:"bytes,2,rep,name=sort,proto3" json:"sort,omitempty"`
+	// The query pagination params.
+	Pagination           *Pagination `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Query) Reset()         { *m = Query{} }
+func (m *Query) String() string { return proto.CompactTextString(m) }
+func (*Query) ProtoMessage()    {}
+func (*Query) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{2}
+}
+
+func (m *Query) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Query.Unmarshal(m, b)
+}
+func (m *Query) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Query.Marshal(b, m, deterministic)
+}
+func (m *Query) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Query.Merge(m, src)
+}
+func (m *Query) XXX_Size() int {
+	return xxx_messageInfo_Query.Size(m)
+}
+func (m *Query) XXX_DiscardUnknown() {
+	xxx_messageInfo_Query.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Query proto.InternalMessageInfo
+
+func (m *Query) GetFilter() map[string]*any.Any {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *Query) GetSort() []*Sorting {
+	if m != nil {
+		return m.Sort
+	}
+	return nil
+}
+
+func (m *Query) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// QueryRequest is for querying state store.
+type QueryRequest struct {
+	// The query to be performed.
+	Query *Query `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Request associated metadata.
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
+func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryRequest) ProtoMessage()    {}
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{3}
+}
+
+func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryRequest.Unmarshal(m, b)
+}
+func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
+}
+func (m *QueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRequest.Merge(m, src)
+}
+func (m *QueryRequest) XXX_Size() int {
+	return xxx_messageInfo_QueryRequest.Size(m)
+}
+func (m *QueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
+
+func (m *QueryRequest) GetQuery() *Query {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (m *QueryRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// QueryItem is an object representing a single entry in query results.
+type QueryItem struct {
+	// The returned item Key.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The returned item Data.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The returned item ETag
+	Etag *Etag `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// The returned error string.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// The returned contenttype
+	ContentType          string   `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryItem) Reset()         { *m = QueryItem{} }
+func (m *QueryItem) String() string { return proto.CompactTextString(m) }
+func (*QueryItem) ProtoMessage()    {}
+func (*QueryItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{4}
+}
+
+func (m *QueryItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryItem.Unmarshal(m, b)
+}
+func (m *QueryItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryItem.Marshal(b, m, deterministic)
+}
+func (m *QueryItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryItem.Merge(m, src)
+}
+func (m *QueryItem) XXX_Size() int {
+	return xxx_messageInfo_QueryItem.Size(m)
+}
+func (m *QueryItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryItem proto.InternalMessageInfo
+
+func (m *QueryItem) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *QueryItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *QueryItem) GetEtag() *Etag {
+	if m != nil {
+		return m.Etag
+	}
+	return nil
+}
+
+func (m *QueryItem) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *QueryItem) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+// QueryResponse is the query response.
+type QueryResponse struct {
+	// The query response items.
+	Items []*QueryItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// The response token.
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// Response associated metadata.
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
+func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryResponse) ProtoMessage()    {}
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{5}
+}
+
+func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
+}
+func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryResponse.Marshal(b, m, deterministic)
+}
+func (m *QueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryResponse.Merge(m, src)
+}
+func (m *QueryResponse) XXX_Size() int {
+	return xxx_messageInfo_QueryResponse.Size(m)
+}
+func (m *QueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryResponse proto.InternalMessageInfo
+
+func (m *QueryResponse) GetItems() []*QueryItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *QueryResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *QueryResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// TransactionalStateOperation describes operation type, key, and value for
+// transactional operation.
+type TransactionalStateOperation struct {
+	// request is either delete or set.
+	//
+	// Types that are valid to be assigned to Request:
+	//	*TransactionalStateOperation_Delete
+	//	*TransactionalStateOperation_Set
+	Request              isTransactionalStateOperation_Request `protobuf_oneof:"request"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *TransactionalStateOperation) Reset()         { *m = TransactionalStateOperation{} }
+func (m *TransactionalStateOperation) String() string { return proto.CompactTextString(m) }
+func (*TransactionalStateOperation) ProtoMessage()    {}
+func (*TransactionalStateOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{6}
+}
+
+func (m *TransactionalStateOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionalStateOperation.Unmarshal(m, b)
+}
+func (m *TransactionalStateOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionalStateOperation.Marshal(b, m, deterministic)
+}
+func (m *TransactionalStateOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionalStateOperation.Merge(m, src)
+}
+func (m *TransactionalStateOperation) XXX_Size() int {
+	return xxx_messageInfo_TransactionalStateOperation.Size(m)
+}
+func (m *TransactionalStateOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionalStateOperation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionalStateOperation proto.InternalMessageInfo
+
+type isTransactionalStateOperation_Request interface {
+	isTransactionalStateOperation_Request()
+}
+
+type TransactionalStateOperation_Delete struct {
+	Delete *DeleteRequest `protobuf:"bytes,1,opt,name=delete,proto3,oneof"`
+}
+
+type TransactionalStateOperation_Set struct {
+	Set *SetRequest `protobuf:"bytes,2,opt,name=set,proto3,oneof"`
+}
+
+func (*TransactionalStateOperation_Delete) isTransactionalStateOperation_Request() {}
+
+func (*TransactionalStateOperation_Set) isTransactionalStateOperation_Request() {}
+
+func (m *TransactionalStateOperation) GetRequest() isTransactionalStateOperation_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *TransactionalStateOperation) GetDelete() *DeleteRequest {
+	if x, ok := m.GetRequest().(*TransactionalStateOperation_Delete); ok {
+		return x.Delete
+	}
+	return nil
+}
+
+func (m *TransactionalStateOperation) GetSet() *SetRequest {
+	if x, ok := m.GetRequest().(*TransactionalStateOperation_Set); ok {
+		return x.Set
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*TransactionalStateOperation) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*TransactionalStateOperation_Delete)(nil),
+		(*TransactionalStateOperation_Set)(nil),
+	}
+}
+
+// TransactionalStateRequest describes a transactional operation against a state
+// store that comprises multiple types of operations The Request field is either
+// a DeleteRequest or SetRequest.
+type TransactionalStateRequest struct {
+	// Operations that should be performed.
+	Operations []*TransactionalStateOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// Request associated metadata.
+	Metadata             map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TransactionalStateRequest) Reset()         { *m = TransactionalStateRequest{} }
+func (m *TransactionalStateRequest) String() string { return proto.CompactTextString(m) }
+func (*TransactionalStateRequest) ProtoMessage()    {}
+func (*TransactionalStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{7}
+}
+
+func (m *TransactionalStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionalStateRequest.Unmarshal(m, b)
+}
+func (m *TransactionalStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionalStateRequest.Marshal(b, m, deterministic)
+}
+func (m *TransactionalStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionalStateRequest.Merge(m, src)
+}
+func (m *TransactionalStateRequest) XXX_Size() int {
+	return xxx_messageInfo_TransactionalStateRequest.Size(m)
+}
+func (m *TransactionalStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionalStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionalStateRequest proto.InternalMessageInfo
+
+func (m *TransactionalStateRequest) GetOperations() []*TransactionalStateOperation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+func (m *TransactionalStateRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type TransactionalStateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionalStateResponse) Reset()         { *m = TransactionalStateResponse{} }
+func (m *TransactionalStateResponse) String() string { return proto.CompactTextString(m) }
+func (*TransactionalStateResponse) ProtoMessage()    {}
+func (*TransactionalStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{8}
+}
+
+func (m *TransactionalStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionalStateResponse.Unmarshal(m, b)
+}
+func (m *TransactionalStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionalStateResponse.Marshal(b, m, deterministic)
+}
+func (m *TransactionalStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionalStateResponse.Merge(m, src)
+}
+func (m *TransactionalStateResponse) XXX_Size() int {
+	return xxx_messageInfo_TransactionalStateResponse.Size(m)
+}
+func (m *TransactionalStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionalStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionalStateResponse proto.InternalMessageInfo
+
+// Etag represents a state item version
+type Etag struct {
+	// value sets the etag value
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Etag) Reset()         { *m = Etag{} }
+func (m *Etag) String() string { return proto.CompactTextString(m) }
+func (*Etag) ProtoMessage()    {}
+func (*Etag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{9}
+}
+
+func (m *Etag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Etag.Unmarshal(m, b)
+}
+func (m *Etag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Etag.Marshal(b, m, deterministic)
+}
+func (m *Etag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Etag.Merge(m, src)
+}
+func (m *Etag) XXX_Size() int {
+	return xxx_messageInfo_Etag.Size(m)
+}
+func (m *Etag) XXX_DiscardUnknown() {
+	xxx_messageInfo_Etag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Etag proto.InternalMessageInfo
+
+func (m *Etag) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// StateOptions configures concurrency and consistency for state operations
+type StateOptions struct {
+	Concurrency          StateOptions_StateConcurrency `protobuf:"varint,1,opt,name=concurrency,proto3,enum=dapr.proto.components.v1.StateOptions_StateConcurrency" json:"concurrency,omitempty"`
+	Consistency          StateOptions_StateConsistency `protobuf:"varint,2,opt,name=consistency,proto3,enum=dapr.proto.components.v1.StateOptions_StateConsistency" json:"consistency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *StateOptions) Reset()         { *m = StateOptions{} }
+func (m *StateOptions) String() string { return proto.CompactTextString(m) }
+func (*StateOptions) ProtoMessage()    {}
+func (*StateOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{10}
+}
+
+func (m *StateOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateOptions.Unmarshal(m, b)
+}
+func (m *StateOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateOptions.Marshal(b, m, deterministic)
+}
+func (m *StateOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateOptions.Merge(m, src)
+}
+func (m *StateOptions) XXX_Size() int {
+	return xxx_messageInfo_StateOptions.Size(m)
+}
+func (m *StateOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateOptions proto.InternalMessageInfo
+
+func (m *StateOptions) GetConcurrency() StateOptions_StateConcurrency {
+	if m != nil {
+		return m.Concurrency
+	}
+	return StateOptions_CONCURRENCY_UNSPECIFIED
+}
+
+func (m *StateOptions) GetConsistency() StateOptions_StateConsistency {
+	if m != nil {
+		return m.Consistency
+	}
+	return StateOptions_CONSISTENCY_UNSPECIFIED
+}
+
+// InitRequest is the request for initializing the component.
+type InitRequest struct {
+	Metadata             *MetadataRequest `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *InitRequest) Reset()         { *m = InitRequest{} }
+func (m *InitRequest) String() string { return proto.CompactTextString(m) }
+func (*InitRequest) ProtoMessage()    {}
+func (*InitRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{11}
+}
+
+func (m *InitRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InitRequest.Unmarshal(m, b)
+}
+func (m *InitRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InitRequest.Marshal(b, m, deterministic)
+}
+func (m *InitRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitRequest.Merge(m, src)
+}
+func (m *InitRequest) XXX_Size() int {
+	return xxx_messageInfo_InitRequest.Size(m)
+}
+func (m *InitRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InitRequest proto.InternalMessageInfo
+
+func (m *InitRequest) GetMetadata() *MetadataRequest {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type InitResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InitResponse) Reset()         { *m = InitResponse{} }
+func (m *InitResponse) String() string { return proto.CompactTextString(m) }
+func (*InitResponse) ProtoMessage()    {}
+func (*InitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{12}
+}
+
+func (m *InitResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InitResponse.Unmarshal(m, b)
+}
+func (m *InitResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InitResponse.Marshal(b, m, deterministic)
+}
+func (m *InitResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InitResponse.Merge(m, src)
+}
+func (m *InitResponse) XXX_Size() int {
+	return xxx_messageInfo_InitResponse.Size(m)
+}
+func (m *InitResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InitResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InitResponse proto.InternalMessageInfo
+
+type GetRequest struct {
+	// The key that should be retrieved.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Request associated metadata.
+	Metadata map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The get consistency level.
+	Consistency          StateOptions_StateConsistency `protobuf:"varint,3,opt,name=consistency,proto3,enum=dapr.proto.components.v1.StateOptions_StateConsistency" json:"consistency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()    {}
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{13}
+}
+
+func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRequest.Unmarshal(m, b)
+}
+func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRequest.Merge(m, src)
+}
+func (m *GetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRequest.Size(m)
+}
+func (m *GetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+
+func (m *GetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *GetRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *GetRequest) GetConsistency() StateOptions_StateConsistency {
+	if m != nil {
+		return m.Consistency
+	}
+	return StateOptions_CONSISTENCY_UNSPECIFIED
+}
+
+type GetResponse struct {
+	// The data of the GetRequest response.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The etag of the associated key.
+	Etag *Etag `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Metadata related to the response.
+	Metadata map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The response data contenttype
+	ContentType          string   `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetResponse) Reset()         { *m = GetResponse{} }
+func (m *GetResponse) String() string { return proto.CompactTextString(m) }
+func (*GetResponse) ProtoMessage()    {}
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{14}
+}
+
+func (m *GetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetResponse.Unmarshal(m, b)
+}
+func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
+}
+func (m *GetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetResponse.Merge(m, src)
+}
+func (m *GetResponse) XXX_Size() int {
+	return xxx_messageInfo_GetResponse.Size(m)
+}
+func (m *GetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+
+func (m *GetResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *GetResponse) GetEtag() *Etag {
+	if m != nil {
+		return m.Etag
+	}
+	return nil
+}
+
+func (m *GetResponse) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *GetResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	// The key that should be deleted.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The etag is used as a If-Match header, to allow certain levels of
+	// consistency.
+	Etag *Etag `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// The request metadata.
+	Metadata             map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Options              *StateOptions     `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{15}
+}
+
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
+}
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
+}
+func (m *DeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteRequest.Size(m)
+}
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
+
+func (m *DeleteRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetEtag() *Etag {
+	if m != nil {
+		return m.Etag
+	}
+	return nil
+}
+
+func (m *DeleteRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *DeleteRequest) GetOptions() *StateOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type DeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{16}
+}
+
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
+}
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(m, src)
+}
+func (m *DeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteResponse.Size(m)
+}
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
+
+type SetRequest struct {
+	// The key that should be set.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Value is the desired content of the given key.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// The etag is used as a If-Match header, to allow certain levels of
+	// consistency.
+	Etag *Etag `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// The request metadata.
+	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The Set request options.
+	Options *StateOptions `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	// The data contenttype
+	ContentType          string   `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetRequest) Reset()         { *m = SetRequest{} }
+func (m *SetRequest) String() string { return proto.CompactTextString(m) }
+func (*SetRequest) ProtoMessage()    {}
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{17}
+}
+
+func (m *SetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRequest.Unmarshal(m, b)
+}
+func (m *SetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRequest.Marshal(b, m, deterministic)
+}
+func (m *SetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRequest.Merge(m, src)
+}
+func (m *SetRequest) XXX_Size() int {
+	return xxx_messageInfo_SetRequest.Size(m)
+}
+func (m *SetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRequest proto.InternalMessageInfo
+
+func (m *SetRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *SetRequest) GetEtag() *Etag {
+	if m != nil {
+		return m.Etag
+	}
+	return nil
+}
+
+func (m *SetRequest) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *SetRequest) GetOptions() *StateOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *SetRequest) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+// reserved for future-proof extensibility
+type SetResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetResponse) Reset()         { *m = SetResponse{} }
+func (m *SetResponse) String() string { return proto.CompactTextString(m) }
+func (*SetResponse) ProtoMessage()    {}
+func (*SetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{18}
+}
+
+func (m *SetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetResponse.Unmarshal(m, b)
+}
+func (m *SetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetResponse.Marshal(b, m, deterministic)
+}
+func (m *SetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetResponse.Merge(m, src)
+}
+func (m *SetResponse) XXX_Size() int {
+	return xxx_messageInfo_SetResponse.Size(m)
+}
+func (m *SetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetResponse proto.InternalMessageInfo
+
+type BulkDeleteRequest struct {
+	Items                []*DeleteRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BulkDeleteRequest) Reset()         { *m = BulkDeleteRequest{} }
+func (m *BulkDeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkDeleteRequest) ProtoMessage()    {}
+func (*BulkDeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{19}
+}
+
+func (m *BulkDeleteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkDeleteRequest.Unmarshal(m, b)
+}
+func (m *BulkDeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkDeleteRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkDeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkDeleteRequest.Merge(m, src)
+}
+func (m *BulkDeleteRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkDeleteRequest.Size(m)
+}
+func (m *BulkDeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkDeleteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkDeleteRequest proto.InternalMessageInfo
+
+func (m *BulkDeleteRequest) GetItems() []*DeleteRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type BulkDeleteResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkDeleteResponse) Reset()         { *m = BulkDeleteResponse{} }
+func (m *BulkDeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkDeleteResponse) ProtoMessage()    {}
+func (*BulkDeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{20}
+}
+
+func (m *BulkDeleteResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkDeleteResponse.Unmarshal(m, b)
+}
+func (m *BulkDeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkDeleteResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkDeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkDeleteResponse.Merge(m, src)
+}
+func (m *BulkDeleteResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkDeleteResponse.Size(m)
+}
+func (m *BulkDeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkDeleteResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkDeleteResponse proto.InternalMessageInfo
+
+type BulkGetRequest struct {
+	Items                []*GetRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BulkGetRequest) Reset()         { *m = BulkGetRequest{} }
+func (m *BulkGetRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkGetRequest) ProtoMessage()    {}
+func (*BulkGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{21}
+}
+
+func (m *BulkGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkGetRequest.Unmarshal(m, b)
+}
+func (m *BulkGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkGetRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkGetRequest.Merge(m, src)
+}
+func (m *BulkGetRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkGetRequest.Size(m)
+}
+func (m *BulkGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkGetRequest proto.InternalMessageInfo
+
+func (m *BulkGetRequest) GetItems() []*GetRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type BulkStateItem struct {
+	// The key of the fetched item.
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The associated data of the fetched item.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The item ETag
+	Etag *Etag `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	// A fetch error if there's some.
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// The State Item metadata.
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The data contenttype
+	ContentType          string   `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkStateItem) Reset()         { *m = BulkStateItem{} }
+func (m *BulkStateItem) String() string { return proto.CompactTextString(m) }
+func (*BulkStateItem) ProtoMessage()    {}
+func (*BulkStateItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{22}
+}
+
+func (m *BulkStateItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkStateItem.Unmarshal(m, b)
+}
+func (m *BulkStateItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkStateItem.Marshal(b, m, deterministic)
+}
+func (m *BulkStateItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkStateItem.Merge(m, src)
+}
+func (m *BulkStateItem) XXX_Size() int {
+	return xxx_messageInfo_BulkStateItem.Size(m)
+}
+func (m *BulkStateItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkStateItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkStateItem proto.InternalMessageInfo
+
+func (m *BulkStateItem) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *BulkStateItem) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *BulkStateItem) GetEtag() *Etag {
+	if m != nil {
+		return m.Etag
+	}
+	return nil
+}
+
+func (m *BulkStateItem) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *BulkStateItem) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *BulkStateItem) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+type BulkGetResponse struct {
+	Items                []*BulkStateItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Got                  bool             `protobuf:"varint,2,opt,name=got,proto3" json:"got,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BulkGetResponse) Reset()         { *m = BulkGetResponse{} }
+func (m *BulkGetResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkGetResponse) ProtoMessage()    {}
+func (*BulkGetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{23}
+}
+
+func (m *BulkGetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkGetResponse.Unmarshal(m, b)
+}
+func (m *BulkGetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkGetResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkGetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkGetResponse.Merge(m, src)
+}
+func (m *BulkGetResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkGetResponse.Size(m)
+}
+func (m *BulkGetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkGetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkGetResponse proto.InternalMessageInfo
+
+func (m *BulkGetResponse) GetItems() []*BulkStateItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *BulkGetResponse) GetGot() bool {
+	if m != nil {
+		return m.Got
+	}
+	return false
+}
+
+type BulkSetRequest struct {
+	Items                []*SetRequest `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BulkSetRequest) Reset()         { *m = BulkSetRequest{} }
+func (m *BulkSetRequest) String() string { return proto.CompactTextString(m) }
+func (*BulkSetRequest) ProtoMessage()    {}
+func (*BulkSetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{24}
+}
+
+func (m *BulkSetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkSetRequest.Unmarshal(m, b)
+}
+func (m *BulkSetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkSetRequest.Marshal(b, m, deterministic)
+}
+func (m *BulkSetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkSetRequest.Merge(m, src)
+}
+func (m *BulkSetRequest) XXX_Size() int {
+	return xxx_messageInfo_BulkSetRequest.Size(m)
+}
+func (m *BulkSetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkSetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkSetRequest proto.InternalMessageInfo
+
+func (m *BulkSetRequest) GetItems() []*SetRequest {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+// reserved for future-proof extensibility
+type BulkSetResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkSetResponse) Reset()         { *m = BulkSetResponse{} }
+func (m *BulkSetResponse) String() string { return proto.CompactTextString(m) }
+func (*BulkSetResponse) ProtoMessage()    {}
+func (*BulkSetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2179950bcc2e9039, []int{25}
+}
+
+func (m *BulkSetResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkSetResponse.Unmarshal(m, b)
+}
+func (m *BulkSetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkSetResponse.Marshal(b, m, deterministic)
+}
+func (m *BulkSetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkSetResponse.Merge(m, src)
+}
+func (m *BulkSetResponse) XXX_Size() int {
+	return xxx_messageInfo_BulkSetResponse.Size(m)
+}
+func (m *BulkSetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkSetResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkSetResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("dapr.proto.components.v1.Sorting_Order", Sorting_Order_name, Sorting_Order_value)
+	proto.RegisterEnum("dapr.proto.components.v1.StateOptions_StateConcurrency", StateOptions_StateConcurrency_name, StateOptions_StateConcurrency_value)
+	proto.RegisterEnum("dapr.proto.components.v1.StateOptions_StateConsistency", StateOptions_StateConsistency_name, StateOptions_StateConsistency_value)
+	proto.RegisterType((*Sorting)(nil), "dapr.proto.components.v1.Sorting")
+	proto.RegisterType((*Pagination)(nil), "dapr.proto.components.v1.Pagination")
+	proto.RegisterType((*Query)(nil), "dapr.proto.components.v1.Query")
+	proto.RegisterMapType((map[string]*any.Any)(nil), "dapr.proto.components.v1.Query.FilterEntry")
+	proto.RegisterType((*QueryRequest)(nil), "dapr.proto.components.v1.QueryRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.QueryRequest.MetadataEntry")
+	proto.RegisterType((*QueryItem)(nil), "dapr.proto.components.v1.QueryItem")
+	proto.RegisterType((*QueryResponse)(nil), "dapr.proto.components.v1.QueryResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.QueryResponse.MetadataEntry")
+	proto.RegisterType((*TransactionalStateOperation)(nil), "dapr.proto.components.v1.TransactionalStateOperation")
+	proto.RegisterType((*TransactionalStateRequest)(nil), "dapr.proto.components.v1.TransactionalStateRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.TransactionalStateRequest.MetadataEntry")
+	proto.RegisterType((*TransactionalStateResponse)(nil), "dapr.proto.components.v1.TransactionalStateResponse")
+	proto.RegisterType((*Etag)(nil), "dapr.proto.components.v1.Etag")
+	proto.RegisterType((*StateOptions)(nil), "dapr.proto.components.v1.StateOptions")
+	proto.RegisterType((*InitRequest)(nil), "dapr.proto.components.v1.InitRequest")
+	proto.RegisterType((*InitResponse)(nil), "dapr.proto.components.v1.InitResponse")
+	proto.RegisterType((*GetRequest)(nil), "dapr.proto.components.v1.GetRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.GetRequest.MetadataEntry")
+	proto.RegisterType((*GetResponse)(nil), "dapr.proto.components.v1.GetResponse")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.GetResponse.MetadataEntry")
+	proto.RegisterType((*DeleteRequest)(nil), "dapr.proto.components.v1.DeleteRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.DeleteRequest.MetadataEntry")
+	proto.RegisterType((*DeleteResponse)(nil), "dapr.proto.components.v1.DeleteResponse")
+	proto.RegisterType((*SetRequest)(nil), "dapr.proto.components.v1.SetRequest")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.SetRequest.MetadataEntry")
+	proto.RegisterType((*SetResponse)(nil), "dapr.proto.components.v1.SetResponse")
+	proto.RegisterType((*BulkDeleteRequest)(nil), "dapr.proto.components.v1.BulkDeleteRequest")
+	proto.RegisterType((*BulkDeleteResponse)(nil), "dapr.proto.components.v1.BulkDeleteResponse")
+	proto.RegisterType((*BulkGetRequest)(nil), "dapr.proto.components.v1.BulkGetRequest")
+	proto.RegisterType((*BulkStateItem)(nil), "dapr.proto.components.v1.BulkStateItem")
+	proto.RegisterMapType((map[string]string)(nil), "dapr.proto.components.v1.BulkStateItem.MetadataEntry")
+	proto.RegisterType((*BulkGetResponse)(nil), "dapr.proto.components.v1.BulkGetResponse")
+	proto.RegisterType((*BulkSetRequest)(nil), "dapr.proto.components.v1.BulkSetRequest")
+	proto.RegisterType((*BulkSetResponse)(nil), "dapr.proto.components.v1.BulkSetResponse")
+}
+
+func init() {
+	proto.RegisterFile("dapr/proto/components/v1/state.proto", fileDescriptor_2179950bcc2e9039)
+}
+
+var fileDescriptor_2179950bcc2e9039 = []byte{
+	// 1324 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcb, 0x6e, 0xdb, 0x46,
+	0x17, 0x36, 0xa9, 0x8b, 0xed, 0x23, 0xd9, 0xbf, 0x32, 0xbf, 0x91, 0x28, 0x4c, 0xd0, 0x3a, 0x6c,
+	0x2e, 0x4a, 0xd2, 0x4a, 0x88, 0x92, 0xa0, 0x69, 0x82, 0x00, 0x55, 0x64, 0xd9, 0x15, 0x9a, 0x48,
+	0xce, 0x50, 0x6e, 0x90, 0x00, 0x81, 0x4b, 0xc9, 0x13, 0x85, 0xb0, 0x44, 0xca, 0xe4, 0xc8, 0x85,
+	0x5e, 0x20, 0xcb, 0x02, 0xed, 0xb2, 0x8b, 0xee, 0x8a, 0xee, 0xfb, 0x2c, 0x5d, 0x15, 0xe8, 0x3b,
+	0xf4, 0x11, 0x0a, 0xce, 0x0c, 0x29, 0xd2, 0xba, 0x90, 0x96, 0x1d, 0x74, 0xc7, 0xe1, 0x9c, 0xef,
+	0x3b, 0x67, 0xce, 0x6d, 0x0e, 0x09, 0xd7, 0x0f, 0xf4, 0x81, 0x5d, 0x1a, 0xd8, 0x16, 0xb5, 0x4a,
+	0x1d, 0xab, 0x3f, 0xb0, 0x4c, 0x62, 0x52, 0xa7, 0x74, 0x7c, 0xaf, 0xe4, 0x50, 0x9d, 0x92, 0x22,
+	0xdb, 0x41, 0x79, 0x57, 0x8a, 0x3f, 0x17, 0xc7, 0x52, 0xc5, 0xe3, 0x7b, 0xca, 0xe5, 0xae, 0x65,
+	0x75, 0x7b, 0x84, 0x33, 0xb4, 0x87, 0xef, 0x4a, 0xba, 0x39, 0xe2, 0x82, 0xca, 0x8d, 0x99, 0xd4,
+	0x1d, 0xab, 0xdf, 0xb7, 0x4c, 0x2e, 0xa6, 0x1e, 0xc3, 0xb2, 0x66, 0xd9, 0xd4, 0x30, 0xbb, 0x28,
+	0x07, 0x89, 0x43, 0x32, 0xca, 0x4b, 0x9b, 0x52, 0x61, 0x15, 0xbb, 0x8f, 0xe8, 0x29, 0xa4, 0x2c,
+	0xfb, 0x80, 0xd8, 0x79, 0x79, 0x53, 0x2a, 0xac, 0x97, 0x6f, 0x15, 0x67, 0x19, 0x52, 0x14, 0x1c,
+	0xc5, 0xa6, 0x2b, 0x8e, 0x39, 0x4a, 0x55, 0x20, 0xc5, 0xd6, 0x68, 0x19, 0x12, 0x15, 0xad, 0x9a,
+	0x5b, 0x42, 0x2b, 0x90, 0xdc, 0xaa, 0x69, 0xd5, 0x9c, 0xa4, 0x3e, 0x02, 0xd8, 0xd5, 0xbb, 0x86,
+	0xa9, 0x53, 0xc3, 0x32, 0xd1, 0x06, 0xa4, 0x7a, 0x46, 0xdf, 0xa0, 0x4c, 0x79, 0x02, 0xf3, 0x85,
+	0xfb, 0x96, 0x5a, 0x87, 0xc4, 0x64, 0xea, 0x57, 0x31, 0x5f, 0xa8, 0xbf, 0xc8, 0x90, 0x7a, 0x39,
+	0x24, 0xf6, 0x08, 0x55, 0x21, 0xfd, 0xce, 0xe8, 0x51, 0x62, 0xe7, 0xa5, 0xcd, 0x44, 0x21, 0x53,
+	0xbe, 0x3b, 0xdb, 0x3e, 0x06, 0x28, 0x6e, 0x33, 0xe9, 0x9a, 0x49, 0xed, 0x11, 0x16, 0x50, 0xf4,
+	0x10, 0x92, 0x8e, 0x65, 0xd3, 0xbc, 0xcc, 0x28, 0xae, 0x45, 0x1e, 0x11, 0x33, 0x71, 0xb4, 0x05,
+	0x30, 0xf0, 0xed, 0xcf, 0x27, 0x36, 0xa5, 0x42, 0xa6, 0x7c, 0x7d, 0x36, 0x78, 0x7c, 0x56, 0x1c,
+	0xc0, 0x29, 0x4d, 0xc8, 0x04, 0x6c, 0x9a, 0x12, 0x81, 0x3b, 0x90, 0x3a, 0xd6, 0x7b, 0x43, 0xc2,
+	0x5c, 0x90, 0x29, 0x6f, 0x14, 0x79, 0xc0, 0x8b, 0x5e, 0xc0, 0x8b, 0x15, 0x73, 0x84, 0xb9, 0xc8,
+	0x63, 0xf9, 0x91, 0xa4, 0xfe, 0x29, 0x41, 0x96, 0x9d, 0x15, 0x93, 0xa3, 0x21, 0x71, 0x28, 0x7a,
+	0x08, 0xa9, 0x23, 0x77, 0xcd, 0x48, 0x33, 0xe5, 0x4f, 0x23, 0x5c, 0x84, 0xb9, 0x34, 0xda, 0x85,
+	0x95, 0x3e, 0xa1, 0xfa, 0x81, 0x4e, 0x75, 0xe1, 0x99, 0x07, 0x51, 0x48, 0xae, 0xb0, 0xf8, 0x42,
+	0xc0, 0xb8, 0x97, 0x7d, 0x16, 0xe5, 0x09, 0xac, 0x85, 0xb6, 0xa6, 0x1c, 0x76, 0x23, 0x78, 0xd8,
+	0xd5, 0xe0, 0xb1, 0x7e, 0x95, 0x60, 0x95, 0x69, 0xa9, 0x53, 0xd2, 0x9f, 0x82, 0x44, 0x90, 0x14,
+	0xa6, 0x4a, 0x85, 0x2c, 0x66, 0xcf, 0xa8, 0x0c, 0x49, 0x42, 0xf5, 0xae, 0x88, 0xcd, 0x27, 0xb3,
+	0xcd, 0xaf, 0x51, 0xbd, 0x8b, 0x99, 0xac, 0x6b, 0x01, 0xb1, 0x6d, 0xcb, 0xce, 0x27, 0xb9, 0x05,
+	0x6c, 0x81, 0xae, 0x41, 0xb6, 0x63, 0x99, 0x94, 0x98, 0x74, 0x9f, 0x8e, 0x06, 0x24, 0x9f, 0x62,
+	0x9b, 0x19, 0xf1, 0xae, 0x35, 0x1a, 0x10, 0xf5, 0x1f, 0x09, 0xd6, 0x84, 0x1b, 0x9c, 0x81, 0x65,
+	0x3a, 0x04, 0x7d, 0x05, 0x29, 0x83, 0x92, 0xbe, 0x23, 0x72, 0xf3, 0xb3, 0x08, 0xf7, 0xb9, 0x07,
+	0xc3, 0x1c, 0x31, 0x3d, 0xef, 0xd1, 0xcb, 0x40, 0x48, 0x12, 0x8c, 0xf3, 0x61, 0x64, 0x48, 0xb8,
+	0x2d, 0x1f, 0x27, 0x26, 0xbf, 0x49, 0x70, 0xa5, 0x65, 0xeb, 0xa6, 0xa3, 0x77, 0xdc, 0x5c, 0xd6,
+	0x7b, 0x1a, 0xd5, 0x29, 0x69, 0x0e, 0x88, 0xcd, 0x6b, 0xba, 0x02, 0xe9, 0x03, 0xd2, 0x23, 0x94,
+	0x88, 0xd4, 0x9b, 0xd3, 0x3d, 0xb6, 0x98, 0x9c, 0xc8, 0xa0, 0x6f, 0x96, 0xb0, 0x00, 0xa2, 0x47,
+	0x90, 0x70, 0x08, 0xcd, 0xcb, 0x51, 0xd5, 0xa5, 0x11, 0x3a, 0x06, 0xbb, 0x90, 0x67, 0xab, 0xb0,
+	0x6c, 0xf3, 0x37, 0xea, 0x8f, 0x32, 0x5c, 0x9e, 0xb4, 0xd3, 0xab, 0x8f, 0x3d, 0x00, 0xcb, 0x33,
+	0xd9, 0x8b, 0xd5, 0x1c, 0xbf, 0xce, 0x39, 0x30, 0x0e, 0x10, 0xa1, 0xb7, 0x13, 0xf5, 0x53, 0x39,
+	0x0d, 0xe9, 0x47, 0x2d, 0xa6, 0xab, 0xa0, 0x4c, 0xd3, 0xc8, 0x73, 0x45, 0xbd, 0x0a, 0xc9, 0x9a,
+	0x28, 0x05, 0x8e, 0x97, 0x02, 0x78, 0xf5, 0xa7, 0x04, 0x64, 0xc5, 0xb1, 0xf9, 0x41, 0x5f, 0x83,
+	0x5b, 0x07, 0x9d, 0xa1, 0x6d, 0x13, 0xb3, 0xc3, 0x0d, 0x58, 0x2f, 0x7f, 0x39, 0x27, 0x54, 0x01,
+	0x30, 0x5f, 0x54, 0xc7, 0x70, 0x1c, 0xe4, 0x12, 0xd4, 0x8e, 0xe1, 0x50, 0x46, 0x2d, 0x2f, 0x44,
+	0xed, 0xc1, 0x71, 0x90, 0x4b, 0x7d, 0x0f, 0xb9, 0x93, 0xba, 0xd1, 0x15, 0xb8, 0x54, 0x6d, 0x36,
+	0xaa, 0x7b, 0x18, 0xd7, 0x1a, 0xd5, 0xd7, 0xfb, 0x7b, 0x0d, 0x6d, 0xb7, 0x56, 0xad, 0x6f, 0xd7,
+	0x6b, 0x5b, 0xb9, 0xa5, 0x93, 0x9b, 0xdb, 0x75, 0xac, 0xb5, 0xf6, 0x5f, 0xe1, 0x7a, 0xab, 0x96,
+	0x93, 0x90, 0x02, 0x17, 0x83, 0x9b, 0xcf, 0x2b, 0xfe, 0x9e, 0xac, 0xea, 0x63, 0x4d, 0x9e, 0x76,
+	0x41, 0xa6, 0xd5, 0xb5, 0xd6, 0x14, 0x4d, 0x79, 0xd8, 0x08, 0x6e, 0xd6, 0xbe, 0xab, 0x35, 0x5a,
+	0x7b, 0x95, 0xe7, 0x39, 0x09, 0x5d, 0x04, 0x14, 0xdc, 0xd1, 0x5a, 0xb8, 0xd9, 0xd8, 0xc9, 0xc9,
+	0x6a, 0x0b, 0x32, 0x75, 0xd3, 0xf0, 0x2a, 0x00, 0xd5, 0x02, 0xa9, 0xc7, 0x2b, 0xef, 0xf6, 0x6c,
+	0x9f, 0x79, 0x59, 0x24, 0xc0, 0xe3, 0x14, 0x53, 0xd7, 0x21, 0xcb, 0x59, 0x45, 0x5e, 0x7c, 0x90,
+	0x01, 0x76, 0xfc, 0x3a, 0x9b, 0x92, 0x70, 0x8d, 0x89, 0x94, 0x2f, 0xcf, 0xd6, 0x3b, 0x66, 0x9a,
+	0x95, 0xe3, 0x27, 0xc3, 0x9f, 0x38, 0xbf, 0xf0, 0x9f, 0xad, 0x7c, 0x3e, 0xc8, 0x90, 0xd9, 0x21,
+	0xbe, 0x63, 0xfc, 0xbb, 0x47, 0x9a, 0x72, 0xf7, 0xc8, 0xa7, 0xb8, 0x7b, 0x9a, 0x13, 0xfd, 0xfd,
+	0x7e, 0x84, 0xff, 0xe6, 0x77, 0xf7, 0x89, 0x6b, 0x2b, 0x39, 0x71, 0x6d, 0x9d, 0xcd, 0x11, 0xbf,
+	0xcb, 0xb0, 0x16, 0xea, 0xdc, 0x53, 0xd0, 0x8b, 0x38, 0xe2, 0x54, 0x17, 0x5d, 0xc8, 0x80, 0x99,
+	0xae, 0xf8, 0x1a, 0x96, 0x2d, 0x9e, 0x19, 0xcc, 0x0b, 0x99, 0xf2, 0xcd, 0x78, 0x79, 0x84, 0x3d,
+	0xd8, 0xd9, 0x3c, 0x95, 0x83, 0x75, 0xcf, 0x4e, 0x51, 0x4d, 0x7f, 0xc9, 0x00, 0xda, 0xbc, 0x6a,
+	0x0a, 0x91, 0x65, 0x05, 0xd9, 0x42, 0x33, 0x4d, 0xb0, 0x2e, 0x93, 0x51, 0x75, 0xa9, 0x11, 0x7a,
+	0x0a, 0x5f, 0xa6, 0x16, 0xf2, 0xe5, 0x44, 0x62, 0xa6, 0xcf, 0x39, 0x31, 0xd7, 0x20, 0xa3, 0x8d,
+	0xeb, 0x43, 0xc5, 0x70, 0xe1, 0xd9, 0xb0, 0x77, 0x18, 0x4e, 0xd5, 0xa7, 0xe1, 0xf1, 0x2c, 0xee,
+	0x70, 0x22, 0x46, 0x34, 0x75, 0x03, 0x50, 0x90, 0x53, 0x68, 0x7a, 0x0e, 0xeb, 0xee, 0xdb, 0x40,
+	0x9b, 0x7c, 0x1c, 0x56, 0x73, 0x3d, 0x4e, 0x47, 0xf4, 0x74, 0xfc, 0x21, 0xc3, 0x9a, 0x4b, 0xc7,
+	0x9c, 0xf8, 0x9f, 0x0c, 0xbe, 0xc1, 0x4a, 0x4c, 0x45, 0x55, 0x62, 0xc8, 0xd4, 0xd8, 0x4d, 0xe9,
+	0xbc, 0x63, 0xdf, 0x86, 0xff, 0xf9, 0x21, 0x10, 0x0d, 0x3a, 0x7e, 0xa8, 0x43, 0x47, 0xf0, 0xa6,
+	0xf1, 0x1c, 0x24, 0xba, 0x16, 0x1f, 0x42, 0x57, 0xb0, 0xfb, 0xe8, 0x85, 0x59, 0x5b, 0x24, 0xcc,
+	0xda, 0x44, 0x98, 0x2f, 0x70, 0x8b, 0x03, 0x19, 0x5b, 0x3e, 0x82, 0xff, 0xbb, 0x03, 0xbc, 0xa1,
+	0xb7, 0x7b, 0x84, 0xd9, 0xa3, 0x51, 0xcb, 0x26, 0xe8, 0x8d, 0xf7, 0xe1, 0x7b, 0x33, 0xde, 0xb7,
+	0x98, 0x72, 0x2b, 0xe6, 0x07, 0x82, 0xba, 0x54, 0xfe, 0x59, 0x82, 0x4b, 0x93, 0x53, 0x21, 0xd7,
+	0xfb, 0x03, 0xac, 0x78, 0x5b, 0xe8, 0xfe, 0x02, 0x63, 0xac, 0xf2, 0xe0, 0x74, 0x20, 0xdf, 0xa8,
+	0xbf, 0xd3, 0x00, 0x01, 0x3b, 0x5e, 0x41, 0xd2, 0x1d, 0x49, 0xd0, 0x8d, 0xd9, 0x74, 0x81, 0x41,
+	0x48, 0xb9, 0x19, 0x25, 0xe6, 0xe9, 0x41, 0x1d, 0x58, 0xd9, 0x26, 0x3a, 0x1d, 0xda, 0xc4, 0x41,
+	0x73, 0x86, 0x25, 0x4f, 0xc6, 0x53, 0x70, 0x27, 0x8e, 0xa8, 0xaf, 0xe4, 0x2d, 0xa4, 0x79, 0xbb,
+	0x40, 0x71, 0x9b, 0x8d, 0x52, 0x88, 0x16, 0xf4, 0xe9, 0x5b, 0x90, 0xd8, 0x21, 0x14, 0xc5, 0xea,
+	0x30, 0xca, 0x8d, 0x58, 0x93, 0x05, 0x67, 0xd5, 0xe6, 0xb3, 0x6a, 0xb1, 0x58, 0xb5, 0x10, 0xeb,
+	0x2b, 0x48, 0xee, 0xba, 0x7f, 0x9c, 0xe6, 0x00, 0xdc, 0xfd, 0x18, 0x81, 0xe4, 0x62, 0x3e, 0xb1,
+	0x01, 0x30, 0x6e, 0xcb, 0xe8, 0xee, 0xfc, 0x4a, 0x0f, 0xfb, 0xfa, 0xf3, 0x78, 0xc2, 0xbe, 0xaa,
+	0xef, 0x61, 0x59, 0x34, 0x1a, 0x54, 0x98, 0x0f, 0x0d, 0xf8, 0xfd, 0x76, 0x0c, 0xc9, 0x93, 0x1a,
+	0xb4, 0x68, 0x0d, 0x5a, 0x6c, 0x0d, 0xa1, 0x38, 0x3c, 0x7b, 0xf1, 0xe6, 0xdb, 0xae, 0x41, 0xdf,
+	0x0f, 0xdb, 0xae, 0x64, 0x89, 0xfd, 0x30, 0x1c, 0x43, 0xbe, 0x70, 0x7b, 0xb2, 0x6d, 0xb4, 0x4b,
+	0x83, 0xde, 0xb0, 0xdb, 0x75, 0x5b, 0xd1, 0xb4, 0xbf, 0x89, 0x4f, 0xc6, 0xab, 0x76, 0x9a, 0xed,
+	0xdf, 0xff, 0x77, 0x00, 0xfa, 0x58, 0x00, 0xc3, 0xd6, 0x14, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// QueriableStateStoreClient is the client API for QueriableStateStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type QueriableStateStoreClient interface {
+	// Query performs a query request on the statestore.
+	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+}
+
+type queriableStateStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewQueriableStateStoreClient(cc *grpc.ClientConn) QueriableStateStoreClient {
+	return &queriableStateStoreClient{cc}
+}
+
+func (c *queriableStateStoreClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
+	out := new(QueryResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.QueriableStateStore/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueriableStateStoreServer is the server API for QueriableStateStore service.
+type QueriableStateStoreServer interface {
+	// Query performs a query request on the statestore.
+	Query(context.Context, *QueryRequest) (*QueryResponse, error)
+}
+
+// UnimplementedQueriableStateStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedQueriableStateStoreServer struct {
+}
+
+func (*UnimplementedQueriableStateStoreServer) Query(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+
+func RegisterQueriableStateStoreServer(s *grpc.Server, srv QueriableStateStoreServer) {
+	s.RegisterService(&_QueriableStateStore_serviceDesc, srv)
+}
+
+func _QueriableStateStore_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueriableStateStoreServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.QueriableStateStore/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueriableStateStoreServer).Query(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _QueriableStateStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.QueriableStateStore",
+	HandlerType: (*QueriableStateStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Query",
+			Handler:    _QueriableStateStore_Query_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/state.proto",
+}
+
+// TransactionalStateStoreClient is the client API for TransactionalStateStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TransactionalStateStoreClient interface {
+	// Transact executes multiples operation in a transactional environment.
+	Transact(ctx context.Context, in *TransactionalStateRequest, opts ...grpc.CallOption) (*TransactionalStateResponse, error)
+}
+
+type transactionalStateStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTransactionalStateStoreClient(cc *grpc.ClientConn) TransactionalStateStoreClient {
+	return &transactionalStateStoreClient{cc}
+}
+
+func (c *transactionalStateStoreClient) Transact(ctx context.Context, in *TransactionalStateRequest, opts ...grpc.CallOption) (*TransactionalStateResponse, error) {
+	out := new(TransactionalStateResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.TransactionalStateStore/Transact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransactionalStateStoreServer is the server API for TransactionalStateStore service.
+type TransactionalStateStoreServer interface {
+	// Transact executes multiples operation in a transactional environment.
+	Transact(context.Context, *TransactionalStateRequest) (*TransactionalStateResponse, error)
+}
+
+// UnimplementedTransactionalStateStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedTransactionalStateStoreServer struct {
+}
+
+func (*UnimplementedTransactionalStateStoreServer) Transact(ctx context.Context, req *TransactionalStateRequest) (*TransactionalStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transact not implemented")
+}
+
+func RegisterTransactionalStateStoreServer(s *grpc.Server, srv TransactionalStateStoreServer) {
+	s.RegisterService(&_TransactionalStateStore_serviceDesc, srv)
+}
+
+func _TransactionalStateStore_Transact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionalStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionalStateStoreServer).Transact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.TransactionalStateStore/Transact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionalStateStoreServer).Transact(ctx, req.(*TransactionalStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TransactionalStateStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.TransactionalStateStore",
+	HandlerType: (*TransactionalStateStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Transact",
+			Handler:    _TransactionalStateStore_Transact_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/state.proto",
+}
+
+// StateStoreClient is the client API for StateStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StateStoreClient interface {
+	// Initializes the state store component with the given metadata.
+	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitResponse, error)
+	// Returns a list of implemented state store features.
+	Features(ctx context.Context, in *FeaturesRequest, opts ...grpc.CallOption) (*FeaturesResponse, error)
+	// Deletes the specified key from the state store.
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// Get data from the given key.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// Sets the value of the specified key.
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
+	// Ping the state store. Used for liveness porpuses.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// Deletes many keys at once.
+	BulkDelete(ctx context.Context, in *BulkDeleteRequest, opts ...grpc.CallOption) (*BulkDeleteResponse, error)
+	// Retrieves many keys at once.
+	BulkGet(ctx context.Context, in *BulkGetRequest, opts ...grpc.CallOption) (*BulkGetResponse, error)
+	// Set the value of many keys at once.
+	BulkSet(ctx context.Context, in *BulkSetRequest, opts ...grpc.CallOption) (*BulkSetResponse, error)
+}
+
+type stateStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStateStoreClient(cc *grpc.ClientConn) StateStoreClient {
+	return &stateStoreClient{cc}
+}
+
+func (c *stateStoreClient) Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*InitResponse, error) {
+	out := new(InitResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Init", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Features(ctx context.Context, in *FeaturesRequest, opts ...grpc.CallOption) (*FeaturesResponse, error) {
+	out := new(FeaturesResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Features", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
+	out := new(SetResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) BulkDelete(ctx context.Context, in *BulkDeleteRequest, opts ...grpc.CallOption) (*BulkDeleteResponse, error) {
+	out := new(BulkDeleteResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/BulkDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) BulkGet(ctx context.Context, in *BulkGetRequest, opts ...grpc.CallOption) (*BulkGetResponse, error) {
+	out := new(BulkGetResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/BulkGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) BulkSet(ctx context.Context, in *BulkSetRequest, opts ...grpc.CallOption) (*BulkSetResponse, error) {
+	out := new(BulkSetResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/BulkSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StateStoreServer is the server API for StateStore service.
+type StateStoreServer interface {
+	// Initializes the state store component with the given metadata.
+	Init(context.Context, *InitRequest) (*InitResponse, error)
+	// Returns a list of implemented state store features.
+	Features(context.Context, *FeaturesRequest) (*FeaturesResponse, error)
+	// Deletes the specified key from the state store.
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// Get data from the given key.
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// Sets the value of the specified key.
+	Set(context.Context, *SetRequest) (*SetResponse, error)
+	// Ping the state store. Used for liveness porpuses.
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// Deletes many keys at once.
+	BulkDelete(context.Context, *BulkDeleteRequest) (*BulkDeleteResponse, error)
+	// Retrieves many keys at once.
+	BulkGet(context.Context, *BulkGetRequest) (*BulkGetResponse, error)
+	// Set the value of many keys at once.
+	BulkSet(context.Context, *BulkSetRequest) (*BulkSetResponse, error)
+}
+
+// UnimplementedStateStoreServer can be embedded to have forward compatible implementations.
+type UnimplementedStateStoreServer struct {
+}
+
+func (*UnimplementedStateStoreServer) Init(ctx context.Context, req *InitRequest) (*InitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Init not implemented")
+}
+func (*UnimplementedStateStoreServer) Features(ctx context.Context, req *FeaturesRequest) (*FeaturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Features not implemented")
+}
+func (*UnimplementedStateStoreServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedStateStoreServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedStateStoreServer) Set(ctx context.Context, req *SetRequest) (*SetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (*UnimplementedStateStoreServer) Ping(ctx context.Context, req *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (*UnimplementedStateStoreServer) BulkDelete(ctx context.Context, req *BulkDeleteRequest) (*BulkDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDelete not implemented")
+}
+func (*UnimplementedStateStoreServer) BulkGet(ctx context.Context, req *BulkGetRequest) (*BulkGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkGet not implemented")
+}
+func (*UnimplementedStateStoreServer) BulkSet(ctx context.Context, req *BulkSetRequest) (*BulkSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkSet not implemented")
+}
+
+func RegisterStateStoreServer(s *grpc.Server, srv StateStoreServer) {
+	s.RegisterService(&_StateStore_serviceDesc, srv)
+}
+
+func _StateStore_Init_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Init(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Init",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Init(ctx, req.(*InitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Features_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeaturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Features(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Features",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Features(ctx, req.(*FeaturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Set",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_BulkDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).BulkDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/BulkDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).BulkDelete(ctx, req.(*BulkDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_BulkGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).BulkGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/BulkGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).BulkGet(ctx, req.(*BulkGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_BulkSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).BulkSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/BulkSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).BulkSet(ctx, req.(*BulkSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _StateStore_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.StateStore",
+	HandlerType: (*StateStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Init",
+			Handler:    _StateStore_Init_Handler,
+		},
+		{
+			MethodName: "Features",
+			Handler:    _StateStore_Features_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _StateStore_Delete_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _StateStore_Get_Handler,
+		},
+		{
+			MethodName: "Set",
+			Handler:    _StateStore_Set_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _StateStore_Ping_Handler,
+		},
+		{
+			MethodName: "BulkDelete",
+			Handler:    _StateStore_BulkDelete_Handler,
+		},
+		{
+			MethodName: "BulkGet",
+			Handler:    _StateStore_BulkGet_Handler,
+		},
+		{
+			MethodName: "BulkSet",
+			Handler:    _StateStore_BulkSet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "dapr/proto/components/v1/state.proto",
+}
@@ -0,0 +1,167 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	components "github.com/dapr/components-contrib/pluggable/proto/components/v1"
+	"github.com/dapr/components-contrib/pubsub"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pubsubServer serves a pub/sub over the PubSub service
+type pubsubServer struct {
+	pubsub pubsub.PubSub
+	// subscribeLock serializes the subscriptions, so that a topic is subscribed to once
+	subscribeLock sync.Mutex
+	// lock guards streams, the streams of the subscribed topics, which are nil between two streams. The topics stay
+	// subscribed to when their stream is closed, as the pub/subs can't unsubscribe, and their messages fail until
+	// Dapr pulls them again.
+	lock    sync.Mutex
+	streams map[string]*pullStream
+}
+
+// pullStream is a stream pulling the messages of a topic
+type pullStream struct {
+	*ackStream
+	stream components.PubSub_PullMessagesServer
+}
+
+// RegisterPubSub registers the PubSub service of the pub/sub with the server
+func RegisterPubSub(s *grpc.Server, ps pubsub.PubSub) {
+	components.RegisterPubSubServer(s, &pubsubServer{pubsub: ps, streams: map[string]*pullStream{}})
+}
+
+func (p *pubsubServer) Init(ctx context.Context, req *components.PubSubInitRequest) (*components.PubSubInitResponse, error) {
+	err := p.pubsub.Init(pubsub.Metadata{Properties: req.GetMetadata().GetProperties()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.PubSubInitResponse{}, nil
+}
+
+func (p *pubsubServer) Features(ctx context.Context, req *components.FeaturesRequest) (*components.FeaturesResponse, error) {
+	return &components.FeaturesResponse{Features: features(p.pubsub.GetComponentMetadata())}, nil
+}
+
+func (p *pubsubServer) Publish(ctx context.Context, req *components.PublishRequest) (*components.PublishResponse, error) {
+	err := p.pubsub.Publish(&pubsub.PublishRequest{Data: req.GetData(), Topic: req.GetTopic(), Metadata: req.GetMetadata()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.PublishResponse{}, nil
+}
+
+// PullMessages subscribes to the topic of the first message of the stream, and sends the messages of the topic on
+// the stream until it is closed. The messages fail when the application fails to handle them, or when the stream is
+// closed before they are acknowledged.
+func (p *pubsubServer) PullMessages(stream components.PubSub_PullMessagesServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	topic := first.GetTopic()
+	if topic.GetName() == "" {
+		return status.Error(codes.InvalidArgument, "the first message of the stream must have the topic to pull the messages of")
+	}
+
+	s := &pullStream{ackStream: newAckStream(), stream: stream}
+	err = p.subscribe(topic, s)
+	if err != nil {
+		return err
+	}
+	defer p.unsubscribe(topic.GetName(), s)
+	defer s.close(nil)
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.ack(req.GetAckMessageId(), ackError(req.GetAckError() != nil, req.GetAckError().GetMessage()))
+	}
+}
+
+func (p *pubsubServer) Ping(ctx context.Context, req *components.PingRequest) (*components.PingResponse, error) {
+	err := ping(p.pubsub)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &components.PingResponse{}, nil
+}
+
+// subscribe makes s the stream of the topic, and subscribes to the topic when it is the first stream of the topic
+func (p *pubsubServer) subscribe(topic *components.Topic, s *pullStream) error {
+	p.subscribeLock.Lock()
+	defer p.subscribeLock.Unlock()
+
+	p.lock.Lock()
+	_, subscribed := p.streams[topic.GetName()]
+	p.streams[topic.GetName()] = s
+	p.lock.Unlock()
+	if subscribed {
+		return nil
+	}
+
+	err := p.pubsub.Subscribe(pubsub.SubscribeRequest{Topic: topic.GetName(), Metadata: topic.GetMetadata()}, p.handler(topic.GetName()))
+	if err != nil {
+		p.lock.Lock()
+		delete(p.streams, topic.GetName())
+		p.lock.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// unsubscribe removes s from the topic, unless another stream replaced it
+func (p *pubsubServer) unsubscribe(topic string, s *pullStream) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.streams[topic] == s {
+		p.streams[topic] = nil
+	}
+}
+
+// handler returns the handler of the subscription to the topic, which delivers the messages to the current stream of
+// the topic
+func (p *pubsubServer) handler(topic string) func(msg *pubsub.NewMessage) error {
+	return func(msg *pubsub.NewMessage) error {
+		p.lock.Lock()
+		s := p.streams[topic]
+		p.lock.Unlock()
+		if s == nil {
+			return fmt.Errorf("no stream pulls the messages of topic %s", topic)
+		}
+
+		received := msg.Topic
+		if received == "" {
+			received = topic
+		}
+
+		return s.deliver(func(id string) error {
+			return s.stream.Send(&components.PullMessagesResponse{
+				Data:      msg.Data,
+				TopicName: received,
+				Metadata:  msg.Metadata,
+				Id:        id,
+			})
+		})
+	}
+}
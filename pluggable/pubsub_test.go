@@ -0,0 +1,176 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/metadata"
+	components "github.com/dapr/components-contrib/pluggable/proto/components/v1"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakePubSub records the publications and the handlers of the subscriptions
+type fakePubSub struct {
+	lock       sync.Mutex
+	published  []*pubsub.PublishRequest
+	handlers   map[string]func(msg *pubsub.NewMessage) error
+	subscribed chan string
+}
+
+func newFakePubSub() *fakePubSub {
+	return &fakePubSub{handlers: map[string]func(msg *pubsub.NewMessage) error{}, subscribed: make(chan string, 10)}
+}
+
+func (f *fakePubSub) Init(metadata pubsub.Metadata) error {
+	return nil
+}
+
+func (f *fakePubSub) Publish(req *pubsub.PublishRequest) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.published = append(f.published, req)
+	return nil
+}
+
+func (f *fakePubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	f.lock.Lock()
+	f.handlers[req.Topic] = handler
+	f.lock.Unlock()
+	f.subscribed <- req.Topic
+	return nil
+}
+
+func (f *fakePubSub) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Capabilities: []metadata.Capability{metadata.CapabilityTTL}}
+}
+
+func (f *fakePubSub) handler(topic string) func(msg *pubsub.NewMessage) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.handlers[topic]
+}
+
+// deliver delivers the message to the handler of the topic, and returns the channel of the result of the handler
+func (f *fakePubSub) deliver(topic string, data string) chan error {
+	result := make(chan error, 1)
+	handler := f.handler(topic)
+	go func() {
+		result <- handler(&pubsub.NewMessage{Topic: topic, Data: []byte(data)})
+	}()
+
+	return result
+}
+
+func waitResult(t *testing.T, result chan error) error {
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the handler didn't return")
+		return nil
+	}
+}
+
+// pull delivers the message to the handler of the orders topic until the stream receives it, as the messages fail
+// until the server received the first message of the stream. It returns the channel of the result of the handler.
+func pull(t *testing.T, ps *fakePubSub, stream components.PubSub_PullMessagesClient, data string) (*components.PullMessagesResponse, chan error) {
+	received := make(chan *components.PullMessagesResponse, 1)
+	go func() {
+		msg, err := stream.Recv()
+		if err == nil {
+			received <- msg
+		}
+	}()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		result := ps.deliver("orders", data)
+		select {
+		case msg := <-received:
+			return msg, result
+		case <-result:
+			// the stream is not pulling yet
+			time.Sleep(10 * time.Millisecond)
+		case <-timeout:
+			require.Fail(t, "the stream didn't receive the message")
+		}
+	}
+}
+
+func TestPubSub(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Features and publish", func(t *testing.T) {
+		ps := newFakePubSub()
+		client := components.NewPubSubClient(serve(t, WithPubSub(ps)))
+
+		resp, err := client.Features(ctx, &components.FeaturesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TTL"}, resp.Features)
+
+		_, err = client.Publish(ctx, &components.PublishRequest{Topic: "orders", Data: []byte("1"), Metadata: map[string]string{"ttlInSeconds": "10"}})
+		require.NoError(t, err)
+		require.Len(t, ps.published, 1)
+		assert.Equal(t, &pubsub.PublishRequest{Topic: "orders", Data: []byte("1"), Metadata: map[string]string{"ttlInSeconds": "10"}}, ps.published[0])
+
+		_, err = client.Ping(ctx, &components.PingRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Pull messages with acknowledgements", func(t *testing.T) {
+		ps := newFakePubSub()
+		client := components.NewPubSubClient(serve(t, WithPubSub(ps)))
+		streamCtx, cancel := context.WithCancel(ctx)
+		stream, err := client.PullMessages(streamCtx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&components.PullMessagesRequest{Topic: &components.Topic{Name: "orders"}}))
+		assert.Equal(t, "orders", <-ps.subscribed)
+
+		msg, result := pull(t, ps, stream, "1")
+		assert.Equal(t, "1", string(msg.Data))
+		assert.Equal(t, "orders", msg.TopicName)
+		require.NoError(t, stream.Send(&components.PullMessagesRequest{AckMessageId: msg.Id}))
+		assert.NoError(t, waitResult(t, result))
+
+		msg, result = pull(t, ps, stream, "2")
+		require.NoError(t, stream.Send(&components.PullMessagesRequest{AckMessageId: msg.Id, AckError: &components.AckMessageError{Message: "failed"}}))
+		assert.EqualError(t, waitResult(t, result), "failed")
+
+		// The messages fail once the stream is closed, until the topic is pulled again without subscribing again
+		_, result = pull(t, ps, stream, "3")
+		cancel()
+		assert.Error(t, waitResult(t, result))
+		assert.Error(t, waitResult(t, ps.deliver("orders", "4")))
+
+		stream, err = client.PullMessages(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&components.PullMessagesRequest{Topic: &components.Topic{Name: "orders"}}))
+		msg, result = pull(t, ps, stream, "5")
+		assert.Equal(t, "5", string(msg.Data))
+		require.NoError(t, stream.Send(&components.PullMessagesRequest{AckMessageId: msg.Id}))
+		assert.NoError(t, waitResult(t, result))
+		assert.Empty(t, ps.subscribed)
+	})
+
+	t.Run("The first message must have the topic", func(t *testing.T) {
+		client := components.NewPubSubClient(serve(t, WithPubSub(newFakePubSub())))
+		stream, err := client.PullMessages(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&components.PullMessagesRequest{}))
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
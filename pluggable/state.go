@@ -0,0 +1,323 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dapr/components-contrib/metadata"
+	components "github.com/dapr/components-contrib/pluggable/proto/components/v1"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/query"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queryAPIFeature is the feature of the state stores implementing state.Querier in Dapr
+const queryAPIFeature = "QUERY_API"
+
+// stateServer serves a state store over the StateStore service
+type stateServer struct {
+	store state.Store
+}
+
+// RegisterStateStore registers the StateStore service of the store with the server, and the TransactionalStateStore
+// and QueriableStateStore services when the store implements state.TransactionalStore and state.Querier
+func RegisterStateStore(s *grpc.Server, store state.Store) {
+	components.RegisterStateStoreServer(s, &stateServer{store: store})
+	if transactional, ok := store.(state.TransactionalStore); ok {
+		components.RegisterTransactionalStateStoreServer(s, &transactionalStateServer{store: transactional})
+	}
+	if querier, ok := store.(state.Querier); ok {
+		components.RegisterQueriableStateStoreServer(s, &queriableStateServer{store: querier})
+	}
+}
+
+func (s *stateServer) Init(ctx context.Context, req *components.InitRequest) (*components.InitResponse, error) {
+	err := s.store.Init(state.Metadata{Properties: req.GetMetadata().GetProperties()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.InitResponse{}, nil
+}
+
+func (s *stateServer) Features(ctx context.Context, req *components.FeaturesRequest) (*components.FeaturesResponse, error) {
+	result := features(s.store.GetComponentMetadata())
+	for i, f := range result {
+		if f == string(metadata.CapabilityQuery) {
+			result[i] = queryAPIFeature
+		}
+	}
+
+	return &components.FeaturesResponse{Features: result}, nil
+}
+
+func (s *stateServer) Get(ctx context.Context, req *components.GetRequest) (*components.GetResponse, error) {
+	resp, err := s.store.Get(toGetRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return &components.GetResponse{}, nil
+	}
+
+	return &components.GetResponse{Data: resp.Data, Etag: toETag(resp.ETag), Metadata: resp.Metadata}, nil
+}
+
+func (s *stateServer) Set(ctx context.Context, req *components.SetRequest) (*components.SetResponse, error) {
+	setReq := toSetRequest(req)
+	err := s.store.Set(&setReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.SetResponse{}, nil
+}
+
+func (s *stateServer) Delete(ctx context.Context, req *components.DeleteRequest) (*components.DeleteResponse, error) {
+	deleteReq := toDeleteRequest(req)
+	err := s.store.Delete(&deleteReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.DeleteResponse{}, nil
+}
+
+// BulkGet gets the keys with the state.BulkStore of the store, or one by one when the store doesn't implement it
+func (s *stateServer) BulkGet(ctx context.Context, req *components.BulkGetRequest) (*components.BulkGetResponse, error) {
+	reqs := make([]state.GetRequest, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		reqs = append(reqs, *toGetRequest(item))
+	}
+
+	bulkStore, ok := s.store.(state.BulkStore)
+	if !ok {
+		bulkStore = state.NewDefaultBulkStore(s.store, 0)
+	}
+	responses, err := bulkStore.BulkGet(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*components.BulkStateItem, 0, len(responses))
+	for _, resp := range responses {
+		items = append(items, &components.BulkStateItem{
+			Key:      resp.Key,
+			Data:     resp.Data,
+			Etag:     toETag(resp.ETag),
+			Error:    resp.Error,
+			Metadata: resp.Metadata,
+		})
+	}
+
+	// Got tells Dapr that the keys were got in bulk, so that it doesn't get them again one by one
+	return &components.BulkGetResponse{Items: items, Got: true}, nil
+}
+
+func (s *stateServer) BulkSet(ctx context.Context, req *components.BulkSetRequest) (*components.BulkSetResponse, error) {
+	reqs := make([]state.SetRequest, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		reqs = append(reqs, toSetRequest(item))
+	}
+	err := s.store.BulkSet(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.BulkSetResponse{}, nil
+}
+
+func (s *stateServer) BulkDelete(ctx context.Context, req *components.BulkDeleteRequest) (*components.BulkDeleteResponse, error) {
+	reqs := make([]state.DeleteRequest, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		reqs = append(reqs, toDeleteRequest(item))
+	}
+	err := s.store.BulkDelete(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.BulkDeleteResponse{}, nil
+}
+
+func (s *stateServer) Ping(ctx context.Context, req *components.PingRequest) (*components.PingResponse, error) {
+	err := ping(s.store)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	return &components.PingResponse{}, nil
+}
+
+// transactionalStateServer serves a state store over the TransactionalStateStore service
+type transactionalStateServer struct {
+	store state.TransactionalStore
+}
+
+// Transact runs the operations in a transaction. The metadata of the transaction is added to the metadata of its
+// operations, as the transactions of the stores have no metadata of their own.
+func (s *transactionalStateServer) Transact(ctx context.Context, req *components.TransactionalStateRequest) (*components.TransactionalStateResponse, error) {
+	reqs := make([]state.TransactionalRequest, 0, len(req.GetOperations()))
+	for i, op := range req.GetOperations() {
+		switch r := op.GetRequest().(type) {
+		case *components.TransactionalStateOperation_Set:
+			setReq := toSetRequest(r.Set)
+			setReq.Metadata = withTransactionMetadata(setReq.Metadata, req.GetMetadata())
+			reqs = append(reqs, state.TransactionalRequest{Operation: state.Upsert, Request: setReq})
+		case *components.TransactionalStateOperation_Delete:
+			deleteReq := toDeleteRequest(r.Delete)
+			deleteReq.Metadata = withTransactionMetadata(deleteReq.Metadata, req.GetMetadata())
+			reqs = append(reqs, state.TransactionalRequest{Operation: state.Delete, Request: deleteReq})
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "operation %d of the transaction is neither a set nor a delete", i)
+		}
+	}
+
+	err := s.store.Multi(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &components.TransactionalStateResponse{}, nil
+}
+
+// withTransactionMetadata returns the metadata of an operation with the metadata of its transaction, without
+// overriding the metadata of the operation
+func withTransactionMetadata(operation, transaction map[string]string) map[string]string {
+	if len(transaction) == 0 {
+		return operation
+	}
+
+	result := make(map[string]string, len(operation)+len(transaction))
+	for k, v := range transaction {
+		result[k] = v
+	}
+	for k, v := range operation {
+		result[k] = v
+	}
+
+	return result
+}
+
+// queriableStateServer serves a state store over the QueriableStateStore service
+type queriableStateServer struct {
+	store state.Querier
+}
+
+// Query runs the query, whose filters are the JSON values of the filters of the Dapr query API
+func (s *queriableStateServer) Query(ctx context.Context, req *components.QueryRequest) (*components.QueryResponse, error) {
+	q, err := toQuery(req.GetQuery())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.store.Query(&state.QueryRequest{Query: q, Metadata: req.GetMetadata()})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*components.QueryItem, 0, len(resp.Results))
+	for _, item := range resp.Results {
+		items = append(items, &components.QueryItem{Key: item.Key, Data: item.Data, Etag: toETag(item.ETag)})
+	}
+
+	return &components.QueryResponse{Items: items, Token: resp.Token}, nil
+}
+
+func toQuery(q *components.Query) (query.Query, error) {
+	result := query.Query{}
+	if len(q.GetFilter()) > 0 {
+		result.Filters = make(map[string]interface{}, len(q.GetFilter()))
+		for key, value := range q.GetFilter() {
+			var filter interface{}
+			err := json.Unmarshal(value.GetValue(), &filter)
+			if err != nil {
+				return query.Query{}, fmt.Errorf("invalid JSON of query filter %s: %s", key, err)
+			}
+			result.Filters[key] = filter
+		}
+	}
+	for _, sorting := range q.GetSort() {
+		result.Sort = append(result.Sort, query.Sorting{Key: sorting.GetKey(), Order: sorting.GetOrder().String()})
+	}
+	result.Page = query.Pagination{Limit: int(q.GetPagination().GetLimit()), Token: q.GetPagination().GetToken()}
+
+	err := result.Parse()
+	if err != nil {
+		return query.Query{}, err
+	}
+
+	return result, nil
+}
+
+func toGetRequest(req *components.GetRequest) *state.GetRequest {
+	return &state.GetRequest{
+		Key:      req.GetKey(),
+		Metadata: req.GetMetadata(),
+		Options:  state.GetStateOption{Consistency: toConsistency(req.GetConsistency())},
+	}
+}
+
+func toSetRequest(req *components.SetRequest) state.SetRequest {
+	return state.SetRequest{
+		Key:      req.GetKey(),
+		Value:    req.GetValue(),
+		ETag:     req.GetEtag().GetValue(),
+		Metadata: req.GetMetadata(),
+		Options: state.SetStateOption{
+			Concurrency: toConcurrency(req.GetOptions().GetConcurrency()),
+			Consistency: toConsistency(req.GetOptions().GetConsistency()),
+		},
+	}
+}
+
+func toDeleteRequest(req *components.DeleteRequest) state.DeleteRequest {
+	return state.DeleteRequest{
+		Key:      req.GetKey(),
+		ETag:     req.GetEtag().GetValue(),
+		Metadata: req.GetMetadata(),
+		Options: state.DeleteStateOption{
+			Concurrency: toConcurrency(req.GetOptions().GetConcurrency()),
+			Consistency: toConsistency(req.GetOptions().GetConsistency()),
+		},
+	}
+}
+
+func toConsistency(consistency components.StateOptions_StateConsistency) string {
+	switch consistency {
+	case components.StateOptions_CONSISTENCY_EVENTUAL:
+		return state.Eventual
+	case components.StateOptions_CONSISTENCY_STRONG:
+		return state.Strong
+	default:
+		return ""
+	}
+}
+
+func toConcurrency(concurrency components.StateOptions_StateConcurrency) string {
+	switch concurrency {
+	case components.StateOptions_CONCURRENCY_FIRST_WRITE:
+		return state.FirstWrite
+	case components.StateOptions_CONCURRENCY_LAST_WRITE:
+		return state.LastWrite
+	default:
+		return ""
+	}
+}
+
+// toETag returns the etag of the value, which is nil for the values without etags
+func toETag(etag string) *components.Etag {
+	if etag == "" {
+		return nil
+	}
+
+	return &components.Etag{Value: etag}
+}
@@ -0,0 +1,170 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"context"
+	"testing"
+
+	components "github.com/dapr/components-contrib/pluggable/proto/components/v1"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/inmemory"
+	"github.com/dapr/components-contrib/state/query"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// queriableStore is an in-memory store whose queries return the keys of the query results
+type queriableStore struct {
+	*inmemory.InMemory
+	query query.Query
+}
+
+func (s *queriableStore) Query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	s.query = req.Query
+	return &state.QueryResponse{Results: []state.QueryItem{{Key: "key", Data: []byte(`"value"`), ETag: "1"}}, Token: "next"}, nil
+}
+
+func newStateClient(t *testing.T) (components.StateStoreClient, *inmemory.InMemory) {
+	store := inmemory.NewInMemoryStateStore(logger.NewLogger("test"))
+	t.Cleanup(func() {
+		store.Close()
+	})
+	client := components.NewStateStoreClient(serve(t, WithStateStore(store)))
+	_, err := client.Init(context.Background(), &components.InitRequest{Metadata: &components.MetadataRequest{}})
+	require.NoError(t, err)
+
+	return client, store
+}
+
+func TestStateStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Features are the capabilities of the store", func(t *testing.T) {
+		client, _ := newStateClient(t)
+		resp, err := client.Features(ctx, &components.FeaturesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ETAG", "TTL", "TRANSACTIONAL"}, resp.Features)
+	})
+
+	t.Run("Set, get and delete with etags", func(t *testing.T) {
+		client, _ := newStateClient(t)
+		_, err := client.Set(ctx, &components.SetRequest{Key: "key", Value: []byte(`"value"`)})
+		require.NoError(t, err)
+
+		resp, err := client.Get(ctx, &components.GetRequest{Key: "key"})
+		require.NoError(t, err)
+		assert.Equal(t, `"value"`, string(resp.Data))
+		require.NotNil(t, resp.Etag)
+
+		_, err = client.Delete(ctx, &components.DeleteRequest{Key: "key", Etag: &components.Etag{Value: "wrong"}})
+		assert.Error(t, err)
+		_, err = client.Delete(ctx, &components.DeleteRequest{Key: "key", Etag: resp.Etag})
+		require.NoError(t, err)
+
+		resp, err = client.Get(ctx, &components.GetRequest{Key: "key"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Data)
+		assert.Nil(t, resp.Etag)
+	})
+
+	t.Run("Bulk operations", func(t *testing.T) {
+		client, _ := newStateClient(t)
+		_, err := client.BulkSet(ctx, &components.BulkSetRequest{Items: []*components.SetRequest{
+			{Key: "key1", Value: []byte(`"1"`)},
+			{Key: "key2", Value: []byte(`"2"`)},
+		}})
+		require.NoError(t, err)
+
+		resp, err := client.BulkGet(ctx, &components.BulkGetRequest{Items: []*components.GetRequest{{Key: "key1"}, {Key: "key2"}}})
+		require.NoError(t, err)
+		assert.True(t, resp.Got)
+		require.Len(t, resp.Items, 2)
+		assert.Equal(t, "key1", resp.Items[0].Key)
+		assert.Equal(t, `"1"`, string(resp.Items[0].Data))
+		assert.Equal(t, `"2"`, string(resp.Items[1].Data))
+
+		_, err = client.BulkDelete(ctx, &components.BulkDeleteRequest{Items: []*components.DeleteRequest{{Key: "key1"}, {Key: "key2"}}})
+		require.NoError(t, err)
+		get, err := client.Get(ctx, &components.GetRequest{Key: "key1"})
+		require.NoError(t, err)
+		assert.Empty(t, get.Data)
+	})
+
+	t.Run("Ping", func(t *testing.T) {
+		client, store := newStateClient(t)
+		_, err := client.Ping(ctx, &components.PingRequest{})
+		assert.NoError(t, err)
+
+		store.Close()
+		_, err = client.Ping(ctx, &components.PingRequest{})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func TestTransactionalStateStore(t *testing.T) {
+	ctx := context.Background()
+	store := inmemory.NewInMemoryStateStore(logger.NewLogger("test"))
+	defer store.Close()
+	conn := serve(t, WithStateStore(store))
+	client := components.NewStateStoreClient(conn)
+	transactional := components.NewTransactionalStateStoreClient(conn)
+	_, err := client.Init(ctx, &components.InitRequest{Metadata: &components.MetadataRequest{}})
+	require.NoError(t, err)
+	_, err = client.Set(ctx, &components.SetRequest{Key: "deleted", Value: []byte(`"value"`)})
+	require.NoError(t, err)
+
+	_, err = transactional.Transact(ctx, &components.TransactionalStateRequest{Operations: []*components.TransactionalStateOperation{
+		{Request: &components.TransactionalStateOperation_Set{Set: &components.SetRequest{Key: "set", Value: []byte(`"value"`)}}},
+		{Request: &components.TransactionalStateOperation_Delete{Delete: &components.DeleteRequest{Key: "deleted"}}},
+	}})
+	require.NoError(t, err)
+
+	resp, err := client.Get(ctx, &components.GetRequest{Key: "set"})
+	require.NoError(t, err)
+	assert.Equal(t, `"value"`, string(resp.Data))
+	resp, err = client.Get(ctx, &components.GetRequest{Key: "deleted"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Data)
+
+	_, err = transactional.Transact(ctx, &components.TransactionalStateRequest{Operations: []*components.TransactionalStateOperation{{}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestQueriableStateStore(t *testing.T) {
+	ctx := context.Background()
+	store := &queriableStore{InMemory: inmemory.NewInMemoryStateStore(logger.NewLogger("test"))}
+	defer store.Close()
+	client := components.NewQueriableStateStoreClient(serve(t, WithStateStore(store)))
+
+	t.Run("Query", func(t *testing.T) {
+		resp, err := client.Query(ctx, &components.QueryRequest{Query: &components.Query{
+			Filter:     map[string]*any.Any{"EQ": {Value: []byte(`{"state": "WA"}`)}},
+			Sort:       []*components.Sorting{{Key: "state", Order: components.Sorting_DESC}},
+			Pagination: &components.Pagination{Limit: 10, Token: "previous"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, &query.EQ{Key: "state", Val: "WA"}, store.query.Filter)
+		assert.Equal(t, []query.Sorting{{Key: "state", Order: query.SortDESC}}, store.query.Sort)
+		assert.Equal(t, query.Pagination{Limit: 10, Token: "previous"}, store.query.Page)
+
+		assert.Equal(t, "next", resp.Token)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, "key", resp.Items[0].Key)
+		assert.Equal(t, "1", resp.Items[0].Etag.Value)
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		_, err := client.Query(ctx, &components.QueryRequest{Query: &components.Query{
+			Filter: map[string]*any.Any{"EQ": {Value: []byte(`not json`)}},
+		}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,107 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pluggable
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// errStreamClosed is the error of the messages whose stream is closed before they are acknowledged
+var errStreamClosed = errors.New("the stream was closed before the message was acknowledged")
+
+// ackStream sends the messages of a component to Dapr on a gRPC stream, on which Dapr acknowledges them once its
+// application handled them. The handlers of the components block in deliver until then, so that the components
+// only acknowledge the messages to their backend once the application handled them.
+type ackStream struct {
+	// sendLock serializes the sends, which gRPC streams don't support concurrently
+	sendLock sync.Mutex
+	// lock guards pending, the acknowledgements awaited by id, and err
+	lock    sync.Mutex
+	pending map[string]chan error
+	err     error
+	done    chan struct{}
+}
+
+func newAckStream() *ackStream {
+	return &ackStream{pending: map[string]chan error{}, done: make(chan struct{})}
+}
+
+// deliver sends a message with a new id, and returns the error of its acknowledgement
+func (s *ackStream) deliver(send func(id string) error) error {
+	id := uuid.New().String()
+	ack := make(chan error, 1)
+	s.lock.Lock()
+	s.pending[id] = ack
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		delete(s.pending, id)
+		s.lock.Unlock()
+	}()
+
+	s.sendLock.Lock()
+	err := send(id)
+	s.sendLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err := <-ack:
+		return err
+	case <-s.done:
+		return errStreamClosed
+	}
+}
+
+// ack acknowledges the message of the id, with the error of the application when it failed to handle it.
+// The acknowledgements of unknown messages, such as those whose deliver returned, are ignored.
+func (s *ackStream) ack(id string, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if ack, ok := s.pending[id]; ok {
+		ack <- err
+		delete(s.pending, id)
+	}
+}
+
+// close fails the messages that are not acknowledged yet, and the stream with err when it's not nil.
+// Closing a stream again has no effect.
+func (s *ackStream) close(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	select {
+	case <-s.done:
+	default:
+		s.err = err
+		close(s.done)
+	}
+}
+
+// closeErr returns the error the stream was closed with
+func (s *ackStream) closeErr() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.err
+}
+
+// ackError returns the error of a negative acknowledgement with message, or nil for a positive one
+func ackError(failed bool, message string) error {
+	if !failed {
+		return nil
+	}
+	if message == "" {
+		message = "the application failed to handle the message"
+	}
+
+	return errors.New(message)
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/google/uuid"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/dapr/dapr/pkg/logger"
@@ -18,6 +21,9 @@ import (
 	"github.com/dapr/components-contrib/pubsub"
 )
 
+// errMessageDelayed is the result of handling a message that is hidden until it is due
+var errMessageDelayed = errors.New("message delayed")
+
 type snsSqs struct {
 	// key is the topic name, value is the ARN of the topic
 	topics map[string]string
@@ -43,13 +49,13 @@ type snsSqsMetadata struct {
 
 	// aws endpoint for the component to use.
 	awsEndpoint string
-	// aws account ID to use for SNS/SQS. Required
+	// aws account ID to use for SNS/SQS. Without it the credentials are resolved through the default AWS credential chain
 	awsAccountID string
-	// aws secret corresponding to the account ID. Required
+	// aws secret corresponding to the account ID.
 	awsSecret string
-	// aws token to use. Required
+	// aws session token of temporary credentials.
 	awsToken string
-	// aws region in which SNS/SQS should create resources. Required
+	// aws region in which SNS/SQS should create resources. Without it the region of the AWS environment is used
 	awsRegion string
 
 	// amount of time in seconds that a message is hidden from receive requests after it is sent to a subscriber. Default: 10
@@ -60,11 +66,28 @@ type snsSqsMetadata struct {
 	messageWaitTimeSeconds int64
 	// maximum number of messsages to receive from the queue at a time. Default: 10, Maximum: 10
 	messageMaxNumber int64
+
+	// whether the topics and queues are FIFO, which deliver the messages of a message group in order and exactly once. Default: false
+	fifo bool
+	// message group of the published messages, unless a message has a partitionKey. Default: the consumerID
+	fifoMessageGroupID string
+	// name of the queue that messages are moved to once they were received messageRetryLimit times. Default: none, the messages are deleted
+	sqsDeadLettersQueueName string
 }
 
 const (
 	awsSqsQueueNameKey = "dapr-queue-name"
 	awsSnsTopicNameKey = "dapr-topic-name"
+
+	// FIFO topics and queues have names with this suffix
+	fifoSuffix = ".fifo"
+
+	// maxVisibilityTimeout is the longest time that a received message can be hidden for
+	maxVisibilityTimeout = 12 * time.Hour
+
+	// publish metadata
+	metadataPartitionKey           = "partitionKey"
+	metadataMessageDeduplicationID = "messageDeduplicationID"
 )
 
 func NewSnsSqs(l logger.Logger) pubsub.PubSub {
@@ -98,34 +121,15 @@ func (s *snsSqs) getSnsSqsMetatdata(metadata pubsub.Metadata) (*snsSqsMetadata,
 		md.awsEndpoint = val
 	}
 
-	val, ok := props["awsAccountID"]
-
-	if !ok {
-		return nil, errors.New("missing required property: awsAccountID")
+	// without static credentials, the default AWS credential chain is used
+	md.awsAccountID = props["awsAccountID"]
+	md.awsSecret = props["awsSecret"]
+	if (md.awsAccountID == "") != (md.awsSecret == "") {
+		return nil, errors.New("awsAccountID and awsSecret must be set together")
 	}
 
-	md.awsAccountID = val
-
-	val, ok = props["awsSecret"]
-	if !ok {
-		return nil, errors.New("missing required property: awsSecret")
-	}
-
-	md.awsSecret = val
-
-	val, ok = props["awsToken"]
-	if !ok {
-		md.awsToken = ""
-	} else {
-		md.awsToken = val
-	}
-
-	val, ok = props["awsRegion"]
-	if !ok {
-		return nil, errors.New("missing required property: awsRegion")
-	}
-
-	md.awsRegion = val
+	md.awsToken = props["awsToken"]
+	md.awsRegion = props["awsRegion"]
 
 	if val, ok := props["messageVisibilityTimeout"]; !ok {
 		md.messageVisibilityTimeout = 10
@@ -193,6 +197,25 @@ func (s *snsSqs) getSnsSqsMetatdata(metadata pubsub.Metadata) (*snsSqsMetadata,
 		md.messageMaxNumber = maxNumber
 	}
 
+	if val, ok := props["fifo"]; ok && val != "" {
+		fifo, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("parsing fifo failed with: %v", err)
+		}
+
+		md.fifo = fifo
+	}
+
+	md.fifoMessageGroupID = md.sqsQueueName
+	if val, ok := props["fifoMessageGroupID"]; ok && val != "" {
+		md.fifoMessageGroupID = val
+	}
+	if md.fifo && md.fifoMessageGroupID == "" {
+		return nil, errors.New("fifo requires a fifoMessageGroupID or a consumerID")
+	}
+
+	md.sqsDeadLettersQueueName = props["sqsDeadLettersQueueName"]
+
 	return &md, nil
 }
 
@@ -211,7 +234,13 @@ func (s *snsSqs) Init(metadata pubsub.Metadata) error {
 	s.topicHash = make(map[string]string)
 	s.queues = make(map[string]*sqsQueueInfo)
 	s.awsAcctID = md.awsAccountID
-	sess, err := aws_auth.GetClient(s.awsAcctID, md.awsSecret, md.awsRegion, md.awsEndpoint)
+	sess, err := aws_auth.GetClientWithOptions(aws_auth.ClientOptions{
+		AccessKey:    md.awsAccountID,
+		SecretKey:    md.awsSecret,
+		SessionToken: md.awsToken,
+		Region:       md.awsRegion,
+		Endpoint:     md.awsEndpoint,
+	})
 	if err != nil {
 		return err
 	}
@@ -220,12 +249,47 @@ func (s *snsSqs) Init(metadata pubsub.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the AWS SNS/SQS pub/sub
+func (s *snsSqs) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: "consumerID", Type: metadata.TypeString},
+			{Name: "awsEndpoint", Type: metadata.TypeString},
+			{Name: "awsAccountID", Type: metadata.TypeString},
+			{Name: "awsSecret", Type: metadata.TypeString},
+			{Name: "awsToken", Type: metadata.TypeString},
+			{Name: "awsRegion", Type: metadata.TypeString},
+			{Name: "messageVisibilityTimeout", Type: metadata.TypeInt, Default: "10"},
+			{Name: "messageRetryLimit", Type: metadata.TypeInt, Default: "10"},
+			{Name: "messageWaitTimeSeconds", Type: metadata.TypeInt, Default: "1"},
+			{Name: "messageMaxNumber", Type: metadata.TypeInt, Default: "10"},
+			{Name: "fifo", Type: metadata.TypeBool},
+			{Name: "fifoMessageGroupID", Type: metadata.TypeString},
+			{Name: "sqsDeadLettersQueueName", Type: metadata.TypeString},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityTTL},
+	}
+}
+
+// resourceName returns the name of the topic or queue in AWS, which is hashed and has the suffix of FIFO resources
+func (s *snsSqs) resourceName(name string) string {
+	if s.metadata.fifo {
+		return nameToHash(name) + fifoSuffix
+	}
+
+	return nameToHash(name)
+}
+
 func (s *snsSqs) createTopic(topic string) (string, string, error) {
-	hashedName := nameToHash(topic)
-	createTopicResponse, err := s.snsClient.CreateTopic(&sns.CreateTopicInput{
+	hashedName := s.resourceName(topic)
+	input := &sns.CreateTopicInput{
 		Name: aws.String(hashedName),
 		Tags: []*sns.Tag{{Key: aws.String(awsSnsTopicNameKey), Value: aws.String(topic)}},
-	})
+	}
+	if s.metadata.fifo {
+		input.Attributes = map[string]*string{"FifoTopic": aws.String("true")}
+	}
+	createTopicResponse, err := s.snsClient.CreateTopic(input)
 
 	if err != nil {
 		return "", "", err
@@ -261,10 +325,14 @@ func (s *snsSqs) getOrCreateTopic(topic string) (string, error) {
 }
 
 func (s *snsSqs) createQueue(queueName string) (*sqsQueueInfo, error) {
-	createQueueResponse, err := s.sqsClient.CreateQueue(&sqs.CreateQueueInput{
-		QueueName: aws.String(nameToHash(queueName)),
+	input := &sqs.CreateQueueInput{
+		QueueName: aws.String(s.resourceName(queueName)),
 		Tags:      map[string]*string{awsSqsQueueNameKey: aws.String(queueName)},
-	})
+	}
+	if s.metadata.fifo {
+		input.Attributes = map[string]*string{sqs.QueueAttributeNameFifoQueue: aws.String("true")}
+	}
+	createQueueResponse, err := s.sqsClient.CreateQueue(input)
 
 	if err != nil {
 		return nil, err
@@ -277,6 +345,7 @@ func (s *snsSqs) createQueue(queueName string) (*sqsQueueInfo, error) {
 
 	if err != nil {
 		s.logger.Errorf("error fetching queue attributes for %s: %v", queueName, err)
+		return nil, err
 	}
 
 	// add permissions to allow SNS to send messages to this queue
@@ -326,18 +395,61 @@ func (s *snsSqs) getOrCreateQueue(queueName string) (*sqsQueueInfo, error) {
 	return queueInfo, nil
 }
 
+// setDeadLettersQueue makes SQS move the messages of the queue that were received messageRetryLimit times to the dead letters queue
+func (s *snsSqs) setDeadLettersQueue(queueInfo *sqsQueueInfo, deadLettersQueueInfo *sqsQueueInfo) error {
+	redrivePolicy, err := json.Marshal(map[string]string{
+		"deadLetterTargetArn": deadLettersQueueInfo.arn,
+		"maxReceiveCount":     strconv.FormatInt(s.metadata.messageRetryLimit, 10),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.sqsClient.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+		Attributes: map[string]*string{
+			sqs.QueueAttributeNameRedrivePolicy: aws.String(string(redrivePolicy)),
+		},
+		QueueUrl: &queueInfo.url,
+	})
+
+	return err
+}
+
 func (s *snsSqs) Publish(req *pubsub.PublishRequest) error {
 	topicArn, err := s.getOrCreateTopic(req.Topic)
 
 	if err != nil {
 		s.logger.Errorf("error getting topic ARN for %s: %v", req.Topic, err)
+		return err
 	}
 
 	message := string(req.Data)
-	_, err = s.snsClient.Publish(&sns.PublishInput{
+	input := &sns.PublishInput{
 		Message:  &message,
 		TopicArn: &topicArn,
-	})
+	}
+
+	// the messages of FIFO topics have a group, in which they are delivered in order, and are deduplicated by their ID
+	if s.metadata.fifo {
+		groupID := s.metadata.fifoMessageGroupID
+		if val, ok := req.Metadata[metadataPartitionKey]; ok && val != "" {
+			groupID = val
+		}
+		deduplicationID := uuid.New().String()
+		if val, ok := req.Metadata[metadataMessageDeduplicationID]; ok && val != "" {
+			deduplicationID = val
+		}
+
+		input.MessageGroupId = &groupID
+		input.MessageDeduplicationId = &deduplicationID
+	}
+
+	input.MessageAttributes, err = messageAttributes(req, time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.snsClient.Publish(input)
 
 	if err != nil {
 		s.logger.Errorf("error publishing topic %s with topic ARN %s: %v", req.Topic, topicArn, err)
@@ -347,9 +459,63 @@ func (s *snsSqs) Publish(req *pubsub.PublishRequest) error {
 	return nil
 }
 
+// messageAttributes returns the attributes of the expiration and the delivery time of a message. The retention period
+// and the delivery delay of SQS are per queue, or per message sent to a queue directly, so the messages published to a
+// topic with a TTL or a delay have their expiration or delivery time as an attribute. Expired messages are deleted
+// instead of handled, and messages that are not due are hidden until they are.
+func messageAttributes(req *pubsub.PublishRequest, now time.Time) (map[string]*sns.MessageAttributeValue, error) {
+	attributes := map[string]*sns.MessageAttributeValue{}
+
+	ttl, ok, err := pubsub.TryGetTTL(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		attributes[pubsub.ExpirationMetadataKey] = &sns.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(pubsub.Expiration(ttl, now)),
+		}
+	}
+
+	delay, ok, err := pubsub.TryGetDeliveryDelay(req.Metadata, now)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		attributes[pubsub.DeliverAtMetadataKey] = &sns.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(now.Add(delay).UTC().Format(time.RFC3339)),
+		}
+	}
+
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+	return attributes, nil
+}
+
 type snsMessage struct {
-	Message  string
-	TopicArn string
+	Message           string
+	TopicArn          string
+	MessageAttributes map[string]snsMessageAttribute
+}
+
+type snsMessageAttribute struct {
+	Type  string
+	Value string
+}
+
+// metadata returns the message attributes of the notification
+func (m *snsMessage) metadata() map[string]string {
+	if len(m.MessageAttributes) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(m.MessageAttributes))
+	for k, v := range m.MessageAttributes {
+		metadata[k] = v.Value
+	}
+	return metadata
 }
 
 func parseTopicArn(arn string) string {
@@ -380,9 +546,8 @@ func (s *snsSqs) handleMessage(message *sqs.Message, queueInfo *sqsQueueInfo, ha
 		return fmt.Errorf("error parsing ApproximateReceiveCount from message: %v", message)
 	}
 
-	// if we are over the allowable retry limit, delete the message from the queue
-	// TODO dead letter queue
-	if recvCountInt >= s.metadata.messageRetryLimit {
+	// if we are over the allowable retry limit, delete the message from the queue, unless SQS moves it to the dead letters queue
+	if recvCountInt >= s.metadata.messageRetryLimit && s.metadata.sqsDeadLettersQueueName == "" {
 		if innerErr := s.acknowledgeMessage(queueInfo.url, message.ReceiptHandle); innerErr != nil {
 			return fmt.Errorf("error acknowledging message after receiving the message too many times: %v", innerErr)
 		}
@@ -401,9 +566,29 @@ func (s *snsSqs) handleMessage(message *sqs.Message, queueInfo *sqsQueueInfo, ha
 
 	topic := parseTopicArn(messageBody.TopicArn)
 	topic = s.topicHash[topic]
+	metadata := messageBody.metadata()
+	now := time.Now()
+	if delay := deliveryDelay(metadata, now); delay > 0 {
+		s.logger.Debugf("delaying message from topic %s by %s", topic, delay)
+		_, err = s.sqsClient.ChangeMessageVisibility(&sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          &queueInfo.url,
+			ReceiptHandle:     message.ReceiptHandle,
+			VisibilityTimeout: aws.Int64(int64(delay / time.Second)),
+		})
+		if err != nil {
+			return fmt.Errorf("error delaying message: %v", err)
+		}
+		return errMessageDelayed
+	}
+	if pubsub.HasExpired(metadata, now) {
+		s.logger.Debugf("deleting expired message from topic %s", topic)
+		return s.acknowledgeMessage(queueInfo.url, message.ReceiptHandle)
+	}
+
 	err = handler(&pubsub.NewMessage{
-		Data:  []byte(messageBody.Message),
-		Topic: topic,
+		Data:     []byte(messageBody.Message),
+		Topic:    topic,
+		Metadata: metadata,
 	})
 
 	if err != nil {
@@ -414,6 +599,29 @@ func (s *snsSqs) handleMessage(message *sqs.Message, queueInfo *sqsQueueInfo, ha
 	return s.acknowledgeMessage(queueInfo.url, message.ReceiptHandle)
 }
 
+// deliveryDelay returns how long a message with a deliverAt attribute is hidden until it is due, in whole seconds up to
+// the maximum visibility timeout. Messages whose delivery time is less than a second away are delivered right away.
+// Messages are received once more every time they are hidden, which counts towards their retry limit.
+func deliveryDelay(metadata map[string]string, now time.Time) time.Duration {
+	val, ok := metadata[pubsub.DeliverAtMetadataKey]
+	if !ok {
+		return 0
+	}
+	deliverAt, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return 0
+	}
+
+	delay := deliverAt.Sub(now).Truncate(time.Second)
+	switch {
+	case delay < 0:
+		return 0
+	case delay > maxVisibilityTimeout:
+		return maxVisibilityTimeout
+	}
+	return delay
+}
+
 func (s *snsSqs) consumeSubscription(queueInfo *sqsQueueInfo, handler func(msg *pubsub.NewMessage) error) {
 	go func() {
 		for {
@@ -421,6 +629,7 @@ func (s *snsSqs) consumeSubscription(queueInfo *sqsQueueInfo, handler func(msg *
 				// use this property to decide when a message should be discarded
 				AttributeNames: []*string{
 					aws.String(sqs.MessageSystemAttributeNameApproximateReceiveCount),
+					aws.String(sqs.MessageSystemAttributeNameMessageGroupId),
 				},
 				MaxNumberOfMessages: aws.Int64(s.metadata.messageMaxNumber),
 				QueueUrl:            &queueInfo.url,
@@ -441,9 +650,19 @@ func (s *snsSqs) consumeSubscription(queueInfo *sqsQueueInfo, handler func(msg *
 
 			s.logger.Debugf("%v message(s) received", len(messageResponse.Messages))
 
+			// the messages of a FIFO group after one that failed are left to be received again after it, to keep their order
+			failedGroups := map[string]bool{}
 			for _, m := range messageResponse.Messages {
+				groupID := aws.StringValue(m.Attributes[sqs.MessageSystemAttributeNameMessageGroupId])
+				if s.metadata.fifo && failedGroups[groupID] {
+					continue
+				}
+
 				if err := s.handleMessage(m, queueInfo, handler); err != nil {
-					s.logger.Error(err)
+					if err != errMessageDelayed {
+						s.logger.Error(err)
+					}
+					failedGroups[groupID] = true
 				}
 			}
 		}
@@ -470,6 +689,20 @@ func (s *snsSqs) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub
 		return err
 	}
 
+	if s.metadata.sqsDeadLettersQueueName != "" {
+		deadLettersQueueInfo, err := s.getOrCreateQueue(s.metadata.sqsDeadLettersQueueName)
+		if err != nil {
+			s.logger.Errorf("error retrieving SQS dead letters queue: %v", err)
+			return err
+		}
+
+		err = s.setDeadLettersQueue(queueInfo, deadLettersQueueInfo)
+		if err != nil {
+			s.logger.Errorf("error setting dead letters queue of SQS queue: %v", err)
+			return err
+		}
+	}
+
 	// subscription creation is idempotent. Subscriptions are unique by topic/queue
 	subscribeOutput, err := s.snsClient.Subscribe(&sns.SubscribeInput{
 		Attributes:            nil,
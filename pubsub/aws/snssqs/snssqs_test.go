@@ -1,9 +1,11 @@
 package snssqs
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
@@ -191,6 +193,90 @@ func Test_getSnsSqsMetatdata_invalidMessageMaxNumberTooLow(t *testing.T) {
 	r.Nil(md)
 }
 
+func Test_getSnsSqsMetatdata_fifoAndDeadLettersQueue(t *testing.T) {
+	r := require.New(t)
+	l := logger.NewLogger("SnsSqs unit test")
+	l.SetOutputLevel(logger.DebugLevel)
+	ps := snsSqs{
+		logger: l,
+	}
+
+	md, err := ps.getSnsSqsMetatdata(pubsub.Metadata{Properties: map[string]string{
+		"consumerID":              "consumer",
+		"fifo":                    "true",
+		"sqsDeadLettersQueueName": "dead-letters",
+	}})
+
+	r.NoError(err)
+
+	r.True(md.fifo)
+	r.Equal("consumer", md.fifoMessageGroupID)
+	r.Equal("dead-letters", md.sqsDeadLettersQueueName)
+	// credentials and region are resolved by the AWS environment
+	r.Equal("", md.awsAccountID)
+	r.Equal("", md.awsRegion)
+
+	md, err = ps.getSnsSqsMetatdata(pubsub.Metadata{Properties: map[string]string{
+		"consumerID":         "consumer",
+		"fifo":               "true",
+		"fifoMessageGroupID": "group",
+	}})
+
+	r.NoError(err)
+	r.Equal("group", md.fifoMessageGroupID)
+}
+
+func Test_getSnsSqsMetatdata_invalidFifo(t *testing.T) {
+	r := require.New(t)
+	l := logger.NewLogger("SnsSqs unit test")
+	l.SetOutputLevel(logger.DebugLevel)
+	ps := snsSqs{
+		logger: l,
+	}
+
+	md, err := ps.getSnsSqsMetatdata(pubsub.Metadata{Properties: map[string]string{
+		"consumerID": "consumer",
+		"fifo":       "maybe",
+	}})
+
+	r.Error(err)
+	r.Nil(md)
+
+	md, err = ps.getSnsSqsMetatdata(pubsub.Metadata{Properties: map[string]string{
+		"fifo": "true",
+	}})
+
+	r.Error(err)
+	r.Nil(md)
+}
+
+func Test_getSnsSqsMetatdata_secretWithoutAccountID(t *testing.T) {
+	r := require.New(t)
+	l := logger.NewLogger("SnsSqs unit test")
+	l.SetOutputLevel(logger.DebugLevel)
+	ps := snsSqs{
+		logger: l,
+	}
+
+	md, err := ps.getSnsSqsMetatdata(pubsub.Metadata{Properties: map[string]string{
+		"consumerID": "consumer",
+		"awsSecret":  "secret",
+	}})
+
+	r.Error(err)
+	r.Nil(md)
+}
+
+func Test_resourceName(t *testing.T) {
+	r := require.New(t)
+
+	ps := snsSqs{metadata: &snsSqsMetadata{}}
+	r.Equal(nameToHash("topic"), ps.resourceName("topic"))
+
+	ps.metadata.fifo = true
+	r.Equal(nameToHash("topic")+".fifo", ps.resourceName("topic"))
+}
+
 func Test_parseInt64(t *testing.T) {
 	r := require.New(t)
 	number, err := parseInt64("applesauce", "propertyName")
@@ -208,6 +294,52 @@ func Test_parseInt64(t *testing.T) {
 	r.Error(err)
 }
 
+func Test_snsMessageMetadata(t *testing.T) {
+	r := require.New(t)
+
+	var message snsMessage
+	err := json.Unmarshal([]byte(`{
+		"Message": "data",
+		"TopicArn": "arn:aws:sns:us-east-1:000000000000:topic",
+		"MessageAttributes": {"expiration": {"Type": "String", "Value": "2020-11-30T12:00:00Z"}}
+	}`), &message)
+	r.NoError(err)
+	r.Equal(map[string]string{pubsub.ExpirationMetadataKey: "2020-11-30T12:00:00Z"}, message.metadata())
+
+	r.Nil((&snsMessage{Message: "data"}).metadata())
+}
+
+func Test_messageAttributes(t *testing.T) {
+	r := require.New(t)
+	now := time.Date(2020, 11, 30, 12, 0, 0, 0, time.UTC)
+
+	attributes, err := messageAttributes(&pubsub.PublishRequest{Metadata: map[string]string{
+		pubsub.TTLMetadataKey:          "60",
+		pubsub.DeliverAfterMetadataKey: "30s",
+	}}, now)
+	r.NoError(err)
+	r.Len(attributes, 2)
+	r.Equal("2020-11-30T12:01:00Z", *attributes[pubsub.ExpirationMetadataKey].StringValue)
+	r.Equal("2020-11-30T12:00:30Z", *attributes[pubsub.DeliverAtMetadataKey].StringValue)
+
+	attributes, err = messageAttributes(&pubsub.PublishRequest{}, now)
+	r.NoError(err)
+	r.Nil(attributes)
+
+	_, err = messageAttributes(&pubsub.PublishRequest{Metadata: map[string]string{pubsub.DeliverAfterMetadataKey: "soon"}}, now)
+	r.Error(err)
+}
+
+func Test_deliveryDelay(t *testing.T) {
+	r := require.New(t)
+	now := time.Date(2020, 11, 30, 12, 0, 0, 0, time.UTC)
+
+	r.Equal(time.Duration(0), deliveryDelay(nil, now))
+	r.Equal(time.Duration(0), deliveryDelay(map[string]string{pubsub.DeliverAtMetadataKey: "2020-11-30T11:00:00Z"}, now))
+	r.Equal(30*time.Second, deliveryDelay(map[string]string{pubsub.DeliverAtMetadataKey: "2020-11-30T12:00:30Z"}, now))
+	r.Equal(maxVisibilityTimeout, deliveryDelay(map[string]string{pubsub.DeliverAtMetadataKey: "2020-12-30T12:00:00Z"}, now))
+}
+
 func Test_nameToHash(t *testing.T) {
 	r := require.New(t)
 
@@ -0,0 +1,86 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package eventhubs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-amqp-common-go/aad"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const (
+	// eventHubsResource and storageResource are the resources that Azure AD tokens are issued for
+	eventHubsResource = "https://eventhubs.azure.net/"
+	storageResource   = "https://storage.azure.com/"
+
+	// tokenRefreshMargin is how long before their expiry the storage tokens are refreshed
+	tokenRefreshMargin = 5 * time.Minute
+	tokenRetryInterval = 30 * time.Second
+)
+
+// newManagedIdentityToken returns a token for the resource of the system assigned identity, or of the user assigned
+// identity with clientID. The token is refreshed before it is returned, so that it can be used right away.
+func newManagedIdentityToken(resource, clientID string) (*adal.ServicePrincipalToken, error) {
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	var spt *adal.ServicePrincipalToken
+	if clientID == "" {
+		spt, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, resource)
+	} else {
+		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, resource, clientID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create managed identity token: %s", err)
+	}
+
+	err = spt.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed identity token for %s: %s", resource, err)
+	}
+
+	return spt, nil
+}
+
+// newTokenProvider returns the provider of the Event Hubs tokens of the managed identity
+func newTokenProvider(clientID string) (*aad.TokenProvider, error) {
+	spt, err := newManagedIdentityToken(eventHubsResource, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return aad.NewJWTProvider(aad.JWTProviderWithAADToken(spt))
+}
+
+// newStorageTokenCredential returns the credential of the checkpoint storage with tokens of the managed identity,
+// which are refreshed before they expire
+func newStorageTokenCredential(clientID string, logger logger.Logger) (azblob.TokenCredential, error) {
+	spt, err := newManagedIdentityToken(storageResource, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	return azblob.NewTokenCredential(spt.OAuthToken(), func(credential azblob.TokenCredential) time.Duration {
+		err := spt.EnsureFresh()
+		if err != nil {
+			logger.Errorf("error refreshing the storage token, retrying in %s: %s", tokenRetryInterval, err)
+			return tokenRetryInterval
+		}
+
+		credential.SetToken(spt.OAuthToken())
+		refresh := time.Until(spt.Token().Expires()) - tokenRefreshMargin
+		if refresh < tokenRetryInterval {
+			return tokenRetryInterval
+		}
+		return refresh
+	}), nil
+}
@@ -0,0 +1,87 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package eventhubs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eventhub "github.com/Azure/azure-event-hubs-go"
+)
+
+// batcher sends the events published concurrently in batches of up to maxSize events, which are sent when they are
+// full or maxWait after their first event. Events are batched by partition key, since a batch has a single one.
+type batcher struct {
+	send    func(ctx context.Context, batch *eventhub.EventBatch) error
+	maxSize int
+	maxWait time.Duration
+
+	pending map[string]*pendingBatch
+	lock    sync.Mutex
+}
+
+// pendingBatch is a batch that was not sent yet, and its result once it was
+type pendingBatch struct {
+	events       []*eventhub.Event
+	partitionKey *string
+	timer        *time.Timer
+	once         sync.Once
+	done         chan struct{}
+	err          error
+}
+
+func newBatcher(send func(ctx context.Context, batch *eventhub.EventBatch) error, maxSize int, maxWait time.Duration) *batcher {
+	return &batcher{send: send, maxSize: maxSize, maxWait: maxWait, pending: map[string]*pendingBatch{}}
+}
+
+// add adds the event to the batch of its partition key, and returns the result of sending the batch
+func (b *batcher) add(event *eventhub.Event) error {
+	var key string
+	if event.PartitionKey != nil {
+		key = *event.PartitionKey
+	}
+
+	b.lock.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &pendingBatch{partitionKey: event.PartitionKey, done: make(chan struct{})}
+		batch.timer = time.AfterFunc(b.maxWait, func() {
+			b.flush(key, batch)
+		})
+		b.pending[key] = batch
+	}
+	batch.events = append(batch.events, event)
+	full := len(batch.events) >= b.maxSize
+	if full {
+		delete(b.pending, key)
+	}
+	b.lock.Unlock()
+
+	if full {
+		b.flush(key, batch)
+	}
+
+	<-batch.done
+	return batch.err
+}
+
+// flush sends the batch once, whether it is full or its wait is over
+func (b *batcher) flush(key string, batch *pendingBatch) {
+	batch.once.Do(func() {
+		b.lock.Lock()
+		batch.timer.Stop()
+		if b.pending[key] == batch {
+			delete(b.pending, key)
+		}
+		b.lock.Unlock()
+
+		eventBatch := eventhub.NewEventBatch(batch.events)
+		eventBatch.PartitionKey = batch.partitionKey
+		batch.err = b.send(context.Background(), eventBatch)
+		close(batch.done)
+	})
+}
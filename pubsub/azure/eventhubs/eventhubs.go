@@ -9,12 +9,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/Azure/azure-amqp-common-go/auth"
 	eventhub "github.com/Azure/azure-event-hubs-go"
 	"github.com/Azure/azure-event-hubs-go/eph"
 	"github.com/Azure/azure-event-hubs-go/storage"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
 )
@@ -24,14 +28,34 @@ const (
 
 	connectionString = "connectionString"
 	consumerID       = "consumerID" // passed by dapr runtime
+	// used with the managed identity instead of a connection string
+	eventHubNamespace = "eventHubNamespace"
+	eventHubName      = "eventHub"
+	azureClientID     = "azureClientId"
 	// required by subscriber
 	storageAccountName   = "storageAccountName"
 	storageAccountKey    = "storageAccountKey"
 	storageContainerName = "storageContainerName"
+	// optional
+	publishBatchSize = "publishBatchSize"
+	publishBatchWait = "publishBatchWait"
+
+	// publish metadata
+	partitionKey = "partitionKey"
+
+	// message metadata
+	partitionIDMetadata    = "partitionID"
+	sequenceNumberMetadata = "sequenceNumber"
+
+	defaultPublishBatchSize = 1
+	defaultPublishBatchWait = 100 * time.Millisecond
+	// maxPublishBatchSize keeps batches of small events below the size limit of a batch
+	maxPublishBatchSize  = 1000
+	handlerRetryInterval = time.Second
 
 	// errors
 
-	missingConnectionStringErrorMsg     = "error: connectionString is a required attribute"
+	missingConnectionStringErrorMsg     = "error: connectionString or eventHubNamespace and eventHub are required attributes"
 	missingStorageAccountNameErrorMsg   = "error: storageAccountName is a required attribute"
 	missingStorageAccountKeyErrorMsg    = "error: storageAccountKey is a required attribute"
 	missingStorageContainerNameErrorMsg = "error: storageContainerName is a required attribute"
@@ -42,16 +66,24 @@ const (
 type AzureEventHubs struct {
 	hub      *eventhub.Hub
 	metadata azureEventHubsMetadata
+	// tokenProvider authenticates with the managed identity when there is no connection string
+	tokenProvider auth.TokenProvider
+	batcher       *batcher
 
 	logger logger.Logger
 }
 
 type azureEventHubsMetadata struct {
 	connectionString     string
+	eventHubNamespace    string
+	eventHubName         string
+	clientID             string
 	consumerGroup        string
 	storageAccountName   string
 	storageAccountKey    string
 	storageContainerName string
+	publishBatchSize     int
+	publishBatchWait     time.Duration
 }
 
 // NewAzureEventHubs returns a new Azure Event hubs instance
@@ -60,11 +92,13 @@ func NewAzureEventHubs(logger logger.Logger) *AzureEventHubs {
 }
 
 func parseEventHubsMetadata(meta pubsub.Metadata) (azureEventHubsMetadata, error) {
-	m := azureEventHubsMetadata{}
+	m := azureEventHubsMetadata{publishBatchSize: defaultPublishBatchSize, publishBatchWait: defaultPublishBatchWait}
 
-	if val, ok := meta.Properties[connectionString]; ok && val != "" {
-		m.connectionString = val
-	} else {
+	m.connectionString = meta.Properties[connectionString]
+	m.eventHubNamespace = meta.Properties[eventHubNamespace]
+	m.eventHubName = meta.Properties[eventHubName]
+	m.clientID = meta.Properties[azureClientID]
+	if m.connectionString == "" && (m.eventHubNamespace == "" || m.eventHubName == "") {
 		return m, errors.New(missingConnectionStringErrorMsg)
 	}
 
@@ -74,9 +108,10 @@ func parseEventHubsMetadata(meta pubsub.Metadata) (azureEventHubsMetadata, error
 		return m, errors.New(missingStorageAccountNameErrorMsg)
 	}
 
+	// Without a connection string, the checkpoint storage can also be accessed with the managed identity
 	if val, ok := meta.Properties[storageAccountKey]; ok && val != "" {
 		m.storageAccountKey = val
-	} else {
+	} else if m.connectionString != "" {
 		return m, errors.New(missingStorageAccountKeyErrorMsg)
 	}
 
@@ -92,38 +127,146 @@ func parseEventHubsMetadata(meta pubsub.Metadata) (azureEventHubsMetadata, error
 		return m, errors.New(missingConsumerIDErrorMsg)
 	}
 
+	if val, ok := meta.Properties[publishBatchSize]; ok && val != "" {
+		size, err := strconv.Atoi(val)
+		if err != nil || size < 1 || size > maxPublishBatchSize {
+			return m, fmt.Errorf("error: invalid publishBatchSize %s, it must be between 1 and %d", val, maxPublishBatchSize)
+		}
+		m.publishBatchSize = size
+	}
+
+	if val, ok := meta.Properties[publishBatchWait]; ok && val != "" {
+		wait, err := time.ParseDuration(val)
+		if err != nil || wait <= 0 {
+			return m, fmt.Errorf("error: invalid publishBatchWait %s", val)
+		}
+		m.publishBatchWait = wait
+	}
+
 	return m, nil
 }
 
-// Init connects to Azure Event Hubs
+// Init connects to Azure Event Hubs, with the connection string or with the managed identity
 func (aeh *AzureEventHubs) Init(metadata pubsub.Metadata) error {
 	m, err := parseEventHubsMetadata(metadata)
 	if err != nil {
 		return err
 	}
 	aeh.metadata = m
-	hub, err := eventhub.NewHubFromConnectionString(aeh.metadata.connectionString)
 
+	var hub *eventhub.Hub
+	if m.connectionString != "" {
+		hub, err = eventhub.NewHubFromConnectionString(m.connectionString)
+	} else {
+		aeh.tokenProvider, err = newTokenProvider(m.clientID)
+		if err != nil {
+			return fmt.Errorf("unable to authenticate to azure event hubs: %v", err)
+		}
+		hub, err = eventhub.NewHub(m.eventHubNamespace, m.eventHubName, aeh.tokenProvider)
+	}
 	if err != nil {
 		return fmt.Errorf("unable to connect to azure event hubs: %v", err)
 	}
 
 	aeh.hub = hub
+	if m.publishBatchSize > 1 {
+		aeh.batcher = newBatcher(func(ctx context.Context, batch *eventhub.EventBatch) error {
+			return aeh.hub.SendBatch(ctx, batch)
+		}, m.publishBatchSize, m.publishBatchWait)
+	}
 	return nil
 }
 
-// Publish sends data to Azure Event Hubs
+// GetComponentMetadata returns the metadata fields of the Azure Event Hubs pub/sub
+func (aeh *AzureEventHubs) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionString, Type: metadata.TypeString},
+			{Name: eventHubNamespace, Type: metadata.TypeString},
+			{Name: eventHubName, Type: metadata.TypeString},
+			{Name: azureClientID, Type: metadata.TypeString},
+			{Name: storageAccountName, Type: metadata.TypeString, Required: true},
+			{Name: storageAccountKey, Type: metadata.TypeString},
+			{Name: storageContainerName, Type: metadata.TypeString, Required: true},
+			{Name: consumerID, Type: metadata.TypeString, Required: true},
+			{Name: publishBatchSize, Type: metadata.TypeInt, Default: strconv.Itoa(defaultPublishBatchSize)},
+			{Name: publishBatchWait, Type: metadata.TypeDuration, Default: defaultPublishBatchWait.String()},
+		},
+	}
+}
+
+// Publish sends data to Azure Event Hubs. The events with the same partitionKey metadata are sent to the same partition.
+// With a publishBatchSize, the events published concurrently are sent in batches.
 func (aeh *AzureEventHubs) Publish(req *pubsub.PublishRequest) error {
-	err := aeh.hub.Send(context.Background(), &eventhub.Event{Data: req.Data})
+	event := &eventhub.Event{Data: req.Data}
+	if val, ok := req.Metadata[partitionKey]; ok && val != "" {
+		event.PartitionKey = &val
+	}
+
+	var err error
+	if aeh.batcher != nil {
+		err = aeh.batcher.add(event)
+	} else {
+		err = aeh.hub.Send(context.Background(), event)
+	}
 	if err != nil {
 		return fmt.Errorf("error from publish: %s", err)
 	}
 	return nil
 }
 
-// Subscribe receives data from Azure Event Hubs
+// BulkPublish sends the entries to Azure Event Hubs in a batch per partition key, since a batch has a single one
+func (aeh *AzureEventHubs) BulkPublish(req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error) {
+	return bulkPublish(req, func(ctx context.Context, batch *eventhub.EventBatch) error {
+		return aeh.hub.SendBatch(ctx, batch)
+	})
+}
+
+func bulkPublish(req *pubsub.BulkPublishRequest, send func(ctx context.Context, batch *eventhub.EventBatch) error) (pubsub.BulkPublishResponse, error) {
+	var keys []string
+	events := map[string][]*eventhub.Event{}
+	entryIDs := map[string][]string{}
+	for _, entry := range req.Entries {
+		entryReq := req.EntryRequest(entry)
+		key := entryReq.Metadata[partitionKey]
+		if _, ok := events[key]; !ok {
+			keys = append(keys, key)
+		}
+		event := &eventhub.Event{Data: entryReq.Data}
+		if key != "" {
+			event.PartitionKey = &key
+		}
+		events[key] = append(events[key], event)
+		entryIDs[key] = append(entryIDs[key], entry.EntryID)
+	}
+
+	res := pubsub.BulkPublishResponse{}
+	for _, key := range keys {
+		batch := eventhub.NewEventBatch(events[key])
+		batch.PartitionKey = events[key][0].PartitionKey
+
+		err := send(context.Background(), batch)
+		if err != nil {
+			for _, entryID := range entryIDs[key] {
+				res.FailedEntries = append(res.FailedEntries, pubsub.BulkEntryStatus{EntryID: entryID, Error: err})
+			}
+		}
+	}
+
+	return res, pubsub.NewBulkPublishError(res, len(req.Entries))
+}
+
+// Subscribe receives data from Azure Event Hubs. The partitions are balanced over the subscribers of the consumer group
+// with leases in Azure Blob Storage, where the position in each partition is checkpointed. The events of a partition
+// are handled in order, and an event is retried until it is handled so that the checkpoint does not pass it.
 func (aeh *AzureEventHubs) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
-	cred, err := azblob.NewSharedKeyCredential(aeh.metadata.storageAccountName, aeh.metadata.storageAccountKey)
+	var cred azblob.Credential
+	var err error
+	if aeh.metadata.storageAccountKey != "" {
+		cred, err = azblob.NewSharedKeyCredential(aeh.metadata.storageAccountName, aeh.metadata.storageAccountKey)
+	} else {
+		cred, err = newStorageTokenCredential(aeh.metadata.clientID, aeh.logger)
+	}
 	if err != nil {
 		return err
 	}
@@ -133,15 +276,33 @@ func (aeh *AzureEventHubs) Subscribe(req pubsub.SubscribeRequest, handler func(m
 		return err
 	}
 
-	processor, err := eph.NewFromConnectionString(context.Background(), aeh.metadata.connectionString, leaserCheckpointer, leaserCheckpointer, eph.WithNoBanner(), eph.WithConsumerGroup(aeh.metadata.consumerGroup))
-
+	var processor *eph.EventProcessorHost
+	opts := []eph.EventProcessorHostOption{eph.WithNoBanner(), eph.WithConsumerGroup(aeh.metadata.consumerGroup)}
+	if aeh.metadata.connectionString != "" {
+		processor, err = eph.NewFromConnectionString(context.Background(), aeh.metadata.connectionString, leaserCheckpointer, leaserCheckpointer, opts...)
+	} else {
+		processor, err = eph.New(context.Background(), aeh.metadata.eventHubNamespace, aeh.metadata.eventHubName, aeh.tokenProvider, leaserCheckpointer, leaserCheckpointer, opts...)
+	}
 	if err != nil {
 		return err
 	}
 
 	_, err = processor.RegisterHandler(context.Background(),
 		func(c context.Context, e *eventhub.Event) error {
-			return handler(&pubsub.NewMessage{Data: e.Data, Topic: req.Topic})
+			msg := &pubsub.NewMessage{Data: e.Data, Topic: req.Topic, Metadata: eventMetadata(e)}
+			for {
+				err := handler(msg)
+				if err == nil {
+					return nil
+				}
+				aeh.logger.Errorf("error handling event from partition %s, retrying in %s: %s", msg.Metadata[partitionIDMetadata], handlerRetryInterval, err)
+
+				select {
+				case <-c.Done():
+					return err
+				case <-time.After(handlerRetryInterval):
+				}
+			}
 		})
 	if err != nil {
 		return err
@@ -154,3 +315,23 @@ func (aeh *AzureEventHubs) Subscribe(req pubsub.SubscribeRequest, handler func(m
 
 	return nil
 }
+
+// eventMetadata returns the partition, partition key and sequence number of a received event
+func eventMetadata(e *eventhub.Event) map[string]string {
+	metadata := map[string]string{}
+	if e.SystemProperties == nil {
+		return metadata
+	}
+
+	if e.SystemProperties.PartitionID != nil {
+		metadata[partitionIDMetadata] = strconv.Itoa(int(*e.SystemProperties.PartitionID))
+	}
+	if e.SystemProperties.PartitionKey != nil {
+		metadata[partitionKey] = *e.SystemProperties.PartitionKey
+	}
+	if e.SystemProperties.SequenceNumber != nil {
+		metadata[sequenceNumberMetadata] = strconv.FormatInt(*e.SystemProperties.SequenceNumber, 10)
+	}
+
+	return metadata
+}
@@ -6,8 +6,13 @@
 package eventhubs
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
+	eventhub "github.com/Azure/azure-event-hubs-go"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,6 +30,23 @@ func TestParseEventHubsMetadata(t *testing.T) {
 		assert.Equal(t, m.storageAccountKey, "key")
 		assert.Equal(t, m.storageContainerName, "container")
 		assert.Equal(t, m.consumerGroup, "mygroup")
+		assert.Equal(t, m.publishBatchSize, defaultPublishBatchSize)
+		assert.Equal(t, m.publishBatchWait, defaultPublishBatchWait)
+	})
+
+	t.Run("test managed identity configuration", func(t *testing.T) {
+		props := map[string]string{"eventHubNamespace": "namespace", "eventHub": "hub", "azureClientId": "client", "consumerID": "mygroup", "storageAccountName": "account", "storageContainerName": "container", "publishBatchSize": "100", "publishBatchWait": "10ms"}
+
+		metadata := pubsub.Metadata{Properties: props}
+		m, err := parseEventHubsMetadata(metadata)
+
+		assert.NoError(t, err)
+		assert.Equal(t, m.eventHubNamespace, "namespace")
+		assert.Equal(t, m.eventHubName, "hub")
+		assert.Equal(t, m.clientID, "client")
+		assert.Equal(t, m.storageAccountKey, "")
+		assert.Equal(t, m.publishBatchSize, 100)
+		assert.Equal(t, m.publishBatchWait, 10*time.Millisecond)
 	})
 
 	type invalidConfigTestCase struct {
@@ -57,6 +79,21 @@ func TestParseEventHubsMetadata(t *testing.T) {
 			"missing storageContainerName",
 			map[string]string{"consumerID": "fake", "connectionString": "fake", "storageAccountName": "name", "storageAccountKey": "key"},
 			missingStorageContainerNameErrorMsg,
+		},
+		{
+			"missing eventHub",
+			map[string]string{"consumerID": "fake", "eventHubNamespace": "namespace", "storageAccountName": "name", "storageContainerName": "container"},
+			missingConnectionStringErrorMsg,
+		},
+		{
+			"invalid publishBatchSize",
+			map[string]string{"consumerID": "fake", "connectionString": "fake", "storageAccountName": "name", "storageAccountKey": "key", "storageContainerName": "container", "publishBatchSize": "0"},
+			"error: invalid publishBatchSize 0, it must be between 1 and 1000",
+		},
+		{
+			"invalid publishBatchWait",
+			map[string]string{"consumerID": "fake", "connectionString": "fake", "storageAccountName": "name", "storageAccountKey": "key", "storageContainerName": "container", "publishBatchWait": "soon"},
+			"error: invalid publishBatchWait soon",
 		}}
 
 	for _, c := range invalidConfigTestCases {
@@ -68,3 +105,121 @@ func TestParseEventHubsMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestEventMetadata(t *testing.T) {
+	partitionID := int16(3)
+	key := "device"
+	sequenceNumber := int64(42)
+	e := &eventhub.Event{SystemProperties: &eventhub.SystemProperties{PartitionID: &partitionID, PartitionKey: &key, SequenceNumber: &sequenceNumber}}
+
+	assert.Equal(t, map[string]string{"partitionID": "3", "partitionKey": "device", "sequenceNumber": "42"}, eventMetadata(e))
+	assert.Empty(t, eventMetadata(&eventhub.Event{}))
+}
+
+// fakeSender records the batches that are sent
+type fakeSender struct {
+	batches []*eventhub.EventBatch
+	err     error
+	lock    sync.Mutex
+}
+
+func (s *fakeSender) send(ctx context.Context, batch *eventhub.EventBatch) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.batches = append(s.batches, batch)
+	return s.err
+}
+
+func publishConcurrently(b *batcher, events ...*eventhub.Event) []error {
+	errs := make([]error, len(events))
+	var wg sync.WaitGroup
+	wg.Add(len(events))
+	for i, e := range events {
+		go func(i int, e *eventhub.Event) {
+			defer wg.Done()
+			errs[i] = b.add(e)
+		}(i, e)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestBatcher(t *testing.T) {
+	t.Run("full batches are sent", func(t *testing.T) {
+		s := &fakeSender{}
+		b := newBatcher(s.send, 2, time.Hour)
+
+		errs := publishConcurrently(b, &eventhub.Event{Data: []byte("1")}, &eventhub.Event{Data: []byte("2")})
+
+		assert.Equal(t, []error{nil, nil}, errs)
+		assert.Len(t, s.batches, 1)
+		assert.Len(t, s.batches[0].Events, 2)
+	})
+
+	t.Run("batches are sent after the wait", func(t *testing.T) {
+		s := &fakeSender{}
+		b := newBatcher(s.send, 10, 10*time.Millisecond)
+
+		errs := publishConcurrently(b, &eventhub.Event{Data: []byte("1")}, &eventhub.Event{Data: []byte("2")}, &eventhub.Event{Data: []byte("3")})
+
+		assert.Equal(t, []error{nil, nil, nil}, errs)
+		events := 0
+		for _, batch := range s.batches {
+			events += len(batch.Events)
+		}
+		assert.Equal(t, 3, events)
+	})
+
+	t.Run("events are batched by partition key", func(t *testing.T) {
+		s := &fakeSender{}
+		b := newBatcher(s.send, 2, time.Hour)
+		a, c := "a", "c"
+
+		publishConcurrently(b, &eventhub.Event{PartitionKey: &a}, &eventhub.Event{PartitionKey: &c}, &eventhub.Event{PartitionKey: &a}, &eventhub.Event{PartitionKey: &c})
+
+		assert.Len(t, s.batches, 2)
+		for _, batch := range s.batches {
+			for _, e := range batch.Events {
+				assert.Equal(t, *batch.PartitionKey, *e.PartitionKey)
+			}
+		}
+	})
+
+	t.Run("errors are returned to all publishers of the batch", func(t *testing.T) {
+		s := &fakeSender{err: errors.New("too large")}
+		b := newBatcher(s.send, 2, time.Hour)
+
+		errs := publishConcurrently(b, &eventhub.Event{}, &eventhub.Event{})
+
+		assert.Equal(t, []error{s.err, s.err}, errs)
+	})
+}
+
+func TestBulkPublish(t *testing.T) {
+	req := &pubsub.BulkPublishRequest{
+		Topic: "hub",
+		Entries: []pubsub.BulkMessageEntry{
+			{EntryID: "1", Data: []byte("1"), Metadata: map[string]string{partitionKey: "a"}},
+			{EntryID: "2", Data: []byte("2"), Metadata: map[string]string{partitionKey: "c"}},
+			{EntryID: "3", Data: []byte("3"), Metadata: map[string]string{partitionKey: "a"}},
+			{EntryID: "4", Data: []byte("4")},
+		},
+	}
+
+	var batches []*eventhub.EventBatch
+	res, err := bulkPublish(req, func(ctx context.Context, batch *eventhub.EventBatch) error {
+		batches = append(batches, batch)
+		if batch.PartitionKey != nil && *batch.PartitionKey == "c" {
+			return errors.New("too large")
+		}
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Len(t, batches, 3)
+	assert.Equal(t, "a", *batches[0].PartitionKey)
+	assert.Len(t, batches[0].Events, 2)
+	assert.Equal(t, "c", *batches[1].PartitionKey)
+	assert.Nil(t, batches[2].PartitionKey)
+	assert.Equal(t, []pubsub.BulkEntryStatus{{EntryID: "2", Error: errors.New("too large")}}, res.FailedEntries)
+}
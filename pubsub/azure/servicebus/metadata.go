@@ -8,8 +8,8 @@ package servicebus
 // Reference for settings:
 // https://github.com/Azure/azure-service-bus-go/blob/54b2faa53e5216616e59725281be692acc120c34/subscription_manager.go#L101
 type metadata struct {
-	ConnectionString               string `json:"connectionString"`
-	ConsumerID                     string `json:"consumerID"`
+	ConnectionString               string `json:"connectionString" metadata:",required"`
+	ConsumerID                     string `json:"consumerID" metadata:",required"`
 	TimeoutInSec                   int    `json:"timeoutInSec"`
 	HandlerTimeoutInSec            int    `json:"handlerTimeoutInSec"`
 	LockRenewalInSec               int    `json:"lockRenewalInSec"`
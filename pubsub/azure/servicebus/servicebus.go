@@ -12,6 +12,7 @@ import (
 	"time"
 
 	azservicebus "github.com/Azure/azure-service-bus-go"
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
 )
@@ -205,7 +206,27 @@ func (a *azureServiceBus) Init(metadata pubsub.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Azure Service Bus pub/sub
+func (a *azureServiceBus) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: componentmetadata.FieldsOf(metadata{
+			TimeoutInSec:                   defaultTimeoutInSec,
+			HandlerTimeoutInSec:            defaultHandlerTimeoutInSec,
+			LockRenewalInSec:               defaultLockRenewalInSec,
+			MaxActiveMessages:              defaultMaxActiveMessages,
+			MaxActiveMessagesRecoveryInSec: defaultMaxActiveMessagesRecoveryInSec,
+			DisableEntityManagement:        defaultDisableEntityManagement,
+		}),
+		Capabilities: []componentmetadata.Capability{componentmetadata.CapabilityTTL},
+	}
+}
+
 func (a *azureServiceBus) Publish(req *pubsub.PublishRequest) error {
+	msg, err := newMessage(req)
+	if err != nil {
+		return err
+	}
+
 	if !a.metadata.DisableEntityManagement {
 		err := a.ensureTopic(req.Topic)
 		if err != nil {
@@ -222,13 +243,32 @@ func (a *azureServiceBus) Publish(req *pubsub.PublishRequest) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(a.metadata.TimeoutInSec))
 	defer cancel()
 
-	err = sender.Send(ctx, azservicebus.NewMessage(req.Data))
+	err = sender.Send(ctx, msg)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// newMessage returns the message of the publish request, which expires after the TTL of the request if it has one.
+// Service Bus does not take a TTL of 0, so that is the shortest TTL it takes.
+func newMessage(req *pubsub.PublishRequest) (*azservicebus.Message, error) {
+	msg := azservicebus.NewMessage(req.Data)
+
+	ttl, ok, err := pubsub.TryGetTTL(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s", errorMessagePrefix, err)
+	}
+	if ok {
+		if ttl == 0 {
+			ttl = time.Second
+		}
+		msg.TTL = &ttl
+	}
+
+	return msg, nil
+}
+
 func (a *azureServiceBus) Subscribe(req pubsub.SubscribeRequest, appHandler func(msg *pubsub.NewMessage) error) error {
 	subID := a.metadata.ConsumerID
 	if !a.metadata.DisableEntityManagement {
@@ -7,6 +7,7 @@ package servicebus
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -498,3 +499,17 @@ func TestParseServiceBusMetadata(t *testing.T) {
 func assertValidErrorMessage(t *testing.T, err error) {
 	assert.Contains(t, err.Error(), errorMessagePrefix)
 }
+
+func TestNewMessage(t *testing.T) {
+	msg, err := newMessage(&pubsub.PublishRequest{Data: []byte("data"), Metadata: map[string]string{pubsub.TTLMetadataKey: "60"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), msg.Data)
+	assert.Equal(t, time.Minute, *msg.TTL)
+
+	msg, err = newMessage(&pubsub.PublishRequest{Data: []byte("data")})
+	assert.NoError(t, err)
+	assert.Nil(t, msg.TTL)
+
+	_, err = newMessage(&pubsub.PublishRequest{Data: []byte("data"), Metadata: map[string]string{pubsub.TTLMetadataKey: invalidNumber}})
+	assert.Error(t, err)
+}
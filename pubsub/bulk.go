@@ -0,0 +1,249 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBulkSubscribeMaxMessagesCount is the maximum number of messages of a bulk message when it is not configured
+	DefaultBulkSubscribeMaxMessagesCount = 100
+	// DefaultBulkSubscribeMaxAwaitDuration is how long messages wait for a bulk message when it is not configured
+	DefaultBulkSubscribeMaxAwaitDuration = time.Second
+)
+
+// BulkPublisher is implemented by the message buses that publish several messages at once natively
+type BulkPublisher interface {
+	// BulkPublish publishes the entries of the request, and returns an error if any of them failed. The failed entries
+	// are in the response, and the other entries were published.
+	BulkPublish(req *BulkPublishRequest) (BulkPublishResponse, error)
+}
+
+// BulkSubscriber is implemented by the message buses that deliver several messages at once natively
+type BulkSubscriber interface {
+	// BulkSubscribe subscribes to the topic, with a handler of messages grouped in bulk messages
+	BulkSubscribe(req SubscribeRequest, config BulkSubscribeConfig, handler BulkHandler) error
+}
+
+// BulkHandler handles a bulk message and returns the statuses of the entries that failed. Returning an error fails
+// all the entries. The failed entries are redelivered like the messages of the message bus whose handling failed.
+type BulkHandler func(msg *BulkMessage) ([]BulkEntryStatus, error)
+
+// BulkMessageEntry is a message of a bulk publish request or of a bulk message
+type BulkMessageEntry struct {
+	// EntryID identifies the entry in the statuses, and is unique within the request or bulk message
+	EntryID string `json:"entryId"`
+	Data    []byte `json:"data"`
+	// Metadata are the publish options of the entry, or the properties of a received message
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// BulkPublishRequest is the request to publish several messages to a topic at once
+type BulkPublishRequest struct {
+	Topic   string             `json:"topic"`
+	Entries []BulkMessageEntry `json:"entries"`
+	// Metadata are the publish options of all the entries, which the metadata of an entry override
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// BulkPublishResponse has the statuses of the entries of a bulk publish request that failed
+type BulkPublishResponse struct {
+	FailedEntries []BulkEntryStatus `json:"failedEntries,omitempty"`
+}
+
+// BulkEntryStatus is the error of an entry whose handling or publishing failed
+type BulkEntryStatus struct {
+	EntryID string `json:"entryId"`
+	Error   error  `json:"-"`
+}
+
+// BulkMessage is a group of messages arriving from a message bus instance
+type BulkMessage struct {
+	Topic   string             `json:"topic"`
+	Entries []BulkMessageEntry `json:"entries"`
+}
+
+// BulkSubscribeConfig is how messages are grouped in bulk messages
+type BulkSubscribeConfig struct {
+	// MaxMessagesCount is the maximum number of messages of a bulk message
+	MaxMessagesCount int `json:"maxMessagesCount,omitempty"`
+	// MaxAwaitDuration is how long the first message of a bulk message waits for it to be full before it is handled
+	MaxAwaitDuration time.Duration `json:"maxAwaitDuration,omitempty"`
+}
+
+// WithDefaults returns the config with the defaults of the values that are not set
+func (c BulkSubscribeConfig) WithDefaults() BulkSubscribeConfig {
+	if c.MaxMessagesCount <= 0 {
+		c.MaxMessagesCount = DefaultBulkSubscribeMaxMessagesCount
+	}
+	if c.MaxAwaitDuration <= 0 {
+		c.MaxAwaitDuration = DefaultBulkSubscribeMaxAwaitDuration
+	}
+
+	return c
+}
+
+// EntryRequest returns the publish request of an entry, with the metadata of the request and the entry
+func (r *BulkPublishRequest) EntryRequest(entry BulkMessageEntry) *PublishRequest {
+	metadata := make(map[string]string, len(r.Metadata)+len(entry.Metadata))
+	for k, v := range r.Metadata {
+		metadata[k] = v
+	}
+	for k, v := range entry.Metadata {
+		metadata[k] = v
+	}
+
+	return &PublishRequest{Topic: r.Topic, Data: entry.Data, Metadata: metadata}
+}
+
+// NewBulkPublishError returns the error of a bulk publish response with failed entries, or nil without
+func NewBulkPublishError(res BulkPublishResponse, total int) error {
+	if len(res.FailedEntries) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("failed to publish %d of %d entries, first error: %s", len(res.FailedEntries), total, res.FailedEntries[0].Error)
+}
+
+// NewBulkPublisher returns the pub sub if it publishes in bulk natively, or a bulk publisher that publishes the
+// entries one by one
+func NewBulkPublisher(p PubSub) BulkPublisher {
+	if bp, ok := p.(BulkPublisher); ok {
+		return bp
+	}
+
+	return &defaultBulkPublisher{pubsub: p}
+}
+
+// NewBulkSubscriber returns the pub sub if it subscribes in bulk natively, or a bulk subscriber that groups the
+// messages handled concurrently by the pub sub in bulk messages
+func NewBulkSubscriber(p PubSub) BulkSubscriber {
+	if bs, ok := p.(BulkSubscriber); ok {
+		return bs
+	}
+
+	return &defaultBulkSubscriber{pubsub: p}
+}
+
+type defaultBulkPublisher struct {
+	pubsub PubSub
+}
+
+// BulkPublish publishes the entries concurrently
+func (p *defaultBulkPublisher) BulkPublish(req *BulkPublishRequest) (BulkPublishResponse, error) {
+	errs := make([]error, len(req.Entries))
+	var wg sync.WaitGroup
+	for i, entry := range req.Entries {
+		wg.Add(1)
+		go func(i int, entry BulkMessageEntry) {
+			defer wg.Done()
+			errs[i] = p.pubsub.Publish(req.EntryRequest(entry))
+		}(i, entry)
+	}
+	wg.Wait()
+
+	res := BulkPublishResponse{}
+	for i, err := range errs {
+		if err != nil {
+			res.FailedEntries = append(res.FailedEntries, BulkEntryStatus{EntryID: req.Entries[i].EntryID, Error: err})
+		}
+	}
+
+	return res, NewBulkPublishError(res, len(req.Entries))
+}
+
+type defaultBulkSubscriber struct {
+	pubsub PubSub
+}
+
+// BulkSubscribe groups the messages that the pub sub handles concurrently. Since the pub sub waits for the result of
+// each message, a bulk message only has more than one entry when the pub sub handles several messages at once.
+func (s *defaultBulkSubscriber) BulkSubscribe(req SubscribeRequest, config BulkSubscribeConfig, handler BulkHandler) error {
+	c := &bulkCollector{topic: req.Topic, config: config.WithDefaults(), handler: handler}
+
+	return s.pubsub.Subscribe(req, c.add)
+}
+
+// bulkCollector groups the messages it is given in bulk messages, which are handled when they are full or when the
+// max await duration of their first message is over
+type bulkCollector struct {
+	topic   string
+	config  BulkSubscribeConfig
+	handler BulkHandler
+
+	pending *pendingBulk
+	nextID  int
+	lock    sync.Mutex
+}
+
+// pendingBulk is a bulk message that was not handled yet, and its result once it was
+type pendingBulk struct {
+	entries  []BulkMessageEntry
+	timer    *time.Timer
+	once     sync.Once
+	done     chan struct{}
+	statuses map[string]error
+	err      error
+}
+
+// add adds the message to the pending bulk message, and returns the result of the message once it was handled
+func (c *bulkCollector) add(msg *NewMessage) error {
+	c.lock.Lock()
+	bulk := c.pending
+	if bulk == nil {
+		bulk = &pendingBulk{done: make(chan struct{})}
+		bulk.timer = time.AfterFunc(c.config.MaxAwaitDuration, func() {
+			c.flush(bulk)
+		})
+		c.pending = bulk
+	}
+	entryID := strconv.Itoa(c.nextID)
+	c.nextID++
+	bulk.entries = append(bulk.entries, BulkMessageEntry{EntryID: entryID, Data: msg.Data, Metadata: msg.Metadata})
+	full := len(bulk.entries) >= c.config.MaxMessagesCount
+	if full {
+		c.pending = nil
+	}
+	c.lock.Unlock()
+
+	if full {
+		c.flush(bulk)
+	}
+
+	<-bulk.done
+	if bulk.err != nil {
+		return bulk.err
+	}
+	return bulk.statuses[entryID]
+}
+
+// flush handles the bulk message once, whether it is full or its wait is over
+func (c *bulkCollector) flush(bulk *pendingBulk) {
+	bulk.once.Do(func() {
+		c.lock.Lock()
+		bulk.timer.Stop()
+		if c.pending == bulk {
+			c.pending = nil
+		}
+		c.lock.Unlock()
+
+		statuses, err := c.handler(&BulkMessage{Topic: c.topic, Entries: bulk.entries})
+		bulk.err = err
+		bulk.statuses = make(map[string]error, len(statuses))
+		for _, status := range statuses {
+			if status.Error == nil {
+				status.Error = errors.New("bulk message entry failed")
+			}
+			bulk.statuses[status.EntryID] = status.Error
+		}
+		close(bulk.done)
+	})
+}
@@ -0,0 +1,206 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePubSub fails to publish the messages with the fail metadata, and delivers the messages given to deliver
+// concurrently to its subscriber
+type fakePubSub struct {
+	published []*PublishRequest
+	handler   func(msg *NewMessage) error
+	lock      sync.Mutex
+}
+
+func (f *fakePubSub) Init(metadata Metadata) error {
+	return nil
+}
+
+func (f *fakePubSub) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+func (f *fakePubSub) Publish(req *PublishRequest) error {
+	if req.Metadata["fail"] == "true" {
+		return errors.New("publish error")
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.published = append(f.published, req)
+	return nil
+}
+
+func (f *fakePubSub) Subscribe(req SubscribeRequest, handler func(msg *NewMessage) error) error {
+	f.handler = handler
+	return nil
+}
+
+// deliver hands the messages to the subscriber concurrently, and returns their results
+func (f *fakePubSub) deliver(messages ...string) []error {
+	errs := make([]error, len(messages))
+	var wg sync.WaitGroup
+	for i, data := range messages {
+		wg.Add(1)
+		go func(i int, data string) {
+			defer wg.Done()
+			errs[i] = f.handler(&NewMessage{Topic: "orders", Data: []byte(data)})
+		}(i, data)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+type fakeBulkPubSub struct {
+	fakePubSub
+}
+
+func (f *fakeBulkPubSub) BulkPublish(req *BulkPublishRequest) (BulkPublishResponse, error) {
+	return BulkPublishResponse{}, nil
+}
+
+func TestBulkPublishRequestEntryRequest(t *testing.T) {
+	req := &BulkPublishRequest{Topic: "orders", Metadata: map[string]string{"a": "request", "b": "request"}}
+	entry := BulkMessageEntry{EntryID: "1", Data: []byte("data"), Metadata: map[string]string{"b": "entry"}}
+
+	publishReq := req.EntryRequest(entry)
+
+	assert.Equal(t, "orders", publishReq.Topic)
+	assert.Equal(t, []byte("data"), publishReq.Data)
+	assert.Equal(t, map[string]string{"a": "request", "b": "entry"}, publishReq.Metadata)
+}
+
+func TestNewBulkPublisher(t *testing.T) {
+	t.Run("native", func(t *testing.T) {
+		p := &fakeBulkPubSub{}
+
+		assert.Equal(t, p, NewBulkPublisher(p))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		p := &fakePubSub{}
+		req := &BulkPublishRequest{
+			Topic: "orders",
+			Entries: []BulkMessageEntry{
+				{EntryID: "1", Data: []byte("first")},
+				{EntryID: "2", Data: []byte("second"), Metadata: map[string]string{"fail": "true"}},
+				{EntryID: "3", Data: []byte("third")},
+			},
+		}
+
+		res, err := NewBulkPublisher(p).BulkPublish(req)
+
+		assert.Error(t, err)
+		require.Len(t, res.FailedEntries, 1)
+		assert.Equal(t, "2", res.FailedEntries[0].EntryID)
+		assert.EqualError(t, res.FailedEntries[0].Error, "publish error")
+		assert.Len(t, p.published, 2)
+	})
+
+	t.Run("default without failures", func(t *testing.T) {
+		p := &fakePubSub{}
+		req := &BulkPublishRequest{Topic: "orders", Entries: []BulkMessageEntry{{EntryID: "1", Data: []byte("first")}}}
+
+		res, err := NewBulkPublisher(p).BulkPublish(req)
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.FailedEntries)
+	})
+}
+
+func TestBulkSubscribeConfigWithDefaults(t *testing.T) {
+	config := BulkSubscribeConfig{}.WithDefaults()
+	assert.Equal(t, DefaultBulkSubscribeMaxMessagesCount, config.MaxMessagesCount)
+	assert.Equal(t, DefaultBulkSubscribeMaxAwaitDuration, config.MaxAwaitDuration)
+
+	config = BulkSubscribeConfig{MaxMessagesCount: 10, MaxAwaitDuration: time.Minute}.WithDefaults()
+	assert.Equal(t, 10, config.MaxMessagesCount)
+	assert.Equal(t, time.Minute, config.MaxAwaitDuration)
+}
+
+func TestDefaultBulkSubscriber(t *testing.T) {
+	t.Run("full bulk messages with failed entries", func(t *testing.T) {
+		p := &fakePubSub{}
+		var bulks []*BulkMessage
+		var lock sync.Mutex
+		handler := func(msg *BulkMessage) ([]BulkEntryStatus, error) {
+			lock.Lock()
+			bulks = append(bulks, msg)
+			lock.Unlock()
+
+			var statuses []BulkEntryStatus
+			for _, entry := range msg.Entries {
+				if string(entry.Data) == "fail" {
+					statuses = append(statuses, BulkEntryStatus{EntryID: entry.EntryID, Error: errors.New("handler error")})
+				}
+			}
+			return statuses, nil
+		}
+
+		config := BulkSubscribeConfig{MaxMessagesCount: 2, MaxAwaitDuration: time.Minute}
+		err := NewBulkSubscriber(p).BulkSubscribe(SubscribeRequest{Topic: "orders"}, config, handler)
+		require.NoError(t, err)
+
+		errs := p.deliver("ok", "fail", "ok", "fail")
+
+		failed := 0
+		for _, err := range errs {
+			if err != nil {
+				failed++
+			}
+		}
+		assert.Equal(t, 2, failed)
+		require.Len(t, bulks, 2)
+		for _, bulk := range bulks {
+			assert.Equal(t, "orders", bulk.Topic)
+			assert.Len(t, bulk.Entries, 2)
+		}
+	})
+
+	t.Run("bulk message handled after the max await duration", func(t *testing.T) {
+		p := &fakePubSub{}
+		handled := 0
+		handler := func(msg *BulkMessage) ([]BulkEntryStatus, error) {
+			handled += len(msg.Entries)
+			return nil, nil
+		}
+
+		config := BulkSubscribeConfig{MaxMessagesCount: 10, MaxAwaitDuration: 10 * time.Millisecond}
+		err := NewBulkSubscriber(p).BulkSubscribe(SubscribeRequest{Topic: "orders"}, config, handler)
+		require.NoError(t, err)
+
+		errs := p.deliver("first")
+
+		assert.NoError(t, errs[0])
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("handler error fails all the entries", func(t *testing.T) {
+		p := &fakePubSub{}
+		handler := func(msg *BulkMessage) ([]BulkEntryStatus, error) {
+			return nil, errors.New("handler error")
+		}
+
+		config := BulkSubscribeConfig{MaxMessagesCount: 2, MaxAwaitDuration: time.Minute}
+		err := NewBulkSubscriber(p).BulkSubscribe(SubscribeRequest{Topic: "orders"}, config, handler)
+		require.NoError(t, err)
+
+		errs := p.deliver("first", "second")
+
+		assert.Error(t, errs[0])
+		assert.Error(t, errs[1])
+	})
+}
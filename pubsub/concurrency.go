@@ -0,0 +1,54 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dapr/components-contrib/metadata"
+)
+
+const (
+	// ConcurrencyKey is the metadata of how many messages of a subscription are handled at the same time
+	ConcurrencyKey = "concurrency"
+	// MaxInFlightKey is the metadata of how many messages of a subscription are delivered to the component and not
+	// acknowledged yet at most, including the ones being handled
+	MaxInFlightKey = "maxInFlight"
+)
+
+// TryGetConcurrency returns the concurrency of the metadata, or the default concurrency without it, and the max in
+// flight of the metadata, or 0 without it. The max in flight cannot be lower than the concurrency, since the messages
+// being handled are in flight.
+func TryGetConcurrency(properties map[string]string, defaultConcurrency int) (int, int, error) {
+	concurrency := defaultConcurrency
+	if val, ok := properties[ConcurrencyKey]; ok && val != "" {
+		var err error
+		concurrency, err = strconv.Atoi(val)
+		if err != nil || concurrency < 1 {
+			return 0, 0, fmt.Errorf("invalid %s value '%s', must be at least 1", ConcurrencyKey, val)
+		}
+	}
+
+	maxInFlight := 0
+	if val, ok := properties[MaxInFlightKey]; ok && val != "" {
+		var err error
+		maxInFlight, err = strconv.Atoi(val)
+		if err != nil || maxInFlight < concurrency {
+			return 0, 0, fmt.Errorf("invalid %s value '%s', must be at least the %s %d", MaxInFlightKey, val, ConcurrencyKey, concurrency)
+		}
+	}
+
+	return concurrency, maxInFlight, nil
+}
+
+// ConcurrencyMetadataFields returns the metadata fields read by TryGetConcurrency
+func ConcurrencyMetadataFields(defaultConcurrency int) []metadata.Field {
+	return []metadata.Field{
+		{Name: ConcurrencyKey, Type: metadata.TypeInt, Default: strconv.Itoa(defaultConcurrency)},
+		{Name: MaxInFlightKey, Type: metadata.TypeInt},
+	}
+}
@@ -0,0 +1,34 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryGetConcurrency(t *testing.T) {
+	concurrency, maxInFlight, err := TryGetConcurrency(nil, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, concurrency)
+	assert.Equal(t, 0, maxInFlight)
+
+	concurrency, maxInFlight, err = TryGetConcurrency(map[string]string{ConcurrencyKey: "2", MaxInFlightKey: "10"}, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, concurrency)
+	assert.Equal(t, 10, maxInFlight)
+
+	for _, properties := range []map[string]string{
+		{ConcurrencyKey: "0"},
+		{ConcurrencyKey: "many"},
+		{MaxInFlightKey: "many"},
+		{ConcurrencyKey: "2", MaxInFlightKey: "1"},
+	} {
+		_, _, err = TryGetConcurrency(properties, 1)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,38 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+const (
+	// DeadLetterTopicKey is the subscription metadata of the topic that the messages whose retries are exhausted are
+	// republished to
+	DeadLetterTopicKey = "deadLetterTopic"
+
+	// DeadLetterOriginalTopicKey and DeadLetterErrorKey are the metadata of a dead letter, with the topic it was
+	// published to and the error of its last handling
+	DeadLetterOriginalTopicKey = "deadLetterOriginalTopic"
+	DeadLetterErrorKey         = "deadLetterError"
+)
+
+// DeadLetterTopic returns the dead-letter topic of the subscription, or an empty string without
+func (r SubscribeRequest) DeadLetterTopic() string {
+	return r.Metadata[DeadLetterTopicKey]
+}
+
+// NewDeadLetterRequest returns the request to republish a message to the dead-letter topic, with the metadata of the
+// message and the DeadLetterOriginalTopicKey and DeadLetterErrorKey metadata. Components map these metadata to the
+// headers or properties of the message bus.
+func NewDeadLetterRequest(deadLetterTopic string, msg *NewMessage, handlerErr error) *PublishRequest {
+	metadata := make(map[string]string, len(msg.Metadata)+2)
+	for k, v := range msg.Metadata {
+		metadata[k] = v
+	}
+	metadata[DeadLetterOriginalTopicKey] = msg.Topic
+	if handlerErr != nil {
+		metadata[DeadLetterErrorKey] = handlerErr.Error()
+	}
+
+	return &PublishRequest{Topic: deadLetterTopic, Data: msg.Data, Metadata: metadata}
+}
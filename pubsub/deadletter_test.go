@@ -0,0 +1,36 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterTopic(t *testing.T) {
+	assert.Equal(t, "", SubscribeRequest{Topic: "orders"}.DeadLetterTopic())
+
+	req := SubscribeRequest{Topic: "orders", Metadata: map[string]string{DeadLetterTopicKey: "orders-dead"}}
+	assert.Equal(t, "orders-dead", req.DeadLetterTopic())
+}
+
+func TestNewDeadLetterRequest(t *testing.T) {
+	msg := &NewMessage{Topic: "orders", Data: []byte("order"), Metadata: map[string]string{"partitionKey": "key"}}
+
+	req := NewDeadLetterRequest("orders-dead", msg, errors.New("handler error"))
+
+	assert.Equal(t, "orders-dead", req.Topic)
+	assert.Equal(t, []byte("order"), req.Data)
+	assert.Equal(t, map[string]string{
+		"partitionKey":             "key",
+		DeadLetterOriginalTopicKey: "orders",
+		DeadLetterErrorKey:         "handler error",
+	}, req.Metadata)
+	// The metadata of the message are not modified
+	assert.Len(t, msg.Metadata, 1)
+}
@@ -0,0 +1,51 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DeliverAfterMetadataKey is the publish metadata of how long after it is published a message is delivered, as a
+	// duration such as 90s
+	DeliverAfterMetadataKey = "deliverAfter"
+	// DeliverAtMetadataKey is the publish metadata of the time at which a message is delivered, in RFC3339
+	DeliverAtMetadataKey = "deliverAt"
+)
+
+// TryGetDeliveryDelay returns how long after now the message of the publish metadata is delivered, and whether it is
+// delayed. Messages to deliver at a time that is over are delayed by 0.
+func TryGetDeliveryDelay(metadata map[string]string, now time.Time) (time.Duration, bool, error) {
+	after, hasAfter := metadata[DeliverAfterMetadataKey]
+	at, hasAt := metadata[DeliverAtMetadataKey]
+	hasAfter = hasAfter && after != ""
+	hasAt = hasAt && at != ""
+
+	switch {
+	case hasAfter && hasAt:
+		return 0, false, fmt.Errorf("only one of %s and %s can be set", DeliverAfterMetadataKey, DeliverAtMetadataKey)
+	case hasAfter:
+		delay, err := time.ParseDuration(after)
+		if err != nil || delay < 0 {
+			return 0, false, fmt.Errorf("invalid %s value '%s', must be a non-negative duration", DeliverAfterMetadataKey, after)
+		}
+		return delay, true, nil
+	case hasAt:
+		deliverAt, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid %s value '%s', must be an RFC3339 time", DeliverAtMetadataKey, at)
+		}
+		delay := deliverAt.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true, nil
+	}
+
+	return 0, false, nil
+}
@@ -0,0 +1,44 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryGetDeliveryDelay(t *testing.T) {
+	now := time.Date(2020, 11, 30, 12, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		metadata map[string]string
+		delay    time.Duration
+		delayed  bool
+	}{
+		{nil, 0, false},
+		{map[string]string{DeliverAfterMetadataKey: ""}, 0, false},
+		{map[string]string{DeliverAfterMetadataKey: "90s"}, 90 * time.Second, true},
+		{map[string]string{DeliverAtMetadataKey: "2020-11-30T12:05:00Z"}, 5 * time.Minute, true},
+		{map[string]string{DeliverAtMetadataKey: "2020-11-30T11:00:00Z"}, 0, true},
+	} {
+		delay, delayed, err := TryGetDeliveryDelay(test.metadata, now)
+		assert.NoError(t, err)
+		assert.Equal(t, test.delay, delay)
+		assert.Equal(t, test.delayed, delayed)
+	}
+
+	for _, metadata := range []map[string]string{
+		{DeliverAfterMetadataKey: "soon"},
+		{DeliverAfterMetadataKey: "-1s"},
+		{DeliverAtMetadataKey: "tomorrow"},
+		{DeliverAfterMetadataKey: "90s", DeliverAtMetadataKey: "2020-11-30T12:05:00Z"},
+	} {
+		_, _, err := TryGetDeliveryDelay(metadata, now)
+		assert.Error(t, err)
+	}
+}
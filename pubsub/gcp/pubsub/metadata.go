@@ -1,17 +1,21 @@
 package pubsub
 
+import "time"
+
 // GCPPubSubMetaData pubsub metadata
 type metadata struct {
 	ConsumerID              string `json:"consumerID"`
-	DisableEntityManagement bool   `json:"disableEntityManagement"`
-	Type                    string `json:"type"`
-	ProjectID               string `json:"project_id"`
-	PrivateKeyID            string `json:"private_key_id"`
-	PrivateKey              string `json:"private_key"`
-	ClientEmail             string `json:"client_email"`
-	ClientID                string `json:"client_id"`
-	AuthURI                 string `json:"auth_uri"`
-	TokenURI                string `json:"token_uri"`
-	AuthProviderCertURL     string `json:"auth_provider_x509_cert_url"`
-	ClientCertURL           string `json:"client_x509_cert_url"`
+	DisableEntityManagement bool   `json:"-"`
+	// AckDeadline is how long a subscriber has to acknowledge a message before it is redelivered
+	AckDeadline         time.Duration `json:"-"`
+	Type                string        `json:"type"`
+	ProjectID           string        `json:"project_id"`
+	PrivateKeyID        string        `json:"private_key_id"`
+	PrivateKey          string        `json:"private_key"`
+	ClientEmail         string        `json:"client_email"`
+	ClientID            string        `json:"client_id"`
+	AuthURI             string        `json:"auth_uri"`
+	TokenURI            string        `json:"token_uri"`
+	AuthProviderCertURL string        `json:"auth_provider_x509_cert_url"`
+	ClientCertURL       string        `json:"client_x509_cert_url"`
 }
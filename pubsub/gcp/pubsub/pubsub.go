@@ -4,23 +4,36 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
 	gcppubsub "cloud.google.com/go/pubsub"
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
 	"google.golang.org/api/option"
 )
 
 const (
-	errorMessagePrefix = "gcp pubsub error:"
-	consumerID         = "consumerID"
+	errorMessagePrefix      = "gcp pubsub error:"
+	consumerID              = "consumerID"
+	disableEntityManagement = "disableEntityManagement"
+	ackDeadline             = "ackDeadline"
+
+	// the ack deadline of subscriptions is between these durations
+	minAckDeadline = 10 * time.Second
+	maxAckDeadline = 600 * time.Second
 )
 
 // GCPPubSub type
 type GCPPubSub struct {
 	client   *gcppubsub.Client
 	metadata *metadata
-	logger   logger.Logger
+	// topics are reused, since each one batches the messages published to it
+	topics map[string]*gcppubsub.Topic
+	lock   sync.Mutex
+	logger logger.Logger
 }
 
 // NewGCPPubSub returns a new GCPPubSub instance
@@ -28,7 +41,8 @@ func NewGCPPubSub(logger logger.Logger) pubsub.PubSub {
 	return &GCPPubSub{logger: logger}
 }
 
-// Init parses metadata and creates a new Pub Sub client
+// Init parses metadata and creates a new Pub Sub client. Without the private key of a service account, the client authenticates
+// with the application default credentials, such as the workload identity of a GKE pod.
 func (g *GCPPubSub) Init(meta pubsub.Metadata) error {
 	b, err := g.parseMetadata(meta)
 	if err != nil {
@@ -40,21 +54,73 @@ func (g *GCPPubSub) Init(meta pubsub.Metadata) error {
 	if err != nil {
 		return err
 	}
-	clientOptions := option.WithCredentialsJSON(b)
+
+	err = parseOptions(meta, &pubsubMeta)
+	if err != nil {
+		return err
+	}
+
+	var clientOptions []option.ClientOption
+	if pubsubMeta.PrivateKey != "" {
+		clientOptions = append(clientOptions, option.WithCredentialsJSON(b))
+	}
 	ctx := context.Background()
-	pubsubClient, err := gcppubsub.NewClient(ctx, pubsubMeta.ProjectID, clientOptions)
+	pubsubClient, err := gcppubsub.NewClient(ctx, pubsubMeta.ProjectID, clientOptions...)
 	if err != nil {
 		return fmt.Errorf("%s error creating pubsub client: %s", errorMessagePrefix, err)
 	}
 
+	g.client = pubsubClient
+	g.metadata = &pubsubMeta
+	g.topics = map[string]*gcppubsub.Topic{}
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the GCP Pub/Sub pub/sub, which are those of the service account
+// credentials and its options
+func (g *GCPPubSub) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	fields := []componentmetadata.Field{
+		{Name: consumerID, Type: componentmetadata.TypeString, Required: true},
+		{Name: "project_id", Type: componentmetadata.TypeString, Required: true},
+	}
+	for _, name := range []string{"type", "private_key_id", "private_key", "client_email", "client_id", "auth_uri", "token_uri", "auth_provider_x509_cert_url", "client_x509_cert_url"} {
+		fields = append(fields, componentmetadata.Field{Name: name, Type: componentmetadata.TypeString})
+	}
+	fields = append(fields,
+		componentmetadata.Field{Name: disableEntityManagement, Type: componentmetadata.TypeBool},
+		componentmetadata.Field{Name: ackDeadline, Type: componentmetadata.TypeDuration},
+	)
+	return componentmetadata.ComponentMetadata{Fields: fields}
+}
+
+// parseOptions parses the metadata that is not part of the service account credentials
+func parseOptions(meta pubsub.Metadata, pubsubMeta *metadata) error {
 	if val, ok := meta.Properties[consumerID]; ok && val != "" {
 		pubsubMeta.ConsumerID = val
 	} else {
 		return fmt.Errorf("%s missing consumerID", errorMessagePrefix)
 	}
 
-	g.client = pubsubClient
-	g.metadata = &pubsubMeta
+	if pubsubMeta.ProjectID == "" {
+		return fmt.Errorf("%s missing project_id", errorMessagePrefix)
+	}
+
+	if val, ok := meta.Properties[disableEntityManagement]; ok && val != "" {
+		disable, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("%s invalid disableEntityManagement %s, %s", errorMessagePrefix, val, err)
+		}
+		pubsubMeta.DisableEntityManagement = disable
+	}
+
+	if val, ok := meta.Properties[ackDeadline]; ok && val != "" {
+		deadline, err := time.ParseDuration(val)
+		if err != nil || deadline < minAckDeadline || deadline > maxAckDeadline {
+			return fmt.Errorf("%s invalid ackDeadline %s, it must be between %s and %s", errorMessagePrefix, val, minAckDeadline, maxAckDeadline)
+		}
+		pubsubMeta.AckDeadline = deadline
+	}
+
 	return nil
 }
 
@@ -112,8 +178,11 @@ func (g *GCPPubSub) handleSubscriptionMessages(topic *gcppubsub.Topic, sub *gcpp
 
 		err := daprHandler(msg)
 
+		// messages that were not handled are redelivered right away instead of after the ack deadline
 		if err == nil {
 			m.Ack()
+		} else {
+			m.Nack()
 		}
 	})
 	return err
@@ -129,7 +198,16 @@ func (g *GCPPubSub) ensureTopic(topic string) error {
 }
 
 func (g *GCPPubSub) getTopic(topic string) *gcppubsub.Topic {
-	return g.client.Topic(topic)
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	t, ok := g.topics[topic]
+	if !ok {
+		t = g.client.Topic(topic)
+		g.topics[topic] = t
+	}
+
+	return t
 }
 
 func (g *GCPPubSub) ensureSubscription(subscription string, topic string) error {
@@ -142,7 +220,7 @@ func (g *GCPPubSub) ensureSubscription(subscription string, topic string) error
 	exists, subErr := entity.Exists(context.Background())
 	if !exists {
 		_, subErr = g.client.CreateSubscription(context.Background(), g.metadata.ConsumerID,
-			gcppubsub.SubscriptionConfig{Topic: g.getTopic(topic)})
+			gcppubsub.SubscriptionConfig{Topic: g.getTopic(topic), AckDeadline: g.metadata.AckDeadline})
 	}
 	return subErr
 }
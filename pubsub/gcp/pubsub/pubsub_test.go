@@ -3,6 +3,7 @@ package pubsub
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -33,3 +34,50 @@ func TestInit(t *testing.T) {
 	assert.Equal(t, "https://token", pubsubMeta.TokenURI)
 	assert.Equal(t, "serviceaccount", pubsubMeta.Type)
 }
+
+func TestParseOptions(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		m := pubsub.Metadata{Properties: map[string]string{"consumerID": "consumer"}}
+		pubsubMeta := metadata{ProjectID: "project1"}
+		err := parseOptions(m, &pubsubMeta)
+		assert.Nil(t, err)
+		assert.Equal(t, "consumer", pubsubMeta.ConsumerID)
+		assert.False(t, pubsubMeta.DisableEntityManagement)
+		assert.Equal(t, time.Duration(0), pubsubMeta.AckDeadline)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		m := pubsub.Metadata{Properties: map[string]string{"consumerID": "consumer", "disableEntityManagement": "true", "ackDeadline": "60s"}}
+		pubsubMeta := metadata{ProjectID: "project1"}
+		err := parseOptions(m, &pubsubMeta)
+		assert.Nil(t, err)
+		assert.True(t, pubsubMeta.DisableEntityManagement)
+		assert.Equal(t, time.Minute, pubsubMeta.AckDeadline)
+	})
+
+	t.Run("missing consumerID", func(t *testing.T) {
+		pubsubMeta := metadata{ProjectID: "project1"}
+		err := parseOptions(pubsub.Metadata{Properties: map[string]string{}}, &pubsubMeta)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("missing project_id", func(t *testing.T) {
+		m := pubsub.Metadata{Properties: map[string]string{"consumerID": "consumer"}}
+		err := parseOptions(m, &metadata{})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("invalid disableEntityManagement", func(t *testing.T) {
+		m := pubsub.Metadata{Properties: map[string]string{"consumerID": "consumer", "disableEntityManagement": "maybe"}}
+		err := parseOptions(m, &metadata{ProjectID: "project1"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ackDeadline out of range", func(t *testing.T) {
+		for _, val := range []string{"5s", "11m", "soon"} {
+			m := pubsub.Metadata{Properties: map[string]string{"consumerID": "consumer", "ackDeadline": val}}
+			err := parseOptions(m, &metadata{ProjectID: "project1"})
+			assert.NotNil(t, err, val)
+		}
+	})
+}
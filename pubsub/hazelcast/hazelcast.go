@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/hazelcast/hazelcast-go-client"
@@ -54,6 +55,13 @@ func (p *Hazelcast) Init(metadata pubsub.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Hazelcast pub/sub
+func (p *Hazelcast) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{{Name: hazelcastServers, Type: componentmetadata.TypeList, Required: true}},
+	}
+}
+
 func (p *Hazelcast) Publish(req *pubsub.PublishRequest) error {
 	topic, err := p.client.GetTopic(req.Topic)
 	if err != nil {
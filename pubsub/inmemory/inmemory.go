@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package inmemory
+
+import (
+	"sync"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+type handler func(msg *pubsub.NewMessage) error
+
+// bus delivers the messages published to a topic to all the subscribers of the topic in the same process.
+// Messages are not persisted, so the messages of topics without subscribers are dropped.
+type bus struct {
+	subscriptions map[string][]handler
+	lock          sync.RWMutex
+	logger        logger.Logger
+}
+
+// NewInMemoryBus returns a new in-memory pub-sub implementation
+func NewInMemoryBus(logger logger.Logger) pubsub.PubSub {
+	return &bus{subscriptions: map[string][]handler{}, logger: logger}
+}
+
+func (b *bus) Init(metadata pubsub.Metadata) error {
+	return nil
+}
+
+// GetComponentMetadata returns the metadata of the in-memory pub/sub, which has no fields
+func (b *bus) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+// Ping always succeeds, as the bus has no backend
+func (b *bus) Ping() error {
+	return nil
+}
+
+// Publish delivers the message to the subscribers of the topic asynchronously, like message buses do
+func (b *bus) Publish(req *pubsub.PublishRequest) error {
+	b.lock.RLock()
+	handlers := b.subscriptions[req.Topic]
+	b.lock.RUnlock()
+
+	for _, h := range handlers {
+		// Each subscriber gets its own copy, since handlers may modify the message
+		data := make([]byte, len(req.Data))
+		copy(data, req.Data)
+		msg := &pubsub.NewMessage{Topic: req.Topic, Data: data}
+
+		go func(h handler) {
+			err := h(msg)
+			if err != nil {
+				b.logger.Errorf("in-memory pubsub error handling message from topic %s: %s", req.Topic, err)
+			}
+		}(h)
+	}
+
+	return nil
+}
+
+func (b *bus) Subscribe(req pubsub.SubscribeRequest, h func(msg *pubsub.NewMessage) error) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// The slices are replaced instead of appended to, since Publish iterates over them without the lock
+	handlers := make([]handler, len(b.subscriptions[req.Topic]), len(b.subscriptions[req.Topic])+1)
+	copy(handlers, b.subscriptions[req.Topic])
+	b.subscriptions[req.Topic] = append(handlers, h)
+
+	return nil
+}
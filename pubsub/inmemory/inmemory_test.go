@@ -0,0 +1,65 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package inmemory
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+func TestPublishSubscribe(t *testing.T) {
+	bus := NewInMemoryBus(logger.NewLogger("test"))
+	assert.Nil(t, bus.Init(pubsub.Metadata{}))
+
+	first := make(chan *pubsub.NewMessage, 1)
+	second := make(chan *pubsub.NewMessage, 1)
+	other := make(chan *pubsub.NewMessage, 1)
+	assert.Nil(t, bus.Subscribe(pubsub.SubscribeRequest{Topic: "orders"}, func(msg *pubsub.NewMessage) error {
+		first <- msg
+		return nil
+	}))
+	assert.Nil(t, bus.Subscribe(pubsub.SubscribeRequest{Topic: "orders"}, func(msg *pubsub.NewMessage) error {
+		second <- msg
+		return errors.New("handler error")
+	}))
+	assert.Nil(t, bus.Subscribe(pubsub.SubscribeRequest{Topic: "payments"}, func(msg *pubsub.NewMessage) error {
+		other <- msg
+		return nil
+	}))
+
+	data := []byte("order")
+	assert.Nil(t, bus.Publish(&pubsub.PublishRequest{Topic: "orders", Data: data}))
+	data[0] = 'O'
+
+	for _, received := range []chan *pubsub.NewMessage{first, second} {
+		select {
+		case msg := <-received:
+			assert.Equal(t, "orders", msg.Topic)
+			assert.Equal(t, "order", string(msg.Data))
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the message")
+		}
+	}
+
+	select {
+	case <-other:
+		t.Fatal("message delivered to the subscriber of another topic")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPublishWithoutSubscribers(t *testing.T) {
+	bus := NewInMemoryBus(logger.NewLogger("test"))
+
+	err := bus.Publish(&pubsub.PublishRequest{Topic: "orders", Data: []byte("order")})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,369 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+/*
+Package jetstream implements a pubsub component with NATS JetStream, which persists the messages of topics in streams
+and delivers them to durable consumers. The streams and consumers are managed with the JetStream API of nats.go.
+*/
+package jetstream
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	componentmetadata "github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/logger"
+	nats "github.com/nats-io/nats.go"
+)
+
+const (
+	natsURL        = "natsURL"
+	consumerID     = "consumerID"
+	streamName     = "streamName"
+	autoProvision  = "autoProvision"
+	ackWait        = "ackWait"
+	maxDeliver     = "maxDeliver"
+	backOff        = "backOff"
+	requestTimeout = "requestTimeout"
+
+	defaultAckWait        = 30 * time.Second
+	defaultRequestTimeout = 5 * time.Second
+
+	deliverSubjectFormat = "dapr.jetstream.%s.%s"
+)
+
+type jetStreamPubSub struct {
+	metadata metadata
+	natsConn *nats.Conn
+	js       nats.JetStreamContext
+	// streams are the streams of the topics that were published or subscribed to
+	streams map[string]string
+	lock    sync.Mutex
+
+	logger logger.Logger
+}
+
+// NewJetStreamPubSub returns a new NATS JetStream pub-sub implementation
+func NewJetStreamPubSub(logger logger.Logger) pubsub.PubSub {
+	return &jetStreamPubSub{logger: logger, streams: map[string]string{}}
+}
+
+func parseJetStreamMetadata(meta pubsub.Metadata) (metadata, error) {
+	m := metadata{
+		autoProvision:  true,
+		ackWait:        defaultAckWait,
+		requestTimeout: defaultRequestTimeout,
+	}
+
+	if val, ok := meta.Properties[natsURL]; ok && val != "" {
+		m.natsURL = val
+	} else {
+		return m, errors.New("jetstream error: missing nats URL")
+	}
+
+	if val, ok := meta.Properties[consumerID]; ok && val != "" {
+		m.consumerID = val
+	} else {
+		return m, errors.New("jetstream error: missing consumer ID")
+	}
+
+	m.streamName = meta.Properties[streamName]
+
+	if val, ok := meta.Properties[autoProvision]; ok && val != "" {
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return m, fmt.Errorf("jetstream error: invalid autoProvision %s: %s", val, err)
+		}
+		m.autoProvision = b
+	}
+
+	for key, field := range map[string]*time.Duration{ackWait: &m.ackWait, requestTimeout: &m.requestTimeout} {
+		if val, ok := meta.Properties[key]; ok && val != "" {
+			d, err := time.ParseDuration(val)
+			if err != nil || d <= 0 {
+				return m, fmt.Errorf("jetstream error: invalid %s %s", key, val)
+			}
+			*field = d
+		}
+	}
+
+	if val, ok := meta.Properties[maxDeliver]; ok && val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n == 0 || n < -1 {
+			return m, fmt.Errorf("jetstream error: invalid maxDeliver %s, it must be positive or -1 for no limit", val)
+		}
+		m.maxDeliver = n
+	}
+
+	if val, ok := meta.Properties[backOff]; ok && val != "" {
+		for _, s := range strings.Split(val, ",") {
+			d, err := time.ParseDuration(strings.TrimSpace(s))
+			if err != nil || d <= 0 {
+				return m, fmt.Errorf("jetstream error: invalid backOff %s", val)
+			}
+			m.backOff = append(m.backOff, d)
+		}
+
+		// Each delay is for a redelivery, so there must be more deliveries than delays
+		if m.maxDeliver <= len(m.backOff) {
+			return m, fmt.Errorf("jetstream error: maxDeliver must be greater than the %d backOff delays", len(m.backOff))
+		}
+
+		// The messages whose delay is not shorter are redelivered once their ackWait expires
+		for _, d := range m.backOff {
+			if d >= m.ackWait {
+				return m, fmt.Errorf("jetstream error: backOff delay %s must be shorter than ackWait %s", d, m.ackWait)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (j *jetStreamPubSub) Init(metadata pubsub.Metadata) error {
+	m, err := parseJetStreamMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	j.metadata = m
+	natsConn, err := nats.Connect(m.natsURL)
+	if err != nil {
+		return fmt.Errorf("jetstream: error connecting to nats at %s: %s", m.natsURL, err)
+	}
+	j.logger.Debugf("connected to nats at %s", m.natsURL)
+
+	js, err := natsConn.JetStream(nats.MaxWait(m.requestTimeout))
+	if err != nil {
+		natsConn.Close()
+		return fmt.Errorf("jetstream: error getting the JetStream context: %s", err)
+	}
+
+	j.natsConn = natsConn
+	j.js = js
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the JetStream pub/sub
+func (j *jetStreamPubSub) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: natsURL, Type: componentmetadata.TypeString, Required: true},
+			{Name: consumerID, Type: componentmetadata.TypeString, Required: true},
+			{Name: streamName, Type: componentmetadata.TypeString},
+			{Name: autoProvision, Type: componentmetadata.TypeBool},
+			{Name: ackWait, Type: componentmetadata.TypeDuration, Default: defaultAckWait.String()},
+			{Name: maxDeliver, Type: componentmetadata.TypeInt},
+			{Name: backOff, Type: componentmetadata.TypeList},
+			{Name: requestTimeout, Type: componentmetadata.TypeDuration, Default: defaultRequestTimeout.String()},
+		},
+	}
+}
+
+// Ping returns an error when the connection to the NATS server is not open, for health checks
+func (j *jetStreamPubSub) Ping() error {
+	if j.natsConn == nil || !j.natsConn.IsConnected() {
+		return errors.New("jetstream: not connected to the server")
+	}
+	return nil
+}
+
+// Publish publishes the message to the stream of the topic, and waits for it to be stored
+func (j *jetStreamPubSub) Publish(req *pubsub.PublishRequest) error {
+	_, err := j.ensureStream(req.Topic)
+	if err != nil {
+		return err
+	}
+
+	_, err = j.js.Publish(req.Topic, req.Data)
+	if err != nil {
+		return fmt.Errorf("jetstream: error from publish: %s", err)
+	}
+
+	return nil
+}
+
+// Subscribe creates the durable consumer of the topic and subscribes to its deliveries in the consumerID queue group,
+// so that the messages are load balanced over the instances of the app. Messages are acknowledged after the handler
+// succeeded, and redelivered after the ackWait, or the backOff delays, otherwise.
+func (j *jetStreamPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
+	stream, err := j.ensureStream(req.Topic)
+	if err != nil {
+		return err
+	}
+
+	durable, err := j.ensureConsumer(stream, req.Topic)
+	if err != nil {
+		return err
+	}
+
+	sub, err := j.js.QueueSubscribe(req.Topic, j.metadata.consumerID, func(natsMsg *nats.Msg) {
+		err := handler(&pubsub.NewMessage{Topic: req.Topic, Data: natsMsg.Data})
+		if err != nil {
+			j.logger.Warnf("jetstream: error handling message from topic %s, it will be redelivered: %s", req.Topic, err)
+			j.redeliver(natsMsg)
+			return
+		}
+
+		err = natsMsg.Ack()
+		if err != nil {
+			j.logger.Warnf("jetstream: error acknowledging message from topic %s: %s", req.Topic, err)
+		}
+	}, nats.Bind(stream, durable), nats.ManualAck())
+	if err != nil {
+		return fmt.Errorf("jetstream: error subscribing to topic %s: %s", req.Topic, err)
+	}
+	j.logger.Debugf("jetstream: subscribed to subject %s with queue group %s", sub.Subject, sub.Queue)
+
+	return nil
+}
+
+// ensureStream returns the stream of the topic. With autoProvision the stream is created if it does not exist,
+// and the topic is added to the subjects of the stream if it is not one of them.
+func (j *jetStreamPubSub) ensureStream(topic string) (string, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if stream, ok := j.streams[topic]; ok {
+		return stream, nil
+	}
+
+	stream := j.metadata.streamName
+	if stream == "" {
+		stream = sanitizeName(topic)
+	}
+
+	info, err := j.js.StreamInfo(stream)
+	switch {
+	case err == nats.ErrStreamNotFound:
+		if !j.metadata.autoProvision {
+			return "", fmt.Errorf("jetstream: stream %s of topic %s does not exist", stream, topic)
+		}
+
+		_, err = j.js.AddStream(&nats.StreamConfig{Name: stream, Subjects: []string{topic}, Storage: nats.FileStorage})
+		if err != nil {
+			return "", fmt.Errorf("jetstream: error creating stream %s: %s", stream, err)
+		}
+		j.logger.Debugf("jetstream: created stream %s for topic %s", stream, topic)
+	case err != nil:
+		return "", fmt.Errorf("jetstream: error getting stream %s: %s", stream, err)
+	case !subjectsMatch(info.Config.Subjects, topic):
+		if !j.metadata.autoProvision {
+			return "", fmt.Errorf("jetstream: topic %s is not a subject of stream %s", topic, stream)
+		}
+
+		config := info.Config
+		config.Subjects = append(config.Subjects, topic)
+		_, err = j.js.UpdateStream(&config)
+		if err != nil {
+			return "", fmt.Errorf("jetstream: error adding topic %s to stream %s: %s", topic, stream, err)
+		}
+		j.logger.Debugf("jetstream: added topic %s to stream %s", topic, stream)
+	}
+
+	j.streams[topic] = stream
+	return stream, nil
+}
+
+// ensureConsumer creates the durable consumer of the topic, and returns its name
+func (j *jetStreamPubSub) ensureConsumer(stream, topic string) (string, error) {
+	config := j.consumerConfig(stream, topic)
+	_, err := j.js.AddConsumer(stream, config)
+	if err == nil {
+		return config.Durable, nil
+	}
+
+	// A consumer that exists with a different configuration is used as it is
+	_, infoErr := j.js.ConsumerInfo(stream, config.Durable)
+	if infoErr != nil {
+		return "", fmt.Errorf("jetstream: error creating consumer %s of stream %s: %s", config.Durable, stream, err)
+	}
+
+	j.logger.Warnf("jetstream: using the existing configuration of consumer %s of stream %s: %s", config.Durable, stream, err)
+	return config.Durable, nil
+}
+
+func (j *jetStreamPubSub) consumerConfig(stream, topic string) *nats.ConsumerConfig {
+	durable := sanitizeName(j.metadata.consumerID + "-" + topic)
+	return &nats.ConsumerConfig{
+		Durable:        durable,
+		DeliverSubject: fmt.Sprintf(deliverSubjectFormat, stream, durable),
+		DeliverGroup:   j.metadata.consumerID,
+		DeliverPolicy:  nats.DeliverAllPolicy,
+		AckPolicy:      nats.AckExplicitPolicy,
+		AckWait:        j.metadata.ackWait,
+		MaxDeliver:     j.metadata.maxDeliver,
+		FilterSubject:  topic,
+	}
+}
+
+// redeliver negatively acknowledges a message after its backOff delay, so that it is redelivered then. Without
+// backOff the message is redelivered once its ackWait expires. The delays are applied here as the consumers of
+// nats.go v1.13, the last release building with Go 1.14, have no backOff.
+func (j *jetStreamPubSub) redeliver(msg *nats.Msg) {
+	if len(j.metadata.backOff) == 0 {
+		return
+	}
+
+	var delivered uint64 = 1
+	if meta, err := msg.Metadata(); err == nil {
+		delivered = meta.NumDelivered
+	}
+
+	time.AfterFunc(backOffDelay(j.metadata.backOff, delivered), func() {
+		err := msg.Nak()
+		if err != nil {
+			j.logger.Warnf("jetstream: error negatively acknowledging message from subject %s: %s", msg.Subject, err)
+		}
+	})
+}
+
+// backOffDelay returns the delay before the redelivery of a message that was delivered the number of times.
+// The last delay is used for the redeliveries after the last one.
+func backOffDelay(backOff []time.Duration, delivered uint64) time.Duration {
+	if delivered < 1 {
+		delivered = 1
+	}
+	if delivered > uint64(len(backOff)) {
+		return backOff[len(backOff)-1]
+	}
+
+	return backOff[delivered-1]
+}
+
+// sanitizeName replaces the characters that are not valid in stream and consumer names
+func sanitizeName(name string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_").Replace(name)
+}
+
+// subjectsMatch returns whether subject matches one of the subjects of a stream, which may have wildcards
+func subjectsMatch(subjects []string, subject string) bool {
+	tokens := strings.Split(subject, ".")
+	for _, s := range subjects {
+		if subjectMatches(strings.Split(s, "."), tokens) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func subjectMatches(pattern, tokens []string) bool {
+	for i, p := range pattern {
+		if p == ">" {
+			return len(tokens) > i
+		}
+		if i >= len(tokens) || (p != "*" && p != tokens[i]) {
+			return false
+		}
+	}
+
+	return len(pattern) == len(tokens)
+}
@@ -0,0 +1,165 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package jetstream
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	natsURLEnvKey = "DAPR_TEST_JETSTREAM_URL" // Environment variable containing the URL of a NATS server with JetStream
+)
+
+func TestJetStreamIntegration(t *testing.T) {
+	url := os.Getenv(natsURLEnvKey)
+	if url == "" {
+		t.Skipf("JetStream pubsub integration tests skipped. To enable start a server with 'nats-server -js' and define its URL using environment variable '%s' (example 'export %s=\"nats://localhost:4222\")", natsURLEnvKey, natsURLEnvKey)
+	}
+
+	t.Run("Stream per topic", func(t *testing.T) {
+		p := newIntegrationPubSub(t, url, map[string]string{})
+		topic := "orders." + uuid.New().String()
+
+		assert.Nil(t, p.Publish(&pubsub.PublishRequest{Topic: topic, Data: []byte("1")}))
+		info, err := p.js.StreamInfo(sanitizeName(topic))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{topic}, info.Config.Subjects)
+		assert.Equal(t, uint64(1), info.State.Msgs)
+		deleteStream(t, p, sanitizeName(topic))
+	})
+
+	t.Run("Topics added to shared stream", func(t *testing.T) {
+		stream := "all_" + uuid.New().String()
+		p := newIntegrationPubSub(t, url, map[string]string{streamName: stream})
+		orders, payments := "orders."+uuid.New().String(), "payments."+uuid.New().String()
+
+		assert.Nil(t, p.Publish(&pubsub.PublishRequest{Topic: orders, Data: []byte("1")}))
+		assert.Nil(t, p.Publish(&pubsub.PublishRequest{Topic: payments, Data: []byte("2")}))
+		info, err := p.js.StreamInfo(stream)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{orders, payments}, info.Config.Subjects)
+		deleteStream(t, p, stream)
+	})
+
+	t.Run("Without auto provisioning", func(t *testing.T) {
+		p := newIntegrationPubSub(t, url, map[string]string{autoProvision: "false"})
+
+		assert.NotNil(t, p.Publish(&pubsub.PublishRequest{Topic: "orders." + uuid.New().String(), Data: []byte("1")}))
+	})
+
+	t.Run("Failed messages are redelivered after their back off", func(t *testing.T) {
+		properties := map[string]string{ackWait: "10s", maxDeliver: "3", backOff: "200ms"}
+		p := newIntegrationPubSub(t, url, properties)
+		topic := "orders." + uuid.New().String()
+
+		handled := make(chan string, 3)
+		failed := false
+		err := p.Subscribe(pubsub.SubscribeRequest{Topic: topic}, func(msg *pubsub.NewMessage) error {
+			handled <- string(msg.Data)
+			if string(msg.Data) == "fail" && !failed {
+				failed = true
+				return fmt.Errorf("failed")
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+
+		config := p.consumerConfig(sanitizeName(topic), topic)
+		info, err := p.js.ConsumerInfo(sanitizeName(topic), config.Durable)
+		assert.Nil(t, err)
+		assert.Equal(t, "app", info.Config.DeliverGroup)
+		assert.Equal(t, topic, info.Config.FilterSubject)
+		assert.Equal(t, 10*time.Second, info.Config.AckWait)
+		assert.Equal(t, 3, info.Config.MaxDeliver)
+
+		assert.Nil(t, p.Publish(&pubsub.PublishRequest{Topic: topic, Data: []byte("ok")}))
+		assert.Equal(t, "ok", receive(t, handled))
+
+		start := time.Now()
+		assert.Nil(t, p.Publish(&pubsub.PublishRequest{Topic: topic, Data: []byte("fail")}))
+		assert.Equal(t, "fail", receive(t, handled))
+		assert.Equal(t, "fail", receive(t, handled))
+		elapsed := time.Since(start)
+		assert.True(t, elapsed >= 200*time.Millisecond && elapsed < 10*time.Second, "redelivered after %s", elapsed)
+
+		assert.Eventually(t, func() bool {
+			info, err := p.js.ConsumerInfo(sanitizeName(topic), config.Durable)
+			return err == nil && info.NumAckPending == 0
+		}, 5*time.Second, 50*time.Millisecond)
+		deleteStream(t, p, sanitizeName(topic))
+	})
+
+	t.Run("Messages are load balanced over the consumer ID", func(t *testing.T) {
+		topic := "orders." + uuid.New().String()
+		first := newIntegrationPubSub(t, url, map[string]string{})
+		second := newIntegrationPubSub(t, url, map[string]string{})
+
+		var lock sync.Mutex
+		received := map[string]int{}
+		handler := func(msg *pubsub.NewMessage) error {
+			lock.Lock()
+			defer lock.Unlock()
+			received[string(msg.Data)]++
+			return nil
+		}
+		assert.Nil(t, first.Subscribe(pubsub.SubscribeRequest{Topic: topic}, handler))
+		assert.Nil(t, second.Subscribe(pubsub.SubscribeRequest{Topic: topic}, handler))
+
+		for i := 0; i < 20; i++ {
+			assert.Nil(t, first.Publish(&pubsub.PublishRequest{Topic: topic, Data: []byte(fmt.Sprint(i))}))
+		}
+
+		assert.Eventually(t, func() bool {
+			lock.Lock()
+			defer lock.Unlock()
+			return len(received) == 20
+		}, 5*time.Second, 50*time.Millisecond)
+		time.Sleep(200 * time.Millisecond)
+
+		lock.Lock()
+		defer lock.Unlock()
+		for data, count := range received {
+			assert.Equal(t, 1, count, "message %s was received %d times", data, count)
+		}
+		deleteStream(t, first, sanitizeName(topic))
+	})
+}
+
+func newIntegrationPubSub(t *testing.T, url string, properties map[string]string) *jetStreamPubSub {
+	properties[natsURL] = url
+	properties[consumerID] = "app"
+	p := NewJetStreamPubSub(logger.NewLogger("test")).(*jetStreamPubSub)
+	err := p.Init(pubsub.Metadata{Properties: properties})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(p.natsConn.Close)
+
+	return p
+}
+
+func deleteStream(t *testing.T, p *jetStreamPubSub, stream string) {
+	assert.Nil(t, p.js.DeleteStream(stream))
+}
+
+func receive(t *testing.T, ch chan string) string {
+	select {
+	case s := <-ch:
+		return s
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message was received")
+		return ""
+	}
+}
@@ -0,0 +1,78 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package jetstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJetStreamMetadata(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		m, err := parseJetStreamMetadata(pubsub.Metadata{Properties: map[string]string{natsURL: "nats://localhost:4222", consumerID: "app"}})
+		assert.Nil(t, err)
+		assert.True(t, m.autoProvision)
+		assert.Equal(t, defaultAckWait, m.ackWait)
+		assert.Equal(t, defaultRequestTimeout, m.requestTimeout)
+		assert.Equal(t, 0, m.maxDeliver)
+		assert.Empty(t, m.backOff)
+	})
+
+	t.Run("Custom values", func(t *testing.T) {
+		m, err := parseJetStreamMetadata(pubsub.Metadata{Properties: map[string]string{
+			natsURL:       "nats://localhost:4222",
+			consumerID:    "app",
+			streamName:    "orders",
+			autoProvision: "false",
+			ackWait:       "60s",
+			maxDeliver:    "4",
+			backOff:       "1s, 5s,30s",
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "orders", m.streamName)
+		assert.False(t, m.autoProvision)
+		assert.Equal(t, 60*time.Second, m.ackWait)
+		assert.Equal(t, 4, m.maxDeliver)
+		assert.Equal(t, []time.Duration{time.Second, 5 * time.Second, 30 * time.Second}, m.backOff)
+	})
+
+	invalid := map[string]map[string]string{
+		"Missing URL":             {consumerID: "app"},
+		"Missing consumer ID":     {natsURL: "nats://localhost:4222"},
+		"Invalid ack wait":        {natsURL: "nats://localhost:4222", consumerID: "app", ackWait: "-1s"},
+		"Invalid max deliver":     {natsURL: "nats://localhost:4222", consumerID: "app", maxDeliver: "0"},
+		"Back off without limit":  {natsURL: "nats://localhost:4222", consumerID: "app", backOff: "1s"},
+		"Too many back offs":      {natsURL: "nats://localhost:4222", consumerID: "app", backOff: "1s,2s", maxDeliver: "2"},
+		"Back off after ack wait": {natsURL: "nats://localhost:4222", consumerID: "app", backOff: "30s", maxDeliver: "2"},
+	}
+	for name, properties := range invalid {
+		properties := properties
+		t.Run(name, func(t *testing.T) {
+			_, err := parseJetStreamMetadata(pubsub.Metadata{Properties: properties})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestSubjectsMatch(t *testing.T) {
+	assert.True(t, subjectsMatch([]string{"orders"}, "orders"))
+	assert.True(t, subjectsMatch([]string{"orders.*"}, "orders.new"))
+	assert.True(t, subjectsMatch([]string{"other", "orders.>"}, "orders.new.eu"))
+	assert.False(t, subjectsMatch([]string{"orders.*"}, "orders.new.eu"))
+	assert.False(t, subjectsMatch([]string{"orders.>"}, "orders"))
+	assert.False(t, subjectsMatch([]string{"orders"}, "orders.new"))
+}
+
+func TestBackOffDelay(t *testing.T) {
+	backOff := []time.Duration{time.Second, 5 * time.Second}
+	assert.Equal(t, time.Second, backOffDelay(backOff, 1))
+	assert.Equal(t, 5*time.Second, backOffDelay(backOff, 2))
+	assert.Equal(t, 5*time.Second, backOffDelay(backOff, 3))
+	assert.Equal(t, time.Second, backOffDelay(backOff, 0))
+}
@@ -0,0 +1,22 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package jetstream
+
+import "time"
+
+type metadata struct {
+	natsURL string
+	// consumerID names the durable consumers and is the queue group their messages are load balanced over
+	consumerID string
+	// streamName is the stream of all topics, when empty each topic has its own stream
+	streamName    string
+	autoProvision bool
+	ackWait       time.Duration
+	maxDeliver    int
+	// backOff are the delays before each redelivery of a message that was not acknowledged
+	backOff        []time.Duration
+	requestTimeout time.Duration
+}
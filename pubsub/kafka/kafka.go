@@ -7,13 +7,16 @@ package kafka
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
+	kafkaauth "github.com/dapr/components-contrib/authentication/kafka"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
 )
@@ -32,38 +35,388 @@ type Kafka struct {
 	cancel        context.CancelFunc
 	consumer      consumer
 	config        *sarama.Config
+	maxRetries    int
+	concurrency   int
+	maxInFlight   int
+	// deadLetterTopics are the dead-letter topics of the subscribed topics that have one
+	deadLetterTopics map[string]string
 }
 
+const (
+	// partitionKey is the publish metadata that sets the key of the message, so that messages with the same key
+	// are published to the same partition
+	partitionKey = "partitionKey"
+
+	initialOffsetNewest = "newest"
+	initialOffsetOldest = "oldest"
+
+	// retryInterval is the time to wait before a message whose handler failed is handled again
+	retryInterval = time.Second
+	// defaultDeadLetterMaxRetries is how many times the messages of subscriptions with a dead-letter topic are
+	// retried when maxRetries is not set, since the others are retried until they succeed
+	defaultDeadLetterMaxRetries = 3
+)
+
 type kafkaMetadata struct {
-	Brokers      []string `json:"brokers"`
-	ConsumerID   string   `json:"consumerID"`
-	AuthRequired bool     `json:"authRequired"`
-	SaslUsername string   `json:"saslUsername"`
-	SaslPassword string   `json:"saslPassword"`
+	Brokers       []string `json:"brokers"`
+	ConsumerID    string   `json:"consumerID"`
+	AuthRequired  bool     `json:"authRequired"`
+	SaslUsername  string   `json:"saslUsername"`
+	SaslPassword  string   `json:"saslPassword"`
+	SaslMechanism string   `json:"saslMechanism"`
+	InitialOffset int64    `json:"initialOffset"`
+	EnableTLS     bool     `json:"enableTLS"`
+	CACert        string   `json:"caCert"`
+	ClientCert    string   `json:"clientCert"`
+	ClientKey     string   `json:"clientKey"`
+	SkipVerify    bool     `json:"skipVerify"`
+	MaxRetries    int      `json:"maxRetries"`
+	Concurrency   int      `json:"concurrency"`
+	MaxInFlight   int      `json:"maxInFlight"`
+
+	// ClientCertFile and ClientKeyFile are loaded again when they change, so that rotated certificates are used
+	ClientCertFile string `json:"clientCertFile"`
+	ClientKeyFile  string `json:"clientKeyFile"`
+	// OAuth are the client credentials of the OAUTHBEARER SASL mechanism
+	OAuth kafkaauth.OAuthOptions `json:"-"`
 }
 
 type consumer struct {
 	ready    chan bool
 	callback func(msg *pubsub.NewMessage) error
-	once     sync.Once
+	// bulkCallback handles the messages of a partition in bulk messages instead of callback
+	bulkCallback pubsub.BulkHandler
+	bulkConfig   pubsub.BulkSubscribeConfig
+	// deadLetterTopics are where the messages of the topics are published once their retries are exhausted
+	deadLetterTopics map[string]string
+	maxRetries       int
+	producer         sarama.SyncProducer
+	once             sync.Once
+	logger           logger.Logger
+	// concurrency is how many messages of a partition are handled at the same time, and maxInFlight how many are
+	// handed to the callback past the last marked message at most
+	concurrency int
+	maxInFlight int
 }
 
+// ConsumeClaim hands the messages of a partition to the callback in order, unless the concurrency is more than 1. A
+// message is only marked, and so its offset committed, after the callback succeeded. Failed messages are retried until
+// they succeed or the session ends, so that the offsets of later messages are never committed past them and delivery
+// is at least once. The messages of topics with a dead-letter topic are published there instead once their retries
+// are exhausted.
 func (consumer *consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	if consumer.bulkCallback != nil {
+		return consumer.consumeBulk(session, claim)
+	}
+	if consumer.concurrency > 1 && consumer.callback != nil {
+		return consumer.consumeConcurrently(session, claim)
+	}
+
 	for message := range claim.Messages() {
 		if consumer.callback != nil {
-			err := consumer.callback(&pubsub.NewMessage{
-				Topic: claim.Topic(),
-				Data:  message.Value,
-			})
+			err := consumer.handle(session, claim.Topic(), message)
+			if err != nil {
+				return err
+			}
+			session.MarkMessage(message, "")
+		}
+	}
+
+	return nil
+}
+
+func (consumer *consumer) handle(session sarama.ConsumerGroupSession, topic string, message *sarama.ConsumerMessage) error {
+	msg := &pubsub.NewMessage{
+		Topic:    topic,
+		Data:     message.Value,
+		Metadata: messageMetadata(message),
+	}
+	if pubsub.HasExpired(msg.Metadata, time.Now()) {
+		if consumer.logger != nil {
+			consumer.logger.Debugf("Dropping expired message from topic %s partition %d offset %d", topic, message.Partition, message.Offset)
+		}
+		return nil
+	}
+	for retries := 0; ; retries++ {
+		err := consumer.callback(msg)
+		if err == nil {
+			return nil
+		}
+
+		if consumer.retriesExhausted(topic, retries) {
+			err = consumer.deadLetter(msg, err)
 			if err == nil {
-				session.MarkMessage(message, "")
+				return nil
 			}
 		}
+
+		if consumer.logger != nil {
+			consumer.logger.Warnf("Error handling message from topic %s partition %d offset %d, retrying: %v", topic, message.Partition, message.Offset, err)
+		}
+
+		select {
+		case <-session.Context().Done():
+			return err
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// consumeConcurrently hands the messages of a partition to the callback with up to concurrency messages handled at
+// the same time, so that they are not handled in order. Offsets are still marked in order, once a message and all the
+// messages before it succeeded, and at most max in flight messages are handed out past the last marked one.
+func (consumer *consumer) consumeConcurrently(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	tracker := newOffsetTracker(session, consumer.maxInFlight)
+	workers := make(chan struct{}, consumer.concurrency)
+	var wg sync.WaitGroup
+	var handleErr error
+	var errOnce sync.Once
+	defer wg.Wait()
+
+	for message := range claim.Messages() {
+		tracked, ok := tracker.add(message)
+		if !ok {
+			break
+		}
+
+		select {
+		case workers <- struct{}{}:
+		case <-session.Context().Done():
+			return nil
+		}
+
+		wg.Add(1)
+		go func(message *sarama.ConsumerMessage) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			err := consumer.handle(session, claim.Topic(), message)
+			if err != nil {
+				errOnce.Do(func() { handleErr = err })
+				return
+			}
+			tracker.done(tracked)
+		}(message)
+	}
+
+	wg.Wait()
+	return handleErr
+}
+
+// offsetTracker marks the messages of a partition in order once they are done, and limits how many messages are not
+// marked yet
+type offsetTracker struct {
+	session  sarama.ConsumerGroupSession
+	inFlight chan struct{}
+	pending  []*trackedMessage
+	lock     sync.Mutex
+}
+
+type trackedMessage struct {
+	message *sarama.ConsumerMessage
+	done    bool
+}
+
+func newOffsetTracker(session sarama.ConsumerGroupSession, maxInFlight int) *offsetTracker {
+	return &offsetTracker{session: session, inFlight: make(chan struct{}, maxInFlight)}
+}
+
+// add tracks the message once fewer than max in flight messages are not marked, and returns false if the session
+// ended first
+func (t *offsetTracker) add(message *sarama.ConsumerMessage) (*trackedMessage, bool) {
+	select {
+	case t.inFlight <- struct{}{}:
+	case <-t.session.Context().Done():
+		return nil, false
+	}
+
+	tracked := &trackedMessage{message: message}
+	t.lock.Lock()
+	t.pending = append(t.pending, tracked)
+	t.lock.Unlock()
+
+	return tracked, true
+}
+
+// done marks the message, after the messages before it that are done, unless one of them is not done yet
+func (t *offsetTracker) done(tracked *trackedMessage) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	tracked.done = true
+	for len(t.pending) > 0 && t.pending[0].done {
+		t.session.MarkMessage(t.pending[0].message, "")
+		t.pending = t.pending[1:]
+		<-t.inFlight
+	}
+}
+
+// consumeBulk hands the messages of a partition to the bulk callback in bulk messages of up to the max messages count,
+// and after the max await duration at the latest. The last message of a bulk message is only marked after all its
+// entries succeeded, with the failed entries retried like the messages of ConsumeClaim.
+func (consumer *consumer) consumeBulk(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	messages := make([]*sarama.ConsumerMessage, 0, consumer.bulkConfig.MaxMessagesCount)
+	ticker := time.NewTicker(consumer.bulkConfig.MaxAwaitDuration)
+	defer ticker.Stop()
+
+	flush := func() error {
+		if len(messages) == 0 {
+			return nil
+		}
+
+		err := consumer.handleBulk(session, claim.Topic(), messages)
+		if err != nil {
+			return err
+		}
+		session.MarkMessage(messages[len(messages)-1], "")
+		messages = messages[:0]
+		return nil
+	}
+
+	for {
+		select {
+		case message, ok := <-claim.Messages():
+			if !ok {
+				return flush()
+			}
+			messages = append(messages, message)
+			if len(messages) >= consumer.bulkConfig.MaxMessagesCount {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		case <-ticker.C:
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (consumer *consumer) handleBulk(session sarama.ConsumerGroupSession, topic string, messages []*sarama.ConsumerMessage) error {
+	// The offsets identify the entries, since they are unique within a partition. Expired messages are dropped.
+	entries := make([]pubsub.BulkMessageEntry, 0, len(messages))
+	now := time.Now()
+	for _, message := range messages {
+		metadata := messageMetadata(message)
+		if pubsub.HasExpired(metadata, now) {
+			continue
+		}
+		entries = append(entries, pubsub.BulkMessageEntry{
+			EntryID:  strconv.FormatInt(message.Offset, 10),
+			Data:     message.Value,
+			Metadata: metadata,
+		})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	for retries := 0; ; retries++ {
+		statuses, err := consumer.bulkCallback(&pubsub.BulkMessage{Topic: topic, Entries: entries})
+		if err == nil && len(statuses) == 0 {
+			return nil
+		}
+
+		// Only the failed entries are handled again
+		errs := make(map[string]error, len(entries))
+		for _, status := range statuses {
+			errs[status.EntryID] = status.Error
+		}
+		retried := make([]pubsub.BulkMessageEntry, 0, len(entries))
+		for _, entry := range entries {
+			entryErr, failed := errs[entry.EntryID]
+			if err != nil {
+				entryErr, failed = err, true
+			}
+			if !failed {
+				continue
+			}
+
+			if consumer.retriesExhausted(topic, retries) {
+				msg := &pubsub.NewMessage{Topic: topic, Data: entry.Data, Metadata: entry.Metadata}
+				if consumer.deadLetter(msg, entryErr) == nil {
+					continue
+				}
+			}
+			retried = append(retried, entry)
+		}
+		if len(retried) == 0 {
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("%d entries failed, first error: %v", len(statuses), statuses[0].Error)
+		}
+		entries = retried
+
+		if consumer.logger != nil {
+			consumer.logger.Warnf("Error handling bulk message from topic %s partition %d, retrying: %v", topic, messages[0].Partition, err)
+		}
+
+		select {
+		case <-session.Context().Done():
+			return err
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// retriesExhausted returns whether a failed message of the topic is published to its dead-letter topic
+func (consumer *consumer) retriesExhausted(topic string, retries int) bool {
+	if consumer.deadLetterTopics[topic] == "" {
+		return false
+	}
+
+	maxRetries := consumer.maxRetries
+	if maxRetries < 0 {
+		maxRetries = defaultDeadLetterMaxRetries
+	}
+	return retries >= maxRetries
+}
+
+// deadLetter publishes the message to the dead-letter topic of its topic, with the metadata in the headers
+func (consumer *consumer) deadLetter(msg *pubsub.NewMessage, handlerErr error) error {
+	req := pubsub.NewDeadLetterRequest(consumer.deadLetterTopics[msg.Topic], msg, handlerErr)
+	deadLetter, err := producerMessage(req)
+	if err != nil {
+		return err
+	}
+	for k, v := range req.Metadata {
+		if k != partitionKey {
+			deadLetter.Headers = append(deadLetter.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+
+	_, _, err = consumer.producer.SendMessage(deadLetter)
+	if err != nil {
+		if consumer.logger != nil {
+			consumer.logger.Errorf("Error publishing message from topic %s to dead-letter topic %s: %v", msg.Topic, req.Topic, err)
+		}
+		return err
 	}
 
 	return nil
 }
 
+// messageMetadata returns the headers of a message as its metadata, with its key as the partitionKey metadata
+func messageMetadata(message *sarama.ConsumerMessage) map[string]string {
+	if len(message.Key) == 0 && len(message.Headers) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(message.Headers)+1)
+	for _, header := range message.Headers {
+		if header != nil {
+			metadata[string(header.Key)] = string(header.Value)
+		}
+	}
+	if len(message.Key) > 0 {
+		metadata[partitionKey] = string(message.Key)
+	}
+
+	return metadata
+}
+
 func (consumer *consumer) Cleanup(sarama.ConsumerGroupSession) error {
 	return nil
 }
@@ -88,7 +441,7 @@ func (k *Kafka) Init(metadata pubsub.Metadata) error {
 		return err
 	}
 
-	p, err := k.getSyncProducer(meta)
+	p, err := getSyncProducer(meta)
 	if err != nil {
 		return err
 	}
@@ -96,6 +449,7 @@ func (k *Kafka) Init(metadata pubsub.Metadata) error {
 	k.brokers = meta.Brokers
 	k.producer = p
 	k.consumerGroup = meta.ConsumerID
+	k.authRequired = meta.AuthRequired
 
 	if meta.AuthRequired {
 		k.saslUsername = meta.SaslUsername
@@ -104,26 +458,60 @@ func (k *Kafka) Init(metadata pubsub.Metadata) error {
 
 	config := sarama.NewConfig()
 	config.Version = sarama.V2_0_0_0
+	config.Consumer.Offsets.Initial = meta.InitialOffset
 
-	if k.authRequired {
-		updateAuthInfo(config, k.saslUsername, k.saslPassword)
+	err = updateAuthInfo(config, meta)
+	if err != nil {
+		return err
 	}
 
 	k.config = config
+	k.maxRetries = meta.MaxRetries
+	k.concurrency = meta.Concurrency
+	k.maxInFlight = meta.MaxInFlight
 
 	k.topics = make(map[string]bool)
+	k.deadLetterTopics = make(map[string]string)
 
 	k.logger.Debug("Kafka message bus initialization complete")
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Kafka pub/sub
+func (k *Kafka) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := []metadata.Field{
+		{Name: "brokers", Type: metadata.TypeList, Required: true},
+		{Name: "consumerID", Type: metadata.TypeString},
+		{Name: "authRequired", Type: metadata.TypeBool, Required: true},
+		{Name: "saslMechanism", Type: metadata.TypeString, Default: sarama.SASLTypePlaintext},
+		{Name: "saslUsername", Type: metadata.TypeString},
+		{Name: "saslPassword", Type: metadata.TypeString},
+		{Name: "initialOffset", Type: metadata.TypeString, Default: initialOffsetNewest},
+		{Name: "enableTLS", Type: metadata.TypeBool},
+		{Name: "skipVerify", Type: metadata.TypeBool},
+		{Name: "maxRetries", Type: metadata.TypeInt},
+		{Name: "caCert", Type: metadata.TypeString},
+		{Name: "clientCert", Type: metadata.TypeString},
+		{Name: "clientKey", Type: metadata.TypeString},
+	}
+	fields = append(fields, kafkaauth.MetadataFields()...)
+	fields = append(fields, pubsub.ConcurrencyMetadataFields(1)...)
+
+	return metadata.ComponentMetadata{
+		Fields:       fields,
+		Capabilities: []metadata.Capability{metadata.CapabilityTTL},
+	}
+}
+
 // Publish message to Kafka cluster
 func (k *Kafka) Publish(req *pubsub.PublishRequest) error {
 	k.logger.Debugf("Publishing topic %v with data: %v", req.Topic, req.Data)
-	partition, offset, err := k.producer.SendMessage(&sarama.ProducerMessage{
-		Topic: req.Topic,
-		Value: sarama.ByteEncoder(req.Data),
-	})
+	msg, err := producerMessage(req)
+	if err != nil {
+		return err
+	}
+
+	partition, offset, err := k.producer.SendMessage(msg)
 
 	k.logger.Debugf("Partition: %v, offset: %v", partition, offset)
 
@@ -134,6 +522,70 @@ func (k *Kafka) Publish(req *pubsub.PublishRequest) error {
 	return nil
 }
 
+// BulkPublish sends the entries to the Kafka cluster in a single produce call
+func (k *Kafka) BulkPublish(req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error) {
+	k.logger.Debugf("Publishing %d messages to topic %v", len(req.Entries), req.Topic)
+	res := pubsub.BulkPublishResponse{}
+	msgs := make([]*sarama.ProducerMessage, 0, len(req.Entries))
+	for _, entry := range req.Entries {
+		msg, err := producerMessage(req.EntryRequest(entry))
+		if err != nil {
+			res.FailedEntries = append(res.FailedEntries, pubsub.BulkEntryStatus{EntryID: entry.EntryID, Error: err})
+			continue
+		}
+		// The metadata of the messages are returned with their errors
+		msg.Metadata = entry.EntryID
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return res, pubsub.NewBulkPublishError(res, len(req.Entries))
+	}
+
+	err := k.producer.SendMessages(msgs)
+	if err == nil {
+		return res, pubsub.NewBulkPublishError(res, len(req.Entries))
+	}
+
+	var producerErrs sarama.ProducerErrors
+	if !errors.As(err, &producerErrs) {
+		for _, msg := range msgs {
+			res.FailedEntries = append(res.FailedEntries, pubsub.BulkEntryStatus{EntryID: msg.Metadata.(string), Error: err})
+		}
+		return res, err
+	}
+	for _, producerErr := range producerErrs {
+		entryID, _ := producerErr.Msg.Metadata.(string)
+		res.FailedEntries = append(res.FailedEntries, pubsub.BulkEntryStatus{EntryID: entryID, Error: producerErr.Err})
+	}
+
+	return res, pubsub.NewBulkPublishError(res, len(req.Entries))
+}
+
+// producerMessage returns the message of a publish request. Kafka has no expiration of messages, so messages with a
+// TTL have their expiration in a header, and they are dropped instead of handled once it is over.
+func producerMessage(req *pubsub.PublishRequest) (*sarama.ProducerMessage, error) {
+	msg := &sarama.ProducerMessage{
+		Topic: req.Topic,
+		Value: sarama.ByteEncoder(req.Data),
+	}
+	if val, ok := req.Metadata[partitionKey]; ok && val != "" {
+		msg.Key = sarama.StringEncoder(val)
+	}
+
+	ttl, ok, err := pubsub.TryGetTTL(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{
+			Key:   []byte(pubsub.ExpirationMetadataKey),
+			Value: []byte(pubsub.Expiration(ttl, time.Now())),
+		})
+	}
+
+	return msg, nil
+}
+
 func (k *Kafka) addTopic(newTopic string) []string {
 	// Add topic to our map of topics
 	k.topics[newTopic] = true
@@ -169,7 +621,28 @@ func (k *Kafka) closeSubscripionResources() {
 // Subscribe to topic in the Kafka cluster
 // This call cannot block like its sibling in bindings/kafka because of where this is invoked in runtime.go
 func (k *Kafka) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
-	topics := k.addTopic(req.Topic)
+	k.addDeadLetterTopic(req)
+	return k.subscribe(req.Topic, &consumer{callback: handler})
+}
+
+// BulkSubscribe subscribes to the topic in the Kafka cluster, with the messages of each partition handled in bulk
+// messages
+func (k *Kafka) BulkSubscribe(req pubsub.SubscribeRequest, config pubsub.BulkSubscribeConfig, handler pubsub.BulkHandler) error {
+	k.addDeadLetterTopic(req)
+	return k.subscribe(req.Topic, &consumer{bulkCallback: handler, bulkConfig: config.WithDefaults()})
+}
+
+func (k *Kafka) addDeadLetterTopic(req pubsub.SubscribeRequest) {
+	if deadLetterTopic := req.DeadLetterTopic(); deadLetterTopic != "" {
+		k.deadLetterTopics[req.Topic] = deadLetterTopic
+	} else {
+		delete(k.deadLetterTopics, req.Topic)
+	}
+}
+
+// subscribe consumes the topic and the previously subscribed topics with the callbacks of c
+func (k *Kafka) subscribe(topic string, c *consumer) error {
+	topics := k.addTopic(topic)
 
 	// Close resources and reset synchronization primitives
 	k.closeSubscripionResources()
@@ -185,10 +658,24 @@ func (k *Kafka) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.
 	ctx, cancel := context.WithCancel(context.Background())
 	k.cancel = cancel
 
+	// The consumer has its own copy of the dead-letter topics, since it handles messages while topics are subscribed
+	deadLetterTopics := make(map[string]string, len(k.deadLetterTopics))
+	for topic, deadLetterTopic := range k.deadLetterTopics {
+		deadLetterTopics[topic] = deadLetterTopic
+	}
+
 	ready := make(chan bool)
 	k.consumer = consumer{
-		ready:    ready,
-		callback: handler,
+		ready:            ready,
+		callback:         c.callback,
+		bulkCallback:     c.bulkCallback,
+		bulkConfig:       c.bulkConfig,
+		deadLetterTopics: deadLetterTopics,
+		maxRetries:       k.maxRetries,
+		concurrency:      k.concurrency,
+		maxInFlight:      k.maxInFlight,
+		producer:         k.producer,
+		logger:           k.logger,
 	}
 
 	go func() {
@@ -253,30 +740,109 @@ func (k *Kafka) getKafkaMetadata(metadata pubsub.Metadata) (*kafkaMetadata, erro
 
 	//ignore SASL properties if authRequired is false
 	if meta.AuthRequired {
-		if val, ok := metadata.Properties["saslUsername"]; ok && val != "" {
-			meta.SaslUsername = val
-		} else {
-			return nil, errors.New("kafka error: missing SASL Username")
+		meta.SaslMechanism = sarama.SASLTypePlaintext
+		if val, ok := metadata.Properties["saslMechanism"]; ok && val != "" {
+			switch strings.ToUpper(val) {
+			case sarama.SASLTypePlaintext, sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512, sarama.SASLTypeOAuth:
+				meta.SaslMechanism = strings.ToUpper(val)
+			default:
+				return nil, fmt.Errorf("kafka error: invalid value '%s' for 'saslMechanism' attribute, supported values are: %s, %s, %s, %s", val, sarama.SASLTypePlaintext, sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512, sarama.SASLTypeOAuth)
+			}
 		}
 
-		if val, ok := metadata.Properties["saslPassword"]; ok && val != "" {
-			meta.SaslPassword = val
+		// OAUTHBEARER tokens are requested with client credentials instead of a username and password
+		if meta.SaslMechanism == sarama.SASLTypeOAuth {
+			meta.OAuth, err = kafkaauth.ParseOAuthOptions(metadata.Properties)
+			if err != nil {
+				return nil, err
+			}
 		} else {
-			return nil, errors.New("kafka error: missing SASL Password")
+			if val, ok := metadata.Properties["saslUsername"]; ok && val != "" {
+				meta.SaslUsername = val
+			} else {
+				return nil, errors.New("kafka error: missing SASL Username")
+			}
+
+			if val, ok := metadata.Properties["saslPassword"]; ok && val != "" {
+				meta.SaslPassword = val
+			} else {
+				return nil, errors.New("kafka error: missing SASL Password")
+			}
+		}
+	}
+
+	meta.InitialOffset = sarama.OffsetNewest
+	if val, ok := metadata.Properties["initialOffset"]; ok && val != "" {
+		switch strings.ToLower(val) {
+		case initialOffsetNewest:
+		case initialOffsetOldest:
+			meta.InitialOffset = sarama.OffsetOldest
+		default:
+			return nil, fmt.Errorf("kafka error: invalid value '%s' for 'initialOffset' attribute, supported values are: %s, %s", val, initialOffsetNewest, initialOffsetOldest)
 		}
 	}
 
+	// TLS was always enabled with SASL, so that stays the default
+	meta.EnableTLS = meta.AuthRequired
+	if val, ok := metadata.Properties["enableTLS"]; ok && val != "" {
+		meta.EnableTLS, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, errors.New("kafka error: invalid value for 'enableTLS' attribute")
+		}
+	}
+
+	if val, ok := metadata.Properties["skipVerify"]; ok && val != "" {
+		meta.SkipVerify, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, errors.New("kafka error: invalid value for 'skipVerify' attribute")
+		}
+	}
+
+	meta.MaxRetries = -1
+	if val, ok := metadata.Properties["maxRetries"]; ok && val != "" {
+		meta.MaxRetries, err = strconv.Atoi(val)
+		if err != nil || meta.MaxRetries < 0 {
+			return nil, fmt.Errorf("kafka error: invalid value '%s' for 'maxRetries' attribute", val)
+		}
+	}
+
+	// Messages of a partition are handled one at a time and in order by default
+	meta.Concurrency, meta.MaxInFlight, err = pubsub.TryGetConcurrency(metadata.Properties, 1)
+	if err != nil {
+		return nil, fmt.Errorf("kafka error: %s", err)
+	}
+	if meta.MaxInFlight == 0 {
+		meta.MaxInFlight = meta.Concurrency
+	}
+
+	meta.CACert = metadata.Properties["caCert"]
+	meta.ClientCert = metadata.Properties["clientCert"]
+	meta.ClientKey = metadata.Properties["clientKey"]
+	if (meta.ClientCert == "") != (meta.ClientKey == "") {
+		return nil, errors.New("kafka error: 'clientCert' and 'clientKey' attributes must be set together")
+	}
+	meta.ClientCertFile, meta.ClientKeyFile, err = kafkaauth.ParseClientCertFiles(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+	if meta.ClientCert != "" && meta.ClientCertFile != "" {
+		return nil, errors.New("kafka error: only one of the 'clientCert' and 'clientCertFile' attributes can be set")
+	}
+
 	return &meta, nil
 }
 
-func (k *Kafka) getSyncProducer(meta *kafkaMetadata) (sarama.SyncProducer, error) {
+func getSyncProducer(meta *kafkaMetadata) (sarama.SyncProducer, error) {
 	config := sarama.NewConfig()
+	// Kafka 0.11 and later store the headers of the dead letters
+	config.Version = sarama.V2_0_0_0
 	config.Producer.RequiredAcks = sarama.WaitForAll
 	config.Producer.Retry.Max = 5
 	config.Producer.Return.Successes = true
 
-	if k.authRequired {
-		updateAuthInfo(config, k.saslUsername, k.saslPassword)
+	err := updateAuthInfo(config, meta)
+	if err != nil {
+		return nil, err
 	}
 
 	producer, err := sarama.NewSyncProducer(meta.Brokers, config)
@@ -286,15 +852,46 @@ func (k *Kafka) getSyncProducer(meta *kafkaMetadata) (sarama.SyncProducer, error
 	return producer, nil
 }
 
-func updateAuthInfo(config *sarama.Config, saslUsername, saslPassword string) {
-	config.Net.SASL.Enable = true
-	config.Net.SASL.User = saslUsername
-	config.Net.SASL.Password = saslPassword
-	config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+// updateAuthInfo configures the SASL authentication and TLS of meta
+func updateAuthInfo(config *sarama.Config, meta *kafkaMetadata) error {
+	if meta.AuthRequired {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.User = meta.SaslUsername
+		config.Net.SASL.Password = meta.SaslPassword
+		config.Net.SASL.Mechanism = sarama.SASLMechanism(meta.SaslMechanism)
+
+		switch meta.SaslMechanism {
+		case sarama.SASLTypeOAuth:
+			config.Net.SASL.TokenProvider = kafkaauth.NewOAuthBearerTokenProvider(meta.OAuth)
+		case sarama.SASLTypeSCRAMSHA256:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{HashGeneratorFcn: sha256Generator}
+			}
+		case sarama.SASLTypeSCRAMSHA512:
+			config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{HashGeneratorFcn: sha512Generator}
+			}
+		}
+	}
+
+	if !meta.EnableTLS {
+		return nil
+	}
 
-	config.Net.TLS.Enable = true
-	config.Net.TLS.Config = &tls.Config{
-		//InsecureSkipVerify: true,
-		ClientAuth: 0,
+	tlsConfig, err := kafkaauth.NewTLSConfig(kafkaauth.TLSOptions{
+		CACert:         meta.CACert,
+		ClientCert:     meta.ClientCert,
+		ClientKey:      meta.ClientKey,
+		ClientCertFile: meta.ClientCertFile,
+		ClientKeyFile:  meta.ClientKeyFile,
+		SkipVerify:     meta.SkipVerify,
+	})
+	if err != nil {
+		return err
 	}
+
+	config.Net.TLS.Enable = true
+	config.Net.TLS.Config = tlsConfig
+
+	return nil
 }
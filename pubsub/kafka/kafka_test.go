@@ -6,12 +6,19 @@
 package kafka
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/dapr/dapr/pkg/logger"
 
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func getKafkaPubsub() *Kafka {
@@ -96,3 +103,430 @@ func TestInvalidAuthRequiredFlag(t *testing.T) {
 
 	assert.Equal(t, "kafka error: invalid value for 'authRequired' attribute", err.Error())
 }
+
+func TestInitialOffset(t *testing.T) {
+	k := getKafkaPubsub()
+	m := pubsub.Metadata{Properties: map[string]string{"brokers": "akfak.com:9092", "authRequired": "false"}}
+	meta, err := k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.OffsetNewest, meta.InitialOffset)
+
+	m.Properties["initialOffset"] = "Oldest"
+	meta, err = k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.OffsetOldest, meta.InitialOffset)
+
+	m.Properties["initialOffset"] = "latest"
+	_, err = k.getKafkaMetadata(m)
+	assert.Error(t, err)
+}
+
+func TestSaslMechanism(t *testing.T) {
+	k := getKafkaPubsub()
+	m := pubsub.Metadata{Properties: map[string]string{
+		"brokers":      "akfak.com:9092",
+		"authRequired": "true",
+		"saslUsername": "sassafras",
+		"saslPassword": "sassapass",
+	}}
+	meta, err := k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.SASLTypePlaintext, meta.SaslMechanism)
+	assert.True(t, meta.EnableTLS)
+
+	m.Properties["saslMechanism"] = "scram-sha-512"
+	m.Properties["enableTLS"] = "false"
+	meta, err = k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.SASLTypeSCRAMSHA512, meta.SaslMechanism)
+	assert.False(t, meta.EnableTLS)
+
+	config := sarama.NewConfig()
+	assert.NoError(t, updateAuthInfo(config, meta))
+	assert.True(t, config.Net.SASL.Enable)
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeSCRAMSHA512), config.Net.SASL.Mechanism)
+	assert.NotNil(t, config.Net.SASL.SCRAMClientGeneratorFunc)
+	assert.False(t, config.Net.TLS.Enable)
+	assert.NoError(t, config.Validate())
+
+	m.Properties["saslMechanism"] = "GSSAPI"
+	_, err = k.getKafkaMetadata(m)
+	assert.Error(t, err)
+}
+
+func TestOAuthBearer(t *testing.T) {
+	k := getKafkaPubsub()
+	m := pubsub.Metadata{Properties: map[string]string{
+		"brokers":       "akfak.com:9092",
+		"authRequired":  "true",
+		"saslMechanism": "oauthbearer",
+	}}
+	_, err := k.getKafkaMetadata(m)
+	assert.Error(t, err)
+
+	m.Properties["oidcTokenEndpoint"] = "https://login.example.com/token"
+	m.Properties["oidcClientID"] = "client"
+	m.Properties["oidcClientSecret"] = "secret"
+	meta, err := k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, sarama.SASLTypeOAuth, meta.SaslMechanism)
+	assert.Equal(t, "client", meta.OAuth.ClientID)
+
+	config := sarama.NewConfig()
+	assert.NoError(t, updateAuthInfo(config, meta))
+	assert.Equal(t, sarama.SASLMechanism(sarama.SASLTypeOAuth), config.Net.SASL.Mechanism)
+	assert.NotNil(t, config.Net.SASL.TokenProvider)
+	assert.NoError(t, config.Validate())
+}
+
+func TestTLS(t *testing.T) {
+	k := getKafkaPubsub()
+	m := pubsub.Metadata{Properties: map[string]string{
+		"brokers":      "akfak.com:9092",
+		"authRequired": "false",
+		"enableTLS":    "true",
+		"caCert":       "not a certificate",
+	}}
+	meta, err := k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.True(t, meta.EnableTLS)
+	assert.Error(t, updateAuthInfo(sarama.NewConfig(), meta))
+
+	m.Properties["clientCert"] = "cert"
+	_, err = k.getKafkaMetadata(m)
+	assert.Error(t, err)
+
+	delete(m.Properties, "caCert")
+	delete(m.Properties, "clientCert")
+	m.Properties["skipVerify"] = "true"
+	meta, err = k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	config := sarama.NewConfig()
+	assert.NoError(t, updateAuthInfo(config, meta))
+	assert.True(t, config.Net.TLS.Enable)
+	assert.True(t, config.Net.TLS.Config.InsecureSkipVerify)
+	assert.False(t, config.Net.SASL.Enable)
+
+	m.Properties["clientCertFile"] = "cert.pem"
+	_, err = k.getKafkaMetadata(m)
+	assert.Error(t, err)
+
+	m.Properties["clientKeyFile"] = "key.pem"
+	meta, err = k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, "cert.pem", meta.ClientCertFile)
+	assert.Error(t, updateAuthInfo(sarama.NewConfig(), meta))
+}
+
+// fakeSyncProducer fails to send the messages with the failed values
+type fakeSyncProducer struct {
+	failed map[string]bool
+	sent   []*sarama.ProducerMessage
+}
+
+func (p *fakeSyncProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	return 0, 0, p.SendMessages([]*sarama.ProducerMessage{msg})
+}
+
+func (p *fakeSyncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	var errs sarama.ProducerErrors
+	for _, msg := range msgs {
+		val, _ := msg.Value.Encode()
+		if p.failed[string(val)] {
+			errs = append(errs, &sarama.ProducerError{Msg: msg, Err: errors.New("produce error")})
+		} else {
+			p.sent = append(p.sent, msg)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (p *fakeSyncProducer) Close() error {
+	return nil
+}
+
+func TestBulkPublish(t *testing.T) {
+	producer := &fakeSyncProducer{failed: map[string]bool{"second": true}}
+	k := getKafkaPubsub()
+	k.producer = producer
+
+	res, err := k.BulkPublish(&pubsub.BulkPublishRequest{
+		Topic: "orders",
+		Entries: []pubsub.BulkMessageEntry{
+			{EntryID: "1", Data: []byte("first"), Metadata: map[string]string{partitionKey: "key"}},
+			{EntryID: "2", Data: []byte("second")},
+		},
+	})
+
+	assert.Error(t, err)
+	assert.Len(t, res.FailedEntries, 1)
+	assert.Equal(t, "2", res.FailedEntries[0].EntryID)
+	assert.Len(t, producer.sent, 1)
+	assert.Equal(t, "orders", producer.sent[0].Topic)
+	assert.Equal(t, sarama.StringEncoder("key"), producer.sent[0].Key)
+}
+
+type fakeSession struct {
+	ctx    context.Context
+	marked []int64
+	lock   sync.Mutex
+}
+
+func (s *fakeSession) Claims() map[string][]int32 { return nil }
+
+func (s *fakeSession) MemberID() string { return "" }
+
+func (s *fakeSession) GenerationID() int32 { return 0 }
+
+func (s *fakeSession) MarkOffset(topic string, partition int32, offset int64, metadata string) {}
+
+func (s *fakeSession) ResetOffset(topic string, partition int32, offset int64, metadata string) {}
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+type fakeClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Topic() string { return "orders" }
+
+func (c *fakeClaim) Partition() int32 { return 0 }
+
+func (c *fakeClaim) InitialOffset() int64 { return 0 }
+
+func (c *fakeClaim) HighWaterMarkOffset() int64 { return 0 }
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func TestConsumeBulk(t *testing.T) {
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, 5)}
+	for i := 0; i < 5; i++ {
+		claim.messages <- &sarama.ConsumerMessage{Topic: "orders", Offset: int64(i), Value: []byte(fmt.Sprintf("message%d", i))}
+	}
+	close(claim.messages)
+
+	var bulks [][]string
+	failedOnce := false
+	c := &consumer{
+		bulkCallback: func(msg *pubsub.BulkMessage) ([]pubsub.BulkEntryStatus, error) {
+			var ids []string
+			for _, entry := range msg.Entries {
+				ids = append(ids, entry.EntryID)
+			}
+			bulks = append(bulks, ids)
+
+			// The second entry fails once, and is the only one handled again
+			if !failedOnce {
+				failedOnce = true
+				return []pubsub.BulkEntryStatus{{EntryID: "1", Error: errors.New("handler error")}}, nil
+			}
+			return nil, nil
+		},
+		bulkConfig: pubsub.BulkSubscribeConfig{MaxMessagesCount: 2, MaxAwaitDuration: time.Minute},
+	}
+	session := &fakeSession{ctx: context.Background()}
+
+	err := c.ConsumeClaim(session, claim)
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"0", "1"}, {"1"}, {"2", "3"}, {"4"}}, bulks)
+	assert.Equal(t, []int64{1, 3, 4}, session.marked)
+}
+
+func TestDeadLetter(t *testing.T) {
+	t.Run("messages are published to the dead-letter topic", func(t *testing.T) {
+		producer := &fakeSyncProducer{}
+		c := &consumer{
+			callback: func(msg *pubsub.NewMessage) error {
+				return errors.New("handler error")
+			},
+			deadLetterTopics: map[string]string{"orders": "orders-dead"},
+			maxRetries:       0,
+			producer:         producer,
+		}
+		session := &fakeSession{ctx: context.Background()}
+
+		err := c.handle(session, "orders", &sarama.ConsumerMessage{Topic: "orders", Key: []byte("key"), Value: []byte("order")})
+
+		assert.NoError(t, err)
+		assert.Len(t, producer.sent, 1)
+		assert.Equal(t, "orders-dead", producer.sent[0].Topic)
+		assert.Equal(t, sarama.StringEncoder("key"), producer.sent[0].Key)
+		headers := map[string]string{}
+		for _, header := range producer.sent[0].Headers {
+			headers[string(header.Key)] = string(header.Value)
+		}
+		assert.Equal(t, map[string]string{pubsub.DeadLetterOriginalTopicKey: "orders", pubsub.DeadLetterErrorKey: "handler error"}, headers)
+	})
+
+	t.Run("messages are retried until the dead letter is published", func(t *testing.T) {
+		producer := &fakeSyncProducer{failed: map[string]bool{"order": true}}
+		c := &consumer{
+			callback: func(msg *pubsub.NewMessage) error {
+				return errors.New("handler error")
+			},
+			deadLetterTopics: map[string]string{"orders": "orders-dead"},
+			producer:         producer,
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		session := &fakeSession{ctx: ctx}
+
+		err := c.handle(session, "orders", &sarama.ConsumerMessage{Topic: "orders", Value: []byte("order")})
+
+		assert.Error(t, err)
+		assert.Empty(t, producer.sent)
+	})
+
+	t.Run("failed bulk entries are published to the dead-letter topic", func(t *testing.T) {
+		producer := &fakeSyncProducer{}
+		c := &consumer{
+			bulkCallback: func(msg *pubsub.BulkMessage) ([]pubsub.BulkEntryStatus, error) {
+				return []pubsub.BulkEntryStatus{{EntryID: "1", Error: errors.New("handler error")}}, nil
+			},
+			deadLetterTopics: map[string]string{"orders": "orders-dead"},
+			maxRetries:       0,
+			producer:         producer,
+		}
+		session := &fakeSession{ctx: context.Background()}
+		messages := []*sarama.ConsumerMessage{{Offset: 0, Value: []byte("first")}, {Offset: 1, Value: []byte("second")}}
+
+		err := c.handleBulk(session, "orders", messages)
+
+		assert.NoError(t, err)
+		assert.Len(t, producer.sent, 1)
+		assert.Equal(t, sarama.ByteEncoder("second"), producer.sent[0].Value)
+	})
+
+	t.Run("messages of topics without dead-letter topic are not dead lettered", func(t *testing.T) {
+		c := &consumer{deadLetterTopics: map[string]string{"orders": "orders-dead"}, maxRetries: -1}
+
+		assert.False(t, c.retriesExhausted("payments", 10))
+		assert.False(t, c.retriesExhausted("orders", defaultDeadLetterMaxRetries-1))
+		assert.True(t, c.retriesExhausted("orders", defaultDeadLetterMaxRetries))
+	})
+}
+
+func TestMaxRetries(t *testing.T) {
+	k := getKafkaPubsub()
+	m := pubsub.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false"}}
+
+	meta, err := k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, meta.MaxRetries)
+
+	m.Properties["maxRetries"] = "5"
+	meta, err = k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, meta.MaxRetries)
+
+	m.Properties["maxRetries"] = "-2"
+	_, err = k.getKafkaMetadata(m)
+	assert.Error(t, err)
+}
+
+func TestTTL(t *testing.T) {
+	t.Run("messages with a TTL have an expiration header", func(t *testing.T) {
+		msg, err := producerMessage(&pubsub.PublishRequest{Topic: "orders", Metadata: map[string]string{pubsub.TTLMetadataKey: "60"}})
+
+		assert.NoError(t, err)
+		require.Len(t, msg.Headers, 1)
+		assert.Equal(t, pubsub.ExpirationMetadataKey, string(msg.Headers[0].Key))
+		expiration, err := time.Parse(time.RFC3339, string(msg.Headers[0].Value))
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), expiration, 2*time.Second)
+
+		_, err = producerMessage(&pubsub.PublishRequest{Topic: "orders", Metadata: map[string]string{pubsub.TTLMetadataKey: "soon"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("expired messages are not handled", func(t *testing.T) {
+		var handled []string
+		c := &consumer{
+			callback: func(msg *pubsub.NewMessage) error {
+				handled = append(handled, string(msg.Data))
+				return nil
+			},
+		}
+		session := &fakeSession{ctx: context.Background()}
+		expired := []*sarama.RecordHeader{{Key: []byte(pubsub.ExpirationMetadataKey), Value: []byte(pubsub.Expiration(0, time.Now().Add(-time.Minute)))}}
+		alive := []*sarama.RecordHeader{{Key: []byte(pubsub.ExpirationMetadataKey), Value: []byte(pubsub.Expiration(time.Minute, time.Now()))}}
+
+		assert.NoError(t, c.handle(session, "orders", &sarama.ConsumerMessage{Value: []byte("expired"), Headers: expired}))
+		assert.NoError(t, c.handle(session, "orders", &sarama.ConsumerMessage{Value: []byte("alive"), Headers: alive}))
+
+		assert.Equal(t, []string{"alive"}, handled)
+	})
+}
+
+func TestConsumeConcurrently(t *testing.T) {
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, 6)}
+	for i := 0; i < 6; i++ {
+		claim.messages <- &sarama.ConsumerMessage{Topic: "orders", Offset: int64(i), Value: []byte{byte(i)}}
+	}
+	close(claim.messages)
+
+	var lock sync.Mutex
+	handling := 0
+	maxHandling := 0
+	c := &consumer{
+		callback: func(msg *pubsub.NewMessage) error {
+			lock.Lock()
+			handling++
+			if handling > maxHandling {
+				maxHandling = handling
+			}
+			lock.Unlock()
+
+			// The earlier messages take longer, so that the later ones are done first
+			time.Sleep(time.Duration(6-msg.Data[0]) * time.Millisecond)
+
+			lock.Lock()
+			handling--
+			lock.Unlock()
+			return nil
+		},
+		concurrency: 2,
+		maxInFlight: 3,
+	}
+	session := &fakeSession{ctx: context.Background()}
+
+	err := c.ConsumeClaim(session, claim)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, maxHandling)
+	require.NotEmpty(t, session.marked)
+	assert.Equal(t, int64(5), session.marked[len(session.marked)-1])
+	for i := 1; i < len(session.marked); i++ {
+		assert.Greater(t, session.marked[i], session.marked[i-1])
+	}
+}
+
+func TestConcurrencyMetadata(t *testing.T) {
+	k := getKafkaPubsub()
+	m := pubsub.Metadata{Properties: map[string]string{"brokers": "a", "authRequired": "false"}}
+
+	meta, err := k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, meta.Concurrency)
+	assert.Equal(t, 1, meta.MaxInFlight)
+
+	m.Properties[pubsub.ConcurrencyKey] = "4"
+	meta, err = k.getKafkaMetadata(m)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, meta.Concurrency)
+	assert.Equal(t, 4, meta.MaxInFlight)
+
+	m.Properties[pubsub.MaxInFlightKey] = "2"
+	_, err = k.getKafkaMetadata(m)
+	assert.Error(t, err)
+}
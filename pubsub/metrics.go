@@ -0,0 +1,138 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/metrics"
+)
+
+// MetricsPubSub records the count, latency and errors of the operations of a pub/sub, and of the handling of the
+// messages it delivers
+type MetricsPubSub struct {
+	pubsub    PubSub
+	component string
+	recorder  metrics.Recorder
+}
+
+// MetricsBulkPublisherPubSub is a MetricsPubSub for a BulkPublisher
+type MetricsBulkPublisherPubSub struct {
+	*MetricsPubSub
+	publisher BulkPublisher
+}
+
+// MetricsBulkSubscriberPubSub is a MetricsPubSub for a BulkSubscriber
+type MetricsBulkSubscriberPubSub struct {
+	*MetricsPubSub
+	subscriber BulkSubscriber
+}
+
+// MetricsBulkPubSub is a MetricsPubSub for a BulkPublisher that is also a BulkSubscriber
+type MetricsBulkPubSub struct {
+	*MetricsPubSub
+	publisher  BulkPublisher
+	subscriber BulkSubscriber
+}
+
+// NewMetricsPubSub wraps p so that its operations are recorded with the name of the component.
+// The result implements the same optional interfaces as p: it is a *MetricsBulkPublisherPubSub when p is a
+// BulkPublisher, a *MetricsBulkSubscriberPubSub when it is a BulkSubscriber, a *MetricsBulkPubSub when it is both,
+// and a *MetricsPubSub otherwise.
+func NewMetricsPubSub(p PubSub, component string, recorder metrics.Recorder) PubSub {
+	m := &MetricsPubSub{pubsub: p, component: component, recorder: recorder}
+	publisher, isPublisher := p.(BulkPublisher)
+	subscriber, isSubscriber := p.(BulkSubscriber)
+	switch {
+	case isPublisher && isSubscriber:
+		return &MetricsBulkPubSub{MetricsPubSub: m, publisher: publisher, subscriber: subscriber}
+	case isPublisher:
+		return &MetricsBulkPublisherPubSub{MetricsPubSub: m, publisher: publisher}
+	case isSubscriber:
+		return &MetricsBulkSubscriberPubSub{MetricsPubSub: m, subscriber: subscriber}
+	default:
+		return m
+	}
+}
+
+// Init initializes the wrapped pub/sub
+func (p *MetricsPubSub) Init(metadata Metadata) error {
+	return metrics.Measure(p.recorder, p.component, "init", func() error {
+		return p.pubsub.Init(metadata)
+	})
+}
+
+// GetComponentMetadata returns the metadata of the wrapped pub/sub
+func (p *MetricsPubSub) GetComponentMetadata() metadata.ComponentMetadata {
+	return p.pubsub.GetComponentMetadata()
+}
+
+// Ping pings the wrapped pub/sub
+func (p *MetricsPubSub) Ping() error {
+	return health.Ping(p.pubsub)
+}
+
+// Publish publishes a message
+func (p *MetricsPubSub) Publish(req *PublishRequest) error {
+	return metrics.Measure(p.recorder, p.component, "publish", func() error {
+		return p.pubsub.Publish(req)
+	})
+}
+
+// Subscribe subscribes to the topic, the handling of each message being recorded as a handle operation
+func (p *MetricsPubSub) Subscribe(req SubscribeRequest, handler func(msg *NewMessage) error) error {
+	return metrics.Measure(p.recorder, p.component, "subscribe", func() error {
+		return p.pubsub.Subscribe(req, func(msg *NewMessage) error {
+			return metrics.Measure(p.recorder, p.component, "handle", func() error {
+				return handler(msg)
+			})
+		})
+	})
+}
+
+// BulkPublish publishes the messages with the wrapped pub/sub
+func (p *MetricsBulkPublisherPubSub) BulkPublish(req *BulkPublishRequest) (BulkPublishResponse, error) {
+	return p.bulkPublish(p.publisher, req)
+}
+
+// BulkPublish publishes the messages with the wrapped pub/sub
+func (p *MetricsBulkPubSub) BulkPublish(req *BulkPublishRequest) (BulkPublishResponse, error) {
+	return p.bulkPublish(p.publisher, req)
+}
+
+// BulkSubscribe subscribes to the topic with the wrapped pub/sub, the handling of each bulk message being recorded
+// as a bulkHandle operation
+func (p *MetricsBulkSubscriberPubSub) BulkSubscribe(req SubscribeRequest, config BulkSubscribeConfig, handler BulkHandler) error {
+	return p.bulkSubscribe(p.subscriber, req, config, handler)
+}
+
+// BulkSubscribe subscribes to the topic with the wrapped pub/sub, the handling of each bulk message being recorded
+// as a bulkHandle operation
+func (p *MetricsBulkPubSub) BulkSubscribe(req SubscribeRequest, config BulkSubscribeConfig, handler BulkHandler) error {
+	return p.bulkSubscribe(p.subscriber, req, config, handler)
+}
+
+func (p *MetricsPubSub) bulkPublish(publisher BulkPublisher, req *BulkPublishRequest) (BulkPublishResponse, error) {
+	var resp BulkPublishResponse
+	err := metrics.Measure(p.recorder, p.component, "bulkPublish", func() (err error) {
+		resp, err = publisher.BulkPublish(req)
+		return err
+	})
+	return resp, err
+}
+
+func (p *MetricsPubSub) bulkSubscribe(subscriber BulkSubscriber, req SubscribeRequest, config BulkSubscribeConfig, handler BulkHandler) error {
+	return metrics.Measure(p.recorder, p.component, "bulkSubscribe", func() error {
+		return subscriber.BulkSubscribe(req, config, func(msg *BulkMessage) ([]BulkEntryStatus, error) {
+			var statuses []BulkEntryStatus
+			err := metrics.Measure(p.recorder, p.component, "bulkHandle", func() (err error) {
+				statuses, err = handler(msg)
+				return err
+			})
+			return statuses, err
+		})
+	})
+}
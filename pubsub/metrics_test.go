@@ -0,0 +1,96 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsPubSub(t *testing.T) {
+	c := metrics.NewCollector()
+	f := &fakePubSub{}
+	p := NewMetricsPubSub(f, "pubsub", c)
+
+	require.NoError(t, p.Init(Metadata{}))
+	require.NoError(t, p.Publish(&PublishRequest{Topic: "orders"}))
+	require.Error(t, p.Publish(&PublishRequest{Topic: "orders", Metadata: map[string]string{"fail": "true"}}))
+	require.NoError(t, p.Subscribe(SubscribeRequest{Topic: "orders"}, func(msg *NewMessage) error {
+		if string(msg.Data) == "bad" {
+			return errors.New("handler error")
+		}
+		return nil
+	}))
+	errs := f.deliver("good", "bad", "good")
+	assert.Error(t, errs[1])
+
+	stats := c.Stats()
+	publish := stats[metrics.OperationKey{Component: "pubsub", Operation: "publish"}]
+	assert.Equal(t, int64(2), publish.Count)
+	assert.Equal(t, int64(1), publish.Errors)
+	handle := stats[metrics.OperationKey{Component: "pubsub", Operation: "handle"}]
+	assert.Equal(t, int64(3), handle.Count)
+	assert.Equal(t, int64(1), handle.Errors)
+	assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "pubsub", Operation: "subscribe"}].Count)
+	assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "pubsub", Operation: "init"}].Count)
+}
+
+// fakeBulkSubscriberPubSub delivers a bulk message of one entry to the handler of BulkSubscribe
+type fakeBulkSubscriberPubSub struct {
+	fakeBulkPubSub
+	statuses []BulkEntryStatus
+}
+
+func (f *fakeBulkSubscriberPubSub) BulkSubscribe(req SubscribeRequest, config BulkSubscribeConfig, handler BulkHandler) error {
+	var err error
+	f.statuses, err = handler(&BulkMessage{Topic: req.Topic, Entries: []BulkMessageEntry{{EntryID: "1"}}})
+	return err
+}
+
+func TestMetricsPubSubForwardsBulkInterfaces(t *testing.T) {
+	t.Run("Bulk publisher", func(t *testing.T) {
+		c := metrics.NewCollector()
+		p := NewMetricsPubSub(&fakeBulkPubSub{}, "pubsub", c)
+
+		publisher, ok := p.(BulkPublisher)
+		require.True(t, ok)
+		_, isSubscriber := p.(BulkSubscriber)
+		assert.False(t, isSubscriber)
+		_, err := publisher.BulkPublish(&BulkPublishRequest{Topic: "orders"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), c.Stats()[metrics.OperationKey{Component: "pubsub", Operation: "bulkPublish"}].Count)
+	})
+
+	t.Run("Bulk publisher and subscriber", func(t *testing.T) {
+		c := metrics.NewCollector()
+		p := NewMetricsPubSub(&fakeBulkSubscriberPubSub{}, "pubsub", c)
+
+		_, isPublisher := p.(BulkPublisher)
+		assert.True(t, isPublisher)
+		subscriber, ok := p.(BulkSubscriber)
+		require.True(t, ok)
+		err := subscriber.BulkSubscribe(SubscribeRequest{Topic: "orders"}, BulkSubscribeConfig{}, func(msg *BulkMessage) ([]BulkEntryStatus, error) {
+			return nil, errors.New("handler error")
+		})
+		assert.Error(t, err)
+		stats := c.Stats()
+		assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "pubsub", Operation: "bulkHandle"}].Errors)
+		assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "pubsub", Operation: "bulkSubscribe"}].Count)
+	})
+
+	t.Run("Not a bulk pub/sub", func(t *testing.T) {
+		p := NewMetricsPubSub(&fakePubSub{}, "pubsub", metrics.NewCollector())
+
+		_, isPublisher := p.(BulkPublisher)
+		assert.False(t, isPublisher)
+		_, isSubscriber := p.(BulkSubscriber)
+		assert.False(t, isSubscriber)
+	})
+}
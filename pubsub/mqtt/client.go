@@ -0,0 +1,120 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mqtt
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/dapr/dapr/pkg/logger"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// messageHandler handles the payload of a message, the topic it was published to, and whether it is a retained message
+type messageHandler func(topic string, payload []byte, retained bool)
+
+// client is a connection to a broker with one of the MQTT protocol versions
+type client interface {
+	publish(topic string, qos byte, retain bool, payload []byte) error
+	// subscribe subscribes to the topic, which can have the + and # wildcards, also after reconnecting
+	subscribe(topic string, handler messageHandler) error
+	// ping returns an error when the client is not connected to the broker
+	ping() error
+}
+
+// v3Client is a client of MQTT v3.1.1
+type v3Client struct {
+	client        mqtt.Client
+	metadata      *metadata
+	subscriptions map[string]messageHandler
+	lock          sync.Mutex
+	logger        logger.Logger
+}
+
+func newV3Client(uri *url.URL, tlsConfig *tls.Config, m *metadata, logger logger.Logger) (*v3Client, error) {
+	c := &v3Client{metadata: m, subscriptions: map[string]messageHandler{}, logger: logger}
+
+	opts := createClientOptions(uri, tlsConfig, m)
+	opts.SetOnConnectHandler(c.resubscribe)
+	c.client = mqtt.NewClient(opts)
+	token := c.client.Connect()
+	for !token.WaitTimeout(defaultWait) {
+	}
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func createClientOptions(uri *url.URL, tlsConfig *tls.Config, m *metadata) *mqtt.ClientOptions {
+	opts := mqtt.NewClientOptions()
+	opts.SetProtocolVersion(4)
+	opts.SetClientID(m.clientID)
+	opts.SetCleanSession(m.cleanSession)
+	if tlsConfig != nil {
+		opts.AddBroker(fmt.Sprintf("ssl://%s", brokerAddress(uri, m)))
+		opts.SetTLSConfig(tlsConfig)
+	} else {
+		opts.AddBroker(fmt.Sprintf("tcp://%s", brokerAddress(uri, m)))
+	}
+	opts.SetUsername(uri.User.Username())
+	password, _ := uri.User.Password()
+	opts.SetPassword(password)
+	return opts
+}
+
+func (c *v3Client) publish(topic string, qos byte, retain bool, payload []byte) error {
+	token := c.client.Publish(topic, qos, retain, payload)
+	if !token.WaitTimeout(defaultWait) {
+		return fmt.Errorf("timeout publishing to topic %s", topic)
+	}
+	return token.Error()
+}
+
+func (c *v3Client) subscribe(topic string, handler messageHandler) error {
+	c.lock.Lock()
+	c.subscriptions[topic] = handler
+	c.lock.Unlock()
+
+	return c.subscribeToBroker(topic, handler)
+}
+
+func (c *v3Client) ping() error {
+	if !c.client.IsConnectionOpen() {
+		return fmt.Errorf("mqtt not connected to the broker")
+	}
+	return nil
+}
+
+func (c *v3Client) subscribeToBroker(topic string, handler messageHandler) error {
+	token := c.client.Subscribe(topic, c.metadata.qos, func(client mqtt.Client, mqttMsg mqtt.Message) {
+		handler(mqttMsg.Topic(), mqttMsg.Payload(), mqttMsg.Retained())
+	})
+	if !token.WaitTimeout(defaultWait) {
+		return fmt.Errorf("timeout subscribing to topic %s", topic)
+	}
+	return token.Error()
+}
+
+// resubscribe subscribes again after reconnecting, since the subscriptions of a clean session are not kept by the broker
+func (c *v3Client) resubscribe(mqtt.Client) {
+	c.lock.Lock()
+	subscriptions := make(map[string]messageHandler, len(c.subscriptions))
+	for topic, handler := range c.subscriptions {
+		subscriptions[topic] = handler
+	}
+	c.lock.Unlock()
+
+	for topic, handler := range subscriptions {
+		err := c.subscribeToBroker(topic, handler)
+		if err != nil {
+			c.logger.Errorf("mqtt error subscribing again to topic %s: %s", topic, err)
+		}
+	}
+}
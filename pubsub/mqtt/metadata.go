@@ -5,10 +5,20 @@
 
 package mqtt
 
+import "time"
+
 type metadata struct {
-	url          string
-	clientID     string
-	qos          byte
-	retain       bool
-	cleanSession bool
+	url             string
+	clientID        string
+	protocolVersion string
+	qos             byte
+	retain          bool
+	// retainHandling is whether the retained messages of a topic are delivered when subscribing to it
+	retainHandling byte
+	cleanSession   bool
+	// sessionExpiry is how long the broker keeps a persistent session after disconnecting, with MQTT v5
+	sessionExpiry time.Duration
+	caCert        string
+	clientCert    string
+	clientKey     string
 }
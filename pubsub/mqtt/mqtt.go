@@ -6,39 +6,67 @@
 package mqtt
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
 	"net/url"
 	"strconv"
 	"time"
 
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/google/uuid"
 
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
-	mqtt "github.com/eclipse/paho.mqtt.golang"
 )
 
 const (
 	// Keys
-	mqttURL          = "url"
-	mqttQOS          = "qos"
-	mqttRetain       = "retain"
-	mqttClientID     = "consumerID"
-	mqttCleanSession = "cleanSession"
+	mqttURL             = "url"
+	mqttQOS             = "qos"
+	mqttRetain          = "retain"
+	mqttClientID        = "consumerID"
+	mqttCleanSession    = "cleanSession"
+	mqttProtocolVersion = "protocolVersion"
+	mqttRetainHandling  = "retainHandling"
+	mqttSessionExpiry   = "sessionExpiry"
+	mqttCACert          = "caCert"
+	mqttClientCert      = "clientCert"
+	mqttClientKey       = "clientKey"
 
 	// errors
 	errorMsgPrefix = "mqtt pub sub error:"
 
+	// Protocol versions
+	protocolVersion311 = "3.1.1"
+	protocolVersion5   = "5"
+
+	// Retain handling, with the values of the MQTT v5 subscription option
+	retainHandlingSend      = 0
+	retainHandlingSendIfNew = 1
+	retainHandlingDoNotSend = 2
+
 	// Defaults
-	defaultQOS          = 0
-	defaultRetain       = false
-	defaultWait         = 3 * time.Second
-	defaultCleanSession = true
+	defaultQOS             = 0
+	defaultRetain          = false
+	defaultWait            = 3 * time.Second
+	defaultCleanSession    = true
+	defaultProtocolVersion = protocolVersion311
+	defaultRetainHandling  = retainHandlingSend
+	defaultPort            = "1883"
+	defaultTLSPort         = "8883"
 )
 
+var retainHandlings = map[string]byte{
+	"send":      retainHandlingSend,
+	"sendIfNew": retainHandlingSendIfNew,
+	"doNotSend": retainHandlingDoNotSend,
+}
+
 // mqttPubSub type allows sending and receiving data to/from MQTT broker.
 type mqttPubSub struct {
-	client   mqtt.Client
+	client   client
 	metadata *metadata
 	logger   logger.Logger
 }
@@ -61,11 +89,11 @@ func parseMQTTMetaData(md pubsub.Metadata) (*metadata, error) {
 	// optional configuration settings
 	m.qos = defaultQOS
 	if val, ok := md.Properties[mqttQOS]; ok && val != "" {
-		qosInt, err := strconv.Atoi(val)
+		qos, err := parseQOS(val)
 		if err != nil {
-			return &m, fmt.Errorf("%s invalid qos %s, %s", errorMsgPrefix, val, err)
+			return &m, err
 		}
-		m.qos = byte(qosInt)
+		m.qos = qos
 	}
 
 	m.retain = defaultRetain
@@ -91,9 +119,54 @@ func parseMQTTMetaData(md pubsub.Metadata) (*metadata, error) {
 		}
 	}
 
+	m.protocolVersion = defaultProtocolVersion
+	if val, ok := md.Properties[mqttProtocolVersion]; ok && val != "" {
+		if val != protocolVersion311 && val != protocolVersion5 {
+			return &m, fmt.Errorf("%s invalid protocol version %s, accepted values are %s and %s", errorMsgPrefix, val, protocolVersion311, protocolVersion5)
+		}
+		m.protocolVersion = val
+	}
+
+	m.retainHandling = defaultRetainHandling
+	if val, ok := md.Properties[mqttRetainHandling]; ok && val != "" {
+		retainHandling, ok := retainHandlings[val]
+		if !ok {
+			return &m, fmt.Errorf("%s invalid retain handling %s, accepted values are send, sendIfNew and doNotSend", errorMsgPrefix, val)
+		}
+		// Only the broker knows whether a subscription is new
+		if retainHandling == retainHandlingSendIfNew && m.protocolVersion != protocolVersion5 {
+			return &m, fmt.Errorf("%s retain handling %s requires protocol version %s", errorMsgPrefix, val, protocolVersion5)
+		}
+		m.retainHandling = retainHandling
+	}
+
+	if val, ok := md.Properties[mqttSessionExpiry]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d < 0 {
+			return &m, fmt.Errorf("%s invalid session expiry %s", errorMsgPrefix, val)
+		}
+		m.sessionExpiry = d
+	}
+
+	m.caCert = md.Properties[mqttCACert]
+	m.clientCert = md.Properties[mqttClientCert]
+	m.clientKey = md.Properties[mqttClientKey]
+	if (m.clientCert == "") != (m.clientKey == "") {
+		return &m, fmt.Errorf("%s clientCert and clientKey must be set together", errorMsgPrefix)
+	}
+
 	return &m, nil
 }
 
+func parseQOS(val string) (byte, error) {
+	qos, err := strconv.Atoi(val)
+	if err != nil || qos < 0 || qos > 2 {
+		return 0, fmt.Errorf("%s invalid qos %s, accepted values are 0, 1 and 2", errorMsgPrefix, val)
+	}
+
+	return byte(qos), nil
+}
+
 // Init parses metadata and creates a new Pub Sub client.
 func (m *mqttPubSub) Init(metadata pubsub.Metadata) error {
 	mqttMeta, err := parseMQTTMetaData(metadata)
@@ -102,65 +175,160 @@ func (m *mqttPubSub) Init(metadata pubsub.Metadata) error {
 	}
 	m.metadata = mqttMeta
 
+	if metadata.Properties[mqttClientID] == "" && !m.metadata.cleanSession {
+		m.logger.Warnf("mqtt persistent session of generated client id %s will not be resumed after a restart", m.metadata.clientID)
+	}
+
 	uri, err := url.Parse(m.metadata.url)
 	if err != nil {
 		return err
 	}
-	client, err := m.connect(uri)
+
+	tlsConfig, err := newTLSConfig(uri, m.metadata)
 	if err != nil {
 		return err
 	}
 
-	m.client = client
+	if m.metadata.protocolVersion == protocolVersion5 {
+		m.client, err = newV5Client(uri, tlsConfig, m.metadata, m.logger)
+	} else {
+		m.client, err = newV3Client(uri, tlsConfig, m.metadata, m.logger)
+	}
+	if err != nil {
+		return err
+	}
 
 	m.logger.Debug("mqtt message bus initialization complete")
 	return nil
 }
 
-// Publish the topic to mqtt pub sub.
+// GetComponentMetadata returns the metadata fields of the MQTT pub/sub
+func (m *mqttPubSub) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: mqttURL, Type: componentmetadata.TypeString, Required: true},
+			{Name: mqttQOS, Type: componentmetadata.TypeInt, Default: strconv.Itoa(defaultQOS)},
+			{Name: mqttRetain, Type: componentmetadata.TypeBool, Default: strconv.FormatBool(defaultRetain)},
+			{Name: mqttClientID, Type: componentmetadata.TypeString},
+			{Name: mqttCleanSession, Type: componentmetadata.TypeBool, Default: strconv.FormatBool(defaultCleanSession)},
+			{Name: mqttProtocolVersion, Type: componentmetadata.TypeString, Default: defaultProtocolVersion},
+			{Name: mqttRetainHandling, Type: componentmetadata.TypeString, Default: "send"},
+			{Name: mqttSessionExpiry, Type: componentmetadata.TypeDuration},
+			{Name: mqttCACert, Type: componentmetadata.TypeString},
+			{Name: mqttClientCert, Type: componentmetadata.TypeString},
+			{Name: mqttClientKey, Type: componentmetadata.TypeString},
+		},
+	}
+}
+
+// Ping returns an error when the client is not connected to the broker, for health checks
+func (m *mqttPubSub) Ping() error {
+	if m.client == nil {
+		return fmt.Errorf("the MQTT pub/sub is not initialized")
+	}
+	return m.client.ping()
+}
+
+// Publish the topic to mqtt pub sub. The qos and retain metadata of the request override the ones of the component.
 func (m *mqttPubSub) Publish(req *pubsub.PublishRequest) error {
 	m.logger.Debugf("mqtt publishing topic %s with data: %v", req.Topic, req.Data)
 
-	token := m.client.Publish(req.Topic, m.metadata.qos, m.metadata.retain, req.Data)
-	if !token.WaitTimeout(defaultWait) || token.Error() != nil {
-		return fmt.Errorf("mqtt error from publish: %v", token.Error())
+	qos := m.metadata.qos
+	if val, ok := req.Metadata[mqttQOS]; ok && val != "" {
+		var err error
+		qos, err = parseQOS(val)
+		if err != nil {
+			return err
+		}
+	}
+
+	retain := m.metadata.retain
+	if val, ok := req.Metadata[mqttRetain]; ok && val != "" {
+		var err error
+		retain, err = strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("%s invalid retain %s, %s", errorMsgPrefix, val, err)
+		}
+	}
+
+	err := m.client.publish(req.Topic, qos, retain, req.Data)
+	if err != nil {
+		return fmt.Errorf("mqtt error from publish: %v", err)
 	}
 	return nil
 }
 
-// Subscribe to the mqtt pub sub topic.
+// Subscribe to the mqtt pub sub topic, which can have wildcards. The topic that messages were published to is in their
+// receivedTopic metadata.
 func (m *mqttPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
-	token := m.client.Subscribe(
-		req.Topic,
-		m.metadata.qos,
-		func(client mqtt.Client, mqttMsg mqtt.Message) {
-			handler(&pubsub.NewMessage{Topic: req.Topic, Data: mqttMsg.Payload()})
+	err := m.client.subscribe(req.Topic, func(topic string, payload []byte, retained bool) {
+		// Brokers only set the retain flag of the retained messages sent when subscribing
+		if retained && m.metadata.retainHandling == retainHandlingDoNotSend {
+			return
+		}
+
+		err := handler(&pubsub.NewMessage{
+			Topic:    req.Topic,
+			Data:     payload,
+			Metadata: map[string]string{pubsub.ReceivedTopicMetadataKey: topic},
 		})
-	if err := token.Error(); err != nil {
+		if err != nil {
+			m.logger.Errorf("mqtt error handling message from topic %s: %s", req.Topic, err)
+		}
+	})
+	if err != nil {
 		return fmt.Errorf("mqtt error from subscribe: %v", err)
 	}
 	return nil
 }
 
-func (m *mqttPubSub) connect(uri *url.URL) (mqtt.Client, error) {
-	opts := m.createClientOptions(uri)
-	client := mqtt.NewClient(opts)
-	token := client.Connect()
-	for !token.WaitTimeout(defaultWait) {
+// isTLS returns whether the broker is connected to with TLS, which is the case for the ssl, tls and mqtts schemes or
+// when certificates are given
+func isTLS(uri *url.URL, m *metadata) bool {
+	switch uri.Scheme {
+	case "ssl", "tls", "mqtts":
+		return true
 	}
-	if err := token.Error(); err != nil {
-		return nil, err
+
+	return m.caCert != "" || m.clientCert != ""
+}
+
+// brokerAddress returns the host and port of the broker, with the default port of the scheme if it has none
+func brokerAddress(uri *url.URL, m *metadata) string {
+	if uri.Port() != "" {
+		return uri.Host
+	}
+
+	if isTLS(uri, m) {
+		return net.JoinHostPort(uri.Hostname(), defaultTLSPort)
 	}
-	return client, nil
+
+	return net.JoinHostPort(uri.Hostname(), defaultPort)
 }
 
-func (m *mqttPubSub) createClientOptions(uri *url.URL) *mqtt.ClientOptions {
-	opts := mqtt.NewClientOptions()
-	opts.SetClientID(m.metadata.clientID)
-	opts.SetCleanSession(m.metadata.cleanSession)
-	opts.AddBroker(uri.Host)
-	opts.SetUsername(uri.User.Username())
-	password, _ := uri.User.Password()
-	opts.SetPassword(password)
-	return opts
+// newTLSConfig returns the TLS configuration with the CA and client certificates, or nil without TLS
+func newTLSConfig(uri *url.URL, m *metadata) (*tls.Config, error) {
+	if !isTLS(uri, m) {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{ServerName: uri.Hostname()}
+
+	if m.caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(m.caCert)) {
+			return nil, fmt.Errorf("%s invalid caCert", errorMsgPrefix)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if m.clientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(m.clientCert), []byte(m.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("%s invalid clientCert or clientKey, %s", errorMsgPrefix, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
 }
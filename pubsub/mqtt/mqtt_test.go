@@ -6,10 +6,20 @@
 package mqtt
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -87,4 +97,250 @@ func TestParseMetadata(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid clean session")
 		assert.Equal(t, fakeProperties[mqttURL], m.url)
 	})
+
+	t.Run("protocol version, retain handling and session expiry", func(t *testing.T) {
+		fakeProperties := getFakeProperties()
+		fakeProperties[mqttProtocolVersion] = "5"
+		fakeProperties[mqttRetainHandling] = "sendIfNew"
+		fakeProperties[mqttSessionExpiry] = "1h"
+
+		m, err := parseMQTTMetaData(pubsub.Metadata{Properties: fakeProperties})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, protocolVersion5, m.protocolVersion)
+		assert.Equal(t, byte(retainHandlingSendIfNew), m.retainHandling)
+		assert.Equal(t, time.Hour, m.sessionExpiry)
+	})
+
+	t.Run("defaults of protocol version and retain handling", func(t *testing.T) {
+		m, err := parseMQTTMetaData(pubsub.Metadata{Properties: getFakeProperties()})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, protocolVersion311, m.protocolVersion)
+		assert.Equal(t, byte(retainHandlingSend), m.retainHandling)
+	})
+
+	invalid := map[string]map[string]string{
+		"invalid qos":                        {mqttQOS: "3"},
+		"invalid protocol version":           {mqttProtocolVersion: "4"},
+		"invalid retain handling":            {mqttRetainHandling: "never"},
+		"retain handling of v5 only":         {mqttRetainHandling: "sendIfNew"},
+		"invalid session expiry":             {mqttSessionExpiry: "-1s"},
+		"client cert without the key":        {mqttClientCert: "cert"},
+		"client key without the client cert": {mqttClientKey: "key"},
+	}
+	for name, properties := range invalid {
+		properties := properties
+		t.Run(name, func(t *testing.T) {
+			fakeProperties := getFakeProperties()
+			for k, v := range properties {
+				fakeProperties[k] = v
+			}
+
+			_, err := parseMQTTMetaData(pubsub.Metadata{Properties: fakeProperties})
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	t.Run("without TLS", func(t *testing.T) {
+		uri, _ := url.Parse("tcp://fake.mqtt.host")
+		config, err := newTLSConfig(uri, &metadata{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Nil(t, config)
+		assert.Equal(t, "fake.mqtt.host:1883", brokerAddress(uri, &metadata{}))
+	})
+
+	t.Run("TLS scheme", func(t *testing.T) {
+		uri, _ := url.Parse("mqtts://fake.mqtt.host")
+		config, err := newTLSConfig(uri, &metadata{})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "fake.mqtt.host", config.ServerName)
+		assert.Nil(t, config.RootCAs)
+		assert.Equal(t, "fake.mqtt.host:8883", brokerAddress(uri, &metadata{}))
+	})
+
+	t.Run("CA and client certificates", func(t *testing.T) {
+		uri, _ := url.Parse("tcp://fake.mqtt.host:1884")
+		m := &metadata{caCert: certPEM, clientCert: certPEM, clientKey: keyPEM}
+		config, err := newTLSConfig(uri, m)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NotNil(t, config.RootCAs)
+		assert.Len(t, config.Certificates, 1)
+		assert.Equal(t, "fake.mqtt.host:1884", brokerAddress(uri, m))
+	})
+
+	t.Run("invalid CA certificate", func(t *testing.T) {
+		uri, _ := url.Parse("ssl://fake.mqtt.host")
+		_, err := newTLSConfig(uri, &metadata{caCert: "invalid"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("client certificate of another key", func(t *testing.T) {
+		_, otherKeyPEM := generateCertificate(t)
+		uri, _ := url.Parse("ssl://fake.mqtt.host")
+		_, err := newTLSConfig(uri, &metadata{clientCert: certPEM, clientKey: otherKeyPEM})
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+type fakePublish struct {
+	topic   string
+	qos     byte
+	retain  bool
+	payload []byte
+}
+
+// fakeClient records the publications and the handlers of the subscriptions
+type fakeClient struct {
+	published []fakePublish
+	handlers  map[string]messageHandler
+	pingErr   error
+}
+
+func (c *fakeClient) publish(topic string, qos byte, retain bool, payload []byte) error {
+	c.published = append(c.published, fakePublish{topic, qos, retain, payload})
+	return nil
+}
+
+func (c *fakeClient) subscribe(topic string, handler messageHandler) error {
+	c.handlers[topic] = handler
+	return nil
+}
+
+func (c *fakeClient) ping() error {
+	return c.pingErr
+}
+
+func newFakePubSub(m *metadata) (*mqttPubSub, *fakeClient) {
+	c := &fakeClient{handlers: map[string]messageHandler{}}
+	return &mqttPubSub{client: c, metadata: m, logger: logger.NewLogger("test")}, c
+}
+
+func TestPublish(t *testing.T) {
+	t.Run("qos and retain of the component", func(t *testing.T) {
+		m, c := newFakePubSub(&metadata{qos: 1, retain: true})
+
+		err := m.Publish(&pubsub.PublishRequest{Topic: "sensors", Data: []byte("1")})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []fakePublish{{"sensors", 1, true, []byte("1")}}, c.published)
+	})
+
+	t.Run("qos and retain of the request", func(t *testing.T) {
+		m, c := newFakePubSub(&metadata{qos: 1, retain: true})
+
+		err := m.Publish(&pubsub.PublishRequest{Topic: "sensors", Data: []byte("1"), Metadata: map[string]string{mqttQOS: "2", mqttRetain: "false"}})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []fakePublish{{"sensors", 2, false, []byte("1")}}, c.published)
+	})
+
+	t.Run("invalid qos of the request", func(t *testing.T) {
+		m, c := newFakePubSub(&metadata{})
+
+		err := m.Publish(&pubsub.PublishRequest{Topic: "sensors", Data: []byte("1"), Metadata: map[string]string{mqttQOS: "5"}})
+
+		// assert
+		assert.Error(t, err)
+		assert.Empty(t, c.published)
+	})
+}
+
+func TestPing(t *testing.T) {
+	m, c := newFakePubSub(&metadata{})
+	assert.NoError(t, m.Ping())
+
+	c.pingErr = errors.New("not connected")
+	assert.Error(t, m.Ping())
+}
+
+func TestSubscribeRetainHandling(t *testing.T) {
+	for _, test := range []struct {
+		retainHandling byte
+		handled        []string
+	}{
+		{retainHandlingSend, []string{"retained", "new"}},
+		{retainHandlingDoNotSend, []string{"new"}},
+	} {
+		m, c := newFakePubSub(&metadata{retainHandling: test.retainHandling})
+		var handled []string
+		err := m.Subscribe(pubsub.SubscribeRequest{Topic: "sensors"}, func(msg *pubsub.NewMessage) error {
+			handled = append(handled, string(msg.Data))
+			return nil
+		})
+		assert.NoError(t, err)
+
+		c.handlers["sensors"]("sensors", []byte("retained"), true)
+		c.handlers["sensors"]("sensors", []byte("new"), false)
+
+		// assert
+		assert.Equal(t, test.handled, handled)
+	}
+}
+
+func TestSubscribeWildcard(t *testing.T) {
+	m, c := newFakePubSub(&metadata{})
+	var received *pubsub.NewMessage
+	err := m.Subscribe(pubsub.SubscribeRequest{Topic: "sensors/+"}, func(msg *pubsub.NewMessage) error {
+		received = msg
+		return nil
+	})
+	assert.NoError(t, err)
+
+	c.handlers["sensors/+"]("sensors/temperature", []byte("1"), false)
+
+	// assert
+	assert.Equal(t, "sensors/+", received.Topic)
+	assert.Equal(t, "sensors/temperature", received.Metadata[pubsub.ReceivedTopicMetadataKey])
+}
+
+// generateCertificate returns a PEM encoded self-signed certificate and its key
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
 }
@@ -0,0 +1,214 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/eclipse/paho.golang/paho"
+)
+
+const keepAlive = 30
+
+// v5Client is a client of MQTT v5. It reconnects when the connection to the broker is lost.
+type v5Client struct {
+	uri       *url.URL
+	tlsConfig *tls.Config
+	metadata  *metadata
+	// router dispatches the messages to the handlers of the subscriptions, over all connections
+	router        *paho.StandardRouter
+	client        *paho.Client
+	subscriptions map[string]bool
+	lock          sync.Mutex
+	// connected is 1 while the client is connected to the broker. It is read without the lock, which is held while
+	// reconnecting.
+	connected int32
+	logger    logger.Logger
+}
+
+// notifyConn calls onError when reading from the connection fails, which is how the lost connection is noticed
+type notifyConn struct {
+	net.Conn
+	onError func(error)
+	once    sync.Once
+}
+
+func (c *notifyConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err != nil {
+		c.once.Do(func() {
+			go c.onError(err)
+		})
+	}
+	return n, err
+}
+
+func newV5Client(uri *url.URL, tlsConfig *tls.Config, m *metadata, logger logger.Logger) (*v5Client, error) {
+	c := &v5Client{
+		uri:           uri,
+		tlsConfig:     tlsConfig,
+		metadata:      m,
+		router:        paho.NewStandardRouter(),
+		subscriptions: map[string]bool{},
+		logger:        logger,
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// connect connects to the broker and returns whether the broker resumed the session. It must be called with the lock held.
+func (c *v5Client) connect() (bool, error) {
+	address := brokerAddress(c.uri, c.metadata)
+	dialer := &net.Dialer{Timeout: defaultWait}
+	var conn net.Conn
+	var err error
+	if c.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, c.tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	client := paho.NewClient()
+	client.Conn = &notifyConn{Conn: conn, onError: func(err error) {
+		c.connectionLost(client, err)
+	}}
+	client.Router = c.router
+
+	password, hasPassword := c.uri.User.Password()
+	connect := &paho.Connect{
+		ClientID:     c.metadata.clientID,
+		KeepAlive:    keepAlive,
+		CleanStart:   c.metadata.cleanSession,
+		Username:     c.uri.User.Username(),
+		UsernameFlag: c.uri.User.Username() != "",
+		Password:     []byte(password),
+		PasswordFlag: hasPassword,
+	}
+	if !c.metadata.cleanSession {
+		// The session of a client that does not start clean must outlive the connection, without expiry by default
+		expiry := uint32(math.MaxUint32)
+		if c.metadata.sessionExpiry > 0 {
+			expiry = uint32(c.metadata.sessionExpiry / time.Second)
+		}
+		connect.Properties = &paho.ConnectProperties{SessionExpiryInterval: &expiry}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWait)
+	defer cancel()
+	ca, err := client.Connect(ctx, connect)
+	if err != nil {
+		conn.Close()
+		return false, err
+	}
+
+	c.client = client
+	atomic.StoreInt32(&c.connected, 1)
+	return ca.SessionPresent, nil
+}
+
+// connectionLost reconnects until it succeeds, and subscribes again unless the broker resumed the session
+func (c *v5Client) connectionLost(client *paho.Client, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Connections that failed to connect were never used
+	if client != c.client {
+		return
+	}
+	atomic.StoreInt32(&c.connected, 0)
+	c.logger.Warnf("mqtt connection lost, reconnecting: %s", err)
+
+	for {
+		time.Sleep(defaultWait)
+
+		sessionPresent, err := c.connect()
+		if err != nil {
+			c.logger.Errorf("mqtt error reconnecting, retrying in %s: %s", defaultWait, err)
+			continue
+		}
+
+		if !sessionPresent {
+			for topic := range c.subscriptions {
+				err = c.subscribeToBroker(topic)
+				if err != nil {
+					c.logger.Errorf("mqtt error subscribing again to topic %s: %s", topic, err)
+				}
+			}
+		}
+
+		c.logger.Infof("mqtt reconnected")
+		return
+	}
+}
+
+func (c *v5Client) publish(topic string, qos byte, retain bool, payload []byte) error {
+	c.lock.Lock()
+	client := c.client
+	c.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWait)
+	defer cancel()
+	resp, err := client.Publish(ctx, &paho.Publish{Topic: topic, QoS: qos, Retain: retain, Payload: payload})
+	if err != nil {
+		return err
+	}
+	if resp != nil && resp.ReasonCode >= 0x80 {
+		return fmt.Errorf("publish to topic %s failed with reason code %d", topic, resp.ReasonCode)
+	}
+
+	return nil
+}
+
+func (c *v5Client) subscribe(topic string, handler messageHandler) error {
+	c.router.RegisterHandler(topic, func(p *paho.Publish) {
+		handler(p.Topic, p.Payload, p.Retain)
+	})
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.subscriptions[topic] = true
+	return c.subscribeToBroker(topic)
+}
+
+func (c *v5Client) ping() error {
+	if atomic.LoadInt32(&c.connected) == 0 {
+		return fmt.Errorf("mqtt not connected to the broker, reconnecting")
+	}
+	return nil
+}
+
+// subscribeToBroker sends the subscription to the broker. It must be called with the lock held.
+func (c *v5Client) subscribeToBroker(topic string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultWait)
+	defer cancel()
+	_, err := c.client.Subscribe(ctx, &paho.Subscribe{
+		Subscriptions: map[string]paho.SubscribeOptions{
+			topic: {QoS: c.metadata.qos, RetainHandling: c.metadata.retainHandling},
+		},
+	})
+	return err
+}
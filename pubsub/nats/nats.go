@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
 	nats "github.com/nats-io/go-nats"
@@ -65,6 +66,24 @@ func (n *natsPubSub) Init(metadata pubsub.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the NATS pub/sub
+func (n *natsPubSub) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: natsURL, Type: componentmetadata.TypeString, Required: true},
+			{Name: consumerID, Type: componentmetadata.TypeString, Required: true},
+		},
+	}
+}
+
+// Ping returns an error when the connection to the NATS server is not open, for health checks
+func (n *natsPubSub) Ping() error {
+	if n.natsConn == nil || !n.natsConn.IsConnected() {
+		return errors.New("nats: not connected to the server")
+	}
+	return nil
+}
+
 func (n *natsPubSub) Publish(req *pubsub.PublishRequest) error {
 	err := n.natsConn.Publish(req.Topic, req.Data)
 	if err != nil {
@@ -73,14 +92,24 @@ func (n *natsPubSub) Publish(req *pubsub.PublishRequest) error {
 	return nil
 }
 
+// Subscribe subscribes to the subject of the topic, which can have the * and > wildcards. The subject that messages
+// were published to is in their receivedTopic metadata.
 func (n *natsPubSub) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
 	sub, err := n.natsConn.QueueSubscribe(req.Topic, n.metadata.natsQueueGroupName, func(natsMsg *nats.Msg) {
-		handler(&pubsub.NewMessage{Topic: req.Topic, Data: natsMsg.Data})
+		handler(newMessage(req.Topic, natsMsg))
 	})
 	if err != nil {
-		n.logger.Warnf("nats: error subscribe: %s", err)
+		return fmt.Errorf("nats: error from subscribe: %s", err)
 	}
 	n.logger.Debugf("nats: subscribed to subject %s with queue group %s", sub.Subject, sub.Queue)
 
 	return nil
 }
+
+func newMessage(topic string, natsMsg *nats.Msg) *pubsub.NewMessage {
+	return &pubsub.NewMessage{
+		Topic:    topic,
+		Data:     natsMsg.Data,
+		Metadata: map[string]string{pubsub.ReceivedTopicMetadataKey: natsMsg.Subject},
+	}
+}
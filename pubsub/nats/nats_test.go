@@ -9,6 +9,7 @@ import (
 	"errors"
 	"testing"
 
+	nats "github.com/nats-io/go-nats"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dapr/components-contrib/pubsub"
@@ -68,3 +69,16 @@ func TestParseNATSMetadata(t *testing.T) {
 		assert.Empty(t, m.natsURL)
 	})
 }
+
+func TestNewMessage(t *testing.T) {
+	msg := newMessage("orders.*", &nats.Msg{Subject: "orders.created", Data: []byte("order")})
+
+	assert.Equal(t, "orders.*", msg.Topic)
+	assert.Equal(t, []byte("order"), msg.Data)
+	assert.Equal(t, "orders.created", msg.Metadata[pubsub.ReceivedTopicMetadataKey])
+}
+
+func TestPingWithoutConnection(t *testing.T) {
+	n := &natsPubSub{}
+	assert.Error(t, n.Ping())
+}
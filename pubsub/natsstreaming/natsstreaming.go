@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"time"
 
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/nats-io/gnatsd/logger"
 	nats "github.com/nats-io/nats.go"
@@ -168,6 +169,34 @@ func (n *natsStreamingPubSub) Init(metadata pubsub.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the NATS Streaming pub/sub
+func (n *natsStreamingPubSub) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: natsURL, Type: componentmetadata.TypeString, Required: true},
+			{Name: natsStreamingClusterID, Type: componentmetadata.TypeString, Required: true},
+			{Name: subscriptionType, Type: componentmetadata.TypeString},
+			{Name: consumerID, Type: componentmetadata.TypeString, Required: true},
+			{Name: durableSubscriptionName, Type: componentmetadata.TypeString},
+			{Name: startAtSequence, Type: componentmetadata.TypeInt},
+			{Name: startWithLastReceived, Type: componentmetadata.TypeBool},
+			{Name: deliverAll, Type: componentmetadata.TypeBool},
+			{Name: deliverNew, Type: componentmetadata.TypeBool},
+			{Name: startAtTimeDelta, Type: componentmetadata.TypeDuration},
+			{Name: startAtTime, Type: componentmetadata.TypeString},
+			{Name: startAtTimeFormat, Type: componentmetadata.TypeString},
+		},
+	}
+}
+
+// Ping returns an error when the connection to the NATS Streaming server is not open, for health checks
+func (n *natsStreamingPubSub) Ping() error {
+	if n.natStreamingConn == nil || n.natStreamingConn.NatsConn() == nil || !n.natStreamingConn.NatsConn().IsConnected() {
+		return errors.New("nats-streaming: not connected to the server")
+	}
+	return nil
+}
+
 func (n *natsStreamingPubSub) Publish(req *pubsub.PublishRequest) error {
 	err := n.natStreamingConn.Publish(req.Topic, req.Data)
 	if err != nil {
@@ -5,9 +5,12 @@
 
 package pubsub
 
+import "github.com/dapr/components-contrib/metadata"
+
 // PubSub is the interface for message buses
 type PubSub interface {
 	Init(metadata Metadata) error
 	Publish(req *PublishRequest) error
 	Subscribe(req SubscribeRequest, handler func(msg *NewMessage) error) error
+	GetComponentMetadata() metadata.ComponentMetadata
 }
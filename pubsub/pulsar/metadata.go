@@ -1,7 +1,27 @@
 package pulsar
 
+import (
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+)
+
 type pulsarMetadata struct {
 	Host       string `json:"host"`
 	ConsumerID string `json:"consumerID"`
 	EnableTLS  bool   `json:"enableTLS"`
+	// SubscriptionType is how the messages of a subscription are dispatched to its consumers
+	SubscriptionType pulsar.SubscriptionType `json:"subscriptionType"`
+	// Token is the JWT of the token authentication
+	Token string `json:"token"`
+	// OAuth2 client credentials authentication, with the path of the file of the client credentials
+	OAuth2IssuerURL string `json:"oauth2IssuerURL"`
+	OAuth2Audience  string `json:"oauth2Audience"`
+	OAuth2ClientID  string `json:"oauth2ClientID"`
+	OAuth2KeyFile   string `json:"oauth2KeyFile"`
+	// NackRedeliveryDelay is how long after the failed handling of a message it is redelivered
+	NackRedeliveryDelay time.Duration `json:"nackRedeliveryDelay"`
+	// ProducerName is the name of the producers, which the broker deduplicates the messages of
+	ProducerName        string `json:"producerName"`
+	EnableDeduplication bool   `json:"enableDeduplication"`
 }
@@ -2,26 +2,52 @@ package pulsar
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 
 	"github.com/dapr/components-contrib/pubsub"
 )
 
 const (
-	host      = "host"
-	enableTLS = "enableTLS"
+	host                = "host"
+	enableTLS           = "enableTLS"
+	subscriptionType    = "subscriptionType"
+	token               = "token"
+	oauth2IssuerURL     = "oauth2IssuerURL"
+	oauth2Audience      = "oauth2Audience"
+	oauth2ClientID      = "oauth2ClientID"
+	oauth2KeyFile       = "oauth2KeyFile"
+	nackRedeliveryDelay = "nackRedeliveryDelay"
+	producerName        = "producerName"
+	enableDeduplication = "enableDeduplication"
+
+	// Publish metadata
+	partitionKey = "partitionKey"
+	sequenceID   = "sequenceID"
 )
 
+var subscriptionTypes = map[string]pulsar.SubscriptionType{
+	"exclusive": pulsar.Exclusive,
+	"shared":    pulsar.Shared,
+	"failover":  pulsar.Failover,
+	"keyShared": pulsar.KeyShared,
+}
+
 type Pulsar struct {
 	logger   logger.Logger
 	client   pulsar.Client
 	metadata pulsarMetadata
+	// producers are created once per topic, since the broker deduplicates the messages per producer
+	producers map[string]pulsar.Producer
+	lock      sync.Mutex
 }
 
 func NewPulsar(l logger.Logger) pubsub.PubSub {
@@ -29,7 +55,7 @@ func NewPulsar(l logger.Logger) pubsub.PubSub {
 }
 
 func parsePulsarMetadata(meta pubsub.Metadata) (*pulsarMetadata, error) {
-	m := pulsarMetadata{}
+	m := pulsarMetadata{SubscriptionType: pulsar.Failover}
 	m.ConsumerID = meta.Properties["consumerID"]
 
 	if val, ok := meta.Properties[host]; ok && val != "" {
@@ -45,63 +71,210 @@ func parsePulsarMetadata(meta pubsub.Metadata) (*pulsarMetadata, error) {
 		m.EnableTLS = tls
 	}
 
+	if val, ok := meta.Properties[subscriptionType]; ok && val != "" {
+		t, ok := subscriptionTypes[val]
+		if !ok {
+			return nil, fmt.Errorf("pulsar error: invalid subscriptionType %s, accepted values are exclusive, shared, failover and keyShared", val)
+		}
+		m.SubscriptionType = t
+	}
+
+	m.Token = meta.Properties[token]
+	m.OAuth2IssuerURL = meta.Properties[oauth2IssuerURL]
+	m.OAuth2Audience = meta.Properties[oauth2Audience]
+	m.OAuth2ClientID = meta.Properties[oauth2ClientID]
+	m.OAuth2KeyFile = meta.Properties[oauth2KeyFile]
+	if m.OAuth2IssuerURL != "" {
+		if m.Token != "" {
+			return nil, errors.New("pulsar error: token and oauth2 authentication cannot be used together")
+		}
+		if m.OAuth2KeyFile == "" {
+			return nil, errors.New("pulsar error: missing oauth2KeyFile")
+		}
+	}
+
+	if val, ok := meta.Properties[nackRedeliveryDelay]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("pulsar error: invalid value for nackRedeliveryDelay %s", val)
+		}
+		m.NackRedeliveryDelay = d
+	}
+
+	m.ProducerName = meta.Properties[producerName]
+	if val, ok := meta.Properties[enableDeduplication]; ok && val != "" {
+		dedup, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, errors.New("pulsar error: invalid value for enableDeduplication")
+		}
+		// The broker only recognizes the messages published again by a producer with the same name
+		if dedup && m.ProducerName == "" {
+			return nil, errors.New("pulsar error: enableDeduplication requires producerName")
+		}
+		m.EnableDeduplication = dedup
+	}
+
 	return &m, nil
 }
 
+// newAuthentication returns the token or OAuth2 authentication, or nil without authentication
+func newAuthentication(m *pulsarMetadata) (pulsar.Authentication, error) {
+	if m.Token != "" {
+		return pulsar.NewAuthenticationToken(m.Token), nil
+	}
+
+	if m.OAuth2IssuerURL != "" {
+		params := map[string]string{
+			"type":       "client_credentials",
+			"issuerUrl":  m.OAuth2IssuerURL,
+			"audience":   m.OAuth2Audience,
+			"clientId":   m.OAuth2ClientID,
+			"privateKey": m.OAuth2KeyFile,
+		}
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		// NewAuthentication returns the errors of the OAuth2 authorization, which NewAuthenticationOAuth2 ignores
+		auth, err := pulsar.NewAuthentication("oauth2", string(b))
+		if err != nil {
+			return nil, fmt.Errorf("pulsar error: oauth2 authentication failed: %s", err)
+		}
+		return auth, nil
+	}
+
+	return nil, nil
+}
+
 func (p *Pulsar) Init(metadata pubsub.Metadata) error {
 	m, err := parsePulsarMetadata(metadata)
 	if err != nil {
 		return err
 	}
-	client, err := pulsar.NewClient(pulsar.ClientOptions{
-		URL:                        fmt.Sprintf("pulsar://%s", m.Host),
+
+	scheme := "pulsar"
+	if m.EnableTLS {
+		scheme = "pulsar+ssl"
+	}
+	options := pulsar.ClientOptions{
+		URL:                        fmt.Sprintf("%s://%s", scheme, m.Host),
 		OperationTimeout:           30 * time.Second,
 		ConnectionTimeout:          30 * time.Second,
 		TLSAllowInsecureConnection: !m.EnableTLS,
-	})
+	}
+	options.Authentication, err = newAuthentication(m)
+	if err != nil {
+		return err
+	}
+
+	client, err := pulsar.NewClient(options)
 	if err != nil {
 		return fmt.Errorf("could not instantiate pulsar client: %v", err)
 	}
-	defer client.Close()
 
 	p.client = client
 	p.metadata = *m
+	p.producers = map[string]pulsar.Producer{}
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Pulsar pub/sub
+func (p *Pulsar) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: host, Type: metadata.TypeString, Required: true},
+			{Name: "consumerID", Type: metadata.TypeString},
+			{Name: enableTLS, Type: metadata.TypeBool},
+			{Name: subscriptionType, Type: metadata.TypeString, Default: "failover"},
+			{Name: token, Type: metadata.TypeString},
+			{Name: oauth2IssuerURL, Type: metadata.TypeString},
+			{Name: oauth2Audience, Type: metadata.TypeString},
+			{Name: oauth2ClientID, Type: metadata.TypeString},
+			{Name: oauth2KeyFile, Type: metadata.TypeString},
+			{Name: nackRedeliveryDelay, Type: metadata.TypeDuration},
+			{Name: producerName, Type: metadata.TypeString},
+			{Name: enableDeduplication, Type: metadata.TypeBool},
+		},
+	}
+}
+
+// Publish publishes the message with the partitionKey metadata as its key, which the messages of key shared
+// subscriptions are dispatched by. With deduplication, the sequenceID metadata is the sequence id of the message.
 func (p *Pulsar) Publish(req *pubsub.PublishRequest) error {
-	producer, err := p.client.CreateProducer(pulsar.ProducerOptions{
-		Topic: req.Topic,
-	})
+	msg, err := p.producerMessage(req)
 	if err != nil {
 		return err
 	}
 
-	_, err = producer.Send(context.Background(), &pulsar.ProducerMessage{
-		Payload: req.Data,
-	})
+	producer, err := p.getProducer(req.Topic)
 	if err != nil {
 		return err
 	}
 
-	defer producer.Close()
+	_, err = producer.Send(context.Background(), msg)
+	return err
+}
+
+// producerMessage returns the message of a publish request. Delayed messages are only delivered later to shared
+// subscriptions, the other subscriptions receive them right away.
+func (p *Pulsar) producerMessage(req *pubsub.PublishRequest) (*pulsar.ProducerMessage, error) {
+	msg := &pulsar.ProducerMessage{
+		Payload: req.Data,
+		Key:     req.Metadata[partitionKey],
+	}
+	if val, ok := req.Metadata[sequenceID]; ok && val != "" && p.metadata.EnableDeduplication {
+		id, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("pulsar error: invalid value for sequenceID %s", val)
+		}
+		msg.SequenceID = &id
+	}
 
-	return nil
+	delay, ok, err := pubsub.TryGetDeliveryDelay(req.Metadata, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("pulsar error: %s", err)
+	}
+	if ok {
+		msg.DeliverAfter = delay
+	}
+
+	return msg, nil
+}
+
+func (p *Pulsar) getProducer(topic string) (pulsar.Producer, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if producer, ok := p.producers[topic]; ok {
+		return producer, nil
+	}
+
+	producer, err := p.client.CreateProducer(pulsar.ProducerOptions{
+		Topic: topic,
+		Name:  p.metadata.ProducerName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.producers[topic] = producer
+
+	return producer, nil
 }
 
 func (p *Pulsar) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
 	channel := make(chan pulsar.ConsumerMessage, 100)
 
 	options := pulsar.ConsumerOptions{
-		Topic:            req.Topic,
-		SubscriptionName: p.metadata.ConsumerID,
-		Type:             pulsar.Failover,
+		Topic:               req.Topic,
+		SubscriptionName:    p.metadata.ConsumerID,
+		Type:                p.metadata.SubscriptionType,
+		NackRedeliveryDelay: p.metadata.NackRedeliveryDelay,
 	}
 
 	options.MessageChannel = channel
 	consumer, err := p.client.Subscribe(options)
 	if err != nil {
-		p.logger.Debugf("Could not subscribe %s", req.Topic)
+		return fmt.Errorf("pulsar error: could not subscribe %s: %v", req.Topic, err)
 	}
 
 	go p.ListenMessage(consumer, req.Topic, handler)
@@ -115,13 +288,21 @@ func (p *Pulsar) ListenMessage(msgs pulsar.Consumer, topic string, handler func(
 	}
 }
 
+// HandleMessage acknowledges the handled messages, and negatively acknowledges the others, which the broker
+// redelivers after the nack redelivery delay
 func (p *Pulsar) HandleMessage(m pulsar.ConsumerMessage, topic string, handler func(msg *pubsub.NewMessage) error) {
-	err := handler(&pubsub.NewMessage{
+	msg := &pubsub.NewMessage{
 		Data:  m.Payload(),
 		Topic: topic,
-	})
+	}
+	if m.Key() != "" {
+		msg.Metadata = map[string]string{partitionKey: m.Key()}
+	}
+
+	err := handler(msg)
 	if err != nil {
 		p.logger.Debugf("Could not handle topic %s", topic)
+		m.Nack(m.Message)
 	} else {
 		m.Ack(m.Message)
 	}
@@ -2,7 +2,9 @@ package pulsar
 
 import (
 	"testing"
+	"time"
 
+	"github.com/apache/pulsar-client-go/pulsar"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dapr/components-contrib/pubsub"
@@ -37,3 +39,89 @@ func TestInvalidTLSInput(t *testing.T) {
 	assert.Nil(t, meta)
 	assert.Equal(t, "pulsar error: invalid value for enableTLS", err.Error())
 }
+
+func TestParsePulsarMetadataOptions(t *testing.T) {
+	m := pubsub.Metadata{}
+	m.Properties = map[string]string{
+		"host": "a", "subscriptionType": "keyShared", "token": "jwt", "nackRedeliveryDelay": "10s",
+		"producerName": "producer", "enableDeduplication": "true",
+	}
+	meta, err := parsePulsarMetadata(m)
+
+	assert.Nil(t, err)
+	assert.Equal(t, pulsar.KeyShared, meta.SubscriptionType)
+	assert.Equal(t, "jwt", meta.Token)
+	assert.Equal(t, 10*time.Second, meta.NackRedeliveryDelay)
+	assert.Equal(t, "producer", meta.ProducerName)
+	assert.True(t, meta.EnableDeduplication)
+}
+
+func TestDefaultSubscriptionType(t *testing.T) {
+	m := pubsub.Metadata{}
+	m.Properties = map[string]string{"host": "a"}
+	meta, err := parsePulsarMetadata(m)
+
+	assert.Nil(t, err)
+	assert.Equal(t, pulsar.Failover, meta.SubscriptionType)
+	assert.Equal(t, time.Duration(0), meta.NackRedeliveryDelay)
+}
+
+func TestParsePulsarMetadataErrors(t *testing.T) {
+	tests := map[string]map[string]string{
+		"invalid subscriptionType":       {"host": "a", "subscriptionType": "any"},
+		"token and oauth2":               {"host": "a", "token": "jwt", "oauth2IssuerURL": "https://issuer", "oauth2KeyFile": "/key.json"},
+		"oauth2 without key file":        {"host": "a", "oauth2IssuerURL": "https://issuer"},
+		"invalid nackRedeliveryDelay":    {"host": "a", "nackRedeliveryDelay": "-1s"},
+		"invalid enableDeduplication":    {"host": "a", "enableDeduplication": "honk"},
+		"deduplication without producer": {"host": "a", "enableDeduplication": "true"},
+	}
+
+	for name, properties := range tests {
+		t.Run(name, func(t *testing.T) {
+			meta, err := parsePulsarMetadata(pubsub.Metadata{Properties: properties})
+
+			assert.Error(t, err)
+			assert.Nil(t, meta)
+		})
+	}
+}
+
+func TestNewAuthentication(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		auth, err := newAuthentication(&pulsarMetadata{})
+
+		assert.Nil(t, err)
+		assert.Nil(t, auth)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		auth, err := newAuthentication(&pulsarMetadata{Token: "jwt"})
+
+		assert.Nil(t, err)
+		assert.NotNil(t, auth)
+	})
+
+	t.Run("oauth2 with missing key file", func(t *testing.T) {
+		auth, err := newAuthentication(&pulsarMetadata{OAuth2IssuerURL: "https://issuer", OAuth2KeyFile: "/missing.json"})
+
+		assert.Error(t, err)
+		assert.Nil(t, auth)
+	})
+}
+
+func TestProducerMessage(t *testing.T) {
+	p := Pulsar{metadata: pulsarMetadata{EnableDeduplication: true}}
+
+	msg, err := p.producerMessage(&pubsub.PublishRequest{
+		Data:     []byte("data"),
+		Metadata: map[string]string{partitionKey: "key", sequenceID: "5", pubsub.DeliverAfterMetadataKey: "30s"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), msg.Payload)
+	assert.Equal(t, "key", msg.Key)
+	assert.Equal(t, int64(5), *msg.SequenceID)
+	assert.Equal(t, 30*time.Second, msg.DeliverAfter)
+
+	_, err = p.producerMessage(&pubsub.PublishRequest{Metadata: map[string]string{pubsub.DeliverAtMetadataKey: "tomorrow"}})
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/dapr/components-contrib/pubsub"
 )
@@ -15,11 +16,17 @@ type metadata struct {
 	autoAck          bool
 	requeueInFailure bool
 	deliveryMode     uint8 // Transient (0 or 1) or Persistent (2)
+	prefetchCount    int   // Unacknowledged messages delivered to each subscription, 0 for no limit. Also set by maxInFlight
+	concurrency      int   // Messages of each subscription handled at the same time
+	reconnectWait    time.Duration
+	topicExchange    string // Topic exchange that the topics are routing keys of, instead of fanout exchanges
+	// Whether the exchanges are delayed message exchanges, which delay the messages with a deliverAfter or deliverAt
+	delayedMessageExchange bool
 }
 
 // createMetadata creates a new instance from the pubsub metadata
 func createMetadata(pubSubMetadata pubsub.Metadata) (*metadata, error) {
-	result := metadata{deleteWhenUnused: true, autoAck: false}
+	result := metadata{deleteWhenUnused: true, autoAck: false, concurrency: defaultConcurrency, reconnectWait: defaultReconnectWait}
 
 	if val, found := pubSubMetadata.Properties[metadataHostKey]; found && val != "" {
 		result.host = val
@@ -66,5 +73,41 @@ func createMetadata(pubSubMetadata pubsub.Metadata) (*metadata, error) {
 		}
 	}
 
+	if val, found := pubSubMetadata.Properties[metadataPrefetchCountKey]; found && val != "" {
+		intVal, err := strconv.Atoi(val)
+		if err != nil || intVal < 0 {
+			return &result, fmt.Errorf("%s invalid RabbitMQ prefetch count %s", errorMessagePrefix, val)
+		}
+		result.prefetchCount = intVal
+	}
+
+	concurrency, maxInFlight, err := pubsub.TryGetConcurrency(pubSubMetadata.Properties, defaultConcurrency)
+	if err != nil {
+		return &result, fmt.Errorf("%s %s", errorMessagePrefix, err)
+	}
+	result.concurrency = concurrency
+	// The messages in flight are the unacknowledged messages that the prefetch count limits
+	if maxInFlight > 0 {
+		result.prefetchCount = maxInFlight
+	}
+
+	if val, found := pubSubMetadata.Properties[metadataReconnectWaitKey]; found && val != "" {
+		duration, err := time.ParseDuration(val)
+		if err != nil || duration <= 0 {
+			return &result, fmt.Errorf("%s invalid RabbitMQ reconnect wait %s", errorMessagePrefix, val)
+		}
+		result.reconnectWait = duration
+	}
+
+	result.topicExchange = pubSubMetadata.Properties[metadataTopicExchangeKey]
+
+	if val, found := pubSubMetadata.Properties[metadataDelayedMessageExchangeKey]; found && val != "" {
+		boolVal, err := strconv.ParseBool(val)
+		if err != nil {
+			return &result, fmt.Errorf("%s invalid RabbitMQ delayed message exchange %s", errorMessagePrefix, val)
+		}
+		result.delayedMessageExchange = boolVal
+	}
+
 	return &result, nil
 }
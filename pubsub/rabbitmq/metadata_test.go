@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/stretchr/testify/assert"
@@ -46,6 +47,7 @@ func TestCreateMetadata(t *testing.T) {
 		assert.Equal(t, false, m.requeueInFailure)
 		assert.Equal(t, true, m.deleteWhenUnused)
 		assert.Equal(t, uint8(0), m.deliveryMode)
+		assert.Empty(t, m.topicExchange)
 	})
 
 	t.Run("host is not given", func(t *testing.T) {
@@ -202,4 +204,69 @@ func TestCreateMetadata(t *testing.T) {
 			assert.Equal(t, tt.expected, m.durable)
 		})
 	}
+
+	t.Run("prefetch, concurrency, and reconnect wait are set", func(t *testing.T) {
+		fakeProperties := getFakeProperties()
+
+		fakeMetaData := pubsub.Metadata{
+			Properties: fakeProperties,
+		}
+		fakeMetaData.Properties[metadataPrefetchCountKey] = "10"
+		fakeMetaData.Properties[metadataConcurrencyKey] = "4"
+		fakeMetaData.Properties[metadataReconnectWaitKey] = "10s"
+
+		// act
+		m, err := createMetadata(fakeMetaData)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 10, m.prefetchCount)
+		assert.Equal(t, 4, m.concurrency)
+		assert.Equal(t, 10*time.Second, m.reconnectWait)
+	})
+
+	t.Run("max in flight sets the prefetch", func(t *testing.T) {
+		fakeProperties := getFakeProperties()
+		fakeProperties[metadataPrefetchCountKey] = "10"
+		fakeProperties[pubsub.MaxInFlightKey] = "20"
+
+		m, err := createMetadata(pubsub.Metadata{Properties: fakeProperties})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 20, m.prefetchCount)
+	})
+
+	t.Run("prefetch, concurrency, and reconnect wait defaults", func(t *testing.T) {
+		m, err := createMetadata(pubsub.Metadata{Properties: getFakeProperties()})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, m.prefetchCount)
+		assert.Equal(t, defaultConcurrency, m.concurrency)
+		assert.Equal(t, defaultReconnectWait, m.reconnectWait)
+	})
+
+	var invalidValues = map[string]string{
+		metadataPrefetchCountKey: "-1",
+		metadataConcurrencyKey:   "0",
+		pubsub.MaxInFlightKey:    "0",
+		metadataReconnectWaitKey: "soon",
+	}
+
+	for key, val := range invalidValues {
+		key, val := key, val
+		t.Run(fmt.Sprintf("%s value=%s", key, val), func(t *testing.T) {
+			fakeProperties := getFakeProperties()
+
+			fakeMetaData := pubsub.Metadata{
+				Properties: fakeProperties,
+			}
+			fakeMetaData.Properties[key] = val
+
+			// act
+			_, err := createMetadata(fakeMetaData)
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
 }
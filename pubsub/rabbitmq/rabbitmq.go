@@ -2,7 +2,11 @@ package rabbitmq
 
 import (
 	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/streadway/amqp"
@@ -10,9 +14,15 @@ import (
 
 const (
 	fanoutExchangeKind = "fanout"
+	topicExchangeKind  = "topic"
 	logMessagePrefix   = "rabbitmq pub/sub:"
 	errorMessagePrefix = "rabbitmq pub/sub error:"
 
+	// delayedMessageExchangeKind is the kind of the exchanges of the delayed message exchange plugin
+	delayedMessageExchangeKind = "x-delayed-message"
+	delayedTypeArgument        = "x-delayed-type"
+	delayHeader                = "x-delay"
+
 	metadataHostKey             = "host"
 	metadataConsumerIDKey       = "consumerID"
 	metadataDurableKey          = "durable"
@@ -20,6 +30,15 @@ const (
 	metadataAutoAckKey          = "autoAck"
 	metadataDeliveryModeKey     = "deliveryMode"
 	metadataRequeueInFailureKey = "requeueInFailure"
+	metadataPrefetchCountKey    = "prefetchCount"
+	metadataConcurrencyKey      = pubsub.ConcurrencyKey
+	metadataReconnectWaitKey    = "reconnectWait"
+	metadataTopicExchangeKey    = "topicExchange"
+	// metadataDelayedMessageExchangeKey declares the exchanges with the delayed message exchange plugin
+	metadataDelayedMessageExchangeKey = "delayedMessageExchange"
+
+	defaultConcurrency   = 1
+	defaultReconnectWait = 3 * time.Second
 )
 
 // RabbitMQ allows sending/receiving messages in pub/sub format
@@ -28,10 +47,20 @@ type rabbitMQ struct {
 	channel           *amqp.Channel
 	metadata          *metadata
 	declaredExchanges map[string]bool
+	subscriptions     []subscription
+	// lock guards the connection, the channel, and the declared exchanges, which are replaced on reconnection
+	lock sync.Mutex
 
 	logger logger.Logger
 }
 
+type subscription struct {
+	topic   string
+	handler func(msg *pubsub.NewMessage) error
+	// deadLetterTopic is where the messages that failed are published once they are not requeued anymore
+	deadLetterTopic string
+}
+
 // NewRabbitMQ creates a new RabbitMQ pub/sub
 func NewRabbitMQ(logger logger.Logger) pubsub.PubSub {
 	return &rabbitMQ{declaredExchanges: make(map[string]bool), logger: logger}
@@ -46,34 +75,133 @@ func (r *rabbitMQ) Init(metadata pubsub.Metadata) error {
 
 	r.metadata = meta
 
-	conn, err := amqp.Dial(meta.host)
+	return r.connect()
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the RabbitMQ pub/sub
+func (r *rabbitMQ) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	fields := []componentmetadata.Field{
+		{Name: metadataHostKey, Type: componentmetadata.TypeString, Required: true},
+		{Name: metadataConsumerIDKey, Type: componentmetadata.TypeString, Required: true},
+		{Name: metadataDeliveryModeKey, Type: componentmetadata.TypeInt},
+		{Name: metadataDurableKey, Type: componentmetadata.TypeBool},
+		{Name: metadataDeleteWhenUnusedKey, Type: componentmetadata.TypeBool, Default: "true"},
+		{Name: metadataAutoAckKey, Type: componentmetadata.TypeBool},
+		{Name: metadataRequeueInFailureKey, Type: componentmetadata.TypeBool},
+		{Name: metadataPrefetchCountKey, Type: componentmetadata.TypeInt},
+		{Name: metadataReconnectWaitKey, Type: componentmetadata.TypeDuration, Default: defaultReconnectWait.String()},
+		{Name: metadataTopicExchangeKey, Type: componentmetadata.TypeString},
+		{Name: metadataDelayedMessageExchangeKey, Type: componentmetadata.TypeBool},
+	}
+
+	return componentmetadata.ComponentMetadata{
+		Fields:       append(fields, pubsub.ConcurrencyMetadataFields(defaultConcurrency)...),
+		Capabilities: []componentmetadata.Capability{componentmetadata.CapabilityTTL},
+	}
+}
+
+// Ping returns an error when the connection to the broker is closed, e.g. while reconnecting, for health checks
+func (r *rabbitMQ) Ping() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.connection == nil || r.connection.IsClosed() {
+		return fmt.Errorf("%s not connected to the broker", logMessagePrefix)
+	}
+	return nil
+}
+
+// connect opens a new connection and channel, and reconnects when either of them is closed by a failure
+func (r *rabbitMQ) connect() error {
+	conn, err := amqp.Dial(r.metadata.host)
 	if err != nil {
 		return err
 	}
 
 	ch, err := conn.Channel()
 	if err != nil {
+		conn.Close()
 		return err
 	}
 
+	if r.metadata.prefetchCount > 0 {
+		err = ch.Qos(r.metadata.prefetchCount, 0, false)
+		if err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	r.lock.Lock()
 	r.connection = conn
 	r.channel = ch
+	r.declaredExchanges = make(map[string]bool)
+	r.lock.Unlock()
+
+	go r.reconnectOnClose(conn, ch)
+
 	return nil
 }
 
+func (r *rabbitMQ) reconnectOnClose(conn *amqp.Connection, ch *amqp.Channel) {
+	var closeErr *amqp.Error
+	select {
+	case closeErr = <-conn.NotifyClose(make(chan *amqp.Error, 1)):
+	case closeErr = <-ch.NotifyClose(make(chan *amqp.Error, 1)):
+	}
+
+	// The notification channels are closed without an error when the connection is closed on purpose
+	if closeErr == nil {
+		return
+	}
+
+	r.logger.Warnf("%s connection lost, reconnecting: %s", logMessagePrefix, closeErr)
+	conn.Close()
+
+	for {
+		time.Sleep(r.metadata.reconnectWait)
+
+		err := r.connect()
+		if err != nil {
+			r.logger.Errorf("%s error reconnecting, retrying in %s: %s", logMessagePrefix, r.metadata.reconnectWait, err)
+			continue
+		}
+
+		break
+	}
+
+	r.lock.Lock()
+	subscriptions := append([]subscription(nil), r.subscriptions...)
+	r.lock.Unlock()
+
+	for _, s := range subscriptions {
+		err := r.subscribe(s)
+		if err != nil {
+			r.logger.Errorf("%s error subscribing again to topic '%s': %s", logMessagePrefix, s.topic, err)
+		}
+	}
+
+	r.logger.Infof("%s reconnected", logMessagePrefix)
+}
+
 func (r *rabbitMQ) Publish(req *pubsub.PublishRequest) error {
-	err := r.ensureExchangeDeclared(req.Topic)
+	msg, err := r.publishing(req)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	exchange, routingKey := r.exchange(req.Topic)
+	err = r.ensureExchangeDeclared(exchange)
 	if err != nil {
 		return err
 	}
 
 	r.logger.Debugf("%s publishing message to topic '%s'", logMessagePrefix, req.Topic)
 
-	err = r.channel.Publish(req.Topic, "", false, false, amqp.Publishing{
-		ContentType:  "text/plain",
-		Body:         req.Data,
-		DeliveryMode: r.metadata.deliveryMode,
-	})
+	err = r.channel.Publish(exchange, routingKey, false, false, msg)
 
 	if err != nil {
 		return err
@@ -83,12 +211,31 @@ func (r *rabbitMQ) Publish(req *pubsub.PublishRequest) error {
 }
 
 func (r *rabbitMQ) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
-	err := r.ensureExchangeDeclared(req.Topic)
+	s := subscription{topic: req.Topic, handler: handler, deadLetterTopic: req.DeadLetterTopic()}
+	err := r.subscribe(s)
+	if err != nil {
+		return err
+	}
+
+	// Subscriptions are made again after reconnecting
+	r.lock.Lock()
+	r.subscriptions = append(r.subscriptions, s)
+	r.lock.Unlock()
+
+	return nil
+}
+
+func (r *rabbitMQ) subscribe(s subscription) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	exchange, bindingKey := r.exchange(s.topic)
+	err := r.ensureExchangeDeclared(exchange)
 	if err != nil {
 		return err
 	}
 
-	queueName := fmt.Sprintf("%s-%s", r.metadata.consumerID, req.Topic)
+	queueName := fmt.Sprintf("%s-%s", r.metadata.consumerID, s.topic)
 
 	r.logger.Debugf("%s declaring queue '%s'", logMessagePrefix, queueName)
 	q, err := r.channel.QueueDeclare(queueName, r.metadata.durable, r.metadata.deleteWhenUnused, true, false, nil)
@@ -96,8 +243,8 @@ func (r *rabbitMQ) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubs
 		return err
 	}
 
-	r.logger.Debugf("%s binding queue '%s' to exchange '%s'", logMessagePrefix, q.Name, req.Topic)
-	err = r.channel.QueueBind(q.Name, "", req.Topic, false, nil)
+	r.logger.Debugf("%s binding queue '%s' to exchange '%s' with key '%s'", logMessagePrefix, q.Name, exchange, bindingKey)
+	err = r.channel.QueueBind(q.Name, bindingKey, exchange, false, nil)
 	if err != nil {
 		return err
 	}
@@ -116,51 +263,193 @@ func (r *rabbitMQ) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubs
 		return err
 	}
 
-	go r.listenMessages(msgs, req.Topic, handler)
+	go r.listenMessages(msgs, s)
 
 	return nil
 }
 
-func (r *rabbitMQ) listenMessages(msgs <-chan amqp.Delivery, topic string, handler func(msg *pubsub.NewMessage) error) {
-	for d := range msgs {
-		r.handleMessage(d, topic, handler)
+// listenMessages handles the messages with as many goroutines as the concurrency. Messages are only handled in order with a concurrency of 1.
+func (r *rabbitMQ) listenMessages(msgs <-chan amqp.Delivery, s subscription) {
+	concurrency := r.metadata.concurrency
+	if concurrency < 1 {
+		concurrency = defaultConcurrency
 	}
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for d := range msgs {
+				r.handleMessage(d, s)
+			}
+		}()
+	}
+	wg.Wait()
 }
 
-func (r *rabbitMQ) handleMessage(d amqp.Delivery, topic string, handler func(msg *pubsub.NewMessage) error) {
+// handleMessage handles a delivery, and acknowledges it unless it is auto acknowledged. Deliveries are acknowledged on
+// the channel they came from. Failed messages that are not requeued are published to the dead-letter topic of the
+// subscription if it has one.
+func (r *rabbitMQ) handleMessage(d amqp.Delivery, s subscription) {
+	topic := s.topic
 	pubsubMsg := &pubsub.NewMessage{
-		Data:  d.Body,
-		Topic: topic,
+		Data:     d.Body,
+		Topic:    topic,
+		Metadata: map[string]string{pubsub.ReceivedTopicMetadataKey: r.receivedTopic(d)},
 	}
 
-	err := handler(pubsubMsg)
+	err := s.handler(pubsubMsg)
 	if err != nil {
 		r.logger.Errorf("%s error handling message from topic '%s', %s", logMessagePrefix, topic, err)
 	}
 
-	//nolint:nestif
-	// if message is not auto acked we need to ack/nack
-	if !r.metadata.autoAck {
-		if err != nil {
-			requeue := r.metadata.requeueInFailure && !d.Redelivered
+	if r.metadata.autoAck {
+		if err != nil && s.deadLetterTopic != "" {
+			r.deadLetter(pubsubMsg, s.deadLetterTopic, err)
+		}
+		return
+	}
 
-			r.logger.Debugf("%s nacking message '%s' from topic '%s', requeue=%t", logMessagePrefix, d.MessageId, topic, requeue)
-			if err = r.channel.Nack(d.DeliveryTag, false, requeue); err != nil {
-				r.logger.Errorf("%s error nacking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
-			}
-		} else {
-			r.logger.Debugf("%s acking message '%s' from topic '%s'", logMessagePrefix, d.MessageId, topic)
-			if err = r.channel.Ack(d.DeliveryTag, false); err != nil {
-				r.logger.Errorf("%s error acking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
-			}
+	if err == nil {
+		r.ack(d, topic)
+		return
+	}
+
+	requeue := r.metadata.requeueInFailure && !d.Redelivered
+	// Dead letters are acknowledged, and requeued if they could not be published so that they are not lost
+	if !requeue && s.deadLetterTopic != "" {
+		if r.deadLetter(pubsubMsg, s.deadLetterTopic, err) {
+			r.ack(d, topic)
+			return
+		}
+		requeue = true
+	}
+
+	r.logger.Debugf("%s nacking message '%s' from topic '%s', requeue=%t", logMessagePrefix, d.MessageId, topic, requeue)
+	if err = d.Nack(false, requeue); err != nil {
+		r.logger.Errorf("%s error nacking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+	}
+}
+
+func (r *rabbitMQ) ack(d amqp.Delivery, topic string) {
+	r.logger.Debugf("%s acking message '%s' from topic '%s'", logMessagePrefix, d.MessageId, topic)
+	if err := d.Ack(false); err != nil {
+		r.logger.Errorf("%s error acking message '%s' from topic '%s', %s", logMessagePrefix, d.MessageId, topic, err)
+	}
+}
+
+// publishing returns the message of a publish request
+func (r *rabbitMQ) publishing(req *pubsub.PublishRequest) (amqp.Publishing, error) {
+	msg := amqp.Publishing{
+		ContentType:  "text/plain",
+		Body:         req.Data,
+		DeliveryMode: r.metadata.deliveryMode,
+	}
+
+	if val, found := req.Metadata[metadataDeliveryModeKey]; found && val != "" {
+		intVal, err := strconv.Atoi(val)
+		if err != nil || intVal < 0 || intVal > 2 {
+			return msg, fmt.Errorf("%s invalid RabbitMQ delivery mode %s, accepted values are between 0 and 2", errorMessagePrefix, val)
+		}
+		msg.DeliveryMode = uint8(intVal)
+	}
+
+	ttl, ok, err := pubsub.TryGetTTL(req.Metadata)
+	if err != nil {
+		return msg, fmt.Errorf("%s %s", errorMessagePrefix, err)
+	}
+	if ok {
+		// The expiration of a message is in milliseconds
+		msg.Expiration = strconv.FormatInt(ttl.Milliseconds(), 10)
+	}
+
+	delay, ok, err := pubsub.TryGetDeliveryDelay(req.Metadata, time.Now())
+	if err != nil {
+		return msg, fmt.Errorf("%s %s", errorMessagePrefix, err)
+	}
+	if ok {
+		// Only the exchanges of the delayed message exchange plugin delay messages, by their delay header in milliseconds
+		if !r.metadata.delayedMessageExchange {
+			return msg, fmt.Errorf("%s delayed messages require the %s metadata", errorMessagePrefix, metadataDelayedMessageExchangeKey)
 		}
+		msg.Headers = amqp.Table{delayHeader: delay.Milliseconds()}
 	}
+
+	return msg, nil
 }
 
+// deadLetter publishes the message to the dead-letter topic, and returns whether it succeeded
+func (r *rabbitMQ) deadLetter(msg *pubsub.NewMessage, deadLetterTopic string, handlerErr error) bool {
+	req := pubsub.NewDeadLetterRequest(deadLetterTopic, msg, handlerErr)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	exchange, routingKey := r.exchange(req.Topic)
+	err := r.ensureExchangeDeclared(exchange)
+	if err == nil {
+		r.logger.Debugf("%s publishing message from topic '%s' to dead-letter topic '%s'", logMessagePrefix, msg.Topic, req.Topic)
+		err = r.channel.Publish(exchange, routingKey, false, false, deadLetterPublishing(req, r.metadata.deliveryMode))
+	}
+	if err != nil {
+		r.logger.Errorf("%s error publishing message from topic '%s' to dead-letter topic '%s', %s", logMessagePrefix, msg.Topic, req.Topic, err)
+		return false
+	}
+
+	return true
+}
+
+// deadLetterPublishing returns the message of a dead letter, with its metadata in the headers
+func deadLetterPublishing(req *pubsub.PublishRequest, deliveryMode uint8) amqp.Publishing {
+	headers := make(amqp.Table, len(req.Metadata))
+	for k, v := range req.Metadata {
+		headers[k] = v
+	}
+
+	return amqp.Publishing{
+		ContentType:  "text/plain",
+		Body:         req.Data,
+		DeliveryMode: deliveryMode,
+		Headers:      headers,
+	}
+}
+
+// exchange returns the exchange and the routing key of a topic. Topics are fanout exchanges, or the routing keys of the
+// topic exchange of the component when it has one, which subscriptions bind to with the * and # wildcards.
+func (r *rabbitMQ) exchange(topic string) (string, string) {
+	if r.metadata.topicExchange != "" {
+		return r.metadata.topicExchange, topic
+	}
+
+	return topic, ""
+}
+
+// receivedTopic returns the topic that a delivery was published to
+func (r *rabbitMQ) receivedTopic(d amqp.Delivery) string {
+	if r.metadata.topicExchange != "" {
+		return d.RoutingKey
+	}
+
+	return d.Exchange
+}
+
+// ensureExchangeDeclared declares the durable exchange of a topic. It must be called with the lock held.
 func (r *rabbitMQ) ensureExchangeDeclared(exchange string) error {
 	if _, exists := r.declaredExchanges[exchange]; !exists {
-		r.logger.Debugf("%s declaring exchange '%s' of kind '%s'", logMessagePrefix, exchange, fanoutExchangeKind)
-		err := r.channel.ExchangeDeclare(exchange, fanoutExchangeKind, true, false, false, false, nil)
+		kind := fanoutExchangeKind
+		if exchange == r.metadata.topicExchange {
+			kind = topicExchangeKind
+		}
+		// Delayed message exchanges route the messages like the exchanges of their delayed type once they are due
+		var args amqp.Table
+		if r.metadata.delayedMessageExchange {
+			args = amqp.Table{delayedTypeArgument: kind}
+			kind = delayedMessageExchangeKind
+		}
+
+		r.logger.Debugf("%s declaring exchange '%s' of kind '%s'", logMessagePrefix, exchange, kind)
+		err := r.channel.ExchangeDeclare(exchange, kind, true, false, false, false, args)
 		if err != nil {
 			return err
 		}
@@ -1,6 +1,7 @@
 package rabbitmq
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/dapr/components-contrib/pubsub"
@@ -37,7 +38,7 @@ func TestProcessSubscriberMessage(t *testing.T) {
 		return nil
 	}
 
-	go testRabbitMQSubscriber.listenMessages(ch, topic, fakeHandler)
+	go testRabbitMQSubscriber.listenMessages(ch, subscription{topic: topic, handler: fakeHandler})
 	assert.Equal(t, messageCount, 0)
 	ch <- createAMQPMessage("{ \"msg\": \"1\"}")
 	ch <- createAMQPMessage("{ \"msg\": \"2\"}")
@@ -47,3 +48,138 @@ func TestProcessSubscriberMessage(t *testing.T) {
 	assert.GreaterOrEqual(t, messageCount, 2)
 	assert.LessOrEqual(t, messageCount, 3)
 }
+
+type fakeAcknowledger struct {
+	acked  []uint64
+	nacked []uint64
+}
+
+func (a *fakeAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = append(a.acked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked = append(a.nacked, tag)
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func TestHandleMessageAcknowledgesDelivery(t *testing.T) {
+	r := &rabbitMQ{
+		metadata: &metadata{autoAck: false},
+		logger:   logger.NewLogger("test"),
+	}
+	acknowledger := &fakeAcknowledger{}
+
+	r.handleMessage(amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 1}, subscription{topic: "topic", handler: func(msg *pubsub.NewMessage) error {
+		return nil
+	}})
+	r.handleMessage(amqp.Delivery{Acknowledger: acknowledger, DeliveryTag: 2}, subscription{topic: "topic", handler: func(msg *pubsub.NewMessage) error {
+		return fmt.Errorf("failed")
+	}})
+
+	assert.Equal(t, []uint64{1}, acknowledger.acked)
+	assert.Equal(t, []uint64{2}, acknowledger.nacked)
+}
+
+func TestReceivedTopic(t *testing.T) {
+	d := amqp.Delivery{Acknowledger: &fakeAcknowledger{}, Exchange: "orders", RoutingKey: "orders.created"}
+	for _, test := range []struct {
+		topicExchange string
+		exchange      string
+		key           string
+		received      string
+	}{
+		{"", "orders.*", "", "orders"},
+		{"events", "events", "orders.*", "orders.created"},
+	} {
+		r := &rabbitMQ{
+			metadata: &metadata{topicExchange: test.topicExchange},
+			logger:   logger.NewLogger("test"),
+		}
+
+		exchange, key := r.exchange("orders.*")
+		var received *pubsub.NewMessage
+		r.handleMessage(d, subscription{topic: "orders.*", handler: func(msg *pubsub.NewMessage) error {
+			received = msg
+			return nil
+		}})
+
+		assert.Equal(t, test.exchange, exchange)
+		assert.Equal(t, test.key, key)
+		assert.Equal(t, "orders.*", received.Topic)
+		assert.Equal(t, test.received, received.Metadata[pubsub.ReceivedTopicMetadataKey])
+	}
+}
+
+func TestListenMessagesConcurrently(t *testing.T) {
+	r := &rabbitMQ{
+		metadata: &metadata{autoAck: true, concurrency: 3},
+		logger:   logger.NewLogger("test"),
+	}
+
+	ch := make(chan amqp.Delivery)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		r.listenMessages(ch, subscription{topic: "topic", handler: func(msg *pubsub.NewMessage) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}})
+		close(done)
+	}()
+
+	// All three messages are handled at the same time
+	for i := 0; i < 3; i++ {
+		ch <- createAMQPMessage("{}")
+	}
+	for i := 0; i < 3; i++ {
+		<-started
+	}
+	close(release)
+	close(ch)
+	<-done
+}
+
+func TestPublishMetadata(t *testing.T) {
+	r := &rabbitMQ{
+		metadata: &metadata{},
+		logger:   logger.NewLogger("test"),
+	}
+
+	err := r.Publish(&pubsub.PublishRequest{Topic: "topic", Metadata: map[string]string{metadataDeliveryModeKey: "3"}})
+	assert.Error(t, err)
+
+	err = r.Publish(&pubsub.PublishRequest{Topic: "topic", Metadata: map[string]string{pubsub.TTLMetadataKey: "soon"}})
+	assert.Error(t, err)
+
+	// Messages are only delayed by delayed message exchanges
+	err = r.Publish(&pubsub.PublishRequest{Topic: "topic", Metadata: map[string]string{pubsub.DeliverAfterMetadataKey: "10s"}})
+	assert.Error(t, err)
+}
+
+func TestPublishingDelay(t *testing.T) {
+	r := &rabbitMQ{metadata: &metadata{delayedMessageExchange: true}}
+
+	msg, err := r.publishing(&pubsub.PublishRequest{Topic: "topic", Data: []byte("data"), Metadata: map[string]string{pubsub.DeliverAfterMetadataKey: "10s"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), msg.Body)
+	assert.Equal(t, amqp.Table{delayHeader: int64(10000)}, msg.Headers)
+}
+
+func TestDeadLetterPublishing(t *testing.T) {
+	req := pubsub.NewDeadLetterRequest("dead", &pubsub.NewMessage{Topic: "topic", Data: []byte("data")}, fmt.Errorf("failed"))
+
+	msg := deadLetterPublishing(req, 2)
+
+	assert.Equal(t, []byte("data"), msg.Body)
+	assert.Equal(t, uint8(2), msg.DeliveryMode)
+	assert.Equal(t, amqp.Table{pubsub.DeadLetterOriginalTopicKey: "topic", pubsub.DeadLetterErrorKey: "failed"}, msg.Headers)
+}
@@ -5,9 +5,21 @@
 
 package redis
 
+import "time"
+
 type metadata struct {
 	host       string
 	password   string
 	consumerID string
 	enableTLS  bool
+	// processingTimeout is how long a message is pending before it is claimed for redelivery, which is disabled when 0
+	processingTimeout time.Duration
+	// redeliverInterval is how often the pending messages are checked for redelivery
+	redeliverInterval time.Duration
+	// maxRetries is how many times the messages of subscriptions with a dead-letter topic are redelivered
+	maxRetries int
+	// concurrency is the maximum number of messages handled at the same time
+	concurrency int
+	// maxInFlight is the maximum number of messages read from the streams that are handled or waiting to be
+	maxInFlight int
 }
@@ -10,9 +10,13 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
 
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/go-redis/redis/v7"
@@ -23,11 +27,39 @@ const (
 	password   = "redisPassword"
 	consumerID = "consumerID"
 	enableTLS  = "enableTLS"
+
+	processingTimeout = "processingTimeout"
+	redeliverInterval = "redeliverInterval"
+	concurrency       = pubsub.ConcurrencyKey
+	maxRetries        = "maxRetries"
+
+	defaultProcessingTimeout = 60 * time.Second
+	defaultRedeliverInterval = 15 * time.Second
+	defaultConcurrency       = 10
+	// defaultMaxRetries is how many times the messages of subscriptions with a dead-letter topic are redelivered
+	defaultMaxRetries = 3
+
+	// readRetryInterval is how long to wait before reading from a stream again after an error
+	readRetryInterval = 1 * time.Second
+	// pendingBatchSize is the maximum number of pending messages checked for redelivery at a time
+	pendingBatchSize = 100
 )
 
+// redisStreams delivers the messages of a stream to one of the consumers of the consumer group named after the
+// consumerID, so that every message is handled by one instance of an app. Messages are acknowledged once they are
+// handled, and the messages left pending by consumers that failed or crashed are claimed for redelivery.
 type redisStreams struct {
 	metadata metadata
 	client   *redis.Client
+	// consumerName is the name of the consumer of this instance in the consumer groups
+	consumerName string
+	// workers limits the number of messages handled at the same time
+	workers chan struct{}
+	// inFlight limits the number of messages handled or waiting for a worker
+	inFlight chan struct{}
+	// deadLetterTopics are the dead-letter topics of the subscribed streams that have one
+	deadLetterTopics map[string]string
+	lock             sync.RWMutex
 
 	logger logger.Logger
 }
@@ -63,9 +95,65 @@ func parseRedisMetadata(meta pubsub.Metadata) (metadata, error) {
 		return m, errors.New("redis streams error: missing consumerID")
 	}
 
+	m.processingTimeout = defaultProcessingTimeout
+	if val, ok := meta.Properties[processingTimeout]; ok && val != "" {
+		d, err := parseDuration(val)
+		if err != nil {
+			return m, fmt.Errorf("redis streams error: can't parse processingTimeout field: %s", err)
+		}
+		m.processingTimeout = d
+	}
+
+	m.redeliverInterval = defaultRedeliverInterval
+	if val, ok := meta.Properties[redeliverInterval]; ok && val != "" {
+		d, err := parseDuration(val)
+		if err != nil || d == 0 {
+			return m, fmt.Errorf("redis streams error: invalid redeliverInterval %s", val)
+		}
+		m.redeliverInterval = d
+	}
+
+	m.maxRetries = defaultMaxRetries
+	if val, ok := meta.Properties[maxRetries]; ok && val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			return m, fmt.Errorf("redis streams error: invalid maxRetries %s", val)
+		}
+		m.maxRetries = n
+	}
+
+	c, maxInFlight, err := pubsub.TryGetConcurrency(meta.Properties, defaultConcurrency)
+	if err != nil {
+		return m, fmt.Errorf("redis streams error: %s", err)
+	}
+	m.concurrency = c
+	m.maxInFlight = c
+	if maxInFlight > 0 {
+		m.maxInFlight = maxInFlight
+	}
+
 	return m, nil
 }
 
+// parseDuration parses a duration, or a number of milliseconds
+func parseDuration(val string) (time.Duration, error) {
+	if ms, err := strconv.Atoi(val); err == nil {
+		if ms < 0 {
+			return 0, fmt.Errorf("negative duration %s", val)
+		}
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %s", val)
+	}
+	return d, nil
+}
+
 func (r *redisStreams) Init(metadata pubsub.Metadata) error {
 	m, err := parseRedisMetadata(metadata)
 	if err != nil {
@@ -96,13 +184,56 @@ func (r *redisStreams) Init(metadata pubsub.Metadata) error {
 	}
 
 	r.client = client
+	r.consumerName = uuid.New().String()
+	r.workers = make(chan struct{}, m.concurrency)
+	r.inFlight = make(chan struct{}, m.maxInFlight)
+	r.deadLetterTopics = map[string]string{}
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Redis Streams pub/sub
+func (r *redisStreams) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	fields := []componentmetadata.Field{
+		{Name: host, Type: componentmetadata.TypeString, Required: true},
+		{Name: password, Type: componentmetadata.TypeString},
+		{Name: enableTLS, Type: componentmetadata.TypeBool},
+		{Name: consumerID, Type: componentmetadata.TypeString, Required: true},
+		// the durations are also accepted as numbers of milliseconds
+		{Name: processingTimeout, Type: componentmetadata.TypeString, Default: defaultProcessingTimeout.String()},
+		{Name: redeliverInterval, Type: componentmetadata.TypeString, Default: defaultRedeliverInterval.String()},
+		{Name: maxRetries, Type: componentmetadata.TypeInt, Default: strconv.Itoa(defaultMaxRetries)},
+	}
+
+	return componentmetadata.ComponentMetadata{
+		Fields:       append(fields, pubsub.ConcurrencyMetadataFields(defaultConcurrency)...),
+		Capabilities: []componentmetadata.Capability{componentmetadata.CapabilityTTL},
+	}
+}
+
+// Ping pings the Redis server, for health checks
+func (r *redisStreams) Ping() error {
+	if r.client == nil {
+		return fmt.Errorf("the Redis pub/sub is not initialized")
+	}
+	return r.client.Ping().Err()
+}
+
+// Publish adds the message to the stream. Streams are only trimmed by length, so the messages with a TTL have their
+// expiration as a field, and they are dropped instead of handled once it is over.
 func (r *redisStreams) Publish(req *pubsub.PublishRequest) error {
-	_, err := r.client.XAdd(&redis.XAddArgs{
+	ttl, ok, err := pubsub.TryGetTTL(req.Metadata)
+	if err != nil {
+		return fmt.Errorf("redis streams: error from publish: %s", err)
+	}
+
+	values := map[string]interface{}{"data": req.Data}
+	if ok {
+		values[pubsub.ExpirationMetadataKey] = pubsub.Expiration(ttl, time.Now())
+	}
+
+	_, err = r.client.XAdd(&redis.XAddArgs{
 		Stream: req.Topic,
-		Values: map[string]interface{}{"data": req.Data},
+		Values: values,
 	}).Result()
 	if err != nil {
 		return fmt.Errorf("redis streams: error from publish: %s", err)
@@ -113,18 +244,30 @@ func (r *redisStreams) Publish(req *pubsub.PublishRequest) error {
 
 func (r *redisStreams) Subscribe(req pubsub.SubscribeRequest, handler func(msg *pubsub.NewMessage) error) error {
 	err := r.client.XGroupCreateMkStream(req.Topic, r.metadata.consumerID, "0").Err()
-	if err != nil {
+	// The consumer group already exists when another instance subscribed first
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
 		r.logger.Warnf("redis streams: %s", err)
 	}
+
+	r.lock.Lock()
+	if deadLetterTopic := req.DeadLetterTopic(); deadLetterTopic != "" {
+		r.deadLetterTopics[req.Topic] = deadLetterTopic
+	}
+	r.lock.Unlock()
+
 	go r.beginReadingFromStream(req.Topic, r.metadata.consumerID, handler)
+	if r.metadata.processingTimeout > 0 {
+		go r.reclaimPendingMessages(req.Topic, r.metadata.consumerID, handler)
+	}
 	return nil
 }
 
 func (r *redisStreams) readFromStream(stream, consumerID, start string) ([]redis.XStream, error) {
 	res, err := r.client.XReadGroup(&redis.XReadGroupArgs{
 		Group:    consumerID,
-		Consumer: consumerID,
+		Consumer: r.consumerName,
 		Streams:  []string{stream, start},
+		Count:    int64(r.metadata.maxInFlight),
 		Block:    0,
 	}).Result()
 	if err != nil {
@@ -134,40 +277,157 @@ func (r *redisStreams) readFromStream(stream, consumerID, start string) ([]redis
 	return res, nil
 }
 
+// processStreams handles the messages concurrently, up to the concurrency of the component, and returns once the
+// messages in flight are fewer than the max in flight. Messages that were not handled stay pending until they are
+// claimed for redelivery.
 func (r *redisStreams) processStreams(consumerID string, streams []redis.XStream, handler func(msg *pubsub.NewMessage) error) {
 	for _, s := range streams {
-		for _, m := range s.Messages {
-			go func(stream string, message redis.XMessage) {
-				msg := pubsub.NewMessage{
-					Topic: stream,
+		r.processMessages(consumerID, s.Stream, s.Messages, nil, handler)
+	}
+}
+
+// processMessages handles the messages, which were delivered as many times before as their deliveries. The messages
+// of streams with a dead-letter topic are published there once their retries are exhausted.
+func (r *redisStreams) processMessages(consumerID, stream string, messages []redis.XMessage, deliveries map[string]int64, handler func(msg *pubsub.NewMessage) error) {
+	r.lock.RLock()
+	deadLetterTopic := r.deadLetterTopics[stream]
+	r.lock.RUnlock()
+
+	for _, m := range messages {
+		r.inFlight <- struct{}{}
+		go func(message redis.XMessage) {
+			defer func() { <-r.inFlight }()
+			r.workers <- struct{}{}
+			defer func() { <-r.workers }()
+
+			msg := pubsub.NewMessage{
+				Topic: stream,
+			}
+			for k, v := range message.Values {
+				if k == "data" {
+					if v != nil {
+						msg.Data = []byte(v.(string))
+					}
+					continue
 				}
-				data, exists := message.Values["data"]
-				if exists && data != nil {
-					msg.Data = []byte(data.(string))
+				// The other fields are the expiration of the message, or the metadata of dead letters
+				if msg.Metadata == nil {
+					msg.Metadata = map[string]string{}
 				}
+				msg.Metadata[k], _ = v.(string)
+			}
 
-				err := handler(&msg)
-				if err == nil {
-					r.client.XAck(stream, consumerID, message.ID).Result()
+			var err error
+			if pubsub.HasExpired(msg.Metadata, time.Now()) {
+				r.logger.Debugf("redis streams: dropping expired message %s from stream %s", message.ID, stream)
+			} else {
+				err = handler(&msg)
+			}
+			if err != nil {
+				r.logger.Debugf("redis streams: error handling message %s from stream %s: %s", message.ID, stream, err)
+
+				if deadLetterTopic == "" || !r.retriesExhausted(deliveries[message.ID]) {
+					return
 				}
-			}(s.Stream, m)
-		}
+				err = r.deadLetter(&msg, deadLetterTopic, err)
+				if err != nil {
+					r.logger.Errorf("redis streams: error publishing message %s from stream %s to dead-letter stream %s: %s", message.ID, stream, deadLetterTopic, err)
+					return
+				}
+			}
+
+			err = r.client.XAck(stream, consumerID, message.ID).Err()
+			if err != nil {
+				r.logger.Errorf("redis streams: error acknowledging message %s from stream %s: %s", message.ID, stream, err)
+			}
+		}(m)
 	}
 }
 
 func (r *redisStreams) beginReadingFromStream(stream, consumerID string, handler func(msg *pubsub.NewMessage) error) {
-	// first read pending items in case of recovering from crash
-	start := "0"
-
+	// Only new messages are read, since the pending messages of crashed consumers are claimed for redelivery
 	for {
-		streams, err := r.readFromStream(stream, consumerID, start)
+		streams, err := r.readFromStream(stream, consumerID, ">")
 		if err != nil {
-			r.logger.Errorf("redis streams: error reading from stream %s: %s", stream, err)
-			return
+			r.logger.Errorf("redis streams: error reading from stream %s, retrying in %s: %s", stream, readRetryInterval, err)
+			time.Sleep(readRetryInterval)
+			continue
 		}
 		r.processStreams(consumerID, streams, handler)
+	}
+}
+
+// reclaimPendingMessages periodically claims the messages of the consumer group that have been pending for longer than
+// the processing timeout, and handles them again. This is how the messages of consumers that crashed, and the ones
+// that failed to be handled, are redelivered.
+func (r *redisStreams) reclaimPendingMessages(stream, consumerID string, handler func(msg *pubsub.NewMessage) error) {
+	ticker := time.NewTicker(r.metadata.redeliverInterval)
+	defer ticker.Stop()
 
-		//continue with new non received items
-		start = ">"
+	for range ticker.C {
+		messages, deliveries, err := r.claimPendingMessages(stream, consumerID)
+		if err != nil {
+			r.logger.Errorf("redis streams: error claiming pending messages from stream %s: %s", stream, err)
+			continue
+		}
+		r.processMessages(consumerID, stream, messages, deliveries, handler)
 	}
 }
+
+// retriesExhausted returns whether a message that failed after as many deliveries before is not redelivered anymore,
+// which is always the case without processing timeout
+func (r *redisStreams) retriesExhausted(deliveries int64) bool {
+	return r.metadata.processingTimeout == 0 || deliveries >= int64(r.metadata.maxRetries)
+}
+
+// deadLetter adds the message to the dead-letter stream, with its metadata as fields
+func (r *redisStreams) deadLetter(msg *pubsub.NewMessage, deadLetterTopic string, handlerErr error) error {
+	req := pubsub.NewDeadLetterRequest(deadLetterTopic, msg, handlerErr)
+	values := make(map[string]interface{}, len(req.Metadata)+1)
+	for k, v := range req.Metadata {
+		values[k] = v
+	}
+	values["data"] = req.Data
+
+	return r.client.XAdd(&redis.XAddArgs{
+		Stream: req.Topic,
+		Values: values,
+	}).Err()
+}
+
+// claimPendingMessages claims the messages pending for longer than the processing timeout, with XPENDING and XCLAIM
+// since XAUTOCLAIM requires Redis 6.2, and returns them with the number of times they were delivered
+func (r *redisStreams) claimPendingMessages(stream, consumerID string) ([]redis.XMessage, map[string]int64, error) {
+	pending, err := r.client.XPendingExt(&redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  consumerID,
+		Start:  "-",
+		End:    "+",
+		Count:  pendingBatchSize,
+	}).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, 0, len(pending))
+	deliveries := make(map[string]int64, len(pending))
+	for _, p := range pending {
+		if p.Idle >= r.metadata.processingTimeout {
+			ids = append(ids, p.ID)
+			deliveries[p.ID] = p.RetryCount
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+
+	// Messages claimed by another consumer in the meantime are not idle anymore, so they are not returned
+	messages, err := r.client.XClaim(&redis.XClaimArgs{
+		Stream:   stream,
+		Group:    consumerID,
+		Consumer: r.consumerName,
+		MinIdle:  r.metadata.processingTimeout,
+		Messages: ids,
+	}).Result()
+	return messages, deliveries, err
+}
@@ -8,6 +8,7 @@ package redis
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -44,6 +45,66 @@ func TestParseRedisMetadata(t *testing.T) {
 		assert.Equal(t, fakeProperties[password], m.password)
 		assert.Equal(t, fakeProperties[consumerID], m.consumerID)
 		assert.Equal(t, true, m.enableTLS)
+		assert.Equal(t, defaultProcessingTimeout, m.processingTimeout)
+		assert.Equal(t, defaultRedeliverInterval, m.redeliverInterval)
+		assert.Equal(t, defaultConcurrency, m.concurrency)
+		assert.Equal(t, defaultConcurrency, m.maxInFlight)
+		assert.Equal(t, defaultMaxRetries, m.maxRetries)
+	})
+
+	t.Run("redelivery and concurrency are given", func(t *testing.T) {
+		fakeProperties := getFakeProperties()
+		fakeProperties[processingTimeout] = "2m"
+		fakeProperties[redeliverInterval] = "5000"
+		fakeProperties[concurrency] = "3"
+		fakeProperties[pubsub.MaxInFlightKey] = "30"
+
+		fakeMetaData := pubsub.Metadata{
+			Properties: fakeProperties,
+		}
+
+		// act
+		m, err := parseRedisMetadata(fakeMetaData)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, m.processingTimeout)
+		assert.Equal(t, 5*time.Second, m.redeliverInterval)
+		assert.Equal(t, 3, m.concurrency)
+		assert.Equal(t, 30, m.maxInFlight)
+	})
+
+	t.Run("redelivery is disabled", func(t *testing.T) {
+		fakeProperties := getFakeProperties()
+		fakeProperties[processingTimeout] = "0"
+
+		fakeMetaData := pubsub.Metadata{
+			Properties: fakeProperties,
+		}
+
+		// act
+		m, err := parseRedisMetadata(fakeMetaData)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), m.processingTimeout)
+	})
+
+	t.Run("redelivery and concurrency are invalid", func(t *testing.T) {
+		for key, val := range map[string]string{processingTimeout: "-1s", redeliverInterval: "0", concurrency: "0", pubsub.MaxInFlightKey: "1", maxRetries: "-1"} {
+			fakeProperties := getFakeProperties()
+			fakeProperties[key] = val
+
+			fakeMetaData := pubsub.Metadata{
+				Properties: fakeProperties,
+			}
+
+			// act
+			_, err := parseRedisMetadata(fakeMetaData)
+
+			// assert
+			assert.Error(t, err, key)
+		}
 	})
 
 	t.Run("host is not given", func(t *testing.T) {
@@ -103,7 +164,7 @@ func TestProcessStreams(t *testing.T) {
 	}
 
 	// act
-	testRedisStream := &redisStreams{logger: logger.NewLogger("test")}
+	testRedisStream := &redisStreams{logger: logger.NewLogger("test"), workers: make(chan struct{}, defaultConcurrency), inFlight: make(chan struct{}, defaultConcurrency)}
 	testRedisStream.processStreams(fakeConsumerID, generateRedisStreamTestData(2, 3, expectedData), fakeHandler)
 
 	// sleep for 10ms to give time to finish processing
@@ -114,6 +175,82 @@ func TestProcessStreams(t *testing.T) {
 	assert.Equal(t, 3, messageCount)
 }
 
+func TestProcessStreamsConcurrency(t *testing.T) {
+	var lock sync.Mutex
+	handling := 0
+	maxHandling := 0
+	done := make(chan struct{}, 6)
+
+	fakeHandler := func(msg *pubsub.NewMessage) error {
+		lock.Lock()
+		handling++
+		if handling > maxHandling {
+			maxHandling = handling
+		}
+		lock.Unlock()
+
+		time.Sleep(time.Millisecond * 10)
+
+		lock.Lock()
+		handling--
+		lock.Unlock()
+		done <- struct{}{}
+
+		// return fake error to skip executing redis client command
+		return errors.New("fake error")
+	}
+
+	// act
+	testRedisStream := &redisStreams{logger: logger.NewLogger("test"), workers: make(chan struct{}, 2), inFlight: make(chan struct{}, 4)}
+	testRedisStream.processStreams("fakeConsumer", generateRedisStreamTestData(2, 3, "testData"), fakeHandler)
+	for i := 0; i < 6; i++ {
+		<-done
+	}
+
+	// assert
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, 2, maxHandling)
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	r := &redisStreams{metadata: metadata{processingTimeout: time.Minute, maxRetries: 2}}
+	assert.False(t, r.retriesExhausted(0))
+	assert.False(t, r.retriesExhausted(1))
+	assert.True(t, r.retriesExhausted(2))
+
+	// Messages are not redelivered without processing timeout
+	r = &redisStreams{metadata: metadata{maxRetries: 2}}
+	assert.True(t, r.retriesExhausted(0))
+}
+
+func TestProcessMessagesExpired(t *testing.T) {
+	var handled []string
+	fakeHandler := func(msg *pubsub.NewMessage) error {
+		handled = append(handled, string(msg.Data))
+		return nil
+	}
+	now := time.Now()
+	messages := []redis.XMessage{
+		{ID: "1", Values: map[string]interface{}{"data": "expired", pubsub.ExpirationMetadataKey: pubsub.Expiration(0, now.Add(-time.Minute))}},
+		{ID: "2", Values: map[string]interface{}{"data": "alive", pubsub.ExpirationMetadataKey: pubsub.Expiration(time.Minute, now)}},
+	}
+
+	// act
+	testRedisStream := &redisStreams{
+		logger:   logger.NewLogger("test"),
+		client:   redis.NewClient(&redis.Options{Addr: "127.0.0.1:0", MaxRetries: -1}),
+		workers:  make(chan struct{}, 1),
+		inFlight: make(chan struct{}, 1),
+	}
+	testRedisStream.processMessages("fakeConsumer", "topic", messages, nil, fakeHandler)
+	// wait for the last message to be processed
+	testRedisStream.inFlight <- struct{}{}
+
+	// assert
+	assert.Equal(t, []string{"alive"}, handled)
+}
+
 func generateRedisStreamTestData(topicCount, messageCount int, data string) []redis.XStream {
 	generateXMessage := func(id int) redis.XMessage {
 		return redis.XMessage{
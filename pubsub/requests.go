@@ -5,19 +5,30 @@
 
 package pubsub
 
+// ReceivedTopicMetadataKey is the metadata of a message with the topic it was published to, which is only different
+// from the topic of the subscription when it has wildcards
+const ReceivedTopicMetadataKey = "receivedTopic"
+
 // PublishRequest is the request to publish a message
 type PublishRequest struct {
 	Data  []byte `json:"data"`
 	Topic string `json:"topic"`
+	// Metadata are the publish options specific to the message bus, such as the partition key of the message
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
-// SubscribeRequest is the request to subscribe to a topic
+// SubscribeRequest is the request to subscribe to a topic. The topic can have the wildcards of the message bus when
+// it supports them, such as `orders/+` with MQTT.
 type SubscribeRequest struct {
 	Topic string `json:"topic"`
+	// Metadata are the subscription options, such as the dead-letter topic of the subscription
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // NewMessage is an event arriving from a message bus instance
 type NewMessage struct {
 	Data  []byte `json:"data"`
 	Topic string `json:"topic"`
+	// Metadata are the properties of the message specific to the message bus, such as the partition it was received from
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
@@ -0,0 +1,57 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// TTLMetadataKey is the publish metadata of the number of seconds after which a message expires
+	TTLMetadataKey = "ttlInSeconds"
+	// ExpirationMetadataKey is the metadata of the time at which a message expires, in RFC3339. Components of message
+	// buses without native expiration publish it with the messages, and drop the expired messages they receive.
+	ExpirationMetadataKey = "expiration"
+)
+
+// TryGetTTL returns the TTL of the publish metadata, and whether there is one. A message with a TTL of 0 expires
+// unless it is delivered right away.
+func TryGetTTL(metadata map[string]string) (time.Duration, bool, error) {
+	val, ok := metadata[TTLMetadataKey]
+	if !ok || val == "" {
+		return 0, false, nil
+	}
+
+	seconds, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false, fmt.Errorf("invalid %s value '%s', must be a non-negative integer", TTLMetadataKey, val)
+	}
+
+	return time.Duration(seconds) * time.Second, true, nil
+}
+
+// Expiration returns the ExpirationMetadataKey value of a message published at now with the TTL
+func Expiration(ttl time.Duration, now time.Time) string {
+	return now.Add(ttl).UTC().Format(time.RFC3339)
+}
+
+// HasExpired returns whether the message has an expiration that is over at now. Messages with an expiration that
+// cannot be parsed are not expired, so that they are not dropped.
+func HasExpired(metadata map[string]string, now time.Time) bool {
+	val, ok := metadata[ExpirationMetadataKey]
+	if !ok || val == "" {
+		return false
+	}
+
+	expiration, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return false
+	}
+
+	return now.After(expiration)
+}
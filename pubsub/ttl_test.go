@@ -0,0 +1,50 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryGetTTL(t *testing.T) {
+	ttl, ok, err := TryGetTTL(map[string]string{TTLMetadataKey: "60"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, ttl)
+
+	ttl, ok, err = TryGetTTL(map[string]string{TTLMetadataKey: "0"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), ttl)
+
+	for _, metadata := range []map[string]string{nil, {TTLMetadataKey: ""}} {
+		_, ok, err = TryGetTTL(metadata)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	for _, val := range []string{"soon", "-1"} {
+		_, _, err = TryGetTTL(map[string]string{TTLMetadataKey: val})
+		assert.Error(t, err)
+	}
+}
+
+func TestHasExpired(t *testing.T) {
+	now := time.Date(2020, 11, 30, 12, 0, 0, 0, time.UTC)
+	expiration := Expiration(time.Minute, now)
+	assert.Equal(t, "2020-11-30T12:01:00Z", expiration)
+
+	metadata := map[string]string{ExpirationMetadataKey: expiration}
+	assert.False(t, HasExpired(metadata, now))
+	assert.False(t, HasExpired(metadata, now.Add(time.Minute)))
+	assert.True(t, HasExpired(metadata, now.Add(time.Minute+time.Second)))
+
+	assert.False(t, HasExpired(nil, now))
+	assert.False(t, HasExpired(map[string]string{ExpirationMetadataKey: "tomorrow"}, now))
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
 
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
@@ -19,8 +20,12 @@ import (
 )
 
 const (
-	VersionID    = "version_id"
-	VersionStage = "version_stage"
+	VersionID    = secretstores.VersionIDMetadataKey
+	VersionStage = secretstores.VersionStageMetadataKey
+
+	// VersionIDKey and VersionStageKey are the camel case aliases of VersionID and VersionStage
+	VersionIDKey    = "versionId"
+	VersionStageKey = "versionStage"
 )
 
 // NewSecretManager returns a new secret manager store
@@ -33,11 +38,17 @@ type secretManagerMetaData struct {
 	AccessKey    string `json:"accessKey"`
 	SecretKey    string `json:"secretKey"`
 	SessionToken string `json:"sessionToken"`
+
+	Endpoint string `json:"endpoint"`
+	RoleARN  string `json:"roleArn"`
+	// ExpandJSON returns the keys of secrets holding a JSON object as separate values
+	ExpandJSON bool `json:"expandJSON,string"`
 }
 
 type smSecretStore struct {
-	client secretsmanageriface.SecretsManagerAPI
-	logger logger.Logger
+	client     secretsmanageriface.SecretsManagerAPI
+	expandJSON bool
+	logger     logger.Logger
 }
 
 // Init creates a AWS secret manager client
@@ -52,19 +63,19 @@ func (s *smSecretStore) Init(metadata secretstores.Metadata) error {
 		return err
 	}
 	s.client = client
+	s.expandJSON = meta.ExpandJSON
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the AWS Secrets Manager secret store
+func (s *smSecretStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(secretManagerMetaData{})}
+}
+
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values
 func (s *smSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
-	var versionID *string
-	if value, ok := req.Metadata[VersionID]; ok {
-		versionID = &value
-	}
-	var versionStage *string
-	if value, ok := req.Metadata[VersionStage]; ok {
-		versionStage = &value
-	}
+	versionID := requestMetadata(req.Metadata, VersionID, VersionIDKey)
+	versionStage := requestMetadata(req.Metadata, VersionStage, VersionStageKey)
 
 	output, err := s.client.GetSecretValue(&secretsmanager.GetSecretValueInput{
 		SecretId:     &req.Name,
@@ -79,14 +90,87 @@ func (s *smSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstor
 	resp := secretstores.GetSecretResponse{
 		Data: map[string]string{},
 	}
-	if output.Name != nil && output.SecretString != nil {
-		resp.Data[*output.Name] = *output.SecretString
+	if output.Name == nil {
+		return resp, nil
+	}
+
+	var value string
+	switch {
+	case output.SecretString != nil:
+		value = *output.SecretString
+	case output.SecretBinary != nil:
+		value = string(output.SecretBinary)
+	default:
+		return resp, nil
+	}
+
+	if s.expandJSON {
+		if values, ok := expandJSON(value); ok {
+			resp.Data = values
+			return resp, nil
+		}
 	}
+
+	resp.Data[*output.Name] = value
 	return resp, nil
 }
 
+// BulkGetSecret retrieves the current version of all the secrets of the account and region
+func (s *smSecretStore) BulkGetSecret(req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	var keys []string
+	err := s.client.ListSecretsPages(&secretsmanager.ListSecretsInput{}, func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		for _, entry := range page.SecretList {
+			if entry.Name != nil {
+				keys = append(keys, *entry.Name)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return secretstores.BulkGetSecretResponse{Data: nil}, fmt.Errorf("couldn't list secrets: %s", err)
+	}
+
+	return secretstores.BulkGetSecretFromKeys(s, secretstores.BulkGetSecretRequest{}, keys)
+}
+
+// requestMetadata returns the value of the first of the keys set in the request metadata, or nil
+func requestMetadata(metadata map[string]string, keys ...string) *string {
+	for _, key := range keys {
+		if value, ok := metadata[key]; ok {
+			return &value
+		}
+	}
+	return nil
+}
+
+// expandJSON returns the keys of a JSON object as a map of values, non-string values being JSON encoded.
+// It returns false when the value isn't a JSON object.
+func expandJSON(value string) (map[string]string, bool) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return nil, false
+	}
+
+	values := make(map[string]string, len(object))
+	for k, raw := range object {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			s = string(raw)
+		}
+		values[k] = s
+	}
+	return values, true
+}
+
 func (s *smSecretStore) getClient(metadata *secretManagerMetaData) (*secretsmanager.SecretsManager, error) {
-	sess, err := aws_auth.GetClient(metadata.AccessKey, metadata.SecretKey, metadata.Region, "")
+	sess, err := aws_auth.GetClientWithOptions(aws_auth.ClientOptions{
+		AccessKey:    metadata.AccessKey,
+		SecretKey:    metadata.SecretKey,
+		SessionToken: metadata.SessionToken,
+		RoleARN:      metadata.RoleARN,
+		Region:       metadata.Region,
+		Endpoint:     metadata.Endpoint,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ const secretValue = "secret"
 
 type mockedSM struct {
 	GetSecretValueFn func(*secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error)
+	ListSecretsFn    func(*secretsmanager.ListSecretsInput) (*secretsmanager.ListSecretsOutput, error)
 	secretsmanageriface.SecretsManagerAPI
 }
 
@@ -27,6 +28,15 @@ func (m *mockedSM) GetSecretValue(input *secretsmanager.GetSecretValueInput) (*s
 	return m.GetSecretValueFn(input)
 }
 
+func (m *mockedSM) ListSecretsPages(input *secretsmanager.ListSecretsInput, fn func(*secretsmanager.ListSecretsOutput, bool) bool) error {
+	output, err := m.ListSecretsFn(input)
+	if err != nil {
+		return err
+	}
+	fn(output, true)
+	return nil
+}
+
 func TestInit(t *testing.T) {
 	m := secretstores.Metadata{}
 	s := NewSecretManager(logger.NewLogger("test"))
@@ -41,6 +51,16 @@ func TestInit(t *testing.T) {
 		err := s.Init(m)
 		assert.Nil(t, err)
 	})
+
+	t.Run("Init with json expansion", func(t *testing.T) {
+		m.Properties = map[string]string{
+			"region":     "a",
+			"expandJSON": "true",
+		}
+		err := s.Init(m)
+		assert.Nil(t, err)
+		assert.True(t, s.(*smSecretStore).expandJSON)
+	})
 }
 
 func TestGetSecret(t *testing.T) {
@@ -120,6 +140,61 @@ func TestGetSecret(t *testing.T) {
 		})
 	})
 
+	t.Run("with camel case version id and version stage", func(t *testing.T) {
+		s := smSecretStore{
+			client: &mockedSM{
+				GetSecretValueFn: func(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+					assert.Equal(t, "1", *input.VersionId)
+					assert.Equal(t, "dev", *input.VersionStage)
+					secret := secretValue
+					return &secretsmanager.GetSecretValueOutput{
+						Name:         input.SecretId,
+						SecretString: &secret,
+					}, nil
+				},
+			},
+		}
+
+		req := secretstores.GetSecretRequest{
+			Name: "/aws/secret/testing",
+			Metadata: map[string]string{
+				VersionIDKey:    "1",
+				VersionStageKey: "dev",
+			},
+		}
+		output, e := s.GetSecret(req)
+		assert.Nil(t, e)
+		assert.Equal(t, secretValue, output.Data[req.Name])
+	})
+
+	t.Run("with json expansion", func(t *testing.T) {
+		secret := `{"username": "dapr", "port": 5432}`
+		s := smSecretStore{
+			expandJSON: true,
+			client: &mockedSM{
+				GetSecretValueFn: func(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+					return &secretsmanager.GetSecretValueOutput{
+						Name:         input.SecretId,
+						SecretString: &secret,
+					}, nil
+				},
+			},
+		}
+
+		req := secretstores.GetSecretRequest{
+			Name:     "/aws/secret/testing",
+			Metadata: map[string]string{},
+		}
+		output, e := s.GetSecret(req)
+		assert.Nil(t, e)
+		assert.Equal(t, map[string]string{"username": "dapr", "port": "5432"}, output.Data)
+
+		secret = secretValue
+		output, e = s.GetSecret(req)
+		assert.Nil(t, e)
+		assert.Equal(t, map[string]string{req.Name: secretValue}, output.Data)
+	})
+
 	t.Run("unsuccessfully retrieve secret", func(t *testing.T) {
 		s := smSecretStore{
 			client: &mockedSM{
@@ -136,3 +211,42 @@ func TestGetSecret(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestBulkGetSecret(t *testing.T) {
+	t.Run("successfully retrieve secrets", func(t *testing.T) {
+		s := smSecretStore{
+			client: &mockedSM{
+				ListSecretsFn: func(input *secretsmanager.ListSecretsInput) (*secretsmanager.ListSecretsOutput, error) {
+					a, b := "a", "b"
+					return &secretsmanager.ListSecretsOutput{
+						SecretList: []*secretsmanager.SecretListEntry{{Name: &a}, {Name: &b}},
+					}, nil
+				},
+				GetSecretValueFn: func(input *secretsmanager.GetSecretValueInput) (*secretsmanager.GetSecretValueOutput, error) {
+					secret := *input.SecretId + "-" + secretValue
+					return &secretsmanager.GetSecretValueOutput{
+						Name:         input.SecretId,
+						SecretString: &secret,
+					}, nil
+				},
+			},
+		}
+
+		output, e := s.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+		assert.Nil(t, e)
+		assert.Equal(t, map[string]map[string]string{"a": {"a": "a-secret"}, "b": {"b": "b-secret"}}, output.Data)
+	})
+
+	t.Run("unsuccessfully list secrets", func(t *testing.T) {
+		s := smSecretStore{
+			client: &mockedSM{
+				ListSecretsFn: func(input *secretsmanager.ListSecretsInput) (*secretsmanager.ListSecretsOutput, error) {
+					return nil, fmt.Errorf("failed due to any reason")
+				},
+			},
+		}
+
+		_, err := s.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+		assert.NotNil(t, err)
+	})
+}
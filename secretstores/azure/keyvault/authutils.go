@@ -92,6 +92,23 @@ func (c CertConfig) ServicePrincipalTokenByCertBytes() (*adal.ServicePrincipalTo
 	return adal.NewServicePrincipalTokenFromCertificate(*oauthConfig, c.ClientID, certificate, rsaPrivateKey, c.Resource)
 }
 
+// GetClientCredentials creates a config object from the available client secret credentials.
+// An error is returned if no client secret is available.
+func (s EnvironmentSettings) GetClientCredentials() (auth.ClientCredentialsConfig, error) {
+	clientSecret := s.Values[componentSPNClientSecret]
+	clientID := s.Values[componentSPNClientID]
+	tenantID := s.Values[componentSPNTenantID]
+
+	if clientSecret == "" {
+		return auth.ClientCredentialsConfig{}, fmt.Errorf("missing client secret")
+	}
+
+	config := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
+	config.Resource = azure.PublicCloud.ResourceIdentifiers.KeyVault
+
+	return config, nil
+}
+
 // MSIConfig provides the options to get a bearer authorizer through MSI.
 type MSIConfig struct {
 	Resource string
@@ -138,14 +155,20 @@ func (mc MSIConfig) Authorizer() (autorest.Authorizer, error) {
 
 // GetAuthorizer creates an Authorizer configured from environment variables in the order:
 // 1. Client certificate
-// 2. MSI
+// 2. Client secret
+// 3. MSI
 func (s EnvironmentSettings) GetAuthorizer() (autorest.Authorizer, error) {
 	// 1. Client Certificate
 	if c, e := s.GetClientCert(); e == nil {
 		return c.Authorizer()
 	}
 
-	// 2. MSI
+	// 2. Client Secret
+	if c, e := s.GetClientCredentials(); e == nil {
+		return c.Authorizer()
+	}
+
+	// 3. MSI
 	return s.GetMSI().Authorizer()
 }
 
@@ -185,3 +185,24 @@ func getTestCert() []byte {
 	certBytes, _ := base64.StdEncoding.DecodeString(testCert)
 	return certBytes
 }
+
+func TestGetClientCredentials(t *testing.T) {
+	settings := EnvironmentSettings{
+		Values: map[string]string{
+			componentSPNClientSecret: "secret",
+			componentSPNClientID:     fakeClientID,
+			componentSPNTenantID:     fakeTenantID,
+			componentVaultName:       "vaultName",
+		},
+	}
+
+	config, err := settings.GetClientCredentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", config.ClientSecret)
+	assert.Equal(t, fakeClientID, config.ClientID)
+	assert.Equal(t, fakeTenantID, config.TenantID)
+	assert.Equal(t, "https://vault.azure.net", config.Resource)
+
+	_, err = EnvironmentSettings{Values: map[string]string{}}.GetClientCredentials()
+	assert.Error(t, err)
+}
@@ -8,7 +8,9 @@ package keyvault
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/logger"
 
@@ -23,8 +25,13 @@ const (
 	componentSPNClientID            = "spnClientId"
 	componentSPNTenantID            = "spnTenantId"
 	componentVaultName              = "vaultName"
+
+	componentSPNClientSecret = "spnClientSecret"
 )
 
+// VersionID is the request metadata of the version of the secret to retrieve, the latest by default
+const VersionID = secretstores.VersionIDMetadataKey
+
 type keyvaultSecretStore struct {
 	vaultName   string
 	vaultClient kv.BaseClient
@@ -57,16 +64,30 @@ func (k *keyvaultSecretStore) Init(metadata secretstores.Metadata) error {
 	return err
 }
 
+// GetComponentMetadata returns the metadata fields of the Azure Key Vault secret store
+func (k *keyvaultSecretStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: componentVaultName, Type: metadata.TypeString, Required: true},
+			{Name: componentSPNTenantID, Type: metadata.TypeString},
+			{Name: componentSPNClientID, Type: metadata.TypeString},
+			{Name: componentSPNClientSecret, Type: metadata.TypeString},
+			{Name: componentSPNCertificate, Type: metadata.TypeString},
+			{Name: componentSPNCertificateFile, Type: metadata.TypeString},
+			{Name: componentSPNCertificatePassword, Type: metadata.TypeString},
+		},
+	}
+}
+
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values
 func (k *keyvaultSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
-	secretResp, err := k.vaultClient.GetSecret(context.Background(), k.getVaultURI(), req.Name, "")
-	if err != nil {
-		return secretstores.GetSecretResponse{}, err
+	if _, ok := req.Metadata[secretstores.VersionStageMetadataKey]; ok {
+		return secretstores.GetSecretResponse{}, fmt.Errorf("%s isn't supported by Azure Key Vault, use %s", secretstores.VersionStageMetadataKey, VersionID)
 	}
 
-	secretValue := ""
-	if secretResp.Value != nil {
-		secretValue = *secretResp.Value
+	secretValue, err := k.getSecretValue(context.Background(), req.Name, req.Metadata[VersionID])
+	if err != nil {
+		return secretstores.GetSecretResponse{}, err
 	}
 
 	return secretstores.GetSecretResponse{
@@ -76,6 +97,53 @@ func (k *keyvaultSecretStore) GetSecret(req secretstores.GetSecretRequest) (secr
 	}, nil
 }
 
+// BulkGetSecret retrieves the latest version of all the enabled secrets of the vault
+func (k *keyvaultSecretStore) BulkGetSecret(req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	ctx := context.Background()
+	data := map[string]map[string]string{}
+
+	secrets, err := k.vaultClient.GetSecretsComplete(ctx, k.getVaultURI(), nil)
+	if err != nil {
+		return secretstores.BulkGetSecretResponse{}, err
+	}
+
+	for ; secrets.NotDone(); err = secrets.NextWithContext(ctx) {
+		if err != nil {
+			return secretstores.BulkGetSecretResponse{}, err
+		}
+
+		item := secrets.Value()
+		if item.ID == nil || (item.Attributes != nil && item.Attributes.Enabled != nil && !*item.Attributes.Enabled) {
+			continue
+		}
+
+		name := (*item.ID)[strings.LastIndex(*item.ID, "/")+1:]
+		secretValue, err := k.getSecretValue(ctx, name, "")
+		if err != nil {
+			return secretstores.BulkGetSecretResponse{}, err
+		}
+		data[name] = map[string]string{name: secretValue}
+	}
+	if err != nil {
+		return secretstores.BulkGetSecretResponse{}, err
+	}
+
+	return secretstores.BulkGetSecretResponse{Data: data}, nil
+}
+
+// getSecretValue returns the value of the version of the secret, the latest when version is empty
+func (k *keyvaultSecretStore) getSecretValue(ctx context.Context, name string, version string) (string, error) {
+	secretResp, err := k.vaultClient.GetSecret(ctx, k.getVaultURI(), name, version)
+	if err != nil {
+		return "", err
+	}
+
+	if secretResp.Value == nil {
+		return "", nil
+	}
+	return *secretResp.Value, nil
+}
+
 // getVaultURI returns Azure Key Vault URI
 func (k *keyvaultSecretStore) getVaultURI() string {
 	return fmt.Sprintf("https://%s.vault.azure.net", k.vaultName)
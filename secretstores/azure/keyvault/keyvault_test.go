@@ -0,0 +1,93 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package keyvault
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault answers the Key Vault requests with the bodies of the request paths
+type fakeVault struct {
+	responses map[string]string
+	paths     []string
+}
+
+func (f *fakeVault) Do(req *http.Request) (*http.Response, error) {
+	path := req.URL.Path
+	if version := req.URL.Query().Get("$skiptoken"); version != "" {
+		path += "?" + version
+	}
+	f.paths = append(f.paths, path)
+
+	status := http.StatusOK
+	body, ok := f.responses[path]
+	if !ok {
+		status = http.StatusNotFound
+		body = `{"error": {"code": "SecretNotFound", "message": "not found"}}`
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}, nil
+}
+
+func newFakeStore(vault *fakeVault) *keyvaultSecretStore {
+	k := NewAzureKeyvaultSecretStore(logger.NewLogger("test")).(*keyvaultSecretStore)
+	k.vaultName = "test"
+	k.vaultClient.Sender = vault
+	return k
+}
+
+func TestGetSecret(t *testing.T) {
+	vault := &fakeVault{responses: map[string]string{
+		"/secrets/db/":   `{"value": "latest"}`,
+		"/secrets/db/v1": `{"value": "first"}`,
+	}}
+	k := newFakeStore(vault)
+
+	resp, err := k.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"db": "latest"}, resp.Data)
+
+	resp, err = k.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{VersionID: "v1"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"db": "first"}, resp.Data)
+
+	_, err = k.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{secretstores.VersionStageMetadataKey: "previous"}})
+	assert.Error(t, err)
+
+	_, err = k.GetSecret(secretstores.GetSecretRequest{Name: "missing"})
+	assert.Error(t, err)
+}
+
+func TestBulkGetSecret(t *testing.T) {
+	vault := &fakeVault{responses: map[string]string{
+		"/secrets": `{"value": [
+			{"id": "https://test.vault.azure.net/secrets/a"},
+			{"id": "https://test.vault.azure.net/secrets/disabled", "attributes": {"enabled": false}}
+		], "nextLink": "https://test.vault.azure.net/secrets?api-version=2016-10-01&$skiptoken=next"}`,
+		"/secrets?next": `{"value": [{"id": "https://test.vault.azure.net/secrets/b", "attributes": {"enabled": true}}]}`,
+		"/secrets/a/":   `{"value": "1"}`,
+		"/secrets/b/":   `{"value": "2"}`,
+	}}
+	k := newFakeStore(vault)
+
+	resp, err := k.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"a": {"a": "1"}, "b": {"b": "2"}}, resp.Data)
+	assert.NotContains(t, vault.paths, "/secrets/disabled/")
+}
@@ -0,0 +1,23 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import "fmt"
+
+// BulkGetSecretFromKeys retrieves the secrets of the keys one by one with the metadata of the request, for the stores
+// that can't list their secrets
+func BulkGetSecretFromKeys(store SecretStore, req BulkGetSecretRequest, keys []string) (BulkGetSecretResponse, error) {
+	data := make(map[string]map[string]string, len(keys))
+	for _, key := range keys {
+		resp, err := store.GetSecret(GetSecretRequest{Name: key, Metadata: req.Metadata})
+		if err != nil {
+			return BulkGetSecretResponse{}, fmt.Errorf("couldn't get secret %s: %s", key, err)
+		}
+		data[key] = resp.Data
+	}
+
+	return BulkGetSecretResponse{Data: data}, nil
+}
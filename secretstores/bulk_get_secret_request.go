@@ -0,0 +1,11 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+// BulkGetSecretRequest describes a request for all the secrets of a secret store.
+type BulkGetSecretRequest struct {
+	Metadata map[string]string `json:"metadata"`
+}
@@ -0,0 +1,12 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+// BulkGetSecretResponse describes the response object for all the secrets returned from a secret store, keyed by
+// secret name
+type BulkGetSecretResponse struct {
+	Data map[string]map[string]string `json:"data"`
+}
@@ -0,0 +1,49 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSecretStore struct {
+	secrets map[string]string
+}
+
+func (f *fakeSecretStore) Init(metadata Metadata) error {
+	return nil
+}
+
+func (f *fakeSecretStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+func (f *fakeSecretStore) GetSecret(req GetSecretRequest) (GetSecretResponse, error) {
+	value, ok := f.secrets[req.Name]
+	if !ok {
+		return GetSecretResponse{}, fmt.Errorf("not found")
+	}
+	return GetSecretResponse{Data: map[string]string{req.Name: value + req.Metadata["suffix"]}}, nil
+}
+
+func (f *fakeSecretStore) BulkGetSecret(req BulkGetSecretRequest) (BulkGetSecretResponse, error) {
+	return BulkGetSecretFromKeys(f, req, []string{"a", "b"})
+}
+
+func TestBulkGetSecretFromKeys(t *testing.T) {
+	store := &fakeSecretStore{secrets: map[string]string{"a": "1", "b": "2"}}
+
+	resp, err := store.BulkGetSecret(BulkGetSecretRequest{Metadata: map[string]string{"suffix": "!"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"a": {"a": "1!"}, "b": {"b": "2!"}}, resp.Data)
+
+	_, err = BulkGetSecretFromKeys(store, BulkGetSecretRequest{}, []string{"a", "c"})
+	assert.EqualError(t, err, "couldn't get secret c: not found")
+}
@@ -0,0 +1,190 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+)
+
+const (
+	// CacheEnabled is the metadata property that enables caching the secrets of a store
+	CacheEnabled = "cacheEnabled"
+	// CacheTTL is the metadata property of how long a secret is cached, e.g. "5m"
+	CacheTTL = "cacheTTL"
+	// MaxCacheEntries is the metadata property of how many secrets are cached at most, 0 for no limit
+	MaxCacheEntries = "maxCacheEntries"
+
+	defaultCacheTTL        = 5 * time.Minute
+	defaultMaxCacheEntries = 1000
+)
+
+// CachedStore caches the secrets retrieved from a secret store, so that hot secrets are not fetched from the remote
+// store on every request. Secrets are retrieved again once their TTL expires. When the cache is full, the expired
+// secrets are evicted first, then the secrets closest to expiring.
+type CachedStore struct {
+	store      SecretStore
+	enabled    bool
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cacheEntry
+	lock       sync.Mutex
+	now        func() time.Time
+}
+
+type cacheEntry struct {
+	response GetSecretResponse
+	expires  time.Time
+}
+
+// NewCachedStore wraps store so that its secrets are cached when the cacheEnabled metadata is true
+func NewCachedStore(store SecretStore) SecretStore {
+	return &CachedStore{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Init reads the cache settings from metadata and initializes the wrapped store
+func (s *CachedStore) Init(metadata Metadata) error {
+	s.enabled = false
+	if val := metadata.Properties[CacheEnabled]; val != "" {
+		enabled, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("invalid %s %s: %s", CacheEnabled, val, err)
+		}
+		s.enabled = enabled
+	}
+
+	s.ttl = defaultCacheTTL
+	if val := metadata.Properties[CacheTTL]; val != "" {
+		ttl, err := time.ParseDuration(val)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid %s %s, must be a positive duration", CacheTTL, val)
+		}
+		s.ttl = ttl
+	}
+
+	s.maxEntries = defaultMaxCacheEntries
+	if val := metadata.Properties[MaxCacheEntries]; val != "" {
+		maxEntries, err := strconv.Atoi(val)
+		if err != nil || maxEntries < 0 {
+			return fmt.Errorf("invalid %s %s, must be 0 or more", MaxCacheEntries, val)
+		}
+		s.maxEntries = maxEntries
+	}
+
+	s.lock.Lock()
+	s.entries = map[string]cacheEntry{}
+	s.lock.Unlock()
+
+	return s.store.Init(metadata)
+}
+
+// GetComponentMetadata returns the metadata of the wrapped store with the cache settings
+func (s *CachedStore) GetComponentMetadata() metadata.ComponentMetadata {
+	m := s.store.GetComponentMetadata()
+	m.Fields = append(m.Fields,
+		metadata.Field{Name: CacheEnabled, Type: metadata.TypeBool},
+		metadata.Field{Name: CacheTTL, Type: metadata.TypeDuration, Default: defaultCacheTTL.String()},
+		metadata.Field{Name: MaxCacheEntries, Type: metadata.TypeInt, Default: strconv.Itoa(defaultMaxCacheEntries)})
+	return m
+}
+
+// Ping pings the wrapped store
+func (s *CachedStore) Ping() error {
+	return health.Ping(s.store)
+}
+
+// GetSecret returns the cached secret of the request, or retrieves it from the wrapped store and caches it
+func (s *CachedStore) GetSecret(req GetSecretRequest) (GetSecretResponse, error) {
+	if !s.enabled {
+		return s.store.GetSecret(req)
+	}
+
+	key := cacheKey(req)
+	now := s.now()
+
+	s.lock.Lock()
+	entry, ok := s.entries[key]
+	if ok && now.After(entry.expires) {
+		delete(s.entries, key)
+		ok = false
+	}
+	s.lock.Unlock()
+	if ok {
+		return copyResponse(entry.response), nil
+	}
+
+	resp, err := s.store.GetSecret(req)
+	if err != nil {
+		return resp, err
+	}
+
+	s.lock.Lock()
+	s.evict(now)
+	s.entries[key] = cacheEntry{response: copyResponse(resp), expires: now.Add(s.ttl)}
+	s.lock.Unlock()
+
+	return resp, nil
+}
+
+// BulkGetSecret retrieves all the secrets from the wrapped store, without caching them
+func (s *CachedStore) BulkGetSecret(req BulkGetSecretRequest) (BulkGetSecretResponse, error) {
+	return s.store.BulkGetSecret(req)
+}
+
+// evict removes the expired entries, then the entries closest to expiring until there is room for a new entry.
+// It must be called with the lock held.
+func (s *CachedStore) evict(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.expires) {
+			delete(s.entries, key)
+		}
+	}
+
+	if s.maxEntries == 0 || len(s.entries) < s.maxEntries {
+		return
+	}
+
+	keys := make([]string, 0, len(s.entries))
+	for key := range s.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return s.entries[keys[i]].expires.Before(s.entries[keys[j]].expires)
+	})
+	for _, key := range keys[:len(s.entries)-s.maxEntries+1] {
+		delete(s.entries, key)
+	}
+}
+
+// cacheKey identifies the secret of a request by its name and metadata, e.g. its version
+func cacheKey(req GetSecretRequest) string {
+	metadata := make([]string, 0, len(req.Metadata))
+	for k, v := range req.Metadata {
+		metadata = append(metadata, strconv.Quote(k)+"="+strconv.Quote(v))
+	}
+	sort.Strings(metadata)
+
+	return strconv.Quote(req.Name) + "|" + strings.Join(metadata, "|")
+}
+
+// copyResponse returns a copy of the response, so that callers modifying their data don't modify the cache
+func copyResponse(resp GetSecretResponse) GetSecretResponse {
+	data := make(map[string]string, len(resp.Data))
+	for k, v := range resp.Data {
+		data[k] = v
+	}
+	return GetSecretResponse{Data: data}
+}
@@ -0,0 +1,118 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingSecretStore struct {
+	fakeSecretStore
+	calls map[string]int
+}
+
+func (c *countingSecretStore) GetSecret(req GetSecretRequest) (GetSecretResponse, error) {
+	c.calls[req.Name]++
+	return c.fakeSecretStore.GetSecret(req)
+}
+
+func newCountingSecretStore() *countingSecretStore {
+	return &countingSecretStore{
+		fakeSecretStore: fakeSecretStore{secrets: map[string]string{"a": "1", "b": "2", "c": "3"}},
+		calls:           map[string]int{},
+	}
+}
+
+func TestCachedStoreInit(t *testing.T) {
+	s := NewCachedStore(newCountingSecretStore()).(*CachedStore)
+
+	require.NoError(t, s.Init(Metadata{Properties: map[string]string{}}))
+	assert.False(t, s.enabled)
+	assert.Equal(t, defaultCacheTTL, s.ttl)
+	assert.Equal(t, defaultMaxCacheEntries, s.maxEntries)
+
+	require.NoError(t, s.Init(Metadata{Properties: map[string]string{"cacheEnabled": "true", "cacheTTL": "1m", "maxCacheEntries": "2"}}))
+	assert.True(t, s.enabled)
+	assert.Equal(t, time.Minute, s.ttl)
+	assert.Equal(t, 2, s.maxEntries)
+
+	for _, properties := range []map[string]string{
+		{"cacheEnabled": "yes please"},
+		{"cacheTTL": "0s"},
+		{"maxCacheEntries": "-1"},
+	} {
+		assert.Error(t, s.Init(Metadata{Properties: properties}))
+	}
+}
+
+func TestCachedStoreGetSecret(t *testing.T) {
+	now := time.Now()
+	inner := newCountingSecretStore()
+	s := NewCachedStore(inner).(*CachedStore)
+	s.now = func() time.Time { return now }
+	require.NoError(t, s.Init(Metadata{Properties: map[string]string{"cacheEnabled": "true", "cacheTTL": "1m", "maxCacheEntries": "2"}}))
+
+	t.Run("Caches secrets", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			resp, err := s.GetSecret(GetSecretRequest{Name: "a"})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"a": "1"}, resp.Data)
+			resp.Data["a"] = "modified"
+		}
+		assert.Equal(t, 1, inner.calls["a"])
+	})
+
+	t.Run("Caches the secrets of different metadata separately", func(t *testing.T) {
+		now = now.Add(time.Millisecond)
+		resp, err := s.GetSecret(GetSecretRequest{Name: "a", Metadata: map[string]string{"suffix": "!"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "1!"}, resp.Data)
+		assert.Equal(t, 2, inner.calls["a"])
+	})
+
+	t.Run("Evicts the secrets closest to expiring when full", func(t *testing.T) {
+		now = now.Add(time.Second)
+		_, err := s.GetSecret(GetSecretRequest{Name: "b"})
+		require.NoError(t, err)
+		assert.Len(t, s.entries, 2)
+
+		_, err = s.GetSecret(GetSecretRequest{Name: "a"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.calls["a"])
+	})
+
+	t.Run("Retrieves secrets again once expired", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		_, err := s.GetSecret(GetSecretRequest{Name: "b"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, inner.calls["b"])
+		assert.Len(t, s.entries, 1)
+	})
+
+	t.Run("Doesn't cache errors", func(t *testing.T) {
+		_, err := s.GetSecret(GetSecretRequest{Name: "missing"})
+		assert.Error(t, err)
+		_, err = s.GetSecret(GetSecretRequest{Name: "missing"})
+		assert.Error(t, err)
+		assert.Equal(t, 2, inner.calls["missing"])
+	})
+}
+
+func TestCachedStoreDisabled(t *testing.T) {
+	inner := newCountingSecretStore()
+	s := NewCachedStore(inner)
+	require.NoError(t, s.Init(Metadata{Properties: map[string]string{}}))
+
+	for i := 0; i < 2; i++ {
+		_, err := s.GetSecret(GetSecretRequest{Name: "a"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, inner.calls["a"])
+}
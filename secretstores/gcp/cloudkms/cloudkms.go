@@ -13,6 +13,7 @@ import (
 
 	cloudkms "cloud.google.com/go/kms/apiv1"
 	"cloud.google.com/go/storage"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/logger"
 	"google.golang.org/api/option"
@@ -82,6 +83,11 @@ func (c *cloudkmsSecretStore) Init(metadata secretstores.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the GCP Cloud KMS secret store
+func (c *cloudkmsSecretStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(cloudkmsMetadata{})}
+}
+
 // GetSecret retrieves a secret using a key and returns a map of decrypted string
 func (c *cloudkmsSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
 	gcpStorageBucket := c.metadata.GCPStorageBucket
@@ -109,6 +115,11 @@ func (c *cloudkmsSecretStore) GetSecret(req secretstores.GetSecretRequest) (secr
 	}, nil
 }
 
+// BulkGetSecret retrieves the secret of the secret object of the metadata, the only secret of the store
+func (c *cloudkmsSecretStore) BulkGetSecret(req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	return secretstores.BulkGetSecretFromKeys(c, req, []string{c.metadata.SecretObject})
+}
+
 func (c *cloudkmsSecretStore) getCipherTextFromSecretObject(gcpStorageBucket string, secretObject string) ([]byte, error) {
 	ctx := context.Background()
 	var client = c.storageclient
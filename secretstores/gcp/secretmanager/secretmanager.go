@@ -4,19 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1beta1"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/logger"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1beta1"
 )
 
-const VersionID = "version_id"
+const VersionID = secretstores.VersionIDMetadataKey
 
 type gcpCredentials struct {
 	Type                string `json:"type"`
-	ProjectID           string `json:"project_id"`
+	ProjectID           string `json:"project_id" metadata:",required"`
 	PrivateKey          string `json:"private_key"`
 	ClientEmail         string `json:"client_email"`
 	PrivateKeyID        string `json:"private_key_id"`
@@ -62,12 +65,22 @@ func (s *Store) Init(metadataRaw secretstores.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the GCP Secret Manager secret store
+func (s *Store) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Fields: metadata.FieldsOf(gcpCredentials{})}
+}
+
+// getClient uses the service account of the metadata, or the application default credentials, e.g. GKE workload
+// identity, when the metadata has no service account key
 func (s *Store) getClient(metadata *secretManagerMetadata) (*secretmanager.Client, error) {
-	b, _ := json.Marshal(metadata)
-	clientOptions := option.WithCredentialsJSON(b)
+	var clientOptions []option.ClientOption
+	if metadata.PrivateKey != "" {
+		b, _ := json.Marshal(metadata)
+		clientOptions = append(clientOptions, option.WithCredentialsJSON(b))
+	}
 	ctx := context.Background()
 
-	client, err := secretmanager.NewClient(ctx, clientOptions)
+	client, err := secretmanager.NewClient(ctx, clientOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -92,15 +105,55 @@ func (s *Store) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSe
 		versionID = value
 	}
 
+	data, err := s.accessSecret(context.Background(), req.Name, versionID)
+	if err != nil {
+		return res, err
+	}
+	return secretstores.GetSecretResponse{Data: map[string]string{req.Name: data}}, nil
+}
+
+// BulkGetSecret retrieves the latest version of all the secrets of the project
+func (s *Store) BulkGetSecret(req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	res := secretstores.BulkGetSecretResponse{Data: nil}
+
+	if s.client == nil {
+		return res, fmt.Errorf("client is not initialized")
+	}
+
 	ctx := context.Background()
+	data := map[string]map[string]string{}
+	it := s.client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", s.ProjectID),
+	})
+	for {
+		secret, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return res, fmt.Errorf("failed to list secrets: %v", err)
+		}
+
+		name := secret.Name[strings.LastIndex(secret.Name, "/")+1:]
+		value, err := s.accessSecret(ctx, name, "latest")
+		if err != nil {
+			return res, err
+		}
+		data[name] = map[string]string{name: value}
+	}
+
+	return secretstores.BulkGetSecretResponse{Data: data}, nil
+}
+
+func (s *Store) accessSecret(ctx context.Context, name string, versionID string) (string, error) {
 	accessRequest := &secretmanagerpb.AccessSecretVersionRequest{
-		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", s.ProjectID, req.Name, versionID),
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", s.ProjectID, name, versionID),
 	}
 	result, err := s.client.AccessSecretVersion(ctx, accessRequest)
 	if err != nil {
-		return res, fmt.Errorf("failed to access secret version: %v", err)
+		return "", fmt.Errorf("failed to access secret version: %v", err)
 	}
-	return secretstores.GetSecretResponse{Data: map[string]string{req.Name: string(result.Payload.Data)}}, nil
+	return string(result.Payload.Data), nil
 }
 
 func (s *Store) parseSecretManagerMetadata(metadataRaw secretstores.Metadata) (*secretManagerMetadata, error) {
@@ -115,6 +168,14 @@ func (s *Store) parseSecretManagerMetadata(metadataRaw secretstores.Metadata) (*
 		return nil, err
 	}
 
+	// Without a service account key, the application default credentials are used
+	if meta.Type == "" && meta.PrivateKey == "" && meta.ClientEmail == "" {
+		if meta.ProjectID == "" {
+			return nil, fmt.Errorf("missing property `project_id` in metadata")
+		}
+		return &meta, nil
+	}
+
 	if meta.Type == "" {
 		return nil, fmt.Errorf("missing property `type` in metadata")
 	}
@@ -1,14 +1,45 @@
 package secretmanager
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"testing"
 
+	secretmanager "cloud.google.com/go/secretmanager/apiv1beta1"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	secretmanagerpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1beta1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+type fakeSecretManagerServer struct {
+	secretmanagerpb.UnimplementedSecretManagerServiceServer
+	secrets map[string]string
+}
+
+func (f *fakeSecretManagerServer) ListSecrets(ctx context.Context, req *secretmanagerpb.ListSecretsRequest) (*secretmanagerpb.ListSecretsResponse, error) {
+	resp := &secretmanagerpb.ListSecretsResponse{}
+	for name := range f.secrets {
+		resp.Secrets = append(resp.Secrets, &secretmanagerpb.Secret{Name: fmt.Sprintf("%s/secrets/%s", req.Parent, name)})
+	}
+	return resp, nil
+}
+
+func (f *fakeSecretManagerServer) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	for name, value := range f.secrets {
+		if req.Name == fmt.Sprintf("projects/p/secrets/%s/versions/latest", name) {
+			return &secretmanagerpb.AccessSecretVersionResponse{Payload: &secretmanagerpb.SecretPayload{Data: []byte(value)}}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "not found")
+}
+
 func TestInit(t *testing.T) {
 	m := secretstores.Metadata{}
 	sm := NewSecreteManager(logger.NewLogger("test"))
@@ -31,9 +62,18 @@ func TestInit(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("Init with application default credentials", func(t *testing.T) {
+		m.Properties = map[string]string{
+			"project_id": "a",
+		}
+		meta, err := sm.parseSecretManagerMetadata(m)
+		assert.Nil(t, err)
+		assert.Equal(t, "a", meta.ProjectID)
+	})
+
 	t.Run("Init with missing `type` metadata", func(t *testing.T) {
 		m.Properties = map[string]string{
-			"dummy": "a",
+			"private_key": "a",
 		}
 		err := sm.Init(m)
 		assert.NotNil(t, err)
@@ -81,3 +121,33 @@ func TestGetSecret(t *testing.T) {
 		assert.Equal(t, secretstores.GetSecretResponse{Data: nil}, v)
 	})
 }
+
+func TestBulkGetSecret(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	secretmanagerpb.RegisterSecretManagerServiceServer(server, &fakeSecretManagerServer{secrets: map[string]string{"a": "1", "b": "2"}})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	client, err := secretmanager.NewClient(context.Background(),
+		option.WithEndpoint(lis.Addr().String()),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithInsecure()))
+	require.NoError(t, err)
+	defer client.Close()
+
+	sm := NewSecreteManager(logger.NewLogger("test"))
+	_, err = sm.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+	assert.Equal(t, fmt.Errorf("client is not initialized"), err)
+
+	sm.client = client
+	sm.ProjectID = "p"
+	resp, err := sm.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"a": {"a": "1"}, "b": {"b": "2"}}, resp.Data)
+
+	sm.ProjectID = "other"
+	_, err = sm.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+	assert.Error(t, err)
+}
@@ -5,6 +5,15 @@
 
 package secretstores
 
+const (
+	// VersionIDMetadataKey is the GetSecretRequest metadata of the version of the secret to retrieve, the latest
+	// by default
+	VersionIDMetadataKey = "version_id"
+	// VersionStageMetadataKey is the GetSecretRequest metadata of the stage label of the version to retrieve, e.g.
+	// AWSPREVIOUS, for the stores that label their versions
+	VersionStageMetadataKey = "version_stage"
+)
+
 // GetSecretRequest describes a get secret request from a secret store.
 type GetSecretRequest struct {
 	Name     string            `json:"name"`
@@ -15,11 +15,16 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/net/http2"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/logger"
 )
@@ -37,6 +42,25 @@ const (
 	defaultVaultKVPrefix         string = "dapr"
 	vaultHTTPHeader              string = "X-Vault-Token"
 	vaultHTTPRequestHeader       string = "X-Vault-Request"
+
+	componentVaultToken             string = "vaultToken"
+	componentEnginePath             string = "enginePath"
+	componentEngineVersion          string = "vaultEngineVersion"
+	componentValueType              string = "vaultValueType"
+	componentAuthMethod             string = "vaultAuthMethod"
+	componentKubernetesRole         string = "vaultKubernetesRole"
+	componentKubernetesMountPath    string = "vaultKubernetesMountPath"
+	componentKubernetesTokenPath    string = "vaultKubernetesTokenPath"
+	defaultEnginePath               string = "secret"
+	defaultKubernetesMountPath      string = "kubernetes"
+	defaultKubernetesTokenPath      string = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	engineV1                        string = "v1"
+	engineV2                        string = "v2"
+	valueTypeMap                    string = "map"
+	valueTypeText                   string = "text"
+	authMethodToken                 string = "token"
+	authMethodKubernetes            string = "kubernetes"
+	kubernetesTokenExpirationLeeway        = 30 * time.Second
 )
 
 // vaultSecretStore is a secret store implementation for HashiCorp Vault
@@ -46,6 +70,18 @@ type vaultSecretStore struct {
 	vaultTokenMountPath string
 	vaultKVPrefix       string
 
+	vaultToken           string
+	enginePath           string
+	engineVersion        string
+	valueType            string
+	authMethod           string
+	kubernetesRole       string
+	kubernetesMountPath  string
+	kubernetesTokenPath  string
+	kubernetesToken      string
+	kubernetesExpiration time.Time
+	lock                 sync.Mutex
+
 	logger logger.Logger
 }
 
@@ -58,13 +94,33 @@ type tlsConfig struct {
 	vaultServerName string
 }
 
-// vaultKVResponse is the response data from Vault KV.
+// vaultKVResponse is the response data from Vault KV v2.
 type vaultKVResponse struct {
 	Data struct {
-		Data map[string]string `json:"data"`
+		Data map[string]interface{} `json:"data"`
 	} `json:"data"`
 }
 
+// vaultKVv1Response is the response data from Vault KV v1.
+type vaultKVv1Response struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// vaultListResponse is the response data from a Vault KV list.
+type vaultListResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+// vaultLoginResponse is the response data from a Vault auth method login.
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+}
+
 // NewHashiCorpVaultSecretStore returns a new HashiCorp Vault secret store
 func NewHashiCorpVaultSecretStore(logger logger.Logger) secretstores.SecretStore {
 	return &vaultSecretStore{
@@ -80,10 +136,10 @@ func (v *vaultSecretStore) Init(metadata secretstores.Metadata) error {
 	// Get Vault address
 	address := props[componentVaultAddress]
 	if address == "" {
-		v.vaultAddress = defaultVaultAddress
+		address = defaultVaultAddress
 	}
 
-	v.vaultAddress = address
+	v.vaultAddress = strings.TrimSuffix(address, "/")
 
 	// Generate TLS config
 	tlsConf := metadataToTLSConfig(props)
@@ -95,13 +151,10 @@ func (v *vaultSecretStore) Init(metadata secretstores.Metadata) error {
 
 	v.client = client
 
-	tokenMountPath := props[componentVaultTokenMountPath]
-	if tokenMountPath == "" {
-		return fmt.Errorf("token mount path not set")
+	if err := v.initAuth(props); err != nil {
+		return err
 	}
 
-	v.vaultTokenMountPath = tokenMountPath
-
 	vaultKVPrefix := props[componentVaultKVPrefix]
 	if vaultKVPrefix == "" {
 		vaultKVPrefix = defaultVaultKVPrefix
@@ -109,6 +162,84 @@ func (v *vaultSecretStore) Init(metadata secretstores.Metadata) error {
 
 	v.vaultKVPrefix = vaultKVPrefix
 
+	v.enginePath = strings.Trim(props[componentEnginePath], "/")
+	if v.enginePath == "" {
+		v.enginePath = defaultEnginePath
+	}
+
+	v.engineVersion = props[componentEngineVersion]
+	switch v.engineVersion {
+	case "":
+		v.engineVersion = engineV2
+	case engineV1, engineV2:
+	default:
+		return fmt.Errorf("invalid %s %s, accepted values are %s and %s", componentEngineVersion, v.engineVersion, engineV1, engineV2)
+	}
+
+	v.valueType = props[componentValueType]
+	switch v.valueType {
+	case "":
+		v.valueType = valueTypeMap
+	case valueTypeMap, valueTypeText:
+	default:
+		return fmt.Errorf("invalid %s %s, accepted values are %s and %s", componentValueType, v.valueType, valueTypeMap, valueTypeText)
+	}
+
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the HashiCorp Vault secret store
+func (v *vaultSecretStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: componentVaultAddress, Type: metadata.TypeString, Default: defaultVaultAddress},
+			{Name: componentCaCert, Type: metadata.TypeString},
+			{Name: componentCaPath, Type: metadata.TypeString},
+			{Name: componentCaPem, Type: metadata.TypeString},
+			{Name: componentSkipVerify, Type: metadata.TypeBool},
+			{Name: componentTLSServerName, Type: metadata.TypeString},
+			{Name: componentAuthMethod, Type: metadata.TypeString, Default: authMethodToken},
+			{Name: componentVaultToken, Type: metadata.TypeString},
+			{Name: componentVaultTokenMountPath, Type: metadata.TypeString},
+			{Name: componentKubernetesRole, Type: metadata.TypeString},
+			{Name: componentKubernetesMountPath, Type: metadata.TypeString, Default: defaultKubernetesMountPath},
+			{Name: componentKubernetesTokenPath, Type: metadata.TypeString, Default: defaultKubernetesTokenPath},
+			{Name: componentVaultKVPrefix, Type: metadata.TypeString, Default: defaultVaultKVPrefix},
+			{Name: componentEnginePath, Type: metadata.TypeString, Default: defaultEnginePath},
+			{Name: componentEngineVersion, Type: metadata.TypeString, Default: engineV2},
+			{Name: componentValueType, Type: metadata.TypeString, Default: valueTypeMap},
+		},
+	}
+}
+
+// initAuth configures how the store authenticates with Vault, with a token or with the Kubernetes auth method
+func (v *vaultSecretStore) initAuth(props map[string]string) error {
+	v.authMethod = props[componentAuthMethod]
+	switch v.authMethod {
+	case "", authMethodToken:
+		v.authMethod = authMethodToken
+		v.vaultToken = props[componentVaultToken]
+		v.vaultTokenMountPath = props[componentVaultTokenMountPath]
+		if v.vaultToken == "" && v.vaultTokenMountPath == "" {
+			return fmt.Errorf("token mount path not set")
+		}
+	case authMethodKubernetes:
+		v.kubernetesRole = props[componentKubernetesRole]
+		if v.kubernetesRole == "" {
+			return fmt.Errorf("%s not set", componentKubernetesRole)
+		}
+		v.kubernetesMountPath = strings.Trim(props[componentKubernetesMountPath], "/")
+		if v.kubernetesMountPath == "" {
+			v.kubernetesMountPath = defaultKubernetesMountPath
+		}
+		v.kubernetesTokenPath = props[componentKubernetesTokenPath]
+		if v.kubernetesTokenPath == "" {
+			v.kubernetesTokenPath = defaultKubernetesTokenPath
+		}
+	default:
+		return fmt.Errorf("invalid %s %s, accepted values are %s and %s", componentAuthMethod, v.authMethod, authMethodToken, authMethodKubernetes)
+	}
+
 	return nil
 }
 
@@ -132,23 +263,37 @@ func metadataToTLSConfig(props map[string]string) *tlsConfig {
 
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values
 func (v *vaultSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
-	token, err := v.readVaultToken()
+	token, err := v.getToken()
 	if err != nil {
 		return secretstores.GetSecretResponse{Data: nil}, err
 	}
 
-	// Create get secret url
-	// TODO: Add support for versioned secrets when the secretstore request has support for it
-	vaultSecretPathAddr := fmt.Sprintf("%s/v1/secret/data/%s/%s?version=0", v.vaultAddress, v.vaultKVPrefix, req.Name)
+	if _, ok := req.Metadata[secretstores.VersionStageMetadataKey]; ok {
+		return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("%s isn't supported by Vault, use %s", secretstores.VersionStageMetadataKey, secretstores.VersionIDMetadataKey)
+	}
+
+	// Create get secret url, version 0 being the latest version of KV v2
+	version := "0"
+	if value, ok := req.Metadata[secretstores.VersionIDMetadataKey]; ok && value != "" {
+		if v.engineVersion == engineV1 {
+			return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("%s isn't supported by the KV v1 engine", secretstores.VersionIDMetadataKey)
+		}
+		version = value
+	}
+
+	vaultSecretPathAddr := fmt.Sprintf("%s/v1/%s/data/%s/%s?version=%s", v.vaultAddress, v.enginePath, v.vaultKVPrefix, req.Name, url.QueryEscape(version))
+	if v.engineVersion == engineV1 {
+		vaultSecretPathAddr = fmt.Sprintf("%s/v1/%s/%s/%s", v.vaultAddress, v.enginePath, v.vaultKVPrefix, req.Name)
+	}
 
 	httpReq, err := http.NewRequest(http.MethodGet, vaultSecretPathAddr, nil)
+	if err != nil {
+		return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't generate request: %s", err)
+	}
 	// Set vault token.
 	httpReq.Header.Set(vaultHTTPHeader, token)
 	// Set X-Vault-Request header
 	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
-	if err != nil {
-		return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't generate request: %s", err)
-	}
 
 	httpresp, err := v.client.Do(httpReq)
 	if err != nil {
@@ -164,10 +309,19 @@ func (v *vaultSecretStore) GetSecret(req secretstores.GetSecretRequest) (secrets
 			httpresp, b.String())
 	}
 
-	var d vaultKVResponse
-
-	if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
-		return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't decode response body: %s", err)
+	var data map[string]interface{}
+	if v.engineVersion == engineV1 {
+		var d vaultKVv1Response
+		if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
+			return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't decode response body: %s", err)
+		}
+		data = d.Data
+	} else {
+		var d vaultKVResponse
+		if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
+			return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't decode response body: %s", err)
+		}
+		data = d.Data.Data
 	}
 
 	resp := secretstores.GetSecretResponse{
@@ -176,13 +330,173 @@ func (v *vaultSecretStore) GetSecret(req secretstores.GetSecretRequest) (secrets
 
 	// Only using secret data and ignore metadata
 	// TODO: add support for metadata response when secretstores support it.
-	for k, v := range d.Data.Data {
-		resp.Data[k] = v
+	for k, val := range data {
+		s, err := secretValue(val)
+		if err != nil {
+			return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't read value of %s: %s", k, err)
+		}
+		resp.Data[k] = s
+	}
+
+	// A text secret holds a single value, which is returned under the name of the secret
+	if v.valueType == valueTypeText {
+		if len(resp.Data) != 1 {
+			return secretstores.GetSecretResponse{Data: nil}, fmt.Errorf("couldn't read text secret %s: it has %d values instead of 1", req.Name, len(resp.Data))
+		}
+		for _, s := range resp.Data {
+			resp.Data = map[string]string{req.Name: s}
+		}
 	}
 
 	return resp, nil
 }
 
+// BulkGetSecret retrieves all the secrets under the KV prefix, including the secrets of nested folders, e.g. "a/b"
+func (v *vaultSecretStore) BulkGetSecret(req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	token, err := v.getToken()
+	if err != nil {
+		return secretstores.BulkGetSecretResponse{Data: nil}, err
+	}
+
+	keys, err := v.listSecrets(token, "")
+	if err != nil {
+		return secretstores.BulkGetSecretResponse{Data: nil}, err
+	}
+
+	return secretstores.BulkGetSecretFromKeys(v, req, keys)
+}
+
+// listSecrets returns the names of the secrets of the folder under the KV prefix, and of its nested folders
+func (v *vaultSecretStore) listSecrets(token string, folder string) ([]string, error) {
+	vaultListPathAddr := fmt.Sprintf("%s/v1/%s/metadata/%s/%s?list=true", v.vaultAddress, v.enginePath, v.vaultKVPrefix, folder)
+	if v.engineVersion == engineV1 {
+		vaultListPathAddr = fmt.Sprintf("%s/v1/%s/%s/%s?list=true", v.vaultAddress, v.enginePath, v.vaultKVPrefix, folder)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodGet, vaultListPathAddr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate request: %s", err)
+	}
+	httpReq.Header.Set(vaultHTTPHeader, token)
+	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
+
+	httpresp, err := v.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list secrets: %s", err)
+	}
+
+	defer httpresp.Body.Close()
+
+	// Vault answers not found for an empty folder
+	if httpresp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if httpresp.StatusCode != 200 {
+		var b bytes.Buffer
+		io.Copy(&b, httpresp.Body)
+		return nil, fmt.Errorf("couldn't list secrets: status %d, %s", httpresp.StatusCode, b.String())
+	}
+
+	var d vaultListResponse
+	if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
+		return nil, fmt.Errorf("couldn't decode response body: %s", err)
+	}
+
+	var keys []string
+	for _, key := range d.Data.Keys {
+		if !strings.HasSuffix(key, "/") {
+			keys = append(keys, folder+key)
+			continue
+		}
+
+		nested, err := v.listSecrets(token, folder+key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, nested...)
+	}
+	return keys, nil
+}
+
+// secretValue returns strings as is, and other JSON values, e.g. numbers and objects, encoded
+func secretValue(val interface{}) (string, error) {
+	if s, ok := val.(string); ok {
+		return s, nil
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// getToken returns the token of the auth method
+func (v *vaultSecretStore) getToken() (string, error) {
+	if v.authMethod == authMethodKubernetes {
+		return v.kubernetesLogin()
+	}
+	if v.vaultToken != "" {
+		return v.vaultToken, nil
+	}
+	return v.readVaultToken()
+}
+
+// kubernetesLogin logs in with the service account token of the pod, and caches the Vault token until it expires
+func (v *vaultSecretStore) kubernetesLogin() (string, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.kubernetesToken != "" && time.Now().Before(v.kubernetesExpiration) {
+		return v.kubernetesToken, nil
+	}
+
+	jwt, err := ioutil.ReadFile(v.kubernetesTokenPath)
+	if err != nil {
+		return "", fmt.Errorf("couldn't read kubernetes service account token: %s", err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"jwt":  string(bytes.TrimSpace(jwt)),
+		"role": v.kubernetesRole,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/auth/%s/login", v.vaultAddress, v.kubernetesMountPath), bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("couldn't generate request: %s", err)
+	}
+	httpReq.Header.Set(vaultHTTPRequestHeader, "true")
+
+	httpresp, err := v.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("couldn't log in with kubernetes auth: %s", err)
+	}
+
+	defer httpresp.Body.Close()
+
+	if httpresp.StatusCode != 200 {
+		var b bytes.Buffer
+		io.Copy(&b, httpresp.Body)
+		return "", fmt.Errorf("couldn't log in with kubernetes auth: status %d, %s", httpresp.StatusCode, b.String())
+	}
+
+	var d vaultLoginResponse
+	if err := json.NewDecoder(httpresp.Body).Decode(&d); err != nil {
+		return "", fmt.Errorf("couldn't decode response body: %s", err)
+	}
+	if d.Auth.ClientToken == "" {
+		return "", fmt.Errorf("couldn't log in with kubernetes auth: no client token in response")
+	}
+
+	v.kubernetesToken = d.Auth.ClientToken
+	v.kubernetesExpiration = time.Now().Add(time.Duration(d.Auth.LeaseDuration)*time.Second - kubernetesTokenExpirationLeeway)
+
+	return v.kubernetesToken, nil
+}
+
 func (v *vaultSecretStore) readVaultToken() (string, error) {
 	data, err := ioutil.ReadFile(v.vaultTokenMountPath)
 	if err != nil {
@@ -7,13 +7,18 @@ package vault
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"testing"
 
 	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -84,6 +89,150 @@ func TestVaultTLSConfig(t *testing.T) {
 	})
 }
 
+func TestInit(t *testing.T) {
+	v := NewHashiCorpVaultSecretStore(logger.NewLogger("test")).(*vaultSecretStore)
+
+	require.NoError(t, v.Init(secretstores.Metadata{Properties: map[string]string{"vaultToken": "root"}}))
+	assert.Equal(t, defaultVaultAddress, v.vaultAddress)
+	assert.Equal(t, defaultEnginePath, v.enginePath)
+	assert.Equal(t, engineV2, v.engineVersion)
+	assert.Equal(t, valueTypeMap, v.valueType)
+
+	require.NoError(t, v.Init(secretstores.Metadata{Properties: map[string]string{"vaultAuthMethod": "kubernetes", "vaultKubernetesRole": "dapr"}}))
+	assert.Equal(t, defaultKubernetesMountPath, v.kubernetesMountPath)
+	assert.Equal(t, defaultKubernetesTokenPath, v.kubernetesTokenPath)
+
+	for _, properties := range []map[string]string{
+		{},
+		{"vaultToken": "root", "vaultEngineVersion": "v3"},
+		{"vaultToken": "root", "vaultValueType": "yaml"},
+		{"vaultAuthMethod": "approle"},
+		{"vaultAuthMethod": "kubernetes"},
+	} {
+		assert.Error(t, v.Init(secretstores.Metadata{Properties: properties}))
+	}
+}
+
+func TestGetSecret(t *testing.T) {
+	var path, token string
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/k8s/login":
+			logins++
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["jwt"] != "service-account-token" || body["role"] != "dapr" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"auth": {"client_token": "kubernetes-token", "lease_duration": 3600}}`))
+		case "/v1/secret/data/dapr/db", "/v1/kv/dapr/db":
+			path = r.URL.Path
+			token = r.Header.Get(vaultHTTPHeader)
+			if path == "/v1/kv/dapr/db" {
+				w.Write([]byte(`{"data": {"password": "secret", "port": 5432}}`))
+				return
+			}
+			if r.URL.Query().Get("version") == "1" {
+				w.Write([]byte(`{"data": {"data": {"password": "old"}}}`))
+				return
+			}
+			w.Write([]byte(`{"data": {"data": {"password": "secret", "port": 5432}}}`))
+		case "/v1/secret/metadata/dapr/":
+			w.Write([]byte(`{"data": {"keys": ["db", "team/"]}}`))
+		case "/v1/secret/metadata/dapr/team/":
+			w.Write([]byte(`{"data": {"keys": ["api-key"]}}`))
+		case "/v1/secret/data/dapr/team/api-key":
+			w.Write([]byte(`{"data": {"data": {"value": "abc"}}}`))
+		case "/v1/secret/data/dapr/api-key":
+			w.Write([]byte(`{"data": {"data": {"value": "abc"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("KV v2 with token", func(t *testing.T) {
+		v := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
+		require.NoError(t, v.Init(secretstores.Metadata{Properties: map[string]string{"vaultAddr": server.URL, "vaultToken": "root"}}))
+
+		resp, err := v.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "secret", "port": "5432"}, resp.Data)
+		assert.Equal(t, "/v1/secret/data/dapr/db", path)
+		assert.Equal(t, "root", token)
+
+		resp, err = v.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{"version_id": "1"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"password": "old"}, resp.Data)
+
+		_, err = v.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{"version_stage": "previous"}})
+		assert.Error(t, err)
+
+		_, err = v.GetSecret(secretstores.GetSecretRequest{Name: "missing"})
+		assert.Error(t, err)
+
+		bulk, err := v.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]map[string]string{
+			"db":           {"password": "secret", "port": "5432"},
+			"team/api-key": {"value": "abc"},
+		}, bulk.Data)
+	})
+
+	t.Run("KV v1 with kubernetes auth", func(t *testing.T) {
+		f, err := ioutil.TempFile(os.TempDir(), "sa-token")
+		require.NoError(t, err)
+		defer os.Remove(f.Name())
+		_, err = f.WriteString("service-account-token\n")
+		require.NoError(t, err)
+
+		v := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
+		require.NoError(t, v.Init(secretstores.Metadata{Properties: map[string]string{
+			"vaultAddr":                server.URL,
+			"vaultAuthMethod":          "kubernetes",
+			"vaultKubernetesRole":      "dapr",
+			"vaultKubernetesMountPath": "k8s",
+			"vaultKubernetesTokenPath": f.Name(),
+			"vaultEngineVersion":       "v1",
+			"enginePath":               "kv",
+		}}))
+
+		for i := 0; i < 2; i++ {
+			resp, err := v.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"password": "secret", "port": "5432"}, resp.Data)
+		}
+		assert.Equal(t, "/v1/kv/dapr/db", path)
+		assert.Equal(t, "kubernetes-token", token)
+		assert.Equal(t, 1, logins)
+
+		_, err = v.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{"version_id": "1"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Text value type", func(t *testing.T) {
+		v := NewHashiCorpVaultSecretStore(logger.NewLogger("test"))
+		require.NoError(t, v.Init(secretstores.Metadata{Properties: map[string]string{"vaultAddr": server.URL, "vaultToken": "root", "vaultValueType": "text"}}))
+
+		resp, err := v.GetSecret(secretstores.GetSecretRequest{Name: "api-key"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api-key": "abc"}, resp.Data)
+
+		_, err = v.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+		assert.Error(t, err)
+	})
+}
+
+func TestSecretValue(t *testing.T) {
+	for val, expected := range map[interface{}]string{"a": "a", float64(1): "1", true: "true"} {
+		s, err := secretValue(val)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, s)
+	}
+}
+
 func getCertificate() []byte {
 	certificateBytes, _ := base64.StdEncoding.DecodeString(certificate)
 
@@ -39,3 +39,12 @@ func GetKubeClient() (*kubernetes.Clientset, error) {
 	}
 	return clientset, nil
 }
+
+// GetKubeClientForConfig returns a kubernetes client for the kubeconfig file at the path
+func GetKubeClientForConfig(kubeconfigPath string) (*kubernetes.Clientset, error) {
+	conf, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(conf)
+}
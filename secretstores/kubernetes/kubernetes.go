@@ -7,16 +7,28 @@ package kubernetes
 
 import (
 	"errors"
+	"os"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/logger"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// kubeconfigPathKey is the metadata of the kubeconfig file used out of cluster, instead of the --kubeconfig flag
+	kubeconfigPathKey = "kubeconfigPath"
+	// defaultNamespaceKey is the metadata of the namespace of the requests without a namespace metadata
+	defaultNamespaceKey = "defaultNamespace"
+	// namespaceEnvVar is the namespace used when the metadata has no default namespace
+	namespaceEnvVar = "NAMESPACE"
+)
+
 type kubernetesSecretStore struct {
-	kubeClient kubernetes.Interface
-	logger     logger.Logger
+	kubeClient       kubernetes.Interface
+	defaultNamespace string
+	logger           logger.Logger
 }
 
 // NewKubernetesSecretStore returns a new Kubernetes secret store
@@ -26,14 +38,44 @@ func NewKubernetesSecretStore(logger logger.Logger) secretstores.SecretStore {
 
 // Init creates a Kubernetes client
 func (k *kubernetesSecretStore) Init(metadata secretstores.Metadata) error {
-	client, err := GetKubeClient()
+	var client kubernetes.Interface
+	var err error
+	if kubeconfigPath := metadata.Properties[kubeconfigPathKey]; kubeconfigPath != "" {
+		client, err = GetKubeClientForConfig(kubeconfigPath)
+	} else {
+		client, err = GetKubeClient()
+	}
 	if err != nil {
 		return err
 	}
 	k.kubeClient = client
+
+	k.defaultNamespace = metadata.Properties[defaultNamespaceKey]
+	if k.defaultNamespace == "" {
+		k.defaultNamespace = os.Getenv(namespaceEnvVar)
+	}
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields of the Kubernetes secret store
+func (k *kubernetesSecretStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: kubeconfigPathKey, Type: metadata.TypeString},
+			{Name: defaultNamespaceKey, Type: metadata.TypeString},
+		},
+	}
+}
+
+// Ping asks the API server for its version, for health checks
+func (k *kubernetesSecretStore) Ping() error {
+	if k.kubeClient == nil {
+		return errors.New("the Kubernetes secret store is not initialized")
+	}
+	_, err := k.kubeClient.Discovery().ServerVersion()
+	return err
+}
+
 // GetSecret retrieves a secret using a key and returns a map of decrypted string/string values
 func (k *kubernetesSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
 	resp := secretstores.GetSecretResponse{
@@ -55,9 +97,37 @@ func (k *kubernetesSecretStore) GetSecret(req secretstores.GetSecretRequest) (se
 	return resp, nil
 }
 
+// BulkGetSecret retrieves all the secrets of the namespace of the request metadata, or the default namespace
+func (k *kubernetesSecretStore) BulkGetSecret(req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	resp := secretstores.BulkGetSecretResponse{
+		Data: map[string]map[string]string{},
+	}
+	namespace, err := k.getNamespaceFromMetadata(req.Metadata)
+	if err != nil {
+		return resp, err
+	}
+
+	secrets, err := k.kubeClient.CoreV1().Secrets(namespace).List(meta_v1.ListOptions{})
+	if err != nil {
+		return resp, err
+	}
+
+	for _, secret := range secrets.Items {
+		data := map[string]string{}
+		for k, v := range secret.Data {
+			data[k] = string(v)
+		}
+		resp.Data[secret.Name] = data
+	}
+	return resp, nil
+}
+
 func (k *kubernetesSecretStore) getNamespaceFromMetadata(metadata map[string]string) (string, error) {
 	if val, ok := metadata["namespace"]; ok && val != "" {
 		return val, nil
 	}
+	if k.defaultNamespace != "" {
+		return k.defaultNamespace, nil
+	}
 	return "", errors.New("namespace is missing on metadata")
 }
@@ -8,8 +8,13 @@ package kubernetes
 import (
 	"testing"
 
+	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestGetNamespace(t *testing.T) {
@@ -22,6 +27,18 @@ func TestGetNamespace(t *testing.T) {
 		assert.Equal(t, namespace, ns)
 	})
 
+	t.Run("default namespace", func(t *testing.T) {
+		store := kubernetesSecretStore{logger: logger.NewLogger("test"), defaultNamespace: "b"}
+
+		ns, err := store.getNamespaceFromMetadata(map[string]string{})
+		assert.Nil(t, err)
+		assert.Equal(t, "b", ns)
+
+		ns, err = store.getNamespaceFromMetadata(map[string]string{"namespace": "a"})
+		assert.Nil(t, err)
+		assert.Equal(t, "a", ns)
+	})
+
 	t.Run("no namespace", func(t *testing.T) {
 		store := kubernetesSecretStore{logger: logger.NewLogger("test")}
 		_, err := store.getNamespaceFromMetadata(map[string]string{})
@@ -30,3 +47,37 @@ func TestGetNamespace(t *testing.T) {
 		assert.Equal(t, "namespace is missing on metadata", err.Error())
 	})
 }
+
+func TestGetSecret(t *testing.T) {
+	store := kubernetesSecretStore{
+		logger:           logger.NewLogger("test"),
+		defaultNamespace: "default",
+		kubeClient: fake.NewSimpleClientset(
+			&v1.Secret{ObjectMeta: meta_v1.ObjectMeta{Name: "db", Namespace: "default"}, Data: map[string][]byte{"password": []byte("a")}},
+			&v1.Secret{ObjectMeta: meta_v1.ObjectMeta{Name: "db", Namespace: "other"}, Data: map[string][]byte{"password": []byte("b")}},
+		),
+	}
+
+	resp, err := store.GetSecret(secretstores.GetSecretRequest{Name: "db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": "a"}, resp.Data)
+
+	resp, err = store.GetSecret(secretstores.GetSecretRequest{Name: "db", Metadata: map[string]string{"namespace": "other"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": "b"}, resp.Data)
+
+	_, err = store.GetSecret(secretstores.GetSecretRequest{Name: "missing"})
+	assert.Error(t, err)
+
+	bulk, err := store.BulkGetSecret(secretstores.BulkGetSecretRequest{Metadata: map[string]string{"namespace": "other"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"db": {"password": "b"}}, bulk.Data)
+}
+
+func TestPing(t *testing.T) {
+	store := kubernetesSecretStore{logger: logger.NewLogger("test")}
+	assert.Error(t, store.Ping())
+
+	store.kubeClient = fake.NewSimpleClientset()
+	assert.NoError(t, store.Ping())
+}
@@ -0,0 +1,83 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// prefixKey is the metadata of the prefix of the environment variables, e.g. "MYAPP_" for "MYAPP_DB_PASSWORD" to be
+// retrieved as "DB_PASSWORD"
+const prefixKey = "prefix"
+
+type envSecretStore struct {
+	prefix    string
+	lookupEnv func(key string) (string, bool)
+	environ   func() []string
+	logger    logger.Logger
+}
+
+// NewEnvSecretStore returns a new environment variables secret store
+func NewEnvSecretStore(logger logger.Logger) secretstores.SecretStore {
+	return &envSecretStore{
+		lookupEnv: os.LookupEnv,
+		environ:   os.Environ,
+		logger:    logger,
+	}
+}
+
+// Init reads the prefix of the environment variables
+func (s *envSecretStore) Init(metadata secretstores.Metadata) error {
+	s.prefix = metadata.Properties[prefixKey]
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields of the environment variable secret store
+func (s *envSecretStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: prefixKey, Type: metadata.TypeString},
+		},
+	}
+}
+
+// Ping always succeeds, as the environment variables are always there
+func (s *envSecretStore) Ping() error {
+	return nil
+}
+
+// GetSecret retrieves the value of the environment variable of the name, with the prefix
+func (s *envSecretStore) GetSecret(req secretstores.GetSecretRequest) (secretstores.GetSecretResponse, error) {
+	value, ok := s.lookupEnv(s.prefix + req.Name)
+	if !ok {
+		return secretstores.GetSecretResponse{}, fmt.Errorf("secret %s not found", req.Name)
+	}
+
+	return secretstores.GetSecretResponse{
+		Data: map[string]string{
+			req.Name: value,
+		},
+	}, nil
+}
+
+// BulkGetSecret retrieves all the environment variables with the prefix, i.e. the whole environment without a prefix
+func (s *envSecretStore) BulkGetSecret(req secretstores.BulkGetSecretRequest) (secretstores.BulkGetSecretResponse, error) {
+	var keys []string
+	for _, env := range s.environ() {
+		key := strings.SplitN(env, "=", 2)[0]
+		if key != "" && strings.HasPrefix(key, s.prefix) {
+			keys = append(keys, strings.TrimPrefix(key, s.prefix))
+		}
+	}
+
+	return secretstores.BulkGetSecretFromKeys(s, req, keys)
+}
@@ -0,0 +1,57 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package env
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSecret(t *testing.T) {
+	s := NewEnvSecretStore(logger.NewLogger("test")).(*envSecretStore)
+	s.lookupEnv = func(key string) (string, bool) {
+		if key == "APP_DB_PASSWORD" {
+			return "secret", true
+		}
+		return "", false
+	}
+
+	t.Run("without prefix", func(t *testing.T) {
+		assert.Nil(t, s.Init(secretstores.Metadata{Properties: map[string]string{}}))
+
+		output, err := s.GetSecret(secretstores.GetSecretRequest{Name: "APP_DB_PASSWORD"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"APP_DB_PASSWORD": "secret"}, output.Data)
+	})
+
+	t.Run("with prefix", func(t *testing.T) {
+		assert.Nil(t, s.Init(secretstores.Metadata{Properties: map[string]string{"prefix": "APP_"}}))
+
+		output, err := s.GetSecret(secretstores.GetSecretRequest{Name: "DB_PASSWORD"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"DB_PASSWORD": "secret"}, output.Data)
+	})
+
+	t.Run("bulk with prefix", func(t *testing.T) {
+		s.environ = func() []string {
+			return []string{"APP_DB_PASSWORD=secret", "HOME=/root"}
+		}
+		assert.Nil(t, s.Init(secretstores.Metadata{Properties: map[string]string{"prefix": "APP_"}}))
+
+		output, err := s.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]map[string]string{"DB_PASSWORD": {"DB_PASSWORD": "secret"}}, output.Data)
+	})
+
+	t.Run("unset variable", func(t *testing.T) {
+		_, err := s.GetSecret(secretstores.GetSecretRequest{Name: "MISSING"})
+		assert.Equal(t, fmt.Errorf("secret MISSING not found"), err)
+	})
+}
@@ -2,7 +2,7 @@
 // Copyright (c) Microsoft Corporation.
 // Licensed under the MIT License.
 // ------------------------------------------------------------
-package localsecretstore
+package file
 
 import (
 	"fmt"
@@ -78,3 +78,41 @@ func TestGetSecret(t *testing.T) {
 		assert.Equal(t, err, fmt.Errorf("secret %s not found", req.Name))
 	})
 }
+
+func TestNestedSecrets(t *testing.T) {
+	m := secretstores.Metadata{}
+	m.Properties = map[string]string{
+		"SecretsFile":     "a",
+		"NestedSeparator": ".",
+	}
+	s := localSecretStore{
+		logger: logger.NewLogger("test"),
+		readLocalFileFn: func(secretsFile string) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"db": map[string]interface{}{
+					"password": secretValue,
+					"port":     float64(5432),
+					"hosts":    []interface{}{"a", "b"},
+					"tls":      true,
+				},
+			}, nil
+		},
+	}
+	assert.Nil(t, s.Init(m))
+
+	bulk, err := s.BulkGetSecret(secretstores.BulkGetSecretRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, bulk.Data, 5)
+	assert.Equal(t, map[string]string{"db.hosts.0": "a"}, bulk.Data["db.hosts.0"])
+
+	for name, expected := range map[string]string{
+		"db.password": secretValue,
+		"db.port":     "5432",
+		"db.hosts.1":  "b",
+		"db.tls":      "true",
+	} {
+		output, err := s.GetSecret(secretstores.GetSecretRequest{Name: name})
+		assert.Nil(t, err)
+		assert.Equal(t, expected, output.Data[name])
+	}
+}
@@ -0,0 +1,61 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/metrics"
+)
+
+// MetricsStore records the count, latency and errors of the operations of a secret store
+type MetricsStore struct {
+	store     SecretStore
+	component string
+	recorder  metrics.Recorder
+}
+
+// NewMetricsStore wraps store so that its operations are recorded with the name of the component
+func NewMetricsStore(store SecretStore, component string, recorder metrics.Recorder) SecretStore {
+	return &MetricsStore{store: store, component: component, recorder: recorder}
+}
+
+// Init initializes the wrapped store
+func (s *MetricsStore) Init(metadata Metadata) error {
+	return metrics.Measure(s.recorder, s.component, "init", func() error {
+		return s.store.Init(metadata)
+	})
+}
+
+// GetComponentMetadata returns the metadata of the wrapped store
+func (s *MetricsStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return s.store.GetComponentMetadata()
+}
+
+// Ping pings the wrapped store
+func (s *MetricsStore) Ping() error {
+	return health.Ping(s.store)
+}
+
+// GetSecret retrieves a secret
+func (s *MetricsStore) GetSecret(req GetSecretRequest) (GetSecretResponse, error) {
+	var resp GetSecretResponse
+	err := metrics.Measure(s.recorder, s.component, "getSecret", func() (err error) {
+		resp, err = s.store.GetSecret(req)
+		return err
+	})
+	return resp, err
+}
+
+// BulkGetSecret retrieves all the secrets of the store
+func (s *MetricsStore) BulkGetSecret(req BulkGetSecretRequest) (BulkGetSecretResponse, error) {
+	var resp BulkGetSecretResponse
+	err := metrics.Measure(s.recorder, s.component, "bulkGetSecret", func() (err error) {
+		resp, err = s.store.BulkGetSecret(req)
+		return err
+	})
+	return resp, err
+}
@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsStore(t *testing.T) {
+	c := metrics.NewCollector()
+	s := NewMetricsStore(&fakeSecretStore{secrets: map[string]string{"a": "1", "b": "2"}}, "vault", c)
+
+	assert.NoError(t, s.Init(Metadata{}))
+	resp, err := s.GetSecret(GetSecretRequest{Name: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1"}, resp.Data)
+	_, err = s.GetSecret(GetSecretRequest{Name: "c"})
+	assert.Error(t, err)
+	bulk, err := s.BulkGetSecret(BulkGetSecretRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, bulk.Data, 2)
+
+	stats := c.Stats()
+	get := stats[metrics.OperationKey{Component: "vault", Operation: "getSecret"}]
+	assert.Equal(t, int64(2), get.Count)
+	assert.Equal(t, int64(1), get.Errors)
+	assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "vault", Operation: "bulkGetSecret"}].Count)
+	assert.Equal(t, int64(1), stats[metrics.OperationKey{Component: "vault", Operation: "init"}].Count)
+}
@@ -5,10 +5,16 @@
 
 package secretstores
 
+import "github.com/dapr/components-contrib/metadata"
+
 // SecretStore is the interface for a component that handles secrets management
 type SecretStore interface {
 	// Init authenticates with the actual secret store and performs other init operation
 	Init(metadata Metadata) error
 	// GetSecret retrieves a secret using a key and returns a map of decrypted string/string values
 	GetSecret(req GetSecretRequest) (GetSecretResponse, error)
+	// BulkGetSecret retrieves all the secrets of the store and returns a map of secret name to string/string values
+	BulkGetSecret(req BulkGetSecretRequest) (BulkGetSecretResponse, error)
+	// GetComponentMetadata returns the metadata fields and the capabilities of the secret store
+	GetComponentMetadata() metadata.ComponentMetadata
 }
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 
@@ -69,6 +70,10 @@ func validateMetadata(metadata state.Metadata) error {
 
 // Init does metadata and connection parsing
 func (aspike *Aerospike) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	err := validateMetadata(metadata)
 	if err != nil {
 		return err
@@ -88,6 +93,18 @@ func (aspike *Aerospike) Init(metadata state.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Aerospike state store
+func (aspike *Aerospike) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: hosts, Type: metadata.TypeList, Required: true},
+			{Name: namespace, Type: metadata.TypeString, Required: true},
+			{Name: set, Type: metadata.TypeString},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag},
+	}
+}
+
 // Set stores value for a key to Aerospike. It honors ETag (for concurrency) and consistency settings
 func (aspike *Aerospike) Set(req *state.SetRequest) error {
 	err := state.CheckSetRequestOptions(req)
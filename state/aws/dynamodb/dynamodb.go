@@ -3,11 +3,16 @@ package dynamodb
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/google/uuid"
 	jsoniterator "github.com/json-iterator/go"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -15,19 +20,36 @@ import (
 	"github.com/dapr/components-contrib/state"
 )
 
+const (
+	keyAttribute   = "key"
+	valueAttribute = "value"
+	etagAttribute  = "etag"
+
+	ttlInSecondsKey = "ttlInSeconds"
+
+	// maxBatchSize is the largest number of items BatchWriteItem accepts in a single call
+	maxBatchSize = 25
+	// maxBatchRetries is how many times items that DynamoDB did not process in a batch are retried
+	maxBatchRetries = 5
+	batchRetryDelay = 50 * time.Millisecond
+)
+
 // StateStore is a DynamoDB state store
 type StateStore struct {
-	client dynamodbiface.DynamoDBAPI
-	table  string
+	client           dynamodbiface.DynamoDBAPI
+	table            string
+	ttlAttributeName string
 }
 
 type dynamoDBMetadata struct {
-	Region       string `json:"region"`
-	Endpoint     string `json:"endpoint"`
-	AccessKey    string `json:"accessKey"`
-	SecretKey    string `json:"secretKey"`
-	SessionToken string `json:"sessionToken"`
-	Table        string `json:"table"`
+	Region           string `json:"region"`
+	Endpoint         string `json:"endpoint"`
+	AccessKey        string `json:"accessKey"`
+	SecretKey        string `json:"secretKey"`
+	SessionToken     string `json:"sessionToken"`
+	RoleARN          string `json:"roleArn"`
+	Table            string `json:"table" metadata:",required"`
+	TTLAttributeName string `json:"ttlAttributeName"`
 }
 
 // NewDynamoDBStateStore returns a new dynamoDB state store
@@ -37,6 +59,10 @@ func NewDynamoDBStateStore() *StateStore {
 
 // Init does metadata and connection parsing
 func (d *StateStore) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	meta, err := d.getDynamoDBMetadata(metadata)
 	if err != nil {
 		return err
@@ -49,16 +75,25 @@ func (d *StateStore) Init(metadata state.Metadata) error {
 
 	d.client = client
 	d.table = meta.Table
+	d.ttlAttributeName = meta.TTLAttributeName
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the DynamoDB state store
+func (d *StateStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields:       metadata.FieldsOf(dynamoDBMetadata{}),
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL},
+	}
+}
+
 // Get retrieves a dynamoDB item
 func (d *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	input := &dynamodb.GetItemInput{
 		ConsistentRead: aws.Bool(req.Options.Consistency == state.Strong),
 		TableName:      aws.String(d.table),
 		Key: map[string]*dynamodb.AttributeValue{
-			"key": {
+			keyAttribute: {
 				S: aws.String(req.Key),
 			},
 		},
@@ -69,33 +104,43 @@ func (d *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
 		return nil, err
 	}
 
-	if len(result.Item) == 0 {
+	// DynamoDB deletes expired items in the background, which can take up to 48 hours
+	if len(result.Item) == 0 || d.isExpired(result.Item) {
 		return &state.GetResponse{}, nil
 	}
 
 	var output string
-	if err = dynamodbattribute.Unmarshal(result.Item["value"], &output); err != nil {
+	if err = dynamodbattribute.Unmarshal(result.Item[valueAttribute], &output); err != nil {
 		return nil, err
 	}
+
+	var etag string
+	if etagValue, ok := result.Item[etagAttribute]; ok {
+		if err = dynamodbattribute.Unmarshal(etagValue, &etag); err != nil {
+			return nil, err
+		}
+	}
+
 	return &state.GetResponse{
 		Data: []byte(output),
+		ETag: etag,
 	}, nil
 }
 
 // Set saves a dynamoDB item
 func (d *StateStore) Set(req *state.SetRequest) error {
-	value, err := d.marshalToString(req.Value)
+	err := state.CheckSetRequestOptions(req)
 	if err != nil {
-		return fmt.Errorf("dynamodb error: failed to set key %s: %s", req.Key, err)
+		return err
 	}
 
-	item := map[string]*dynamodb.AttributeValue{
-		"key": {
-			S: aws.String(req.Key),
-		},
-		"value": {
-			S: aws.String(value),
-		},
+	return state.SetWithRetries(d.setValue, req)
+}
+
+func (d *StateStore) setValue(req *state.SetRequest) error {
+	item, err := d.getItem(req)
+	if err != nil {
+		return err
 	}
 
 	input := &dynamodb.PutItemInput{
@@ -103,68 +148,113 @@ func (d *StateStore) Set(req *state.SetRequest) error {
 		TableName: &d.table,
 	}
 
+	switch {
+	case req.ETag != "":
+		input.ConditionExpression = aws.String("#etag = :etag")
+		input.ExpressionAttributeNames = map[string]*string{"#etag": aws.String(etagAttribute)}
+		input.ExpressionAttributeValues = map[string]*dynamodb.AttributeValue{":etag": {S: aws.String(req.ETag)}}
+	case req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so the key may only be created
+		input.ConditionExpression = aws.String("attribute_not_exists(#key)")
+		input.ExpressionAttributeNames = map[string]*string{"#key": aws.String(keyAttribute)}
+	}
+
 	_, e := d.client.PutItem(input)
+	if isConditionalCheckFailed(e) {
+		return fmt.Errorf("dynamodb error: failed to set key %s: possible etag mismatch", req.Key)
+	}
 	return e
 }
 
 // BulkSet performs a bulk set operation
 func (d *StateStore) BulkSet(req []state.SetRequest) error {
+	// BatchWriteItem does not support conditions, so concurrency checked writes are made one by one
+	for i := range req {
+		if req[i].ETag != "" || req[i].Options.Concurrency == state.FirstWrite {
+			return d.setEach(req)
+		}
+	}
+
 	writeRequests := []*dynamodb.WriteRequest{}
 
-	for _, r := range req {
-		value, err := d.marshalToString(r.Value)
+	for i := range req {
+		item, err := d.getItem(&req[i])
 		if err != nil {
-			return fmt.Errorf("dynamodb error: failed to set key %s: %s", r.Key, err)
+			return err
 		}
 
 		writeRequest := &dynamodb.WriteRequest{
 			PutRequest: &dynamodb.PutRequest{
-				Item: map[string]*dynamodb.AttributeValue{
-					"key": {
-						S: aws.String(r.Key),
-					},
-					"value": {
-						S: aws.String(value),
-					},
-				},
+				Item: item,
 			},
 		}
 
 		writeRequests = append(writeRequests, writeRequest)
 	}
 
-	requestItems := map[string][]*dynamodb.WriteRequest{}
-	requestItems[d.table] = writeRequests
+	return d.batchWrite(writeRequests)
+}
 
-	_, e := d.client.BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: requestItems,
-	})
-	return e
+func (d *StateStore) setEach(req []state.SetRequest) error {
+	for i := range req {
+		err := d.Set(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Delete performs a delete operation
 func (d *StateStore) Delete(req *state.DeleteRequest) error {
+	err := state.CheckDeleteRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	return state.DeleteWithRetries(d.deleteValue, req)
+}
+
+func (d *StateStore) deleteValue(req *state.DeleteRequest) error {
 	input := &dynamodb.DeleteItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
-			"key": {
+			keyAttribute: {
 				S: aws.String(req.Key),
 			},
 		},
 		TableName: aws.String(d.table),
 	}
+
+	if req.ETag != "" {
+		input.ConditionExpression = aws.String("#etag = :etag")
+		input.ExpressionAttributeNames = map[string]*string{"#etag": aws.String(etagAttribute)}
+		input.ExpressionAttributeValues = map[string]*dynamodb.AttributeValue{":etag": {S: aws.String(req.ETag)}}
+	}
+
 	_, err := d.client.DeleteItem(input)
+	if isConditionalCheckFailed(err) {
+		return fmt.Errorf("dynamodb error: failed to delete key %s: possible etag mismatch", req.Key)
+	}
 	return err
 }
 
 // BulkDelete performs a bulk delete operation
 func (d *StateStore) BulkDelete(req []state.DeleteRequest) error {
+	// BatchWriteItem does not support conditions, so concurrency checked deletes are made one by one
+	for i := range req {
+		if req[i].ETag != "" {
+			return d.deleteEach(req)
+		}
+	}
+
 	writeRequests := []*dynamodb.WriteRequest{}
 
 	for _, r := range req {
 		writeRequest := &dynamodb.WriteRequest{
 			DeleteRequest: &dynamodb.DeleteRequest{
 				Key: map[string]*dynamodb.AttributeValue{
-					"key": {
+					keyAttribute: {
 						S: aws.String(r.Key),
 					},
 				},
@@ -173,13 +263,112 @@ func (d *StateStore) BulkDelete(req []state.DeleteRequest) error {
 		writeRequests = append(writeRequests, writeRequest)
 	}
 
-	requestItems := map[string][]*dynamodb.WriteRequest{}
-	requestItems[d.table] = writeRequests
+	return d.batchWrite(writeRequests)
+}
 
-	_, e := d.client.BatchWriteItem(&dynamodb.BatchWriteItemInput{
-		RequestItems: requestItems,
-	})
-	return e
+func (d *StateStore) deleteEach(req []state.DeleteRequest) error {
+	for i := range req {
+		err := d.Delete(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// batchWrite writes the requests in batches of the largest size DynamoDB accepts.
+// Items that DynamoDB could not process, for example because of throttling, are retried with a backoff.
+func (d *StateStore) batchWrite(writeRequests []*dynamodb.WriteRequest) error {
+	for start := 0; start < len(writeRequests); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(writeRequests) {
+			end = len(writeRequests)
+		}
+
+		requestItems := map[string][]*dynamodb.WriteRequest{}
+		requestItems[d.table] = writeRequests[start:end]
+
+		delay := batchRetryDelay
+		for retry := 0; len(requestItems) > 0; retry++ {
+			if retry > maxBatchRetries {
+				return fmt.Errorf("dynamodb error: %d items were not processed after %d retries", len(requestItems[d.table]), maxBatchRetries)
+			}
+			if retry > 0 {
+				time.Sleep(delay)
+				delay *= 2
+			}
+
+			output, e := d.client.BatchWriteItem(&dynamodb.BatchWriteItemInput{
+				RequestItems: requestItems,
+			})
+			if e != nil {
+				return e
+			}
+
+			requestItems = output.UnprocessedItems
+		}
+	}
+
+	return nil
+}
+
+// getItem returns the item for a set request with a new etag, and an expiry time when the request has a TTL.
+func (d *StateStore) getItem(req *state.SetRequest) (map[string]*dynamodb.AttributeValue, error) {
+	value, err := d.marshalToString(req.Value)
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb error: failed to set key %s: %s", req.Key, err)
+	}
+
+	item := map[string]*dynamodb.AttributeValue{
+		keyAttribute: {
+			S: aws.String(req.Key),
+		},
+		valueAttribute: {
+			S: aws.String(value),
+		},
+		etagAttribute: {
+			S: aws.String(uuid.New().String()),
+		},
+	}
+
+	ttl, err := parseTTL(req.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("dynamodb error: failed to set key %s: %s", req.Key, err)
+	}
+
+	if ttl > 0 {
+		if d.ttlAttributeName == "" {
+			return nil, fmt.Errorf("dynamodb error: failed to set key %s: %s requires the ttlAttributeName metadata", req.Key, ttlInSecondsKey)
+		}
+
+		// DynamoDB expires items at a time in seconds since the Unix epoch
+		expiry := time.Now().Unix() + ttl
+		item[d.ttlAttributeName] = &dynamodb.AttributeValue{
+			N: aws.String(strconv.FormatInt(expiry, 10)),
+		}
+	}
+
+	return item, nil
+}
+
+// isExpired returns true if the expiry time of the item has passed.
+func (d *StateStore) isExpired(item map[string]*dynamodb.AttributeValue) bool {
+	if d.ttlAttributeName == "" {
+		return false
+	}
+
+	ttlValue, ok := item[d.ttlAttributeName]
+	if !ok || ttlValue.N == nil {
+		return false
+	}
+
+	expiry, err := strconv.ParseInt(*ttlValue.N, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return expiry <= time.Now().Unix()
 }
 
 func (d *StateStore) getDynamoDBMetadata(metadata state.Metadata) (*dynamoDBMetadata, error) {
@@ -194,15 +383,27 @@ func (d *StateStore) getDynamoDBMetadata(metadata state.Metadata) (*dynamoDBMeta
 		return nil, err
 	}
 
-	if meta.SecretKey == "" || meta.AccessKey == "" || meta.Region == "" || meta.SessionToken == "" {
+	// Without an access key, credentials are resolved from the environment, a profile, or the instance role
+	if (meta.AccessKey == "") != (meta.SecretKey == "") || (meta.SessionToken != "" && meta.AccessKey == "") {
 		return nil, fmt.Errorf("missing aws credentials in metadata")
 	}
 
+	if meta.Table == "" {
+		return nil, fmt.Errorf("missing dynamodb table name in metadata")
+	}
+
 	return &meta, nil
 }
 
 func (d *StateStore) getClient(metadata *dynamoDBMetadata) (*dynamodb.DynamoDB, error) {
-	sess, err := aws_auth.GetClient(metadata.AccessKey, metadata.SecretKey, metadata.Region, metadata.Endpoint)
+	sess, err := aws_auth.GetClientWithOptions(aws_auth.ClientOptions{
+		AccessKey:    metadata.AccessKey,
+		SecretKey:    metadata.SecretKey,
+		SessionToken: metadata.SessionToken,
+		RoleARN:      metadata.RoleARN,
+		Region:       metadata.Region,
+		Endpoint:     metadata.Endpoint,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -217,3 +418,31 @@ func (d *StateStore) marshalToString(v interface{}) (string, error) {
 
 	return jsoniterator.ConfigFastest.MarshalToString(v)
 }
+
+// parseTTL returns the number of seconds until an item expires from the metadata of a set request.
+// Items without a ttlInSeconds, or with a value that is not positive, never expire.
+func parseTTL(requestMetadata map[string]string) (int64, error) {
+	val, ok := requestMetadata[ttlInSecondsKey]
+	if !ok || val == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s', must be an integer", ttlInSecondsKey, val)
+	}
+
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func isConditionalCheckFailed(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException
+	}
+
+	return false
+}
@@ -6,9 +6,12 @@ package dynamodb
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/dapr/components-contrib/state"
@@ -49,18 +52,47 @@ func TestInit(t *testing.T) {
 			"Region":       "a",
 			"SecretKey":    "a",
 			"SessionToken": "a",
+			"Table":        "a",
 		}
 		err := s.Init(m)
 		assert.Nil(t, err)
 	})
 
+	t.Run("Init without credentials uses the default credential chain", func(t *testing.T) {
+		m.Properties = map[string]string{
+			"Region": "a",
+			"Table":  "a",
+		}
+		err := s.Init(m)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Init with an assumed role", func(t *testing.T) {
+		m.Properties = map[string]string{
+			"Region":  "a",
+			"RoleARN": "arn:aws:iam::123456789012:role/dapr",
+			"Table":   "a",
+		}
+		err := s.Init(m)
+		assert.Nil(t, err)
+	})
+
+	t.Run("Init with a session token and no access key", func(t *testing.T) {
+		m.Properties = map[string]string{
+			"SessionToken": "a",
+			"Table":        "a",
+		}
+		err := s.Init(m)
+		assert.Equal(t, fmt.Errorf("missing aws credentials in metadata"), err)
+	})
+
 	t.Run("Init with missing metadata", func(t *testing.T) {
 		m.Properties = map[string]string{
 			"Dummy": "a",
 		}
 		err := s.Init(m)
 		assert.NotNil(t, err)
-		assert.Equal(t, err, fmt.Errorf("missing aws credentials in metadata"))
+		assert.Equal(t, err, fmt.Errorf("missing dynamodb table name in metadata"))
 	})
 }
 
@@ -169,13 +201,10 @@ func TestSet(t *testing.T) {
 		ss := StateStore{
 			client: &mockedDynamoDB{
 				PutItemFn: func(input *dynamodb.PutItemInput) (output *dynamodb.PutItemOutput, err error) {
-					assert.Equal(t, map[string]*dynamodb.AttributeValue{
-						"key": {
-							S: aws.String("key"),
-						},
-						"value": {
-							S: aws.String(`{"Value":"value"}`),
-						}}, input.Item)
+					assert.Equal(t, "key", *input.Item["key"].S)
+					assert.Equal(t, `{"Value":"value"}`, *input.Item["value"].S)
+					assert.NotEmpty(t, *input.Item["etag"].S)
+					assert.Nil(t, input.ConditionExpression)
 					return &dynamodb.PutItemOutput{
 						Attributes: map[string]*dynamodb.AttributeValue{
 							"key": {
@@ -224,34 +253,13 @@ func TestBulkSet(t *testing.T) {
 		ss := StateStore{
 			client: &mockedDynamoDB{
 				BatchWriteItemFn: func(input *dynamodb.BatchWriteItemInput) (output *dynamodb.BatchWriteItemOutput, err error) {
-					expected := map[string][]*dynamodb.WriteRequest{}
-					expected[tableName] = []*dynamodb.WriteRequest{
-						{
-							PutRequest: &dynamodb.PutRequest{
-								Item: map[string]*dynamodb.AttributeValue{
-									"key": {
-										S: aws.String("key1"),
-									},
-									"value": {
-										S: aws.String(`{"Value":"value1"}`),
-									},
-								},
-							},
-						},
-						{
-							PutRequest: &dynamodb.PutRequest{
-								Item: map[string]*dynamodb.AttributeValue{
-									"key": {
-										S: aws.String("key2"),
-									},
-									"value": {
-										S: aws.String(`{"Value":"value2"}`),
-									},
-								},
-							},
-						},
+					writeRequests := input.RequestItems[tableName]
+					assert.Len(t, writeRequests, 2)
+					for i, w := range writeRequests {
+						assert.Equal(t, fmt.Sprintf("key%d", i+1), *w.PutRequest.Item["key"].S)
+						assert.Equal(t, fmt.Sprintf(`{"Value":"value%d"}`, i+1), *w.PutRequest.Item["value"].S)
+						assert.NotEmpty(t, *w.PutRequest.Item["etag"].S)
 					}
-					assert.Equal(t, expected, input.RequestItems)
 					return &dynamodb.BatchWriteItemOutput{
 						UnprocessedItems: map[string][]*dynamodb.WriteRequest{},
 					}, nil
@@ -398,3 +406,203 @@ func TestBulkDelete(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestETag(t *testing.T) {
+	t.Run("Get returns the etag", func(t *testing.T) {
+		ss := StateStore{
+			client: &mockedDynamoDB{
+				GetItemFn: func(input *dynamodb.GetItemInput) (output *dynamodb.GetItemOutput, err error) {
+					return &dynamodb.GetItemOutput{
+						Item: map[string]*dynamodb.AttributeValue{
+							"key":   {S: aws.String("key")},
+							"value": {S: aws.String("value")},
+							"etag":  {S: aws.String("1")},
+						},
+					}, nil
+				},
+			},
+		}
+		out, err := ss.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, "1", out.ETag)
+	})
+
+	t.Run("Set with etag is conditional", func(t *testing.T) {
+		ss := StateStore{
+			client: &mockedDynamoDB{
+				PutItemFn: func(input *dynamodb.PutItemInput) (output *dynamodb.PutItemOutput, err error) {
+					assert.Equal(t, "#etag = :etag", *input.ConditionExpression)
+					assert.Equal(t, "1", *input.ExpressionAttributeValues[":etag"].S)
+					assert.NotEqual(t, "1", *input.Item["etag"].S)
+					return &dynamodb.PutItemOutput{}, nil
+				},
+			},
+		}
+		err := ss.Set(&state.SetRequest{Key: "key", Value: "value", ETag: "1"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Set with mismatched etag", func(t *testing.T) {
+		ss := StateStore{
+			client: &mockedDynamoDB{
+				PutItemFn: func(input *dynamodb.PutItemInput) (output *dynamodb.PutItemOutput, err error) {
+					return nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)
+				},
+			},
+		}
+		err := ss.Set(&state.SetRequest{Key: "key", Value: "value", ETag: "1"})
+		assert.Equal(t, fmt.Errorf("dynamodb error: failed to set key key: possible etag mismatch"), err)
+	})
+
+	t.Run("First write without etag only creates the key", func(t *testing.T) {
+		ss := StateStore{
+			client: &mockedDynamoDB{
+				PutItemFn: func(input *dynamodb.PutItemInput) (output *dynamodb.PutItemOutput, err error) {
+					assert.Equal(t, "attribute_not_exists(#key)", *input.ConditionExpression)
+					return &dynamodb.PutItemOutput{}, nil
+				},
+			},
+		}
+		err := ss.Set(&state.SetRequest{
+			Key:   "key",
+			Value: "value",
+			Options: state.SetStateOption{
+				Concurrency: state.FirstWrite,
+			},
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Delete with etag is conditional", func(t *testing.T) {
+		ss := StateStore{
+			client: &mockedDynamoDB{
+				DeleteItemFn: func(input *dynamodb.DeleteItemInput) (output *dynamodb.DeleteItemOutput, err error) {
+					assert.Equal(t, "#etag = :etag", *input.ConditionExpression)
+					assert.Equal(t, "1", *input.ExpressionAttributeValues[":etag"].S)
+					return nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)
+				},
+			},
+		}
+		err := ss.Delete(&state.DeleteRequest{Key: "key", ETag: "1"})
+		assert.Equal(t, fmt.Errorf("dynamodb error: failed to delete key key: possible etag mismatch"), err)
+	})
+
+	t.Run("Bulk operations with etags are not batched", func(t *testing.T) {
+		puts, deletes := 0, 0
+		ss := StateStore{
+			client: &mockedDynamoDB{
+				PutItemFn: func(input *dynamodb.PutItemInput) (output *dynamodb.PutItemOutput, err error) {
+					puts++
+					return &dynamodb.PutItemOutput{}, nil
+				},
+				DeleteItemFn: func(input *dynamodb.DeleteItemInput) (output *dynamodb.DeleteItemOutput, err error) {
+					deletes++
+					return &dynamodb.DeleteItemOutput{}, nil
+				},
+			},
+		}
+		err := ss.BulkSet([]state.SetRequest{{Key: "key1", Value: "value"}, {Key: "key2", Value: "value", ETag: "1"}})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, puts)
+
+		err = ss.BulkDelete([]state.DeleteRequest{{Key: "key1"}, {Key: "key2", ETag: "1"}})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, deletes)
+	})
+}
+
+func TestTTL(t *testing.T) {
+	t.Run("Set with TTL sets the expiry attribute", func(t *testing.T) {
+		ss := StateStore{
+			ttlAttributeName: "expiresAt",
+			client: &mockedDynamoDB{
+				PutItemFn: func(input *dynamodb.PutItemInput) (output *dynamodb.PutItemOutput, err error) {
+					expiry, err := strconv.ParseInt(*input.Item["expiresAt"].N, 10, 64)
+					assert.Nil(t, err)
+					assert.InDelta(t, time.Now().Unix()+100, expiry, 5)
+					return &dynamodb.PutItemOutput{}, nil
+				},
+			},
+		}
+		err := ss.Set(&state.SetRequest{Key: "key", Value: "value", Metadata: map[string]string{"ttlInSeconds": "100"}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Set with TTL requires the TTL attribute name", func(t *testing.T) {
+		ss := StateStore{}
+		err := ss.Set(&state.SetRequest{Key: "key", Value: "value", Metadata: map[string]string{"ttlInSeconds": "100"}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Set with invalid TTL", func(t *testing.T) {
+		ss := StateStore{ttlAttributeName: "expiresAt"}
+		err := ss.Set(&state.SetRequest{Key: "key", Value: "value", Metadata: map[string]string{"ttlInSeconds": "soon"}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Get does not return expired items", func(t *testing.T) {
+		ss := StateStore{
+			ttlAttributeName: "expiresAt",
+			client: &mockedDynamoDB{
+				GetItemFn: func(input *dynamodb.GetItemInput) (output *dynamodb.GetItemOutput, err error) {
+					return &dynamodb.GetItemOutput{
+						Item: map[string]*dynamodb.AttributeValue{
+							"key":       {S: aws.String("key")},
+							"value":     {S: aws.String("value")},
+							"expiresAt": {N: aws.String(strconv.FormatInt(time.Now().Unix()-1, 10))},
+						},
+					}, nil
+				},
+			},
+		}
+		out, err := ss.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Nil(t, out.Data)
+	})
+}
+
+func TestBatchWrite(t *testing.T) {
+	t.Run("Writes are split into batches", func(t *testing.T) {
+		var sizes []int
+		ss := StateStore{
+			table: "table_name",
+			client: &mockedDynamoDB{
+				BatchWriteItemFn: func(input *dynamodb.BatchWriteItemInput) (output *dynamodb.BatchWriteItemOutput, err error) {
+					sizes = append(sizes, len(input.RequestItems["table_name"]))
+					return &dynamodb.BatchWriteItemOutput{}, nil
+				},
+			},
+		}
+		req := make([]state.DeleteRequest, 60)
+		for i := range req {
+			req[i].Key = fmt.Sprintf("key%d", i)
+		}
+		err := ss.BulkDelete(req)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{25, 25, 10}, sizes)
+	})
+
+	t.Run("Unprocessed items are retried", func(t *testing.T) {
+		calls := 0
+		ss := StateStore{
+			table: "table_name",
+			client: &mockedDynamoDB{
+				BatchWriteItemFn: func(input *dynamodb.BatchWriteItemInput) (output *dynamodb.BatchWriteItemOutput, err error) {
+					calls++
+					if calls == 1 {
+						return &dynamodb.BatchWriteItemOutput{
+							UnprocessedItems: map[string][]*dynamodb.WriteRequest{
+								"table_name": input.RequestItems["table_name"][1:],
+							},
+						}, nil
+					}
+					assert.Len(t, input.RequestItems["table_name"], 1)
+					return &dynamodb.BatchWriteItemOutput{}, nil
+				},
+			},
+		}
+		err := ss.BulkDelete([]state.DeleteRequest{{Key: "key1"}, {Key: "key2"}})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, calls)
+	})
+}
@@ -0,0 +1,364 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package s3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	aws_auth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/dapr/components-contrib/metadata"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	jsoniterator "github.com/json-iterator/go"
+)
+
+const (
+	sseAES256 = "AES256"
+	sseKMS    = "aws:kms"
+
+	// maxDeleteBatchSize is the largest number of objects DeleteObjects accepts in a single call
+	maxDeleteBatchSize = 1000
+)
+
+// StateStore is a state store that keeps each key in an S3 object
+type StateStore struct {
+	client      s3iface.S3API
+	metadata    *s3Metadata
+	keyPrefixer state.KeyPrefixer
+	logger      logger.Logger
+}
+
+type s3Metadata struct {
+	Region               string `json:"region"`
+	Endpoint             string `json:"endpoint"`
+	AccessKey            string `json:"accessKey"`
+	SecretKey            string `json:"secretKey"`
+	SessionToken         string `json:"sessionToken"`
+	RoleARN              string `json:"roleArn"`
+	Bucket               string `json:"bucket" metadata:",required"`
+	Prefix               string `json:"prefix"`
+	ServerSideEncryption string `json:"serverSideEncryption"`
+	SSEKMSKeyID          string `json:"sseKmsKeyId"`
+	ForcePathStyle       bool   `json:"forcePathStyle,string"`
+}
+
+// NewS3StateStore returns a new S3 state store
+func NewS3StateStore(logger logger.Logger) *StateStore {
+	return &StateStore{logger: logger}
+}
+
+// Init does metadata and connection parsing
+func (s *StateStore) Init(metadata state.Metadata) error {
+	meta, err := getS3Metadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient(meta)
+	if err != nil {
+		return err
+	}
+
+	keyPrefixer, err := state.NewKeyPrefixer(metadata)
+	if err != nil {
+		return err
+	}
+
+	s.client = client
+	s.metadata = meta
+	s.keyPrefixer = keyPrefixer
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the S3 state store
+func (s *StateStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields:       append(metadata.FieldsOf(s3Metadata{}), metadata.Field{Name: state.KeyPrefix, Type: metadata.TypeString}),
+		Capabilities: []metadata.Capability{metadata.CapabilityETag},
+	}
+}
+
+// Get retrieves the object of a key. S3 reads are strongly consistent.
+func (s *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	output, err := s.client.GetObject(&awss3.GetObjectInput{
+		Bucket: aws.String(s.metadata.Bucket),
+		Key:    aws.String(s.objectKey(req.Key)),
+	})
+	if err != nil {
+		if hasStatusCode(err, http.StatusNotFound) {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	data, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state.GetResponse{
+		Data: data,
+		ETag: unquoteETag(aws.StringValue(output.ETag)),
+	}, nil
+}
+
+// Set saves the value of a key to its object
+func (s *StateStore) Set(req *state.SetRequest) error {
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	return state.SetWithRetries(s.setValue, req)
+}
+
+// setValue writes the object of a key. S3 does not support conditional writes, so the etag
+// or absence of the object is checked with a HEAD request before the object is written.
+// A concurrent write between the two requests is not detected.
+func (s *StateStore) setValue(req *state.SetRequest) error {
+	value, err := marshal(req.Value)
+	if err != nil {
+		return fmt.Errorf("s3 error: failed to set key %s: %s", req.Key, err)
+	}
+
+	switch {
+	case req.ETag != "" && req.Options.Concurrency != state.LastWrite:
+		err = s.checkETag(req.Key, req.ETag)
+	case req.Options.Concurrency == state.FirstWrite:
+		err = s.checkNotExists(req.Key)
+	}
+	if err != nil {
+		return err
+	}
+
+	input := &awss3.PutObjectInput{
+		Bucket: aws.String(s.metadata.Bucket),
+		Key:    aws.String(s.objectKey(req.Key)),
+		Body:   bytes.NewReader(value),
+	}
+	if s.metadata.ServerSideEncryption != "" {
+		input.ServerSideEncryption = aws.String(s.metadata.ServerSideEncryption)
+	}
+	if s.metadata.SSEKMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(s.metadata.SSEKMSKeyID)
+	}
+
+	_, err = s.client.PutObject(input)
+	return err
+}
+
+// BulkSet performs a bulk set operation
+func (s *StateStore) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		err := s.Set(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the object of a key
+func (s *StateStore) Delete(req *state.DeleteRequest) error {
+	err := state.CheckDeleteRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	return state.DeleteWithRetries(s.deleteValue, req)
+}
+
+func (s *StateStore) deleteValue(req *state.DeleteRequest) error {
+	if req.ETag != "" && req.Options.Concurrency != state.LastWrite {
+		err := s.checkETag(req.Key, req.ETag)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := s.client.DeleteObject(&awss3.DeleteObjectInput{
+		Bucket: aws.String(s.metadata.Bucket),
+		Key:    aws.String(s.objectKey(req.Key)),
+	})
+	return err
+}
+
+// BulkDelete performs a bulk delete operation
+func (s *StateStore) BulkDelete(req []state.DeleteRequest) error {
+	// DeleteObjects does not support conditions, so concurrency checked deletes are made one by one
+	for i := range req {
+		if req[i].ETag != "" {
+			return s.deleteEach(req)
+		}
+	}
+
+	for start := 0; start < len(req); start += maxDeleteBatchSize {
+		end := start + maxDeleteBatchSize
+		if end > len(req) {
+			end = len(req)
+		}
+
+		objects := make([]*awss3.ObjectIdentifier, 0, end-start)
+		for _, r := range req[start:end] {
+			objects = append(objects, &awss3.ObjectIdentifier{Key: aws.String(s.objectKey(r.Key))})
+		}
+
+		output, err := s.client.DeleteObjects(&awss3.DeleteObjectsInput{
+			Bucket: aws.String(s.metadata.Bucket),
+			Delete: &awss3.Delete{
+				Objects: objects,
+				Quiet:   aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(output.Errors) > 0 {
+			first := output.Errors[0]
+			return fmt.Errorf("s3 error: failed to delete %d keys, first error for key %s: %s",
+				len(output.Errors), aws.StringValue(first.Key), aws.StringValue(first.Message))
+		}
+	}
+
+	return nil
+}
+
+func (s *StateStore) deleteEach(req []state.DeleteRequest) error {
+	for i := range req {
+		err := s.Delete(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkETag returns an error if the object of a key does not exist or has a different etag
+func (s *StateStore) checkETag(key string, etag string) error {
+	_, err := s.client.HeadObject(&awss3.HeadObjectInput{
+		Bucket:  aws.String(s.metadata.Bucket),
+		Key:     aws.String(s.objectKey(key)),
+		IfMatch: aws.String(quoteETag(etag)),
+	})
+	if hasStatusCode(err, http.StatusPreconditionFailed) || hasStatusCode(err, http.StatusNotFound) {
+		return fmt.Errorf("s3 error: failed to write key %s: possible etag mismatch", key)
+	}
+
+	return err
+}
+
+// checkNotExists returns an error if the object of a key exists
+func (s *StateStore) checkNotExists(key string) error {
+	_, err := s.client.HeadObject(&awss3.HeadObjectInput{
+		Bucket: aws.String(s.metadata.Bucket),
+		Key:    aws.String(s.objectKey(key)),
+	})
+	if err == nil {
+		return fmt.Errorf("s3 error: failed to write key %s: possible etag mismatch", key)
+	}
+	if hasStatusCode(err, http.StatusNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// objectKey returns the key of the object of a state key, which is prefixed with the keyPrefix strategy and
+// namespaced by the configured prefix
+func (s *StateStore) objectKey(key string) string {
+	return s.metadata.Prefix + s.keyPrefixer.PrefixKey(key)
+}
+
+func getS3Metadata(metadata state.Metadata) (*s3Metadata, error) {
+	b, err := json.Marshal(metadata.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta s3Metadata
+	err = json.Unmarshal(b, &meta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an access key, credentials are resolved from the environment, a profile, or the instance role
+	if (meta.AccessKey == "") != (meta.SecretKey == "") || (meta.SessionToken != "" && meta.AccessKey == "") {
+		return nil, fmt.Errorf("missing aws credentials in metadata")
+	}
+
+	if meta.Bucket == "" {
+		return nil, fmt.Errorf("missing s3 bucket name in metadata")
+	}
+
+	switch meta.ServerSideEncryption {
+	case "", sseAES256:
+		if meta.SSEKMSKeyID != "" {
+			return nil, fmt.Errorf("sseKmsKeyId requires serverSideEncryption to be %s", sseKMS)
+		}
+	case sseKMS:
+	default:
+		return nil, fmt.Errorf("invalid serverSideEncryption '%s', must be %s or %s", meta.ServerSideEncryption, sseAES256, sseKMS)
+	}
+
+	return &meta, nil
+}
+
+func getClient(metadata *s3Metadata) (*awss3.S3, error) {
+	sess, err := aws_auth.GetClientWithOptions(aws_auth.ClientOptions{
+		AccessKey:    metadata.AccessKey,
+		SecretKey:    metadata.SecretKey,
+		SessionToken: metadata.SessionToken,
+		RoleARN:      metadata.RoleARN,
+		Region:       metadata.Region,
+		Endpoint:     metadata.Endpoint,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Path style addressing is needed by S3 compatible stores that do not support bucket subdomains
+	c := awss3.New(sess, aws.NewConfig().WithS3ForcePathStyle(metadata.ForcePathStyle))
+	return c, nil
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	if buf, ok := v.([]byte); ok {
+		return buf, nil
+	}
+
+	return jsoniterator.ConfigFastest.Marshal(v)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return reqErr.StatusCode() == statusCode
+	}
+
+	return false
+}
+
+// S3 returns etags in quotes, which are removed so etags can be passed around without escaping
+func unquoteETag(etag string) string {
+	return strings.Trim(etag, `"`)
+}
+
+func quoteETag(etag string) string {
+	return `"` + unquoteETag(etag) + `"`
+}
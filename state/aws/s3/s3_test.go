@@ -0,0 +1,298 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package s3
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedS3 struct {
+	GetObjectFn     func(input *awss3.GetObjectInput) (*awss3.GetObjectOutput, error)
+	HeadObjectFn    func(input *awss3.HeadObjectInput) (*awss3.HeadObjectOutput, error)
+	PutObjectFn     func(input *awss3.PutObjectInput) (*awss3.PutObjectOutput, error)
+	DeleteObjectFn  func(input *awss3.DeleteObjectInput) (*awss3.DeleteObjectOutput, error)
+	DeleteObjectsFn func(input *awss3.DeleteObjectsInput) (*awss3.DeleteObjectsOutput, error)
+	s3iface.S3API
+}
+
+func (m *mockedS3) GetObject(input *awss3.GetObjectInput) (*awss3.GetObjectOutput, error) {
+	return m.GetObjectFn(input)
+}
+
+func (m *mockedS3) HeadObject(input *awss3.HeadObjectInput) (*awss3.HeadObjectOutput, error) {
+	return m.HeadObjectFn(input)
+}
+
+func (m *mockedS3) PutObject(input *awss3.PutObjectInput) (*awss3.PutObjectOutput, error) {
+	return m.PutObjectFn(input)
+}
+
+func (m *mockedS3) DeleteObject(input *awss3.DeleteObjectInput) (*awss3.DeleteObjectOutput, error) {
+	return m.DeleteObjectFn(input)
+}
+
+func (m *mockedS3) DeleteObjects(input *awss3.DeleteObjectsInput) (*awss3.DeleteObjectsOutput, error) {
+	return m.DeleteObjectsFn(input)
+}
+
+func requestFailure(statusCode int) error {
+	return awserr.NewRequestFailure(awserr.New("Failure", http.StatusText(statusCode), nil), statusCode, "request-id")
+}
+
+func newStateStore(client s3iface.S3API) *StateStore {
+	return &StateStore{
+		client:   client,
+		metadata: &s3Metadata{Bucket: "bucket", Prefix: "app/"},
+		logger:   logger.NewLogger("test"),
+	}
+}
+
+func TestInit(t *testing.T) {
+	s := NewS3StateStore(logger.NewLogger("test"))
+
+	t.Run("Init with valid metadata", func(t *testing.T) {
+		err := s.Init(state.Metadata{Properties: map[string]string{
+			"accessKey":            "a",
+			"secretKey":            "a",
+			"region":               "a",
+			"bucket":               "a",
+			"prefix":               "app/",
+			"serverSideEncryption": "aws:kms",
+			"sseKmsKeyId":          "key",
+			"forcePathStyle":       "true",
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "app/", s.metadata.Prefix)
+		assert.True(t, s.metadata.ForcePathStyle)
+	})
+
+	t.Run("Init without bucket", func(t *testing.T) {
+		err := s.Init(state.Metadata{Properties: map[string]string{"region": "a"}})
+		assert.Equal(t, fmt.Errorf("missing s3 bucket name in metadata"), err)
+	})
+
+	t.Run("Init with an access key and no secret key", func(t *testing.T) {
+		err := s.Init(state.Metadata{Properties: map[string]string{"accessKey": "a", "bucket": "a"}})
+		assert.Equal(t, fmt.Errorf("missing aws credentials in metadata"), err)
+	})
+
+	t.Run("Init with invalid server side encryption", func(t *testing.T) {
+		err := s.Init(state.Metadata{Properties: map[string]string{"bucket": "a", "serverSideEncryption": "aws:other"}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Init with a kms key and AES256 encryption", func(t *testing.T) {
+		err := s.Init(state.Metadata{Properties: map[string]string{"bucket": "a", "serverSideEncryption": "AES256", "sseKmsKeyId": "key"}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("Successfully retrieve object", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			GetObjectFn: func(input *awss3.GetObjectInput) (*awss3.GetObjectOutput, error) {
+				assert.Equal(t, "bucket", *input.Bucket)
+				assert.Equal(t, "app/key", *input.Key)
+				return &awss3.GetObjectOutput{
+					Body: ioutil.NopCloser(strings.NewReader("value")),
+					ETag: aws.String(`"abc"`),
+				}, nil
+			},
+		})
+		out, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), out.Data)
+		assert.Equal(t, "abc", out.ETag)
+	})
+
+	t.Run("Retrieve object of the key prefix strategy", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			GetObjectFn: func(input *awss3.GetObjectInput) (*awss3.GetObjectOutput, error) {
+				assert.Equal(t, "app/store||key", *input.Key)
+				return &awss3.GetObjectOutput{Body: ioutil.NopCloser(strings.NewReader("value"))}, nil
+			},
+		})
+		var err error
+		s.keyPrefixer, err = state.NewKeyPrefixer(state.Metadata{Properties: map[string]string{
+			state.AppIDProperty:     "myapp",
+			state.KeyPrefix:         state.KeyPrefixStoreName,
+			state.StoreNameProperty: "store",
+		}})
+		assert.Nil(t, err)
+
+		out, err := s.Get(&state.GetRequest{Key: "myapp||key"})
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), out.Data)
+	})
+
+	t.Run("Object does not exist", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			GetObjectFn: func(input *awss3.GetObjectInput) (*awss3.GetObjectOutput, error) {
+				return nil, requestFailure(http.StatusNotFound)
+			},
+		})
+		out, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Nil(t, out.Data)
+	})
+
+	t.Run("Unsuccessfully get object", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			GetObjectFn: func(input *awss3.GetObjectInput) (*awss3.GetObjectOutput, error) {
+				return nil, requestFailure(http.StatusForbidden)
+			},
+		})
+		out, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.NotNil(t, err)
+		assert.Nil(t, out)
+	})
+}
+
+func TestSet(t *testing.T) {
+	type value struct {
+		Value string
+	}
+
+	t.Run("Successfully set object with encryption", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			PutObjectFn: func(input *awss3.PutObjectInput) (*awss3.PutObjectOutput, error) {
+				assert.Equal(t, "app/key", *input.Key)
+				assert.Equal(t, sseKMS, *input.ServerSideEncryption)
+				assert.Equal(t, "kms-key", *input.SSEKMSKeyId)
+				body, _ := ioutil.ReadAll(input.Body)
+				assert.Equal(t, `{"Value":"value"}`, string(body))
+				return &awss3.PutObjectOutput{}, nil
+			},
+		})
+		s.metadata.ServerSideEncryption = sseKMS
+		s.metadata.SSEKMSKeyID = "kms-key"
+		err := s.Set(&state.SetRequest{Key: "key", Value: value{Value: "value"}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Set with matching etag", func(t *testing.T) {
+		put := false
+		s := newStateStore(&mockedS3{
+			HeadObjectFn: func(input *awss3.HeadObjectInput) (*awss3.HeadObjectOutput, error) {
+				assert.Equal(t, `"abc"`, *input.IfMatch)
+				return &awss3.HeadObjectOutput{}, nil
+			},
+			PutObjectFn: func(input *awss3.PutObjectInput) (*awss3.PutObjectOutput, error) {
+				put = true
+				return &awss3.PutObjectOutput{}, nil
+			},
+		})
+		err := s.Set(&state.SetRequest{Key: "key", Value: []byte("value"), ETag: "abc"})
+		assert.Nil(t, err)
+		assert.True(t, put)
+	})
+
+	t.Run("Set with mismatched etag", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			HeadObjectFn: func(input *awss3.HeadObjectInput) (*awss3.HeadObjectOutput, error) {
+				return nil, requestFailure(http.StatusPreconditionFailed)
+			},
+		})
+		err := s.Set(&state.SetRequest{Key: "key", Value: []byte("value"), ETag: "abc"})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "possible etag mismatch")
+	})
+
+	t.Run("First write of an existing key", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			HeadObjectFn: func(input *awss3.HeadObjectInput) (*awss3.HeadObjectOutput, error) {
+				return &awss3.HeadObjectOutput{}, nil
+			},
+		})
+		err := s.Set(&state.SetRequest{Key: "key", Value: []byte("value"), Options: state.SetStateOption{Concurrency: state.FirstWrite}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("First write of a new key", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			HeadObjectFn: func(input *awss3.HeadObjectInput) (*awss3.HeadObjectOutput, error) {
+				return nil, requestFailure(http.StatusNotFound)
+			},
+			PutObjectFn: func(input *awss3.PutObjectInput) (*awss3.PutObjectOutput, error) {
+				return &awss3.PutObjectOutput{}, nil
+			},
+		})
+		err := s.Set(&state.SetRequest{Key: "key", Value: []byte("value"), Options: state.SetStateOption{Concurrency: state.FirstWrite}})
+		assert.Nil(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("Successfully delete object", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			DeleteObjectFn: func(input *awss3.DeleteObjectInput) (*awss3.DeleteObjectOutput, error) {
+				assert.Equal(t, "app/key", *input.Key)
+				return &awss3.DeleteObjectOutput{}, nil
+			},
+		})
+		err := s.Delete(&state.DeleteRequest{Key: "key"})
+		assert.Nil(t, err)
+	})
+
+	t.Run("Delete with mismatched etag", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			HeadObjectFn: func(input *awss3.HeadObjectInput) (*awss3.HeadObjectOutput, error) {
+				return nil, requestFailure(http.StatusPreconditionFailed)
+			},
+		})
+		err := s.Delete(&state.DeleteRequest{Key: "key", ETag: "abc"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestBulkDelete(t *testing.T) {
+	t.Run("Deletes in batches", func(t *testing.T) {
+		var batchSizes []int
+		s := newStateStore(&mockedS3{
+			DeleteObjectsFn: func(input *awss3.DeleteObjectsInput) (*awss3.DeleteObjectsOutput, error) {
+				batchSizes = append(batchSizes, len(input.Delete.Objects))
+				return &awss3.DeleteObjectsOutput{}, nil
+			},
+		})
+		req := make([]state.DeleteRequest, maxDeleteBatchSize+1)
+		for i := range req {
+			req[i] = state.DeleteRequest{Key: fmt.Sprintf("key%d", i)}
+		}
+		err := s.BulkDelete(req)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{maxDeleteBatchSize, 1}, batchSizes)
+	})
+
+	t.Run("Returns errors of objects", func(t *testing.T) {
+		s := newStateStore(&mockedS3{
+			DeleteObjectsFn: func(input *awss3.DeleteObjectsInput) (*awss3.DeleteObjectsOutput, error) {
+				return &awss3.DeleteObjectsOutput{
+					Errors: []*awss3.Error{{Key: aws.String("app/key"), Message: aws.String("Access Denied")}},
+				}, nil
+			},
+		})
+		err := s.BulkDelete([]state.DeleteRequest{{Key: "key"}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestETagQuoting(t *testing.T) {
+	assert.Equal(t, "abc", unquoteETag(`"abc"`))
+	assert.Equal(t, `"abc"`, quoteETag("abc"))
+	assert.Equal(t, `"abc"`, quoteETag(`"abc"`))
+}
@@ -0,0 +1,127 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cosmosdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/a8m/documentdb"
+)
+
+const (
+	// Transactional batches require a newer version of the REST API than the documentdb client uses
+	batchAPIVersion        = "2018-12-31"
+	headerIsBatchRequest   = "x-ms-cosmos-is-batch-request"
+	headerBatchAtomic      = "x-ms-cosmos-batch-atomic"
+	maxBatchOperations     = 100
+	batchOperationUpsert   = "Upsert"
+	batchOperationDelete   = "Delete"
+	statusFailedDependency = 424
+)
+
+// batchOperation is an operation of a transactional batch
+type batchOperation struct {
+	OperationType string      `json:"operationType"`
+	ID            string      `json:"id"`
+	ResourceBody  interface{} `json:"resourceBody,omitempty"`
+	IfMatch       string      `json:"ifMatch,omitempty"`
+}
+
+// batchResult is the result of a batchOperation
+type batchResult struct {
+	StatusCode int `json:"statusCode"`
+}
+
+// executeBatch runs operations atomically in the partition, and returns the result of each operation and whether
+// all of them succeeded. In a batch that failed the operations that did not fail themselves have the status 424.
+func (c *StateStore) executeBatch(partitionKey string, operations []batchOperation) ([]batchResult, bool, error) {
+	body, err := json.Marshal(operations)
+	if err != nil {
+		return nil, false, err
+	}
+
+	link := c.collection.Self + "docs/"
+	httpReq, err := http.NewRequest(http.MethodPost, c.url+"/"+link, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+
+	req := documentdb.ResourceRequest(link, httpReq)
+	err = req.DefaultHeaders(c.masterKey)
+	if err != nil {
+		return nil, false, err
+	}
+	err = documentdb.PartitionKey(partitionKey)(req)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set(documentdb.HeaderVersion, batchAPIVersion)
+	req.Header.Set(documentdb.HeaderContentType, "application/json")
+	req.Header.Set(headerIsBatchRequest, "True")
+	req.Header.Set(headerBatchAtomic, "True")
+
+	resp, err := c.httpClient.Do(req.Request)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusMultiStatus {
+		var reqErr documentdb.RequestError
+		_ = json.Unmarshal(respBody, &reqErr)
+		return nil, false, fmt.Errorf("transactional batch failed with status %d: %s", resp.StatusCode, reqErr.Error())
+	}
+
+	var results []batchResult
+	err = json.Unmarshal(respBody, &results)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse transactional batch response: %s", err)
+	}
+
+	return results, resp.StatusCode == http.StatusOK, nil
+}
+
+// batchError returns the error of the operations of a failed batch
+func batchError(operations []batchOperation, results []batchResult) error {
+	for i, result := range results {
+		if i < len(operations) && result.StatusCode != statusFailedDependency && result.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("transactional batch failed: %s of %s failed with status %d", operations[i].OperationType, operations[i].ID, result.StatusCode)
+		}
+	}
+
+	return fmt.Errorf("transactional batch failed")
+}
+
+// withoutMissingDeletes returns the operations without the deletes that failed only because the item does not
+// exist, which Delete treats as a success. It returns false if any other operation failed.
+func withoutMissingDeletes(operations []batchOperation, results []batchResult) ([]batchOperation, bool) {
+	remaining := make([]batchOperation, 0, len(operations))
+	for i, o := range operations {
+		status := statusFailedDependency
+		if i < len(results) {
+			status = results[i].StatusCode
+		}
+
+		switch {
+		case status == http.StatusNotFound && o.OperationType == batchOperationDelete && o.IfMatch == "":
+		case status == statusFailedDependency:
+			remaining = append(remaining, o)
+		default:
+			return nil, false
+		}
+	}
+
+	return remaining, true
+}
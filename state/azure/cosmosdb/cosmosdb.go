@@ -9,8 +9,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	jsoniter "github.com/json-iterator/go"
@@ -20,19 +22,23 @@ import (
 
 // StateStore is a CosmosDB state store
 type StateStore struct {
-	client     *documentdb.DocumentDB
-	collection *documentdb.Collection
-	db         *documentdb.Database
-	sp         *documentdb.Sproc
+	client               *documentdb.DocumentDB
+	collection           *documentdb.Collection
+	db                   *documentdb.Database
+	url                  string
+	masterKey            *documentdb.Key
+	httpClient           *http.Client
+	partitionKeyStrategy string
 
 	logger logger.Logger
 }
 
 type credentials struct {
-	URL        string `json:"url"`
-	MasterKey  string `json:"masterKey"`
-	Database   string `json:"database"`
-	Collection string `json:"collection"`
+	URL                  string `json:"url" metadata:",required"`
+	MasterKey            string `json:"masterKey" metadata:",required"`
+	Database             string `json:"database" metadata:",required"`
+	Collection           string `json:"collection" metadata:",required"`
+	PartitionKeyStrategy string `json:"partitionKeyStrategy"`
 }
 
 // CosmosItem is a wrapper around a CosmosDB document
@@ -43,24 +49,31 @@ type CosmosItem struct {
 	PartitionKey string      `json:"partitionKey"`
 }
 
-type storedProcedureDefinition struct {
-	ID   string `json:"id"`
-	Body string `json:"body"`
-}
-
 const (
-	storedProcedureName  = "__dapr__"
 	metadataPartitionKey = "partitionKey"
 	unknownPartitionKey  = "__UNKNOWN__"
+	// partitionKeyStrategyKey partitions items by their key, so each item has its own partition
+	partitionKeyStrategyKey = "key"
+	// partitionKeyStrategyKeyPrefix partitions items by their key up to the last || separator, so that for example
+	// all the state of an actor, with keys of the form appid||actortype||actorid||key, is in the same partition
+	partitionKeyStrategyKeyPrefix = "keyPrefix"
+	keySeparator                  = "||"
 )
 
 // NewCosmosDBStateStore returns a new CosmosDB state store
 func NewCosmosDBStateStore(logger logger.Logger) *StateStore {
-	return &StateStore{logger: logger}
+	return &StateStore{
+		httpClient: &http.Client{},
+		logger:     logger,
+	}
 }
 
 // Init does metadata and connection parsing
 func (c *StateStore) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	c.logger.Debugf("CosmosDB init start")
 
 	connInfo := metadata.Properties
@@ -75,10 +88,19 @@ func (c *StateStore) Init(metadata state.Metadata) error {
 		return err
 	}
 
+	switch creds.PartitionKeyStrategy {
+	case "":
+		c.partitionKeyStrategy = partitionKeyStrategyKey
+	case partitionKeyStrategyKey, partitionKeyStrategyKeyPrefix:
+		c.partitionKeyStrategy = creds.PartitionKeyStrategy
+	default:
+		return fmt.Errorf("invalid partitionKeyStrategy '%s', supported values are: %s, %s", creds.PartitionKeyStrategy, partitionKeyStrategyKey, partitionKeyStrategyKeyPrefix)
+	}
+
+	c.url = creds.URL
+	c.masterKey = documentdb.NewKey(creds.MasterKey)
 	client := documentdb.New(creds.URL, &documentdb.Config{
-		MasterKey: &documentdb.Key{
-			Key: creds.MasterKey,
-		},
+		MasterKey: c.masterKey,
 	})
 
 	dbs, err := client.QueryDatabases(&documentdb.Query{
@@ -109,40 +131,23 @@ func (c *StateStore) Init(metadata state.Metadata) error {
 	c.collection = &colls[0]
 	c.client = client
 
-	sps, err := c.client.ReadStoredProcedures(c.collection.Self)
-	if err != nil {
-		return err
-	}
-
-	// get a link to the sp
-	for _, proc := range sps {
-		if proc.Id == storedProcedureName {
-			c.sp = &proc
-			break
-		}
-	}
-
-	if c.sp == nil {
-		// register the stored procedure
-		createspBody := storedProcedureDefinition{ID: storedProcedureName, Body: spDefinition}
-		c.sp, err = c.client.CreateStoredProcedure(c.collection.Self, createspBody)
-		if err != nil {
-			// if it already exists that is success
-			if !strings.HasPrefix(err.Error(), "Conflict") {
-				return err
-			}
-		}
-	}
-
 	c.logger.Debug("cosmos Init done")
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the CosmosDB state store
+func (c *StateStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields:       metadata.FieldsOf(credentials{PartitionKeyStrategy: partitionKeyStrategyKey}),
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTransactional},
+	}
+}
+
 // Get retrieves a CosmosDB item
 func (c *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	key := req.Key
 
-	partitionKey := populatePartitionMetadata(req.Key, req.Metadata)
+	partitionKey := c.partitionKey(req.Key, req.Metadata)
 
 	items := []CosmosItem{}
 	options := []documentdb.CallOption{documentdb.PartitionKey(partitionKey)}
@@ -183,7 +188,7 @@ func (c *StateStore) Set(req *state.SetRequest) error {
 		return err
 	}
 
-	partitionKey := populatePartitionMetadata(req.Key, req.Metadata)
+	partitionKey := c.partitionKey(req.Key, req.Metadata)
 	options := []documentdb.CallOption{documentdb.PartitionKey(partitionKey)}
 
 	if req.ETag != "" {
@@ -224,7 +229,7 @@ func (c *StateStore) Delete(req *state.DeleteRequest) error {
 		return err
 	}
 
-	partitionKey := populatePartitionMetadata(req.Key, req.Metadata)
+	partitionKey := c.partitionKey(req.Key, req.Metadata)
 	options := []documentdb.CallOption{documentdb.PartitionKey(partitionKey)}
 
 	items := []CosmosItem{}
@@ -269,10 +274,10 @@ func (c *StateStore) BulkDelete(req []state.DeleteRequest) error {
 	return nil
 }
 
-// Multi performs a transactional operation. succeeds only if all operations succeed, and fails if one or more operations fail
+// Multi performs a transactional operation. succeeds only if all operations succeed, and fails if one or more operations fail.
+// The operations run as a transactional batch, so they must all be in the same partition and there can be at most 100 of them.
 func (c *StateStore) Multi(operations []state.TransactionalRequest) error {
-	upserts := []CosmosItem{}
-	deletes := []CosmosItem{}
+	batch := make([]batchOperation, 0, len(operations))
 
 	partitionKey := unknownPartitionKey
 	previousPartitionKey := unknownPartitionKey
@@ -282,7 +287,7 @@ func (c *StateStore) Multi(operations []state.TransactionalRequest) error {
 		key := t.GetKey()
 		metadata := t.GetMetadata()
 
-		partitionKey = populatePartitionMetadata(key, metadata)
+		partitionKey = c.partitionKey(key, metadata)
 		if previousPartitionKey != unknownPartitionKey &&
 			partitionKey != previousPartitionKey {
 			return errors.New("all objects used in Multi() must have the same partition key")
@@ -292,43 +297,70 @@ func (c *StateStore) Multi(operations []state.TransactionalRequest) error {
 		if o.Operation == state.Upsert {
 			req := o.Request.(state.SetRequest)
 
-			upsertOperation := CosmosItem{
-				ID:           req.Key,
-				Value:        req.Value,
-				PartitionKey: partitionKey}
-
-			upserts = append(upserts, upsertOperation)
+			batch = append(batch, batchOperation{
+				OperationType: batchOperationUpsert,
+				ID:            req.Key,
+				ResourceBody: CosmosItem{
+					ID:           req.Key,
+					Value:        req.Value,
+					PartitionKey: partitionKey,
+				},
+				IfMatch: req.ETag,
+			})
 		} else if o.Operation == state.Delete {
 			req := o.Request.(state.DeleteRequest)
 
-			deleteOperation := CosmosItem{
-				ID:           req.Key,
-				Value:        "", // Value does not need to be specified
-				PartitionKey: partitionKey}
-			deletes = append(deletes, deleteOperation)
+			batch = append(batch, batchOperation{
+				OperationType: batchOperationDelete,
+				ID:            req.Key,
+				IfMatch:       req.ETag,
+			})
 		}
 	}
 
-	c.logger.Debugf("#upserts=%d,#deletes=%d, partitionkey=%s", len(upserts), len(deletes), partitionKey)
+	if len(batch) == 0 {
+		return nil
+	}
+	if len(batch) > maxBatchOperations {
+		return fmt.Errorf("at most %d operations are supported in Multi(), got %d", maxBatchOperations, len(batch))
+	}
 
-	options := []documentdb.CallOption{documentdb.PartitionKey(partitionKey)}
-	var retString string
+	c.logger.Debugf("#operations=%d, partitionkey=%s", len(batch), partitionKey)
 
-	// The stored procedure throws if it failed, which sets err to non-nil.  It doesn't return anything else.
-	err := c.client.ExecuteStoredProcedure(c.sp.Self, [...]interface{}{upserts, deletes}, &retString, options...)
-	if err != nil {
-		c.logger.Debugf("error=%e", err)
-		return err
-	}
+	for {
+		results, ok, err := c.executeBatch(partitionKey, batch)
+		if err != nil {
+			c.logger.Debugf("error=%e", err)
+			return err
+		}
+		if ok {
+			return nil
+		}
 
-	return nil
+		// Deleting an item that does not exist fails the whole batch, so the batch is retried without those deletes
+		remaining, retry := withoutMissingDeletes(batch, results)
+		if !retry {
+			return batchError(batch, results)
+		}
+		if len(remaining) == 0 {
+			return nil
+		}
+		batch = remaining
+	}
 }
 
-// This is a helper to return the partition key to use.  If if metadata["partitionkey"] is present,
-// use that, otherwise use what's in "key".
-func populatePartitionMetadata(key string, requestMetadata map[string]string) string {
+// partitionKey returns the partition key to use. If metadata["partitionKey"] is present use that,
+// otherwise derive it from key with the partitionKeyStrategy.
+func (c *StateStore) partitionKey(key string, requestMetadata map[string]string) string {
 	if val, found := requestMetadata[metadataPartitionKey]; found {
 		return val
 	}
+
+	if c.partitionKeyStrategy == partitionKeyStrategyKeyPrefix {
+		if i := strings.LastIndex(key, keySeparator); i > 0 {
+			return key[:i]
+		}
+	}
+
 	return key
 }
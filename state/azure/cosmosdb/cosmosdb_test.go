@@ -0,0 +1,147 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cosmosdb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a8m/documentdb"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionKey(t *testing.T) {
+	store := NewCosmosDBStateStore(logger.NewLogger("test"))
+	store.partitionKeyStrategy = partitionKeyStrategyKey
+	assert.Equal(t, "app||actor||1||key", store.partitionKey("app||actor||1||key", nil))
+	assert.Equal(t, "pk", store.partitionKey("app||actor||1||key", map[string]string{metadataPartitionKey: "pk"}))
+
+	store.partitionKeyStrategy = partitionKeyStrategyKeyPrefix
+	assert.Equal(t, "app||actor||1", store.partitionKey("app||actor||1||key", nil))
+	assert.Equal(t, "key", store.partitionKey("key", nil))
+	assert.Equal(t, "pk", store.partitionKey("app||actor||1||key", map[string]string{metadataPartitionKey: "pk"}))
+}
+
+// batchServer is a fake of the transactional batch API that fails the operations in statuses
+type batchServer struct {
+	statuses map[string]int
+	batches  [][]batchOperation
+	headers  http.Header
+}
+
+func (s *batchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.headers = r.Header
+	body, _ := ioutil.ReadAll(r.Body)
+	var operations []batchOperation
+	_ = json.Unmarshal(body, &operations)
+	s.batches = append(s.batches, operations)
+
+	results := make([]batchResult, len(operations))
+	status := http.StatusOK
+	for i, o := range operations {
+		results[i].StatusCode = http.StatusOK
+		if code, ok := s.statuses[o.ID]; ok {
+			results[i].StatusCode = code
+			status = http.StatusMultiStatus
+		}
+	}
+	if status == http.StatusMultiStatus {
+		for i := range results {
+			if results[i].StatusCode == http.StatusOK {
+				results[i].StatusCode = statusFailedDependency
+			}
+		}
+	}
+
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(results)
+}
+
+func newBatchStore(t *testing.T, server *batchServer) *StateStore {
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	store := NewCosmosDBStateStore(logger.NewLogger("test"))
+	store.url = httpServer.URL
+	store.masterKey = documentdb.NewKey("a2V5")
+	store.collection = &documentdb.Collection{Resource: documentdb.Resource{Self: "dbs/db/colls/coll/"}}
+	store.partitionKeyStrategy = partitionKeyStrategyKeyPrefix
+
+	return store
+}
+
+func TestMulti(t *testing.T) {
+	t.Run("Runs a batch in the partition", func(t *testing.T) {
+		server := &batchServer{}
+		store := newBatchStore(t, server)
+
+		err := store.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||actor||1||a", Value: "x", ETag: "etag"}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "app||actor||1||b"}},
+		})
+		assert.Nil(t, err)
+		assert.Len(t, server.batches, 1)
+		assert.Equal(t, batchOperationUpsert, server.batches[0][0].OperationType)
+		assert.Equal(t, "etag", server.batches[0][0].IfMatch)
+		assert.Equal(t, batchOperationDelete, server.batches[0][1].OperationType)
+		assert.Equal(t, `["app||actor||1"]`, server.headers.Get(documentdb.HeaderPartitionKey))
+		assert.Equal(t, "True", server.headers.Get(headerBatchAtomic))
+		assert.Equal(t, batchAPIVersion, server.headers.Get(documentdb.HeaderVersion))
+	})
+
+	t.Run("Different partitions", func(t *testing.T) {
+		store := newBatchStore(t, &batchServer{})
+		err := store.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||actor||1||a", Value: "x"}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||actor||2||a", Value: "x"}},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Retries without missing deletes", func(t *testing.T) {
+		server := &batchServer{statuses: map[string]int{"app||b": http.StatusNotFound}}
+		store := newBatchStore(t, server)
+
+		err := store.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||a", Value: "x"}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "app||b"}},
+		})
+		assert.Nil(t, err)
+		assert.Len(t, server.batches, 2)
+		assert.Len(t, server.batches[1], 1)
+		assert.Equal(t, "app||a", server.batches[1][0].ID)
+	})
+
+	t.Run("Fails on etag mismatch", func(t *testing.T) {
+		server := &batchServer{statuses: map[string]int{"app||a": http.StatusPreconditionFailed}}
+		store := newBatchStore(t, server)
+
+		err := store.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "app||a", Value: "x", ETag: "old"}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "app||b"}},
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "app||a")
+		assert.Len(t, server.batches, 1)
+	})
+
+	t.Run("Too many operations", func(t *testing.T) {
+		server := &batchServer{}
+		store := newBatchStore(t, server)
+
+		operations := make([]state.TransactionalRequest, maxBatchOperations+1)
+		for i := range operations {
+			operations[i] = state.TransactionalRequest{Operation: state.Delete, Request: state.DeleteRequest{Key: "app||a"}}
+		}
+		assert.NotNil(t, store.Multi(operations))
+		assert.Len(t, server.batches, 0)
+	})
+}
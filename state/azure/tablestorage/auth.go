@@ -0,0 +1,91 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package tablestorage
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+const (
+	// storageResource is the resource that Azure AD tokens for Azure Storage are issued for
+	storageResource = "https://storage.azure.com/"
+	// oauthAPIVersion is the first Table service version that accepts Azure AD tokens
+	oauthAPIVersion = "2020-12-06"
+
+	headerAuthorization = "Authorization"
+	headerAPIVersion    = "x-ms-version"
+)
+
+// newClient returns a client that authenticates with the account key when one is configured,
+// and with an Azure AD token of the managed identity otherwise.
+func newClient(meta *tablesMetadata) (storage.Client, error) {
+	if meta.accountKey != "" {
+		return storage.NewBasicClient(meta.accountName, meta.accountKey)
+	}
+
+	spt, err := newManagedIdentityToken(meta.clientID)
+	if err != nil {
+		return storage.Client{}, err
+	}
+
+	// A SAS client with an empty token sends requests without signing them, leaving the
+	// authorization to the sender
+	client := storage.NewAccountSASClient(meta.accountName, url.Values{}, azure.PublicCloud)
+	client.Sender = &tokenSender{
+		sender: client.Sender,
+		spt:    spt,
+	}
+
+	return client, nil
+}
+
+// newManagedIdentityToken returns a token of the system assigned identity, or of the user assigned identity with clientID.
+func newManagedIdentityToken(clientID string) (*adal.ServicePrincipalToken, error) {
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	var spt *adal.ServicePrincipalToken
+	if clientID == "" {
+		spt, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, storageResource)
+	} else {
+		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, storageResource, clientID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create managed identity token: %s", err)
+	}
+
+	return spt, nil
+}
+
+// tokenSender adds a fresh Azure AD token to the requests of a storage client.
+type tokenSender struct {
+	sender storage.Sender
+	spt    *adal.ServicePrincipalToken
+}
+
+// Send implements storage.Sender
+func (t *tokenSender) Send(c *storage.Client, req *http.Request) (*http.Response, error) {
+	err := t.spt.EnsureFreshWithContext(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh Azure AD token: %s", err)
+	}
+
+	// The storage client sets headers without canonicalizing their names
+	delete(req.Header, headerAuthorization)
+	delete(req.Header, headerAPIVersion)
+	req.Header.Set(headerAuthorization, "Bearer "+t.spt.OAuthToken())
+	req.Header.Set(headerAPIVersion, oauthAPIVersion)
+
+	return t.sender.Send(c, req)
+}
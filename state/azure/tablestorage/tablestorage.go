@@ -22,6 +22,9 @@ Sample configuration in yaml:
 	  - name: tableName
 		value: <table name>
 
+Without an accountKey, the store authenticates with the managed identity of the host. Set azureClientId
+to the client ID of a user assigned identity.
+
 This store uses PartitionKey as service name, and RowKey as the rest of the composite key.
 
 Concurrency is supported with ETags according to https://docs.microsoft.com/en-us/azure/storage/common/storage-concurrency#managing-concurrency-in-table-storage
@@ -31,9 +34,11 @@ package tablestorage
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	jsoniter "github.com/json-iterator/go"
@@ -48,8 +53,14 @@ const (
 	accountNameKey = "accountName"
 	accountKeyKey  = "accountKey"
 	tableNameKey   = "tableName"
+	clientIDKey    = "azureClientId"
+
+	// disallowedKeyCharacters cannot be used in PartitionKey and RowKey values
+	disallowedKeyCharacters = "/\\#?"
 )
 
+var errETagMismatch = errors.New("possible etag mismatch")
+
 type StateStore struct {
 	table *storage.Table
 	json  jsoniter.API
@@ -61,16 +72,24 @@ type tablesMetadata struct {
 	accountName string
 	accountKey  string
 	tableName   string
+	clientID    string
 }
 
 // Initialises connection to table storage, optionally creates a table if it doesn't exist.
 func (r *StateStore) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	meta, err := getTablesMetadata(metadata.Properties)
 	if err != nil {
 		return err
 	}
 
-	client, _ := storage.NewBasicClient(meta.accountName, meta.accountKey)
+	client, err := newClient(meta)
+	if err != nil {
+		return err
+	}
 	tables := client.GetTableService()
 	r.table = tables.GetTableReference(meta.tableName)
 
@@ -91,6 +110,19 @@ func (r *StateStore) Init(metadata state.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Table Storage state store
+func (r *StateStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: accountNameKey, Type: metadata.TypeString, Required: true},
+			{Name: accountKeyKey, Type: metadata.TypeString},
+			{Name: tableNameKey, Type: metadata.TypeString, Required: true},
+			{Name: clientIDKey, Type: metadata.TypeString},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag},
+	}
+}
+
 func (r *StateStore) Delete(req *state.DeleteRequest) error {
 	r.logger.Debugf("delete %s", req.Key)
 	return r.deleteRow(req)
@@ -109,9 +141,12 @@ func (r *StateStore) BulkDelete(req []state.DeleteRequest) error {
 
 func (r *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	r.logger.Debugf("fetching %s", req.Key)
-	pk, rk := getPartitionAndRowKey(req.Key)
+	pk, rk, err := getPartitionAndRowKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
 	entity := r.table.GetEntityReference(pk, rk)
-	err := entity.Get(operationTimeout, storage.FullMetadata, nil)
+	err = entity.Get(operationTimeout, storage.FullMetadata, nil)
 
 	if err != nil {
 		if isNotFoundError(err) {
@@ -130,6 +165,10 @@ func (r *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
 
 func (r *StateStore) Set(req *state.SetRequest) error {
 	r.logger.Debugf("saving %s", req.Key)
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
 
 	return r.writeRow(req)
 }
@@ -163,11 +202,9 @@ func getTablesMetadata(metadata map[string]string) (*tablesMetadata, error) {
 		return nil, errors.New(fmt.Sprintf("missing or empty %s field from metadata", accountNameKey))
 	}
 
-	if val, ok := metadata[accountKeyKey]; ok && val != "" {
-		meta.accountKey = val
-	} else {
-		return nil, errors.New(fmt.Sprintf("missing of empty %s field from metadata", accountKeyKey))
-	}
+	// Without an account key, the managed identity is used
+	meta.accountKey = metadata[accountKeyKey]
+	meta.clientID = metadata[clientIDKey]
 
 	if val, ok := metadata[tableNameKey]; ok && val != "" {
 		meta.tableName = val
@@ -179,24 +216,33 @@ func getTablesMetadata(metadata map[string]string) (*tablesMetadata, error) {
 }
 
 func (r *StateStore) writeRow(req *state.SetRequest) error {
-	pk, rk := getPartitionAndRowKey(req.Key)
+	pk, rk, err := getPartitionAndRowKey(req.Key)
+	if err != nil {
+		return err
+	}
 	entity := r.table.GetEntityReference(pk, rk)
 	entity.Properties = map[string]interface{}{
 		valueEntityProperty: r.marshal(req),
 	}
-	entity.OdataEtag = req.ETag
-
-	// InsertOrReplace does not support ETag concurrency, therefore we will try to use Update method first
-	// as it's more frequent, and then Insert
 
-	err := entity.Update(false, nil)
-	if err != nil {
-		if isNotFoundError(err) {
-			// When entity is not found (set state first time) create it
-			entity.OdataEtag = ""
-			return entity.Insert(storage.FullMetadata, nil)
+	switch {
+	case req.ETag != "":
+		// Update only replaces the entity if its native ETag matches, and fails if the entity does not exist
+		entity.OdataEtag = req.ETag
+		err = entity.Update(false, nil)
+		if isNotFoundError(err) || isPreconditionFailedError(err) {
+			return fmt.Errorf("failed to set key %s: %s", req.Key, errETagMismatch)
+		}
+	case req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so the entity may only be inserted
+		err = entity.Insert(storage.EmptyPayload, nil)
+		if isEntityAlreadyExistsError(err) {
+			return fmt.Errorf("failed to set key %s: %s", req.Key, errETagMismatch)
 		}
+	default:
+		err = entity.InsertOrReplace(nil)
 	}
+
 	return err
 }
 
@@ -205,24 +251,51 @@ func isNotFoundError(err error) bool {
 	return ok && azureError.Code == "ResourceNotFound"
 }
 
+func isPreconditionFailedError(err error) bool {
+	azureError, ok := err.(storage.AzureStorageServiceError)
+	return ok && azureError.StatusCode == http.StatusPreconditionFailed
+}
+
+func isEntityAlreadyExistsError(err error) bool {
+	azureError, ok := err.(storage.AzureStorageServiceError)
+	return ok && azureError.Code == "EntityAlreadyExists"
+}
+
 func isTableAlreadyExistsError(err error) bool {
 	azureError, ok := err.(storage.AzureStorageServiceError)
 	return ok && azureError.Code == "TableAlreadyExists"
 }
 
 func (r *StateStore) deleteRow(req *state.DeleteRequest) error {
-	pk, rk := getPartitionAndRowKey(req.Key)
+	pk, rk, err := getPartitionAndRowKey(req.Key)
+	if err != nil {
+		return err
+	}
 	entity := r.table.GetEntityReference(pk, rk)
 	entity.OdataEtag = req.ETag
-	return entity.Delete(true, nil)
+
+	// Without an ETag the delete is forced, and deleting a missing entity succeeds
+	err = entity.Delete(req.ETag == "", nil)
+	if req.ETag == "" && isNotFoundError(err) {
+		return nil
+	}
+	if isNotFoundError(err) || isPreconditionFailedError(err) {
+		return fmt.Errorf("failed to delete key %s: %s", req.Key, errETagMismatch)
+	}
+	return err
 }
 
-func getPartitionAndRowKey(key string) (string, string) {
-	pr := strings.Split(key, keyDelimiter)
+// getPartitionAndRowKey splits the key at the first delimiter, so the row key may contain further delimiters.
+func getPartitionAndRowKey(key string) (string, string, error) {
+	if strings.ContainsAny(key, disallowedKeyCharacters) {
+		return "", "", errors.New(fmt.Sprintf("key %s contains a character that is not allowed in table storage keys: %s", key, disallowedKeyCharacters))
+	}
+
+	pr := strings.SplitN(key, keyDelimiter, 2)
 	if len(pr) != 2 {
-		return pr[0], ""
+		return pr[0], "", nil
 	}
-	return pr[0], pr[1]
+	return pr[0], pr[1], nil
 }
 
 func (r *StateStore) marshal(req *state.SetRequest) string {
@@ -6,8 +6,14 @@
 package tablestorage
 
 import (
+	"encoding/json"
+	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,18 +37,87 @@ func TestGetTableStorageMetadata(t *testing.T) {
 		assert.Equal(t, "key", meta.accountKey)
 		assert.Equal(t, "dapr", meta.tableName)
 	})
+
+	t.Run("Managed identity without account key", func(t *testing.T) {
+		m := make(map[string]string)
+		m["accountName"] = "acc"
+		m["tableName"] = "dapr"
+		m["azureClientId"] = "client"
+		meta, err := getTablesMetadata(m)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "", meta.accountKey)
+		assert.Equal(t, "client", meta.clientID)
+	})
+
+	t.Run("Missing table name", func(t *testing.T) {
+		m := make(map[string]string)
+		m["accountName"] = "acc"
+		m["accountKey"] = "key"
+		_, err := getTablesMetadata(m)
+
+		assert.NotNil(t, err)
+	})
 }
 
 func TestPartitionAndRowKey(t *testing.T) {
 	t.Run("Valid composite key", func(t *testing.T) {
-		pk, rk := getPartitionAndRowKey("pk||rk")
+		pk, rk, err := getPartitionAndRowKey("pk||rk")
+		assert.Nil(t, err)
 		assert.Equal(t, "pk", pk)
 		assert.Equal(t, "rk", rk)
 	})
 
 	t.Run("No delimiter present", func(t *testing.T) {
-		pk, rk := getPartitionAndRowKey("pk_rk")
+		pk, rk, err := getPartitionAndRowKey("pk_rk")
+		assert.Nil(t, err)
 		assert.Equal(t, "pk_rk", pk)
 		assert.Equal(t, "", rk)
 	})
+
+	t.Run("Row key keeps further delimiters", func(t *testing.T) {
+		pk, rk, err := getPartitionAndRowKey("pk||rk||suffix")
+		assert.Nil(t, err)
+		assert.Equal(t, "pk", pk)
+		assert.Equal(t, "rk||suffix", rk)
+	})
+
+	t.Run("Disallowed characters", func(t *testing.T) {
+		_, _, err := getPartitionAndRowKey("pk||a/b")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestTokenSender(t *testing.T) {
+	oauthConfig, err := adal.NewOAuthConfig("https://login.microsoftonline.com/", "tenant")
+	assert.Nil(t, err)
+	spt, err := adal.NewServicePrincipalTokenFromManualToken(*oauthConfig, "client", storageResource, adal.Token{
+		AccessToken: "token",
+		ExpiresOn:   json.Number(strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)),
+	})
+	assert.Nil(t, err)
+
+	var sent *http.Request
+	sender := &tokenSender{
+		sender: senderFunc(func(c *storage.Client, req *http.Request) (*http.Response, error) {
+			sent = req
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}),
+		spt: spt,
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://acc.table.core.windows.net/dapr", nil)
+	req.Header[headerAPIVersion] = []string{""}
+	_, err = sender.Send(&storage.Client{}, req)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer token", sent.Header.Get(headerAuthorization))
+	assert.Equal(t, []string{oauthAPIVersion}, sent.Header.Values(headerAPIVersion))
+	_, ok := sent.Header[headerAPIVersion]
+	assert.False(t, ok)
+}
+
+type senderFunc func(c *storage.Client, req *http.Request) (*http.Response, error)
+
+func (f senderFunc) Send(c *storage.Client, req *http.Request) (*http.Response, error) {
+	return f(c, req)
 }
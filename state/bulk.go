@@ -0,0 +1,142 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultBulkParallelism is the number of operations a DefaultBulkStore runs at once when no parallelism is given
+const DefaultBulkParallelism = 10
+
+// BulkStore is an interface for state stores that can get multiple keys at once
+type BulkStore interface {
+	BulkGet(req []GetRequest) ([]BulkGetResponse, error)
+}
+
+// BulkItemError is the error of a single key of a bulk operation
+type BulkItemError struct {
+	Key string
+	Err error
+}
+
+// BulkError is returned by bulk operations when one or more keys failed.
+// Errors are in the order of the requests.
+type BulkError struct {
+	Errors []BulkItemError
+}
+
+func (e *BulkError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, item := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", item.Key, item.Err))
+	}
+
+	return fmt.Sprintf("%d bulk operations failed: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// DefaultBulkStore implements BulkGet, BulkSet, and BulkDelete for stores without native batching
+// by running the single key operations of the store in parallel
+type DefaultBulkStore struct {
+	Store
+	parallelism int
+}
+
+// NewDefaultBulkStore returns a DefaultBulkStore that runs up to parallelism operations of store at once.
+// DefaultBulkParallelism is used when parallelism is not positive.
+func NewDefaultBulkStore(store Store, parallelism int) *DefaultBulkStore {
+	if parallelism <= 0 {
+		parallelism = DefaultBulkParallelism
+	}
+
+	return &DefaultBulkStore{
+		Store:       store,
+		parallelism: parallelism,
+	}
+}
+
+// BulkGet gets the keys of req. The responses are in the order of the requests,
+// and the error of each key is reported in its response.
+func (s *DefaultBulkStore) BulkGet(req []GetRequest) ([]BulkGetResponse, error) {
+	responses := make([]BulkGetResponse, len(req))
+	s.forEach(len(req), func(i int) error {
+		responses[i].Key = req[i].Key
+		resp, err := s.Store.Get(&req[i])
+		if err != nil {
+			responses[i].Error = err.Error()
+			return nil
+		}
+		if resp != nil {
+			responses[i].Data = resp.Data
+			responses[i].ETag = resp.ETag
+			responses[i].Metadata = resp.Metadata
+		}
+		return nil
+	})
+
+	return responses, nil
+}
+
+// BulkSet sets the keys of req. A *BulkError is returned when any of them fail.
+func (s *DefaultBulkStore) BulkSet(req []SetRequest) error {
+	return s.bulkError(len(req), func(i int) string {
+		return req[i].Key
+	}, func(i int) error {
+		return s.Store.Set(&req[i])
+	})
+}
+
+// BulkDelete deletes the keys of req. A *BulkError is returned when any of them fail.
+func (s *DefaultBulkStore) BulkDelete(req []DeleteRequest) error {
+	return s.bulkError(len(req), func(i int) string {
+		return req[i].Key
+	}, func(i int) error {
+		return s.Store.Delete(&req[i])
+	})
+}
+
+func (s *DefaultBulkStore) bulkError(n int, key func(int) string, op func(int) error) error {
+	errs := s.forEach(n, op)
+
+	var bulkErr *BulkError
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if bulkErr == nil {
+			bulkErr = &BulkError{}
+		}
+		bulkErr.Errors = append(bulkErr.Errors, BulkItemError{Key: key(i), Err: err})
+	}
+
+	if bulkErr == nil {
+		return nil
+	}
+	return bulkErr
+}
+
+// forEach runs op for 0..n-1 with at most s.parallelism running at once, and returns their errors by index.
+func (s *DefaultBulkStore) forEach(n int, op func(int) error) []error {
+	errs := make([]error, n)
+	limit := make(chan struct{}, s.parallelism)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		limit <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			errs[i] = op(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,158 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package state
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore fails the keys that start with "fail" and records the highest number of concurrent calls
+type fakeStore struct {
+	lock    sync.Mutex
+	values  map[string][]byte
+	running int32
+	peak    int32
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{values: map[string][]byte{}}
+}
+
+func (f *fakeStore) call(key string) error {
+	running := atomic.AddInt32(&f.running, 1)
+	defer atomic.AddInt32(&f.running, -1)
+	for {
+		peak := atomic.LoadInt32(&f.peak)
+		if running <= peak || atomic.CompareAndSwapInt32(&f.peak, peak, running) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	if strings.HasPrefix(key, "fail") {
+		return fmt.Errorf("failed %s", key)
+	}
+	return nil
+}
+
+func (f *fakeStore) Init(metadata Metadata) error {
+	return nil
+}
+
+func (f *fakeStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{}
+}
+
+func (f *fakeStore) Get(req *GetRequest) (*GetResponse, error) {
+	err := f.call(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return &GetResponse{Data: f.values[req.Key], ETag: "1"}, nil
+}
+
+func (f *fakeStore) Set(req *SetRequest) error {
+	err := f.call(req.Key)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.values[req.Key] = req.Value.([]byte)
+	return nil
+}
+
+func (f *fakeStore) Delete(req *DeleteRequest) error {
+	err := f.call(req.Key)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.values, req.Key)
+	return nil
+}
+
+func (f *fakeStore) BulkSet(req []SetRequest) error {
+	return fmt.Errorf("not used")
+}
+
+func (f *fakeStore) BulkDelete(req []DeleteRequest) error {
+	return fmt.Errorf("not used")
+}
+
+func TestDefaultBulkStore(t *testing.T) {
+	t.Run("Default parallelism", func(t *testing.T) {
+		s := NewDefaultBulkStore(newFakeStore(), 0)
+		assert.Equal(t, DefaultBulkParallelism, s.parallelism)
+	})
+
+	t.Run("BulkSet and BulkGet", func(t *testing.T) {
+		f := newFakeStore()
+		s := NewDefaultBulkStore(f, 3)
+
+		sets := make([]SetRequest, 20)
+		gets := make([]GetRequest, 20)
+		for i := range sets {
+			key := fmt.Sprintf("key%d", i)
+			sets[i] = SetRequest{Key: key, Value: []byte(key)}
+			gets[i] = GetRequest{Key: key}
+		}
+		assert.Nil(t, s.BulkSet(sets))
+		assert.True(t, atomic.LoadInt32(&f.peak) <= 3)
+
+		responses, err := s.BulkGet(gets)
+		assert.Nil(t, err)
+		assert.Len(t, responses, 20)
+		for i, resp := range responses {
+			assert.Equal(t, gets[i].Key, resp.Key)
+			assert.Equal(t, []byte(gets[i].Key), resp.Data)
+			assert.Equal(t, "", resp.Error)
+		}
+	})
+
+	t.Run("BulkGet reports errors per key", func(t *testing.T) {
+		s := NewDefaultBulkStore(newFakeStore(), 2)
+		responses, err := s.BulkGet([]GetRequest{{Key: "a"}, {Key: "fail1"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "", responses[0].Error)
+		assert.Equal(t, "failed fail1", responses[1].Error)
+	})
+
+	t.Run("BulkDelete returns the failed keys in order", func(t *testing.T) {
+		s := NewDefaultBulkStore(newFakeStore(), 2)
+		err := s.BulkDelete([]DeleteRequest{{Key: "fail2"}, {Key: "a"}, {Key: "fail1"}})
+		assert.NotNil(t, err)
+
+		bulkErr, ok := err.(*BulkError)
+		assert.True(t, ok)
+		assert.Len(t, bulkErr.Errors, 2)
+		assert.Equal(t, "fail2", bulkErr.Errors[0].Key)
+		assert.Equal(t, "fail1", bulkErr.Errors[1].Key)
+		assert.Equal(t, "2 bulk operations failed: fail2: failed fail2; fail1: failed fail1", err.Error())
+	})
+
+	t.Run("Empty requests", func(t *testing.T) {
+		s := NewDefaultBulkStore(newFakeStore(), 2)
+		assert.Nil(t, s.BulkSet(nil))
+		assert.Nil(t, s.BulkDelete(nil))
+		responses, err := s.BulkGet(nil)
+		assert.Nil(t, err)
+		assert.Empty(t, responses)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/gocql/gocql"
@@ -27,6 +28,7 @@ const (
 	table                    = "table"
 	keyspace                 = "keyspace"
 	replicationFactor        = "replicationFactor"
+	ttlInSeconds             = "ttlInSeconds"
 	defaultProtoVersion      = 4
 	defaultReplicationFactor = 1
 	defaultConsistency       = gocql.All
@@ -63,6 +65,10 @@ func NewCassandraStateStore(logger logger.Logger) *Cassandra {
 
 // Init performs metadata and connection parsing
 func (c *Cassandra) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	meta, err := getCassandraMetadata(metadata)
 	if err != nil {
 		return err
@@ -82,18 +88,44 @@ func (c *Cassandra) Init(metadata state.Metadata) error {
 
 	err = c.tryCreateKeyspace(meta.keyspace, meta.replicationFactor)
 	if err != nil {
-		return fmt.Errorf("error creating keyspace %s: %s", meta.table, err)
+		return fmt.Errorf("error creating keyspace %s: %s", meta.keyspace, err)
 	}
 
 	err = c.tryCreateTable(meta.table, meta.keyspace)
 	if err != nil {
-		return fmt.Errorf("error creating keyspace %s: %s", meta.table, err)
+		return fmt.Errorf("error creating table %s: %s", meta.table, err)
 	}
 
 	c.table = fmt.Sprintf("%s.%s", meta.keyspace, meta.table)
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Cassandra state store
+func (c *Cassandra) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: hosts, Type: metadata.TypeList, Required: true},
+			{Name: port, Type: metadata.TypeInt, Default: strconv.Itoa(defaultPort)},
+			{Name: username, Type: metadata.TypeString},
+			{Name: password, Type: metadata.TypeString},
+			{Name: protoVersion, Type: metadata.TypeInt, Default: strconv.Itoa(defaultProtoVersion)},
+			{Name: consistency, Type: metadata.TypeString, Default: "All"},
+			{Name: table, Type: metadata.TypeString, Default: defaultTable},
+			{Name: keyspace, Type: metadata.TypeString, Default: defaultKeyspace},
+			{Name: replicationFactor, Type: metadata.TypeInt, Default: strconv.Itoa(defaultReplicationFactor)},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityTTL},
+	}
+}
+
+// Ping queries the local node of the cluster, for health checks
+func (c *Cassandra) Ping() error {
+	if c.session == nil {
+		return fmt.Errorf("the Cassandra state store is not initialized")
+	}
+	return c.session.Query("SELECT now() FROM system.local").Exec()
+}
+
 func (c *Cassandra) tryCreateKeyspace(keyspace string, replicationFactor int) error {
 	return c.session.Query(fmt.Sprintf("CREATE KEYSPACE IF NOT EXISTS %s WITH REPLICATION = {'class' : 'SimpleStrategy', 'replication_factor' : %s};", keyspace, fmt.Sprintf("%v", replicationFactor))).Exec()
 }
@@ -209,7 +241,8 @@ func getCassandraMetadata(metadata state.Metadata) (*cassandraMetadata, error) {
 
 // Delete performs a delete operation
 func (c *Cassandra) Delete(req *state.DeleteRequest) error {
-	return c.session.Query("DELETE FROM ? WHERE key = ?", c.table, req.Key).Exec()
+	// Table names cannot be bound as query parameters
+	return c.session.Query(fmt.Sprintf("DELETE FROM %s WHERE key = ?", c.table), req.Key).Exec()
 }
 
 // BulkDelete performs a bulk delete operation
@@ -226,25 +259,14 @@ func (c *Cassandra) BulkDelete(req []state.DeleteRequest) error {
 
 // Get retrieves state from cassandra with a key
 func (c *Cassandra) Get(req *state.GetRequest) (*state.GetResponse, error) {
-	session := c.session
-
+	query := c.session.Query(fmt.Sprintf("SELECT value FROM %s WHERE key = ?", c.table), req.Key)
 	if req.Options.Consistency == state.Strong {
-		sess, err := c.createSession(gocql.All)
-		if err != nil {
-			return nil, err
-		}
-		defer sess.Close()
-		session = sess
+		query = query.Consistency(gocql.All)
 	} else if req.Options.Consistency == state.Eventual {
-		sess, err := c.createSession(gocql.One)
-		if err != nil {
-			return nil, err
-		}
-		defer sess.Close()
-		session = sess
+		query = query.Consistency(gocql.One)
 	}
 
-	results, err := session.Query("SELECT value FROM ? WHERE key = ?", c.table, req.Key).Iter().SliceMap()
+	results, err := query.Iter().SliceMap()
 	if err != nil {
 		return nil, err
 	}
@@ -267,35 +289,46 @@ func (c *Cassandra) Set(req *state.SetRequest) error {
 		bt, _ = jsoniter.ConfigFastest.Marshal(req.Value)
 	}
 
-	session := c.session
+	ttl, err := parseTTL(req.Metadata)
+	if err != nil {
+		return err
+	}
+
+	// Cassandra removes expired values itself, so a TTL needs no cleanup
+	var query *gocql.Query
+	if ttl > 0 {
+		query = c.session.Query(fmt.Sprintf("INSERT INTO %s (key, value) VALUES (?, ?) USING TTL ?", c.table), req.Key, bt, ttl)
+	} else {
+		query = c.session.Query(fmt.Sprintf("INSERT INTO %s (key, value) VALUES (?, ?)", c.table), req.Key, bt)
+	}
 
 	if req.Options.Consistency == state.Strong {
-		sess, err := c.createSession(gocql.Quorum)
-		if err != nil {
-			return err
-		}
-		defer sess.Close()
-		session = sess
+		query = query.Consistency(gocql.Quorum)
 	} else if req.Options.Consistency == state.Eventual {
-		sess, err := c.createSession(gocql.Any)
-		if err != nil {
-			return err
-		}
-		defer sess.Close()
-		session = sess
+		query = query.Consistency(gocql.Any)
 	}
 
-	return session.Query("INSERT INTO ? (key, value) VALUES (?, ?)", c.table, req.Key, bt).Exec()
+	return query.Exec()
 }
 
-func (c *Cassandra) createSession(consistency gocql.Consistency) (*gocql.Session, error) {
-	session, err := c.cluster.CreateSession()
+// parseTTL returns the number of seconds until a value expires from the metadata of a set request.
+// Values without a ttlInSeconds, or with a value that is not positive, never expire.
+func parseTTL(requestMetadata map[string]string) (int, error) {
+	val, ok := requestMetadata[ttlInSeconds]
+	if !ok || val == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf("error creating session: %s", err)
+		return 0, fmt.Errorf("error parsing %s field: %s", ttlInSeconds, err)
+	}
+
+	if ttl <= 0 {
+		return 0, nil
 	}
 
-	session.SetConsistency(consistency)
-	return session, nil
+	return int(ttl), nil
 }
 
 // BulkSet performs a bulks save operation
@@ -98,3 +98,28 @@ func TestGetCassandraMetadata(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestParseTTL(t *testing.T) {
+	t.Run("No TTL", func(t *testing.T) {
+		ttl, err := parseTTL(map[string]string{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, ttl)
+	})
+
+	t.Run("Positive TTL", func(t *testing.T) {
+		ttl, err := parseTTL(map[string]string{ttlInSeconds: "60"})
+		assert.Nil(t, err)
+		assert.Equal(t, 60, ttl)
+	})
+
+	t.Run("Negative TTL never expires", func(t *testing.T) {
+		ttl, err := parseTTL(map[string]string{ttlInSeconds: "-1"})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, ttl)
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		_, err := parseTTL(map[string]string{ttlInSeconds: "soon"})
+		assert.NotNil(t, err)
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	pb "github.com/dapr/components-contrib/state/cloudstate/proto"
 	kvstore_pb "github.com/dapr/components-contrib/state/cloudstate/proto/kv_store"
@@ -252,6 +253,10 @@ func NewCRDT(logger logger.Logger) *CRDT {
 
 // Init does metadata and connection parsing
 func (c *CRDT) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	m, err := c.parseMetadata(metadata)
 	if err != nil {
 		return err
@@ -262,6 +267,16 @@ func (c *CRDT) Init(metadata state.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Cloudstate state store
+func (c *CRDT) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: host, Type: metadata.TypeString, Required: true},
+			{Name: serverPort, Type: metadata.TypeInt, Required: true},
+		},
+	}
+}
+
 func (c *CRDT) startServer() error {
 	lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%v", c.metadata.serverPort))
 	if err != nil {
@@ -0,0 +1,122 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cockroachdb
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// CockroachDB state store
+type CockroachDB struct {
+	logger   logger.Logger
+	dbaccess dbAccess
+}
+
+// NewCockroachDBStateStore creates a new instance of CockroachDB state store
+func NewCockroachDBStateStore(logger logger.Logger) *CockroachDB {
+	dba := newCockroachDBAccess(logger)
+	return newCockroachDBStateStore(logger, dba)
+}
+
+// newCockroachDBStateStore creates a new instance of a CockroachDB state store.
+// This unexported constructor allows injecting a dbAccess instance for unit testing.
+func newCockroachDBStateStore(logger logger.Logger, dba dbAccess) *CockroachDB {
+	return &CockroachDB{
+		logger:   logger,
+		dbaccess: dba,
+	}
+}
+
+// Init initializes the CockroachDB state store
+func (c *CockroachDB) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+	return c.dbaccess.Init(metadata)
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the CockroachDB state store
+func (c *CockroachDB) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionStringKey, Type: metadata.TypeString, Required: true},
+			{Name: tableNameKey, Type: metadata.TypeString, Default: defaultTableName},
+			{Name: maxRetriesKey, Type: metadata.TypeInt, Default: strconv.Itoa(defaultMaxRetries)},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTransactional},
+	}
+}
+
+// Delete removes an entity from the store
+func (c *CockroachDB) Delete(req *state.DeleteRequest) error {
+	return c.dbaccess.Delete(req)
+}
+
+// BulkDelete removes multiple entries from the store
+func (c *CockroachDB) BulkDelete(req []state.DeleteRequest) error {
+	return c.dbaccess.BulkDelete(req)
+}
+
+// Get returns an entity from store
+func (c *CockroachDB) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return c.dbaccess.Get(req)
+}
+
+// Set adds/updates an entity on store
+func (c *CockroachDB) Set(req *state.SetRequest) error {
+	return c.dbaccess.Set(req)
+}
+
+// BulkSet adds/updates multiple entities on store
+func (c *CockroachDB) BulkSet(req []state.SetRequest) error {
+	return c.dbaccess.BulkSet(req)
+}
+
+// Multi handles multiple transactions. Implements TransactionalStore.
+// Operations are applied in order and atomically.
+func (c *CockroachDB) Multi(reqs []state.TransactionalRequest) error {
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			if _, ok := req.Request.(state.SetRequest); !ok {
+				return fmt.Errorf("expecting set request")
+			}
+
+		case state.Delete:
+			if _, ok := req.Request.(state.DeleteRequest); !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+
+		default:
+			return fmt.Errorf("unsupported operation: %s", req.Operation)
+		}
+	}
+
+	if len(reqs) > 0 {
+		return c.dbaccess.ExecuteMulti(reqs)
+	}
+
+	return nil
+}
+
+// Ping reports whether the database can be reached, for health checks
+func (c *CockroachDB) Ping() error {
+	return c.dbaccess.Ping()
+}
+
+// Close implements io.Closer
+func (c *CockroachDB) Close() error {
+	if c.dbaccess != nil {
+		return c.dbaccess.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package cockroachdb
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/conformance"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	connectionStringEnvKey = "DAPR_TEST_COCKROACHDB_CONNSTRING" // Environment variable containing the connection string
+)
+
+type fakeItem struct {
+	Color string
+}
+
+func TestCockroachDBIntegration(t *testing.T) {
+	connectionString := getConnectionString()
+	if connectionString == "" {
+		t.Skipf("CockroachDB state integration tests skipped. To enable define the connection string using environment variable '%s' (example 'export %s=\"postgresql://root@localhost:26257/dapr_test?sslmode=disable\")", connectionStringEnvKey, connectionStringEnvKey)
+	}
+
+	m := NewCockroachDBStateStore(logger.NewLogger("test"))
+	t.Cleanup(func() {
+		defer m.Close()
+	})
+
+	err := m.Init(state.Metadata{
+		Properties: map[string]string{connectionStringKey: connectionString},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("CRUD conformance", func(t *testing.T) {
+		t.Parallel()
+		conformance.CRUD(t, m)
+	})
+
+	t.Run("Delete item that does not exist fails", func(t *testing.T) {
+		t.Parallel()
+		err := m.Delete(&state.DeleteRequest{Key: randomKey()})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Transactions conformance", func(t *testing.T) {
+		t.Parallel()
+		conformance.Transactions(t, m)
+	})
+
+	t.Run("Conflicting transactions are retried", func(t *testing.T) {
+		t.Parallel()
+		conflictingTransactionsAreRetried(t, m)
+	})
+}
+
+// conflictingTransactionsAreRetried runs transactions writing the same keys concurrently, which conflict
+// and only succeed because serialization failures are retried.
+func conflictingTransactionsAreRetried(t *testing.T, m *CockroachDB) {
+	keys := []string{randomKey(), randomKey()}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(color string) {
+			defer wg.Done()
+			errs <- m.Multi([]state.TransactionalRequest{
+				{Operation: state.Upsert, Request: state.SetRequest{Key: keys[0], Value: &fakeItem{Color: color}}},
+				{Operation: state.Upsert, Request: state.SetRequest{Key: keys[1], Value: &fakeItem{Color: color}}},
+			})
+		}(strconv.Itoa(i))
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.Nil(t, err)
+	}
+
+	// Both keys are written by the same transaction last
+	assert.Equal(t, getItem(t, m, keys[0]).Data, getItem(t, m, keys[1]).Data)
+	assert.Nil(t, m.BulkDelete([]state.DeleteRequest{{Key: keys[0]}, {Key: keys[1]}}))
+}
+
+func getItem(t *testing.T, m *CockroachDB, key string) *state.GetResponse {
+	response, err := m.Get(&state.GetRequest{Key: key})
+	assert.Nil(t, err)
+	assert.NotNil(t, response)
+	return response
+}
+
+func getConnectionString() string {
+	return os.Getenv(connectionStringEnvKey)
+}
@@ -0,0 +1,217 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package cockroachdb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeConnectionString = "not a real connection"
+)
+
+// Fake implementation of interface cockroachdb.dbaccess
+type fakeDBaccess struct {
+	logger        logger.Logger
+	initExecuted  bool
+	multiExecuted bool
+	pingErr       error
+}
+
+func (f *fakeDBaccess) Init(metadata state.Metadata) error {
+	f.initExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Set(req *state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeDBaccess) Delete(req *state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	f.multiExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Ping() error {
+	return f.pingErr
+}
+
+func (f *fakeDBaccess) Close() error {
+	return nil
+}
+
+// Proves that the Init method runs the init method
+func TestInitRunsDBAccessInit(t *testing.T) {
+	t.Parallel()
+	_, fake := createCockroachDBWithFake(t)
+	assert.True(t, fake.initExecuted)
+}
+
+func TestPingReturnsDBAccessResult(t *testing.T) {
+	t.Parallel()
+	store, fake := createCockroachDBWithFake(t)
+	assert.Nil(t, store.Ping())
+
+	fake.pingErr = errors.New("connection refused")
+	assert.Equal(t, fake.pingErr, store.Ping())
+}
+
+func TestMultiWithNoRequestsDoesNothing(t *testing.T) {
+	t.Parallel()
+	m, fake := createCockroachDBWithFake(t)
+	err := m.Multi(nil)
+	assert.Nil(t, err)
+	assert.False(t, fake.multiExecuted)
+}
+
+func TestValidMultiRequests(t *testing.T) {
+	t.Parallel()
+	m, fake := createCockroachDBWithFake(t)
+	err := m.Multi([]state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: randomKey(), Value: "value"}},
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: randomKey()}},
+	})
+	assert.Nil(t, err)
+	assert.True(t, fake.multiExecuted)
+}
+
+func TestInvalidMultiRequests(t *testing.T) {
+	t.Parallel()
+	invalid := map[string]state.TransactionalRequest{
+		"Invalid operation":           {Operation: "Something invalid", Request: state.SetRequest{Key: randomKey()}},
+		"Upsert with delete request":  {Operation: state.Upsert, Request: state.DeleteRequest{Key: randomKey()}},
+		"Delete with set request":     {Operation: state.Delete, Request: state.SetRequest{Key: randomKey()}},
+		"Upsert with request pointer": {Operation: state.Upsert, Request: &state.SetRequest{Key: randomKey()}},
+	}
+	for name, req := range invalid {
+		req := req
+		t.Run(name, func(t *testing.T) {
+			m, fake := createCockroachDBWithFake(t)
+			err := m.Multi([]state.TransactionalRequest{req})
+			assert.NotNil(t, err)
+			assert.False(t, fake.multiExecuted)
+		})
+	}
+}
+
+func TestInitWithoutConnectionStringFails(t *testing.T) {
+	t.Parallel()
+	dba := newCockroachDBAccess(logger.NewLogger("test"))
+	err := dba.Init(state.Metadata{Properties: map[string]string{}})
+	assert.NotNil(t, err)
+}
+
+func TestInitWithInvalidTableNameFails(t *testing.T) {
+	t.Parallel()
+	dba := newCockroachDBAccess(logger.NewLogger("test"))
+	err := dba.Init(state.Metadata{Properties: map[string]string{
+		connectionStringKey: fakeConnectionString,
+		tableNameKey:        "state; DROP TABLE users",
+	}})
+	assert.NotNil(t, err)
+}
+
+type fakeSQLStateError struct {
+	code string
+}
+
+func (e *fakeSQLStateError) Error() string {
+	return "fake database error"
+}
+
+func (e *fakeSQLStateError) SQLState() string {
+	return e.code
+}
+
+func TestRetryOnSerializationFailure(t *testing.T) {
+	t.Run("Serialization failures are retried", func(t *testing.T) {
+		calls := 0
+		err := retryOnSerializationFailure(3, time.Millisecond, func() error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("commit failed: %w", &fakeSQLStateError{code: serializationFailure})
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Retries are limited", func(t *testing.T) {
+		calls := 0
+		err := retryOnSerializationFailure(2, time.Millisecond, func() error {
+			calls++
+			return &fakeSQLStateError{code: serializationFailure}
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Other errors are not retried", func(t *testing.T) {
+		calls := 0
+		err := retryOnSerializationFailure(3, time.Millisecond, func() error {
+			calls++
+			return errNoRowsAffected
+		})
+		assert.Equal(t, errNoRowsAffected, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestInitWithInvalidMaxRetriesFails(t *testing.T) {
+	t.Parallel()
+	dba := newCockroachDBAccess(logger.NewLogger("test"))
+	err := dba.Init(state.Metadata{Properties: map[string]string{
+		connectionStringKey: fakeConnectionString,
+		maxRetriesKey:       "-1",
+	}})
+	assert.NotNil(t, err)
+}
+
+func createCockroachDBWithFake(t *testing.T) (*CockroachDB, *fakeDBaccess) {
+	logger := logger.NewLogger("test")
+
+	dba := &fakeDBaccess{
+		logger: logger,
+	}
+
+	m := newCockroachDBStateStore(logger, dba)
+	assert.NotNil(t, m)
+
+	err := m.Init(state.Metadata{
+		Properties: map[string]string{connectionStringKey: fakeConnectionString},
+	})
+	assert.Nil(t, err)
+
+	return m, dba
+}
+
+func randomKey() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,354 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cockroachdb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+
+	// Blank import for the underlying PostgreSQL wire protocol driver
+	_ "github.com/jackc/pgx/v4/stdlib"
+)
+
+const (
+	connectionStringKey        = "connectionString"
+	tableNameKey               = "tableName"
+	maxRetriesKey              = "maxRetries"
+	errMissingConnectionString = "missing connection string"
+	defaultTableName           = "state"
+	defaultMaxRetries          = 5
+
+	// retryBackoff is the delay before the first retry of a transaction, which doubles with every retry
+	retryBackoff = 10 * time.Millisecond
+
+	// serializationFailure is the SQLSTATE of transactions that CockroachDB aborts and expects clients to retry
+	serializationFailure = "40001"
+)
+
+var errNoRowsAffected = errors.New("database operation failed: no rows match given key and etag")
+
+// cockroachDBAccess implements dbaccess
+type cockroachDBAccess struct {
+	logger           logger.Logger
+	metadata         state.Metadata
+	db               *sql.DB
+	connectionString string
+	tableName        string
+	maxRetries       int
+}
+
+// newCockroachDBAccess creates a new instance of cockroachDBAccess
+func newCockroachDBAccess(logger logger.Logger) *cockroachDBAccess {
+	logger.Debug("Instantiating new CockroachDB state store")
+	return &cockroachDBAccess{
+		logger: logger,
+	}
+}
+
+// Init sets up the CockroachDB connection and ensures that the state table exists
+func (c *cockroachDBAccess) Init(metadata state.Metadata) error {
+	c.logger.Debug("Initializing CockroachDB state store")
+	c.metadata = metadata
+
+	if val, ok := metadata.Properties[connectionStringKey]; ok && val != "" {
+		c.connectionString = val
+	} else {
+		c.logger.Error("Missing CockroachDB connection string")
+		return fmt.Errorf(errMissingConnectionString)
+	}
+
+	c.tableName = defaultTableName
+	if val, ok := metadata.Properties[tableNameKey]; ok && val != "" {
+		if !sqlutil.IsValidName(val) {
+			return fmt.Errorf("invalid table name '%s', accepted characters are (A-Z, a-z, 0-9, _)", val)
+		}
+		c.tableName = val
+	}
+
+	c.maxRetries = defaultMaxRetries
+	if val, ok := metadata.Properties[maxRetriesKey]; ok && val != "" {
+		maxRetries, err := strconv.Atoi(val)
+		if err != nil || maxRetries < 0 {
+			return fmt.Errorf("invalid %s value '%s', must be a non-negative integer", maxRetriesKey, val)
+		}
+		c.maxRetries = maxRetries
+	}
+
+	db, err := sql.Open("pgx", c.connectionString)
+	if err != nil {
+		c.logger.Error(err)
+		return err
+	}
+
+	c.db = db
+
+	pingErr := db.Ping()
+	if pingErr != nil {
+		return pingErr
+	}
+
+	return c.ensureStateTable()
+}
+
+// Set makes an insert or update to the database.
+func (c *cockroachDBAccess) Set(req *state.SetRequest) error {
+	return state.SetWithRetries(func(req *state.SetRequest) error {
+		return c.inTransaction(func(tx *sql.Tx) error {
+			return c.setValue(tx, req)
+		})
+	}, req)
+}
+
+// setValue is an internal implementation of set to enable passing the logic to state.SetWithRetries as a func.
+// Every write stores a new random etag, since CockroachDB has no equivalent of the PostgreSQL xmin column.
+func (c *cockroachDBAccess) setValue(db dbExecutor, req *state.SetRequest) error {
+	c.logger.Debug("Setting state value in CockroachDB")
+
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	if req.Key == "" {
+		return fmt.Errorf("missing key in set operation")
+	}
+
+	value, err := sqlutil.SerializeValue(req.Value)
+	if err != nil {
+		return err
+	}
+
+	etag := uuid.New().String()
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+
+	var result sql.Result
+	switch {
+	case reqEtag == "" && req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so the key may only be inserted
+		result, err = db.Exec(fmt.Sprintf(
+			`INSERT INTO %s (key, value, etag) VALUES ($1, $2, $3)
+			ON CONFLICT (key) DO NOTHING;`,
+			c.tableName), req.Key, value, etag)
+	case reqEtag == "":
+		result, err = db.Exec(fmt.Sprintf(
+			`INSERT INTO %s (key, value, etag) VALUES ($1, $2, $3)
+			ON CONFLICT (key) DO UPDATE SET value = $2, etag = $3, updatedate = NOW();`,
+			c.tableName), req.Key, value, etag)
+	default:
+		// When an etag is provided do an update - no insert
+		result, err = db.Exec(fmt.Sprintf(
+			`UPDATE %s SET value = $1, etag = $2, updatedate = NOW()
+			WHERE key = $3 AND etag = $4;`,
+			c.tableName), value, etag, req.Key, reqEtag)
+	}
+
+	return c.returnSingleDBResult(result, err)
+}
+
+// Get returns data from the database. If data does not exist for the key an empty state.GetResponse will be returned.
+func (c *cockroachDBAccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	c.logger.Debug("Getting state value from CockroachDB")
+	if req.Key == "" {
+		return nil, fmt.Errorf("missing key in get operation")
+	}
+
+	var value string
+	var etag string
+	err := c.db.QueryRow(fmt.Sprintf("SELECT value, etag FROM %s WHERE key = $1", c.tableName), req.Key).Scan(&value, &etag)
+	if err != nil {
+		// If no rows exist, return an empty response, otherwise return the error.
+		if err == sql.ErrNoRows {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+
+	response := &state.GetResponse{
+		Data:     []byte(value),
+		ETag:     etag,
+		Metadata: req.Metadata,
+	}
+
+	return response, nil
+}
+
+// Delete removes an item from the state store.
+func (c *cockroachDBAccess) Delete(req *state.DeleteRequest) error {
+	return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
+		return c.inTransaction(func(tx *sql.Tx) error {
+			return c.deleteValue(tx, req)
+		})
+	}, req)
+}
+
+// deleteValue is an internal implementation of delete to enable passing the logic to state.DeleteWithRetries as a func.
+func (c *cockroachDBAccess) deleteValue(db dbExecutor, req *state.DeleteRequest) error {
+	c.logger.Debug("Deleting state value from CockroachDB")
+	if req.Key == "" {
+		return fmt.Errorf("missing key in delete operation")
+	}
+
+	var result sql.Result
+	var err error
+
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+	if reqEtag == "" {
+		result, err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = $1", c.tableName), req.Key)
+	} else {
+		result, err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = $1 AND etag = $2", c.tableName), req.Key, reqEtag)
+	}
+
+	return c.returnSingleDBResult(result, err)
+}
+
+// BulkSet runs all set requests in a single transaction.
+func (c *cockroachDBAccess) BulkSet(req []state.SetRequest) error {
+	return c.inTransaction(func(tx *sql.Tx) error {
+		for i := range req {
+			err := c.setValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// BulkDelete runs all delete requests in a single transaction.
+func (c *cockroachDBAccess) BulkDelete(req []state.DeleteRequest) error {
+	return c.inTransaction(func(tx *sql.Tx) error {
+		for i := range req {
+			err := c.deleteValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ExecuteMulti runs set and delete requests in order within a single transaction.
+func (c *cockroachDBAccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	c.logger.Debug("Executing multiple CockroachDB operations")
+
+	return c.inTransaction(func(tx *sql.Tx) error {
+		for _, req := range reqs {
+			var err error
+			switch r := req.Request.(type) {
+			case state.SetRequest:
+				err = c.setValue(tx, &r)
+			case state.DeleteRequest:
+				err = c.deleteValue(tx, &r)
+			default:
+				err = fmt.Errorf("unsupported request type %T", req.Request)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// inTransaction runs fn in a transaction with sqlutil.InTransaction. Transactions that fail with a serialization
+// conflict are retried, since CockroachDB runs all transactions with serializable isolation and expects clients to
+// retry them.
+func (c *cockroachDBAccess) inTransaction(fn func(tx *sql.Tx) error) error {
+	return retryOnSerializationFailure(c.maxRetries, retryBackoff, func() error {
+		return sqlutil.InTransaction(c.db, fn)
+	})
+}
+
+// sqlStateError is implemented by PostgreSQL driver errors which carry a SQLSTATE code
+type sqlStateError interface {
+	SQLState() string
+}
+
+// retryOnSerializationFailure calls fn until it does not fail with a serialization conflict, at most maxRetries more times.
+func retryOnSerializationFailure(maxRetries int, backoff time.Duration, fn func() error) error {
+	err := fn()
+	for retry := 0; retry < maxRetries && isSerializationFailure(err); retry++ {
+		time.Sleep(backoff << uint(retry))
+		err = fn()
+	}
+
+	return err
+}
+
+func isSerializationFailure(err error) bool {
+	var sqlErr sqlStateError
+	return errors.As(err, &sqlErr) && sqlErr.SQLState() == serializationFailure
+}
+
+// Verifies that the sql.Result affected only one row and no errors exist
+func (c *cockroachDBAccess) returnSingleDBResult(result sql.Result, err error) error {
+	if err != nil {
+		c.logger.Debug(err)
+		return err
+	}
+
+	rowsAffected, resultErr := result.RowsAffected()
+
+	if resultErr != nil {
+		c.logger.Error(resultErr)
+		return resultErr
+	}
+
+	if rowsAffected == 0 {
+		c.logger.Error(errNoRowsAffected)
+		return errNoRowsAffected
+	}
+
+	if rowsAffected > 1 {
+		tooManyRowsErr := errors.New("database operation failed: more than one row affected, expected one")
+		c.logger.Error(tooManyRowsErr)
+		return tooManyRowsErr
+	}
+
+	return nil
+}
+
+// Ping checks that the database can be reached
+func (c *cockroachDBAccess) Ping() error {
+	if c.db == nil {
+		return fmt.Errorf("the CockroachDB state store is not initialized")
+	}
+
+	return c.db.Ping()
+}
+
+// Close implements io.Close
+func (c *cockroachDBAccess) Close() error {
+	if c.db != nil {
+		return c.db.Close()
+	}
+
+	return nil
+}
+
+func (c *cockroachDBAccess) ensureStateTable() error {
+	c.logger.Debug("Ensuring CockroachDB state table exists")
+	createTable := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+								key STRING NOT NULL PRIMARY KEY,
+								value JSONB NOT NULL,
+								etag STRING NOT NULL,
+								insertdate TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+								updatedate TIMESTAMPTZ NULL);`, c.tableName)
+	_, err := c.db.Exec(createTable)
+
+	return err
+}
@@ -0,0 +1,31 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package cockroachdb
+
+import (
+	"database/sql"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// dbAccess is a private interface which enables unit testing of CockroachDB
+type dbAccess interface {
+	Init(metadata state.Metadata) error
+	Set(req *state.SetRequest) error
+	Get(req *state.GetRequest) (*state.GetResponse, error)
+	Delete(req *state.DeleteRequest) error
+	BulkSet(req []state.SetRequest) error
+	BulkDelete(req []state.DeleteRequest) error
+	ExecuteMulti(reqs []state.TransactionalRequest) error
+	Ping() error
+	Close() error // io.Closer
+}
+
+// dbExecutor is implemented by both *sql.DB and *sql.Tx, allowing statements to run inside or outside a transaction
+type dbExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
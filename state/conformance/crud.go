@@ -0,0 +1,156 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// item is the JSON value written by the tests
+type item struct {
+	Color string
+}
+
+// CRUD verifies the gets, sets and deletes of a store, one by one and in bulk, with ETags and first-write concurrency
+func CRUD(t *testing.T, store state.Store) {
+	get := func(t *testing.T, key string) *state.GetResponse {
+		resp, err := store.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		if resp == nil {
+			resp = &state.GetResponse{}
+		}
+		return resp
+	}
+
+	t.Run("Set, update with its ETag and delete one item", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: &item{Color: "yellow"}}))
+
+		resp := get(t, key)
+		assert.Equal(t, &item{Color: "yellow"}, decode(t, resp))
+		assert.NotEmpty(t, resp.ETag)
+
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: &item{Color: "blue"}, ETag: resp.ETag}))
+		updated := get(t, key)
+		assert.Equal(t, &item{Color: "blue"}, decode(t, updated))
+		assert.NotEqual(t, resp.ETag, updated.ETag)
+
+		assert.Nil(t, store.Delete(&state.DeleteRequest{Key: key, ETag: updated.ETag}))
+		assert.Nil(t, get(t, key).Data)
+	})
+
+	t.Run("Get item that does not exist", func(t *testing.T) {
+		assert.Nil(t, get(t, uuid.New().String()).Data)
+	})
+
+	t.Run("Update with old ETag fails", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: &item{Color: "yellow"}}))
+		oldETag := get(t, key).ETag
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: &item{Color: "green"}}))
+
+		assert.NotNil(t, store.Set(&state.SetRequest{Key: key, Value: &item{Color: "red"}, ETag: oldETag}))
+		assert.NotNil(t, store.Delete(&state.DeleteRequest{Key: key, ETag: oldETag}))
+		assert.Equal(t, &item{Color: "green"}, decode(t, get(t, key)))
+
+		assert.Nil(t, store.Delete(&state.DeleteRequest{Key: key}))
+	})
+
+	t.Run("First write fails for an existing key", func(t *testing.T) {
+		key := uuid.New().String()
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: &item{Color: "yellow"}, Options: firstWrite}))
+
+		assert.NotNil(t, store.Set(&state.SetRequest{Key: key, Value: &item{Color: "red"}, Options: firstWrite}))
+		assert.Equal(t, &item{Color: "yellow"}, decode(t, get(t, key)))
+
+		assert.Nil(t, store.Delete(&state.DeleteRequest{Key: key}))
+	})
+
+	t.Run("Bulk set and bulk delete", func(t *testing.T) {
+		keys := []string{uuid.New().String(), uuid.New().String(), uuid.New().String()}
+		var sets []state.SetRequest
+		var deletes []state.DeleteRequest
+		for _, key := range keys {
+			sets = append(sets, state.SetRequest{Key: key, Value: &item{Color: key}})
+			deletes = append(deletes, state.DeleteRequest{Key: key})
+		}
+
+		assert.Nil(t, store.BulkSet(sets))
+		for _, key := range keys {
+			assert.Equal(t, &item{Color: key}, decode(t, get(t, key)))
+		}
+
+		assert.Nil(t, store.BulkDelete(deletes))
+		for _, key := range keys {
+			assert.Nil(t, get(t, key).Data)
+		}
+	})
+}
+
+// Transactions verifies that the transactions of a store are applied entirely, or not at all when an operation fails.
+// The store must be a state.TransactionalStore.
+func Transactions(t *testing.T, store state.Store) {
+	transactional, ok := store.(state.TransactionalStore)
+	if !ok {
+		t.Fatal("the store is not a transactional store")
+	}
+	get := func(t *testing.T, key string) *state.GetResponse {
+		resp, err := store.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		if resp == nil {
+			resp = &state.GetResponse{}
+		}
+		return resp
+	}
+
+	t.Run("Multi rolls back on ETag mismatch", func(t *testing.T) {
+		existing, added := uuid.New().String(), uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: existing, Value: &item{Color: "yellow"}}))
+
+		err := transactional.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: added, Value: &item{Color: "blue"}}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: existing, Value: &item{Color: "red"}, ETag: uuid.New().String()}},
+		})
+		assert.NotNil(t, err)
+		assert.Nil(t, get(t, added).Data)
+		assert.Equal(t, &item{Color: "yellow"}, decode(t, get(t, existing)))
+
+		err = transactional.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: added, Value: &item{Color: "blue"}}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: existing, ETag: uuid.New().String()}},
+		})
+		assert.NotNil(t, err)
+		assert.Nil(t, get(t, added).Data)
+		assert.NotNil(t, get(t, existing).Data)
+	})
+
+	t.Run("Multi applies all the operations", func(t *testing.T) {
+		existing, added := uuid.New().String(), uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: existing, Value: &item{Color: "yellow"}}))
+
+		err := transactional.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: added, Value: &item{Color: "blue"}}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: existing}},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &item{Color: "blue"}, decode(t, get(t, added)))
+		assert.Nil(t, get(t, existing).Data)
+
+		assert.Nil(t, store.Delete(&state.DeleteRequest{Key: added}))
+	})
+}
+
+func decode(t *testing.T, resp *state.GetResponse) *item {
+	var i item
+	assert.Nil(t, json.Unmarshal(resp.Data, &i))
+	return &i
+}
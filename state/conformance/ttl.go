@@ -0,0 +1,85 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package conformance contains tests that state stores run against themselves to verify
+// that they behave the same way.
+package conformance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TTL verifies the ttlInSeconds semantics of state.ParseTTL for a store initialized without a default TTL.
+// expire moves the expiration time of a key into the past, if the key has one. When expire is nil the test
+// waits for the TTL of the values to pass instead.
+func TTL(t *testing.T, store state.Store, expire func(key string)) {
+	if expire == nil {
+		expire = func(key string) {
+			time.Sleep(2 * time.Second)
+		}
+	}
+	ttl := func(seconds string) map[string]string {
+		return map[string]string{state.TTLInSecondsKey: seconds}
+	}
+	get := func(t *testing.T, key string) *state.GetResponse {
+		resp, err := store.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		if resp == nil {
+			resp = &state.GetResponse{}
+		}
+		return resp
+	}
+
+	t.Run("Get after expiry returns no data", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: "expiring", Metadata: ttl("1")}))
+		assert.NotNil(t, get(t, key).Data)
+
+		expire(key)
+		assert.Nil(t, get(t, key).Data)
+	})
+
+	t.Run("Negative TTL persists", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: "persistent", Metadata: ttl("-1")}))
+
+		expire(key)
+		assert.NotNil(t, get(t, key).Data)
+	})
+
+	t.Run("Setting without a TTL removes the expiry", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: "expiring", Metadata: ttl("1")}))
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: "persistent", Metadata: ttl("0")}))
+
+		expire(key)
+		assert.NotNil(t, get(t, key).Data)
+	})
+
+	t.Run("First write after expiry succeeds", func(t *testing.T) {
+		key := uuid.New().String()
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: "expiring", Metadata: ttl("1"), Options: firstWrite}))
+
+		expire(key)
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: "again", Options: firstWrite}))
+		assert.NotNil(t, get(t, key).Data)
+	})
+
+	t.Run("ETag writes to expired values fail", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, store.Set(&state.SetRequest{Key: key, Value: "expiring", Metadata: ttl("1")}))
+		etag := get(t, key).ETag
+
+		expire(key)
+		assert.NotNil(t, store.Set(&state.SetRequest{Key: key, Value: "updated", ETag: etag}))
+		assert.Nil(t, get(t, key).Data)
+	})
+}
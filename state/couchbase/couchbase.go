@@ -9,29 +9,50 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/couchbase/gocb/v2"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
-	"gopkg.in/couchbase/gocb.v1"
 
 	jsoniter "github.com/json-iterator/go"
 )
 
 const (
-	couchbaseURL = "couchbaseURL"
-	username     = "username"
-	password     = "password"
-	bucketName   = "bucketName"
+	couchbaseURL   = "couchbaseURL"
+	username       = "username"
+	password       = "password"
+	bucketName     = "bucketName"
+	scopeName      = "scopeName"
+	collectionName = "collectionName"
 
-	//see https://docs.couchbase.com/go-sdk/1.6/durability.html#configuring-durability
+	//see https://docs.couchbase.com/go-sdk/2.1/howtos/subdocument-operations.html#durability
+	durabilityLevel = "durabilityLevel"
+
+	//see https://docs.couchbase.com/go-sdk/2.1/concept-docs/durability-replication-failure-considerations.html#older-server-versions
 	numReplicasDurableReplication = "numReplicasDurableReplication"
 	numReplicasDurablePersistence = "numReplicasDurablePersistence"
+
+	durabilityLevelMajority                 = "majority"
+	durabilityLevelMajorityAndPersistActive = "majorityAndPersistActive"
+	durabilityLevelPersistToMajority        = "persistToMajority"
+
+	connectTimeout = 10 * time.Second
 )
 
+var durabilityLevels = map[string]gocb.DurabilityLevel{
+	durabilityLevelMajority:                 gocb.DurabilityLevelMajority,
+	durabilityLevelMajorityAndPersistActive: gocb.DurabilityLevelMajorityAndPersistOnMaster,
+	durabilityLevelPersistToMajority:        gocb.DurabilityLevelPersistToMajority,
+}
+
 // Couchbase is a couchbase state store
 type Couchbase struct {
-	bucket                        *gocb.Bucket
+	cluster                       *gocb.Cluster
+	collection                    *gocb.Collection
 	bucketName                    string //TODO: having bucket name sent as part of request (get,set etc.) metadata would be more flexible
+	durabilityLevel               gocb.DurabilityLevel
 	numReplicasDurableReplication uint
 	numReplicasDurablePersistence uint
 	json                          jsoniter.API
@@ -64,6 +85,10 @@ func validateMetadata(metadata state.Metadata) error {
 		return errors.New("couchbase error: couchbase bucket name is missing")
 	}
 
+	if metadata.Properties[collectionName] != "" && metadata.Properties[scopeName] == "" {
+		return errors.New("couchbase error: couchbase scope name is required with a collection name")
+	}
+
 	v := metadata.Properties[numReplicasDurableReplication]
 	if v != "" {
 		_, err := strconv.ParseUint(v, 10, 0)
@@ -80,32 +105,65 @@ func validateMetadata(metadata state.Metadata) error {
 		}
 	}
 
+	v = metadata.Properties[durabilityLevel]
+	if v != "" {
+		if _, ok := durabilityLevels[v]; !ok {
+			return fmt.Errorf("couchbase error: invalid durability level %s, supported values are: %s, %s, %s", v,
+				durabilityLevelMajority, durabilityLevelMajorityAndPersistActive, durabilityLevelPersistToMajority)
+		}
+
+		// Durability levels replace the replication and persistence observed by the client
+		if metadata.Properties[numReplicasDurableReplication] != "" || metadata.Properties[numReplicasDurablePersistence] != "" {
+			return fmt.Errorf("couchbase error: %s cannot be combined with %s or %s", durabilityLevel, numReplicasDurableReplication, numReplicasDurablePersistence)
+		}
+	}
+
 	return nil
 }
 
 // Init does metadata and connection parsing
 func (cbs *Couchbase) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	err := validateMetadata(metadata)
 	if err != nil {
 		return err
 	}
 	cbs.bucketName = metadata.Properties[bucketName]
-	c, err := gocb.Connect(metadata.Properties[couchbaseURL])
+	c, err := gocb.Connect(metadata.Properties[couchbaseURL], gocb.ClusterOptions{
+		Authenticator: gocb.PasswordAuthenticator{
+			Username: metadata.Properties[username],
+			Password: metadata.Properties[password],
+		},
+	})
 	if err != nil {
 		return fmt.Errorf("couchbase error: unable to connect to couchbase at %s - %v ", metadata.Properties[couchbaseURL], err)
 	}
-	//does not actually trigger the authentication
-	c.Authenticate(gocb.PasswordAuthenticator{
-		Username: metadata.Properties[username],
-		Password: metadata.Properties[password],
-	})
+	cbs.cluster = c
 
-	//with RBAC, bucket-passwords are no longer used - https://docs.couchbase.com/go-sdk/1.6/sdk-authentication-overview.html#authenticating-with-legacy-sdk-versions
-	bucket, err := c.OpenBucket(cbs.bucketName, "")
+	//with RBAC, bucket-passwords are no longer used - https://docs.couchbase.com/go-sdk/2.1/howtos/managing-connections.html
+	bucket := c.Bucket(cbs.bucketName)
+	err = bucket.WaitUntilReady(connectTimeout, nil)
 	if err != nil {
 		return fmt.Errorf("couchbase error: failed to open bucket %s - %v", cbs.bucketName, err)
 	}
-	cbs.bucket = bucket
+
+	// Scopes and collections require Couchbase Server 7.0, earlier versions only have the default collection
+	if s := metadata.Properties[scopeName]; s != "" {
+		cn := metadata.Properties[collectionName]
+		if cn == "" {
+			cn = "_default"
+		}
+		cbs.collection = bucket.Scope(s).Collection(cn)
+	} else {
+		cbs.collection = bucket.DefaultCollection()
+	}
+
+	if l := metadata.Properties[durabilityLevel]; l != "" {
+		cbs.durabilityLevel = durabilityLevels[l]
+	}
 
 	r := metadata.Properties[numReplicasDurableReplication]
 	if r != "" {
@@ -121,44 +179,96 @@ func (cbs *Couchbase) Init(metadata state.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Couchbase state store
+func (cbs *Couchbase) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: couchbaseURL, Type: metadata.TypeString, Required: true},
+			{Name: username, Type: metadata.TypeString, Required: true},
+			{Name: password, Type: metadata.TypeString, Required: true},
+			{Name: bucketName, Type: metadata.TypeString, Required: true},
+			{Name: scopeName, Type: metadata.TypeString},
+			{Name: collectionName, Type: metadata.TypeString},
+			{Name: durabilityLevel, Type: metadata.TypeString},
+			{Name: numReplicasDurableReplication, Type: metadata.TypeInt},
+			{Name: numReplicasDurablePersistence, Type: metadata.TypeInt},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag},
+	}
+}
+
+// durability is how a write is replicated or persisted before it succeeds
+type durability struct {
+	level       gocb.DurabilityLevel
+	replicateTo uint
+	persistTo   uint
+}
+
+// durability returns the durability for a write with the given consistency.
+// Only strongly consistent writes wait for the configured durability.
+func (cbs *Couchbase) durability(consistency string) durability {
+	if consistency != state.Strong {
+		return durability{}
+	}
+
+	return durability{
+		level:       cbs.durabilityLevel,
+		replicateTo: cbs.numReplicasDurableReplication,
+		persistTo:   cbs.numReplicasDurablePersistence,
+	}
+}
+
 //Set stores value for a key to couchbase. It honors ETag (for concurrency) and consistency settings
 func (cbs *Couchbase) Set(req *state.SetRequest) error {
 	err := state.CheckSetRequestOptions(req)
 	if err != nil {
 		return err
 	}
-	var value string
+	var value []byte
 	b, ok := req.Value.([]byte)
 	if ok {
-		value = string(b)
+		value = b
 	} else {
-		value, err = cbs.json.MarshalToString(req.Value)
+		value, err = cbs.json.Marshal(req.Value)
 	}
 
 	if err != nil {
 		return fmt.Errorf("couchbase error: failed to convert value %v", err)
 	}
 
-	//nolint:nestif
+	d := cbs.durability(req.Options.Consistency)
+
 	//key already exists (use Replace)
-	if req.ETag != "" {
-		//compare-and-swap (CAS) for managing concurrent modifications - https://docs.couchbase.com/go-sdk/current/concurrent-mutations-cluster.html
+	switch {
+	case req.ETag != "":
+		//compare-and-swap (CAS) for managing concurrent modifications - https://docs.couchbase.com/go-sdk/2.1/howtos/concurrent-document-mutations.html
 		cas, cerr := eTagToCas(req.ETag)
 		if cerr != nil {
-			return fmt.Errorf("couchbase error: failed to set value for key %s - %v", req.Key, err)
+			return fmt.Errorf("couchbase error: failed to set value for key %s - %v", req.Key, cerr)
 		}
-		if req.Options.Consistency == state.Strong {
-			_, err = cbs.bucket.ReplaceDura(req.Key, value, cas, 0, cbs.numReplicasDurableReplication, cbs.numReplicasDurablePersistence)
-		} else {
-			_, err = cbs.bucket.Replace(req.Key, value, cas, 0)
-		}
-	} else {
+		_, err = cbs.collection.Replace(req.Key, value, &gocb.ReplaceOptions{
+			Cas:             cas,
+			DurabilityLevel: d.level,
+			ReplicateTo:     d.replicateTo,
+			PersistTo:       d.persistTo,
+			Transcoder:      valueTranscoder,
+		})
+	case req.Options.Concurrency == state.FirstWrite:
+		//the first write wins, so the key may only be inserted
+		_, err = cbs.collection.Insert(req.Key, value, &gocb.InsertOptions{
+			DurabilityLevel: d.level,
+			ReplicateTo:     d.replicateTo,
+			PersistTo:       d.persistTo,
+			Transcoder:      valueTranscoder,
+		})
+	default:
 		//key does not exist: replace or insert (with Upsert)
-		if req.Options.Consistency == state.Strong {
-			_, err = cbs.bucket.UpsertDura(req.Key, value, 0, cbs.numReplicasDurableReplication, cbs.numReplicasDurablePersistence)
-		} else {
-			_, err = cbs.bucket.Upsert(req.Key, value, 0)
-		}
+		_, err = cbs.collection.Upsert(req.Key, value, &gocb.UpsertOptions{
+			DurabilityLevel: d.level,
+			ReplicateTo:     d.replicateTo,
+			PersistTo:       d.persistTo,
+			Transcoder:      valueTranscoder,
+		})
 	}
 
 	if err != nil {
@@ -182,23 +292,25 @@ func (cbs *Couchbase) BulkSet(req []state.SetRequest) error {
 
 // Get retrieves state from couchbase with a key
 func (cbs *Couchbase) Get(req *state.GetRequest) (*state.GetResponse, error) {
-	var data interface{}
-	cas, err := cbs.bucket.Get(req.Key, &data)
+	result, err := cbs.collection.Get(req.Key, &gocb.GetOptions{
+		Transcoder: valueTranscoder,
+	})
 	if err != nil {
-		if gocb.IsKeyNotFoundError(err) {
+		if errors.Is(err, gocb.ErrDocumentNotFound) {
 			return &state.GetResponse{}, nil
 		}
 		return nil, fmt.Errorf("couchbase error: failed to get value for key %s - %v", req.Key, err)
 	}
-	value, err := cbs.json.Marshal(&data)
 
+	var value []byte
+	err = result.Content(&value)
 	if err != nil {
 		return nil, fmt.Errorf("couchbase error: failed to convert value to byte[] - %v", err)
 	}
 
 	return &state.GetResponse{
 		Data: value,
-		ETag: fmt.Sprintf("%d", cas),
+		ETag: fmt.Sprintf("%d", result.Cas()),
 	}, nil
 }
 
@@ -217,11 +329,14 @@ func (cbs *Couchbase) Delete(req *state.DeleteRequest) error {
 			return fmt.Errorf("couchbase error: failed to delete key %s - %v", req.Key, err)
 		}
 	}
-	if req.Options.Consistency == state.Strong {
-		_, err = cbs.bucket.RemoveDura(req.Key, cas, cbs.numReplicasDurableReplication, cbs.numReplicasDurablePersistence)
-	} else {
-		_, err = cbs.bucket.Remove(req.Key, cas)
-	}
+
+	d := cbs.durability(req.Options.Consistency)
+	_, err = cbs.collection.Remove(req.Key, &gocb.RemoveOptions{
+		Cas:             cas,
+		DurabilityLevel: d.level,
+		ReplicateTo:     d.replicateTo,
+		PersistTo:       d.persistTo,
+	})
 	if err != nil {
 		return fmt.Errorf("couchbase error: failed to delete key %s - %v", req.Key, err)
 	}
@@ -241,10 +356,19 @@ func (cbs *Couchbase) BulkDelete(req []state.DeleteRequest) error {
 	return nil
 }
 
+// Close closes the connection to the cluster
+func (cbs *Couchbase) Close() error {
+	if cbs.cluster == nil {
+		return nil
+	}
+
+	return cbs.cluster.Close(nil)
+}
+
 //converts string etag sent by the application into a gocb.Cas object, which can then be used for optimistic locking for set and delete operations
 func eTagToCas(eTag string) (gocb.Cas, error) {
 	var cas gocb.Cas = 0
-	//CAS is a 64-bit integer - https://docs.couchbase.com/go-sdk/current/concurrent-mutations-cluster.html#cas-value-format
+	//CAS is a 64-bit integer - https://docs.couchbase.com/go-sdk/2.1/howtos/concurrent-document-mutations.html
 	temp, err := strconv.ParseUint(eTag, 10, 64)
 	if err != nil {
 		return cas, err
@@ -8,8 +8,9 @@ package couchbase
 import (
 	"testing"
 
+	"github.com/couchbase/gocb/v2"
+	"github.com/couchbase/gocbcore/v9"
 	"github.com/dapr/components-contrib/state"
-	"gopkg.in/couchbase/gocb.v1"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -92,6 +93,57 @@ func TestValidateMetadata(t *testing.T) {
 		err := validateMetadata(metadata)
 		assert.NotNil(t, err)
 	})
+	t.Run("with durability level, scope and collection", func(t *testing.T) {
+		props := map[string]string{
+			couchbaseURL:    "foo://bar",
+			username:        "kehsihba",
+			password:        "secret",
+			bucketName:      "testbucket",
+			scopeName:       "dapr",
+			collectionName:  "state",
+			durabilityLevel: durabilityLevelMajority,
+		}
+		metadata := state.Metadata{Properties: props}
+		err := validateMetadata(metadata)
+		assert.Equal(t, nil, err)
+	})
+	t.Run("With collection and no scope", func(t *testing.T) {
+		props := map[string]string{
+			couchbaseURL:   "foo://bar",
+			username:       "kehsihba",
+			password:       "secret",
+			bucketName:     "testbucket",
+			collectionName: "state",
+		}
+		metadata := state.Metadata{Properties: props}
+		err := validateMetadata(metadata)
+		assert.NotNil(t, err)
+	})
+	t.Run("With invalid durability level", func(t *testing.T) {
+		props := map[string]string{
+			couchbaseURL:    "foo://bar",
+			username:        "kehsihba",
+			password:        "secret",
+			bucketName:      "testbucket",
+			durabilityLevel: "all",
+		}
+		metadata := state.Metadata{Properties: props}
+		err := validateMetadata(metadata)
+		assert.NotNil(t, err)
+	})
+	t.Run("With durability level and durable replication", func(t *testing.T) {
+		props := map[string]string{
+			couchbaseURL:                  "foo://bar",
+			username:                      "kehsihba",
+			password:                      "secret",
+			bucketName:                    "testbucket",
+			durabilityLevel:               durabilityLevelPersistToMajority,
+			numReplicasDurableReplication: "1",
+		}
+		metadata := state.Metadata{Properties: props}
+		err := validateMetadata(metadata)
+		assert.NotNil(t, err)
+	})
 	t.Run("With invalid durable persistence", func(t *testing.T) {
 		props := map[string]string{
 			couchbaseURL:                  "foo://bar",
@@ -119,3 +171,45 @@ func TestETagToCas(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestDurability(t *testing.T) {
+	cbs := &Couchbase{
+		durabilityLevel:               gocb.DurabilityLevelMajority,
+		numReplicasDurableReplication: 1,
+	}
+
+	t.Run("strong consistency", func(t *testing.T) {
+		d := cbs.durability(state.Strong)
+		assert.Equal(t, gocb.DurabilityLevelMajority, d.level)
+		assert.Equal(t, uint(1), d.replicateTo)
+	})
+	t.Run("eventual consistency", func(t *testing.T) {
+		assert.Equal(t, durability{}, cbs.durability(state.Eventual))
+	})
+}
+
+func TestRawValueTranscoder(t *testing.T) {
+	t.Run("JSON value", func(t *testing.T) {
+		b, flags, err := valueTranscoder.Encode([]byte(`{"a":1}`))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(`{"a":1}`), b)
+		valueType, _ := gocbcore.DecodeCommonFlags(flags)
+		assert.Equal(t, gocbcore.JSONType, valueType)
+	})
+	t.Run("binary value", func(t *testing.T) {
+		_, flags, err := valueTranscoder.Encode([]byte("not json"))
+		assert.Nil(t, err)
+		valueType, _ := gocbcore.DecodeCommonFlags(flags)
+		assert.Equal(t, gocbcore.BinaryType, valueType)
+	})
+	t.Run("decode string document", func(t *testing.T) {
+		var value []byte
+		err := valueTranscoder.Decode([]byte("value"), gocbcore.EncodeCommonFlags(gocbcore.StringType, gocbcore.NoCompression), &value)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), value)
+	})
+	t.Run("encode non byte value", func(t *testing.T) {
+		_, _, err := valueTranscoder.Encode("value")
+		assert.NotNil(t, err)
+	})
+}
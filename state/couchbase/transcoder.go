@@ -0,0 +1,50 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package couchbase
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/couchbase/gocbcore/v9"
+)
+
+var valueTranscoder = &rawValueTranscoder{}
+
+// rawValueTranscoder stores state values as they are given, as JSON documents when they are valid JSON
+// so that they can be queried, and as binary documents otherwise. Values are read back unchanged whatever
+// their format, including the string documents written by earlier versions of this store.
+type rawValueTranscoder struct{}
+
+// Decode implements gocb.Transcoder
+func (t *rawValueTranscoder) Decode(bytes []byte, flags uint32, out interface{}) error {
+	_, compression := gocbcore.DecodeCommonFlags(flags)
+	if compression != gocbcore.NoCompression {
+		return errors.New("unexpected value compression")
+	}
+
+	typedOut, ok := out.(*[]byte)
+	if !ok {
+		return errors.New("values must be decoded into a byte array")
+	}
+
+	*typedOut = bytes
+	return nil
+}
+
+// Encode implements gocb.Transcoder
+func (t *rawValueTranscoder) Encode(value interface{}) ([]byte, uint32, error) {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil, 0, errors.New("values must be encoded from a byte array")
+	}
+
+	if json.Valid(bytes) {
+		return bytes, gocbcore.EncodeCommonFlags(gocbcore.JSONType, gocbcore.NoCompression), nil
+	}
+
+	return bytes, gocbcore.EncodeCommonFlags(gocbcore.BinaryType, gocbcore.NoCompression), nil
+}
@@ -0,0 +1,273 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+)
+
+const (
+	// PrimaryEncryptionKey is the metadata property of the hex encoded AES key that values are encrypted with
+	PrimaryEncryptionKey = "primaryEncryptionKey"
+	// SecondaryEncryptionKey is the metadata property of a previous hex encoded AES key, which values can still be decrypted with
+	SecondaryEncryptionKey = "secondaryEncryptionKey"
+)
+
+// EncryptedStore encrypts values with AES-GCM before they are written to a state store, and decrypts them when they are read.
+// Values are encrypted with the primary key. The secondary key allows values written before the keys were rotated to still be read.
+type EncryptedStore struct {
+	store     Store
+	primary   cipher.AEAD
+	secondary cipher.AEAD
+}
+
+// EncryptedTransactionalStore is an EncryptedStore for a TransactionalStore
+type EncryptedTransactionalStore struct {
+	*EncryptedStore
+	transactional TransactionalStore
+}
+
+// NewEncryptedStore wraps store so that its values are encrypted.
+// The result is an *EncryptedTransactionalStore when store is a TransactionalStore, and an *EncryptedStore otherwise.
+func NewEncryptedStore(store Store) Store {
+	s := &EncryptedStore{store: store}
+	if transactional, ok := store.(TransactionalStore); ok {
+		return &EncryptedTransactionalStore{
+			EncryptedStore: s,
+			transactional:  transactional,
+		}
+	}
+
+	return s
+}
+
+// Init reads the encryption keys from metadata and initializes the wrapped store
+func (s *EncryptedStore) Init(metadata Metadata) error {
+	primary, ok := metadata.Properties[PrimaryEncryptionKey]
+	if !ok || primary == "" {
+		return fmt.Errorf("missing %s in metadata", PrimaryEncryptionKey)
+	}
+
+	var err error
+	s.primary, err = newAEAD(primary)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %s", PrimaryEncryptionKey, err)
+	}
+
+	s.secondary = nil
+	if secondary := metadata.Properties[SecondaryEncryptionKey]; secondary != "" {
+		s.secondary, err = newAEAD(secondary)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", SecondaryEncryptionKey, err)
+		}
+	}
+
+	return s.store.Init(metadata)
+}
+
+// GetComponentMetadata returns the metadata of the wrapped store with the encryption keys. The encrypted store can't
+// be queried, as the wrapped store can't filter the encrypted values.
+func (s *EncryptedStore) GetComponentMetadata() metadata.ComponentMetadata {
+	m := s.store.GetComponentMetadata().WithoutCapability(metadata.CapabilityQuery)
+	m.Fields = append(m.Fields,
+		metadata.Field{Name: PrimaryEncryptionKey, Type: metadata.TypeString, Required: true},
+		metadata.Field{Name: SecondaryEncryptionKey, Type: metadata.TypeString})
+	return m
+}
+
+// Ping pings the wrapped store
+func (s *EncryptedStore) Ping() error {
+	return health.Ping(s.store)
+}
+
+// Get gets and decrypts the value of a key
+func (s *EncryptedStore) Get(req *GetRequest) (*GetResponse, error) {
+	resp, err := s.store.Get(req)
+	if err != nil || resp == nil || resp.Data == nil {
+		return resp, err
+	}
+
+	resp.Data, err = s.decrypt(req.Key, resp.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the value of key %s: %s", req.Key, err)
+	}
+
+	return resp, nil
+}
+
+// BulkGet gets and decrypts the values of keys. The wrapped store is used when it is a BulkStore,
+// otherwise the keys are got with a DefaultBulkStore.
+func (s *EncryptedStore) BulkGet(req []GetRequest) ([]BulkGetResponse, error) {
+	bulk, ok := s.store.(BulkStore)
+	if !ok {
+		bulk = NewDefaultBulkStore(s.store, DefaultBulkParallelism)
+	}
+
+	responses, err := bulk.BulkGet(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range responses {
+		if responses[i].Error != "" || responses[i].Data == nil {
+			continue
+		}
+
+		data, err := s.decrypt(responses[i].Key, responses[i].Data)
+		if err != nil {
+			responses[i].Data = nil
+			responses[i].ETag = ""
+			responses[i].Error = fmt.Sprintf("failed to decrypt the value of key %s: %s", responses[i].Key, err)
+			continue
+		}
+		responses[i].Data = data
+	}
+
+	return responses, nil
+}
+
+// Set encrypts and sets the value of a key
+func (s *EncryptedStore) Set(req *SetRequest) error {
+	encrypted, err := s.encryptRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Set(encrypted)
+}
+
+// BulkSet encrypts and sets the values of keys
+func (s *EncryptedStore) BulkSet(req []SetRequest) error {
+	encrypted := make([]SetRequest, 0, len(req))
+	for i := range req {
+		r, err := s.encryptRequest(&req[i])
+		if err != nil {
+			return err
+		}
+		encrypted = append(encrypted, *r)
+	}
+
+	return s.store.BulkSet(encrypted)
+}
+
+// Delete deletes a key
+func (s *EncryptedStore) Delete(req *DeleteRequest) error {
+	return s.store.Delete(req)
+}
+
+// BulkDelete deletes keys
+func (s *EncryptedStore) BulkDelete(req []DeleteRequest) error {
+	return s.store.BulkDelete(req)
+}
+
+// Multi encrypts the values of the upserts and runs the requests in a transaction of the wrapped store
+func (s *EncryptedTransactionalStore) Multi(reqs []TransactionalRequest) error {
+	encrypted := make([]TransactionalRequest, 0, len(reqs))
+	for _, req := range reqs {
+		if setReq, ok := req.Request.(SetRequest); ok && req.Operation == Upsert {
+			r, err := s.encryptRequest(&setReq)
+			if err != nil {
+				return err
+			}
+			req.Request = *r
+		}
+		encrypted = append(encrypted, req)
+	}
+
+	return s.transactional.Multi(encrypted)
+}
+
+// encryptRequest returns a copy of req with an encrypted value
+func (s *EncryptedStore) encryptRequest(req *SetRequest) (*SetRequest, error) {
+	var plaintext []byte
+	switch v := req.Value.(type) {
+	case []byte:
+		plaintext = v
+	default:
+		var err error
+		plaintext, err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ciphertext, err := s.encrypt(req.Key, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt the value of key %s: %s", req.Key, err)
+	}
+
+	encrypted := *req
+	encrypted.Value = ciphertext
+	return &encrypted, nil
+}
+
+// encrypt returns the base64 encoding of a random nonce followed by the plaintext sealed with the state key as
+// additional data, so that the value of a key can't be read as the value of another key.
+// The encoding keeps the value intact in stores that treat values as text.
+func (s *EncryptedStore) encrypt(key string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.primary.NonceSize())
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := s.primary.Seal(nonce, nonce, plaintext, []byte(key))
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(sealed)))
+	base64.StdEncoding.Encode(encoded, sealed)
+	return encoded, nil
+}
+
+// decrypt opens a value of the state key encrypted with the primary key, or with the secondary key.
+func (s *EncryptedStore) decrypt(key string, value []byte) ([]byte, error) {
+	sealed := make([]byte, base64.StdEncoding.DecodedLen(len(value)))
+	n, err := base64.StdEncoding.Decode(sealed, value)
+	if err != nil {
+		return nil, fmt.Errorf("value is not encrypted: %s", err)
+	}
+	sealed = sealed[:n]
+
+	plaintext, err := open(s.primary, key, sealed)
+	if err != nil && s.secondary != nil {
+		plaintext, err = open(s.secondary, key, sealed)
+	}
+
+	return plaintext, err
+}
+
+func open(aead cipher.AEAD, key string, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("value is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, []byte(key))
+}
+
+// newAEAD returns AES-GCM for a hex encoded 128, 192, or 256 bit key.
+func newAEAD(key string) (cipher.AEAD, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
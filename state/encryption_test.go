@@ -0,0 +1,203 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package state
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testKey1 = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	testKey2 = "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"
+)
+
+// transactionalFakeStore is a fakeStore with working bulk sets and transactions
+type transactionalFakeStore struct {
+	*fakeStore
+}
+
+func (f *transactionalFakeStore) BulkSet(req []SetRequest) error {
+	for i := range req {
+		err := f.Set(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *transactionalFakeStore) Multi(reqs []TransactionalRequest) error {
+	for _, req := range reqs {
+		switch r := req.Request.(type) {
+		case SetRequest:
+			err := f.Set(&r)
+			if err != nil {
+				return err
+			}
+		case DeleteRequest:
+			err := f.Delete(&r)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newEncryptedFakeStore(t *testing.T, properties map[string]string) (*EncryptedTransactionalStore, *fakeStore) {
+	f := newFakeStore()
+	s, ok := NewEncryptedStore(&transactionalFakeStore{fakeStore: f}).(*EncryptedTransactionalStore)
+	assert.True(t, ok)
+	assert.Nil(t, s.Init(Metadata{Properties: properties}))
+	return s, f
+}
+
+func TestEncryptedStoreInit(t *testing.T) {
+	invalid := map[string]map[string]string{
+		"Without primary key":        {},
+		"With non-hex primary key":   {PrimaryEncryptionKey: "not hex"},
+		"With short primary key":     {PrimaryEncryptionKey: "0001"},
+		"With invalid secondary key": {PrimaryEncryptionKey: testKey1, SecondaryEncryptionKey: "0001"},
+	}
+	for name, properties := range invalid {
+		properties := properties
+		t.Run(name, func(t *testing.T) {
+			s := NewEncryptedStore(newFakeStore())
+			assert.NotNil(t, s.Init(Metadata{Properties: properties}))
+		})
+	}
+
+	t.Run("Non-transactional store", func(t *testing.T) {
+		s := NewEncryptedStore(newFakeStore())
+		_, ok := s.(TransactionalStore)
+		assert.False(t, ok)
+	})
+
+	t.Run("Queriable store", func(t *testing.T) {
+		s := NewEncryptedStore(&queriableFakeStore{fakeStore: newFakeStore()})
+		_, ok := s.(Querier)
+		assert.False(t, ok)
+		assert.False(t, s.GetComponentMetadata().HasCapability(metadata.CapabilityQuery))
+		assert.True(t, s.GetComponentMetadata().HasCapability(metadata.CapabilityETag))
+	})
+}
+
+func TestEncryptedStore(t *testing.T) {
+	t.Run("Values are stored encrypted", func(t *testing.T) {
+		s, f := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+
+		assert.Nil(t, s.Set(&SetRequest{Key: "key", Value: []byte("secret")}))
+		assert.NotContains(t, string(f.values["key"]), "secret")
+
+		resp, err := s.Get(&GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", string(resp.Data))
+	})
+
+	t.Run("Non-byte values are encrypted as JSON", func(t *testing.T) {
+		s, _ := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+
+		assert.Nil(t, s.Set(&SetRequest{Key: "key", Value: struct{ Color string }{Color: "red"}}))
+		resp, err := s.Get(&GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, `{"Color":"red"}`, string(resp.Data))
+	})
+
+	t.Run("Missing keys are not decrypted", func(t *testing.T) {
+		s, _ := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+
+		resp, err := s.Get(&GetRequest{Key: "missing"})
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("Values written with the secondary key can be read after rotation", func(t *testing.T) {
+		s, f := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+		assert.Nil(t, s.Set(&SetRequest{Key: "key", Value: []byte("secret")}))
+
+		rotated := NewEncryptedStore(f)
+		assert.Nil(t, rotated.Init(Metadata{Properties: map[string]string{
+			PrimaryEncryptionKey:   testKey2,
+			SecondaryEncryptionKey: testKey1,
+		}}))
+		resp, err := rotated.Get(&GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", string(resp.Data))
+
+		other := NewEncryptedStore(f)
+		assert.Nil(t, other.Init(Metadata{Properties: map[string]string{PrimaryEncryptionKey: testKey2}}))
+		_, err = other.Get(&GetRequest{Key: "key"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Values moved to another key fail to decrypt", func(t *testing.T) {
+		s, f := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+		assert.Nil(t, s.Set(&SetRequest{Key: "key1", Value: []byte("secret1")}))
+		assert.Nil(t, s.Set(&SetRequest{Key: "key2", Value: []byte("secret2")}))
+		f.values["key1"], f.values["key2"] = f.values["key2"], f.values["key1"]
+
+		_, err := s.Get(&GetRequest{Key: "key1"})
+		assert.NotNil(t, err)
+		responses, err := s.BulkGet([]GetRequest{{Key: "key2"}})
+		assert.Nil(t, err)
+		assert.Nil(t, responses[0].Data)
+		assert.NotEmpty(t, responses[0].Error)
+	})
+
+	t.Run("Plaintext values fail to decrypt", func(t *testing.T) {
+		s, f := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+		f.values["key"] = []byte("plain")
+
+		_, err := s.Get(&GetRequest{Key: "key"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Bulk set and bulk get", func(t *testing.T) {
+		s, f := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+
+		sets := make([]SetRequest, 5)
+		gets := make([]GetRequest, 5)
+		for i := range sets {
+			key := fmt.Sprintf("key%d", i)
+			sets[i] = SetRequest{Key: key, Value: []byte("secret" + key)}
+			gets[i] = GetRequest{Key: key}
+		}
+		assert.Nil(t, s.BulkSet(sets))
+		for _, v := range f.values {
+			assert.False(t, strings.HasPrefix(string(v), "secret"))
+		}
+
+		f.values["key4"] = []byte("plain")
+		responses, err := s.BulkGet(gets)
+		assert.Nil(t, err)
+		for i := 0; i < 4; i++ {
+			assert.Equal(t, "secret"+gets[i].Key, string(responses[i].Data))
+		}
+		assert.Nil(t, responses[4].Data)
+		assert.NotEqual(t, "", responses[4].Error)
+	})
+
+	t.Run("Multi encrypts upserts", func(t *testing.T) {
+		s, f := newEncryptedFakeStore(t, map[string]string{PrimaryEncryptionKey: testKey1})
+		f.values["old"] = []byte("x")
+
+		err := s.Multi([]TransactionalRequest{
+			{Operation: Upsert, Request: SetRequest{Key: "key", Value: []byte("secret")}},
+			{Operation: Delete, Request: DeleteRequest{Key: "old"}},
+		})
+		assert.Nil(t, err)
+		assert.NotContains(t, string(f.values["key"]), "secret")
+		assert.NotContains(t, f.values, "old")
+
+		resp, err := s.Get(&GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", string(resp.Data))
+	})
+}
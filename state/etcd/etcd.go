@@ -10,9 +10,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	jsoniter "github.com/json-iterator/go"
@@ -22,9 +24,11 @@ import (
 
 const defaultOperationTimeout = 10 * time.Second
 const defaultSeparator = ","
+const ttlInSecondsKey = "ttlInSeconds"
 
 var errMissingEndpoints = errors.New("endpoints are required")
 var errInvalidDialTimeout = errors.New("DialTimeout is invalid")
+var errETagMismatch = errors.New("possible etag mismatch")
 
 // ETCD is a state store
 type ETCD struct {
@@ -52,6 +56,10 @@ func NewETCD(logger logger.Logger) *ETCD {
 
 // Init does metadata and connection parsing
 func (r *ETCD) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	cp, err := toConfigProperties(metadata.Properties)
 	if err != nil {
 		return err
@@ -73,16 +81,27 @@ func (r *ETCD) Init(metadata state.Metadata) error {
 
 	r.client = client
 
-	ot := defaultOperationTimeout
+	r.operationTimeout = defaultOperationTimeout
 	newOt, err := time.ParseDuration(cp.OperationTimeout)
 	if err == nil {
 		r.operationTimeout = newOt
 	}
-	r.operationTimeout = ot
 
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the etcd state store
+func (r *ETCD) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: "endpoints", Type: metadata.TypeList, Required: true},
+			{Name: "dialTimeout", Type: metadata.TypeDuration, Required: true},
+			{Name: "operationTimeout", Type: metadata.TypeDuration, Default: defaultOperationTimeout.String()},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL, metadata.CapabilityTransactional},
+	}
+}
+
 func toConfigProperties(properties map[string]string) (*configProperties, error) {
 	b, err := json.Marshal(properties)
 	if err != nil {
@@ -131,7 +150,7 @@ func validateRequired(configProps *configProperties) error {
 func (r *ETCD) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), r.operationTimeout)
 	defer cancel()
-	resp, err := r.client.Get(ctx, req.Key, clientv3.WithSort(clientv3.SortByVersion, clientv3.SortDescend))
+	resp, err := r.client.Get(ctx, req.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -140,9 +159,10 @@ func (r *ETCD) Get(req *state.GetRequest) (*state.GetResponse, error) {
 		return &state.GetResponse{}, nil
 	}
 
+	// The mod revision changes on every write of the key, unlike the version which restarts when a key is recreated
 	return &state.GetResponse{
 		Data: resp.Kvs[0].Value,
-		ETag: fmt.Sprintf("%d", resp.Kvs[0].Version),
+		ETag: strconv.FormatInt(resp.Kvs[0].ModRevision, 10),
 	}, nil
 }
 
@@ -156,20 +176,12 @@ func (r *ETCD) Delete(req *state.DeleteRequest) error {
 	ctx, cancelFn := context.WithTimeout(context.Background(), r.operationTimeout)
 	defer cancelFn()
 
-	version := req.ETag
-	//honor client etag
-	if version != "" {
-		txn := r.client.KV.Txn(ctx)
-		_, err = txn.If(clientv3.Compare(clientv3.Version(req.Key), "=", version)).Then(clientv3.OpDelete(req.Key)).Commit()
-	} else {
-		_, err = r.client.Delete(ctx, req.Key)
-	}
-
+	op, cmps, err := r.deleteOp(req)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return r.commit(ctx, cmps, []clientv3.Op{op})
 }
 
 // BulkDelete performs a bulk delete operation
@@ -192,6 +204,82 @@ func (r *ETCD) Set(req *state.SetRequest) error {
 	}
 	ctx, cancelFn := context.WithTimeout(context.Background(), r.operationTimeout)
 	defer cancelFn()
+
+	op, cmps, err := r.putOp(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return r.commit(ctx, cmps, []clientv3.Op{op})
+}
+
+// BulkSet performs a bulks save operation
+func (r *ETCD) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		err := r.Set(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Multi performs the set and delete operations in a single etcd transaction,
+// which only succeeds if the etags of all the requests match
+func (r *ETCD) Multi(reqs []state.TransactionalRequest) error {
+	ctx, cancelFn := context.WithTimeout(context.Background(), r.operationTimeout)
+	defer cancelFn()
+
+	var cmps []clientv3.Cmp
+	ops := make([]clientv3.Op, 0, len(reqs))
+	for _, o := range reqs {
+		var op clientv3.Op
+		var opCmps []clientv3.Cmp
+		var err error
+
+		switch o.Operation {
+		case state.Upsert:
+			req, ok := o.Request.(state.SetRequest)
+			if !ok {
+				return fmt.Errorf("expecting set request")
+			}
+			err = state.CheckSetRequestOptions(&req)
+			if err != nil {
+				return err
+			}
+			op, opCmps, err = r.putOp(ctx, &req)
+		case state.Delete:
+			req, ok := o.Request.(state.DeleteRequest)
+			if !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+			err = state.CheckDeleteRequestOptions(&req)
+			if err != nil {
+				return err
+			}
+			op, opCmps, err = r.deleteOp(&req)
+		default:
+			return fmt.Errorf("unsupported operation: %s", o.Operation)
+		}
+		if err != nil {
+			return err
+		}
+
+		ops = append(ops, op)
+		cmps = append(cmps, opCmps...)
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	return r.commit(ctx, cmps, ops)
+}
+
+// putOp returns the put operation for a set request and the comparisons that must hold for it to be applied.
+// Values with a TTL are attached to a new lease, which etcd revokes along with the value when it expires.
+func (r *ETCD) putOp(ctx context.Context, req *state.SetRequest) (clientv3.Op, []clientv3.Cmp, error) {
 	var vStr string
 	b, ok := req.Value.([]byte)
 	if ok {
@@ -200,29 +288,96 @@ func (r *ETCD) Set(req *state.SetRequest) error {
 		vStr, _ = r.json.MarshalToString(req.Value)
 	}
 
-	version := req.ETag
-	//honor client etag
-	if version != "" {
-		txn := r.client.KV.Txn(ctx)
-		_, err = txn.If(clientv3.Compare(clientv3.Version(req.Key), "=", version)).Then(clientv3.OpPut(req.Key, vStr)).Commit()
-	} else {
-		_, err = r.client.Put(ctx, req.Key, vStr)
+	cmps, err := concurrencyCmps(req.Key, req.ETag, req.Options.Concurrency)
+	if err != nil {
+		return clientv3.Op{}, nil, err
 	}
 
+	ttl, err := parseTTL(req.Metadata)
+	if err != nil {
+		return clientv3.Op{}, nil, err
+	}
+
+	if ttl > 0 {
+		lease, err := r.client.Grant(ctx, ttl)
+		if err != nil {
+			return clientv3.Op{}, nil, fmt.Errorf("failed to create lease for key %s: %s", req.Key, err)
+		}
+
+		return clientv3.OpPut(req.Key, vStr, clientv3.WithLease(lease.ID)), cmps, nil
+	}
+
+	return clientv3.OpPut(req.Key, vStr), cmps, nil
+}
+
+// deleteOp returns the delete operation for a delete request and the comparisons that must hold for it to be applied.
+func (r *ETCD) deleteOp(req *state.DeleteRequest) (clientv3.Op, []clientv3.Cmp, error) {
+	cmps, err := concurrencyCmps(req.Key, req.ETag, "")
+	if err != nil {
+		return clientv3.Op{}, nil, err
+	}
+
+	return clientv3.OpDelete(req.Key), cmps, nil
+}
+
+// commit applies the operations in a transaction if all the comparisons hold.
+func (r *ETCD) commit(ctx context.Context, cmps []clientv3.Cmp, ops []clientv3.Op) error {
+	resp, err := r.client.Txn(ctx).If(cmps...).Then(ops...).Commit()
 	if err != nil {
 		return err
 	}
+
+	if !resp.Succeeded {
+		return errETagMismatch
+	}
+
 	return nil
 }
 
-// BulkSet performs a bulks save operation
-func (r *ETCD) BulkSet(req []state.SetRequest) error {
-	for i := range req {
-		err := r.Set(&req[i])
+// concurrencyCmps returns the comparisons that honor the client etag. With first-write concurrency and
+// no etag, the key must not exist yet.
+func concurrencyCmps(key string, etag string, concurrency string) ([]clientv3.Cmp, error) {
+	if etag != "" {
+		modRevision, err := strconv.ParseInt(etag, 10, 64)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("invalid etag '%s' for key %s", etag, key)
 		}
+
+		return []clientv3.Cmp{clientv3.Compare(clientv3.ModRevision(key), "=", modRevision)}, nil
 	}
 
-	return nil
+	if concurrency == state.FirstWrite {
+		return []clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(key), "=", 0)}, nil
+	}
+
+	return nil, nil
+}
+
+// parseTTL returns the number of seconds until a value expires from the metadata of a set request.
+// Values without a ttlInSeconds, or with a value that is not positive, never expire.
+func parseTTL(requestMetadata map[string]string) (int64, error) {
+	val, ok := requestMetadata[ttlInSecondsKey]
+	if !ok || val == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s', must be an integer", ttlInSecondsKey, val)
+	}
+
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+// Close closes the client connection
+func (r *ETCD) Close() error {
+	if r.client == nil {
+		return nil
+	}
+
+	return r.client.Close()
 }
@@ -0,0 +1,95 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package etcd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	endpointsEnvKey = "DAPR_TEST_ETCD_ENDPOINTS" // Environment variable containing the etcd endpoints
+)
+
+func TestETCDIntegration(t *testing.T) {
+	endpoints := os.Getenv(endpointsEnvKey)
+	if endpoints == "" {
+		t.Skipf("etcd state integration tests skipped. To enable define the endpoints using environment variable '%s' (example 'export %s=\"localhost:2379\")", endpointsEnvKey, endpointsEnvKey)
+	}
+
+	r := NewETCD(logger.NewLogger("test"))
+	err := r.Init(state.Metadata{
+		Properties: map[string]string{
+			"endpoints":   endpoints,
+			"dialTimeout": "5s",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	t.Run("Set with old etag fails", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, r.Set(&state.SetRequest{Key: key, Value: "v1"}))
+
+		resp, err := r.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		assert.Equal(t, `"v1"`, string(resp.Data))
+		oldETag := resp.ETag
+
+		assert.Nil(t, r.Set(&state.SetRequest{Key: key, Value: "v2", ETag: oldETag}))
+		err = r.Set(&state.SetRequest{Key: key, Value: "v3", ETag: oldETag})
+		assert.Equal(t, errETagMismatch, err)
+
+		err = r.Delete(&state.DeleteRequest{Key: key, ETag: oldETag})
+		assert.Equal(t, errETagMismatch, err)
+		assert.Nil(t, r.Delete(&state.DeleteRequest{Key: key}))
+	})
+
+	t.Run("Set with TTL expires", func(t *testing.T) {
+		key := uuid.New().String()
+		err := r.Set(&state.SetRequest{Key: key, Value: "v", Metadata: map[string]string{ttlInSecondsKey: "1"}})
+		assert.Nil(t, err)
+
+		assert.Eventually(t, func() bool {
+			resp, err := r.Get(&state.GetRequest{Key: key})
+			return err == nil && resp.Data == nil
+		}, 10*time.Second, 500*time.Millisecond)
+	})
+
+	t.Run("Multi is atomic", func(t *testing.T) {
+		key1, key2 := uuid.New().String(), uuid.New().String()
+		assert.Nil(t, r.Set(&state.SetRequest{Key: key1, Value: "v"}))
+
+		err := r.Multi([]state.TransactionalRequest{
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: key1}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: key2, Value: "v", ETag: "1"}},
+		})
+		assert.Equal(t, errETagMismatch, err)
+
+		resp, err := r.Get(&state.GetRequest{Key: key1})
+		assert.Nil(t, err)
+		assert.NotNil(t, resp.Data)
+
+		err = r.Multi([]state.TransactionalRequest{
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: key1}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: key2, Value: "v"}},
+		})
+		assert.Nil(t, err)
+
+		resp, err = r.Get(&state.GetRequest{Key: key2})
+		assert.Nil(t, err)
+		assert.NotNil(t, resp.Data)
+		assert.Nil(t, r.Delete(&state.DeleteRequest{Key: key2}))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/dapr/components-contrib/state"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,3 +70,69 @@ func TestValidateRequired(t *testing.T) {
 		assert.NotNil(t, err, "failed to get invalid dialTimeout error")
 	})
 }
+
+// concurrencyCmps
+func TestConcurrencyCmps(t *testing.T) {
+	t.Run("Without etag", func(t *testing.T) {
+		cmps, err := concurrencyCmps("key", "", state.LastWrite)
+		assert.Nil(t, err)
+		assert.Empty(t, cmps)
+	})
+	t.Run("With etag compares the mod revision", func(t *testing.T) {
+		cmps, err := concurrencyCmps("key", "5", "")
+		assert.Nil(t, err)
+		assert.Len(t, cmps, 1)
+		assert.Equal(t, "MOD", cmps[0].Target.String())
+		assert.Equal(t, []byte("key"), cmps[0].Key)
+	})
+	t.Run("First write without etag requires a new key", func(t *testing.T) {
+		cmps, err := concurrencyCmps("key", "", state.FirstWrite)
+		assert.Nil(t, err)
+		assert.Len(t, cmps, 1)
+		assert.Equal(t, "CREATE", cmps[0].Target.String())
+	})
+	t.Run("With invalid etag", func(t *testing.T) {
+		_, err := concurrencyCmps("key", "abc", "")
+		assert.NotNil(t, err)
+	})
+}
+
+// parseTTL
+func TestParseTTL(t *testing.T) {
+	t.Run("Without TTL", func(t *testing.T) {
+		ttl, err := parseTTL(map[string]string{})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), ttl)
+	})
+	t.Run("With TTL", func(t *testing.T) {
+		ttl, err := parseTTL(map[string]string{ttlInSecondsKey: "30"})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(30), ttl)
+	})
+	t.Run("With negative TTL", func(t *testing.T) {
+		ttl, err := parseTTL(map[string]string{ttlInSecondsKey: "-1"})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), ttl)
+	})
+	t.Run("With invalid TTL", func(t *testing.T) {
+		_, err := parseTTL(map[string]string{ttlInSecondsKey: "1m"})
+		assert.NotNil(t, err)
+	})
+}
+
+// Multi
+func TestMultiValidation(t *testing.T) {
+	r := NewETCD(nil)
+	t.Run("With wrong request type", func(t *testing.T) {
+		err := r.Multi([]state.TransactionalRequest{{Operation: state.Upsert, Request: state.DeleteRequest{Key: "key"}}})
+		assert.Equal(t, fmt.Errorf("expecting set request"), err)
+	})
+	t.Run("With unsupported operation", func(t *testing.T) {
+		err := r.Multi([]state.TransactionalRequest{{Operation: "get", Request: state.SetRequest{Key: "key"}}})
+		assert.Equal(t, fmt.Errorf("unsupported operation: get"), err)
+	})
+	t.Run("Without requests", func(t *testing.T) {
+		err := r.Multi(nil)
+		assert.Nil(t, err)
+	})
+}
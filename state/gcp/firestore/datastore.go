@@ -0,0 +1,57 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package firestore
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/datastore"
+	"github.com/dapr/components-contrib/state"
+	"google.golang.org/api/option"
+)
+
+// datastoreBackend stores the values as entities of a kind in a Firestore database in Datastore mode.
+// The entities have no update time, so the values have no etags.
+type datastoreBackend struct {
+	client     *datastore.Client
+	entityKind string
+}
+
+func newDatastoreBackend(ctx context.Context, meta *firestoreMetadata, opts []option.ClientOption) (*datastoreBackend, error) {
+	client, err := datastore.NewClient(ctx, meta.ProjectID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastoreBackend{client: client, entityKind: meta.Collection}, nil
+}
+
+func (d *datastoreBackend) get(ctx context.Context, key string) (*state.GetResponse, error) {
+	var entity StateEntity
+	err := d.client.Get(ctx, datastore.NameKey(d.entityKind, key, nil), &entity)
+	if err != nil {
+		if errors.Is(err, datastore.ErrNoSuchEntity) {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+
+	return &state.GetResponse{Data: []byte(entity.Value)}, nil
+}
+
+func (d *datastoreBackend) set(ctx context.Context, req *state.SetRequest, value string) error {
+	_, err := d.client.Put(ctx, datastore.NameKey(d.entityKind, req.Key, nil), &StateEntity{Value: value})
+	return err
+}
+
+func (d *datastoreBackend) delete(ctx context.Context, req *state.DeleteRequest) error {
+	return d.client.Delete(ctx, datastore.NameKey(d.entityKind, req.Key, nil))
+}
+
+func (d *datastoreBackend) close() error {
+	return d.client.Close()
+}
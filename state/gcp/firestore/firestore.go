@@ -8,29 +8,43 @@ package firestore
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 
-	"cloud.google.com/go/datastore"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	jsoniter "github.com/json-iterator/go"
 	"google.golang.org/api/option"
 )
 
-const defaultEntityKind = "DaprState"
+const (
+	defaultCollection = "DaprState"
+
+	// The modes of the Firestore databases. Datastore mode is the default, as the component stored its values
+	// in Datastore mode before Native mode was supported.
+	datastoreMode = "datastore"
+	nativeMode    = "native"
+)
 
 // Firestore State Store
 type Firestore struct {
-	client     *datastore.Client
-	entityKind string
+	backend backend
+	mode    string
 
 	logger logger.Logger
 }
 
+// backend stores the values in a Firestore database of a mode
+type backend interface {
+	get(ctx context.Context, key string) (*state.GetResponse, error)
+	set(ctx context.Context, req *state.SetRequest, value string) error
+	delete(ctx context.Context, req *state.DeleteRequest) error
+	close() error
+}
+
 type firestoreMetadata struct {
 	Type                string `json:"type"`
-	ProjectID           string `json:"project_id"`
+	ProjectID           string `json:"project_id" metadata:",required"`
 	PrivateKeyID        string `json:"private_key_id"`
 	PrivateKey          string `json:"private_key"`
 	ClientEmail         string `json:"client_email"`
@@ -39,11 +53,15 @@ type firestoreMetadata struct {
 	TokenURI            string `json:"token_uri"`
 	AuthProviderCertURL string `json:"auth_provider_x509_cert_url"`
 	ClientCertURL       string `json:"client_x509_cert_url"`
-	EntityKind          string `json:"entity_kind"`
+	// Mode is the mode of the database, datastore or native
+	Mode string `json:"-" metadata:"mode"`
+	// Collection is the entity kind of the values in Datastore mode, and their collection in Native mode
+	Collection string `json:"-" metadata:"collection,alias=entity_kind"`
 }
 
+// StateEntity is the entity, or the document in Native mode, stored for each key
 type StateEntity struct {
-	Value string
+	Value string `firestore:"value"`
 }
 
 func NewFirestoreStateStore(logger logger.Logger) *Firestore {
@@ -52,44 +70,57 @@ func NewFirestoreStateStore(logger logger.Logger) *Firestore {
 
 // Init does metadata and connection parsing
 func (f *Firestore) Init(metadata state.Metadata) error {
-	meta, err := getFirestoreMetadata(metadata)
-	if err != nil {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
 		return err
 	}
-	b, err := json.Marshal(meta)
+
+	meta, err := getFirestoreMetadata(metadata)
 	if err != nil {
 		return err
 	}
 
-	opt := option.WithCredentialsJSON(b)
+	var opts []option.ClientOption
+	if meta.hasServiceAccount() {
+		b, err := json.Marshal(meta)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, option.WithCredentialsJSON(b))
+	} else {
+		// Without a service account key the application default credentials are used,
+		// which includes workload identity when running on GKE
+		f.logger.Debug("no service account key configured, using application default credentials")
+	}
+
 	ctx := context.Background()
-	client, err := datastore.NewClient(ctx, meta.ProjectID, opt)
+	if meta.Mode == nativeMode {
+		f.backend, err = newNativeBackend(ctx, meta, opts)
+	} else {
+		f.backend, err = newDatastoreBackend(ctx, meta, opts)
+	}
 	if err != nil {
 		return err
 	}
 
-	f.client = client
-	f.entityKind = meta.EntityKind
+	f.mode = meta.Mode
 	return nil
 }
 
-// Get retrieves state from Firestore with a key (Always strong consistency)
-func (f *Firestore) Get(req *state.GetRequest) (*state.GetResponse, error) {
-	key := req.Key
-
-	entityKey := datastore.NameKey(f.entityKind, key, nil)
-	var entity StateEntity
-	err := f.client.Get(context.Background(), entityKey, &entity)
-
-	if err != nil && !errors.Is(err, datastore.ErrNoSuchEntity) {
-		return nil, err
-	} else if errors.Is(err, datastore.ErrNoSuchEntity) {
-		return &state.GetResponse{}, nil
+// GetComponentMetadata returns the metadata fields and the capabilities of the Firestore state store.
+// The values have etags in Native mode only.
+func (f *Firestore) GetComponentMetadata() metadata.ComponentMetadata {
+	m := metadata.ComponentMetadata{
+		Fields: metadata.FieldsOf(firestoreMetadata{Mode: datastoreMode, Collection: defaultCollection}),
 	}
+	if f.mode == nativeMode {
+		m.Capabilities = []metadata.Capability{metadata.CapabilityETag}
+	}
+	return m
+}
 
-	return &state.GetResponse{
-		Data: []byte(entity.Value),
-	}, nil
+// Get retrieves state from Firestore with a key (Always strong consistency)
+func (f *Firestore) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return f.backend.get(context.Background(), req.Key)
 }
 
 func (f *Firestore) setValue(req *state.SetRequest) error {
@@ -106,18 +137,7 @@ func (f *Firestore) setValue(req *state.SetRequest) error {
 		v, _ = jsoniter.MarshalToString(req.Value)
 	}
 
-	entity := &StateEntity{
-		Value: v,
-	}
-	ctx := context.Background()
-	key := datastore.NameKey(f.entityKind, req.Key, nil)
-
-	_, err = f.client.Put(ctx, key, entity)
-
-	if err != nil {
-		return err
-	}
-	return nil
+	return f.backend.set(context.Background(), req, v)
 }
 
 // Set saves state into Firestore with retry
@@ -137,15 +157,12 @@ func (f *Firestore) BulkSet(req []state.SetRequest) error {
 }
 
 func (f *Firestore) deleteValue(req *state.DeleteRequest) error {
-	ctx := context.Background()
-	key := datastore.NameKey(f.entityKind, req.Key, nil)
-
-	err := f.client.Delete(ctx, key)
-
+	err := state.CheckDeleteRequestOptions(req)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return f.backend.delete(context.Background(), req)
 }
 
 // Delete performs a delete operation
@@ -164,18 +181,22 @@ func (f *Firestore) BulkDelete(req []state.DeleteRequest) error {
 	return nil
 }
 
+// Close closes the connection to Firestore
+func (f *Firestore) Close() error {
+	if f.backend != nil {
+		return f.backend.close()
+	}
+	return nil
+}
+
 func getFirestoreMetadata(metadata state.Metadata) (*firestoreMetadata, error) {
 	meta := firestoreMetadata{
-		EntityKind: defaultEntityKind,
+		Mode:       datastoreMode,
+		Collection: defaultCollection,
 	}
-	var requiredMetaProperties = []string{
-		"type", "project_id", "private_key_id", "private_key", "client_email", "client_id",
-		"auth_uri", "token_uri", "auth_provider_x509_cert_url", "client_x509_cert_url"}
 
-	for _, k := range requiredMetaProperties {
-		if val, ok := metadata.Properties[k]; !ok || len(val) < 1 {
-			return nil, fmt.Errorf("error parsing required field: %s", k)
-		}
+	if val, ok := metadata.Properties["project_id"]; !ok || len(val) < 1 {
+		return nil, fmt.Errorf("error parsing required field: project_id")
 	}
 
 	meta.Type = metadata.Properties["type"]
@@ -189,9 +210,37 @@ func getFirestoreMetadata(metadata state.Metadata) (*firestoreMetadata, error) {
 	meta.AuthProviderCertURL = metadata.Properties["auth_provider_x509_cert_url"]
 	meta.ClientCertURL = metadata.Properties["client_x509_cert_url"]
 
+	// A service account key is optional, but when one is given it has to be complete
+	if meta.hasServiceAccount() {
+		var serviceAccountProperties = []string{
+			"type", "private_key_id", "private_key", "client_email", "client_id",
+			"auth_uri", "token_uri", "auth_provider_x509_cert_url", "client_x509_cert_url"}
+
+		for _, k := range serviceAccountProperties {
+			if val, ok := metadata.Properties[k]; !ok || len(val) < 1 {
+				return nil, fmt.Errorf("error parsing required field: %s", k)
+			}
+		}
+	}
+
+	if val, ok := metadata.Properties["mode"]; ok && val != "" {
+		meta.Mode = val
+	}
+	if meta.Mode != datastoreMode && meta.Mode != nativeMode {
+		return nil, fmt.Errorf("invalid mode %s, accepted values are %s and %s", meta.Mode, datastoreMode, nativeMode)
+	}
+
+	// entity_kind, the name of the kind of the entities in Datastore mode, is accepted in both modes
 	if val, ok := metadata.Properties["entity_kind"]; ok && val != "" {
-		meta.EntityKind = val
+		meta.Collection = val
+	}
+	if val, ok := metadata.Properties["collection"]; ok && val != "" {
+		meta.Collection = val
 	}
 
 	return &meta, nil
 }
+
+func (m *firestoreMetadata) hasServiceAccount() bool {
+	return m.PrivateKey != "" || m.PrivateKeyID != "" || m.ClientEmail != ""
+}
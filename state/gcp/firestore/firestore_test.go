@@ -7,9 +7,14 @@ package firestore
 
 import (
 	"testing"
+	"time"
 
+	"cloud.google.com/go/firestore"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestGetFirestoreMetadata(t *testing.T) {
@@ -35,7 +40,8 @@ func TestGetFirestoreMetadata(t *testing.T) {
 		assert.Equal(t, "myprojectid", metadata.ProjectID)
 		assert.Equal(t, "123", metadata.PrivateKeyID)
 		assert.Equal(t, "mykey", metadata.PrivateKey)
-		assert.Equal(t, defaultEntityKind, metadata.EntityKind)
+		assert.Equal(t, datastoreMode, metadata.Mode)
+		assert.Equal(t, defaultCollection, metadata.Collection)
 	})
 
 	t.Run("With incorrect properties", func(t *testing.T) {
@@ -51,4 +57,101 @@ func TestGetFirestoreMetadata(t *testing.T) {
 		_, err := getFirestoreMetadata(m)
 		assert.NotNil(t, err)
 	})
+
+	t.Run("Without project id", func(t *testing.T) {
+		m := state.Metadata{
+			Properties: map[string]string{},
+		}
+		_, err := getFirestoreMetadata(m)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Without service account uses default credentials", func(t *testing.T) {
+		m := state.Metadata{
+			Properties: map[string]string{"project_id": "myprojectid"},
+		}
+		metadata, err := getFirestoreMetadata(m)
+		assert.Nil(t, err)
+		assert.Equal(t, "myprojectid", metadata.ProjectID)
+		assert.False(t, metadata.hasServiceAccount())
+	})
+
+	t.Run("With collection", func(t *testing.T) {
+		m := state.Metadata{
+			Properties: map[string]string{"project_id": "myprojectid", "collection": "orders"},
+		}
+		metadata, err := getFirestoreMetadata(m)
+		assert.Nil(t, err)
+		assert.Equal(t, "orders", metadata.Collection)
+	})
+
+	t.Run("With entity kind", func(t *testing.T) {
+		m := state.Metadata{
+			Properties: map[string]string{"project_id": "myprojectid", "entity_kind": "Orders"},
+		}
+		metadata, err := getFirestoreMetadata(m)
+		assert.Nil(t, err)
+		assert.Equal(t, "Orders", metadata.Collection)
+	})
+
+	t.Run("With native mode", func(t *testing.T) {
+		m := state.Metadata{
+			Properties: map[string]string{"project_id": "myprojectid", "mode": "native"},
+		}
+		metadata, err := getFirestoreMetadata(m)
+		assert.Nil(t, err)
+		assert.Equal(t, nativeMode, metadata.Mode)
+	})
+
+	t.Run("With invalid mode", func(t *testing.T) {
+		m := state.Metadata{
+			Properties: map[string]string{"project_id": "myprojectid", "mode": "firestore"},
+		}
+		_, err := getFirestoreMetadata(m)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGetComponentMetadata(t *testing.T) {
+	f := NewFirestoreStateStore(nil)
+	assert.Empty(t, f.GetComponentMetadata().Capabilities)
+
+	f.mode = nativeMode
+	assert.Equal(t, []metadata.Capability{metadata.CapabilityETag}, f.GetComponentMetadata().Capabilities)
+}
+
+func TestDocID(t *testing.T) {
+	n := &nativeBackend{client: &firestore.Client{}, collection: "DaprState"}
+
+	assert.Equal(t, "myapp||order", n.doc("myapp||order").ID)
+	// The slashes would separate the segments of the document path
+	assert.Equal(t, "myapp||orders%2F1", n.doc("myapp||orders/1").ID)
+	assert.Equal(t, "DaprState", n.doc("myapp||orders/1").Parent.ID)
+	assert.Equal(t, "100%25%2F", n.doc("100%/").ID)
+}
+
+func TestETag(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		updateTime := time.Date(2020, 9, 1, 10, 30, 15, 123456000, time.UTC)
+		parsed, err := parseETag(formatETag(updateTime))
+		assert.Nil(t, err)
+		assert.True(t, updateTime.Equal(parsed))
+	})
+
+	t.Run("Invalid etag is a mismatch", func(t *testing.T) {
+		_, err := parseETag("not a time")
+		assert.Equal(t, errETagMismatch, err)
+	})
+}
+
+func TestMapPreconditionError(t *testing.T) {
+	assert.Nil(t, mapPreconditionError(nil))
+
+	for _, code := range []codes.Code{codes.FailedPrecondition, codes.AlreadyExists, codes.NotFound} {
+		err := mapPreconditionError(status.Error(code, "failed"))
+		assert.Contains(t, err.Error(), errETagMismatch.Error())
+	}
+
+	err := mapPreconditionError(status.Error(codes.Unavailable, "failed"))
+	assert.NotContains(t, err.Error(), errETagMismatch.Error())
 }
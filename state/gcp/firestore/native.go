@@ -0,0 +1,130 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package firestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/dapr/components-contrib/state"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const valueField = "value"
+
+var errETagMismatch = errors.New("possible etag mismatch")
+
+// docIDEscaper escapes the slashes of the keys, which separate the segments of the document paths, and the
+// percent signs, so that the document IDs are decoded unambiguously
+var docIDEscaper = strings.NewReplacer("%", "%25", "/", "%2F")
+
+// nativeBackend stores the values as documents of a collection in a Firestore database in Native mode.
+// The etags are the update times of the documents, which are the preconditions of the writes.
+type nativeBackend struct {
+	client     *firestore.Client
+	collection string
+}
+
+func newNativeBackend(ctx context.Context, meta *firestoreMetadata, opts []option.ClientOption) (*nativeBackend, error) {
+	client, err := firestore.NewClient(ctx, meta.ProjectID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nativeBackend{client: client, collection: meta.Collection}, nil
+}
+
+func (n *nativeBackend) doc(key string) *firestore.DocumentRef {
+	return n.client.Collection(n.collection).Doc(docIDEscaper.Replace(key))
+}
+
+func (n *nativeBackend) get(ctx context.Context, key string) (*state.GetResponse, error) {
+	snapshot, err := n.doc(key).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+
+	var entity StateEntity
+	err = snapshot.DataTo(&entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &state.GetResponse{
+		Data: []byte(entity.Value),
+		ETag: formatETag(snapshot.UpdateTime),
+	}, nil
+}
+
+func (n *nativeBackend) set(ctx context.Context, req *state.SetRequest, value string) error {
+	doc := n.doc(req.Key)
+	switch {
+	case req.ETag != "" && req.Options.Concurrency != state.LastWrite:
+		updateTime, err := parseETag(req.ETag)
+		if err != nil {
+			return err
+		}
+		_, err = doc.Update(ctx, []firestore.Update{{Path: valueField, Value: value}}, firestore.LastUpdateTime(updateTime))
+		return mapPreconditionError(err)
+	case req.Options.Concurrency == state.FirstWrite:
+		_, err := doc.Create(ctx, &StateEntity{Value: value})
+		return mapPreconditionError(err)
+	default:
+		_, err := doc.Set(ctx, &StateEntity{Value: value})
+		return err
+	}
+}
+
+func (n *nativeBackend) delete(ctx context.Context, req *state.DeleteRequest) error {
+	doc := n.doc(req.Key)
+	if req.ETag != "" && req.Options.Concurrency != state.LastWrite {
+		updateTime, err := parseETag(req.ETag)
+		if err != nil {
+			return err
+		}
+		_, err = doc.Delete(ctx, firestore.LastUpdateTime(updateTime))
+		return mapPreconditionError(err)
+	}
+
+	_, err := doc.Delete(ctx)
+	return err
+}
+
+func (n *nativeBackend) close() error {
+	return n.client.Close()
+}
+
+func formatETag(updateTime time.Time) string {
+	return updateTime.UTC().Format(time.RFC3339Nano)
+}
+
+func parseETag(etag string) (time.Time, error) {
+	updateTime, err := time.Parse(time.RFC3339Nano, etag)
+	if err != nil {
+		// An etag that is not an update time can never match
+		return time.Time{}, errETagMismatch
+	}
+	return updateTime, nil
+}
+
+// mapPreconditionError returns errETagMismatch for the errors of a failed precondition
+func mapPreconditionError(err error) error {
+	switch status.Code(err) {
+	case codes.FailedPrecondition, codes.AlreadyExists, codes.NotFound:
+		return fmt.Errorf("%s: %s", errETagMismatch, err)
+	default:
+		return err
+	}
+}
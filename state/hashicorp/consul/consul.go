@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/hashicorp/consul/api"
@@ -19,6 +20,7 @@ import (
 type Consul struct {
 	client        *api.Client
 	keyPrefixPath string
+	keyPrefixer   state.KeyPrefixer
 	logger        logger.Logger
 }
 
@@ -42,6 +44,10 @@ func (c *Consul) Init(metadata state.Metadata) error {
 	if err != nil {
 		return fmt.Errorf("couldn't convert metadata properties: %s", err)
 	}
+	keyPrefixer, err := state.NewKeyPrefixer(metadata)
+	if err != nil {
+		return err
+	}
 
 	var keyPrefixPath string
 	if consulConfig.KeyPrefixPath == "" {
@@ -62,10 +68,27 @@ func (c *Consul) Init(metadata state.Metadata) error {
 
 	c.client = client
 	c.keyPrefixPath = keyPrefixPath
+	c.keyPrefixer = keyPrefixer
 
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Consul state store
+func (c *Consul) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: append(metadata.FieldsOf(consulConfig{KeyPrefixPath: "dapr"}), metadata.Field{Name: state.KeyPrefix, Type: metadata.TypeString}),
+	}
+}
+
+// Ping asks the Consul agent for the leader of the cluster, for health checks
+func (c *Consul) Ping() error {
+	if c.client == nil {
+		return fmt.Errorf("the Consul state store is not initialized")
+	}
+	_, err := c.client.Status().Leader()
+	return err
+}
+
 func metadataToConfig(connInfo map[string]string) (*consulConfig, error) {
 	b, err := json.Marshal(connInfo)
 	if err != nil {
@@ -88,7 +111,7 @@ func (c *Consul) Get(req *state.GetRequest) (*state.GetResponse, error) {
 		queryOpts.RequireConsistent = true
 	}
 
-	resp, queryMeta, err := c.client.KV().Get(fmt.Sprintf("%s/%s", c.keyPrefixPath, req.Key), queryOpts)
+	resp, queryMeta, err := c.client.KV().Get(c.keyWithPath(req.Key), queryOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +136,7 @@ func (c *Consul) Set(req *state.SetRequest) error {
 		reqValByte, _ = json.Marshal(req.Value)
 	}
 
-	keyWithPath := fmt.Sprintf("%s/%s", c.keyPrefixPath, req.Key)
+	keyWithPath := c.keyWithPath(req.Key)
 
 	_, err := c.client.KV().Put(&api.KVPair{
 		Key:   keyWithPath,
@@ -140,7 +163,7 @@ func (c *Consul) BulkSet(req []state.SetRequest) error {
 
 // Delete performes a Consul KV delete operation
 func (c *Consul) Delete(req *state.DeleteRequest) error {
-	keyWithPath := fmt.Sprintf("%s/%s", c.keyPrefixPath, req.Key)
+	keyWithPath := c.keyWithPath(req.Key)
 	_, err := c.client.KV().Delete(keyWithPath, nil)
 	if err != nil {
 		return fmt.Errorf("couldn't delete key %s: %s", keyWithPath, err)
@@ -160,3 +183,9 @@ func (c *Consul) BulkDelete(req []state.DeleteRequest) error {
 
 	return nil
 }
+
+// keyWithPath returns the Consul key of a state key, which is prefixed with the keyPrefix strategy under the key
+// prefix path
+func (c *Consul) keyWithPath(key string) string {
+	return fmt.Sprintf("%s/%s", c.keyPrefixPath, c.keyPrefixer.PrefixKey(key))
+}
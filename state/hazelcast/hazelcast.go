@@ -3,8 +3,11 @@ package hazelcast
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/hazelcast/hazelcast-go-client/core"
 	jsoniter "github.com/json-iterator/go"
@@ -14,15 +17,23 @@ import (
 )
 
 const (
-	hazelcastServers = "hazelcastServers"
-	hazelcastMap     = "hazelcastMap"
+	hazelcastServers      = "hazelcastServers"
+	hazelcastMap          = "hazelcastMap"
+	nearCacheKey          = "nearCache"
+	nearCacheMaxSizeKey   = "nearCacheMaxSize"
+	nearCacheTTLInSeconds = "nearCacheTTLInSeconds"
+
+	ttlInSecondsKey = "ttlInSeconds"
+
+	defaultNearCacheMaxSize = 10000
 )
 
 //Hazelcast state store
 type Hazelcast struct {
-	hzMap  core.Map
-	json   jsoniter.API
-	logger logger.Logger
+	hzMap     core.Map
+	nearCache *nearCache
+	json      jsoniter.API
+	logger    logger.Logger
 }
 
 // NewHazelcastStore returns a new hazelcast backed state store
@@ -41,11 +52,52 @@ func validateMetadata(metadata state.Metadata) error {
 		return errors.New("hazelcast error: missing hazelcast map name")
 	}
 
-	return nil
+	_, err := parseNearCache(metadata)
+	return err
+}
+
+// parseNearCache returns the near cache configured by the metadata, or nil if the near cache is disabled
+func parseNearCache(metadata state.Metadata) (*nearCache, error) {
+	val := metadata.Properties[nearCacheKey]
+	if val == "" {
+		return nil, nil
+	}
+
+	enabled, err := strconv.ParseBool(val)
+	if err != nil {
+		return nil, fmt.Errorf("hazelcast error: invalid %s value '%s'", nearCacheKey, val)
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	maxSize := defaultNearCacheMaxSize
+	if val := metadata.Properties[nearCacheMaxSizeKey]; val != "" {
+		maxSize, err = strconv.Atoi(val)
+		if err != nil || maxSize <= 0 {
+			return nil, fmt.Errorf("hazelcast error: invalid %s value '%s', must be a positive integer", nearCacheMaxSizeKey, val)
+		}
+	}
+
+	// Without a TTL, entries are only removed from the near cache when they change or it is full
+	var ttl time.Duration
+	if val := metadata.Properties[nearCacheTTLInSeconds]; val != "" {
+		seconds, err := strconv.Atoi(val)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("hazelcast error: invalid %s value '%s', must be a non negative integer", nearCacheTTLInSeconds, val)
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+
+	return newNearCache(maxSize, ttl), nil
 }
 
 // Init does metadata and connection parsing
 func (store *Hazelcast) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	err := validateMetadata(metadata)
 	if err != nil {
 		return err
@@ -64,9 +116,36 @@ func (store *Hazelcast) Init(metadata state.Metadata) error {
 	if err != nil {
 		return fmt.Errorf("hazelcast error: %v", err)
 	}
+
+	store.nearCache, err = parseNearCache(metadata)
+	if err != nil {
+		return err
+	}
+	if store.nearCache != nil {
+		// Values are not needed in the events, since entries are invalidated rather than updated
+		_, err = store.hzMap.AddEntryListener(store.nearCache, false)
+		if err != nil {
+			return fmt.Errorf("hazelcast error: failed to listen for near cache invalidations: %v", err)
+		}
+	}
+
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Hazelcast state store
+func (store *Hazelcast) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: hazelcastServers, Type: metadata.TypeList, Required: true},
+			{Name: hazelcastMap, Type: metadata.TypeString, Required: true},
+			{Name: nearCacheKey, Type: metadata.TypeBool},
+			{Name: nearCacheMaxSizeKey, Type: metadata.TypeInt, Default: strconv.Itoa(defaultNearCacheMaxSize)},
+			{Name: nearCacheTTLInSeconds, Type: metadata.TypeInt},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityTTL},
+	}
+}
+
 //Set stores value for a key to Hazelcast
 func (store *Hazelcast) Set(req *state.SetRequest) error {
 	err := state.CheckSetRequestOptions(req)
@@ -84,7 +163,17 @@ func (store *Hazelcast) Set(req *state.SetRequest) error {
 			return fmt.Errorf("hazelcast error: failed to set key %s: %s", req.Key, err)
 		}
 	}
-	_, err = store.hzMap.Put(req.Key, value)
+	ttl, err := parseTTL(req.Metadata)
+	if err != nil {
+		return fmt.Errorf("hazelcast error: failed to set key %s: %s", req.Key, err)
+	}
+
+	if ttl > 0 {
+		err = store.hzMap.SetWithTTL(req.Key, value, time.Duration(ttl)*time.Second)
+	} else {
+		err = store.hzMap.Set(req.Key, value)
+	}
+	store.invalidateNearCache(req.Key)
 
 	if err != nil {
 		return fmt.Errorf("hazelcast error: failed to set key %s: %s", req.Key, err)
@@ -104,9 +193,10 @@ func (store *Hazelcast) BulkSet(req []state.SetRequest) error {
 	return nil
 }
 
-// Get retrieves state from Hazelcast with a key
+// Get retrieves state from Hazelcast with a key.
+// With the near cache enabled, eventually consistent reads may be served from the near cache.
 func (store *Hazelcast) Get(req *state.GetRequest) (*state.GetResponse, error) {
-	resp, err := store.hzMap.Get(req.Key)
+	resp, err := store.getValue(req)
 	if err != nil {
 		return nil, fmt.Errorf("hazelcast error: failed to get value for %s: %s", req.Key, err)
 	}
@@ -126,6 +216,34 @@ func (store *Hazelcast) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	}, nil
 }
 
+func (store *Hazelcast) getValue(req *state.GetRequest) (interface{}, error) {
+	useNearCache := store.nearCache != nil && req.Options.Consistency != state.Strong
+	if useNearCache {
+		if value, ok := store.nearCache.get(req.Key); ok {
+			return value, nil
+		}
+	}
+
+	value, err := store.hzMap.Get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if useNearCache && value != nil {
+		store.nearCache.put(req.Key, value)
+	}
+
+	return value, nil
+}
+
+// invalidateNearCache removes a key from the near cache after a local write,
+// so that it is not read before the event of the write arrives.
+func (store *Hazelcast) invalidateNearCache(key string) {
+	if store.nearCache != nil {
+		store.nearCache.invalidate(key)
+	}
+}
+
 // Delete performs a delete operation
 func (store *Hazelcast) Delete(req *state.DeleteRequest) error {
 	err := state.CheckDeleteRequestOptions(req)
@@ -133,6 +251,7 @@ func (store *Hazelcast) Delete(req *state.DeleteRequest) error {
 		return err
 	}
 	err = store.hzMap.Delete(req.Key)
+	store.invalidateNearCache(req.Key)
 	if err != nil {
 		return fmt.Errorf("hazelcast error: failed to delete key - %s", req.Key)
 	}
@@ -149,3 +268,23 @@ func (store *Hazelcast) BulkDelete(req []state.DeleteRequest) error {
 	}
 	return nil
 }
+
+// parseTTL returns the number of seconds until an entry expires from the metadata of a set request.
+// Entries without a ttlInSeconds, or with a value that is not positive, use the expiry configured for the map.
+func parseTTL(requestMetadata map[string]string) (int64, error) {
+	val, ok := requestMetadata[ttlInSecondsKey]
+	if !ok || val == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s', must be an integer", ttlInSecondsKey, val)
+	}
+
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
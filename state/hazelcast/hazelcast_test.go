@@ -2,6 +2,7 @@ package hazelcast
 
 import (
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/state"
 	"github.com/stretchr/testify/assert"
@@ -51,3 +52,59 @@ func TestValidateMetadata(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestParseNearCache(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		cache, err := parseNearCache(state.Metadata{Properties: map[string]string{}})
+		assert.Nil(t, err)
+		assert.Nil(t, cache)
+	})
+
+	t.Run("enabled with defaults", func(t *testing.T) {
+		cache, err := parseNearCache(state.Metadata{Properties: map[string]string{"nearCache": "true"}})
+		assert.Nil(t, err)
+		assert.Equal(t, defaultNearCacheMaxSize, cache.maxSize)
+		assert.Equal(t, time.Duration(0), cache.ttl)
+	})
+
+	t.Run("enabled with size and ttl", func(t *testing.T) {
+		cache, err := parseNearCache(state.Metadata{Properties: map[string]string{
+			"nearCache":             "true",
+			"nearCacheMaxSize":      "100",
+			"nearCacheTTLInSeconds": "30",
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, 100, cache.maxSize)
+		assert.Equal(t, 30*time.Second, cache.ttl)
+	})
+
+	t.Run("with invalid size", func(t *testing.T) {
+		_, err := parseNearCache(state.Metadata{Properties: map[string]string{
+			"nearCache":        "true",
+			"nearCacheMaxSize": "0",
+		}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("with invalid flag", func(t *testing.T) {
+		_, err := parseNearCache(state.Metadata{Properties: map[string]string{"nearCache": "maybe"}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestParseTTL(t *testing.T) {
+	ttl, err := parseTTL(map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), ttl)
+
+	ttl, err = parseTTL(map[string]string{"ttlInSeconds": "60"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(60), ttl)
+
+	ttl, err = parseTTL(map[string]string{"ttlInSeconds": "-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), ttl)
+
+	_, err = parseTTL(map[string]string{"ttlInSeconds": "soon"})
+	assert.NotNil(t, err)
+}
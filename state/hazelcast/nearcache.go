@@ -0,0 +1,116 @@
+package hazelcast
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/core"
+)
+
+// nearCache keeps values read from the Hazelcast map in the local process. Entries are invalidated
+// by the events of the map, so changes made by other members and clients are seen shortly after they happen.
+type nearCache struct {
+	lock    sync.RWMutex
+	entries map[interface{}]nearCacheEntry
+	maxSize int
+	ttl     time.Duration
+}
+
+type nearCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newNearCache(maxSize int, ttl time.Duration) *nearCache {
+	return &nearCache{
+		entries: map[interface{}]nearCacheEntry{},
+		maxSize: maxSize,
+		ttl:     ttl,
+	}
+}
+
+func (c *nearCache) get(key interface{}) (interface{}, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || (!entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt)) {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (c *nearCache) put(key interface{}, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxSize {
+		// Map iteration order is random, so this evicts a random entry
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+
+	entry := nearCacheEntry{value: value}
+	if c.ttl > 0 {
+		entry.expiresAt = time.Now().Add(c.ttl)
+	}
+
+	c.entries[key] = entry
+}
+
+func (c *nearCache) invalidate(key interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.entries, key)
+}
+
+func (c *nearCache) clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = map[interface{}]nearCacheEntry{}
+}
+
+// EntryAdded implements core.EntryAddedListener
+func (c *nearCache) EntryAdded(event core.EntryEvent) {
+	c.invalidate(event.Key())
+}
+
+// EntryUpdated implements core.EntryUpdatedListener
+func (c *nearCache) EntryUpdated(event core.EntryEvent) {
+	c.invalidate(event.Key())
+}
+
+// EntryRemoved implements core.EntryRemovedListener
+func (c *nearCache) EntryRemoved(event core.EntryEvent) {
+	c.invalidate(event.Key())
+}
+
+// EntryEvicted implements core.EntryEvictedListener
+func (c *nearCache) EntryEvicted(event core.EntryEvent) {
+	c.invalidate(event.Key())
+}
+
+// EntryExpired implements core.EntryExpiredListener
+func (c *nearCache) EntryExpired(event core.EntryEvent) {
+	c.invalidate(event.Key())
+}
+
+// EntryMerged implements core.EntryMergedListener
+func (c *nearCache) EntryMerged(event core.EntryEvent) {
+	c.invalidate(event.Key())
+}
+
+// MapEvicted implements core.MapEvictedListener
+func (c *nearCache) MapEvicted(event core.MapEvent) {
+	c.clear()
+}
+
+// MapCleared implements core.MapClearedListener
+func (c *nearCache) MapCleared(event core.MapEvent) {
+	c.clear()
+}
@@ -0,0 +1,52 @@
+package hazelcast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearCache(t *testing.T) {
+	t.Run("get after put", func(t *testing.T) {
+		cache := newNearCache(10, 0)
+		cache.put("key", "value")
+		value, ok := cache.get("key")
+		assert.True(t, ok)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("invalidate removes the entry", func(t *testing.T) {
+		cache := newNearCache(10, 0)
+		cache.put("key", "value")
+		cache.invalidate("key")
+		_, ok := cache.get("key")
+		assert.False(t, ok)
+	})
+
+	t.Run("expired entries are not returned", func(t *testing.T) {
+		cache := newNearCache(10, time.Millisecond)
+		cache.put("key", "value")
+		time.Sleep(5 * time.Millisecond)
+		_, ok := cache.get("key")
+		assert.False(t, ok)
+	})
+
+	t.Run("size is limited", func(t *testing.T) {
+		cache := newNearCache(2, 0)
+		cache.put("a", "1")
+		cache.put("b", "2")
+		cache.put("c", "3")
+		assert.Len(t, cache.entries, 2)
+		_, ok := cache.get("c")
+		assert.True(t, ok)
+	})
+
+	t.Run("clear removes all entries", func(t *testing.T) {
+		cache := newNearCache(10, 0)
+		cache.put("a", "1")
+		cache.put("b", "2")
+		cache.MapCleared(nil)
+		assert.Len(t, cache.entries, 0)
+	})
+}
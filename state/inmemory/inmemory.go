@@ -0,0 +1,350 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package inmemory
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	cleanupIntervalKey     = "cleanupInterval"
+	ttlInSecondsKey        = "ttlInSeconds"
+	defaultCleanupInterval = time.Minute
+)
+
+var errETagMismatch = errors.New("possible etag mismatch")
+
+// InMemory is a state store that keeps values in the memory of the process, for local development and tests.
+// Values are lost when the process exits.
+type InMemory struct {
+	lock    sync.RWMutex
+	items   map[string]*inMemoryItem
+	version uint64
+	json    jsoniter.API
+	logger  logger.Logger
+	closeCh chan struct{}
+	closed  bool
+}
+
+type inMemoryItem struct {
+	data      []byte
+	etag      string
+	expiresAt time.Time
+}
+
+func (i *inMemoryItem) isExpired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
+}
+
+// NewInMemoryStateStore returns a new in-memory state store
+func NewInMemoryStateStore(logger logger.Logger) *InMemory {
+	return &InMemory{
+		items:   map[string]*inMemoryItem{},
+		json:    jsoniter.ConfigFastest,
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// Init starts removing expired values in the background
+func (m *InMemory) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
+	cleanupInterval := defaultCleanupInterval
+	if val, ok := metadata.Properties[cleanupIntervalKey]; ok && val != "" {
+		var err error
+		cleanupInterval, err = time.ParseDuration(val)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %s", cleanupIntervalKey, err)
+		}
+	}
+
+	// Expired values are never returned, so cleaning them up only frees memory
+	if cleanupInterval > 0 {
+		go m.cleanupExpired(cleanupInterval)
+	}
+
+	return nil
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the in-memory state store
+func (m *InMemory) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: cleanupIntervalKey, Type: metadata.TypeDuration, Default: defaultCleanupInterval.String()},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL, metadata.CapabilityTransactional},
+	}
+}
+
+// Ping fails once the store is closed
+func (m *InMemory) Ping() error {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.closed {
+		return errors.New("the in-memory state store is closed")
+	}
+	return nil
+}
+
+// Get returns the value of a key, or an empty response if the key does not exist or has expired
+func (m *InMemory) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	item, ok := m.items[req.Key]
+	if !ok || item.isExpired(time.Now()) {
+		return &state.GetResponse{}, nil
+	}
+
+	return &state.GetResponse{
+		Data: item.data,
+		ETag: item.etag,
+	}, nil
+}
+
+// Set saves a value
+func (m *InMemory) Set(req *state.SetRequest) error {
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.setValue(req)
+}
+
+// BulkSet saves values, stopping at the first one that fails
+func (m *InMemory) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		err := m.Set(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes a key
+func (m *InMemory) Delete(req *state.DeleteRequest) error {
+	err := state.CheckDeleteRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.deleteValue(req)
+}
+
+// BulkDelete removes keys, stopping at the first one that fails
+func (m *InMemory) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		err := m.Delete(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Multi applies all the operations or none of them
+func (m *InMemory) Multi(reqs []state.TransactionalRequest) error {
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			setReq, ok := req.Request.(state.SetRequest)
+			if !ok {
+				return fmt.Errorf("expecting set request")
+			}
+			if err := state.CheckSetRequestOptions(&setReq); err != nil {
+				return err
+			}
+
+		case state.Delete:
+			deleteReq, ok := req.Request.(state.DeleteRequest)
+			if !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+			if err := state.CheckDeleteRequestOptions(&deleteReq); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unsupported operation: %s", req.Operation)
+		}
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Operations are applied to a copy of the items, which replaces them once all have succeeded
+	items := make(map[string]*inMemoryItem, len(m.items))
+	for k, v := range m.items {
+		items[k] = v
+	}
+	original := m.items
+	m.items = items
+
+	for _, req := range reqs {
+		var err error
+		switch req.Operation {
+		case state.Upsert:
+			setReq := req.Request.(state.SetRequest)
+			err = m.setValue(&setReq)
+		case state.Delete:
+			deleteReq := req.Request.(state.DeleteRequest)
+			err = m.deleteValue(&deleteReq)
+		}
+
+		if err != nil {
+			m.items = original
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close stops removing expired values and drops all values
+func (m *InMemory) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.closed {
+		m.closed = true
+		close(m.closeCh)
+	}
+	m.items = map[string]*inMemoryItem{}
+
+	return nil
+}
+
+// setValue must be called with the lock held
+func (m *InMemory) setValue(req *state.SetRequest) error {
+	var data []byte
+	if b, ok := req.Value.([]byte); ok {
+		// The caller may reuse its buffer
+		data = append([]byte(nil), b...)
+	} else {
+		var err error
+		data, err = m.json.Marshal(req.Value)
+		if err != nil {
+			return fmt.Errorf("failed to set key %s: %s", req.Key, err)
+		}
+	}
+
+	ttl, err := parseTTL(req.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to set key %s: %s", req.Key, err)
+	}
+
+	now := time.Now()
+	current, exists := m.items[req.Key]
+	if exists && current.isExpired(now) {
+		exists = false
+	}
+
+	if req.ETag != "" {
+		if !exists || current.etag != req.ETag {
+			return fmt.Errorf("failed to set key %s: %s", req.Key, errETagMismatch)
+		}
+	} else if req.Options.Concurrency == state.FirstWrite && exists {
+		return fmt.Errorf("failed to set key %s: %s", req.Key, errETagMismatch)
+	}
+
+	item := &inMemoryItem{
+		data: data,
+		etag: m.nextETag(),
+	}
+	if ttl > 0 {
+		item.expiresAt = now.Add(ttl)
+	}
+	m.items[req.Key] = item
+
+	return nil
+}
+
+// deleteValue must be called with the lock held
+func (m *InMemory) deleteValue(req *state.DeleteRequest) error {
+	if req.ETag != "" {
+		current, exists := m.items[req.Key]
+		if !exists || current.isExpired(time.Now()) || current.etag != req.ETag {
+			return fmt.Errorf("failed to delete key %s: %s", req.Key, errETagMismatch)
+		}
+	}
+
+	delete(m.items, req.Key)
+	return nil
+}
+
+// nextETag returns an etag that no value of the store has had before. It must be called with the lock held.
+func (m *InMemory) nextETag() string {
+	m.version++
+	return strconv.FormatUint(m.version, 10)
+}
+
+func (m *InMemory) cleanupExpired(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.removeExpired(time.Now())
+		case <-m.closeCh:
+			return
+		}
+	}
+}
+
+func (m *InMemory) removeExpired(now time.Time) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for key, item := range m.items {
+		if item.isExpired(now) {
+			delete(m.items, key)
+		}
+	}
+}
+
+// parseTTL returns how long until a value expires from the metadata of a set request.
+// Values without a ttlInSeconds, or with a value that is not positive, never expire.
+func parseTTL(requestMetadata map[string]string) (time.Duration, error) {
+	val, ok := requestMetadata[ttlInSecondsKey]
+	if !ok || val == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s', must be an integer", ttlInSecondsKey, val)
+	}
+
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	return time.Duration(ttl) * time.Second, nil
+}
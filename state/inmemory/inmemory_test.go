@@ -0,0 +1,180 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package inmemory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStore(t *testing.T) *InMemory {
+	m := NewInMemoryStateStore(logger.NewLogger("test"))
+	err := m.Init(state.Metadata{Properties: map[string]string{}})
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		m.Close()
+	})
+
+	return m
+}
+
+func TestInit(t *testing.T) {
+	t.Run("With invalid cleanup interval", func(t *testing.T) {
+		m := NewInMemoryStateStore(logger.NewLogger("test"))
+		err := m.Init(state.Metadata{Properties: map[string]string{cleanupIntervalKey: "soon"}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("With cleanup disabled", func(t *testing.T) {
+		m := NewInMemoryStateStore(logger.NewLogger("test"))
+		err := m.Init(state.Metadata{Properties: map[string]string{cleanupIntervalKey: "0"}})
+		assert.Nil(t, err)
+	})
+
+	t.Run("With unsupported key prefix", func(t *testing.T) {
+		m := NewInMemoryStateStore(logger.NewLogger("test"))
+		err := m.Init(state.Metadata{Properties: map[string]string{
+			state.KeyPrefix: state.KeyPrefixStoreName, state.AppIDProperty: "app", state.StoreNameProperty: "store",
+		}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestSetGetDelete(t *testing.T) {
+	m := newTestStore(t)
+
+	resp, err := m.Get(&state.GetRequest{Key: "key"})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Data)
+
+	type value struct {
+		Color string `json:"color"`
+	}
+	err = m.Set(&state.SetRequest{Key: "key", Value: value{Color: "blue"}})
+	assert.Nil(t, err)
+
+	resp, err = m.Get(&state.GetRequest{Key: "key"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"color":"blue"}`, string(resp.Data))
+	assert.NotEmpty(t, resp.ETag)
+
+	err = m.Delete(&state.DeleteRequest{Key: "key"})
+	assert.Nil(t, err)
+
+	resp, err = m.Get(&state.GetRequest{Key: "key"})
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Data)
+}
+
+func TestETag(t *testing.T) {
+	m := newTestStore(t)
+
+	assert.Nil(t, m.Set(&state.SetRequest{Key: "key", Value: []byte("v1")}))
+	resp, _ := m.Get(&state.GetRequest{Key: "key"})
+	oldETag := resp.ETag
+
+	t.Run("Set with current etag", func(t *testing.T) {
+		assert.Nil(t, m.Set(&state.SetRequest{Key: "key", Value: []byte("v2"), ETag: oldETag}))
+	})
+
+	t.Run("Set with old etag fails", func(t *testing.T) {
+		assert.NotNil(t, m.Set(&state.SetRequest{Key: "key", Value: []byte("v3"), ETag: oldETag}))
+		resp, _ := m.Get(&state.GetRequest{Key: "key"})
+		assert.Equal(t, "v2", string(resp.Data))
+	})
+
+	t.Run("Delete with old etag fails", func(t *testing.T) {
+		assert.NotNil(t, m.Delete(&state.DeleteRequest{Key: "key", ETag: oldETag}))
+	})
+
+	t.Run("First write fails for an existing key", func(t *testing.T) {
+		err := m.Set(&state.SetRequest{
+			Key:     "key",
+			Value:   []byte("v4"),
+			Options: state.SetStateOption{Concurrency: state.FirstWrite},
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Set with etag fails for a missing key", func(t *testing.T) {
+		assert.NotNil(t, m.Set(&state.SetRequest{Key: "missing", Value: []byte("v"), ETag: "1"}))
+	})
+}
+
+func TestTTL(t *testing.T) {
+	m := newTestStore(t)
+
+	t.Run("Expired values are not returned", func(t *testing.T) {
+		err := m.Set(&state.SetRequest{Key: "key", Value: []byte("v"), Metadata: map[string]string{ttlInSecondsKey: "1"}})
+		assert.Nil(t, err)
+
+		resp, _ := m.Get(&state.GetRequest{Key: "key"})
+		assert.Equal(t, "v", string(resp.Data))
+
+		m.lock.Lock()
+		m.items["key"].expiresAt = time.Now().Add(-time.Second)
+		m.lock.Unlock()
+
+		resp, _ = m.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, resp.Data)
+
+		m.removeExpired(time.Now())
+		assert.Empty(t, m.items)
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		err := m.Set(&state.SetRequest{Key: "key", Value: []byte("v"), Metadata: map[string]string{ttlInSecondsKey: "a"}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestMulti(t *testing.T) {
+	m := newTestStore(t)
+	assert.Nil(t, m.Set(&state.SetRequest{Key: "key1", Value: []byte("v")}))
+
+	t.Run("Failed operation rolls back the transaction", func(t *testing.T) {
+		err := m.Multi([]state.TransactionalRequest{
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "key1"}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "key2", Value: []byte("v"), ETag: "100"}},
+		})
+		assert.NotNil(t, err)
+
+		resp, _ := m.Get(&state.GetRequest{Key: "key1"})
+		assert.Equal(t, "v", string(resp.Data))
+		resp, _ = m.Get(&state.GetRequest{Key: "key2"})
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("Successful transaction", func(t *testing.T) {
+		err := m.Multi([]state.TransactionalRequest{
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "key1"}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "key2", Value: []byte("v")}},
+		})
+		assert.Nil(t, err)
+
+		resp, _ := m.Get(&state.GetRequest{Key: "key1"})
+		assert.Nil(t, resp.Data)
+		resp, _ = m.Get(&state.GetRequest{Key: "key2"})
+		assert.Equal(t, "v", string(resp.Data))
+	})
+
+	t.Run("Wrong request type", func(t *testing.T) {
+		err := m.Multi([]state.TransactionalRequest{{Operation: state.Upsert, Request: state.DeleteRequest{Key: "key"}}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestPing(t *testing.T) {
+	m := newTestStore(t)
+	assert.Nil(t, m.Ping())
+
+	m.Close()
+	assert.NotNil(t, m.Ping())
+}
@@ -0,0 +1,349 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+)
+
+const (
+	// KeyPrefix is the metadata property that selects how keys are prefixed
+	KeyPrefix = "keyPrefix"
+	// KeyPrefixAppID prefixes keys with the app ID, so that each app has its own keys. This is the default when the app ID is known,
+	// and keeps the keys of Dapr as they are.
+	KeyPrefixAppID = "appid"
+	// KeyPrefixStoreName prefixes keys with the name of the state store, so that all apps using the store share its keys
+	KeyPrefixStoreName = "storename"
+	// KeyPrefixNamespace prefixes keys with the namespace and the app ID, so that apps with the same ID in different namespaces have their own keys
+	KeyPrefixNamespace = "namespace"
+	// KeyPrefixNone does not prefix keys, so that all apps share the keys of the backing store
+	KeyPrefixNone = "none"
+
+	// AppIDProperty is the metadata property with the ID of the app using the state store
+	AppIDProperty = "appID"
+	// StoreNameProperty is the metadata property with the name of the state store component
+	StoreNameProperty = "storeName"
+	// NamespaceProperty is the metadata property with the namespace of the app. The NAMESPACE environment variable is used when it is not set.
+	NamespaceProperty = "namespace"
+
+	keySeparator = "||"
+)
+
+// GetKeyPrefix returns the prefix of keys for the keyPrefix strategy in metadata. The prefix is empty when keys are not prefixed.
+func GetKeyPrefix(metadata Metadata) (string, error) {
+	appID := metadata.Properties[AppIDProperty]
+	strategy, ok := metadata.Properties[KeyPrefix]
+	if !ok || strategy == "" {
+		// Stores used without an app ID keep their keys as they are
+		if appID == "" {
+			return "", nil
+		}
+		strategy = KeyPrefixAppID
+	}
+
+	switch strings.ToLower(strategy) {
+	case KeyPrefixAppID:
+		if appID == "" {
+			return "", fmt.Errorf("%s key prefix requires %s in metadata", KeyPrefixAppID, AppIDProperty)
+		}
+		return appID, nil
+	case KeyPrefixStoreName:
+		storeName := metadata.Properties[StoreNameProperty]
+		if storeName == "" {
+			return "", fmt.Errorf("%s key prefix requires %s in metadata", KeyPrefixStoreName, StoreNameProperty)
+		}
+		return storeName, nil
+	case KeyPrefixNamespace:
+		namespace := metadata.Properties[NamespaceProperty]
+		if namespace == "" {
+			namespace = os.Getenv("NAMESPACE")
+		}
+		if namespace == "" || appID == "" {
+			return "", fmt.Errorf("%s key prefix requires %s and %s in metadata", KeyPrefixNamespace, NamespaceProperty, AppIDProperty)
+		}
+		return namespace + "." + appID, nil
+	case KeyPrefixNone:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unrecognized key prefix '%s', supported values are: %s, %s, %s, %s",
+			strategy, KeyPrefixAppID, KeyPrefixStoreName, KeyPrefixNamespace, KeyPrefixNone)
+	}
+}
+
+// PrefixKey returns key with prefix
+func PrefixKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + keySeparator + key
+}
+
+// UnprefixKey returns key without prefix
+func UnprefixKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return strings.TrimPrefix(key, prefix+keySeparator)
+}
+
+// CheckDefaultKeyPrefix returns an error unless the keyPrefix strategy in metadata keeps the keys as they are. The
+// stores that don't apply the strategy themselves check it at Init, so that a strategy isn't silently ignored.
+func CheckDefaultKeyPrefix(metadata Metadata) error {
+	prefixer, err := NewKeyPrefixer(metadata)
+	if err != nil {
+		return err
+	}
+	if prefixer.prefix != prefixer.appID {
+		return fmt.Errorf("%s '%s' is not supported by the state store, which keeps the keys as they are: wrap it with NewKeyPrefixStore",
+			KeyPrefix, metadata.Properties[KeyPrefix])
+	}
+
+	return nil
+}
+
+// KeyPrefixer maps the keys that Dapr passes to the state stores, which it already prefixes with the app ID, to the
+// keys of the keyPrefix strategy. The app ID prefix is replaced with the prefix of the strategy, so the keys of the
+// default strategy are kept as they are. The zero KeyPrefixer keeps all keys as they are.
+type KeyPrefixer struct {
+	appID  string
+	prefix string
+}
+
+// NewKeyPrefixer returns the KeyPrefixer of the keyPrefix strategy in metadata
+func NewKeyPrefixer(metadata Metadata) (KeyPrefixer, error) {
+	prefix, err := GetKeyPrefix(metadata)
+	if err != nil {
+		return KeyPrefixer{}, err
+	}
+
+	return KeyPrefixer{appID: metadata.Properties[AppIDProperty], prefix: prefix}, nil
+}
+
+// PrefixKey returns the key of the store for a key of Dapr
+func (p KeyPrefixer) PrefixKey(key string) string {
+	return PrefixKey(p.prefix, UnprefixKey(p.appID, key))
+}
+
+// UnprefixKey returns the key of Dapr for a key of the store
+func (p KeyPrefixer) UnprefixKey(key string) string {
+	return PrefixKey(p.appID, UnprefixKey(p.prefix, key))
+}
+
+// HasPrefix returns whether a key of the store has the prefix of the strategy
+func (p KeyPrefixer) HasPrefix(key string) bool {
+	return p.prefix == "" || strings.HasPrefix(key, p.prefix+keySeparator)
+}
+
+// KeyPrefixStore prefixes the keys of a state store with the keyPrefix strategy in its metadata, for the stores that
+// don't apply the strategy themselves
+type KeyPrefixStore struct {
+	store    Store
+	prefixer KeyPrefixer
+}
+
+// KeyPrefixTransactionalStore is a KeyPrefixStore for a TransactionalStore
+type KeyPrefixTransactionalStore struct {
+	*KeyPrefixStore
+	transactional TransactionalStore
+}
+
+// KeyPrefixQueriableStore is a KeyPrefixStore for a Querier
+type KeyPrefixQueriableStore struct {
+	*KeyPrefixStore
+	querier Querier
+}
+
+// KeyPrefixTransactionalQueriableStore is a KeyPrefixStore for a TransactionalStore that is also a Querier
+type KeyPrefixTransactionalQueriableStore struct {
+	*KeyPrefixTransactionalStore
+	querier Querier
+}
+
+// NewKeyPrefixStore wraps store so that its keys are prefixed.
+// The result is a *KeyPrefixTransactionalStore when store is a TransactionalStore, a *KeyPrefixQueriableStore when
+// it is a Querier, a *KeyPrefixTransactionalQueriableStore when it is both, and a *KeyPrefixStore otherwise.
+func NewKeyPrefixStore(store Store) Store {
+	s := &KeyPrefixStore{store: store}
+	transactional, isTransactional := store.(TransactionalStore)
+	querier, isQuerier := store.(Querier)
+	switch {
+	case isTransactional && isQuerier:
+		return &KeyPrefixTransactionalQueriableStore{
+			KeyPrefixTransactionalStore: &KeyPrefixTransactionalStore{KeyPrefixStore: s, transactional: transactional},
+			querier:                     querier,
+		}
+	case isTransactional:
+		return &KeyPrefixTransactionalStore{
+			KeyPrefixStore: s,
+			transactional:  transactional,
+		}
+	case isQuerier:
+		return &KeyPrefixQueriableStore{KeyPrefixStore: s, querier: querier}
+	default:
+		return s
+	}
+}
+
+// Init reads the key prefix from metadata and initializes the wrapped store without it, as the keys it gets are
+// already prefixed
+func (s *KeyPrefixStore) Init(metadata Metadata) error {
+	prefixer, err := NewKeyPrefixer(metadata)
+	if err != nil {
+		return err
+	}
+	s.prefixer = prefixer
+
+	properties := make(map[string]string, len(metadata.Properties))
+	for k, v := range metadata.Properties {
+		if k != KeyPrefix {
+			properties[k] = v
+		}
+	}
+	metadata.Properties = properties
+
+	return s.store.Init(metadata)
+}
+
+// GetComponentMetadata returns the metadata of the wrapped store with the key prefix strategy
+func (s *KeyPrefixStore) GetComponentMetadata() metadata.ComponentMetadata {
+	m := s.store.GetComponentMetadata()
+	m.Fields = append(m.Fields, metadata.Field{Name: KeyPrefix, Type: metadata.TypeString})
+	return m
+}
+
+// Ping pings the wrapped store
+func (s *KeyPrefixStore) Ping() error {
+	return health.Ping(s.store)
+}
+
+// Get gets the value of a prefixed key
+func (s *KeyPrefixStore) Get(req *GetRequest) (*GetResponse, error) {
+	r := *req
+	r.Key = s.prefixer.PrefixKey(req.Key)
+	return s.store.Get(&r)
+}
+
+// BulkGet gets the values of prefixed keys. The wrapped store is used when it is a BulkStore,
+// otherwise the keys are got with a DefaultBulkStore.
+func (s *KeyPrefixStore) BulkGet(req []GetRequest) ([]BulkGetResponse, error) {
+	bulk, ok := s.store.(BulkStore)
+	if !ok {
+		bulk = NewDefaultBulkStore(s.store, DefaultBulkParallelism)
+	}
+
+	prefixed := make([]GetRequest, len(req))
+	keys := make(map[string]string, len(req))
+	for i := range req {
+		prefixed[i] = req[i]
+		prefixed[i].Key = s.prefixer.PrefixKey(req[i].Key)
+		keys[prefixed[i].Key] = req[i].Key
+	}
+
+	responses, err := bulk.BulkGet(prefixed)
+	if err != nil {
+		return nil, err
+	}
+	for i := range responses {
+		if key, ok := keys[responses[i].Key]; ok {
+			responses[i].Key = key
+		} else {
+			responses[i].Key = s.prefixer.UnprefixKey(responses[i].Key)
+		}
+	}
+
+	return responses, nil
+}
+
+// Set sets the value of a prefixed key
+func (s *KeyPrefixStore) Set(req *SetRequest) error {
+	r := *req
+	r.Key = s.prefixer.PrefixKey(req.Key)
+	return s.store.Set(&r)
+}
+
+// BulkSet sets the values of prefixed keys
+func (s *KeyPrefixStore) BulkSet(req []SetRequest) error {
+	prefixed := make([]SetRequest, len(req))
+	for i := range req {
+		prefixed[i] = req[i]
+		prefixed[i].Key = s.prefixer.PrefixKey(req[i].Key)
+	}
+
+	return s.store.BulkSet(prefixed)
+}
+
+// Delete deletes a prefixed key
+func (s *KeyPrefixStore) Delete(req *DeleteRequest) error {
+	r := *req
+	r.Key = s.prefixer.PrefixKey(req.Key)
+	return s.store.Delete(&r)
+}
+
+// BulkDelete deletes prefixed keys
+func (s *KeyPrefixStore) BulkDelete(req []DeleteRequest) error {
+	prefixed := make([]DeleteRequest, len(req))
+	for i := range req {
+		prefixed[i] = req[i]
+		prefixed[i].Key = s.prefixer.PrefixKey(req[i].Key)
+	}
+
+	return s.store.BulkDelete(prefixed)
+}
+
+// Multi prefixes the keys of the requests and runs them in a transaction of the wrapped store
+func (s *KeyPrefixTransactionalStore) Multi(reqs []TransactionalRequest) error {
+	prefixed := make([]TransactionalRequest, 0, len(reqs))
+	for _, req := range reqs {
+		switch r := req.Request.(type) {
+		case SetRequest:
+			r.Key = s.prefixer.PrefixKey(r.Key)
+			req.Request = r
+		case DeleteRequest:
+			r.Key = s.prefixer.PrefixKey(r.Key)
+			req.Request = r
+		}
+		prefixed = append(prefixed, req)
+	}
+
+	return s.transactional.Multi(prefixed)
+}
+
+// Query runs the query of the wrapped store, and returns the results of the prefixed keys with the keys of Dapr
+func (s *KeyPrefixQueriableStore) Query(req *QueryRequest) (*QueryResponse, error) {
+	return s.query(s.querier, req)
+}
+
+// Query runs the query of the wrapped store, and returns the results of the prefixed keys with the keys of Dapr
+func (s *KeyPrefixTransactionalQueriableStore) Query(req *QueryRequest) (*QueryResponse, error) {
+	return s.query(s.querier, req)
+}
+
+// query runs the query, whose results are those of all the keys of the wrapped store. The results of the keys
+// without the prefix are dropped, so a page may have fewer results than the limit of the query.
+func (s *KeyPrefixStore) query(querier Querier, req *QueryRequest) (*QueryResponse, error) {
+	resp, err := querier.Query(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	results := make([]QueryItem, 0, len(resp.Results))
+	for _, item := range resp.Results {
+		if !s.prefixer.HasPrefix(item.Key) {
+			continue
+		}
+		item.Key = s.prefixer.UnprefixKey(item.Key)
+		results = append(results, item)
+	}
+
+	return &QueryResponse{Results: results, Token: resp.Token}, nil
+}
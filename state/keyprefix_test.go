@@ -0,0 +1,251 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package state
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKeyPrefix(t *testing.T) {
+	valid := map[string]struct {
+		properties map[string]string
+		prefix     string
+	}{
+		"Default without app ID": {map[string]string{}, ""},
+		"Default with app ID":    {map[string]string{AppIDProperty: "app"}, "app"},
+		"App ID":                 {map[string]string{KeyPrefix: KeyPrefixAppID, AppIDProperty: "app"}, "app"},
+		"Store name":             {map[string]string{KeyPrefix: KeyPrefixStoreName, AppIDProperty: "app", StoreNameProperty: "store"}, "store"},
+		"Namespace":              {map[string]string{KeyPrefix: KeyPrefixNamespace, AppIDProperty: "app", NamespaceProperty: "ns"}, "ns.app"},
+		"None":                   {map[string]string{KeyPrefix: KeyPrefixNone, AppIDProperty: "app"}, ""},
+		"Strategies ignore case": {map[string]string{KeyPrefix: "AppID", AppIDProperty: "app"}, "app"},
+	}
+	for name, tc := range valid {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			prefix, err := GetKeyPrefix(Metadata{Properties: tc.properties})
+			assert.Nil(t, err)
+			assert.Equal(t, tc.prefix, prefix)
+		})
+	}
+
+	t.Run("Namespace from environment", func(t *testing.T) {
+		os.Setenv("NAMESPACE", "env")
+		defer os.Unsetenv("NAMESPACE")
+		prefix, err := GetKeyPrefix(Metadata{Properties: map[string]string{KeyPrefix: KeyPrefixNamespace, AppIDProperty: "app"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "env.app", prefix)
+	})
+
+	invalid := map[string]map[string]string{
+		"App ID without app ID":         {KeyPrefix: KeyPrefixAppID},
+		"Store name without store name": {KeyPrefix: KeyPrefixStoreName, AppIDProperty: "app"},
+		"Namespace without namespace":   {KeyPrefix: KeyPrefixNamespace, AppIDProperty: "app"},
+		"Unrecognized strategy":         {KeyPrefix: "custom", AppIDProperty: "app"},
+	}
+	for name, properties := range invalid {
+		properties := properties
+		t.Run(name+" fails", func(t *testing.T) {
+			_, err := GetKeyPrefix(Metadata{Properties: properties})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestPrefixKey(t *testing.T) {
+	assert.Equal(t, "app||key", PrefixKey("app", "key"))
+	assert.Equal(t, "key", PrefixKey("", "key"))
+	assert.Equal(t, "key", UnprefixKey("app", "app||key"))
+	assert.Equal(t, "key", UnprefixKey("", "key"))
+}
+
+func TestKeyPrefixer(t *testing.T) {
+	p, err := NewKeyPrefixer(Metadata{Properties: map[string]string{AppIDProperty: "app"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "app||key", p.PrefixKey("app||key"))
+	assert.Equal(t, "app||key", p.PrefixKey("key"))
+	assert.Equal(t, "app||key", p.UnprefixKey("app||key"))
+
+	p, err = NewKeyPrefixer(Metadata{Properties: map[string]string{AppIDProperty: "app", KeyPrefix: KeyPrefixStoreName, StoreNameProperty: "store"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "store||key", p.PrefixKey("app||key"))
+	assert.Equal(t, "app||key", p.UnprefixKey("store||key"))
+	assert.True(t, p.HasPrefix("store||key"))
+	assert.False(t, p.HasPrefix("other||key"))
+
+	p, err = NewKeyPrefixer(Metadata{Properties: map[string]string{AppIDProperty: "app", KeyPrefix: KeyPrefixNone}})
+	assert.Nil(t, err)
+	assert.Equal(t, "key", p.PrefixKey("app||key"))
+	assert.Equal(t, "app||key", p.UnprefixKey("key"))
+	assert.True(t, p.HasPrefix("key"))
+
+	p = KeyPrefixer{}
+	assert.Equal(t, "app||key", p.PrefixKey("app||key"))
+	assert.Equal(t, "app||key", p.UnprefixKey("app||key"))
+}
+
+func TestCheckDefaultKeyPrefix(t *testing.T) {
+	valid := map[string]map[string]string{
+		"No app ID":   {},
+		"Default":     {AppIDProperty: "app"},
+		"App ID":      {KeyPrefix: KeyPrefixAppID, AppIDProperty: "app"},
+		"No prefix":   {KeyPrefix: KeyPrefixNone},
+		"Empty value": {KeyPrefix: "", AppIDProperty: "app"},
+	}
+	for name, properties := range valid {
+		t.Run(name, func(t *testing.T) {
+			assert.Nil(t, CheckDefaultKeyPrefix(Metadata{Properties: properties}))
+		})
+	}
+
+	invalid := map[string]map[string]string{
+		"Store name":   {KeyPrefix: KeyPrefixStoreName, AppIDProperty: "app", StoreNameProperty: "store"},
+		"Namespace":    {KeyPrefix: KeyPrefixNamespace, AppIDProperty: "app", NamespaceProperty: "ns"},
+		"None":         {KeyPrefix: KeyPrefixNone, AppIDProperty: "app"},
+		"Unrecognized": {KeyPrefix: "other", AppIDProperty: "app"},
+	}
+	for name, properties := range invalid {
+		t.Run(name+" fails", func(t *testing.T) {
+			assert.NotNil(t, CheckDefaultKeyPrefix(Metadata{Properties: properties}))
+		})
+	}
+}
+
+// checkingFakeStore is a fakeStore that doesn't apply the keyPrefix strategy
+type checkingFakeStore struct {
+	*fakeStore
+}
+
+func (f *checkingFakeStore) Init(metadata Metadata) error {
+	return CheckDefaultKeyPrefix(metadata)
+}
+
+func TestKeyPrefixStoreInit(t *testing.T) {
+	properties := map[string]string{AppIDProperty: "app", KeyPrefix: KeyPrefixStoreName, StoreNameProperty: "store"}
+	assert.NotNil(t, (&checkingFakeStore{fakeStore: newFakeStore()}).Init(Metadata{Properties: properties}))
+
+	s := NewKeyPrefixStore(&checkingFakeStore{fakeStore: newFakeStore()})
+	assert.Nil(t, s.Init(Metadata{Properties: properties}))
+	assert.Equal(t, KeyPrefixStoreName, properties[KeyPrefix])
+}
+
+func TestKeyPrefixStore(t *testing.T) {
+	f := newFakeStore()
+	s, ok := NewKeyPrefixStore(&transactionalFakeStore{fakeStore: f}).(*KeyPrefixTransactionalStore)
+	assert.True(t, ok)
+	assert.Nil(t, s.Init(Metadata{Properties: map[string]string{AppIDProperty: "app"}}))
+
+	t.Run("Set and get", func(t *testing.T) {
+		assert.Nil(t, s.Set(&SetRequest{Key: "key", Value: []byte("value")}))
+		assert.Equal(t, []byte("value"), f.values["app||key"])
+
+		resp, err := s.Get(&GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), resp.Data)
+	})
+
+	t.Run("Bulk set and bulk get", func(t *testing.T) {
+		assert.Nil(t, s.BulkSet([]SetRequest{{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}}))
+		assert.Equal(t, []byte("1"), f.values["app||a"])
+
+		responses, err := s.BulkGet([]GetRequest{{Key: "a"}, {Key: "b"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "a", responses[0].Key)
+		assert.Equal(t, []byte("2"), responses[1].Data)
+	})
+
+	t.Run("Multi", func(t *testing.T) {
+		err := s.Multi([]TransactionalRequest{
+			{Operation: Upsert, Request: SetRequest{Key: "c", Value: []byte("3")}},
+			{Operation: Delete, Request: DeleteRequest{Key: "a"}},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("3"), f.values["app||c"])
+		assert.NotContains(t, f.values, "app||a")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert.Nil(t, s.Delete(&DeleteRequest{Key: "key"}))
+		assert.NotContains(t, f.values, "app||key")
+	})
+
+	t.Run("Keys of Dapr are not prefixed again", func(t *testing.T) {
+		assert.Nil(t, s.Set(&SetRequest{Key: "app||dapr", Value: []byte("value")}))
+		assert.Equal(t, []byte("value"), f.values["app||dapr"])
+		assert.NotContains(t, f.values, "app||app||dapr")
+	})
+
+	t.Run("App ID prefix is replaced", func(t *testing.T) {
+		f := newFakeStore()
+		s := NewKeyPrefixStore(f)
+		assert.Nil(t, s.Init(Metadata{Properties: map[string]string{AppIDProperty: "app", KeyPrefix: KeyPrefixStoreName, StoreNameProperty: "store"}}))
+
+		assert.Nil(t, s.Set(&SetRequest{Key: "app||key", Value: []byte("value")}))
+		assert.Equal(t, []byte("value"), f.values["store||key"])
+
+		responses, err := s.(BulkStore).BulkGet([]GetRequest{{Key: "app||key"}})
+		assert.Nil(t, err)
+		assert.Equal(t, "app||key", responses[0].Key)
+		assert.Equal(t, []byte("value"), responses[0].Data)
+	})
+}
+
+func TestKeyPrefixQueriableStore(t *testing.T) {
+	properties := map[string]string{KeyPrefix: KeyPrefixStoreName, StoreNameProperty: "store"}
+
+	t.Run("Querier", func(t *testing.T) {
+		s := NewKeyPrefixStore(&prefixedQueriableFakeStore{fakeStore: newFakeStore()})
+		_, isTransactional := s.(TransactionalStore)
+		assert.False(t, isTransactional)
+		querier, ok := s.(Querier)
+		assert.True(t, ok)
+		assert.Nil(t, s.Init(Metadata{Properties: properties}))
+
+		resp, err := querier.Query(&QueryRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, []QueryItem{{Key: "a"}, {Key: "c"}}, resp.Results)
+		assert.Equal(t, "next", resp.Token)
+	})
+
+	t.Run("Results have the keys of Dapr", func(t *testing.T) {
+		s := NewKeyPrefixStore(&prefixedQueriableFakeStore{fakeStore: newFakeStore()})
+		assert.Nil(t, s.Init(Metadata{Properties: map[string]string{KeyPrefix: KeyPrefixStoreName, StoreNameProperty: "store", AppIDProperty: "app"}}))
+
+		resp, err := s.(Querier).Query(&QueryRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, []QueryItem{{Key: "app||a"}, {Key: "app||c"}}, resp.Results)
+	})
+
+	t.Run("Transactional querier", func(t *testing.T) {
+		s := NewKeyPrefixStore(&transactionalQueriableFakeStore{transactionalFakeStore: &transactionalFakeStore{fakeStore: newFakeStore()}})
+		_, isTransactional := s.(TransactionalStore)
+		assert.True(t, isTransactional)
+		_, ok := s.(Querier)
+		assert.True(t, ok)
+	})
+
+	t.Run("Without prefix", func(t *testing.T) {
+		s := NewKeyPrefixStore(&prefixedQueriableFakeStore{fakeStore: newFakeStore()})
+		assert.Nil(t, s.Init(Metadata{Properties: map[string]string{KeyPrefix: KeyPrefixNone}}))
+
+		resp, err := s.(Querier).Query(&QueryRequest{})
+		assert.Nil(t, err)
+		assert.Len(t, resp.Results, 3)
+	})
+}
+
+// prefixedQueriableFakeStore returns the results of keys with different prefixes
+type prefixedQueriableFakeStore struct {
+	*fakeStore
+}
+
+func (f *prefixedQueriableFakeStore) Query(req *QueryRequest) (*QueryResponse, error) {
+	return &QueryResponse{
+		Results: []QueryItem{{Key: "store||a"}, {Key: "other||b"}, {Key: "store||c"}},
+		Token:   "next",
+	}, nil
+}
@@ -3,11 +3,13 @@ package memcached
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	jsoniter "github.com/json-iterator/go"
@@ -17,11 +19,16 @@ const (
 	hosts              = "hosts"
 	maxIdleConnections = "maxIdleConnections"
 	timeout            = "timeout"
+	ttlInSeconds       = "ttlInSeconds"
 	// These defaults are already provided by gomemcache
 	defaultMaxIdleConnections = 2
 	defaultTimeout            = 1000 * time.Millisecond
+	// Memcached treats expiration times over 30 days as Unix timestamps instead of relative seconds
+	maxRelativeExpiration = 60 * 60 * 24 * 30
 )
 
+var errETagMismatch = errors.New("possible etag mismatch")
+
 type Memcached struct {
 	client *memcache.Client
 	json   jsoniter.API
@@ -42,6 +49,10 @@ func NewMemCacheStateStore(logger logger.Logger) *Memcached {
 }
 
 func (m *Memcached) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	meta, err := getMemcachedMetadata(metadata)
 	if err != nil {
 		return err
@@ -61,6 +72,27 @@ func (m *Memcached) Init(metadata state.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Memcached state store. The timeout is
+// in milliseconds.
+func (m *Memcached) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: hosts, Type: metadata.TypeList, Required: true},
+			{Name: maxIdleConnections, Type: metadata.TypeInt, Default: strconv.Itoa(defaultMaxIdleConnections)},
+			{Name: timeout, Type: metadata.TypeInt, Default: strconv.FormatInt(defaultTimeout.Milliseconds(), 10)},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL},
+	}
+}
+
+// Ping checks that all the Memcached servers are alive, for health checks
+func (m *Memcached) Ping() error {
+	if m.client == nil {
+		return fmt.Errorf("the Memcached state store is not initialized")
+	}
+	return m.client.Ping()
+}
+
 func getMemcachedMetadata(metadata state.Metadata) (*memcachedMetadata, error) {
 	meta := memcachedMetadata{
 		maxIdleConnections: defaultMaxIdleConnections,
@@ -100,7 +132,25 @@ func (m *Memcached) setValue(req *state.SetRequest) error {
 	} else {
 		bt, _ = m.json.Marshal(req.Value)
 	}
-	err := m.client.Set(&memcache.Item{Key: req.Key, Value: bt})
+
+	expiration, err := parseExpiration(req.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to set key %s: %s", req.Key, err)
+	}
+
+	item := &memcache.Item{Key: req.Key, Value: bt, Expiration: expiration}
+	switch {
+	case req.ETag != "":
+		err = m.compareAndSwap(req.ETag, item)
+	case req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so the key may only be added
+		err = m.client.Add(item)
+		if errors.Is(err, memcache.ErrNotStored) {
+			err = errETagMismatch
+		}
+	default:
+		err = m.client.Set(item)
+	}
 
 	if err != nil {
 		return fmt.Errorf("failed to set key %s: %s", req.Key, err)
@@ -110,13 +160,53 @@ func (m *Memcached) setValue(req *state.SetRequest) error {
 }
 
 func (m *Memcached) Delete(req *state.DeleteRequest) error {
-	err := m.client.Delete(req.Key)
+	err := state.CheckDeleteRequestOptions(req)
 	if err != nil {
 		return err
 	}
+
+	if req.ETag != "" {
+		// Memcached has no conditional delete, but an item stored with a negative expiration expires immediately
+		err = m.compareAndSwap(req.ETag, &memcache.Item{Key: req.Key, Expiration: -1})
+		if err != nil {
+			return fmt.Errorf("failed to delete key %s: %s", req.Key, err)
+		}
+		return nil
+	}
+
+	err = m.client.Delete(req.Key)
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return err
+	}
 	return nil
 }
 
+// compareAndSwap stores item only if the CAS token of the stored item is etag and the item has not changed since.
+func (m *Memcached) compareAndSwap(etag string, item *memcache.Item) error {
+	current, err := m.client.Get(item.Key)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return errETagMismatch
+	}
+	if err != nil {
+		return err
+	}
+
+	if casToken(current) != etag {
+		return errETagMismatch
+	}
+
+	// CompareAndSwap uses the CAS token of the item returned by Get
+	current.Value = item.Value
+	current.Flags = item.Flags
+	current.Expiration = item.Expiration
+	err = m.client.CompareAndSwap(current)
+	if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+		return errETagMismatch
+	}
+
+	return err
+}
+
 func (m *Memcached) BulkDelete(req []state.DeleteRequest) error {
 	for i := range req {
 		err := m.Delete(&req[i])
@@ -139,10 +229,16 @@ func (m *Memcached) Get(req *state.GetRequest) (*state.GetResponse, error) {
 
 	return &state.GetResponse{
 		Data: item.Value,
+		ETag: casToken(item),
 	}, nil
 }
 
 func (m *Memcached) Set(req *state.SetRequest) error {
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
 	return state.SetWithRetries(m.setValue, req)
 }
 
@@ -155,3 +251,33 @@ func (m *Memcached) BulkSet(req []state.SetRequest) error {
 	}
 	return nil
 }
+
+// casToken returns the CAS token memcached assigned to the item when it was last stored.
+// gomemcache keeps the token unexported, so it is read with reflection.
+func casToken(item *memcache.Item) string {
+	return strconv.FormatUint(reflect.ValueOf(item).Elem().FieldByName("casid").Uint(), 10)
+}
+
+// parseExpiration returns the memcached expiration time for the TTL in the metadata of a set request.
+// Values without a ttlInSeconds, or with a value that is not positive, never expire.
+func parseExpiration(requestMetadata map[string]string) (int32, error) {
+	val, ok := requestMetadata[ttlInSeconds]
+	if !ok || val == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %s", ttlInSeconds)
+	}
+
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	if ttl > maxRelativeExpiration {
+		return int32(time.Now().Unix() + ttl), nil
+	}
+
+	return int32(ttl), nil
+}
@@ -0,0 +1,61 @@
+package memcached
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	hostsEnvKey = "DAPR_TEST_MEMCACHED_HOSTS" // Environment variable containing the memcached hosts
+)
+
+func TestMemcachedIntegration(t *testing.T) {
+	memcachedHosts := os.Getenv(hostsEnvKey)
+	if memcachedHosts == "" {
+		t.Skipf("Memcached state integration tests skipped. To enable define the hosts using environment variable '%s' (example 'export %s=\"localhost:11211\")", hostsEnvKey, hostsEnvKey)
+	}
+
+	m := NewMemCacheStateStore(logger.NewLogger("test"))
+	err := m.Init(state.Metadata{
+		Properties: map[string]string{hosts: memcachedHosts},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Set with CAS token", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, m.Set(&state.SetRequest{Key: key, Value: "v1"}))
+
+		resp, err := m.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		assert.NotEqual(t, "0", resp.ETag)
+		oldETag := resp.ETag
+
+		assert.Nil(t, m.Set(&state.SetRequest{Key: key, Value: "v2", ETag: oldETag}))
+		assert.NotNil(t, m.Set(&state.SetRequest{Key: key, Value: "v3", ETag: oldETag}))
+		assert.NotNil(t, m.Delete(&state.DeleteRequest{Key: key, ETag: oldETag}))
+
+		resp, err = m.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		assert.Equal(t, `"v2"`, string(resp.Data))
+
+		assert.Nil(t, m.Delete(&state.DeleteRequest{Key: key, ETag: resp.ETag}))
+		resp, err = m.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("First write fails for an existing key", func(t *testing.T) {
+		key := uuid.New().String()
+		req := &state.SetRequest{Key: key, Value: "v", Options: state.SetStateOption{Concurrency: state.FirstWrite}}
+		assert.Nil(t, m.Set(req))
+		assert.NotNil(t, m.Set(req))
+		assert.Nil(t, m.Delete(&state.DeleteRequest{Key: key}))
+	})
+}
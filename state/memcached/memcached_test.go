@@ -1,10 +1,12 @@
 package memcached
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/dapr/components-contrib/state"
 	"github.com/stretchr/testify/assert"
 )
@@ -65,3 +67,39 @@ func TestMemcachedMetadata(t *testing.T) {
 		assert.Equal(t, 5000*time.Millisecond, metadata.timeout)
 	})
 }
+
+func TestParseExpiration(t *testing.T) {
+	t.Run("without TTL", func(t *testing.T) {
+		expiration, err := parseExpiration(map[string]string{})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(0), expiration)
+	})
+
+	t.Run("with relative TTL", func(t *testing.T) {
+		expiration, err := parseExpiration(map[string]string{ttlInSeconds: "60"})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(60), expiration)
+	})
+
+	t.Run("with TTL over 30 days", func(t *testing.T) {
+		ttl := maxRelativeExpiration + 1
+		expiration, err := parseExpiration(map[string]string{ttlInSeconds: strconv.Itoa(ttl)})
+		assert.Nil(t, err)
+		assert.InDelta(t, time.Now().Unix()+int64(ttl), int64(expiration), 5)
+	})
+
+	t.Run("with negative TTL", func(t *testing.T) {
+		expiration, err := parseExpiration(map[string]string{ttlInSeconds: "-1"})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(0), expiration)
+	})
+
+	t.Run("with invalid TTL", func(t *testing.T) {
+		_, err := parseExpiration(map[string]string{ttlInSeconds: "a"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCASToken(t *testing.T) {
+	assert.Equal(t, "0", casToken(&memcache.Item{Key: "key"}))
+}
@@ -0,0 +1,160 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/metrics"
+)
+
+// MetricsStore records the count, latency and errors of the operations of a state store
+type MetricsStore struct {
+	store     Store
+	component string
+	recorder  metrics.Recorder
+}
+
+// MetricsTransactionalStore is a MetricsStore for a TransactionalStore
+type MetricsTransactionalStore struct {
+	*MetricsStore
+	transactional TransactionalStore
+}
+
+// MetricsQueriableStore is a MetricsStore for a Querier
+type MetricsQueriableStore struct {
+	*MetricsStore
+	querier Querier
+}
+
+// MetricsTransactionalQueriableStore is a MetricsStore for a TransactionalStore that is also a Querier
+type MetricsTransactionalQueriableStore struct {
+	*MetricsTransactionalStore
+	querier Querier
+}
+
+// NewMetricsStore wraps store so that its operations are recorded with the name of the component.
+// The result implements the same optional interfaces as store: it is a *MetricsTransactionalStore when store is a
+// TransactionalStore, a *MetricsQueriableStore when it is a Querier, a *MetricsTransactionalQueriableStore when it
+// is both, and a *MetricsStore otherwise.
+func NewMetricsStore(store Store, component string, recorder metrics.Recorder) Store {
+	s := &MetricsStore{store: store, component: component, recorder: recorder}
+	transactional, isTransactional := store.(TransactionalStore)
+	querier, isQuerier := store.(Querier)
+	switch {
+	case isTransactional && isQuerier:
+		return &MetricsTransactionalQueriableStore{
+			MetricsTransactionalStore: &MetricsTransactionalStore{MetricsStore: s, transactional: transactional},
+			querier:                   querier,
+		}
+	case isTransactional:
+		return &MetricsTransactionalStore{
+			MetricsStore:  s,
+			transactional: transactional,
+		}
+	case isQuerier:
+		return &MetricsQueriableStore{MetricsStore: s, querier: querier}
+	default:
+		return s
+	}
+}
+
+// Init initializes the wrapped store
+func (s *MetricsStore) Init(metadata Metadata) error {
+	return metrics.Measure(s.recorder, s.component, "init", func() error {
+		return s.store.Init(metadata)
+	})
+}
+
+// GetComponentMetadata returns the metadata of the wrapped store
+func (s *MetricsStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return s.store.GetComponentMetadata()
+}
+
+// Ping pings the wrapped store
+func (s *MetricsStore) Ping() error {
+	return health.Ping(s.store)
+}
+
+// Get gets the value of a key
+func (s *MetricsStore) Get(req *GetRequest) (*GetResponse, error) {
+	var resp *GetResponse
+	err := metrics.Measure(s.recorder, s.component, "get", func() (err error) {
+		resp, err = s.store.Get(req)
+		return err
+	})
+	return resp, err
+}
+
+// BulkGet gets the values of keys. The wrapped store is used when it is a BulkStore,
+// otherwise the keys are got with a DefaultBulkStore.
+func (s *MetricsStore) BulkGet(req []GetRequest) ([]BulkGetResponse, error) {
+	bulk, ok := s.store.(BulkStore)
+	if !ok {
+		bulk = NewDefaultBulkStore(s.store, DefaultBulkParallelism)
+	}
+
+	var responses []BulkGetResponse
+	err := metrics.Measure(s.recorder, s.component, "bulkGet", func() (err error) {
+		responses, err = bulk.BulkGet(req)
+		return err
+	})
+	return responses, err
+}
+
+// Set sets the value of a key
+func (s *MetricsStore) Set(req *SetRequest) error {
+	return metrics.Measure(s.recorder, s.component, "set", func() error {
+		return s.store.Set(req)
+	})
+}
+
+// BulkSet sets the values of keys
+func (s *MetricsStore) BulkSet(req []SetRequest) error {
+	return metrics.Measure(s.recorder, s.component, "bulkSet", func() error {
+		return s.store.BulkSet(req)
+	})
+}
+
+// Delete deletes a key
+func (s *MetricsStore) Delete(req *DeleteRequest) error {
+	return metrics.Measure(s.recorder, s.component, "delete", func() error {
+		return s.store.Delete(req)
+	})
+}
+
+// BulkDelete deletes keys
+func (s *MetricsStore) BulkDelete(req []DeleteRequest) error {
+	return metrics.Measure(s.recorder, s.component, "bulkDelete", func() error {
+		return s.store.BulkDelete(req)
+	})
+}
+
+// Multi runs the requests in a transaction of the wrapped store
+func (s *MetricsTransactionalStore) Multi(reqs []TransactionalRequest) error {
+	return metrics.Measure(s.recorder, s.component, "multi", func() error {
+		return s.transactional.Multi(reqs)
+	})
+}
+
+// Query runs the query of the wrapped store
+func (s *MetricsQueriableStore) Query(req *QueryRequest) (*QueryResponse, error) {
+	return s.query(s.querier, req)
+}
+
+// Query runs the query of the wrapped store
+func (s *MetricsTransactionalQueriableStore) Query(req *QueryRequest) (*QueryResponse, error) {
+	return s.query(s.querier, req)
+}
+
+func (s *MetricsStore) query(querier Querier, req *QueryRequest) (*QueryResponse, error) {
+	var resp *QueryResponse
+	err := metrics.Measure(s.recorder, s.component, "query", func() (err error) {
+		resp, err = querier.Query(req)
+		return err
+	})
+	return resp, err
+}
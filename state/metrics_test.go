@@ -0,0 +1,125 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dapr/components-contrib/health"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsStore(t *testing.T) {
+	t.Run("Records the operations", func(t *testing.T) {
+		c := metrics.NewCollector()
+		s := NewMetricsStore(newFakeStore(), "statestore", c)
+		_, ok := s.(*MetricsStore)
+		assert.True(t, ok)
+
+		assert.Nil(t, s.Init(Metadata{}))
+		assert.Nil(t, s.Set(&SetRequest{Key: "key", Value: []byte("value")}))
+		assert.NotNil(t, s.Set(&SetRequest{Key: "fail", Value: []byte("value")}))
+		resp, err := s.Get(&GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), resp.Data)
+		assert.Nil(t, s.Delete(&DeleteRequest{Key: "key"}))
+		assert.NotNil(t, s.BulkDelete([]DeleteRequest{{Key: "key"}}))
+
+		responses, err := s.(*MetricsStore).BulkGet([]GetRequest{{Key: "key"}, {Key: "fail"}})
+		assert.Nil(t, err)
+		assert.Len(t, responses, 2)
+
+		stats := c.Stats()
+		assert.Equal(t, metrics.OperationStats{Count: 2, Errors: 1}, withoutLatency(stats[metrics.OperationKey{Component: "statestore", Operation: "set"}]))
+		assert.Equal(t, metrics.OperationStats{Count: 1}, withoutLatency(stats[metrics.OperationKey{Component: "statestore", Operation: "init"}]))
+		assert.Equal(t, metrics.OperationStats{Count: 1}, withoutLatency(stats[metrics.OperationKey{Component: "statestore", Operation: "get"}]))
+		assert.Equal(t, metrics.OperationStats{Count: 1}, withoutLatency(stats[metrics.OperationKey{Component: "statestore", Operation: "delete"}]))
+		assert.Equal(t, metrics.OperationStats{Count: 1, Errors: 1}, withoutLatency(stats[metrics.OperationKey{Component: "statestore", Operation: "bulkDelete"}]))
+		assert.Equal(t, metrics.OperationStats{Count: 1}, withoutLatency(stats[metrics.OperationKey{Component: "statestore", Operation: "bulkGet"}]))
+		assert.True(t, stats[metrics.OperationKey{Component: "statestore", Operation: "get"}].TotalLatency > 0)
+	})
+
+	t.Run("Transactional store", func(t *testing.T) {
+		c := metrics.NewCollector()
+		f := newFakeStore()
+		s, ok := NewMetricsStore(&transactionalFakeStore{fakeStore: f}, "statestore", c).(*MetricsTransactionalStore)
+		assert.True(t, ok)
+
+		assert.Nil(t, s.Multi([]TransactionalRequest{{Operation: Upsert, Request: SetRequest{Key: "key", Value: []byte("value")}}}))
+		assert.Equal(t, []byte("value"), f.values["key"])
+		assert.Equal(t, int64(1), c.Stats()[metrics.OperationKey{Component: "statestore", Operation: "multi"}].Count)
+	})
+
+	t.Run("Querier", func(t *testing.T) {
+		c := metrics.NewCollector()
+		s := NewMetricsStore(&queriableFakeStore{fakeStore: newFakeStore()}, "statestore", c)
+		_, isTransactional := s.(TransactionalStore)
+		assert.False(t, isTransactional)
+		querier, ok := s.(Querier)
+		assert.True(t, ok)
+
+		resp, err := querier.Query(&QueryRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, "key", resp.Results[0].Key)
+		assert.Equal(t, int64(1), c.Stats()[metrics.OperationKey{Component: "statestore", Operation: "query"}].Count)
+	})
+
+	t.Run("Transactional querier", func(t *testing.T) {
+		s := NewMetricsStore(&transactionalQueriableFakeStore{&transactionalFakeStore{fakeStore: newFakeStore()}}, "statestore", metrics.NewCollector())
+		_, isTransactional := s.(TransactionalStore)
+		assert.True(t, isTransactional)
+		_, isQuerier := s.(Querier)
+		assert.True(t, isQuerier)
+	})
+
+	t.Run("Forwards the pings", func(t *testing.T) {
+		s := NewMetricsStore(newFakeStore(), "statestore", metrics.NewCollector())
+		assert.Equal(t, health.ErrPingNotImplemented, health.Ping(s))
+
+		pingErr := errors.New("unreachable")
+		s = NewMetricsStore(&pingerFakeStore{fakeStore: newFakeStore(), err: pingErr}, "statestore", metrics.NewCollector())
+		assert.Equal(t, pingErr, health.Ping(s))
+	})
+}
+
+// queriableFakeStore returns the keys of the query results
+type queriableFakeStore struct {
+	*fakeStore
+}
+
+func (f *queriableFakeStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityQuery}}
+}
+
+func (f *queriableFakeStore) Query(req *QueryRequest) (*QueryResponse, error) {
+	return &QueryResponse{Results: []QueryItem{{Key: "key"}}}, nil
+}
+
+type transactionalQueriableFakeStore struct {
+	*transactionalFakeStore
+}
+
+func (f *transactionalQueriableFakeStore) Query(req *QueryRequest) (*QueryResponse, error) {
+	return &QueryResponse{Results: []QueryItem{{Key: "key"}}}, nil
+}
+
+type pingerFakeStore struct {
+	*fakeStore
+	err error
+}
+
+func (f *pingerFakeStore) Ping() error {
+	return f.err
+}
+
+func withoutLatency(s metrics.OperationStats) metrics.OperationStats {
+	s.TotalLatency = 0
+	s.MaxLatency = 0
+	return s
+}
@@ -0,0 +1,204 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package migration copies the items of one state store to another.
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// KeyLister lists the keys of the source store one page at a time. The first page is listed with an empty cursor,
+// and nextCursor is empty after the last page.
+type KeyLister func(cursor string) (keys []string, nextCursor string, err error)
+
+// DoneCursor is the cursor saved after the last page, with which a resumed migration has nothing left to migrate
+const DoneCursor = "<done>"
+
+// Checkpoint saves the cursor of the next page to migrate, so that an interrupted migration can be resumed
+type Checkpoint interface {
+	// Load returns the saved cursor, which is empty when the migration has not started, and DoneCursor when it
+	// completed
+	Load() (string, error)
+	Save(cursor string) error
+}
+
+// Options configures a migration
+type Options struct {
+	// RateLimit is the maximum number of items written per second. There is no limit when it is zero.
+	RateLimit int
+	// Checkpoint saves the progress of the migration. The migration starts from the beginning when it is nil.
+	Checkpoint Checkpoint
+}
+
+// Result describes a migration
+type Result struct {
+	// Migrated is the number of items written to the target store
+	Migrated int
+	// Skipped is the number of listed keys that no longer had a value when they were read
+	Skipped int
+}
+
+// Migrator copies the items of a source store to a target store
+type Migrator struct {
+	source state.Store
+	list   KeyLister
+	target state.Store
+	opts   Options
+	logger logger.Logger
+}
+
+// NewMigrator returns a Migrator for the keys of source listed by list.
+// Both stores must be initialized.
+func NewMigrator(source state.Store, list KeyLister, target state.Store, opts Options, logger logger.Logger) *Migrator {
+	return &Migrator{
+		source: source,
+		list:   list,
+		target: target,
+		opts:   opts,
+		logger: logger,
+	}
+}
+
+// Migrate copies the items page by page until all keys are migrated or ctx is done.
+// Values are written with last-write concurrency, so the target store assigns its own ETags.
+// When a checkpoint is configured, the cursor of each page is saved after the page is written, and DoneCursor after
+// the last page.
+func (m *Migrator) Migrate(ctx context.Context) (Result, error) {
+	var result Result
+
+	cursor := ""
+	if m.opts.Checkpoint != nil {
+		var err error
+		cursor, err = m.opts.Checkpoint.Load()
+		if err != nil {
+			return result, fmt.Errorf("failed to load checkpoint: %s", err)
+		}
+		if cursor == DoneCursor {
+			m.logger.Info("The migration has already completed")
+			return result, nil
+		}
+		if cursor != "" {
+			m.logger.Infof("Resuming migration from cursor %s", cursor)
+		}
+	}
+
+	var throttle <-chan time.Time
+	if m.opts.RateLimit > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(m.opts.RateLimit))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	for {
+		keys, next, err := m.list(cursor)
+		if err != nil {
+			return result, fmt.Errorf("failed to list keys: %s", err)
+		}
+
+		for _, key := range keys {
+			if throttle != nil {
+				select {
+				case <-throttle:
+				case <-ctx.Done():
+					return result, ctx.Err()
+				}
+			} else if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+
+			migrated, err := m.migrateKey(key)
+			if err != nil {
+				return result, err
+			}
+			if migrated {
+				result.Migrated++
+			} else {
+				result.Skipped++
+			}
+		}
+
+		if m.opts.Checkpoint != nil {
+			saved := next
+			if saved == "" {
+				saved = DoneCursor
+			}
+			err = m.opts.Checkpoint.Save(saved)
+			if err != nil {
+				return result, fmt.Errorf("failed to save checkpoint: %s", err)
+			}
+		}
+
+		m.logger.Debugf("Migrated %d items", result.Migrated)
+		if next == "" {
+			return result, nil
+		}
+		cursor = next
+	}
+}
+
+// migrateKey copies a single item, and returns false when the key no longer has a value.
+func (m *Migrator) migrateKey(key string) (bool, error) {
+	resp, err := m.source.Get(&state.GetRequest{Key: key})
+	if err != nil {
+		return false, fmt.Errorf("failed to get key %s: %s", key, err)
+	}
+	if resp == nil || resp.Data == nil {
+		return false, nil
+	}
+
+	err = m.target.Set(&state.SetRequest{
+		Key:     key,
+		Value:   resp.Data,
+		Options: state.SetStateOption{Concurrency: state.LastWrite},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to set key %s: %s", key, err)
+	}
+
+	return true, nil
+}
+
+// FileCheckpoint saves the cursor of a migration in a file
+type FileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint returns a Checkpoint saved in the file at path
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+// Load returns the cursor in the file, or an empty cursor when the file does not exist
+func (c *FileCheckpoint) Load() (string, error) {
+	b, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Save writes cursor to the file. The file is replaced atomically, so that it is never left half written.
+func (c *FileCheckpoint) Save(cursor string) error {
+	tmp := c.path + ".tmp"
+	err := ioutil.WriteFile(tmp, []byte(cursor), 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, c.path)
+}
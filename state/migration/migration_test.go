@@ -0,0 +1,166 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/inmemory"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCheckpoint keeps the cursors it saved
+type memoryCheckpoint struct {
+	cursor string
+	saved  []string
+}
+
+func (c *memoryCheckpoint) Load() (string, error) {
+	return c.cursor, nil
+}
+
+func (c *memoryCheckpoint) Save(cursor string) error {
+	c.cursor = cursor
+	c.saved = append(c.saved, cursor)
+	return nil
+}
+
+func newStore(t *testing.T) *inmemory.InMemory {
+	s := inmemory.NewInMemoryStateStore(logger.NewLogger("test"))
+	assert.Nil(t, s.Init(state.Metadata{}))
+	return s
+}
+
+// pagedLister lists keys in pages of size, using the index of the next key as the cursor
+func pagedLister(keys []string, size int) KeyLister {
+	return func(cursor string) ([]string, string, error) {
+		start := 0
+		if cursor != "" {
+			fmt.Sscanf(cursor, "%d", &start)
+		}
+		end := start + size
+		if end >= len(keys) {
+			return keys[start:], "", nil
+		}
+		return keys[start:end], fmt.Sprintf("%d", end), nil
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	t.Run("Copies all items", func(t *testing.T) {
+		source, target := newStore(t), newStore(t)
+		keys := []string{"a", "b", "c", "d", "e"}
+		for _, key := range keys {
+			assert.Nil(t, source.Set(&state.SetRequest{Key: key, Value: []byte(`"` + key + `"`)}))
+		}
+		// Keys can be removed from the source while it is listed
+		keys = append(keys, "deleted")
+
+		checkpoint := &memoryCheckpoint{}
+		m := NewMigrator(source, pagedLister(keys, 2), target, Options{Checkpoint: checkpoint}, logger.NewLogger("test"))
+		result, err := m.Migrate(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, Result{Migrated: 5, Skipped: 1}, result)
+		assert.Equal(t, []string{"2", "4", DoneCursor}, checkpoint.saved)
+
+		for _, key := range keys[:5] {
+			resp, err := target.Get(&state.GetRequest{Key: key})
+			assert.Nil(t, err)
+			assert.Equal(t, `"`+key+`"`, string(resp.Data))
+		}
+	})
+
+	t.Run("Resumes from the checkpoint", func(t *testing.T) {
+		source, target := newStore(t), newStore(t)
+		keys := []string{"a", "b", "c", "d"}
+		for _, key := range keys {
+			assert.Nil(t, source.Set(&state.SetRequest{Key: key, Value: []byte(`"` + key + `"`)}))
+		}
+
+		m := NewMigrator(source, pagedLister(keys, 2), target, Options{Checkpoint: &memoryCheckpoint{cursor: "2"}}, logger.NewLogger("test"))
+		result, err := m.Migrate(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 2, result.Migrated)
+
+		resp, err := target.Get(&state.GetRequest{Key: "a"})
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("Completed migrations are not run again", func(t *testing.T) {
+		source, target := newStore(t), newStore(t)
+		assert.Nil(t, source.Set(&state.SetRequest{Key: "a", Value: []byte(`"a"`)}))
+
+		checkpoint := &memoryCheckpoint{}
+		m := NewMigrator(source, pagedLister([]string{"a"}, 2), target, Options{Checkpoint: checkpoint}, logger.NewLogger("test"))
+		result, err := m.Migrate(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, result.Migrated)
+		assert.Equal(t, DoneCursor, checkpoint.cursor)
+
+		resumed := NewMigrator(source, func(cursor string) ([]string, string, error) {
+			return nil, "", fmt.Errorf("listed the keys of a completed migration")
+		}, target, Options{Checkpoint: checkpoint}, logger.NewLogger("test"))
+		result, err = resumed.Migrate(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, Result{}, result)
+	})
+
+	t.Run("Rate limit", func(t *testing.T) {
+		source, target := newStore(t), newStore(t)
+		keys := []string{"a", "b", "c", "d", "e"}
+		for _, key := range keys {
+			assert.Nil(t, source.Set(&state.SetRequest{Key: key, Value: []byte(`"` + key + `"`)}))
+		}
+
+		m := NewMigrator(source, pagedLister(keys, 10), target, Options{RateLimit: 100}, logger.NewLogger("test"))
+		start := time.Now()
+		_, err := m.Migrate(context.Background())
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) >= 40*time.Millisecond)
+	})
+
+	t.Run("Stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		m := NewMigrator(newStore(t), pagedLister([]string{"a"}, 10), newStore(t), Options{}, logger.NewLogger("test"))
+		_, err := m.Migrate(ctx)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("Listing errors fail the migration", func(t *testing.T) {
+		list := func(cursor string) ([]string, string, error) {
+			return nil, "", fmt.Errorf("unavailable")
+		}
+		m := NewMigrator(newStore(t), list, newStore(t), Options{}, logger.NewLogger("test"))
+		_, err := m.Migrate(context.Background())
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFileCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dapr-migration")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	c := NewFileCheckpoint(filepath.Join(dir, "checkpoint"))
+	cursor, err := c.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "", cursor)
+
+	assert.Nil(t, c.Save("42"))
+	cursor, err = c.Load()
+	assert.Nil(t, err)
+	assert.Equal(t, "42", cursor)
+}
@@ -12,14 +12,17 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/dapr/pkg/logger"
 	json "github.com/json-iterator/go"
 
 	"github.com/dapr/components-contrib/state"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -55,6 +58,8 @@ type MongoDB struct {
 	client           *mongo.Client
 	collection       *mongo.Collection
 	operationTimeout time.Duration
+	// transactions are only supported by replica sets and sharded clusters
+	transactionsSupported bool
 
 	logger logger.Logger
 }
@@ -71,10 +76,17 @@ type mongoDBMetadata struct {
 	operationTimeout time.Duration
 }
 
-// Mongodb document wrapper
+// Mongodb document wrapper. Values that are JSON objects are stored as documents, so that they can be queried,
+// and other values are stored as strings.
 type Item struct {
-	Key   string `bson:"_id"`
-	Value string `bson:"value"`
+	Key   string        `bson:"_id"`
+	Value bson.RawValue `bson:"value"`
+}
+
+// isMasterResult is the part of the result of the isMaster command that describes the deployment
+type isMasterResult struct {
+	SetName string `bson:"setName"`
+	Msg     string `bson:"msg"`
 }
 
 // NewMongoDBStateStore returns a new MongoDB state store
@@ -84,6 +96,10 @@ func NewMongoDB(logger logger.Logger) *MongoDB {
 
 // Init establishes connection to the store based on the metadata
 func (m *MongoDB) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	meta, err := getMongoDBMetaData(metadata)
 	if err != nil {
 		return err
@@ -103,6 +119,11 @@ func (m *MongoDB) Init(metadata state.Metadata) error {
 
 	m.client = client
 
+	m.transactionsSupported, err = m.isReplicaSetOrCluster()
+	if err != nil {
+		return fmt.Errorf("error in getting the mongodb deployment type: %s", err)
+	}
+
 	// get the write concern
 	wc, err := getWriteConcernObject(meta.writeconcern)
 
@@ -125,6 +146,34 @@ func (m *MongoDB) Init(metadata state.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the MongoDB state store
+func (m *MongoDB) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: host, Type: metadata.TypeString, Required: true},
+			{Name: username, Type: metadata.TypeString},
+			{Name: password, Type: metadata.TypeString},
+			{Name: databaseName, Type: metadata.TypeString, Default: defaultDatabaseName},
+			{Name: collectionName, Type: metadata.TypeString, Default: defaultCollectionName},
+			{Name: writeConcern, Type: metadata.TypeString},
+			{Name: readConcern, Type: metadata.TypeString},
+			{Name: operationTimeout, Type: metadata.TypeDuration, Default: defaultTimeout.String()},
+			{Name: params, Type: metadata.TypeString},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityQuery, metadata.CapabilityTransactional},
+	}
+}
+
+// Ping pings the primary of the MongoDB deployment, for health checks
+func (m *MongoDB) Ping() error {
+	if m.client == nil {
+		return fmt.Errorf("the MongoDB state store is not initialized")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	defer cancel()
+	return m.client.Ping(ctx, nil)
+}
+
 // Set saves state into MongoDB
 func (m *MongoDB) Set(req *state.SetRequest) error {
 	ctx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
@@ -150,7 +199,7 @@ func (m *MongoDB) setInternal(ctx context.Context, req *state.SetRequest) error
 
 	// create a document based on request key and value
 	filter := bson.M{id: req.Key}
-	update := bson.M{"$set": bson.M{id: req.Key, value: vStr}}
+	update := bson.M{"$set": bson.M{id: req.Key, value: documentOrString(vStr)}}
 	_, err := m.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
 
 	if err != nil {
@@ -174,13 +223,40 @@ func (m *MongoDB) Get(req *state.GetRequest) (*state.GetResponse, error) {
 		return &state.GetResponse{}, err
 	}
 
-	value := []byte(result.Value)
+	value, err := itemValue(result.Value)
+	if err != nil {
+		return &state.GetResponse{}, err
+	}
 
 	return &state.GetResponse{
 		Data: value,
 	}, nil
 }
 
+// documentOrString returns the document of a JSON object, or the string itself for any other value
+func documentOrString(vStr string) interface{} {
+	if !strings.HasPrefix(strings.TrimSpace(vStr), "{") {
+		return vStr
+	}
+
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON([]byte(vStr), false, &doc); err != nil {
+		return vStr
+	}
+
+	return doc
+}
+
+// itemValue returns the JSON of a value stored as a document, or the string of any other value
+func itemValue(v bson.RawValue) ([]byte, error) {
+	if v.Type == bsontype.EmbeddedDocument {
+		return bson.MarshalExtJSON(v.Document(), false, false)
+	}
+
+	s, _ := v.StringValueOK()
+	return []byte(s), nil
+}
+
 // BulkSet performs a bulks save operation
 func (m *MongoDB) BulkSet(req []state.SetRequest) error {
 	for i := range req {
@@ -230,21 +306,28 @@ func (m *MongoDB) BulkDelete(req []state.DeleteRequest) error {
 	return nil
 }
 
-// Multi performs a transactional operation. succeeds only if all operations succeed, and fails if one or more operations fail
+// Multi performs a transactional operation. succeeds only if all operations succeed, and fails if one or more operations fail.
+// It requires a replica set or a sharded cluster, as standalone servers do not support multi-document transactions.
 func (m *MongoDB) Multi(operations []state.TransactionalRequest) error {
-	sess, err := m.client.StartSession()
-	txnOpts := options.Transaction().SetReadConcern(readconcern.Snapshot()).
-		SetWriteConcern(writeconcern.New(writeconcern.WMajority()))
-
-	defer sess.EndSession(context.Background())
+	if !m.transactionsSupported {
+		return errors.New("transactions require mongodb to be a replica set or a sharded cluster")
+	}
 
+	sess, err := m.client.StartSession()
 	if err != nil {
 		return fmt.Errorf("error in starting the transaction: %s", err)
 	}
+	defer sess.EndSession(context.Background())
 
-	sess.WithTransaction(context.Background(), func(sessCtx mongo.SessionContext) (interface{}, error) {
-		err = m.doTransaction(sessCtx, operations)
-		return nil, err
+	txnOpts := options.Transaction().SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.New(writeconcern.WMajority()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	defer cancel()
+
+	// WithTransaction commits when the callback succeeds, and retries transient errors
+	_, err = sess.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, m.doTransaction(sessCtx, operations)
 	}, txnOpts)
 
 	return err
@@ -262,7 +345,6 @@ func (m *MongoDB) doTransaction(sessCtx mongo.SessionContext, operations []state
 		}
 
 		if err != nil {
-			sessCtx.AbortTransaction(sessCtx)
 			return fmt.Errorf("error during transaction, aborting the transaction: %s", err)
 		}
 	}
@@ -270,6 +352,20 @@ func (m *MongoDB) doTransaction(sessCtx mongo.SessionContext, operations []state
 	return nil
 }
 
+// isReplicaSetOrCluster returns whether the client is connected to a replica set or to the mongos of a sharded cluster
+func (m *MongoDB) isReplicaSetOrCluster() (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	defer cancel()
+
+	var result isMasterResult
+	err := m.client.Database("admin").RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&result)
+	if err != nil {
+		return false, err
+	}
+
+	return result.SetName != "" || result.Msg == "isdbgrid", nil
+}
+
 func getMongoURI(metadata *mongoDBMetadata) string {
 	if metadata.username != "" && metadata.password != "" {
 		return fmt.Sprintf(connectionURIFormatWithAuthentication, metadata.username, metadata.password, metadata.host, metadata.databaseName, metadata.params)
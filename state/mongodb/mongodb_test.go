@@ -10,6 +10,8 @@ import (
 
 	"github.com/dapr/components-contrib/state"
 	"github.com/stretchr/testify/assert"
+
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 func TestGetMongoDBMetadata(t *testing.T) {
@@ -124,3 +126,17 @@ func TestGetMongoDBMetadata(t *testing.T) {
 		assert.Equal(t, expected, uri)
 	})
 }
+
+func TestValueRoundTrip(t *testing.T) {
+	for _, v := range []string{`{"person":{"org":"Dev Ops","age":42}}`, `"a string"`, `[1,2]`, `not json`} {
+		raw, err := bson.Marshal(bson.D{{Key: value, Value: documentOrString(v)}})
+		assert.Nil(t, err)
+
+		data, err := itemValue(bson.Raw(raw).Lookup(value))
+		assert.Nil(t, err)
+		assert.Equal(t, v, string(data))
+	}
+
+	_, ok := documentOrString(`{"person":{"org":"Dev Ops"}}`).(bson.D)
+	assert.True(t, ok)
+}
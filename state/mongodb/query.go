@@ -0,0 +1,161 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/query"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// queryIndexName is the request metadata naming the index the query should use
+	queryIndexName = "queryIndexName"
+)
+
+// Query returns the state values that match the query with find(). Only values stored as documents match filters.
+func (m *MongoDB) Query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	builder := &queryBuilder{}
+	err := query.NewQueryBuilder(builder).BuildQuery(&req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	if val, ok := req.Metadata[queryIndexName]; ok && val != "" {
+		builder.opts.SetHint(val)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.operationTimeout)
+	defer cancel()
+
+	cursor, err := m.collection.Find(ctx, builder.filter, builder.opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	response := &state.QueryResponse{
+		Results: []state.QueryItem{},
+	}
+	for cursor.Next(ctx) {
+		var item Item
+		err = cursor.Decode(&item)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := itemValue(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, state.QueryItem{
+			Key:  item.Key,
+			Data: data,
+		})
+	}
+
+	err = cursor.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page means there may be more results
+	if req.Query.Page.Limit > 0 && len(response.Results) == req.Query.Page.Limit {
+		response.Token = strconv.Itoa(builder.offset + req.Query.Page.Limit)
+	}
+
+	return response, nil
+}
+
+// queryBuilder translates a query into a find() filter and options. The filters are built as extended JSON.
+// It implements query.Visitor.
+type queryBuilder struct {
+	filter bson.D
+	opts   *options.FindOptions
+	offset int
+}
+
+// VisitEQ implements query.Visitor.
+func (b *queryBuilder) VisitEQ(f *query.EQ) (string, error) {
+	return b.condition(f.Key, f.Val)
+}
+
+// VisitIN implements query.Visitor.
+func (b *queryBuilder) VisitIN(f *query.IN) (string, error) {
+	return b.condition(f.Key, map[string]interface{}{"$in": f.Vals})
+}
+
+// VisitAND implements query.Visitor.
+func (b *queryBuilder) VisitAND(f *query.AND, filters []string) (string, error) {
+	return `{"$and": [` + strings.Join(filters, ", ") + `]}`, nil
+}
+
+// VisitOR implements query.Visitor.
+func (b *queryBuilder) VisitOR(f *query.OR, filters []string) (string, error) {
+	return `{"$or": [` + strings.Join(filters, ", ") + `]}`, nil
+}
+
+// Finalize implements query.Visitor.
+func (b *queryBuilder) Finalize(filter string, q *query.Query) error {
+	b.filter = bson.D{}
+	if filter != "" {
+		err := bson.UnmarshalExtJSON([]byte(filter), false, &b.filter)
+		if err != nil {
+			return fmt.Errorf("invalid query filter: %s", err)
+		}
+	}
+
+	sort := make(bson.D, 0, len(q.Sort)+1)
+	for _, sorting := range q.Sort {
+		order := 1
+		if sorting.Order == query.SortDESC {
+			order = -1
+		}
+		sort = append(sort, bson.E{Key: valuePath(sorting.Key), Value: order})
+	}
+	// Sort by key last so that results are stable between pages
+	sort = append(sort, bson.E{Key: id, Value: 1})
+
+	b.opts = options.Find().SetSort(sort)
+
+	if q.Page.Limit > 0 {
+		b.opts.SetLimit(int64(q.Page.Limit))
+	}
+
+	if q.Page.Token != "" {
+		offset, err := strconv.Atoi(q.Page.Token)
+		if err != nil || offset < 0 {
+			return fmt.Errorf("invalid page token '%s'", q.Page.Token)
+		}
+		b.offset = offset
+		b.opts.SetSkip(int64(offset))
+	}
+
+	return nil
+}
+
+// condition returns the extended JSON of the condition on the value at key
+func (b *queryBuilder) condition(key string, condition interface{}) (string, error) {
+	c, err := json.Marshal(map[string]interface{}{valuePath(key): condition})
+	if err != nil {
+		return "", err
+	}
+
+	return string(c), nil
+}
+
+// valuePath returns the path of a dot separated key of the value in the document
+func valuePath(key string) string {
+	return value + "." + key
+}
@@ -0,0 +1,55 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mongodb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/state/query"
+	"github.com/stretchr/testify/assert"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	t.Run("Filter, sort, and page", func(t *testing.T) {
+		var q query.Query
+		err := json.Unmarshal([]byte(`{
+			"filter": {"OR": [{"EQ": {"person.org": "Dev Ops"}}, {"AND": [{"EQ": {"person.org": "Finance"}}, {"IN": {"state": ["CA", "WA"]}}]}]},
+			"sort": [{"key": "state", "order": "DESC"}, {"key": "person.name"}],
+			"page": {"limit": 2, "token": "4"}
+		}`), &q)
+		assert.Nil(t, err)
+
+		builder := &queryBuilder{}
+		assert.Nil(t, query.NewQueryBuilder(builder).BuildQuery(&q))
+
+		filter, err := bson.MarshalExtJSON(builder.filter, false, false)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"$or": [
+			{"value.person.org": "Dev Ops"},
+			{"$and": [{"value.person.org": "Finance"}, {"value.state": {"$in": ["CA", "WA"]}}]}
+		]}`, string(filter))
+		assert.Equal(t, bson.D{{Key: "value.state", Value: -1}, {Key: "value.person.name", Value: 1}, {Key: id, Value: 1}}, builder.opts.Sort)
+		assert.Equal(t, int64(2), *builder.opts.Limit)
+		assert.Equal(t, int64(4), *builder.opts.Skip)
+		assert.Equal(t, 4, builder.offset)
+	})
+
+	t.Run("No filter", func(t *testing.T) {
+		builder := &queryBuilder{}
+		assert.Nil(t, query.NewQueryBuilder(builder).BuildQuery(&query.Query{}))
+		assert.Equal(t, bson.D{}, builder.filter)
+		assert.Nil(t, builder.opts.Limit)
+		assert.Nil(t, builder.opts.Skip)
+	})
+
+	t.Run("Invalid page token", func(t *testing.T) {
+		q := query.Query{Page: query.Pagination{Token: "next"}}
+		assert.NotNil(t, query.NewQueryBuilder(&queryBuilder{}).BuildQuery(&q))
+	})
+}
@@ -0,0 +1,122 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// MySQL state store, which also supports MariaDB
+type MySQL struct {
+	logger   logger.Logger
+	dbaccess dbAccess
+}
+
+// NewMySQLStateStore creates a new instance of MySQL state store
+func NewMySQLStateStore(logger logger.Logger) *MySQL {
+	dba := newMySQLDBAccess(logger)
+	return newMySQLStateStore(logger, dba)
+}
+
+// newMySQLStateStore creates a new instance of a MySQL state store.
+// This unexported constructor allows injecting a dbAccess instance for unit testing.
+func newMySQLStateStore(logger logger.Logger, dba dbAccess) *MySQL {
+	return &MySQL{
+		logger:   logger,
+		dbaccess: dba,
+	}
+}
+
+// Init initializes the MySQL state store
+func (m *MySQL) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+	return m.dbaccess.Init(metadata)
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the MySQL state store
+func (m *MySQL) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionStringKey, Type: metadata.TypeString, Required: true},
+			{Name: tableNameKey, Type: metadata.TypeString, Default: defaultTableName},
+			{Name: state.DefaultTTLInSecondsKey, Type: metadata.TypeInt},
+			{Name: cleanupIntervalKey, Type: metadata.TypeDuration, Default: defaultCleanupInterval.String()},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL, metadata.CapabilityTransactional},
+	}
+}
+
+// Delete removes an entity from the store
+func (m *MySQL) Delete(req *state.DeleteRequest) error {
+	return m.dbaccess.Delete(req)
+}
+
+// BulkDelete removes multiple entries from the store
+func (m *MySQL) BulkDelete(req []state.DeleteRequest) error {
+	return m.dbaccess.BulkDelete(req)
+}
+
+// Get returns an entity from store
+func (m *MySQL) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return m.dbaccess.Get(req)
+}
+
+// Set adds/updates an entity on store
+func (m *MySQL) Set(req *state.SetRequest) error {
+	return m.dbaccess.Set(req)
+}
+
+// BulkSet adds/updates multiple entities on store
+func (m *MySQL) BulkSet(req []state.SetRequest) error {
+	return m.dbaccess.BulkSet(req)
+}
+
+// Multi handles multiple transactions. Implements TransactionalStore.
+// Operations are applied in order and atomically.
+func (m *MySQL) Multi(reqs []state.TransactionalRequest) error {
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			if _, ok := req.Request.(state.SetRequest); !ok {
+				return fmt.Errorf("expecting set request")
+			}
+
+		case state.Delete:
+			if _, ok := req.Request.(state.DeleteRequest); !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+
+		default:
+			return fmt.Errorf("unsupported operation: %s", req.Operation)
+		}
+	}
+
+	if len(reqs) > 0 {
+		return m.dbaccess.ExecuteMulti(reqs)
+	}
+
+	return nil
+}
+
+// Ping reports whether the database can be reached, for health checks
+func (m *MySQL) Ping() error {
+	return m.dbaccess.Ping()
+}
+
+// Close implements io.Closer
+func (m *MySQL) Close() error {
+	if m.dbaccess != nil {
+		return m.dbaccess.Close()
+	}
+
+	return nil
+}
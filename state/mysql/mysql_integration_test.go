@@ -0,0 +1,63 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package mysql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/conformance"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	connectionStringEnvKey = "DAPR_TEST_MYSQL_CONNSTRING" // Environment variable containing the connection string
+)
+
+func TestMySQLIntegration(t *testing.T) {
+	connectionString := getConnectionString()
+	if connectionString == "" {
+		t.Skipf("MySQL state integration tests skipped. To enable define the connection string using environment variable '%s' (example 'export %s=\"root:example@tcp(localhost:3306)/dapr_test\")", connectionStringEnvKey, connectionStringEnvKey)
+	}
+
+	m := NewMySQLStateStore(logger.NewLogger("test"))
+	t.Cleanup(func() {
+		defer m.Close()
+	})
+
+	err := m.Init(state.Metadata{
+		Properties: map[string]string{connectionStringKey: connectionString},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("CRUD conformance", func(t *testing.T) {
+		t.Parallel()
+		conformance.CRUD(t, m)
+	})
+
+	t.Run("Delete item that does not exist fails", func(t *testing.T) {
+		t.Parallel()
+		err := m.Delete(&state.DeleteRequest{Key: randomKey()})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Transactions conformance", func(t *testing.T) {
+		t.Parallel()
+		conformance.Transactions(t, m)
+	})
+
+	t.Run("TTL conformance", func(t *testing.T) {
+		t.Parallel()
+		conformance.TTL(t, m, nil)
+	})
+}
+
+func getConnectionString() string {
+	return os.Getenv(connectionStringEnvKey)
+}
@@ -0,0 +1,181 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeConnectionString = "not a real connection"
+)
+
+// Fake implementation of interface mysql.dbaccess
+type fakeDBaccess struct {
+	logger        logger.Logger
+	initExecuted  bool
+	multiExecuted bool
+	pingErr       error
+}
+
+func (f *fakeDBaccess) Init(metadata state.Metadata) error {
+	f.initExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Set(req *state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeDBaccess) Delete(req *state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	f.multiExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Ping() error {
+	return f.pingErr
+}
+
+func (f *fakeDBaccess) Close() error {
+	return nil
+}
+
+// Proves that the Init method runs the init method
+func TestInitRunsDBAccessInit(t *testing.T) {
+	t.Parallel()
+	_, fake := createMySQLWithFake(t)
+	assert.True(t, fake.initExecuted)
+}
+
+func TestPingReturnsDBAccessResult(t *testing.T) {
+	t.Parallel()
+	store, fake := createMySQLWithFake(t)
+	assert.Nil(t, store.Ping())
+
+	fake.pingErr = errors.New("connection refused")
+	assert.Equal(t, fake.pingErr, store.Ping())
+}
+
+func TestMultiWithNoRequestsDoesNothing(t *testing.T) {
+	t.Parallel()
+	m, fake := createMySQLWithFake(t)
+	err := m.Multi(nil)
+	assert.Nil(t, err)
+	assert.False(t, fake.multiExecuted)
+}
+
+func TestValidMultiRequests(t *testing.T) {
+	t.Parallel()
+	m, fake := createMySQLWithFake(t)
+	err := m.Multi([]state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: randomKey(), Value: "value"}},
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: randomKey()}},
+	})
+	assert.Nil(t, err)
+	assert.True(t, fake.multiExecuted)
+}
+
+func TestInvalidMultiRequests(t *testing.T) {
+	t.Parallel()
+	invalid := map[string]state.TransactionalRequest{
+		"Invalid operation":           {Operation: "Something invalid", Request: state.SetRequest{Key: randomKey()}},
+		"Upsert with delete request":  {Operation: state.Upsert, Request: state.DeleteRequest{Key: randomKey()}},
+		"Delete with set request":     {Operation: state.Delete, Request: state.SetRequest{Key: randomKey()}},
+		"Upsert with request pointer": {Operation: state.Upsert, Request: &state.SetRequest{Key: randomKey()}},
+	}
+	for name, req := range invalid {
+		req := req
+		t.Run(name, func(t *testing.T) {
+			m, fake := createMySQLWithFake(t)
+			err := m.Multi([]state.TransactionalRequest{req})
+			assert.NotNil(t, err)
+			assert.False(t, fake.multiExecuted)
+		})
+	}
+}
+
+func TestInitWithoutConnectionStringFails(t *testing.T) {
+	t.Parallel()
+	dba := newMySQLDBAccess(logger.NewLogger("test"))
+	err := dba.Init(state.Metadata{Properties: map[string]string{}})
+	assert.NotNil(t, err)
+}
+
+func TestInitWithInvalidTableNameFails(t *testing.T) {
+	t.Parallel()
+	dba := newMySQLDBAccess(logger.NewLogger("test"))
+	err := dba.Init(state.Metadata{Properties: map[string]string{
+		connectionStringKey: fakeConnectionString,
+		tableNameKey:        "state; DROP TABLE users",
+	}})
+	assert.NotNil(t, err)
+}
+
+func TestIsDuplicateEntry(t *testing.T) {
+	assert.True(t, isDuplicateEntry(&mysql.MySQLError{Number: errDuplicateEntry}))
+	assert.True(t, isDuplicateEntry(fmt.Errorf("wrapped: %w", &mysql.MySQLError{Number: errDuplicateEntry})))
+	assert.False(t, isDuplicateEntry(&mysql.MySQLError{Number: 1213}))
+	assert.False(t, isDuplicateEntry(nil))
+}
+
+func createMySQLWithFake(t *testing.T) (*MySQL, *fakeDBaccess) {
+	logger := logger.NewLogger("test")
+
+	dba := &fakeDBaccess{
+		logger: logger,
+	}
+
+	m := newMySQLStateStore(logger, dba)
+	assert.NotNil(t, m)
+
+	err := m.Init(state.Metadata{
+		Properties: map[string]string{connectionStringKey: fakeConnectionString},
+	})
+	assert.Nil(t, err)
+
+	return m, dba
+}
+
+func randomKey() string {
+	return uuid.New().String()
+}
+
+func TestParseCleanupInterval(t *testing.T) {
+	interval, err := parseCleanupInterval(map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, defaultCleanupInterval, interval)
+
+	interval, err = parseCleanupInterval(map[string]string{cleanupIntervalKey: "10m"})
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Minute, interval)
+
+	_, err = parseCleanupInterval(map[string]string{cleanupIntervalKey: "often"})
+	assert.NotNil(t, err)
+}
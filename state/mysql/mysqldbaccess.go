@@ -0,0 +1,371 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+)
+
+const (
+	connectionStringKey        = "connectionString"
+	tableNameKey               = "tableName"
+	errMissingConnectionString = "missing connection string"
+	defaultTableName           = "state"
+
+	// errDuplicateEntry is the MySQL and MariaDB error number of a primary key violation
+	errDuplicateEntry = 1062
+)
+
+var errNoRowsAffected = errors.New("database operation failed: no rows match given key and etag")
+
+// mySQLDBAccess implements dbaccess
+type mySQLDBAccess struct {
+	logger           logger.Logger
+	metadata         state.Metadata
+	db               *sql.DB
+	connectionString string
+	tableName        string
+	defaultTTL       int
+	closeCh          chan struct{}
+}
+
+// newMySQLDBAccess creates a new instance of mySQLDBAccess
+func newMySQLDBAccess(logger logger.Logger) *mySQLDBAccess {
+	logger.Debug("Instantiating new MySQL state store")
+	return &mySQLDBAccess{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// Init sets up the MySQL connection and ensures that the state table exists
+func (m *mySQLDBAccess) Init(metadata state.Metadata) error {
+	m.logger.Debug("Initializing MySQL state store")
+	m.metadata = metadata
+
+	if val, ok := metadata.Properties[connectionStringKey]; ok && val != "" {
+		m.connectionString = val
+	} else {
+		m.logger.Error("Missing MySQL connection string")
+		return fmt.Errorf(errMissingConnectionString)
+	}
+
+	m.tableName = defaultTableName
+	if val, ok := metadata.Properties[tableNameKey]; ok && val != "" {
+		if !sqlutil.IsValidName(val) {
+			return fmt.Errorf("invalid table name '%s', accepted characters are (A-Z, a-z, 0-9, _)", val)
+		}
+		m.tableName = val
+	}
+
+	var err error
+	m.defaultTTL, err = state.ParseDefaultTTL(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	cleanupInterval, err := parseCleanupInterval(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("mysql", m.connectionString)
+	if err != nil {
+		m.logger.Error(err)
+		return err
+	}
+
+	m.db = db
+
+	pingErr := db.Ping()
+	if pingErr != nil {
+		return pingErr
+	}
+
+	err = m.ensureStateTable()
+	if err != nil {
+		return err
+	}
+
+	if cleanupInterval > 0 {
+		m.scheduleCleanupExpiredData(cleanupInterval)
+	}
+
+	return nil
+}
+
+// Set makes an insert or update to the database.
+func (m *mySQLDBAccess) Set(req *state.SetRequest) error {
+	return state.SetWithRetries(func(req *state.SetRequest) error {
+		return m.setValue(m.db, req)
+	}, req)
+}
+
+// setValue is an internal implementation of set to enable passing the logic to state.SetWithRetries as a func.
+// Every write stores a new random etag, since MySQL has no row version that can be used instead.
+func (m *mySQLDBAccess) setValue(db dbExecutor, req *state.SetRequest) error {
+	m.logger.Debug("Setting state value in MySQL")
+
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	if req.Key == "" {
+		return fmt.Errorf("missing key in set operation")
+	}
+
+	value, err := sqlutil.SerializeValue(req.Value)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := sqlutil.ParseTTL(req.Metadata, m.defaultTTL)
+	if err != nil {
+		return err
+	}
+
+	etag := uuid.New().String()
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+
+	switch {
+	case reqEtag == "" && req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so the key may only be inserted. An expired row does not count as written.
+		_, err = db.Exec(fmt.Sprintf(
+			"DELETE FROM `%s` WHERE id = ? AND NOT %s",
+			m.tableName, notExpiredCondition), req.Key)
+		if err != nil {
+			return err
+		}
+		_, err = db.Exec(fmt.Sprintf(
+			"INSERT INTO `%s` (id, value, eTag, expireDate) VALUES (?, ?, ?, %s)",
+			m.tableName, expireDateExpression), req.Key, value, etag, ttl)
+		if isDuplicateEntry(err) {
+			err = errNoRowsAffected
+		}
+		return err
+	case reqEtag == "":
+		// Upserts affect one row when inserting and two when updating, so the count is not checked
+		_, err = db.Exec(fmt.Sprintf(
+			"INSERT INTO `%s` (id, value, eTag, expireDate) VALUES (?, ?, ?, %s) ON DUPLICATE KEY UPDATE value = VALUES(value), eTag = VALUES(eTag), expireDate = VALUES(expireDate)",
+			m.tableName, expireDateExpression), req.Key, value, etag, ttl)
+		return err
+	default:
+		// When an etag is provided do an update - no insert
+		result, err := db.Exec(fmt.Sprintf(
+			"UPDATE `%s` SET value = ?, eTag = ?, expireDate = %s WHERE id = ? AND eTag = ? AND %s",
+			m.tableName, expireDateExpression, notExpiredCondition), value, etag, ttl, req.Key, reqEtag)
+		return m.returnSingleDBResult(result, err)
+	}
+}
+
+// Get returns data from the database. If data does not exist for the key an empty state.GetResponse will be returned.
+func (m *mySQLDBAccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	m.logger.Debug("Getting state value from MySQL")
+	if req.Key == "" {
+		return nil, fmt.Errorf("missing key in get operation")
+	}
+
+	var value []byte
+	var etag string
+	err := m.db.QueryRow(fmt.Sprintf("SELECT value, eTag FROM `%s` WHERE id = ? AND %s", m.tableName, notExpiredCondition), req.Key).Scan(&value, &etag)
+	if err != nil {
+		// If no rows exist, return an empty response, otherwise return the error.
+		if err == sql.ErrNoRows {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+
+	response := &state.GetResponse{
+		Data:     value,
+		ETag:     etag,
+		Metadata: req.Metadata,
+	}
+
+	return response, nil
+}
+
+// Delete removes an item from the state store.
+func (m *mySQLDBAccess) Delete(req *state.DeleteRequest) error {
+	return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
+		return m.deleteValue(m.db, req)
+	}, req)
+}
+
+// deleteValue is an internal implementation of delete to enable passing the logic to state.DeleteWithRetries as a func.
+func (m *mySQLDBAccess) deleteValue(db dbExecutor, req *state.DeleteRequest) error {
+	m.logger.Debug("Deleting state value from MySQL")
+	if req.Key == "" {
+		return fmt.Errorf("missing key in delete operation")
+	}
+
+	var result sql.Result
+	var err error
+
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+	if reqEtag == "" {
+		result, err = db.Exec(fmt.Sprintf("DELETE FROM `%s` WHERE id = ?", m.tableName), req.Key)
+	} else {
+		result, err = db.Exec(fmt.Sprintf("DELETE FROM `%s` WHERE id = ? AND eTag = ? AND %s", m.tableName, notExpiredCondition), req.Key, reqEtag)
+	}
+
+	return m.returnSingleDBResult(result, err)
+}
+
+// BulkSet runs all set requests in a single transaction.
+func (m *mySQLDBAccess) BulkSet(req []state.SetRequest) error {
+	return sqlutil.InTransaction(m.db, func(tx *sql.Tx) error {
+		for i := range req {
+			err := m.setValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// BulkDelete runs all delete requests in a single transaction.
+func (m *mySQLDBAccess) BulkDelete(req []state.DeleteRequest) error {
+	return sqlutil.InTransaction(m.db, func(tx *sql.Tx) error {
+		for i := range req {
+			err := m.deleteValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ExecuteMulti runs set and delete requests in order within a single transaction.
+func (m *mySQLDBAccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	m.logger.Debug("Executing multiple MySQL operations")
+
+	return sqlutil.InTransaction(m.db, func(tx *sql.Tx) error {
+		for _, req := range reqs {
+			var err error
+			switch r := req.Request.(type) {
+			case state.SetRequest:
+				err = m.setValue(tx, &r)
+			case state.DeleteRequest:
+				err = m.deleteValue(tx, &r)
+			default:
+				err = fmt.Errorf("unsupported request type %T", req.Request)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Verifies that the sql.Result affected only one row and no errors exist
+func (m *mySQLDBAccess) returnSingleDBResult(result sql.Result, err error) error {
+	if err != nil {
+		m.logger.Debug(err)
+		return err
+	}
+
+	rowsAffected, resultErr := result.RowsAffected()
+
+	if resultErr != nil {
+		m.logger.Error(resultErr)
+		return resultErr
+	}
+
+	if rowsAffected == 0 {
+		m.logger.Error(errNoRowsAffected)
+		return errNoRowsAffected
+	}
+
+	if rowsAffected > 1 {
+		tooManyRowsErr := errors.New("database operation failed: more than one row affected, expected one")
+		m.logger.Error(tooManyRowsErr)
+		return tooManyRowsErr
+	}
+
+	return nil
+}
+
+// Ping checks that the database can be reached
+func (m *mySQLDBAccess) Ping() error {
+	if m.db == nil {
+		return fmt.Errorf("the MySQL state store is not initialized")
+	}
+
+	return m.db.Ping()
+}
+
+// Close implements io.Close
+func (m *mySQLDBAccess) Close() error {
+	if m.closeCh != nil {
+		close(m.closeCh)
+		m.closeCh = nil
+	}
+
+	if m.db != nil {
+		return m.db.Close()
+	}
+
+	return nil
+}
+
+func (m *mySQLDBAccess) ensureStateTable() error {
+	exists, err := tableExists(m.db, m.tableName)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		m.logger.Info("Creating MySQL state table")
+		createTable := fmt.Sprintf(`CREATE TABLE %s (
+									id VARCHAR(255) NOT NULL PRIMARY KEY,
+									value JSON NOT NULL,
+									eTag VARCHAR(36) NOT NULL,
+									insertDate TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+									updateDate TIMESTAMP NULL DEFAULT NULL ON UPDATE CURRENT_TIMESTAMP,
+									expireDate TIMESTAMP NULL DEFAULT NULL,
+									INDEX expireDate_idx (expireDate));`, "`"+m.tableName+"`")
+		_, err = m.db.Exec(createTable)
+		return err
+	}
+
+	// Tables created before values could expire do not have the expireDate column
+	var hasExpireDate bool
+	err = m.db.QueryRow("SELECT EXISTS (SELECT * FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? AND column_name = 'expireDate')", m.tableName).Scan(&hasExpireDate)
+	if err != nil || hasExpireDate {
+		return err
+	}
+
+	m.logger.Info("Adding expireDate column to MySQL state table")
+	_, err = m.db.Exec(fmt.Sprintf("ALTER TABLE `%s` ADD COLUMN expireDate TIMESTAMP NULL DEFAULT NULL, ADD INDEX expireDate_idx (expireDate)", m.tableName))
+	return err
+}
+
+func tableExists(db *sql.DB, tableName string) (bool, error) {
+	var exists bool = false
+	err := db.QueryRow("SELECT EXISTS (SELECT * FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?)", tableName).Scan(&exists)
+	return exists, err
+}
+
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry
+}
@@ -0,0 +1,77 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package mysql
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	cleanupIntervalKey = "cleanupInterval"
+
+	defaultCleanupInterval = time.Hour
+
+	// expireDateExpression is the expiration time of a row given the TTL in seconds parameter, NULL when the TTL is NULL
+	expireDateExpression = "TIMESTAMPADD(SECOND, ?, CURRENT_TIMESTAMP)"
+	// notExpiredCondition filters out rows that have expired but have not been cleaned up yet
+	notExpiredCondition = "(expireDate IS NULL OR expireDate > CURRENT_TIMESTAMP)"
+)
+
+// parseCleanupInterval returns how often expired rows are deleted. A value of zero or less disables the cleanup.
+func parseCleanupInterval(properties map[string]string) (time.Duration, error) {
+	val, ok := properties[cleanupIntervalKey]
+	if !ok || val == "" {
+		return defaultCleanupInterval, nil
+	}
+
+	interval, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s', must be a duration such as 10m", cleanupIntervalKey, val)
+	}
+
+	return interval, nil
+}
+
+// scheduleCleanupExpiredData periodically deletes expired rows until the store is closed.
+func (m *mySQLDBAccess) scheduleCleanupExpiredData(interval time.Duration) {
+	m.logger.Infof("Scheduling cleanup of expired MySQL state every %s", interval)
+
+	closeCh := m.closeCh
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := m.cleanupExpiredData()
+				if err != nil {
+					m.logger.Errorf("error removing expired MySQL state: %s", err)
+				}
+			case <-closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// cleanupExpiredData deletes the rows whose expiration time has passed.
+func (m *mySQLDBAccess) cleanupExpiredData() error {
+	result, err := m.db.Exec(fmt.Sprintf(
+		"DELETE FROM `%s` WHERE expireDate IS NOT NULL AND expireDate <= CURRENT_TIMESTAMP", m.tableName))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	m.logger.Debugf("Removed %d expired rows from MySQL state", rowsAffected)
+
+	return nil
+}
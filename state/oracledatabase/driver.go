@@ -0,0 +1,16 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build cgo
+// +build cgo
+
+package oracledatabase
+
+import (
+	// Registers the godror driver, which uses the Oracle client libraries through cgo
+	_ "github.com/godror/godror"
+)
+
+const driverAvailable = true
@@ -0,0 +1,12 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build !cgo
+// +build !cgo
+
+package oracledatabase
+
+// The godror driver requires cgo, so builds without it cannot connect to Oracle Database
+const driverAvailable = false
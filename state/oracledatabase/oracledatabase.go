@@ -0,0 +1,121 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package oracledatabase
+
+import (
+	"fmt"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// OracleDatabase state store
+type OracleDatabase struct {
+	logger   logger.Logger
+	dbaccess dbAccess
+}
+
+// NewOracleDatabaseStateStore creates a new instance of Oracle Database state store
+func NewOracleDatabaseStateStore(logger logger.Logger) *OracleDatabase {
+	dba := newOracleDatabaseAccess(logger)
+	return newOracleDatabaseStateStore(logger, dba)
+}
+
+// newOracleDatabaseStateStore creates a new instance of an Oracle Database state store.
+// This unexported constructor allows injecting a dbAccess instance for unit testing.
+func newOracleDatabaseStateStore(logger logger.Logger, dba dbAccess) *OracleDatabase {
+	return &OracleDatabase{
+		logger:   logger,
+		dbaccess: dba,
+	}
+}
+
+// Init initializes the Oracle Database state store
+func (o *OracleDatabase) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+	return o.dbaccess.Init(metadata)
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the Oracle Database state store
+func (o *OracleDatabase) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionStringKey, Type: metadata.TypeString, Required: true},
+			{Name: tableNameKey, Type: metadata.TypeString, Default: defaultTableName},
+			{Name: walletLocationKey, Type: metadata.TypeString},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTransactional},
+	}
+}
+
+// Delete removes an entity from the store
+func (o *OracleDatabase) Delete(req *state.DeleteRequest) error {
+	return o.dbaccess.Delete(req)
+}
+
+// BulkDelete removes multiple entries from the store
+func (o *OracleDatabase) BulkDelete(req []state.DeleteRequest) error {
+	return o.dbaccess.BulkDelete(req)
+}
+
+// Get returns an entity from store
+func (o *OracleDatabase) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return o.dbaccess.Get(req)
+}
+
+// Set adds/updates an entity on store
+func (o *OracleDatabase) Set(req *state.SetRequest) error {
+	return o.dbaccess.Set(req)
+}
+
+// BulkSet adds/updates multiple entities on store
+func (o *OracleDatabase) BulkSet(req []state.SetRequest) error {
+	return o.dbaccess.BulkSet(req)
+}
+
+// Multi handles multiple transactions. Implements TransactionalStore.
+// Operations are applied in order and atomically.
+func (o *OracleDatabase) Multi(reqs []state.TransactionalRequest) error {
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			if _, ok := req.Request.(state.SetRequest); !ok {
+				return fmt.Errorf("expecting set request")
+			}
+
+		case state.Delete:
+			if _, ok := req.Request.(state.DeleteRequest); !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+
+		default:
+			return fmt.Errorf("unsupported operation: %s", req.Operation)
+		}
+	}
+
+	if len(reqs) > 0 {
+		return o.dbaccess.ExecuteMulti(reqs)
+	}
+
+	return nil
+}
+
+// Ping reports whether the database can be reached, for health checks
+func (o *OracleDatabase) Ping() error {
+	return o.dbaccess.Ping()
+}
+
+// Close implements io.Closer
+func (o *OracleDatabase) Close() error {
+	if o.dbaccess != nil {
+		return o.dbaccess.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package oracledatabase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/godror/godror/dsn"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeConnectionString = "not a real connection"
+)
+
+// Fake implementation of interface oracledatabase.dbaccess
+type fakeDBaccess struct {
+	logger        logger.Logger
+	initExecuted  bool
+	multiExecuted bool
+	pingErr       error
+}
+
+func (f *fakeDBaccess) Init(metadata state.Metadata) error {
+	f.initExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Set(req *state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeDBaccess) Delete(req *state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	f.multiExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Ping() error {
+	return f.pingErr
+}
+
+func (f *fakeDBaccess) Close() error {
+	return nil
+}
+
+// fakeOracleError mimics the errors returned by the godror driver
+type fakeOracleError struct {
+	code int
+}
+
+func (e *fakeOracleError) Code() int {
+	return e.code
+}
+
+func (e *fakeOracleError) Error() string {
+	return fmt.Sprintf("ORA-%05d", e.code)
+}
+
+// Proves that the Init method runs the init method
+func TestInitRunsDBAccessInit(t *testing.T) {
+	t.Parallel()
+	_, fake := createOracleDatabaseWithFake(t)
+	assert.True(t, fake.initExecuted)
+}
+
+func TestPingReturnsDBAccessResult(t *testing.T) {
+	t.Parallel()
+	store, fake := createOracleDatabaseWithFake(t)
+	assert.Nil(t, store.Ping())
+
+	fake.pingErr = errors.New("connection refused")
+	assert.Equal(t, fake.pingErr, store.Ping())
+}
+
+func TestMultiWithNoRequestsDoesNothing(t *testing.T) {
+	t.Parallel()
+	o, fake := createOracleDatabaseWithFake(t)
+	err := o.Multi(nil)
+	assert.Nil(t, err)
+	assert.False(t, fake.multiExecuted)
+}
+
+func TestValidMultiRequests(t *testing.T) {
+	t.Parallel()
+	o, fake := createOracleDatabaseWithFake(t)
+	err := o.Multi([]state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: randomKey(), Value: "value"}},
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: randomKey()}},
+	})
+	assert.Nil(t, err)
+	assert.True(t, fake.multiExecuted)
+}
+
+func TestInvalidMultiRequests(t *testing.T) {
+	t.Parallel()
+	invalid := map[string]state.TransactionalRequest{
+		"Invalid operation":           {Operation: "Something invalid", Request: state.SetRequest{Key: randomKey()}},
+		"Upsert with delete request":  {Operation: state.Upsert, Request: state.DeleteRequest{Key: randomKey()}},
+		"Delete with set request":     {Operation: state.Delete, Request: state.SetRequest{Key: randomKey()}},
+		"Upsert with request pointer": {Operation: state.Upsert, Request: &state.SetRequest{Key: randomKey()}},
+	}
+	for name, req := range invalid {
+		req := req
+		t.Run(name, func(t *testing.T) {
+			o, fake := createOracleDatabaseWithFake(t)
+			err := o.Multi([]state.TransactionalRequest{req})
+			assert.NotNil(t, err)
+			assert.False(t, fake.multiExecuted)
+		})
+	}
+}
+
+func TestInitWithoutConnectionStringFails(t *testing.T) {
+	t.Parallel()
+	dba := newOracleDatabaseAccess(logger.NewLogger("test"))
+	err := dba.Init(state.Metadata{Properties: map[string]string{}})
+	assert.NotNil(t, err)
+}
+
+func TestInitWithInvalidTableNameFails(t *testing.T) {
+	t.Parallel()
+	dba := newOracleDatabaseAccess(logger.NewLogger("test"))
+	err := dba.Init(state.Metadata{Properties: map[string]string{
+		connectionStringKey: fakeConnectionString,
+		tableNameKey:        "state; DROP TABLE users",
+	}})
+	assert.NotNil(t, err)
+}
+
+func TestWithWallet(t *testing.T) {
+	connectionString, err := withWallet(`user="dapr" password="secret" connectString="dapr_high"`, "/etc/oracle/wallet")
+	assert.Nil(t, err)
+
+	params, err := dsn.Parse(connectionString)
+	assert.Nil(t, err)
+	assert.Equal(t, "/etc/oracle/wallet", params.ConfigDir)
+	assert.Equal(t, "dapr", params.Username)
+	assert.Equal(t, "secret", params.Password.Secret())
+	assert.Equal(t, "dapr_high", params.ConnectString)
+
+	_, err = withWallet("oracle://dapr:secret@%zz", "/etc/oracle/wallet")
+	assert.NotNil(t, err)
+}
+
+func TestSerializeValue(t *testing.T) {
+	value, binaryYN, err := serializeValue(map[string]string{"color": "red"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"color":"red"}`, value)
+	assert.Equal(t, "N", binaryYN)
+
+	value, binaryYN, err = serializeValue([]byte(`{"color":"red"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"color":"red"}`, value)
+	assert.Equal(t, "N", binaryYN)
+
+	value, binaryYN, err = serializeValue("red")
+	assert.Nil(t, err)
+	assert.Equal(t, `"red"`, value)
+	assert.Equal(t, "N", binaryYN)
+
+	value, binaryYN, err = serializeValue([]byte{0xde, 0xad, 0xbe, 0xef})
+	assert.Nil(t, err)
+	assert.Equal(t, "3q2+7w==", value)
+	assert.Equal(t, "Y", binaryYN)
+}
+
+func TestDeserializeValue(t *testing.T) {
+	data, err := deserializeValue(`{"color":"red"}`, "N")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(`{"color":"red"}`), data)
+
+	data, err = deserializeValue("3q2+7w==", "Y")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, data)
+
+	_, err = deserializeValue("not base64!", "Y")
+	assert.NotNil(t, err)
+}
+
+func TestIsUniqueConstraintViolation(t *testing.T) {
+	assert.True(t, isUniqueConstraintViolation(&fakeOracleError{code: errUniqueConstraint}))
+	assert.True(t, isUniqueConstraintViolation(fmt.Errorf("wrapped: %w", &fakeOracleError{code: errUniqueConstraint})))
+	assert.False(t, isUniqueConstraintViolation(&fakeOracleError{code: 60}))
+	assert.False(t, isUniqueConstraintViolation(nil))
+}
+
+func createOracleDatabaseWithFake(t *testing.T) (*OracleDatabase, *fakeDBaccess) {
+	logger := logger.NewLogger("test")
+
+	dba := &fakeDBaccess{
+		logger: logger,
+	}
+
+	o := newOracleDatabaseStateStore(logger, dba)
+	assert.NotNil(t, o)
+
+	err := o.Init(state.Metadata{
+		Properties: map[string]string{connectionStringKey: fakeConnectionString},
+	})
+	assert.Nil(t, err)
+
+	return o, dba
+}
+
+func randomKey() string {
+	return uuid.New().String()
+}
@@ -0,0 +1,402 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package oracledatabase
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/godror/godror/dsn"
+)
+
+const (
+	connectionStringKey        = "connectionString"
+	tableNameKey               = "tableName"
+	walletLocationKey          = "oracleWalletLocation"
+	errMissingConnectionString = "missing connection string"
+	defaultTableName           = "state"
+
+	// errUniqueConstraint is the Oracle error code of a primary key violation (ORA-00001)
+	errUniqueConstraint = 1
+)
+
+var errNoRowsAffected = errors.New("database operation failed: no rows match given key and etag")
+
+// oracleError is implemented by the errors of the godror driver
+type oracleError interface {
+	Code() int
+}
+
+// oracleDatabaseAccess implements dbaccess
+type oracleDatabaseAccess struct {
+	logger           logger.Logger
+	metadata         state.Metadata
+	db               *sql.DB
+	connectionString string
+	tableName        string
+}
+
+// newOracleDatabaseAccess creates a new instance of oracleDatabaseAccess
+func newOracleDatabaseAccess(logger logger.Logger) *oracleDatabaseAccess {
+	logger.Debug("Instantiating new Oracle Database state store")
+	return &oracleDatabaseAccess{
+		logger: logger,
+	}
+}
+
+// Init sets up the Oracle Database connection and ensures that the state table exists
+func (o *oracleDatabaseAccess) Init(metadata state.Metadata) error {
+	o.logger.Debug("Initializing Oracle Database state store")
+	o.metadata = metadata
+
+	if val, ok := metadata.Properties[connectionStringKey]; ok && val != "" {
+		o.connectionString = val
+	} else {
+		o.logger.Error("Missing Oracle Database connection string")
+		return fmt.Errorf(errMissingConnectionString)
+	}
+
+	o.tableName = defaultTableName
+	if val, ok := metadata.Properties[tableNameKey]; ok && val != "" {
+		if !sqlutil.IsValidName(val) {
+			return fmt.Errorf("invalid table name '%s', accepted characters are (A-Z, a-z, 0-9, _)", val)
+		}
+		o.tableName = val
+	}
+
+	if val, ok := metadata.Properties[walletLocationKey]; ok && val != "" {
+		connectionString, err := withWallet(o.connectionString, val)
+		if err != nil {
+			return err
+		}
+		o.connectionString = connectionString
+	}
+
+	if !driverAvailable {
+		return fmt.Errorf("the Oracle Database state store requires a build with cgo enabled")
+	}
+
+	db, err := sql.Open("godror", o.connectionString)
+	if err != nil {
+		o.logger.Error(err)
+		return err
+	}
+
+	o.db = db
+
+	pingErr := db.Ping()
+	if pingErr != nil {
+		return pingErr
+	}
+
+	return o.ensureStateTable()
+}
+
+// Set makes an insert or update to the database.
+func (o *oracleDatabaseAccess) Set(req *state.SetRequest) error {
+	return state.SetWithRetries(func(req *state.SetRequest) error {
+		return o.setValue(o.db, req)
+	}, req)
+}
+
+// setValue is an internal implementation of set to enable passing the logic to state.SetWithRetries as a func.
+// Every write increments the version column of the row, which is used as its etag.
+func (o *oracleDatabaseAccess) setValue(db dbExecutor, req *state.SetRequest) error {
+	o.logger.Debug("Setting state value in Oracle Database")
+
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	if req.Key == "" {
+		return fmt.Errorf("missing key in set operation")
+	}
+
+	value, binaryYN, err := serializeValue(req.Value)
+	if err != nil {
+		return err
+	}
+
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+
+	switch {
+	case reqEtag == "" && req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so the key may only be inserted
+		_, err = db.Exec(fmt.Sprintf(
+			"INSERT INTO %s (key, value, binary_yn, version) VALUES (:1, :2, :3, 1)",
+			o.tableName), req.Key, value, binaryYN)
+		if isUniqueConstraintViolation(err) {
+			err = errNoRowsAffected
+		}
+		return err
+	case reqEtag == "":
+		_, err = db.Exec(fmt.Sprintf(`MERGE INTO %s t
+			USING (SELECT :1 AS key, :2 AS value, :3 AS binary_yn FROM dual) new_state
+			ON (t.key = new_state.key)
+			WHEN MATCHED THEN UPDATE SET value = new_state.value, binary_yn = new_state.binary_yn, version = t.version + 1, update_time = SYSTIMESTAMP
+			WHEN NOT MATCHED THEN INSERT (key, value, binary_yn, version) VALUES (new_state.key, new_state.value, new_state.binary_yn, 1)`,
+			o.tableName), req.Key, value, binaryYN)
+		return err
+	default:
+		version, err := strconv.ParseInt(reqEtag, 10, 64)
+		if err != nil {
+			// Etags are always versions, so anything else cannot match
+			return errNoRowsAffected
+		}
+
+		// When an etag is provided do an update - no insert
+		result, err := db.Exec(fmt.Sprintf(
+			"UPDATE %s SET value = :1, binary_yn = :2, version = version + 1, update_time = SYSTIMESTAMP WHERE key = :3 AND version = :4",
+			o.tableName), value, binaryYN, req.Key, version)
+		return o.returnSingleDBResult(result, err)
+	}
+}
+
+// Get returns data from the database. If data does not exist for the key an empty state.GetResponse will be returned.
+func (o *oracleDatabaseAccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	o.logger.Debug("Getting state value from Oracle Database")
+	if req.Key == "" {
+		return nil, fmt.Errorf("missing key in get operation")
+	}
+
+	var value string
+	var binaryYN string
+	var version int64
+	err := o.db.QueryRow(fmt.Sprintf("SELECT value, binary_yn, version FROM %s WHERE key = :1", o.tableName), req.Key).Scan(&value, &binaryYN, &version)
+	if err != nil {
+		// If no rows exist, return an empty response, otherwise return the error.
+		if err == sql.ErrNoRows {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+
+	data, err := deserializeValue(value, binaryYN)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &state.GetResponse{
+		Data:     data,
+		ETag:     strconv.FormatInt(version, 10),
+		Metadata: req.Metadata,
+	}
+
+	return response, nil
+}
+
+// Delete removes an item from the state store.
+func (o *oracleDatabaseAccess) Delete(req *state.DeleteRequest) error {
+	return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
+		return o.deleteValue(o.db, req)
+	}, req)
+}
+
+// deleteValue is an internal implementation of delete to enable passing the logic to state.DeleteWithRetries as a func.
+func (o *oracleDatabaseAccess) deleteValue(db dbExecutor, req *state.DeleteRequest) error {
+	o.logger.Debug("Deleting state value from Oracle Database")
+	if req.Key == "" {
+		return fmt.Errorf("missing key in delete operation")
+	}
+
+	var result sql.Result
+	var err error
+
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+	if reqEtag == "" {
+		result, err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = :1", o.tableName), req.Key)
+	} else {
+		version, parseErr := strconv.ParseInt(reqEtag, 10, 64)
+		if parseErr != nil {
+			return errNoRowsAffected
+		}
+		result, err = db.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = :1 AND version = :2", o.tableName), req.Key, version)
+	}
+
+	return o.returnSingleDBResult(result, err)
+}
+
+// BulkSet runs all set requests in a single transaction.
+func (o *oracleDatabaseAccess) BulkSet(req []state.SetRequest) error {
+	return sqlutil.InTransaction(o.db, func(tx *sql.Tx) error {
+		for i := range req {
+			err := o.setValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// BulkDelete runs all delete requests in a single transaction.
+func (o *oracleDatabaseAccess) BulkDelete(req []state.DeleteRequest) error {
+	return sqlutil.InTransaction(o.db, func(tx *sql.Tx) error {
+		for i := range req {
+			err := o.deleteValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ExecuteMulti runs set and delete requests in order within a single transaction.
+func (o *oracleDatabaseAccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	o.logger.Debug("Executing multiple Oracle Database operations")
+
+	return sqlutil.InTransaction(o.db, func(tx *sql.Tx) error {
+		for _, req := range reqs {
+			var err error
+			switch r := req.Request.(type) {
+			case state.SetRequest:
+				err = o.setValue(tx, &r)
+			case state.DeleteRequest:
+				err = o.deleteValue(tx, &r)
+			default:
+				err = fmt.Errorf("unsupported request type %T", req.Request)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Verifies that the sql.Result affected only one row and no errors exist
+func (o *oracleDatabaseAccess) returnSingleDBResult(result sql.Result, err error) error {
+	if err != nil {
+		o.logger.Debug(err)
+		return err
+	}
+
+	rowsAffected, resultErr := result.RowsAffected()
+
+	if resultErr != nil {
+		o.logger.Error(resultErr)
+		return resultErr
+	}
+
+	if rowsAffected == 0 {
+		o.logger.Error(errNoRowsAffected)
+		return errNoRowsAffected
+	}
+
+	if rowsAffected > 1 {
+		tooManyRowsErr := errors.New("database operation failed: more than one row affected, expected one")
+		o.logger.Error(tooManyRowsErr)
+		return tooManyRowsErr
+	}
+
+	return nil
+}
+
+// Ping checks that the database can be reached
+func (o *oracleDatabaseAccess) Ping() error {
+	if o.db == nil {
+		return fmt.Errorf("the Oracle Database state store is not initialized")
+	}
+
+	return o.db.Ping()
+}
+
+// Close implements io.Close
+func (o *oracleDatabaseAccess) Close() error {
+	if o.db != nil {
+		return o.db.Close()
+	}
+
+	return nil
+}
+
+func (o *oracleDatabaseAccess) ensureStateTable() error {
+	exists, err := tableExists(o.db, o.tableName)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		o.logger.Info("Creating Oracle Database state table")
+		createTable := fmt.Sprintf(`CREATE TABLE %s (
+									key VARCHAR2(2000) NOT NULL PRIMARY KEY,
+									value CLOB NOT NULL,
+									binary_yn VARCHAR2(1) NOT NULL,
+									version NUMBER(19) NOT NULL,
+									creation_time TIMESTAMP WITH TIME ZONE DEFAULT SYSTIMESTAMP NOT NULL,
+									update_time TIMESTAMP WITH TIME ZONE NULL)`, o.tableName)
+		_, err = o.db.Exec(createTable)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func tableExists(db *sql.DB, tableName string) (bool, error) {
+	var count int
+	// Unquoted identifiers are stored in upper case in the data dictionary
+	err := db.QueryRow("SELECT COUNT(*) FROM user_tables WHERE table_name = UPPER(:1)", tableName).Scan(&count)
+	return count > 0, err
+}
+
+// withWallet returns the connection string with its configuration directory set to the wallet location,
+// so that the wallet, tnsnames.ora and sqlnet.ora in it are used for TLS connections.
+func withWallet(connectionString string, walletLocation string) (string, error) {
+	params, err := dsn.Parse(connectionString)
+	if err != nil {
+		return "", fmt.Errorf("invalid connection string: %s", err)
+	}
+
+	params.ConfigDir = walletLocation
+	return params.StringWithPassword(), nil
+}
+
+// serializeValue returns the text to store for a state value and whether it is binary.
+// []byte values that are valid JSON are stored as is, other []byte values are stored base64 encoded.
+func serializeValue(value interface{}) (string, string, error) {
+	if b, ok := value.([]byte); ok {
+		if json.Valid(b) {
+			return string(b), "N", nil
+		}
+
+		return base64.StdEncoding.EncodeToString(b), "Y", nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(valueBytes), "N", nil
+}
+
+// deserializeValue returns the state value stored by serializeValue
+func deserializeValue(value string, binaryYN string) ([]byte, error) {
+	if binaryYN == "Y" {
+		return base64.StdEncoding.DecodeString(value)
+	}
+
+	return []byte(value), nil
+}
+
+func isUniqueConstraintViolation(err error) bool {
+	var oraErr oracleError
+	return errors.As(err, &oraErr) && oraErr.Code() == errUniqueConstraint
+}
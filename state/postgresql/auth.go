@@ -0,0 +1,224 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+	awsAuth "github.com/dapr/components-contrib/authentication/aws"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	authTypeKey = "authType"
+
+	authTypePassword = "password"
+	authTypeAzureAD  = "azureAD"
+	authTypeAWSIAM   = "awsIAM"
+
+	azureClientIDKey     = "azureClientId"
+	azureClientSecretKey = "azureClientSecret"
+	azureTenantIDKey     = "azureTenantId"
+
+	awsRegionKey    = "awsRegion"
+	awsAccessKeyKey = "awsAccessKey"
+	awsSecretKeyKey = "awsSecretKey"
+
+	// azureDatabaseResource is the resource that Azure AD tokens for Azure Database for PostgreSQL are issued for
+	azureDatabaseResource = "https://ossrdbms-aad.database.windows.net"
+
+	// tokenRefreshInterval is how often pools using token authentication are replaced, well within
+	// the 15 minute lifetime of RDS IAM tokens
+	tokenRefreshInterval = 10 * time.Minute
+)
+
+// tokenProvider returns a short lived password for a new connection.
+type tokenProvider interface {
+	token(ctx context.Context) (string, error)
+}
+
+// openPool opens a connection pool with the authentication and pool settings of the component metadata.
+// With token authentication, connections use a fresh token as their password and the pool is replaced
+// before the tokens of its connections expire.
+func (p *postgresDBAccess) openPool(ctx context.Context, connectionString string, properties map[string]string, pool poolConfig) (*connPool, error) {
+	config, err := pgxpool.ParseConfig(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	var provider tokenProvider
+	switch authType := properties[authTypeKey]; authType {
+	case "", authTypePassword:
+	case authTypeAzureAD:
+		provider, err = newAzureADTokenProvider(properties)
+	case authTypeAWSIAM:
+		provider, err = newAWSIAMTokenProvider(properties, config.ConnConfig)
+	default:
+		err = fmt.Errorf("unsupported %s '%s', supported values are: %s, %s, %s", authTypeKey, authType, authTypePassword, authTypeAzureAD, authTypeAWSIAM)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	connect := func(ctx context.Context) (*pgxpool.Pool, error) {
+		// ParseConfig returns a new config, which the pool uses for all its connections
+		config, err := pgxpool.ParseConfig(connectionString)
+		if err != nil {
+			return nil, err
+		}
+		applyPoolConfig(config, pool)
+
+		if provider != nil {
+			config.ConnConfig.Password, err = provider.token(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get PostgreSQL access token: %s", err)
+			}
+		}
+
+		return pgxpool.ConnectConfig(ctx, config)
+	}
+
+	db, err := newConnPool(ctx, connect)
+	if err != nil {
+		return nil, err
+	}
+
+	if provider != nil {
+		p.logger.Infof("Using %s token authentication for PostgreSQL", properties[authTypeKey])
+		db.scheduleRefresh(tokenRefreshInterval, p.timeout, func(err error) {
+			p.logger.Errorf("failed to refresh PostgreSQL connection pool token: %s", err)
+		})
+	}
+
+	return db, nil
+}
+
+// withParam returns the connection string, in either URL or keyword/value format, with a parameter set.
+func withParam(connectionString string, key string, value string) (string, error) {
+	if isURLConnectionString(connectionString) {
+		u, err := url.Parse(connectionString)
+		if err != nil {
+			return "", err
+		}
+		query := u.Query()
+		query.Set(key, value)
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	// The last occurrence of a keyword wins
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return fmt.Sprintf("%s %s='%s'", connectionString, key, escaped), nil
+}
+
+func isURLConnectionString(connectionString string) bool {
+	return strings.HasPrefix(connectionString, "postgres://") || strings.HasPrefix(connectionString, "postgresql://")
+}
+
+// azureADTokenProvider returns Azure AD access tokens of a service principal or managed identity.
+type azureADTokenProvider struct {
+	spt *adal.ServicePrincipalToken
+}
+
+// newAzureADTokenProvider uses a service principal when a client secret is configured, and a managed identity otherwise.
+// The client ID of a managed identity is only required for user assigned identities.
+func newAzureADTokenProvider(properties map[string]string) (*azureADTokenProvider, error) {
+	clientID := properties[azureClientIDKey]
+	clientSecret := properties[azureClientSecretKey]
+
+	var spt *adal.ServicePrincipalToken
+	if clientSecret != "" {
+		tenantID := properties[azureTenantIDKey]
+		if clientID == "" || tenantID == "" {
+			return nil, fmt.Errorf("%s and %s are required with %s", azureClientIDKey, azureTenantIDKey, azureClientSecretKey)
+		}
+
+		oauthConfig, err := adal.NewOAuthConfig(azure.PublicCloud.ActiveDirectoryEndpoint, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		spt, err = adal.NewServicePrincipalToken(*oauthConfig, clientID, clientSecret, azureDatabaseResource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create service principal token: %s", err)
+		}
+
+		return &azureADTokenProvider{spt: spt}, nil
+	}
+
+	msiEndpoint, err := adal.GetMSIEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	if clientID == "" {
+		spt, err = adal.NewServicePrincipalTokenFromMSI(msiEndpoint, azureDatabaseResource)
+	} else {
+		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(msiEndpoint, azureDatabaseResource, clientID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create managed identity token: %s", err)
+	}
+
+	return &azureADTokenProvider{spt: spt}, nil
+}
+
+func (a *azureADTokenProvider) token(ctx context.Context) (string, error) {
+	err := a.spt.EnsureFreshWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return a.spt.OAuthToken(), nil
+}
+
+// awsIAMTokenProvider returns RDS IAM authentication tokens for the host and user of the connection string.
+type awsIAMTokenProvider struct {
+	endpoint    string
+	region      string
+	user        string
+	credentials *credentials.Credentials
+}
+
+// newAWSIAMTokenProvider uses the configured access key, or the default AWS credential chain when none is set.
+func newAWSIAMTokenProvider(properties map[string]string, config *pgx.ConnConfig) (*awsIAMTokenProvider, error) {
+	region := properties[awsRegionKey]
+	if region == "" {
+		return nil, fmt.Errorf("%s is required with %s %s", awsRegionKey, authTypeKey, authTypeAWSIAM)
+	}
+
+	if config.User == "" {
+		return nil, fmt.Errorf("the connection string must include a user with %s %s", authTypeKey, authTypeAWSIAM)
+	}
+
+	session, err := awsAuth.GetClient(properties[awsAccessKeyKey], properties[awsSecretKeyKey], region, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &awsIAMTokenProvider{
+		endpoint:    net.JoinHostPort(config.Host, strconv.Itoa(int(config.Port))),
+		region:      region,
+		user:        config.User,
+		credentials: session.Config.Credentials,
+	}, nil
+}
+
+// token signs a new token, which is valid for 15 minutes, without calling AWS.
+func (a *awsIAMTokenProvider) token(ctx context.Context) (string, error) {
+	return rdsutils.BuildAuthToken(a.endpoint, a.region, a.user, a.credentials)
+}
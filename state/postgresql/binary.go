@@ -0,0 +1,68 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+const (
+	// contentTypeKey is the set request metadata key, and get response metadata key, holding the content type of a value
+	contentTypeKey = "contentType"
+
+	defaultBinaryContentType = "application/octet-stream"
+)
+
+// serializeValue returns the bytes to store for the value of a set request and their content type.
+// JSON values have an empty content type and are stored in the json column. Other values are stored
+// byte-for-byte in the bytea column:
+//   - values of a request with a non-JSON contentType in its metadata
+//   - []byte values that are not valid JSON
+func serializeValue(value interface{}, requestMetadata map[string]string, escapeHTML bool) ([]byte, string, error) {
+	contentType := requestMetadata[contentTypeKey]
+	if contentType != "" && !isJSONContentType(contentType) {
+		switch v := value.(type) {
+		case []byte:
+			return v, contentType, nil
+		case string:
+			return []byte(v), contentType, nil
+		default:
+			return nil, "", fmt.Errorf("values with %s '%s' must be a string or []byte, got %T", contentTypeKey, contentType, value)
+		}
+	}
+
+	if b, ok := value.([]byte); ok {
+		if json.Valid(b) {
+			return b, "", nil
+		}
+
+		if contentType != "" {
+			return nil, "", fmt.Errorf("value is not valid JSON but %s is '%s'", contentTypeKey, contentType)
+		}
+
+		return b, defaultBinaryContentType, nil
+	}
+
+	valueBytes, err := marshalValue(value, escapeHTML)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return valueBytes, "", nil
+}
+
+// isJSONContentType returns true for application/json and media types with a +json suffix.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
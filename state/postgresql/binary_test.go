@@ -0,0 +1,93 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerializeValue(t *testing.T) {
+	t.Run("Objects are serialized to JSON", func(t *testing.T) {
+		data, contentType, err := serializeValue(map[string]string{"color": "red"}, nil, false)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"color":"red"}`, string(data))
+		assert.Equal(t, "", contentType)
+	})
+
+	t.Run("JSON bytes are stored as is", func(t *testing.T) {
+		data, contentType, err := serializeValue([]byte(`{"color":"red"}`), nil, false)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"color":"red"}`, string(data))
+		assert.Equal(t, "", contentType)
+	})
+
+	t.Run("Non-JSON bytes are binary", func(t *testing.T) {
+		data, contentType, err := serializeValue([]byte{0xff, 0x00, 0x01}, nil, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte{0xff, 0x00, 0x01}, data)
+		assert.Equal(t, defaultBinaryContentType, contentType)
+	})
+
+	t.Run("Strings with a non-JSON content type are stored as is", func(t *testing.T) {
+		data, contentType, err := serializeValue("hello", map[string]string{contentTypeKey: "text/plain"}, false)
+		assert.Nil(t, err)
+		assert.Equal(t, "hello", string(data))
+		assert.Equal(t, "text/plain", contentType)
+	})
+
+	t.Run("Objects with a non-JSON content type fail", func(t *testing.T) {
+		_, _, err := serializeValue(map[string]string{}, map[string]string{contentTypeKey: "text/plain"}, false)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Invalid JSON bytes with a JSON content type fail", func(t *testing.T) {
+		_, _, err := serializeValue([]byte("{"), map[string]string{contentTypeKey: "application/json"}, false)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestIsJSONContentType(t *testing.T) {
+	assert.True(t, isJSONContentType("application/json"))
+	assert.True(t, isJSONContentType("application/json; charset=utf-8"))
+	assert.True(t, isJSONContentType("application/cloudevents+json"))
+	assert.False(t, isJSONContentType("text/plain"))
+	assert.False(t, isJSONContentType("application/octet-stream"))
+}
+
+func TestEncodeBinaryValue(t *testing.T) {
+	binary := []byte{0xff, 0x00, 0x01}
+
+	t.Run("Binary values round trip", func(t *testing.T) {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		value, binaryValue, rowMeta, err := p.encodeValue(binary, "image/png")
+		assert.Nil(t, err)
+		assert.False(t, value.Valid)
+		assert.Equal(t, binary, binaryValue)
+		assert.Equal(t, `{"contentType":"image/png"}`, rowMeta.String)
+
+		decoded, contentType, err := decodeValue(value, binaryValue, rowMeta)
+		assert.Nil(t, err)
+		assert.Equal(t, binary, decoded)
+		assert.Equal(t, "image/png", contentType)
+	})
+
+	t.Run("Compressed binary values round trip", func(t *testing.T) {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		p.compression = compressionGzip
+		p.compressionThreshold = 0
+		value, binaryValue, rowMeta, err := p.encodeValue(binary, "image/png")
+		assert.Nil(t, err)
+		assert.False(t, value.Valid)
+		assert.Equal(t, `{"encoding":"gzip","contentType":"image/png"}`, rowMeta.String)
+
+		decoded, contentType, err := decodeValue(value, binaryValue, rowMeta)
+		assert.Nil(t, err)
+		assert.Equal(t, binary, decoded)
+		assert.Equal(t, "image/png", contentType)
+	})
+}
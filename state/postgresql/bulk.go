@@ -0,0 +1,197 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/state"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	bulkSetOperation    = "bulkset"
+	bulkDeleteOperation = "bulkdelete"
+
+	// Rows per multi-row insert, keeping the number of parameters well below the PostgreSQL limit of 65535
+	bulkSetBatchSize = 1000
+)
+
+// BulkSet upserts all unconditional requests with multi-row inserts inside a single transaction.
+// Requests with an etag or first-write concurrency are conditional and are executed individually
+// in the same transaction, after the unconditional upserts.
+func (p *postgresDBAccess) BulkSet(req []state.SetRequest) error {
+	start := time.Now()
+	ctx, cancel := p.operationContext()
+	defer cancel()
+	err := p.inTransaction(ctx, func(tx pgx.Tx) error {
+		return p.bulkSet(ctx, tx, req)
+	})
+	p.recordOperation(bulkSetOperation, start, err)
+	return err
+}
+
+// BulkDelete deletes all requests without an etag with a single statement inside a transaction.
+// Requests with an etag are executed individually in the same transaction.
+func (p *postgresDBAccess) BulkDelete(req []state.DeleteRequest) error {
+	start := time.Now()
+	ctx, cancel := p.operationContext()
+	defer cancel()
+	err := p.inTransaction(ctx, func(tx pgx.Tx) error {
+		return p.bulkDelete(ctx, tx, req)
+	})
+	p.recordOperation(bulkDeleteOperation, start, err)
+	return err
+}
+
+// inTransaction runs fn in a transaction, which is committed if fn succeeds and rolled back otherwise.
+func (p *postgresDBAccess) inTransaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (p *postgresDBAccess) bulkSet(ctx context.Context, db dbExecutor, req []state.SetRequest) error {
+	p.logger.Debugf("Bulk setting %d state values in PostgreSQL", len(req))
+
+	var rows []*setRow
+	var conditional []state.SetRequest
+	indexByKey := map[string]int{}
+	for i := range req {
+		if isConditionalSet(&req[i]) {
+			conditional = append(conditional, req[i])
+			continue
+		}
+
+		row, err := p.prepareSetRow(&req[i])
+		if err != nil {
+			return err
+		}
+
+		// A multi-row upsert cannot affect the same row twice, so the last request for a key wins
+		if index, ok := indexByKey[row.key]; ok {
+			rows[index] = row
+		} else {
+			indexByKey[row.key] = len(rows)
+			rows = append(rows, row)
+		}
+	}
+
+	for batchStart := 0; batchStart < len(rows); batchStart += bulkSetBatchSize {
+		batchEnd := batchStart + bulkSetBatchSize
+		if batchEnd > len(rows) {
+			batchEnd = len(rows)
+		}
+
+		err := p.upsertRows(ctx, db, rows[batchStart:batchEnd])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range conditional {
+		_, err := p.setValue(ctx, db, &conditional[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertRows inserts or updates rows with a single multi-row statement.
+func (p *postgresDBAccess) upsertRows(ctx context.Context, db dbExecutor, rows []*setRow) error {
+	values := make([]string, 0, len(rows))
+	args := make([]interface{}, 0, len(rows)*5)
+	for i, row := range rows {
+		n := i * 5
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, $%d, %s)",
+			n+1, n+2, n+3, n+4, expireDateExpression(fmt.Sprintf("$%d", n+5))))
+		args = append(args, row.key, row.value, row.binaryValue, row.rowMeta, row.ttl)
+	}
+
+	_, err := db.Exec(ctx, fmt.Sprintf(
+		`INSERT INTO %s (key, value, binaryvalue, metadata, expiredate) VALUES %s
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, binaryvalue = EXCLUDED.binaryvalue,
+		metadata = EXCLUDED.metadata, expiredate = EXCLUDED.expiredate, updatedate = NOW();`,
+		p.table, strings.Join(values, ", ")), args...)
+
+	return err
+}
+
+func (p *postgresDBAccess) bulkDelete(ctx context.Context, db dbExecutor, req []state.DeleteRequest) error {
+	p.logger.Debugf("Bulk deleting %d state values from PostgreSQL", len(req))
+
+	var keys []string
+	var conditional []state.DeleteRequest
+	seen := map[string]bool{}
+	for i := range req {
+		if req[i].Key == "" {
+			return fmt.Errorf("missing key in delete operation")
+		}
+
+		if sqlutil.ETagCondition(req[i].ETag, req[i].Options.Concurrency) != "" {
+			conditional = append(conditional, req[i])
+			continue
+		}
+
+		if !seen[req[i].Key] {
+			seen[req[i].Key] = true
+			keys = append(keys, req[i].Key)
+		}
+	}
+
+	if len(keys) > 0 {
+		err := p.deleteKeys(ctx, db, keys)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range conditional {
+		err := p.deleteValue(ctx, db, &conditional[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteKeys deletes rows by key with a single statement. Like Delete, it fails if a key does not exist.
+func (p *postgresDBAccess) deleteKeys(ctx context.Context, db dbExecutor, keys []string) error {
+	var keyArray pgtype.TextArray
+	err := keyArray.Set(keys)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE key = ANY($1)", p.table), keyArray)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != int64(len(keys)) {
+		p.logger.Error(errNoRowsAffected)
+		return errNoRowsAffected
+	}
+
+	return nil
+}
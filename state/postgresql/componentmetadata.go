@@ -0,0 +1,57 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import "github.com/dapr/components-contrib/metadata"
+
+// ConnectionMetadataFields returns the metadata fields read by Connect, so that the components connecting to
+// PostgreSQL the same way as the state store describe them too
+func ConnectionMetadataFields() []metadata.Field {
+	return []metadata.Field{
+		{Name: connectionStringKey, Type: metadata.TypeString, Required: true},
+		{Name: sslModeKey, Type: metadata.TypeString},
+		{Name: sslRootCertKey, Type: metadata.TypeString},
+		{Name: sslCertKey, Type: metadata.TypeString},
+		{Name: sslKeyKey, Type: metadata.TypeString},
+		{Name: authTypeKey, Type: metadata.TypeString, Default: authTypePassword},
+		{Name: azureClientIDKey, Type: metadata.TypeString},
+		{Name: azureClientSecretKey, Type: metadata.TypeString},
+		{Name: azureTenantIDKey, Type: metadata.TypeString},
+		{Name: awsRegionKey, Type: metadata.TypeString},
+		{Name: awsAccessKeyKey, Type: metadata.TypeString},
+		{Name: awsSecretKeyKey, Type: metadata.TypeString},
+		{Name: maxOpenConnectionsKey, Type: metadata.TypeInt},
+		{Name: maxIdleConnectionsKey, Type: metadata.TypeInt},
+		{Name: connectionMaxLifetimeKey, Type: metadata.TypeDuration},
+		{Name: connectionMaxIdleTimeKey, Type: metadata.TypeDuration},
+		{Name: timeoutKey, Type: metadata.TypeDuration, Default: defaultTimeout.String()},
+	}
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the PostgreSQL state store
+func (p *PostgreSQL) GetComponentMetadata() metadata.ComponentMetadata {
+	fields := metadata.FieldsOf(postgresMetadata{
+		TableName:            defaultTableName,
+		Schema:               defaultSchema,
+		CompressionThreshold: defaultCompressionThreshold,
+		ListKeysMaxLimit:     defaultListKeysMaxLimit,
+	})
+	// the connection string is also a field of the connection
+	fields = append(fields[1:], ConnectionMetadataFields()...)
+	fields = append(fields,
+		metadata.Field{Name: readConnectionStringKey, Type: metadata.TypeString},
+		metadata.Field{Name: cleanupIntervalKey, Type: metadata.TypeDuration, Default: defaultCleanupInterval.String()},
+		metadata.Field{Name: createIndexesKey, Type: metadata.TypeBool},
+		metadata.Field{Name: maxRowAgeKey, Type: metadata.TypeDuration},
+	)
+
+	return metadata.ComponentMetadata{
+		Fields: fields,
+		Capabilities: []metadata.Capability{
+			metadata.CapabilityETag, metadata.CapabilityTTL, metadata.CapabilityQuery, metadata.CapabilityTransactional,
+		},
+	}
+}
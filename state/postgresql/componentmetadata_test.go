@@ -0,0 +1,34 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetComponentMetadata(t *testing.T) {
+	m := NewPostgreSQLStateStore(logger.NewLogger("test")).GetComponentMetadata()
+
+	names := map[string]bool{}
+	for _, f := range m.Fields {
+		assert.False(t, names[f.Name], "duplicate field %s", f.Name)
+		names[f.Name] = true
+	}
+	assert.True(t, names[tableNameKey])
+	assert.True(t, names[timeoutKey])
+
+	for _, c := range []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL, metadata.CapabilityQuery, metadata.CapabilityTransactional} {
+		assert.True(t, m.HasCapability(c), c)
+	}
+
+	assert.NoError(t, m.Validate(map[string]string{connectionStringKey: fakeConnectionString, timeoutKey: "30s"}))
+	assert.Error(t, m.Validate(map[string]string{}))
+	assert.Error(t, m.Validate(map[string]string{connectionStringKey: fakeConnectionString, timeoutKey: "30"}))
+}
@@ -0,0 +1,58 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+)
+
+const (
+	compressionKey = "compression"
+
+	compressionGzip = "gzip"
+
+	// Values smaller than this are not worth the CPU cost of compressing
+	defaultCompressionThreshold = 1024
+)
+
+// rowMetadata is stored in the metadata column of each row and describes how the value was persisted.
+// Rows without metadata hold an uncompressed JSON value, which allows compressed and uncompressed
+// rows to coexist in the same table. Rows with a content type hold a non-JSON value.
+type rowMetadata struct {
+	Encoding    string `json:"encoding,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// gzipCompress compresses data using gzip.
+func gzipCompress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+
+	_, err := writer.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// gzipDecompress decompresses gzip compressed data.
+func gzipDecompress(data []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
@@ -0,0 +1,77 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGzipRoundTrip(t *testing.T) {
+	original := []byte(`{"color":"` + strings.Repeat("blue", 1000) + `"}`)
+
+	compressed, err := gzipCompress(original)
+	assert.Nil(t, err)
+	assert.Less(t, len(compressed), len(original))
+
+	decompressed, err := gzipDecompress(compressed)
+	assert.Nil(t, err)
+	assert.Equal(t, original, decompressed)
+}
+
+func TestEncodeValue(t *testing.T) {
+	large := []byte(`{"color":"` + strings.Repeat("red", 1000) + `"}`)
+	small := []byte(`{"color":"red"}`)
+
+	t.Run("Compression disabled stores JSON", func(t *testing.T) {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		value, binaryValue, rowMeta, err := p.encodeValue(large, "")
+		assert.Nil(t, err)
+		assert.Equal(t, string(large), value.String)
+		assert.Nil(t, binaryValue)
+		assert.False(t, rowMeta.Valid)
+	})
+
+	t.Run("Values below threshold are not compressed", func(t *testing.T) {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		p.compression = compressionGzip
+		p.compressionThreshold = defaultCompressionThreshold
+		value, binaryValue, rowMeta, err := p.encodeValue(small, "")
+		assert.Nil(t, err)
+		assert.Equal(t, string(small), value.String)
+		assert.Nil(t, binaryValue)
+		assert.False(t, rowMeta.Valid)
+	})
+
+	t.Run("Values above threshold are compressed and round trip", func(t *testing.T) {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		p.compression = compressionGzip
+		p.compressionThreshold = defaultCompressionThreshold
+		value, binaryValue, rowMeta, err := p.encodeValue(large, "")
+		assert.Nil(t, err)
+		assert.False(t, value.Valid)
+		assert.True(t, rowMeta.Valid)
+		assert.Less(t, len(binaryValue), len(large))
+
+		decoded, _, err := decodeValue(value, binaryValue, rowMeta)
+		assert.Nil(t, err)
+		assert.Equal(t, large, decoded)
+	})
+
+	t.Run("Rows without metadata decode as JSON", func(t *testing.T) {
+		decoded, _, err := decodeValue(sql.NullString{String: string(small), Valid: true}, nil, sql.NullString{})
+		assert.Nil(t, err)
+		assert.Equal(t, small, decoded)
+	})
+
+	t.Run("Unknown encoding fails", func(t *testing.T) {
+		_, _, err := decodeValue(sql.NullString{}, []byte("abc"), sql.NullString{String: `{"encoding":"lz4"}`, Valid: true})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/state"
+)
+
+// isConditionalSet returns true when a set request may only be applied if it does not overwrite another write:
+// it has an etag to match, or it uses first-write concurrency and may only create the key.
+func isConditionalSet(req *state.SetRequest) bool {
+	return sqlutil.ETagCondition(req.ETag, req.Options.Concurrency) != "" || req.Options.Concurrency == state.FirstWrite
+}
+
+// parseETag converts an etag to the xmin transaction ID of a row, which is an unsigned 32-bit integer.
+func parseETag(etag string) (uint32, error) {
+	xmin, err := strconv.ParseUint(etag, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid etag '%s': %s", etag, err)
+	}
+
+	return uint32(xmin), nil
+}
+
+// formatETag converts the xmin transaction ID of a row to its etag.
+func formatETag(xmin uint32) string {
+	return strconv.FormatUint(uint64(xmin), 10)
+}
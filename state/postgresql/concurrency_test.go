@@ -0,0 +1,35 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsConditionalSet(t *testing.T) {
+	assert.False(t, isConditionalSet(&state.SetRequest{Key: "k"}))
+	assert.True(t, isConditionalSet(&state.SetRequest{Key: "k", ETag: "1"}))
+	assert.False(t, isConditionalSet(&state.SetRequest{Key: "k", ETag: "1", Options: state.SetStateOption{Concurrency: state.LastWrite}}))
+	assert.True(t, isConditionalSet(&state.SetRequest{Key: "k", Options: state.SetStateOption{Concurrency: state.FirstWrite}}))
+}
+
+func TestParseETag(t *testing.T) {
+	xmin, err := parseETag("4294967295")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(4294967295), xmin)
+	assert.Equal(t, "4294967295", formatETag(xmin))
+
+	_, err = parseETag("4294967296")
+	assert.NotNil(t, err)
+
+	_, err = parseETag("-1")
+	assert.NotNil(t, err)
+
+	_, err = parseETag("abc")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,107 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/metadata"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// Conn is a PostgreSQL connection pool opened with the connection string, TLS, authentication, pool and timeout
+// settings of the state store metadata, so that other components connect to PostgreSQL the same way.
+type Conn struct {
+	access *postgresDBAccess
+}
+
+// Connect opens a connection pool with the settings of the metadata. Unlike the state store, it does not create
+// any table.
+func Connect(logger logger.Logger, properties map[string]string) (*Conn, error) {
+	p := newPostgresDBAccess(logger)
+
+	var m struct {
+		ConnectionString string `metadata:"connectionString,required"`
+	}
+	err := metadata.Decode(properties, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.ConnectionString == "" {
+		return nil, fmt.Errorf(errMissingConnectionString)
+	}
+	p.connectionString = m.ConnectionString
+
+	err = p.applyTLSConfig(properties)
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	pool, err := parsePoolConfig(properties)
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	p.timeout, err = parseTimeout(properties)
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	ctx, cancel := p.operationContext()
+	defer cancel()
+
+	p.db, err = p.openPool(ctx, p.connectionString, properties, pool)
+	if err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	return &Conn{access: p}, nil
+}
+
+// Timeout is how long a single operation may take, from the timeout metadata
+func (c *Conn) Timeout() time.Duration {
+	return c.access.timeout
+}
+
+// Exec runs a statement and returns the number of rows it affected
+func (c *Conn) Exec(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	tag, err := c.access.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// Query runs a query, whose rows must be closed
+func (c *Conn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return c.access.db.Query(ctx, sql, args...)
+}
+
+// Acquire returns a connection of the pool for the statements that need a session, such as LISTEN. The connection
+// must be released.
+func (c *Conn) Acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	return c.access.db.current().Acquire(ctx)
+}
+
+// Ping checks that the database can be reached
+func (c *Conn) Ping(ctx context.Context) error {
+	return c.access.db.Ping(ctx)
+}
+
+// Close closes the connection pool and removes the TLS files written for it
+func (c *Conn) Close() error {
+	return c.access.Close()
+}
@@ -0,0 +1,150 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// connPool runs statements on a pgxpool.Pool that can be replaced while it is in use.
+// With token authentication, the pool is periodically replaced by one that connects with a fresh token,
+// because a pool uses the same password for all the connections it opens. Replaced pools are closed
+// once the connections acquired from them are released, so statements in progress are not interrupted.
+type connPool struct {
+	lock    sync.RWMutex
+	pool    *pgxpool.Pool
+	connect func(ctx context.Context) (*pgxpool.Pool, error)
+	closed  bool
+	closeCh chan struct{}
+}
+
+// newConnPool opens a pool with connect, which is also used to open the pools that replace it.
+func newConnPool(ctx context.Context, connect func(ctx context.Context) (*pgxpool.Pool, error)) (*connPool, error) {
+	pool, err := connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &connPool{
+		pool:    pool,
+		connect: connect,
+		closeCh: make(chan struct{}),
+	}, nil
+}
+
+// current returns the pool that new statements run on.
+func (c *connPool) current() *pgxpool.Pool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.pool
+}
+
+// Exec implements dbExecutor.
+func (c *connPool) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return c.current().Exec(ctx, sql, args...)
+}
+
+// Query implements dbExecutor.
+func (c *connPool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return c.current().Query(ctx, sql, args...)
+}
+
+// QueryRow implements dbExecutor.
+func (c *connPool) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return c.current().QueryRow(ctx, sql, args...)
+}
+
+// Begin starts a transaction, which keeps its connection until it is committed or rolled back.
+func (c *connPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	return c.current().Begin(ctx)
+}
+
+// Ping acquires a connection and checks that the database responds.
+func (c *connPool) Ping(ctx context.Context) error {
+	conn, err := c.current().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	return conn.Conn().Ping(ctx)
+}
+
+// Stat returns the statistics of the current pool.
+func (c *connPool) Stat() *pgxpool.Stat {
+	return c.current().Stat()
+}
+
+// refresh replaces the pool with a newly connected one.
+func (c *connPool) refresh(ctx context.Context) error {
+	pool, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		pool.Close()
+		return nil
+	}
+	old := c.pool
+	c.pool = pool
+	c.lock.Unlock()
+
+	// Close blocks until the acquired connections are released
+	go old.Close()
+
+	return nil
+}
+
+// scheduleRefresh replaces the pool every interval until the pool is closed.
+func (c *connPool) scheduleRefresh(interval time.Duration, timeout time.Duration, onError func(err error)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				err := c.refresh(ctx)
+				cancel()
+				if err != nil {
+					onError(err)
+				}
+			case <-c.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops refreshing the pool and closes it, waiting for acquired connections to be released.
+func (c *connPool) Close() {
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		return
+	}
+	c.closed = true
+	if c.closeCh != nil {
+		close(c.closeCh)
+	}
+	pool := c.pool
+	c.lock.Unlock()
+
+	if pool != nil {
+		pool.Close()
+	}
+}
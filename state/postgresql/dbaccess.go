@@ -6,15 +6,32 @@
 package postgresql
 
 import (
+	"context"
+
 	"github.com/dapr/components-contrib/state"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 )
 
 // dbAccess is a private interface which enables unit testing of PostgreSQL
 type dbAccess interface {
 	Init(metadata state.Metadata) error
 	Set(req *state.SetRequest) error
+	SetAndGetETag(req *state.SetRequest) (string, error)
 	Get(req *state.GetRequest) (*state.GetResponse, error)
 	Delete(req *state.DeleteRequest) error
-	ExecuteMulti(sets []state.SetRequest, deletes []state.DeleteRequest) error
+	BulkSet(req []state.SetRequest) error
+	BulkDelete(req []state.DeleteRequest) error
+	ListKeys(req *ListKeysRequest) (*ListKeysResponse, error)
+	Query(req *state.QueryRequest) (*state.QueryResponse, error)
+	ExecuteMulti(reqs []state.TransactionalRequest) error
+	Ping() error
 	Close() error // io.Closer
 }
+
+// dbExecutor is implemented by both *connPool and pgx.Tx, allowing statements to run inside or outside a transaction
+type dbExecutor interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
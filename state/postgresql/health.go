@@ -0,0 +1,54 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const pingOperation = "ping"
+
+// Ping checks that the primary and the read replicas can be reached and that the state table exists,
+// so that an unhealthy database is reported before requests fail.
+func (p *postgresDBAccess) Ping() error {
+	start := time.Now()
+	ctx, cancel := p.operationContext()
+	defer cancel()
+	err := p.ping(ctx)
+	p.recordOperation(pingOperation, start, err)
+	return err
+}
+
+// ping is an internal implementation of ping that is timed by Ping.
+func (p *postgresDBAccess) ping(ctx context.Context) error {
+	if p.db == nil {
+		return fmt.Errorf("the PostgreSQL state store is not initialized")
+	}
+
+	err := p.db.Ping(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to PostgreSQL: %s", err)
+	}
+
+	exists, err := tableExists(ctx, p.db, p.schema, p.tableName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("the PostgreSQL state table %s does not exist", p.table)
+	}
+
+	for i, db := range p.readDBs {
+		err = db.Ping(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to PostgreSQL read replica %d: %s", i+1, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"strings"
+)
+
+const (
+	listKeysMaxLimitKey = "listKeysMaxLimit"
+
+	defaultListKeysLimit    = 100
+	defaultListKeysMaxLimit = 1000
+)
+
+// ListKeysRequest is the object describing a request to list the keys that start with a prefix
+type ListKeysRequest struct {
+	Prefix string `json:"prefix"`
+	// Cursor is the NextCursor of a previous response, used to continue listing after its last key
+	Cursor string `json:"cursor,omitempty"`
+	// Limit is the maximum number of keys to return; the default is used when it is zero
+	Limit int `json:"limit,omitempty"`
+}
+
+// ListKeysResponse is the response object for a list keys request
+type ListKeysResponse struct {
+	Keys []string `json:"keys"`
+	// NextCursor is empty when there are no more keys to list
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// escapeLikePattern escapes the LIKE wildcard characters in s so it is matched literally.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// resolveListKeysLimit returns the number of keys to list for a requested limit.
+func resolveListKeysLimit(requested int, maxLimit int) int {
+	if requested <= 0 {
+		requested = defaultListKeysLimit
+	}
+
+	if requested > maxLimit {
+		return maxLimit
+	}
+
+	return requested
+}
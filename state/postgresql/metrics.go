@@ -0,0 +1,192 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const (
+	getOperation      = "get"
+	setOperation      = "set"
+	deleteOperation   = "delete"
+	listKeysOperation = "listkeys"
+	multiOperation    = "multi"
+
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+
+	// Error categories that are not SQLSTATE classes
+	errorCategoryETagMismatch = "etag_mismatch"
+	errorCategoryOther        = "other"
+
+	poolPrimary = "primary"
+	poolReplica = "replica"
+)
+
+var (
+	operationKey     = tag.MustNewKey("operation")
+	outcomeKey       = tag.MustNewKey("outcome")
+	errorCategoryKey = tag.MustNewKey("error_category")
+	poolKey          = tag.MustNewKey("pool")
+
+	operationLatency = stats.Float64(
+		"component/state/postgresql/operation_latency",
+		"The latency of PostgreSQL state store operations.",
+		stats.UnitMilliseconds)
+
+	operationCountView = &view.View{
+		Name:        "component/state/postgresql/operation_count",
+		Description: "The number of PostgreSQL state store operations.",
+		Measure:     operationLatency,
+		TagKeys:     []tag.Key{operationKey, outcomeKey, errorCategoryKey},
+		Aggregation: view.Count(),
+	}
+
+	operationLatencyView = &view.View{
+		Name:        "component/state/postgresql/operation_latency",
+		Description: "The distribution of PostgreSQL state store operation latencies.",
+		Measure:     operationLatency,
+		TagKeys:     []tag.Key{operationKey, outcomeKey},
+		Aggregation: view.Distribution(1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000),
+	}
+
+	poolAcquiredConnections = stats.Int64(
+		"component/state/postgresql/pool_acquired_connections",
+		"The number of connections currently in use.",
+		stats.UnitDimensionless)
+	poolIdleConnections = stats.Int64(
+		"component/state/postgresql/pool_idle_connections",
+		"The number of idle connections in the pool.",
+		stats.UnitDimensionless)
+	poolTotalConnections = stats.Int64(
+		"component/state/postgresql/pool_total_connections",
+		"The number of open connections in the pool.",
+		stats.UnitDimensionless)
+
+	poolViews = []*view.View{
+		poolView(poolAcquiredConnections),
+		poolView(poolIdleConnections),
+		poolView(poolTotalConnections),
+	}
+)
+
+// poolView reports the last recorded value of a connection pool measure for each pool.
+func poolView(measure *stats.Int64Measure) *view.View {
+	return &view.View{
+		Name:        measure.Name(),
+		Description: measure.Description(),
+		Measure:     measure,
+		TagKeys:     []tag.Key{poolKey},
+		Aggregation: view.LastValue(),
+	}
+}
+
+// sqlStateError is implemented by PostgreSQL driver errors which carry a SQLSTATE code
+type sqlStateError interface {
+	SQLState() string
+}
+
+// errorCategory returns the SQLSTATE class of a database error, e.g. "23" for integrity constraint violations.
+func errorCategory(err error) string {
+	if errors.Is(err, errNoRowsAffected) {
+		return errorCategoryETagMismatch
+	}
+
+	var sqlErr sqlStateError
+	if errors.As(err, &sqlErr) && len(sqlErr.SQLState()) >= 2 {
+		return sqlErr.SQLState()[:2]
+	}
+
+	return errorCategoryOther
+}
+
+// registerMetricViews registers the views that aggregate the PostgreSQL operation and connection pool metrics.
+func registerMetricViews() error {
+	return view.Register(append([]*view.View{operationCountView, operationLatencyView}, poolViews...)...)
+}
+
+// recordOperation logs the duration of an operation and records it as a metric when metrics are enabled.
+func (p *postgresDBAccess) recordOperation(operation string, start time.Time, err error) {
+	elapsed := time.Since(start)
+	p.logger.Debugf("PostgreSQL %s operation completed in %s", operation, elapsed)
+
+	if !p.metricsEnabled {
+		return
+	}
+
+	outcome := outcomeSuccess
+	category := ""
+	if err != nil {
+		outcome = outcomeError
+		category = errorCategory(err)
+	}
+
+	mutators := []tag.Mutator{
+		tag.Upsert(operationKey, operation),
+		tag.Upsert(outcomeKey, outcome),
+		tag.Upsert(errorCategoryKey, category),
+	}
+
+	latency := float64(elapsed) / float64(time.Millisecond)
+	if recordErr := stats.RecordWithTags(context.Background(), mutators, operationLatency.M(latency)); recordErr != nil {
+		p.logger.Warnf("failed to record PostgreSQL operation metric: %s", recordErr)
+	}
+
+	if p.db != nil {
+		p.recordPoolStats(poolPrimary, newPoolStats(p.db.Stat()))
+	}
+	if len(p.readDBs) > 0 {
+		p.recordPoolStats(poolReplica, sumPoolStats(p.readDBs))
+	}
+}
+
+// poolStats holds the connection counts of one or more pools.
+type poolStats struct {
+	acquired int32
+	idle     int32
+	total    int32
+}
+
+func newPoolStats(stat *pgxpool.Stat) poolStats {
+	return poolStats{
+		acquired: stat.AcquiredConns(),
+		idle:     stat.IdleConns(),
+		total:    stat.TotalConns(),
+	}
+}
+
+// sumPoolStats returns the connection counts of all the read replicas together.
+func sumPoolStats(pools []*connPool) poolStats {
+	var sum poolStats
+	for _, pool := range pools {
+		counts := newPoolStats(pool.Stat())
+		sum.acquired += counts.acquired
+		sum.idle += counts.idle
+		sum.total += counts.total
+	}
+
+	return sum
+}
+
+// recordPoolStats records the connection counts of a pool, so that pool exhaustion can be monitored.
+func (p *postgresDBAccess) recordPoolStats(pool string, counts poolStats) {
+	mutators := []tag.Mutator{tag.Upsert(poolKey, pool)}
+	err := stats.RecordWithTags(context.Background(), mutators,
+		poolAcquiredConnections.M(int64(counts.acquired)),
+		poolIdleConnections.M(int64(counts.idle)),
+		poolTotalConnections.M(int64(counts.total)))
+	if err != nil {
+		p.logger.Warnf("failed to record PostgreSQL connection pool metric: %s", err)
+	}
+}
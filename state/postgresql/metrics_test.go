@@ -0,0 +1,94 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+type fakeSQLStateError struct {
+	code string
+}
+
+func (e *fakeSQLStateError) Error() string {
+	return "fake database error"
+}
+
+func (e *fakeSQLStateError) SQLState() string {
+	return e.code
+}
+
+func TestErrorCategory(t *testing.T) {
+	assert.Equal(t, errorCategoryETagMismatch, errorCategory(errNoRowsAffected))
+	assert.Equal(t, "23", errorCategory(&fakeSQLStateError{code: "23505"}))
+	assert.Equal(t, "40", errorCategory(fmt.Errorf("wrapped: %w", &fakeSQLStateError{code: "40001"})))
+	assert.Equal(t, errorCategoryOther, errorCategory(errors.New("connection refused")))
+}
+
+func TestRecordOperation(t *testing.T) {
+	assert.Nil(t, registerMetricViews())
+	defer view.Unregister(append([]*view.View{operationCountView, operationLatencyView}, poolViews...)...)
+
+	t.Run("Nothing is recorded when metrics are disabled", func(t *testing.T) {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		p.recordOperation(getOperation, time.Now(), nil)
+
+		rows, err := view.RetrieveData(operationCountView.Name)
+		assert.Nil(t, err)
+		assert.Empty(t, rows)
+	})
+
+	t.Run("Operations are recorded with outcome and error category", func(t *testing.T) {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		p.metricsEnabled = true
+		p.recordOperation(setOperation, time.Now(), nil)
+		p.recordOperation(setOperation, time.Now(), errNoRowsAffected)
+		p.recordOperation(setOperation, time.Now(), errNoRowsAffected)
+
+		rows, err := view.RetrieveData(operationCountView.Name)
+		assert.Nil(t, err)
+		assert.Len(t, rows, 2)
+
+		counts := map[string]int64{}
+		for _, row := range rows {
+			counts[tagValue(row.Tags, outcomeKey)+"/"+tagValue(row.Tags, errorCategoryKey)] = row.Data.(*view.CountData).Value
+		}
+		assert.Equal(t, int64(1), counts[outcomeSuccess+"/"])
+		assert.Equal(t, int64(2), counts[outcomeError+"/"+errorCategoryETagMismatch])
+	})
+}
+
+func TestRecordPoolStats(t *testing.T) {
+	assert.Nil(t, registerMetricViews())
+	defer view.Unregister(append([]*view.View{operationCountView, operationLatencyView}, poolViews...)...)
+
+	p := newPostgresDBAccess(logger.NewLogger("test"))
+	p.recordPoolStats(poolPrimary, poolStats{acquired: 3, idle: 2, total: 5})
+	p.recordPoolStats(poolPrimary, poolStats{acquired: 1, idle: 4, total: 5})
+
+	rows, err := view.RetrieveData(poolAcquiredConnections.Name())
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, poolPrimary, tagValue(rows[0].Tags, poolKey))
+	assert.Equal(t, float64(1), rows[0].Data.(*view.LastValueData).Value)
+}
+
+func tagValue(tags []tag.Tag, key tag.Key) string {
+	for _, t := range tags {
+		if t.Key == key {
+			return t.Value
+		}
+	}
+
+	return ""
+}
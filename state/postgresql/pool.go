@@ -0,0 +1,108 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/metadata"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const (
+	maxOpenConnectionsKey    = "maxOpenConnections"
+	maxIdleConnectionsKey    = "maxIdleConnections"
+	connectionMaxLifetimeKey = "connectionMaxLifetime"
+	connectionMaxIdleTimeKey = "connectionMaxIdleTime"
+)
+
+// poolConfig holds the connection pool settings. Zero values keep the pgxpool defaults.
+type poolConfig struct {
+	maxOpenConnections    int
+	maxIdleConnections    int
+	connectionMaxLifetime time.Duration
+	connectionMaxIdleTime time.Duration
+}
+
+// parsePoolConfig reads the connection pool settings from the component metadata.
+func parsePoolConfig(properties map[string]string) (poolConfig, error) {
+	var m struct {
+		MaxOpenConnections    *int           `metadata:"maxOpenConnections"`
+		MaxIdleConnections    *int           `metadata:"maxIdleConnections"`
+		ConnectionMaxLifetime *time.Duration `metadata:"connectionMaxLifetime"`
+		ConnectionMaxIdleTime *time.Duration `metadata:"connectionMaxIdleTime"`
+	}
+	if err := metadata.Decode(properties, &m); err != nil {
+		return poolConfig{}, err
+	}
+
+	var config poolConfig
+	var err error
+
+	config.maxOpenConnections, err = positiveInt(maxOpenConnectionsKey, m.MaxOpenConnections)
+	if err != nil {
+		return config, err
+	}
+
+	config.maxIdleConnections, err = positiveInt(maxIdleConnectionsKey, m.MaxIdleConnections)
+	if err != nil {
+		return config, err
+	}
+
+	config.connectionMaxLifetime, err = positiveDuration(connectionMaxLifetimeKey, m.ConnectionMaxLifetime)
+	if err != nil {
+		return config, err
+	}
+
+	config.connectionMaxIdleTime, err = positiveDuration(connectionMaxIdleTimeKey, m.ConnectionMaxIdleTime)
+	if err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// applyPoolConfig applies the connection pool settings that are set to config.
+func applyPoolConfig(config *pgxpool.Config, pool poolConfig) {
+	if pool.maxOpenConnections > 0 {
+		config.MaxConns = int32(pool.maxOpenConnections)
+	}
+
+	if pool.connectionMaxLifetime > 0 {
+		config.MaxConnLifetime = pool.connectionMaxLifetime
+	}
+
+	if pool.connectionMaxIdleTime > 0 {
+		config.MaxConnIdleTime = pool.connectionMaxIdleTime
+	}
+}
+
+// positiveInt returns the decoded value, which must be positive when it is set, or zero when it is not
+func positiveInt(key string, val *int) (int, error) {
+	if val == nil {
+		return 0, nil
+	}
+
+	if *val <= 0 {
+		return 0, fmt.Errorf("invalid %s value '%d', must be a positive integer", key, *val)
+	}
+
+	return *val, nil
+}
+
+// positiveDuration returns the decoded value, which must be positive when it is set, or zero when it is not
+func positiveDuration(key string, val *time.Duration) (time.Duration, error) {
+	if val == nil {
+		return 0, nil
+	}
+
+	if *val <= 0 {
+		return 0, fmt.Errorf("invalid %s value '%s', must be a positive duration such as 5m", key, *val)
+	}
+
+	return *val, nil
+}
@@ -0,0 +1,60 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePoolConfig(t *testing.T) {
+	t.Run("Defaults are kept when nothing is set", func(t *testing.T) {
+		config, err := parsePoolConfig(map[string]string{})
+		assert.Nil(t, err)
+		assert.Equal(t, poolConfig{}, config)
+	})
+
+	t.Run("All settings are parsed", func(t *testing.T) {
+		config, err := parsePoolConfig(map[string]string{
+			maxOpenConnectionsKey:    "20",
+			maxIdleConnectionsKey:    "5",
+			connectionMaxLifetimeKey: "30m",
+			connectionMaxIdleTimeKey: "2m",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 20, config.maxOpenConnections)
+		assert.Equal(t, 5, config.maxIdleConnections)
+		assert.Equal(t, 30*time.Minute, config.connectionMaxLifetime)
+		assert.Equal(t, 2*time.Minute, config.connectionMaxIdleTime)
+	})
+
+	invalid := map[string]string{
+		maxOpenConnectionsKey:    "many",
+		maxIdleConnectionsKey:    "-1",
+		connectionMaxLifetimeKey: "30",
+		connectionMaxIdleTimeKey: "-5s",
+	}
+	for key, val := range invalid {
+		key, val := key, val
+		t.Run("Invalid "+key, func(t *testing.T) {
+			_, err := parsePoolConfig(map[string]string{key: val})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestApplyPoolConfig(t *testing.T) {
+	config, err := pgxpool.ParseConfig("host=localhost user=dapr")
+	assert.Nil(t, err)
+	defaultLifetime := config.MaxConnLifetime
+
+	applyPoolConfig(config, poolConfig{maxOpenConnections: 7, connectionMaxIdleTime: 2 * time.Minute})
+	assert.Equal(t, int32(7), config.MaxConns)
+	assert.Equal(t, 2*time.Minute, config.MaxConnIdleTime)
+	assert.Equal(t, defaultLifetime, config.MaxConnLifetime)
+}
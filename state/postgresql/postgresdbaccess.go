@@ -6,39 +6,77 @@
 package postgresql
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
-
 	"errors"
 	"fmt"
-	"strconv"
+	"time"
 
+	contribmetadata "github.com/dapr/components-contrib/internal/metadata"
+	"github.com/dapr/components-contrib/internal/sqlutil"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
-
-	// Blank import for the underlying PostgreSQL driver
-	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 )
 
 const (
 	connectionStringKey        = "connectionString"
 	errMissingConnectionString = "missing connection string"
-	tableName                  = "state"
+	tableNameKey               = "tableName"
+	schemaKey                  = "schema"
+	defaultTableName           = "state"
+	defaultSchema              = "public"
 )
 
+var errNoRowsAffected = errors.New("database operation failed: no rows match given key and etag")
+
+// postgresMetadata holds the settings of the metadata that only the state store reads
+type postgresMetadata struct {
+	ConnectionString     string                   `metadata:"connectionString,required"`
+	TableName            string                   `metadata:"tableName"`
+	Schema               string                   `metadata:"schema"`
+	Compression          string                   `metadata:"compression"`
+	CompressionThreshold contribmetadata.ByteSize `metadata:"compressionThreshold"`
+	EscapeHTML           bool                     `metadata:"escapeHTML"`
+	MetricsEnabled       bool                     `metadata:"metricsEnabled"`
+	ListKeysMaxLimit     int                      `metadata:"listKeysMaxLimit"`
+}
+
 // postgresDBAccess implements dbaccess
 type postgresDBAccess struct {
-	logger           logger.Logger
-	metadata         state.Metadata
-	db               *sql.DB
-	connectionString string
+	logger               logger.Logger
+	metadata             state.Metadata
+	db                   *connPool
+	connectionString     string
+	schema               string
+	tableName            string
+	table                string // Quoted schema qualified name of the state table used in queries
+	compression          string
+	compressionThreshold int
+	listKeysMaxLimit     int
+	escapeHTML           bool
+	metricsEnabled       bool
+	closeCh              chan struct{}
+	tlsDir               string
+	tlsParams            []tlsParam
+	readDBs              []*connPool
+	readIndex            uint32
+	timeout              time.Duration
+	createIndexes        bool
+	maxRowAge            time.Duration
+	defaultTTL           int
 }
 
 // newPostgresDBAccess creates a new instance of postgresAccess
 func newPostgresDBAccess(logger logger.Logger) *postgresDBAccess {
 	logger.Debug("Instantiating new PostgreSQL state store")
 	return &postgresDBAccess{
-		logger: logger,
+		logger:  logger,
+		closeCh: make(chan struct{}),
+		timeout: defaultTimeout,
 	}
 }
 
@@ -47,14 +85,100 @@ func (p *postgresDBAccess) Init(metadata state.Metadata) error {
 	p.logger.Debug("Initializing PostgreSQL state store")
 	p.metadata = metadata
 
-	if val, ok := metadata.Properties[connectionStringKey]; ok && val != "" {
-		p.connectionString = val
-	} else {
+	m := postgresMetadata{
+		TableName:            defaultTableName,
+		Schema:               defaultSchema,
+		CompressionThreshold: defaultCompressionThreshold,
+		ListKeysMaxLimit:     defaultListKeysMaxLimit,
+	}
+	err := contribmetadata.Decode(metadata.Properties, &m)
+	if err != nil {
+		return err
+	}
+
+	if m.ConnectionString == "" {
 		p.logger.Error("Missing postgreSQL connection string")
 		return fmt.Errorf(errMissingConnectionString)
 	}
+	p.connectionString = m.ConnectionString
 
-	db, err := sql.Open("pgx", p.connectionString)
+	err = p.applyTLSConfig(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	if !sqlutil.IsValidName(m.TableName) {
+		return fmt.Errorf("invalid table name '%s', accepted characters are (A-Z, a-z, 0-9, _)", m.TableName)
+	}
+	p.tableName = m.TableName
+
+	if !sqlutil.IsValidName(m.Schema) {
+		return fmt.Errorf("invalid schema name '%s', accepted characters are (A-Z, a-z, 0-9, _)", m.Schema)
+	}
+	p.schema = m.Schema
+
+	p.table = qualifiedTableName(p.schema, p.tableName)
+
+	if m.Compression != "" && m.Compression != compressionGzip {
+		return fmt.Errorf("unsupported compression '%s', supported values are: %s", m.Compression, compressionGzip)
+	}
+	p.compression = m.Compression
+
+	p.compressionThreshold = int(m.CompressionThreshold)
+	p.escapeHTML = m.EscapeHTML
+	p.metricsEnabled = m.MetricsEnabled
+
+	if p.metricsEnabled {
+		err := registerMetricViews()
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.ListKeysMaxLimit <= 0 {
+		return fmt.Errorf("invalid %s value '%d', must be a positive integer", listKeysMaxLimitKey, m.ListKeysMaxLimit)
+	}
+	p.listKeysMaxLimit = m.ListKeysMaxLimit
+
+	pool, err := parsePoolConfig(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	if pool.maxIdleConnections > 0 {
+		p.logger.Warnf("%s is ignored because idle connections are limited by %s", maxIdleConnectionsKey, connectionMaxIdleTimeKey)
+	}
+
+	cleanupInterval, err := parseCleanupInterval(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	p.defaultTTL, err = state.ParseDefaultTTL(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	p.createIndexes, p.maxRowAge, err = parseRetention(metadata.Properties, cleanupInterval)
+	if err != nil {
+		return err
+	}
+
+	p.timeout, err = parseTimeout(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	readConnectionStrings, err := parseReadConnectionStrings(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := p.operationContext()
+	defer cancel()
+
+	// The pool connects when it is opened, so connection errors are returned here
+	db, err := p.openPool(ctx, p.connectionString, metadata.Properties, pool)
 	if err != nil {
 		p.logger.Error(err)
 		return err
@@ -62,181 +186,405 @@ func (p *postgresDBAccess) Init(metadata state.Metadata) error {
 
 	p.db = db
 
-	pingErr := db.Ping()
-	if pingErr != nil {
-		return pingErr
+	err = p.ensureStateTable(ctx, p.schema, p.tableName)
+	if err != nil {
+		return err
 	}
 
-	err = p.ensureStateTable(tableName)
+	if p.createIndexes {
+		err = p.ensureIndexes(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.openReadReplicas(ctx, readConnectionStrings, metadata.Properties, pool)
 	if err != nil {
 		return err
 	}
 
+	if cleanupInterval > 0 {
+		p.scheduleCleanupExpiredData(cleanupInterval)
+	}
+
 	return nil
 }
 
 // Set makes an insert or update to the database.
 func (p *postgresDBAccess) Set(req *state.SetRequest) error {
-	return state.SetWithRetries(p.setValue, req)
+	_, err := p.SetAndGetETag(req)
+	return err
 }
 
-// setValue is an internal implementation of set to enable passing the logic to state.SetWithRetries as a func.
-func (p *postgresDBAccess) setValue(req *state.SetRequest) error {
-	p.logger.Debug("Setting state value in PostgreSQL")
+// SetAndGetETag makes an insert or update to the database and returns the new etag of the item.
+func (p *postgresDBAccess) SetAndGetETag(req *state.SetRequest) (string, error) {
+	start := time.Now()
+	var etag string
+	err := state.SetWithRetries(func(req *state.SetRequest) error {
+		var setErr error
+		ctx, cancel := p.operationContext()
+		defer cancel()
+		etag, setErr = p.setValue(ctx, p.db, req)
+		return setErr
+	}, req)
+	p.recordOperation(setOperation, start, err)
+	return etag, err
+}
+
+// setRow holds the column values written for a set request.
+type setRow struct {
+	key         string
+	value       sql.NullString
+	binaryValue []byte
+	rowMeta     sql.NullString
+	ttl         sql.NullInt64
+}
 
+// prepareSetRow validates a set request and serializes it into the column values to write.
+func (p *postgresDBAccess) prepareSetRow(req *state.SetRequest) (*setRow, error) {
 	err := state.CheckSetRequestOptions(req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if req.Key == "" {
-		return fmt.Errorf("missing key in set operation")
+		return nil, fmt.Errorf("missing key in set operation")
 	}
 
-	var valueBytes []byte
+	valueBytes, contentType, err := serializeValue(req.Value, req.Metadata, p.escapeHTML)
+	if err != nil {
+		return nil, err
+	}
 
-	// Convert to json string
-	valueBytes, err = json.Marshal(req.Value)
+	row := &setRow{key: req.Key}
+	row.value, row.binaryValue, row.rowMeta, err = p.encodeValue(valueBytes, contentType)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	value := string(valueBytes)
 
-	var result sql.Result
+	row.ttl, err = sqlutil.ParseTTL(req.Metadata, p.defaultTTL)
+	if err != nil {
+		return nil, err
+	}
 
-	// Sprintf is required for table name because sql.DB does not substitute parameters for table names.
-	// Other parameters use sql.DB parameter substitution.
-	if req.ETag == "" {
-		result, err = p.db.Exec(fmt.Sprintf(
-			`INSERT INTO %s (key, value) VALUES ($1, $2)
-			ON CONFLICT (key) DO UPDATE SET value = $2, updatedate = NOW();`,
-			tableName), req.Key, value)
-	} else {
-		// Convert req.ETag to integer for postgres compatibility
-		var etag int
-		etag, err = strconv.Atoi(req.ETag)
+	return row, nil
+}
+
+// setValue is an internal implementation of set to enable passing the logic to state.SetWithRetries as a func.
+// The etag of the written row is returned by the statement so that no read back is needed.
+func (p *postgresDBAccess) setValue(ctx context.Context, db dbExecutor, req *state.SetRequest) (string, error) {
+	p.logger.Debug("Setting state value in PostgreSQL")
+
+	row, err := p.prepareSetRow(req)
+	if err != nil {
+		return "", err
+	}
+
+	var newEtag uint32
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+
+	// Sprintf is required for table name because PostgreSQL does not substitute parameters for table names.
+	// Other parameters use PostgreSQL parameter substitution.
+	switch {
+	case reqEtag == "" && req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so only a missing or expired row may be written
+		err = db.QueryRow(ctx, fmt.Sprintf(
+			`INSERT INTO %[1]s AS existing (key, value, binaryvalue, metadata, expiredate) VALUES ($1, $2, $3, $4, %[2]s)
+			ON CONFLICT (key) DO UPDATE SET value = $2, binaryvalue = $3, metadata = $4, expiredate = %[2]s, updatedate = NOW()
+			WHERE existing.expiredate IS NOT NULL AND existing.expiredate <= NOW()
+			RETURNING xmin;`,
+			p.table, expireDateExpression("$5")), row.key, row.value, row.binaryValue, row.rowMeta, row.ttl).Scan(&newEtag)
+	case reqEtag == "":
+		err = db.QueryRow(ctx, fmt.Sprintf(
+			`INSERT INTO %s (key, value, binaryvalue, metadata, expiredate) VALUES ($1, $2, $3, $4, %s)
+			ON CONFLICT (key) DO UPDATE SET value = $2, binaryvalue = $3, metadata = $4, expiredate = %s, updatedate = NOW()
+			RETURNING xmin;`,
+			p.table, expireDateExpression("$5"), expireDateExpression("$5")), row.key, row.value, row.binaryValue, row.rowMeta, row.ttl).Scan(&newEtag)
+	default:
+		var etag uint32
+		etag, err = parseETag(reqEtag)
 		if err != nil {
-			return err
+			return "", err
 		}
 
 		// When an etag is provided do an update - no insert
-		result, err = p.db.Exec(fmt.Sprintf(
-			`UPDATE %s SET value = $1, updatedate = NOW() 
-			 WHERE key = $2 AND xmin = $3;`,
-			tableName), value, req.Key, etag)
+		err = db.QueryRow(ctx, fmt.Sprintf(
+			`UPDATE %s SET value = $1, binaryvalue = $2, metadata = $3, expiredate = %s, updatedate = NOW()
+			 WHERE key = $5 AND xmin = $6 AND %s
+			 RETURNING xmin;`,
+			p.table, expireDateExpression("$4"), notExpiredCondition), row.value, row.binaryValue, row.rowMeta, row.ttl, row.key, etag).Scan(&newEtag)
 	}
 
-	return p.returnSingleDBResult(result, err)
+	if err == pgx.ErrNoRows {
+		err = errNoRowsAffected
+	}
+	if err != nil {
+		p.logger.Debug(err)
+		return "", err
+	}
+
+	return formatETag(newEtag), nil
 }
 
 // Get returns data from the database. If data does not exist for the key an empty state.GetResponse will be returned.
 func (p *postgresDBAccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	start := time.Now()
+	ctx, cancel := p.operationContext()
+	defer cancel()
+	response, err := p.getValue(ctx, req)
+	p.recordOperation(getOperation, start, err)
+	return response, err
+}
+
+// getValue is an internal implementation of get that is timed by Get.
+func (p *postgresDBAccess) getValue(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
 	p.logger.Debug("Getting state value from PostgreSQL")
 	if req.Key == "" {
 		return nil, fmt.Errorf("missing key in get operation")
 	}
 
-	var value string
-	var etag int
-	err := p.db.QueryRow(fmt.Sprintf("SELECT value, xmin as etag FROM %s WHERE key = $1", tableName), req.Key).Scan(&value, &etag)
+	var value sql.NullString
+	var binaryValue []byte
+	var rowMeta sql.NullString
+	var etag uint32
+	err := p.readDB(req.Options.Consistency).QueryRow(ctx, fmt.Sprintf("SELECT value, binaryvalue, metadata, xmin as etag FROM %s WHERE key = $1 AND %s", p.table, notExpiredCondition), req.Key).Scan(&value, &binaryValue, &rowMeta, &etag)
 	if err != nil {
 		// If no rows exist, return an empty response, otherwise return the error.
-		if err == sql.ErrNoRows {
+		if err == pgx.ErrNoRows {
 			return &state.GetResponse{}, nil
 		}
 		return nil, err
 	}
 
+	data, contentType, err := decodeValue(value, binaryValue, rowMeta)
+	if err != nil {
+		return nil, err
+	}
+
 	response := &state.GetResponse{
-		Data:     []byte(value),
-		ETag:     strconv.Itoa(etag),
+		Data:     data,
+		ETag:     formatETag(etag),
 		Metadata: req.Metadata,
 	}
 
+	if contentType != "" {
+		response.Metadata = map[string]string{contentTypeKey: contentType}
+		for k, v := range req.Metadata {
+			if k != contentTypeKey {
+				response.Metadata[k] = v
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// ListKeys returns the keys that start with the requested prefix in key order, one page at a time.
+func (p *postgresDBAccess) ListKeys(req *ListKeysRequest) (*ListKeysResponse, error) {
+	start := time.Now()
+	ctx, cancel := p.operationContext()
+	defer cancel()
+	response, err := p.listKeys(ctx, req)
+	p.recordOperation(listKeysOperation, start, err)
+	return response, err
+}
+
+// listKeys is an internal implementation of list keys that is timed by ListKeys.
+func (p *postgresDBAccess) listKeys(ctx context.Context, req *ListKeysRequest) (*ListKeysResponse, error) {
+	p.logger.Debug("Listing state keys from PostgreSQL")
+
+	limit := resolveListKeysLimit(req.Limit, p.listKeysMaxLimit)
+
+	// Query one extra row to find out whether there is another page
+	rows, err := p.readDB("").Query(ctx, fmt.Sprintf(
+		`SELECT key FROM %s WHERE key LIKE $1 AND key > $2 AND %s ORDER BY key LIMIT $3`,
+		p.table, notExpiredCondition), escapeLikePattern(req.Prefix)+"%", req.Cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	response := &ListKeysResponse{
+		Keys: []string{},
+	}
+	for rows.Next() {
+		var key string
+		err = rows.Scan(&key)
+		if err != nil {
+			return nil, err
+		}
+		response.Keys = append(response.Keys, key)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Keys) > limit {
+		response.Keys = response.Keys[:limit]
+		response.NextCursor = response.Keys[limit-1]
+	}
+
 	return response, nil
 }
 
 // Delete removes an item from the state store.
 func (p *postgresDBAccess) Delete(req *state.DeleteRequest) error {
-	return state.DeleteWithRetries(p.deleteValue, req)
+	start := time.Now()
+	err := state.DeleteWithRetries(func(req *state.DeleteRequest) error {
+		ctx, cancel := p.operationContext()
+		defer cancel()
+		return p.deleteValue(ctx, p.db, req)
+	}, req)
+	p.recordOperation(deleteOperation, start, err)
+	return err
 }
 
 // deleteValue is an internal implementation of delete to enable passing the logic to state.DeleteWithRetries as a func.
-func (p *postgresDBAccess) deleteValue(req *state.DeleteRequest) error {
+func (p *postgresDBAccess) deleteValue(ctx context.Context, db dbExecutor, req *state.DeleteRequest) error {
 	p.logger.Debug("Deleting state value from PostgreSQL")
 	if req.Key == "" {
 		return fmt.Errorf("missing key in delete operation")
 	}
 
-	var result sql.Result
+	var result pgconn.CommandTag
 	var err error
 
-	if req.ETag == "" {
-		result, err = p.db.Exec("DELETE FROM state WHERE key = $1", req.Key)
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+	if reqEtag == "" {
+		result, err = db.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE key = $1", p.table), req.Key)
 	} else {
-		// Convert req.ETag to integer for postgres compatibility
-		etag, conversionError := strconv.Atoi(req.ETag)
+		etag, conversionError := parseETag(reqEtag)
 		if conversionError != nil {
 			return conversionError
 		}
 
-		result, err = p.db.Exec("DELETE FROM state WHERE key = $1 and xmin = $2", req.Key, etag)
+		result, err = db.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE key = $1 and xmin = $2", p.table), req.Key, etag)
 	}
 
 	return p.returnSingleDBResult(result, err)
 }
 
-func (p *postgresDBAccess) ExecuteMulti(sets []state.SetRequest, deletes []state.DeleteRequest) error {
+// ExecuteMulti runs set and delete requests in order within a single transaction.
+func (p *postgresDBAccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	start := time.Now()
+	ctx, cancel := p.operationContext()
+	defer cancel()
+	err := p.executeMulti(ctx, reqs)
+	p.recordOperation(multiOperation, start, err)
+	return err
+}
+
+// executeMulti is an internal implementation of multi that is timed by ExecuteMulti.
+// All operations run in a single transaction, so either all of them are applied or none are.
+func (p *postgresDBAccess) executeMulti(ctx context.Context, reqs []state.TransactionalRequest) error {
 	p.logger.Debug("Executing multiple PostgreSQL operations")
-	tx, err := p.db.Begin()
-	if err != nil {
-		return err
-	}
 
-	if len(deletes) > 0 {
-		for _, d := range deletes {
-			da := d // Fix for gosec  G601: Implicit memory aliasing in for loop.
-			err = p.Delete(&da)
+	return p.inTransaction(ctx, func(tx pgx.Tx) error {
+		for _, req := range reqs {
+			var err error
+			switch r := req.Request.(type) {
+			case state.SetRequest:
+				_, err = p.setValue(ctx, tx, &r)
+			case state.DeleteRequest:
+				err = p.deleteValue(ctx, tx, &r)
+			default:
+				err = fmt.Errorf("unsupported request type %T", req.Request)
+			}
 			if err != nil {
-				tx.Rollback()
 				return err
 			}
 		}
+
+		return nil
+	})
+}
+
+// marshalValue serializes a state value to JSON. Unlike json.Marshal, HTML characters such as <, >, and &
+// are only escaped when escapeHTML is true so that values round-trip byte-for-byte.
+func marshalValue(value interface{}, escapeHTML bool) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(escapeHTML)
+
+	err := encoder.Encode(value)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(sets) > 0 {
-		for _, s := range sets {
-			sa := s // Fix for gosec  G601: Implicit memory aliasing in for loop.
-			err = p.Set(&sa)
-			if err != nil {
-				tx.Rollback()
-				return err
-			}
+	// Encode terminates the value with a newline which is not part of the value
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// encodeValue returns the column values to store for a serialized state value.
+// JSON values, which have an empty content type, are stored in the value column and other values in the binaryvalue column.
+// Values at or above the compression threshold are gzipped into the binaryvalue column when compression is enabled.
+func (p *postgresDBAccess) encodeValue(valueBytes []byte, contentType string) (value sql.NullString, binaryValue []byte, rowMeta sql.NullString, err error) {
+	meta := rowMetadata{ContentType: contentType}
+
+	switch {
+	case p.compression == compressionGzip && len(valueBytes) >= p.compressionThreshold:
+		binaryValue, err = gzipCompress(valueBytes)
+		if err != nil {
+			return value, nil, rowMeta, err
 		}
+		meta.Encoding = compressionGzip
+	case contentType == "":
+		return sql.NullString{String: string(valueBytes), Valid: true}, nil, sql.NullString{}, nil
+	default:
+		binaryValue = valueBytes
 	}
 
-	err = tx.Commit()
-	return err
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return value, nil, rowMeta, err
+	}
+
+	return sql.NullString{}, binaryValue, sql.NullString{String: string(metaBytes), Valid: true}, nil
+}
+
+// decodeValue returns the original serialized state value and its content type from the columns of a row.
+// The content type is empty for JSON values.
+func decodeValue(value sql.NullString, binaryValue []byte, rowMeta sql.NullString) ([]byte, string, error) {
+	if !rowMeta.Valid {
+		return []byte(value.String), "", nil
+	}
+
+	var meta rowMetadata
+	err := json.Unmarshal([]byte(rowMeta.String), &meta)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data := binaryValue
+	if value.Valid {
+		data = []byte(value.String)
+	}
+
+	switch meta.Encoding {
+	case "":
+		return data, meta.ContentType, nil
+	case compressionGzip:
+		data, err = gzipDecompress(data)
+		return data, meta.ContentType, err
+	default:
+		return nil, "", fmt.Errorf("unsupported value encoding '%s'", meta.Encoding)
+	}
 }
 
-// Verifies that the sql.Result affected only one row and no errors exist
-func (p *postgresDBAccess) returnSingleDBResult(result sql.Result, err error) error {
+// Verifies that the command affected only one row and no errors exist
+func (p *postgresDBAccess) returnSingleDBResult(result pgconn.CommandTag, err error) error {
 	if err != nil {
 		p.logger.Debug(err)
 		return err
 	}
 
-	rowsAffected, resultErr := result.RowsAffected()
-
-	if resultErr != nil {
-		p.logger.Error(resultErr)
-		return resultErr
-	}
+	rowsAffected := result.RowsAffected()
 
 	if rowsAffected == 0 {
-		noRowsErr := errors.New("database operation failed: no rows match given key and etag")
-		p.logger.Error(noRowsErr)
-		return noRowsErr
+		p.logger.Error(errNoRowsAffected)
+		return errNoRowsAffected
 	}
 
 	if rowsAffected > 1 {
@@ -250,15 +598,27 @@ func (p *postgresDBAccess) returnSingleDBResult(result sql.Result, err error) er
 
 // Close implements io.Close
 func (p *postgresDBAccess) Close() error {
+	if p.closeCh != nil {
+		close(p.closeCh)
+		p.closeCh = nil
+	}
+
+	err := p.removeTLSFiles()
+	if err != nil {
+		p.logger.Warnf("error removing PostgreSQL TLS files: %s", err)
+	}
+
+	p.closeReadReplicas()
+
 	if p.db != nil {
-		return p.db.Close()
+		p.db.Close()
 	}
 
 	return nil
 }
 
-func (p *postgresDBAccess) ensureStateTable(stateTableName string) error {
-	exists, err := tableExists(p.db, stateTableName)
+func (p *postgresDBAccess) ensureStateTable(ctx context.Context, schema string, stateTableName string) error {
+	exists, err := tableExists(ctx, p.db, schema, stateTableName)
 	if err != nil {
 		return err
 	}
@@ -267,20 +627,39 @@ func (p *postgresDBAccess) ensureStateTable(stateTableName string) error {
 		p.logger.Info("Creating PostgreSQL state table")
 		createTable := fmt.Sprintf(`CREATE TABLE %s (
 									key text NOT NULL PRIMARY KEY,
-									value json NOT NULL,
+									value json NULL,
+									binaryvalue bytea NULL,
+									metadata json NULL,
+									expiredate TIMESTAMP WITH TIME ZONE NULL,
 									insertdate TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-									updatedate TIMESTAMP WITH TIME ZONE NULL);`, stateTableName)
-		_, err = p.db.Exec(createTable)
+									updatedate TIMESTAMP WITH TIME ZONE NULL);`, qualifiedTableName(schema, stateTableName))
+		_, err = p.db.Exec(ctx, createTable)
 		if err != nil {
 			return err
 		}
+
+		return nil
 	}
 
-	return nil
+	// Tables created by earlier versions do not have the columns used for compressed and expiring values
+	alterTable := fmt.Sprintf(`ALTER TABLE %s
+								ADD COLUMN IF NOT EXISTS binaryvalue bytea NULL,
+								ADD COLUMN IF NOT EXISTS metadata json NULL,
+								ADD COLUMN IF NOT EXISTS expiredate TIMESTAMP WITH TIME ZONE NULL,
+								ALTER COLUMN value DROP NOT NULL;`, qualifiedTableName(schema, stateTableName))
+	_, err = p.db.Exec(ctx, alterTable)
+
+	return err
 }
 
-func tableExists(db *sql.DB, tableName string) (bool, error) {
+func tableExists(ctx context.Context, db dbExecutor, schema string, tableName string) (bool, error) {
 	var exists bool = false
-	err := db.QueryRow("SELECT EXISTS (SELECT FROM pg_tables where tablename = $1)", tableName).Scan(&exists)
+	err := db.QueryRow(ctx, "SELECT EXISTS (SELECT FROM pg_tables where schemaname = $1 AND tablename = $2)", schema, tableName).Scan(&exists)
 	return exists, err
 }
+
+// qualifiedTableName returns the quoted schema qualified name of a table.
+// Names are quoted so they are used exactly as configured rather than folded to lower case.
+func qualifiedTableName(schema string, tableName string) string {
+	return fmt.Sprintf(`"%s"."%s"`, schema, tableName)
+}
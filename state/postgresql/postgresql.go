@@ -35,6 +35,9 @@ func newPostgreSQLStateStore(logger logger.Logger, dba dbAccess) *PostgreSQL {
 
 // Init initializes the SQL server state store
 func (p *PostgreSQL) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
 	return p.dbaccess.Init(metadata)
 }
 
@@ -45,7 +48,7 @@ func (p *PostgreSQL) Delete(req *state.DeleteRequest) error {
 
 // BulkDelete removes multiple entries from the store
 func (p *PostgreSQL) BulkDelete(req []state.DeleteRequest) error {
-	return p.dbaccess.ExecuteMulti(nil, req)
+	return p.dbaccess.BulkDelete(req)
 }
 
 // Get returns an entity from store
@@ -53,33 +56,43 @@ func (p *PostgreSQL) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	return p.dbaccess.Get(req)
 }
 
+// ListKeys returns the keys that start with a prefix. Results are paginated using the NextCursor of the response.
+func (p *PostgreSQL) ListKeys(req *ListKeysRequest) (*ListKeysResponse, error) {
+	return p.dbaccess.ListKeys(req)
+}
+
+// Query returns the entities whose JSON values match the filter of the query
+func (p *PostgreSQL) Query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	return p.dbaccess.Query(req)
+}
+
 // Set adds/updates an entity on store
 func (p *PostgreSQL) Set(req *state.SetRequest) error {
 	return p.dbaccess.Set(req)
 }
 
+// SetAndGetETag adds/updates an entity on store and returns its new etag, saving a Get before a conditional update.
+func (p *PostgreSQL) SetAndGetETag(req *state.SetRequest) (string, error) {
+	return p.dbaccess.SetAndGetETag(req)
+}
+
 // BulkSet adds/updates multiple entities on store
 func (p *PostgreSQL) BulkSet(req []state.SetRequest) error {
-	return p.dbaccess.ExecuteMulti(req, nil)
+	return p.dbaccess.BulkSet(req)
 }
 
 // Multi handles multiple transactions. Implements TransactionalStore.
+// Operations are applied in order and atomically, which allows the store to be used as the actor state store.
 func (p *PostgreSQL) Multi(reqs []state.TransactionalRequest) error {
-	var deletes []state.DeleteRequest
-	var sets []state.SetRequest
 	for _, req := range reqs {
 		switch req.Operation {
 		case state.Upsert:
-			if setReq, ok := req.Request.(state.SetRequest); ok {
-				sets = append(sets, setReq)
-			} else {
+			if _, ok := req.Request.(state.SetRequest); !ok {
 				return fmt.Errorf("expecting set request")
 			}
 
 		case state.Delete:
-			if delReq, ok := req.Request.(state.DeleteRequest); ok {
-				deletes = append(deletes, delReq)
-			} else {
+			if _, ok := req.Request.(state.DeleteRequest); !ok {
 				return fmt.Errorf("expecting delete request")
 			}
 
@@ -88,13 +101,18 @@ func (p *PostgreSQL) Multi(reqs []state.TransactionalRequest) error {
 		}
 	}
 
-	if len(sets) > 0 || len(deletes) > 0 {
-		return p.dbaccess.ExecuteMulti(sets, deletes)
+	if len(reqs) > 0 {
+		return p.dbaccess.ExecuteMulti(reqs)
 	}
 
 	return nil
 }
 
+// Ping reports whether the database can be reached and the state table exists, for health checks
+func (p *PostgreSQL) Ping() error {
+	return p.dbaccess.Ping()
+}
+
 // Close implements io.Closer
 func (p *PostgreSQL) Close() error {
 	if p.dbaccess != nil {
@@ -5,15 +5,21 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/conformance"
+	"github.com/dapr/components-contrib/state/query"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,11 +55,31 @@ func TestPostgreSQLIntegration(t *testing.T) {
 		t.Fatal(error)
 	}
 
+	t.Run("Ping succeeds", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, pgs.Ping())
+	})
+
+	t.Run("Ping fails without the state table", func(t *testing.T) {
+		t.Parallel()
+		testPingWithoutStateTable(t)
+	})
+
+	t.Run("Rows older than the maximum row age are pruned", func(t *testing.T) {
+		t.Parallel()
+		testPruneOldData(t)
+	})
+
 	t.Run("Create table succeeds", func(t *testing.T) {
 		t.Parallel()
 		testCreateTable(t, pgs.dbaccess.(*postgresDBAccess))
 	})
 
+	t.Run("Custom table name and schema", func(t *testing.T) {
+		t.Parallel()
+		testCustomTableAndSchema(t)
+	})
+
 	t.Run("Get Set Delete one item", func(t *testing.T) {
 		t.Parallel()
 		setGetUpdateDeleteOneItem(t, pgs)
@@ -84,6 +110,16 @@ func TestPostgreSQLIntegration(t *testing.T) {
 		testBulkSetAndBulkDelete(t, pgs)
 	})
 
+	t.Run("Bulk set and bulk delete large batches", func(t *testing.T) {
+		t.Parallel()
+		testBulkSetAndBulkDeleteLargeBatches(t, pgs)
+	})
+
+	t.Run("Bulk delete with missing key rolls back", func(t *testing.T) {
+		t.Parallel()
+		testBulkDeleteMissingKeyRollsBack(t, pgs)
+	})
+
 	t.Run("Update and delete with etag succeeds", func(t *testing.T) {
 		t.Parallel()
 		updateAndDeleteWithEtagSucceeds(t, pgs)
@@ -128,6 +164,311 @@ func TestPostgreSQLIntegration(t *testing.T) {
 		t.Parallel()
 		multiWithSetOnly(t, pgs)
 	})
+
+	t.Run("Multi with etag mismatch rolls back", func(t *testing.T) {
+		t.Parallel()
+		multiWithETagMismatchRollsBack(t, pgs)
+	})
+
+	t.Run("Multi applies operations in order", func(t *testing.T) {
+		t.Parallel()
+		multiAppliesOperationsInOrder(t, pgs)
+	})
+
+	t.Run("First-write and last-write concurrency", func(t *testing.T) {
+		t.Parallel()
+		testConcurrencyModes(t, pgs)
+	})
+
+	t.Run("Set and get compressed values", func(t *testing.T) {
+		t.Parallel()
+		setGetCompressedValues(t, pgs)
+	})
+
+	t.Run("List keys by prefix", func(t *testing.T) {
+		t.Parallel()
+		listKeysByPrefix(t, pgs)
+	})
+
+	t.Run("Query values", func(t *testing.T) {
+		t.Parallel()
+		queryValues(t, pgs)
+	})
+
+	t.Run("Set and get binary values", func(t *testing.T) {
+		t.Parallel()
+		setGetBinaryValues(t, pgs)
+	})
+
+	t.Run("HTML characters are not escaped", func(t *testing.T) {
+		t.Parallel()
+		setGetUnescapedHTML(t, pgs)
+	})
+
+	t.Run("Set returns the new etag", func(t *testing.T) {
+		t.Parallel()
+		setReturnsNewETag(t, pgs)
+	})
+
+	t.Run("Expired items are not returned and are cleaned up", func(t *testing.T) {
+		t.Parallel()
+		setItemWithTTL(t, pgs)
+	})
+
+	t.Run("TTL conformance", func(t *testing.T) {
+		t.Parallel()
+		conformance.TTL(t, pgs, nil)
+	})
+}
+
+// setItemWithTTL validates that items expire after their TTL and that expired rows are deleted by the cleanup.
+func setItemWithTTL(t *testing.T, pgs *PostgreSQL) {
+	key := randomKey()
+	err := pgs.Set(&state.SetRequest{
+		Key:      key,
+		Value:    randomJSON(),
+		Metadata: map[string]string{ttlInSecondsKey: "1"},
+	})
+	assert.Nil(t, err)
+
+	response, _ := getItem(t, pgs, key)
+	assert.NotNil(t, response.Data)
+
+	time.Sleep(2 * time.Second)
+
+	// The expired row still exists until the cleanup runs, but it is not returned
+	response, _ = getItem(t, pgs, key)
+	assert.Nil(t, response.Data)
+	assert.True(t, storeItemExists(t, key))
+
+	err = pgs.dbaccess.(*postgresDBAccess).cleanupExpiredData()
+	assert.Nil(t, err)
+	assert.False(t, storeItemExists(t, key))
+
+	// Setting an item again without a TTL removes the expiry
+	persistentKey := randomKey()
+	setItem(t, pgs, persistentKey, randomJSON(), "")
+	err = pgs.Set(&state.SetRequest{
+		Key:      persistentKey,
+		Value:    randomJSON(),
+		Metadata: map[string]string{ttlInSecondsKey: "1"},
+	})
+	assert.Nil(t, err)
+	setItem(t, pgs, persistentKey, randomJSON(), "")
+	time.Sleep(2 * time.Second)
+	response, _ = getItem(t, pgs, persistentKey)
+	assert.NotNil(t, response.Data)
+
+	deleteItem(t, pgs, persistentKey, "")
+}
+
+// setReturnsNewETag validates that the etag returned by a set matches a subsequent get and can be used to update.
+func setReturnsNewETag(t *testing.T, pgs *PostgreSQL) {
+	key := randomKey()
+	etag, err := pgs.SetAndGetETag(&state.SetRequest{Key: key, Value: randomJSON()})
+	assert.Nil(t, err)
+
+	getResponse, _ := getItem(t, pgs, key)
+	assert.Equal(t, getResponse.ETag, etag)
+
+	// The returned etag is used for a conditional update without a get
+	newValue := randomJSON()
+	newEtag, err := pgs.SetAndGetETag(&state.SetRequest{Key: key, Value: newValue, ETag: etag})
+	assert.Nil(t, err)
+	assert.NotEqual(t, etag, newEtag)
+
+	getResponse, outputObject := getItem(t, pgs, key)
+	assert.Equal(t, getResponse.ETag, newEtag)
+	assert.Equal(t, newValue, outputObject)
+
+	// The previous etag is no longer valid
+	_, err = pgs.SetAndGetETag(&state.SetRequest{Key: key, Value: randomJSON(), ETag: etag})
+	assert.NotNil(t, err)
+
+	deleteItem(t, pgs, key, newEtag)
+}
+
+// setGetUnescapedHTML validates that <, >, and & round-trip without being escaped.
+func setGetUnescapedHTML(t *testing.T, pgs *PostgreSQL) {
+	key := randomKey()
+	value := &fakeItem{Color: "<b>red</b> & blue"}
+	setItem(t, pgs, key, value, "")
+
+	response, outputObject := getItem(t, pgs, key)
+	assert.Equal(t, `{"Color":"<b>red</b> & blue"}`, string(response.Data))
+	assert.Equal(t, value, outputObject)
+
+	deleteItem(t, pgs, key, "")
+}
+
+// listKeysByPrefix validates listing keys by prefix, escaping of wildcards, and pagination.
+func listKeysByPrefix(t *testing.T, pgs *PostgreSQL) {
+	prefix := randomKey() + "_%||"
+	var keys []string
+	for i := 0; i < 5; i++ {
+		key := fmt.Sprintf("%s%d", prefix, i)
+		keys = append(keys, key)
+		setItem(t, pgs, key, randomJSON(), "")
+	}
+
+	// This key would match if the wildcards in the prefix were not escaped
+	otherKey := strings.Replace(prefix, "_%", "xyz", 1) + "0"
+	setItem(t, pgs, otherKey, randomJSON(), "")
+
+	response, err := pgs.ListKeys(&ListKeysRequest{Prefix: prefix})
+	assert.Nil(t, err)
+	assert.Equal(t, keys, response.Keys)
+	assert.Equal(t, "", response.NextCursor)
+
+	// Page through the keys two at a time
+	var pagedKeys []string
+	cursor := ""
+	for {
+		response, err = pgs.ListKeys(&ListKeysRequest{Prefix: prefix, Cursor: cursor, Limit: 2})
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(response.Keys), 2)
+		pagedKeys = append(pagedKeys, response.Keys...)
+		if response.NextCursor == "" {
+			break
+		}
+		cursor = response.NextCursor
+	}
+	assert.Equal(t, keys, pagedKeys)
+
+	for _, key := range append(keys, otherKey) {
+		deleteItem(t, pgs, key, "")
+	}
+}
+
+// queryValues validates filtering, sorting, and paging over the JSON values.
+func queryValues(t *testing.T, pgs *PostgreSQL) {
+	org := randomKey()
+	people := map[string]map[string]interface{}{
+		randomKey(): {"org": org, "state": "CA", "age": 30},
+		randomKey(): {"org": org, "state": "WA", "age": 25},
+		randomKey(): {"org": org, "state": "NY", "age": 40},
+		randomKey(): {"org": randomKey(), "state": "CA", "age": 35},
+	}
+	for key, person := range people {
+		setItem(t, pgs, key, map[string]interface{}{"person": person}, "")
+	}
+
+	var q query.Query
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{
+		"filter": {"AND": [{"EQ": {"person.org": "%s"}}, {"IN": {"person.state": ["CA", "WA"]}}]},
+		"sort": [{"key": "person.age", "order": "DESC"}]
+	}`, org)), &q)
+	assert.Nil(t, err)
+
+	response, err := pgs.Query(&state.QueryRequest{Query: q})
+	assert.Nil(t, err)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, "", response.Token)
+	ages := make([]float64, 0, len(response.Results))
+	for _, item := range response.Results {
+		var value map[string]map[string]interface{}
+		assert.Nil(t, json.Unmarshal(item.Data, &value))
+		assert.Equal(t, org, value["person"]["org"])
+		assert.NotEqual(t, "", item.ETag)
+		ages = append(ages, value["person"]["age"].(float64))
+	}
+	assert.Equal(t, []float64{30, 25}, ages)
+
+	// Numbers compare as JSON, and pages continue from the token
+	q.Filters = map[string]interface{}{"OR": []interface{}{
+		map[string]interface{}{"EQ": map[string]interface{}{"person.age": 40.0}},
+		map[string]interface{}{"EQ": map[string]interface{}{"person.org": org}},
+	}}
+	q.Page = query.Pagination{Limit: 2}
+	response, err = pgs.Query(&state.QueryRequest{Query: q})
+	assert.Nil(t, err)
+	assert.Len(t, response.Results, 2)
+	assert.NotEqual(t, "", response.Token)
+
+	q.Page.Token = response.Token
+	response, err = pgs.Query(&state.QueryRequest{Query: q})
+	assert.Nil(t, err)
+	assert.Len(t, response.Results, 1)
+
+	for key := range people {
+		deleteItem(t, pgs, key, "")
+	}
+}
+
+// setGetBinaryValues validates that non-JSON values round-trip byte-for-byte.
+func setGetBinaryValues(t *testing.T, pgs *PostgreSQL) {
+	binaryKey := randomKey()
+	binary := []byte{0xff, 0xfe, 0x00, 0x01}
+	err := pgs.Set(&state.SetRequest{Key: binaryKey, Value: binary})
+	assert.Nil(t, err)
+
+	response, err := pgs.Get(&state.GetRequest{Key: binaryKey})
+	assert.Nil(t, err)
+	assert.Equal(t, binary, response.Data)
+	assert.Equal(t, defaultBinaryContentType, response.Metadata[contentTypeKey])
+
+	textKey := randomKey()
+	err = pgs.Set(&state.SetRequest{Key: textKey, Value: "not {json", Metadata: map[string]string{contentTypeKey: "text/plain"}})
+	assert.Nil(t, err)
+
+	response, err = pgs.Get(&state.GetRequest{Key: textKey})
+	assert.Nil(t, err)
+	assert.Equal(t, "not {json", string(response.Data))
+	assert.Equal(t, "text/plain", response.Metadata[contentTypeKey])
+
+	// JSON bytes are stored as JSON, not as a base64 string
+	jsonKey := randomKey()
+	err = pgs.Set(&state.SetRequest{Key: jsonKey, Value: []byte(`{"color":"red"}`)})
+	assert.Nil(t, err)
+
+	returnValue, _, _ := getRowData(t, jsonKey)
+	assert.Equal(t, `{"color":"red"}`, returnValue)
+
+	for _, key := range []string{binaryKey, textKey, jsonKey} {
+		deleteItem(t, pgs, key, "")
+	}
+}
+
+// setGetCompressedValues validates that large values are compressed and coexist with uncompressed rows.
+func setGetCompressedValues(t *testing.T, pgs *PostgreSQL) {
+	compressed := NewPostgreSQLStateStore(logger.NewLogger("test"))
+	defer compressed.Close()
+
+	err := compressed.Init(state.Metadata{
+		Properties: map[string]string{
+			connectionStringKey: getConnectionString(),
+			compressionKey:      compressionGzip,
+		},
+	})
+	assert.Nil(t, err)
+
+	// Large value is compressed into the binaryvalue column
+	largeKey := randomKey()
+	largeValue := &fakeItem{Color: strings.Repeat("indigo", 1000)}
+	setItem(t, compressed, largeKey, largeValue, "")
+
+	serialized, err := json.Marshal(largeValue)
+	assert.Nil(t, err)
+	binaryLength, encoding := getBinaryRowData(t, largeKey)
+	assert.Equal(t, compressionGzip, encoding)
+	assert.Less(t, binaryLength, len(serialized))
+
+	_, outputObject := getItem(t, compressed, largeKey)
+	assert.Equal(t, largeValue, outputObject)
+
+	// Small value is stored as JSON
+	smallKey := randomKey()
+	smallValue := &fakeItem{Color: "ochre"}
+	setItem(t, compressed, smallKey, smallValue, "")
+	_, encoding = getBinaryRowData(t, smallKey)
+	assert.Equal(t, "", encoding)
+
+	// A store without compression enabled reads compressed rows
+	_, outputObject = getItem(t, pgs, largeKey)
+	assert.Equal(t, largeValue, outputObject)
+
+	deleteItem(t, compressed, largeKey, "")
+	deleteItem(t, compressed, smallKey, "")
 }
 
 // setGetUpdateDeleteOneItem validates setting one item, getting it, and deleting it.
@@ -154,25 +495,134 @@ func testCreateTable(t *testing.T, dba *postgresDBAccess) {
 	tableName := "test_state"
 
 	// Drop the table if it already exists
-	exists, err := tableExists(dba.db, tableName)
+	exists, err := tableExists(context.Background(), dba.db, defaultSchema, tableName)
 	assert.Nil(t, err)
 	if exists {
-		dropTable(t, dba.db, tableName)
+		dropTable(t, dba.db, defaultSchema, tableName)
 	}
 
 	// Create the state table and test for its existence
-	err = dba.ensureStateTable(tableName)
+	err = dba.ensureStateTable(context.Background(), defaultSchema, tableName)
 	assert.Nil(t, err)
-	exists, err = tableExists(dba.db, tableName)
+	exists, err = tableExists(context.Background(), dba.db, defaultSchema, tableName)
 	assert.Nil(t, err)
 	assert.True(t, exists)
 
 	// Drop the state table
-	dropTable(t, dba.db, tableName)
+	dropTable(t, dba.db, defaultSchema, tableName)
 }
 
-func dropTable(t *testing.T, db *sql.DB, tableName string) {
-	_, err := db.Exec(fmt.Sprintf("DROP TABLE %s", tableName))
+// testPingWithoutStateTable tests that Ping reports a state table that was dropped after Init.
+func testPingWithoutStateTable(t *testing.T) {
+	tableName := "test_ping_state"
+
+	pgs := NewPostgreSQLStateStore(logger.NewLogger("test"))
+	defer pgs.Close()
+
+	err := pgs.Init(state.Metadata{
+		Properties: map[string]string{
+			connectionStringKey: getConnectionString(),
+			tableNameKey:        tableName,
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, pgs.Ping())
+
+	dropTable(t, pgs.dbaccess.(*postgresDBAccess).db, defaultSchema, tableName)
+	assert.NotNil(t, pgs.Ping())
+}
+
+// testPruneOldData tests that the retention indexes are created and that rows which have not been written
+// for longer than the maximum row age are removed.
+func testPruneOldData(t *testing.T) {
+	tableName := "test_retention_state"
+
+	pgs := NewPostgreSQLStateStore(logger.NewLogger("test"))
+	defer pgs.Close()
+
+	err := pgs.Init(state.Metadata{
+		Properties: map[string]string{
+			connectionStringKey: getConnectionString(),
+			tableNameKey:        tableName,
+			createIndexesKey:    "true",
+			maxRowAgeKey:        "1h",
+		},
+	})
+	assert.Nil(t, err)
+	dba := pgs.dbaccess.(*postgresDBAccess)
+	defer dropTable(t, dba.db, defaultSchema, tableName)
+
+	var indexes int
+	err = dba.db.QueryRow(context.Background(), "SELECT COUNT(*) FROM pg_indexes WHERE schemaname = $1 AND tablename = $2 AND indexname LIKE '%_idx'", defaultSchema, tableName).Scan(&indexes)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, indexes)
+
+	oldKey, newKey := randomKey(), randomKey()
+	assert.Nil(t, pgs.Set(&state.SetRequest{Key: oldKey, Value: randomJSON()}))
+	assert.Nil(t, pgs.Set(&state.SetRequest{Key: newKey, Value: randomJSON()}))
+
+	_, err = dba.db.Exec(context.Background(), fmt.Sprintf("UPDATE %s SET insertdate = NOW() - INTERVAL '2 hours' WHERE key = $1", dba.table), oldKey)
+	assert.Nil(t, err)
+
+	assert.Nil(t, dba.pruneOldData())
+
+	response, err := pgs.Get(&state.GetRequest{Key: oldKey})
+	assert.Nil(t, err)
+	assert.Nil(t, response.Data)
+
+	response, err = pgs.Get(&state.GetRequest{Key: newKey})
+	assert.Nil(t, err)
+	assert.NotNil(t, response.Data)
+}
+
+// testCustomTableAndSchema tests that the state table name and schema can be configured.
+func testCustomTableAndSchema(t *testing.T) {
+	schema := "test_dapr_schema"
+	tableName := "Test_Custom_State"
+
+	db := connect(t)
+	defer db.Close(context.Background())
+
+	_, err := db.Exec(context.Background(), fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", schema))
+	assert.Nil(t, err)
+	defer db.Exec(context.Background(), fmt.Sprintf("DROP SCHEMA %s CASCADE", schema))
+
+	pgs := NewPostgreSQLStateStore(logger.NewLogger("test"))
+	defer pgs.Close()
+
+	err = pgs.Init(state.Metadata{
+		Properties: map[string]string{
+			connectionStringKey: getConnectionString(),
+			tableNameKey:        tableName,
+			schemaKey:           schema,
+		},
+	})
+	assert.Nil(t, err)
+
+	exists, err := tableExists(context.Background(), db, schema, tableName)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	key := randomKey()
+	value := randomJSON()
+	err = pgs.Set(&state.SetRequest{Key: key, Value: value})
+	assert.Nil(t, err)
+
+	var count int
+	err = db.QueryRow(context.Background(), fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE key = $1", qualifiedTableName(schema, tableName)), key).Scan(&count)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	assert.False(t, storeItemExists(t, key))
+
+	_, outputObject := getItem(t, pgs, key)
+	assert.Equal(t, value, outputObject)
+
+	err = pgs.Delete(&state.DeleteRequest{Key: key})
+	assert.Nil(t, err)
+}
+
+func dropTable(t *testing.T, db dbExecutor, schema string, tableName string) {
+	_, err := db.Exec(context.Background(), fmt.Sprintf("DROP TABLE %s", qualifiedTableName(schema, tableName)))
 	assert.Nil(t, err)
 }
 
@@ -452,6 +902,120 @@ func testBulkSetAndBulkDelete(t *testing.T, pgs *PostgreSQL) {
 	assert.False(t, storeItemExists(t, setReq[1].Key))
 }
 
+// Tests bulk operations spanning several multi-row statements, with duplicate keys and etags
+func testBulkSetAndBulkDeleteLargeBatches(t *testing.T, pgs *PostgreSQL) {
+	existingKey := randomKey()
+	setItem(t, pgs, existingKey, randomJSON(), "")
+	existing, _ := getItem(t, pgs, existingKey)
+
+	var setReq []state.SetRequest
+	for i := 0; i < bulkSetBatchSize+500; i++ {
+		setReq = append(setReq, state.SetRequest{Key: randomKey(), Value: randomJSON()})
+	}
+
+	// The last value for a duplicate key wins
+	duplicateKey := setReq[0].Key
+	lastValue := &fakeItem{Color: "last"}
+	setReq = append(setReq, state.SetRequest{Key: duplicateKey, Value: lastValue})
+
+	// Conditional updates are applied in the same transaction
+	updatedValue := &fakeItem{Color: "updated"}
+	setReq = append(setReq, state.SetRequest{Key: existingKey, Value: updatedValue, ETag: existing.ETag})
+
+	err := pgs.BulkSet(setReq)
+	assert.Nil(t, err)
+
+	_, outputObject := getItem(t, pgs, duplicateKey)
+	assert.Equal(t, lastValue, outputObject)
+	_, outputObject = getItem(t, pgs, existingKey)
+	assert.Equal(t, updatedValue, outputObject)
+
+	deleteReq := make([]state.DeleteRequest, 0, len(setReq))
+	for _, req := range setReq {
+		deleteReq = append(deleteReq, state.DeleteRequest{Key: req.Key})
+	}
+
+	err = pgs.BulkDelete(deleteReq)
+	assert.Nil(t, err)
+	for _, req := range setReq {
+		assert.False(t, storeItemExists(t, req.Key))
+	}
+}
+
+// Tests that a bulk delete fails as a whole when one of its keys does not exist
+// multiWithETagMismatchRollsBack validates that no operation of a multi request is applied when one of them fails.
+func multiWithETagMismatchRollsBack(t *testing.T, pgs *PostgreSQL) {
+	deleteKey := randomKey()
+	setItem(t, pgs, deleteKey, randomJSON(), "")
+
+	updateKey := randomKey()
+	originalValue := randomJSON()
+	setItem(t, pgs, updateKey, originalValue, "")
+	getResponse, _ := getItem(t, pgs, updateKey)
+	staleEtag := getResponse.ETag
+	setItem(t, pgs, updateKey, originalValue, getResponse.ETag)
+
+	newKey := randomKey()
+	err := pgs.Multi([]state.TransactionalRequest{
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: deleteKey}},
+		{Operation: state.Upsert, Request: state.SetRequest{Key: newKey, Value: randomJSON()}},
+		{Operation: state.Upsert, Request: state.SetRequest{Key: updateKey, Value: randomJSON(), ETag: staleEtag}},
+	})
+	assert.NotNil(t, err)
+
+	assert.True(t, storeItemExists(t, deleteKey))
+	assert.False(t, storeItemExists(t, newKey))
+	_, updatedItem := getItem(t, pgs, updateKey)
+	assert.Equal(t, originalValue, updatedItem)
+
+	deleteItem(t, pgs, deleteKey, "")
+	deleteItem(t, pgs, updateKey, "")
+}
+
+// multiAppliesOperationsInOrder validates that a delete after a set of the same key removes it, as actors rely on.
+func multiAppliesOperationsInOrder(t *testing.T, pgs *PostgreSQL) {
+	key := "myapp||actortype||actorid||" + randomKey()
+	err := pgs.Multi([]state.TransactionalRequest{
+		{Operation: state.Upsert, Request: state.SetRequest{Key: key, Value: randomJSON()}},
+		{Operation: state.Delete, Request: state.DeleteRequest{Key: key}},
+	})
+	assert.Nil(t, err)
+	assert.False(t, storeItemExists(t, key))
+}
+
+func testConcurrencyModes(t *testing.T, pgs *PostgreSQL) {
+	key := randomKey()
+	firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+
+	// First-write without an etag creates the key only once
+	err := pgs.Set(&state.SetRequest{Key: key, Value: randomJSON(), Options: firstWrite})
+	assert.Nil(t, err)
+	err = pgs.Set(&state.SetRequest{Key: key, Value: randomJSON(), Options: firstWrite})
+	assert.NotNil(t, err)
+
+	// Last-write ignores a stale etag
+	getResponse, _ := getItem(t, pgs, key)
+	staleEtag := getResponse.ETag
+	setItem(t, pgs, key, randomJSON(), "")
+	err = pgs.Set(&state.SetRequest{Key: key, Value: randomJSON(), ETag: staleEtag, Options: state.SetStateOption{Concurrency: state.LastWrite}})
+	assert.Nil(t, err)
+
+	err = pgs.Delete(&state.DeleteRequest{Key: key, ETag: staleEtag, Options: state.DeleteStateOption{Concurrency: state.LastWrite}})
+	assert.Nil(t, err)
+	assert.False(t, storeItemExists(t, key))
+}
+
+func testBulkDeleteMissingKeyRollsBack(t *testing.T, pgs *PostgreSQL) {
+	key := randomKey()
+	setItem(t, pgs, key, randomJSON(), "")
+
+	err := pgs.BulkDelete([]state.DeleteRequest{{Key: key}, {Key: randomKey()}})
+	assert.NotNil(t, err)
+	assert.True(t, storeItemExists(t, key))
+
+	deleteItem(t, pgs, key, "")
+}
+
 // testInitConfiguration tests valid and invalid config settings
 func testInitConfiguration(t *testing.T) {
 	logger := logger.NewLogger("test")
@@ -465,6 +1029,26 @@ func testInitConfiguration(t *testing.T) {
 			props:       map[string]string{},
 			expectedErr: errMissingConnectionString,
 		},
+		{
+			name:        "Invalid compression",
+			props:       map[string]string{connectionStringKey: getConnectionString(), compressionKey: "lz4"},
+			expectedErr: "unsupported compression 'lz4', supported values are: gzip",
+		},
+		{
+			name:        "Invalid list keys max limit",
+			props:       map[string]string{connectionStringKey: getConnectionString(), listKeysMaxLimitKey: "0"},
+			expectedErr: "invalid listKeysMaxLimit value '0', must be a positive integer",
+		},
+		{
+			name:        "Invalid table name",
+			props:       map[string]string{connectionStringKey: getConnectionString(), tableNameKey: "state; DROP TABLE state"},
+			expectedErr: "invalid table name 'state; DROP TABLE state', accepted characters are (A-Z, a-z, 0-9, _)",
+		},
+		{
+			name:        "Invalid schema name",
+			props:       map[string]string{connectionStringKey: getConnectionString(), schemaKey: "public.state"},
+			expectedErr: "invalid schema name 'public.state', accepted characters are (A-Z, a-z, 0-9, _)",
+		},
 		{
 			name:        "Valid connection string",
 			props:       map[string]string{connectionStringKey: getConnectionString()},
@@ -535,28 +1119,96 @@ func deleteItem(t *testing.T, pgs *PostgreSQL, key string, etag string) {
 	assert.False(t, storeItemExists(t, key))
 }
 
+// BenchmarkSetAndGet measures the round trips of the store, e.g. to compare driver settings:
+// go test -run none -bench SetAndGet ./state/postgresql
+func BenchmarkSetAndGet(b *testing.B) {
+	connectionString := getConnectionString()
+	if connectionString == "" {
+		b.Skipf("PostgreSQL state benchmarks skipped. To enable define the connection string using environment variable '%s'", connectionStringEnvKey)
+	}
+
+	pgs := NewPostgreSQLStateStore(logger.NewLogger("test"))
+	defer pgs.Close()
+
+	err := pgs.Init(state.Metadata{
+		Properties: map[string]string{connectionStringKey: connectionString},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	key := randomKey()
+	value := randomJSON()
+	defer pgs.Delete(&state.DeleteRequest{Key: key})
+
+	b.Run("Set", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			err := pgs.Set(&state.SetRequest{Key: key, Value: value})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := pgs.Get(&state.GetRequest{Key: key})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Parallel get", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, err := pgs.Get(&state.GetRequest{Key: key})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+}
+
 func storeItemExists(t *testing.T, key string) bool {
-	db, err := sql.Open("pgx", getConnectionString())
-	assert.Nil(t, err)
-	defer db.Close()
+	db := connect(t)
+	defer db.Close(context.Background())
 
 	var exists bool = false
-	statement := fmt.Sprintf(`SELECT EXISTS (SELECT FROM %s WHERE key = $1)`, tableName)
-	err = db.QueryRow(statement, key).Scan(&exists)
+	statement := fmt.Sprintf(`SELECT EXISTS (SELECT FROM %s WHERE key = $1)`, qualifiedTableName(defaultSchema, defaultTableName))
+	err := db.QueryRow(context.Background(), statement, key).Scan(&exists)
 	assert.Nil(t, err)
 	return exists
 }
 
 func getRowData(t *testing.T, key string) (returnValue string, insertdate sql.NullString, updatedate sql.NullString) {
-	db, err := sql.Open("pgx", getConnectionString())
-	assert.Nil(t, err)
-	defer db.Close()
+	db := connect(t)
+	defer db.Close(context.Background())
 
-	err = db.QueryRow(fmt.Sprintf("SELECT value, insertdate, updatedate FROM %s WHERE key = $1", tableName), key).Scan(&returnValue, &insertdate, &updatedate)
+	err := db.QueryRow(context.Background(), fmt.Sprintf("SELECT value, insertdate, updatedate FROM %s WHERE key = $1", qualifiedTableName(defaultSchema, defaultTableName)), key).Scan(&returnValue, &insertdate, &updatedate)
 	assert.Nil(t, err)
 	return returnValue, insertdate, updatedate
 }
 
+func getBinaryRowData(t *testing.T, key string) (binaryLength int, encoding string) {
+	db := connect(t)
+	defer db.Close(context.Background())
+
+	var length sql.NullInt64
+	var metadata sql.NullString
+	err := db.QueryRow(context.Background(), fmt.Sprintf("SELECT octet_length(binaryvalue), metadata->>'encoding' FROM %s WHERE key = $1", qualifiedTableName(defaultSchema, defaultTableName)), key).Scan(&length, &metadata)
+	assert.Nil(t, err)
+	return int(length.Int64), metadata.String
+}
+
+// connect opens a connection for checking the contents of the database directly.
+func connect(t *testing.T) *pgx.Conn {
+	db, err := pgx.Connect(context.Background(), getConnectionString())
+	assert.Nil(t, err)
+	return db
+}
+
 func randomKey() string {
 	return uuid.New().String()
 }
@@ -5,6 +5,8 @@
 package postgresql
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/dapr/components-contrib/state"
@@ -22,6 +24,7 @@ type fakeDBaccess struct {
 	initExecuted bool
 	setExecuted  bool
 	getExecuted  bool
+	pingErr      error
 }
 
 func (m *fakeDBaccess) Init(metadata state.Metadata) error {
@@ -34,6 +37,11 @@ func (m *fakeDBaccess) Set(req *state.SetRequest) error {
 	return nil
 }
 
+func (m *fakeDBaccess) SetAndGetETag(req *state.SetRequest) (string, error) {
+	m.setExecuted = true
+	return "", nil
+}
+
 func (m *fakeDBaccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	m.getExecuted = true
 	return nil, nil
@@ -43,10 +51,30 @@ func (m *fakeDBaccess) Delete(req *state.DeleteRequest) error {
 	return nil
 }
 
-func (m *fakeDBaccess) ExecuteMulti(sets []state.SetRequest, deletes []state.DeleteRequest) error {
+func (m *fakeDBaccess) BulkSet(req []state.SetRequest) error {
 	return nil
 }
 
+func (m *fakeDBaccess) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (m *fakeDBaccess) ListKeys(req *ListKeysRequest) (*ListKeysResponse, error) {
+	return &ListKeysResponse{}, nil
+}
+
+func (m *fakeDBaccess) Query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	return &state.QueryResponse{}, nil
+}
+
+func (m *fakeDBaccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	return nil
+}
+
+func (m *fakeDBaccess) Ping() error {
+	return m.pingErr
+}
+
 func (m *fakeDBaccess) Close() error {
 	return nil
 }
@@ -58,6 +86,21 @@ func TestInitRunsDBAccessInit(t *testing.T) {
 	assert.True(t, fake.initExecuted)
 }
 
+func TestPingReturnsDBAccessResult(t *testing.T) {
+	t.Parallel()
+	pgs, fake := createPostgreSQLWithFake(t)
+	assert.Nil(t, pgs.Ping())
+
+	fake.pingErr = errors.New("connection refused")
+	assert.Equal(t, fake.pingErr, pgs.Ping())
+}
+
+func TestPingBeforeInitFails(t *testing.T) {
+	t.Parallel()
+	dba := newPostgresDBAccess(logger.NewLogger("test"))
+	assert.NotNil(t, dba.Ping())
+}
+
 func TestMultiWithNoRequestsReturnsNil(t *testing.T) {
 	t.Parallel()
 	var multiRequest []state.TransactionalRequest
@@ -136,6 +179,40 @@ func TestInvalidMultiDeleteRequest(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestEscapeLikePattern(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "orders||", escapeLikePattern("orders||"))
+	assert.Equal(t, `100\%\_off`, escapeLikePattern("100%_off"))
+	assert.Equal(t, `a\\b`, escapeLikePattern(`a\b`))
+}
+
+func TestResolveListKeysLimit(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, defaultListKeysLimit, resolveListKeysLimit(0, defaultListKeysMaxLimit))
+	assert.Equal(t, 10, resolveListKeysLimit(10, defaultListKeysMaxLimit))
+	assert.Equal(t, 50, resolveListKeysLimit(1000000, 50))
+}
+
+func TestMarshalValue(t *testing.T) {
+	t.Parallel()
+	value := &struct {
+		URL string `json:"url"`
+	}{URL: "https://example.com/?a=<b>&c=d"}
+
+	t.Run("HTML characters are not escaped by default", func(t *testing.T) {
+		valueBytes, err := marshalValue(value, false)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"url":"https://example.com/?a=<b>&c=d"}`, string(valueBytes))
+		assert.True(t, json.Valid(valueBytes))
+	})
+
+	t.Run("HTML characters are escaped when enabled", func(t *testing.T) {
+		valueBytes, err := marshalValue(value, true)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"url":"https://example.com/?a=\u003cb\u003e\u0026c=d"}`, string(valueBytes))
+	})
+}
+
 func createSetRequest() state.SetRequest {
 	return state.SetRequest{
 		Key:   randomKey(),
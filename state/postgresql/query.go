@@ -0,0 +1,229 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/query"
+	"github.com/jackc/pgtype"
+)
+
+const (
+	queryOperation = "query"
+)
+
+// Query returns the state values that match the query. Compressed values are not queryable.
+func (p *postgresDBAccess) Query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	start := time.Now()
+	response, err := p.query(req)
+	p.recordOperation(queryOperation, start, err)
+	return response, err
+}
+
+func (p *postgresDBAccess) query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	p.logger.Debug("Querying state values from PostgreSQL")
+
+	ctx, cancel := p.operationContext()
+	defer cancel()
+
+	builder := &queryBuilder{table: p.table}
+	statement, offset, err := builder.build(&req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := p.readDB("").Query(ctx, statement, builder.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	response := &state.QueryResponse{
+		Results: []state.QueryItem{},
+	}
+	for rows.Next() {
+		var key, value string
+		var etag uint32
+		err = rows.Scan(&key, &value, &etag)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, state.QueryItem{
+			Key:  key,
+			Data: []byte(value),
+			ETag: formatETag(etag),
+		})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page means there may be more results
+	if req.Query.Page.Limit > 0 && len(response.Results) == req.Query.Page.Limit {
+		response.Token = strconv.Itoa(offset + req.Query.Page.Limit)
+	}
+
+	return response, nil
+}
+
+// queryBuilder translates a query into a SQL statement and its parameters.
+// It implements query.Visitor.
+type queryBuilder struct {
+	table     string
+	args      []interface{}
+	statement string
+	offset    int
+}
+
+// build returns the statement for q and the offset it starts at.
+func (b *queryBuilder) build(q *query.Query) (string, int, error) {
+	err := query.NewQueryBuilder(b).BuildQuery(q)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return b.statement, b.offset, nil
+}
+
+// VisitEQ implements query.Visitor.
+func (b *queryBuilder) VisitEQ(f *query.EQ) (string, error) {
+	path, err := b.path(f.Key)
+	if err != nil {
+		return "", err
+	}
+
+	jsonValue, err := b.jsonArg(f.Val)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s = %s", path, jsonValue), nil
+}
+
+// VisitIN implements query.Visitor.
+func (b *queryBuilder) VisitIN(f *query.IN) (string, error) {
+	path, err := b.path(f.Key)
+	if err != nil {
+		return "", err
+	}
+
+	jsonValues := make([]string, 0, len(f.Vals))
+	for _, v := range f.Vals {
+		jsonValue, err := b.jsonArg(v)
+		if err != nil {
+			return "", err
+		}
+		jsonValues = append(jsonValues, jsonValue)
+	}
+
+	return fmt.Sprintf("%s IN (%s)", path, strings.Join(jsonValues, ", ")), nil
+}
+
+// VisitAND implements query.Visitor.
+func (b *queryBuilder) VisitAND(f *query.AND, conditions []string) (string, error) {
+	return "(" + strings.Join(conditions, " AND ") + ")", nil
+}
+
+// VisitOR implements query.Visitor.
+func (b *queryBuilder) VisitOR(f *query.OR, conditions []string) (string, error) {
+	return "(" + strings.Join(conditions, " OR ") + ")", nil
+}
+
+// Finalize implements query.Visitor.
+func (b *queryBuilder) Finalize(filter string, q *query.Query) error {
+	where := []string{"value IS NOT NULL", notExpiredCondition}
+	if filter != "" {
+		where = append(where, filter)
+	}
+
+	orderBy := make([]string, 0, len(q.Sort)+1)
+	for _, sorting := range q.Sort {
+		path, err := b.path(sorting.Key)
+		if err != nil {
+			return err
+		}
+		order, err := sortOrder(sorting.Order)
+		if err != nil {
+			return err
+		}
+		orderBy = append(orderBy, path+" "+order)
+	}
+	// Order by key last so that results are stable between pages
+	orderBy = append(orderBy, "key")
+
+	statement := fmt.Sprintf("SELECT key, value, xmin as etag FROM %s WHERE %s ORDER BY %s",
+		b.table, strings.Join(where, " AND "), strings.Join(orderBy, ", "))
+
+	if q.Page.Limit > 0 {
+		statement += " LIMIT " + b.arg(q.Page.Limit)
+	}
+
+	offset := 0
+	if q.Page.Token != "" {
+		var err error
+		offset, err = strconv.Atoi(q.Page.Token)
+		if err != nil || offset < 0 {
+			return fmt.Errorf("invalid page token '%s'", q.Page.Token)
+		}
+		statement += " OFFSET " + b.arg(offset)
+	}
+
+	b.statement = statement
+	b.offset = offset
+	return nil
+}
+
+// sortOrder returns the SQL sort order of a query sort order, which is ASC when it is empty. The orders are mapped
+// to fixed keywords, as they are written to the statement.
+func sortOrder(order string) (string, error) {
+	switch strings.ToUpper(order) {
+	case "", query.SortASC:
+		return "ASC", nil
+	case query.SortDESC:
+		return "DESC", nil
+	default:
+		return "", fmt.Errorf("invalid sort order '%s', supported values are: %s, %s", order, query.SortASC, query.SortDESC)
+	}
+}
+
+// path returns the expression for the JSON value at a dot separated key.
+func (b *queryBuilder) path(key string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("query key cannot be empty")
+	}
+
+	var path pgtype.TextArray
+	err := path.Set(strings.Split(key, "."))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("(value::jsonb #> %s::text[])", b.arg(path)), nil
+}
+
+// jsonArg adds v as a jsonb parameter, so that values compare as JSON regardless of their type.
+func (b *queryBuilder) jsonArg(v interface{}) (string, error) {
+	jsonValue, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return b.arg(string(jsonValue)) + "::jsonb", nil
+}
+
+// arg adds a parameter and returns its placeholder.
+func (b *queryBuilder) arg(v interface{}) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
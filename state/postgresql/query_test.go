@@ -0,0 +1,89 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/state/query"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseQuery(t *testing.T, s string) *query.Query {
+	var q query.Query
+	err := json.Unmarshal([]byte(s), &q)
+	assert.Nil(t, err)
+	return &q
+}
+
+func textArray(t *testing.T, elements ...string) pgtype.TextArray {
+	var array pgtype.TextArray
+	assert.Nil(t, array.Set(elements))
+	return array
+}
+
+func TestBuildQuery(t *testing.T) {
+	t.Run("Empty query returns all values ordered by key", func(t *testing.T) {
+		b := &queryBuilder{table: "state"}
+		statement, offset, err := b.build(&query.Query{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, offset)
+		assert.Equal(t, "SELECT key, value, xmin as etag FROM state WHERE value IS NOT NULL AND "+notExpiredCondition+" ORDER BY key", statement)
+		assert.Empty(t, b.args)
+	})
+
+	t.Run("Filter, sort, and page are translated", func(t *testing.T) {
+		b := &queryBuilder{table: "state"}
+		statement, offset, err := b.build(parseQuery(t, `{
+			"filter": {"AND": [{"EQ": {"person.org": "Dev Ops"}}, {"OR": [{"IN": {"state": ["CA", "WA"]}}, {"EQ": {"age": 30}}]}]},
+			"sort": [{"key": "person.id", "order": "desc"}],
+			"page": {"limit": 10, "token": "20"}
+		}`))
+		assert.Nil(t, err)
+		assert.Equal(t, 20, offset)
+		assert.Equal(t, "SELECT key, value, xmin as etag FROM state WHERE value IS NOT NULL AND "+notExpiredCondition+
+			" AND ((value::jsonb #> $1::text[]) = $2::jsonb AND ((value::jsonb #> $3::text[]) IN ($4::jsonb, $5::jsonb) OR (value::jsonb #> $6::text[]) = $7::jsonb))"+
+			" ORDER BY (value::jsonb #> $8::text[]) DESC, key LIMIT $9 OFFSET $10", statement)
+		assert.Equal(t, []interface{}{
+			textArray(t, "person", "org"), `"Dev Ops"`,
+			textArray(t, "state"), `"CA"`, `"WA"`,
+			textArray(t, "age"), `30`,
+			textArray(t, "person", "id"),
+			10, 20,
+		}, b.args)
+	})
+
+	t.Run("Filters set without unmarshalling are parsed", func(t *testing.T) {
+		b := &queryBuilder{table: "state"}
+		statement, _, err := b.build(&query.Query{Filters: map[string]interface{}{
+			"EQ": map[string]interface{}{"color": "red"},
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "SELECT key, value, xmin as etag FROM state WHERE value IS NOT NULL AND "+notExpiredCondition+
+			" AND (value::jsonb #> $1::text[]) = $2::jsonb ORDER BY key", statement)
+	})
+
+	t.Run("Invalid sort order without filters fails", func(t *testing.T) {
+		b := &queryBuilder{table: "state"}
+		_, _, err := b.build(&query.Query{Sort: []query.Sorting{{Key: "a", Order: "ASC; DROP TABLE state"}}})
+		assert.NotNil(t, err)
+		assert.Empty(t, b.statement)
+	})
+
+	invalid := map[string]string{
+		"Non-numeric page token": `{"page": {"token": "abc"}}`,
+		"Negative page token":    `{"page": {"token": "-10"}}`,
+	}
+	for name, q := range invalid {
+		name, q := name, q
+		t.Run(name+" fails", func(t *testing.T) {
+			b := &queryBuilder{table: "state"}
+			_, _, err := b.build(parseQuery(t, q))
+			assert.NotNil(t, err)
+		})
+	}
+}
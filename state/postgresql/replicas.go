@@ -0,0 +1,87 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dapr/components-contrib/state"
+)
+
+// readConnectionStringKey holds the connection string of a read replica, or a JSON array of them
+const readConnectionStringKey = "readConnectionString"
+
+// parseReadConnectionStrings returns the connection strings of the read replicas.
+func parseReadConnectionStrings(properties map[string]string) ([]string, error) {
+	val := strings.TrimSpace(properties[readConnectionStringKey])
+	if val == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(val, "[") {
+		return []string{val}, nil
+	}
+
+	var connectionStrings []string
+	err := json.Unmarshal([]byte(val), &connectionStrings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s, must be a connection string or a JSON array of connection strings: %s", readConnectionStringKey, err)
+	}
+
+	for _, connectionString := range connectionStrings {
+		if connectionString == "" {
+			return nil, fmt.Errorf("invalid %s, connection strings cannot be empty", readConnectionStringKey)
+		}
+	}
+
+	return connectionStrings, nil
+}
+
+// openReadReplicas connects to the read replicas, which use the same authentication, TLS, and pool settings as the primary.
+func (p *postgresDBAccess) openReadReplicas(ctx context.Context, connectionStrings []string, properties map[string]string, pool poolConfig) error {
+	for _, connectionString := range connectionStrings {
+		connectionString, err := p.withTLSParams(connectionString)
+		if err != nil {
+			return err
+		}
+
+		db, err := p.openPool(ctx, connectionString, properties, pool)
+		if err != nil {
+			return fmt.Errorf("failed to connect to read replica: %s", err)
+		}
+
+		p.readDBs = append(p.readDBs, db)
+	}
+
+	if len(p.readDBs) > 0 {
+		p.logger.Infof("Routing PostgreSQL reads to %d read replicas", len(p.readDBs))
+	}
+
+	return nil
+}
+
+// readDB returns the database to read from. Reads are spread across the read replicas unless
+// strong consistency is requested or no replicas are configured, in which case the primary is used.
+func (p *postgresDBAccess) readDB(consistency string) *connPool {
+	if len(p.readDBs) == 0 || consistency == state.Strong {
+		return p.db
+	}
+
+	next := atomic.AddUint32(&p.readIndex, 1)
+	return p.readDBs[int(next)%len(p.readDBs)]
+}
+
+// closeReadReplicas closes the read replicas.
+func (p *postgresDBAccess) closeReadReplicas() {
+	for _, db := range p.readDBs {
+		db.Close()
+	}
+	p.readDBs = nil
+}
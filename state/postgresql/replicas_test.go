@@ -0,0 +1,59 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReadConnectionStrings(t *testing.T) {
+	connectionStrings, err := parseReadConnectionStrings(map[string]string{})
+	assert.Nil(t, err)
+	assert.Empty(t, connectionStrings)
+
+	connectionStrings, err = parseReadConnectionStrings(map[string]string{readConnectionStringKey: "host=replica1"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"host=replica1"}, connectionStrings)
+
+	connectionStrings, err = parseReadConnectionStrings(map[string]string{readConnectionStringKey: `["host=replica1", "postgres://replica2/db"]`})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"host=replica1", "postgres://replica2/db"}, connectionStrings)
+
+	_, err = parseReadConnectionStrings(map[string]string{readConnectionStringKey: `["host=replica1",`})
+	assert.NotNil(t, err)
+
+	_, err = parseReadConnectionStrings(map[string]string{readConnectionStringKey: `[""]`})
+	assert.NotNil(t, err)
+}
+
+func TestReadDB(t *testing.T) {
+	// Routing only compares pools, so they do not need to be connected
+	p := newPostgresDBAccess(logger.NewLogger("test"))
+	p.db = &connPool{}
+
+	t.Run("Primary is used without replicas", func(t *testing.T) {
+		assert.Same(t, p.db, p.readDB(""))
+	})
+
+	replica1, replica2 := &connPool{}, &connPool{}
+	p.readDBs = []*connPool{replica1, replica2}
+
+	t.Run("Reads are spread across replicas", func(t *testing.T) {
+		first := p.readDB("")
+		second := p.readDB(state.Eventual)
+		assert.NotSame(t, first, second)
+		assert.Contains(t, p.readDBs, first)
+		assert.Contains(t, p.readDBs, second)
+		assert.Same(t, first, p.readDB(""))
+	})
+
+	t.Run("Strong consistency reads from the primary", func(t *testing.T) {
+		assert.Same(t, p.db, p.readDB(state.Strong))
+	})
+}
@@ -0,0 +1,82 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/metadata"
+)
+
+const (
+	createIndexesKey = "createIndexes"
+	maxRowAgeKey     = "maxRowAge"
+
+	// lastModifiedExpression is the time a row was last written, since updatedate is only set by updates
+	lastModifiedExpression = "COALESCE(updatedate, insertdate)"
+)
+
+// parseRetention reads whether to index the update and expiry times of rows, and the age after which rows are pruned.
+// Old rows are pruned by the cleanup of expired rows, so a maximum row age requires a cleanup interval.
+func parseRetention(properties map[string]string, cleanupInterval time.Duration) (bool, time.Duration, error) {
+	var m struct {
+		CreateIndexes bool           `metadata:"createIndexes"`
+		MaxRowAge     *time.Duration `metadata:"maxRowAge"`
+	}
+	if err := metadata.Decode(properties, &m); err != nil {
+		return false, 0, err
+	}
+
+	maxRowAge, err := positiveDuration(maxRowAgeKey, m.MaxRowAge)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if maxRowAge > 0 && cleanupInterval <= 0 {
+		return false, 0, fmt.Errorf("%s requires a positive %s", maxRowAgeKey, cleanupIntervalKey)
+	}
+
+	return m.CreateIndexes, maxRowAge, nil
+}
+
+// ensureIndexes creates the indexes used to find expired and old rows, if they do not exist yet.
+func (p *postgresDBAccess) ensureIndexes(ctx context.Context) error {
+	indexes := []struct {
+		name       string
+		expression string
+	}{
+		{p.tableName + "_updatedate_idx", lastModifiedExpression},
+		{p.tableName + "_expiredate_idx", "expiredate"},
+	}
+
+	for _, index := range indexes {
+		_, err := p.db.Exec(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS "%s" ON %s ((%s))`, index.name, p.table, index.expression))
+		if err != nil {
+			return fmt.Errorf("failed to create index %s: %s", index.name, err)
+		}
+	}
+
+	return nil
+}
+
+// pruneOldData deletes the rows that have not been written for longer than the maximum row age.
+func (p *postgresDBAccess) pruneOldData() error {
+	ctx, cancel := p.operationContext()
+	defer cancel()
+
+	result, err := p.db.Exec(ctx, fmt.Sprintf(
+		`DELETE FROM %s WHERE %s < NOW() - $1::double precision * INTERVAL '1 second'`, p.table, lastModifiedExpression),
+		p.maxRowAge.Seconds())
+	if err != nil {
+		return err
+	}
+
+	p.logger.Debugf("Removed %d PostgreSQL state rows older than %s", result.RowsAffected(), p.maxRowAge)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetention(t *testing.T) {
+	t.Run("Disabled by default", func(t *testing.T) {
+		createIndexes, maxRowAge, err := parseRetention(map[string]string{}, defaultCleanupInterval)
+		assert.Nil(t, err)
+		assert.False(t, createIndexes)
+		assert.Equal(t, time.Duration(0), maxRowAge)
+	})
+
+	t.Run("Indexes and maximum row age", func(t *testing.T) {
+		createIndexes, maxRowAge, err := parseRetention(map[string]string{createIndexesKey: "true", maxRowAgeKey: "24h"}, defaultCleanupInterval)
+		assert.Nil(t, err)
+		assert.True(t, createIndexes)
+		assert.Equal(t, 24*time.Hour, maxRowAge)
+	})
+
+	t.Run("Maximum row age requires the cleanup", func(t *testing.T) {
+		_, _, err := parseRetention(map[string]string{maxRowAgeKey: "24h"}, 0)
+		assert.NotNil(t, err)
+	})
+
+	invalid := map[string]string{
+		createIndexesKey: "yes please",
+		maxRowAgeKey:     "-1h",
+	}
+	for key, val := range invalid {
+		key, val := key, val
+		t.Run("Invalid "+key, func(t *testing.T) {
+			_, _, err := parseRetention(map[string]string{key: val}, defaultCleanupInterval)
+			assert.NotNil(t, err)
+		})
+	}
+}
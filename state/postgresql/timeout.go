@@ -0,0 +1,40 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/metadata"
+)
+
+const (
+	timeoutKey = "timeout"
+
+	defaultTimeout = 20 * time.Second
+)
+
+// parseTimeout returns how long a single operation, including all of its statements, may take.
+func parseTimeout(properties map[string]string) (time.Duration, error) {
+	var m struct {
+		Timeout *time.Duration `metadata:"timeout"`
+	}
+	if err := metadata.Decode(properties, &m); err != nil {
+		return 0, err
+	}
+
+	if m.Timeout == nil {
+		return defaultTimeout, nil
+	}
+
+	return positiveDuration(timeoutKey, m.Timeout)
+}
+
+// operationContext returns the context for a single operation, which is canceled once the timeout elapses.
+func (p *postgresDBAccess) operationContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), p.timeout)
+}
@@ -0,0 +1,42 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeout(t *testing.T) {
+	timeout, err := parseTimeout(map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, defaultTimeout, timeout)
+
+	timeout, err = parseTimeout(map[string]string{timeoutKey: "5s"})
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	_, err = parseTimeout(map[string]string{timeoutKey: "0s"})
+	assert.NotNil(t, err)
+
+	_, err = parseTimeout(map[string]string{timeoutKey: "soon"})
+	assert.NotNil(t, err)
+}
+
+func TestOperationContext(t *testing.T) {
+	p := newPostgresDBAccess(logger.NewLogger("test"))
+	p.timeout = time.Minute
+
+	ctx, cancel := p.operationContext()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+	cancel()
+	assert.NotNil(t, ctx.Err())
+}
@@ -0,0 +1,135 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sslModeKey     = "sslMode"
+	sslRootCertKey = "sslRootCert"
+	sslCertKey     = "sslCert"
+	sslKeyKey      = "sslKey"
+
+	pemPrefix = "-----BEGIN"
+)
+
+var validSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// tlsParam is a connection string parameter holding a TLS setting.
+type tlsParam struct {
+	key   string
+	value string
+}
+
+// applyTLSConfig adds the TLS settings from the component metadata to the connection string.
+// Certificates and keys are either file paths or PEM contents. PEM contents are written to files
+// in a private directory that is removed when the store is closed, since the driver reads them from disk.
+func (p *postgresDBAccess) applyTLSConfig(properties map[string]string) error {
+	if mode := properties[sslModeKey]; mode != "" {
+		if !isValidSSLMode(mode) {
+			return fmt.Errorf("invalid %s '%s', supported values are: %s", sslModeKey, mode, strings.Join(validSSLModes, ", "))
+		}
+
+		p.tlsParams = append(p.tlsParams, tlsParam{"sslmode", mode})
+	}
+
+	if (properties[sslCertKey] == "") != (properties[sslKeyKey] == "") {
+		return fmt.Errorf("%s and %s must be set together", sslCertKey, sslKeyKey)
+	}
+
+	files := []struct {
+		key   string
+		param string
+	}{
+		{sslRootCertKey, "sslrootcert"},
+		{sslCertKey, "sslcert"},
+		{sslKeyKey, "sslkey"},
+	}
+	for _, f := range files {
+		val := properties[f.key]
+		if val == "" {
+			continue
+		}
+
+		path := val
+		if strings.HasPrefix(strings.TrimSpace(val), pemPrefix) {
+			var err error
+			path, err = p.writeTLSFile(f.param, val)
+			if err != nil {
+				return fmt.Errorf("failed to write %s: %s", f.key, err)
+			}
+		}
+
+		p.tlsParams = append(p.tlsParams, tlsParam{f.param, path})
+	}
+
+	connectionString, err := p.withTLSParams(p.connectionString)
+	if err != nil {
+		return err
+	}
+
+	p.connectionString = connectionString
+	return nil
+}
+
+// withTLSParams returns the connection string with the TLS settings of the component metadata.
+func (p *postgresDBAccess) withTLSParams(connectionString string) (string, error) {
+	var err error
+	for _, param := range p.tlsParams {
+		connectionString, err = withParam(connectionString, param.key, param.value)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return connectionString, nil
+}
+
+// writeTLSFile writes PEM contents to a file that only the current user can read and returns its path.
+func (p *postgresDBAccess) writeTLSFile(name string, contents string) (string, error) {
+	if p.tlsDir == "" {
+		dir, err := ioutil.TempDir("", "dapr-postgresql-tls")
+		if err != nil {
+			return "", err
+		}
+		p.tlsDir = dir
+	}
+
+	path := filepath.Join(p.tlsDir, name+".pem")
+	err := ioutil.WriteFile(path, []byte(contents), 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// removeTLSFiles deletes the files written by writeTLSFile.
+func (p *postgresDBAccess) removeTLSFiles() error {
+	if p.tlsDir == "" {
+		return nil
+	}
+
+	err := os.RemoveAll(p.tlsDir)
+	p.tlsDir = ""
+	return err
+}
+
+func isValidSSLMode(mode string) bool {
+	for _, m := range validSSLModes {
+		if mode == m {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+const fakePEM = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+func TestApplyTLSConfig(t *testing.T) {
+	newDBAccess := func(connectionString string) *postgresDBAccess {
+		p := newPostgresDBAccess(logger.NewLogger("test"))
+		p.connectionString = connectionString
+		return p
+	}
+
+	t.Run("No settings keep the connection string", func(t *testing.T) {
+		p := newDBAccess(fakeConnectionString)
+		err := p.applyTLSConfig(map[string]string{})
+		assert.Nil(t, err)
+		assert.Equal(t, fakeConnectionString, p.connectionString)
+	})
+
+	t.Run("Mode and file paths are added", func(t *testing.T) {
+		p := newDBAccess("host=localhost")
+		err := p.applyTLSConfig(map[string]string{
+			sslModeKey:     "verify-full",
+			sslRootCertKey: "/certs/ca.pem",
+			sslCertKey:     "/certs/client.pem",
+			sslKeyKey:      "/certs/client.key",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "host=localhost sslmode='verify-full' sslrootcert='/certs/ca.pem' sslcert='/certs/client.pem' sslkey='/certs/client.key'", p.connectionString)
+		assert.Equal(t, "", p.tlsDir)
+	})
+
+	t.Run("URL connection strings get query parameters", func(t *testing.T) {
+		p := newDBAccess("postgres://dapr@localhost/db?connect_timeout=10")
+		err := p.applyTLSConfig(map[string]string{sslModeKey: "require"})
+		assert.Nil(t, err)
+		assert.Equal(t, "postgres://dapr@localhost/db?connect_timeout=10&sslmode=require", p.connectionString)
+	})
+
+	t.Run("PEM contents are written to private files", func(t *testing.T) {
+		p := newDBAccess("host=localhost")
+		err := p.applyTLSConfig(map[string]string{sslRootCertKey: fakePEM})
+		assert.Nil(t, err)
+		assert.NotEqual(t, "", p.tlsDir)
+
+		path := filepath.Join(p.tlsDir, "sslrootcert.pem")
+		assert.Contains(t, p.connectionString, "sslrootcert='"+path+"'")
+
+		info, err := os.Stat(path)
+		assert.Nil(t, err)
+		if runtime.GOOS != "windows" {
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+
+		contents, err := ioutil.ReadFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, fakePEM, string(contents))
+
+		dir := p.tlsDir
+		assert.Nil(t, p.Close())
+		_, err = os.Stat(dir)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Invalid mode fails", func(t *testing.T) {
+		p := newDBAccess("host=localhost")
+		err := p.applyTLSConfig(map[string]string{sslModeKey: "always"})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Client certificate without key fails", func(t *testing.T) {
+		p := newDBAccess("host=localhost")
+		err := p.applyTLSConfig(map[string]string{sslCertKey: "/certs/client.pem"})
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,86 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package postgresql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/metadata"
+	"github.com/dapr/components-contrib/state"
+)
+
+const (
+	ttlInSecondsKey    = state.TTLInSecondsKey
+	cleanupIntervalKey = "cleanupInterval"
+
+	defaultCleanupInterval = time.Hour
+
+	// notExpiredCondition filters out rows that have expired but have not been cleaned up yet
+	notExpiredCondition = "(expiredate IS NULL OR expiredate > NOW())"
+)
+
+// expireDateExpression returns the SQL expression for the expiredate of a row given the parameter holding the TTL in seconds.
+func expireDateExpression(ttlParam string) string {
+	return fmt.Sprintf("CASE WHEN %[1]s::integer IS NULL THEN NULL ELSE NOW() + %[1]s::integer * INTERVAL '1 second' END", ttlParam)
+}
+
+// parseCleanupInterval returns how often expired rows are deleted. A value of zero or less disables the cleanup.
+func parseCleanupInterval(properties map[string]string) (time.Duration, error) {
+	m := struct {
+		CleanupInterval time.Duration `metadata:"cleanupInterval"`
+	}{CleanupInterval: defaultCleanupInterval}
+	if err := metadata.Decode(properties, &m); err != nil {
+		return 0, err
+	}
+
+	return m.CleanupInterval, nil
+}
+
+// scheduleCleanupExpiredData periodically deletes expired rows, and rows older than the maximum row age
+// when one is set, until the store is closed.
+func (p *postgresDBAccess) scheduleCleanupExpiredData(interval time.Duration) {
+	p.logger.Infof("Scheduling cleanup of expired PostgreSQL state every %s", interval)
+
+	closeCh := p.closeCh
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := p.cleanupExpiredData()
+				if err != nil {
+					p.logger.Errorf("error removing expired PostgreSQL state: %s", err)
+				}
+				if p.maxRowAge > 0 {
+					err = p.pruneOldData()
+					if err != nil {
+						p.logger.Errorf("error removing old PostgreSQL state: %s", err)
+					}
+				}
+			case <-closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// cleanupExpiredData deletes the rows whose expiredate has passed.
+func (p *postgresDBAccess) cleanupExpiredData() error {
+	ctx, cancel := p.operationContext()
+	defer cancel()
+
+	result, err := p.db.Exec(ctx, fmt.Sprintf(
+		`DELETE FROM %s WHERE expiredate IS NOT NULL AND expiredate < NOW()`, p.table))
+	if err != nil {
+		return err
+	}
+
+	p.logger.Debugf("Removed %d expired rows from PostgreSQL state", result.RowsAffected())
+
+	return nil
+}
@@ -0,0 +1,29 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package postgresql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCleanupInterval(t *testing.T) {
+	interval, err := parseCleanupInterval(map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, defaultCleanupInterval, interval)
+
+	interval, err = parseCleanupInterval(map[string]string{cleanupIntervalKey: "10m"})
+	assert.Nil(t, err)
+	assert.Equal(t, 10*time.Minute, interval)
+
+	interval, err = parseCleanupInterval(map[string]string{cleanupIntervalKey: "0"})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+
+	_, err = parseCleanupInterval(map[string]string{cleanupIntervalKey: "often"})
+	assert.NotNil(t, err)
+}
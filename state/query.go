@@ -0,0 +1,32 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import "github.com/dapr/components-contrib/state/query"
+
+// Querier is an interface for state stores that can query their JSON values
+type Querier interface {
+	Query(req *QueryRequest) (*QueryResponse, error)
+}
+
+// QueryRequest is the object describing a query over the JSON values in the state store
+type QueryRequest struct {
+	Query    query.Query       `json:"query"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// QueryItem is a single query result
+type QueryItem struct {
+	Key  string `json:"key"`
+	Data []byte `json:"data"`
+	ETag string `json:"etag,omitempty"`
+}
+
+// QueryResponse is the response object for a query. Token is empty when there are no more results.
+type QueryResponse struct {
+	Results []QueryItem `json:"results"`
+	Token   string      `json:"token,omitempty"`
+}
@@ -0,0 +1,167 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package query
+
+import (
+	"fmt"
+)
+
+const (
+	FilterEQ  = "EQ"
+	FilterIN  = "IN"
+	FilterAND = "AND"
+	FilterOR  = "OR"
+)
+
+// Filter is a parsed filter operation
+type Filter interface {
+	Parse(interface{}) error
+}
+
+// EQ matches values whose field at Key equals Val
+type EQ struct {
+	Key string
+	Val interface{}
+}
+
+// Parse parses an operand of the form {"key": value}
+func (f *EQ) Parse(operand interface{}) error {
+	key, val, err := singleField(FilterEQ, operand)
+	if err != nil {
+		return err
+	}
+
+	f.Key = key
+	f.Val = val
+	return nil
+}
+
+// IN matches values whose field at Key equals one of Vals
+type IN struct {
+	Key  string
+	Vals []interface{}
+}
+
+// Parse parses an operand of the form {"key": [values]}
+func (f *IN) Parse(operand interface{}) error {
+	key, val, err := singleField(FilterIN, operand)
+	if err != nil {
+		return err
+	}
+
+	vals, ok := val.([]interface{})
+	if !ok || len(vals) == 0 {
+		return fmt.Errorf("%s filter on '%s' requires a non-empty array of values", FilterIN, key)
+	}
+
+	f.Key = key
+	f.Vals = vals
+	return nil
+}
+
+// AND matches values that match all of Filters
+type AND struct {
+	Filters []Filter
+}
+
+// Parse parses an operand of the form [filters]
+func (f *AND) Parse(operand interface{}) error {
+	filters, err := parseFilters(FilterAND, operand)
+	if err != nil {
+		return err
+	}
+
+	f.Filters = filters
+	return nil
+}
+
+// OR matches values that match any of Filters
+type OR struct {
+	Filters []Filter
+}
+
+// Parse parses an operand of the form [filters]
+func (f *OR) Parse(operand interface{}) error {
+	filters, err := parseFilters(FilterOR, operand)
+	if err != nil {
+		return err
+	}
+
+	f.Filters = filters
+	return nil
+}
+
+// ParseFilter parses a filter with a single operation keyed by EQ, IN, AND, or OR
+func ParseFilter(filter map[string]interface{}) (Filter, error) {
+	if len(filter) != 1 {
+		return nil, fmt.Errorf("a filter must have exactly one operation, found %d", len(filter))
+	}
+
+	for op, operand := range filter {
+		var f Filter
+		switch op {
+		case FilterEQ:
+			f = &EQ{}
+		case FilterIN:
+			f = &IN{}
+		case FilterAND:
+			f = &AND{}
+		case FilterOR:
+			f = &OR{}
+		default:
+			return nil, fmt.Errorf("unsupported filter operation '%s'", op)
+		}
+
+		err := f.Parse(operand)
+		if err != nil {
+			return nil, err
+		}
+
+		return f, nil
+	}
+
+	return nil, nil
+}
+
+func parseFilters(op string, operand interface{}) ([]Filter, error) {
+	items, ok := operand.([]interface{})
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("%s requires a non-empty array of filters", op)
+	}
+
+	filters := make([]Filter, 0, len(items))
+	for _, item := range items {
+		filter, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s requires an array of filters", op)
+		}
+
+		f, err := ParseFilter(filter)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	return filters, nil
+}
+
+// singleField returns the key and value of an operand in the form {"key": value}.
+func singleField(op string, operand interface{}) (string, interface{}, error) {
+	fields, ok := operand.(map[string]interface{})
+	if !ok || len(fields) != 1 {
+		return "", nil, fmt.Errorf("%s filter must have exactly one key", op)
+	}
+
+	for key, val := range fields {
+		if key == "" {
+			return "", nil, fmt.Errorf("query key cannot be empty")
+		}
+		return key, val, nil
+	}
+
+	return "", nil, nil
+}
@@ -0,0 +1,180 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	SortASC  = "ASC"
+	SortDESC = "DESC"
+)
+
+// Query filters, sorts, and pages state values.
+// Filters are a single operation keyed by EQ, IN, AND, or OR, for example:
+//
+//	{"AND": [{"EQ": {"person.org": "Dev Ops"}}, {"IN": {"state": ["CA", "WA"]}}]}
+//
+// Keys are dot separated paths into the JSON value.
+type Query struct {
+	Filters map[string]interface{} `json:"filter,omitempty"`
+	Sort    []Sorting              `json:"sort,omitempty"`
+	Page    Pagination             `json:"page,omitempty"`
+
+	// Filter is the parsed form of Filters, set by Parse
+	Filter Filter `json:"-"`
+}
+
+// Sorting orders query results by the value at a key
+type Sorting struct {
+	Key   string `json:"key"`
+	Order string `json:"order,omitempty"` // ASC, DESC
+}
+
+// Pagination limits the number of query results. Token is the Token of a previous response.
+// The format of tokens is specific to each state store.
+type Pagination struct {
+	Limit int    `json:"limit,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+// UnmarshalJSON parses and validates a query
+func (q *Query) UnmarshalJSON(data []byte) error {
+	// The alias has no methods, so unmarshalling it does not recurse
+	type query Query
+	var raw query
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*q = Query(raw)
+	return q.Parse()
+}
+
+// Parse parses Filters into Filter, and validates and normalizes the sort orders and pagination
+func (q *Query) Parse() error {
+	q.Filter = nil
+	if len(q.Filters) > 0 {
+		filter, err := ParseFilter(q.Filters)
+		if err != nil {
+			return err
+		}
+		q.Filter = filter
+	}
+
+	return q.parseSortAndPage()
+}
+
+// parseSortAndPage validates and normalizes the sort orders and pagination
+func (q *Query) parseSortAndPage() error {
+	for i := range q.Sort {
+		if q.Sort[i].Key == "" {
+			return fmt.Errorf("query key cannot be empty")
+		}
+
+		order := strings.ToUpper(q.Sort[i].Order)
+		if order == "" {
+			order = SortASC
+		}
+		if order != SortASC && order != SortDESC {
+			return fmt.Errorf("invalid sort order '%s', supported values are: %s, %s", q.Sort[i].Order, SortASC, SortDESC)
+		}
+		q.Sort[i].Order = order
+	}
+
+	if q.Page.Limit < 0 {
+		return fmt.Errorf("invalid page limit %d", q.Page.Limit)
+	}
+
+	return nil
+}
+
+// Visitor translates the filters of a query to the native query language of a state store.
+// The Visit methods return the native form of a filter. AND and OR receive the native forms of their filters.
+type Visitor interface {
+	VisitEQ(f *EQ) (string, error)
+	VisitIN(f *IN) (string, error)
+	VisitAND(f *AND, filters []string) (string, error)
+	VisitOR(f *OR, filters []string) (string, error)
+	// Finalize is called with the native form of the whole filter, which is empty if the query has no filter,
+	// to complete the query with its sorting and pagination.
+	Finalize(filter string, q *Query) error
+}
+
+// Builder walks the filters of a query with a Visitor
+type Builder struct {
+	visitor Visitor
+}
+
+// NewQueryBuilder returns a Builder for visitor
+func NewQueryBuilder(visitor Visitor) *Builder {
+	return &Builder{visitor: visitor}
+}
+
+// BuildQuery visits the filters of q, then finalizes it. The sort orders and pagination are always validated,
+// and Filters is parsed again when it is set, so that changes made to the query after unmarshalling are taken
+// into account.
+func (b *Builder) BuildQuery(q *Query) error {
+	var err error
+	if len(q.Filters) > 0 {
+		err = q.Parse()
+	} else {
+		err = q.parseSortAndPage()
+	}
+	if err != nil {
+		return err
+	}
+
+	filter := ""
+	if q.Filter != nil {
+		filter, err = b.visit(q.Filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return b.visitor.Finalize(filter, q)
+}
+
+func (b *Builder) visit(filter Filter) (string, error) {
+	switch f := filter.(type) {
+	case *EQ:
+		return b.visitor.VisitEQ(f)
+	case *IN:
+		return b.visitor.VisitIN(f)
+	case *AND:
+		filters, err := b.visitAll(f.Filters)
+		if err != nil {
+			return "", err
+		}
+		return b.visitor.VisitAND(f, filters)
+	case *OR:
+		filters, err := b.visitAll(f.Filters)
+		if err != nil {
+			return "", err
+		}
+		return b.visitor.VisitOR(f, filters)
+	default:
+		return "", fmt.Errorf("unsupported filter type %T", filter)
+	}
+}
+
+func (b *Builder) visitAll(filters []Filter) ([]string, error) {
+	result := make([]string, 0, len(filters))
+	for _, f := range filters {
+		s, err := b.visit(f)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,148 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// printer renders filters in a readable form to check the order the builder visits them in
+type printer struct {
+	filter string
+	query  *Query
+}
+
+func (p *printer) VisitEQ(f *EQ) (string, error) {
+	return fmt.Sprintf("%s=%v", f.Key, f.Val), nil
+}
+
+func (p *printer) VisitIN(f *IN) (string, error) {
+	return fmt.Sprintf("%s in %v", f.Key, f.Vals), nil
+}
+
+func (p *printer) VisitAND(f *AND, filters []string) (string, error) {
+	return "(" + strings.Join(filters, " and ") + ")", nil
+}
+
+func (p *printer) VisitOR(f *OR, filters []string) (string, error) {
+	return "(" + strings.Join(filters, " or ") + ")", nil
+}
+
+func (p *printer) Finalize(filter string, q *Query) error {
+	p.filter = filter
+	p.query = q
+	return nil
+}
+
+func TestParseQuery(t *testing.T) {
+	t.Run("Filter, sort, and page are parsed", func(t *testing.T) {
+		var q Query
+		err := json.Unmarshal([]byte(`{
+			"filter": {"AND": [{"EQ": {"person.org": "Dev Ops"}}, {"IN": {"state": ["CA", "WA"]}}]},
+			"sort": [{"key": "person.id", "order": "desc"}, {"key": "state"}],
+			"page": {"limit": 10, "token": "20"}
+		}`), &q)
+		assert.Nil(t, err)
+		assert.Equal(t, &AND{Filters: []Filter{
+			&EQ{Key: "person.org", Val: "Dev Ops"},
+			&IN{Key: "state", Vals: []interface{}{"CA", "WA"}},
+		}}, q.Filter)
+		assert.Equal(t, []Sorting{{Key: "person.id", Order: SortDESC}, {Key: "state", Order: SortASC}}, q.Sort)
+		assert.Equal(t, Pagination{Limit: 10, Token: "20"}, q.Page)
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		var q Query
+		err := json.Unmarshal([]byte(`{}`), &q)
+		assert.Nil(t, err)
+		assert.Nil(t, q.Filter)
+	})
+
+	invalid := map[string]string{
+		"Unsupported operation":      `{"filter": {"GT": {"age": 30}}}`,
+		"Multiple operations":        `{"filter": {"EQ": {"a": 1}, "IN": {"b": [1]}}}`,
+		"EQ with multiple keys":      `{"filter": {"EQ": {"a": 1, "b": 2}}}`,
+		"EQ with non-object operand": `{"filter": {"EQ": "a"}}`,
+		"IN without values":          `{"filter": {"IN": {"a": []}}}`,
+		"IN with non-array value":    `{"filter": {"IN": {"a": 1}}}`,
+		"AND without filters":        `{"filter": {"AND": []}}`,
+		"OR with non-filter":         `{"filter": {"OR": [1]}}`,
+		"Nested invalid operation":   `{"filter": {"AND": [{"EQ": {"a": 1}}, {"NOT": {"b": 1}}]}}`,
+		"Empty key":                  `{"filter": {"EQ": {"": 1}}}`,
+		"Invalid sort order":         `{"sort": [{"key": "a", "order": "up"}]}`,
+		"Sort with empty key":        `{"sort": [{"key": ""}]}`,
+		"Negative page limit":        `{"page": {"limit": -1}}`,
+	}
+	for name, s := range invalid {
+		name, s := name, s
+		t.Run(name+" fails", func(t *testing.T) {
+			var q Query
+			err := json.Unmarshal([]byte(s), &q)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	t.Run("Filters are visited depth first", func(t *testing.T) {
+		var q Query
+		err := json.Unmarshal([]byte(`{"filter": {"OR": [{"EQ": {"a": 1}}, {"AND": [{"EQ": {"b": "x"}}, {"IN": {"c": [1, 2]}}]}]}}`), &q)
+		assert.Nil(t, err)
+
+		p := &printer{}
+		err = NewQueryBuilder(p).BuildQuery(&q)
+		assert.Nil(t, err)
+		assert.Equal(t, "(a=1 or (b=x and c in [1 2]))", p.filter)
+		assert.Equal(t, &q, p.query)
+	})
+
+	t.Run("Query without filter is finalized", func(t *testing.T) {
+		p := &printer{}
+		err := NewQueryBuilder(p).BuildQuery(&Query{})
+		assert.Nil(t, err)
+		assert.Equal(t, "", p.filter)
+	})
+
+	t.Run("Filters set without unmarshalling are parsed", func(t *testing.T) {
+		p := &printer{}
+		err := NewQueryBuilder(p).BuildQuery(&Query{Filters: map[string]interface{}{
+			"IN": map[string]interface{}{"c": []interface{}{"x"}},
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "c in [x]", p.filter)
+	})
+
+	t.Run("Invalid filters set without unmarshalling fail", func(t *testing.T) {
+		err := NewQueryBuilder(&printer{}).BuildQuery(&Query{Filters: map[string]interface{}{"GT": 1}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Sort and page set without unmarshalling are normalized", func(t *testing.T) {
+		p := &printer{}
+		err := NewQueryBuilder(p).BuildQuery(&Query{Sort: []Sorting{{Key: "a"}, {Key: "b", Order: "desc"}}})
+		assert.Nil(t, err)
+		assert.Equal(t, []Sorting{{Key: "a", Order: SortASC}, {Key: "b", Order: SortDESC}}, p.query.Sort)
+	})
+
+	invalid := map[string]*Query{
+		"Invalid sort order": {Sort: []Sorting{{Key: "a", Order: "ASC; DROP TABLE state"}}},
+		"Empty sort key":     {Sort: []Sorting{{Order: SortASC}}},
+		"Negative limit":     {Page: Pagination{Limit: -1}},
+	}
+	for name, q := range invalid {
+		name, q := name, q
+		t.Run(name+" without filters fails", func(t *testing.T) {
+			p := &printer{}
+			err := NewQueryBuilder(p).BuildQuery(q)
+			assert.NotNil(t, err)
+			assert.Nil(t, p.query)
+		})
+	}
+}
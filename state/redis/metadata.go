@@ -8,9 +8,32 @@ package redis
 import "time"
 
 type metadata struct {
-	host            string
-	password        string
-	enableTLS       bool
-	maxRetries      int
-	maxRetryBackoff time.Duration
+	host      string
+	username  string
+	password  string
+	enableTLS bool
+	// caCert, clientCert, and clientKey are PEM encoded
+	caCert             string
+	clientCert         string
+	clientKey          string
+	insecureSkipVerify bool
+	maxRetries         int
+	maxRetryBackoff    time.Duration
+
+	// redisType is node, cluster, or sentinel
+	redisType string
+	// hosts are the addresses of the cluster nodes or sentinels
+	hosts              []string
+	sentinelMasterName string
+	sentinelUsername   string
+	sentinelPassword   string
+	maxRedirects       int
+	readOnly           bool
+	routeByLatency     bool
+	routeRandomly      bool
+
+	// queryIndexes are the value fields indexed with RediSearch, which are queryable when set
+	queryIndexName   string
+	queryIndexPrefix string
+	queryIndexes     []queryIndex
 }
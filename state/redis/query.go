@@ -0,0 +1,345 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/query"
+)
+
+const (
+	queryIndexName          = "queryIndexName"
+	queryIndexPrefix        = "queryIndexPrefix"
+	queryIndexes            = "queryIndexes"
+	defaultQueryIndexName   = "daprStateIndex"
+	queryFieldPrefix        = "q_"
+	queryFieldTypeTag       = "TAG"
+	queryFieldTypeText      = "TEXT"
+	queryFieldTypeNumeric   = "NUMERIC"
+	queryPageSize           = 1000
+	indexAlreadyExistsError = "Index already exists"
+)
+
+// queryIndex is a value field indexed with RediSearch
+type queryIndex struct {
+	// Key is the dot separated path of the field in the JSON value
+	Key string `json:"key"`
+	// Type is TAG, TEXT, or NUMERIC
+	Type string `json:"type,omitempty"`
+}
+
+var nonFieldCharacters = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// field returns the name of the hash field the value of the index is copied to
+func (i queryIndex) field() string {
+	return queryFieldPrefix + nonFieldCharacters.ReplaceAllString(i.Key, "_")
+}
+
+// parseQueryIndexes parses the JSON array of indexes, for example [{"key": "person.org", "type": "TAG"}]
+func parseQueryIndexes(val string) ([]queryIndex, error) {
+	var indexes []queryIndex
+	err := json.Unmarshal([]byte(val), &indexes)
+	if err != nil {
+		return nil, fmt.Errorf("redis store error: can't parse queryIndexes field: %s", err)
+	}
+
+	fields := map[string]string{}
+	for i := range indexes {
+		if indexes[i].Key == "" {
+			return nil, errors.New("redis store error: queryIndexes key cannot be empty")
+		}
+
+		indexes[i].Type = strings.ToUpper(indexes[i].Type)
+		switch indexes[i].Type {
+		case "":
+			indexes[i].Type = queryFieldTypeTag
+		case queryFieldTypeTag, queryFieldTypeText, queryFieldTypeNumeric:
+		default:
+			return nil, fmt.Errorf("redis store error: invalid queryIndexes type '%s', supported values are: %s, %s, %s", indexes[i].Type, queryFieldTypeTag, queryFieldTypeText, queryFieldTypeNumeric)
+		}
+
+		field := indexes[i].field()
+		if other, ok := fields[field]; ok {
+			return nil, fmt.Errorf("redis store error: queryIndexes keys '%s' and '%s' map to the same field", other, indexes[i].Key)
+		}
+		fields[field] = indexes[i].Key
+	}
+
+	return indexes, nil
+}
+
+// createQueryIndex creates the RediSearch index of the indexed fields, unless it already exists
+func (r *StateStore) createQueryIndex() error {
+	args := []interface{}{"FT.CREATE", r.metadata.queryIndexName, "ON", "HASH"}
+	if r.metadata.queryIndexPrefix != "" {
+		args = append(args, "PREFIX", 1, r.metadata.queryIndexPrefix)
+	}
+	args = append(args, "SCHEMA")
+	for _, index := range r.metadata.queryIndexes {
+		args = append(args, index.field(), index.Type, "SORTABLE")
+	}
+
+	_, err := r.client.DoContext(context.Background(), args...).Result()
+	if err != nil && !strings.Contains(err.Error(), indexAlreadyExistsError) {
+		return fmt.Errorf("redis store error: failed to create query index %s: %s", r.metadata.queryIndexName, err)
+	}
+
+	return nil
+}
+
+// indexedFields returns the field and value pairs of the indexed fields of a JSON value.
+// Fields missing from the value have an empty value, so that they are removed from the hash.
+func (r *StateStore) indexedFields(value []byte) []interface{} {
+	if len(r.metadata.queryIndexes) == 0 {
+		return nil
+	}
+
+	var doc interface{}
+	// Values that are not JSON are stored as they are and are not indexed
+	_ = json.Unmarshal(value, &doc)
+
+	fields := make([]interface{}, 0, 2*len(r.metadata.queryIndexes))
+	for _, index := range r.metadata.queryIndexes {
+		fields = append(fields, index.field(), fieldValue(doc, index.Key))
+	}
+
+	return fields
+}
+
+// fieldValue returns the value at a dot separated key of doc as a string, or an empty string if there is none
+func fieldValue(doc interface{}, key string) string {
+	for _, name := range strings.Split(key, ".") {
+		object, ok := doc.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		doc = object[name]
+	}
+
+	return formatValue(doc)
+}
+
+// formatValue returns a JSON value as a string, or an empty string if it is null
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// Query returns the state values that match the query with FT.SEARCH. Only the fields in queryIndexes can be
+// filtered and sorted on, and only values written with Set are indexed.
+func (r *StateStore) Query(req *state.QueryRequest) (*state.QueryResponse, error) {
+	if len(r.metadata.queryIndexes) == 0 {
+		return nil, errors.New("redis store error: query requires the queryIndexes field")
+	}
+
+	builder := &queryBuilder{indexes: r.metadata.queryIndexes}
+	err := query.NewQueryBuilder(builder).BuildQuery(&req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &state.QueryResponse{
+		Results: []state.QueryItem{},
+	}
+
+	// Without a limit every result is returned, a page at a time
+	offset := builder.offset
+	for {
+		limit := queryPageSize
+		if req.Query.Page.Limit > 0 {
+			limit = req.Query.Page.Limit - len(response.Results)
+		}
+
+		args := append([]interface{}{"FT.SEARCH", r.metadata.queryIndexName}, builder.args...)
+		args = append(args, "RETURN", 2, "data", "version", "LIMIT", offset, limit)
+		res, err := r.client.DoContext(context.Background(), args...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("redis store error: failed to query %s: %s", r.metadata.queryIndexName, err)
+		}
+
+		total, items, err := r.parseSearchResult(res)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, items...)
+		offset += len(items)
+
+		if len(items) == 0 || offset >= total || (req.Query.Page.Limit > 0 && len(response.Results) >= req.Query.Page.Limit) {
+			if req.Query.Page.Limit > 0 && offset < total {
+				response.Token = strconv.Itoa(offset)
+			}
+			return response, nil
+		}
+	}
+}
+
+// parseSearchResult parses the total number of results and the items of a FT.SEARCH reply, which is of the form
+// [total, key1, [field, value, ...], key2, [field, value, ...], ...]
+func (r *StateStore) parseSearchResult(res interface{}) (int, []state.QueryItem, error) {
+	vals, ok := res.([]interface{})
+	if !ok || len(vals) == 0 {
+		return 0, nil, fmt.Errorf("redis store error: unexpected query result %v", res)
+	}
+
+	total, ok := vals[0].(int64)
+	if !ok {
+		return 0, nil, fmt.Errorf("redis store error: unexpected query result count %v", vals[0])
+	}
+
+	items := make([]state.QueryItem, 0, (len(vals)-1)/2)
+	for i := 1; i+1 < len(vals); i += 2 {
+		key, _ := strconv.Unquote(fmt.Sprintf("%q", vals[i]))
+		fields, _ := vals[i+1].([]interface{})
+		data, version, err := r.getKeyVersion(fields)
+		if err != nil {
+			return 0, nil, fmt.Errorf("redis store error: failed to read query result %s: %s", key, err)
+		}
+		items = append(items, state.QueryItem{
+			Key:  key,
+			Data: []byte(data),
+			ETag: version,
+		})
+	}
+
+	return int(total), items, nil
+}
+
+// queryBuilder translates a query into the arguments of FT.SEARCH that follow the index name.
+// It implements query.Visitor.
+type queryBuilder struct {
+	indexes []queryIndex
+	args    []interface{}
+	offset  int
+}
+
+// VisitEQ implements query.Visitor.
+func (b *queryBuilder) VisitEQ(f *query.EQ) (string, error) {
+	index, err := b.index(f.Key)
+	if err != nil {
+		return "", err
+	}
+
+	return matchValues(index, []interface{}{f.Val})
+}
+
+// VisitIN implements query.Visitor.
+func (b *queryBuilder) VisitIN(f *query.IN) (string, error) {
+	index, err := b.index(f.Key)
+	if err != nil {
+		return "", err
+	}
+
+	return matchValues(index, f.Vals)
+}
+
+// VisitAND implements query.Visitor.
+func (b *queryBuilder) VisitAND(f *query.AND, filters []string) (string, error) {
+	return "(" + strings.Join(filters, " ") + ")", nil
+}
+
+// VisitOR implements query.Visitor.
+func (b *queryBuilder) VisitOR(f *query.OR, filters []string) (string, error) {
+	return "(" + strings.Join(filters, " | ") + ")", nil
+}
+
+// Finalize implements query.Visitor.
+func (b *queryBuilder) Finalize(filter string, q *query.Query) error {
+	if filter == "" {
+		filter = "*"
+	}
+	b.args = []interface{}{filter}
+
+	if len(q.Sort) > 1 {
+		return errors.New("redis store error: query can only be sorted by one key")
+	}
+	if len(q.Sort) == 1 {
+		index, err := b.index(q.Sort[0].Key)
+		if err != nil {
+			return err
+		}
+		b.args = append(b.args, "SORTBY", index.field(), q.Sort[0].Order)
+	}
+
+	if q.Page.Token != "" {
+		offset, err := strconv.Atoi(q.Page.Token)
+		if err != nil || offset < 0 {
+			return fmt.Errorf("invalid page token '%s'", q.Page.Token)
+		}
+		b.offset = offset
+	}
+
+	return nil
+}
+
+func (b *queryBuilder) index(key string) (queryIndex, error) {
+	for _, index := range b.indexes {
+		if index.Key == key {
+			return index, nil
+		}
+	}
+
+	return queryIndex{}, fmt.Errorf("redis store error: query key '%s' is not in queryIndexes", key)
+}
+
+// matchValues returns the expression that matches the field of index with any of vals
+func matchValues(index queryIndex, vals []interface{}) (string, error) {
+	exprs := make([]string, 0, len(vals))
+	for _, v := range vals {
+		s := formatValue(v)
+		switch index.Type {
+		case queryFieldTypeNumeric:
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				return "", fmt.Errorf("redis store error: query value %v of '%s' is not a number", v, index.Key)
+			}
+			exprs = append(exprs, fmt.Sprintf("@%s:[%s %s]", index.field(), s, s))
+		case queryFieldTypeText:
+			exprs = append(exprs, fmt.Sprintf(`@%s:"%s"`, index.field(), strings.ReplaceAll(s, `"`, `\"`)))
+		default:
+			exprs = append(exprs, escapeTag(s))
+		}
+	}
+
+	if index.Type == queryFieldTypeTag {
+		return fmt.Sprintf("@%s:{%s}", index.field(), strings.Join(exprs, " | ")), nil
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+
+	return "(" + strings.Join(exprs, " | ") + ")", nil
+}
+
+// escapeTag escapes the punctuation and spaces of a tag value, which otherwise separate or modify tags
+func escapeTag(s string) string {
+	var sb strings.Builder
+	for _, c := range s {
+		if !(c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c > 127) {
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(c)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,146 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package redis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryIndexes(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		indexes, err := parseQueryIndexes(`[{"key": "person.org"}, {"key": "age", "type": "numeric"}]`)
+		assert.Nil(t, err)
+		assert.Equal(t, []queryIndex{{Key: "person.org", Type: queryFieldTypeTag}, {Key: "age", Type: queryFieldTypeNumeric}}, indexes)
+		assert.Equal(t, "q_person_org", indexes[0].field())
+	})
+
+	invalid := map[string]string{
+		"Not JSON":     `person.org`,
+		"Empty key":    `[{"key": ""}]`,
+		"Invalid type": `[{"key": "age", "type": "GEO"}]`,
+		"Same field":   `[{"key": "person.org"}, {"key": "person_org"}]`,
+	}
+	for name, val := range invalid {
+		val := val
+		t.Run(name, func(t *testing.T) {
+			_, err := parseQueryIndexes(val)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestIndexedFields(t *testing.T) {
+	store := &StateStore{metadata: metadata{queryIndexes: []queryIndex{
+		{Key: "person.org", Type: queryFieldTypeTag},
+		{Key: "age", Type: queryFieldTypeNumeric},
+		{Key: "missing", Type: queryFieldTypeText},
+	}}}
+
+	fields := store.indexedFields([]byte(`{"person": {"org": "Dev Ops"}, "age": 42.5}`))
+	assert.Equal(t, []interface{}{"q_person_org", "Dev Ops", "q_age", "42.5", "q_missing", ""}, fields)
+
+	fields = store.indexedFields([]byte(`not json`))
+	assert.Equal(t, []interface{}{"q_person_org", "", "q_age", "", "q_missing", ""}, fields)
+
+	assert.Nil(t, (&StateStore{}).indexedFields([]byte(`{}`)))
+}
+
+func TestQueryBuilder(t *testing.T) {
+	indexes := []queryIndex{
+		{Key: "person.org", Type: queryFieldTypeTag},
+		{Key: "age", Type: queryFieldTypeNumeric},
+		{Key: "name", Type: queryFieldTypeText},
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		args   []interface{}
+		offset int
+	}{
+		{
+			name:  "No filter",
+			query: `{}`,
+			args:  []interface{}{"*"},
+		},
+		{
+			name:  "Tag EQ",
+			query: `{"filter": {"EQ": {"person.org": "Dev Ops"}}}`,
+			args:  []interface{}{`@q_person_org:{Dev\ Ops}`},
+		},
+		{
+			name:  "Numeric IN",
+			query: `{"filter": {"IN": {"age": [30, 40]}}}`,
+			args:  []interface{}{`(@q_age:[30 30] | @q_age:[40 40])`},
+		},
+		{
+			name:   "AND and OR with sort and page",
+			query:  `{"filter": {"AND": [{"EQ": {"name": "John \"Doe\""}}, {"OR": [{"IN": {"person.org": ["a-b", "c"]}}, {"EQ": {"age": 7}}]}]}, "sort": [{"key": "age", "order": "desc"}], "page": {"limit": 2, "token": "4"}}`,
+			args:   []interface{}{`(@q_name:"John \"Doe\"" (@q_person_org:{a\-b | c} | @q_age:[7 7]))`, "SORTBY", "q_age", "DESC"},
+			offset: 4,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var q query.Query
+			assert.Nil(t, json.Unmarshal([]byte(tt.query), &q))
+
+			builder := &queryBuilder{indexes: indexes}
+			assert.Nil(t, query.NewQueryBuilder(builder).BuildQuery(&q))
+			assert.Equal(t, tt.args, builder.args)
+			assert.Equal(t, tt.offset, builder.offset)
+		})
+	}
+
+	invalid := map[string]string{
+		"Key not indexed":    `{"filter": {"EQ": {"city": "Seattle"}}}`,
+		"Not a number":       `{"filter": {"EQ": {"age": "old"}}}`,
+		"Sort not indexed":   `{"sort": [{"key": "city"}]}`,
+		"Sort by two keys":   `{"sort": [{"key": "age"}, {"key": "name"}]}`,
+		"Invalid page token": `{"page": {"token": "next"}}`,
+	}
+	for name, val := range invalid {
+		val := val
+		t.Run(name, func(t *testing.T) {
+			var q query.Query
+			assert.Nil(t, json.Unmarshal([]byte(val), &q))
+			assert.NotNil(t, query.NewQueryBuilder(&queryBuilder{indexes: indexes}).BuildQuery(&q))
+		})
+	}
+}
+
+func TestParseSearchResult(t *testing.T) {
+	store := NewRedisStateStore(nil)
+	total, items, err := store.parseSearchResult([]interface{}{
+		int64(3),
+		"key1", []interface{}{"data", `{"age": 1}`, "version", "2"},
+		"key2", []interface{}{"version", "1", "data", `{"age": 2}`},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []state.QueryItem{
+		{Key: "key1", Data: []byte(`{"age": 1}`), ETag: "2"},
+		{Key: "key2", Data: []byte(`{"age": 2}`), ETag: "1"},
+	}, items)
+
+	_, _, err = store.parseSearchResult([]interface{}{int64(1), "key1", []interface{}{"data", "x"}})
+	assert.NotNil(t, err)
+
+	_, _, err = store.parseSearchResult("OK")
+	assert.NotNil(t, err)
+}
+
+func TestQueryWithoutIndexes(t *testing.T) {
+	store := NewRedisStateStore(nil)
+	_, err := store.Query(&state.QueryRequest{})
+	assert.NotNil(t, err)
+}
@@ -8,12 +8,16 @@ package redis
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	componentmetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 
@@ -23,15 +27,31 @@ import (
 )
 
 const (
-	setQuery                 = "local var1 = redis.pcall(\"HGET\", KEYS[1], \"version\"); if type(var1) == \"table\" then redis.call(\"DEL\", KEYS[1]); end; if not var1 or type(var1)==\"table\" or var1 == \"\" or var1 == ARGV[1] or ARGV[1] == \"0\" then redis.call(\"HSET\", KEYS[1], \"data\", ARGV[2]) return redis.call(\"HINCRBY\", KEYS[1], \"version\", 1) else return error(\"failed to set key \" .. KEYS[1]) end"
+	setQuery                 = "local var1 = redis.pcall(\"HGET\", KEYS[1], \"version\"); if type(var1) == \"table\" then redis.call(\"DEL\", KEYS[1]); end; if not var1 or type(var1)==\"table\" or var1 == \"\" or var1 == ARGV[1] or ARGV[1] == \"0\" then redis.call(\"HSET\", KEYS[1], \"data\", ARGV[2]); for i = 3, #ARGV, 2 do if ARGV[i+1] == \"\" then redis.call(\"HDEL\", KEYS[1], ARGV[i]) else redis.call(\"HSET\", KEYS[1], ARGV[i], ARGV[i+1]) end end; return redis.call(\"HINCRBY\", KEYS[1], \"version\", 1) else return error(\"failed to set key \" .. KEYS[1]) end"
 	delQuery                 = "local var1 = redis.pcall(\"HGET\", KEYS[1], \"version\"); if not var1 or type(var1)==\"table\" or var1 == ARGV[1] or var1 == \"\" or ARGV[1] == \"0\" then return redis.call(\"DEL\", KEYS[1]) else return error(\"failed to delete \" .. KEYS[1]) end"
 	connectedSlavesReplicas  = "connected_slaves:"
 	infoReplicationDelimiter = "\r\n"
 	host                     = "redisHost"
+	username                 = "redisUsername"
 	password                 = "redisPassword"
 	enableTLS                = "enableTLS"
+	caCert                   = "caCert"
+	clientCert               = "clientCert"
+	clientKey                = "clientKey"
+	insecureSkipVerify       = "insecureSkipVerify"
 	maxRetries               = "maxRetries"
 	maxRetryBackoff          = "maxRetryBackoff"
+	redisType                = "redisType"
+	sentinelMasterName       = "sentinelMasterName"
+	sentinelUsername         = "sentinelUsername"
+	sentinelPassword         = "sentinelPassword"
+	maxRedirects             = "maxRedirects"
+	readOnly                 = "readOnly"
+	routeByLatency           = "routeByLatency"
+	routeRandomly            = "routeRandomly"
+	redisTypeNode            = "node"
+	redisTypeCluster         = "cluster"
+	redisTypeSentinel        = "sentinel"
 	defaultBase              = 10
 	defaultBitSize           = 0
 	defaultDB                = 0
@@ -39,11 +59,12 @@ const (
 	defaultMaxRetries        = 3
 	defaultMaxRetryBackoff   = time.Second * 2
 	defaultEnableTLS         = false
+	defaultMaxRedirects      = 8
 )
 
 // StateStore is a Redis state store
 type StateStore struct {
-	client   *redis.Client
+	client   redis.UniversalClient
 	json     jsoniter.API
 	metadata metadata
 	replicas int
@@ -68,6 +89,10 @@ func parseRedisMetadata(meta state.Metadata) (metadata, error) {
 		return m, errors.New("redis store error: missing host address")
 	}
 
+	if val, ok := meta.Properties[username]; ok && val != "" {
+		m.username = val
+	}
+
 	if val, ok := meta.Properties[password]; ok && val != "" {
 		m.password = val
 	}
@@ -81,6 +106,26 @@ func parseRedisMetadata(meta state.Metadata) (metadata, error) {
 		m.enableTLS = tls
 	}
 
+	m.caCert = meta.Properties[caCert]
+	m.clientCert = meta.Properties[clientCert]
+	m.clientKey = meta.Properties[clientKey]
+	if (m.clientCert == "") != (m.clientKey == "") {
+		return m, errors.New("redis store error: clientCert and clientKey must be set together")
+	}
+	if !m.enableTLS && (m.caCert != "" || m.clientCert != "") {
+		return m, errors.New("redis store error: caCert, clientCert, and clientKey require enableTLS")
+	}
+
+	// Server certificates were never verified before caCert could be set, so verification is only on by default with it
+	m.insecureSkipVerify = m.caCert == ""
+	if val, ok := meta.Properties[insecureSkipVerify]; ok && val != "" {
+		skip, err := strconv.ParseBool(val)
+		if err != nil {
+			return m, fmt.Errorf("redis store error: can't parse insecureSkipVerify field: %s", err)
+		}
+		m.insecureSkipVerify = skip
+	}
+
 	m.maxRetries = defaultMaxRetries
 	if val, ok := meta.Properties[maxRetries]; ok && val != "" {
 		parsedVal, err := strconv.ParseInt(val, defaultBase, defaultBitSize)
@@ -99,43 +144,230 @@ func parseRedisMetadata(meta state.Metadata) (metadata, error) {
 		m.maxRetryBackoff = time.Duration(parsedVal)
 	}
 
+	m.redisType = redisTypeNode
+	if val, ok := meta.Properties[redisType]; ok && val != "" {
+		m.redisType = strings.ToLower(val)
+	}
+
+	switch m.redisType {
+	case redisTypeNode:
+	case redisTypeCluster, redisTypeSentinel:
+		for _, h := range strings.Split(m.host, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				m.hosts = append(m.hosts, h)
+			}
+		}
+	default:
+		return m, fmt.Errorf("redis store error: invalid redisType '%s', supported values are: %s, %s, %s", m.redisType, redisTypeNode, redisTypeCluster, redisTypeSentinel)
+	}
+
+	if m.redisType == redisTypeSentinel {
+		m.sentinelMasterName = meta.Properties[sentinelMasterName]
+		if m.sentinelMasterName == "" {
+			return m, errors.New("redis store error: missing sentinelMasterName for redisType sentinel")
+		}
+		m.sentinelUsername = meta.Properties[sentinelUsername]
+		m.sentinelPassword = meta.Properties[sentinelPassword]
+	}
+
+	m.maxRedirects = defaultMaxRedirects
+	if val, ok := meta.Properties[maxRedirects]; ok && val != "" {
+		parsedVal, err := strconv.Atoi(val)
+		if err != nil {
+			return m, fmt.Errorf("redis store error: can't parse maxRedirects field: %s", err)
+		}
+		m.maxRedirects = parsedVal
+	}
+
+	for key, field := range map[string]*bool{
+		readOnly:       &m.readOnly,
+		routeByLatency: &m.routeByLatency,
+		routeRandomly:  &m.routeRandomly,
+	} {
+		if val, ok := meta.Properties[key]; ok && val != "" {
+			parsedVal, err := strconv.ParseBool(val)
+			if err != nil {
+				return m, fmt.Errorf("redis store error: can't parse %s field: %s", key, err)
+			}
+			*field = parsedVal
+		}
+	}
+
+	m.queryIndexName = defaultQueryIndexName
+	if val, ok := meta.Properties[queryIndexName]; ok && val != "" {
+		m.queryIndexName = val
+	}
+	m.queryIndexPrefix = meta.Properties[queryIndexPrefix]
+	if val, ok := meta.Properties[queryIndexes]; ok && val != "" {
+		indexes, err := parseQueryIndexes(val)
+		if err != nil {
+			return m, err
+		}
+		m.queryIndexes = indexes
+	}
+
 	return m, nil
 }
 
+// newTLSConfig returns the TLS configuration of m, which is nil when TLS is not enabled
+func newTLSConfig(m metadata) (*tls.Config, error) {
+	if !m.enableTLS {
+		return nil, nil
+	}
+
+	/* #nosec */
+	config := &tls.Config{
+		InsecureSkipVerify: m.insecureSkipVerify,
+	}
+
+	if m.caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(m.caCert)) {
+			return nil, errors.New("redis store error: caCert is not a valid PEM certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	if m.clientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(m.clientCert), []byte(m.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("redis store error: invalid clientCert or clientKey: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// newClient returns the client for the redisType of m
+func newClient(m metadata) (redis.UniversalClient, error) {
+	tlsConfig, err := newTLSConfig(m)
+	if err != nil {
+		return nil, err
+	}
+
+	switch m.redisType {
+	case redisTypeCluster:
+		// Commands are sent to the master of the slot of their key, and are redirected when slots move after a failover
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:           m.hosts,
+			Username:        m.username,
+			Password:        m.password,
+			MaxRetries:      m.maxRetries,
+			MaxRetryBackoff: m.maxRetryBackoff,
+			MaxRedirects:    m.maxRedirects,
+			ReadOnly:        m.readOnly,
+			RouteByLatency:  m.routeByLatency,
+			RouteRandomly:   m.routeRandomly,
+			TLSConfig:       tlsConfig,
+		}), nil
+	case redisTypeSentinel:
+		// The sentinels are asked for the address of the master, which is looked up again after a failover
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       m.sentinelMasterName,
+			SentinelAddrs:    m.hosts,
+			SentinelUsername: m.sentinelUsername,
+			SentinelPassword: m.sentinelPassword,
+			Username:         m.username,
+			Password:         m.password,
+			DB:               defaultDB,
+			MaxRetries:       m.maxRetries,
+			MaxRetryBackoff:  m.maxRetryBackoff,
+			TLSConfig:        tlsConfig,
+		}), nil
+	default:
+		return redis.NewClient(&redis.Options{
+			Addr:            m.host,
+			Username:        m.username,
+			Password:        m.password,
+			DB:              defaultDB,
+			MaxRetries:      m.maxRetries,
+			MaxRetryBackoff: m.maxRetryBackoff,
+			TLSConfig:       tlsConfig,
+		}), nil
+	}
+}
+
 // Init does metadata and connection parsing
 func (r *StateStore) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	m, err := parseRedisMetadata(metadata)
 	if err != nil {
 		return err
 	}
 	r.metadata = m
 
-	opts := &redis.Options{
-		Addr:            m.host,
-		Password:        m.password,
-		DB:              defaultDB,
-		MaxRetries:      m.maxRetries,
-		MaxRetryBackoff: m.maxRetryBackoff,
-	}
-
-	/* #nosec */
-	if m.enableTLS {
-		opts.TLSConfig = &tls.Config{
-			InsecureSkipVerify: m.enableTLS,
-		}
+	r.client, err = newClient(m)
+	if err != nil {
+		return err
 	}
 
-	r.client = redis.NewClient(opts)
 	_, err = r.client.Ping().Result()
 	if err != nil {
 		return fmt.Errorf("redis store: error connecting to redis at %s: %s", m.host, err)
 	}
 
+	if len(m.queryIndexes) > 0 {
+		err = r.createQueryIndex()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Commands that are not keyed go to any node of a cluster, so strong consistency cannot wait for the replicas of a write
+	if m.redisType == redisTypeCluster {
+		return nil
+	}
+
 	r.replicas, err = r.getConnectedSlaves()
 
 	return err
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Redis state store
+func (r *StateStore) GetComponentMetadata() componentmetadata.ComponentMetadata {
+	return componentmetadata.ComponentMetadata{
+		Fields: []componentmetadata.Field{
+			{Name: host, Type: componentmetadata.TypeString, Required: true},
+			{Name: username, Type: componentmetadata.TypeString},
+			{Name: password, Type: componentmetadata.TypeString},
+			{Name: enableTLS, Type: componentmetadata.TypeBool},
+			{Name: caCert, Type: componentmetadata.TypeString},
+			{Name: clientCert, Type: componentmetadata.TypeString},
+			{Name: clientKey, Type: componentmetadata.TypeString},
+			{Name: insecureSkipVerify, Type: componentmetadata.TypeBool},
+			{Name: maxRetries, Type: componentmetadata.TypeInt, Default: strconv.Itoa(defaultMaxRetries)},
+			// maxRetryBackoff is in nanoseconds
+			{Name: maxRetryBackoff, Type: componentmetadata.TypeInt, Default: strconv.FormatInt(int64(defaultMaxRetryBackoff), 10)},
+			{Name: redisType, Type: componentmetadata.TypeString, Default: redisTypeNode},
+			{Name: sentinelMasterName, Type: componentmetadata.TypeString},
+			{Name: sentinelUsername, Type: componentmetadata.TypeString},
+			{Name: sentinelPassword, Type: componentmetadata.TypeString},
+			{Name: maxRedirects, Type: componentmetadata.TypeInt, Default: strconv.Itoa(defaultMaxRedirects)},
+			{Name: readOnly, Type: componentmetadata.TypeBool},
+			{Name: routeByLatency, Type: componentmetadata.TypeBool},
+			{Name: routeRandomly, Type: componentmetadata.TypeBool},
+			{Name: queryIndexName, Type: componentmetadata.TypeString, Default: defaultQueryIndexName},
+			{Name: queryIndexPrefix, Type: componentmetadata.TypeString},
+			{Name: queryIndexes, Type: componentmetadata.TypeString},
+		},
+		Capabilities: []componentmetadata.Capability{
+			componentmetadata.CapabilityETag, componentmetadata.CapabilityQuery, componentmetadata.CapabilityTransactional,
+		},
+	}
+}
+
+// Ping pings the Redis server, for health checks
+func (r *StateStore) Ping() error {
+	if r.client == nil {
+		return fmt.Errorf("the Redis state store is not initialized")
+	}
+	return r.client.Ping().Err()
+}
+
 func (r *StateStore) getConnectedSlaves() (int, error) {
 	res, err := r.client.DoContext(context.Background(), "INFO", "replication").Result()
 	if err != nil {
@@ -260,7 +492,9 @@ func (r *StateStore) setValue(req *state.SetRequest) error {
 		bt, _ = r.json.Marshal(req.Value)
 	}
 
-	_, err = r.client.DoContext(context.Background(), "EVAL", setQuery, 1, req.Key, ver, bt).Result()
+	// The indexed fields are copied next to the data, so that RediSearch can index them
+	args := append([]interface{}{"EVAL", setQuery, 1, req.Key, ver, bt}, r.indexedFields(bt)...)
+	_, err = r.client.DoContext(context.Background(), args...).Result()
 	if err != nil {
 		return fmt.Errorf("failed to set key %s: %s", req.Key, err)
 	}
@@ -292,7 +526,8 @@ func (r *StateStore) BulkSet(req []state.SetRequest) error {
 	return nil
 }
 
-// Multi performs a transactional operation. succeeds only if all operations succeed, and fails if one or more operations fail
+// Multi performs a transactional operation. succeeds only if all operations succeed, and fails if one or more operations fail.
+// With redisType cluster all keys of the operations must hash to the same slot, for example by sharing a {hash tag}.
 func (r *StateStore) Multi(operations []state.TransactionalRequest) error {
 	pipe := r.client.TxPipeline()
 	for _, o := range operations {
@@ -341,3 +576,131 @@ func (r *StateStore) parseETag(etag string) (int, error) {
 	}
 	return ver, nil
 }
+
+// ListKeys lists the keys of the state one page at a time with SCAN. The first page is listed with an empty cursor,
+// and the returned cursor is empty after the last page. A page may have more or fewer than count keys.
+// The keys that are neither hashes nor strings, such as the streams of the pub/sub, aren't state and are skipped.
+// In cluster mode the masters are scanned one after the other, and the cursor is the index of the master in the
+// sorted addresses of the masters followed by the cursor of its scan.
+func (r *StateStore) ListKeys(cursor string, count int) ([]string, string, error) {
+	nodes, err := r.scannedNodes()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list keys: %s", err)
+	}
+
+	node, c, err := parseListCursor(cursor, len(nodes))
+	if err != nil {
+		return nil, "", err
+	}
+
+	keys, next, err := nodes[node].Scan(c, "", int64(count)).Result()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list keys: %s", err)
+	}
+	keys, err = stateKeys(nodes[node], keys)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list keys: %s", err)
+	}
+
+	// SCAN returns a cursor of 0 after the last page of a node
+	if next == 0 {
+		node++
+		if node == len(nodes) {
+			return keys, "", nil
+		}
+	}
+
+	return keys, formatListCursor(len(nodes), node, next), nil
+}
+
+// scannedNodes returns the nodes that ListKeys scans, which are the masters sorted by address in cluster mode
+func (r *StateStore) scannedNodes() ([]redis.Cmdable, error) {
+	cluster, ok := r.client.(*redis.ClusterClient)
+	if !ok {
+		return []redis.Cmdable{r.client}, nil
+	}
+
+	var lock sync.Mutex
+	var masters []*redis.Client
+	err := cluster.ForEachMaster(func(client *redis.Client) error {
+		lock.Lock()
+		defer lock.Unlock()
+		masters = append(masters, client)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(masters) == 0 {
+		return nil, errors.New("the cluster has no masters")
+	}
+
+	sort.Slice(masters, func(i, j int) bool { return masters[i].Options().Addr < masters[j].Options().Addr })
+	nodes := make([]redis.Cmdable, 0, len(masters))
+	for _, master := range masters {
+		nodes = append(nodes, master)
+	}
+	return nodes, nil
+}
+
+// stateKeys returns the keys that are hashes, which are written by the store, or strings, which it can still read
+func stateKeys(node redis.Cmdable, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return keys, nil
+	}
+
+	pipe := node.Pipeline()
+	types := make([]*redis.StatusCmd, 0, len(keys))
+	for _, key := range keys {
+		types = append(types, pipe.Type(key))
+	}
+	_, err := pipe.Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(keys))
+	for i, key := range keys {
+		switch types[i].Val() {
+		case "hash", "string":
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+// parseListCursor returns the index of the node and the cursor of its scan. The cursors of a single node are the
+// cursor of its scan.
+func parseListCursor(cursor string, nodes int) (int, uint64, error) {
+	if cursor == "" {
+		return 0, 0, nil
+	}
+
+	node := 0
+	scan := cursor
+	if nodes > 1 {
+		parts := strings.SplitN(cursor, ":", 2)
+		if len(parts) != 2 {
+			return 0, 0, fmt.Errorf("invalid cursor '%s'", cursor)
+		}
+		var err error
+		node, err = strconv.Atoi(parts[0])
+		if err != nil || node < 0 || node >= nodes {
+			return 0, 0, fmt.Errorf("invalid cursor '%s'", cursor)
+		}
+		scan = parts[1]
+	}
+
+	c, err := strconv.ParseUint(scan, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cursor '%s'", cursor)
+	}
+	return node, c, nil
+}
+
+func formatListCursor(nodes, node int, scan uint64) string {
+	if nodes > 1 {
+		return strconv.Itoa(node) + ":" + strconv.FormatUint(scan, 10)
+	}
+	return strconv.FormatUint(scan, 10)
+}
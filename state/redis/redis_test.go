@@ -6,9 +6,20 @@
 package redis
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
+	redis "github.com/go-redis/redis/v7"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -85,3 +96,240 @@ func TestParseConnectedSlavs(t *testing.T) {
 		assert.Equal(t, 1, slaves, "connected slaves must be 1")
 	})
 }
+
+func TestParseRedisMetadata(t *testing.T) {
+	t.Run("Node by default", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{host: "localhost:6379"}})
+		assert.Nil(t, err)
+		assert.Equal(t, redisTypeNode, m.redisType)
+		assert.Equal(t, defaultMaxRedirects, m.maxRedirects)
+		client, err := newClient(m)
+		assert.Nil(t, err)
+		_, ok := client.(*redis.Client)
+		assert.True(t, ok)
+	})
+
+	t.Run("Cluster", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{
+			host:           "node1:6379, node2:6379,node3:6379",
+			redisType:      "Cluster",
+			maxRedirects:   "3",
+			readOnly:       "true",
+			routeByLatency: "true",
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, redisTypeCluster, m.redisType)
+		assert.Equal(t, []string{"node1:6379", "node2:6379", "node3:6379"}, m.hosts)
+		assert.Equal(t, 3, m.maxRedirects)
+		assert.True(t, m.readOnly)
+		assert.True(t, m.routeByLatency)
+		assert.False(t, m.routeRandomly)
+		client, err := newClient(m)
+		assert.Nil(t, err)
+		_, ok := client.(*redis.ClusterClient)
+		assert.True(t, ok)
+	})
+
+	t.Run("Sentinel", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{
+			host:               "sentinel1:26379,sentinel2:26379",
+			redisType:          redisTypeSentinel,
+			sentinelMasterName: "mymaster",
+			sentinelPassword:   "secret",
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"sentinel1:26379", "sentinel2:26379"}, m.hosts)
+		assert.Equal(t, "mymaster", m.sentinelMasterName)
+		assert.Equal(t, "secret", m.sentinelPassword)
+		client, err := newClient(m)
+		assert.Nil(t, err)
+		_, ok := client.(*redis.Client)
+		assert.True(t, ok)
+	})
+
+	invalid := map[string]map[string]string{
+		"Without host":             {},
+		"Invalid type":             {host: "localhost:6379", redisType: "ring"},
+		"Sentinel without master":  {host: "localhost:26379", redisType: redisTypeSentinel},
+		"Invalid max redirects":    {host: "localhost:6379", maxRedirects: "many"},
+		"Invalid route by latency": {host: "localhost:6379", routeByLatency: "sometimes"},
+		"Client cert without key":  {host: "localhost:6379", enableTLS: "true", clientCert: "cert"},
+		"CA cert without TLS":      {host: "localhost:6379", caCert: "cert"},
+		"Invalid skip verify":      {host: "localhost:6379", insecureSkipVerify: "maybe"},
+	}
+	for name, properties := range invalid {
+		properties := properties
+		t.Run(name, func(t *testing.T) {
+			_, err := parseRedisMetadata(state.Metadata{Properties: properties})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	t.Run("Disabled", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{host: "localhost:6379"}})
+		assert.Nil(t, err)
+		config, err := newTLSConfig(m)
+		assert.Nil(t, err)
+		assert.Nil(t, config)
+	})
+
+	t.Run("Skips verification without CA cert", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{host: "localhost:6379", enableTLS: "true"}})
+		assert.Nil(t, err)
+		config, err := newTLSConfig(m)
+		assert.Nil(t, err)
+		assert.True(t, config.InsecureSkipVerify)
+		assert.Nil(t, config.RootCAs)
+	})
+
+	t.Run("CA and client certs", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{
+			host:       "localhost:6379",
+			username:   "dapr",
+			enableTLS:  "true",
+			caCert:     certPEM,
+			clientCert: certPEM,
+			clientKey:  keyPEM,
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "dapr", m.username)
+		config, err := newTLSConfig(m)
+		assert.Nil(t, err)
+		assert.False(t, config.InsecureSkipVerify)
+		assert.NotNil(t, config.RootCAs)
+		assert.Len(t, config.Certificates, 1)
+	})
+
+	t.Run("Explicit skip verify", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{
+			host:               "localhost:6379",
+			enableTLS:          "true",
+			caCert:             certPEM,
+			insecureSkipVerify: "true",
+		}})
+		assert.Nil(t, err)
+		config, err := newTLSConfig(m)
+		assert.Nil(t, err)
+		assert.True(t, config.InsecureSkipVerify)
+	})
+
+	t.Run("Invalid CA cert", func(t *testing.T) {
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{host: "localhost:6379", enableTLS: "true", caCert: "not a cert"}})
+		assert.Nil(t, err)
+		_, err = newTLSConfig(m)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Mismatched client key", func(t *testing.T) {
+		_, otherKeyPEM := generateCertificate(t)
+		m, err := parseRedisMetadata(state.Metadata{Properties: map[string]string{
+			host:       "localhost:6379",
+			enableTLS:  "true",
+			clientCert: certPEM,
+			clientKey:  otherKeyPEM,
+		}})
+		assert.Nil(t, err)
+		_, err = newClient(m)
+		assert.NotNil(t, err)
+	})
+}
+
+// generateCertificate returns a PEM encoded self-signed certificate and its key
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
+
+func TestListKeys(t *testing.T) {
+	t.Run("Lists the state keys", func(t *testing.T) {
+		s, err := miniredis.Run()
+		assert.Nil(t, err)
+		defer s.Close()
+		s.HSet("hash", "data", "1")
+		assert.Nil(t, s.Set("string", "2"))
+		_, err = s.Lpush("list", "3")
+		assert.Nil(t, err)
+
+		r := NewRedisStateStore(logger.NewLogger("test"))
+		r.client = redis.NewClient(&redis.Options{Addr: s.Addr()})
+		defer r.client.Close()
+
+		keys, cursor, err := r.ListKeys("", 10)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"hash", "string"}, keys)
+		assert.Equal(t, "", cursor)
+	})
+
+	t.Run("Lists the keys of all the masters of a cluster", func(t *testing.T) {
+		s1, err := miniredis.Run()
+		assert.Nil(t, err)
+		defer s1.Close()
+		s2, err := miniredis.Run()
+		assert.Nil(t, err)
+		defer s2.Close()
+		s1.HSet("a", "data", "1")
+		s2.HSet("b", "data", "2")
+
+		first, second := s1.Addr(), s2.Addr()
+		if second < first {
+			first, second = second, first
+		}
+		r := NewRedisStateStore(logger.NewLogger("test"))
+		r.metadata.redisType = redisTypeCluster
+		r.client = redis.NewClusterClient(&redis.ClusterOptions{
+			ClusterSlots: func() ([]redis.ClusterSlot, error) {
+				return []redis.ClusterSlot{
+					{Start: 0, End: 8191, Nodes: []redis.ClusterNode{{Addr: first}}},
+					{Start: 8192, End: 16383, Nodes: []redis.ClusterNode{{Addr: second}}},
+				}, nil
+			},
+		})
+		defer r.client.Close()
+
+		var keys []string
+		cursor := ""
+		for pages := 0; pages < 10; pages++ {
+			page, next, err := r.ListKeys(cursor, 10)
+			assert.Nil(t, err)
+			keys = append(keys, page...)
+			if next == "" {
+				break
+			}
+			assert.Contains(t, next, ":")
+			cursor = next
+		}
+		assert.ElementsMatch(t, []string{"a", "b"}, keys)
+
+		_, _, err = r.ListKeys("2:0", 10)
+		assert.NotNil(t, err)
+	})
+}
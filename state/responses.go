@@ -11,3 +11,12 @@ type GetResponse struct {
 	ETag     string            `json:"etag,omitempty"`
 	Metadata map[string]string `json:"metadata"`
 }
+
+// BulkGetResponse is the response object for a single key of a bulk get. Error is set when getting the key failed.
+type BulkGetResponse struct {
+	Key      string            `json:"key"`
+	Data     []byte            `json:"data"`
+	ETag     string            `json:"etag,omitempty"`
+	Metadata map[string]string `json:"metadata"`
+	Error    string            `json:"error,omitempty"`
+}
@@ -0,0 +1,550 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package rethinkdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	r "gopkg.in/rethinkdb/rethinkdb-go.v6"
+)
+
+const (
+	addressKey  = "address"
+	databaseKey = "database"
+	tableKey    = "table"
+	usernameKey = "username"
+	passwordKey = "password"
+	timeoutKey  = "timeout"
+	archiveKey  = "archive"
+
+	defaultTable   = "daprstate"
+	defaultTimeout = 10 * time.Second
+
+	idField      = "id"
+	dataField    = "data"
+	etagField    = "etag"
+	deletedField = "deleted"
+)
+
+var errETagMismatch = errors.New("possible etag mismatch")
+
+// RethinkDB is a state store backed by a RethinkDB table, with one document per key.
+// Clients can subscribe to changes of the table as they happen through Changes.
+type RethinkDB struct {
+	session  r.QueryExecutor
+	metadata *rethinkDBMetadata
+
+	logger logger.Logger
+}
+
+type rethinkDBMetadata struct {
+	Address  string
+	Database string
+	Table    string
+	Username string
+	Password string
+	Timeout  time.Duration
+	// Archive soft deletes documents, which are kept in the table and marked as deleted
+	Archive bool
+}
+
+// stateRecord is the document of a key
+type stateRecord struct {
+	ID      string      `rethinkdb:"id"`
+	Data    interface{} `rethinkdb:"data"`
+	ETag    string      `rethinkdb:"etag"`
+	Deleted bool        `rethinkdb:"deleted,omitempty"`
+}
+
+// StateChange is a change of the state of a key
+type StateChange struct {
+	Key  string
+	Data []byte
+	ETag string
+	// Deleted is true when the key was deleted, including soft deletes in archive mode
+	Deleted bool
+	// Created is true when the key did not exist, or was soft deleted, before the change
+	Created bool
+}
+
+type changeRecord struct {
+	NewVal *stateRecord `rethinkdb:"new_val"`
+	OldVal *stateRecord `rethinkdb:"old_val"`
+}
+
+// NewRethinkDBStateStore returns a new RethinkDB state store
+func NewRethinkDBStateStore(logger logger.Logger) *RethinkDB {
+	return &RethinkDB{logger: logger}
+}
+
+// Init parses metadata, connects to RethinkDB and creates the database and table if they do not exist
+func (s *RethinkDB) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
+	meta, err := getRethinkDBMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	session, err := r.Connect(r.ConnectOpts{
+		Address:  meta.Address,
+		Database: meta.Database,
+		Username: meta.Username,
+		Password: meta.Password,
+		Timeout:  meta.Timeout,
+	})
+	if err != nil {
+		return fmt.Errorf("rethinkdb error: failed to connect to %s: %s", meta.Address, err)
+	}
+
+	s.session = session
+	s.metadata = meta
+
+	return s.ensureTable()
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the RethinkDB state store
+func (s *RethinkDB) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: addressKey, Type: metadata.TypeString, Required: true},
+			{Name: databaseKey, Type: metadata.TypeString, Required: true},
+			{Name: tableKey, Type: metadata.TypeString, Default: defaultTable},
+			{Name: usernameKey, Type: metadata.TypeString},
+			{Name: passwordKey, Type: metadata.TypeString},
+			{Name: timeoutKey, Type: metadata.TypeDuration, Default: defaultTimeout.String()},
+			{Name: archiveKey, Type: metadata.TypeBool},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTransactional},
+	}
+}
+
+func (s *RethinkDB) ensureTable() error {
+	var exists bool
+	err := r.DBList().Contains(s.metadata.Database).ReadOne(&exists, s.session)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		s.logger.Infof("creating RethinkDB database %s", s.metadata.Database)
+		_, err = r.DBCreate(s.metadata.Database).RunWrite(s.session)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = r.DB(s.metadata.Database).TableList().Contains(s.metadata.Table).ReadOne(&exists, s.session)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		s.logger.Infof("creating RethinkDB table %s", s.metadata.Table)
+		_, err = r.DB(s.metadata.Database).TableCreate(s.metadata.Table, r.TableCreateOpts{PrimaryKey: idField}).RunWrite(s.session)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get retrieves the document of a key
+func (s *RethinkDB) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	cursor, err := s.table().Get(req.Key).Run(s.session)
+	if err != nil {
+		return nil, fmt.Errorf("rethinkdb error: failed to get key %s: %s", req.Key, err)
+	}
+	defer cursor.Close()
+
+	if cursor.IsNil() {
+		return &state.GetResponse{}, nil
+	}
+
+	var record stateRecord
+	err = cursor.One(&record)
+	if err == r.ErrEmptyResult {
+		return &state.GetResponse{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rethinkdb error: failed to read key %s: %s", req.Key, err)
+	}
+
+	// Soft deleted documents are only kept for their history
+	if record.Deleted {
+		return &state.GetResponse{}, nil
+	}
+
+	data, err := decodeData(record.Data)
+	if err != nil {
+		return nil, fmt.Errorf("rethinkdb error: failed to read key %s: %s", req.Key, err)
+	}
+
+	return &state.GetResponse{
+		Data: data,
+		ETag: record.ETag,
+	}, nil
+}
+
+// Set saves the document of a key
+func (s *RethinkDB) Set(req *state.SetRequest) error {
+	return state.SetWithRetries(s.setValue, req)
+}
+
+// setValue writes the document of a key with a new etag. Writes with an etag or first-write
+// concurrency are conditional. The condition is checked by the server as part of the write.
+func (s *RethinkDB) setValue(req *state.SetRequest) error {
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeData(req.Value)
+	if err != nil {
+		return fmt.Errorf("rethinkdb error: failed to set key %s: %s", req.Key, err)
+	}
+
+	doc := map[string]interface{}{
+		idField:   req.Key,
+		dataField: data,
+		etagField: uuid.New().String(),
+	}
+
+	var term r.Term
+	switch {
+	case req.ETag != "" && req.Options.Concurrency != state.LastWrite:
+		term = s.table().Get(req.Key).Replace(func(row r.Term) interface{} {
+			return r.Branch(isLive(row).And(row.Field(etagField).Eq(req.ETag)), doc, r.Error(errETagMismatch.Error()))
+		})
+	case req.Options.Concurrency == state.FirstWrite:
+		// A soft deleted document does not exist, so it can be replaced by a first write
+		term = s.table().Get(req.Key).Replace(func(row r.Term) interface{} {
+			return r.Branch(isLive(row), r.Error(errETagMismatch.Error()), doc)
+		})
+	default:
+		term = s.table().Insert(doc, r.InsertOpts{Conflict: "replace"})
+	}
+
+	_, err = term.RunWrite(s.session)
+	if err != nil {
+		return fmt.Errorf("rethinkdb error: failed to set key %s: %s", req.Key, err)
+	}
+
+	return nil
+}
+
+// BulkSet performs a bulk set operation
+func (s *RethinkDB) BulkSet(req []state.SetRequest) error {
+	for i := range req {
+		err := s.Set(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the document of a key, or marks it as deleted in archive mode
+func (s *RethinkDB) Delete(req *state.DeleteRequest) error {
+	return state.DeleteWithRetries(s.deleteValue, req)
+}
+
+func (s *RethinkDB) deleteValue(req *state.DeleteRequest) error {
+	err := state.CheckDeleteRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	var replacement interface{}
+	if s.metadata.Archive {
+		replacement = map[string]interface{}{
+			deletedField: true,
+			etagField:    uuid.New().String(),
+		}
+	}
+
+	var term r.Term
+	switch {
+	case req.ETag != "" && req.Options.Concurrency != state.LastWrite:
+		term = s.table().Get(req.Key).Replace(func(row r.Term) interface{} {
+			return r.Branch(isLive(row).And(row.Field(etagField).Eq(req.ETag)), deletedDoc(row, replacement), r.Error(errETagMismatch.Error()))
+		})
+	case s.metadata.Archive:
+		term = s.table().Get(req.Key).Replace(func(row r.Term) interface{} {
+			return r.Branch(isLive(row), deletedDoc(row, replacement), row)
+		})
+	default:
+		term = s.table().Get(req.Key).Delete()
+	}
+
+	_, err = term.RunWrite(s.session)
+	if err != nil {
+		return fmt.Errorf("rethinkdb error: failed to delete key %s: %s", req.Key, err)
+	}
+
+	return nil
+}
+
+// deletedDoc returns the document that replaces a deleted document. Without archive mode the document is removed.
+func deletedDoc(row r.Term, replacement interface{}) interface{} {
+	if replacement == nil {
+		return nil
+	}
+
+	return row.Merge(replacement)
+}
+
+// BulkDelete performs a bulk delete operation
+func (s *RethinkDB) BulkDelete(req []state.DeleteRequest) error {
+	for i := range req {
+		err := s.Delete(&req[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Multi applies the operations in order. RethinkDB only guarantees atomicity for the document of a single key,
+// so the documents of all keys are read first and written back if an operation fails.
+// Writes by other clients between the operations and the rollback are overwritten by the rollback.
+func (s *RethinkDB) Multi(reqs []state.TransactionalRequest) error {
+	keys := make([]interface{}, 0, len(reqs))
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			setReq, ok := req.Request.(state.SetRequest)
+			if !ok {
+				return fmt.Errorf("expecting set request")
+			}
+			keys = append(keys, setReq.Key)
+
+		case state.Delete:
+			delReq, ok := req.Request.(state.DeleteRequest)
+			if !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+			keys = append(keys, delReq.Key)
+
+		default:
+			return fmt.Errorf("unsupported operation: %s", req.Operation)
+		}
+	}
+
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	var previous []map[string]interface{}
+	err := s.table().GetAll(keys...).ReadAll(&previous, s.session)
+	if err != nil {
+		return fmt.Errorf("rethinkdb error: failed to read keys of transaction: %s", err)
+	}
+
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			setReq := req.Request.(state.SetRequest)
+			err = s.setValue(&setReq)
+		case state.Delete:
+			delReq := req.Request.(state.DeleteRequest)
+			err = s.deleteValue(&delReq)
+		}
+
+		if err != nil {
+			s.rollback(keys, previous)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollback restores the documents of keys to the documents read before a transaction
+func (s *RethinkDB) rollback(keys []interface{}, previous []map[string]interface{}) {
+	existing := map[string]map[string]interface{}{}
+	for _, doc := range previous {
+		if id, ok := doc[idField].(string); ok {
+			existing[id] = doc
+		}
+	}
+
+	for _, key := range keys {
+		id := key.(string)
+
+		var term r.Term
+		if doc, ok := existing[id]; ok {
+			term = s.table().Get(id).Replace(doc)
+		} else {
+			term = s.table().Get(id).Delete()
+		}
+
+		_, err := term.RunWrite(s.session)
+		if err != nil {
+			s.logger.Errorf("rethinkdb error: failed to roll back key %s: %s", id, err)
+		}
+	}
+}
+
+// Changes calls handler with every change to the state of a key until the context is cancelled,
+// which allows the table to be used as a source of events.
+func (s *RethinkDB) Changes(ctx context.Context, handler func(*StateChange) error) error {
+	cursor, err := s.table().Changes().Run(s.session, r.RunOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("rethinkdb error: failed to subscribe to changes: %s", err)
+	}
+	defer cursor.Close()
+
+	var change changeRecord
+	for cursor.Next(&change) {
+		stateChange, err := toStateChange(&change)
+		if err != nil {
+			return err
+		}
+
+		err = handler(stateChange)
+		if err != nil {
+			return err
+		}
+
+		change = changeRecord{}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return cursor.Err()
+}
+
+func toStateChange(change *changeRecord) (*StateChange, error) {
+	if change.NewVal == nil || change.NewVal.Deleted {
+		record := change.NewVal
+		if record == nil {
+			record = change.OldVal
+		}
+		if record == nil {
+			return nil, fmt.Errorf("rethinkdb error: change without a document")
+		}
+
+		return &StateChange{Key: record.ID, ETag: record.ETag, Deleted: true}, nil
+	}
+
+	data, err := decodeData(change.NewVal.Data)
+	if err != nil {
+		return nil, fmt.Errorf("rethinkdb error: failed to read change of key %s: %s", change.NewVal.ID, err)
+	}
+
+	return &StateChange{
+		Key:     change.NewVal.ID,
+		Data:    data,
+		ETag:    change.NewVal.ETag,
+		Created: change.OldVal == nil || change.OldVal.Deleted,
+	}, nil
+}
+
+// Close closes the connection to RethinkDB
+func (s *RethinkDB) Close() error {
+	if session, ok := s.session.(*r.Session); ok {
+		return session.Close()
+	}
+
+	return nil
+}
+
+func (s *RethinkDB) table() r.Term {
+	return r.DB(s.metadata.Database).Table(s.metadata.Table)
+}
+
+// isLive returns a term that is true if a row exists and is not soft deleted
+func isLive(row r.Term) r.Term {
+	return row.Ne(nil).And(row.Field(deletedField).Default(false).Not())
+}
+
+// encodeData returns the value to store for a state value. JSON is stored as a document,
+// so that it can be queried and is readable in changefeeds. Other values are stored as binary.
+func encodeData(value interface{}) (interface{}, error) {
+	if b, ok := value.([]byte); ok {
+		if json.Valid(b) {
+			return r.JSON(string(b)), nil
+		}
+
+		return b, nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.JSON(string(b)), nil
+}
+
+// decodeData returns the state value of the data of a document
+func decodeData(data interface{}) ([]byte, error) {
+	if b, ok := data.([]byte); ok {
+		return b, nil
+	}
+
+	return json.Marshal(data)
+}
+
+func getRethinkDBMetadata(metadata state.Metadata) (*rethinkDBMetadata, error) {
+	meta := rethinkDBMetadata{
+		Table:   defaultTable,
+		Timeout: defaultTimeout,
+	}
+
+	if val, ok := metadata.Properties[addressKey]; ok && val != "" {
+		meta.Address = val
+	} else {
+		return nil, fmt.Errorf("rethinkdb error: missing address")
+	}
+
+	if val, ok := metadata.Properties[databaseKey]; ok && val != "" {
+		meta.Database = val
+	} else {
+		return nil, fmt.Errorf("rethinkdb error: missing database")
+	}
+
+	if val, ok := metadata.Properties[tableKey]; ok && val != "" {
+		meta.Table = val
+	}
+
+	meta.Username = metadata.Properties[usernameKey]
+	meta.Password = metadata.Properties[passwordKey]
+
+	if val, ok := metadata.Properties[timeoutKey]; ok && val != "" {
+		timeout, err := time.ParseDuration(val)
+		if err != nil {
+			return nil, fmt.Errorf("rethinkdb error: invalid timeout %s: %s", val, err)
+		}
+		meta.Timeout = timeout
+	}
+
+	if val, ok := metadata.Properties[archiveKey]; ok && val != "" {
+		archive, err := strconv.ParseBool(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("rethinkdb error: invalid archive %s: %s", val, err)
+		}
+		meta.Archive = archive
+	}
+
+	return &meta, nil
+}
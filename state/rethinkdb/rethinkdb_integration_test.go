@@ -0,0 +1,106 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package rethinkdb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	addressEnvKey = "DAPR_TEST_RETHINKDB_ADDRESS" // Environment variable containing the address of RethinkDB
+)
+
+func TestRethinkDBIntegration(t *testing.T) {
+	address := os.Getenv(addressEnvKey)
+	if address == "" {
+		t.Skipf("RethinkDB state integration tests skipped. To enable define the address using environment variable '%s' (example 'export %s=\"localhost:28015\")", addressEnvKey, addressEnvKey)
+	}
+
+	s := NewRethinkDBStateStore(logger.NewLogger("test"))
+	err := s.Init(state.Metadata{
+		Properties: map[string]string{
+			addressKey:  address,
+			databaseKey: "dapr_test",
+			archiveKey:  "true",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		s.Close()
+	})
+
+	t.Run("Set with etag and soft delete", func(t *testing.T) {
+		key := uuid.New().String()
+		assert.Nil(t, s.Set(&state.SetRequest{Key: key, Value: []byte(`{"color":"red"}`)}))
+
+		resp, err := s.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"color":"red"}`, string(resp.Data))
+
+		assert.NotNil(t, s.Set(&state.SetRequest{Key: key, Value: []byte(`{"color":"blue"}`), ETag: "not-the-etag"}))
+		assert.Nil(t, s.Set(&state.SetRequest{Key: key, Value: []byte(`{"color":"blue"}`), ETag: resp.ETag}))
+
+		assert.Nil(t, s.Delete(&state.DeleteRequest{Key: key}))
+		resp, err = s.Get(&state.GetRequest{Key: key})
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Data)
+
+		// A soft deleted key can be written again with first-write concurrency
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		assert.Nil(t, s.Set(&state.SetRequest{Key: key, Value: []byte(`"green"`), Options: firstWrite}))
+		assert.NotNil(t, s.Set(&state.SetRequest{Key: key, Value: []byte(`"green"`), Options: firstWrite}))
+	})
+
+	t.Run("Multi rolls back on etag mismatch", func(t *testing.T) {
+		existing := uuid.New().String()
+		assert.Nil(t, s.Set(&state.SetRequest{Key: existing, Value: []byte(`"red"`)}))
+
+		added := uuid.New().String()
+		err := s.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: added, Value: []byte(`"blue"`)}},
+			{Operation: state.Upsert, Request: state.SetRequest{Key: existing, Value: []byte(`"blue"`), ETag: "not-the-etag"}},
+		})
+		assert.NotNil(t, err)
+
+		resp, err := s.Get(&state.GetRequest{Key: added})
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		key := uuid.New().String()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		changes := make(chan *StateChange, 10)
+		go s.Changes(ctx, func(change *StateChange) error {
+			if change.Key == key {
+				changes <- change
+			}
+			return nil
+		})
+
+		// Give the changefeed time to start
+		time.Sleep(time.Second)
+		assert.Nil(t, s.Set(&state.SetRequest{Key: key, Value: []byte(`"red"`)}))
+		assert.Nil(t, s.Delete(&state.DeleteRequest{Key: key}))
+
+		set := <-changes
+		assert.Equal(t, `"red"`, string(set.Data))
+		deleted := <-changes
+		assert.True(t, deleted.Deleted)
+	})
+}
@@ -0,0 +1,245 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package rethinkdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	r "gopkg.in/rethinkdb/rethinkdb-go.v6"
+)
+
+func newMockedStore(mock *r.Mock, archive bool) *RethinkDB {
+	return &RethinkDB{
+		session: mock,
+		metadata: &rethinkDBMetadata{
+			Database: "dapr",
+			Table:    defaultTable,
+			Archive:  archive,
+		},
+		logger: logger.NewLogger("test"),
+	}
+}
+
+func TestGetRethinkDBMetadata(t *testing.T) {
+	t.Run("With required properties", func(t *testing.T) {
+		meta, err := getRethinkDBMetadata(state.Metadata{Properties: map[string]string{
+			addressKey:  "localhost:28015",
+			databaseKey: "dapr",
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "localhost:28015", meta.Address)
+		assert.Equal(t, "dapr", meta.Database)
+		assert.Equal(t, defaultTable, meta.Table)
+		assert.Equal(t, defaultTimeout, meta.Timeout)
+		assert.False(t, meta.Archive)
+	})
+
+	t.Run("With all properties", func(t *testing.T) {
+		meta, err := getRethinkDBMetadata(state.Metadata{Properties: map[string]string{
+			addressKey:  "localhost:28015",
+			databaseKey: "dapr",
+			tableKey:    "orders",
+			usernameKey: "admin",
+			passwordKey: "secret",
+			timeoutKey:  "30s",
+			archiveKey:  "true",
+		}})
+		assert.Nil(t, err)
+		assert.Equal(t, "orders", meta.Table)
+		assert.Equal(t, "admin", meta.Username)
+		assert.Equal(t, "secret", meta.Password)
+		assert.Equal(t, 30*time.Second, meta.Timeout)
+		assert.True(t, meta.Archive)
+	})
+
+	t.Run("Without address", func(t *testing.T) {
+		_, err := getRethinkDBMetadata(state.Metadata{Properties: map[string]string{databaseKey: "dapr"}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Without database", func(t *testing.T) {
+		_, err := getRethinkDBMetadata(state.Metadata{Properties: map[string]string{addressKey: "localhost:28015"}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("With invalid archive", func(t *testing.T) {
+		_, err := getRethinkDBMetadata(state.Metadata{Properties: map[string]string{
+			addressKey:  "localhost:28015",
+			databaseKey: "dapr",
+			archiveKey:  "sometimes",
+		}})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestGet(t *testing.T) {
+	t.Run("Document exists", func(t *testing.T) {
+		mock := r.NewMock()
+		mock.On(r.DB("dapr").Table(defaultTable).Get("key")).Return(map[string]interface{}{
+			idField:   "key",
+			dataField: map[string]interface{}{"color": "red"},
+			etagField: "etag",
+		}, nil)
+		s := newMockedStore(mock, false)
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Equal(t, `{"color":"red"}`, string(resp.Data))
+		assert.Equal(t, "etag", resp.ETag)
+	})
+
+	t.Run("Document does not exist", func(t *testing.T) {
+		mock := r.NewMock()
+		mock.On(r.DB("dapr").Table(defaultTable).Get("key")).Return(nil, nil)
+		s := newMockedStore(mock, false)
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Data)
+	})
+
+	t.Run("Document is soft deleted", func(t *testing.T) {
+		mock := r.NewMock()
+		mock.On(r.DB("dapr").Table(defaultTable).Get("key")).Return(map[string]interface{}{
+			idField:      "key",
+			dataField:    "red",
+			etagField:    "etag",
+			deletedField: true,
+		}, nil)
+		s := newMockedStore(mock, true)
+
+		resp, err := s.Get(&state.GetRequest{Key: "key"})
+		assert.Nil(t, err)
+		assert.Nil(t, resp.Data)
+	})
+}
+
+func TestSet(t *testing.T) {
+	t.Run("Upserts the document", func(t *testing.T) {
+		mock := r.NewMock()
+		mock.On(r.DB("dapr").Table(defaultTable).Insert(r.MockAnything(), r.InsertOpts{Conflict: "replace"})).Return(r.WriteResponse{Inserted: 1}, nil)
+		s := newMockedStore(mock, false)
+
+		err := s.Set(&state.SetRequest{Key: "key", Value: []byte(`{"color":"red"}`)})
+		assert.Nil(t, err)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("Returns a mismatch for a failed condition", func(t *testing.T) {
+		mock := r.NewMock()
+		mock.On(r.DB("dapr").Table(defaultTable).Get("key").Replace(r.MockAnything())).Return(r.WriteResponse{
+			Errors:     1,
+			FirstError: errETagMismatch.Error(),
+		}, nil)
+		s := newMockedStore(mock, false)
+
+		err := s.Set(&state.SetRequest{Key: "key", Value: []byte(`{"color":"red"}`), ETag: "etag"})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), errETagMismatch.Error())
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("Removes the document", func(t *testing.T) {
+		mock := r.NewMock()
+		mock.On(r.DB("dapr").Table(defaultTable).Get("key").Delete()).Return(r.WriteResponse{Deleted: 1}, nil)
+		s := newMockedStore(mock, false)
+
+		err := s.Delete(&state.DeleteRequest{Key: "key"})
+		assert.Nil(t, err)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("Soft deletes the document in archive mode", func(t *testing.T) {
+		mock := r.NewMock()
+		mock.On(r.DB("dapr").Table(defaultTable).Get("key").Replace(r.MockAnything())).Return(r.WriteResponse{Replaced: 1}, nil)
+		s := newMockedStore(mock, true)
+
+		err := s.Delete(&state.DeleteRequest{Key: "key"})
+		assert.Nil(t, err)
+		mock.AssertExpectations(t)
+	})
+}
+
+func TestMultiValidation(t *testing.T) {
+	s := newMockedStore(r.NewMock(), false)
+
+	invalid := map[string]state.TransactionalRequest{
+		"Invalid operation":          {Operation: "Something invalid", Request: state.SetRequest{Key: "key"}},
+		"Upsert with delete request": {Operation: state.Upsert, Request: state.DeleteRequest{Key: "key"}},
+		"Delete with set request":    {Operation: state.Delete, Request: state.SetRequest{Key: "key"}},
+	}
+	for name, req := range invalid {
+		req := req
+		t.Run(name, func(t *testing.T) {
+			err := s.Multi([]state.TransactionalRequest{req})
+			assert.NotNil(t, err)
+		})
+	}
+
+	t.Run("No requests", func(t *testing.T) {
+		assert.Nil(t, s.Multi(nil))
+	})
+}
+
+func TestDecodeData(t *testing.T) {
+	data, err := decodeData([]byte{0xde, 0xad})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xde, 0xad}, data)
+
+	data, err = decodeData("red")
+	assert.Nil(t, err)
+	assert.Equal(t, `"red"`, string(data))
+}
+
+func TestToStateChange(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		change, err := toStateChange(&changeRecord{
+			NewVal: &stateRecord{ID: "key", Data: map[string]interface{}{"color": "red"}, ETag: "2"},
+			OldVal: &stateRecord{ID: "key", Data: map[string]interface{}{"color": "blue"}, ETag: "1"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &StateChange{Key: "key", Data: []byte(`{"color":"red"}`), ETag: "2"}, change)
+	})
+
+	t.Run("Insert", func(t *testing.T) {
+		change, err := toStateChange(&changeRecord{
+			NewVal: &stateRecord{ID: "key", Data: "red", ETag: "1"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &StateChange{Key: "key", Data: []byte(`"red"`), ETag: "1", Created: true}, change)
+	})
+
+	t.Run("Set after soft delete", func(t *testing.T) {
+		change, err := toStateChange(&changeRecord{
+			NewVal: &stateRecord{ID: "key", Data: "red", ETag: "3"},
+			OldVal: &stateRecord{ID: "key", Data: "red", ETag: "2", Deleted: true},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &StateChange{Key: "key", Data: []byte(`"red"`), ETag: "3", Created: true}, change)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		change, err := toStateChange(&changeRecord{
+			OldVal: &stateRecord{ID: "key", Data: "red", ETag: "1"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &StateChange{Key: "key", ETag: "1", Deleted: true}, change)
+	})
+
+	t.Run("Soft delete", func(t *testing.T) {
+		change, err := toStateChange(&changeRecord{
+			NewVal: &stateRecord{ID: "key", Data: "red", ETag: "2", Deleted: true},
+			OldVal: &stateRecord{ID: "key", Data: "red", ETag: "1"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, &StateChange{Key: "key", ETag: "2", Deleted: true}, change)
+	})
+}
@@ -0,0 +1,16 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build cgo
+// +build cgo
+
+package sqlite
+
+import (
+	// Registers the go-sqlite3 driver, which uses the SQLite C library through cgo
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const driverAvailable = true
@@ -0,0 +1,12 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+//go:build !cgo
+// +build !cgo
+
+package sqlite
+
+// The go-sqlite3 driver requires cgo, so builds without it cannot open SQLite databases
+const driverAvailable = false
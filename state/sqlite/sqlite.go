@@ -0,0 +1,123 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+// SQLite state store, backed by a local database file
+type SQLite struct {
+	logger   logger.Logger
+	dbaccess dbAccess
+}
+
+// NewSQLiteStateStore creates a new instance of SQLite state store
+func NewSQLiteStateStore(logger logger.Logger) *SQLite {
+	dba := newSQLiteDBAccess(logger)
+	return newSQLiteStateStore(logger, dba)
+}
+
+// newSQLiteStateStore creates a new instance of a SQLite state store.
+// This unexported constructor allows injecting a dbAccess instance for unit testing.
+func newSQLiteStateStore(logger logger.Logger, dba dbAccess) *SQLite {
+	return &SQLite{
+		logger:   logger,
+		dbaccess: dba,
+	}
+}
+
+// Init initializes the SQLite state store
+func (s *SQLite) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+	return s.dbaccess.Init(metadata)
+}
+
+// GetComponentMetadata returns the metadata fields and the capabilities of the SQLite state store
+func (s *SQLite) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionStringKey, Type: metadata.TypeString, Required: true},
+			{Name: tableNameKey, Type: metadata.TypeString, Default: defaultTableName},
+			{Name: busyTimeoutKey, Type: metadata.TypeDuration, Default: defaultBusyTimeout.String()},
+			{Name: cleanupIntervalKey, Type: metadata.TypeDuration, Default: defaultCleanupInterval.String()},
+			{Name: state.DefaultTTLInSecondsKey, Type: metadata.TypeInt},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL, metadata.CapabilityTransactional},
+	}
+}
+
+// Delete removes an entity from the store
+func (s *SQLite) Delete(req *state.DeleteRequest) error {
+	return s.dbaccess.Delete(req)
+}
+
+// BulkDelete removes multiple entries from the store
+func (s *SQLite) BulkDelete(req []state.DeleteRequest) error {
+	return s.dbaccess.BulkDelete(req)
+}
+
+// Get returns an entity from store
+func (s *SQLite) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return s.dbaccess.Get(req)
+}
+
+// Set adds/updates an entity on store
+func (s *SQLite) Set(req *state.SetRequest) error {
+	return s.dbaccess.Set(req)
+}
+
+// BulkSet adds/updates multiple entities on store
+func (s *SQLite) BulkSet(req []state.SetRequest) error {
+	return s.dbaccess.BulkSet(req)
+}
+
+// Multi handles multiple transactions. Implements TransactionalStore.
+// Operations are applied in order and atomically.
+func (s *SQLite) Multi(reqs []state.TransactionalRequest) error {
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			if _, ok := req.Request.(state.SetRequest); !ok {
+				return fmt.Errorf("expecting set request")
+			}
+
+		case state.Delete:
+			if _, ok := req.Request.(state.DeleteRequest); !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+
+		default:
+			return fmt.Errorf("unsupported operation: %s", req.Operation)
+		}
+	}
+
+	if len(reqs) > 0 {
+		return s.dbaccess.ExecuteMulti(reqs)
+	}
+
+	return nil
+}
+
+// Ping reports whether the database can be reached, for health checks
+func (s *SQLite) Ping() error {
+	return s.dbaccess.Ping()
+}
+
+// Close implements io.Closer
+func (s *SQLite) Close() error {
+	if s.dbaccess != nil {
+		return s.dbaccess.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package sqlite
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/conformance"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fake implementation of interface sqlite.dbaccess
+type fakeDBaccess struct {
+	logger        logger.Logger
+	initExecuted  bool
+	multiExecuted bool
+	pingErr       error
+}
+
+func (f *fakeDBaccess) Init(metadata state.Metadata) error {
+	f.initExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Set(req *state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	return nil, nil
+}
+
+func (f *fakeDBaccess) Delete(req *state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkSet(req []state.SetRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) BulkDelete(req []state.DeleteRequest) error {
+	return nil
+}
+
+func (f *fakeDBaccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	f.multiExecuted = true
+	return nil
+}
+
+func (f *fakeDBaccess) Ping() error {
+	return f.pingErr
+}
+
+func (f *fakeDBaccess) Close() error {
+	return nil
+}
+
+func TestInitRunsDBAccessInit(t *testing.T) {
+	logger := logger.NewLogger("test")
+	fake := &fakeDBaccess{logger: logger}
+	s := newSQLiteStateStore(logger, fake)
+	assert.Nil(t, s.Init(state.Metadata{}))
+	assert.True(t, fake.initExecuted)
+}
+
+func TestPingReturnsDBAccessResult(t *testing.T) {
+	logger := logger.NewLogger("test")
+	fake := &fakeDBaccess{logger: logger}
+	s := newSQLiteStateStore(logger, fake)
+	assert.Nil(t, s.Ping())
+
+	fake.pingErr = errors.New("connection refused")
+	assert.Equal(t, fake.pingErr, s.Ping())
+}
+
+func TestInvalidMultiRequestsAreNotExecuted(t *testing.T) {
+	logger := logger.NewLogger("test")
+	fake := &fakeDBaccess{logger: logger}
+	s := newSQLiteStateStore(logger, fake)
+
+	err := s.Multi([]state.TransactionalRequest{{Operation: state.Upsert, Request: state.DeleteRequest{Key: "k"}}})
+	assert.NotNil(t, err)
+	err = s.Multi([]state.TransactionalRequest{{Operation: "Something invalid", Request: state.SetRequest{Key: "k"}}})
+	assert.NotNil(t, err)
+	assert.False(t, fake.multiExecuted)
+
+	err = s.Multi([]state.TransactionalRequest{{Operation: state.Delete, Request: state.DeleteRequest{Key: "k"}}})
+	assert.Nil(t, err)
+	assert.True(t, fake.multiExecuted)
+}
+
+func TestInitValidation(t *testing.T) {
+	invalid := map[string]map[string]string{
+		"Missing connection string": {},
+		"Invalid table name":        {connectionStringKey: ":memory:", tableNameKey: "state; DROP TABLE users"},
+		"Invalid busy timeout":      {connectionStringKey: ":memory:", busyTimeoutKey: "5"},
+		"Invalid cleanup interval":  {connectionStringKey: ":memory:", cleanupIntervalKey: "often"},
+	}
+	for name, properties := range invalid {
+		properties := properties
+		t.Run(name, func(t *testing.T) {
+			dba := newSQLiteDBAccess(logger.NewLogger("test"))
+			err := dba.Init(state.Metadata{Properties: properties})
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestWithConnectionParams(t *testing.T) {
+	assert.Equal(t, "data.db?_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate", withConnectionParams("data.db", 5*time.Second))
+	assert.Equal(t, "file:data.db?cache=shared&_journal_mode=WAL&_busy_timeout=100&_txlock=immediate", withConnectionParams("file:data.db?cache=shared", 100*time.Millisecond))
+}
+
+func TestSQLiteStore(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	t.Run("CRUD conformance", func(t *testing.T) {
+		conformance.CRUD(t, s)
+	})
+
+	t.Run("Delete item that does not exist fails", func(t *testing.T) {
+		assert.NotNil(t, s.Delete(&state.DeleteRequest{Key: randomKey()}))
+	})
+
+	t.Run("Transactions conformance", func(t *testing.T) {
+		conformance.Transactions(t, s)
+	})
+
+	t.Run("Expired items are not returned and are cleaned up", func(t *testing.T) {
+		key := randomKey()
+		assert.Nil(t, s.Set(&state.SetRequest{Key: key, Value: "expiring", Metadata: map[string]string{ttlInSecondsKey: "1"}}))
+		assert.NotNil(t, getItem(t, s, key).Data)
+
+		dba := s.dbaccess.(*sqliteDBAccess)
+		_, err := dba.db.Exec(`UPDATE "state" SET expiration_time = datetime('now', '-1 seconds') WHERE key = ?`, key)
+		assert.Nil(t, err)
+		assert.Nil(t, getItem(t, s, key).Data)
+
+		// An expired key can be written again with first-write concurrency
+		firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+		assert.Nil(t, s.Set(&state.SetRequest{Key: key, Value: "again", Options: firstWrite, Metadata: map[string]string{ttlInSecondsKey: "1"}}))
+		_, err = dba.db.Exec(`UPDATE "state" SET expiration_time = datetime('now', '-1 seconds') WHERE key = ?`, key)
+		assert.Nil(t, err)
+
+		assert.Nil(t, dba.cleanupExpiredData())
+		var count int
+		assert.Nil(t, dba.db.QueryRow(`SELECT COUNT(*) FROM "state" WHERE key = ?`, key).Scan(&count))
+		assert.Equal(t, 0, count)
+	})
+}
+
+func TestSQLiteTTLConformance(t *testing.T) {
+	s := newTestStore(t)
+	defer s.Close()
+
+	dba := s.dbaccess.(*sqliteDBAccess)
+	conformance.TTL(t, s, func(key string) {
+		_, err := dba.db.Exec(`UPDATE "state" SET expiration_time = datetime('now', '-1 seconds') WHERE key = ? AND expiration_time IS NOT NULL`, key)
+		assert.Nil(t, err)
+	})
+}
+
+// newTestStore opens a store on a database file in a temporary directory.
+// The test is skipped when the driver is not available, since it requires cgo.
+func newTestStore(t *testing.T) *SQLite {
+	dir, err := ioutil.TempDir("", "dapr-sqlite")
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	s := NewSQLiteStateStore(logger.NewLogger("test"))
+	err = s.Init(state.Metadata{
+		Properties: map[string]string{connectionStringKey: filepath.Join(dir, "state.db")},
+	})
+	if err != nil && strings.Contains(err.Error(), "cgo") {
+		t.Skipf("SQLite state store tests skipped: %s", err)
+	}
+	assert.Nil(t, err)
+
+	return s
+}
+
+func getItem(t *testing.T, s *SQLite, key string) *state.GetResponse {
+	response, err := s.Get(&state.GetRequest{Key: key})
+	assert.Nil(t, err)
+	assert.NotNil(t, response)
+	return response
+}
+
+func randomKey() string {
+	return uuid.New().String()
+}
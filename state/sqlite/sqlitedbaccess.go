@@ -0,0 +1,380 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/google/uuid"
+)
+
+const (
+	connectionStringKey        = "connectionString"
+	tableNameKey               = "tableName"
+	busyTimeoutKey             = "busyTimeout"
+	cleanupIntervalKey         = "cleanupInterval"
+	ttlInSecondsKey            = state.TTLInSecondsKey
+	errMissingConnectionString = "missing connection string"
+	defaultTableName           = "state"
+	defaultBusyTimeout         = 5 * time.Second
+	defaultCleanupInterval     = time.Hour
+
+	// notExpiredCondition filters out rows that have expired but have not been cleaned up yet
+	notExpiredCondition = "(expiration_time IS NULL OR expiration_time > datetime('now'))"
+)
+
+var errNoRowsAffected = errors.New("database operation failed: no rows match given key and etag")
+
+// sqliteDBAccess implements dbaccess
+type sqliteDBAccess struct {
+	logger           logger.Logger
+	metadata         state.Metadata
+	db               *sql.DB
+	connectionString string
+	tableName        string
+	defaultTTL       int
+	closeCh          chan struct{}
+}
+
+// newSQLiteDBAccess creates a new instance of sqliteDBAccess
+func newSQLiteDBAccess(logger logger.Logger) *sqliteDBAccess {
+	logger.Debug("Instantiating new SQLite state store")
+	return &sqliteDBAccess{
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// Init opens the database file in WAL mode and ensures that the state table exists
+func (s *sqliteDBAccess) Init(metadata state.Metadata) error {
+	s.logger.Debug("Initializing SQLite state store")
+	s.metadata = metadata
+
+	if val, ok := metadata.Properties[connectionStringKey]; ok && val != "" {
+		s.connectionString = val
+	} else {
+		s.logger.Error("Missing SQLite connection string")
+		return fmt.Errorf(errMissingConnectionString)
+	}
+
+	s.tableName = defaultTableName
+	if val, ok := metadata.Properties[tableNameKey]; ok && val != "" {
+		if !sqlutil.IsValidName(val) {
+			return fmt.Errorf("invalid table name '%s', accepted characters are (A-Z, a-z, 0-9, _)", val)
+		}
+		s.tableName = val
+	}
+
+	busyTimeout, err := parseDuration(metadata.Properties, busyTimeoutKey, defaultBusyTimeout)
+	if err != nil {
+		return err
+	}
+
+	cleanupInterval, err := parseDuration(metadata.Properties, cleanupIntervalKey, defaultCleanupInterval)
+	if err != nil {
+		return err
+	}
+
+	s.defaultTTL, err = state.ParseDefaultTTL(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
+	if !driverAvailable {
+		return fmt.Errorf("the SQLite state store requires a build with cgo enabled")
+	}
+
+	db, err := sql.Open("sqlite3", withConnectionParams(s.connectionString, busyTimeout))
+	if err != nil {
+		s.logger.Error(err)
+		return err
+	}
+
+	s.db = db
+
+	pingErr := db.Ping()
+	if pingErr != nil {
+		return pingErr
+	}
+
+	err = s.ensureStateTable()
+	if err != nil {
+		return err
+	}
+
+	if cleanupInterval > 0 {
+		s.scheduleCleanupExpiredData(cleanupInterval)
+	}
+
+	return nil
+}
+
+// withConnectionParams adds the driver parameters that every connection is opened with to the connection string.
+// WAL mode lets reads run while another connection writes, and transactions take the write lock when they
+// begin, so that concurrent transactions wait for the busy timeout instead of failing when they write.
+func withConnectionParams(connectionString string, busyTimeout time.Duration) string {
+	separator := "?"
+	if strings.Contains(connectionString, "?") {
+		separator = "&"
+	}
+
+	return fmt.Sprintf("%s%s_journal_mode=WAL&_busy_timeout=%d&_txlock=immediate", connectionString, separator, busyTimeout.Milliseconds())
+}
+
+// Set makes an insert or update to the database.
+func (s *sqliteDBAccess) Set(req *state.SetRequest) error {
+	return state.SetWithRetries(func(req *state.SetRequest) error {
+		return s.setValue(s.db, req)
+	}, req)
+}
+
+// setValue is an internal implementation of set to enable passing the logic to state.SetWithRetries as a func.
+// Every write stores a new random etag, since SQLite has no row version that can be used instead.
+func (s *sqliteDBAccess) setValue(db dbExecutor, req *state.SetRequest) error {
+	s.logger.Debug("Setting state value in SQLite")
+
+	err := state.CheckSetRequestOptions(req)
+	if err != nil {
+		return err
+	}
+
+	if req.Key == "" {
+		return fmt.Errorf("missing key in set operation")
+	}
+
+	value, err := sqlutil.SerializeValue(req.Value)
+	if err != nil {
+		return err
+	}
+
+	ttl, err := sqlutil.ParseTTL(req.Metadata, s.defaultTTL)
+	if err != nil {
+		return err
+	}
+
+	etag := uuid.New().String()
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+
+	var result sql.Result
+	switch {
+	case reqEtag == "" && req.Options.Concurrency == state.FirstWrite:
+		// The first write wins, so only a missing or expired row may be written
+		result, err = db.Exec(fmt.Sprintf(
+			`INSERT INTO "%[1]s" (key, value, etag, expiration_time) VALUES (?, ?, ?, %[2]s)
+			ON CONFLICT (key) DO UPDATE SET value = excluded.value, etag = excluded.etag,
+			expiration_time = excluded.expiration_time, update_time = CURRENT_TIMESTAMP
+			WHERE "%[1]s".expiration_time IS NOT NULL AND "%[1]s".expiration_time <= datetime('now')`,
+			s.tableName, expirationTimeExpression), req.Key, value, etag, ttl)
+	case reqEtag == "":
+		_, err = db.Exec(fmt.Sprintf(
+			`INSERT INTO "%s" (key, value, etag, expiration_time) VALUES (?, ?, ?, %s)
+			ON CONFLICT (key) DO UPDATE SET value = excluded.value, etag = excluded.etag,
+			expiration_time = excluded.expiration_time, update_time = CURRENT_TIMESTAMP`,
+			s.tableName, expirationTimeExpression), req.Key, value, etag, ttl)
+		return err
+	default:
+		// When an etag is provided do an update - no insert
+		result, err = db.Exec(fmt.Sprintf(
+			`UPDATE "%s" SET value = ?, etag = ?, expiration_time = %s, update_time = CURRENT_TIMESTAMP
+			WHERE key = ? AND etag = ? AND %s`,
+			s.tableName, expirationTimeExpression, notExpiredCondition), value, etag, ttl, req.Key, reqEtag)
+	}
+
+	return s.returnSingleDBResult(result, err)
+}
+
+// Get returns data from the database. If data does not exist for the key an empty state.GetResponse will be returned.
+func (s *sqliteDBAccess) Get(req *state.GetRequest) (*state.GetResponse, error) {
+	s.logger.Debug("Getting state value from SQLite")
+	if req.Key == "" {
+		return nil, fmt.Errorf("missing key in get operation")
+	}
+
+	var value string
+	var etag string
+	err := s.db.QueryRow(fmt.Sprintf(`SELECT value, etag FROM "%s" WHERE key = ? AND %s`, s.tableName, notExpiredCondition), req.Key).Scan(&value, &etag)
+	if err != nil {
+		// If no rows exist, return an empty response, otherwise return the error.
+		if err == sql.ErrNoRows {
+			return &state.GetResponse{}, nil
+		}
+		return nil, err
+	}
+
+	response := &state.GetResponse{
+		Data:     []byte(value),
+		ETag:     etag,
+		Metadata: req.Metadata,
+	}
+
+	return response, nil
+}
+
+// Delete removes an item from the state store.
+func (s *sqliteDBAccess) Delete(req *state.DeleteRequest) error {
+	return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
+		return s.deleteValue(s.db, req)
+	}, req)
+}
+
+// deleteValue is an internal implementation of delete to enable passing the logic to state.DeleteWithRetries as a func.
+func (s *sqliteDBAccess) deleteValue(db dbExecutor, req *state.DeleteRequest) error {
+	s.logger.Debug("Deleting state value from SQLite")
+	if req.Key == "" {
+		return fmt.Errorf("missing key in delete operation")
+	}
+
+	var result sql.Result
+	var err error
+
+	reqEtag := sqlutil.ETagCondition(req.ETag, req.Options.Concurrency)
+	if reqEtag == "" {
+		result, err = db.Exec(fmt.Sprintf(`DELETE FROM "%s" WHERE key = ?`, s.tableName), req.Key)
+	} else {
+		result, err = db.Exec(fmt.Sprintf(`DELETE FROM "%s" WHERE key = ? AND etag = ?`, s.tableName), req.Key, reqEtag)
+	}
+
+	return s.returnSingleDBResult(result, err)
+}
+
+// BulkSet runs all set requests in a single transaction.
+func (s *sqliteDBAccess) BulkSet(req []state.SetRequest) error {
+	return sqlutil.InTransaction(s.db, func(tx *sql.Tx) error {
+		for i := range req {
+			err := s.setValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// BulkDelete runs all delete requests in a single transaction.
+func (s *sqliteDBAccess) BulkDelete(req []state.DeleteRequest) error {
+	return sqlutil.InTransaction(s.db, func(tx *sql.Tx) error {
+		for i := range req {
+			err := s.deleteValue(tx, &req[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ExecuteMulti runs set and delete requests in order within a single transaction.
+func (s *sqliteDBAccess) ExecuteMulti(reqs []state.TransactionalRequest) error {
+	s.logger.Debug("Executing multiple SQLite operations")
+
+	return sqlutil.InTransaction(s.db, func(tx *sql.Tx) error {
+		for _, req := range reqs {
+			var err error
+			switch r := req.Request.(type) {
+			case state.SetRequest:
+				err = s.setValue(tx, &r)
+			case state.DeleteRequest:
+				err = s.deleteValue(tx, &r)
+			default:
+				err = fmt.Errorf("unsupported request type %T", req.Request)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Verifies that the sql.Result affected only one row and no errors exist
+func (s *sqliteDBAccess) returnSingleDBResult(result sql.Result, err error) error {
+	if err != nil {
+		s.logger.Debug(err)
+		return err
+	}
+
+	rowsAffected, resultErr := result.RowsAffected()
+
+	if resultErr != nil {
+		s.logger.Error(resultErr)
+		return resultErr
+	}
+
+	if rowsAffected == 0 {
+		s.logger.Error(errNoRowsAffected)
+		return errNoRowsAffected
+	}
+
+	if rowsAffected > 1 {
+		tooManyRowsErr := errors.New("database operation failed: more than one row affected, expected one")
+		s.logger.Error(tooManyRowsErr)
+		return tooManyRowsErr
+	}
+
+	return nil
+}
+
+// Ping checks that the database can be reached
+func (s *sqliteDBAccess) Ping() error {
+	if s.db == nil {
+		return fmt.Errorf("the SQLite state store is not initialized")
+	}
+
+	return s.db.Ping()
+}
+
+// Close implements io.Close
+func (s *sqliteDBAccess) Close() error {
+	if s.closeCh != nil {
+		close(s.closeCh)
+		s.closeCh = nil
+	}
+
+	if s.db != nil {
+		return s.db.Close()
+	}
+
+	return nil
+}
+
+func (s *sqliteDBAccess) ensureStateTable() error {
+	createTable := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS "%[1]s" (
+								key TEXT NOT NULL PRIMARY KEY,
+								value TEXT NOT NULL,
+								etag TEXT NOT NULL,
+								expiration_time TEXT NULL,
+								insert_time TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+								update_time TEXT NULL);
+								CREATE INDEX IF NOT EXISTS "%[1]s_expiration_time_idx" ON "%[1]s" (expiration_time);`, s.tableName)
+	_, err := s.db.Exec(createTable)
+
+	return err
+}
+
+// parseDuration reads a duration from the component metadata. A value of zero or less disables the setting.
+func parseDuration(properties map[string]string, key string, defaultValue time.Duration) (time.Duration, error) {
+	val, ok := properties[key]
+	if !ok || val == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s', must be a duration such as 10m", key, val)
+	}
+
+	return d, nil
+}
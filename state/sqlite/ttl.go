@@ -0,0 +1,54 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package sqlite
+
+import (
+	"fmt"
+	"time"
+)
+
+// expirationTimeExpression is the expiration time of a row given the TTL in seconds parameter, NULL when the TTL is NULL
+const expirationTimeExpression = "datetime('now', '+' || ? || ' seconds')"
+
+// scheduleCleanupExpiredData periodically deletes expired rows until the store is closed.
+func (s *sqliteDBAccess) scheduleCleanupExpiredData(interval time.Duration) {
+	s.logger.Infof("Scheduling cleanup of expired SQLite state every %s", interval)
+
+	closeCh := s.closeCh
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				err := s.cleanupExpiredData()
+				if err != nil {
+					s.logger.Errorf("error removing expired SQLite state: %s", err)
+				}
+			case <-closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// cleanupExpiredData deletes the rows whose expiration time has passed.
+func (s *sqliteDBAccess) cleanupExpiredData() error {
+	result, err := s.db.Exec(fmt.Sprintf(
+		`DELETE FROM "%s" WHERE expiration_time IS NOT NULL AND expiration_time <= datetime('now')`, s.tableName))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debugf("Removed %d expired rows from SQLite state", rowsAffected)
+
+	return nil
+}
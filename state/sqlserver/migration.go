@@ -38,12 +38,13 @@ func newMigration(store *SQLServer) migrator {
 
 /* #nosec */
 func (m *migration) executeMigrations() (migrationResult, error) {
+	// The upsert procedure name is versioned, since procedures that already exist are not replaced
 	r := migrationResult{
 		bulkDeleteProcName:       fmt.Sprintf("sp_BulkDelete_%s", m.store.tableName),
 		itemRefTableTypeName:     fmt.Sprintf("[%s].%s_Table", m.store.schema, m.store.tableName),
-		upsertProcName:           fmt.Sprintf("sp_Upsert_%s", m.store.tableName),
-		getCommand:               fmt.Sprintf("SELECT [Data], [RowVersion] FROM [%s].[%s] WHERE [Key] = @Key", m.store.schema, m.store.tableName),
-		deleteWithETagCommand:    fmt.Sprintf(`DELETE [%s].[%s] WHERE [Key]=@Key AND [RowVersion]=@RowVersion`, m.store.schema, m.store.tableName),
+		upsertProcName:           fmt.Sprintf("sp_Upsert_v2_%s", m.store.tableName),
+		getCommand:               fmt.Sprintf("SELECT [Data], [RowVersion] FROM [%s].[%s] WHERE [Key] = @Key AND %s", m.store.schema, m.store.tableName, notExpiredCondition),
+		deleteWithETagCommand:    fmt.Sprintf(`DELETE [%s].[%s] WHERE [Key]=@Key AND [RowVersion]=@RowVersion AND %s`, m.store.schema, m.store.tableName, notExpiredCondition),
 		deleteWithoutETagCommand: fmt.Sprintf(`DELETE [%s].[%s] WHERE [Key]=@Key`, m.store.schema, m.store.tableName),
 	}
 
@@ -78,6 +79,11 @@ func (m *migration) executeMigrations() (migrationResult, error) {
 		return r, fmt.Errorf("failed to create db table: %v", err)
 	}
 
+	err = m.ensureExpireDateColumnExists(db)
+	if err != nil {
+		return r, fmt.Errorf("failed to add expire date column: %v", err)
+	}
+
 	err = m.ensureStoredProcedureExists(db, r)
 	if err != nil {
 		return r, fmt.Errorf("failed to create stored procedures: %v", err)
@@ -160,6 +166,17 @@ func (m *migration) ensureTableExists(db *sql.DB, r migrationResult) error {
 	return runCommand(tsql, db)
 }
 
+// ensureExpireDateColumnExists adds the ExpireDate column to tables created before values could expire.
+/* #nosec */
+func (m *migration) ensureExpireDateColumnExists(db *sql.DB) error {
+	tsql := fmt.Sprintf(`
+	IF COL_LENGTH('[%s].[%s]', 'ExpireDate') IS NULL
+		ALTER TABLE [%s].[%s] ADD [ExpireDate] DateTime2 NULL`,
+		m.store.schema, m.store.tableName, m.store.schema, m.store.tableName)
+
+	return runCommand(tsql, db)
+}
+
 /* #nosec */
 func (m *migration) ensureTypeExists(db *sql.DB, mr migrationResult) error {
 	tsql := fmt.Sprintf(`
@@ -232,32 +249,34 @@ func (m *migration) ensureUpsertStoredProcedureExists(db *sql.DB, mr migrationRe
 		CREATE PROCEDURE %s (
 			@Key 			%s,
 			@Data 			NVARCHAR(MAX),
-			@RowVersion 	BINARY(8))
+			@RowVersion 	BINARY(8),
+			@TTLInSeconds 	INT)
 		AS
+			DECLARE @ExpireDate DateTime2 = CASE WHEN @TTLInSeconds IS NULL THEN NULL ELSE DATEADD(SECOND, @TTLInSeconds, GETDATE()) END
+
 			IF (@RowVersion IS NOT NULL)
 			BEGIN
-				UPDATE [%s]
-				SET [Data]=@Data, UpdateDate=GETDATE()
-				WHERE [Key]=@Key AND RowVersion = @RowVersion
+				UPDATE [%[3]s]
+				SET [Data]=@Data, UpdateDate=GETDATE(), ExpireDate=@ExpireDate
+				WHERE [Key]=@Key AND RowVersion = @RowVersion AND %[4]s
 
 				RETURN
 			END
 			
 			BEGIN TRY
-				INSERT INTO [%s] ([Key], [Data]) VALUES (@Key, @Data);
+				INSERT INTO [%[3]s] ([Key], [Data], [ExpireDate]) VALUES (@Key, @Data, @ExpireDate);
 			END TRY
 
 			BEGIN CATCH
 				IF ERROR_NUMBER() IN (2601, 2627) 
-				UPDATE [%s]
-				SET [Data]=@Data, UpdateDate=GETDATE()
+				UPDATE [%[3]s]
+				SET [Data]=@Data, UpdateDate=GETDATE(), ExpireDate=@ExpireDate
 				WHERE [Key]=@Key AND RowVersion = ISNULL(@RowVersion, RowVersion)
 			END CATCH`,
 		mr.upsertProcFullName,
 		mr.pkColumnType,
 		m.store.tableName,
-		m.store.tableName,
-		m.store.tableName)
+		notExpiredCondition)
 
 	return m.createStoredProcedureIfNotExists(db, mr.upsertProcName, tsql)
 }
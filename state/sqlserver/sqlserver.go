@@ -14,6 +14,8 @@ import (
 	"strconv"
 	"unicode"
 
+	"github.com/dapr/components-contrib/internal/sqlutil"
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	mssql "github.com/denisenkom/go-mssqldb"
@@ -62,6 +64,9 @@ const (
 
 	defaultKeyLength = 200
 	defaultSchema    = "dbo"
+
+	// notExpiredCondition filters out rows that have expired
+	notExpiredCondition = "([ExpireDate] IS NULL OR [ExpireDate] > GETDATE())"
 )
 
 // NewSQLServerStateStore creates a new instance of a Sql Server transaction store
@@ -87,6 +92,7 @@ type SQLServer struct {
 	keyType           KeyType
 	keyLength         int
 	indexedProperties []IndexedProperty
+	defaultTTL        int
 	migratorFactory   func(*SQLServer) migrator
 
 	bulkDeleteCommand        string
@@ -132,6 +138,10 @@ func isValidIndexedPropertyType(s string) bool {
 
 // Init initializes the SQL server state store
 func (s *SQLServer) Init(metadata state.Metadata) error {
+	if err := state.CheckDefaultKeyPrefix(metadata); err != nil {
+		return err
+	}
+
 	if val, ok := metadata.Properties[connectionStringKey]; ok && val != "" {
 		s.connectionString = val
 	} else {
@@ -221,6 +231,12 @@ func (s *SQLServer) Init(metadata state.Metadata) error {
 		s.indexedProperties = indexedProperties
 	}
 
+	var err error
+	s.defaultTTL, err = state.ParseDefaultTTL(metadata.Properties)
+	if err != nil {
+		return err
+	}
+
 	migration := s.migratorFactory(s)
 	mr, err := migration.executeMigrations()
 	if err != nil {
@@ -237,6 +253,23 @@ func (s *SQLServer) Init(metadata state.Metadata) error {
 	return nil
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the SQL Server state store. The indexed
+// properties are a JSON array of IndexedProperty.
+func (s *SQLServer) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: connectionStringKey, Type: metadata.TypeString, Required: true},
+			{Name: tableNameKey, Type: metadata.TypeString, Required: true},
+			{Name: schemaKey, Type: metadata.TypeString, Default: defaultSchema},
+			{Name: keyTypeKey, Type: metadata.TypeString, Default: string(StringKeyType)},
+			{Name: keyLengthKey, Type: metadata.TypeInt, Default: strconv.Itoa(defaultKeyLength)},
+			{Name: indexedPropertiesKey, Type: metadata.TypeString},
+			{Name: state.DefaultTTLInSecondsKey, Type: metadata.TypeInt},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTTL, metadata.CapabilityTransactional},
+	}
+}
+
 // Multi performs multiple updates on a Sql server store
 func (s *SQLServer) Multi(reqs []state.TransactionalRequest) error {
 	var deletes []state.DeleteRequest
@@ -483,6 +516,12 @@ func (s *SQLServer) executeSet(db dbExecutor, req *state.SetRequest) error {
 		return err
 	}
 
+	ttl, err := sqlutil.ParseTTL(req.Metadata, s.defaultTTL)
+	if err != nil {
+		return err
+	}
+	ttlInSeconds := sql.Named("TTLInSeconds", ttl)
+
 	etag := sql.Named(rowVersionColumnName, nil)
 	if req.ETag != "" {
 		var b []byte
@@ -492,7 +531,7 @@ func (s *SQLServer) executeSet(db dbExecutor, req *state.SetRequest) error {
 		}
 		etag.Value = b
 	}
-	res, err := db.Exec(s.upsertCommand, sql.Named(keyColumnName, req.Key), sql.Named("Data", string(json)), etag)
+	res, err := db.Exec(s.upsertCommand, sql.Named(keyColumnName, req.Key), sql.Named("Data", string(json)), etag, ttlInSeconds)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/conformance"
 	"github.com/dapr/dapr/pkg/logger"
 
 	uuid "github.com/google/uuid"
@@ -81,6 +82,7 @@ func TestIntegrationCases(t *testing.T) {
 	t.Run("Bulk delete", testBulkDelete)
 	t.Run("Insert and Update Set Record Dates", testInsertAndUpdateSetRecordDates)
 	t.Run("Multiple initializations", testMultipleInitializations)
+	t.Run("TTL conformance", testTTLConformance)
 
 	// Run concurrent set tests 10 times
 	const executions = 10
@@ -89,6 +91,11 @@ func TestIntegrationCases(t *testing.T) {
 	}
 }
 
+func testTTLConformance(t *testing.T) {
+	store := getTestStore(t, "")
+	conformance.TTL(t, store, nil)
+}
+
 func getUniqueDBSchema() string {
 	uuid := uuid.New().String()
 	uuid = strings.ReplaceAll(uuid, "-", "")
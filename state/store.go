@@ -5,6 +5,8 @@
 
 package state
 
+import "github.com/dapr/components-contrib/metadata"
+
 // Store is an interface to perform operations on store
 type Store interface {
 	Init(metadata Metadata) error
@@ -13,4 +15,5 @@ type Store interface {
 	Get(req *GetRequest) (*GetResponse, error)
 	Set(req *SetRequest) error
 	BulkSet(req []SetRequest) error
+	GetComponentMetadata() metadata.ComponentMetadata
 }
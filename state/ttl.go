@@ -0,0 +1,58 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// TTLInSecondsKey is the metadata property of a set request with the number of seconds until its value expires
+	TTLInSecondsKey = "ttlInSeconds"
+	// DefaultTTLInSecondsKey is the metadata property of a state store with the TTL of values set without one
+	DefaultTTLInSecondsKey = "defaultTTLInSeconds"
+)
+
+// ParseTTL returns the number of seconds until the value of a set request expires, or 0 when it never expires.
+// A negative ttlInSeconds persists the value, 0 or no ttlInSeconds uses defaultTTL, and a positive ttlInSeconds
+// expires the value after that many seconds.
+func ParseTTL(requestMetadata map[string]string, defaultTTL int) (int, error) {
+	val, ok := requestMetadata[TTLInSecondsKey]
+	if !ok || val == "" {
+		return defaultTTL, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s', must be an integer", TTLInSecondsKey, val)
+	}
+
+	switch {
+	case ttl < 0:
+		return 0, nil
+	case ttl == 0:
+		return defaultTTL, nil
+	default:
+		return int(ttl), nil
+	}
+}
+
+// ParseDefaultTTL returns the TTL of values set without one from the metadata properties of a state store,
+// or 0 when they never expire.
+func ParseDefaultTTL(properties map[string]string) (int, error) {
+	val, ok := properties[DefaultTTLInSecondsKey]
+	if !ok || val == "" {
+		return 0, nil
+	}
+
+	ttl, err := strconv.ParseInt(val, 10, 32)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid %s value '%s', must be an integer of 0 or more", DefaultTTLInSecondsKey, val)
+	}
+
+	return int(ttl), nil
+}
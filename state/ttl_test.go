@@ -0,0 +1,60 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTTL(t *testing.T) {
+	valid := map[string]struct {
+		metadata   map[string]string
+		defaultTTL int
+		ttl        int
+	}{
+		"No TTL without default":       {map[string]string{}, 0, 0},
+		"No TTL with default":          {map[string]string{}, 30, 30},
+		"Zero TTL uses the default":    {map[string]string{TTLInSecondsKey: "0"}, 30, 30},
+		"Negative TTL persists":        {map[string]string{TTLInSecondsKey: "-1"}, 30, 0},
+		"Positive TTL expires":         {map[string]string{TTLInSecondsKey: "60"}, 30, 60},
+		"Positive TTL without default": {map[string]string{TTLInSecondsKey: "60"}, 0, 60},
+	}
+	for name, tc := range valid {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ttl, err := ParseTTL(tc.metadata, tc.defaultTTL)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.ttl, ttl)
+		})
+	}
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		_, err := ParseTTL(map[string]string{TTLInSecondsKey: "soon"}, 0)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("TTL out of range", func(t *testing.T) {
+		_, err := ParseTTL(map[string]string{TTLInSecondsKey: "9999999999"}, 0)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestParseDefaultTTL(t *testing.T) {
+	ttl, err := ParseDefaultTTL(map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, ttl)
+
+	ttl, err = ParseDefaultTTL(map[string]string{DefaultTTLInSecondsKey: "3600"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3600, ttl)
+
+	_, err = ParseDefaultTTL(map[string]string{DefaultTTLInSecondsKey: "-1"})
+	assert.NotNil(t, err)
+
+	_, err = ParseDefaultTTL(map[string]string{DefaultTTLInSecondsKey: "soon"})
+	assert.NotNil(t, err)
+}
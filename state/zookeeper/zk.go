@@ -7,11 +7,13 @@ package zookeeper
 
 import (
 	"errors"
+	"fmt"
 	"path"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/logger"
 	"github.com/hashicorp/go-multierror"
@@ -26,6 +28,10 @@ const anyVersion = -1
 
 var errMissingServers = errors.New("servers are required")
 var errInvalidSessionTimeout = errors.New("sessionTimeout is invalid")
+var errETagMismatch = errors.New("possible etag mismatch")
+
+// defaultACL is the access of znodes created by the state store
+var defaultACL = zk.WorldACL(zk.PermAll)
 
 type properties struct {
 	Servers           string `json:"servers"`
@@ -104,13 +110,15 @@ type Conn interface {
 // StateStore is a state store
 type StateStore struct {
 	*config
-	conn Conn
+	conn        Conn
+	keyPrefixer state.KeyPrefixer
 
 	logger logger.Logger
 }
 
 var _ Conn = (*zk.Conn)(nil)
 var _ state.Store = (*StateStore)(nil)
+var _ state.TransactionalStore = (*StateStore)(nil)
 
 // NewZookeeperStateStore returns a new Zookeeper state store
 func NewZookeeperStateStore(logger logger.Logger) *StateStore {
@@ -124,6 +132,11 @@ func (s *StateStore) Init(metadata state.Metadata) (err error) {
 		return
 	}
 
+	var keyPrefixer state.KeyPrefixer
+	if keyPrefixer, err = state.NewKeyPrefixer(metadata); err != nil {
+		return
+	}
+
 	conn, _, err := zk.Connect(c.servers, c.sessionTimeout,
 		zk.WithMaxBufferSize(c.maxBufferSize), zk.WithMaxConnBufferSize(c.maxConnBufferSize))
 	if err != nil {
@@ -132,10 +145,26 @@ func (s *StateStore) Init(metadata state.Metadata) (err error) {
 
 	s.config = c
 	s.conn = conn
+	s.keyPrefixer = keyPrefixer
 
 	return
 }
 
+// GetComponentMetadata returns the metadata fields and the capabilities of the Zookeeper state store
+func (s *StateStore) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: []metadata.Field{
+			{Name: "servers", Type: metadata.TypeList, Required: true},
+			{Name: "sessionTimeout", Type: metadata.TypeDuration, Required: true},
+			{Name: "maxBufferSize", Type: metadata.TypeInt, Default: strconv.Itoa(defaultMaxBufferSize)},
+			{Name: "maxConnBufferSize", Type: metadata.TypeInt, Default: strconv.Itoa(defaultMaxConnBufferSize)},
+			{Name: "keyPrefixPath", Type: metadata.TypeString},
+			{Name: state.KeyPrefix, Type: metadata.TypeString},
+		},
+		Capabilities: []metadata.Capability{metadata.CapabilityETag, metadata.CapabilityTransactional},
+	}
+}
+
 // Get retrieves state from Zookeeper with a key
 func (s *StateStore) Get(req *state.GetRequest) (*state.GetResponse, error) {
 	value, stat, err := s.conn.Get(s.prefixedKey(req.Key))
@@ -162,10 +191,10 @@ func (s *StateStore) Delete(req *state.DeleteRequest) error {
 
 	return state.DeleteWithRetries(func(req *state.DeleteRequest) error {
 		err := s.conn.Delete(r.Path, r.Version)
-		if errors.Is(err, zk.ErrNoNode) {
+		if errors.Is(err, zk.ErrNoNode) && r.Version == anyVersion {
 			return nil
 		}
-		return err
+		return etagError(err)
 	}, req)
 }
 
@@ -198,19 +227,25 @@ func (s *StateStore) BulkDelete(reqs []state.DeleteRequest) error {
 
 // Set saves state into Zookeeper
 func (s *StateStore) Set(req *state.SetRequest) error {
-	r, err := s.newSetDataRequest(req)
+	op, err := s.newSetRequest(req)
 	if err != nil {
 		return err
 	}
 
 	return state.SetWithRetries(func(req *state.SetRequest) error {
-		_, err = s.conn.Set(r.Path, r.Data, r.Version)
-
-		if errors.Is(err, zk.ErrNoNode) {
-			_, err = s.conn.Create(r.Path, r.Data, 0, nil)
+		switch r := op.(type) {
+		case *zk.CreateRequest:
+			_, err = s.conn.Create(r.Path, r.Data, r.Flags, r.Acl)
+		case *zk.SetDataRequest:
+			_, err = s.conn.Set(r.Path, r.Data, r.Version)
+
+			// Only a write without an etag can create the znode
+			if errors.Is(err, zk.ErrNoNode) && r.Version == anyVersion {
+				_, err = s.conn.Create(r.Path, r.Data, 0, defaultACL)
+			}
 		}
 
-		return err
+		return etagError(err)
 	}, req)
 }
 
@@ -219,7 +254,7 @@ func (s *StateStore) BulkSet(reqs []state.SetRequest) error {
 	ops := make([]interface{}, 0, len(reqs))
 
 	for i := range reqs {
-		req, err := s.newSetDataRequest(&reqs[i])
+		req, err := s.newSetRequest(&reqs[i])
 		if err != nil {
 			return err
 		}
@@ -237,7 +272,7 @@ func (s *StateStore) BulkSet(reqs []state.SetRequest) error {
 		for i, res := range res {
 			if res.Error != nil {
 				if errors.Is(res.Error, zk.ErrNoNode) {
-					if req, ok := ops[i].(*zk.SetDataRequest); ok {
+					if req, ok := ops[i].(*zk.SetDataRequest); ok && req.Version == anyVersion {
 						retry = append(retry, s.newCreateRequest(req))
 						continue
 					}
@@ -255,8 +290,85 @@ func (s *StateStore) BulkSet(reqs []state.SetRequest) error {
 	}
 }
 
+// Multi performs the operations in a single ZooKeeper multi-op, which succeeds or fails as a whole.
+func (s *StateStore) Multi(reqs []state.TransactionalRequest) error {
+	ops := make([]interface{}, 0, len(reqs))
+
+	for _, req := range reqs {
+		switch req.Operation {
+		case state.Upsert:
+			setReq, ok := req.Request.(state.SetRequest)
+			if !ok {
+				return fmt.Errorf("expecting set request")
+			}
+
+			op, err := s.newSetRequest(&setReq)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, op)
+
+		case state.Delete:
+			delReq, ok := req.Request.(state.DeleteRequest)
+			if !ok {
+				return fmt.Errorf("expecting delete request")
+			}
+
+			op, err := s.newDeleteRequest(&delReq)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, op)
+
+		default:
+			return fmt.Errorf("unsupported operation: %s", req.Operation)
+		}
+	}
+
+	for len(ops) > 0 {
+		res, err := s.conn.Multi(ops...)
+
+		// ZooKeeper stops at the first failed operation. The results of the others only report the rollback.
+		failed := -1
+		for i := range res {
+			if res[i].Error != nil {
+				failed = i
+				break
+			}
+		}
+
+		if failed < 0 {
+			return err
+		}
+
+		// Writes and deletes without an etag do not depend on the znode existing,
+		// so the operation is changed to a create, or dropped, and the multi-op retried.
+		opErr := res[failed].Error
+		if !errors.Is(opErr, zk.ErrNoNode) {
+			return etagError(opErr)
+		}
+
+		switch r := ops[failed].(type) {
+		case *zk.SetDataRequest:
+			if r.Version != anyVersion {
+				return etagError(opErr)
+			}
+			ops[failed] = s.newCreateRequest(r)
+		case *zk.DeleteRequest:
+			if r.Version != anyVersion {
+				return etagError(opErr)
+			}
+			ops = append(ops[:failed], ops[failed+1:]...)
+		default:
+			return opErr
+		}
+	}
+
+	return nil
+}
+
 func (s *StateStore) newCreateRequest(req *zk.SetDataRequest) *zk.CreateRequest {
-	return &zk.CreateRequest{Path: req.Path, Data: req.Data}
+	return &zk.CreateRequest{Path: req.Path, Data: req.Data, Acl: defaultACL}
 }
 
 func (s *StateStore) newDeleteRequest(req *state.DeleteRequest) (*zk.DeleteRequest, error) {
@@ -265,12 +377,13 @@ func (s *StateStore) newDeleteRequest(req *state.DeleteRequest) (*zk.DeleteReque
 		return nil, err
 	}
 
-	var version int32
+	var version int32 = anyVersion
 
-	if req.Options.Concurrency == state.LastWrite {
-		version = anyVersion
-	} else {
-		version = s.parseETag(req.ETag)
+	if req.Options.Concurrency != state.LastWrite {
+		version, err = s.parseETag(req.ETag)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &zk.DeleteRequest{
@@ -279,7 +392,9 @@ func (s *StateStore) newDeleteRequest(req *state.DeleteRequest) (*zk.DeleteReque
 	}, nil
 }
 
-func (s *StateStore) newSetDataRequest(req *state.SetRequest) (*zk.SetDataRequest, error) {
+// newSetRequest returns the operation of a set request. With first-write concurrency and no etag
+// the znode is created, which fails if it already exists.
+func (s *StateStore) newSetRequest(req *state.SetRequest) (interface{}, error) {
 	err := state.CheckSetRequestOptions(req)
 	if err != nil {
 		return nil, err
@@ -290,12 +405,21 @@ func (s *StateStore) newSetDataRequest(req *state.SetRequest) (*zk.SetDataReques
 		return nil, err
 	}
 
-	var version int32
+	if req.Options.Concurrency == state.FirstWrite && req.ETag == "" {
+		return &zk.CreateRequest{
+			Path: s.prefixedKey(req.Key),
+			Data: data,
+			Acl:  defaultACL,
+		}, nil
+	}
+
+	var version int32 = anyVersion
 
-	if req.Options.Concurrency == state.LastWrite {
-		version = anyVersion
-	} else {
-		version = s.parseETag(req.ETag)
+	if req.Options.Concurrency != state.LastWrite {
+		version, err = s.parseETag(req.ETag)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &zk.SetDataRequest{
@@ -310,18 +434,31 @@ func (s *StateStore) prefixedKey(key string) string {
 		return key
 	}
 
-	return path.Join(s.keyPrefixPath, key)
+	return path.Join(s.keyPrefixPath, s.keyPrefixer.PrefixKey(key))
 }
 
-func (s *StateStore) parseETag(etag string) int32 {
-	if etag != "" {
-		// Since the version is taken to be int32
-		version, err := strconv.ParseInt(etag, 10, 32)
-		if err == nil {
-			return int32(version)
-		}
+// parseETag returns the znode version of an etag, or anyVersion if there is no etag
+func (s *StateStore) parseETag(etag string) (int32, error) {
+	if etag == "" {
+		return anyVersion, nil
+	}
+
+	// Since the version is taken to be int32
+	version, err := strconv.ParseInt(etag, 10, 32)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("%s: invalid etag %s", errETagMismatch, etag)
 	}
-	return anyVersion
+
+	return int32(version), nil
+}
+
+// etagError returns an etag mismatch error for the errors of a failed version check
+func etagError(err error) error {
+	if errors.Is(err, zk.ErrBadVersion) || errors.Is(err, zk.ErrNodeExists) || errors.Is(err, zk.ErrNoNode) {
+		return fmt.Errorf("%s: %w", errETagMismatch, err)
+	}
+
+	return err
 }
 
 func (s *StateStore) marshalData(v interface{}) ([]byte, error) {
@@ -6,6 +6,7 @@
 package zookeeper
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -119,6 +120,19 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err, "Key must be exists")
 	})
 
+	t.Run("With key and version mismatch", func(t *testing.T) {
+		conn.EXPECT().Delete("foo", int32(123)).Return(zk.ErrBadVersion).Times(1)
+
+		err := s.Delete(&state.DeleteRequest{Key: "foo", ETag: "123"})
+		assert.True(t, errors.Is(err, zk.ErrBadVersion))
+		assert.Contains(t, err.Error(), errETagMismatch.Error())
+	})
+
+	t.Run("With invalid etag", func(t *testing.T) {
+		err := s.Delete(&state.DeleteRequest{Key: "foo", ETag: "not-a-version"})
+		assert.NotNil(t, err)
+	})
+
 	t.Run("With delete error", func(t *testing.T) {
 		conn.EXPECT().Delete("foo", int32(anyVersion)).Return(zk.ErrUnknown).Times(1)
 
@@ -245,11 +259,27 @@ func TestSet(t *testing.T) {
 	})
 	t.Run("With NoNode error and retry", func(t *testing.T) {
 		conn.EXPECT().Set("foo", []byte("\"bar\""), int32(anyVersion)).Return(nil, zk.ErrNoNode).Times(1)
-		conn.EXPECT().Create("foo", []byte("\"bar\""), int32(0), nil).Return("/foo", nil).Times(1)
+		conn.EXPECT().Create("foo", []byte("\"bar\""), int32(0), defaultACL).Return("/foo", nil).Times(1)
 
 		err := s.Set(&state.SetRequest{Key: "foo", Value: "bar"})
 		assert.NoError(t, err, "Key must be create")
 	})
+	t.Run("With version and NoNode error", func(t *testing.T) {
+		conn.EXPECT().Set("foo", []byte("\"bar\""), int32(123)).Return(nil, zk.ErrNoNode).Times(1)
+
+		err := s.Set(&state.SetRequest{Key: "foo", Value: "bar", ETag: "123"})
+		assert.Contains(t, err.Error(), errETagMismatch.Error())
+	})
+	t.Run("With first write", func(t *testing.T) {
+		conn.EXPECT().Create("foo", []byte("\"bar\""), int32(0), defaultACL).Return("", zk.ErrNodeExists).Times(1)
+
+		err := s.Set(&state.SetRequest{
+			Key:     "foo",
+			Value:   "bar",
+			Options: state.SetStateOption{Concurrency: state.FirstWrite},
+		})
+		assert.Contains(t, err.Error(), errETagMismatch.Error())
+	})
 	t.Run("With delete error and retry", func(t *testing.T) {
 		conn.EXPECT().Set("foo", []byte("\"bar\""), int32(anyVersion)).Return(nil, zk.ErrUnknown).Times(2)
 		conn.EXPECT().Set("foo", []byte("\"bar\""), int32(anyVersion)).Return(stat, nil).Times(1)
@@ -324,7 +354,7 @@ func TestBulkSet(t *testing.T) {
 			{Error: zk.ErrNoNode}, {},
 		}, nil).Times(1)
 		conn.EXPECT().Multi([]interface{}{
-			&zk.CreateRequest{Path: "foo", Data: []byte("\"bar\"")},
+			&zk.CreateRequest{Path: "foo", Data: []byte("\"bar\""), Acl: defaultACL},
 		}).Return([]zk.MultiResponse{{}, {}}, nil).Times(1)
 
 		err := s.BulkSet([]state.SetRequest{
@@ -334,3 +364,70 @@ func TestBulkSet(t *testing.T) {
 		assert.NoError(t, err, "Key must be set")
 	})
 }
+
+// Multi
+func TestMulti(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conn := NewMockConn(ctrl)
+	s := StateStore{conn: conn}
+
+	t.Run("With operations", func(t *testing.T) {
+		conn.EXPECT().Multi([]interface{}{
+			&zk.SetDataRequest{Path: "foo", Data: []byte("\"bar\""), Version: int32(3)},
+			&zk.DeleteRequest{Path: "bar", Version: int32(anyVersion)},
+		}).Return([]zk.MultiResponse{{}, {}}, nil).Times(1)
+
+		err := s.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "foo", Value: "bar", ETag: "3"}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "bar"}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("With NoNode errors for operations without etags", func(t *testing.T) {
+		gomock.InOrder(
+			conn.EXPECT().Multi([]interface{}{
+				&zk.SetDataRequest{Path: "foo", Data: []byte("\"bar\""), Version: int32(anyVersion)},
+				&zk.DeleteRequest{Path: "bar", Version: int32(anyVersion)},
+			}).Return([]zk.MultiResponse{{Error: zk.ErrNoNode}, {Error: zk.ErrUnknown}}, zk.ErrNoNode),
+			conn.EXPECT().Multi([]interface{}{
+				&zk.CreateRequest{Path: "foo", Data: []byte("\"bar\""), Acl: defaultACL},
+				&zk.DeleteRequest{Path: "bar", Version: int32(anyVersion)},
+			}).Return([]zk.MultiResponse{{}, {Error: zk.ErrNoNode}}, zk.ErrNoNode),
+			conn.EXPECT().Multi([]interface{}{
+				&zk.CreateRequest{Path: "foo", Data: []byte("\"bar\""), Acl: defaultACL},
+			}).Return([]zk.MultiResponse{{}}, nil),
+		)
+
+		err := s.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "foo", Value: "bar"}},
+			{Operation: state.Delete, Request: state.DeleteRequest{Key: "bar"}},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("With version conflict", func(t *testing.T) {
+		conn.EXPECT().Multi([]interface{}{
+			&zk.SetDataRequest{Path: "foo", Data: []byte("\"bar\""), Version: int32(3)},
+		}).Return([]zk.MultiResponse{{Error: zk.ErrBadVersion}}, zk.ErrBadVersion).Times(1)
+
+		err := s.Multi([]state.TransactionalRequest{
+			{Operation: state.Upsert, Request: state.SetRequest{Key: "foo", Value: "bar", ETag: "3"}},
+		})
+		assert.True(t, errors.Is(err, zk.ErrBadVersion))
+	})
+
+	t.Run("With invalid requests", func(t *testing.T) {
+		invalid := []state.TransactionalRequest{
+			{Operation: "Something invalid", Request: state.SetRequest{Key: "foo"}},
+			{Operation: state.Upsert, Request: state.DeleteRequest{Key: "foo"}},
+			{Operation: state.Delete, Request: state.SetRequest{Key: "foo"}},
+		}
+		for _, req := range invalid {
+			err := s.Multi([]state.TransactionalRequest{req})
+			assert.NotNil(t, err)
+		}
+	})
+}
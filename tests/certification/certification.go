@@ -0,0 +1,144 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package certification runs the components against real backends started in ephemeral containers with dockertest,
+// and breaks the backends while the components use them, so that every certified component proves that it recovers
+// from the failures of its backend.
+//
+// The tests of the components are built with the certtests build tag, and require a Docker daemon.
+package certification
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ory/dockertest/v3"
+	dc "github.com/ory/dockertest/v3/docker"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// readyTimeout is the time a backend has to accept requests once started, restarted or healed
+	readyTimeout = 2 * time.Minute
+	// stopTimeout is the time in seconds a backend has to stop when restarted, before it is killed
+	stopTimeout = 10
+
+	eventuallyInterval = 500 * time.Millisecond
+)
+
+// BackendOptions are the options of the container of a backend
+type BackendOptions struct {
+	Repository string
+	Tag        string
+	Env        []string
+	Cmd        []string
+	// Ports are the ports of the container, e.g. 5432/tcp, which are bound to fixed ports of the host so that the
+	// components keep connecting to the same address when the backend is restarted
+	Ports []string
+	// Ready returns nil once the backend accepts requests
+	Ready func(b *Backend) error
+}
+
+// Backend is a backend running in a container, which is removed at the end of the test
+type Backend struct {
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+	ports    map[string]string
+	ready    func(b *Backend) error
+}
+
+// StartBackend starts the container of the backend and waits until it is ready
+func StartBackend(t *testing.T, opts BackendOptions) *Backend {
+	pool, err := dockertest.NewPool("")
+	require.NoError(t, err, "the certification tests require docker")
+	pool.MaxWait = readyTimeout
+
+	b := &Backend{pool: pool, ports: map[string]string{}, ready: opts.Ready}
+	bindings := map[dc.Port][]dc.PortBinding{}
+	for _, port := range opts.Ports {
+		b.ports[port] = freePort(t)
+		bindings[dc.Port(port)] = []dc.PortBinding{{HostIP: "127.0.0.1", HostPort: b.ports[port]}}
+	}
+
+	b.resource, err = pool.RunWithOptions(&dockertest.RunOptions{
+		Repository:   opts.Repository,
+		Tag:          opts.Tag,
+		Env:          opts.Env,
+		Cmd:          opts.Cmd,
+		ExposedPorts: opts.Ports,
+		PortBindings: bindings,
+	})
+	require.NoError(t, err, "failed to start %s:%s", opts.Repository, opts.Tag)
+	t.Cleanup(func() {
+		if err := pool.Purge(b.resource); err != nil {
+			t.Logf("failed to remove the container of %s: %s", opts.Repository, err)
+		}
+	})
+
+	b.waitReady(t)
+	return b
+}
+
+// freePort returns a port of the host that no one listens on
+func freePort(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return fmt.Sprintf("%d", l.Addr().(*net.TCPAddr).Port)
+}
+
+func (b *Backend) waitReady(t *testing.T) {
+	if b.ready == nil {
+		return
+	}
+	require.NoError(t, b.pool.Retry(func() error {
+		return b.ready(b)
+	}), "the backend isn't ready")
+}
+
+// Port returns the port of the host bound to the port of the container
+func (b *Backend) Port(port string) string {
+	return b.ports[port]
+}
+
+// Address returns the address of the host bound to the port of the container
+func (b *Backend) Address(port string) string {
+	return net.JoinHostPort("127.0.0.1", b.ports[port])
+}
+
+// Restart stops and starts the backend, which closes the connections of the components, and waits until it is ready
+func (b *Backend) Restart(t *testing.T) {
+	require.NoError(t, b.pool.Client.RestartContainer(b.resource.Container.ID, stopTimeout))
+	b.waitReady(t)
+}
+
+// Partition pauses the backend, so that the connections of the components stay open but their requests get no
+// response, as when the network is partitioned
+func (b *Backend) Partition(t *testing.T) {
+	require.NoError(t, b.pool.Client.PauseContainer(b.resource.Container.ID))
+}
+
+// Heal resumes the backend paused by Partition and waits until it is ready
+func (b *Backend) Heal(t *testing.T) {
+	require.NoError(t, b.pool.Client.UnpauseContainer(b.resource.Container.ID))
+	b.waitReady(t)
+}
+
+// Eventually calls op until it succeeds, and fails the test with its last error when it still fails after the timeout
+func Eventually(t *testing.T, timeout time.Duration, op func() error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := op()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			require.NoError(t, err, "still failing after %s", timeout)
+		}
+		time.Sleep(eventuallyInterval)
+	}
+}
@@ -0,0 +1,20 @@
+module github.com/dapr/components-contrib/tests/certification
+
+go 1.14
+
+require (
+	github.com/dapr/components-contrib v0.0.0
+	github.com/dapr/dapr v0.4.1-0.20200228055659-71892bc0111e
+	github.com/go-redis/redis/v7 v7.4.0
+	github.com/google/uuid v1.1.1
+	github.com/jackc/pgx/v4 v4.6.0
+	github.com/ory/dockertest/v3 v3.6.0
+	github.com/streadway/amqp v0.0.0-20190827072141-edfb9018d271
+	github.com/stretchr/testify v1.5.1
+)
+
+replace (
+	github.com/Azure/go-autorest => github.com/Azure/go-autorest v13.3.0+incompatible
+	github.com/dapr/components-contrib => ../../
+	k8s.io/client => github.com/kubernetes-client/go v0.0.0-20190928040339-c757968c4c36
+)
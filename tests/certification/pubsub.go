@@ -0,0 +1,92 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package certification
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+// deliveryTimeout is the time a published message has to be delivered to the subscription
+const deliveryTimeout = 5 * time.Second
+
+// subscription receives the messages of a topic
+type subscription struct {
+	topic    string
+	messages chan string
+}
+
+func subscribe(t *testing.T, ps pubsub.PubSub, name string) *subscription {
+	s := &subscription{topic: uniqueKey(name), messages: make(chan string, 100)}
+	require.NoError(t, ps.Subscribe(pubsub.SubscribeRequest{Topic: s.topic}, func(msg *pubsub.NewMessage) error {
+		s.messages <- string(msg.Data)
+		return nil
+	}))
+	return s
+}
+
+// publishAndReceive publishes a message with the prefix, and returns nil once a message with the prefix is received,
+// which may be one published by a previous call
+func (s *subscription) publishAndReceive(ps pubsub.PubSub, prefix string) error {
+	data := fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+	if err := ps.Publish(&pubsub.PublishRequest{Topic: s.topic, Data: []byte(data)}); err != nil {
+		return err
+	}
+
+	timeout := time.After(deliveryTimeout)
+	for {
+		select {
+		case msg := <-s.messages:
+			if strings.HasPrefix(msg, prefix) {
+				return nil
+			}
+		case <-timeout:
+			return fmt.Errorf("%s not received", data)
+		}
+	}
+}
+
+// PubSubRestart restarts the broker under a subscription, which receives the messages published once the broker is
+// back
+func PubSubRestart(t *testing.T, ps pubsub.PubSub, backend *Backend) {
+	s := subscribe(t, ps, "restart")
+	require.NoError(t, s.publishAndReceive(ps, "before"))
+
+	backend.Restart(t)
+	Eventually(t, recoveryTimeout, func() error {
+		return s.publishAndReceive(ps, "after")
+	})
+}
+
+// PubSubPartition partitions the broker from a subscription, then heals it and checks that the subscription
+// receives the messages published once the partition is healed
+func PubSubPartition(t *testing.T, ps pubsub.PubSub, backend *Backend) {
+	s := subscribe(t, ps, "partition")
+	require.NoError(t, s.publishAndReceive(ps, "before"))
+
+	backend.Partition(t)
+	// the messages published during the partition may be lost, as long as the publisher doesn't hang
+	published := make(chan error, 1)
+	go func() {
+		published <- ps.Publish(&pubsub.PublishRequest{Topic: s.topic, Data: []byte("during")})
+	}()
+	time.Sleep(deliveryTimeout)
+	backend.Heal(t)
+
+	select {
+	case <-published:
+	case <-time.After(partitionTimeout):
+		require.Fail(t, "publishing hangs after the partition is healed")
+	}
+	Eventually(t, recoveryTimeout, func() error {
+		return s.publishAndReceive(ps, "after")
+	})
+}
@@ -0,0 +1,59 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// +build certtests
+
+package rabbitmq
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/pubsub/rabbitmq"
+	"github.com/dapr/components-contrib/tests/certification"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/require"
+)
+
+const amqpPort = "5672/tcp"
+
+// startRabbitMQ starts RabbitMQ and returns a pub/sub initialized with it
+func startRabbitMQ(t *testing.T) (*certification.Backend, pubsub.PubSub) {
+	var host string
+	backend := certification.StartBackend(t, certification.BackendOptions{
+		Repository: "rabbitmq",
+		Tag:        "3-alpine",
+		Ports:      []string{amqpPort},
+		Ready: func(b *certification.Backend) error {
+			host = fmt.Sprintf("amqp://guest:guest@%s", b.Address(amqpPort))
+			conn, err := amqp.Dial(host)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	})
+
+	ps := rabbitmq.NewRabbitMQ(logger.NewLogger("certification"))
+	require.NoError(t, ps.Init(pubsub.Metadata{Properties: map[string]string{
+		"host":          host,
+		"consumerID":    "certification",
+		"durable":       "true",
+		"reconnectWait": "1s",
+	}}))
+	return backend, ps
+}
+
+func TestBrokerRestart(t *testing.T) {
+	backend, ps := startRabbitMQ(t)
+	certification.PubSubRestart(t, ps, backend)
+}
+
+func TestNetworkPartition(t *testing.T) {
+	backend, ps := startRabbitMQ(t)
+	certification.PubSubPartition(t, ps, backend)
+}
@@ -0,0 +1,174 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package certification
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// etagRaceWriters are the writers racing to replace a value with the same etag
+	etagRaceWriters = 10
+	// transactionSize is the number of keys written by each transaction during a restart
+	transactionSize = 10
+	// transactionsBeforeRestart are the transactions committed before the backend is restarted under them
+	transactionsBeforeRestart = 5
+
+	// recoveryTimeout is the time a component has to serve requests again once its backend is ready
+	recoveryTimeout = time.Minute
+	// partitionTimeout is the time a request has to fail while the backend is partitioned, rather than hang
+	partitionTimeout = time.Minute
+)
+
+// uniqueKey returns a key unique to the run, so that the tests can run again against the same backend
+func uniqueKey(name string) string {
+	return fmt.Sprintf("%s-%s", uuid.New().String(), name)
+}
+
+// StateETagRace replaces a value with the same etag from concurrent writers, of which exactly one must succeed
+func StateETagRace(t *testing.T, store state.Store) {
+	key := uniqueKey("etag-race")
+	require.NoError(t, store.Set(&state.SetRequest{Key: key, Value: []byte(`"initial"`)}))
+	res, err := store.Get(&state.GetRequest{Key: key})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.ETag)
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var winners []string
+	for i := 0; i < etagRaceWriters; i++ {
+		value := fmt.Sprintf(`"writer-%d"`, i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if store.Set(&state.SetRequest{Key: key, Value: []byte(value), ETag: res.ETag}) == nil {
+				lock.Lock()
+				winners = append(winners, value)
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, winners, 1, "exactly one writer replaces the value of the etag")
+	res, err = store.Get(&state.GetRequest{Key: key})
+	require.NoError(t, err)
+	assert.Equal(t, winners[0], string(res.Data))
+}
+
+// StateRestartMidTransaction restarts the backend while transactions are being committed. Every transaction is
+// atomic across the restart, with all or none of its keys, and the transactions committed before the restart are
+// kept. The store serves the requests again once the backend is back.
+func StateRestartMidTransaction(t *testing.T, store state.Store, transactional state.TransactionalStore, backend *Backend) {
+	prefix := uniqueKey("transaction")
+	key := func(transaction, i int) string {
+		return fmt.Sprintf("%s-%d-%d", prefix, transaction, i)
+	}
+
+	// committed are the numbers of the transactions that succeeded, out of the attempted ones
+	var committed []int
+	attempted := 0
+	multi := func() error {
+		reqs := make([]state.TransactionalRequest, transactionSize)
+		for i := range reqs {
+			reqs[i] = state.TransactionalRequest{
+				Operation: state.Upsert,
+				Request:   state.SetRequest{Key: key(attempted, i), Value: []byte(fmt.Sprintf(`"%d"`, i))},
+			}
+		}
+		err := transactional.Multi(reqs)
+		if err == nil {
+			committed = append(committed, attempted)
+		}
+		attempted++
+		return err
+	}
+	for i := 0; i < transactionsBeforeRestart; i++ {
+		require.NoError(t, multi())
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				multi()
+			}
+		}
+	}()
+	backend.Restart(t)
+	close(stop)
+	<-done
+
+	Eventually(t, recoveryTimeout, func() error {
+		return store.Set(&state.SetRequest{Key: uniqueKey("after-restart"), Value: []byte(`"recovered"`)})
+	})
+
+	isCommitted := map[int]bool{}
+	for _, transaction := range committed {
+		isCommitted[transaction] = true
+	}
+	for transaction := 0; transaction < attempted; transaction++ {
+		found := 0
+		for i := 0; i < transactionSize; i++ {
+			res, err := store.Get(&state.GetRequest{Key: key(transaction, i)})
+			require.NoError(t, err)
+			if len(res.Data) > 0 {
+				found++
+			}
+		}
+		if isCommitted[transaction] {
+			assert.Equal(t, transactionSize, found, "committed transaction %d lost keys", transaction)
+		} else {
+			assert.Contains(t, []int{0, transactionSize}, found, "transaction %d is partially committed", transaction)
+		}
+	}
+}
+
+// StatePartition partitions the backend from the store, whose requests fail rather than hang, then heals it and
+// checks that the store serves the requests again with the values written before the partition
+func StatePartition(t *testing.T, store state.Store, backend *Backend) {
+	key := uniqueKey("partition")
+	require.NoError(t, store.Set(&state.SetRequest{Key: key, Value: []byte(`"before"`)}))
+
+	backend.Partition(t)
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := store.Get(&state.GetRequest{Key: key})
+		errCh <- err
+	}()
+	select {
+	case err := <-errCh:
+		assert.Error(t, err, "the requests fail while the backend is partitioned")
+	case <-time.After(partitionTimeout):
+		assert.Fail(t, "the requests hang while the backend is partitioned")
+	}
+	backend.Heal(t)
+
+	Eventually(t, recoveryTimeout, func() error {
+		res, err := store.Get(&state.GetRequest{Key: key})
+		if err != nil {
+			return err
+		}
+		if string(res.Data) != `"before"` {
+			return fmt.Errorf("unexpected value %s", res.Data)
+		}
+		return nil
+	})
+	assert.NoError(t, store.Set(&state.SetRequest{Key: key, Value: []byte(`"after"`)}))
+}
@@ -0,0 +1,65 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// +build certtests
+
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/postgresql"
+	"github.com/dapr/components-contrib/tests/certification"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const postgresPort = "5432/tcp"
+
+// startPostgres starts PostgreSQL and returns a state store initialized with it
+func startPostgres(t *testing.T) (*certification.Backend, *postgresql.PostgreSQL) {
+	var connectionString string
+	backend := certification.StartBackend(t, certification.BackendOptions{
+		Repository: "postgres",
+		Tag:        "12-alpine",
+		Env:        []string{"POSTGRES_PASSWORD=certification"},
+		Ports:      []string{postgresPort},
+		Ready: func(b *certification.Backend) error {
+			connectionString = fmt.Sprintf("host=127.0.0.1 port=%s user=postgres password=certification dbname=postgres sslmode=disable connect_timeout=5", b.Port(postgresPort))
+			conn, err := pgx.Connect(context.Background(), connectionString)
+			if err != nil {
+				return err
+			}
+			return conn.Close(context.Background())
+		},
+	})
+
+	store := postgresql.NewPostgreSQLStateStore(logger.NewLogger("certification"))
+	require.NoError(t, store.Init(state.Metadata{Properties: map[string]string{
+		"connectionString": connectionString,
+		"timeout":          "5s",
+	}}))
+	t.Cleanup(func() { store.Close() })
+	return backend, store
+}
+
+func TestETagRace(t *testing.T) {
+	_, store := startPostgres(t)
+	certification.StateETagRace(t, store)
+}
+
+func TestRestartMidTransaction(t *testing.T) {
+	backend, store := startPostgres(t)
+	certification.StateRestartMidTransaction(t, store, store, backend)
+}
+
+func TestNetworkPartition(t *testing.T) {
+	backend, store := startPostgres(t)
+	certification.StatePartition(t, store, backend)
+}
@@ -0,0 +1,58 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// +build certtests
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/redis"
+	"github.com/dapr/components-contrib/tests/certification"
+	"github.com/dapr/dapr/pkg/logger"
+	goredis "github.com/go-redis/redis/v7"
+	"github.com/stretchr/testify/require"
+)
+
+const redisPort = "6379/tcp"
+
+// startRedis starts Redis with an append only file synced on every write, so that the acknowledged writes survive a
+// restart, and returns a state store initialized with it
+func startRedis(t *testing.T) (*certification.Backend, *redis.StateStore) {
+	backend := certification.StartBackend(t, certification.BackendOptions{
+		Repository: "redis",
+		Tag:        "6-alpine",
+		Cmd:        []string{"redis-server", "--appendonly", "yes", "--appendfsync", "always"},
+		Ports:      []string{redisPort},
+		Ready: func(b *certification.Backend) error {
+			client := goredis.NewClient(&goredis.Options{Addr: b.Address(redisPort)})
+			defer client.Close()
+			return client.Ping().Err()
+		},
+	})
+
+	store := redis.NewRedisStateStore(logger.NewLogger("certification"))
+	require.NoError(t, store.Init(state.Metadata{Properties: map[string]string{
+		"redisHost": backend.Address(redisPort),
+	}}))
+	return backend, store
+}
+
+func TestETagRace(t *testing.T) {
+	_, store := startRedis(t)
+	certification.StateETagRace(t, store)
+}
+
+func TestRestartMidTransaction(t *testing.T) {
+	backend, store := startRedis(t)
+	certification.StateRestartMidTransaction(t, store, store, backend)
+}
+
+func TestNetworkPartition(t *testing.T) {
+	backend, store := startRedis(t)
+	certification.StatePartition(t, store, backend)
+}
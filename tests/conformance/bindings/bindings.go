@@ -0,0 +1,132 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package bindings
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/components-contrib/tests/conformance/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The operations of the conformance tests of the bindings, besides the operations of the output bindings
+const (
+	OperationOperations = "operations"
+	OperationRead       = "read"
+)
+
+// outputOperations are the operations invoked by the conformance tests, in their order
+var outputOperations = []bindings.OperationKind{bindings.CreateOperation, bindings.GetOperation, bindings.DeleteOperation, bindings.ListOperation}
+
+const defaultReadBindingTimeout = 20 * time.Second
+
+// TestConfig is the configuration of the conformance tests of a binding, with the metadata of its invocations
+type TestConfig struct {
+	utils.CommonConfig
+	ReadBindingTimeout time.Duration
+	InvokeMetadata     map[string]string
+}
+
+// NewTestConfig returns the configuration of the conformance tests of the binding, with the readBindingTimeout setting
+// of the component, whose other settings are the metadata of the invocations
+func NewTestConfig(component string, allOperations bool, operations []string, configMap map[string]string) (TestConfig, error) {
+	config := TestConfig{
+		CommonConfig:       utils.NewCommonConfig("bindings", component, allOperations, operations),
+		ReadBindingTimeout: defaultReadBindingTimeout,
+		InvokeMetadata:     map[string]string{},
+	}
+
+	for k, v := range configMap {
+		if k != "readBindingTimeout" {
+			config.InvokeMetadata[k] = v
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return config, fmt.Errorf("invalid readBindingTimeout %s", v)
+		}
+		config.ReadBindingTimeout = d
+	}
+	return config, nil
+}
+
+// ConformanceTests runs the tests of the operations of the configuration against the input binding, the output
+// binding, or both when the component is both, e.g. reading the messages the output binding creates
+func ConformanceTests(t *testing.T, props map[string]string, inputBinding bindings.InputBinding, outputBinding bindings.OutputBinding, config TestConfig) {
+	require.True(t, inputBinding != nil || outputBinding != nil, "missing binding")
+	if inputBinding != nil {
+		require.NoError(t, inputBinding.GetComponentMetadata().Validate(props))
+		require.NoError(t, inputBinding.Init(bindings.Metadata{Properties: props}))
+	}
+	if outputBinding != nil {
+		require.NoError(t, outputBinding.GetComponentMetadata().Validate(props))
+		require.NoError(t, outputBinding.Init(bindings.Metadata{Properties: props}))
+	}
+
+	// The events are read while the output binding is invoked
+	readCh := make(chan *bindings.ReadResponse, 1)
+	readErrCh := make(chan error, 1)
+	if inputBinding != nil && config.HasOperation(OperationRead) {
+		go func() {
+			readErrCh <- inputBinding.Read(func(r *bindings.ReadResponse) error {
+				select {
+				case readCh <- r:
+				default:
+				}
+				return nil
+			})
+		}()
+	}
+
+	if outputBinding != nil {
+		if config.HasOperation(OperationOperations) {
+			t.Run(OperationOperations, func(t *testing.T) {
+				ops := outputBinding.Operations()
+				assert.NotEmpty(t, ops)
+				// the operations listed explicitly by the configuration must be supported
+				for _, op := range outputOperations {
+					if config.HasOperation(string(op)) && !config.AllOperations {
+						assert.Contains(t, ops, op, "the tested operation %s is not one of the operations of the binding", op)
+					}
+				}
+			})
+		}
+
+		data := []byte(fmt.Sprintf("conformance test %s", uuid.New().String()))
+		for _, op := range outputOperations {
+			op := op
+			if !config.HasOperation(string(op)) || !bindings.SupportsOperation(outputBinding, op) {
+				continue
+			}
+			t.Run(string(op), func(t *testing.T) {
+				req := &bindings.InvokeRequest{Operation: op, Metadata: config.InvokeMetadata}
+				if op == bindings.CreateOperation {
+					req.Data = data
+				}
+				_, err := outputBinding.Invoke(req)
+				assert.NoError(t, err)
+			})
+		}
+	}
+
+	if inputBinding != nil && config.HasOperation(OperationRead) {
+		t.Run(OperationRead, func(t *testing.T) {
+			select {
+			case r := <-readCh:
+				assert.NotNil(t, r)
+			case err := <-readErrCh:
+				assert.Fail(t, "read failed", "%v", err)
+			case <-time.After(config.ReadBindingTimeout):
+				assert.Fail(t, "no event was read")
+			}
+		})
+	}
+}
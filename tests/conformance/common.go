@@ -0,0 +1,118 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package conformance runs the same tests against all the components of a type configured with YAML files, so that
+// every component proves that it implements the interface of its type the same way.
+//
+// The tests of a component type are configured by the tests.yml file of its directory, e.g. tests/config/state, and
+// the metadata of each component by the Dapr component manifest of tests/config/<type>/<component>, where the dots
+// of the component name are directories.
+package conformance
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const testsFileName = "tests.yml"
+
+// envVarRegexp matches the ${{NAME}} references to the environment variables in the metadata values
+var envVarRegexp = regexp.MustCompile(`\$\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TestConfiguration is the configuration of the conformance tests of a component type
+type TestConfiguration struct {
+	ComponentType string          `yaml:"componentType"`
+	Components    []TestComponent `yaml:"components"`
+}
+
+// TestComponent is a component tested with its operations, or all the operations of its type, and the settings of its
+// tests, such as the topic of a pub/sub component
+type TestComponent struct {
+	Component     string            `yaml:"component"`
+	AllOperations bool              `yaml:"allOperations"`
+	Operations    []string          `yaml:"operations"`
+	Config        map[string]string `yaml:"config"`
+}
+
+// componentManifest is the part of a Dapr component manifest with the metadata of the component
+type componentManifest struct {
+	Spec struct {
+		Type     string `yaml:"type"`
+		Metadata []struct {
+			Name  string `yaml:"name"`
+			Value string `yaml:"value"`
+		} `yaml:"metadata"`
+	} `yaml:"spec"`
+}
+
+// LoadTestConfiguration reads the tests.yml file of the directory of a component type
+func LoadTestConfiguration(dir string) (*TestConfiguration, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, testsFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the test configuration: %s", err)
+	}
+
+	var config TestConfiguration
+	if err = yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the test configuration %s: %s", dir, err)
+	}
+	if config.ComponentType == "" {
+		return nil, fmt.Errorf("missing componentType in the test configuration %s", dir)
+	}
+	for _, c := range config.Components {
+		if c.Component == "" {
+			return nil, fmt.Errorf("missing component in the test configuration %s", dir)
+		}
+	}
+	return &config, nil
+}
+
+// ComponentDir returns the directory of the manifest of the component of the test configuration
+func ComponentDir(dir, component string) string {
+	return filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(component, ".", "/")))
+}
+
+// LoadComponentMetadata reads the metadata properties of the component manifest of the directory, whose ${{NAME}}
+// references are replaced with the values of the NAME environment variables
+func LoadComponentMetadata(dir string) (map[string]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the component manifest: %s", err)
+	}
+
+	var manifests []string
+	for _, f := range files {
+		ext := filepath.Ext(f.Name())
+		if !f.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			manifests = append(manifests, filepath.Join(dir, f.Name()))
+		}
+	}
+	if len(manifests) != 1 {
+		return nil, fmt.Errorf("expected a single component manifest in %s, found %d", dir, len(manifests))
+	}
+
+	b, err := ioutil.ReadFile(manifests[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the component manifest: %s", err)
+	}
+	var manifest componentManifest
+	if err = yaml.Unmarshal(b, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the component manifest %s: %s", manifests[0], err)
+	}
+
+	props := make(map[string]string, len(manifest.Spec.Metadata))
+	for _, m := range manifest.Spec.Metadata {
+		props[m.Name] = envVarRegexp.ReplaceAllStringFunc(m.Value, func(ref string) string {
+			return os.Getenv(envVarRegexp.FindStringSubmatch(ref)[1])
+		})
+	}
+	return props, nil
+}
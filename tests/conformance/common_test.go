@@ -0,0 +1,109 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package conformance
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+}
+
+func TestLoadTestConfiguration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conformance")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, testsFileName), `
+componentType: state
+components:
+  - component: redis
+    allOperations: true
+  - component: azure.cosmosdb
+    operations: ["set", "get"]
+    config:
+      key: value
+`)
+	config, err := LoadTestConfiguration(dir)
+	require.NoError(t, err)
+	assert.Equal(t, &TestConfiguration{
+		ComponentType: "state",
+		Components: []TestComponent{
+			{Component: "redis", AllOperations: true},
+			{Component: "azure.cosmosdb", Operations: []string{"set", "get"}, Config: map[string]string{"key": "value"}},
+		},
+	}, config)
+	assert.Equal(t, filepath.Join(dir, "azure", "cosmosdb"), ComponentDir(dir, "azure.cosmosdb"))
+
+	writeFile(t, filepath.Join(dir, testsFileName), `components: [{component: redis}]`)
+	_, err = LoadTestConfiguration(dir)
+	assert.Error(t, err)
+
+	_, err = LoadTestConfiguration(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestLoadComponentMetadata(t *testing.T) {
+	dir, err := ioutil.TempDir("", "conformance")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	os.Setenv("CONFORMANCE_TEST_HOST", "localhost:6379")
+	defer os.Unsetenv("CONFORMANCE_TEST_HOST")
+
+	writeFile(t, filepath.Join(dir, "redis", "statestore.yaml"), `
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+spec:
+  type: state.redis
+  metadata:
+  - name: redisHost
+    value: ${{CONFORMANCE_TEST_HOST}}
+  - name: url
+    value: redis://${{ CONFORMANCE_TEST_HOST }}/0
+  - name: redisPassword
+    value: ${{CONFORMANCE_TEST_MISSING}}
+  - name: enableTLS
+    value: "false"
+`)
+	props, err := LoadComponentMetadata(filepath.Join(dir, "redis"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"redisHost":     "localhost:6379",
+		"url":           "redis://localhost:6379/0",
+		"redisPassword": "",
+		"enableTLS":     "false",
+	}, props)
+
+	writeFile(t, filepath.Join(dir, "redis", "other.yml"), `spec: {}`)
+	_, err = LoadComponentMetadata(filepath.Join(dir, "redis"))
+	assert.Error(t, err, "the directory has two manifests")
+}
+
+// TestConfigurations checks that the configurations of the conformance tests can be loaded
+func TestConfigurations(t *testing.T) {
+	for _, componentType := range []string{"state", "pubsub", "bindings", "secretstores"} {
+		dir := filepath.Join("..", "config", componentType)
+		config, err := LoadTestConfiguration(dir)
+		require.NoError(t, err, componentType)
+		assert.Equal(t, componentType, config.ComponentType)
+
+		for _, tc := range config.Components {
+			_, err := LoadComponentMetadata(ComponentDir(dir, tc.Component))
+			assert.NoError(t, err, tc.Component)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// +build conftests
+
+package conformance
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dapr/components-contrib/bindings"
+	b_cron "github.com/dapr/components-contrib/bindings/cron"
+	b_kafka "github.com/dapr/components-contrib/bindings/kafka"
+	b_redis "github.com/dapr/components-contrib/bindings/redis"
+	"github.com/dapr/components-contrib/pubsub"
+	p_inmemory "github.com/dapr/components-contrib/pubsub/inmemory"
+	p_redis "github.com/dapr/components-contrib/pubsub/redis"
+	"github.com/dapr/components-contrib/secretstores"
+	ss_local_env "github.com/dapr/components-contrib/secretstores/local/env"
+	ss_local_file "github.com/dapr/components-contrib/secretstores/local/file"
+	"github.com/dapr/components-contrib/state"
+	s_inmemory "github.com/dapr/components-contrib/state/inmemory"
+	s_postgresql "github.com/dapr/components-contrib/state/postgresql"
+	s_redis "github.com/dapr/components-contrib/state/redis"
+	conf_bindings "github.com/dapr/components-contrib/tests/conformance/bindings"
+	conf_pubsub "github.com/dapr/components-contrib/tests/conformance/pubsub"
+	conf_secret "github.com/dapr/components-contrib/tests/conformance/secretstores"
+	conf_state "github.com/dapr/components-contrib/tests/conformance/state"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// configDir is the directory of the configurations of the tests, which are run with the conftests build tag, e.g.
+// by make conf-tests CONF_TESTS="TestStateConformance/redis"
+const configDir = "../config"
+
+var testLogger = logger.NewLogger("testLogger")
+
+// runConformance runs the tests of each component of the configuration of the component type
+func runConformance(t *testing.T, componentType string, test func(t *testing.T, tc TestComponent, props map[string]string)) {
+	dir := filepath.Join(configDir, componentType)
+	config, err := LoadTestConfiguration(dir)
+	require.NoError(t, err)
+
+	for _, tc := range config.Components {
+		tc := tc
+		t.Run(tc.Component, func(t *testing.T) {
+			props, err := LoadComponentMetadata(ComponentDir(dir, tc.Component))
+			require.NoError(t, err)
+			test(t, tc, props)
+		})
+	}
+}
+
+// closeComponent closes the component at the end of its tests, when it can be closed
+func closeComponent(t *testing.T, component interface{}) {
+	if c, ok := component.(io.Closer); ok {
+		t.Cleanup(func() { c.Close() })
+	}
+}
+
+func TestStateConformance(t *testing.T) {
+	runConformance(t, "state", func(t *testing.T, tc TestComponent, props map[string]string) {
+		store := loadStateStore(tc.Component)
+		require.NotNil(t, store, "unknown state store %s", tc.Component)
+		closeComponent(t, store)
+
+		conf_state.ConformanceTests(t, props, store, conf_state.NewTestConfig(tc.Component, tc.AllOperations, tc.Operations))
+	})
+}
+
+func TestPubsubConformance(t *testing.T) {
+	runConformance(t, "pubsub", func(t *testing.T, tc TestComponent, props map[string]string) {
+		ps := loadPubSub(tc.Component)
+		require.NotNil(t, ps, "unknown pub/sub component %s", tc.Component)
+		closeComponent(t, ps)
+
+		config, err := conf_pubsub.NewTestConfig(tc.Component, tc.AllOperations, tc.Operations, tc.Config)
+		require.NoError(t, err)
+		conf_pubsub.ConformanceTests(t, props, ps, config)
+	})
+}
+
+func TestBindingsConformance(t *testing.T) {
+	runConformance(t, "bindings", func(t *testing.T, tc TestComponent, props map[string]string) {
+		inputBinding := loadInputBinding(tc.Component)
+		outputBinding := loadOutputBinding(tc.Component)
+		require.True(t, inputBinding != nil || outputBinding != nil, "unknown binding %s", tc.Component)
+		closeComponent(t, inputBinding)
+		closeComponent(t, outputBinding)
+
+		config, err := conf_bindings.NewTestConfig(tc.Component, tc.AllOperations, tc.Operations, tc.Config)
+		require.NoError(t, err)
+		conf_bindings.ConformanceTests(t, props, inputBinding, outputBinding, config)
+	})
+}
+
+func TestSecretStoreConformance(t *testing.T) {
+	runConformance(t, "secretstores", func(t *testing.T, tc TestComponent, props map[string]string) {
+		store := loadSecretStore(tc.Component)
+		require.NotNil(t, store, "unknown secret store %s", tc.Component)
+
+		// the environment holds the secrets of the environment variables store
+		if tc.Component == "local.env" {
+			for name, value := range conf_secret.ExpectedSecrets {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+		}
+
+		conf_secret.ConformanceTests(t, props, store, conf_secret.NewTestConfig(tc.Component, tc.AllOperations, tc.Operations))
+	})
+}
+
+func loadStateStore(name string) state.Store {
+	switch name {
+	case "inmemory":
+		return s_inmemory.NewInMemoryStateStore(testLogger)
+	case "redis":
+		return s_redis.NewRedisStateStore(testLogger)
+	case "postgresql":
+		return s_postgresql.NewPostgreSQLStateStore(testLogger)
+	}
+	return nil
+}
+
+func loadPubSub(name string) pubsub.PubSub {
+	switch name {
+	case "inmemory":
+		return p_inmemory.NewInMemoryBus(testLogger)
+	case "redis":
+		return p_redis.NewRedisStreams(testLogger)
+	}
+	return nil
+}
+
+func loadInputBinding(name string) bindings.InputBinding {
+	switch name {
+	case "cron":
+		return b_cron.NewCron(testLogger)
+	case "kafka":
+		return b_kafka.NewKafka(testLogger)
+	}
+	return nil
+}
+
+func loadOutputBinding(name string) bindings.OutputBinding {
+	switch name {
+	case "redis":
+		return b_redis.NewRedis(testLogger)
+	case "kafka":
+		return b_kafka.NewKafka(testLogger)
+	}
+	return nil
+}
+
+func loadSecretStore(name string) secretstores.SecretStore {
+	switch name {
+	case "local.env":
+		return ss_local_env.NewEnvSecretStore(testLogger)
+	case "local.file":
+		return ss_local_file.NewLocalSecretStore(testLogger)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/components-contrib/tests/conformance/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The operations of the conformance tests of the pub/sub components
+const (
+	OperationPublish    = "publish"
+	OperationSubscribe  = "subscribe"
+	OperationOrdering   = "ordering"
+	OperationRedelivery = "redelivery"
+)
+
+const (
+	defaultTopicName       = "dapr-conformance-test"
+	defaultMessageCount    = 10
+	defaultMaxReadDuration = 20 * time.Second
+
+	// redeliveryPrefix is the prefix of the messages whose first delivery fails
+	redeliveryPrefix = "redelivery-"
+)
+
+// TestConfig is the configuration of the conformance tests of a pub/sub component, with the topic they publish to,
+// which must exist for the message buses that don't create their topics
+type TestConfig struct {
+	utils.CommonConfig
+	TestTopicName   string
+	MessageCount    int
+	MaxReadDuration time.Duration
+}
+
+// NewTestConfig returns the configuration of the conformance tests of the pub/sub component, with the testTopicName,
+// messageCount and maxReadDuration settings of the component
+func NewTestConfig(component string, allOperations bool, operations []string, configMap map[string]string) (TestConfig, error) {
+	config := TestConfig{
+		CommonConfig:    utils.NewCommonConfig("pubsub", component, allOperations, operations),
+		TestTopicName:   defaultTopicName,
+		MessageCount:    defaultMessageCount,
+		MaxReadDuration: defaultMaxReadDuration,
+	}
+
+	if val, ok := configMap["testTopicName"]; ok && val != "" {
+		config.TestTopicName = val
+	}
+	if val, ok := configMap["messageCount"]; ok && val != "" {
+		count, err := strconv.Atoi(val)
+		if err != nil || count <= 0 {
+			return config, fmt.Errorf("invalid messageCount %s", val)
+		}
+		config.MessageCount = count
+	}
+	if val, ok := configMap["maxReadDuration"]; ok && val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return config, fmt.Errorf("invalid maxReadDuration %s", val)
+		}
+		config.MaxReadDuration = d
+	}
+	return config, nil
+}
+
+// received are the messages received by the subscription of the tests
+type received struct {
+	lock sync.Mutex
+	// deliveries are the numbers of deliveries of the messages
+	deliveries map[string]int
+	// order are the messages in the order they were handled successfully
+	order []string
+}
+
+func (r *received) handle(msg *pubsub.NewMessage) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	data := string(msg.Data)
+	r.deliveries[data]++
+	if strings.HasPrefix(data, redeliveryPrefix) && r.deliveries[data] == 1 {
+		return errors.New("conformance test redelivery")
+	}
+	r.order = append(r.order, data)
+	return nil
+}
+
+// handled returns whether all the messages were handled successfully, and the order of the messages of the prefix
+func (r *received) handled(messages []string, prefix string) (bool, []string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	done := map[string]bool{}
+	var order []string
+	for _, m := range r.order {
+		done[m] = true
+		if strings.HasPrefix(m, prefix) {
+			order = append(order, m)
+		}
+	}
+	for _, m := range messages {
+		if !done[m] {
+			return false, order
+		}
+	}
+	return true, order
+}
+
+// ConformanceTests runs the tests of the operations of the configuration against the pub/sub component. The
+// subscription is made before publishing, and the messages are unique to each run.
+func ConformanceTests(t *testing.T, props map[string]string, ps pubsub.PubSub, config TestConfig) {
+	require.NoError(t, ps.GetComponentMetadata().Validate(props))
+	require.NoError(t, ps.Init(pubsub.Metadata{Properties: props}))
+
+	r := &received{deliveries: map[string]int{}}
+	if config.HasOperation(OperationSubscribe) {
+		t.Run(OperationSubscribe, func(t *testing.T) {
+			require.NoError(t, ps.Subscribe(pubsub.SubscribeRequest{Topic: config.TestTopicName}, r.handle))
+		})
+	}
+
+	run := uuid.New().String()
+	publish := func(t *testing.T, prefix string) []string {
+		var messages []string
+		for i := 0; i < config.MessageCount; i++ {
+			data := fmt.Sprintf("%smessage-%s-%03d", prefix, run, i)
+			require.NoError(t, ps.Publish(&pubsub.PublishRequest{Topic: config.TestTopicName, Data: []byte(data)}))
+			messages = append(messages, data)
+		}
+		return messages
+	}
+
+	var published []string
+	if config.HasOperation(OperationPublish) {
+		t.Run(OperationPublish, func(t *testing.T) {
+			published = publish(t, "")
+		})
+	}
+
+	if config.HasOperation(OperationSubscribe) && config.HasOperation(OperationPublish) {
+		t.Run("receive", func(t *testing.T) {
+			var order []string
+			assert.Eventually(t, func() bool {
+				var done bool
+				done, order = r.handled(published, "message-"+run)
+				return done
+			}, config.MaxReadDuration, 100*time.Millisecond, "not all the messages were received")
+
+			if config.HasOperation(OperationOrdering) {
+				assert.Equal(t, published, order, "the messages were received out of order")
+			}
+		})
+	}
+
+	if config.HasOperation(OperationSubscribe) && config.HasOperation(OperationRedelivery) {
+		t.Run(OperationRedelivery, func(t *testing.T) {
+			messages := publish(t, redeliveryPrefix)
+			assert.Eventually(t, func() bool {
+				done, _ := r.handled(messages, redeliveryPrefix)
+				return done
+			}, config.MaxReadDuration, 100*time.Millisecond, "the messages whose handling failed were not redelivered")
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package secretstores
+
+import (
+	"testing"
+
+	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/components-contrib/tests/conformance/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The operations of the conformance tests of the secret stores
+const (
+	OperationGet     = "get"
+	OperationBulkGet = "bulkget"
+)
+
+// ExpectedSecrets are the secrets that the secret stores must hold for the conformance tests
+var ExpectedSecrets = map[string]string{
+	"conftestsecret": "abcd",
+	"secondsecret":   "efgh",
+}
+
+// TestConfig is the configuration of the conformance tests of a secret store
+type TestConfig struct {
+	utils.CommonConfig
+}
+
+// NewTestConfig returns the configuration of the conformance tests of the secret store
+func NewTestConfig(component string, allOperations bool, operations []string) TestConfig {
+	return TestConfig{
+		CommonConfig: utils.NewCommonConfig("secretstores", component, allOperations, operations),
+	}
+}
+
+// ConformanceTests runs the tests of the operations of the configuration against the secret store, which must hold
+// the expected secrets
+func ConformanceTests(t *testing.T, props map[string]string, store secretstores.SecretStore, config TestConfig) {
+	require.NoError(t, store.GetComponentMetadata().Validate(props))
+	require.NoError(t, store.Init(secretstores.Metadata{Properties: props}))
+
+	if config.HasOperation(OperationGet) {
+		t.Run(OperationGet, func(t *testing.T) {
+			for name, value := range ExpectedSecrets {
+				res, err := store.GetSecret(secretstores.GetSecretRequest{Name: name, Metadata: map[string]string{}})
+				require.NoError(t, err, name)
+				assert.Equal(t, map[string]string{name: value}, res.Data)
+			}
+
+			_, err := store.GetSecret(secretstores.GetSecretRequest{Name: "conftestmissingsecret", Metadata: map[string]string{}})
+			assert.Error(t, err, "a missing secret is an error")
+		})
+	}
+
+	if config.HasOperation(OperationBulkGet) {
+		t.Run(OperationBulkGet, func(t *testing.T) {
+			res, err := store.BulkGetSecret(secretstores.BulkGetSecretRequest{Metadata: map[string]string{}})
+			require.NoError(t, err)
+			// the store may hold other secrets
+			for name, value := range ExpectedSecrets {
+				assert.Equal(t, map[string]string{name: value}, res.Data[name], name)
+			}
+		})
+	}
+}
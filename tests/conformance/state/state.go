@@ -0,0 +1,211 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package state
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/conformance"
+	"github.com/dapr/components-contrib/tests/conformance/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The operations of the conformance tests of the state stores
+const (
+	OperationSet         = "set"
+	OperationGet         = "get"
+	OperationDelete      = "delete"
+	OperationBulkSet     = "bulkset"
+	OperationBulkDelete  = "bulkdelete"
+	OperationETag        = "etag"
+	OperationFirstWrite  = "first-write"
+	OperationTransaction = "transaction"
+	OperationTTL         = "ttl"
+)
+
+// TestConfig is the configuration of the conformance tests of a state store
+type TestConfig struct {
+	utils.CommonConfig
+}
+
+// NewTestConfig returns the configuration of the conformance tests of the state store
+func NewTestConfig(component string, allOperations bool, operations []string) TestConfig {
+	return TestConfig{
+		CommonConfig: utils.NewCommonConfig("state", component, allOperations, operations),
+	}
+}
+
+// scenario is a value set by the tests, which is read back as it was set
+type scenario struct {
+	key   string
+	value []byte
+}
+
+// ConformanceTests runs the tests of the operations of the configuration against the state store
+func ConformanceTests(t *testing.T, props map[string]string, statestore state.Store, config TestConfig) {
+	// The metadata of the component accepts its properties, and its capabilities include the tested operations
+	componentMetadata := statestore.GetComponentMetadata()
+	require.NoError(t, componentMetadata.Validate(props))
+	for operation, capability := range map[string]metadata.Capability{
+		OperationETag:        metadata.CapabilityETag,
+		OperationTTL:         metadata.CapabilityTTL,
+		OperationTransaction: metadata.CapabilityTransactional,
+	} {
+		if config.HasOperation(operation) {
+			assert.True(t, componentMetadata.HasCapability(capability), "missing %s capability", capability)
+		}
+	}
+	require.NoError(t, statestore.Init(state.Metadata{Properties: props}))
+
+	// The keys are unique to each run, so that the tests can run again against a store holding their previous values
+	prefix := uuid.New().String()
+	key := func(name string) string {
+		return fmt.Sprintf("%s-%s", prefix, name)
+	}
+	scenarios := []scenario{
+		{key: key("string"), value: []byte(`"hello world"`)},
+		{key: key("object"), value: []byte(`{"message":"hello world","count":1}`)},
+		{key: key("bytes"), value: []byte{0x1, 0x2, 0x3}},
+	}
+	get := func(t *testing.T, key string) *state.GetResponse {
+		res, err := statestore.Get(&state.GetRequest{Key: key})
+		require.NoError(t, err)
+		if res == nil {
+			res = &state.GetResponse{}
+		}
+		return res
+	}
+
+	if config.HasOperation(OperationSet) {
+		t.Run(OperationSet, func(t *testing.T) {
+			for _, s := range scenarios {
+				assert.NoError(t, statestore.Set(&state.SetRequest{Key: s.key, Value: s.value}), s.key)
+			}
+		})
+	}
+
+	if config.HasOperation(OperationGet) {
+		t.Run(OperationGet, func(t *testing.T) {
+			if config.HasOperation(OperationSet) {
+				for _, s := range scenarios {
+					assert.Equal(t, s.value, get(t, s.key).Data, s.key)
+				}
+			}
+			assert.Empty(t, get(t, key("missing")).Data)
+		})
+	}
+
+	if config.HasOperation(OperationDelete) {
+		t.Run(OperationDelete, func(t *testing.T) {
+			for _, s := range scenarios {
+				assert.NoError(t, statestore.Delete(&state.DeleteRequest{Key: s.key}), s.key)
+				assert.Empty(t, get(t, s.key).Data, s.key)
+			}
+			// deleting a missing key is not an error
+			assert.NoError(t, statestore.Delete(&state.DeleteRequest{Key: key("missing")}))
+		})
+	}
+
+	if config.HasOperation(OperationBulkSet) {
+		t.Run(OperationBulkSet, func(t *testing.T) {
+			var reqs []state.SetRequest
+			for _, s := range scenarios {
+				reqs = append(reqs, state.SetRequest{Key: s.key + "-bulk", Value: s.value})
+			}
+			require.NoError(t, statestore.BulkSet(reqs))
+			for _, s := range scenarios {
+				assert.Equal(t, s.value, get(t, s.key+"-bulk").Data, s.key)
+			}
+		})
+	}
+
+	if config.HasOperation(OperationBulkDelete) {
+		t.Run(OperationBulkDelete, func(t *testing.T) {
+			var reqs []state.DeleteRequest
+			for _, s := range scenarios {
+				require.NoError(t, statestore.Set(&state.SetRequest{Key: s.key + "-bulk", Value: s.value}))
+				reqs = append(reqs, state.DeleteRequest{Key: s.key + "-bulk"})
+			}
+			require.NoError(t, statestore.BulkDelete(reqs))
+			for _, s := range scenarios {
+				assert.Empty(t, get(t, s.key+"-bulk").Data, s.key)
+			}
+		})
+	}
+
+	if config.HasOperation(OperationETag) {
+		t.Run(OperationETag, func(t *testing.T) {
+			k := key("etag")
+			require.NoError(t, statestore.Set(&state.SetRequest{Key: k, Value: []byte(`"first"`)}))
+			first := get(t, k).ETag
+			require.NotEmpty(t, first)
+
+			require.NoError(t, statestore.Set(&state.SetRequest{Key: k, Value: []byte(`"second"`), ETag: first}))
+			second := get(t, k)
+			assert.Equal(t, []byte(`"second"`), second.Data)
+			assert.NotEqual(t, first, second.ETag)
+
+			// the writes with a stale etag fail and keep the value
+			assert.Error(t, statestore.Set(&state.SetRequest{Key: k, Value: []byte(`"third"`), ETag: first}))
+			assert.Error(t, statestore.Delete(&state.DeleteRequest{Key: k, ETag: first}))
+			assert.Equal(t, []byte(`"second"`), get(t, k).Data)
+
+			assert.NoError(t, statestore.Delete(&state.DeleteRequest{Key: k, ETag: second.ETag}))
+			assert.Empty(t, get(t, k).Data)
+		})
+	}
+
+	if config.HasOperation(OperationFirstWrite) {
+		t.Run(OperationFirstWrite, func(t *testing.T) {
+			k := key("first-write")
+			firstWrite := state.SetStateOption{Concurrency: state.FirstWrite}
+			require.NoError(t, statestore.Set(&state.SetRequest{Key: k, Value: []byte(`"first"`), Options: firstWrite}))
+			assert.Error(t, statestore.Set(&state.SetRequest{Key: k, Value: []byte(`"second"`), Options: firstWrite}))
+			assert.Equal(t, []byte(`"first"`), get(t, k).Data)
+
+			// the last write wins without options
+			assert.NoError(t, statestore.Set(&state.SetRequest{Key: k, Value: []byte(`"third"`)}))
+			assert.Equal(t, []byte(`"third"`), get(t, k).Data)
+		})
+	}
+
+	if config.HasOperation(OperationTransaction) {
+		t.Run(OperationTransaction, func(t *testing.T) {
+			transactional, ok := statestore.(state.TransactionalStore)
+			require.True(t, ok, "the state store is not transactional")
+
+			deleted, kept := key("transaction-deleted"), key("transaction-kept")
+			require.NoError(t, statestore.Set(&state.SetRequest{Key: deleted, Value: []byte(`"deleted"`)}))
+
+			require.NoError(t, transactional.Multi([]state.TransactionalRequest{
+				{Operation: state.Upsert, Request: state.SetRequest{Key: kept, Value: []byte(`"kept"`)}},
+				{Operation: state.Delete, Request: state.DeleteRequest{Key: deleted}},
+			}))
+			assert.Equal(t, []byte(`"kept"`), get(t, kept).Data)
+			assert.Empty(t, get(t, deleted).Data)
+
+			// a failing operation rolls back the others
+			etag := get(t, kept).ETag
+			assert.Error(t, transactional.Multi([]state.TransactionalRequest{
+				{Operation: state.Upsert, Request: state.SetRequest{Key: deleted, Value: []byte(`"rolled back"`)}},
+				{Operation: state.Upsert, Request: state.SetRequest{Key: kept, Value: []byte(`"stale"`), ETag: etag + "-stale"}},
+			}))
+			assert.Empty(t, get(t, deleted).Data)
+			assert.Equal(t, []byte(`"kept"`), get(t, kept).Data)
+		})
+	}
+
+	if config.HasOperation(OperationTTL) {
+		t.Run(OperationTTL, func(t *testing.T) {
+			conformance.TTL(t, statestore, nil)
+		})
+	}
+}
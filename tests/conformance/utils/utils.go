@@ -0,0 +1,39 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// Package utils contains the configuration shared by the conformance tests of all the component types.
+package utils
+
+// CommonConfig is the configuration of the conformance tests of a component, with the operations they run
+type CommonConfig struct {
+	ComponentType string
+	ComponentName string
+	AllOperations bool
+	Operations    map[string]struct{}
+}
+
+// NewCommonConfig returns the configuration running the operations, or all the operations of the component type
+func NewCommonConfig(componentType, componentName string, allOperations bool, operations []string) CommonConfig {
+	ops := make(map[string]struct{}, len(operations))
+	for _, o := range operations {
+		ops[o] = struct{}{}
+	}
+
+	return CommonConfig{
+		ComponentType: componentType,
+		ComponentName: componentName,
+		AllOperations: allOperations,
+		Operations:    ops,
+	}
+}
+
+// HasOperation returns whether the tests of the operation run
+func (cc CommonConfig) HasOperation(operation string) bool {
+	if cc.AllOperations {
+		return true
+	}
+	_, ok := cc.Operations[operation]
+	return ok
+}
@@ -0,0 +1,158 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+// statemigration copies all items from one state store to another, for example:
+//
+//	statemigration -source redis -source-metadata redisHost=localhost:6379 \
+//		-target postgresql -target-metadata "connectionString=host=localhost user=postgres" \
+//		-rate 500 -checkpoint migration.cursor
+//
+// Sources must be able to list their keys. Running the command again with the same checkpoint
+// resumes an interrupted migration, and does nothing once the migration completed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/dapr/components-contrib/state"
+	"github.com/dapr/components-contrib/state/migration"
+	"github.com/dapr/components-contrib/state/mysql"
+	"github.com/dapr/components-contrib/state/postgresql"
+	"github.com/dapr/components-contrib/state/redis"
+	"github.com/dapr/dapr/pkg/logger"
+)
+
+const pageSize = 100
+
+// metadataFlag collects repeated key=value flags
+type metadataFlag map[string]string
+
+func (f metadataFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for k, v := range f {
+		pairs = append(pairs, k+"="+v)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f metadataFlag) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("metadata must be in the form key=value")
+	}
+	f[parts[0]] = parts[1]
+	return nil
+}
+
+// stores creates the state stores that can be migrated to
+var stores = map[string]func(logger.Logger) state.Store{
+	"mysql":      func(l logger.Logger) state.Store { return mysql.NewMySQLStateStore(l) },
+	"postgresql": func(l logger.Logger) state.Store { return postgresql.NewPostgreSQLStateStore(l) },
+	"redis":      func(l logger.Logger) state.Store { return redis.NewRedisStateStore(l) },
+}
+
+// keyLister returns the key lister of the stores that can be migrated from
+func keyLister(store state.Store) (migration.KeyLister, bool) {
+	switch s := store.(type) {
+	case *redis.StateStore:
+		return func(cursor string) ([]string, string, error) {
+			return s.ListKeys(cursor, pageSize)
+		}, true
+	case *postgresql.PostgreSQL:
+		return func(cursor string) ([]string, string, error) {
+			resp, err := s.ListKeys(&postgresql.ListKeysRequest{Cursor: cursor, Limit: pageSize})
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Keys, resp.NextCursor, nil
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func newStore(name string, properties map[string]string, log logger.Logger) (state.Store, error) {
+	factory, ok := stores[name]
+	if !ok {
+		names := make([]string, 0, len(stores))
+		for n := range stores {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unsupported state store '%s', supported stores are: %s", name, strings.Join(names, ", "))
+	}
+
+	store := factory(log)
+	err := store.Init(state.Metadata{Properties: properties})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s: %s", name, err)
+	}
+
+	return store, nil
+}
+
+func main() {
+	sourceMetadata := metadataFlag{}
+	targetMetadata := metadataFlag{}
+	source := flag.String("source", "", "type of the state store to migrate from")
+	target := flag.String("target", "", "type of the state store to migrate to")
+	flag.Var(sourceMetadata, "source-metadata", "metadata property of the source store as key=value, may be repeated")
+	flag.Var(targetMetadata, "target-metadata", "metadata property of the target store as key=value, may be repeated")
+	rate := flag.Int("rate", 0, "maximum number of items written per second, 0 for no limit")
+	checkpoint := flag.String("checkpoint", "", "file that saves the progress of the migration so it can be resumed")
+	flag.Parse()
+
+	log := logger.NewLogger("dapr.state.migration")
+	err := run(*source, sourceMetadata, *target, targetMetadata, *rate, *checkpoint, log)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+}
+
+func run(sourceName string, sourceMetadata map[string]string, targetName string, targetMetadata map[string]string, rate int, checkpoint string, log logger.Logger) error {
+	if sourceName == "" || targetName == "" {
+		return fmt.Errorf("both -source and -target are required")
+	}
+
+	source, err := newStore(sourceName, sourceMetadata, log)
+	if err != nil {
+		return err
+	}
+	list, ok := keyLister(source)
+	if !ok {
+		return fmt.Errorf("state store '%s' cannot list its keys, so it cannot be migrated from", sourceName)
+	}
+
+	target, err := newStore(targetName, targetMetadata, log)
+	if err != nil {
+		return err
+	}
+
+	opts := migration.Options{RateLimit: rate}
+	if checkpoint != "" {
+		opts.Checkpoint = migration.NewFileCheckpoint(checkpoint)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		log.Info("Stopping migration")
+		cancel()
+	}()
+
+	result, err := migration.NewMigrator(source, list, target, opts, log).Migrate(ctx)
+	log.Infof("Migrated %d items, skipped %d keys without a value", result.Migrated, result.Skipped)
+	return err
+}
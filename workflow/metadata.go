@@ -0,0 +1,11 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflow
+
+// Metadata contains a workflow specific set of metadata properties
+type Metadata struct {
+	Properties map[string]string `json:"properties"`
+}
@@ -0,0 +1,40 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflow
+
+// StartRequest is the request to start an instance of a workflow
+type StartRequest struct {
+	// InstanceID is the ID of the new instance, which is unique among the running instances
+	InstanceID   string `json:"instanceID"`
+	WorkflowName string `json:"workflowName"`
+	// Options are the start options specific to the workflow engine, such as the task queue of the workflow
+	Options map[string]string `json:"options,omitempty"`
+	// Input is the input of the workflow, which is usually JSON
+	Input []byte `json:"input,omitempty"`
+}
+
+// TerminateRequest is the request to terminate an instance of a workflow
+type TerminateRequest struct {
+	InstanceID string `json:"instanceID"`
+}
+
+// RaiseEventRequest is the request to send an event to an instance of a workflow
+type RaiseEventRequest struct {
+	InstanceID string `json:"instanceID"`
+	EventName  string `json:"eventName"`
+	// EventData is the data of the event, which is usually JSON
+	EventData []byte `json:"eventData,omitempty"`
+}
+
+// GetStatusRequest is the request to get the status of an instance of a workflow
+type GetStatusRequest struct {
+	InstanceID string `json:"instanceID"`
+}
+
+// PurgeRequest is the request to delete an instance of a workflow and its history
+type PurgeRequest struct {
+	InstanceID string `json:"instanceID"`
+}
@@ -0,0 +1,37 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflow
+
+import "time"
+
+// The runtime statuses of the instances of the workflows
+const (
+	StatusRunning        = "RUNNING"
+	StatusCompleted      = "COMPLETED"
+	StatusFailed         = "FAILED"
+	StatusCanceled       = "CANCELED"
+	StatusTerminated     = "TERMINATED"
+	StatusContinuedAsNew = "CONTINUED_AS_NEW"
+	StatusTimedOut       = "TIMED_OUT"
+	StatusUnknown        = "UNKNOWN"
+)
+
+// StartResponse is the response of a StartRequest
+type StartResponse struct {
+	InstanceID string `json:"instanceID"`
+}
+
+// GetStatusResponse is the status of an instance of a workflow
+type GetStatusResponse struct {
+	InstanceID    string    `json:"instanceID"`
+	WorkflowName  string    `json:"workflowName"`
+	RuntimeStatus string    `json:"runtimeStatus"`
+	StartTime     time.Time `json:"startTime"`
+	// CloseTime is the time the instance stopped running, which is zero while it runs
+	CloseTime time.Time `json:"closeTime,omitempty"`
+	// Properties are the properties of the instance specific to the workflow engine
+	Properties map[string]string `json:"properties,omitempty"`
+}
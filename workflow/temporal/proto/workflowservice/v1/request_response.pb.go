@@ -0,0 +1,635 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: temporal/api/workflowservice/v1/request_response.proto
+
+package workflowservice
+
+import (
+	fmt "fmt"
+	v1 "github.com/dapr/components-contrib/workflow/temporal/proto/common/v1"
+	v11 "github.com/dapr/components-contrib/workflow/temporal/proto/taskqueue/v1"
+	v12 "github.com/dapr/components-contrib/workflow/temporal/proto/workflow/v1"
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type StartWorkflowExecutionRequest struct {
+	Namespace                string             `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WorkflowId               string             `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	WorkflowType             *v1.WorkflowType   `protobuf:"bytes,3,opt,name=workflow_type,json=workflowType,proto3" json:"workflow_type,omitempty"`
+	TaskQueue                *v11.TaskQueue     `protobuf:"bytes,4,opt,name=task_queue,json=taskQueue,proto3" json:"task_queue,omitempty"`
+	Input                    *v1.Payloads       `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+	WorkflowExecutionTimeout *duration.Duration `protobuf:"bytes,6,opt,name=workflow_execution_timeout,json=workflowExecutionTimeout,proto3" json:"workflow_execution_timeout,omitempty"`
+	WorkflowRunTimeout       *duration.Duration `protobuf:"bytes,7,opt,name=workflow_run_timeout,json=workflowRunTimeout,proto3" json:"workflow_run_timeout,omitempty"`
+	WorkflowTaskTimeout      *duration.Duration `protobuf:"bytes,8,opt,name=workflow_task_timeout,json=workflowTaskTimeout,proto3" json:"workflow_task_timeout,omitempty"`
+	Identity                 string             `protobuf:"bytes,9,opt,name=identity,proto3" json:"identity,omitempty"`
+	RequestId                string             `protobuf:"bytes,10,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{}           `json:"-"`
+	XXX_unrecognized         []byte             `json:"-"`
+	XXX_sizecache            int32              `json:"-"`
+}
+
+func (m *StartWorkflowExecutionRequest) Reset()         { *m = StartWorkflowExecutionRequest{} }
+func (m *StartWorkflowExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*StartWorkflowExecutionRequest) ProtoMessage()    {}
+func (*StartWorkflowExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{0}
+}
+
+func (m *StartWorkflowExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartWorkflowExecutionRequest.Unmarshal(m, b)
+}
+func (m *StartWorkflowExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartWorkflowExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *StartWorkflowExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartWorkflowExecutionRequest.Merge(m, src)
+}
+func (m *StartWorkflowExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_StartWorkflowExecutionRequest.Size(m)
+}
+func (m *StartWorkflowExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartWorkflowExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartWorkflowExecutionRequest proto.InternalMessageInfo
+
+func (m *StartWorkflowExecutionRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StartWorkflowExecutionRequest) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
+func (m *StartWorkflowExecutionRequest) GetWorkflowType() *v1.WorkflowType {
+	if m != nil {
+		return m.WorkflowType
+	}
+	return nil
+}
+
+func (m *StartWorkflowExecutionRequest) GetTaskQueue() *v11.TaskQueue {
+	if m != nil {
+		return m.TaskQueue
+	}
+	return nil
+}
+
+func (m *StartWorkflowExecutionRequest) GetInput() *v1.Payloads {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *StartWorkflowExecutionRequest) GetWorkflowExecutionTimeout() *duration.Duration {
+	if m != nil {
+		return m.WorkflowExecutionTimeout
+	}
+	return nil
+}
+
+func (m *StartWorkflowExecutionRequest) GetWorkflowRunTimeout() *duration.Duration {
+	if m != nil {
+		return m.WorkflowRunTimeout
+	}
+	return nil
+}
+
+func (m *StartWorkflowExecutionRequest) GetWorkflowTaskTimeout() *duration.Duration {
+	if m != nil {
+		return m.WorkflowTaskTimeout
+	}
+	return nil
+}
+
+func (m *StartWorkflowExecutionRequest) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *StartWorkflowExecutionRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+type StartWorkflowExecutionResponse struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartWorkflowExecutionResponse) Reset()         { *m = StartWorkflowExecutionResponse{} }
+func (m *StartWorkflowExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*StartWorkflowExecutionResponse) ProtoMessage()    {}
+func (*StartWorkflowExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{1}
+}
+
+func (m *StartWorkflowExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartWorkflowExecutionResponse.Unmarshal(m, b)
+}
+func (m *StartWorkflowExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartWorkflowExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *StartWorkflowExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartWorkflowExecutionResponse.Merge(m, src)
+}
+func (m *StartWorkflowExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_StartWorkflowExecutionResponse.Size(m)
+}
+func (m *StartWorkflowExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartWorkflowExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartWorkflowExecutionResponse proto.InternalMessageInfo
+
+func (m *StartWorkflowExecutionResponse) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type SignalWorkflowExecutionRequest struct {
+	Namespace            string                `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WorkflowExecution    *v1.WorkflowExecution `protobuf:"bytes,2,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"`
+	SignalName           string                `protobuf:"bytes,3,opt,name=signal_name,json=signalName,proto3" json:"signal_name,omitempty"`
+	Input                *v1.Payloads          `protobuf:"bytes,4,opt,name=input,proto3" json:"input,omitempty"`
+	Identity             string                `protobuf:"bytes,5,opt,name=identity,proto3" json:"identity,omitempty"`
+	RequestId            string                `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Control              string                `protobuf:"bytes,7,opt,name=control,proto3" json:"control,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *SignalWorkflowExecutionRequest) Reset()         { *m = SignalWorkflowExecutionRequest{} }
+func (m *SignalWorkflowExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*SignalWorkflowExecutionRequest) ProtoMessage()    {}
+func (*SignalWorkflowExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{2}
+}
+
+func (m *SignalWorkflowExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignalWorkflowExecutionRequest.Unmarshal(m, b)
+}
+func (m *SignalWorkflowExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignalWorkflowExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *SignalWorkflowExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignalWorkflowExecutionRequest.Merge(m, src)
+}
+func (m *SignalWorkflowExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_SignalWorkflowExecutionRequest.Size(m)
+}
+func (m *SignalWorkflowExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignalWorkflowExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignalWorkflowExecutionRequest proto.InternalMessageInfo
+
+func (m *SignalWorkflowExecutionRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *SignalWorkflowExecutionRequest) GetWorkflowExecution() *v1.WorkflowExecution {
+	if m != nil {
+		return m.WorkflowExecution
+	}
+	return nil
+}
+
+func (m *SignalWorkflowExecutionRequest) GetSignalName() string {
+	if m != nil {
+		return m.SignalName
+	}
+	return ""
+}
+
+func (m *SignalWorkflowExecutionRequest) GetInput() *v1.Payloads {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+func (m *SignalWorkflowExecutionRequest) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *SignalWorkflowExecutionRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *SignalWorkflowExecutionRequest) GetControl() string {
+	if m != nil {
+		return m.Control
+	}
+	return ""
+}
+
+type SignalWorkflowExecutionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignalWorkflowExecutionResponse) Reset()         { *m = SignalWorkflowExecutionResponse{} }
+func (m *SignalWorkflowExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*SignalWorkflowExecutionResponse) ProtoMessage()    {}
+func (*SignalWorkflowExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{3}
+}
+
+func (m *SignalWorkflowExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignalWorkflowExecutionResponse.Unmarshal(m, b)
+}
+func (m *SignalWorkflowExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignalWorkflowExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *SignalWorkflowExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignalWorkflowExecutionResponse.Merge(m, src)
+}
+func (m *SignalWorkflowExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_SignalWorkflowExecutionResponse.Size(m)
+}
+func (m *SignalWorkflowExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignalWorkflowExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignalWorkflowExecutionResponse proto.InternalMessageInfo
+
+type TerminateWorkflowExecutionRequest struct {
+	Namespace            string                `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WorkflowExecution    *v1.WorkflowExecution `protobuf:"bytes,2,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"`
+	Reason               string                `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Details              *v1.Payloads          `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"`
+	Identity             string                `protobuf:"bytes,5,opt,name=identity,proto3" json:"identity,omitempty"`
+	FirstExecutionRunId  string                `protobuf:"bytes,6,opt,name=first_execution_run_id,json=firstExecutionRunId,proto3" json:"first_execution_run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *TerminateWorkflowExecutionRequest) Reset()         { *m = TerminateWorkflowExecutionRequest{} }
+func (m *TerminateWorkflowExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*TerminateWorkflowExecutionRequest) ProtoMessage()    {}
+func (*TerminateWorkflowExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{4}
+}
+
+func (m *TerminateWorkflowExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminateWorkflowExecutionRequest.Unmarshal(m, b)
+}
+func (m *TerminateWorkflowExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminateWorkflowExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *TerminateWorkflowExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminateWorkflowExecutionRequest.Merge(m, src)
+}
+func (m *TerminateWorkflowExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_TerminateWorkflowExecutionRequest.Size(m)
+}
+func (m *TerminateWorkflowExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminateWorkflowExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TerminateWorkflowExecutionRequest proto.InternalMessageInfo
+
+func (m *TerminateWorkflowExecutionRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *TerminateWorkflowExecutionRequest) GetWorkflowExecution() *v1.WorkflowExecution {
+	if m != nil {
+		return m.WorkflowExecution
+	}
+	return nil
+}
+
+func (m *TerminateWorkflowExecutionRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *TerminateWorkflowExecutionRequest) GetDetails() *v1.Payloads {
+	if m != nil {
+		return m.Details
+	}
+	return nil
+}
+
+func (m *TerminateWorkflowExecutionRequest) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *TerminateWorkflowExecutionRequest) GetFirstExecutionRunId() string {
+	if m != nil {
+		return m.FirstExecutionRunId
+	}
+	return ""
+}
+
+type TerminateWorkflowExecutionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TerminateWorkflowExecutionResponse) Reset()         { *m = TerminateWorkflowExecutionResponse{} }
+func (m *TerminateWorkflowExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*TerminateWorkflowExecutionResponse) ProtoMessage()    {}
+func (*TerminateWorkflowExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{5}
+}
+
+func (m *TerminateWorkflowExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminateWorkflowExecutionResponse.Unmarshal(m, b)
+}
+func (m *TerminateWorkflowExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminateWorkflowExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *TerminateWorkflowExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminateWorkflowExecutionResponse.Merge(m, src)
+}
+func (m *TerminateWorkflowExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_TerminateWorkflowExecutionResponse.Size(m)
+}
+func (m *TerminateWorkflowExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminateWorkflowExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TerminateWorkflowExecutionResponse proto.InternalMessageInfo
+
+type DeleteWorkflowExecutionRequest struct {
+	Namespace            string                `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WorkflowExecution    *v1.WorkflowExecution `protobuf:"bytes,2,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *DeleteWorkflowExecutionRequest) Reset()         { *m = DeleteWorkflowExecutionRequest{} }
+func (m *DeleteWorkflowExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWorkflowExecutionRequest) ProtoMessage()    {}
+func (*DeleteWorkflowExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{6}
+}
+
+func (m *DeleteWorkflowExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWorkflowExecutionRequest.Unmarshal(m, b)
+}
+func (m *DeleteWorkflowExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWorkflowExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWorkflowExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWorkflowExecutionRequest.Merge(m, src)
+}
+func (m *DeleteWorkflowExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWorkflowExecutionRequest.Size(m)
+}
+func (m *DeleteWorkflowExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWorkflowExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWorkflowExecutionRequest proto.InternalMessageInfo
+
+func (m *DeleteWorkflowExecutionRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeleteWorkflowExecutionRequest) GetWorkflowExecution() *v1.WorkflowExecution {
+	if m != nil {
+		return m.WorkflowExecution
+	}
+	return nil
+}
+
+type DeleteWorkflowExecutionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWorkflowExecutionResponse) Reset()         { *m = DeleteWorkflowExecutionResponse{} }
+func (m *DeleteWorkflowExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteWorkflowExecutionResponse) ProtoMessage()    {}
+func (*DeleteWorkflowExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{7}
+}
+
+func (m *DeleteWorkflowExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWorkflowExecutionResponse.Unmarshal(m, b)
+}
+func (m *DeleteWorkflowExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWorkflowExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteWorkflowExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWorkflowExecutionResponse.Merge(m, src)
+}
+func (m *DeleteWorkflowExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteWorkflowExecutionResponse.Size(m)
+}
+func (m *DeleteWorkflowExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWorkflowExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWorkflowExecutionResponse proto.InternalMessageInfo
+
+type DescribeWorkflowExecutionRequest struct {
+	Namespace            string                `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Execution            *v1.WorkflowExecution `protobuf:"bytes,2,opt,name=execution,proto3" json:"execution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *DescribeWorkflowExecutionRequest) Reset()         { *m = DescribeWorkflowExecutionRequest{} }
+func (m *DescribeWorkflowExecutionRequest) String() string { return proto.CompactTextString(m) }
+func (*DescribeWorkflowExecutionRequest) ProtoMessage()    {}
+func (*DescribeWorkflowExecutionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{8}
+}
+
+func (m *DescribeWorkflowExecutionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeWorkflowExecutionRequest.Unmarshal(m, b)
+}
+func (m *DescribeWorkflowExecutionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeWorkflowExecutionRequest.Marshal(b, m, deterministic)
+}
+func (m *DescribeWorkflowExecutionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeWorkflowExecutionRequest.Merge(m, src)
+}
+func (m *DescribeWorkflowExecutionRequest) XXX_Size() int {
+	return xxx_messageInfo_DescribeWorkflowExecutionRequest.Size(m)
+}
+func (m *DescribeWorkflowExecutionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeWorkflowExecutionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeWorkflowExecutionRequest proto.InternalMessageInfo
+
+func (m *DescribeWorkflowExecutionRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DescribeWorkflowExecutionRequest) GetExecution() *v1.WorkflowExecution {
+	if m != nil {
+		return m.Execution
+	}
+	return nil
+}
+
+type DescribeWorkflowExecutionResponse struct {
+	WorkflowExecutionInfo *v12.WorkflowExecutionInfo `protobuf:"bytes,2,opt,name=workflow_execution_info,json=workflowExecutionInfo,proto3" json:"workflow_execution_info,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                   `json:"-"`
+	XXX_unrecognized      []byte                     `json:"-"`
+	XXX_sizecache         int32                      `json:"-"`
+}
+
+func (m *DescribeWorkflowExecutionResponse) Reset()         { *m = DescribeWorkflowExecutionResponse{} }
+func (m *DescribeWorkflowExecutionResponse) String() string { return proto.CompactTextString(m) }
+func (*DescribeWorkflowExecutionResponse) ProtoMessage()    {}
+func (*DescribeWorkflowExecutionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2c30b34f996ae016, []int{9}
+}
+
+func (m *DescribeWorkflowExecutionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeWorkflowExecutionResponse.Unmarshal(m, b)
+}
+func (m *DescribeWorkflowExecutionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeWorkflowExecutionResponse.Marshal(b, m, deterministic)
+}
+func (m *DescribeWorkflowExecutionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeWorkflowExecutionResponse.Merge(m, src)
+}
+func (m *DescribeWorkflowExecutionResponse) XXX_Size() int {
+	return xxx_messageInfo_DescribeWorkflowExecutionResponse.Size(m)
+}
+func (m *DescribeWorkflowExecutionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeWorkflowExecutionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeWorkflowExecutionResponse proto.InternalMessageInfo
+
+func (m *DescribeWorkflowExecutionResponse) GetWorkflowExecutionInfo() *v12.WorkflowExecutionInfo {
+	if m != nil {
+		return m.WorkflowExecutionInfo
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StartWorkflowExecutionRequest)(nil), "temporal.api.workflowservice.v1.StartWorkflowExecutionRequest")
+	proto.RegisterType((*StartWorkflowExecutionResponse)(nil), "temporal.api.workflowservice.v1.StartWorkflowExecutionResponse")
+	proto.RegisterType((*SignalWorkflowExecutionRequest)(nil), "temporal.api.workflowservice.v1.SignalWorkflowExecutionRequest")
+	proto.RegisterType((*SignalWorkflowExecutionResponse)(nil), "temporal.api.workflowservice.v1.SignalWorkflowExecutionResponse")
+	proto.RegisterType((*TerminateWorkflowExecutionRequest)(nil), "temporal.api.workflowservice.v1.TerminateWorkflowExecutionRequest")
+	proto.RegisterType((*TerminateWorkflowExecutionResponse)(nil), "temporal.api.workflowservice.v1.TerminateWorkflowExecutionResponse")
+	proto.RegisterType((*DeleteWorkflowExecutionRequest)(nil), "temporal.api.workflowservice.v1.DeleteWorkflowExecutionRequest")
+	proto.RegisterType((*DeleteWorkflowExecutionResponse)(nil), "temporal.api.workflowservice.v1.DeleteWorkflowExecutionResponse")
+	proto.RegisterType((*DescribeWorkflowExecutionRequest)(nil), "temporal.api.workflowservice.v1.DescribeWorkflowExecutionRequest")
+	proto.RegisterType((*DescribeWorkflowExecutionResponse)(nil), "temporal.api.workflowservice.v1.DescribeWorkflowExecutionResponse")
+}
+
+func init() {
+	proto.RegisterFile("temporal/api/workflowservice/v1/request_response.proto", fileDescriptor_2c30b34f996ae016)
+}
+
+var fileDescriptor_2c30b34f996ae016 = []byte{
+	// 744 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x56, 0x5d, 0x6e, 0xdb, 0x46,
+	0x10, 0x86, 0x64, 0x4a, 0x26, 0x47, 0x2d, 0x20, 0xd3, 0xb5, 0xcb, 0x0a, 0xb5, 0x2c, 0x0b, 0x46,
+	0xeb, 0x3e, 0x94, 0x84, 0x6d, 0xc0, 0x05, 0xda, 0xb7, 0xc6, 0x41, 0x20, 0x05, 0x09, 0x12, 0x5a,
+	0x80, 0x83, 0x00, 0x81, 0xb0, 0x12, 0x57, 0xca, 0x42, 0xe4, 0x2e, 0xbd, 0xbb, 0x94, 0xa2, 0x23,
+	0xe4, 0x06, 0x39, 0x40, 0x0e, 0x92, 0x03, 0xe4, 0x08, 0x39, 0x4c, 0xc0, 0xe5, 0x8f, 0x64, 0xfd,
+	0x39, 0x71, 0x5e, 0xfc, 0xa6, 0x19, 0xce, 0x7c, 0x33, 0xf3, 0xcd, 0xb7, 0xbb, 0x82, 0x0b, 0x89,
+	0x83, 0x90, 0x71, 0xe4, 0x3b, 0x28, 0x24, 0xce, 0x84, 0xf1, 0xd1, 0xc0, 0x67, 0x13, 0x81, 0xf9,
+	0x98, 0xf4, 0xb1, 0x33, 0x3e, 0x75, 0x38, 0xbe, 0x89, 0xb0, 0x90, 0x5d, 0x8e, 0x45, 0xc8, 0xa8,
+	0xc0, 0x76, 0xc8, 0x99, 0x64, 0xe6, 0x61, 0x96, 0x67, 0xa3, 0x90, 0xd8, 0x0b, 0x79, 0xf6, 0xf8,
+	0xb4, 0x56, 0x1f, 0x32, 0x36, 0xf4, 0xb1, 0xa3, 0xc2, 0x7b, 0xd1, 0xc0, 0xf1, 0x22, 0x8e, 0x24,
+	0x61, 0x34, 0x01, 0xa8, 0x1d, 0xdf, 0x2a, 0xdc, 0x67, 0x41, 0xc0, 0x68, 0x5c, 0x2f, 0xc0, 0x42,
+	0xa0, 0x61, 0x5a, 0xa6, 0xf6, 0xe7, 0xad, 0x28, 0x89, 0xc4, 0xe8, 0x26, 0xc2, 0x11, 0x5e, 0x0e,
+	0xfc, 0x63, 0xe5, 0x1c, 0x4b, 0x71, 0xcd, 0x2f, 0x1a, 0x1c, 0x5c, 0x49, 0xc4, 0xe5, 0x75, 0x1a,
+	0xf2, 0xf8, 0x1d, 0xee, 0x47, 0x71, 0x5f, 0x6e, 0x32, 0xa8, 0xf9, 0x3b, 0x18, 0x14, 0x05, 0x58,
+	0x84, 0xa8, 0x8f, 0xad, 0x42, 0xa3, 0x70, 0x62, 0xb8, 0x33, 0x87, 0x79, 0x08, 0x95, 0x0c, 0xbc,
+	0x4b, 0x3c, 0xab, 0xa8, 0xbe, 0x43, 0xe6, 0x6a, 0x79, 0x66, 0x0b, 0x7e, 0xce, 0x03, 0xe4, 0x34,
+	0xc4, 0xd6, 0x56, 0xa3, 0x70, 0x52, 0x39, 0x3b, 0xb6, 0x6f, 0x11, 0x96, 0xcc, 0x6b, 0x8f, 0x4f,
+	0xed, 0xac, 0x8f, 0xce, 0x34, 0xc4, 0xee, 0x4f, 0x93, 0x39, 0xcb, 0x7c, 0x04, 0x10, 0x4f, 0xdc,
+	0x55, 0x23, 0x5b, 0xda, 0x2a, 0x9c, 0x9c, 0x91, 0x18, 0xaa, 0x83, 0xc4, 0xe8, 0x65, 0x6c, 0xb8,
+	0x86, 0xcc, 0x7e, 0x9a, 0x17, 0x50, 0x22, 0x34, 0x8c, 0xa4, 0x55, 0x52, 0xf9, 0x8d, 0x75, 0x7d,
+	0xbc, 0x40, 0x53, 0x9f, 0x21, 0x4f, 0xb8, 0x49, 0xb8, 0x79, 0x0d, 0xb5, 0x7c, 0x0e, 0x9c, 0x71,
+	0xd4, 0x95, 0x24, 0xc0, 0x2c, 0x92, 0x56, 0x59, 0x81, 0xfd, 0x66, 0x27, 0x4b, 0xb6, 0xb3, 0x25,
+	0xdb, 0x97, 0xe9, 0x92, 0x5d, 0x6b, 0xb2, 0xc8, 0x6f, 0x27, 0x49, 0x35, 0x9f, 0xc2, 0x2f, 0x39,
+	0x30, 0x8f, 0x66, 0x90, 0xdb, 0x77, 0x41, 0x9a, 0x59, 0x9a, 0x1b, 0xe5, 0x60, 0xcf, 0x60, 0x6f,
+	0xc6, 0x76, 0xcc, 0x55, 0x86, 0xa6, 0xdf, 0x85, 0xb6, 0x9b, 0x53, 0x8d, 0xc4, 0x28, 0x83, 0xab,
+	0x81, 0x4e, 0x3c, 0x4c, 0x25, 0x91, 0x53, 0xcb, 0x50, 0xab, 0xcd, 0x6d, 0xf3, 0x00, 0x20, 0x3b,
+	0x0b, 0xc4, 0xb3, 0x20, 0x11, 0x46, 0xea, 0x69, 0x79, 0x6d, 0x4d, 0xaf, 0x54, 0x77, 0x9a, 0xff,
+	0x40, 0x7d, 0x9d, 0xba, 0x92, 0xe3, 0x63, 0xee, 0x41, 0x39, 0x9e, 0x9a, 0x78, 0xa9, 0xb6, 0x4a,
+	0x3c, 0xa2, 0x2d, 0xaf, 0xf9, 0xb9, 0x08, 0xf5, 0x2b, 0x32, 0xa4, 0xc8, 0xbf, 0xa7, 0x30, 0x5f,
+	0x81, 0xb9, 0xbc, 0x2f, 0xa5, 0xcf, 0xca, 0xd9, 0x5f, 0x77, 0x89, 0x6f, 0x56, 0x6b, 0x67, 0x69,
+	0x6f, 0xb1, 0xe4, 0x85, 0xea, 0xac, 0x1b, 0x57, 0x53, 0x7a, 0x36, 0x5c, 0x48, 0x5c, 0xcf, 0x51,
+	0x30, 0x27, 0x31, 0xed, 0xfb, 0x24, 0x36, 0xcf, 0x76, 0x69, 0x23, 0xdb, 0xe5, 0x05, 0xb6, 0x4d,
+	0x0b, 0xb6, 0xfb, 0x8c, 0x4a, 0xce, 0x7c, 0xa5, 0x1b, 0xc3, 0xcd, 0xcc, 0xb6, 0xa6, 0xeb, 0x55,
+	0xa3, 0x79, 0x04, 0x87, 0x6b, 0xd9, 0x4c, 0x16, 0xd1, 0xfc, 0x54, 0x84, 0xa3, 0x0e, 0xe6, 0x01,
+	0xa1, 0x48, 0xe2, 0x07, 0x47, 0xfa, 0x3e, 0x94, 0x39, 0x46, 0x82, 0xd1, 0x94, 0xef, 0xd4, 0x32,
+	0xff, 0x85, 0x6d, 0x0f, 0x4b, 0x44, 0x7c, 0xf1, 0xcd, 0x6c, 0x67, 0x09, 0x1b, 0xf9, 0x3e, 0x87,
+	0xfd, 0x01, 0xe1, 0x42, 0xce, 0x9d, 0xf5, 0x54, 0xa6, 0x09, 0xf7, 0xbb, 0xea, 0xeb, 0xac, 0x65,
+	0x25, 0xda, 0x63, 0x68, 0x6e, 0x62, 0x30, 0x25, 0xfa, 0x43, 0x01, 0xea, 0x97, 0xd8, 0xc7, 0x0f,
+	0x8f, 0xe5, 0x58, 0x26, 0x6b, 0x3b, 0x4b, 0xbb, 0x7f, 0x5f, 0x80, 0xc6, 0x25, 0x16, 0x7d, 0x4e,
+	0x7a, 0xf7, 0xed, 0xff, 0x09, 0x18, 0x3f, 0xd0, 0xf6, 0x2c, 0xb7, 0xf9, 0xb1, 0x00, 0x47, 0x1b,
+	0x7a, 0x49, 0x6f, 0x98, 0x21, 0xfc, 0xba, 0xe2, 0xe6, 0x26, 0x74, 0xc0, 0xd2, 0xe2, 0x8e, 0xbd,
+	0xf2, 0xf1, 0x5e, 0x59, 0xbe, 0x45, 0x07, 0xcc, 0xdd, 0x9b, 0xac, 0x72, 0xb7, 0x35, 0xbd, 0x50,
+	0x2d, 0xb6, 0x35, 0x7d, 0xab, 0xaa, 0xb5, 0x35, 0x5d, 0xab, 0x96, 0xda, 0x9a, 0x5e, 0xaa, 0x96,
+	0xff, 0xef, 0xbe, 0x7e, 0x33, 0x24, 0xf2, 0x6d, 0xd4, 0x8b, 0xe7, 0x72, 0x3c, 0x14, 0xf2, 0xf8,
+	0x7d, 0x0f, 0x19, 0xc5, 0x54, 0x8a, 0xbf, 0xd5, 0x41, 0x25, 0xbd, 0xd9, 0x1b, 0x9d, 0xbf, 0xdc,
+	0xea, 0x92, 0x5e, 0xf1, 0x1f, 0xe4, 0xbf, 0x05, 0x57, 0xaf, 0xac, 0x22, 0xcf, 0xbf, 0x0e, 0x00,
+	0xe4, 0x8e, 0x4b, 0xf1, 0xbd, 0x08, 0x00, 0x00,
+}
@@ -0,0 +1,308 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package temporal
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	contribmetadata "github.com/dapr/components-contrib/internal/metadata"
+	"github.com/dapr/components-contrib/metadata"
+	"github.com/dapr/components-contrib/workflow"
+	common "github.com/dapr/components-contrib/workflow/temporal/proto/common/v1"
+	enums "github.com/dapr/components-contrib/workflow/temporal/proto/enums/v1"
+	taskqueue "github.com/dapr/components-contrib/workflow/temporal/proto/taskqueue/v1"
+	workflowservice "github.com/dapr/components-contrib/workflow/temporal/proto/workflowservice/v1"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultHostPort  = "localhost:7233"
+	defaultNamespace = "default"
+	defaultIdentity  = "dapr"
+	defaultTimeout   = 10 * time.Second
+
+	terminateReason = "terminated with dapr"
+	// taskQueueStartOptionKey is the start option with the task queue of the workflow
+	taskQueueStartOptionKey = "task_queue"
+
+	// The properties of the status of the workflows
+	taskQueueProperty = "taskQueue"
+	runIDProperty     = "runID"
+
+	encodingMetadataKey = "encoding"
+	encodingJSON        = "json/plain"
+	encodingBinary      = "binary/plain"
+)
+
+// statuses are the runtime statuses of the WorkflowExecutionStatus values of Temporal
+var statuses = map[enums.WorkflowExecutionStatus]string{
+	enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_RUNNING:          workflow.StatusRunning,
+	enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_COMPLETED:        workflow.StatusCompleted,
+	enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_FAILED:           workflow.StatusFailed,
+	enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_CANCELED:         workflow.StatusCanceled,
+	enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_TERMINATED:       workflow.StatusTerminated,
+	enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW: workflow.StatusContinuedAsNew,
+	enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_TIMED_OUT:        workflow.StatusTimedOut,
+}
+
+type temporalMetadata struct {
+	// HostPort is the address of the Temporal frontend
+	HostPort  string `json:"hostPort"`
+	Namespace string `json:"namespace"`
+	// Identity is the identity of the requests in the history of the workflows
+	Identity string `json:"identity"`
+	// TaskQueue is the task queue of the started workflows, unless they have the task_queue option
+	TaskQueue string        `json:"taskQueue"`
+	EnableTLS bool          `json:"enableTLS"`
+	Timeout   time.Duration `json:"timeout"`
+}
+
+// TemporalWF is a workflow component starting and managing the workflows of a Temporal cluster, which are run by the
+// workers of the applications. It calls the WorkflowService of the Temporal frontend with gRPC.
+type TemporalWF struct {
+	conn     *grpc.ClientConn
+	client   workflowservice.WorkflowServiceClient
+	metadata *temporalMetadata
+	timeout  time.Duration
+	logger   logger.Logger
+}
+
+// NewTemporalWorkflow returns a new Temporal workflow component
+func NewTemporalWorkflow(logger logger.Logger) *TemporalWF {
+	return &TemporalWF{logger: logger}
+}
+
+// Init connects to the Temporal frontend
+func (c *TemporalWF) Init(metadata workflow.Metadata) error {
+	meta, err := c.parseMetadata(metadata)
+	if err != nil {
+		return err
+	}
+	c.metadata = meta
+
+	if meta.Timeout <= 0 {
+		return fmt.Errorf("temporal workflow error: invalid timeout %s", meta.Timeout)
+	}
+	c.timeout = meta.Timeout
+
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if meta.EnableTLS {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))}
+	}
+	conn, err := grpc.Dial(meta.HostPort, opts...)
+	if err != nil {
+		return fmt.Errorf("temporal workflow error: failed to connect to %s: %s", meta.HostPort, err)
+	}
+	c.conn = conn
+	c.client = workflowservice.NewWorkflowServiceClient(conn)
+	return nil
+}
+
+func (c *TemporalWF) parseMetadata(metadata workflow.Metadata) (*temporalMetadata, error) {
+	meta := temporalMetadata{
+		HostPort:  defaultHostPort,
+		Namespace: defaultNamespace,
+		Identity:  defaultIdentity,
+		Timeout:   defaultTimeout,
+	}
+	if err := contribmetadata.Decode(metadata.Properties, &meta); err != nil {
+		return nil, fmt.Errorf("temporal workflow error: %s", err)
+	}
+	return &meta, nil
+}
+
+// GetComponentMetadata returns the metadata fields of the Temporal workflow component
+func (c *TemporalWF) GetComponentMetadata() metadata.ComponentMetadata {
+	return metadata.ComponentMetadata{
+		Fields: metadata.FieldsOf(temporalMetadata{
+			HostPort:  defaultHostPort,
+			Namespace: defaultNamespace,
+			Identity:  defaultIdentity,
+			Timeout:   defaultTimeout,
+		}),
+	}
+}
+
+// Start starts the workflow in the task queue of the task_queue option, or of the taskQueue metadata
+func (c *TemporalWF) Start(req *workflow.StartRequest) (*workflow.StartResponse, error) {
+	if req.InstanceID == "" || req.WorkflowName == "" {
+		return nil, errors.New("temporal workflow error: missing instance ID or workflow name")
+	}
+
+	taskQueue := req.Options[taskQueueStartOptionKey]
+	if taskQueue == "" {
+		taskQueue = c.metadata.TaskQueue
+	}
+	if taskQueue == "" {
+		return nil, fmt.Errorf("temporal workflow error: missing %s option or taskQueue metadata", taskQueueStartOptionKey)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_, err := c.client.StartWorkflowExecution(ctx, &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:    c.metadata.Namespace,
+		WorkflowId:   req.InstanceID,
+		WorkflowType: &common.WorkflowType{Name: req.WorkflowName},
+		TaskQueue:    &taskqueue.TaskQueue{Name: taskQueue},
+		Input:        payloads(req.Input),
+		Identity:     c.metadata.Identity,
+		RequestId:    uuid.New().String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("temporal workflow error: failed to start %s: %s", req.InstanceID, err)
+	}
+	return &workflow.StartResponse{InstanceID: req.InstanceID}, nil
+}
+
+// Terminate terminates the current run of the workflow
+func (c *TemporalWF) Terminate(req *workflow.TerminateRequest) error {
+	if req.InstanceID == "" {
+		return errors.New("temporal workflow error: missing instance ID")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_, err := c.client.TerminateWorkflowExecution(ctx, &workflowservice.TerminateWorkflowExecutionRequest{
+		Namespace:         c.metadata.Namespace,
+		WorkflowExecution: &common.WorkflowExecution{WorkflowId: req.InstanceID},
+		Reason:            terminateReason,
+		Identity:          c.metadata.Identity,
+	})
+	if err != nil {
+		return fmt.Errorf("temporal workflow error: failed to terminate %s: %s", req.InstanceID, err)
+	}
+	return nil
+}
+
+// RaiseEvent sends the event as a signal of the current run of the workflow
+func (c *TemporalWF) RaiseEvent(req *workflow.RaiseEventRequest) error {
+	if req.InstanceID == "" || req.EventName == "" {
+		return errors.New("temporal workflow error: missing instance ID or event name")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_, err := c.client.SignalWorkflowExecution(ctx, &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace:         c.metadata.Namespace,
+		WorkflowExecution: &common.WorkflowExecution{WorkflowId: req.InstanceID},
+		SignalName:        req.EventName,
+		Input:             payloads(req.EventData),
+		Identity:          c.metadata.Identity,
+		RequestId:         uuid.New().String(),
+	})
+	if err != nil {
+		return fmt.Errorf("temporal workflow error: failed to raise event %s of %s: %s", req.EventName, req.InstanceID, err)
+	}
+	return nil
+}
+
+// GetStatus returns the status of the current run of the workflow, with its run ID and task queue as properties
+func (c *TemporalWF) GetStatus(req *workflow.GetStatusRequest) (*workflow.GetStatusResponse, error) {
+	if req.InstanceID == "" {
+		return nil, errors.New("temporal workflow error: missing instance ID")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	response, err := c.client.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
+		Namespace: c.metadata.Namespace,
+		Execution: &common.WorkflowExecution{WorkflowId: req.InstanceID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("temporal workflow error: failed to get the status of %s: %s", req.InstanceID, err)
+	}
+
+	info := response.GetWorkflowExecutionInfo()
+	res := &workflow.GetStatusResponse{
+		InstanceID:    info.GetExecution().GetWorkflowId(),
+		WorkflowName:  info.GetType().GetName(),
+		RuntimeStatus: workflow.StatusUnknown,
+		Properties: map[string]string{
+			runIDProperty:     info.GetExecution().GetRunId(),
+			taskQueueProperty: info.GetTaskQueue(),
+		},
+	}
+	if runtimeStatus, ok := statuses[info.GetStatus()]; ok {
+		res.RuntimeStatus = runtimeStatus
+	}
+	if res.StartTime, err = timeOf(info.GetStartTime()); err != nil {
+		return nil, fmt.Errorf("temporal workflow error: failed to get the status of %s: %s", req.InstanceID, err)
+	}
+	if res.CloseTime, err = timeOf(info.GetCloseTime()); err != nil {
+		return nil, fmt.Errorf("temporal workflow error: failed to get the status of %s: %s", req.InstanceID, err)
+	}
+	return res, nil
+}
+
+// Purge deletes the current run of the workflow and its history, which requires Temporal 1.18 or later
+func (c *TemporalWF) Purge(req *workflow.PurgeRequest) error {
+	if req.InstanceID == "" {
+		return errors.New("temporal workflow error: missing instance ID")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	_, err := c.client.DeleteWorkflowExecution(ctx, &workflowservice.DeleteWorkflowExecutionRequest{
+		Namespace:         c.metadata.Namespace,
+		WorkflowExecution: &common.WorkflowExecution{WorkflowId: req.InstanceID},
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			return fmt.Errorf("temporal workflow error: purge requires Temporal 1.18 or later: %s", err)
+		}
+		return fmt.Errorf("temporal workflow error: failed to purge %s: %s", req.InstanceID, err)
+	}
+	return nil
+}
+
+// Close closes the connection to the Temporal frontend
+func (c *TemporalWF) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// payloads returns the Payloads with the payload of the data, which is JSON or binary, or nil without data
+func payloads(data []byte) *common.Payloads {
+	if len(data) == 0 {
+		return nil
+	}
+
+	encoding := encodingBinary
+	if json.Valid(data) {
+		encoding = encodingJSON
+	}
+	return &common.Payloads{Payloads: []*common.Payload{{
+		Metadata: map[string][]byte{encodingMetadataKey: []byte(encoding)},
+		Data:     data,
+	}}}
+}
+
+// timeOf returns the UTC time of the timestamp, which is zero when it is not set
+func timeOf(ts *timestamp.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	return ptypes.Timestamp(ts)
+}
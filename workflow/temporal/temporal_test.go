@@ -0,0 +1,241 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package temporal
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dapr/components-contrib/workflow"
+	common "github.com/dapr/components-contrib/workflow/temporal/proto/common/v1"
+	enums "github.com/dapr/components-contrib/workflow/temporal/proto/enums/v1"
+	workflowpb "github.com/dapr/components-contrib/workflow/temporal/proto/workflow/v1"
+	workflowservice "github.com/dapr/components-contrib/workflow/temporal/proto/workflowservice/v1"
+	"github.com/dapr/dapr/pkg/logger"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The methods of the fake frontend
+const (
+	startMethod     = "StartWorkflowExecution"
+	terminateMethod = "TerminateWorkflowExecution"
+	signalMethod    = "SignalWorkflowExecution"
+	describeMethod  = "DescribeWorkflowExecution"
+	deleteMethod    = "DeleteWorkflowExecution"
+)
+
+// fakeFrontend records the requests of the WorkflowService and fails the methods with their errors
+type fakeFrontend struct {
+	lock     sync.Mutex
+	requests map[string]proto.Message
+	errors   map[string]error
+	describe *workflowservice.DescribeWorkflowExecutionResponse
+}
+
+func newFakeFrontend(t *testing.T) (*fakeFrontend, string) {
+	f := &fakeFrontend{requests: map[string]proto.Message{}, errors: map[string]error{}}
+	server := grpc.NewServer()
+	workflowservice.RegisterWorkflowServiceServer(server, f)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return f, lis.Addr().String()
+}
+
+func (f *fakeFrontend) record(method string, request proto.Message) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.requests[method] = request
+	return f.errors[method]
+}
+
+func (f *fakeFrontend) fail(method string, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.errors[method] = err
+}
+
+func (f *fakeFrontend) request(method string) proto.Message {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.requests[method]
+}
+
+func (f *fakeFrontend) StartWorkflowExecution(ctx context.Context, req *workflowservice.StartWorkflowExecutionRequest) (*workflowservice.StartWorkflowExecutionResponse, error) {
+	if err := f.record(startMethod, req); err != nil {
+		return nil, err
+	}
+	return &workflowservice.StartWorkflowExecutionResponse{RunId: "run-1"}, nil
+}
+
+func (f *fakeFrontend) SignalWorkflowExecution(ctx context.Context, req *workflowservice.SignalWorkflowExecutionRequest) (*workflowservice.SignalWorkflowExecutionResponse, error) {
+	if err := f.record(signalMethod, req); err != nil {
+		return nil, err
+	}
+	return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+}
+
+func (f *fakeFrontend) TerminateWorkflowExecution(ctx context.Context, req *workflowservice.TerminateWorkflowExecutionRequest) (*workflowservice.TerminateWorkflowExecutionResponse, error) {
+	if err := f.record(terminateMethod, req); err != nil {
+		return nil, err
+	}
+	return &workflowservice.TerminateWorkflowExecutionResponse{}, nil
+}
+
+func (f *fakeFrontend) DeleteWorkflowExecution(ctx context.Context, req *workflowservice.DeleteWorkflowExecutionRequest) (*workflowservice.DeleteWorkflowExecutionResponse, error) {
+	if err := f.record(deleteMethod, req); err != nil {
+		return nil, err
+	}
+	return &workflowservice.DeleteWorkflowExecutionResponse{}, nil
+}
+
+func (f *fakeFrontend) DescribeWorkflowExecution(ctx context.Context, req *workflowservice.DescribeWorkflowExecutionRequest) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	if err := f.record(describeMethod, req); err != nil {
+		return nil, err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.describe, nil
+}
+
+func newTestWorkflow(t *testing.T, properties map[string]string) (*fakeFrontend, *TemporalWF) {
+	f, addr := newFakeFrontend(t)
+	properties["hostPort"] = addr
+	c := NewTemporalWorkflow(logger.NewLogger("test"))
+	require.NoError(t, c.Init(workflow.Metadata{Properties: properties}))
+	t.Cleanup(func() { c.Close() })
+	return f, c
+}
+
+func TestInit(t *testing.T) {
+	c := NewTemporalWorkflow(logger.NewLogger("test"))
+	require.NoError(t, c.Init(workflow.Metadata{Properties: map[string]string{}}))
+	defer c.Close()
+	assert.Equal(t, defaultHostPort, c.metadata.HostPort)
+	assert.Equal(t, defaultNamespace, c.metadata.Namespace)
+	assert.Equal(t, defaultIdentity, c.metadata.Identity)
+	assert.Equal(t, defaultTimeout, c.timeout)
+
+	assert.Error(t, c.Init(workflow.Metadata{Properties: map[string]string{"timeout": "10"}}))
+}
+
+func TestStart(t *testing.T) {
+	f, c := newTestWorkflow(t, map[string]string{"namespace": "orders", "taskQueue": "default-queue"})
+
+	res, err := c.Start(&workflow.StartRequest{InstanceID: "order-1", WorkflowName: "ProcessOrder", Input: []byte(`{"id":1}`)})
+	require.NoError(t, err)
+	assert.Equal(t, "order-1", res.InstanceID)
+
+	request := f.request(startMethod).(*workflowservice.StartWorkflowExecutionRequest)
+	assert.Equal(t, "orders", request.GetNamespace())
+	assert.Equal(t, "order-1", request.GetWorkflowId())
+	assert.Equal(t, "ProcessOrder", request.GetWorkflowType().GetName())
+	assert.Equal(t, "default-queue", request.GetTaskQueue().GetName())
+	assert.Equal(t, defaultIdentity, request.GetIdentity())
+	assert.NotEmpty(t, request.GetRequestId())
+	require.Len(t, request.GetInput().GetPayloads(), 1)
+	payload := request.GetInput().GetPayloads()[0]
+	assert.Equal(t, `{"id":1}`, string(payload.GetData()))
+	assert.Equal(t, map[string][]byte{encodingMetadataKey: []byte(encodingJSON)}, payload.GetMetadata())
+
+	// the task queue of the options replaces the one of the metadata, and the input is optional
+	_, err = c.Start(&workflow.StartRequest{InstanceID: "order-2", WorkflowName: "ProcessOrder", Options: map[string]string{taskQueueStartOptionKey: "other-queue"}})
+	require.NoError(t, err)
+	request = f.request(startMethod).(*workflowservice.StartWorkflowExecutionRequest)
+	assert.Equal(t, "other-queue", request.GetTaskQueue().GetName())
+	assert.Nil(t, request.GetInput())
+
+	_, err = c.Start(&workflow.StartRequest{InstanceID: "order-3"})
+	assert.Error(t, err)
+
+	f.fail(startMethod, status.Error(codes.AlreadyExists, "workflow execution already started"))
+	_, err = c.Start(&workflow.StartRequest{InstanceID: "order-1", WorkflowName: "ProcessOrder"})
+	assert.Error(t, err)
+}
+
+func TestStartMissingTaskQueue(t *testing.T) {
+	_, c := newTestWorkflow(t, map[string]string{})
+	_, err := c.Start(&workflow.StartRequest{InstanceID: "order-1", WorkflowName: "ProcessOrder"})
+	assert.Error(t, err)
+}
+
+func TestTerminateAndRaiseEvent(t *testing.T) {
+	f, c := newTestWorkflow(t, map[string]string{})
+
+	require.NoError(t, c.Terminate(&workflow.TerminateRequest{InstanceID: "order-1"}))
+	terminate := f.request(terminateMethod).(*workflowservice.TerminateWorkflowExecutionRequest)
+	assert.Equal(t, defaultNamespace, terminate.GetNamespace())
+	assert.Equal(t, "order-1", terminate.GetWorkflowExecution().GetWorkflowId())
+	assert.Equal(t, terminateReason, terminate.GetReason())
+	assert.Equal(t, defaultIdentity, terminate.GetIdentity())
+
+	require.NoError(t, c.RaiseEvent(&workflow.RaiseEventRequest{InstanceID: "order-1", EventName: "approved", EventData: []byte("yes")}))
+	signal := f.request(signalMethod).(*workflowservice.SignalWorkflowExecutionRequest)
+	assert.Equal(t, "order-1", signal.GetWorkflowExecution().GetWorkflowId())
+	assert.Equal(t, "approved", signal.GetSignalName())
+	assert.NotEmpty(t, signal.GetRequestId())
+	require.Len(t, signal.GetInput().GetPayloads(), 1)
+	payload := signal.GetInput().GetPayloads()[0]
+	assert.Equal(t, "yes", string(payload.GetData()))
+	assert.Equal(t, encodingBinary, string(payload.GetMetadata()[encodingMetadataKey]))
+
+	assert.Error(t, c.Terminate(&workflow.TerminateRequest{}))
+	assert.Error(t, c.RaiseEvent(&workflow.RaiseEventRequest{InstanceID: "order-1"}))
+}
+
+func TestGetStatus(t *testing.T) {
+	f, c := newTestWorkflow(t, map[string]string{})
+	f.describe = &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+			Execution: &common.WorkflowExecution{WorkflowId: "order-1", RunId: "run-1"},
+			Type:      &common.WorkflowType{Name: "ProcessOrder"},
+			StartTime: &timestamp.Timestamp{Seconds: 100},
+			Status:    enums.WorkflowExecutionStatus_WORKFLOW_EXECUTION_STATUS_TERMINATED,
+			TaskQueue: "default-queue",
+		},
+	}
+
+	res, err := c.GetStatus(&workflow.GetStatusRequest{InstanceID: "order-1"})
+	require.NoError(t, err)
+	assert.Equal(t, &workflow.GetStatusResponse{
+		InstanceID:    "order-1",
+		WorkflowName:  "ProcessOrder",
+		RuntimeStatus: workflow.StatusTerminated,
+		StartTime:     time.Unix(100, 0).UTC(),
+		Properties:    map[string]string{runIDProperty: "run-1", taskQueueProperty: "default-queue"},
+	}, res)
+
+	request := f.request(describeMethod).(*workflowservice.DescribeWorkflowExecutionRequest)
+	assert.Equal(t, "order-1", request.GetExecution().GetWorkflowId())
+
+	f.fail(describeMethod, status.Error(codes.NotFound, "workflow not found"))
+	_, err = c.GetStatus(&workflow.GetStatusRequest{InstanceID: "order-2"})
+	assert.Error(t, err)
+}
+
+func TestPurge(t *testing.T) {
+	f, c := newTestWorkflow(t, map[string]string{})
+
+	require.NoError(t, c.Purge(&workflow.PurgeRequest{InstanceID: "order-1"}))
+	request := f.request(deleteMethod).(*workflowservice.DeleteWorkflowExecutionRequest)
+	assert.Equal(t, "order-1", request.GetWorkflowExecution().GetWorkflowId())
+
+	f.fail(deleteMethod, status.Error(codes.Unimplemented, "unknown method"))
+	err := c.Purge(&workflow.PurgeRequest{InstanceID: "order-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1.18")
+}
@@ -0,0 +1,26 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package workflow
+
+import "github.com/dapr/components-contrib/metadata"
+
+// Workflow is the interface for a component that runs workflows with a workflow engine
+type Workflow interface {
+	// Init connects to the workflow engine with the metadata of the component
+	Init(metadata Metadata) error
+	// Start starts a new instance of a workflow
+	Start(req *StartRequest) (*StartResponse, error)
+	// Terminate stops a running instance of a workflow
+	Terminate(req *TerminateRequest) error
+	// RaiseEvent sends an event to a running instance of a workflow
+	RaiseEvent(req *RaiseEventRequest) error
+	// GetStatus returns the status of an instance of a workflow
+	GetStatus(req *GetStatusRequest) (*GetStatusResponse, error)
+	// Purge deletes the history of an instance of a workflow
+	Purge(req *PurgeRequest) error
+	// GetComponentMetadata returns the metadata fields and the capabilities of the component
+	GetComponentMetadata() metadata.ComponentMetadata
+}